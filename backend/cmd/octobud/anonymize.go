@@ -0,0 +1,130 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/anonymize"
+)
+
+// runAnonymize implements "octobud anonymize", which writes an anonymized copy of
+// the database that can be attached to bug reports. It returns the exit code.
+func runAnonymize(args []string) int {
+	fs := flag.NewFlagSet("anonymize", flag.ContinueOnError)
+	dataDir := fs.String("data-dir", "", "Data directory (default: platform-specific)")
+	dbPath := fs.String("db", "", "Database to copy (default: <data-dir>/octobud.db)")
+	outPath := fs.String("out", "octobud-anonymized.db", "Path to write the anonymized copy to")
+	salt := fs.String(
+		"salt",
+		"",
+		"Key for hashing names, reuse it to get the same names across runs (default: random)",
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: octobud anonymize [flags]")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), "Writes a copy of the database with titles, logins,")
+		fmt.Fprintln(fs.Output(), "repository names and GitHub payloads scrambled, for")
+		fmt.Fprintln(fs.Output(), "attaching to bug reports.")
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *dbPath == "" {
+		if *dataDir == "" {
+			var err error
+			*dataDir, err = db.GetDefaultDataDir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get default data directory: %v\n", err)
+				return 1
+			}
+		}
+		*dbPath = filepath.Join(*dataDir, "octobud.db")
+	}
+
+	if err := anonymizeDatabase(context.Background(), *dbPath, *outPath, *salt); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to anonymize database: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func anonymizeDatabase(ctx context.Context, srcPath, dstPath, salt string) error {
+	anonymizer, err := anonymize.New(salt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Copying %s to %s\n", srcPath, dstPath)
+	if err := anonymize.CopyDatabase(ctx, srcPath, dstPath); err != nil {
+		return err
+	}
+
+	// Don't leave a half-anonymized copy behind
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			_ = os.Remove(dstPath)
+			_ = os.Remove(dstPath + "-wal")
+			_ = os.Remove(dstPath + "-shm")
+		}
+	}()
+
+	dbConn, err := db.OpenDatabase(dstPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbConn.Close() }()
+
+	// Bring older databases up to the current schema so every table exists
+	if _, err := runMigrations(dbConn); err != nil {
+		return err
+	}
+
+	result, err := anonymizer.Anonymize(ctx, dbConn)
+	if err != nil {
+		return err
+	}
+	succeeded = true
+
+	fmt.Printf(
+		"Anonymized %d repositories, %d pull requests, %d notifications and %d saved queries\n",
+		result.Repositories,
+		result.PullRequests,
+		result.Notifications,
+		result.Queries,
+	)
+	fmt.Printf("Removed %d queued jobs\n", result.JobsDeleted)
+	fmt.Printf("Removed %d cached author profiles\n", result.ProfilesDeleted)
+	fmt.Printf("Removed %d saved replies\n", result.RepliesDeleted)
+	fmt.Printf("Removed %d pending triage restores\n", result.RestoresDeleted)
+	fmt.Printf("Removed %d linked accounts\n", result.AccountsDeleted)
+	fmt.Printf("Removed %d recently opened lists and notifications\n", result.AccessDeleted)
+	fmt.Printf("Removed %d offboarded organizations\n", result.OrgsDeleted)
+	fmt.Printf("Removed %d webhooks\n", result.HooksDeleted)
+	fmt.Printf("Removed %d API tokens\n", result.TokensDeleted)
+	fmt.Printf("Removed %d view shares\n", result.SharesDeleted)
+	fmt.Printf("Wrote %s\n", dstPath)
+	return nil
+}
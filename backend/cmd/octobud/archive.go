@@ -0,0 +1,89 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// archiveRequest is the body of a bulk archive: either a query or notification IDs
+type archiveRequest struct {
+	GithubIDs []string `json:"githubIDs,omitempty"`
+	Query     string   `json:"query,omitempty"`
+}
+
+// runArchive implements "octobud archive", which archives notifications by ID or every
+// notification matching a query. It returns the exit code.
+func runArchive(args []string) int {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	var flags clientFlags
+	flags.register(fs)
+	query := fs.String(
+		"query",
+		"",
+		`Archive every notification matching this query, like "author:dependabot"`,
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: octobud archive [flags] <id>...")
+		fmt.Fprintln(fs.Output(), "       octobud archive [flags] -query <query>")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), "Archives the notifications with the given IDs, as")
+		fmt.Fprintln(fs.Output(), `printed by "octobud list", or every notification`)
+		fmt.Fprintln(fs.Output(), "matching a query.")
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// An empty query means the inbox to the API, which is too much to archive by accident
+	req := archiveRequest{GithubIDs: fs.Args(), Query: strings.TrimSpace(*query)}
+	if (req.Query == "") == (len(req.GithubIDs) == 0) {
+		fmt.Fprintln(os.Stderr, "Pass either notification IDs or -query")
+		fs.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+	client, err := connect(ctx, flags, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect: %v\n", err)
+		return 1
+	}
+	defer client.close()
+
+	var result struct {
+		Count int `json:"count"`
+	}
+	err = client.doJSON(ctx, http.MethodPost, "/notifications/bulk/archive", req, &result)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to archive notifications: %v\n", err)
+		return 1
+	}
+
+	noun := "notifications"
+	if result.Count == 1 {
+		noun = "notification"
+	}
+	fmt.Printf("Archived %d %s\n", result.Count, noun)
+	return 0
+}
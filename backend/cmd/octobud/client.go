@@ -0,0 +1,304 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+// Environment variables the terminal commands read their defaults from
+const (
+	serverEnv = "OCTOBUD_SERVER"
+	tokenEnv  = "OCTOBUD_TOKEN"
+)
+
+const (
+	defaultServerURL = "http://localhost:8808"
+	// probeTimeout bounds how long the commands wait to find out whether the server runs
+	probeTimeout = 2 * time.Second
+	// requestTimeout bounds each API call, since a bulk archive can touch many rows
+	requestTimeout = time.Minute
+)
+
+// errServerNotRunning means nothing answered at the server URL
+var errServerNotRunning = errors.New("octobud isn't running")
+
+// clientFlags are the flags every terminal command shares
+type clientFlags struct {
+	server  string
+	token   string
+	dataDir string
+	dbPath  string
+}
+
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(
+		&f.server,
+		"server",
+		os.Getenv(serverEnv),
+		"URL of a running Octobud (default: $"+serverEnv+" or "+defaultServerURL+")",
+	)
+	fs.StringVar(
+		&f.token,
+		"token",
+		os.Getenv(tokenEnv),
+		"API token for servers that require signing in (default: $"+tokenEnv+")",
+	)
+	fs.StringVar(
+		&f.dataDir,
+		"data-dir",
+		"",
+		"Data directory used when Octobud isn't running (default: platform-specific)",
+	)
+	fs.StringVar(
+		&f.dbPath,
+		"db",
+		"",
+		"Database used when Octobud isn't running (default: the active workspace's)",
+	)
+}
+
+// apiClient calls the API of a running server, or of the database opened in-process
+// when nothing is running. Commands make the same requests either way.
+type apiClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+	// direct is set when the client serves requests from the database itself
+	direct bool
+	close  func()
+}
+
+// connect returns a client for the server. When no server was asked for and the
+// default one isn't running, the database is opened directly if allowDirect is set;
+// otherwise errServerNotRunning is returned.
+func connect(ctx context.Context, flags clientFlags, allowDirect bool) (*apiClient, error) {
+	explicit := flags.server != ""
+	baseURL := strings.TrimRight(flags.server, "/")
+	if baseURL == "" {
+		baseURL = defaultServerURL
+	}
+
+	client := &apiClient{
+		baseURL: baseURL,
+		token:   flags.token,
+		http:    &http.Client{Timeout: requestTimeout},
+		close:   func() {},
+	}
+	if serverRunning(ctx, baseURL) {
+		return client, nil
+	}
+	if explicit || !allowDirect {
+		return nil, fmt.Errorf("%w at %s", errServerNotRunning, baseURL)
+	}
+
+	dbPath, err := directDatabasePath(flags)
+	if err != nil {
+		return nil, err
+	}
+	handler, closeDB, err := openDirect(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Octobud isn't running, using %s\n", dbPath)
+
+	client.baseURL = "http://octobud.local"
+	client.http = &http.Client{Transport: handlerTransport{handler: handler}}
+	client.direct = true
+	client.close = closeDB
+	return client, nil
+}
+
+// serverRunning reports whether anything answers HTTP at baseURL. Any response counts,
+// even an error, since only a server that's down doesn't respond at all.
+func serverRunning(ctx context.Context, baseURL string) bool {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL+"/", http.NoBody)
+	if err != nil {
+		return false
+	}
+	// A server that requires signing in may redirect to its identity provider
+	probe := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := probe.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return true
+}
+
+// directDatabasePath returns the database of the workspace the app last had open,
+// unless -db names one
+func directDatabasePath(flags clientFlags) (string, error) {
+	if flags.dbPath != "" {
+		return flags.dbPath, nil
+	}
+	dataDir := flags.dataDir
+	if dataDir == "" {
+		var err error
+		dataDir, err = db.GetDefaultDataDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get default data directory: %w", err)
+		}
+	}
+	workspaces, err := workspace.NewManager(dataDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(workspaces.DataDir(workspaces.Active().Slug), "octobud.db"), nil
+}
+
+// openDirect serves the API from the database at dbPath without starting the
+// scheduler, so nothing syncs and no background work runs. Threads to mark on GitHub are
+// queued in the database for the server to send when it next starts.
+func openDirect(dbPath string) (http.Handler, func(), error) {
+	// Opening a missing database would create an empty one
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil, fmt.Errorf("no database at %s: %w", dbPath, err)
+	}
+
+	dbConn, err := db.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	closeDB := func() { _ = dbConn.Close() }
+
+	// The server would bring the schema up to date when it next starts anyway, so this
+	// is done quietly
+	goose.SetLogger(goose.NopLogger())
+	if _, err := runMigrations(dbConn); err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+
+	router := chi.NewRouter()
+	marker := jobs.NewGitHubMarker(jobs.NewSQLiteJobQueue(dbConn), zap.NewNop())
+	handler := api.NewHandler(db.NewStore(dbConn), api.WithGitHubMarker(marker))
+	router.Route("/api", handler.RegisterAllRoutes)
+	return router, closeDB, nil
+}
+
+// handlerTransport answers requests by calling a handler in-process
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+// do sends a request to the API and returns the response along with its body. Error
+// responses are returned as errors carrying the API's message.
+func (c *apiClient) do(
+	ctx context.Context,
+	method, path string,
+	body any,
+) (*http.Response, []byte, error) {
+	reqBody := io.Reader(http.NoBody)
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/api"+path, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" && !c.direct {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, c.errorFor(resp.StatusCode, data)
+	}
+	return resp, data, nil
+}
+
+// doJSON sends a request to the API and decodes its JSON response into out
+func (c *apiClient) doJSON(ctx context.Context, method, path string, body, out any) error {
+	_, data, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("unexpected response from %s: %w", c.baseURL, err)
+	}
+	return nil
+}
+
+// errorFor turns an error response into an error with the API's message, pointing at
+// API tokens when the server wants a sign-in the client didn't send
+func (c *apiClient) errorFor(status int, data []byte) error {
+	var body struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		message = body.Error
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	if status == http.StatusUnauthorized && c.token == "" && !c.direct {
+		message += " (set $" + tokenEnv + " or -token to an API token)"
+	}
+	return errors.New(message)
+}
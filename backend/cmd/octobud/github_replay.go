@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/github/replay"
+)
+
+// newGitHubClient builds the GitHub client. With -record-github, real responses are
+// recorded and the returned func saves them; with -replay-github, responses come from
+// a fixture file and GitHub is never contacted.
+func newGitHubClient(cfg appConfig) (githubinterfaces.Client, func(), error) {
+	switch {
+	case cfg.recordGitHub != "" && cfg.replayGitHub != "":
+		return nil, nil, errors.New("-record-github and -replay-github can't be used together")
+
+	case cfg.recordGitHub != "":
+		recorder := replay.NewRecorder(nil)
+		fmt.Printf("     GitHub: Recording responses to %s\n", cfg.recordGitHub)
+		save := func() {
+			if err := recorder.Save(cfg.recordGitHub); err != nil {
+				log.Printf("Warning: failed to save GitHub recording: %v", err)
+			}
+		}
+		return github.NewClientWithTransport(recorder), save, nil
+
+	case cfg.replayGitHub != "":
+		fixture, err := replay.Load(cfg.replayGitHub)
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Printf("     GitHub: Replaying responses from %s\n", cfg.replayGitHub)
+		return github.NewClientWithTransport(replay.NewReplayer(fixture)), func() {}, nil
+
+	default:
+		return github.NewClient(), func() {}, nil
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const defaultListLimit = 50
+
+// listedNotification is a line of the plain notification stream in JSON lines format
+type listedNotification struct {
+	ID        string `json:"id"`
+	Repo      string `json:"repo"`
+	Type      string `json:"type"`
+	Number    *int64 `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	Reason    string `json:"reason"`
+	Author    string `json:"author"`
+	Unread    bool   `json:"unread"`
+	Starred   bool   `json:"starred"`
+	UpdatedAt string `json:"updatedAt"`
+	Summary   string `json:"summary"`
+}
+
+// runList implements "octobud list", which prints the notifications matching a query.
+// It returns the exit code.
+func runList(args []string) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	var flags clientFlags
+	flags.register(fs)
+	limit := fs.Int("limit", defaultListLimit, "Most notifications to print, up to 200")
+	asJSON := fs.Bool("json", false, "Print one JSON object per notification")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: octobud list [flags] [query]")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), "Prints the notifications matching a query, like")
+		fmt.Fprintln(fs.Output(), `"in:inbox is:unread". An empty query lists the inbox.`)
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *limit < 1 {
+		fmt.Fprintln(os.Stderr, "-limit must be at least 1")
+		return 2
+	}
+
+	ctx := context.Background()
+	client, err := connect(ctx, flags, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect: %v\n", err)
+		return 1
+	}
+	defer client.close()
+
+	params := url.Values{}
+	params.Set("format", "jsonl")
+	params.Set("query", strings.Join(fs.Args(), " "))
+	params.Set("pageSize", strconv.Itoa(*limit))
+	resp, data, err := client.do(ctx, http.MethodGet, "/notifications/plain?"+params.Encode(), nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list notifications: %v\n", err)
+		return 1
+	}
+
+	// The stream is already JSON lines
+	if *asJSON {
+		_, _ = os.Stdout.Write(data)
+		return 0
+	}
+
+	notifications, err := parseNotificationLines(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read notifications: %v\n", err)
+		return 1
+	}
+	printNotifications(notifications, time.Now())
+
+	total, _ := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	switch {
+	case total == 0:
+		fmt.Fprintln(os.Stderr, "No notifications")
+	case total > len(notifications):
+		fmt.Fprintf(os.Stderr, "Showing %d of %d notifications\n", len(notifications), total)
+	}
+	return 0
+}
+
+func parseNotificationLines(data []byte) ([]listedNotification, error) {
+	var notifications []listedNotification
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var n listedNotification
+		if err := json.Unmarshal(scanner.Bytes(), &n); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, scanner.Err()
+}
+
+// printNotifications prints notifications as a table, marking unread ones with "•"
+func printNotifications(notifications []listedNotification, now time.Time) {
+	if len(notifications) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\t\tREPO\tSUBJECT\tUPDATED\tTITLE")
+	for _, n := range notifications {
+		unread := ""
+		if n.Unread {
+			unread = "•"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			n.ID, unread, n.Repo, subjectLabel(n), shortAge(n.UpdatedAt, now), n.Title)
+	}
+	_ = w.Flush()
+}
+
+// subjectLabel names a notification's subject briefly, like "PR #42" or "Issue #7"
+func subjectLabel(n listedNotification) string {
+	label := n.Type
+	switch n.Type {
+	case "PullRequest":
+		label = "PR"
+	case "RepositoryVulnerabilityAlert":
+		label = "Alert"
+	}
+	if n.Number != nil {
+		label += " #" + strconv.FormatInt(*n.Number, 10)
+	}
+	return label
+}
+
+// shortAge renders how long ago an RFC 3339 time was, like "5m", "3h" or "2d"
+func shortAge(value string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return ""
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dw", int(d.Hours()/(24*7)))
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -40,26 +41,16 @@ import (
 	"github.com/pressly/goose/v3"
 	"gopkg.in/natefinch/lumberjack.v2"
 
-	"github.com/octobud-hq/octobud/backend/internal/api"
-	"github.com/octobud-hq/octobud/backend/internal/api/navigation"
 	config "github.com/octobud-hq/octobud/backend/internal/config"
-	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
-	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
-	"github.com/octobud-hq/octobud/backend/internal/core/notification"
-	"github.com/octobud-hq/octobud/backend/internal/core/pullrequest"
-	"github.com/octobud-hq/octobud/backend/internal/core/repository"
-	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
-	"github.com/octobud-hq/octobud/backend/internal/core/update"
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
 	"github.com/octobud-hq/octobud/backend/internal/db/sqlite"
-	"github.com/octobud-hq/octobud/backend/internal/github"
-	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/osactions"
 	"github.com/octobud-hq/octobud/backend/internal/query"
 	"github.com/octobud-hq/octobud/backend/internal/server"
-	"github.com/octobud-hq/octobud/backend/internal/sync"
 	"github.com/octobud-hq/octobud/backend/internal/tray"
-	"github.com/octobud-hq/octobud/backend/internal/xcrypto"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
 	"github.com/octobud-hq/octobud/backend/web"
 
 	// SQLite driver
@@ -70,15 +61,37 @@ import (
 
 const indexHTML = "index.html"
 
+// prewarmWait is the longest the browser waits for recently opened items to warm
+const prewarmWait = 3 * time.Second
+
 // appConfig holds the parsed command-line configuration.
 type appConfig struct {
-	port        int
-	dataDir     string
-	noOpen      bool
-	frontendURL string // URL to open in browser (defaults to http://localhost:<port>)
+	port         int
+	dataDir      string
+	noOpen       bool
+	frontendURL  string // URL to open in browser (defaults to http://localhost:<port>)
+	configPath   string // Optional config file (defaults to <data-dir>/config.json)
+	recordGitHub string // Fixture file to record GitHub responses to
+	replayGitHub string // Fixture file to replay GitHub responses from
+	snapshotPath string // Where to write database snapshots (defaults to <workspace>/octobud-snapshot.db)
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "anonymize":
+			os.Exit(runAnonymize(os.Args[2:]))
+		case "snapshot":
+			os.Exit(runSnapshot(os.Args[2:]))
+		case "list":
+			os.Exit(runList(os.Args[2:]))
+		case "archive":
+			os.Exit(runArchive(os.Args[2:]))
+		case "sync":
+			os.Exit(runSync(os.Args[2:]))
+		}
+	}
+
 	// Parse command-line flags
 	port := flag.Int("port", 8808, "Port to listen on")
 	dataDir := flag.String("data-dir", "", "Data directory (default: platform-specific)")
@@ -88,6 +101,27 @@ func main() {
 		"",
 		"Frontend URL for tray/browser (default: http://localhost:<port>)",
 	)
+	configPath := flag.String(
+		"config",
+		"",
+		"Config file for server deployments (default: <data-dir>/"+config.FileName+")",
+	)
+	recordGitHub := flag.String(
+		"record-github",
+		"",
+		"Record sanitized GitHub API responses to this fixture file",
+	)
+	replayGitHub := flag.String(
+		"replay-github",
+		"",
+		"Replay GitHub API responses from this fixture file instead of calling GitHub",
+	)
+	snapshotPath := flag.String(
+		"snapshot-path",
+		"",
+		"Where POST /api/system/snapshot writes a read-only database copy "+
+			"(default: <data-dir>/"+snapshot.FileName+")",
+	)
 	showVersion := flag.Bool("version", false, "Show version and exit")
 	flag.Parse()
 
@@ -132,11 +166,19 @@ func main() {
 		fURL = fmt.Sprintf("http://localhost:%d", *port)
 	}
 
+	if *configPath == "" {
+		*configPath = filepath.Join(*dataDir, config.FileName)
+	}
+
 	cfg := appConfig{
-		port:        *port,
-		dataDir:     *dataDir,
-		noOpen:      *noOpen,
-		frontendURL: fURL,
+		port:         *port,
+		dataDir:      *dataDir,
+		noOpen:       *noOpen,
+		frontendURL:  fURL,
+		configPath:   *configPath,
+		recordGitHub: *recordGitHub,
+		replayGitHub: *replayGitHub,
+		snapshotPath: *snapshotPath,
 	}
 
 	// Create a logger for tray operations that writes to both console and logfile
@@ -183,162 +225,54 @@ func runServer(cfg appConfig, trayApp *tray.Tray, logWriter *lumberjack.Logger)
 		cancel()
 	}()
 
-	// Set up database path
-	dsn := filepath.Join(cfg.dataDir, "octobud.db")
-	fmt.Printf("     Database: %s\n", dsn)
-
-	// Open database
-	dbConn, err := db.OpenDatabase(dsn)
-	if err != nil {
-		cancel()
-		//nolint:gocritic // exitAfterDefer: cancel() is called explicitly before log.Fatalf
-		log.Fatalf("Failed to open database: %v", err)
-	}
-	defer func() {
-		if closeErr := dbConn.Close(); closeErr != nil {
-			log.Printf("Error closing database: %v", closeErr)
-		}
-	}()
-
-	// Run migrations
-	if err = runMigrations(dbConn); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
-	}
-
 	// Initialize logger (console format for desktop app)
 	// Reuse the same logWriter that was created in main() for consistency
 	logger := config.NewConsoleLoggerWithFile(logWriter)
 
-	// Create store
-	store := db.NewStore(dbConn)
-
-	// Initialize auth service and ensure user record exists
-	authService := authsvc.NewService(store)
-	if err = authService.EnsureUser(ctx); err != nil {
-		log.Fatalf("Failed to ensure user exists: %v", err)
-	}
-
-	// Initialize token storage
-	// NewKeychain() returns platform-specific implementation via build tags:
-	//   - macOS: real keychain implementation
-	//   - Non-macOS: fallback that returns errors (tokens stored in SQLite instead)
-	// Both platforms also get an encryptor for SQLite fallback/legacy support
-	var encryptor *xcrypto.Encryptor
-	var keychain = xcrypto.NewKeychain()
-	encryptionKey, err := xcrypto.LoadOrGenerateKey(cfg.dataDir)
+	// Load optional config file
+	fileConfig, err := config.LoadFile(cfg.configPath)
 	if err != nil {
-		log.Fatalf("Failed to load/generate encryption key: %v", err)
+		cancel()
+		//nolint:gocritic // exitAfterDefer: cancel() is called explicitly before log.Fatalf
+		log.Fatalf("Failed to load config: %v", err)
 	}
-	encryptor, err = xcrypto.NewEncryptor(encryptionKey)
+
+	// Load embedded frontend
+	frontendFS, err := web.DistFS()
 	if err != nil {
-		log.Fatalf("Failed to create encryptor: %v", err)
+		log.Fatalf("Failed to load frontend assets: %v", err)
 	}
 
-	// Initialize GitHub client
-	githubClient := github.NewClient()
-
-	// Create token manager
-	tokenManager := coregithub.NewTokenManager(
-		store,
-		encryptor,
-		keychain,
-		githubClient,
-		authService,
-		logger,
-	)
-
-	// Initialize token manager (loads stored token)
-	if initErr := tokenManager.Initialize(ctx); initErr != nil {
-		// Don't fatal on token init failure - user can configure via UI
-		log.Printf("Warning: Failed to initialize GitHub token: %v", initErr)
+	deps := workspaceDeps{
+		logger:     logger,
+		fileConfig: fileConfig,
+		frontendFS: frontendFS,
+		trayApp:    trayApp,
 	}
 
-	// Check if token is configured
-	tokenConfigured := tokenManager.IsConnected()
-
-	// Initialize business logic services
-	syncStateSvc := syncstate.NewSyncStateService(store)
-	repositorySvc := repository.NewService(store)
-	pullRequestSvc := pullrequest.NewService(store)
-	notificationSvc := notification.NewService(store)
-
-	// Initialize sync service
-	syncService := sync.NewService(
-		logger,
-		time.Now,
-		githubClient,
-		syncStateSvc,
-		repositorySvc,
-		pullRequestSvc,
-		notificationSvc,
-		store,
+	// With multiple users every user's workspace runs at once; otherwise one workspace
+	// runs and the user can switch between them
+	var (
+		handler         http.Handler
+		warmed          <-chan struct{}
+		closeWorkspaces func()
 	)
-
-	// Create update service
-	updateService := update.NewService(logger)
-
-	// Create scheduler with persistent job queue
-	scheduler := jobs.NewSQLiteScheduler(jobs.SQLiteSchedulerConfig{
-		Logger:        logger,
-		DBConn:        dbConn, // For persistent job queue
-		Store:         store,
-		SyncService:   syncService,
-		SyncInterval:  20 * time.Second,
-		AuthService:   authService,
-		UpdateService: updateService,
-	})
-
-	// Start scheduler
-	if startErr := scheduler.Start(ctx); startErr != nil {
-		log.Fatalf("Failed to start scheduler: %v", startErr)
-	}
-	defer func() {
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer shutdownCancel()
-		if stopErr := scheduler.Stop(shutdownCtx); stopErr != nil {
-			log.Printf("Warning: scheduler shutdown error: %v", stopErr)
+	if fileConfig.Auth.MultiUser() {
+		multiUser, runtimes, multiUserErr := openUserWorkspaces(ctx, cfg, deps)
+		if multiUserErr != nil {
+			log.Fatalf("Failed to start multi-user mode: %v", multiUserErr)
 		}
-	}()
-
-	// Create navigation broadcaster for tray menu navigation
-	navBroadcaster := navigation.NewBroadcaster(logger)
-
-	// Configure API handler
-	// Always set up sync service - OAuth can configure the token later
-	opts := []api.HandlerOption{
-		api.WithScheduler(scheduler),
-		api.WithTokenManager(tokenManager),
-		api.WithSyncService(store, githubClient, logger),
-		api.WithNavigationBroadcaster(navBroadcaster),
-	}
-	if tokenConfigured {
-		status := tokenManager.GetStatus()
-		fmt.Printf("     GitHub: Connected as %s ✓\n", status.GitHubUsername)
+		handler, closeWorkspaces = multiUser, runtimes.closeAll
 	} else {
-		fmt.Println("     GitHub: Not configured (configure in app)")
-	}
-	apiHandler := api.NewHandler(store, opts...)
-
-	// Pass navigation broadcaster to tray if available
-	if trayApp != nil {
-		navBroadcasterFromHandler := apiHandler.GetNavigationBroadcaster()
-		if navBroadcasterFromHandler != nil {
-			trayApp.SetNavigationBroadcaster(navBroadcasterFromHandler)
-		}
+		handler, warmed, closeWorkspaces = openSwitchableWorkspace(ctx, cfg, deps)
 	}
 
-	// Set up router with standard middleware
-	router := server.NewRouter(server.DefaultConfig())
-
-	// API routes
-	router.Route("/api", apiHandler.RegisterAllRoutes)
-
-	// Serve embedded frontend
-	frontendFS, err := web.DistFS()
-	if err != nil {
-		log.Fatalf("Failed to load frontend assets: %v", err)
-	}
-	serveStaticFiles(router, frontendFS)
+	// Set up router with standard middleware. An authenticating proxy's address is
+	// what proves a request came through it, so forwarded addresses aren't trusted then.
+	serverCfg := server.DefaultConfig()
+	serverCfg.RealIP = fileConfig.Auth.Provider != config.AuthProviderProxy
+	router := server.NewRouter(serverCfg)
+	router.Mount("/", handler)
 
 	// Start server
 	addr := fmt.Sprintf(":%d", cfg.port)
@@ -362,51 +296,14 @@ func runServer(cfg appConfig, trayApp *tray.Tray, logWriter *lumberjack.Logger)
 
 	// Auto-open browser to frontend URL
 	if !cfg.noOpen {
-		// Give the server a moment to start
+		// Give the server a moment to start and recently opened items a moment to warm,
+		// so the first page doesn't wait on GitHub
 		time.Sleep(500 * time.Millisecond)
-		openBrowser(cfg.frontendURL)
-	}
-
-	// Set up a ticker to periodically update tray with sync status
-	if trayApp != nil {
-		// Helper to update tray status
-		updateTrayStatus := func() {
-			// Get userID for data scoping
-			user, userErr := authService.GetUser(ctx)
-			if userErr != nil || user == nil || user.GithubUserID == "" {
-				// User not configured yet, skip tray update
-				return
-			}
-			userID := user.GithubUserID
-
-			// Update last sync time
-			state, err := syncStateSvc.GetSyncState(ctx, userID)
-			if err == nil && state.LastSuccessfulPoll.Valid {
-				trayApp.SetLastSync(state.LastSuccessfulPoll.Time)
-			}
-			// Update unread count
-			unreadCount, err := getUnreadCount(ctx, store, userID)
-			if err == nil {
-				trayApp.SetUnreadCount(int(unreadCount))
-			}
+		select {
+		case <-warmed:
+		case <-time.After(prewarmWait):
 		}
-
-		// Update immediately on startup
-		updateTrayStatus()
-
-		// Then update periodically
-		go func() {
-			ticker := time.NewTicker(10 * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					updateTrayStatus()
-				}
-			}
-		}()
+		openBrowser(cfg.frontendURL)
 	}
 
 	// Wait for shutdown signal or server error
@@ -431,19 +328,103 @@ func runServer(cfg appConfig, trayApp *tray.Tray, logWriter *lumberjack.Logger)
 		log.Printf("Warning: server shutdown error: %v", err)
 	}
 
+	closeWorkspaces()
+
 	fmt.Println("Goodbye!")
 }
 
+// openSwitchableWorkspace opens the active workspace and serves it until the user
+// switches to another. It returns the handler serving the current workspace, a channel
+// closed once the first workspace is warm, and a function closing whichever workspace
+// is open at shutdown.
+func openSwitchableWorkspace(
+	ctx context.Context,
+	cfg appConfig,
+	deps workspaceDeps,
+) (http.Handler, <-chan struct{}, func()) {
+	// Each workspace keeps its own database and settings; the data directory itself
+	// is the default workspace
+	workspaces, err := workspace.NewManager(cfg.dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load workspaces: %v", err)
+	}
+	deps.manager = workspaces
+
+	active := workspaces.Active()
+	current, err := openWorkspace(ctx, cfg, deps, active, workspaces.DataDir(active.Slug))
+	if err != nil {
+		log.Fatalf("Failed to open workspace %q: %v", active.Name, err)
+	}
+	var currentMu sync.Mutex
+
+	handler := &swappableHandler{}
+	handler.set(current.handler)
+
+	// Switching opens the new workspace before closing the old one, so a workspace
+	// that fails to open leaves the current one running
+	workspaces.SetSwitcher(func(ws models.Workspace, dataDir string) error {
+		next, openErr := openWorkspace(ctx, cfg, deps, ws, dataDir)
+		if openErr != nil {
+			return openErr
+		}
+		handler.set(next.handler)
+
+		currentMu.Lock()
+		previous := current
+		current = next
+		currentMu.Unlock()
+
+		previous.close()
+		return nil
+	})
+
+	// Keep the tray's workspace menu in sync
+	if deps.trayApp != nil {
+		updateTrayWorkspaces := func() {
+			deps.trayApp.SetWorkspaces(workspaces.List(), func(slug string) {
+				if _, switchErr := workspaces.Activate(slug); switchErr != nil {
+					log.Printf("Failed to switch workspace: %v", switchErr)
+				}
+			})
+		}
+		workspaces.SetOnChange(updateTrayWorkspaces)
+		updateTrayWorkspaces()
+	}
+
+	// No more switches once the server is down, then close the open workspace
+	closeAll := func() {
+		workspaces.SetSwitcher(nil)
+		currentMu.Lock()
+		current.close()
+		currentMu.Unlock()
+	}
+	return handler, current.warmed, closeAll
+}
+
+// migrationResult records the schema version before and after migrations ran.
+type migrationResult struct {
+	fromVersion int64
+	toVersion   int64
+}
+
 // runMigrations runs database migrations using goose.
-func runMigrations(dbConn *sql.DB) error {
+func runMigrations(dbConn *sql.DB) (migrationResult, error) {
 	if err := goose.SetDialect("sqlite3"); err != nil {
-		return fmt.Errorf("failed to set goose dialect: %w", err)
+		return migrationResult{}, fmt.Errorf("failed to set goose dialect: %w", err)
 	}
 	goose.SetBaseFS(sqlite.MigrationsFS)
+	fromVersion, err := goose.GetDBVersion(dbConn)
+	if err != nil {
+		return migrationResult{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
 	if err := goose.Up(dbConn, "migrations"); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+		return migrationResult{}, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	toVersion, err := goose.GetDBVersion(dbConn)
+	if err != nil {
+		return migrationResult{}, fmt.Errorf("failed to read schema version: %w", err)
 	}
-	return nil
+	return migrationResult{fromVersion: fromVersion, toVersion: toVersion}, nil
 }
 
 // serveStaticFiles serves the embedded frontend files with proper SPA routing.
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+)
+
+// runSnapshot implements "octobud snapshot", which writes a read-only copy of the
+// database for running your own SQL against. It returns the exit code.
+func runSnapshot(args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	dataDir := fs.String("data-dir", "", "Data directory (default: platform-specific)")
+	dbPath := fs.String("db", "", "Database to copy (default: <data-dir>/octobud.db)")
+	outPath := fs.String(
+		"out",
+		"",
+		"Path to write the snapshot to, replacing any previous one "+
+			"(default: <data-dir>/"+snapshot.FileName+")",
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: octobud snapshot [flags]")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), "Writes a consistent, read-only copy of the database.")
+		fmt.Fprintln(fs.Output(), "Safe to run while Octobud is open; queries against the")
+		fmt.Fprintln(fs.Output(), "copy never lock the live database.")
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *dataDir == "" {
+		var err error
+		*dataDir, err = db.GetDefaultDataDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get default data directory: %v\n", err)
+			return 1
+		}
+	}
+	if *dbPath == "" {
+		*dbPath = filepath.Join(*dataDir, "octobud.db")
+	}
+	if *outPath == "" {
+		*outPath = filepath.Join(*dataDir, snapshot.FileName)
+	}
+
+	info, err := snapshot.Write(context.Background(), *dbPath, *outPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write snapshot: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Wrote %s (%d bytes)\n", info.Path, info.SizeBytes)
+	return 0
+}
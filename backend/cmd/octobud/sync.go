@@ -0,0 +1,68 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// runSync implements "octobud sync now", which asks a running server to sync
+// notifications from GitHub right away. It returns the exit code.
+func runSync(args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	var flags clientFlags
+	flags.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: octobud sync now [flags]")
+		fmt.Fprintln(fs.Output(), "")
+		fmt.Fprintln(fs.Output(), "Syncs notifications from GitHub without waiting for")
+		fmt.Fprintln(fs.Output(), "the next scheduled sync. Octobud has to be running.")
+		fmt.Fprintln(fs.Output(), "")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "now" {
+		fs.Usage()
+		return 2
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	// Syncing needs the scheduler and the GitHub token, which only the server has
+	ctx := context.Background()
+	client, err := connect(ctx, flags, false)
+	if errors.Is(err, errServerNotRunning) {
+		fmt.Fprintf(os.Stderr, "Failed to start a sync: %v; it syncs as soon as it starts\n", err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect: %v\n", err)
+		return 1
+	}
+	defer client.close()
+
+	if _, _, err := client.do(ctx, http.MethodPost, "/user/sync", nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start a sync: %v\n", err)
+		return 1
+	}
+	fmt.Println("Sync started")
+	return 0
+}
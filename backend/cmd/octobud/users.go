@@ -0,0 +1,269 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	apisharedviews "github.com/octobud-hq/octobud/backend/internal/api/sharedviews"
+	apiusers "github.com/octobud-hq/octobud/backend/internal/api/users"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	config "github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+// userWorkspaces runs the workspace of every user of a multi-user server at once, so
+// everyone's notifications keep syncing, and serves each request from the signed-in
+// user's workspace.
+type userWorkspaces struct {
+	mu       sync.RWMutex
+	runtimes map[string]*workspaceRuntime
+}
+
+func (u *userWorkspaces) add(username string, rt *workspaceRuntime) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.runtimes[username] = rt
+}
+
+// remove stops a user's workspace
+func (u *userWorkspaces) remove(username string) {
+	u.mu.Lock()
+	rt := u.runtimes[username]
+	delete(u.runtimes, username)
+	u.mu.Unlock()
+
+	if rt != nil {
+		rt.close()
+	}
+}
+
+// closeAll stops every user's workspace
+func (u *userWorkspaces) closeAll() {
+	u.mu.Lock()
+	runtimes := u.runtimes
+	u.runtimes = make(map[string]*workspaceRuntime)
+	u.mu.Unlock()
+
+	for _, rt := range runtimes {
+		rt.close()
+	}
+}
+
+// ServeHTTP hands the request to the workspace of the user in its session. Requests
+// only ever reach the signed-in user's workspace, which keeps users' data apart.
+func (u *userWorkspaces) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	session := authn.SessionFromContext(r.Context())
+	if session == nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	u.mu.RLock()
+	rt := u.runtimes[session.Subject]
+	u.mu.RUnlock()
+	if rt == nil {
+		helpers.WriteError(w, http.StatusServiceUnavailable, "Your workspace isn't running")
+		return
+	}
+
+	// The workspace's router matches the full path itself, so it gets a fresh routing
+	// context rather than the one this request was routed here with
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, chi.NewRouteContext())
+	rt.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ViewShares returns the view sharing of every running workspace, by username
+func (u *userWorkspaces) ViewShares() map[string]viewshare.ViewShareService {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	shares := make(map[string]viewshare.ViewShareService, len(u.runtimes))
+	for username, rt := range u.runtimes {
+		if rt.viewShares != nil {
+			shares[username] = rt.viewShares
+		}
+	}
+	return shares
+}
+
+// tokenOwner returns the user who created an API token, along with the token. Tokens are
+// kept in each user's own database, so every running workspace is asked in turn.
+func (u *userWorkspaces) tokenOwner(
+	ctx context.Context,
+	value string,
+) (string, models.APIToken, bool) {
+	u.mu.RLock()
+	verifiers := make(map[string]apitoken.APITokenService, len(u.runtimes))
+	for username, rt := range u.runtimes {
+		verifiers[username] = rt.tokens
+	}
+	u.mu.RUnlock()
+
+	for username, verifier := range verifiers {
+		if token, err := verifier.VerifyToken(ctx, value); err == nil {
+			return username, token, true
+		}
+	}
+	return "", models.APIToken{}, false
+}
+
+// authenticate signs in requests carrying an API token as the user who created it and
+// hands every other request to signIn. The user's workspace checks the token again,
+// along with what it's allowed to do; routes served here check ReadOnly themselves.
+func (u *userWorkspaces) authenticate(
+	signIn func(http.Handler) http.Handler,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		signedIn := signIn(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value, ok := authn.BearerToken(r)
+			if !ok {
+				signedIn.ServeHTTP(w, r)
+				return
+			}
+			username, token, found := u.tokenOwner(r.Context(), value)
+			if !found {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				helpers.WriteError(w, http.StatusUnauthorized, "Invalid or expired API token")
+				return
+			}
+			session := &authn.Session{
+				Subject:  username,
+				Account:  authn.AnyAccount,
+				TokenID:  token.ID,
+				ReadOnly: token.ReadOnly(),
+			}
+			next.ServeHTTP(w, r.WithContext(authn.ContextWithSession(r.Context(), session)))
+		})
+	}
+}
+
+// openUserWorkspaces brings up the workspace of every user of a multi-user server and
+// returns the routes that sign users in and send their requests to their own workspace.
+// Users added later get a workspace as they're created. With local sign-in and no users
+// yet, an admin is created and their password printed.
+func openUserWorkspaces(
+	ctx context.Context,
+	cfg appConfig,
+	deps workspaceDeps,
+) (http.Handler, *userWorkspaces, error) {
+	manager, err := users.NewManager(cfg.dataDir, time.Now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load users: %w", err)
+	}
+
+	// Users can't switch workspaces, and a snapshot path shared between users would
+	// hold whoever took the last one
+	if cfg.snapshotPath != "" {
+		log.Printf("Warning: --snapshot-path is ignored with multiple users")
+		cfg.snapshotPath = ""
+	}
+	deps.trayApp = nil
+	deps.manager = nil
+	deps.users = manager
+
+	runtimes := &userWorkspaces{runtimes: make(map[string]*workspaceRuntime)}
+	open := func(user models.ServerUser, dataDir string) error {
+		// Keychain entries are namespaced by slug, so users' slugs are kept apart from
+		// workspaces created before multi-user mode was turned on
+		ws := models.Workspace{Slug: user.Workspace, Name: user.Username, Active: true}
+		if ws.Slug != workspace.DefaultSlug {
+			ws.Slug = "users." + ws.Slug
+		}
+		userDeps := deps
+		userDeps.username = user.Username
+		rt, openErr := openWorkspace(ctx, cfg, userDeps, ws, dataDir)
+		if openErr != nil {
+			return openErr
+		}
+		runtimes.add(user.Username, rt)
+		return nil
+	}
+	for _, user := range manager.List() {
+		if err = open(user, manager.DataDir(user)); err != nil {
+			runtimes.closeAll()
+			return nil, nil, fmt.Errorf("failed to open workspace for %q: %w", user.Username, err)
+		}
+	}
+	manager.SetHooks(users.Hooks{
+		Added: open,
+		Removed: func(user models.ServerUser) {
+			runtimes.remove(user.Username)
+		},
+	})
+
+	var authenticator authn.Authenticator
+	switch deps.fileConfig.Auth.Provider {
+	case config.AuthProviderLocal:
+		authenticator, err = authn.NewLocal(deps.logger, deps.fileConfig.Auth.Local, manager)
+		if err == nil {
+			err = bootstrapAdmin(manager)
+		}
+	case config.AuthProviderProxy:
+		authenticator, err = authn.NewProxy(deps.logger, deps.fileConfig.Auth.Proxy, manager)
+	default:
+		err = fmt.Errorf(
+			"auth provider %q doesn't support multiple users", deps.fileConfig.Auth.Provider,
+		)
+	}
+	if err != nil {
+		runtimes.closeAll()
+		return nil, nil, err
+	}
+	fmt.Printf("     Auth: %s, %d users\n", deps.fileConfig.Auth.Provider, len(manager.List()))
+
+	// Sign-in routes are reachable without a session; user management is for admins.
+	// Views shared by other users are served from their owners' workspaces.
+	router := chi.NewRouter()
+	router.Route("/api", func(r chi.Router) {
+		authenticator.Register(r)
+		r.Group(func(r chi.Router) {
+			r.Use(runtimes.authenticate(authenticator.Middleware))
+			apiusers.New(deps.logger, manager).Register(r)
+			apisharedviews.New(deps.logger, runtimes).Register(r)
+			r.Handle("/*", runtimes)
+		})
+	})
+	router.With(authenticator.Middleware).Handle("/*", runtimes)
+	return router, runtimes, nil
+}
+
+// bootstrapAdmin creates the first admin of a server using local sign-in and prints
+// their password, which is shown only this once
+func bootstrapAdmin(manager *users.Manager) error {
+	user, password, err := manager.Bootstrap()
+	if err != nil {
+		return fmt.Errorf("failed to create the first admin: %w", err)
+	}
+	if password != "" {
+		fmt.Printf("     Users: Created admin %q with password %s\n", user.Username, password)
+		fmt.Println("            Sign in and change it with PUT /api/auth/password")
+	}
+	return nil
+}
@@ -0,0 +1,502 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api"
+	"github.com/octobud-hq/octobud/backend/internal/api/navigation"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	config "github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/authorprofile"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/core/changelog"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
+	"github.com/octobud-hq/octobud/backend/internal/core/pullrequest"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
+	"github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	"github.com/octobud-hq/octobud/backend/internal/core/update"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/osactions"
+	"github.com/octobud-hq/octobud/backend/internal/recovery"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
+	"github.com/octobud-hq/octobud/backend/internal/tray"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+	"github.com/octobud-hq/octobud/backend/internal/xcrypto"
+)
+
+// swappableHandler forwards requests to the active workspace's routes, so switching
+// workspaces doesn't restart the HTTP listener.
+type swappableHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+func (h *swappableHandler) set(handler http.Handler) {
+	h.current.Store(&handler)
+}
+
+func (h *swappableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.current.Load()).ServeHTTP(w, r)
+}
+
+// workspaceDeps holds the process-wide pieces every workspace is opened with.
+type workspaceDeps struct {
+	logger     *zap.Logger
+	fileConfig *config.File
+	frontendFS fs.FS
+	trayApp    *tray.Tray
+	manager    *workspace.Manager
+	// Set on a multi-user server: the users views can be shared with, and the
+	// username the workspace belongs to
+	users    *users.Manager
+	username string
+}
+
+// workspaceRuntime is one open workspace: its database, services, background jobs,
+// and routes.
+type workspaceRuntime struct {
+	handler http.Handler
+	closers []func()
+	// Closed once recently opened items have been warmed
+	warmed <-chan struct{}
+	// Verifies the API tokens the workspace's user created
+	tokens apitoken.APITokenService
+	// Shares the workspace's views with other users of a multi-user server
+	viewShares viewshare.ViewShareService
+}
+
+func (w *workspaceRuntime) onClose(fn func()) {
+	w.closers = append(w.closers, fn)
+}
+
+// close stops the workspace's background work and closes its database, undoing
+// setup in reverse order.
+func (w *workspaceRuntime) close() {
+	for i := len(w.closers) - 1; i >= 0; i-- {
+		w.closers[i]()
+	}
+	w.closers = nil
+}
+
+// openWorkspace opens a workspace's database and starts its services and scheduler.
+// On error, everything already started is closed again.
+//
+//nolint:gocyclo // cyclomatic complexity is high but function is cohesive
+func openWorkspace(
+	parent context.Context,
+	cfg appConfig,
+	deps workspaceDeps,
+	ws models.Workspace,
+	dataDir string,
+) (_ *workspaceRuntime, err error) {
+	rt := &workspaceRuntime{}
+	defer func() {
+		if err != nil {
+			rt.close()
+		}
+	}()
+
+	fmt.Printf("     Workspace: %s\n", ws.Name)
+
+	// Set up database path
+	dsn := filepath.Join(dataDir, "octobud.db")
+	fmt.Printf("     Database: %s\n", dsn)
+
+	// Swap in a backup staged for restore while nothing has the database open
+	backupDir := filepath.Join(dataDir, backup.DirName)
+	replaced, restoreErr := backup.ApplyPending(parent, dsn, backupDir, time.Now())
+	if restoreErr != nil {
+		log.Printf("Warning: Failed to restore staged backup, keeping current database: %v", restoreErr)
+	} else if replaced != nil {
+		fmt.Printf("     Restore: Restored staged backup (previous database saved as %s)\n", replaced.Name)
+	}
+
+	// Detect an unclean previous shutdown before SQLite replays its WAL
+	startupReport, recoveryErr := recovery.Begin(dataDir, dsn)
+	if recoveryErr != nil {
+		log.Printf("Warning: Failed to check previous shutdown state: %v", recoveryErr)
+	} else {
+		rt.onClose(func() {
+			if endErr := recovery.End(dataDir); endErr != nil {
+				log.Printf("Error removing startup marker: %v", endErr)
+			}
+		})
+	}
+
+	// Open database
+	dbConn, err := db.OpenDatabase(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	rt.onClose(func() {
+		if closeErr := dbConn.Close(); closeErr != nil {
+			log.Printf("Error closing database: %v", closeErr)
+		}
+	})
+
+	// Run migrations
+	migrations, err := runMigrations(dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create store
+	store := db.NewStore(dbConn)
+
+	// Backups run on the schedule in the user's settings and can be taken on demand
+	backupSvc := backup.NewService(store, dsn, backupDir, time.Now)
+
+	// Tell the user about changes introduced by migrations this update applied
+	if _, changelogErr := changelog.NewService(store, time.Now).Record(
+		parent, migrations.fromVersion, migrations.toVersion,
+	); changelogErr != nil {
+		log.Printf("Warning: failed to record changelog entries: %v", changelogErr)
+	}
+
+	// Initialize auth service and ensure user record exists
+	authService := authsvc.NewService(store)
+	if err = authService.EnsureUser(parent); err != nil {
+		return nil, fmt.Errorf("failed to ensure user exists: %w", err)
+	}
+
+	// Set up request authentication
+	var authenticator authn.Authenticator = authn.NoAuth{}
+	if deps.fileConfig.Auth.Provider == config.AuthProviderOIDC {
+		oidcAuth, oidcErr := authn.NewOIDC(
+			parent, deps.logger, deps.fileConfig.Auth.OIDC, authService,
+		)
+		if oidcErr != nil {
+			return nil, fmt.Errorf("failed to set up OIDC authentication: %w", oidcErr)
+		}
+		authenticator = oidcAuth
+		fmt.Printf("     Auth: OIDC (%s)\n", deps.fileConfig.Auth.OIDC.Issuer)
+	}
+
+	// Initialize token storage
+	// NewKeychain() returns platform-specific implementation via build tags:
+	//   - macOS: real keychain implementation
+	//   - Non-macOS: fallback that returns errors (tokens stored in SQLite instead)
+	// Both platforms also get an encryptor for SQLite fallback/legacy support.
+	// Keychain entries are namespaced per workspace so tokens never cross over.
+	var encryptor *xcrypto.Encryptor
+	var keychain = workspace.Keychain(xcrypto.NewKeychain(), ws.Slug)
+	encryptionKey, err := xcrypto.LoadOrGenerateKey(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/generate encryption key: %w", err)
+	}
+	encryptor, err = xcrypto.NewEncryptor(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryptor: %w", err)
+	}
+
+	// Initialize GitHub client
+	githubClient, saveRecording, err := newGitHubClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up GitHub client: %w", err)
+	}
+	rt.onClose(saveRecording)
+
+	// Create token manager
+	tokenManager := coregithub.NewTokenManager(
+		store,
+		encryptor,
+		keychain,
+		githubClient,
+		authService,
+		deps.logger,
+	)
+
+	// Initialize token manager (loads stored token)
+	if initErr := tokenManager.Initialize(parent); initErr != nil {
+		// Don't fail on token init failure - user can configure via UI
+		log.Printf("Warning: Failed to initialize GitHub token: %v", initErr)
+	}
+
+	// Check if token is configured
+	tokenConfigured := tokenManager.IsConnected()
+
+	// Background work below stops when the workspace closes, before its database does
+	ctx, cancel := context.WithCancel(parent)
+	rt.onClose(cancel)
+
+	// Cache author profiles, refreshing them in the background as lists request them
+	authorProfileSvc := authorprofile.NewService(deps.logger, store, githubClient, time.Now)
+	go authorProfileSvc.Run(ctx)
+
+	// Saved replies are imported from GitHub on demand and cached
+	savedReplySvc := savedreply.NewService(deps.logger, store, githubClient, time.Now)
+
+	// Scripts and CLI tools sign in with API tokens instead of a browser session
+	rt.tokens = apitoken.NewService(store, time.Now)
+
+	// Initialize business logic services
+	syncStateSvc := syncstate.NewSyncStateService(store)
+	repositorySvc := repository.NewService(store)
+	pullRequestSvc := pullrequest.NewService(store)
+	// Live update streams hear about upserts, bulk results and sync status
+	eventBus := events.NewService(deps.logger, time.Now)
+	notificationSvc := notification.NewService(store).WithEvents(eventBus)
+
+	// Initialize sync service
+	syncService := sync.NewService(
+		deps.logger,
+		time.Now,
+		githubClient,
+		syncStateSvc,
+		repositorySvc,
+		pullRequestSvc,
+		notificationSvc,
+		store,
+	)
+
+	// Create update service
+	updateService := update.NewService(deps.logger)
+
+	// Alerts are recorded as notifications arrive, so every client sees the same policy
+	alertSvc := alert.NewService(store, time.Now)
+
+	// Live query subscribers hear about synced notifications and API changes alike
+	liveQuerySvc := livequery.NewService(store, deps.logger, time.Now)
+
+	// Recently opened lists and notifications are warmed on startup and served once
+	prewarmSvc := prewarm.NewService(store, time.Now)
+
+	// Digests are generated on the schedule in the user's settings, kept next to the
+	// database, and emailed when config.json has a mail server
+	digestSvc := digest.NewService(
+		store, notificationSvc, workhours.NewService(store), filepath.Join(dataDir, digest.DirName), time.Now,
+	)
+	if deps.fileConfig.SMTP.Configured() {
+		digestSvc = digestSvc.WithMailer(digest.NewSMTPMailer(deps.fileConfig.SMTP, time.Now))
+	}
+
+	// Webhooks rules notify are sent by the scheduler, retried until they go through
+	integrationSvc := integration.NewService(store, encryptor, time.Now)
+
+	// Run startup consistency checks before the scheduler picks up any jobs
+	if startupReport != nil {
+		var userID string
+		if user, userErr := authService.GetUser(ctx); userErr == nil && user != nil {
+			userID = user.GithubUserID
+		}
+		jobQueue := jobs.NewSQLiteJobQueue(dbConn)
+		recovery.RunChecks(ctx, startupReport, dbConn, jobQueue, store, userID)
+		if startupReport.UncleanShutdown {
+			fmt.Println(
+				"     Recovery: Previous run did not shut down cleanly " +
+					"(see /api/system/last-startup)",
+			)
+		}
+	}
+
+	// Create scheduler with persistent job queue
+	scheduler := jobs.NewSQLiteScheduler(jobs.SQLiteSchedulerConfig{
+		Logger:          deps.logger,
+		DBConn:          dbConn, // For persistent job queue
+		Store:           store,
+		SyncService:     syncService,
+		SyncInterval:    20 * time.Second,
+		AuthService:     authService,
+		UpdateService:   updateService,
+		AlertService:    alertSvc,
+		NativeNotifier:  osactions.NewService(),
+		SystemNotifier:  sysnotify.NewService(store, time.Now),
+		TokenExpiration: githubClient.TokenExpiration,
+		LiveQueries:     liveQuerySvc,
+		Events:          eventBus,
+		AccountClients:  tokenManager,
+		Prewarm:         prewarmSvc,
+		GitHubClient:    githubClient,
+		Backups:         backupSvc,
+		Priorities:      priority.NewService(store, time.Now),
+		Digests:         digestSvc,
+		Integrations:    integrationSvc,
+		// Zero, when the config file doesn't set it, picks the default
+		NotificationWorkers: deps.fileConfig.Sync.Workers,
+	})
+
+	// Start scheduler
+	if err = scheduler.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start scheduler: %w", err)
+	}
+	rt.warmed = scheduler.Warmed()
+	rt.onClose(func() {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if stopErr := scheduler.Stop(shutdownCtx); stopErr != nil {
+			log.Printf("Warning: scheduler shutdown error: %v", stopErr)
+		}
+	})
+
+	// GitHub webhook deliveries update subjects right away once enabled in sync settings
+	webhookSvc := webhook.NewService(
+		deps.logger,
+		authService,
+		store,
+		syncService,
+		scheduler,
+		liveQuerySvc,
+	)
+	if deps.fileConfig.Webhooks.Secret != "" {
+		fmt.Println("     Webhooks: POST /api/webhooks/github")
+	}
+
+	// Create navigation broadcaster for tray menu navigation
+	navBroadcaster := navigation.NewBroadcaster(deps.logger)
+
+	// Configure API handler
+	// Always set up sync service - OAuth can configure the token later
+	opts := []api.HandlerOption{
+		api.WithScheduler(scheduler),
+		api.WithTokenManager(tokenManager),
+		api.WithSyncService(store, githubClient, deps.logger),
+		api.WithNavigationBroadcaster(navBroadcaster),
+		api.WithStartupReport(startupReport),
+		api.WithAuthenticator(authenticator),
+		api.WithAuthorProfiles(authorProfileSvc),
+		api.WithSavedReplies(savedReplySvc),
+		api.WithAlerts(alertSvc),
+		api.WithLiveQueries(liveQuerySvc),
+		api.WithEvents(eventBus),
+		api.WithWebhooks(webhookSvc, deps.fileConfig.Webhooks.Secret),
+		api.WithWorkspaces(deps.manager),
+		api.WithSnapshots(snapshot.NewService(dsn, snapshotPath(cfg, dataDir))),
+		api.WithBackups(backupSvc),
+		api.WithDigests(digestSvc),
+		api.WithIntegrations(integrationSvc),
+		api.WithPrewarm(prewarmSvc),
+		api.WithAPITokens(rt.tokens),
+	}
+	if deps.users != nil {
+		rt.viewShares = viewshare.NewService(store, deps.username, deps.users, time.Now)
+		opts = append(opts, api.WithViewShares(rt.viewShares))
+	}
+	if tokenConfigured {
+		status := tokenManager.GetStatus()
+		fmt.Printf("     GitHub: Connected as %s ✓\n", status.GitHubUsername)
+	} else {
+		fmt.Println("     GitHub: Not configured (configure in app)")
+	}
+	apiHandler := api.NewHandler(store, opts...)
+
+	// Pass navigation broadcaster to tray if available
+	if deps.trayApp != nil {
+		navBroadcasterFromHandler := apiHandler.GetNavigationBroadcaster()
+		if navBroadcasterFromHandler != nil {
+			deps.trayApp.SetNavigationBroadcaster(navBroadcasterFromHandler)
+		}
+	}
+
+	// API routes and the embedded frontend; standard middleware is applied by the
+	// router in front of the swappable handler
+	router := chi.NewRouter()
+	router.Route("/api", apiHandler.RegisterAllRoutes)
+	serveStaticFiles(router.With(authenticator.Middleware), deps.frontendFS)
+	rt.handler = router
+
+	// Set up a ticker to periodically update tray with sync status
+	if deps.trayApp != nil {
+		go watchTrayStatus(ctx, deps.trayApp, authService, syncStateSvc, store)
+	}
+
+	return rt, nil
+}
+
+// snapshotPath returns where snapshots of the workspace at dataDir are written. A
+// configured path is shared, so it always holds a copy of the active workspace.
+func snapshotPath(cfg appConfig, dataDir string) string {
+	if cfg.snapshotPath != "" {
+		return cfg.snapshotPath
+	}
+	return filepath.Join(dataDir, snapshot.FileName)
+}
+
+// watchTrayStatus keeps the tray's last sync time and unread count up to date until
+// ctx is cancelled.
+func watchTrayStatus(
+	ctx context.Context,
+	trayApp *tray.Tray,
+	authService *authsvc.Service,
+	syncStateSvc *syncstate.Service,
+	store db.Store,
+) {
+	// Helper to update tray status
+	updateTrayStatus := func() {
+		// Get userID for data scoping
+		user, userErr := authService.GetUser(ctx)
+		if userErr != nil || user == nil || user.GithubUserID == "" {
+			// User not configured yet, skip tray update
+			return
+		}
+		userID := user.GithubUserID
+
+		// Update last sync time
+		state, err := syncStateSvc.GetSyncState(ctx, userID)
+		if err == nil && state.LastSuccessfulPoll.Valid {
+			trayApp.SetLastSync(state.LastSuccessfulPoll.Time)
+		}
+		// Update unread count
+		unreadCount, err := getUnreadCount(ctx, store, userID)
+		if err == nil {
+			trayApp.SetUnreadCount(int(unreadCount))
+		}
+	}
+
+	// Update immediately on startup
+	updateTrayStatus()
+
+	// Then update periodically
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			updateTrayStatus()
+		}
+	}
+}
@@ -26,7 +26,28 @@ package backend
 //go:generate mockgen -source=internal/core/syncstate/service.go -destination=internal/core/syncstate/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/core/auth/service.go -destination=internal/core/auth/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/core/repository/service.go -destination=internal/core/repository/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/authorprofile/service.go -destination=internal/core/authorprofile/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/changelog/service.go -destination=internal/core/changelog/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/savedreply/service.go -destination=internal/core/savedreply/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/core/pullrequest/service.go -destination=internal/core/pullrequest/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/quicklook/service.go -destination=internal/core/quicklook/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/workhours/service.go -destination=internal/core/workhours/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/team/service.go -destination=internal/core/team/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/triage/service.go -destination=internal/core/triage/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/alert/service.go -destination=internal/core/alert/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/sysnotify/service.go -destination=internal/core/sysnotify/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/hidden/service.go -destination=internal/core/hidden/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/offboarding/service.go -destination=internal/core/offboarding/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/livequery/service.go -destination=internal/core/livequery/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/events/service.go -destination=internal/core/events/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/webhook/service.go -destination=internal/core/webhook/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/snooze/service.go -destination=internal/core/snooze/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/history/service.go -destination=internal/core/history/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/undo/service.go -destination=internal/core/undo/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/backup/service.go -destination=internal/core/backup/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/analytics/service.go -destination=internal/core/analytics/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/apitoken/service.go -destination=internal/core/apitoken/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/viewshare/service.go -destination=internal/core/viewshare/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/jobs/scheduler.go -destination=internal/jobs/mocks/mock_scheduler.go -package=mocks
 //go:generate mockgen -source=internal/jobs/handlers/rule_matcher.go -destination=internal/jobs/mocks/mock_rule_matcher.go -package=mocks
 //go:generate mockgen -destination=internal/sync/mocks/mock_sync.go -package=syncmocks github.com/octobud-hq/octobud/backend/internal/sync SyncOperations
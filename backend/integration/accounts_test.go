@@ -0,0 +1,103 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestLinkedAccounts_AccountFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		// Notifications synced without an account belong to the primary account
+		personal := fixtures.NewNotification(repo.ID).
+			WithGithubID("personal-notif").
+			Build(t, ctx, ts.Store, userID)
+		work := fixtures.NewNotification(repo.ID).
+			WithGithubID("work-notif").
+			WithAccount("octocat-work").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "account:testuser", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, personal.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].Account)
+		require.Equal(t, "testuser", *result.Notifications[0].Account)
+
+		// Logins match case-insensitively
+		result = c.ListNotifications(t, "account:OCTOCAT-WORK", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, work.GithubID, result.Notifications[0].GithubID)
+
+		result = c.ListNotifications(t, "-account:octocat-work", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, personal.GithubID, result.Notifications[0].GithubID)
+	})
+}
+
+func TestLinkedAccounts_ListAndUnlink(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		require.Empty(t, c.ListLinkedAccounts(t))
+
+		_, err := ts.Store.UpsertLinkedAccount(ctx, userID, db.UpsertLinkedAccountParams{
+			GithubUserID: "5151",
+			Login:        "octocat-work",
+		})
+		require.NoError(t, err)
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("personal-notif").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("work-notif").
+			WithAccount("octocat-work").
+			Build(t, ctx, ts.Store, userID)
+
+		accounts := c.ListLinkedAccounts(t)
+		require.Len(t, accounts, 1)
+		require.Equal(t, "octocat-work", accounts[0].Login)
+		require.Nil(t, accounts[0].LastSyncedAt)
+
+		// Unlinking removes the account and the notifications synced for it
+		require.Equal(t, http.StatusNoContent, c.UnlinkAccount(t, "Octocat-Work"))
+		require.Empty(t, c.ListLinkedAccounts(t))
+
+		result := c.ListNotifications(t, "", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, "personal-notif", result.Notifications[0].GithubID)
+
+		require.Equal(t, http.StatusNotFound, c.UnlinkAccount(t, "octocat-work"))
+	})
+}
@@ -0,0 +1,135 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestAlerts_RulesThenViewsThenDefault(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		alerts := alert.NewService(ts.Store, time.Now)
+
+		view, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		})
+		require.NoError(t, err)
+		rule := createRule(t, ts, "Mentions ring", "reason:mention", "", models.RuleActions{
+			Alert: &models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "bell"},
+		})
+
+		saved, status := c.UpdateAlertSettings(t, client.AlertSettings{
+			Default: client.AlertConfig{Level: models.AlertLevelBadge},
+			Views: map[string]client.AlertConfig{
+				view.ID: {Level: models.AlertLevelDesktop, Sound: "chime"},
+			},
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, models.AlertLevelBadge, saved.Default.Level)
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		record := func(githubID, reason string, archived bool) {
+			t.Helper()
+			notification := fixtures.NewNotification(repo.ID).
+				WithGithubID(githubID).
+				WithReason(reason).
+				WithArchived(archived).
+				Build(t, ctx, ts.Store, userID)
+			_, err := handlers.RecordAlertWithDB(ctx, ts.Store, alerts, userID, notification.ID)
+			require.NoError(t, err)
+		}
+		record("mention", "mention", false)
+		record("review", "review_requested", false)
+		record("subscribed", "subscribed", false)
+		record("archived", "subscribed", true)
+
+		// Do-not-disturb turns desktop alerts into badges
+		_, err = ts.Store.UpdateUserMutedUntil(ctx, sql.NullTime{
+			Time:  time.Now().Add(time.Hour),
+			Valid: true,
+		})
+		require.NoError(t, err)
+		record("mention-dnd", "mention", false)
+
+		got := c.ListAlerts(t, 0)
+		require.Len(t, got, 5)
+
+		require.Equal(t, "mention", got[0].GithubID)
+		require.Equal(t, models.AlertLevelDesktop, got[0].Level)
+		require.Equal(t, "bell", got[0].Sound)
+		require.Equal(t, alert.SourceRule, got[0].Source)
+		require.Equal(t, rule.ID, got[0].SourceID)
+
+		require.Equal(t, models.AlertLevelDesktop, got[1].Level)
+		require.Equal(t, "chime", got[1].Sound)
+		require.Equal(t, alert.SourceView, got[1].Source)
+		require.Equal(t, view.ID, got[1].SourceID)
+
+		require.Equal(t, models.AlertLevelBadge, got[2].Level)
+		require.Equal(t, alert.SourceDefault, got[2].Source)
+
+		// Archived notifications never reached the inbox
+		require.Equal(t, models.AlertLevelNone, got[3].Level)
+
+		require.Equal(t, models.AlertLevelBadge, got[4].Level)
+		require.Empty(t, got[4].Sound)
+		require.Equal(t, models.AlertSuppressedDND, got[4].Suppressed)
+
+		// The cursor only returns newer alerts
+		require.Len(t, c.ListAlerts(t, got[3].ID), 1)
+	})
+}
+
+func TestAlerts_RejectsInvalidSettings(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		_, status := c.UpdateAlertSettings(t, client.AlertSettings{
+			Default: client.AlertConfig{Level: "loud"},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+
+		_, status = c.UpdateAlertSettings(t, client.AlertSettings{
+			Default: client.AlertConfig{Level: models.AlertLevelDesktop},
+			Views:   map[string]client.AlertConfig{"missing-view": {Level: models.AlertLevelBadge}},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+
+		_, status = c.UpdateAlertSettings(t, client.AlertSettings{
+			Default:    client.AlertConfig{Level: models.AlertLevelDesktop},
+			QuietHours: client.QuietHours{Enabled: true, Start: "22:00", End: "22:00"},
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
@@ -0,0 +1,113 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestAnalytics(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		cli := fixtures.NewRepository().WithFullName("cli/cli").Build(t, ctx, ts.Store, userID)
+		docs := fixtures.NewRepository().WithFullName("cli/docs").Build(t, ctx, ts.Store, userID)
+		other := fixtures.NewRepository().WithFullName("acme/app").Build(t, ctx, ts.Store, userID)
+
+		now := time.Now().UTC()
+		updated := now.Add(-2 * time.Hour)
+		build := func(githubID string, repoID int64, reason string) {
+			fixtures.NewNotification(repoID).
+				WithGithubID(githubID).
+				WithReason(reason).
+				WithGithubUpdatedAt(updated).
+				Build(t, ctx, ts.Store, userID)
+		}
+		build("a", cli.ID, "mention")
+		build("b", cli.ID, "review_requested")
+		build("c", docs.ID, "mention")
+		build("d", other.ID, "subscribed")
+		// Outside the window
+		fixtures.NewNotification(other.ID).
+			WithGithubID("old").
+			WithReason("mention").
+			WithGithubUpdatedAt(now.AddDate(0, 0, -30)).
+			Build(t, ctx, ts.Store, userID)
+
+		record := func(githubIDs []string, action, source string, at time.Time) {
+			t.Helper()
+			require.NoError(t, ts.Store.RecordNotificationEvents(ctx, userID, db.RecordNotificationEventsParams{
+				GithubIDs: githubIDs,
+				Action:    action,
+				Source:    source,
+				CreatedAt: at,
+			}))
+		}
+		record([]string{"a", "b", "c", "d"}, "synced", "sync", updated)
+		// Read an hour and three hours after the update; the second read of "a" doesn't count
+		record([]string{"a"}, "read", "user", updated.Add(time.Hour))
+		record([]string{"b"}, "read", "user", updated.Add(3*time.Hour))
+		record([]string{"a"}, "read", "user", updated.Add(4*time.Hour))
+		// Rules reading notifications aren't the user reading them
+		record([]string{"c"}, "read", "rule", updated.Add(time.Minute))
+		c.ArchiveNotification(t, "d")
+
+		activity := c.GetAnalyticsActivity(t, 7)
+		require.Equal(t, 7, activity.Days)
+		require.Len(t, activity.Daily, 7)
+		var received, read, archived int64
+		for _, day := range activity.Daily {
+			received += day.Received
+			read += day.Read
+			archived += day.Archived
+		}
+		require.Equal(t, int64(4), received)
+		require.Equal(t, int64(4), read)
+		require.Equal(t, int64(1), archived)
+		require.Equal(t, now.Format(time.DateOnly), activity.Daily[6].Date)
+		require.Equal(t, int64(2), activity.ReadMeasured)
+		require.Equal(t, int64(2*time.Hour/time.Second), activity.MeanTimeToReadSeconds)
+
+		byRepo := c.GetAnalyticsBreakdown(t, 7, "repo")
+		require.Equal(t, int64(4), byRepo.Total)
+		require.Equal(t, []client.VolumeEntry{
+			{Key: "cli/cli", Total: 2, Unread: 2},
+			{Key: "acme/app", Total: 1, Unread: 1},
+			{Key: "cli/docs", Total: 1, Unread: 1},
+		}, byRepo.Entries)
+
+		byOrg := c.GetAnalyticsBreakdown(t, 7, "org")
+		require.Equal(t, []client.VolumeEntry{
+			{Key: "cli", Total: 3, Unread: 3},
+			{Key: "acme", Total: 1, Unread: 1},
+		}, byOrg.Entries)
+
+		byReason := c.GetAnalyticsBreakdown(t, 7, "reason")
+		require.Equal(t, "mention", byReason.Entries[0].Key)
+		require.Equal(t, int64(2), byReason.Entries[0].Total)
+	})
+}
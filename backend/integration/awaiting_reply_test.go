@@ -0,0 +1,109 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func TestAwaitingReply(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		stale := time.Now().UTC().AddDate(0, 0, -5)
+		fresh := time.Now().UTC().Add(-time.Hour)
+
+		unanswered := fixtures.NewNotification(repo.ID).
+			WithOwnLatestComment("Any update on this?", stale).
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+		muted := fixtures.NewNotification(repo.ID).
+			WithOwnLatestComment("Friendly ping", stale).
+			WithMuted(true).
+			Build(t, ctx, ts.Store, userID)
+		recent := fixtures.NewNotification(repo.ID).
+			WithOwnLatestComment("Pushed a fix", fresh).
+			Build(t, ctx, ts.Store, userID)
+		replied := fixtures.NewNotification(repo.ID).
+			WithLatestComment("Thanks, merging").
+			Build(t, ctx, ts.Store, userID)
+
+		handler := handlers.NewClassifyAwaitingRepliesHandler(ts.Store, zap.NewNop())
+
+		// Off until the user picks a window
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, result.Skipped)
+
+		require.Equal(t, http.StatusOK, c.SetAwaitingReplySettings(t, 3, true))
+		before := time.Now().UTC().Add(-time.Second)
+		result, err = handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{unanswered.GithubID, muted.GithubID}, result.Classified)
+
+		githubIDs := func(query string) []string {
+			list := c.ListNotifications(t, query, 0, 0)
+			ids := make([]string, 0, len(list.Notifications))
+			for _, n := range list.Notifications {
+				ids = append(ids, n.GithubID)
+			}
+			return ids
+		}
+		require.ElementsMatch(
+			t,
+			[]string{unanswered.GithubID, muted.GithubID},
+			githubIDs("in:anywhere awaiting:reply"),
+		)
+		require.ElementsMatch(
+			t,
+			[]string{recent.GithubID, replied.GithubID},
+			githubIDs("in:anywhere -awaiting:reply"),
+		)
+
+		// Resurfacing brings the archived thread back, but leaves the muted one alone
+		n := c.GetNotification(t, unanswered.GithubID).Notification
+		require.False(t, n.Archived)
+		require.False(t, n.EffectiveSortDate.Before(before))
+		require.True(t, c.GetNotification(t, muted.GithubID).Notification.Muted)
+
+		// A reply from someone else clears the classification
+		fixtures.NewNotification(repo.ID).
+			WithGithubID(unanswered.GithubID).
+			WithLatestComment("Sorry, on it now").
+			Build(t, ctx, ts.Store, userID)
+		require.ElementsMatch(t, []string{muted.GithubID}, githubIDs("in:anywhere awaiting:reply"))
+
+		// Classified threads are not reported again on the next run
+		result, err = handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, result.Classified)
+	})
+}
@@ -0,0 +1,86 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestBodySearch_MatchesDescriptionsAndComments(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("described").
+			WithSubjectTitle("Sync stalls").
+			WithSubjectRaw(`{"number": 1, "body": "The flaky test in sync_test.go times out"}`).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("commented").
+			WithSubjectTitle("Release checklist").
+			WithLatestComment("Blocked on a segfault in the Résumé parser").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("unrelated").
+			WithSubjectTitle("Flaky title only").
+			Build(t, ctx, ts.Store, userID)
+
+		githubIDs := func(query string) []string {
+			result := c.ListNotifications(t, query, 0, 0)
+			ids := make([]string, 0, len(result.Notifications))
+			for _, n := range result.Notifications {
+				ids = append(ids, n.GithubID)
+			}
+			return ids
+		}
+
+		require.ElementsMatch(t, []string{"described"}, githubIDs(`body:"flaky test"`))
+		require.ElementsMatch(t, []string{"commented"}, githubIDs("body:resume"))
+		require.ElementsMatch(t, []string{"described", "commented"}, githubIDs("body:flak,segf"))
+		require.Empty(t, githubIDs(`body:"test flaky"`))
+
+		// Free text searches titles as before, plus descriptions and comments
+		require.ElementsMatch(t, []string{"described", "unrelated"}, githubIDs("flaky"))
+		require.ElementsMatch(t, []string{"commented"}, githubIDs("segfault"))
+
+		// A new description replaces the indexed one
+		_, err := ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+			GithubID:     "described",
+			RepositoryID: repo.ID,
+			SubjectType:  "PullRequest",
+			SubjectTitle: "Sync stalls",
+			SubjectRaw: db.NullRawMessage{
+				RawMessage: []byte(`{"number": 1, "body": "Fixed by retrying"}`),
+				Valid:      true,
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, githubIDs(`body:"flaky test"`))
+		require.ElementsMatch(t, []string{"described"}, githubIDs("body:retrying"))
+	})
+}
@@ -0,0 +1,136 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestBulkUndo_RestoresArchiveByQuery(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("acme/undo").Build(t, ctx, ts.Store, userID)
+		snoozedUntil := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+		snoozed := fixtures.NewNotification(repo.ID).
+			WithSnoozedUntil(snoozedUntil).
+			Build(t, ctx, ts.Store, userID)
+		plain := fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, userID)
+		archived := fixtures.NewNotification(repo.ID).WithArchived(true).Build(t, ctx, ts.Store, userID)
+
+		result := c.BulkArchive(t, nil, "repo:acme/undo in:anywhere")
+		require.Equal(t, 3, result.Count)
+		require.NotZero(t, result.UndoID)
+
+		// Changes made since are kept
+		c.MuteNotification(t, plain.GithubID)
+
+		undone, status := c.BulkUndo(t, result.UndoID)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "archive", undone.Operation)
+		require.Equal(t, 3, undone.Count)
+
+		restored := c.GetNotification(t, snoozed.GithubID).Notification
+		require.False(t, restored.Archived)
+		require.NotNil(t, restored.SnoozedUntil)
+		require.WithinDuration(t, snoozedUntil, *restored.SnoozedUntil, time.Second)
+		require.WithinDuration(t, snoozedUntil, restored.EffectiveSortDate, time.Second)
+
+		restored = c.GetNotification(t, plain.GithubID).Notification
+		require.False(t, restored.Archived)
+		require.True(t, restored.Muted)
+
+		// It was archived before, so it stays archived
+		require.True(t, c.GetNotification(t, archived.GithubID).Notification.Archived)
+
+		events, _ := c.GetNotificationHistory(t, plain.GithubID)
+		require.Equal(t, "undone", events[0].Action)
+		require.Equal(t, "archive", events[0].Detail)
+
+		_, status = c.BulkUndo(t, result.UndoID)
+		require.Equal(t, http.StatusConflict, status)
+	})
+}
+
+func TestBulkUndo_RestoresSnoozeAndRejectsUnknownIDs(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		read := fixtures.NewNotification(repo.ID).WithIsRead(true).Build(t, ctx, ts.Store, userID)
+		unread := fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, userID)
+
+		result := c.BulkSnooze(t, client.BulkSnoozeRequest{
+			GithubIDs:    []string{read.GithubID, unread.GithubID},
+			SnoozedUntil: time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		})
+		require.NotZero(t, result.UndoID)
+		_, status := c.BulkUndo(t, result.UndoID)
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, c.GetNotification(t, read.GithubID).Notification.SnoozedUntil)
+		require.Nil(t, c.GetNotification(t, unread.GithubID).Notification.SnoozedUntil)
+
+		_, status = c.BulkUndo(t, result.UndoID+1000)
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+// seedNotifications inserts count notifications into a repository in one statement, far
+// faster than building them one by one
+func seedNotifications(t *testing.T, ts *testserver.TestServer, repoID int64, count int) {
+	t.Helper()
+	_, err := ts.DB.ExecContext(context.Background(), `
+		WITH RECURSIVE seq(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM seq WHERE i < ?)
+		INSERT INTO notifications (user_id, github_id, repository_id, subject_type, subject_title)
+		SELECT ?, 'seeded-' || i, ?, 'Issue', 'Seeded ' || i FROM seq`,
+		count, ts.UserID, repoID,
+	)
+	require.NoError(t, err)
+}
+
+func TestBulkUndo_CapturesMoreNotificationsThanSQLiteCanBind(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+
+		// More than SQLite's limit of 32766 bound variables per statement
+		const count = 33000
+		repo := fixtures.NewRepository().WithFullName("acme/large").Build(t, ctx, ts.Store, ts.UserID)
+		seedNotifications(t, ts, repo.ID, count)
+
+		result := c.BulkArchive(t, nil, "repo:acme/large")
+		require.Equal(t, count, result.Count)
+		require.NotZero(t, result.UndoID)
+
+		undone, status := c.BulkUndo(t, result.UndoID)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, count, undone.Count)
+		require.False(t, c.GetNotification(t, "seeded-1").Notification.Archived)
+		require.False(t, c.GetNotification(t, "seeded-33000").Notification.Archived)
+	})
+}
@@ -0,0 +1,61 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/changelog"
+)
+
+func TestChangelog_RecordAndAcknowledge(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		svc := changelog.NewService(ts.Store, time.Now)
+
+		// A fresh install has nothing to announce
+		require.Empty(t, c.ListUnseenChangelog(t))
+
+		// Upgrading from schema version 3 announces later changes, once
+		recorded, err := svc.Record(ctx, 3, 5)
+		require.NoError(t, err)
+		require.Positive(t, recorded)
+		_, err = svc.Record(ctx, 3, 5)
+		require.NoError(t, err)
+
+		entries := c.ListUnseenChangelog(t)
+		require.Len(t, entries, recorded)
+		for _, entry := range entries {
+			require.Greater(t, entry.SchemaVersion, int64(3))
+			require.LessOrEqual(t, entry.SchemaVersion, int64(5))
+		}
+
+		// Acknowledging up to the first entry leaves the rest unseen
+		require.Equal(t, int64(1), c.AcknowledgeChangelog(t, entries[0].ID))
+		require.Len(t, c.ListUnseenChangelog(t), recorded-1)
+
+		c.AcknowledgeChangelog(t, 0)
+		require.Empty(t, c.ListUnseenChangelog(t))
+	})
+}
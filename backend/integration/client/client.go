@@ -19,6 +19,7 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -47,22 +49,53 @@ func New(baseURL string) *Client {
 
 // Notification represents a notification in API responses.
 type Notification struct {
-	ID                int64      `json:"id"`
-	GithubID          string     `json:"githubId"`
-	RepositoryID      int64      `json:"repositoryId"`
-	SubjectType       string     `json:"subjectType"`
-	SubjectTitle      string     `json:"subjectTitle"`
-	Reason            *string    `json:"reason,omitempty"`
-	Archived          bool       `json:"archived"`
-	IsRead            bool       `json:"isRead"`
-	Muted             bool       `json:"muted"`
-	Starred           bool       `json:"starred"`
-	Filtered          bool       `json:"filtered"`
-	SnoozedUntil      *time.Time `json:"snoozedUntil,omitempty"`
-	SnoozedAt         *time.Time `json:"snoozedAt,omitempty"`
-	EffectiveSortDate time.Time  `json:"effectiveSortDate"`
-	GithubUpdatedAt   *time.Time `json:"githubUpdatedAt,omitempty"`
-	ImportedAt        time.Time  `json:"importedAt"`
+	ID                   int64      `json:"id"`
+	GithubID             string     `json:"githubId"`
+	RepositoryID         int64      `json:"repositoryId"`
+	SubjectType          string     `json:"subjectType"`
+	ContentKind          string     `json:"contentKind"`
+	HeadBranch           *string    `json:"headBranch,omitempty"`
+	BaseBranch           *string    `json:"baseBranch,omitempty"`
+	SubjectDraft         *bool      `json:"subjectDraft,omitempty"`
+	Labels               []string   `json:"labels,omitempty"`
+	ReviewState          *string    `json:"reviewState,omitempty"`
+	Milestone            *string    `json:"milestone,omitempty"`
+	Projects             []string   `json:"projects,omitempty"`
+	CIState              *string    `json:"ciState,omitempty"`
+	Mergeable            *bool      `json:"mergeable,omitempty"`
+	WaitingOn            *string    `json:"waitingOn,omitempty"`
+	Priority             *int64     `json:"priority,omitempty"`
+	Account              *string    `json:"account,omitempty"`
+	SubjectTitle         string     `json:"subjectTitle"`
+	Reason               *string    `json:"reason,omitempty"`
+	Archived             bool       `json:"archived"`
+	IsRead               bool       `json:"isRead"`
+	Muted                bool       `json:"muted"`
+	Starred              bool       `json:"starred"`
+	Filtered             bool       `json:"filtered"`
+	SnoozedUntil         *time.Time `json:"snoozedUntil,omitempty"`
+	SnoozedAt            *time.Time `json:"snoozedAt,omitempty"`
+	SnoozeUntilUpdated   bool       `json:"snoozeUntilUpdated,omitempty"`
+	ReturnedFromSnoozeAt *time.Time `json:"returnedFromSnoozeAt,omitempty"`
+	EffectiveSortDate    time.Time  `json:"effectiveSortDate"`
+	GithubUpdatedAt      *time.Time `json:"githubUpdatedAt,omitempty"`
+	ImportedAt           time.Time  `json:"importedAt"`
+	Tags                 []Tag      `json:"tags,omitempty"`
+	// Conversation is only set when the list collapses notifications by subject
+	Conversation *Conversation `json:"conversation,omitempty"`
+}
+
+// Conversation summarizes the notifications sharing a subject.
+type Conversation struct {
+	Count        int64   `json:"count"`
+	NewestReason *string `json:"newestReason,omitempty"`
+}
+
+// Tag is a tag assigned to a notification.
+type Tag struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	AssignedAt *time.Time `json:"assignedAt,omitempty"`
 }
 
 // ListNotificationsResponse represents the response from listing notifications.
@@ -71,6 +104,8 @@ type ListNotificationsResponse struct {
 	Total         int64          `json:"total"`
 	Page          int            `json:"page"`
 	PageSize      int            `json:"pageSize"`
+	Defaults      *QueryDefaults `json:"defaults"`
+	NextCursor    string         `json:"nextCursor"`
 }
 
 // NotificationResponse represents a single notification response.
@@ -80,7 +115,24 @@ type NotificationResponse struct {
 
 // BulkResponse represents the response from bulk operations.
 type BulkResponse struct {
-	Count int `json:"count"`
+	Count  int   `json:"count"`
+	UndoID int64 `json:"undoId"`
+}
+
+// BulkUndoResponse is the response from undoing a bulk operation.
+type BulkUndoResponse struct {
+	Operation string   `json:"operation"`
+	Count     int      `json:"count"`
+	GithubIDs []string `json:"githubIDs"`
+}
+
+// ChangelogEntry is a changelog entry returned by the API.
+type ChangelogEntry struct {
+	ID            int64  `json:"id"`
+	SchemaVersion int64  `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
 }
 
 // ListNotifications retrieves a list of notifications.
@@ -122,6 +174,100 @@ func (c *Client) ListNotifications(t *testing.T, query string, page, pageSize in
 	return &result
 }
 
+// ListNotificationsAfter lists a page of notifications starting after a cursor from a
+// previous page, or the first page with an empty cursor. It returns the status code, and
+// the response when it's 200.
+func (c *Client) ListNotificationsAfter(
+	t *testing.T,
+	query, cursor string,
+	pageSize int,
+) (*ListNotificationsResponse, int) {
+	t.Helper()
+	return c.ListNotificationsSorted(t, query, "", cursor, pageSize)
+}
+
+// ListNotificationsSorted lists a page of notifications in the given sort order, such as
+// "priority", starting after a cursor from a previous page. It returns the status code,
+// and the response when it's 200.
+func (c *Client) ListNotificationsSorted(
+	t *testing.T,
+	query, sort, cursor string,
+	pageSize int,
+) (*ListNotificationsResponse, int) {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	if sort != "" {
+		params.Set("sort", sort)
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("ListNotificationsSorted request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result ListNotificationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListNotificationsSorted response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// NotificationGroup is one group of a grouped notification list.
+type NotificationGroup struct {
+	Key           string         `json:"key"`
+	Count         int64          `json:"count"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// GroupNotificationsResponse represents the grouped notification list response.
+type GroupNotificationsResponse struct {
+	GroupBy string              `json:"groupBy"`
+	Total   int64               `json:"total"`
+	Groups  []NotificationGroup `json:"groups"`
+}
+
+// GroupNotifications groups the notifications matching a query and returns the response
+// along with the status code.
+func (c *Client) GroupNotifications(
+	t *testing.T,
+	query, groupBy string,
+	perGroup int,
+) (*GroupNotificationsResponse, int) {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("groupBy", groupBy)
+	params.Set("perGroup", strconv.Itoa(perGroup))
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications/groups?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("GroupNotifications request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result GroupNotificationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GroupNotifications response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
 // GetNotification retrieves a single notification by GitHub ID.
 func (c *Client) GetNotification(t *testing.T, githubID string) *NotificationResponse {
 	t.Helper()
@@ -172,6 +318,40 @@ func (c *Client) SnoozeNotification(t *testing.T, githubID string, until time.Ti
 	return &result
 }
 
+// SnoozeRequest snoozes until a time or a preset, optionally ending early once the
+// thread is updated.
+type SnoozeRequest struct {
+	SnoozedUntil string `json:"snoozedUntil,omitempty"`
+	Preset       string `json:"preset,omitempty"`
+	UntilUpdated bool   `json:"untilUpdated,omitempty"`
+}
+
+// SnoozeNotificationWith snoozes a notification with the given request and returns the
+// status code.
+func (c *Client) SnoozeNotificationWith(
+	t *testing.T,
+	githubID string,
+	req SnoozeRequest,
+) (*NotificationResponse, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/notifications/"+url.PathEscape(githubID)+"/snooze", req)
+	if err != nil {
+		t.Fatalf("SnoozeNotificationWith request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result NotificationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode SnoozeNotificationWith response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
 // UnsnoozeNotification unsnoozes a notification.
 func (c *Client) UnsnoozeNotification(t *testing.T, githubID string) *NotificationResponse {
 	t.Helper()
@@ -335,6 +515,36 @@ func (c *Client) BulkArchive(t *testing.T, githubIDs []string, query string) *Bu
 	return &result
 }
 
+// MarkGroupReadResponse represents the response from marking a thread group read.
+type MarkGroupReadResponse struct {
+	Count     int      `json:"count"`
+	GithubIDs []string `json:"githubIDs"`
+}
+
+// MarkGroupRead marks every unread notification sharing a subject URL as read.
+func (c *Client) MarkGroupRead(t *testing.T, subjectURL string) *MarkGroupReadResponse {
+	t.Helper()
+
+	body := map[string]string{"subjectUrl": subjectURL}
+	resp, err := c.doRequest(t, "POST", "/api/notifications/bulk/mark-group-read", body)
+	if err != nil {
+		t.Fatalf("MarkGroupRead request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		t.Fatalf("MarkGroupRead failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result MarkGroupReadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode MarkGroupRead response: %v", err)
+	}
+
+	return &result
+}
+
 // BulkUnsnooze unsnoozes multiple notifications.
 func (c *Client) BulkUnsnooze(t *testing.T, githubIDs []string, query string) *BulkResponse {
 	t.Helper()
@@ -366,27 +576,1936 @@ func (c *Client) BulkUnsnooze(t *testing.T, githubIDs []string, query string) *B
 	return &result
 }
 
-// doRequest performs an HTTP request.
-// No authentication needed - trusts localhost.
-func (c *Client) doRequest(t *testing.T, method, path string, body interface{}) (*http.Response, error) {
+// BulkUndo undoes a bulk operation and returns the result with the status code.
+func (c *Client) BulkUndo(t *testing.T, undoID int64) (*BulkUndoResponse, int) {
 	t.Helper()
 
-	var bodyReader io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	resp, err := c.doRequest(t, "POST", fmt.Sprintf("/api/notifications/bulk/undo/%d", undoID), nil)
+	if err != nil {
+		t.Fatalf("BulkUndo request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result BulkUndoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode BulkUndo response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// ListUnseenChangelog lists changelog entries that haven't been acknowledged.
+func (c *Client) ListUnseenChangelog(t *testing.T) []ChangelogEntry {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/changelog/unseen", nil)
+	if err != nil {
+		t.Fatalf("ListUnseenChangelog request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListUnseenChangelog failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Entries []ChangelogEntry `json:"entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListUnseenChangelog response: %v", err)
+	}
+
+	return result.Entries
+}
+
+// AcknowledgeChangelog acknowledges changelog entries up to and including upToID.
+func (c *Client) AcknowledgeChangelog(t *testing.T, upToID int64) int64 {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/changelog/acknowledge", map[string]int64{"upToId": upToID})
+	if err != nil {
+		t.Fatalf("AcknowledgeChangelog request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("AcknowledgeChangelog failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Acknowledged int64 `json:"acknowledged"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode AcknowledgeChangelog response: %v", err)
+	}
+
+	return result.Acknowledged
+}
+
+// RepositoryDigest summarizes a repository's unread notifications.
+type RepositoryDigest struct {
+	UnreadCount            int64            `json:"unreadCount"`
+	ReasonCounts           map[string]int64 `json:"reasonCounts"`
+	OldestUnreadAt         *time.Time       `json:"oldestUnreadAt,omitempty"`
+	OldestUnreadAgeSeconds int64            `json:"oldestUnreadAgeSeconds"`
+	RecentUnread           []struct {
+		GithubID  string    `json:"githubId"`
+		Title     string    `json:"title"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	} `json:"recentUnread"`
+}
+
+// Repository represents a repository in API responses.
+type Repository struct {
+	ID       int64             `json:"id"`
+	FullName string            `json:"fullName"`
+	Digest   *RepositoryDigest `json:"digest,omitempty"`
+}
+
+// ListRepositories retrieves all repositories with their unread digests.
+func (c *Client) ListRepositories(t *testing.T) []Repository {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/repositories", nil)
+	if err != nil {
+		t.Fatalf("ListRepositories request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListRepositories failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Repositories []Repository `json:"repositories"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListRepositories response: %v", err)
+	}
+
+	return result.Repositories
+}
+
+// GetRepository retrieves a single repository with its unread digest.
+func (c *Client) GetRepository(t *testing.T, id int64) *Repository {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/repositories/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		t.Fatalf("GetRepository request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GetRepository failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Repository Repository `json:"repository"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetRepository response: %v", err)
+	}
+
+	return &result.Repository
+}
+
+// DependentRule identifies a rule that references a deleted tag or view.
+type DependentRule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// DeleteResult is the outcome of deleting a tag or view that rules may depend on.
+type DeleteResult struct {
+	StatusCode      int
+	LinkedRuleCount int             `json:"linkedRuleCount"`
+	DependentRules  []DependentRule `json:"dependentRules"`
+	DisabledRules   []DependentRule `json:"disabledRules"`
+}
+
+// DeleteTag deletes a tag. A 409 listing dependent rules is returned, not treated as failure.
+func (c *Client) DeleteTag(t *testing.T, id string, force bool) *DeleteResult {
+	t.Helper()
+	return c.deleteWithDependents(t, "DeleteTag", "/api/tags/"+url.PathEscape(id), force)
+}
+
+// DeleteView deletes a view. A 409 listing dependent rules is returned, not treated as failure.
+func (c *Client) DeleteView(t *testing.T, id string, force bool) *DeleteResult {
+	t.Helper()
+	return c.deleteWithDependents(t, "DeleteView", "/api/views/"+url.PathEscape(id), force)
+}
+
+func (c *Client) deleteWithDependents(t *testing.T, name, path string, force bool) *DeleteResult {
+	t.Helper()
+
+	if force {
+		path += "?force=true"
+	}
+	resp, err := c.doRequest(t, "DELETE", path, nil)
+	if err != nil {
+		t.Fatalf("%s request failed: %v", name, err)
+	}
+	defer resp.Body.Close()
+
+	result := &DeleteResult{StatusCode: resp.StatusCode}
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return result
+	case http.StatusOK, http.StatusConflict:
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatalf("Failed to decode %s response: %v", name, err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
+		return result
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s failed with status %d: %s", name, resp.StatusCode, string(body))
+		return nil
 	}
+}
 
-	fullURL := c.BaseURL + path
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+// WorkingHours represents the user's working hours in API requests and responses.
+type WorkingHours struct {
+	Enabled  bool     `json:"enabled"`
+	Days     []int    `json:"days"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone,omitempty"`
+	Holidays []string `json:"holidays"`
+}
+
+// UpdateWorkingHours saves the user's working hours.
+func (c *Client) UpdateWorkingHours(t *testing.T, hours WorkingHours) *WorkingHours {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/working-hours", hours)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		t.Fatalf("UpdateWorkingHours request failed: %v", err)
 	}
+	defer resp.Body.Close()
 
-	req.Header.Set("Content-Type", "application/json")
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("UpdateWorkingHours failed with status %d: %s", resp.StatusCode, string(body))
+	}
 
-	return c.HTTPClient.Do(req)
+	var result WorkingHours
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateWorkingHours response: %v", err)
+	}
+
+	return &result
+}
+
+// NextBusinessDay retrieves the start of the first business day after from.
+func (c *Client) NextBusinessDay(t *testing.T, from time.Time) time.Time {
+	t.Helper()
+
+	path := "/api/user/working-hours/next-business-day?from=" +
+		url.QueryEscape(from.Format(time.RFC3339))
+	resp, err := c.doRequest(t, "GET", path, nil)
+	if err != nil {
+		t.Fatalf("NextBusinessDay request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("NextBusinessDay failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		At time.Time `json:"at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode NextBusinessDay response: %v", err)
+	}
+
+	return result.At
+}
+
+// StorageCapabilities reports which features the storage settings leave available.
+type StorageCapabilities struct {
+	SubjectDetails    bool `json:"subjectDetails"`
+	DescriptionSearch bool `json:"descriptionSearch"`
+	CommentSearch     bool `json:"commentSearch"`
+	AwaitingReply     bool `json:"awaitingReply"`
+	TimelineWarming   bool `json:"timelineWarming"`
+}
+
+// SyncSettings represents the user's sync settings in API responses.
+type SyncSettings struct {
+	SetupCompleted     bool                `json:"setupCompleted"`
+	SkipPayloadStorage bool                `json:"skipPayloadStorage"`
+	SkipCommentCaching bool                `json:"skipCommentCaching"`
+	TitlesOnly         bool                `json:"titlesOnly"`
+	Capabilities       StorageCapabilities `json:"capabilities"`
+}
+
+// GetSyncSettings retrieves the user's sync settings.
+func (c *Client) GetSyncSettings(t *testing.T) *SyncSettings {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/user/sync-settings", nil)
+	if err != nil {
+		t.Fatalf("GetSyncSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GetSyncSettings failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result SyncSettings
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetSyncSettings response: %v", err)
+	}
+
+	return &result
+}
+
+// ParsedQuery is the response from parsing a query.
+type ParsedQuery struct {
+	Query     string          `json:"query"`
+	Canonical string          `json:"canonical"`
+	AST       json.RawMessage `json:"ast"`
+	Defaults  QueryDefaults   `json:"defaults"`
+}
+
+// QueryDefaults describes the implicit filters applied to a notification query.
+type QueryDefaults struct {
+	Scope    string   `json:"scope"`
+	Excluded []string `json:"excluded"`
+	Reason   string   `json:"reason"`
+}
+
+// ParseQuery parses a query and returns its canonical form and AST, along with the status code.
+func (c *Client) ParseQuery(t *testing.T, query string) (*ParsedQuery, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/query/parse", map[string]string{"query": query})
+	if err != nil {
+		t.Fatalf("ParseQuery request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result ParsedQuery
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ParseQuery response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// QuerySuggestion is a completion candidate for a partly typed query.
+type QuerySuggestion struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// QuerySuggestions are the completions for the text at a cursor in a query.
+type QuerySuggestions struct {
+	Field       string            `json:"field"`
+	Prefix      string            `json:"prefix"`
+	Start       int               `json:"start"`
+	End         int               `json:"end"`
+	Suggestions []QuerySuggestion `json:"suggestions"`
+}
+
+// SuggestQuery returns completions for the text at cursor, a byte offset into query.
+func (c *Client) SuggestQuery(t *testing.T, query string, cursor int) *QuerySuggestions {
+	t.Helper()
+
+	body := map[string]any{"query": query, "cursor": cursor}
+	resp, err := c.doRequest(t, "POST", "/api/query/suggest", body)
+	if err != nil {
+		t.Fatalf("SuggestQuery request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		t.Fatalf("SuggestQuery failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result QuerySuggestions
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode SuggestQuery response: %v", err)
+	}
+	return &result
+}
+
+// ExplainQueryDefaults returns the implicit filters the notifications list applies to a query.
+func (c *Client) ExplainQueryDefaults(t *testing.T, query string) *QueryDefaults {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("explainDefaults", "true")
+	resp, err := c.doRequest(t, "GET", "/api/notifications?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("ExplainQueryDefaults request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ExplainQueryDefaults failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Defaults *QueryDefaults `json:"defaults"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ExplainQueryDefaults response: %v", err)
+	}
+	return result.Defaults
+}
+
+// UpdateTeamSettings saves the team members whose review load is reported.
+func (c *Client) UpdateTeamSettings(t *testing.T, members []string) []string {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/team-settings", map[string][]string{
+		"members": members,
+	})
+	if err != nil {
+		t.Fatalf("UpdateTeamSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("UpdateTeamSettings failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Members []string `json:"members"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateTeamSettings response: %v", err)
+	}
+	return result.Members
+}
+
+// ReviewerLoad is one reviewer's entry in a review load report.
+type ReviewerLoad struct {
+	Login      string `json:"login"`
+	Requests   int    `json:"requests"`
+	Open       int    `json:"open"`
+	Unread     int    `json:"unread"`
+	Overloaded bool   `json:"overloaded"`
+}
+
+// ReviewLoadReport aggregates review requests by requested reviewer.
+type ReviewLoadReport struct {
+	Days          int            `json:"days"`
+	Reviewers     []ReviewerLoad `json:"reviewers"`
+	Average       float64        `json:"average"`
+	TotalRequests int            `json:"totalRequests"`
+	Unassigned    int            `json:"unassigned"`
+}
+
+// GetReviewLoad retrieves the review load report for the last days.
+func (c *Client) GetReviewLoad(t *testing.T, days int) *ReviewLoadReport {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/team/review-load?days="+strconv.Itoa(days), nil)
+	if err != nil {
+		t.Fatalf("GetReviewLoad request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GetReviewLoad failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result ReviewLoadReport
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetReviewLoad response: %v", err)
+	}
+	return &result
+}
+
+// TriageEntry is the triage state of one notification in a triage backup.
+type TriageEntry struct {
+	GithubID     string     `json:"githubId"`
+	IsRead       bool       `json:"isRead"`
+	Archived     bool       `json:"archived"`
+	Starred      bool       `json:"starred"`
+	Muted        bool       `json:"muted"`
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+}
+
+// TriageBackup is an export of triage state.
+type TriageBackup struct {
+	Version       int           `json:"version"`
+	ExportedAt    time.Time     `json:"exportedAt"`
+	Notifications []TriageEntry `json:"notifications"`
+	Tags          []TriageTag   `json:"tags,omitempty"`
+	Views         []TriageView  `json:"views,omitempty"`
+	Rules         []TriageRule  `json:"rules,omitempty"`
+}
+
+// TriageTag is a tag in a triage backup.
+type TriageTag struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TriageView is a custom view in a triage backup.
+type TriageView struct {
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	Description   string `json:"description,omitempty"`
+	Icon          string `json:"icon,omitempty"`
+	Query         string `json:"query"`
+	WIPLimit      *int64 `json:"wipLimit,omitempty"`
+	WIPAutoSnooze bool   `json:"wipAutoSnooze,omitempty"`
+}
+
+// TriageRule is a rule in a triage backup.
+type TriageRule struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description,omitempty"`
+	Query          string         `json:"query,omitempty"`
+	ViewSlug       string         `json:"viewSlug,omitempty"`
+	Enabled        bool           `json:"enabled"`
+	Actions        map[string]any `json:"actions"`
+	Priority       int            `json:"priority,omitempty"`
+	StopProcessing bool           `json:"stopProcessing,omitempty"`
+	Condition      string         `json:"condition,omitempty"`
+}
+
+// TriageImportCounts reports how many items of one kind a triage import created and skipped.
+type TriageImportCounts struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+// TriageImportResult reports what a triage import did.
+type TriageImportResult struct {
+	Staged       int                `json:"staged"`
+	Applied      int64              `json:"applied"`
+	Pending      int64              `json:"pending"`
+	Tags         TriageImportCounts `json:"tags"`
+	Views        TriageImportCounts `json:"views"`
+	Rules        TriageImportCounts `json:"rules"`
+	ResyncQueued bool               `json:"resyncQueued"`
+}
+
+// DownloadTriageExport requests a triage backup with extra headers, such as Range and
+// If-Range, and returns the status, response headers and body.
+func (c *Client) DownloadTriageExport(t *testing.T, header http.Header) (int, http.Header, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", c.BaseURL+"/api/user/triage-export", nil)
+	if err != nil {
+		t.Fatalf("Failed to create DownloadTriageExport request: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("DownloadTriageExport request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read DownloadTriageExport response: %v", err)
+	}
+	return resp.StatusCode, resp.Header, body
+}
+
+// ExportTriage downloads a backup of the user's triage state.
+func (c *Client) ExportTriage(t *testing.T) *TriageBackup {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/user/triage-export", nil)
+	if err != nil {
+		t.Fatalf("ExportTriage request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ExportTriage failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result TriageBackup
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ExportTriage response: %v", err)
+	}
+	return &result
+}
+
+// ImportTriage restores a triage backup.
+func (c *Client) ImportTriage(t *testing.T, backup *TriageBackup, resync bool) *TriageImportResult {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/user/triage-import", map[string]interface{}{
+		"backup": backup,
+		"resync": resync,
+	})
+	if err != nil {
+		t.Fatalf("ImportTriage request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ImportTriage failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result TriageImportResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ImportTriage response: %v", err)
+	}
+	return &result
+}
+
+// AlertConfig is an alert level and sound.
+type AlertConfig struct {
+	Level string `json:"level"`
+	Sound string `json:"sound,omitempty"`
+}
+
+// QuietHours is a daily window when desktop alerts become badges.
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// AlertSettings is the user's alert policy.
+type AlertSettings struct {
+	Default                  AlertConfig            `json:"default"`
+	Views                    map[string]AlertConfig `json:"views"`
+	QuietHours               QuietHours             `json:"quietHours"`
+	QuietOutsideWorkingHours bool                   `json:"quietOutsideWorkingHours"`
+}
+
+// Alert is an alert recorded when a notification arrived.
+type Alert struct {
+	ID         int64     `json:"id"`
+	GithubID   string    `json:"githubId"`
+	Level      string    `json:"level"`
+	Sound      string    `json:"sound,omitempty"`
+	Source     string    `json:"source"`
+	SourceID   string    `json:"sourceId,omitempty"`
+	Suppressed string    `json:"suppressed,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// UpdateAlertSettings saves the alert policy and returns the status code. The saved
+// settings are returned when the update succeeds.
+func (c *Client) UpdateAlertSettings(t *testing.T, settings AlertSettings) (*AlertSettings, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/alert-settings", settings)
+	if err != nil {
+		t.Fatalf("UpdateAlertSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result AlertSettings
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateAlertSettings response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// ListAlerts lists alerts recorded after the given alert ID, oldest first.
+func (c *Client) ListAlerts(t *testing.T, after int64) []Alert {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/alerts?after="+strconv.FormatInt(after, 10), nil)
+	if err != nil {
+		t.Fatalf("ListAlerts request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListAlerts failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListAlerts response: %v", err)
+	}
+	return result.Alerts
+}
+
+// RuleTraceEntry records what happened to one rule when a notification was evaluated.
+type RuleTraceEntry struct {
+	RuleID            string `json:"ruleId"`
+	RuleName          string `json:"ruleName"`
+	Priority          int    `json:"priority"`
+	Result            string `json:"result"`
+	StoppedProcessing bool   `json:"stoppedProcessing"`
+	Error             string `json:"error"`
+}
+
+// RuleEvaluation is the latest evaluation of the rules against a notification.
+type RuleEvaluation struct {
+	GithubID     string           `json:"githubId"`
+	SubjectTitle string           `json:"subjectTitle"`
+	EvaluatedAt  time.Time        `json:"evaluatedAt"`
+	Rules        []RuleTraceEntry `json:"rules"`
+}
+
+// UpdateRuleCondition sets a rule's condition. An empty condition removes it.
+func (c *Client) UpdateRuleCondition(t *testing.T, ruleID string, condition string) int {
+	t.Helper()
+
+	body := map[string]interface{}{"condition": condition}
+	resp, err := c.doRequest(t, "PUT", "/api/rules/"+url.PathEscape(ruleID), body)
+	if err != nil {
+		t.Fatalf("UpdateRuleCondition request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// UpdateRuleOrdering sets a rule's priority and stop-processing flag.
+func (c *Client) UpdateRuleOrdering(t *testing.T, ruleID string, priority int, stopProcessing bool) int {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"priority":       priority,
+		"stopProcessing": stopProcessing,
+	}
+	resp, err := c.doRequest(t, "PUT", "/api/rules/"+url.PathEscape(ruleID), body)
+	if err != nil {
+		t.Fatalf("UpdateRuleOrdering request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// ListRuleActivity lists recent rule evaluations, optionally for one notification.
+func (c *Client) ListRuleActivity(t *testing.T, githubID string) []RuleEvaluation {
+	t.Helper()
+
+	path := "/api/rules/activity"
+	if githubID != "" {
+		path += "?notification=" + url.QueryEscape(githubID)
+	}
+	resp, err := c.doRequest(t, "GET", path, nil)
+	if err != nil {
+		t.Fatalf("ListRuleActivity request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListRuleActivity failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Evaluations []RuleEvaluation `json:"evaluations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListRuleActivity response: %v", err)
+	}
+	return result.Evaluations
+}
+
+// RuleRunResult reports the outcome of running a rule against existing notifications.
+type RuleRunResult struct {
+	Matched int64 `json:"matched"`
+	DryRun  bool  `json:"dryRun"`
+}
+
+// RunRule applies a rule to existing notifications, or only counts matches with dryRun.
+func (c *Client) RunRule(t *testing.T, ruleID string, dryRun bool) RuleRunResult {
+	t.Helper()
+
+	path := "/api/rules/" + url.PathEscape(ruleID) + "/run"
+	if dryRun {
+		path += "?dryRun=true"
+	}
+	resp, err := c.doRequest(t, "POST", path, nil)
+	if err != nil {
+		t.Fatalf("RunRule request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("RunRule failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result RuleRunResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode RunRule response: %v", err)
+	}
+	return result
+}
+
+// HiddenGroup lists the notifications hidden by one mechanism.
+type HiddenGroup struct {
+	Mechanism     string         `json:"mechanism"`
+	Query         string         `json:"query"`
+	Total         int64          `json:"total"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// HiddenSummary is everything currently hidden, grouped by mechanism.
+type HiddenSummary struct {
+	Groups            []HiddenGroup `json:"groups"`
+	AccountMutedUntil *time.Time    `json:"accountMutedUntil,omitempty"`
+}
+
+// Group returns the group for a mechanism, or nil.
+func (s *HiddenSummary) Group(mechanism string) *HiddenGroup {
+	for i := range s.Groups {
+		if s.Groups[i].Mechanism == mechanism {
+			return &s.Groups[i]
+		}
+	}
+	return nil
+}
+
+// ListHidden lists snoozed, muted and filtered notifications grouped by mechanism.
+func (c *Client) ListHidden(t *testing.T) *HiddenSummary {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/hidden", nil)
+	if err != nil {
+		t.Fatalf("ListHidden request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListHidden failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result HiddenSummary
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListHidden response: %v", err)
+	}
+	return &result
+}
+
+// WakeHidden brings back everything hidden by a mechanism and returns how much was woken.
+func (c *Client) WakeHidden(t *testing.T, mechanism string) (int64, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/hidden/"+url.PathEscape(mechanism)+"/wake", nil)
+	if err != nil {
+		t.Fatalf("WakeHidden request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Woken int64 `json:"woken"`
+	}
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("Failed to decode WakeHidden response: %v", err)
+		}
+	}
+	return result.Woken, resp.StatusCode
+}
+
+// OrgOffboarding summarizes an organization the user left.
+type OrgOffboarding struct {
+	Org                   string    `json:"org"`
+	Action                string    `json:"action"`
+	NotificationsAffected int64     `json:"notificationsAffected"`
+	Repositories          int64     `json:"repositories"`
+	OffboardedAt          time.Time `json:"offboardedAt"`
+}
+
+// OffboardOrg archives or deletes an org's notifications and stops syncing it. An empty
+// action uses the server default.
+func (c *Client) OffboardOrg(t *testing.T, org, action string) (*OrgOffboarding, int) {
+	t.Helper()
+
+	body := map[string]string{"org": org}
+	if action != "" {
+		body["action"] = action
+	}
+	resp, err := c.doRequest(t, "POST", "/api/offboarding/orgs", body)
+	if err != nil {
+		t.Fatalf("OffboardOrg request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result OrgOffboarding
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode OffboardOrg response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// ListOffboardings lists the orgs the user offboarded, most recent first.
+func (c *Client) ListOffboardings(t *testing.T) []OrgOffboarding {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/offboarding/orgs", nil)
+	if err != nil {
+		t.Fatalf("ListOffboardings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListOffboardings failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Offboardings []OrgOffboarding `json:"offboardings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListOffboardings response: %v", err)
+	}
+	return result.Offboardings
+}
+
+// RejoinOrg syncs an offboarded org again and returns the status code.
+func (c *Client) RejoinOrg(t *testing.T, org string) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "DELETE", "/api/offboarding/orgs/"+url.PathEscape(org), nil)
+	if err != nil {
+		t.Fatalf("RejoinOrg request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// LiveQueryEvent is an event on a live query stream.
+type LiveQueryEvent struct {
+	Event    string
+	GithubID string
+	ID       string
+	Matching int
+}
+
+// LiveQueryStream reads events from a live query subscription.
+type LiveQueryStream struct {
+	StatusCode int
+	events     chan LiveQueryEvent
+	body       io.ReadCloser
+}
+
+// SubscribeLiveQuery opens a live query stream. Events are read in the background
+// until Close is called.
+func (c *Client) SubscribeLiveQuery(t *testing.T, query string) *LiveQueryStream {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/live-queries?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		t.Fatalf("SubscribeLiveQuery request failed: %v", err)
+	}
+
+	stream := &LiveQueryStream{
+		StatusCode: resp.StatusCode,
+		events:     make(chan LiveQueryEvent, 100),
+		body:       resp.Body,
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		close(stream.events)
+		return stream
+	}
+
+	go func() {
+		defer close(stream.events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var data struct {
+					GithubID string `json:"githubId"`
+					ID       string `json:"id"`
+					Matching int    `json:"matching"`
+				}
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data)
+				stream.events <- LiveQueryEvent{
+					Event:    event,
+					GithubID: data.GithubID,
+					ID:       data.ID,
+					Matching: data.Matching,
+				}
+			}
+		}
+	}()
+	return stream
+}
+
+// Next waits for the next event, skipping keepalive pings.
+func (s *LiveQueryStream) Next(t *testing.T) LiveQueryEvent {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-s.events:
+			if !ok {
+				t.Fatalf("live query stream closed")
+			}
+			if event.Event != "ping" {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for live query event")
+		}
+	}
+}
+
+// Close ends the subscription.
+func (s *LiveQueryStream) Close() {
+	s.body.Close()
+}
+
+// Event is an event on the live update stream.
+type Event struct {
+	Event     string
+	GithubID  string
+	Operation string
+	Count     int64
+	SyncState string
+}
+
+// EventStream reads events from the live update stream.
+type EventStream struct {
+	StatusCode int
+	events     chan Event
+	body       io.ReadCloser
+}
+
+// SubscribeEvents opens the live update stream. Events are read in the background
+// until Close is called.
+func (c *Client) SubscribeEvents(t *testing.T) *EventStream {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/events", nil)
+	if err != nil {
+		t.Fatalf("SubscribeEvents request failed: %v", err)
+	}
+
+	stream := &EventStream{
+		StatusCode: resp.StatusCode,
+		events:     make(chan Event, 100),
+		body:       resp.Body,
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		close(stream.events)
+		return stream
+	}
+
+	go func() {
+		defer close(stream.events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var data struct {
+					GithubID string `json:"githubId"`
+					Bulk     *struct {
+						Operation string `json:"operation"`
+						Count     int64  `json:"count"`
+					} `json:"bulk"`
+					Sync *struct {
+						State string `json:"state"`
+					} `json:"sync"`
+				}
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data)
+				parsed := Event{Event: event, GithubID: data.GithubID}
+				if data.Bulk != nil {
+					parsed.Operation = data.Bulk.Operation
+					parsed.Count = data.Bulk.Count
+				}
+				if data.Sync != nil {
+					parsed.SyncState = data.Sync.State
+				}
+				stream.events <- parsed
+			}
+		}
+	}()
+	return stream
+}
+
+// Next waits for the next event, skipping keepalive pings.
+func (s *EventStream) Next(t *testing.T) Event {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-s.events:
+			if !ok {
+				t.Fatalf("event stream closed")
+			}
+			if event.Event != "ping" {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for event")
+		}
+	}
+}
+
+// Close ends the stream.
+func (s *EventStream) Close() {
+	s.body.Close()
+}
+
+// SetWebhooksEnabled turns webhook sync on or off, leaving the rest of the sync
+// settings as they are, and returns the status code.
+func (c *Client) SetWebhooksEnabled(t *testing.T, enabled bool) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/sync-settings", map[string]any{
+		"setupCompleted":  true,
+		"webhooksEnabled": enabled,
+	})
+	if err != nil {
+		t.Fatalf("SetWebhooksEnabled request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// SetAwaitingReplySettings sets when threads count as awaiting a reply and whether they
+// resurface, leaving the rest of the sync settings as they are, and returns the status code.
+func (c *Client) SetAwaitingReplySettings(t *testing.T, days int, resurface bool) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/sync-settings", map[string]any{
+		"setupCompleted":           true,
+		"awaitingReplyDays":        days,
+		"resurfaceAwaitingReplies": resurface,
+	})
+	if err != nil {
+		t.Fatalf("SetAwaitingReplySettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// WebhookResult describes what a webhook delivery changed.
+type WebhookResult struct {
+	Event      string   `json:"event"`
+	Ignored    bool     `json:"ignored"`
+	SubjectURL string   `json:"subjectUrl"`
+	Updated    []string `json:"updated"`
+}
+
+// SendWebhook posts a GitHub webhook delivery with the given signature header and
+// returns the result along with the status code.
+func (c *Client) SendWebhook(
+	t *testing.T,
+	event string,
+	payload []byte,
+	signature string,
+) (*WebhookResult, int) {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/webhooks/github", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Failed to create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", signature)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("SendWebhook request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result WebhookResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode SendWebhook response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// LinkedAccount represents a GitHub account linked alongside the primary account.
+type LinkedAccount struct {
+	GithubUserID string     `json:"githubUserId"`
+	Login        string     `json:"login"`
+	LastSyncedAt *time.Time `json:"lastSyncedAt,omitempty"`
+}
+
+// ListLinkedAccounts lists the linked GitHub accounts.
+func (c *Client) ListLinkedAccounts(t *testing.T) []LinkedAccount {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/user/accounts", nil)
+	if err != nil {
+		t.Fatalf("ListLinkedAccounts request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListLinkedAccounts failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Accounts []LinkedAccount `json:"accounts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListLinkedAccounts response: %v", err)
+	}
+	return result.Accounts
+}
+
+// UnlinkAccount unlinks a GitHub account and returns the status code.
+func (c *Client) UnlinkAccount(t *testing.T, login string) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "DELETE", "/api/user/accounts/"+url.PathEscape(login), nil)
+	if err != nil {
+		t.Fatalf("UnlinkAccount request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// SnoozeNotificationDefault snoozes a notification without a time, so the server applies
+// the repository's or the user's default snooze.
+func (c *Client) SnoozeNotificationDefault(t *testing.T, githubID string) *NotificationResponse {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/notifications/"+url.PathEscape(githubID)+"/snooze", nil)
+	if err != nil {
+		t.Fatalf("SnoozeNotificationDefault request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		t.Fatalf("SnoozeNotificationDefault failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result NotificationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode SnoozeNotificationDefault response: %v", err)
+	}
+
+	return &result
+}
+
+// UpdateSnoozeSettings saves the user's default snooze preset and returns the status code.
+func (c *Client) UpdateSnoozeSettings(t *testing.T, defaultPreset string) int {
+	t.Helper()
+
+	body := map[string]string{"defaultPreset": defaultPreset}
+	resp, err := c.doRequest(t, "PUT", "/api/user/snooze-settings", body)
+	if err != nil {
+		t.Fatalf("UpdateSnoozeSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// SnoozeSettings represents the user's snooze settings in API requests.
+type SnoozeSettings struct {
+	DefaultPreset      string `json:"defaultPreset"`
+	MarkUnreadOnReturn bool   `json:"markUnreadOnReturn"`
+}
+
+// UpdateSnoozeSettingsWith saves all of the user's snooze settings and returns the status code.
+func (c *Client) UpdateSnoozeSettingsWith(t *testing.T, settings SnoozeSettings) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/snooze-settings", settings)
+	if err != nil {
+		t.Fatalf("UpdateSnoozeSettingsWith request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// RepositorySettings represents a repository's settings in API requests and responses.
+type RepositorySettings struct {
+	RepositoryID                int64   `json:"repositoryId"`
+	DefaultSnooze               *string `json:"defaultSnooze"`
+	ResurfaceSuppressionMinutes *int64  `json:"resurfaceSuppressionMinutes"`
+}
+
+// UpdateRepositorySettings saves a repository's settings (nil fields are cleared) and
+// returns the saved settings with the status code.
+func (c *Client) UpdateRepositorySettings(
+	t *testing.T,
+	repositoryID int64,
+	settings RepositorySettings,
+) (*RepositorySettings, int) {
+	t.Helper()
+
+	body := settings
+	path := fmt.Sprintf("/api/repositories/%d/settings", repositoryID)
+	resp, err := c.doRequest(t, "PUT", path, body)
+	if err != nil {
+		t.Fatalf("UpdateRepositorySettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		Settings RepositorySettings `json:"settings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateRepositorySettings response: %v", err)
+	}
+	return &result.Settings, resp.StatusCode
+}
+
+// RenderView fetches the printable HTML page for a view and returns its body, content type,
+// and status code.
+func (c *Client) RenderView(t *testing.T, viewID, format string) (string, string, int) {
+	t.Helper()
+
+	path := "/api/views/" + url.PathEscape(viewID) + "/render"
+	if format != "" {
+		path += "?format=" + url.QueryEscape(format)
+	}
+	resp, err := c.doRequest(t, "GET", path, nil)
+	if err != nil {
+		t.Fatalf("RenderView request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read RenderView response: %v", err)
+	}
+	return string(body), resp.Header.Get("Content-Type"), resp.StatusCode
+}
+
+// View is a view with its unread count as listed by the API.
+type View struct {
+	ID            string `json:"id"`
+	Slug          string `json:"slug"`
+	Name          string `json:"name"`
+	SystemView    bool   `json:"systemView"`
+	UnreadCount   int64  `json:"unreadCount"`
+	WIPLimit      *int64 `json:"wipLimit"`
+	WIPAutoSnooze bool   `json:"wipAutoSnooze"`
+	Count         *int64 `json:"count"`
+	OverLimit     bool   `json:"overLimit"`
+}
+
+// SetViewWIPLimit sets or, with a nil limit, clears a view's WIP limit and returns the
+// status code. The updated view is returned when the update succeeds.
+func (c *Client) SetViewWIPLimit(t *testing.T, viewID string, limit *int64, autoSnooze bool) (*View, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/views/"+viewID+"/wip-limit", map[string]any{
+		"limit":      limit,
+		"autoSnooze": autoSnooze,
+	})
+	if err != nil {
+		t.Fatalf("SetViewWIPLimit request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		View View `json:"view"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode SetViewWIPLimit response: %v", err)
+	}
+	return &result.View, resp.StatusCode
+}
+
+// ListViews lists the views with their unread counts.
+func (c *Client) ListViews(t *testing.T) []View {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/views", nil)
+	if err != nil {
+		t.Fatalf("ListViews request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListViews failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Views []View `json:"views"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListViews response: %v", err)
+	}
+	return result.Views
+}
+
+// ViewCount is a view's counts as returned by the view counts endpoint.
+type ViewCount struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	UnreadCount int64  `json:"unreadCount"`
+	Count       *int64 `json:"count"`
+	OverLimit   bool   `json:"overLimit"`
+}
+
+// ViewCounts fetches every view's counts.
+func (c *Client) ViewCounts(t *testing.T) []ViewCount {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/views/counts", nil)
+	if err != nil {
+		t.Fatalf("ViewCounts request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ViewCounts failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Counts []ViewCount `json:"counts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ViewCounts response: %v", err)
+	}
+	return result.Counts
+}
+
+// PlainNotifications fetches the plain notification stream and returns its body, content type,
+// and status code.
+func (c *Client) PlainNotifications(t *testing.T, query, format string) (string, string, int) {
+	t.Helper()
+
+	params := url.Values{}
+	if query != "" {
+		params.Set("query", query)
+	}
+	if format != "" {
+		params.Set("format", format)
+	}
+	path := "/api/notifications/plain"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	resp, err := c.doRequest(t, "GET", path, nil)
+	if err != nil {
+		t.Fatalf("PlainNotifications request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read PlainNotifications response: %v", err)
+	}
+	return string(body), resp.Header.Get("Content-Type"), resp.StatusCode
+}
+
+// HistoryEvent represents one entry in a notification's history.
+type HistoryEvent struct {
+	Action    string    `json:"action"`
+	Source    string    `json:"source"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetNotificationHistory fetches a notification's history, newest first, and returns it
+// with the status code.
+func (c *Client) GetNotificationHistory(t *testing.T, githubID string) ([]HistoryEvent, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications/"+url.PathEscape(githubID)+"/history", nil)
+	if err != nil {
+		t.Fatalf("GetNotificationHistory request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		Events []HistoryEvent `json:"events"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetNotificationHistory response: %v", err)
+	}
+	return result.Events, resp.StatusCode
+}
+
+// ListConversations lists the notifications matching a query with one entry per subject.
+func (c *Client) ListConversations(t *testing.T, query string) *ListNotificationsResponse {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("conversations", "true")
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("ListConversations request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("ListConversations failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result ListNotificationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListConversations response: %v", err)
+	}
+	return &result
+}
+
+// GetConversation returns every notification sharing a notification's subject, along with
+// the status code.
+func (c *Client) GetConversation(t *testing.T, githubID string) ([]Notification, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications/"+url.PathEscape(githubID)+"/conversation", nil)
+	if err != nil {
+		t.Fatalf("GetConversation request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		Notifications []Notification `json:"notifications"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetConversation response: %v", err)
+	}
+	return result.Notifications, resp.StatusCode
+}
+
+// NextNotification fetches the notification after (or, with before set, before) the cursor
+// in the query's list order. It returns nil past either end of the list, along with the
+// status code.
+func (c *Client) NextNotification(
+	t *testing.T,
+	query, cursor string,
+	before bool,
+) (*Notification, int) {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("query", query)
+	if cursor != "" {
+		if before {
+			params.Set("before", cursor)
+		} else {
+			params.Set("after", cursor)
+		}
+	}
+
+	resp, err := c.doRequest(t, "GET", "/api/notifications/next?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("NextNotification request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		Notification *Notification `json:"notification"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode NextNotification response: %v", err)
+	}
+	return result.Notification, resp.StatusCode
+}
+
+// DailyActivity counts what happened to notifications on one UTC day.
+type DailyActivity struct {
+	Date     string `json:"date"`
+	Received int64  `json:"received"`
+	Read     int64  `json:"read"`
+	Archived int64  `json:"archived"`
+}
+
+// ActivityReport is a day-by-day series of notification activity.
+type ActivityReport struct {
+	Days                  int             `json:"days"`
+	Daily                 []DailyActivity `json:"daily"`
+	MeanTimeToReadSeconds int64           `json:"meanTimeToReadSeconds"`
+	ReadMeasured          int64           `json:"readMeasured"`
+}
+
+// VolumeEntry counts the notifications in one group of a breakdown.
+type VolumeEntry struct {
+	Key    string `json:"key"`
+	Total  int64  `json:"total"`
+	Unread int64  `json:"unread"`
+}
+
+// VolumeBreakdown counts notifications by repository, org or reason.
+type VolumeBreakdown struct {
+	By      string        `json:"by"`
+	Entries []VolumeEntry `json:"entries"`
+	Total   int64         `json:"total"`
+}
+
+// GetAnalyticsActivity fetches notification activity over the last days.
+func (c *Client) GetAnalyticsActivity(t *testing.T, days int) *ActivityReport {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/analytics/activity?days="+strconv.Itoa(days), nil)
+	if err != nil {
+		t.Fatalf("GetAnalyticsActivity request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GetAnalyticsActivity failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result ActivityReport
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetAnalyticsActivity response: %v", err)
+	}
+	return &result
+}
+
+// GetAnalyticsBreakdown fetches notification volume over the last days broken down by
+// repo, org or reason.
+func (c *Client) GetAnalyticsBreakdown(t *testing.T, days int, by string) *VolumeBreakdown {
+	t.Helper()
+
+	params := url.Values{}
+	params.Set("days", strconv.Itoa(days))
+	params.Set("by", by)
+
+	resp, err := c.doRequest(t, "GET", "/api/analytics/breakdown?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatalf("GetAnalyticsBreakdown request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("GetAnalyticsBreakdown failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result VolumeBreakdown
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetAnalyticsBreakdown response: %v", err)
+	}
+	return &result
+}
+
+// doRequest performs an HTTP request.
+// No authentication needed - trusts localhost.
+func (c *Client) doRequest(t *testing.T, method, path string, body interface{}) (*http.Response, error) {
+	t.Helper()
+
+	var bodyReader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	fullURL := c.BaseURL + path
+	req, err := http.NewRequest(method, fullURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.HTTPClient.Do(req)
+}
+
+// DigestSettings is the user's digest schedule.
+type DigestSettings struct {
+	Enabled   bool   `json:"enabled"`
+	Frequency string `json:"frequency"`
+	Hour      int    `json:"hour"`
+	Weekday   int    `json:"weekday"`
+	Query     string `json:"query"`
+	EmailTo   string `json:"emailTo"`
+	CanEmail  bool   `json:"canEmail,omitempty"`
+}
+
+// Digest is a generated notification digest.
+type Digest struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Markdown  string    `json:"markdown"`
+	HTML      string    `json:"html"`
+	EmailedTo string    `json:"emailedTo,omitempty"`
+}
+
+// GetDigestSettings gets the user's digest schedule.
+func (c *Client) GetDigestSettings(t *testing.T) DigestSettings {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/user/digest-settings", nil)
+	if err != nil {
+		t.Fatalf("GetDigestSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetDigestSettings returned status %d", resp.StatusCode)
+	}
+
+	var result DigestSettings
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetDigestSettings response: %v", err)
+	}
+	return result
+}
+
+// UpdateDigestSettings saves the digest schedule and returns the status code. The saved
+// settings are returned when the update succeeds.
+func (c *Client) UpdateDigestSettings(t *testing.T, settings DigestSettings) (*DigestSettings, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/user/digest-settings", settings)
+	if err != nil {
+		t.Fatalf("UpdateDigestSettings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result DigestSettings
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateDigestSettings response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// GenerateDigest generates a digest now and returns it with the status code.
+func (c *Client) GenerateDigest(t *testing.T) (*Digest, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/user/digests", nil)
+	if err != nil {
+		t.Fatalf("GenerateDigest request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, resp.StatusCode
+	}
+
+	var result Digest
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GenerateDigest response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// GetLatestDigest gets the most recent digest and the status code.
+func (c *Client) GetLatestDigest(t *testing.T) (*Digest, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/user/digests/latest", nil)
+	if err != nil {
+		t.Fatalf("GetLatestDigest request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result Digest
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode GetLatestDigest response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// Webhook is a webhook rules can send notifications to. Its secret is never returned.
+type Webhook struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Format    string `json:"format"`
+	URL       string `json:"url"`
+	HasSecret bool   `json:"hasSecret"`
+}
+
+// WebhookRequest creates or updates a webhook. A nil secret keeps the current one.
+type WebhookRequest struct {
+	Name   string  `json:"name"`
+	Format string  `json:"format,omitempty"`
+	URL    string  `json:"url"`
+	Secret *string `json:"secret,omitempty"`
+}
+
+// WebhookDelivery is an entry in a webhook's delivery log.
+type WebhookDelivery struct {
+	ID             int64  `json:"id"`
+	RuleName       string `json:"ruleName"`
+	GithubID       string `json:"githubId"`
+	Status         string `json:"status"`
+	Attempts       int64  `json:"attempts"`
+	ResponseStatus *int64 `json:"responseStatus"`
+	Error          string `json:"error"`
+}
+
+// ListWebhooks lists the user's webhooks.
+func (c *Client) ListWebhooks(t *testing.T) []Webhook {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/integrations/webhooks", nil)
+	if err != nil {
+		t.Fatalf("ListWebhooks request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ListWebhooks returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListWebhooks response: %v", err)
+	}
+	return result.Webhooks
+}
+
+// CreateWebhook creates a webhook and returns it with the status code.
+func (c *Client) CreateWebhook(t *testing.T, req WebhookRequest) (*Webhook, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "POST", "/api/integrations/webhooks", req)
+	if err != nil {
+		t.Fatalf("CreateWebhook request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, resp.StatusCode
+	}
+
+	var result Webhook
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode CreateWebhook response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// UpdateWebhook updates a webhook and returns it with the status code.
+func (c *Client) UpdateWebhook(t *testing.T, id string, req WebhookRequest) (*Webhook, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "PUT", "/api/integrations/webhooks/"+url.PathEscape(id), req)
+	if err != nil {
+		t.Fatalf("UpdateWebhook request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result Webhook
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode UpdateWebhook response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// DeleteWebhook deletes a webhook and returns the status code.
+func (c *Client) DeleteWebhook(t *testing.T, id string) int {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "DELETE", "/api/integrations/webhooks/"+url.PathEscape(id), nil)
+	if err != nil {
+		t.Fatalf("DeleteWebhook request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+// ListWebhookDeliveries lists a webhook's recent deliveries, newest first, and the status code.
+func (c *Client) ListWebhookDeliveries(t *testing.T, id string) ([]WebhookDelivery, int) {
+	t.Helper()
+
+	resp, err := c.doRequest(t, "GET", "/api/integrations/webhooks/"+url.PathEscape(id)+"/deliveries", nil)
+	if err != nil {
+		t.Fatalf("ListWebhookDeliveries request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+
+	var result struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode ListWebhookDeliveries response: %v", err)
+	}
+	return result.Deliveries, resp.StatusCode
 }
@@ -0,0 +1,89 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestConversations(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("cli/cli").Build(t, ctx, ts.Store, userID)
+		pullURL := "https://api.github.com/repos/cli/cli/pulls/1"
+		issueURL := "https://api.github.com/repos/cli/cli/issues/2"
+
+		now := time.Now().UTC()
+		build := func(githubID, subjectURL, reason string, age time.Duration) {
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(githubID).
+				WithSubjectURL(subjectURL).
+				WithReason(reason).
+				WithGithubUpdatedAt(now.Add(-age)).
+				Build(t, ctx, ts.Store, userID)
+		}
+		build("pull-old", pullURL, "review_requested", 3*time.Hour)
+		build("pull-new", pullURL, "mention", time.Hour)
+		build("pull-account", pullURL, "comment", 2*time.Hour)
+		build("issue", issueURL, "subscribed", 90*time.Minute)
+
+		// Each subject is listed once, as its newest notification
+		result := c.ListConversations(t, "")
+		require.Equal(t, int64(2), result.Total)
+		require.Len(t, result.Notifications, 2)
+		require.Equal(t, "pull-new", result.Notifications[0].GithubID)
+		require.Equal(t, int64(3), result.Notifications[0].Conversation.Count)
+		require.Equal(t, "mention", *result.Notifications[0].Conversation.NewestReason)
+		require.Equal(t, "issue", result.Notifications[1].GithubID)
+		require.Equal(t, int64(1), result.Notifications[1].Conversation.Count)
+
+		// Queries narrow what a conversation counts
+		result = c.ListConversations(t, "-reason:mention")
+		require.Equal(t, int64(2), result.Total)
+		require.Equal(t, "pull-account", result.Notifications[1].GithubID)
+		require.Equal(t, int64(2), result.Notifications[1].Conversation.Count)
+
+		// Without conversations the list is unchanged
+		plain := c.ListNotifications(t, "", 0, 0)
+		require.Equal(t, int64(4), plain.Total)
+		require.Nil(t, plain.Notifications[0].Conversation)
+
+		// Expanding a conversation lists its notifications, newest first
+		notifications, status := c.GetConversation(t, "pull-old")
+		require.Equal(t, http.StatusOK, status)
+		ids := make([]string, 0, len(notifications))
+		for _, n := range notifications {
+			ids = append(ids, n.GithubID)
+		}
+		require.Equal(t, []string{"pull-new", "pull-account", "pull-old"}, ids)
+
+		_, status = c.GetConversation(t, "missing")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
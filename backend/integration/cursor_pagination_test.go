@@ -0,0 +1,78 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestCursorPagination(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("cli/cli").Build(t, ctx, ts.Store, userID)
+		now := time.Now().UTC().Truncate(time.Second)
+		for i := 1; i <= 5; i++ {
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(fmt.Sprintf("n%d", i)).
+				WithGithubUpdatedAt(now.Add(-time.Duration(i)*time.Hour)).
+				Build(t, ctx, ts.Store, userID)
+		}
+
+		ids := func(page *client.ListNotificationsResponse) []string {
+			result := make([]string, 0, len(page.Notifications))
+			for _, n := range page.Notifications {
+				result = append(result, n.GithubID)
+			}
+			return result
+		}
+
+		first, status := c.ListNotificationsAfter(t, "", "", 2)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"n1", "n2"}, ids(first))
+		require.Equal(t, int64(5), first.Total)
+		require.NotEmpty(t, first.NextCursor)
+
+		// Archiving from the first page would shift an offset-based second page past n3
+		c.ArchiveNotification(t, "n1")
+		require.Equal(t, []string{"n4", "n5"}, ids(c.ListNotifications(t, "", 2, 2)))
+
+		second, status := c.ListNotificationsAfter(t, "", first.NextCursor, 2)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"n3", "n4"}, ids(second))
+		require.Equal(t, int64(4), second.Total)
+
+		last, status := c.ListNotificationsAfter(t, "", second.NextCursor, 2)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"n5"}, ids(last))
+		require.Empty(t, last.NextCursor)
+
+		_, status = c.ListNotificationsAfter(t, "", "not-a-cursor", 2)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
@@ -0,0 +1,143 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func createRule(
+	t *testing.T,
+	ts *testserver.TestServer,
+	name string,
+	query, viewID string,
+	actions models.RuleActions,
+) db.Rule {
+	t.Helper()
+	data, err := json.Marshal(actions)
+	require.NoError(t, err)
+	rule, err := ts.Store.CreateRule(context.Background(), ts.UserID, db.CreateRuleParams{
+		Name:    name,
+		Query:   sql.NullString{String: query, Valid: query != ""},
+		ViewID:  sql.NullString{String: viewID, Valid: viewID != ""},
+		Enabled: true,
+		Actions: data,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+func TestDeleteTag_DependentRules(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		tag := fixtures.NewTag().WithName("triage").Build(t, ctx, ts.Store, ts.UserID)
+		other := fixtures.NewTag().WithName("other").Build(t, ctx, ts.Store, ts.UserID)
+
+		assigns := createRule(t, ts, "Assign triage", "reason:mention", "",
+			models.RuleActions{AssignTags: []string{other.ID, tag.ID}})
+		removes := createRule(t, ts, "Remove triage", "is:read", "",
+			models.RuleActions{RemoveTags: []string{tag.ID}})
+		unrelated := createRule(t, ts, "Unrelated", "is:unread", "",
+			models.RuleActions{AssignTags: []string{other.ID}})
+
+		// Without force the tag is kept and the dependent rules are listed
+		result := c.DeleteTag(t, tag.ID, false)
+		require.Equal(t, http.StatusConflict, result.StatusCode)
+		require.Equal(t, 2, result.LinkedRuleCount)
+		dependents := []string{assigns.ID, removes.ID}
+		require.ElementsMatch(t, dependents, dependentRuleIDs(result.DependentRules))
+		_, err := ts.Store.GetTag(ctx, ts.UserID, tag.ID)
+		require.NoError(t, err)
+
+		// With force the tag is deleted and the dependents disabled
+		result = c.DeleteTag(t, tag.ID, true)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.ElementsMatch(t, dependents, dependentRuleIDs(result.DisabledRules))
+		_, err = ts.Store.GetTag(ctx, ts.UserID, tag.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		for _, id := range dependents {
+			rule, err := ts.Store.GetRule(ctx, ts.UserID, id)
+			require.NoError(t, err)
+			require.False(t, rule.Enabled)
+		}
+		rule, err := ts.Store.GetRule(ctx, ts.UserID, unrelated.ID)
+		require.NoError(t, err)
+		require.True(t, rule.Enabled)
+
+		// Disabled rules still count as dependents of the tags they reference
+		result = c.DeleteTag(t, other.ID, false)
+		require.Equal(t, http.StatusConflict, result.StatusCode)
+		require.Equal(t, 2, result.LinkedRuleCount)
+	})
+}
+
+func TestDeleteView_DependentRules(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		view, err := ts.Store.CreateView(ctx, ts.UserID, db.CreateViewParams{
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		})
+		require.NoError(t, err)
+		linked := createRule(t, ts, "Star reviews", "", view.ID, models.RuleActions{Star: true})
+
+		result := c.DeleteView(t, view.ID, false)
+		require.Equal(t, http.StatusConflict, result.StatusCode)
+		require.Equal(t, []string{linked.ID}, dependentRuleIDs(result.DependentRules))
+		_, err = ts.Store.GetView(ctx, ts.UserID, view.ID)
+		require.NoError(t, err)
+
+		result = c.DeleteView(t, view.ID, true)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, []string{linked.ID}, dependentRuleIDs(result.DisabledRules))
+		_, err = ts.Store.GetView(ctx, ts.UserID, view.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		// The disabled rule keeps the view's query so it can be re-enabled
+		rule, err := ts.Store.GetRule(ctx, ts.UserID, linked.ID)
+		require.NoError(t, err)
+		require.False(t, rule.Enabled)
+		require.False(t, rule.ViewID.Valid)
+		require.Equal(t, "reason:review_requested", rule.Query.String)
+
+		_, err = ts.Store.DeleteViewAndDisableRules(ctx, ts.UserID, view.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func dependentRuleIDs(rules []client.DependentRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
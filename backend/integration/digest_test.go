@@ -0,0 +1,78 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestDigests(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().WithFullName("octo/digest").Build(t, ctx, ts.Store, userID)
+
+		fixtures.NewNotification(repo.ID).
+			WithSubjectTitle("Review the parser").
+			WithReason("review_requested").
+			WithIsRead(false).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithSubjectTitle("Already read").
+			WithIsRead(true).
+			Build(t, ctx, ts.Store, userID)
+
+		// Nothing has been generated yet
+		_, status := c.GetLatestDigest(t)
+		require.Equal(t, http.StatusNotFound, status)
+
+		settings := c.GetDigestSettings(t)
+		require.False(t, settings.Enabled)
+		require.Equal(t, "daily", settings.Frequency)
+		require.False(t, settings.CanEmail)
+
+		// An address can't be set without a mail server
+		settings.EmailTo = "me@example.com"
+		_, status = c.UpdateDigestSettings(t, settings)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		settings.EmailTo = ""
+		settings.Query = "in:anywhere is:unread"
+		saved, status := c.UpdateDigestSettings(t, settings)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "in:anywhere is:unread", saved.Query)
+
+		generated, status := c.GenerateDigest(t)
+		require.Equal(t, http.StatusCreated, status)
+		require.Contains(t, generated.Markdown, "Review the parser")
+		require.NotContains(t, generated.Markdown, "Already read")
+		require.Contains(t, generated.HTML, "Review the parser")
+		require.Empty(t, generated.EmailedTo)
+
+		latest, status := c.GetLatestDigest(t)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, generated.Name, latest.Name)
+		require.Equal(t, generated.Markdown, latest.Markdown)
+	})
+}
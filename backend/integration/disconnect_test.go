@@ -0,0 +1,134 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestDisconnect_KeepStarredTagged(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		keptRepo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		droppedRepo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		starred := fixtures.NewNotification(keptRepo.ID).
+			WithStarred(true).
+			Build(t, ctx, ts.Store, userID)
+		tagged := fixtures.NewNotification(keptRepo.ID).
+			Build(t, ctx, ts.Store, userID)
+		tag := fixtures.NewTag().Build(t, ctx, ts.Store, userID)
+		fixtures.AssignTag(t, ctx, ts.Store, userID, tag.ID, tagged.ID)
+		fixtures.NewNotification(keptRepo.ID).Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(droppedRepo.ID).Build(t, ctx, ts.Store, userID)
+
+		var steps int
+		err := ts.Store.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+			Mode: db.DisconnectModeKeepStarredTagged,
+			OnProgress: func(p db.DeleteGitHubDataProgress) {
+				steps = p.StepsCompleted
+				require.Equal(t, 8, p.TotalSteps)
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 8, steps)
+
+		result := c.ListNotifications(t, "in:anywhere", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		githubIDs := []string{
+			result.Notifications[0].GithubID,
+			result.Notifications[1].GithubID,
+		}
+		require.ElementsMatch(t, []string{starred.GithubID, tagged.GithubID}, githubIDs)
+
+		repos, err := ts.Store.ListRepositories(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		require.Equal(t, keptRepo.ID, repos[0].ID)
+	})
+}
+
+func TestDisconnect_KeepTriage(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		notif := fixtures.NewNotification(repo.ID).
+			WithArchived(true).
+			WithStarred(true).
+			Build(t, ctx, ts.Store, userID)
+
+		// Store raw GitHub payloads on the notification
+		_, err := ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+			GithubID:     notif.GithubID,
+			RepositoryID: repo.ID,
+			SubjectType:  notif.SubjectType,
+			SubjectTitle: notif.SubjectTitle,
+			Payload:      db.NullRawMessage{RawMessage: []byte(`{"id":"1"}`), Valid: true},
+			SubjectRaw:   db.NullRawMessage{RawMessage: []byte(`{"body":"secret"}`), Valid: true},
+		})
+		require.NoError(t, err)
+
+		err = ts.Store.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+			Mode: db.DisconnectModeKeepTriage,
+		})
+		require.NoError(t, err)
+
+		kept, err := ts.Store.GetNotificationByGithubID(ctx, userID, notif.GithubID)
+		require.NoError(t, err)
+		require.True(t, kept.Archived)
+		require.True(t, kept.Starred)
+		require.Equal(t, notif.SubjectTitle, kept.SubjectTitle)
+		require.False(t, kept.SubjectRaw.Valid)
+		require.False(t, kept.Payload.Valid)
+		require.False(t, kept.PullRequestID.Valid)
+	})
+}
+
+func TestDisconnect_FullWipe(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).WithStarred(true).Build(t, ctx, ts.Store, userID)
+
+		err := ts.Store.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+			Mode: db.DisconnectModeFullWipe,
+		})
+		require.NoError(t, err)
+
+		result := c.ListNotifications(t, "in:anywhere", 1, 100)
+		require.Equal(t, int64(0), result.Total)
+
+		repos, err := ts.Store.ListRepositories(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, repos)
+	})
+}
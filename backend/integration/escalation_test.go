@@ -0,0 +1,118 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestEscalateReviewRequests(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		stale := time.Now().UTC().AddDate(0, 0, -5)
+		fresh := time.Now().UTC().Add(-time.Hour)
+
+		ignored := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(stale).
+			Build(t, ctx, ts.Store, userID)
+		archived := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(stale).
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+		recent := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(fresh).
+			Build(t, ctx, ts.Store, userID)
+		read := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(stale).
+			WithIsRead(true).
+			Build(t, ctx, ts.Store, userID)
+		mention := fixtures.NewNotification(repo.ID).
+			WithReason("mention").
+			WithGithubUpdatedAt(stale).
+			Build(t, ctx, ts.Store, userID)
+		snoozed := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(stale).
+			WithSnoozedUntil(time.Now().UTC().Add(24*time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+
+		handler := handlers.NewEscalateReviewRequestsHandler(ts.Store, zap.NewNop())
+		require.NoError(t, handler.UpdateEscalationSettings(ctx, userID, &models.EscalationSettings{
+			Enabled:      true,
+			AfterDays:    3,
+			TagEscalated: true,
+		}))
+
+		before := time.Now().UTC().Add(-time.Second)
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, result.Skipped)
+		require.ElementsMatch(t, []string{ignored.GithubID, archived.GithubID}, result.Escalated)
+
+		for _, githubID := range result.Escalated {
+			n := c.GetNotification(t, githubID).Notification
+			require.False(t, n.Archived)
+			require.False(t, n.EffectiveSortDate.Before(before))
+
+			tags, err := ts.Store.ListTagsForEntity(ctx, userID, db.ListTagsForEntityParams{
+				EntityType: "notification",
+				EntityID:   n.ID,
+			})
+			require.NoError(t, err)
+			require.Len(t, tags, 1)
+			require.Equal(t, handlers.EscalationTagName, tags[0].Name)
+		}
+
+		// Recently touched, read and non-review notifications stay put
+		for _, githubID := range []string{recent.GithubID, read.GithubID, mention.GithubID} {
+			n := c.GetNotification(t, githubID).Notification
+			require.True(t, n.EffectiveSortDate.Before(before), githubID)
+		}
+
+		// A review request snoozed since it went stale counts as touched
+		require.NotNil(t, c.GetNotification(t, snoozed.GithubID).Notification.SnoozedUntil)
+
+		// Escalated notifications are not escalated again on the next run
+		result, err = handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, result.Escalated)
+
+		settings, err := handler.GetEscalationSettings(ctx, userID)
+		require.NoError(t, err)
+		require.NotEmpty(t, settings.LastRunAt)
+	})
+}
@@ -0,0 +1,74 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestEvents_StreamsInboxUpdates(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("inbox-1").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("inbox-2").
+			Build(t, ctx, ts.Store, userID)
+
+		stream := c.SubscribeEvents(t)
+		defer stream.Close()
+		require.Equal(t, http.StatusOK, stream.StatusCode)
+		require.Equal(t, "connected", stream.Next(t).Event)
+
+		// Bulk actions through the API report how many notifications they changed
+		c.BulkArchive(t, []string{"inbox-1", "inbox-2"}, "")
+		event := stream.Next(t)
+		require.Equal(t, "bulk_completed", event.Event)
+		require.Equal(t, "archive", event.Operation)
+		require.Equal(t, int64(2), event.Count)
+
+		// Sync status is passed along as the scheduler publishes it
+		ts.Events.Publish(userID, models.Event{
+			Type: models.EventSyncStatus,
+			Sync: &models.SyncStatus{State: models.SyncStatusCompleted},
+		})
+		event = stream.Next(t)
+		require.Equal(t, "sync_status", event.Event)
+		require.Equal(t, models.SyncStatusCompleted, event.SyncState)
+
+		// Other users' events aren't sent
+		ts.Events.Publish("someone-else", models.Event{Type: models.EventNotificationUpserted, GithubID: "x"})
+		ts.Events.Publish(userID, models.Event{Type: models.EventNotificationUpserted, GithubID: "inbox-1"})
+		event = stream.Next(t)
+		require.Equal(t, "notification_upserted", event.Event)
+		require.Equal(t, "inbox-1", event.GithubID)
+	})
+}
@@ -21,6 +21,7 @@ package fixtures
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sync/atomic"
 	"testing"
@@ -100,8 +101,10 @@ func (b *RepositoryBuilder) Build(t *testing.T, ctx context.Context, store db.St
 type NotificationBuilder struct {
 	githubID        string
 	repositoryID    int64
+	pullRequestID   sql.NullInt64
 	subjectType     string
 	subjectTitle    string
+	subjectURL      string
 	reason          string
 	archived        bool
 	isRead          bool
@@ -110,6 +113,22 @@ type NotificationBuilder struct {
 	filtered        bool
 	snoozedUntil    sql.NullTime
 	githubUpdatedAt sql.NullTime
+	headBranch      sql.NullString
+	baseBranch      sql.NullString
+	draft           sql.NullBool
+	labels          db.NullRawMessage
+	reviewState     sql.NullString
+	milestone       sql.NullString
+	projects        db.NullRawMessage
+	ciState         sql.NullString
+	mergeable       sql.NullBool
+	waitingOn       sql.NullString
+	priority        sql.NullInt64
+	account         sql.NullString
+	authorLogin     sql.NullString
+	subjectRaw      db.NullRawMessage
+	latestComment   sql.NullString
+	awaitingSince   sql.NullTime
 }
 
 // NewNotification creates a new notification builder with defaults.
@@ -131,6 +150,12 @@ func (b *NotificationBuilder) WithGithubID(id string) *NotificationBuilder {
 	return b
 }
 
+// WithPullRequestID links the notification to a pull request.
+func (b *NotificationBuilder) WithPullRequestID(id int64) *NotificationBuilder {
+	b.pullRequestID = sql.NullInt64{Int64: id, Valid: true}
+	return b
+}
+
 // WithSubjectType sets the subject type.
 func (b *NotificationBuilder) WithSubjectType(t string) *NotificationBuilder {
 	b.subjectType = t
@@ -143,6 +168,12 @@ func (b *NotificationBuilder) WithSubjectTitle(title string) *NotificationBuilde
 	return b
 }
 
+// WithSubjectURL sets the subject API URL, which groups notifications into threads.
+func (b *NotificationBuilder) WithSubjectURL(url string) *NotificationBuilder {
+	b.subjectURL = url
+	return b
+}
+
 // WithReason sets the reason.
 func (b *NotificationBuilder) WithReason(reason string) *NotificationBuilder {
 	b.reason = reason
@@ -191,18 +222,131 @@ func (b *NotificationBuilder) WithGithubUpdatedAt(t time.Time) *NotificationBuil
 	return b
 }
 
+// WithBranches sets the pull request's head and base branch.
+func (b *NotificationBuilder) WithBranches(head, base string) *NotificationBuilder {
+	b.headBranch = sql.NullString{String: head, Valid: true}
+	b.baseBranch = sql.NullString{String: base, Valid: true}
+	return b
+}
+
+// WithDraft sets whether the pull request is a draft.
+func (b *NotificationBuilder) WithDraft(draft bool) *NotificationBuilder {
+	b.draft = sql.NullBool{Bool: draft, Valid: true}
+	return b
+}
+
+// WithLabels sets the names of the subject's labels.
+func (b *NotificationBuilder) WithLabels(names ...string) *NotificationBuilder {
+	raw, _ := json.Marshal(names)
+	b.labels = db.NullRawMessage{RawMessage: raw, Valid: true}
+	return b
+}
+
+// WithReviewState sets the pull request's review state: approved, changes_requested or pending.
+func (b *NotificationBuilder) WithReviewState(state string) *NotificationBuilder {
+	b.reviewState = sql.NullString{String: state, Valid: true}
+	return b
+}
+
+// WithMilestone sets the title of the subject's milestone.
+func (b *NotificationBuilder) WithMilestone(title string) *NotificationBuilder {
+	b.milestone = sql.NullString{String: title, Valid: true}
+	return b
+}
+
+// WithProjects sets the titles of the projects the subject belongs to.
+func (b *NotificationBuilder) WithProjects(titles ...string) *NotificationBuilder {
+	raw, _ := json.Marshal(titles)
+	b.projects = db.NullRawMessage{RawMessage: raw, Valid: true}
+	return b
+}
+
+// WithCIState sets the pull request's CI state: success, failure or pending.
+func (b *NotificationBuilder) WithCIState(state string) *NotificationBuilder {
+	b.ciState = sql.NullString{String: state, Valid: true}
+	return b
+}
+
+// WithMergeable sets whether the pull request can be merged without conflicts.
+func (b *NotificationBuilder) WithMergeable(mergeable bool) *NotificationBuilder {
+	b.mergeable = sql.NullBool{Bool: mergeable, Valid: true}
+	return b
+}
+
+// WithWaitingOn sets whose turn it is on the subject: me or them.
+func (b *NotificationBuilder) WithWaitingOn(turn string) *NotificationBuilder {
+	b.waitingOn = sql.NullString{String: turn, Valid: true}
+	return b
+}
+
+// WithPriority sets the notification's priority score.
+func (b *NotificationBuilder) WithPriority(score int64) *NotificationBuilder {
+	b.priority = sql.NullInt64{Int64: score, Valid: true}
+	return b
+}
+
+// WithAccount sets the login of the linked account the notification was synced for.
+func (b *NotificationBuilder) WithAccount(login string) *NotificationBuilder {
+	b.account = sql.NullString{String: login, Valid: true}
+	return b
+}
+
+// WithAuthorLogin sets the login of the subject's author.
+func (b *NotificationBuilder) WithAuthorLogin(login string) *NotificationBuilder {
+	b.authorLogin = sql.NullString{String: login, Valid: true}
+	return b
+}
+
+// WithSubjectRaw sets the subject data, such as the issue or pull request JSON.
+func (b *NotificationBuilder) WithSubjectRaw(raw string) *NotificationBuilder {
+	b.subjectRaw = db.NullRawMessage{RawMessage: json.RawMessage(raw), Valid: true}
+	return b
+}
+
+// WithLatestComment sets the body of the latest comment in the search index.
+func (b *NotificationBuilder) WithLatestComment(body string) *NotificationBuilder {
+	b.latestComment = sql.NullString{String: body, Valid: true}
+	return b
+}
+
+// WithOwnLatestComment sets a latest comment posted by the user at the given time, which
+// starts the awaiting reply clock.
+func (b *NotificationBuilder) WithOwnLatestComment(body string, postedAt time.Time) *NotificationBuilder {
+	b.latestComment = sql.NullString{String: body, Valid: true}
+	b.awaitingSince = sql.NullTime{Time: postedAt, Valid: true}
+	return b
+}
+
 // Build creates the notification in the database.
 func (b *NotificationBuilder) Build(t *testing.T, ctx context.Context, store db.Store, userID string) db.Notification {
 	t.Helper()
 
 	// Create notification via upsert
 	notif, err := store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
-		GithubID:        b.githubID,
-		RepositoryID:    b.repositoryID,
-		SubjectType:     b.subjectType,
-		SubjectTitle:    b.subjectTitle,
-		Reason:          sql.NullString{String: b.reason, Valid: true},
-		GithubUpdatedAt: b.githubUpdatedAt,
+		GithubID:           b.githubID,
+		RepositoryID:       b.repositoryID,
+		PullRequestID:      b.pullRequestID,
+		SubjectType:        b.subjectType,
+		SubjectTitle:       b.subjectTitle,
+		SubjectURL:         sql.NullString{String: b.subjectURL, Valid: b.subjectURL != ""},
+		Reason:             sql.NullString{String: b.reason, Valid: true},
+		GithubUpdatedAt:    b.githubUpdatedAt,
+		HeadBranch:         b.headBranch,
+		BaseBranch:         b.baseBranch,
+		SubjectDraft:       b.draft,
+		SubjectLabels:      b.labels,
+		ReviewState:        b.reviewState,
+		SubjectMilestone:   b.milestone,
+		SubjectProjects:    b.projects,
+		CIState:            b.ciState,
+		SubjectMergeable:   b.mergeable,
+		WaitingOn:          b.waitingOn,
+		Priority:           b.priority,
+		Account:            b.account,
+		AuthorLogin:        b.authorLogin,
+		SubjectRaw:         b.subjectRaw,
+		LatestCommentBody:  b.latestComment,
+		AwaitingReplySince: b.awaitingSince,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create notification: %v", err)
@@ -0,0 +1,122 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/githubsettings"
+	rulescore "github.com/octobud-hq/octobud/backend/internal/core/rules"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// subscriptionsClient answers watch setting lookups from a fixed table
+type subscriptionsClient struct {
+	githubinterfaces.Client
+	subscriptions map[string]string
+}
+
+func (c subscriptionsClient) FetchRepositorySubscriptions(
+	_ context.Context,
+	fullNames []string,
+) ([]types.RepositorySubscription, error) {
+	var result []types.RepositorySubscription
+	for _, fullName := range fullNames {
+		if subscription, ok := c.subscriptions[fullName]; ok {
+			result = append(result, types.RepositorySubscription{
+				FullName:     fullName,
+				Subscription: subscription,
+			})
+		}
+	}
+	return result, nil
+}
+
+func TestGitHubSettings_SuggestAndAdopt(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		docs := fixtures.NewRepository().WithFullName("acme/docs").Build(t, ctx, ts.Store, userID)
+		api := fixtures.NewRepository().WithFullName("acme/api").Build(t, ctx, ts.Store, userID)
+		fixtures.NewRepository().WithFullName("acme/quiet").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(docs.ID).
+			WithGithubID("release-1").
+			WithSubjectType("Release").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(docs.ID).
+			WithGithubID("release-2").
+			WithSubjectType("Release").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(api.ID).
+			WithGithubID("issue-1").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+
+		service := githubsettings.NewService(
+			ts.Store,
+			subscriptionsClient{subscriptions: map[string]string{
+				"acme/docs":  types.SubscriptionUnsubscribed,
+				"acme/api":   types.SubscriptionIgnored,
+				"acme/quiet": types.SubscriptionSubscribed,
+			}},
+			view.NewService(ts.Store),
+			rulescore.NewService(ts.Store),
+		)
+
+		// acme/quiet has no notifications, so it isn't looked up
+		suggestions, err := service.Suggestions(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 2)
+		require.Equal(t, "releases", suggestions[0].ID)
+		require.Equal(t, "type:release repo:acme/docs", suggestions[0].Query)
+		require.Equal(t, "ignored", suggestions[1].ID)
+		require.False(t, suggestions[0].Adopted)
+
+		adopted, err := service.Adopt(ctx, userID, "releases")
+		require.NoError(t, err)
+		require.NotNil(t, adopted.View)
+
+		var releases *client.View
+		for _, v := range c.ListViews(t) {
+			if v.ID == adopted.View.ID {
+				releases = &v
+			}
+		}
+		require.NotNil(t, releases)
+		require.Equal(t, int64(2), releases.UnreadCount)
+
+		suggestions, err = service.Suggestions(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, suggestions[0].Adopted)
+		_, err = service.Adopt(ctx, userID, "releases")
+		require.ErrorIs(t, err, view.ErrViewNameAlreadyExists)
+
+		adopted, err = service.Adopt(ctx, userID, "ignored")
+		require.NoError(t, err)
+		require.True(t, adopted.Rule.Actions.Mute)
+	})
+}
@@ -0,0 +1,68 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestMarkGroupRead(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		const thread = "https://api.github.com/repos/octo/repo/pulls/1"
+		const other = "https://api.github.com/repos/octo/repo/pulls/2"
+
+		first := fixtures.NewNotification(repo.ID).
+			WithSubjectURL(thread).
+			Build(t, ctx, ts.Store, userID)
+		second := fixtures.NewNotification(repo.ID).
+			WithSubjectURL(thread).
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithSubjectURL(thread).
+			WithIsRead(true).
+			Build(t, ctx, ts.Store, userID)
+		unrelated := fixtures.NewNotification(repo.ID).
+			WithSubjectURL(other).
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.MarkGroupRead(t, thread)
+		require.Equal(t, 2, result.Count)
+		require.ElementsMatch(t, []string{first.GithubID, second.GithubID}, result.GithubIDs)
+
+		require.True(t, c.GetNotification(t, first.GithubID).Notification.IsRead)
+		require.True(t, c.GetNotification(t, second.GithubID).Notification.IsRead)
+		require.False(t, c.GetNotification(t, unrelated.GithubID).Notification.IsRead)
+
+		// Marking an already-read group is a no-op
+		result = c.MarkGroupRead(t, thread)
+		require.Zero(t, result.Count)
+		require.Empty(t, result.GithubIDs)
+	})
+}
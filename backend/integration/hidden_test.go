@@ -0,0 +1,112 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestHidden_ListAndWakeGroups(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		wakeAt := time.Now().Add(4 * time.Hour).UTC().Truncate(time.Second)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("snoozed-notif").
+			WithSnoozedUntil(wakeAt).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("muted-notif").
+			WithMuted(true).
+			Build(t, ctx, ts.Store, userID)
+		// Muted and snoozed only counts as muted
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("muted-snoozed-notif").
+			WithMuted(true).
+			WithSnoozedUntil(wakeAt).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("filtered-notif").
+			WithFiltered(true).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("inbox-notif").
+			Build(t, ctx, ts.Store, userID)
+
+		_, err := ts.Store.UpdateUserMutedUntil(ctx, sql.NullTime{
+			Time:  time.Now().Add(time.Hour),
+			Valid: true,
+		})
+		require.NoError(t, err)
+
+		summary := c.ListHidden(t)
+		require.NotNil(t, summary.AccountMutedUntil)
+
+		snoozed := summary.Group("snoozed")
+		require.NotNil(t, snoozed)
+		require.Equal(t, int64(1), snoozed.Total)
+		require.Equal(t, "snoozed-notif", snoozed.Notifications[0].GithubID)
+		require.NotNil(t, snoozed.Notifications[0].SnoozedUntil)
+		require.True(t, wakeAt.Equal(*snoozed.Notifications[0].SnoozedUntil))
+
+		muted := summary.Group("muted")
+		require.NotNil(t, muted)
+		require.Equal(t, int64(2), muted.Total)
+
+		filtered := summary.Group("filtered")
+		require.NotNil(t, filtered)
+		require.Equal(t, int64(1), filtered.Total)
+
+		// Waking snoozed notifications puts them back in the inbox
+		woken, status := c.WakeHidden(t, "snoozed")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, int64(1), woken)
+		inbox := c.ListNotifications(t, "in:inbox", 1, 100)
+		require.Equal(t, int64(2), inbox.Total)
+
+		woken, status = c.WakeHidden(t, "filtered")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, int64(1), woken)
+
+		woken, status = c.WakeHidden(t, "account")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, int64(1), woken)
+
+		summary = c.ListHidden(t)
+		require.Nil(t, summary.AccountMutedUntil)
+		require.Equal(t, int64(0), summary.Group("snoozed").Total)
+		require.Equal(t, int64(0), summary.Group("filtered").Total)
+		require.Equal(t, int64(2), summary.Group("muted").Total)
+
+		_, status = c.WakeHidden(t, "archived")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
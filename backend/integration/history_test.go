@@ -0,0 +1,96 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestHistory_RecordsUserActionsNewestFirst(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("acme/history").Build(t, ctx, ts.Store, userID)
+		notif := fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, userID)
+		other := fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, userID)
+
+		events, status := c.GetNotificationHistory(t, notif.GithubID)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, events)
+
+		c.ArchiveNotification(t, notif.GithubID)
+		c.UnarchiveNotification(t, notif.GithubID)
+
+		events, status = c.GetNotificationHistory(t, notif.GithubID)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, events, 2)
+		require.Equal(t, "unarchived", events[0].Action)
+		require.Equal(t, "archived", events[1].Action)
+		for _, event := range events {
+			require.Equal(t, "user", event.Source)
+		}
+
+		// Bulk actions by query record every notification they touched
+		require.Equal(t, 2, c.BulkArchive(t, nil, "repo:acme/history").Count)
+		events, _ = c.GetNotificationHistory(t, other.GithubID)
+		require.Len(t, events, 1)
+		require.Equal(t, "archived", events[0].Action)
+		events, _ = c.GetNotificationHistory(t, notif.GithubID)
+		require.Len(t, events, 3)
+		require.Equal(t, "archived", events[0].Action)
+	})
+}
+
+func TestHistory_RecordsBulkActionsLargerThanSQLiteCanBind(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+
+		// More than SQLite's limit of 32766 bound variables per statement
+		const count = 33000
+		repo := fixtures.NewRepository().WithFullName("acme/large").Build(t, ctx, ts.Store, ts.UserID)
+		seedNotifications(t, ts, repo.ID, count)
+
+		require.Equal(t, count, c.BulkArchive(t, nil, "repo:acme/large").Count)
+
+		var recorded int
+		require.NoError(t, ts.DB.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM notification_events WHERE action = 'archived'",
+		).Scan(&recorded))
+		require.Equal(t, count, recorded)
+
+		events, _ := c.GetNotificationHistory(t, "seeded-33000")
+		require.Len(t, events, 1)
+		require.Equal(t, "archived", events[0].Action)
+	})
+}
+
+func TestHistory_UnknownNotification(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		_, status := c.GetNotificationHistory(t, "no-such-thread")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,125 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestWebhookIntegrations(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		var received []integration.Payload
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var payload integration.Payload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			received = append(received, payload)
+			require.Equal(t, integration.Sign("s3cret", body), r.Header.Get(integration.HeaderSignature))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		secret := "s3cret"
+		hook, status := c.CreateWebhook(t, client.WebhookRequest{
+			Name:   "Team feed",
+			URL:    receiver.URL,
+			Secret: &secret,
+		})
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, models.IntegrationFormatJSON, hook.Format)
+		require.True(t, hook.HasSecret)
+
+		_, status = c.CreateWebhook(t, client.WebhookRequest{Name: "Team feed", URL: receiver.URL})
+		require.Equal(t, http.StatusConflict, status)
+		_, status = c.CreateWebhook(t, client.WebhookRequest{Name: "Bad", URL: "ftp://example.com"})
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Len(t, c.ListWebhooks(t), 1)
+
+		repo := fixtures.NewRepository().WithFullName("octo/hooks").Build(t, ctx, ts.Store, userID)
+		mention := fixtures.NewNotification(repo.ID).
+			WithGithubID("hook-mention").
+			WithSubjectTitle("Ping the team").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+		createRule(t, ts, "Mentions to the team", "reason:mention", "", models.RuleActions{
+			NotifyWebhook: []string{hook.ID},
+		})
+
+		_, err := handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, mention.ID)
+		require.NoError(t, err)
+
+		deliveries, status := c.ListWebhookDeliveries(t, hook.ID)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, models.DeliveryPending, deliveries[0].Status)
+
+		attempted, err := ts.Integrations.DeliverDue(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempted)
+
+		require.Len(t, received, 1)
+		require.Equal(t, integration.EventRuleMatched, received[0].Event)
+		require.Equal(t, "Mentions to the team", received[0].Rule.Name)
+		require.Equal(t, "hook-mention", received[0].Notification.GithubID)
+		require.Equal(t, "Ping the team", received[0].Notification.Title)
+		require.Equal(t, "octo/hooks", received[0].Notification.Repository)
+
+		deliveries, _ = c.ListWebhookDeliveries(t, hook.ID)
+		require.Equal(t, models.DeliveryDelivered, deliveries[0].Status)
+		require.Equal(t, int64(1), deliveries[0].Attempts)
+		require.NotNil(t, deliveries[0].ResponseStatus)
+		require.Equal(t, int64(http.StatusNoContent), *deliveries[0].ResponseStatus)
+
+		// Nothing is left to send
+		attempted, err = ts.Integrations.DeliverDue(ctx, userID)
+		require.NoError(t, err)
+		require.Zero(t, attempted)
+
+		// Leaving the secret out of an update keeps it
+		renamed, status := c.UpdateWebhook(t, hook.ID, client.WebhookRequest{
+			Name: "Renamed feed",
+			URL:  receiver.URL,
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "Renamed feed", renamed.Name)
+		require.True(t, renamed.HasSecret)
+
+		require.Equal(t, http.StatusNoContent, c.DeleteWebhook(t, hook.ID))
+		require.Equal(t, http.StatusNotFound, c.DeleteWebhook(t, hook.ID))
+		_, status = c.ListWebhookDeliveries(t, hook.ID)
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,89 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestLiveQuery_PushesMatchChanges(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("inbox-1").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("inbox-2").
+			Build(t, ctx, ts.Store, userID)
+
+		stream := c.SubscribeLiveQuery(t, "in:inbox")
+		defer stream.Close()
+		subscribed := stream.Next(t)
+		require.Equal(t, "subscribed", subscribed.Event)
+		require.NotEmpty(t, subscribed.ID)
+		require.Equal(t, 2, subscribed.Matching)
+
+		// Triage through the API stops a notification matching
+		c.ArchiveNotification(t, "inbox-1")
+		event := stream.Next(t)
+		require.Equal(t, "unmatch", event.Event)
+		require.Equal(t, "inbox-1", event.GithubID)
+
+		// Bulk actions are picked up too
+		c.BulkSnooze(t, client.BulkSnoozeRequest{
+			GithubIDs:    []string{"inbox-2"},
+			SnoozedUntil: time.Now().Add(time.Hour).UTC(),
+		})
+		event = stream.Next(t)
+		require.Equal(t, "unmatch", event.Event)
+		require.Equal(t, "inbox-2", event.GithubID)
+
+		// A newly synced notification starts matching
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("synced-1").
+			Build(t, ctx, ts.Store, userID)
+		ts.LiveQueries.NotificationChanged(ctx, userID, "synced-1")
+		event = stream.Next(t)
+		require.Equal(t, "match", event.Event)
+		require.Equal(t, "synced-1", event.GithubID)
+
+		c.UnarchiveNotification(t, "inbox-1")
+		event = stream.Next(t)
+		require.Equal(t, "match", event.Event)
+		require.Equal(t, "inbox-1", event.GithubID)
+	})
+}
+
+func TestLiveQuery_InvalidQuery(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, _ *testserver.TestServer, c *client.Client) {
+		stream := c.SubscribeLiveQuery(t, "repo:(")
+		require.Equal(t, http.StatusBadRequest, stream.StatusCode)
+	})
+}
@@ -0,0 +1,92 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestNextNotification(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("cli/cli").Build(t, ctx, ts.Store, userID)
+		now := time.Now().UTC().Truncate(time.Second)
+		build := func(githubID string, age time.Duration) {
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(githubID).
+				WithGithubUpdatedAt(now.Add(-age)).
+				Build(t, ctx, ts.Store, userID)
+		}
+		build("newest", time.Hour)
+		// Notifications updated at the same time still get a position of their own
+		build("tied-1", 2*time.Hour)
+		build("tied-2", 2*time.Hour)
+		build("oldest", 3*time.Hour)
+
+		order := c.ListNotifications(t, "", 0, 0)
+		ids := make([]string, 0, len(order.Notifications))
+		for _, n := range order.Notifications {
+			ids = append(ids, n.GithubID)
+		}
+		require.Len(t, ids, 4)
+		require.Equal(t, "newest", ids[0])
+		require.Equal(t, "oldest", ids[3])
+
+		// Stepping forward from the start visits the list in order, then runs out
+		var visited []string
+		cursor := ""
+		for {
+			next, status := c.NextNotification(t, "", cursor, false)
+			require.Equal(t, http.StatusOK, status)
+			if next == nil {
+				break
+			}
+			visited = append(visited, next.GithubID)
+			cursor = next.GithubID
+		}
+		require.Equal(t, ids, visited)
+
+		// Stepping back retraces it
+		prev, status := c.NextNotification(t, "", ids[2], true)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, ids[1], prev.GithubID)
+		prev, _ = c.NextNotification(t, "", ids[0], true)
+		require.Nil(t, prev)
+
+		// Archiving the current notification and advancing lands on the one after it
+		c.ArchiveNotification(t, ids[1])
+		next, status := c.NextNotification(t, "", ids[1], false)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, ids[2], next.GithubID)
+
+		_, status = c.NextNotification(t, "", "missing", false)
+		require.Equal(t, http.StatusNotFound, status)
+		_, status = c.NextNotification(t, "is:", "", false)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
@@ -0,0 +1,99 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestGroupNotifications(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		cli := fixtures.NewRepository().WithFullName("cli/cli").Build(t, ctx, ts.Store, userID)
+		docs := fixtures.NewRepository().WithFullName("cli/docs").Build(t, ctx, ts.Store, userID)
+		widgets := fixtures.NewRepository().WithFullName("octo/widgets").Build(t, ctx, ts.Store, userID)
+
+		now := time.Now().UTC()
+		build := func(githubID string, repoID int64, author string, age time.Duration) {
+			b := fixtures.NewNotification(repoID).
+				WithGithubID(githubID).
+				WithReason("review_requested").
+				WithGithubUpdatedAt(now.Add(-age))
+			if author != "" {
+				b = b.WithAuthorLogin(author)
+			}
+			b.Build(t, ctx, ts.Store, userID)
+		}
+		build("cli-old", cli.ID, "alice", 3*time.Hour)
+		build("cli-new", cli.ID, "bob", time.Hour)
+		build("docs", docs.ID, "alice", 2*time.Hour)
+		build("widgets", widgets.ID, "", 30*time.Minute)
+		fixtures.NewNotification(widgets.ID).
+			WithGithubID("archived").
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+
+		keys := func(result *client.GroupNotificationsResponse) []string {
+			keys := make([]string, 0, len(result.Groups))
+			for _, g := range result.Groups {
+				keys = append(keys, g.Key)
+			}
+			return keys
+		}
+
+		// Groups the inbox, most recently active group first, with the newest of each
+		result, status := c.GroupNotifications(t, "", "repo", 1)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"octo/widgets", "cli/cli", "cli/docs"}, keys(result))
+		require.Equal(t, int64(4), result.Total)
+		require.Equal(t, int64(2), result.Groups[1].Count)
+		require.Len(t, result.Groups[1].Notifications, 1)
+		require.Equal(t, "cli-new", result.Groups[1].Notifications[0].GithubID)
+
+		result, status = c.GroupNotifications(t, "", "org", 5)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"octo", "cli"}, keys(result))
+		require.Equal(t, int64(3), result.Groups[1].Count)
+		require.Len(t, result.Groups[1].Notifications, 3)
+
+		// Notifications without an author share the empty group
+		result, status = c.GroupNotifications(t, "", "author", 0)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"", "bob", "alice"}, keys(result))
+		require.Empty(t, result.Groups[0].Notifications)
+
+		// Queries that join repositories themselves group the same way
+		result, status = c.GroupNotifications(t, "repo:cli in:anywhere", "repo", 5)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"cli/cli", "cli/docs"}, keys(result))
+
+		_, status = c.GroupNotifications(t, "", "label", 5)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
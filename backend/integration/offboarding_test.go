@@ -0,0 +1,130 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestOffboarding_ArchivesOrgNotifications(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		widgets := fixtures.NewRepository().
+			WithGithubID(101).
+			WithName("widgets").
+			WithFullName("acme/widgets").
+			WithOwnerLogin("acme").
+			Build(t, ctx, ts.Store, userID)
+		gadgets := fixtures.NewRepository().
+			WithGithubID(102).
+			WithName("gadgets").
+			WithFullName("acme/gadgets").
+			WithOwnerLogin("acme").
+			Build(t, ctx, ts.Store, userID)
+		// A similarly named org is left alone
+		other := fixtures.NewRepository().
+			WithGithubID(103).
+			WithName("widgets").
+			WithFullName("acme-labs/widgets").
+			WithOwnerLogin("acme-labs").
+			Build(t, ctx, ts.Store, userID)
+
+		fixtures.NewNotification(widgets.ID).WithGithubID("acme-1").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(gadgets.ID).WithGithubID("acme-2").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(other.ID).WithGithubID("labs-1").Build(t, ctx, ts.Store, userID)
+
+		summary, status := c.OffboardOrg(t, "acme", "")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "acme", summary.Org)
+		require.Equal(t, "archive", summary.Action)
+		require.Equal(t, int64(2), summary.NotificationsAffected)
+		require.Equal(t, int64(2), summary.Repositories)
+
+		require.True(t, c.GetNotification(t, "acme-1").Notification.Archived)
+		require.True(t, c.GetNotification(t, "acme-2").Notification.Archived)
+		require.False(t, c.GetNotification(t, "labs-1").Notification.Archived)
+
+		offboardings := c.ListOffboardings(t)
+		require.Len(t, offboardings, 1)
+		require.Equal(t, int64(2), offboardings[0].NotificationsAffected)
+
+		offboarded, err := ts.Store.IsOrgOffboarded(ctx, userID, "ACME")
+		require.NoError(t, err)
+		require.True(t, offboarded)
+
+		// Rejoining syncs the org again but leaves its notifications archived
+		require.Equal(t, http.StatusNoContent, c.RejoinOrg(t, "acme"))
+		require.Empty(t, c.ListOffboardings(t))
+		require.True(t, c.GetNotification(t, "acme-1").Notification.Archived)
+		require.Equal(t, http.StatusNotFound, c.RejoinOrg(t, "acme"))
+	})
+}
+
+func TestOffboarding_DeletesOrgNotifications(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		widgets := fixtures.NewRepository().
+			WithGithubID(101).
+			WithName("widgets").
+			WithFullName("acme/widgets").
+			WithOwnerLogin("acme").
+			Build(t, ctx, ts.Store, userID)
+		other := fixtures.NewRepository().
+			WithGithubID(103).
+			WithName("widgets").
+			WithFullName("acme-labs/widgets").
+			WithOwnerLogin("acme-labs").
+			Build(t, ctx, ts.Store, userID)
+
+		fixtures.NewNotification(widgets.ID).WithGithubID("acme-1").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(widgets.ID).WithGithubID("acme-2").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(other.ID).WithGithubID("labs-1").Build(t, ctx, ts.Store, userID)
+
+		summary, status := c.OffboardOrg(t, "acme", "delete")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, int64(2), summary.NotificationsAffected)
+		require.Equal(t, int64(1), summary.Repositories)
+
+		remaining := c.ListNotifications(t, "in:anywhere", 1, 50)
+		require.Equal(t, int64(1), remaining.Total)
+		require.Equal(t, "labs-1", remaining.Notifications[0].GithubID)
+	})
+}
+
+func TestOffboarding_RejectsInvalidRequests(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, _ *testserver.TestServer, c *client.Client) {
+		_, status := c.OffboardOrg(t, "acme/widgets", "")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		_, status = c.OffboardOrg(t, "acme", "mute")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		require.Empty(t, c.ListOffboardings(t))
+	})
+}
@@ -0,0 +1,77 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestPlainNotifications_ListsOneLinePerNotification(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().WithFullName("octo/repo").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("review-notif").
+			WithSubjectTitle("Review the\nparser rewrite").
+			WithReason("review_requested").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("mention-notif").
+			WithSubjectTitle("Mentioned in release notes").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+
+		body, contentType, status := c.PlainNotifications(t, "reason:review_requested", "")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "text/plain; charset=utf-8", contentType)
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		require.Len(t, lines, 2)
+		require.Equal(t, "Showing 1 to 1 of 1 notification.", lines[0])
+		require.Contains(t, lines[1], "in octo/repo: Review the parser rewrite.")
+		require.Contains(t, lines[1], "Review requested.")
+
+		body, contentType, status = c.PlainNotifications(t, "", "jsonl")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "application/x-ndjson", contentType)
+		lines = strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		require.Len(t, lines, 2)
+		ids := make([]string, 0, len(lines))
+		for _, line := range lines {
+			var item map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &item))
+			require.Equal(t, "octo/repo", item["repo"])
+			ids = append(ids, item["id"].(string))
+		}
+		require.ElementsMatch(t, []string{"review-notif", "mention-notif"}, ids)
+
+		_, _, status = c.PlainNotifications(t, "", "xml")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
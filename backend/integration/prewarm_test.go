@@ -0,0 +1,97 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func TestPrewarm(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		opened := fixtures.NewNotification(repo.ID).WithIsRead(false).Build(t, ctx, ts.Store, userID)
+
+		// Opening lists and notifications is remembered, most recent first
+		c.ListNotifications(t, "in:inbox", 1, 25)
+		c.ListNotifications(t, "in:inbox", 2, 25) // Later pages aren't
+		c.ListNotifications(t, "is:unread", 0, 0)
+		c.GetNotification(t, opened.GithubID)
+
+		queries, err := ts.Prewarm.RecentAccess(ctx, userID, prewarm.KindQuery)
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		require.Equal(t, "is:unread", queries[0].Target)
+		require.Equal(t, "in:inbox", queries[1].Target)
+
+		notifications, err := ts.Prewarm.RecentAccess(ctx, userID, prewarm.KindNotification)
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		require.Equal(t, opened.GithubID, notifications[0].Target)
+
+		// Only the most recent few of each kind are kept
+		for i := 0; i < prewarm.KeepRecent+3; i++ {
+			c.ListNotifications(t, fmt.Sprintf("repo:octo/repo-%d", i), 1, 25)
+		}
+		queries, err = ts.Prewarm.RecentAccess(ctx, userID, prewarm.KindQuery)
+		require.NoError(t, err)
+		require.Len(t, queries, prewarm.KeepRecent)
+
+		// Warm-up without GitHub still prepares the sidebar counts
+		handler := handlers.NewWarmCachesHandler(
+			ts.Store,
+			ts.Prewarm,
+			view.NewService(ts.Store),
+			time.Now,
+			zap.NewNop(),
+		)
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, result.Counts)
+
+		inboxUnread := func(views []client.View) int64 {
+			for _, v := range views {
+				if v.Slug == "inbox" {
+					return v.UnreadCount
+				}
+			}
+			t.Fatal("inbox view not listed")
+			return 0
+		}
+
+		// The warmed counts are served once, then counts are live again
+		fixtures.NewNotification(repo.ID).WithIsRead(false).Build(t, ctx, ts.Store, userID)
+		require.Equal(t, int64(1), inboxUnread(c.ListViews(t)))
+		require.Equal(t, int64(2), inboxUnread(c.ListViews(t)))
+	})
+}
@@ -0,0 +1,143 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestParseQuery_CanonicalMatchesSameNotifications(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		repo := fixtures.NewRepository().WithFullName("octo/cli").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).WithReason("mention").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).WithReason("review_requested").
+			Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).WithReason("subscribed").WithIsRead(true).
+			Build(t, ctx, ts.Store, ts.UserID)
+
+		query := "repo:octo/cli  (reason:mention OR NOT is:read) -reason:review_requested"
+		parsed, status := c.ParseQuery(t, query)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, query, parsed.Query)
+		require.Equal(t,
+			"repo:octo/cli AND (reason:mention OR -is:read) AND -reason:review_requested",
+			parsed.Canonical)
+		require.Contains(t, string(parsed.AST), `"type":"binary"`)
+
+		// The canonical string is the same query as far as the backend is concerned
+		original := c.ListNotifications(t, query, 1, 100)
+		canonical := c.ListNotifications(t, parsed.Canonical, 1, 100)
+		require.Equal(t, int64(1), original.Total)
+		require.Equal(t, original.Total, canonical.Total)
+
+		reparsed, status := c.ParseQuery(t, parsed.Canonical)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, parsed.Canonical, reparsed.Canonical)
+
+		_, status = c.ParseQuery(t, "reason:")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQueryDefaults_NegatedInKeepsMutedHidden(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).WithGithubID("archived").WithArchived(true).
+			Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).WithGithubID("muted").WithMuted(true).
+			Build(t, ctx, ts.Store, ts.UserID)
+
+		resp := c.ListNotifications(t, "-in:snoozed", 1, 100)
+		require.Len(t, resp.Notifications, 1)
+		require.Equal(t, "archived", resp.Notifications[0].GithubID)
+
+		defaults := c.ExplainQueryDefaults(t, "-in:snoozed")
+		require.NotNil(t, defaults)
+		require.Equal(t, "mutedOnly", defaults.Scope)
+		require.Equal(t, []string{"muted"}, defaults.Excluded)
+
+		// Asking for muted, or picking a scope with in:, turns the default off
+		resp = c.ListNotifications(t, "is:muted -in:snoozed", 1, 100)
+		require.Len(t, resp.Notifications, 1)
+		require.Equal(t, "muted", resp.Notifications[0].GithubID)
+		require.Equal(t, "none", c.ExplainQueryDefaults(t, "in:anywhere").Scope)
+
+		parsed, status := c.ParseQuery(t, "")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "inbox", parsed.Defaults.Scope)
+		inbox := []string{"archived", "snoozed", "muted", "filtered"}
+		require.Equal(t, inbox, parsed.Defaults.Excluded)
+
+		// Without the flag the response doesn't carry defaults
+		require.Nil(t, c.ListNotifications(t, "-in:snoozed", 1, 100).Defaults)
+	})
+}
+
+func TestSuggestQuery_CompletesFromLocalData(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		cli := fixtures.NewRepository().WithFullName("octo/cli").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewRepository().WithFullName("acme/client").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithAuthorLogin("octocat").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithAuthorLogin("octocat").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithAuthorLogin("octo_bot").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithAuthorLogin("hubot").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewTag().WithName("Needs review").WithSlug("needs-review").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithLabels("good first issue", "🐛 bug").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithLabels("good first issue").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(cli.ID).WithLabels("Good-to-merge").Build(t, ctx, ts.Store, ts.UserID)
+
+		values := func(result *client.QuerySuggestions) []string {
+			var out []string
+			for _, suggestion := range result.Suggestions {
+				out = append(out, suggestion.Value)
+			}
+			return out
+		}
+
+		result := c.SuggestQuery(t, "is:unread repo:cli", 18)
+		require.Equal(t, "repo", result.Field)
+		require.Equal(t, 15, result.Start)
+		require.Equal(t, []string{"acme/client", "octo/cli"}, values(result))
+
+		// The most frequent author comes first, and _ isn't a wildcard
+		require.Equal(t, []string{"octocat", "octo_bot"}, values(c.SuggestQuery(t, `author:"OCTO`, 12)))
+		require.Equal(t, []string{"octo_bot"}, values(c.SuggestQuery(t, "author:octo_", 12)))
+
+		require.Equal(t, []string{"acme", "octo"}, values(c.SuggestQuery(t, "org:", 4)))
+		require.Equal(t, []string{"needs-review"}, values(c.SuggestQuery(t, "tags:needs (", 10)))
+
+		// Labels with spaces come back quoted, ready to insert
+		require.Equal(t, []string{`"good first issue"`, "Good-to-merge"}, values(c.SuggestQuery(t, "label:go", 8)))
+		require.Equal(t, []string{`"🐛 bug"`}, values(c.SuggestQuery(t, `label:"🐛`, 11)))
+
+		fields := c.SuggestQuery(t, "-au", 3)
+		require.Equal(t, []string{"author:"}, values(fields))
+		require.Equal(t, "field", fields.Suggestions[0].Kind)
+	})
+}
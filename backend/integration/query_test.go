@@ -19,6 +19,8 @@ package integration
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/octobud-hq/octobud/backend/integration/client"
 	"github.com/octobud-hq/octobud/backend/integration/fixtures"
 	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
 )
 
 func TestQuery_InInbox(t *testing.T) {
@@ -326,6 +329,452 @@ func TestQuery_RepoFilter(t *testing.T) {
 	})
 }
 
+func TestQuery_KindFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		pr := fixtures.NewNotification(repo.ID).
+			WithGithubID("pr-notif").
+			WithSubjectType("PullRequest").
+			Build(t, ctx, ts.Store, userID)
+		issue := fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("release-notif").
+			WithSubjectType("Release").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "kind:code", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, pr.GithubID, result.Notifications[0].GithubID)
+		require.Equal(t, db.ContentKindCode, result.Notifications[0].ContentKind)
+
+		result = c.ListNotifications(t, "kind:discussion", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, issue.GithubID, result.Notifications[0].GithubID)
+
+		result = c.ListNotifications(t, "-kind:other", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+	})
+}
+
+func TestQuery_BranchFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		backport := fixtures.NewNotification(repo.ID).
+			WithGithubID("backport-notif").
+			WithBranches("backport/fix-login", "release/1.2").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("feature-notif").
+			WithBranches("feature/search", "main").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "branch:release/*", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, backport.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].HeadBranch)
+		require.Equal(t, "backport/fix-login", *result.Notifications[0].HeadBranch)
+		require.NotNil(t, result.Notifications[0].BaseBranch)
+		require.Equal(t, "release/1.2", *result.Notifications[0].BaseBranch)
+
+		// Head branches match too, and values without * match whole names only
+		result = c.ListNotifications(t, "branch:feature/search", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		result = c.ListNotifications(t, "branch:feature", 1, 100)
+		require.Equal(t, int64(0), result.Total)
+
+		// Negating keeps notifications without branches, like rules do
+		result = c.ListNotifications(t, "-branch:release/*", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+	})
+}
+
+func TestQuery_PullRequestMetadataFilters(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		ready := fixtures.NewNotification(repo.ID).
+			WithGithubID("ready-notif").
+			WithReason("review_requested").
+			WithDraft(false).
+			WithLabels("bug", "Needs Review").
+			WithReviewState("pending").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("draft-notif").
+			WithReason("review_requested").
+			WithDraft(true).
+			WithReviewState("pending").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("approved-notif").
+			WithReason("review_requested").
+			WithDraft(false).
+			WithReviewState("approved").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			WithLabels("bug").
+			Build(t, ctx, ts.Store, userID)
+
+		// Non-draft pull requests still waiting on a review
+		result := c.ListNotifications(t, "draft:false review:pending reason:review_requested", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, ready.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].SubjectDraft)
+		require.False(t, *result.Notifications[0].SubjectDraft)
+		require.Equal(t, []string{"bug", "Needs Review"}, result.Notifications[0].Labels)
+		require.NotNil(t, result.Notifications[0].ReviewState)
+		require.Equal(t, "pending", *result.Notifications[0].ReviewState)
+
+		result = c.ListNotifications(t, "draft:true", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		result = c.ListNotifications(t, "review:approved,changes_requested", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+
+		// Labels match whole names case-insensitively, on issues too
+		result = c.ListNotifications(t, `label:"needs review"`, 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		result = c.ListNotifications(t, "label:bug", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, "label:bu", 1, 100)
+		require.Equal(t, int64(0), result.Total)
+
+		// Negating keeps notifications the field doesn't apply to, like rules do
+		result = c.ListNotifications(t, "-draft:true", 1, 100)
+		require.Equal(t, int64(3), result.Total)
+		result = c.ListNotifications(t, "-review:pending", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+	})
+}
+
+func TestQuery_CIFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		red := fixtures.NewNotification(repo.ID).
+			WithGithubID("red-notif").
+			WithCIState("failure").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("green-notif").
+			WithCIState("success").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("running-notif").
+			WithCIState("pending").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "ci:failing", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, red.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].CIState)
+		require.Equal(t, "failure", *result.Notifications[0].CIState)
+
+		// GitHub's state names work too
+		result = c.ListNotifications(t, "ci:success,pending", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		// Negating keeps notifications without a CI state
+		result = c.ListNotifications(t, "-ci:passing", 1, 100)
+		require.Equal(t, int64(3), result.Total)
+
+		_, status := c.ListNotificationsAfter(t, "ci:red", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_WaitingOnFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		mine := fixtures.NewNotification(repo.ID).
+			WithGithubID("my-turn-notif").
+			WithWaitingOn("me").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("their-turn-notif").
+			WithWaitingOn("them").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("watching-notif").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "waiting-on:me", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, mine.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].WaitingOn)
+		require.Equal(t, "me", *result.Notifications[0].WaitingOn)
+
+		result = c.ListNotifications(t, "waiting-on:me,them", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		// Negating keeps notifications that aren't waiting on anyone
+		result = c.ListNotifications(t, "-waiting-on:them", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		_, status := c.ListNotificationsAfter(t, "waiting-on:you", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_PriorityFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		now := time.Now().UTC().Truncate(time.Second)
+
+		urgent := fixtures.NewNotification(repo.ID).
+			WithGithubID("urgent-notif").
+			WithGithubUpdatedAt(now.Add(-3*time.Hour)).
+			WithPriority(45).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("routine-notif").
+			WithGithubUpdatedAt(now.Add(-2*time.Hour)).
+			WithPriority(10).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("another-routine-notif").
+			WithGithubUpdatedAt(now.Add(-1*time.Hour)).
+			WithPriority(10).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("unscored-notif").
+			WithGithubUpdatedAt(now).
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "priority:>20", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, urgent.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].Priority)
+		require.Equal(t, int64(45), *result.Notifications[0].Priority)
+
+		result = c.ListNotifications(t, "priority:10", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		// Unscored notifications don't match any score
+		result = c.ListNotifications(t, "priority:<20", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		_, status := c.ListNotificationsAfter(t, "priority:high", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_PrioritySort(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		now := time.Now().UTC().Truncate(time.Second)
+
+		scores := map[string]int64{"low": 5, "high": 40, "mid-old": 20, "mid-new": 20}
+		updated := map[string]time.Duration{"low": 1, "high": 4, "mid-old": 3, "mid-new": 2}
+		for id, score := range scores {
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(id).
+				WithGithubUpdatedAt(now.Add(-updated[id]*time.Hour)).
+				WithPriority(score).
+				Build(t, ctx, ts.Store, userID)
+		}
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("unscored").
+			WithGithubUpdatedAt(now).
+			Build(t, ctx, ts.Store, userID)
+
+		var listed []string
+		cursor := ""
+		for {
+			page, status := c.ListNotificationsSorted(t, "", "priority", cursor, 2)
+			require.Equal(t, http.StatusOK, status)
+			for _, n := range page.Notifications {
+				listed = append(listed, n.GithubID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		require.Equal(t, []string{"high", "mid-new", "mid-old", "low", "unscored"}, listed)
+
+		_, status := c.ListNotificationsSorted(t, "", "loudest", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_MergeableFilter(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		conflicted := fixtures.NewNotification(repo.ID).
+			WithGithubID("conflicted-notif").
+			WithMergeable(false).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("clean-notif").
+			WithMergeable(true).
+			Build(t, ctx, ts.Store, userID)
+		// GitHub hadn't worked out mergeability yet
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("unknown-notif").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "mergeable:false", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, conflicted.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].Mergeable)
+		require.False(t, *result.Notifications[0].Mergeable)
+
+		result = c.ListNotifications(t, "mergeable:true", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.True(t, *result.Notifications[0].Mergeable)
+
+		_, status := c.ListNotificationsAfter(t, "mergeable:maybe", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_RequestedReviewFilters(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		reviewRequest := func(githubID string, number int32, reviewers, teams string) {
+			t.Helper()
+			pr, err := ts.Store.UpsertPullRequest(ctx, userID, db.UpsertPullRequestParams{
+				RepositoryID:       repo.ID,
+				Number:             number,
+				RequestedReviewers: db.NullRawMessage{RawMessage: []byte(reviewers), Valid: true},
+				RequestedTeams:     db.NullRawMessage{RawMessage: []byte(teams), Valid: true},
+			})
+			require.NoError(t, err)
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(githubID).
+				WithReason("review_requested").
+				WithPullRequestID(pr.ID).
+				Build(t, ctx, ts.Store, userID)
+		}
+
+		reviewRequest("team-notif", 1, `[]`, `["acme/core"]`)
+		reviewRequest("both-notif", 2, `["alice"]`, `["acme/core","acme/docs"]`)
+		reviewRequest("alice-notif", 3, `["Alice","bob"]`, `[]`)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ListNotifications(t, "team-review-requested:acme/core", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, "team-review-requested:ACME/docs", 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, "both-notif", result.Notifications[0].GithubID)
+
+		// Reviewers match whole logins case-insensitively
+		result = c.ListNotifications(t, "reviewer:alice", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, "reviewer:ali", 1, 100)
+		require.Equal(t, int64(0), result.Total)
+		result = c.ListNotifications(t, "reviewer:bob OR team-review-requested:acme/docs", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		// Negating keeps notifications without a pull request
+		result = c.ListNotifications(t, "-team-review-requested:acme/core", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+
+		_, status := c.ListNotificationsAfter(t, "team-review-requested:core", "", 10)
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestQuery_MilestoneAndProjectFilters(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		release := fixtures.NewNotification(repo.ID).
+			WithGithubID("release-notif").
+			WithSubjectType("Issue").
+			WithMilestone("v2.0").
+			WithProjects("Q3 Roadmap", "Bug Triage").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("release-pr-notif").
+			WithMilestone("V2.0").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("next-notif").
+			WithMilestone("v2.1").
+			WithProjects("Q3 Roadmap").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("untracked-notif").
+			WithSubjectType("Issue").
+			Build(t, ctx, ts.Store, userID)
+
+		// Milestones match whole titles case-insensitively
+		result := c.ListNotifications(t, "milestone:v2.0", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, "milestone:v2", 1, 100)
+		require.Equal(t, int64(0), result.Total)
+		result = c.ListNotifications(t, "milestone:v2.0,v2.1", 1, 100)
+		require.Equal(t, int64(3), result.Total)
+
+		result = c.ListNotifications(t, `project:"q3 roadmap"`, 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, `project:"Bug Triage" milestone:v2.0`, 1, 100)
+		require.Equal(t, int64(1), result.Total)
+		require.Equal(t, release.GithubID, result.Notifications[0].GithubID)
+		require.NotNil(t, result.Notifications[0].Milestone)
+		require.Equal(t, "v2.0", *result.Notifications[0].Milestone)
+		require.Equal(t, []string{"Q3 Roadmap", "Bug Triage"}, result.Notifications[0].Projects)
+
+		// Negating keeps notifications without a milestone or projects
+		result = c.ListNotifications(t, "-milestone:v2.0", 1, 100)
+		require.Equal(t, int64(2), result.Total)
+		result = c.ListNotifications(t, `-project:"Q3 Roadmap"`, 1, 100)
+		require.Equal(t, int64(2), result.Total)
+	})
+}
+
 func TestQuery_OrgFilter(t *testing.T) {
 	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
 		ctx := context.Background()
@@ -358,6 +807,43 @@ func TestQuery_OrgFilter(t *testing.T) {
 	})
 }
 
+func TestQuery_RenamedRepoMatchesOldAndNewNames(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().
+			WithName("widget").
+			WithFullName("old-org/widget").
+			Build(t, ctx, ts.Store, userID)
+
+		notif := fixtures.NewNotification(repo.ID).
+			WithGithubID("renamed-repo-notif").
+			Build(t, ctx, ts.Store, userID)
+
+		// Repository was transferred to a new org
+		err := ts.Store.RenameRepository(ctx, userID, db.RenameRepositoryParams{
+			ID:          repo.ID,
+			OldFullName: "old-org/widget",
+			FullName:    "new-org/widget",
+			Name:        "widget",
+			OwnerLogin:  sql.NullString{String: "new-org", Valid: true},
+		})
+		require.NoError(t, err)
+
+		for _, q := range []string{
+			"repo:new-org/widget in:anywhere",
+			"repo:old-org/widget in:anywhere",
+			"org:new-org in:anywhere",
+			"org:old-org in:anywhere",
+		} {
+			result := c.ListNotifications(t, q, 1, 100)
+			require.Equal(t, int64(1), result.Total, q)
+			require.Equal(t, notif.GithubID, result.Notifications[0].GithubID, q)
+		}
+	})
+}
+
 func TestQuery_CombinedFilters(t *testing.T) {
 	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
 		ctx := context.Background()
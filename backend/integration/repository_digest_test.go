@@ -0,0 +1,86 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestRepositoryDigest(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		busy := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		quiet := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		base := time.Now().UTC().Add(-10 * time.Hour).Truncate(time.Second)
+		titles := []string{"First", "Second", "Third", "Fourth"}
+		reasons := []string{"mention", "mention", "review_requested", "subscribed"}
+		for i, title := range titles {
+			fixtures.NewNotification(busy.ID).
+				WithSubjectTitle(title).
+				WithReason(reasons[i]).
+				WithGithubUpdatedAt(base.Add(time.Duration(i)*time.Hour)).
+				Build(t, ctx, ts.Store, userID)
+		}
+		// Read, archived and muted notifications are left out of the digest
+		fixtures.NewNotification(busy.ID).WithIsRead(true).Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(busy.ID).WithArchived(true).Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(busy.ID).WithMuted(true).Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(quiet.ID).WithIsRead(true).Build(t, ctx, ts.Store, userID)
+
+		repos := c.ListRepositories(t)
+		byID := make(map[int64]client.Repository, len(repos))
+		for _, repo := range repos {
+			byID[repo.ID] = repo
+		}
+
+		digest := byID[busy.ID].Digest
+		require.NotNil(t, digest)
+		require.Equal(t, int64(4), digest.UnreadCount)
+		require.Equal(t, map[string]int64{
+			"mention":          2,
+			"review_requested": 1,
+			"subscribed":       1,
+		}, digest.ReasonCounts)
+		require.NotNil(t, digest.OldestUnreadAt)
+		require.True(t, digest.OldestUnreadAt.Equal(base))
+		require.GreaterOrEqual(t, digest.OldestUnreadAgeSeconds, int64(10*60*60))
+		require.Len(t, digest.RecentUnread, 3)
+		require.Equal(t, "Fourth", digest.RecentUnread[0].Title)
+		require.Equal(t, "Third", digest.RecentUnread[1].Title)
+		require.Equal(t, "Second", digest.RecentUnread[2].Title)
+
+		quietDigest := byID[quiet.ID].Digest
+		require.NotNil(t, quietDigest)
+		require.Zero(t, quietDigest.UnreadCount)
+		require.Empty(t, quietDigest.RecentUnread)
+
+		detail := c.GetRepository(t, busy.ID)
+		require.Equal(t, busy.FullName, detail.FullName)
+		require.Equal(t, digest, detail.Digest)
+	})
+}
@@ -0,0 +1,120 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func TestMergeDuplicateRepositories(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		// The older row still carries the name the repository had before it was transferred
+		stale := fixtures.NewRepository().
+			WithGithubID(4242).
+			WithFullName("old-org/widgets").
+			Build(t, ctx, ts.Store, userID)
+		current := fixtures.NewRepository().
+			WithGithubID(4242).
+			WithFullName("octo/widgets").
+			Build(t, ctx, ts.Store, userID)
+
+		pullRequest := func(repoID int64, number int32) db.PullRequest {
+			t.Helper()
+			pr, err := ts.Store.UpsertPullRequest(ctx, userID, db.UpsertPullRequestParams{
+				RepositoryID: repoID,
+				Number:       number,
+			})
+			require.NoError(t, err)
+			return pr
+		}
+		stalePR1 := pullRequest(stale.ID, 1)
+		stalePR2 := pullRequest(stale.ID, 2)
+		currentPR1 := pullRequest(current.ID, 1)
+
+		fixtures.NewNotification(stale.ID).
+			WithGithubID("merge-1").
+			WithPullRequestID(stalePR1.ID).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(stale.ID).
+			WithGithubID("merge-2").
+			WithPullRequestID(stalePR2.ID).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(current.ID).
+			WithGithubID("merge-3").
+			WithPullRequestID(currentPR1.ID).
+			Build(t, ctx, ts.Store, userID)
+
+		_, err := ts.Store.UpsertRepositorySettings(ctx, userID, db.UpsertRepositorySettingsParams{
+			RepositoryID:  stale.ID,
+			DefaultSnooze: sql.NullString{String: "tomorrow", Valid: true},
+		})
+		require.NoError(t, err)
+
+		handler := handlers.NewMergeDuplicateRepositoriesHandler(ts.Store, zap.NewNop())
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []handlers.RepositoryMerge{{
+			KeptID:             current.ID,
+			KeptFullName:       "octo/widgets",
+			MergedID:           stale.ID,
+			MergedFullName:     "old-org/widgets",
+			NotificationsMoved: 2,
+			PullRequestsMoved:  2,
+		}}, result.Merges)
+
+		for _, repo := range c.ListRepositories(t) {
+			require.NotEqual(t, stale.ID, repo.ID)
+		}
+
+		// The stale name became an alias, so filters written against it still match everything
+		resp := c.ListNotifications(t, "repo:old-org/widgets", 1, 50)
+		require.Equal(t, int64(3), resp.Total)
+		resp = c.ListNotifications(t, "repo:octo/widgets", 1, 50)
+		require.Equal(t, int64(3), resp.Total)
+
+		// Notifications on a pull request both rows had now share the kept row's copy
+		merged, err := ts.Store.GetNotificationByGithubID(ctx, userID, "merge-1")
+		require.NoError(t, err)
+		require.Equal(t, current.ID, merged.RepositoryID)
+		require.Equal(t, currentPR1.ID, merged.PullRequestID.Int64)
+		moved, err := ts.Store.GetNotificationByGithubID(ctx, userID, "merge-2")
+		require.NoError(t, err)
+		require.Equal(t, stalePR2.ID, moved.PullRequestID.Int64)
+
+		settings, err := ts.Store.GetRepositorySettings(ctx, userID, current.ID)
+		require.NoError(t, err)
+		require.Equal(t, "tomorrow", settings.DefaultSnooze.String)
+
+		result, err = handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, result.Merges)
+	})
+}
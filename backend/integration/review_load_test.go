@@ -0,0 +1,79 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestReviewLoad(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		reviewRequest := func(number int32, updatedAt time.Time, reviewers ...string) {
+			t.Helper()
+			reviewersJSON, err := json.Marshal(reviewers)
+			require.NoError(t, err)
+			pr, err := ts.Store.UpsertPullRequest(ctx, userID, db.UpsertPullRequestParams{
+				RepositoryID:       repo.ID,
+				Number:             number,
+				RequestedReviewers: db.NullRawMessage{RawMessage: reviewersJSON, Valid: true},
+			})
+			require.NoError(t, err)
+			fixtures.NewNotification(repo.ID).
+				WithReason("review_requested").
+				WithPullRequestID(pr.ID).
+				WithGithubUpdatedAt(updatedAt).
+				Build(t, ctx, ts.Store, userID)
+		}
+
+		recent := time.Now().UTC().Add(-time.Hour)
+		reviewRequest(1, recent, "alice")
+		reviewRequest(2, recent, "alice", "bob")
+		reviewRequest(3, recent, "alice")
+		reviewRequest(4, recent)
+		// Outside the window
+		reviewRequest(5, time.Now().UTC().AddDate(0, 0, -30), "bob")
+
+		require.Equal(t, []string{"alice", "bob", "carol"},
+			c.UpdateTeamSettings(t, []string{"alice", "@bob", "carol"}))
+
+		report := c.GetReviewLoad(t, 7)
+		require.Equal(t, 4, report.TotalRequests)
+		require.Equal(t, 1, report.Unassigned)
+		require.Len(t, report.Reviewers, 3)
+		require.Equal(t, "alice", report.Reviewers[0].Login)
+		require.Equal(t, 3, report.Reviewers[0].Requests)
+		require.True(t, report.Reviewers[0].Overloaded)
+		require.Equal(t, 1, report.Reviewers[1].Requests)
+		require.False(t, report.Reviewers[1].Overloaded)
+		require.Equal(t, 0, report.Reviewers[2].Requests)
+	})
+}
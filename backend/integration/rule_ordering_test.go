@@ -0,0 +1,85 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestRuleOrdering_PriorityAndStopProcessing(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		// Created first, so it runs first by display order until priorities are set
+		archive := createRule(t, ts, "Archive bots", "reason:mention", "", models.RuleActions{
+			Archive: true,
+		})
+		star := createRule(t, ts, "Star mentions", "reason:mention", "", models.RuleActions{
+			Star: true,
+		})
+		require.Equal(t, http.StatusOK, c.UpdateRuleOrdering(t, star.ID, 10, true))
+		require.Equal(t, http.StatusBadRequest, c.UpdateRuleOrdering(t, archive.ID, 5000, false))
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		notification := fixtures.NewNotification(repo.ID).
+			WithGithubID("mention-1").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+
+		matched, err := handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, notification.ID)
+		require.NoError(t, err)
+		require.True(t, matched)
+
+		// The higher priority rule ran first and stopped the archive rule
+		got := c.GetNotification(t, "mention-1").Notification
+		require.True(t, got.Starred)
+		require.False(t, got.Archived)
+
+		activity := c.ListRuleActivity(t, "mention-1")
+		require.Len(t, activity, 1)
+		require.Equal(t, "mention-1", activity[0].GithubID)
+		require.Len(t, activity[0].Rules, 2)
+		require.Equal(t, star.ID, activity[0].Rules[0].RuleID)
+		require.Equal(t, models.RuleResultMatched, activity[0].Rules[0].Result)
+		require.True(t, activity[0].Rules[0].StoppedProcessing)
+		require.Equal(t, 10, activity[0].Rules[0].Priority)
+		require.Equal(t, archive.ID, activity[0].Rules[1].RuleID)
+		require.Equal(t, models.RuleResultSkipped, activity[0].Rules[1].Result)
+
+		// Evaluating again replaces the notification's trace
+		require.Equal(t, http.StatusOK, c.UpdateRuleOrdering(t, star.ID, 10, false))
+		_, err = handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, notification.ID)
+		require.NoError(t, err)
+
+		activity = c.ListRuleActivity(t, "")
+		require.Len(t, activity, 1)
+		require.Equal(t, models.RuleResultMatched, activity[0].Rules[1].Result)
+		require.True(t, c.GetNotification(t, "mention-1").Notification.Archived)
+	})
+}
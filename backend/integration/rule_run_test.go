@@ -0,0 +1,163 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestRunRule_AppliesToExistingNotifications(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("mention-1").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("mention-archived").
+			WithReason("mention").
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("subscribed-1").
+			WithReason("subscribed").
+			Build(t, ctx, ts.Store, userID)
+
+		// Marking read would stop later actions matching if it ran first
+		rule := createRule(t, ts, "Triage mentions", "reason:mention is:unread", "", models.RuleActions{
+			MarkRead: true,
+			Star:     true,
+		})
+
+		preview := c.RunRule(t, rule.ID, true)
+		require.Equal(t, client.RuleRunResult{Matched: 2, DryRun: true}, preview)
+		require.False(t, c.GetNotification(t, "mention-1").Notification.IsRead)
+
+		result := c.RunRule(t, rule.ID, false)
+		require.Equal(t, client.RuleRunResult{Matched: 2}, result)
+
+		for _, githubID := range []string{"mention-1", "mention-archived"} {
+			got := c.GetNotification(t, githubID).Notification
+			require.True(t, got.IsRead, githubID)
+			require.True(t, got.Starred, githubID)
+		}
+		untouched := c.GetNotification(t, "subscribed-1").Notification
+		require.False(t, untouched.IsRead)
+		require.False(t, untouched.Starred)
+
+		// Nothing is left for the rule to match
+		require.Equal(t, client.RuleRunResult{Matched: 0, DryRun: true}, c.RunRule(t, rule.ID, true))
+	})
+}
+
+func TestRuleTagSlugs_CreateTagOnDemand(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		alert := fixtures.NewNotification(repo.ID).
+			WithGithubID("alert-1").
+			WithReason("security_alert").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("alert-2").
+			WithReason("security_alert").
+			Build(t, ctx, ts.Store, userID)
+		triage := fixtures.NewTag().WithName("triage").Build(t, ctx, ts.Store, userID)
+		fixtures.AssignTag(t, ctx, ts.Store, userID, triage.ID, alert.ID)
+
+		rule := createRule(t, ts, "Tag security alerts", "reason:security_alert", "", models.RuleActions{
+			AddTagSlugs:    []string{"security-alerts"},
+			RemoveTagSlugs: []string{"triage", "never-made"},
+		})
+
+		// The first match creates the tag
+		matched, err := handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, alert.ID)
+		require.NoError(t, err)
+		require.True(t, matched)
+		created, err := ts.Store.GetTagBySlug(ctx, userID, "security-alerts")
+		require.NoError(t, err)
+		require.Equal(t, "security-alerts", created.Name)
+
+		tagged := c.ListNotifications(t, "tags:security-alerts", 1, 100)
+		require.Equal(t, int64(1), tagged.Total)
+		require.Equal(t, "alert-1", tagged.Notifications[0].GithubID)
+		require.Equal(t, int64(0), c.ListNotifications(t, "tags:triage", 1, 100).Total)
+
+		// Running the rule over existing notifications reuses the tag
+		require.Equal(t, client.RuleRunResult{Matched: 2}, c.RunRule(t, rule.ID, false))
+		require.Equal(t, int64(2), c.ListNotifications(t, "tags:security-alerts", 1, 100).Total)
+		tags, err := ts.Store.ListAllTags(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, tags, 2)
+	})
+}
+
+func TestRuleConditions(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		busy := fixtures.NewNotification(repo.ID).
+			WithGithubID("busy").
+			WithReason("mention").
+			WithLabels("bug", "p1", "needs-review").
+			Build(t, ctx, ts.Store, userID)
+		quiet := fixtures.NewNotification(repo.ID).
+			WithGithubID("quiet").
+			WithReason("mention").
+			WithLabels("bug").
+			Build(t, ctx, ts.Store, userID)
+
+		rule := createRule(t, ts, "Star busy threads", "reason:mention", "", models.RuleActions{Star: true})
+		require.Equal(t, http.StatusBadRequest, c.UpdateRuleCondition(t, rule.ID, "len(labels) >= x"))
+		require.Equal(t, http.StatusOK, c.UpdateRuleCondition(t, rule.ID, "len(labels) >= 3"))
+
+		matched, err := handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, quiet.ID)
+		require.NoError(t, err)
+		require.False(t, matched)
+		matched, err = handlers.MatchAndApplyRulesWithDB(ctx, ts.Store, userID, busy.ID)
+		require.NoError(t, err)
+		require.True(t, matched)
+		require.True(t, c.GetNotification(t, "busy").Notification.Starred)
+		require.False(t, c.GetNotification(t, "quiet").Notification.Starred)
+
+		// Running the rule only counts and changes notifications the condition holds for
+		require.Equal(t, client.RuleRunResult{Matched: 1, DryRun: true}, c.RunRule(t, rule.ID, true))
+
+		// Without the condition the query alone decides
+		require.Equal(t, http.StatusOK, c.UpdateRuleCondition(t, rule.ID, ""))
+		require.Equal(t, client.RuleRunResult{Matched: 2}, c.RunRule(t, rule.ID, false))
+		require.True(t, c.GetNotification(t, "quiet").Notification.Starred)
+	})
+}
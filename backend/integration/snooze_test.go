@@ -19,14 +19,19 @@ package integration
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/integration/client"
 	"github.com/octobud-hq/octobud/backend/integration/fixtures"
 	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
 )
 
 func TestSnooze_SetsEffectiveSortDate(t *testing.T) {
@@ -252,3 +257,248 @@ func TestBulkUnsnooze_RestoresEffectiveSortDate(t *testing.T) {
 		require.WithinDuration(t, githubUpdatedAt, n2.Notification.EffectiveSortDate, 2*time.Second)
 	})
 }
+
+func TestSnooze_WithoutTimeUsesDefaults(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		noisy := fixtures.NewRepository().WithFullName("acme/infra").Build(t, ctx, ts.Store, userID)
+		quiet := fixtures.NewRepository().WithFullName("acme/app").Build(t, ctx, ts.Store, userID)
+		infraNotif := fixtures.NewNotification(noisy.ID).Build(t, ctx, ts.Store, userID)
+		appNotif := fixtures.NewNotification(quiet.ID).Build(t, ctx, ts.Store, userID)
+
+		require.Equal(t, http.StatusOK, c.UpdateSnoozeSettings(t, "4h"))
+		threeDays := "3d"
+		settings, status := c.UpdateRepositorySettings(t, noisy.ID, client.RepositorySettings{
+			DefaultSnooze: &threeDays,
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "3d", *settings.DefaultSnooze)
+
+		// The repository's default wins over the user's
+		result := c.SnoozeNotificationDefault(t, infraNotif.GithubID)
+		require.NotNil(t, result.Notification.SnoozedUntil)
+		require.WithinDuration(t, time.Now().AddDate(0, 0, 3), *result.Notification.SnoozedUntil, time.Minute)
+
+		// Repositories without one use the user's default
+		result = c.SnoozeNotificationDefault(t, appNotif.GithubID)
+		require.NotNil(t, result.Notification.SnoozedUntil)
+		require.WithinDuration(t, time.Now().Add(4*time.Hour), *result.Notification.SnoozedUntil, time.Minute)
+
+		// Clearing the repository's default falls back to the user's again
+		settings, status = c.UpdateRepositorySettings(t, noisy.ID, client.RepositorySettings{})
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, settings.DefaultSnooze)
+		result = c.SnoozeNotificationDefault(t, infraNotif.GithubID)
+		require.WithinDuration(t, time.Now().Add(4*time.Hour), *result.Notification.SnoozedUntil, time.Minute)
+	})
+}
+
+func TestSnooze_DefaultsAreValidated(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		repo := fixtures.NewRepository().Build(t, context.Background(), ts.Store, ts.UserID)
+
+		require.Equal(t, http.StatusBadRequest, c.UpdateSnoozeSettings(t, "someday"))
+
+		forever := "forever"
+		_, status := c.UpdateRepositorySettings(t, repo.ID, client.RepositorySettings{DefaultSnooze: &forever})
+		require.Equal(t, http.StatusBadRequest, status)
+
+		weekly := "1w"
+		_, status = c.UpdateRepositorySettings(t, repo.ID+1000, client.RepositorySettings{DefaultSnooze: &weekly})
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestSnooze_PresetResolvesInUserTimeZone(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		c.UpdateWorkingHours(t, client.WorkingHours{
+			Enabled:  true,
+			Days:     []int{1, 2, 3, 4, 5},
+			Start:    "09:00",
+			End:      "17:00",
+			Timezone: "Asia/Tokyo",
+			Holidays: []string{},
+		})
+		t.Cleanup(func() {
+			c.UpdateWorkingHours(t, client.WorkingHours{
+				Days:     []int{1, 2, 3, 4, 5},
+				Start:    "09:00",
+				End:      "17:00",
+				Timezone: "UTC",
+				Holidays: []string{},
+			})
+		})
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		notif := fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, userID)
+
+		result, status := c.SnoozeNotificationWith(t, notif.GithubID, client.SnoozeRequest{Preset: "tomorrow"})
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, result.Notification.SnoozedUntil)
+
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		require.NoError(t, err)
+		until := result.Notification.SnoozedUntil.In(tokyo)
+		require.Equal(t, 8, until.Hour())
+		require.Equal(t, time.Now().In(tokyo).AddDate(0, 0, 1).Day(), until.Day())
+
+		_, status = c.SnoozeNotificationWith(t, notif.GithubID, client.SnoozeRequest{Preset: "someday"})
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestSnooze_UntilUpdatedWakesOnNewerUpdate(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		updatedAt := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+		notif := fixtures.NewNotification(repo.ID).
+			WithGithubUpdatedAt(updatedAt).
+			Build(t, ctx, ts.Store, userID)
+
+		result, status := c.SnoozeNotificationWith(t, notif.GithubID, client.SnoozeRequest{UntilUpdated: true})
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, result.Notification.SnoozeUntilUpdated)
+		require.NotNil(t, result.Notification.SnoozedUntil)
+		require.True(t, result.Notification.SnoozedUntil.After(time.Now().AddDate(0, 11, 0)))
+
+		upsert := func(updatedAt time.Time) {
+			t.Helper()
+			_, err := ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+				GithubID:        notif.GithubID,
+				RepositoryID:    repo.ID,
+				SubjectType:     "PullRequest",
+				SubjectTitle:    "Synced title",
+				GithubUpdatedAt: sql.NullTime{Time: updatedAt, Valid: true},
+			})
+			require.NoError(t, err)
+		}
+
+		// A sync without a newer update keeps it snoozed
+		upsert(updatedAt)
+		require.NotNil(t, c.GetNotification(t, notif.GithubID).Notification.SnoozedUntil)
+
+		// A newer update ends the snooze and sorts it by the update
+		newUpdatedAt := time.Now().UTC().Truncate(time.Second)
+		upsert(newUpdatedAt)
+		woken := c.GetNotification(t, notif.GithubID).Notification
+		require.Nil(t, woken.SnoozedUntil)
+		require.False(t, woken.SnoozeUntilUpdated)
+		require.WithinDuration(t, newUpdatedAt, woken.EffectiveSortDate, 2*time.Second)
+	})
+}
+
+func TestSnooze_PlainSnoozeIgnoresUpdates(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		notif := fixtures.NewNotification(repo.ID).
+			WithGithubUpdatedAt(time.Now().Add(-2*time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+
+		// Snoozing again without untilUpdated replaces a snooze until updated
+		_, status := c.SnoozeNotificationWith(t, notif.GithubID, client.SnoozeRequest{UntilUpdated: true})
+		require.Equal(t, http.StatusOK, status)
+		snoozedUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		result := c.SnoozeNotification(t, notif.GithubID, snoozedUntil)
+		require.False(t, result.Notification.SnoozeUntilUpdated)
+
+		_, err := ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+			GithubID:        notif.GithubID,
+			RepositoryID:    repo.ID,
+			SubjectType:     "PullRequest",
+			SubjectTitle:    "Synced title",
+			GithubUpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, c.GetNotification(t, notif.GithubID).Notification.SnoozedUntil)
+	})
+}
+
+func TestReturnSnoozes_ReturnsExpiredSnoozesUnread(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		require.Equal(t, http.StatusOK, c.UpdateSnoozeSettingsWith(t, client.SnoozeSettings{
+			DefaultPreset:      "tomorrow",
+			MarkUnreadOnReturn: true,
+		}))
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		expired := fixtures.NewNotification(repo.ID).
+			WithIsRead(true).
+			WithSnoozedUntil(time.Now().UTC().Add(-time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+		pending := fixtures.NewNotification(repo.ID).
+			WithIsRead(true).
+			WithSnoozedUntil(time.Now().UTC().Add(time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+		archived := fixtures.NewNotification(repo.ID).
+			WithArchived(true).
+			WithSnoozedUntil(time.Now().UTC().Add(-time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		handler := handlers.NewReturnSnoozesHandler(ts.Store, func() time.Time { return now }, zap.NewNop())
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, result.MarkUnread)
+		require.Equal(t, []string{expired.GithubID}, result.Returned)
+
+		returned := c.GetNotification(t, expired.GithubID).Notification
+		require.Nil(t, returned.SnoozedUntil)
+		require.False(t, returned.IsRead)
+		require.NotNil(t, returned.ReturnedFromSnoozeAt)
+		require.WithinDuration(t, now, *returned.ReturnedFromSnoozeAt, time.Second)
+
+		stillSnoozed := c.GetNotification(t, pending.GithubID).Notification
+		require.NotNil(t, stillSnoozed.SnoozedUntil)
+		require.True(t, stillSnoozed.IsRead)
+		require.Nil(t, stillSnoozed.ReturnedFromSnoozeAt)
+
+		require.Nil(t, c.GetNotification(t, archived.GithubID).Notification.ReturnedFromSnoozeAt)
+
+		// Snoozing it again clears the reason it came back
+		resnoozed := c.SnoozeNotification(t, expired.GithubID, time.Now().UTC().Add(time.Hour)).Notification
+		require.Nil(t, resnoozed.ReturnedFromSnoozeAt)
+	})
+}
+
+func TestReturnSnoozes_KeepsReadStateWhenNotAsked(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		require.Equal(t, http.StatusOK, c.UpdateSnoozeSettingsWith(t, client.SnoozeSettings{
+			DefaultPreset: "tomorrow",
+		}))
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		expired := fixtures.NewNotification(repo.ID).
+			WithIsRead(true).
+			WithSnoozedUntil(time.Now().UTC().Add(-time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+
+		handler := handlers.NewReturnSnoozesHandler(ts.Store, time.Now, zap.NewNop())
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, result.MarkUnread)
+		require.Equal(t, []string{expired.GithubID}, result.Returned)
+
+		returned := c.GetNotification(t, expired.GithubID).Notification
+		require.Nil(t, returned.SnoozedUntil)
+		require.True(t, returned.IsRead)
+		require.NotNil(t, returned.ReturnedFromSnoozeAt)
+	})
+}
@@ -0,0 +1,226 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/pullrequest"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	"github.com/octobud-hq/octobud/backend/internal/github/replay"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
+)
+
+// These tests run a full sync against GitHub responses replayed from fixture files in
+// testdata/github. Record new fixtures with `octobud -record-github <file>`.
+
+// directEnqueuer processes notifications immediately instead of queueing jobs
+type directEnqueuer struct {
+	syncService *sync.Service
+}
+
+func (e directEnqueuer) EnqueueProcessNotification(
+	ctx context.Context,
+	userID string,
+	notificationData []byte,
+) error {
+	return e.syncService.ProcessNotificationData(ctx, userID, notificationData)
+}
+
+// replaySync runs one sync for the test user with GitHub responses from the named fixture.
+func replaySync(t *testing.T, ts *testserver.TestServer, fixtureName string) *replay.Replayer {
+	t.Helper()
+	return replaySyncWithSettings(t, ts, fixtureName, `{"setupCompleted": true}`)
+}
+
+// replaySyncWithSettings runs one sync like replaySync, under the given sync settings.
+func replaySyncWithSettings(
+	t *testing.T,
+	ts *testserver.TestServer,
+	fixtureName, syncSettings string,
+) *replay.Replayer {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := ts.Store.UpdateUserSyncSettings(ctx, db.NullRawMessage{
+		RawMessage: []byte(syncSettings),
+		Valid:      true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = ts.Store.UpdateUserSyncSettings(context.Background(), db.NullRawMessage{})
+	})
+
+	fixture, err := replay.Load(filepath.Join("testdata", "github", fixtureName))
+	require.NoError(t, err)
+	replayer := replay.NewReplayer(fixture)
+
+	syncService := sync.NewService(
+		config.NewConsoleLogger(),
+		time.Now,
+		github.NewClientWithTransport(replayer),
+		syncstate.NewSyncStateService(ts.Store),
+		repository.NewService(ts.Store),
+		pullrequest.NewService(ts.Store),
+		notification.NewService(ts.Store),
+		ts.Store,
+	)
+	handler := handlers.NewSyncNotificationsHandler(
+		syncService,
+		directEnqueuer{syncService: syncService},
+		config.NewConsoleLogger(),
+	)
+
+	result, err := handler.Handle(ctx, ts.UserID)
+	require.NoError(t, err)
+	require.NoError(t, handler.UpdateSyncState(ctx, result))
+
+	return replayer
+}
+
+func TestReplaySync_GatewayErrorMidPagination(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		replayer := replaySync(t, ts, "gateway_error_mid_pagination.json")
+		require.Empty(t, replayer.Unmatched())
+
+		// The second page failed with a 502 and was retried at a smaller page size without
+		// skipping or duplicating notifications
+		result := c.ListNotifications(t, "in:anywhere", 1, 100)
+		require.Equal(t, int64(53), result.Total)
+
+		state, err := ts.Store.GetSyncState(context.Background(), ts.UserID)
+		require.NoError(t, err)
+		require.True(t, state.LatestNotificationAt.Valid)
+		require.Equal(t,
+			time.Date(2024, 3, 3, 5, 0, 0, 0, time.UTC),
+			state.LatestNotificationAt.Time.UTC())
+	})
+}
+
+func TestReplaySync_PayloadQuirks(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		replayer := replaySync(t, ts, "payload_quirks.json")
+		require.Empty(t, replayer.Unmatched())
+
+		result := c.ListNotifications(t, "in:anywhere", 1, 100)
+		require.Equal(t, int64(4), result.Total)
+
+		// Merged bot pull request: subject fetched, author and merge state extracted
+		pr, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2001")
+		require.NoError(t, err)
+		require.True(t, pr.SubjectRaw.Valid)
+		require.Equal(t, "dependabot[bot]", pr.AuthorLogin.String)
+		require.True(t, pr.SubjectMerged.Valid && pr.SubjectMerged.Bool)
+		require.True(t, pr.PullRequestID.Valid)
+		require.True(t, pr.SubjectDraft.Valid && !pr.SubjectDraft.Bool)
+		// Reviews are only fetched for open pull requests
+		require.False(t, pr.ReviewState.Valid)
+		require.JSONEq(t, `["Dependency Updates"]`, string(pr.SubjectProjects.RawMessage))
+		require.False(t, pr.SubjectMilestone.Valid)
+
+		// Deleted issue: the 404s for it and its latest comment aren't retried and the
+		// notification is kept without a subject
+		issue, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2002")
+		require.NoError(t, err)
+		require.False(t, issue.SubjectRaw.Valid)
+		require.False(t, issue.AuthorLogin.Valid)
+
+		// Subjects without an API URL aren't fetched, and titles are stored verbatim
+		discussion, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2003")
+		require.NoError(t, err)
+		require.False(t, discussion.SubjectURL.Valid)
+		require.Equal(t, `RFC: <script> tags & "quotes" in titles 🚀`, discussion.SubjectTitle)
+
+		release, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2004")
+		require.NoError(t, err)
+		require.True(t, release.GithubUnread.Valid)
+		require.False(t, release.GithubUnread.Bool)
+		require.True(t, release.GithubLastReadAt.Valid)
+
+		// The organization-owned repository has no description
+		repo, err := ts.Store.GetRepositoryByFullName(ctx, ts.UserID, "acme/widgets")
+		require.NoError(t, err)
+		require.False(t, repo.Description.Valid)
+	})
+}
+
+func TestReplaySync_SkipPayloadStorage(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		replayer := replaySyncWithSettings(t, ts, "payload_quirks.json",
+			`{"setupCompleted": true, "skipPayloadStorage": true}`)
+		require.Empty(t, replayer.Unmatched())
+
+		// The subject was fetched and its metadata kept, but no payload was stored
+		pr, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2001")
+		require.NoError(t, err)
+		require.False(t, pr.Payload.Valid)
+		require.False(t, pr.SubjectRaw.Valid)
+		require.True(t, pr.SubjectFetchedAt.Valid)
+		require.Equal(t, "dependabot[bot]", pr.AuthorLogin.String)
+		require.True(t, pr.SubjectMerged.Valid && pr.SubjectMerged.Bool)
+		require.True(t, pr.PullRequestID.Valid)
+
+		repo, err := ts.Store.GetRepositoryByFullName(ctx, ts.UserID, "acme/widgets")
+		require.NoError(t, err)
+		require.False(t, repo.Raw.Valid)
+
+		settings := c.GetSyncSettings(t)
+		require.True(t, settings.Capabilities.SubjectDetails)
+		require.False(t, settings.Capabilities.DescriptionSearch)
+		require.True(t, settings.Capabilities.CommentSearch)
+	})
+}
+
+func TestReplaySync_TitlesOnly(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		replaySyncWithSettings(t, ts, "payload_quirks.json",
+			`{"setupCompleted": true, "titlesOnly": true}`)
+
+		result := c.ListNotifications(t, "in:anywhere", 1, 100)
+		require.Equal(t, int64(4), result.Total)
+
+		// Only what the notification itself carries is kept
+		pr, err := ts.Store.GetNotificationByGithubID(ctx, ts.UserID, "2001")
+		require.NoError(t, err)
+		require.NotEmpty(t, pr.SubjectTitle)
+		require.False(t, pr.Payload.Valid)
+		require.False(t, pr.SubjectRaw.Valid)
+		require.False(t, pr.SubjectFetchedAt.Valid)
+		require.False(t, pr.AuthorLogin.Valid)
+		require.False(t, pr.PullRequestID.Valid)
+
+		settings := c.GetSyncSettings(t)
+		require.Equal(t, client.StorageCapabilities{}, settings.Capabilities)
+	})
+}
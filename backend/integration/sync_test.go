@@ -20,6 +20,7 @@ package integration
 import (
 	"context"
 	"database/sql"
+	"net/http"
 	"testing"
 	"time"
 
@@ -229,3 +230,64 @@ func TestUpsert_SameUpdateDoesNotUnarchive(t *testing.T) {
 		require.True(t, updatedNotif.Notification.Archived, "Notification should stay archived when github_updated_at is unchanged")
 	})
 }
+
+func TestUpsert_RecentlyArchivedStaysArchivedWithinSuppressionWindow(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		notif := fixtures.NewNotification(repo.ID).
+			WithGithubID("test-notif").
+			WithGithubUpdatedAt(time.Now().Add(-2*time.Hour).UTC()).
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+
+		// New activity shortly after the archive stays out of the inbox
+		_, err := ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+			GithubID:          notif.GithubID,
+			RepositoryID:      repo.ID,
+			SubjectType:       "PullRequest",
+			SubjectTitle:      "New activity",
+			GithubUpdatedAt:   sql.NullTime{Time: time.Now().Add(-time.Hour).UTC(), Valid: true},
+			KeepArchivedSince: sql.NullTime{Time: time.Now().Add(-2 * time.Hour).UTC(), Valid: true},
+		})
+		require.NoError(t, err)
+		updatedNotif := c.GetNotification(t, notif.GithubID)
+		require.True(t, updatedNotif.Notification.Archived, "Recently archived notification should stay archived")
+		require.Equal(t, "New activity", updatedNotif.Notification.SubjectTitle)
+
+		// Once the archive is older than the window, new activity brings it back
+		_, err = ts.Store.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+			GithubID:          notif.GithubID,
+			RepositoryID:      repo.ID,
+			SubjectType:       "PullRequest",
+			SubjectTitle:      "Later activity",
+			GithubUpdatedAt:   sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			KeepArchivedSince: sql.NullTime{Time: time.Now().Add(time.Minute).UTC(), Valid: true},
+		})
+		require.NoError(t, err)
+		updatedNotif = c.GetNotification(t, notif.GithubID)
+		require.False(t, updatedNotif.Notification.Archived, "Notification should resurface after the window")
+	})
+}
+
+func TestRepositorySettings_ResurfaceSuppression(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		repo := fixtures.NewRepository().Build(t, context.Background(), ts.Store, ts.UserID)
+
+		twoHours := int64(120)
+		settings, status := c.UpdateRepositorySettings(t, repo.ID, client.RepositorySettings{
+			ResurfaceSuppressionMinutes: &twoHours,
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, settings.DefaultSnooze)
+		require.Equal(t, int64(120), *settings.ResurfaceSuppressionMinutes)
+
+		tooLong := int64(8 * 24 * 60)
+		_, status = c.UpdateRepositorySettings(t, repo.ID, client.RepositorySettings{
+			ResurfaceSuppressionMinutes: &tooLong,
+		})
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
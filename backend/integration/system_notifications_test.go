@@ -0,0 +1,73 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestSystemNotifications_FilterAndDedupe(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		notifier := sysnotify.NewService(ts.Store, time.Now)
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("github-thread").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+
+		created, err := notifier.UpdateAvailable(ctx, userID, "v2.0.0")
+		require.NoError(t, err)
+		require.True(t, created)
+		created, err = notifier.CleanupCompleted(ctx, userID, 12)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		system := c.ListNotifications(t, "is:system", 1, 50)
+		require.Equal(t, int64(2), system.Total)
+		for _, n := range system.Notifications {
+			require.Equal(t, db.SubjectTypeSystem, n.SubjectType)
+			require.NotNil(t, n.Reason)
+			require.Equal(t, db.ReasonSystem, *n.Reason)
+			require.False(t, n.IsRead)
+		}
+
+		others := c.ListNotifications(t, "-is:system", 1, 50)
+		require.Equal(t, int64(1), others.Total)
+		require.Equal(t, "github-thread", others.Notifications[0].GithubID)
+
+		// Reporting the same event again is skipped, even once it's archived
+		c.ArchiveNotification(t, db.SystemGithubIDPrefix+"update:v2.0.0")
+		created, err = notifier.UpdateAvailable(ctx, userID, "v2.0.0")
+		require.NoError(t, err)
+		require.False(t, created)
+		require.Equal(t, int64(1), c.ListNotifications(t, "is:system in:inbox", 1, 50).Total)
+	})
+}
@@ -0,0 +1,75 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestTagRecency(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		tag := fixtures.NewTag().WithName("Bug").WithSlug("bug").Build(t, ctx, ts.Store, userID)
+
+		recent := fixtures.NewNotification(repo.ID).WithGithubID("recent").Build(t, ctx, ts.Store, userID)
+		old := fixtures.NewNotification(repo.ID).WithGithubID("old").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).WithGithubID("untagged").Build(t, ctx, ts.Store, userID)
+
+		before := time.Now().UTC().Truncate(time.Second)
+		fixtures.AssignTag(t, ctx, ts.Store, userID, tag.ID, recent.ID)
+		fixtures.AssignTag(t, ctx, ts.Store, userID, tag.ID, old.ID)
+		_, err := ts.DB.ExecContext(ctx,
+			"UPDATE tag_assignments SET created_at = ? WHERE entity_id = ?",
+			time.Now().UTC().AddDate(0, 0, -10).Format(time.RFC3339), old.ID,
+		)
+		require.NoError(t, err)
+
+		t.Run("notification tags carry when they were assigned", func(t *testing.T) {
+			notif := c.GetNotification(t, recent.GithubID).Notification
+			require.Len(t, notif.Tags, 1)
+			require.NotNil(t, notif.Tags[0].AssignedAt)
+			require.False(t, notif.Tags[0].AssignedAt.Before(before))
+		})
+
+		t.Run("tagged filters by assignment age", func(t *testing.T) {
+			result := c.ListNotifications(t, "tagged:<7d in:anywhere", 1, 100)
+			require.Equal(t, int64(1), result.Total)
+			require.Equal(t, recent.GithubID, result.Notifications[0].GithubID)
+
+			result = c.ListNotifications(t, "tagged:>1w in:anywhere", 1, 100)
+			require.Equal(t, int64(1), result.Total)
+			require.Equal(t, old.GithubID, result.Notifications[0].GithubID)
+		})
+
+		t.Run("recently tagged view counts the week's tags", func(t *testing.T) {
+			view := findView(t, c.ListViews(t), "recently-tagged")
+			require.True(t, view.SystemView)
+			require.Equal(t, int64(1), view.UnreadCount)
+		})
+	})
+}
@@ -22,27 +22,56 @@ import (
 	"context"
 	"database/sql"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/api"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
 	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/pullrequest"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/db/sqlite"
 	"github.com/octobud-hq/octobud/backend/internal/server"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
+	"github.com/octobud-hq/octobud/backend/internal/xcrypto"
 
 	// SQLite driver
 	_ "modernc.org/sqlite"
 )
 
+// WebhookSecret signs webhook deliveries sent to the test server
+const WebhookSecret = "integration-webhook-secret"
+
 // TestServer wraps a test HTTP server with database access.
 type TestServer struct {
-	Server  *httptest.Server
-	Store   db.Store
-	DB      *sql.DB
-	UserID  string // GitHub user ID for test user
-	Cleanup func()
+	Server *httptest.Server
+	Store  db.Store
+	DB     *sql.DB
+	UserID string // GitHub user ID for test user
+	// LiveQueries is shared with the API so tests can report synced notifications
+	LiveQueries *livequery.Service
+	// Events is shared with the API so tests can publish sync status
+	Events *events.Service
+	// Prewarm is shared with the API so tests can warm results and see them served
+	Prewarm *prewarm.Service
+	// Integrations is shared with the API so tests can send the deliveries rules queue
+	Integrations *integration.Service
+	Cleanup      func()
 }
 
 // NewSQLite creates a test server backed by in-memory SQLite.
@@ -94,7 +123,55 @@ func NewSQLite(t *testing.T) *TestServer {
 	}
 
 	// Create API handler (trusts localhost - no JWT auth needed)
-	apiHandler := api.NewHandler(store)
+	liveQueries := livequery.NewService(store, zap.NewNop(), time.Now)
+	eventBus := events.NewService(zap.NewNop(), time.Now)
+
+	// Webhook deliveries carry their subject data, so no GitHub client or scheduler is needed
+	syncService := sync.NewService(
+		zap.NewNop(),
+		time.Now,
+		nil,
+		syncstate.NewSyncStateService(store),
+		repository.NewService(store),
+		pullrequest.NewService(store),
+		notification.NewService(store).WithEvents(eventBus),
+		store,
+	)
+	webhooks := webhook.NewService(zap.NewNop(), authService, store, syncService, nil, liveQueries)
+
+	// Linked accounts can be listed and unlinked; linking needs GitHub to check the token
+	encryptor, err := xcrypto.NewEncryptor(make([]byte, 32))
+	if err != nil {
+		dbConn.Close()
+		t.Fatalf("Failed to create encryptor: %v", err)
+	}
+	tokenManager := coregithub.NewTokenManager(store, encryptor, nil, nil, authService, zap.NewNop())
+
+	prewarmSvc := prewarm.NewService(store, time.Now)
+
+	// Digests are written to a per-test directory; no mail server is configured
+	digests := digest.NewService(
+		store,
+		notification.NewService(store),
+		workhours.NewService(store),
+		filepath.Join(t.TempDir(), digest.DirName),
+		time.Now,
+	)
+
+	// Webhooks rules notify share the encryptor with linked accounts
+	integrations := integration.NewService(store, encryptor, time.Now)
+
+	apiHandler := api.NewHandler(
+		store,
+		api.WithAlerts(alert.NewService(store, time.Now)),
+		api.WithLiveQueries(liveQueries),
+		api.WithEvents(eventBus),
+		api.WithWebhooks(webhooks, WebhookSecret),
+		api.WithTokenManager(tokenManager),
+		api.WithPrewarm(prewarmSvc),
+		api.WithDigests(digests),
+		api.WithIntegrations(integrations),
+	)
 
 	// Set up router
 	router := server.NewRouter(server.DefaultConfig())
@@ -104,10 +181,14 @@ func NewSQLite(t *testing.T) *TestServer {
 	ts := httptest.NewServer(router)
 
 	return &TestServer{
-		Server: ts,
-		Store:  store,
-		DB:     dbConn,
-		UserID: testUserID,
+		Server:       ts,
+		Store:        store,
+		DB:           dbConn,
+		UserID:       testUserID,
+		LiveQueries:  liveQueries,
+		Events:       eventBus,
+		Prewarm:      prewarmSvc,
+		Integrations: integrations,
 		Cleanup: func() {
 			ts.Close()
 			dbConn.Close()
@@ -124,6 +205,9 @@ func (ts *TestServer) Reset(t *testing.T) {
 
 	// Delete all data in reverse order of dependencies
 	tables := []string{
+		"notification_events",
+		"bulk_operation_states",
+		"bulk_operations",
 		"tag_assignments",
 		"notifications",
 		"pull_requests",
@@ -132,6 +216,10 @@ func (ts *TestServer) Reset(t *testing.T) {
 		"views",
 		"rules",
 		"sync_state",
+		"recent_access",
+		"org_offboardings",
+		"integration_deliveries",
+		"integrations",
 		// Don't delete users - we need the user record
 	}
 
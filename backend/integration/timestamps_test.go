@@ -0,0 +1,189 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/sqlite"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// canonicalTimestamp matches db.TimestampLayout
+var canonicalTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)
+
+// timestampColumns returns every column in the schema that holds a timestamp, by table
+func timestampColumns(t *testing.T, conn *sql.DB) map[string][]string {
+	t.Helper()
+
+	rows, err := conn.Query(`SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'goose_db_version'`)
+	require.NoError(t, err)
+	var tables []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		tables = append(tables, name)
+	}
+	require.NoError(t, rows.Close())
+
+	columns := make(map[string][]string)
+	for _, table := range tables {
+		info, err := conn.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", table))
+		require.NoError(t, err)
+		for info.Next() {
+			var name string
+			require.NoError(t, info.Scan(&name))
+			if strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_until") ||
+				strings.HasSuffix(name, "_date") || name == "last_successful_poll" {
+				columns[table] = append(columns[table], name)
+			}
+		}
+		require.NoError(t, info.Close())
+	}
+	return columns
+}
+
+// requireCanonicalTimestamps fails if any stored timestamp isn't in db.TimestampLayout
+func requireCanonicalTimestamps(t *testing.T, conn *sql.DB) {
+	t.Helper()
+
+	tables := timestampColumns(t, conn)
+	require.Contains(t, tables["notifications"], "effective_sort_date")
+	require.Contains(t, tables["sync_state"], "last_successful_poll")
+	for table, columns := range tables {
+		for _, column := range columns {
+			rows, err := conn.Query(fmt.Sprintf(
+				"SELECT %s FROM %s WHERE %s IS NOT NULL", column, table, column))
+			require.NoError(t, err)
+			for rows.Next() {
+				var value string
+				require.NoError(t, rows.Scan(&value))
+				require.Regexp(t, canonicalTimestamp, value, "%s.%s", table, column)
+			}
+			require.NoError(t, rows.Close())
+		}
+	}
+}
+
+func TestTimestamps_StoredInCanonicalForm(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, ts.UserID)
+		snoozed := fixtures.NewNotification(repo.ID).
+			WithGithubUpdatedAt(time.Date(2025, 3, 30, 3, 30, 0, 500, berlin)).
+			Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.NewNotification(repo.ID).Build(t, ctx, ts.Store, ts.UserID)
+		tag := fixtures.NewTag().WithName("triage").Build(t, ctx, ts.Store, ts.UserID)
+		fixtures.AssignTag(t, ctx, ts.Store, ts.UserID, tag.ID, snoozed.ID)
+
+		c.SnoozeNotification(t, snoozed.GithubID, time.Now().In(berlin).Add(time.Hour))
+		createRule(t, ts, "Triage", "reason:mention", "", models.RuleActions{Star: true})
+		latest := time.Date(2025, 10, 26, 2, 30, 0, 0, berlin)
+		_, err = ts.Store.UpsertSyncState(ctx, ts.UserID, db.UpsertSyncStateParams{
+			LastSuccessfulPoll:   sql.NullTime{Time: time.Now().In(berlin), Valid: true},
+			LatestNotificationAt: sql.NullTime{Time: latest, Valid: true},
+		})
+		require.NoError(t, err)
+
+		requireCanonicalTimestamps(t, ts.DB)
+	})
+}
+
+func TestTimestamps_MigrationNormalizesLegacyValues(t *testing.T) {
+	conn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	goose.SetBaseFS(sqlite.MigrationsFS)
+	require.NoError(t, goose.UpTo(conn, "migrations", 8))
+
+	_, err = conn.Exec(`INSERT INTO repositories (id, user_id, name, full_name, created_at)
+		VALUES (1, 'u', 'repo', 'o/repo', '2024-01-15 10:00:00')`)
+	require.NoError(t, err)
+	// The same instant, and neighbours, written in every format found in older databases
+	_, err = conn.Exec(`INSERT INTO notifications (
+			user_id, github_id, repository_id, subject_type, subject_title,
+			github_updated_at, imported_at, effective_sort_date, snoozed_until
+		) VALUES
+		('u', 'a', 1, 'Issue', 'a', '2024-03-10T06:30:00.123Z', '2024-03-10 06:30:00',
+			'2024-03-10T07:30:00+01:00', NULL),
+		('u', 'b', 1, 'Issue', 'b', '2024-03-10T06:00:00Z', '2024-03-10T06:00:00Z',
+			'2024-03-10T06:00:00Z', '2024-03-09T22:45:00-08:00'),
+		('u', 'c', 1, 'Issue', 'c', NULL, '2024-03-10T06:31:00Z', '2024-03-10T06:31:00Z',
+			'not a timestamp')`)
+	require.NoError(t, err)
+
+	require.NoError(t, goose.Up(conn, "migrations"))
+
+	var repoCreatedAt string
+	require.NoError(t, conn.QueryRow(
+		"SELECT created_at FROM repositories WHERE id = 1").Scan(&repoCreatedAt))
+	require.Equal(t, "2024-01-15T10:00:00Z", repoCreatedAt)
+
+	type row struct {
+		githubID          string
+		githubUpdatedAt   sql.NullString
+		importedAt        string
+		effectiveSortDate string
+		snoozedUntil      sql.NullString
+	}
+	rows, err := conn.Query(`SELECT github_id, github_updated_at, imported_at,
+		effective_sort_date, snoozed_until FROM notifications ORDER BY effective_sort_date`)
+	require.NoError(t, err)
+	var got []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(
+			&r.githubID, &r.githubUpdatedAt, &r.importedAt, &r.effectiveSortDate, &r.snoozedUntil))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Close())
+
+	// Ordered by the instants the values represent, not by how they were written
+	require.Len(t, got, 3)
+	order := []string{got[0].githubID, got[1].githubID, got[2].githubID}
+	require.Equal(t, []string{"b", "a", "c"}, order)
+
+	a := got[1]
+	require.Equal(t, "2024-03-10T06:30:00Z", a.githubUpdatedAt.String)
+	require.Equal(t, "2024-03-10T06:30:00Z", a.importedAt)
+	require.Equal(t, "2024-03-10T06:30:00Z", a.effectiveSortDate)
+	require.Equal(t, "2024-03-10T06:45:00Z", got[0].snoozedUntil.String)
+
+	// NULLs and unparseable values are left alone
+	require.False(t, got[2].githubUpdatedAt.Valid)
+	require.Equal(t, "not a timestamp", got[2].snoozedUntil.String)
+}
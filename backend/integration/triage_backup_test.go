@@ -0,0 +1,229 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestTriageBackup_RestoresAfterFullWipe(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		snoozedUntil := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		readArchived := fixtures.NewNotification(repo.ID).
+			WithGithubID("read-archived").
+			WithIsRead(true).
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+		tag := fixtures.NewTag().WithName("Urgent").Build(t, ctx, ts.Store, userID)
+		fixtures.AssignTag(t, ctx, ts.Store, userID, tag.ID, readArchived.ID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("starred-snoozed").
+			WithStarred(true).
+			WithSnoozedUntil(snoozedUntil).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("untouched").
+			Build(t, ctx, ts.Store, userID)
+
+		backup := c.ExportTriage(t)
+		require.Len(t, backup.Notifications, 2)
+		require.Equal(t, "read-archived", backup.Notifications[0].GithubID)
+		require.Equal(t, []string{"Urgent"}, backup.Notifications[0].Tags)
+
+		// Wipe everything, including the tag, and re-sync one notification that the user
+		// starred before importing
+		require.NoError(t, ts.Store.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+			Mode: db.DisconnectModeFullWipe,
+		}))
+		require.NoError(t, ts.Store.DeleteTag(ctx, userID, tag.ID))
+		repo = fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		resynced := fixtures.NewNotification(repo.ID).
+			WithGithubID("read-archived").
+			WithStarred(true).
+			Build(t, ctx, ts.Store, userID)
+
+		result := c.ImportTriage(t, backup, false)
+		require.Equal(t, 2, result.Staged)
+		require.Equal(t, int64(1), result.Applied)
+		require.Equal(t, int64(1), result.Pending)
+
+		// State is merged, not replaced: the local star survives
+		merged := c.GetNotification(t, "read-archived").Notification
+		require.True(t, merged.IsRead)
+		require.True(t, merged.Archived)
+		require.True(t, merged.Starred)
+		tags, err := ts.Store.ListTagsForEntity(ctx, userID, db.ListTagsForEntityParams{
+			EntityType: "notification",
+			EntityID:   resynced.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		require.Equal(t, "Urgent", tags[0].Name)
+
+		// The second notification picks up its state once it syncs
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("starred-snoozed").
+			Build(t, ctx, ts.Store, userID)
+		restored, err := ts.Store.ApplyTriageRestore(ctx, userID, "starred-snoozed")
+		require.NoError(t, err)
+		require.True(t, restored)
+
+		later := c.GetNotification(t, "starred-snoozed").Notification
+		require.True(t, later.Starred)
+		require.NotNil(t, later.SnoozedUntil)
+		require.True(t, snoozedUntil.Equal(*later.SnoozedUntil))
+
+		pending, err := ts.Store.CountTriageRestores(ctx, userID)
+		require.NoError(t, err)
+		require.Zero(t, pending)
+	})
+}
+
+func TestTriageBackup_RestoresSetupOnAnotherMachine(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		tag := fixtures.NewTag().WithName("Urgent").WithSlug("urgent").WithColor("#ff0000").Build(t, ctx, ts.Store, userID)
+		view, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		})
+		require.NoError(t, err)
+		_, err = ts.Store.CreateRule(ctx, userID, db.CreateRuleParams{
+			Name:    "Flag reviews",
+			ViewID:  sql.NullString{String: view.ID, Valid: true},
+			Enabled: true,
+			Actions: []byte(fmt.Sprintf(`{"skipInbox":false,"assignTags":[%q]}`, tag.ID)),
+		})
+		require.NoError(t, err)
+
+		backup := c.ExportTriage(t)
+		require.Len(t, backup.Tags, 1)
+		require.Len(t, backup.Views, 1)
+		require.Len(t, backup.Rules, 1)
+		require.Equal(t, "reviews", backup.Rules[0].ViewSlug)
+
+		// The new machine has none of the setup except a view with the same name
+		rules, err := ts.Store.ListRules(ctx, userID)
+		require.NoError(t, err)
+		require.NoError(t, ts.Store.DeleteRule(ctx, userID, rules[0].ID))
+		require.NoError(t, ts.Store.DeleteTag(ctx, userID, tag.ID))
+		_, err = ts.Store.DeleteView(ctx, userID, view.ID)
+		require.NoError(t, err)
+		local, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "is:unread", Valid: true},
+		})
+		require.NoError(t, err)
+
+		result := c.ImportTriage(t, backup, false)
+		require.Equal(t, client.TriageImportCounts{Created: 1}, result.Tags)
+		require.Equal(t, client.TriageImportCounts{Skipped: 1}, result.Views)
+		require.Equal(t, client.TriageImportCounts{Created: 1}, result.Rules)
+
+		// The local view wins, and the rule follows it and tags by slug
+		views, err := ts.Store.ListViews(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, views, 1)
+		require.Equal(t, "is:unread", views[0].Query.String)
+		tags, err := ts.Store.ListAllTags(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		require.Equal(t, "#ff0000", tags[0].Color.String)
+		rules, err = ts.Store.ListRules(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		require.Equal(t, local.ID, rules[0].ViewID.String)
+		require.JSONEq(t, `{"skipInbox":false,"addTagSlugs":["urgent"]}`, string(rules[0].Actions))
+
+		// Importing again changes nothing
+		result = c.ImportTriage(t, backup, false)
+		require.Equal(t, client.TriageImportCounts{Skipped: 1}, result.Tags)
+		require.Equal(t, client.TriageImportCounts{Skipped: 1}, result.Views)
+		require.Equal(t, client.TriageImportCounts{Skipped: 1}, result.Rules)
+	})
+}
+
+func TestTriageBackup_ResumesDownload(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		// More notifications than an export reads at once
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		for i := range triage.ExportPageSize + 20 {
+			fixtures.NewNotification(repo.ID).
+				WithGithubID(fmt.Sprintf("n%04d", i)).
+				WithIsRead(true).
+				Build(t, ctx, ts.Store, userID)
+		}
+
+		status, header, full := c.DownloadTriageExport(t, nil)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, strconv.Itoa(triage.ExportPageSize+20), header.Get("X-Export-Entries"))
+		require.Equal(t, strconv.Itoa(len(full)), header.Get("X-Download-Size"))
+		require.Equal(t, "bytes", header.Get("Accept-Ranges"))
+		var backup client.TriageBackup
+		require.NoError(t, json.Unmarshal(full, &backup))
+		require.Len(t, backup.Notifications, triage.ExportPageSize+20)
+
+		// Resume after the first half, even once the export time has passed
+		etag := header.Get("ETag")
+		half := len(full) / 2
+		status, header, rest := c.DownloadTriageExport(t, http.Header{
+			"Range":    {fmt.Sprintf("bytes=%d-", half)},
+			"If-Range": {etag},
+		})
+		require.Equal(t, http.StatusPartialContent, status)
+		require.Equal(t, fmt.Sprintf("bytes %d-%d/%d", half, len(full)-1, len(full)), header.Get("Content-Range"))
+		require.Equal(t, full[half:], rest)
+
+		// Once triage state changes, the old download can't be resumed
+		fixtures.NewNotification(repo.ID).WithGithubID("zzz").WithStarred(true).Build(t, ctx, ts.Store, userID)
+		status, header, fresh := c.DownloadTriageExport(t, http.Header{
+			"Range":    {fmt.Sprintf("bytes=%d-", half)},
+			"If-Range": {etag},
+		})
+		require.Equal(t, http.StatusOK, status)
+		require.NotEqual(t, etag, header.Get("ETag"))
+		require.NoError(t, json.Unmarshal(fresh, &backup))
+		require.Len(t, backup.Notifications, triage.ExportPageSize+21)
+	})
+}
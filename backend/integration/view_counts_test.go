@@ -0,0 +1,98 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func findViewCount(t *testing.T, counts []client.ViewCount, id string) client.ViewCount {
+	t.Helper()
+	for _, count := range counts {
+		if count.ID == id {
+			return count
+		}
+	}
+	t.Fatalf("view %s not counted", id)
+	return client.ViewCount{}
+}
+
+func TestViewCounts(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		view, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Mentions",
+			Slug:  "mentions",
+			Query: sql.NullString{String: "reason:mention", Valid: true},
+		})
+		require.NoError(t, err)
+
+		first := fixtures.NewNotification(repo.ID).WithReason("mention").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).WithReason("mention").Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).WithReason("subscribed").Build(t, ctx, ts.Store, userID)
+
+		counts := c.ViewCounts(t)
+		require.Equal(t, int64(2), findViewCount(t, counts, view.ID).UnreadCount)
+		require.Equal(t, int64(3), findViewCount(t, counts, "inbox").UnreadCount)
+		require.Equal(t, int64(0), findViewCount(t, counts, "starred").UnreadCount)
+
+		// Counts match the full view list
+		for _, v := range c.ListViews(t) {
+			require.Equal(t, v.UnreadCount, findViewCount(t, counts, v.ID).UnreadCount, v.ID)
+		}
+
+		// Writes outside the API, like a sync, still refresh the counts
+		_, err = ts.Store.MarkNotificationRead(ctx, userID, first.GithubID)
+		require.NoError(t, err)
+		counts = c.ViewCounts(t)
+		require.Equal(t, int64(1), findViewCount(t, counts, view.ID).UnreadCount)
+		require.Equal(t, int64(2), findViewCount(t, counts, "inbox").UnreadCount)
+
+		// So do tag changes
+		tag, err := ts.Store.UpsertTag(ctx, userID, db.UpsertTagParams{Name: "Urgent", Slug: "urgent"})
+		require.NoError(t, err)
+		tagged, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Urgent",
+			Slug:  "urgent",
+			Query: sql.NullString{String: "tags:urgent", Valid: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(0), findViewCount(t, c.ViewCounts(t), tagged.ID).UnreadCount)
+
+		second := fixtures.NewNotification(repo.ID).WithReason("mention").Build(t, ctx, ts.Store, userID)
+		require.Equal(t, int64(0), findViewCount(t, c.ViewCounts(t), tagged.ID).UnreadCount)
+		_, err = ts.Store.AssignTagToEntity(ctx, userID, db.AssignTagToEntityParams{
+			TagID:      tag.ID,
+			EntityType: "notification",
+			EntityID:   second.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), findViewCount(t, c.ViewCounts(t), tagged.ID).UnreadCount)
+	})
+}
@@ -0,0 +1,77 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func TestRenderView_PrintsViewContents(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("review-notif").
+			WithSubjectTitle("Review the <b>parser</b> rewrite").
+			WithReason("review_requested").
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("mention-notif").
+			WithSubjectTitle("Mentioned in release notes").
+			WithReason("mention").
+			Build(t, ctx, ts.Store, userID)
+
+		view, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		})
+		require.NoError(t, err)
+
+		body, contentType, status := c.RenderView(t, view.ID, "html")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "text/html; charset=utf-8", contentType)
+		require.Contains(t, body, "<h1>Reviews</h1>")
+		require.Contains(t, body, "Review the &lt;b&gt;parser&lt;/b&gt; rewrite")
+		require.NotContains(t, body, "Mentioned in release notes")
+
+		// Built-in views render too
+		body, _, status = c.RenderView(t, "inbox", "")
+		require.Equal(t, http.StatusOK, status)
+		require.Contains(t, body, "<h1>Inbox</h1>")
+		require.Contains(t, body, "Mentioned in release notes")
+
+		_, _, status = c.RenderView(t, view.ID, "pdf")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		_, _, status = c.RenderView(t, "missing-view", "html")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,134 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func findView(t *testing.T, views []client.View, id string) client.View {
+	t.Helper()
+	for _, v := range views {
+		if v.ID == id {
+			return v
+		}
+	}
+	t.Fatalf("view %s not listed", id)
+	return client.View{}
+}
+
+func TestViewWIPLimits(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+
+		view, err := ts.Store.CreateView(ctx, userID, db.CreateViewParams{
+			Name:  "Review queue",
+			Slug:  "review-queue",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		})
+		require.NoError(t, err)
+
+		now := time.Now().UTC()
+		fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(now.Add(-3*time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+		newest := fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(now.Add(-time.Hour)).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithGithubUpdatedAt(now).
+			WithStarred(true).
+			Build(t, ctx, ts.Store, userID)
+		// Archived notifications don't count against the limit
+		fixtures.NewNotification(repo.ID).
+			WithReason("review_requested").
+			WithArchived(true).
+			Build(t, ctx, ts.Store, userID)
+
+		zero := int64(0)
+		_, status := c.SetViewWIPLimit(t, view.ID, &zero, false)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		limit := int64(2)
+		updated, status := c.SetViewWIPLimit(t, view.ID, &limit, true)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, &limit, updated.WIPLimit)
+		require.True(t, updated.WIPAutoSnooze)
+
+		listed := findView(t, c.ListViews(t), view.ID)
+		require.Equal(t, int64(3), *listed.Count)
+		require.True(t, listed.OverLimit)
+
+		handler := handlers.NewEnforceWIPLimitsHandler(
+			ts.Store,
+			snooze.NewService(ts.Store, workhours.NewService(ts.Store), time.Now),
+			sysnotify.NewService(ts.Store, time.Now),
+			zap.NewNop(),
+		)
+		result, err := handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []string{view.ID}, result.OverLimit)
+		// The starred notification and the one that waited longest stay
+		require.Equal(t, []string{newest.GithubID}, result.Snoozed)
+
+		snoozed := c.ListNotifications(t, "in:snoozed", 1, 50)
+		require.Equal(t, int64(1), snoozed.Total)
+		require.Equal(t, newest.GithubID, snoozed.Notifications[0].GithubID)
+
+		listed = findView(t, c.ListViews(t), view.ID)
+		require.Equal(t, int64(2), *listed.Count)
+		require.False(t, listed.OverLimit)
+
+		warnings := c.ListNotifications(t, "is:system", 1, 50)
+		require.Equal(t, int64(1), warnings.Total)
+		require.Equal(t,
+			"Review queue has 3 notifications, over its limit of 2; snoozed the 1 newest",
+			warnings.Notifications[0].SubjectTitle)
+
+		// Clearing the limit stops the checks
+		cleared, status := c.SetViewWIPLimit(t, view.ID, nil, true)
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, cleared.WIPLimit)
+		require.False(t, cleared.WIPAutoSnooze)
+
+		result, err = handler.Handle(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, result.OverLimit)
+	})
+}
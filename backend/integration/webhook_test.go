@@ -0,0 +1,101 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/fixtures"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+)
+
+func TestWebhook_ClosedIssueUpdatesNotification(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, ts *testserver.TestServer, c *client.Client) {
+		ctx := context.Background()
+		userID := ts.UserID
+
+		const issueURL = "https://api.github.com/repos/test-org/test-repo/issues/7"
+		repo := fixtures.NewRepository().Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("issue-notif").
+			WithSubjectType("Issue").
+			WithSubjectURL(issueURL).
+			Build(t, ctx, ts.Store, userID)
+		fixtures.NewNotification(repo.ID).
+			WithGithubID("other-notif").
+			WithSubjectType("Issue").
+			WithSubjectURL("https://api.github.com/repos/test-org/test-repo/issues/8").
+			Build(t, ctx, ts.Store, userID)
+
+		payload := []byte(`{
+			"action": "closed",
+			"issue": {
+				"url": "` + issueURL + `",
+				"number": 7,
+				"state": "closed",
+				"state_reason": "completed",
+				"user": {"login": "octocat", "id": 1}
+			}
+		}`)
+		signature := webhook.Sign(testserver.WebhookSecret, payload)
+
+		// Polling is the default, so deliveries are refused until webhook sync is on
+		_, status := c.SendWebhook(t, webhook.EventIssues, payload, signature)
+		require.Equal(t, http.StatusForbidden, status)
+
+		require.Equal(t, http.StatusOK, c.SetWebhooksEnabled(t, true))
+
+		_, status = c.SendWebhook(t, webhook.EventIssues, payload, "sha256=00")
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		result, status := c.SendWebhook(t, webhook.EventIssues, payload, signature)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, []string{"issue-notif"}, result.Updated)
+
+		n, err := ts.Store.GetNotificationByGithubID(ctx, userID, "issue-notif")
+		require.NoError(t, err)
+		require.Equal(t, "closed", n.SubjectState.String)
+		require.Equal(t, "completed", n.SubjectStateReason.String)
+		require.Equal(t, "octocat", n.AuthorLogin.String)
+
+		closed := c.ListNotifications(t, "state:closed", 0, 0)
+		require.Len(t, closed.Notifications, 1)
+		require.Equal(t, "issue-notif", closed.Notifications[0].GithubID)
+	})
+}
+
+func TestWebhook_PingAndUnhandledEvents(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, _ *testserver.TestServer, c *client.Client) {
+		payload := []byte(`{"zen": "Keep it logically awesome."}`)
+		signature := webhook.Sign(testserver.WebhookSecret, payload)
+
+		result, status := c.SendWebhook(t, webhook.EventPing, payload, signature)
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, result.Ignored)
+
+		result, status = c.SendWebhook(t, "push", payload, signature)
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, result.Ignored)
+	})
+}
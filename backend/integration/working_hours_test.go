@@ -0,0 +1,54 @@
+//go:build test && integration
+
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/integration/client"
+	"github.com/octobud-hq/octobud/backend/integration/testserver"
+)
+
+func TestWorkingHours_NextBusinessDay(t *testing.T) {
+	RunWithBackends(t, func(t *testing.T, _ *testserver.TestServer, c *client.Client) {
+		friday := time.Date(2025, 12, 19, 15, 0, 0, 0, time.UTC)
+
+		// Without working hours every day is a business day, so Saturday comes next
+		next := c.NextBusinessDay(t, friday)
+		require.True(t, next.After(friday))
+		require.True(t, next.Before(friday.Add(48*time.Hour)), "got %s", next)
+
+		saved := c.UpdateWorkingHours(t, client.WorkingHours{
+			Enabled:  true,
+			Days:     []int{1, 2, 3, 4, 5},
+			Start:    "08:30",
+			End:      "17:00",
+			Timezone: "UTC",
+			Holidays: []string{"2025-12-22"},
+		})
+		require.True(t, saved.Enabled)
+		require.Equal(t, []string{"2025-12-22"}, saved.Holidays)
+
+		// The weekend and the Monday holiday are skipped
+		next = c.NextBusinessDay(t, friday)
+		require.True(t, time.Date(2025, 12, 23, 8, 30, 0, 0, time.UTC).Equal(next), "got %s", next)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package alerts provides the recorded alert feed that clients poll to show desktop
+// notifications and play sounds.
+package alerts
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToListAlerts = errors.New("failed to list alerts")
+)
+
+// Handler handles alert HTTP routes
+type Handler struct {
+	logger  *zap.Logger
+	alerts  alert.AlertService
+	authSvc authsvc.AuthService
+}
+
+// New creates a new alerts handler
+func New(
+	logger *zap.Logger,
+	alerts alert.AlertService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:  logger,
+		alerts:  alerts,
+		authSvc: authSvc,
+	}
+}
+
+// Register registers alert routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/alerts", h.handleList)
+}
+
+type listResponse struct {
+	Alerts []models.Alert `json:"alerts"`
+}
+
+// handleList returns alerts recorded after ?after=<id>, oldest first. Without a cursor
+// it returns the most recent alerts so a client can pick up where the feed is.
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	afterID := int64(-1)
+	if raw := r.URL.Query().Get("after"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			helpers.WriteError(w, http.StatusBadRequest, "Invalid after parameter")
+			return
+		}
+		afterID = parsed
+	}
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			helpers.WriteError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	alerts, err := h.alerts.ListAlerts(ctx, userID, afterID, limit)
+	if err != nil {
+		h.logger.Error(
+			"failed to list alerts",
+			zap.Int64("after", afterID),
+			zap.Error(errors.Join(ErrFailedToListAlerts, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load alerts")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Alerts: alerts})
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package alerts
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	alertmocks "github.com/octobud-hq/octobud/backend/internal/core/alert/mocks"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*alertmocks.MockAlertService),
+	path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := alertmocks.NewMockAlertService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleList(t *testing.T) {
+	createdAt := time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+	w := serve(t, func(m *alertmocks.MockAlertService) {
+		m.EXPECT().ListAlerts(gomock.Any(), testUserID, int64(41), 10).Return([]models.Alert{
+			{
+				ID:        42,
+				GithubID:  "thread-1",
+				Level:     models.AlertLevelDesktop,
+				Sound:     "chime",
+				Source:    "rule",
+				SourceID:  "rule-1",
+				CreatedAt: createdAt,
+			},
+		}, nil)
+	}, "/alerts?after=41&limit=10")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"alerts": [{
+		"id": 42,
+		"githubId": "thread-1",
+		"level": "desktop",
+		"sound": "chime",
+		"source": "rule",
+		"sourceId": "rule-1",
+		"createdAt": "2025-03-04T09:00:00Z"
+	}]}`, w.Body.String())
+}
+
+func TestHandler_handleList_WithoutCursorListsRecent(t *testing.T) {
+	w := serve(t, func(m *alertmocks.MockAlertService) {
+		m.EXPECT().ListAlerts(gomock.Any(), testUserID, int64(-1), 0).Return([]models.Alert{}, nil)
+	}, "/alerts")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"alerts": []}`, w.Body.String())
+}
+
+func TestHandler_handleList_Errors(t *testing.T) {
+	require.Equal(t, http.StatusBadRequest, serve(t, nil, "/alerts?after=abc").Code)
+	require.Equal(t, http.StatusBadRequest, serve(t, nil, "/alerts?limit=0").Code)
+
+	w := serve(t, func(m *alertmocks.MockAlertService) {
+		m.EXPECT().ListAlerts(gomock.Any(), testUserID, int64(5), 0).Return(nil, errors.New("db"))
+	}, "/alerts?after=5")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
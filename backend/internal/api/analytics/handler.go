@@ -0,0 +1,147 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package analytics provides the notification volume API behind the dashboard.
+package analytics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/analytics"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// defaultDays is the reporting window when none is given
+const defaultDays = 30
+
+// Error definitions
+var (
+	ErrFailedToLoadActivity  = errors.New("failed to load notification activity")
+	ErrFailedToLoadBreakdown = errors.New("failed to load notification breakdown")
+)
+
+// Handler handles analytics HTTP routes
+type Handler struct {
+	logger       *zap.Logger
+	analyticsSvc analytics.AnalyticsService
+	authSvc      authsvc.AuthService
+}
+
+// New creates a new analytics handler
+func New(
+	logger *zap.Logger,
+	analyticsSvc analytics.AnalyticsService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:       logger,
+		analyticsSvc: analyticsSvc,
+		authSvc:      authSvc,
+	}
+}
+
+// Register registers analytics routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/analytics", func(r chi.Router) {
+		r.Get("/activity", h.handleActivity)
+		r.Get("/breakdown", h.handleBreakdown)
+	})
+}
+
+// handleActivity returns notifications received, read and archived on each of the last
+// ?days=N days (default 30), with the mean time to read.
+func (h *Handler) handleActivity(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDays(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsSvc.Activity(ctx, userID, days)
+	if err != nil {
+		h.logger.Error(
+			"failed to load notification activity",
+			zap.Int("days", days),
+			zap.Error(errors.Join(ErrFailedToLoadActivity, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load notification activity")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, report)
+}
+
+// handleBreakdown returns notifications updated in the last ?days=N days (default 30)
+// counted by ?by=repo|org|reason (default repo).
+func (h *Handler) handleBreakdown(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDays(w, r)
+	if !ok {
+		return
+	}
+	by := models.BreakdownByRepository
+	if value := r.URL.Query().Get("by"); value != "" {
+		by = models.BreakdownDimension(value)
+		if !by.IsValid() {
+			helpers.WriteError(w, http.StatusBadRequest, "by must be repo, org or reason")
+			return
+		}
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsSvc.Breakdown(ctx, userID, days, by)
+	if err != nil {
+		h.logger.Error(
+			"failed to load notification breakdown",
+			zap.Int("days", days),
+			zap.String("by", string(by)),
+			zap.Error(errors.Join(ErrFailedToLoadBreakdown, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load notification breakdown")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, report)
+}
+
+// parseDays reads the ?days= window, writing a 400 when it's out of range
+func parseDays(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return defaultDays, true
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < analytics.MinDays || days > analytics.MaxDays {
+		helpers.WriteError(w, http.StatusBadRequest, "days must be between 1 and 90")
+		return 0, false
+	}
+	return days, true
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package analytics
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	analyticsmocks "github.com/octobud-hq/octobud/backend/internal/core/analytics/mocks"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*analyticsmocks.MockAnalyticsService),
+	path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := analyticsmocks.NewMockAnalyticsService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleActivity(t *testing.T) {
+	t.Run("defaults to thirty days", func(t *testing.T) {
+		w := serve(t, func(m *analyticsmocks.MockAnalyticsService) {
+			m.EXPECT().
+				Activity(gomock.Any(), testUserID, 30).
+				Return(&models.ActivityReport{
+					Days:                  30,
+					Daily:                 []models.DailyActivity{{Date: "2025-06-15", Received: 4}},
+					MeanTimeToReadSeconds: 90,
+				}, nil)
+		}, "/analytics/activity")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report models.ActivityReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		require.Equal(t, int64(4), report.Daily[0].Received)
+		require.Equal(t, int64(90), report.MeanTimeToReadSeconds)
+	})
+
+	t.Run("invalid window returns 400", func(t *testing.T) {
+		w := serve(t, nil, "/analytics/activity?days=0")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *analyticsmocks.MockAnalyticsService) {
+			m.EXPECT().
+				Activity(gomock.Any(), testUserID, 7).
+				Return(nil, errors.New("database error"))
+		}, "/analytics/activity?days=7")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_handleBreakdown(t *testing.T) {
+	t.Run("defaults to repositories", func(t *testing.T) {
+		w := serve(t, func(m *analyticsmocks.MockAnalyticsService) {
+			m.EXPECT().
+				Breakdown(gomock.Any(), testUserID, 30, models.BreakdownByRepository).
+				Return(&models.VolumeBreakdown{By: models.BreakdownByRepository}, nil)
+		}, "/analytics/breakdown")
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("uses the given dimension and window", func(t *testing.T) {
+		w := serve(t, func(m *analyticsmocks.MockAnalyticsService) {
+			m.EXPECT().
+				Breakdown(gomock.Any(), testUserID, 7, models.BreakdownByOrg).
+				Return(&models.VolumeBreakdown{
+					By:      models.BreakdownByOrg,
+					Entries: []models.VolumeEntry{{Key: "acme", Total: 3}},
+				}, nil)
+		}, "/analytics/breakdown?by=org&days=7")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report models.VolumeBreakdown
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		require.Equal(t, "acme", report.Entries[0].Key)
+	})
+
+	t.Run("unknown dimension returns 400", func(t *testing.T) {
+		w := serve(t, nil, "/analytics/breakdown?by=author")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -22,51 +22,137 @@ import (
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	apialerts "github.com/octobud-hq/octobud/backend/internal/api/alerts"
+	apianalytics "github.com/octobud-hq/octobud/backend/internal/api/analytics"
+	apichangelog "github.com/octobud-hq/octobud/backend/internal/api/changelog"
+	apievents "github.com/octobud-hq/octobud/backend/internal/api/events"
+	apigithubsettings "github.com/octobud-hq/octobud/backend/internal/api/githubsettings"
+	apihidden "github.com/octobud-hq/octobud/backend/internal/api/hidden"
+	"github.com/octobud-hq/octobud/backend/internal/api/integrations"
+	apilivequeries "github.com/octobud-hq/octobud/backend/internal/api/livequeries"
 	"github.com/octobud-hq/octobud/backend/internal/api/navigation"
 	"github.com/octobud-hq/octobud/backend/internal/api/notifications"
 	"github.com/octobud-hq/octobud/backend/internal/api/oauth"
+	apioffboarding "github.com/octobud-hq/octobud/backend/internal/api/offboarding"
+	apiquery "github.com/octobud-hq/octobud/backend/internal/api/query"
+	apiquicklook "github.com/octobud-hq/octobud/backend/internal/api/quicklook"
+	apiratelimit "github.com/octobud-hq/octobud/backend/internal/api/ratelimit"
 	"github.com/octobud-hq/octobud/backend/internal/api/repositories"
 	"github.com/octobud-hq/octobud/backend/internal/api/rules"
+	apisavedreplies "github.com/octobud-hq/octobud/backend/internal/api/savedreplies"
+	apisyncprogress "github.com/octobud-hq/octobud/backend/internal/api/syncprogress"
+	"github.com/octobud-hq/octobud/backend/internal/api/system"
 	"github.com/octobud-hq/octobud/backend/internal/api/tags"
+	apiteam "github.com/octobud-hq/octobud/backend/internal/api/team"
+	apitokens "github.com/octobud-hq/octobud/backend/internal/api/tokens"
 	apiuser "github.com/octobud-hq/octobud/backend/internal/api/user"
 	"github.com/octobud-hq/octobud/backend/internal/api/views"
+	apiwebhooks "github.com/octobud-hq/octobud/backend/internal/api/webhooks"
+	apiworkspaces "github.com/octobud-hq/octobud/backend/internal/api/workspaces"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
 	config "github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	"github.com/octobud-hq/octobud/backend/internal/core/analytics"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
 	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/authorprofile"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/core/changelog"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	"github.com/octobud-hq/octobud/backend/internal/core/githubsettings"
+	"github.com/octobud-hq/octobud/backend/internal/core/hidden"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
 	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/offboarding"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
 	"github.com/octobud-hq/octobud/backend/internal/core/pullrequest"
+	"github.com/octobud-hq/octobud/backend/internal/core/quicklook"
 	"github.com/octobud-hq/octobud/backend/internal/core/repository"
 	rulescore "github.com/octobud-hq/octobud/backend/internal/core/rules"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/core/suggest"
 	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
 	"github.com/octobud-hq/octobud/backend/internal/core/tag"
+	"github.com/octobud-hq/octobud/backend/internal/core/team"
 	timelinesvc "github.com/octobud-hq/octobud/backend/internal/core/timeline"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
 	"github.com/octobud-hq/octobud/backend/internal/core/update"
 	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
 	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
 	"github.com/octobud-hq/octobud/backend/internal/jobs"
 	"github.com/octobud-hq/octobud/backend/internal/osactions"
+	"github.com/octobud-hq/octobud/backend/internal/recovery"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
 )
 
 // Handler wires HTTP routes to database-backed operations.
 type Handler struct {
-	logger         *zap.Logger
-	store          db.Store
-	notifications  *notification.Service
-	syncService    sync.SyncOperations
-	githubClient   githubinterfaces.Client
-	timelineSvc    *timelinesvc.Service
-	scheduler      jobs.Scheduler
-	notificationsH *notifications.Handler
-	tagsH          *tags.Handler
-	viewsH         *views.Handler
-	rulesH         *rules.Handler
-	repositoriesH  *repositories.Handler
-	userH          *apiuser.Handler
-	oauthH         *oauth.Handler
+	logger          *zap.Logger
+	store           db.Store
+	notifications   *notification.Service
+	syncService     sync.SyncOperations
+	githubClient    githubinterfaces.Client
+	timelineSvc     *timelinesvc.Service
+	scheduler       jobs.Scheduler
+	githubMarker    notification.GitHubMarker
+	notificationsH  *notifications.Handler
+	tagsH           *tags.Handler
+	viewsH          *views.Handler
+	rulesH          *rules.Handler
+	repositoriesH   *repositories.Handler
+	userH           *apiuser.Handler
+	oauthH          *oauth.Handler
+	systemH         *system.Handler
+	quickLookH      *apiquicklook.Handler
+	integrationsH   *integrations.Handler
+	changelogH      *apichangelog.Handler
+	queryH          *apiquery.Handler
+	workspacesH     *apiworkspaces.Handler
+	savedRepliesH   *apisavedreplies.Handler
+	githubSettingsH *apigithubsettings.Handler
+	rateLimitH      *apiratelimit.Handler
+	syncProgressH   *apisyncprogress.Handler
+	teamH           *apiteam.Handler
+	analyticsH      *apianalytics.Handler
+	alertsH         *apialerts.Handler
+	hiddenH         *apihidden.Handler
+	offboardingH    *apioffboarding.Handler
+	liveQueriesH    *apilivequeries.Handler
+	eventsH         *apievents.Handler
+	webhooksH       *apiwebhooks.Handler
+	tokensH         *apitokens.Handler
 
 	tokenManager          apiuser.TokenManagerInterface
 	navigationBroadcaster *navigation.Broadcaster
+	startupReport         *recovery.Report
+	authenticator         authn.Authenticator
+	authorProfiles        authorprofile.AuthorProfileService
+	savedReplies          savedreply.SavedReplyService
+	alerts                alert.AlertService
+	liveQueries           livequery.LiveQueryService
+	events                events.EventBus
+	webhooks              webhook.WebhookService
+	webhookSecret         string
+	workspaces            *workspace.Manager
+	snapshots             *snapshot.Service
+	backups               backup.BackupService
+	digests               digest.DigestService
+	integrations          integration.IntegrationService
+	prewarm               prewarm.PrewarmService
+	apiTokens             apitoken.APITokenService
+	viewShares            viewshare.ViewShareService
 }
 
 // HandlerOption configures a Handler
@@ -106,6 +192,14 @@ func WithScheduler(scheduler jobs.Scheduler) HandlerOption {
 	}
 }
 
+// WithGitHubMarker configures where reads and archives are queued to be marked on GitHub
+// when there's no scheduler, such as when the command line opens the database directly.
+func WithGitHubMarker(marker notification.GitHubMarker) HandlerOption {
+	return func(h *Handler) {
+		h.githubMarker = marker
+	}
+}
+
 // WithTokenManager configures the handler with a GitHub token manager.
 // This enables GitHub token management endpoints.
 func WithTokenManager(tokenManager apiuser.TokenManagerInterface) HandlerOption {
@@ -122,6 +216,135 @@ func WithNavigationBroadcaster(broadcaster *navigation.Broadcaster) HandlerOptio
 	}
 }
 
+// WithStartupReport configures the handler with the report produced by startup recovery checks.
+// This enables the /system/last-startup endpoint.
+func WithStartupReport(report *recovery.Report) HandlerOption {
+	return func(h *Handler) {
+		h.startupReport = report
+	}
+}
+
+// WithAuthenticator configures how API requests are authenticated.
+// Without it, all requests are allowed (desktop mode on localhost).
+func WithAuthenticator(authenticator authn.Authenticator) HandlerOption {
+	return func(h *Handler) {
+		h.authenticator = authenticator
+	}
+}
+
+// WithAuthorProfiles configures the handler with the author profile cache.
+// This joins author names, avatars, and types into notification responses.
+func WithAuthorProfiles(profiles authorprofile.AuthorProfileService) HandlerOption {
+	return func(h *Handler) {
+		h.authorProfiles = profiles
+	}
+}
+
+// WithSavedReplies configures the handler with the saved reply cache.
+// This enables the /saved-replies endpoint and replying with a saved reply.
+func WithSavedReplies(savedReplies savedreply.SavedReplyService) HandlerOption {
+	return func(h *Handler) {
+		h.savedReplies = savedReplies
+	}
+}
+
+// WithAlerts configures the handler with the alert service that records alerts during sync.
+// This enables the /alerts feed and the /user/alert-settings endpoints.
+func WithAlerts(alerts alert.AlertService) HandlerOption {
+	return func(h *Handler) {
+		h.alerts = alerts
+	}
+}
+
+// WithLiveQueries configures the handler with the live query subscriptions shared with sync.
+// This enables the /live-queries stream and refreshes subscribers after every change.
+func WithLiveQueries(liveQueries livequery.LiveQueryService) HandlerOption {
+	return func(h *Handler) {
+		h.liveQueries = liveQueries
+	}
+}
+
+// WithEvents configures the handler with the event bus shared with sync.
+// This enables the /events stream and publishes upserts and bulk results made through the API.
+func WithEvents(bus events.EventBus) HandlerOption {
+	return func(h *Handler) {
+		h.events = bus
+	}
+}
+
+// WithWebhooks configures the handler with the GitHub webhook receiver.
+// This enables POST /webhooks/github, which accepts deliveries signed with secret.
+func WithWebhooks(webhooks webhook.WebhookService, secret string) HandlerOption {
+	return func(h *Handler) {
+		h.webhooks = webhooks
+		h.webhookSecret = secret
+	}
+}
+
+// WithWorkspaces configures the handler with the workspace manager.
+// This enables the /workspaces endpoints for listing, creating, and switching workspaces.
+func WithWorkspaces(manager *workspace.Manager) HandlerOption {
+	return func(h *Handler) {
+		h.workspaces = manager
+	}
+}
+
+// WithSnapshots configures the handler with a snapshot service.
+// This enables the /system/snapshot endpoints for writing read-only database copies.
+func WithSnapshots(snapshots *snapshot.Service) HandlerOption {
+	return func(h *Handler) {
+		h.snapshots = snapshots
+	}
+}
+
+// WithBackups configures the handler with the backup service shared with the scheduler.
+// This enables the /system/backups endpoints and the backup settings.
+func WithBackups(backups backup.BackupService) HandlerOption {
+	return func(h *Handler) {
+		h.backups = backups
+	}
+}
+
+// WithDigests configures the handler with the digest service shared with the scheduler.
+// This enables the digest settings and the /user/digests endpoints.
+func WithDigests(digests digest.DigestService) HandlerOption {
+	return func(h *Handler) {
+		h.digests = digests
+	}
+}
+
+// WithIntegrations configures the handler with the integration service shared with the
+// scheduler. This enables the /integrations/webhooks endpoints.
+func WithIntegrations(integrations integration.IntegrationService) HandlerOption {
+	return func(h *Handler) {
+		h.integrations = integrations
+	}
+}
+
+// WithPrewarm configures the handler with the prewarm service shared with the scheduler.
+// This records opened lists and notifications and serves results warmed for them at startup.
+func WithPrewarm(prewarmSvc prewarm.PrewarmService) HandlerOption {
+	return func(h *Handler) {
+		h.prewarm = prewarmSvc
+	}
+}
+
+// WithAPITokens configures the handler with the API token service. This enables the
+// /tokens endpoints and lets requests sign in with an "Authorization: Bearer" token.
+func WithAPITokens(apiTokens apitoken.APITokenService) HandlerOption {
+	return func(h *Handler) {
+		h.apiTokens = apiTokens
+	}
+}
+
+// WithViewShares configures the handler with view sharing for a multi-user server. This
+// enables the /views/{id}/shares endpoints.
+func WithViewShares(viewShares viewshare.ViewShareService) HandlerOption {
+	return func(h *Handler) {
+		h.viewShares = viewShares
+	}
+}
+
 // NewHandler returns an API handler backed by the provided db store.
 func NewHandler(store db.Store, opts ...HandlerOption) *Handler {
 	// Initialize zap logger with human-readable console format
@@ -132,14 +355,18 @@ func NewHandler(store db.Store, opts ...HandlerOption) *Handler {
 	repositorySvc := repository.NewService(store)
 	tagSvc := tag.NewService(store)
 	viewSvc := view.NewService(store)
-	ruleSvc := rulescore.NewService(store)
 	syncStateSvc := syncstate.NewSyncStateService(store)
 	authService := authsvc.NewService(store)
+	// Changes made through the API go in each notification's history
+	historySvc := history.NewService(store, time.Now, logger)
+	notificationsSvc.WithHistory(historySvc).WithUndo(undo.NewService(store, time.Now))
+	ruleSvc := rulescore.NewService(store).WithHistory(historySvc)
 
 	h := &Handler{
 		logger:        logger,
 		store:         store,
 		notifications: notificationsSvc,
+		authenticator: authn.NoAuth{},
 	}
 
 	// Apply options
@@ -147,15 +374,100 @@ func NewHandler(store db.Store, opts ...HandlerOption) *Handler {
 		opt(h)
 	}
 
+	if h.apiTokens != nil {
+		h.authenticator = authn.WithTokens(logger, h.authenticator, h.apiTokens)
+		h.tokensH = apitokens.New(logger, h.apiTokens, authService)
+	}
+	if h.authorProfiles != nil {
+		notificationsSvc.WithAuthorProfiles(h.authorProfiles)
+	}
+	if h.scheduler != nil && h.githubMarker == nil {
+		h.githubMarker = h.scheduler
+	}
+	if h.githubMarker != nil {
+		// Reads and archives are marked on GitHub when the user turns that on
+		notificationsSvc.WithGitHubMarker(h.githubMarker)
+	}
+	if h.events != nil {
+		notificationsSvc.WithEvents(h.events)
+	}
+
 	// Create all resource handlers
 	h.notificationsH = notifications.New(
 		logger, store, notificationsSvc, repositorySvc, tagSvc,
 		h.timelineSvc, h.githubClient, h.syncService, h.scheduler, authService,
-	)
+	).WithHistory(historySvc)
+	if h.savedReplies != nil {
+		h.notificationsH = h.notificationsH.WithSavedReplies(h.savedReplies)
+		h.savedRepliesH = apisavedreplies.New(logger, h.savedReplies, authService)
+	}
 	h.tagsH = tags.New(logger, tagSvc, authService)
-	h.viewsH = views.New(logger, viewSvc, authService)
+	triageSvc := triage.NewService(store, time.Now)
+	h.viewsH = views.New(logger, viewSvc, authService).
+		WithNotifications(notificationsSvc).
+		WithTriage(triageSvc)
+	if h.prewarm != nil {
+		h.notificationsH = h.notificationsH.WithPrewarm(h.prewarm)
+		h.viewsH = h.viewsH.WithPrewarm(h.prewarm)
+	}
+	if h.viewShares != nil {
+		h.viewsH = h.viewsH.WithSharing(h.viewShares)
+	}
 	h.rulesH = rules.NewWithScheduler(logger, ruleSvc, viewSvc, h.scheduler, authService)
+	if h.githubClient != nil {
+		githubSettingsSvc := githubsettings.NewService(store, h.githubClient, viewSvc, ruleSvc)
+		h.githubSettingsH = apigithubsettings.New(logger, githubSettingsSvc, authService)
+		h.rateLimitH = apiratelimit.New(logger, h.githubClient, time.Now)
+	}
+	workHoursSvc := workhours.NewService(store)
+	snoozeSvc := snooze.NewService(store, workHoursSvc, time.Now)
+	h.notificationsH = h.notificationsH.WithSnoozeDefaults(snoozeSvc)
 	h.repositoriesH = repositories.New(logger, repositorySvc, authService)
+	h.systemH = system.New(logger, h.startupReport)
+	if h.snapshots != nil {
+		h.systemH = h.systemH.WithSnapshots(h.snapshots)
+	}
+	if h.backups != nil {
+		h.systemH = h.systemH.WithBackups(h.backups)
+	}
+	h.changelogH = apichangelog.New(logger, changelog.NewService(store, time.Now))
+	h.queryH = apiquery.New(logger).WithSuggestions(suggest.NewService(store), authService)
+	teamSvc := team.NewService(store, time.Now)
+	h.teamH = apiteam.New(logger, teamSvc, authService)
+	h.analyticsH = apianalytics.New(logger, analytics.NewService(store, time.Now), authService)
+	h.hiddenH = apihidden.New(
+		logger,
+		hidden.NewService(notificationsSvc, authService, time.Now),
+		authService,
+	)
+	h.offboardingH = apioffboarding.New(
+		logger,
+		offboarding.NewService(store, notificationsSvc),
+		authService,
+	)
+	if h.liveQueries != nil {
+		h.liveQueriesH = apilivequeries.New(logger, h.liveQueries, authService)
+	}
+	if h.events != nil {
+		h.eventsH = apievents.New(logger, h.events, authService)
+	}
+	if h.webhooks != nil && h.webhookSecret != "" {
+		h.webhooksH = apiwebhooks.New(logger, h.webhookSecret, h.webhooks)
+	}
+	if h.workspaces != nil {
+		h.workspacesH = apiworkspaces.New(logger, h.workspaces)
+	}
+
+	// Quick look and launcher integrations share one summary cache
+	quickLookSvc := quicklook.NewService(store, time.Now).WithHistory(historySvc)
+	h.quickLookH = apiquicklook.New(logger, quickLookSvc, authService)
+	h.integrationsH = integrations.New(logger, quickLookSvc, authService)
+	if h.authorProfiles != nil {
+		h.integrationsH = h.integrationsH.WithAuthorProfiles(h.authorProfiles)
+	}
+	if h.integrations != nil {
+		h.integrationsH = h.integrationsH.WithWebhooks(h.integrations)
+	}
 
 	// Create user handler
 	h.userH = apiuser.New(logger, authService)
@@ -163,9 +475,25 @@ func NewHandler(store db.Store, opts ...HandlerOption) *Handler {
 	// Wire up scheduler and sync state
 	if h.scheduler != nil {
 		h.userH = h.userH.WithScheduler(h.scheduler)
+		h.syncProgressH = apisyncprogress.New(logger, syncStateSvc, h.scheduler, authService, time.Now)
 	}
 	h.userH = h.userH.WithSyncStateService(syncStateSvc)
 	h.userH = h.userH.WithStore(store)
+	h.userH = h.userH.WithWorkingHoursService(workHoursSvc)
+	h.userH = h.userH.WithSnoozeService(snoozeSvc)
+	h.userH = h.userH.WithPriorityService(priority.NewService(store, time.Now))
+	if h.backups != nil {
+		h.userH = h.userH.WithBackupService(h.backups)
+	}
+	if h.digests != nil {
+		h.userH = h.userH.WithDigestService(h.digests)
+	}
+	h.userH = h.userH.WithTeamService(teamSvc)
+	h.userH = h.userH.WithTriageService(triageSvc)
+	if h.alerts != nil {
+		h.userH = h.userH.WithAlertService(h.alerts)
+		h.alertsH = apialerts.New(logger, h.alerts, authService)
+	}
 
 	// Wire up token manager
 	if h.tokenManager != nil {
@@ -193,28 +521,87 @@ func (h *Handler) Register(r chi.Router) {
 	h.viewsH.Register(r)
 	h.rulesH.Register(r)
 	h.repositoriesH.Register(r)
+	h.systemH.Register(r)
+	h.changelogH.Register(r)
+	h.queryH.Register(r)
+	h.teamH.Register(r)
+	h.analyticsH.Register(r)
+	h.hiddenH.Register(r)
+	h.offboardingH.Register(r)
+	h.quickLookH.Register(r)
+	h.integrationsH.Register(r)
+	if h.savedRepliesH != nil {
+		h.savedRepliesH.Register(r)
+	}
+	if h.tokensH != nil {
+		h.tokensH.Register(r)
+	}
+	if h.githubSettingsH != nil {
+		h.githubSettingsH.Register(r)
+	}
+	if h.rateLimitH != nil {
+		h.rateLimitH.Register(r)
+	}
+	if h.syncProgressH != nil {
+		h.syncProgressH.Register(r)
+	}
+	if h.alertsH != nil {
+		h.alertsH.Register(r)
+	}
+	if h.liveQueriesH != nil {
+		h.liveQueriesH.Register(r)
+	}
+	if h.eventsH != nil {
+		h.eventsH.Register(r)
+	}
 }
 
 // RegisterAllRoutes registers all API routes.
 func (h *Handler) RegisterAllRoutes(r chi.Router) {
-	// User routes
-	if h.userH != nil {
-		h.userH.Register(r)
-	}
+	// Login routes must be reachable without a session
+	h.authenticator.Register(r)
 
-	// OAuth routes
-	if h.oauthH != nil {
-		h.oauthH.Register(r)
+	// Webhook deliveries are authenticated by their signature instead of a session
+	if h.webhooksH != nil {
+		h.webhooksH.Register(r)
 	}
 
-	// Navigation routes (SSE for tray menu navigation)
-	if h.navigationBroadcaster != nil {
-		navHandler := navigation.NewHandler(h.logger, h.navigationBroadcaster)
-		navHandler.Register(r)
-	}
+	r.Group(func(r chi.Router) {
+		r.Use(h.authenticator.Middleware)
+		if h.liveQueriesH != nil {
+			r.Use(h.liveQueriesH.Middleware)
+		}
+
+		// User routes
+		if h.userH != nil {
+			h.userH.Register(r)
+		}
+
+		// OAuth routes
+		if h.oauthH != nil {
+			h.oauthH.Register(r)
+		}
+
+		// Workspace routes
+		if h.workspacesH != nil {
+			h.workspacesH.Register(r)
+		}
+
+		// Navigation routes (SSE for tray menu navigation)
+		if h.navigationBroadcaster != nil {
+			navHandler := navigation.NewHandler(h.logger, h.navigationBroadcaster)
+			navHandler.Register(r)
+		}
+
+		// All other API routes
+		h.Register(r)
+	})
+}
 
-	// All other API routes
-	h.Register(r)
+// Authenticator returns the configured authenticator so the same policy can be
+// applied to the frontend.
+func (h *Handler) Authenticator() authn.Authenticator {
+	return h.authenticator
 }
 
 // GetNavigationBroadcaster returns the navigation broadcaster if configured.
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	backupmocks "github.com/octobud-hq/octobud/backend/internal/core/backup/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestNewHandler_WithBackups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockBackup := backupmocks.NewMockBackupService(ctrl)
+
+	mockStore.EXPECT().
+		GetUser(gomock.Any()).
+		Return(db.User{ID: 1, GithubUserID: sql.NullString{String: "test-user-id", Valid: true}}, nil).
+		AnyTimes()
+	mockBackup.EXPECT().
+		GetBackupSettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultBackupSettings(), nil)
+
+	var h *Handler
+	require.NotPanics(t, func() {
+		h = NewHandler(mockStore, WithBackups(mockBackup))
+	})
+	require.NotNil(t, h)
+
+	// The user handler must receive the backup service, not only the system handler
+	router := chi.NewRouter()
+	h.RegisterAllRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/backup-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+// recordingMarker records the threads it's asked to mark on GitHub
+type recordingMarker struct {
+	githubIDs []string
+	done      bool
+}
+
+func (m *recordingMarker) EnqueueMarkOnGitHub(
+	_ context.Context,
+	_ string,
+	githubIDs []string,
+	done bool,
+) error {
+	m.githubIDs = append(m.githubIDs, githubIDs...)
+	m.done = done
+	return nil
+}
+
+func TestNewHandler_WithGitHubMarker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	syncSettings, err := json.Marshal(models.SyncSettings{MarkOnGitHub: true})
+	require.NoError(t, err)
+	mockStore.EXPECT().
+		GetUser(gomock.Any()).
+		Return(db.User{
+			ID:           1,
+			GithubUserID: sql.NullString{String: "test-user-id", Valid: true},
+			SyncSettings: db.NullRawMessage{RawMessage: syncSettings, Valid: true},
+		}, nil).
+		AnyTimes()
+	archived := db.Notification{ID: 1, GithubID: "thread-1", Archived: true}
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), "test-user-id", "thread-1").
+		Return(archived, nil)
+	mockStore.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "test-user-id", "thread-1").
+		Return(archived, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		ListTagsForEntity(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		RecordNotificationEvents(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	// Without a scheduler, as when the command line opens the database directly, archives
+	// still reach the marker
+	marker := &recordingMarker{}
+	h := NewHandler(mockStore, WithGitHubMarker(marker))
+
+	router := chi.NewRouter()
+	h.RegisterAllRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/notifications/thread-1/archive", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"thread-1"}, marker.githubIDs)
+	require.True(t, marker.done)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package changelog provides the handler for changelog routes.
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	changelogcore "github.com/octobud-hq/octobud/backend/internal/core/changelog"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Handler handles changelog HTTP routes
+type Handler struct {
+	logger       *zap.Logger
+	changelogSvc changelogcore.ChangelogService
+}
+
+// New creates a new changelog handler
+func New(logger *zap.Logger, changelogSvc changelogcore.ChangelogService) *Handler {
+	return &Handler{
+		logger:       logger,
+		changelogSvc: changelogSvc,
+	}
+}
+
+// Register registers changelog routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/changelog", func(r chi.Router) {
+		r.Get("/unseen", h.handleListUnseen)
+		r.Post("/acknowledge", h.handleAcknowledge)
+	})
+}
+
+type listUnseenResponse struct {
+	Entries []models.ChangelogEntry `json:"entries"`
+}
+
+type acknowledgeRequest struct {
+	// UpToID is the newest entry the client displayed; 0 or omitted acknowledges everything
+	UpToID int64 `json:"upToId"`
+}
+
+type acknowledgeResponse struct {
+	Acknowledged int64 `json:"acknowledged"`
+}
+
+func (h *Handler) handleListUnseen(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.changelogSvc.ListUnseen(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list changelog entries", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load changelog")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listUnseenResponse{Entries: entries})
+}
+
+func (h *Handler) handleAcknowledge(w http.ResponseWriter, r *http.Request) {
+	var req acknowledgeRequest
+	// An empty body acknowledges everything
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	count, err := h.changelogSvc.Acknowledge(r.Context(), req.UpToID)
+	if err != nil {
+		if errors.Is(err, changelogcore.ErrInvalidAcknowledgeThreshold) {
+			helpers.WriteError(w, http.StatusBadRequest, "upToId must not be negative")
+			return
+		}
+		h.logger.Error("failed to acknowledge changelog entries", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to acknowledge changelog")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, acknowledgeResponse{Acknowledged: count})
+}
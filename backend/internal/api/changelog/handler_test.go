@@ -0,0 +1,137 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package changelog
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	changelogcore "github.com/octobud-hq/octobud/backend/internal/core/changelog"
+	changelogmocks "github.com/octobud-hq/octobud/backend/internal/core/changelog/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func serve(
+	t *testing.T,
+	setupMock func(*changelogmocks.MockChangelogService),
+	method, path, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := changelogmocks.NewMockChangelogService(ctrl)
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc).Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestHandler_handleListUnseen(t *testing.T) {
+	w := serve(t, func(m *changelogmocks.MockChangelogService) {
+		m.EXPECT().ListUnseen(gomock.Any()).Return([]models.ChangelogEntry{
+			{ID: 1, SchemaVersion: 4, Kind: "behavior_change", Title: "Renames"},
+		}, nil)
+	}, http.MethodGet, "/changelog/unseen", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"title":"Renames"`)
+	require.Contains(t, w.Body.String(), `"kind":"behavior_change"`)
+}
+
+func TestHandler_handleListUnseen_Empty(t *testing.T) {
+	w := serve(t, func(m *changelogmocks.MockChangelogService) {
+		m.EXPECT().ListUnseen(gomock.Any()).Return([]models.ChangelogEntry{}, nil)
+	}, http.MethodGet, "/changelog/unseen", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"entries":[]}`, w.Body.String())
+}
+
+func TestHandler_handleAcknowledge(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*changelogmocks.MockChangelogService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "acknowledges up to id",
+			body: `{"upToId": 3}`,
+			setupMock: func(m *changelogmocks.MockChangelogService) {
+				m.EXPECT().Acknowledge(gomock.Any(), int64(3)).Return(int64(2), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"acknowledged":2}`,
+		},
+		{
+			name: "empty body acknowledges everything",
+			setupMock: func(m *changelogmocks.MockChangelogService) {
+				m.EXPECT().Acknowledge(gomock.Any(), int64(0)).Return(int64(5), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"acknowledged":5}`,
+		},
+		{
+			name:           "invalid body",
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "negative id",
+			body: `{"upToId": -1}`,
+			setupMock: func(m *changelogmocks.MockChangelogService) {
+				m.EXPECT().
+					Acknowledge(gomock.Any(), int64(-1)).
+					Return(int64(0), changelogcore.ErrInvalidAcknowledgeThreshold)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: `{}`,
+			setupMock: func(m *changelogmocks.MockChangelogService) {
+				m.EXPECT().
+					Acknowledge(gomock.Any(), int64(0)).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, tt.setupMock, http.MethodPost, "/changelog/acknowledge", tt.body)
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package events provides the API for streaming live inbox updates.
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	coreevents "github.com/octobud-hq/octobud/backend/internal/core/events"
+)
+
+// pingInterval is how often an idle stream is sent a keepalive
+const pingInterval = 30 * time.Second
+
+// Handler handles live update HTTP routes
+type Handler struct {
+	logger  *zap.Logger
+	events  coreevents.EventBus
+	authSvc authsvc.AuthService
+}
+
+// New creates a new live update handler
+func New(
+	logger *zap.Logger,
+	events coreevents.EventBus,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:  logger,
+		events:  events,
+		authSvc: authSvc,
+	}
+}
+
+// Register registers live update routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/events", h.handleStream)
+}
+
+// handleStream streams notification upserts, bulk operation results and sync status as
+// Server-Sent Events for as long as the stream is open.
+func (h *Handler) handleStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	sub := h.events.Subscribe(userID)
+	defer h.events.Unsubscribe(sub)
+
+	// The stream stays open far longer than the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("failed to clear write deadline for event stream", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+
+	err := h.sendEvent(w, "connected", map[string]interface{}{
+		"timestamp": time.Now().Unix(),
+	})
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if err := h.sendEvent(w, string(event.Type), event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			err := h.sendEvent(w, "ping", map[string]interface{}{
+				"timestamp": time.Now().Unix(),
+			})
+			if err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendEvent writes and flushes an SSE-formatted event. An error means the connection
+// is broken.
+func (h *Handler) sendEvent(w http.ResponseWriter, eventType string, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error(
+			"failed to marshal live update event",
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// SSE format: "event: <type>\ndata: <json>\n\n"
+	if _, err := w.Write([]byte("event: " + eventType + "\ndata: " + string(jsonData) + "\n\n")); err != nil {
+		h.logger.Debug(
+			"failed to write live update event",
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package events
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	coreevents "github.com/octobud-hq/octobud/backend/internal/core/events"
+	eventmocks "github.com/octobud-hq/octobud/backend/internal/core/events/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func TestHandler_handleStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBus := eventmocks.NewMockEventBus(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	handler := New(zap.NewNop(), mockBus, mockAuthSvc)
+
+	// A subscriber with one event waiting that's already been removed, so the stream
+	// ends once the event is sent
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	bus := coreevents.NewService(zap.NewNop(), func() time.Time { return now })
+	sub := bus.Subscribe(testUserID)
+	bus.Publish(testUserID, models.Event{Type: models.EventNotificationUpserted, GithubID: "n1"})
+	bus.Unsubscribe(sub)
+
+	mockBus.EXPECT().Subscribe(testUserID).Return(sub)
+	mockBus.EXPECT().Unsubscribe(sub)
+
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	require.Contains(t, body, "event: connected\n")
+	require.Contains(t, body,
+		"event: notification_upserted\n"+
+			`data: {"type":"notification_upserted","githubId":"n1","timestamp":"2025-06-15T12:00:00Z"}`+"\n\n")
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package githubsettings serves views and rules suggested from GitHub notification settings.
+package githubsettings
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/githubsettings"
+	rulescore "github.com/octobud-hq/octobud/backend/internal/core/rules"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Handler handles GitHub settings suggestion routes
+type Handler struct {
+	logger   *zap.Logger
+	settings githubsettings.GitHubSettingsService
+	authSvc  authsvc.AuthService
+}
+
+// New creates a new GitHub settings handler
+func New(
+	logger *zap.Logger,
+	settings githubsettings.GitHubSettingsService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:   logger,
+		settings: settings,
+		authSvc:  authSvc,
+	}
+}
+
+// Register registers GitHub settings routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/github-settings/suggestions", func(r chi.Router) {
+		r.Get("/", h.handleListSuggestions)
+		r.Post("/{id}/adopt", h.handleAdoptSuggestion)
+	})
+}
+
+type listSuggestionsResponse struct {
+	Suggestions []models.SettingsSuggestion `json:"suggestions"`
+}
+
+// handleListSuggestions suggests views and rules mirroring the user's GitHub watch settings
+func (h *Handler) handleListSuggestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.settings.Suggestions(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to suggest from GitHub settings", zap.Error(err))
+		if errors.Is(err, githubsettings.ErrFailedToFetchSettings) {
+			helpers.WriteError(w, http.StatusBadGateway, "Failed to read notification settings from GitHub")
+			return
+		}
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load suggestions")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listSuggestionsResponse{Suggestions: suggestions})
+}
+
+// handleAdoptSuggestion creates the view or rule of a suggestion
+func (h *Handler) handleAdoptSuggestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	suggestionID := chi.URLParam(r, "id")
+	adopted, err := h.settings.Adopt(ctx, userID, suggestionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, githubsettings.ErrSuggestionNotFound):
+			helpers.WriteError(w, http.StatusNotFound, "suggestion not found")
+		case errors.Is(err, view.ErrViewNameAlreadyExists),
+			errors.Is(err, rulescore.ErrRuleNameAlreadyExists):
+			helpers.WriteError(w, http.StatusConflict, "suggestion already adopted")
+		case errors.Is(err, githubsettings.ErrFailedToFetchSettings):
+			h.logger.Error("failed to adopt suggestion", zap.Error(err))
+			helpers.WriteError(w, http.StatusBadGateway, "Failed to read notification settings from GitHub")
+		default:
+			h.logger.Error("failed to adopt suggestion", zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to adopt suggestion")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, adopted)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package githubsettings
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/githubsettings"
+	settingsmocks "github.com/octobud-hq/octobud/backend/internal/core/githubsettings/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*settingsmocks.MockGitHubSettingsService),
+	method, path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := settingsmocks.NewMockGitHubSettingsService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	setupMock(mockSvc)
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleListSuggestions(t *testing.T) {
+	w := serve(t, func(m *settingsmocks.MockGitHubSettingsService) {
+		m.EXPECT().Suggestions(gomock.Any(), testUserID).Return([]models.SettingsSuggestion{{
+			ID:           "ignored",
+			Kind:         models.SettingsSuggestionRule,
+			Name:         "Ignored on GitHub",
+			Reason:       "You ignore these repositories on GitHub",
+			Query:        "repo:acme/legacy",
+			Actions:      &models.RuleActions{Mute: true},
+			Repositories: []string{"acme/legacy"},
+		}}, nil)
+	}, http.MethodGet, "/github-settings/suggestions")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"suggestions": [{
+		"id": "ignored",
+		"kind": "rule",
+		"name": "Ignored on GitHub",
+		"reason": "You ignore these repositories on GitHub",
+		"query": "repo:acme/legacy",
+		"actions": {"skipInbox": false, "mute": true},
+		"repositories": ["acme/legacy"],
+		"adopted": false
+	}]}`, w.Body.String())
+}
+
+func TestHandler_handleListSuggestions_GitHubError(t *testing.T) {
+	w := serve(t, func(m *settingsmocks.MockGitHubSettingsService) {
+		m.EXPECT().
+			Suggestions(gomock.Any(), testUserID).
+			Return(nil, errors.Join(githubsettings.ErrFailedToFetchSettings, errors.New("rate limited")))
+	}, http.MethodGet, "/github-settings/suggestions")
+
+	require.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestHandler_handleAdoptSuggestion(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "adopted", expectedStatus: http.StatusCreated},
+		{
+			name:           "unknown suggestion",
+			err:            githubsettings.ErrSuggestionNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "already adopted",
+			err:            errors.Join(githubsettings.ErrFailedToAdopt, view.ErrViewNameAlreadyExists),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "store error",
+			err:            errors.New("database is locked"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, func(m *settingsmocks.MockGitHubSettingsService) {
+				m.EXPECT().
+					Adopt(gomock.Any(), testUserID, "watching:acme").
+					Return(models.AdoptedSuggestion{View: &models.View{ID: "view-1"}}, tt.err)
+			}, http.MethodPost, "/github-settings/suggestions/watching:acme/adopt")
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				require.Contains(t, w.Body.String(), `"view":{"id":"view-1"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package helpers
+
+import (
+	"net/http"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// DependentRule identifies a rule that references a tag or view being deleted.
+type DependentRule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// dependentRulesConflict is the 409 response for deleting a tag or view that rules use.
+// linkedRuleCount is kept for clients that only show a count.
+type dependentRulesConflict struct {
+	Error           string          `json:"error"`
+	LinkedRuleCount int             `json:"linkedRuleCount"`
+	DependentRules  []DependentRule `json:"dependentRules"`
+}
+
+// disabledRulesResponse is the response for a forced delete that disabled rules.
+type disabledRulesResponse struct {
+	DisabledRules []DependentRule `json:"disabledRules"`
+}
+
+// DependentRules converts rules to their DependentRule summaries.
+func DependentRules(rules []models.Rule) []DependentRule {
+	result := make([]DependentRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, DependentRule{
+			ID:      rule.ID,
+			Name:    rule.Name,
+			Enabled: rule.Enabled,
+		})
+	}
+	return result
+}
+
+// WriteDependentRulesConflict writes a 409 listing the rules that block a delete.
+func WriteDependentRulesConflict(w http.ResponseWriter, msg string, rules []models.Rule) {
+	WriteJSON(w, http.StatusConflict, dependentRulesConflict{
+		Error:           msg,
+		LinkedRuleCount: len(rules),
+		DependentRules:  DependentRules(rules),
+	})
+}
+
+// WriteDeleted writes the response for a successful delete: 204, or 200 listing the rules
+// that were disabled when a forced delete had dependents.
+func WriteDeleted(w http.ResponseWriter, disabled []models.Rule) {
+	if len(disabled) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	WriteJSON(w, http.StatusOK, disabledRulesResponse{DisabledRules: DependentRules(disabled)})
+}
+
+// RuleIDs returns the IDs of rules, for logging.
+func RuleIDs(rules []models.Rule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// downloadChunkSize is how much of a download is sent between flushes
+const downloadChunkSize = 32 * 1024
+
+// Download is a generated file that Write can produce again with the same bytes, which
+// lets ServeDownload resume it from an offset
+type Download struct {
+	Filename    string
+	ContentType string
+	// ETag identifies the bytes Write produces. A Range request whose If-Range names a
+	// different ETag gets the whole file instead.
+	ETag string
+	// Size is the length of the whole file in bytes
+	Size int64
+	// Write writes the whole file to w. It should fail if it can no longer produce the
+	// bytes ETag names.
+	Write func(ctx context.Context, w io.Writer) error
+}
+
+// ServeDownload streams d without holding it in memory. Write runs on its own goroutine
+// and feeds the response through a pipe, flushing each chunk as it is written. Responses
+// use chunked encoding; the total size is sent in X-Download-Size so clients can show
+// progress. A single byte range is honored so interrupted downloads can resume.
+//
+// If Write fails after the response has started, the connection is aborted so the client
+// sees an incomplete download rather than a truncated file.
+func ServeDownload(w http.ResponseWriter, r *http.Request, d Download) {
+	// Large downloads take far longer than the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	start, length, status := int64(0), d.Size, http.StatusOK
+	if spec := r.Header.Get("Range"); spec != "" && ifRangeMatches(r, d.ETag) {
+		rangeStart, rangeLength, ok, satisfiable := parseRange(spec, d.Size)
+		if !satisfiable {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", d.Size))
+			WriteError(w, http.StatusRequestedRangeNotSatisfiable, "requested range not satisfiable")
+			return
+		}
+		if ok {
+			start, length, status = rangeStart, rangeLength, http.StatusPartialContent
+		}
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(d.Write(ctx, pw))
+	}()
+
+	if start > 0 {
+		if _, err := io.CopyN(io.Discard, pr, start); err != nil {
+			WriteError(w, http.StatusInternalServerError, "failed to generate download")
+			return
+		}
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", d.ContentType)
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, d.Filename))
+	header.Set("ETag", d.ETag)
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("X-Download-Size", strconv.FormatInt(d.Size, 10))
+	header.Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	if status == http.StatusPartialContent {
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, d.Size))
+	}
+	w.WriteHeader(status)
+
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, downloadChunkSize)
+	sent, err := io.CopyBuffer(flushWriter{w, flusher}, io.LimitReader(pr, length), buf)
+	if err == nil && sent < length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == nil && start+length == d.Size {
+		// Wait for Write to finish, so a failure at the very end still aborts
+		_, err = pr.Read(buf[:1])
+		if errors.Is(err, io.EOF) {
+			err = nil
+		} else if err == nil {
+			err = errors.New("download is longer than its size")
+		}
+	}
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// flushWriter flushes each chunk written to the response
+type flushWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+	return n, err
+}
+
+// ifRangeMatches reports whether a Range request applies to the file named by etag.
+// Dates aren't supported in If-Range since downloads have no modification time.
+func ifRangeMatches(r *http.Request, etag string) bool {
+	ifRange := r.Header.Get("If-Range")
+	return ifRange == "" || ifRange == etag
+}
+
+// parseRange parses a Range header for a file of size bytes. ok is false for ranges that
+// are ignored in favor of the whole file: other units, several ranges and malformed
+// values. satisfiable is false when the range starts past the end of the file.
+func parseRange(spec string, size int64) (start, length int64, ok, satisfiable bool) {
+	spec, found := strings.CutPrefix(spec, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, true
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, true
+	}
+
+	if first == "" {
+		// A suffix range: the last n bytes
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return 0, 0, false, true
+		}
+		if n == 0 {
+			return 0, 0, false, false
+		}
+		n = min(n, size)
+		return size - n, n, true, true
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false, true
+	}
+	if start >= size {
+		return 0, 0, false, false
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, true
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, true, true
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package hidden provides the API for managing temporarily hidden notifications.
+package hidden
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/hidden"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Handler handles hidden notification HTTP routes
+type Handler struct {
+	logger    *zap.Logger
+	hiddenSvc hidden.HiddenService
+	authSvc   authsvc.AuthService
+}
+
+// New creates a new hidden notification handler
+func New(logger *zap.Logger, hiddenSvc hidden.HiddenService, authSvc authsvc.AuthService) *Handler {
+	return &Handler{
+		logger:    logger,
+		hiddenSvc: hiddenSvc,
+		authSvc:   authSvc,
+	}
+}
+
+// wakeResponse reports how much a wake operation brought back
+type wakeResponse struct {
+	Mechanism models.HiddenMechanism `json:"mechanism"`
+	Woken     int64                  `json:"woken"`
+}
+
+// Register registers hidden notification routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/hidden", func(r chi.Router) {
+		r.Get("/", h.handleList)
+		r.Post("/{mechanism}/wake", h.handleWake)
+	})
+}
+
+// handleList returns snoozed, muted and filtered notifications grouped by mechanism,
+// plus the account-wide mute
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	summary, err := h.hiddenSvc.List(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to list hidden notifications", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list hidden notifications")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, summary)
+}
+
+// handleWake brings back everything hidden by one mechanism
+func (h *Handler) handleWake(w http.ResponseWriter, r *http.Request) {
+	mechanism := models.HiddenMechanism(chi.URLParam(r, "mechanism"))
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	woken, err := h.hiddenSvc.Wake(ctx, userID, mechanism)
+	if err != nil {
+		if errors.Is(err, hidden.ErrUnknownMechanism) {
+			helpers.WriteError(
+				w,
+				http.StatusBadRequest,
+				"mechanism must be one of snoozed, muted, filtered, account",
+			)
+			return
+		}
+		h.logger.Error(
+			"failed to wake hidden notifications",
+			zap.String("mechanism", string(mechanism)),
+			zap.Error(err),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to wake hidden notifications")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, wakeResponse{Mechanism: mechanism, Woken: woken})
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package hidden
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/hidden"
+	hiddenmocks "github.com/octobud-hq/octobud/backend/internal/core/hidden/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*hiddenmocks.MockHiddenService),
+	method, path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := hiddenmocks.NewMockHiddenService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleList(t *testing.T) {
+	t.Run("returns groups", func(t *testing.T) {
+		w := serve(t, func(m *hiddenmocks.MockHiddenService) {
+			m.EXPECT().
+				List(gomock.Any(), testUserID).
+				Return(&models.HiddenSummary{Groups: []models.HiddenGroup{{
+					Mechanism:     models.HiddenSnoozed,
+					Query:         "in:snoozed",
+					Total:         1,
+					Notifications: []models.Notification{{GithubID: "n1"}},
+				}}}, nil)
+		}, http.MethodGet, "/hidden")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var summary models.HiddenSummary
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+		require.Len(t, summary.Groups, 1)
+		require.Equal(t, "n1", summary.Groups[0].Notifications[0].GithubID)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *hiddenmocks.MockHiddenService) {
+			m.EXPECT().
+				List(gomock.Any(), testUserID).
+				Return(nil, errors.New("database error"))
+		}, http.MethodGet, "/hidden")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_handleWake(t *testing.T) {
+	t.Run("wakes a group", func(t *testing.T) {
+		w := serve(t, func(m *hiddenmocks.MockHiddenService) {
+			m.EXPECT().
+				Wake(gomock.Any(), testUserID, models.HiddenSnoozed).
+				Return(int64(3), nil)
+		}, http.MethodPost, "/hidden/snoozed/wake")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response wakeResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, int64(3), response.Woken)
+	})
+
+	t.Run("unknown mechanism returns 400", func(t *testing.T) {
+		w := serve(t, func(m *hiddenmocks.MockHiddenService) {
+			m.EXPECT().
+				Wake(gomock.Any(), testUserID, models.HiddenMechanism("archived")).
+				Return(int64(0), hidden.ErrUnknownMechanism)
+		}, http.MethodPost, "/hidden/archived/wake")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *hiddenmocks.MockHiddenService) {
+			m.EXPECT().
+				Wake(gomock.Any(), testUserID, models.HiddenMuted).
+				Return(int64(0), errors.New("database error"))
+		}, http.MethodPost, "/hidden/muted/wake")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
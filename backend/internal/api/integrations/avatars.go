@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+)
+
+const (
+	// avatarTTL is how long a proxied avatar is served from memory
+	avatarTTL = 24 * time.Hour
+	// maxAvatarEntries caps how many avatars are kept in memory
+	maxAvatarEntries = 500
+	// maxAvatarBytes caps the size of a single avatar
+	maxAvatarBytes = 1 << 20
+	// avatarSize is the pixel size requested from GitHub; launchers show small icons
+	avatarSize = 64
+)
+
+// loginPattern matches GitHub user, organization, and bot logins
+var loginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})(?:\[bot\])?$`)
+
+// avatarCache fetches avatars from GitHub and keeps them in memory, so launchers can show
+// icons without talking to GitHub themselves
+type avatarCache struct {
+	*imageCache
+	fallbackURL string
+}
+
+func newAvatarCache(client *http.Client, now func() time.Time) *avatarCache {
+	return &avatarCache{
+		imageCache:  newImageCache(client, now, avatarTTL, maxAvatarEntries, maxAvatarBytes),
+		fallbackURL: "https://github.com",
+	}
+}
+
+// handleAvatar serves the avatar for a login
+func (h *Handler) handleAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	login, err := url.PathUnescape(chi.URLParam(r, "login"))
+	if err != nil || !loginPattern.MatchString(login) {
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid login")
+		return
+	}
+
+	var profileURL string
+	if h.authorProfiles != nil {
+		profile, found := h.authorProfiles.Profiles(ctx, userID, []string{login})[login]
+		if found && profile.AvatarURL != nil {
+			profileURL = *profile.AvatarURL
+		}
+	}
+
+	entry, err := h.avatars.get(ctx, login, profileURL)
+	if err != nil {
+		if errors.Is(err, errImageNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "Avatar not found")
+			return
+		}
+		h.logger.Warn(
+			"failed to load avatar",
+			zap.String("login", login),
+			zap.Error(errors.Join(ErrFailedToLoadAvatar, err)),
+		)
+		helpers.WriteError(w, http.StatusBadGateway, "Failed to load avatar")
+		return
+	}
+
+	writeImage(w, entry, avatarTTL)
+}
+
+// get returns a login's avatar, fetching it when it isn't cached. profileURL is the avatar
+// URL from the author's cached profile, if known.
+func (c *avatarCache) get(ctx context.Context, login, profileURL string) (imageEntry, error) {
+	return c.load(ctx, strings.ToLower(login), c.sourceURL(login, profileURL))
+}
+
+// sourceURL prefers the profile's avatar URL when it points at GitHub, and otherwise uses
+// GitHub's login-based avatar redirect
+func (c *avatarCache) sourceURL(login, profileURL string) string {
+	if profileURL != "" {
+		if u, err := url.Parse(profileURL); err == nil && isGitHubAvatarHost(u.Host) {
+			q := u.Query()
+			q.Set("s", fmt.Sprint(avatarSize))
+			u.RawQuery = q.Encode()
+			return u.String()
+		}
+	}
+	return fmt.Sprintf("%s/%s.png?size=%d", c.fallbackURL, url.PathEscape(login), avatarSize)
+}
+
+// isGitHubAvatarHost reports whether a host serves GitHub avatars
+func isGitHubAvatarHost(host string) bool {
+	return host == "github.com" || host == "githubusercontent.com" ||
+		strings.HasSuffix(host, ".githubusercontent.com")
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package integrations provides ready-to-render payloads for third-party launcher extensions,
+// and manages the webhooks rules can send notifications to.
+package integrations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/authorprofile"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/core/quicklook"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadSummary = errors.New("failed to load quick look summary")
+	ErrFailedToLoadAvatar  = errors.New("failed to load avatar")
+	ErrFailedToLoadImage   = errors.New("failed to load image")
+)
+
+// Handler handles launcher integration routes. Like the quick look API, the launcher payload
+// is a stable contract; see docs/guides/quick-look-api.md before changing it.
+type Handler struct {
+	logger         *zap.Logger
+	quickLookSvc   quicklook.QuickLookService
+	authSvc        authsvc.AuthService
+	authorProfiles authorprofile.AuthorProfileService
+	avatars        *avatarCache
+	images         *imageCache
+	webhooks       integration.IntegrationService
+}
+
+// New creates a new integrations handler
+func New(
+	logger *zap.Logger,
+	quickLookSvc quicklook.QuickLookService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:       logger,
+		quickLookSvc: quickLookSvc,
+		authSvc:      authSvc,
+		avatars:      newAvatarCache(&http.Client{Timeout: 5 * time.Second}, time.Now),
+		images: newImageCache(
+			newContentImageClient(),
+			time.Now,
+			imageTTL,
+			maxImageEntries,
+			maxImageBytes,
+		),
+	}
+}
+
+// WithAuthorProfiles lets the avatar proxy use cached profile avatars instead of guessing
+// the avatar URL from the login.
+func (h *Handler) WithAuthorProfiles(profiles authorprofile.AuthorProfileService) *Handler {
+	h.authorProfiles = profiles
+	return h
+}
+
+// Register registers integration routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/integrations", func(r chi.Router) {
+		r.Get("/launcher", h.handleLauncher)
+		r.Get("/avatars/{login}", h.handleAvatar)
+		r.Get("/images", h.handleImage)
+		h.registerWebhooks(r)
+	})
+}
+
+// launcherResponse is the payload for launcher extensions
+type launcherResponse struct {
+	UnreadCount int64          `json:"unreadCount"`
+	Items       []launcherItem `json:"items"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+}
+
+// launcherItem is a notification rendered for display in a launcher list
+type launcherItem struct {
+	ID        string           `json:"id"`
+	Title     string           `json:"title"`
+	Subtitle  string           `json:"subtitle"`
+	IconURL   *string          `json:"iconUrl,omitempty"`
+	DeepLink  string           `json:"deepLink"`
+	GithubURL *string          `json:"githubUrl,omitempty"`
+	IsRead    bool             `json:"isRead"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	Actions   []launcherAction `json:"actions"`
+}
+
+// launcherAction is something a launcher can do with an item. Actions with a method are
+// API calls; actions without one are URLs to open.
+type launcherAction struct {
+	Title  string `json:"title"`
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url"`
+}
+
+// handleLauncher returns the inbox summary pre-rendered for launcher extensions
+func (h *Handler) handleLauncher(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	summary, err := h.quickLookSvc.Summary(ctx, userID)
+	if err != nil {
+		h.logger.Error(
+			"failed to load quick look summary",
+			zap.Error(errors.Join(ErrFailedToLoadSummary, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load inbox")
+		return
+	}
+
+	baseURL := requestBaseURL(r)
+	items := make([]launcherItem, 0, len(summary.Items))
+	for _, item := range summary.Items {
+		items = append(items, renderLauncherItem(baseURL, item))
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, launcherResponse{
+		UnreadCount: summary.UnreadCount,
+		Items:       items,
+		GeneratedAt: summary.GeneratedAt,
+	})
+}
+
+// renderLauncherItem converts a quick look item into display-ready strings and links
+func renderLauncherItem(baseURL string, item models.QuickLookItem) launcherItem {
+	escapedID := url.PathEscape(item.GithubID)
+	rendered := launcherItem{
+		ID:        item.GithubID,
+		Title:     item.Title,
+		Subtitle:  launcherSubtitle(item),
+		DeepLink:  baseURL + "/views/inbox?id=" + url.QueryEscape(item.GithubID),
+		GithubURL: item.URL,
+		IsRead:    item.IsRead,
+		UpdatedAt: item.UpdatedAt,
+		Actions: []launcherAction{
+			{
+				Title:  "Archive",
+				Method: http.MethodPost,
+				URL:    baseURL + "/api/quick/" + escapedID + "/archive",
+			},
+		},
+	}
+
+	if login := iconLogin(item); login != "" {
+		iconURL := baseURL + "/api/integrations/avatars/" + url.PathEscape(login)
+		rendered.IconURL = &iconURL
+	}
+	if item.URL != nil {
+		rendered.Actions = append(rendered.Actions, launcherAction{
+			Title: "Open on GitHub",
+			URL:   *item.URL,
+		})
+	}
+	return rendered
+}
+
+// launcherSubtitle renders e.g. "octo/repo #42 · review requested"
+func launcherSubtitle(item models.QuickLookItem) string {
+	subtitle := item.Repository
+	if item.Number != nil {
+		subtitle = strings.TrimSpace(fmt.Sprintf("%s #%d", subtitle, *item.Number))
+	}
+	if item.Reason != nil && *item.Reason != "" {
+		reason := strings.ReplaceAll(*item.Reason, "_", " ")
+		if subtitle == "" {
+			return reason
+		}
+		subtitle += " · " + reason
+	}
+	return subtitle
+}
+
+// iconLogin picks whose avatar represents an item: the author, else the repository owner
+func iconLogin(item models.QuickLookItem) string {
+	if item.AuthorLogin != nil && *item.AuthorLogin != "" {
+		return *item.AuthorLogin
+	}
+	owner, _, found := strings.Cut(item.Repository, "/")
+	if !found {
+		return ""
+	}
+	return owner
+}
+
+// requestBaseURL returns the scheme and host the client used to reach the API, so links work
+// behind a reverse proxy as well as on localhost.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,255 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	authorprofilemocks "github.com/octobud-hq/octobud/backend/internal/core/authorprofile/mocks"
+	quicklookmocks "github.com/octobud-hq/octobud/backend/internal/core/quicklook/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func setupTestHandler(
+	ctrl *gomock.Controller,
+) (*Handler, *quicklookmocks.MockQuickLookService) {
+	mockSvc := quicklookmocks.NewMockQuickLookService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	return New(zap.NewNop(), mockSvc, mockAuthSvc), mockSvc
+}
+
+func serve(h *Handler, req *http.Request) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	h.Register(router)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleLauncher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, mockSvc := setupTestHandler(ctrl)
+
+	reason := "review_requested"
+	number := int64(42)
+	author := "octocat"
+	githubURL := "https://github.com/octo/repo/pull/42"
+	generatedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockSvc.EXPECT().
+		Summary(gomock.Any(), testUserID).
+		Return(models.QuickLookSummary{
+			UnreadCount: 2,
+			GeneratedAt: generatedAt,
+			Items: []models.QuickLookItem{
+				{
+					GithubID:    "n1",
+					Title:       "Fix the thing",
+					Repository:  "octo/repo",
+					AuthorLogin: &author,
+					Reason:      &reason,
+					Number:      &number,
+					URL:         &githubURL,
+				},
+				{GithubID: "n2", Title: "v1.0.0", Repository: "octo/repo"},
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/integrations/launcher", nil)
+	req.Host = "localhost:8808"
+	w := serve(h, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response launcherResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, int64(2), response.UnreadCount)
+	require.Equal(t, generatedAt, response.GeneratedAt)
+	require.Len(t, response.Items, 2)
+
+	first := response.Items[0]
+	require.Equal(t, "octo/repo #42 · review requested", first.Subtitle)
+	require.Equal(t, "http://localhost:8808/api/integrations/avatars/octocat", *first.IconURL)
+	require.Equal(t, "http://localhost:8808/views/inbox?id=n1", first.DeepLink)
+	require.Equal(t, []launcherAction{
+		{
+			Title:  "Archive",
+			Method: http.MethodPost,
+			URL:    "http://localhost:8808/api/quick/n1/archive",
+		},
+		{Title: "Open on GitHub", URL: githubURL},
+	}, first.Actions)
+
+	// Without an author, the repository owner's avatar is used
+	second := response.Items[1]
+	require.Equal(t, "octo/repo", second.Subtitle)
+	require.Equal(t, "http://localhost:8808/api/integrations/avatars/octo", *second.IconURL)
+	require.Len(t, second.Actions, 1)
+}
+
+func TestRequestBaseURL(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/integrations/launcher", nil)
+	req.Host = "localhost:8808"
+	require.Equal(t, "http://localhost:8808", requestBaseURL(req))
+
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "octobud.example.com")
+	require.Equal(t, "https://octobud.example.com", requestBaseURL(req))
+}
+
+func TestHandler_handleAvatar(t *testing.T) {
+	requests := 0
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/octocat.png" {
+			http.NotFound(w, r)
+			return
+		}
+		require.Equal(t, "64", r.URL.Query().Get("size"))
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png-bytes"))
+	}))
+	defer github.Close()
+
+	ctrl := gomock.NewController(t)
+	h, _ := setupTestHandler(ctrl)
+	h.avatars.fallbackURL = github.URL
+
+	for range 2 {
+		w := serve(h, httptest.NewRequest(http.MethodGet, "/integrations/avatars/octocat", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		require.Equal(t, "png-bytes", w.Body.String())
+	}
+	require.Equal(t, 1, requests, "second request should be served from cache")
+
+	w := serve(h, httptest.NewRequest(http.MethodGet, "/integrations/avatars/ghost", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(h, httptest.NewRequest(http.MethodGet, "/integrations/avatars/..%2Fetc", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_handleAvatar_UsesProfileAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, _ := setupTestHandler(ctrl)
+	profiles := authorprofilemocks.NewMockAuthorProfileService(ctrl)
+	h = h.WithAuthorProfiles(profiles)
+
+	avatarURL := "https://avatars.githubusercontent.com/u/49699333?v=4"
+	profiles.EXPECT().
+		Profiles(gomock.Any(), testUserID, []string{"dependabot[bot]"}).
+		Return(map[string]models.AuthorProfile{
+			"dependabot[bot]": {Login: "dependabot[bot]", AvatarURL: &avatarURL},
+		})
+
+	var fetched string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fetched = r.URL.String()
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "image/png")
+		_, _ = rec.WriteString("bot-png")
+		return rec.Result(), nil
+	})
+	h.avatars.client = &http.Client{Transport: transport}
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/integrations/avatars/dependabot%5Bbot%5D",
+		nil,
+	)
+	w := serve(h, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "https://avatars.githubusercontent.com/u/49699333?s=64&v=4", fetched)
+}
+
+func TestHandler_handleImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, _ := setupTestHandler(ctrl)
+
+	source := "https://raw.githubusercontent.com/octo/repo/main/logo.png"
+	requests := 0
+	h.images.client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		require.Equal(t, source, r.URL.String())
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "image/png")
+		_, _ = rec.WriteString("logo-png")
+		return rec.Result(), nil
+	})}
+
+	path := "/integrations/images?url=" + url.QueryEscape(source)
+	for range 2 {
+		w := serve(h, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "logo-png", w.Body.String())
+		require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		require.Contains(t, w.Header().Get("Content-Security-Policy"), "sandbox")
+	}
+	require.Equal(t, 1, requests, "second request should be served from cache")
+
+	// Only GitHub image hosts are fetched
+	for _, source := range []string{"https://example.com/a.png", "http://127.0.0.1/a.png", ""} {
+		w := serve(h, httptest.NewRequest(http.MethodGet, "/integrations/images?url="+url.QueryEscape(source), nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, source)
+	}
+}
+
+func TestContentImageClient_RejectsRedirectsOffGitHub(t *testing.T) {
+	client := newContentImageClient()
+	via := []*http.Request{httptest.NewRequest(http.MethodGet, "https://github.com/o/r/raw/main/a.png", nil)}
+
+	allowed := httptest.NewRequest(http.MethodGet, "https://raw.githubusercontent.com/o/r/main/a.png", nil)
+	require.NoError(t, client.CheckRedirect(allowed, via))
+
+	internal := httptest.NewRequest(http.MethodGet, "http://169.254.169.254/latest/meta-data", nil)
+	require.Error(t, client.CheckRedirect(internal, via))
+}
+
+func TestAvatarCache_sourceURL(t *testing.T) {
+	c := newAvatarCache(http.DefaultClient, time.Now)
+
+	// Profile URLs that don't point at GitHub are ignored
+	require.Equal(
+		t,
+		"https://github.com/octocat.png?size=64",
+		c.sourceURL("octocat", "https://evil.example.com/a.png"),
+	)
+	require.Equal(t, "https://github.com/octocat.png?size=64", c.sourceURL("octocat", ""))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/sanitize"
+)
+
+const (
+	// imageTTL is how long a proxied image from GitHub content is served from memory
+	imageTTL = time.Hour
+	// maxImageEntries caps how many content images are kept in memory
+	maxImageEntries = 200
+	// maxImageBytes caps the size of a single content image
+	maxImageBytes = 5 << 20
+	// maxImageRedirects caps how many redirects are followed when fetching an image
+	maxImageRedirects = 5
+	// imageCSP stops an image opened on its own, such as an SVG, from running scripts
+	imageCSP = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+)
+
+// errImageNotFound is returned when GitHub has no image at a URL
+var errImageNotFound = errors.New("image not found")
+
+// imageEntry is a cached image
+type imageEntry struct {
+	body        []byte
+	contentType string
+	expires     time.Time
+}
+
+// imageCache fetches images and keeps them in memory for a while
+type imageCache struct {
+	client     *http.Client
+	now        func() time.Time
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int
+
+	mu      sync.Mutex
+	entries map[string]imageEntry
+}
+
+func newImageCache(
+	client *http.Client,
+	now func() time.Time,
+	ttl time.Duration,
+	maxEntries, maxBytes int,
+) *imageCache {
+	return &imageCache{
+		client:     client,
+		now:        now,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]imageEntry),
+	}
+}
+
+// newContentImageClient returns a client for images in GitHub content. Redirects are only
+// followed to hosts the proxy may fetch from.
+func newContentImageClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxImageRedirects {
+				return errors.New("too many redirects")
+			}
+			if !sanitize.ProxyableImage(req.URL) {
+				return fmt.Errorf("redirect to %s is not allowed", req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// handleImage serves an image from GitHub content. Bodies returned by the API point their
+// images here, so the browser never loads them from GitHub directly.
+func (h *Handler) handleImage(w http.ResponseWriter, r *http.Request) {
+	if _, ok := helpers.RequireUserID(r.Context(), w, h.authSvc); !ok {
+		return
+	}
+
+	source := r.URL.Query().Get("url")
+	u, err := url.Parse(source)
+	if err != nil || !sanitize.ProxyableImage(u) {
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid image URL")
+		return
+	}
+
+	entry, err := h.images.load(r.Context(), u.String(), u.String())
+	if err != nil {
+		if errors.Is(err, errImageNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "Image not found")
+			return
+		}
+		h.logger.Warn(
+			"failed to load image",
+			zap.String("url", u.Redacted()),
+			zap.Error(errors.Join(ErrFailedToLoadImage, err)),
+		)
+		helpers.WriteError(w, http.StatusBadGateway, "Failed to load image")
+		return
+	}
+
+	writeImage(w, entry, imageTTL)
+}
+
+// writeImage writes a cached image
+func writeImage(w http.ResponseWriter, entry imageEntry, ttl time.Duration) {
+	w.Header().Set("Content-Type", entry.contentType)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(ttl.Seconds())))
+	w.Header().Set("Content-Security-Policy", imageCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(entry.body)
+}
+
+// load returns the image cached under key, fetching it from sourceURL when it isn't cached
+func (c *imageCache) load(ctx context.Context, key, sourceURL string) (imageEntry, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry, nil
+	}
+
+	entry, err := c.fetch(ctx, sourceURL)
+	if err != nil {
+		return imageEntry{}, err
+	}
+	entry.expires = now.Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.maxEntries {
+		c.evictLocked(now)
+	}
+	c.entries[key] = entry
+	return entry, nil
+}
+
+// fetch downloads an image
+func (c *imageCache) fetch(ctx context.Context, sourceURL string) (imageEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return imageEntry{}, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return imageEntry{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return imageEntry{}, errImageNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return imageEntry{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		return imageEntry{}, fmt.Errorf("unexpected content type %q", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(c.maxBytes)+1))
+	if err != nil {
+		return imageEntry{}, err
+	}
+	if len(body) > c.maxBytes {
+		return imageEntry{}, fmt.Errorf("image larger than %d bytes", c.maxBytes)
+	}
+	return imageEntry{body: body, contentType: contentType}, nil
+}
+
+// evictLocked drops expired entries, or an arbitrary one if none have expired.
+// The caller must hold c.mu.
+func (c *imageCache) evictLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) < c.maxEntries {
+		return
+	}
+	for key := range c.entries {
+		delete(c.entries, key)
+		return
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// webhookRequest is the body for creating or updating a webhook. Leaving secret out keeps
+// the current one; an empty secret removes it.
+type webhookRequest struct {
+	Name   string  `json:"name"`
+	Format string  `json:"format"`
+	URL    string  `json:"url"`
+	Secret *string `json:"secret"`
+}
+
+// webhooksResponse is the list of the user's webhooks
+type webhooksResponse struct {
+	Webhooks []models.Integration `json:"webhooks"`
+}
+
+// deliveriesResponse is a webhook's delivery log
+type deliveriesResponse struct {
+	Deliveries []models.IntegrationDelivery `json:"deliveries"`
+}
+
+// WithWebhooks enables the /integrations/webhooks endpoints, which manage the webhooks
+// rules can notify
+func (h *Handler) WithWebhooks(webhooks integration.IntegrationService) *Handler {
+	h.webhooks = webhooks
+	return h
+}
+
+func (h *Handler) registerWebhooks(r chi.Router) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", h.handleListWebhooks)
+		r.Post("/", h.handleCreateWebhook)
+		r.Get("/{id}", h.handleGetWebhook)
+		r.Put("/{id}", h.handleUpdateWebhook)
+		r.Delete("/{id}", h.handleDeleteWebhook)
+		r.Get("/{id}/deliveries", h.handleListDeliveries)
+	})
+}
+
+// handleListWebhooks handles GET /api/integrations/webhooks
+func (h *Handler) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhooks.ListIntegrations(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to list webhooks", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load webhooks")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, webhooksResponse{Webhooks: webhooks})
+}
+
+// handleGetWebhook handles GET /api/integrations/webhooks/{id}
+func (h *Handler) handleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.GetIntegration(ctx, userID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeWebhookError(w, err, "Failed to load webhook")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, webhook)
+}
+
+// handleCreateWebhook handles POST /api/integrations/webhooks
+func (h *Handler) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode webhook request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.CreateIntegration(ctx, userID, req.params())
+	if err != nil {
+		h.writeWebhookError(w, err, "Failed to create webhook")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, webhook)
+}
+
+// handleUpdateWebhook handles PUT /api/integrations/webhooks/{id}
+func (h *Handler) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode webhook request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooks.UpdateIntegration(ctx, userID, chi.URLParam(r, "id"), req.params())
+	if err != nil {
+		h.writeWebhookError(w, err, "Failed to update webhook")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, webhook)
+}
+
+// handleDeleteWebhook handles DELETE /api/integrations/webhooks/{id}
+func (h *Handler) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.DeleteIntegration(ctx, userID, chi.URLParam(r, "id")); err != nil {
+		h.writeWebhookError(w, err, "Failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListDeliveries handles GET /api/integrations/webhooks/{id}/deliveries
+func (h *Handler) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.requireWebhooks(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			helpers.WriteError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhooks.ListDeliveries(ctx, userID, chi.URLParam(r, "id"), limit)
+	if err != nil {
+		h.writeWebhookError(w, err, "Failed to load deliveries")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, deliveriesResponse{Deliveries: deliveries})
+}
+
+// requireWebhooks checks webhooks are configured and returns the current user
+func (h *Handler) requireWebhooks(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.webhooks == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Webhooks not configured")
+		return "", false
+	}
+	return helpers.RequireUserID(r.Context(), w, h.authSvc)
+}
+
+// writeWebhookError maps integration errors to responses, logging unexpected ones
+func (h *Handler) writeWebhookError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, integration.ErrIntegrationNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "Webhook not found")
+	case errors.Is(err, integration.ErrNameTaken):
+		helpers.WriteError(w, http.StatusConflict, "A webhook with that name already exists")
+	case errors.Is(err, integration.ErrInvalidIntegration),
+		errors.Is(err, integration.ErrInvalidLimit),
+		errors.Is(err, integration.ErrEncryptionNotConfigured):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(msg, zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, msg)
+	}
+}
+
+func (req webhookRequest) params() models.IntegrationParams {
+	return models.IntegrationParams{
+		Name:   req.Name,
+		Format: req.Format,
+		URL:    req.URL,
+		Secret: req.Secret,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	integrationmocks "github.com/octobud-hq/octobud/backend/internal/core/integration/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "created",
+			body:       `{"name":"CI","url":"https://example.com/hook","secret":"s3cret"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid body",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid webhook",
+			body:       `{"name":"CI","url":"ftp://example.com"}`,
+			serviceErr: fmt.Errorf("%w: url must be http or https", integration.ErrInvalidIntegration),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "name taken",
+			body:       `{"name":"CI","url":"https://example.com/hook"}`,
+			serviceErr: integration.ErrNameTaken,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h, _ := setupTestHandler(ctrl)
+			svc := integrationmocks.NewMockIntegrationService(ctrl)
+			h = h.WithWebhooks(svc)
+
+			if tt.body != `{` {
+				svc.EXPECT().
+					CreateIntegration(gomock.Any(), testUserID, gomock.Any()).
+					DoAndReturn(func(_ any, _ string, params models.IntegrationParams) (models.Integration, error) {
+						if tt.serviceErr != nil {
+							return models.Integration{}, tt.serviceErr
+						}
+						require.Equal(t, "CI", params.Name)
+						require.NotNil(t, params.Secret)
+						return models.Integration{ID: "w1", Name: params.Name, URL: params.URL, HasSecret: true}, nil
+					})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/integrations/webhooks", strings.NewReader(tt.body))
+			w := serve(h, req)
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantStatus == http.StatusCreated {
+				var created map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+				require.Equal(t, "w1", created["id"])
+				require.Equal(t, true, created["hasSecret"])
+				require.NotContains(t, created, "secret")
+			}
+		})
+	}
+}
+
+func TestHandler_handleListDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, _ := setupTestHandler(ctrl)
+	svc := integrationmocks.NewMockIntegrationService(ctrl)
+	h = h.WithWebhooks(svc)
+
+	svc.EXPECT().
+		ListDeliveries(gomock.Any(), testUserID, "w1", 10).
+		Return([]models.IntegrationDelivery{{ID: 1, Status: models.DeliveryDelivered}}, nil)
+	svc.EXPECT().
+		ListDeliveries(gomock.Any(), testUserID, "missing", 0).
+		Return(nil, integration.ErrIntegrationNotFound)
+
+	w := serve(h, httptest.NewRequest(http.MethodGet, "/integrations/webhooks/w1/deliveries?limit=10", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp deliveriesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Deliveries, 1)
+
+	w = serve(h, httptest.NewRequest(http.MethodGet, "/integrations/webhooks/missing/deliveries", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(h, httptest.NewRequest(http.MethodGet, "/integrations/webhooks/w1/deliveries?limit=x", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_webhooksNotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, _ := setupTestHandler(ctrl)
+
+	w := serve(h, httptest.NewRequest(http.MethodGet, "/integrations/webhooks", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package livequeries provides the API for subscribing to live query results.
+package livequeries
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+)
+
+// pingInterval is how often an idle stream is sent a keepalive
+const pingInterval = 30 * time.Second
+
+// Handler handles live query HTTP routes
+type Handler struct {
+	logger      *zap.Logger
+	liveQueries livequery.LiveQueryService
+	authSvc     authsvc.AuthService
+}
+
+// New creates a new live query handler
+func New(
+	logger *zap.Logger,
+	liveQueries livequery.LiveQueryService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:      logger,
+		liveQueries: liveQueries,
+		authSvc:     authSvc,
+	}
+}
+
+// subscribedEvent is the first event on a stream
+type subscribedEvent struct {
+	ID       string `json:"id"`
+	Query    string `json:"query"`
+	Matching int    `json:"matching"`
+}
+
+// Register registers live query routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/live-queries", h.handleSubscribe)
+}
+
+// Middleware checks live queries again after every successful request that may have
+// changed notifications, so bulk actions and triage from any client reach subscribers.
+func (h *Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		if ww.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		userID, err := helpers.GetUserID(r.Context(), h.authSvc)
+		if err != nil {
+			return
+		}
+		h.liveQueries.Refresh(context.WithoutCancel(r.Context()), userID)
+	})
+}
+
+// handleSubscribe streams changes to a query's results as Server-Sent Events. The query
+// is registered for as long as the stream is open.
+func (h *Handler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	sub, err := h.liveQueries.Subscribe(ctx, userID, r.URL.Query().Get("query"))
+	if err != nil {
+		if errors.Is(err, livequery.ErrInvalidQuery) {
+			// The joined error reads "invalid live query\n<reason>"; only the reason is useful
+			msg := strings.TrimPrefix(err.Error(), livequery.ErrInvalidQuery.Error()+"\n")
+			helpers.WriteError(w, http.StatusBadRequest, msg)
+			return
+		}
+		h.logger.Error("failed to subscribe to live query", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to subscribe to live query")
+		return
+	}
+	defer h.liveQueries.Unsubscribe(sub)
+
+	// The stream stays open far longer than the server's write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("failed to clear write deadline for live query stream", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+
+	err = h.sendEvent(w, "subscribed", subscribedEvent{
+		ID:       sub.ID,
+		Query:    sub.Query,
+		Matching: sub.Matching(),
+	})
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			if err := h.sendEvent(w, string(event.Type), event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			err := h.sendEvent(w, "ping", map[string]interface{}{
+				"timestamp": time.Now().Unix(),
+			})
+			if err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendEvent writes and flushes an SSE-formatted event. An error means the connection
+// is broken.
+func (h *Handler) sendEvent(w http.ResponseWriter, eventType string, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		h.logger.Error(
+			"failed to marshal live query event",
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// SSE format: "event: <type>\ndata: <json>\n\n"
+	if _, err := w.Write([]byte("event: " + eventType + "\ndata: " + string(jsonData) + "\n\n")); err != nil {
+		h.logger.Debug(
+			"failed to write live query event",
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
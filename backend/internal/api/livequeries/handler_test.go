@@ -0,0 +1,99 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package livequeries
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	livequerymocks "github.com/octobud-hq/octobud/backend/internal/core/livequery/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func setupHandler(t *testing.T) (*Handler, *livequerymocks.MockLiveQueryService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := livequerymocks.NewMockLiveQueryService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	return New(zap.NewNop(), mockSvc, mockAuthSvc), mockSvc
+}
+
+func TestHandler_handleSubscribe_InvalidQuery(t *testing.T) {
+	handler, mockSvc := setupHandler(t)
+	mockSvc.EXPECT().
+		Subscribe(gomock.Any(), testUserID, "repo:(").
+		Return(nil, errors.Join(livequery.ErrInvalidQuery, errors.New("parse failed: unexpected (")))
+
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/live-queries?query=repo:(", nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "parse failed: unexpected (")
+	require.NotContains(t, w.Body.String(), "invalid live query")
+}
+
+func TestHandler_Middleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		status      int
+		wantRefresh bool
+	}{
+		{name: "refreshes after a change", method: http.MethodPost, status: http.StatusOK, wantRefresh: true},
+		{name: "refreshes after a delete", method: http.MethodDelete, status: http.StatusNoContent, wantRefresh: true},
+		{name: "skips failed changes", method: http.MethodPost, status: http.StatusBadRequest},
+		{name: "skips reads", method: http.MethodGet, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockSvc := setupHandler(t)
+			if tt.wantRefresh {
+				mockSvc.EXPECT().Refresh(gomock.Any(), testUserID)
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+			req := httptest.NewRequest(tt.method, "/notifications/bulk/archive", nil)
+			w := httptest.NewRecorder()
+			handler.Middleware(next).ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -22,14 +22,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	notificationcore "github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
 )
 
 // Error definitions
@@ -42,6 +45,8 @@ var (
 	ErrFailedToMuteNotification       = errors.New("failed to mute notification")
 	ErrFailedToUnmuteNotification     = errors.New("failed to unmute notification")
 	ErrFailedToSnoozeNotification     = errors.New("failed to snooze notification")
+	ErrFailedToResolveDefaultSnooze   = errors.New("failed to resolve default snooze")
+	ErrFailedToResolveSnoozePreset    = errors.New("failed to resolve snooze preset")
 	ErrFailedToUnsnoozeNotification   = errors.New("failed to unsnooze notification")
 	ErrFailedToStarNotification       = errors.New("failed to star notification")
 	ErrFailedToUnstarNotification     = errors.New("failed to unstar notification")
@@ -75,6 +80,10 @@ const (
 
 type snoozeNotificationRequest struct {
 	SnoozedUntil string `json:"snoozedUntil"`
+	// Preset is a snooze preset or duration, resolved in the user's time zone
+	Preset string `json:"preset"`
+	// UntilUpdated also ends the snooze when sync sees the thread updated
+	UntilUpdated bool `json:"untilUpdated"`
 }
 
 type assignTagRequest struct {
@@ -214,10 +223,6 @@ func (h *Handler) handleUnarchiveNotification(w http.ResponseWriter, r *http.Req
 	h.handleNotificationAction(w, r, ActionUnarchive)
 }
 
-func (h *Handler) handleMuteNotification(w http.ResponseWriter, r *http.Request) {
-	h.handleNotificationAction(w, r, ActionMute)
-}
-
 func (h *Handler) handleUnmuteNotification(w http.ResponseWriter, r *http.Request) {
 	h.handleNotificationAction(w, r, ActionUnmute)
 }
@@ -239,7 +244,9 @@ func (h *Handler) handleUnfilterNotification(w http.ResponseWriter, r *http.Requ
 }
 
 // handleSnoozeNotification snoozes a notification
-// This is kept separate because it requires a request body with snoozedUntil
+// This is kept separate because it takes a request body with snoozedUntil or a preset.
+// Without either, the notification is snoozed until the default for its repository, or
+// with untilUpdated, until the thread is next updated.
 func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -261,7 +268,8 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 	}
 
 	var req snoozeNotificationRequest
-	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+	decodeErr := json.NewDecoder(r.Body).Decode(&req)
+	if decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
 		h.logger.Error(
 			"invalid request body",
 			zap.Error(errors.Join(ErrFailedToDecodeRequest, decodeErr)),
@@ -270,16 +278,37 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if req.SnoozedUntil == "" {
+	if req.SnoozedUntil != "" && req.Preset != "" {
+		helpers.WriteError(w, http.StatusBadRequest, "send either snoozedUntil or preset, not both")
+		return
+	}
+	if req.SnoozedUntil == "" && h.snoozes == nil {
 		helpers.WriteError(w, http.StatusBadRequest, "snoozedUntil is required")
 		return
 	}
+	if req.SnoozedUntil == "" && req.Preset == "" && req.UntilUpdated {
+		req.Preset = snooze.UntilUpdatedFallback
+	}
 
 	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
 	if !ok {
 		return
 	}
-	_, err = h.notifications.SnoozeNotification(ctx, userID, githubID, req.SnoozedUntil)
+	switch {
+	case req.Preset != "":
+		until, ok := h.presetSnoozedUntil(ctx, w, userID, req.Preset)
+		if !ok {
+			return
+		}
+		req.SnoozedUntil = until.UTC().Format(time.RFC3339)
+	case req.SnoozedUntil == "":
+		until, ok := h.defaultSnoozedUntil(ctx, w, userID, githubID)
+		if !ok {
+			return
+		}
+		req.SnoozedUntil = until.UTC().Format(time.RFC3339)
+	}
+	_, err = h.notifications.SnoozeNotification(ctx, userID, githubID, req.SnoozedUntil, req.UntilUpdated)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug("notification not found", zap.String("github_id", githubID))
@@ -310,6 +339,65 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 	helpers.WriteJSON(w, http.StatusOK, notificationActionResponse{Notification: notification})
 }
 
+// presetSnoozedUntil resolves when a snooze of a preset or duration ends, writing an error
+// response if it can't
+func (h *Handler) presetSnoozedUntil(
+	ctx context.Context,
+	w http.ResponseWriter,
+	userID, preset string,
+) (time.Time, bool) {
+	until, err := h.snoozes.Until(ctx, userID, preset)
+	if err != nil {
+		if errors.Is(err, snooze.ErrInvalidSnooze) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return time.Time{}, false
+		}
+		h.logger.Error(
+			"failed to resolve snooze preset",
+			zap.String("preset", preset),
+			zap.Error(errors.Join(ErrFailedToResolveSnoozePreset, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to snooze notification")
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// defaultSnoozedUntil resolves when a notification snoozed without a time should wake up,
+// writing an error response if it can't
+func (h *Handler) defaultSnoozedUntil(
+	ctx context.Context,
+	w http.ResponseWriter,
+	userID, githubID string,
+) (time.Time, bool) {
+	notification, err := h.notifications.GetByGithubID(ctx, userID, githubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			helpers.WriteError(w, http.StatusNotFound, "notification not found")
+			return time.Time{}, false
+		}
+		h.logger.Error(
+			"failed to get notification",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToGetNotification, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to get notification")
+		return time.Time{}, false
+	}
+
+	until, err := h.snoozes.DefaultUntil(ctx, userID, notification)
+	if err != nil {
+		h.logger.Error(
+			"failed to resolve default snooze",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToResolveDefaultSnooze, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to snooze notification")
+		return time.Time{}, false
+	}
+	return until, true
+}
+
 // handleAssignTagToNotification assigns a tag to a notification
 // This is kept separate because it requires a request body with tagID
 func (h *Handler) handleAssignTagToNotification(w http.ResponseWriter, r *http.Request) {
@@ -24,6 +24,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,8 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	notificationcore "github.com/octobud-hq/octobud/backend/internal/core/notification"
 	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	snoozemocks "github.com/octobud-hq/octobud/backend/internal/core/snooze/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
@@ -153,7 +156,7 @@ func TestHandler_handleSnoozeNotification(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2024-12-31T23:59:59Z").
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2024-12-31T23:59:59Z", false).
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
 					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
@@ -189,7 +192,7 @@ func TestHandler_handleSnoozeNotification(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					SnoozeNotification(gomock.Any(), "test-user-id", "not-found", "2024-12-31T23:59:59Z").
+					SnoozeNotification(gomock.Any(), "test-user-id", "not-found", "2024-12-31T23:59:59Z", false).
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -234,6 +237,190 @@ func TestHandler_handleSnoozeNotification(t *testing.T) {
 	}
 }
 
+func TestHandler_handleSnoozeNotification_DefaultSnooze(t *testing.T) {
+	notification := db.Notification{GithubID: "test-id", RepositoryID: 42}
+	until := time.Date(2025, time.March, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*notificationmocks.MockNotificationService, *snoozemocks.MockSnoozeService)
+		expectedStatus int
+	}{
+		{
+			name:        "empty body snoozes until the default",
+			requestBody: nil,
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				mockSnoozes *snoozemocks.MockSnoozeService,
+			) {
+				mockSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "test-id").
+					Return(notification, nil)
+				mockSnoozes.EXPECT().
+					DefaultUntil(gomock.Any(), "test-user-id", notification).
+					Return(until, nil)
+				mockSvc.EXPECT().
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2025-03-14T15:30:00Z", false).
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "explicit time skips the default",
+			requestBody: snoozeNotificationRequest{SnoozedUntil: "2024-12-31T23:59:59Z"},
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				_ *snoozemocks.MockSnoozeService,
+			) {
+				mockSvc.EXPECT().
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2024-12-31T23:59:59Z", false).
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "unknown notification returns 404",
+			requestBody: snoozeNotificationRequest{},
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				_ *snoozemocks.MockSnoozeService,
+			) {
+				mockSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "test-id").
+					Return(db.Notification{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "preset is resolved in the user's time zone",
+			requestBody: snoozeNotificationRequest{Preset: "next_week"},
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				mockSnoozes *snoozemocks.MockSnoozeService,
+			) {
+				mockSnoozes.EXPECT().
+					Until(gomock.Any(), "test-user-id", "next_week").
+					Return(until, nil)
+				mockSvc.EXPECT().
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2025-03-14T15:30:00Z", false).
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "until updated without a time falls back to a year",
+			requestBody: snoozeNotificationRequest{UntilUpdated: true},
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				mockSnoozes *snoozemocks.MockSnoozeService,
+			) {
+				mockSnoozes.EXPECT().
+					Until(gomock.Any(), "test-user-id", snooze.UntilUpdatedFallback).
+					Return(until, nil)
+				mockSvc.EXPECT().
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2025-03-14T15:30:00Z", true).
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "until updated keeps an explicit time",
+			requestBody: snoozeNotificationRequest{SnoozedUntil: "2024-12-31T23:59:59Z", UntilUpdated: true},
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				_ *snoozemocks.MockSnoozeService,
+			) {
+				mockSvc.EXPECT().
+					SnoozeNotification(gomock.Any(), "test-user-id", "test-id", "2024-12-31T23:59:59Z", true).
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "unknown preset returns 400",
+			requestBody: snoozeNotificationRequest{Preset: "someday"},
+			setupMocks: func(
+				_ *notificationmocks.MockNotificationService,
+				mockSnoozes *snoozemocks.MockSnoozeService,
+			) {
+				mockSnoozes.EXPECT().
+					Until(gomock.Any(), "test-user-id", "someday").
+					Return(time.Time{}, snooze.ErrInvalidSnooze)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "time and preset together return 400",
+			requestBody: snoozeNotificationRequest{SnoozedUntil: "2024-12-31T23:59:59Z", Preset: "tomorrow"},
+			setupMocks: func(
+				_ *notificationmocks.MockNotificationService,
+				_ *snoozemocks.MockSnoozeService,
+			) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "resolve error returns 500",
+			requestBody: nil,
+			setupMocks: func(
+				mockSvc *notificationmocks.MockNotificationService,
+				mockSnoozes *snoozemocks.MockSnoozeService,
+			) {
+				mockSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "test-id").
+					Return(notification, nil)
+				mockSnoozes.EXPECT().
+					DefaultUntil(gomock.Any(), "test-user-id", notification).
+					Return(time.Time{}, errors.New("database is locked"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockSnoozes := snoozemocks.NewMockSnoozeService(ctrl)
+			handler.WithSnoozeDefaults(mockSnoozes)
+			tt.setupMocks(mockSvc, mockSnoozes)
+
+			req := createRequest(http.MethodPost, "/notifications/test-id/snooze", tt.requestBody)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "test-id")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleSnoozeNotification(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestHandler_handleAssignTagToNotification(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -35,7 +35,6 @@ import (
 var (
 	ErrFailedToDecodeBulkRequest = errors.New("failed to decode bulk request")
 	ErrFailedToBuildQuery        = errors.New("failed to build query")
-	ErrFailedToListNotifications = errors.New("failed to list notifications")
 )
 
 // BulkOperation represents a type of bulk operation
@@ -60,6 +59,15 @@ type bulkMarkNotificationsRequest struct {
 	Query     string   `json:"query,omitempty"`
 }
 
+type markGroupReadRequest struct {
+	SubjectURL string `json:"subjectUrl"`
+}
+
+type markGroupReadResponse struct {
+	Count     int      `json:"count"`
+	GithubIDs []string `json:"githubIDs"`
+}
+
 type bulkTagNotificationsRequest struct {
 	GithubIDs []string `json:"githubIDs,omitempty"`
 	TagID     string   `json:"tagId"`
@@ -115,7 +123,7 @@ func (h *Handler) handleBulkOperation(w http.ResponseWriter, r *http.Request, op
 		return
 	}
 
-	var count int64
+	var count, undoID int64
 	var err error
 	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
 	if !ok {
@@ -123,9 +131,9 @@ func (h *Handler) handleBulkOperation(w http.ResponseWriter, r *http.Request, op
 	}
 
 	if hasQuery {
-		count, err = h.executeBulkOperationByQuery(ctx, userID, op, req.Query)
+		count, undoID, err = h.executeBulkOperationByQuery(ctx, userID, op, req.Query)
 	} else {
-		count, err = h.executeBulkOperationByIDs(ctx, userID, op, req.GithubIDs)
+		count, undoID, err = h.executeBulkOperationByIDs(ctx, userID, op, req.GithubIDs)
 	}
 
 	if err != nil {
@@ -146,7 +154,7 @@ func (h *Handler) handleBulkOperation(w http.ResponseWriter, r *http.Request, op
 		return
 	}
 
-	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: int(count)})
+	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: int(count), UndoID: undoID})
 }
 
 // executeBulkOperationByQuery executes a bulk operation using a query string
@@ -155,8 +163,8 @@ func (h *Handler) executeBulkOperationByQuery(
 	userID string,
 	op BulkOperation,
 	queryStr string,
-) (int64, error) {
-	return h.notifications.BulkUpdate(
+) (int64, int64, error) {
+	return h.notifications.BulkUpdateWithUndo(
 		ctx,
 		userID,
 		models.BulkOperationType(op),
@@ -171,8 +179,8 @@ func (h *Handler) executeBulkOperationByIDs(
 	userID string,
 	op BulkOperation,
 	githubIDs []string,
-) (int64, error) {
-	return h.notifications.BulkUpdate(
+) (int64, int64, error) {
+	return h.notifications.BulkUpdateWithUndo(
 		ctx,
 		userID,
 		models.BulkOperationType(op),
@@ -181,6 +189,43 @@ func (h *Handler) executeBulkOperationByIDs(
 	)
 }
 
+// handleMarkGroupRead marks every unread notification in a thread group as read at once.
+// The group key is the shared subject URL.
+func (h *Handler) handleMarkGroupRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req markGroupReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	githubIDs, err := h.notifications.MarkGroupRead(ctx, userID, req.SubjectURL)
+	if err != nil {
+		if errors.Is(err, notification.ErrNoSubjectURL) {
+			helpers.WriteError(w, http.StatusBadRequest, "subjectUrl is required")
+			return
+		}
+		h.logger.Error(
+			"failed to mark group read",
+			zap.String("subject_url", req.SubjectURL),
+			zap.Error(err),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to mark group read")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, markGroupReadResponse{
+		Count:     len(githubIDs),
+		GithubIDs: githubIDs,
+	})
+}
+
 // Individual handler methods that delegate to the unified handler
 func (h *Handler) handleBulkMarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
 	h.handleBulkOperation(w, r, BulkOpMarkRead)
@@ -290,41 +335,27 @@ func (h *Handler) handleBulkAssignTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var notifications []db.Notification
 	if hasQuery {
-		// Use service to list notifications from query string
-		notifications, err = h.notifications.ListNotificationsFromQueryString(
-			ctx,
-			userID,
-			req.Query,
-			999999,
-		)
-		if err != nil {
-			h.logger.Error(
-				"failed to list notifications",
-				zap.Error(errors.Join(ErrFailedToListNotifications, err)),
-			)
-			helpers.WriteError(w, http.StatusInternalServerError, "failed to list notifications")
-			return
-		}
-	} else {
-		// Get notifications by IDs
-		notifications = make([]db.Notification, 0, len(req.GithubIDs))
-		for _, githubID := range req.GithubIDs {
-			notif, getErr := h.notifications.GetByGithubID(ctx, userID, githubID)
-			if getErr != nil {
-				if errors.Is(getErr, sql.ErrNoRows) {
-					continue // Skip missing notifications
-				}
-				h.logger.Warn(
-					"failed to get notification",
-					zap.String("github_id", githubID),
-					zap.Error(errors.Join(ErrFailedToGetNotification, err)),
-				)
-				continue
+		h.tagByQuery(ctx, w, userID, req, h.notifications.BulkAssignTagByQuery, "failed to assign tag")
+		return
+	}
+
+	// Get notifications by IDs
+	notifications := make([]db.Notification, 0, len(req.GithubIDs))
+	for _, githubID := range req.GithubIDs {
+		notif, getErr := h.notifications.GetByGithubID(ctx, userID, githubID)
+		if getErr != nil {
+			if errors.Is(getErr, sql.ErrNoRows) {
+				continue // Skip missing notifications
 			}
-			notifications = append(notifications, notif)
+			h.logger.Warn(
+				"failed to get notification",
+				zap.String("github_id", githubID),
+				zap.Error(errors.Join(ErrFailedToGetNotification, getErr)),
+			)
+			continue
 		}
+		notifications = append(notifications, notif)
 	}
 
 	if len(notifications) == 0 {
@@ -394,42 +425,27 @@ func (h *Handler) handleBulkRemoveTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var notifications []db.Notification
-	var err error
 	if hasQuery {
-		// Use service to list notifications from query string
-		notifications, err = h.notifications.ListNotificationsFromQueryString(
-			ctx,
-			userID,
-			req.Query,
-			999999,
-		)
-		if err != nil {
-			h.logger.Error(
-				"failed to list notifications",
-				zap.Error(errors.Join(ErrFailedToListNotifications, err)),
-			)
-			helpers.WriteError(w, http.StatusInternalServerError, "failed to list notifications")
-			return
-		}
-	} else {
-		// Get notifications by IDs
-		notifications = make([]db.Notification, 0, len(req.GithubIDs))
-		for _, githubID := range req.GithubIDs {
-			notif, getErr := h.notifications.GetByGithubID(ctx, userID, githubID)
-			if getErr != nil {
-				if errors.Is(getErr, sql.ErrNoRows) {
-					continue // Skip missing notifications
-				}
-				h.logger.Warn(
-					"failed to get notification",
-					zap.String("github_id", githubID),
-					zap.Error(errors.Join(ErrFailedToGetNotification, err)),
-				)
-				continue
+		h.tagByQuery(ctx, w, userID, req, h.notifications.BulkRemoveTagByQuery, "failed to remove tag")
+		return
+	}
+
+	// Get notifications by IDs
+	notifications := make([]db.Notification, 0, len(req.GithubIDs))
+	for _, githubID := range req.GithubIDs {
+		notif, getErr := h.notifications.GetByGithubID(ctx, userID, githubID)
+		if getErr != nil {
+			if errors.Is(getErr, sql.ErrNoRows) {
+				continue // Skip missing notifications
 			}
-			notifications = append(notifications, notif)
+			h.logger.Warn(
+				"failed to get notification",
+				zap.String("github_id", githubID),
+				zap.Error(errors.Join(ErrFailedToGetNotification, getErr)),
+			)
+			continue
 		}
+		notifications = append(notifications, notif)
 	}
 
 	if len(notifications) == 0 {
@@ -451,3 +467,32 @@ func (h *Handler) handleBulkRemoveTag(w http.ResponseWriter, r *http.Request) {
 
 	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: count})
 }
+
+// tagByQuery assigns or removes a tag on every notification matching the request's query,
+// without loading them. An empty query matches the inbox.
+func (h *Handler) tagByQuery(
+	ctx context.Context,
+	w http.ResponseWriter,
+	userID string,
+	req bulkTagNotificationsRequest,
+	apply func(ctx context.Context, userID, queryStr, tagID string) (int64, error),
+	failureMsg string,
+) {
+	count, err := apply(ctx, userID, req.Query, req.TagID)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidQuery) {
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+			return
+		}
+		h.logger.Error(
+			failureMsg,
+			zap.String("tag_id", req.TagID),
+			zap.String("query", req.Query),
+			zap.Error(err),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, failureMsg)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: int(count)})
+}
@@ -92,7 +92,7 @@ func (h *Handler) handleBulkSnoozeNotifications(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	var count int64
+	var count, undoID int64
 	var err error
 	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
 	if !ok {
@@ -100,7 +100,7 @@ func (h *Handler) handleBulkSnoozeNotifications(w http.ResponseWriter, r *http.R
 	}
 
 	if hasQuery {
-		count, err = h.notifications.BulkUpdate(
+		count, undoID, err = h.notifications.BulkUpdateWithUndo(
 			ctx,
 			userID,
 			models.BulkOpSnooze,
@@ -108,7 +108,7 @@ func (h *Handler) handleBulkSnoozeNotifications(w http.ResponseWriter, r *http.R
 			models.BulkUpdateParams{SnoozedUntil: req.SnoozedUntil},
 		)
 	} else {
-		count, err = h.notifications.BulkUpdate(
+		count, undoID, err = h.notifications.BulkUpdateWithUndo(
 			ctx,
 			userID,
 			models.BulkOpSnooze,
@@ -127,7 +127,7 @@ func (h *Handler) handleBulkSnoozeNotifications(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: int(count)})
+	helpers.WriteJSON(w, http.StatusOK, bulkNotificationsResponse{Count: int(count), UndoID: undoID})
 }
 
 // handleBulkUnsnoozeNotifications is now handled by the unified bulk handler in bulk.go
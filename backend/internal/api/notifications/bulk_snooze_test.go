@@ -47,8 +47,8 @@ func TestHandler_handleBulkSnoozeNotifications(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
-					Return(int64(2), nil)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
+					Return(int64(2), int64(0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -66,8 +66,8 @@ func TestHandler_handleBulkSnoozeNotifications(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
-					Return(int64(5), nil)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
+					Return(int64(5), int64(0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -97,8 +97,8 @@ func TestHandler_handleBulkSnoozeNotifications(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
-					Return(int64(0), notification.ErrNoNotificationIDs)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), notification.ErrNoNotificationIDs)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
@@ -110,8 +110,8 @@ func TestHandler_handleBulkSnoozeNotifications(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
-					Return(int64(0), errors.New("database error"))
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpSnooze, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
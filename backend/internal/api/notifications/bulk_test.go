@@ -51,8 +51,8 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
-					Return(int64(2), nil)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
+					Return(int64(2), int64(7), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -60,6 +60,7 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				require.Equal(t, 2, response.Count)
+				require.Equal(t, int64(7), response.UndoID)
 			},
 		},
 		{
@@ -70,8 +71,8 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
-					Return(int64(5), nil)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
+					Return(int64(5), int64(0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -98,8 +99,8 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				// Empty query and empty IDs is treated as query operation with empty query
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
-					Return(int64(0), nil)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -127,8 +128,8 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
-					Return(int64(0), notification.ErrNoNotificationIDs)
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), notification.ErrNoNotificationIDs)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
@@ -140,8 +141,8 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					BulkUpdate(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
-					Return(int64(0), errors.New("database error"))
+					BulkUpdateWithUndo(gomock.Any(), "test-user-id", models.BulkOpMarkRead, gomock.Any(), gomock.Any()).
+					Return(int64(0), int64(0), errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -222,14 +223,8 @@ func TestHandler_handleBulkAssignTag(t *testing.T) {
 					GetTag(gomock.Any(), "test-user-id", "1").
 					Return(db.Tag{ID: "1", Name: "test"}, nil)
 				mockSvc.EXPECT().
-					ListNotificationsFromQueryString(gomock.Any(), "test-user-id", "is:unread", int32(999999)).
-					Return([]db.Notification{
-						{GithubID: "id1"},
-						{GithubID: "id2"},
-					}, nil)
-				mockSvc.EXPECT().
-					BulkAssignTag(gomock.Any(), "test-user-id", gomock.Any(), "1").
-					Return(2, nil)
+					BulkAssignTagByQuery(gomock.Any(), "test-user-id", "is:unread", "1").
+					Return(int64(2), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -348,14 +343,8 @@ func TestHandler_handleBulkRemoveTag(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					ListNotificationsFromQueryString(gomock.Any(), "test-user-id", "is:unread", int32(999999)).
-					Return([]db.Notification{
-						{GithubID: "id1"},
-						{GithubID: "id2"},
-					}, nil)
-				mockSvc.EXPECT().
-					BulkRemoveTag(gomock.Any(), "test-user-id", gomock.Any(), "1").
-					Return(2, nil)
+					BulkRemoveTagByQuery(gomock.Any(), "test-user-id", "is:unread", "1").
+					Return(int64(2), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -371,6 +360,19 @@ func TestHandler_handleBulkRemoveTag(t *testing.T) {
 			setupMock:      func(*notificationmocks.MockNotificationService) {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "invalid query returns 400",
+			requestBody: bulkTagNotificationsRequest{
+				Query: "is:",
+				TagID: "1",
+			},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					BulkRemoveTagByQuery(gomock.Any(), "test-user-id", "is:", "1").
+					Return(int64(0), errors.Join(notification.ErrInvalidQuery, errors.New("bad")))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "no notifications found returns 0 count",
 			requestBody: bulkTagNotificationsRequest{
@@ -419,3 +421,77 @@ func TestHandler_handleBulkRemoveTag(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_handleMarkGroupRead(t *testing.T) {
+	const subjectURL = "https://api.github.com/repos/octo/repo/issues/7"
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "success returns changed ids",
+			requestBody: markGroupReadRequest{SubjectURL: subjectURL},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					MarkGroupRead(gomock.Any(), "test-user-id", subjectURL).
+					Return([]string{"n1", "n2"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"count":2,"githubIDs":["n1","n2"]}`,
+		},
+		{
+			name:        "missing subject url",
+			requestBody: markGroupReadRequest{},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					MarkGroupRead(gomock.Any(), "test-user-id", "").
+					Return(nil, notification.ErrNoSubjectURL)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			requestBody: markGroupReadRequest{SubjectURL: subjectURL},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					MarkGroupRead(gomock.Any(), "test-user-id", subjectURL).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+
+			req := createRequest(
+				http.MethodPost,
+				"/notifications/bulk/mark-group-read",
+				tt.requestBody,
+			)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+			handler.handleMarkGroupRead(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
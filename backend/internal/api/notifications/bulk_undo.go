@@ -0,0 +1,73 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
+)
+
+type undoBulkOperationResponse struct {
+	Operation string   `json:"operation"`
+	Count     int      `json:"count"`
+	GithubIDs []string `json:"githubIDs"`
+}
+
+// handleUndoBulkOperation handles POST /api/notifications/bulk/undo/{id}, putting back what a
+// recent bulk operation changed
+func (h *Handler) handleUndoBulkOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid undo id")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	result, err := h.notifications.UndoBulkOperation(ctx, userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, undo.ErrOperationNotFound):
+			helpers.WriteError(w, http.StatusNotFound, "bulk operation not found")
+		case errors.Is(err, undo.ErrOperationExpired):
+			helpers.WriteError(w, http.StatusGone, "bulk operation can no longer be undone")
+		case errors.Is(err, undo.ErrAlreadyUndone):
+			helpers.WriteError(w, http.StatusConflict, "bulk operation was already undone")
+		default:
+			h.logger.Error("failed to undo bulk operation", zap.Int64("id", id), zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "failed to undo bulk operation")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, undoBulkOperationResponse{
+		Operation: string(result.Operation),
+		Count:     len(result.GithubIDs),
+		GithubIDs: result.GithubIDs,
+	})
+}
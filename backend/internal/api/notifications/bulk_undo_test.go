@@ -0,0 +1,135 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleUndoBulkOperation(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   *undoBulkOperationResponse
+	}{
+		{
+			name: "undoes the operation",
+			id:   "12",
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(models.BulkUndoResult{
+						Operation: models.BulkOpArchive,
+						GithubIDs: []string{"id1", "id2"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: &undoBulkOperationResponse{
+				Operation: "archive",
+				Count:     2,
+				GithubIDs: []string{"id1", "id2"},
+			},
+		},
+		{
+			name:           "invalid id returns 400",
+			id:             "latest",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown operation returns 404",
+			id:   "12",
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(models.BulkUndoResult{}, undo.ErrOperationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "expired operation returns 410",
+			id:   "12",
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(models.BulkUndoResult{}, undo.ErrOperationExpired)
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name: "undone operation returns 409",
+			id:   "12",
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(models.BulkUndoResult{}, undo.ErrAlreadyUndone)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "store error returns 500",
+			id:   "12",
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(models.BulkUndoResult{}, errors.Join(undo.ErrFailedToUndo, errors.New("database is locked")))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+
+			req := createRequest(http.MethodPost, "/notifications/bulk/undo/"+tt.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.handleUndoBulkOperation(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var response undoBulkOperationResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, *tt.expectedBody, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// commentRequest is the body of POST /notifications/{githubID}/comments
+type commentRequest struct {
+	Body string `json:"body"`
+}
+
+// commentResponse is the posted comment and, when it could be fetched, the first
+// timeline page including it.
+type commentResponse struct {
+	Comment  ReplyComment      `json:"comment"`
+	Timeline *TimelineResponse `json:"timeline,omitempty"`
+}
+
+// handleCreateComment posts a comment on the notification's issue or pull request and
+// returns the refreshed timeline, so the conversation can carry on without leaving.
+func (h *Handler) handleCreateComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID encoding")
+		return
+	}
+
+	var req commentRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		h.logger.Error(
+			"invalid request body",
+			zap.Error(errors.Join(ErrFailedToDecodeRequest, decodeErr)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "body is required")
+		return
+	}
+
+	if h.githubClient == nil {
+		h.logger.Warn("comments not available", zap.Error(ErrGitHubClientNotConfigured))
+		helpers.WriteError(w, http.StatusServiceUnavailable, "comments not available")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	notification, subjectInfo, ok := h.loadCommentTarget(w, r, userID, githubID)
+	if !ok {
+		return
+	}
+
+	comment, ok := h.postComment(w, r, userID, notification, subjectInfo, req.Body)
+	if !ok {
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, commentResponse{
+		Comment:  replyComment(comment),
+		Timeline: h.refreshTimeline(ctx, userID, notification, subjectInfo),
+	})
+}
+
+// refreshTimeline fetches the first timeline page after the user added to the thread.
+// The addition is already on GitHub, so a failed fetch only leaves the timeline out.
+func (h *Handler) refreshTimeline(
+	ctx context.Context,
+	userID string,
+	notification db.Notification,
+	subjectInfo *types.SubjectInfo,
+) *TimelineResponse {
+	// Pages warmed before the addition would hide it until the next sync bumps the
+	// notification's updated time
+	if h.prewarm != nil {
+		h.prewarm.ForgetTimelines(userID, notification.GithubID)
+	}
+	if h.timelineSvc == nil {
+		return nil
+	}
+
+	result, err := h.timelineSvc.FetchFilteredTimeline(
+		ctx,
+		h.githubClient,
+		subjectInfo,
+		notification.SubjectType,
+		prewarm.TimelinePerPage,
+		1,
+	)
+	if err != nil {
+		h.logger.Warn(
+			"failed to refresh timeline",
+			zap.String("github_id", notification.GithubID),
+			zap.Error(errors.Join(ErrFailedToFetchTimeline, err)),
+		)
+		return nil
+	}
+
+	items := make([]ThreadItem, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = convertTimelineItemToThreadItem(item)
+	}
+	return &TimelineResponse{
+		Items:   items,
+		Total:   result.Total,
+		Page:    result.Page,
+		PerPage: result.PerPage,
+		HasMore: result.HasMore,
+	}
+}
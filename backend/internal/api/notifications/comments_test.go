@@ -0,0 +1,234 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
+	repositorymocks "github.com/octobud-hq/octobud/backend/internal/core/repository/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/timeline"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleCreateComment(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	posted := types.TimelineEvent{
+		Event:     "commented",
+		ID:        json.RawMessage(`7`),
+		CreatedAt: &createdAt,
+		User:      &types.SimpleUser{Login: "me"},
+		Body:      "Looks good",
+	}
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		setupMocks func(
+			*notificationmocks.MockNotificationService,
+			*repositorymocks.MockRepositoryService,
+			*githubmocks.MockClient,
+		)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "posts comment and returns refreshed timeline",
+			body: commentRequest{Body: "Looks good"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *repositorymocks.MockRepositoryService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().TokenScopes().Return(nil, false)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "Looks good").
+					Return(&types.IssueComment{ID: 7, Body: "Looks good"}, nil)
+				client.EXPECT().
+					FetchTimeline(gomock.Any(), "cli", "cli", 42, gomock.Any(), gomock.Any()).
+					Return([]types.TimelineEvent{posted}, nil).
+					AnyTimes()
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response commentResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(7), response.Comment.ID)
+				require.NotNil(t, response.Timeline)
+				require.Len(t, response.Timeline.Items, 1)
+				require.Equal(t, "Looks good", response.Timeline.Items[0].Body)
+			},
+		},
+		{
+			name: "failed timeline refresh still returns the comment",
+			body: commentRequest{Body: "Looks good"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *repositorymocks.MockRepositoryService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				client.EXPECT().TokenScopes().Return(nil, false)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "Looks good").
+					Return(&types.IssueComment{ID: 7, Body: "Looks good"}, nil)
+				client.EXPECT().
+					FetchTimeline(gomock.Any(), "cli", "cli", 42, gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("github: timeline status 500: boom"))
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response commentResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(7), response.Comment.ID)
+				require.Nil(t, response.Timeline)
+			},
+		},
+		{
+			name: "token without repo scope returns 403 for private repository",
+			body: commentRequest{Body: "Looks good"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				repoSvc *repositorymocks.MockRepositoryService,
+				client *githubmocks.MockClient,
+			) {
+				notification := replyNotification("Issue")
+				notification.RepositoryID = 3
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(notification, nil)
+				client.EXPECT().TokenScopes().Return([]string{"notifications", "public_repo"}, true)
+				repoSvc.EXPECT().
+					GetRepositoryByID(gomock.Any(), "test-user-id", int64(3)).
+					Return(db.Repository{ID: 3, Private: sql.NullBool{Bool: true, Valid: true}}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Contains(t, w.Body.String(), "repo scope")
+			},
+		},
+		{
+			name: "public_repo scope is enough for public repository",
+			body: commentRequest{Body: "Looks good"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				repoSvc *repositorymocks.MockRepositoryService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				client.EXPECT().TokenScopes().Return([]string{"public_repo"}, true)
+				repoSvc.EXPECT().
+					GetRepositoryByID(gomock.Any(), "test-user-id", int64(0)).
+					Return(db.Repository{Private: sql.NullBool{Bool: false, Valid: true}}, nil)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "Looks good").
+					Return(&types.IssueComment{ID: 7, Body: "Looks good"}, nil)
+				client.EXPECT().
+					FetchTimeline(gomock.Any(), "cli", "cli", 42, gomock.Any(), gomock.Any()).
+					Return([]types.TimelineEvent{posted}, nil).
+					AnyTimes()
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "empty body returns 400",
+			body:           commentRequest{Body: "  "},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "github permission error returns 403",
+			body: commentRequest{Body: "hi"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *repositorymocks.MockRepositoryService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				client.EXPECT().TokenScopes().Return(nil, false)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "hi").
+					Return(nil, errors.New("github: comment status 403: locked"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockRepoSvc := repositorymocks.NewMockRepositoryService(ctrl)
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+			handler.repositorySvc = mockRepoSvc
+			handler.githubClient = mockClient
+			handler.timelineSvc = timeline.NewService(zap.NewNop())
+			handler.prewarm = mockPrewarm
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockRepoSvc, mockClient)
+			}
+			if tt.expectedStatus == http.StatusCreated {
+				mockPrewarm.EXPECT().ForgetTimelines(testUserID, "thread-1")
+			}
+
+			req := createRequest(http.MethodPost, "/notifications/thread-1/comments", tt.body)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleCreateComment(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+)
+
+// ErrFailedToCompareQueries is returned when a query comparison cannot be computed
+var ErrFailedToCompareQueries = errors.New("failed to compare queries")
+
+// compareQueriesRequest holds the two queries to compare. An empty query means the inbox,
+// matching the list endpoint.
+type compareQueriesRequest struct {
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+// handleCompareQueries reports which notifications match only one of two queries, or both.
+// Useful for debugging why a filtered view shows fewer results than its base query.
+func (h *Handler) handleCompareQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req compareQueriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.notifications.CompareQueries(ctx, userID, req.Left, req.Right)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidQuery) {
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+			return
+		}
+		h.logger.Error(
+			"failed to compare queries",
+			zap.String("left", req.Left),
+			zap.String("right", req.Right),
+			zap.Error(errors.Join(ErrFailedToCompareQueries, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to compare queries")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, result)
+}
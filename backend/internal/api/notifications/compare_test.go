@@ -0,0 +1,128 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleCompareQueries(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success returns comparison",
+			body: compareQueriesRequest{Left: "repo:cli/cli", Right: "repo:cli/cli state:open"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					CompareQueries(
+						gomock.Any(),
+						"test-user-id",
+						"repo:cli/cli",
+						"repo:cli/cli state:open",
+					).
+					Return(models.QueryComparison{
+						Left: models.QueryComparisonSide{Query: "repo:cli/cli", Count: 2},
+						Right: models.QueryComparisonSide{
+							Query: "repo:cli/cli state:open",
+							Count: 1,
+						},
+						OnlyLeft: models.QueryIDSet{Count: 1, IDs: []string{"a"}},
+						Both:     models.QueryIDSet{Count: 1, IDs: []string{"b"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.QueryComparison
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, 2, response.Left.Count)
+				require.Equal(t, []string{"a"}, response.OnlyLeft.IDs)
+				require.Equal(t, []string{"b"}, response.Both.IDs)
+			},
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid query returns 400",
+			body: compareQueriesRequest{Left: "repo:(", Right: ""},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					CompareQueries(gomock.Any(), "test-user-id", "repo:(", "").
+					Return(models.QueryComparison{}, errors.Join(
+						notification.ErrInvalidQuery,
+						errors.New("unexpected token"),
+					))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error returns 500",
+			body: compareQueriesRequest{Left: "", Right: "is:unread"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					CompareQueries(gomock.Any(), "test-user-id", "", "is:unread").
+					Return(models.QueryComparison{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+
+			req := createRequest(http.MethodPost, "/query/compare", tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleCompareQueries(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
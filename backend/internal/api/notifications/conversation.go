@@ -0,0 +1,67 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+)
+
+// ErrFailedToListConversation is returned when a conversation cannot be expanded
+var ErrFailedToListConversation = errors.New("failed to list conversation")
+
+// ConversationResponse lists every notification for a subject, newest first
+type ConversationResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+// handleGetConversation handles GET /api/notifications/{githubID}/conversation, expanding
+// a conversation collapsed by conversations=true into its individual notifications
+func (h *Handler) handleGetConversation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil || githubID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID")
+		return
+	}
+
+	notifications, err := h.notifications.ListConversation(ctx, userID, githubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			helpers.WriteError(w, http.StatusNotFound, "notification not found")
+			return
+		}
+		h.logger.Error("failed to list conversation",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToListConversation, err)))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to list conversation")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, ConversationResponse{Notifications: notifications})
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleGetConversation(t *testing.T) {
+	mention := "mention"
+	comment := "comment"
+
+	tests := []struct {
+		name           string
+		result         []models.Notification
+		err            error
+		expectedStatus int
+	}{
+		{
+			name: "lists the conversation",
+			result: []models.Notification{
+				{GithubID: "thread-2", Reason: &mention},
+				{GithubID: "thread-1", Reason: &comment},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown notification returns 404",
+			err:            sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "store error returns 500",
+			err:            errors.New("database is locked"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockNotificationSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			mockNotificationSvc.EXPECT().
+				ListConversation(gomock.Any(), "test-user-id", "thread-1").
+				Return(tt.result, tt.err)
+
+			req := createRequest(http.MethodGet, "/notifications/thread-1/conversation", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.handleGetConversation(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.result != nil {
+				var response ConversationResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tt.result, response.Notifications)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+)
+
+// explainQueryRequest is a query to explain. An empty query means the inbox, matching the
+// list endpoint.
+type explainQueryRequest struct {
+	Query string `json:"query"`
+	Sort  string `json:"sort"`
+}
+
+// handleExplainQuery shows the SQL a query compiles to, the defaults added to it, and
+// SQLite's plan for it, for debugging why a view shows unexpected results
+func (h *Handler) handleExplainQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req explainQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	explanation, err := h.notifications.ExplainQuery(
+		ctx,
+		userID,
+		req.Query,
+		strings.TrimSpace(req.Sort),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, notification.ErrInvalidQuery):
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+		case errors.Is(err, notification.ErrInvalidSort):
+			helpers.WriteError(w, http.StatusBadRequest, "invalid sort (expected priority)")
+		default:
+			h.logger.Error("failed to explain query", zap.String("query", req.Query), zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to explain query")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, explanation)
+}
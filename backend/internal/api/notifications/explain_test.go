@@ -0,0 +1,113 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleExplainQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+	}{
+		{
+			name: "success returns explanation",
+			body: explainQueryRequest{Query: "state:open", Sort: " priority "},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ExplainQuery(gomock.Any(), "test-user-id", "state:open", "priority").
+					Return(models.QueryExplanation{
+						Query: "state:open",
+						Where: []string{"n.subject_state = ?"},
+						Plan:  []models.QueryPlanStep{{ID: 2, Detail: "SCAN n"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid query returns 400",
+			body: explainQueryRequest{Query: "repo:("},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ExplainQuery(gomock.Any(), "test-user-id", "repo:(", "").
+					Return(models.QueryExplanation{}, errors.Join(
+						notification.ErrInvalidQuery,
+						errors.New("unexpected token"),
+					))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error returns 500",
+			body: explainQueryRequest{Query: "is:unread"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ExplainQuery(gomock.Any(), "test-user-id", "is:unread", "").
+					Return(models.QueryExplanation{}, notification.ErrFailedToExplainQuery)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+
+			req := createRequest(http.MethodPost, "/query/explain", tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleExplainQuery(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				var response models.QueryExplanation
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "SCAN n", response.Plan[0].Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// defaultGroupSize is how many notifications each group returns when perGroup is omitted
+const defaultGroupSize = 5
+
+// ErrFailedToGroupNotifications is returned when notifications cannot be grouped
+var ErrFailedToGroupNotifications = errors.New("failed to group notifications")
+
+// handleGroupNotifications groups the notifications matching a query by repo, org, reason,
+// author or type, returning each group's count and newest notifications for a grouped inbox.
+func (h *Handler) handleGroupNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	opts := models.GroupOptions{
+		Query:    params.Get("query"),
+		GroupBy:  params.Get("groupBy"),
+		PerGroup: defaultGroupSize,
+	}
+	if raw := strings.TrimSpace(params.Get("perGroup")); raw != "" {
+		perGroup, err := strconv.Atoi(raw)
+		if err != nil || perGroup < 0 {
+			helpers.WriteError(w, http.StatusBadRequest, "perGroup must be a non-negative number")
+			return
+		}
+		opts.PerGroup = perGroup
+	}
+
+	result, err := h.notifications.GroupNotifications(ctx, userID, opts)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidGroupKey) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, notification.ErrInvalidQuery) {
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+			return
+		}
+		h.logger.Error(
+			"failed to group notifications",
+			zap.String("query", opts.Query),
+			zap.String("groupBy", opts.GroupBy),
+			zap.Error(errors.Join(ErrFailedToGroupNotifications, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to group notifications")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, result)
+}
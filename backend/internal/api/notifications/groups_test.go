@@ -0,0 +1,149 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleGroupNotifications(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success returns groups",
+			url:  "/groups?query=is:unread&groupBy=repo",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GroupNotifications(gomock.Any(), "test-user-id", models.GroupOptions{
+						Query:    "is:unread",
+						GroupBy:  "repo",
+						PerGroup: defaultGroupSize,
+					}).
+					Return(models.GroupResult{
+						GroupBy: "repo",
+						Total:   3,
+						Groups: []models.NotificationGroup{
+							{
+								Key:           "cli/cli",
+								Count:         3,
+								Notifications: []models.Notification{{GithubID: "a"}},
+							},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.GroupResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(3), response.Total)
+				require.Len(t, response.Groups, 1)
+				require.Equal(t, "cli/cli", response.Groups[0].Key)
+				require.Equal(t, "a", response.Groups[0].Notifications[0].GithubID)
+			},
+		},
+		{
+			name: "perGroup zero asks for counts only",
+			url:  "/groups?groupBy=reason&perGroup=0",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GroupNotifications(gomock.Any(), "test-user-id", models.GroupOptions{GroupBy: "reason"}).
+					Return(models.GroupResult{GroupBy: "reason"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "negative perGroup returns 400",
+			url:            "/groups?groupBy=repo&perGroup=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown group key returns 400",
+			url:  "/groups?groupBy=label",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GroupNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.GroupResult{}, notification.ErrInvalidGroupKey)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid query returns 400",
+			url:  "/groups?groupBy=repo&query=repo:(",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GroupNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.GroupResult{}, errors.Join(
+						notification.ErrInvalidQuery,
+						errors.New("unexpected token"),
+					))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error returns 500",
+			url:  "/groups?groupBy=author",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GroupNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.GroupResult{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+
+			req := createRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleGroupNotifications(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -16,12 +16,18 @@
 package notifications
 
 import (
+	"time"
+
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
 	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
 	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
 	"github.com/octobud-hq/octobud/backend/internal/core/tag"
 	timelinesvc "github.com/octobud-hq/octobud/backend/internal/core/timeline"
 	"github.com/octobud-hq/octobud/backend/internal/db"
@@ -42,6 +48,11 @@ type Handler struct {
 	syncService   sync.SyncOperations
 	scheduler     jobs.Scheduler
 	authSvc       authsvc.AuthService
+	savedReplies  savedreply.SavedReplyService
+	snoozes       snooze.SnoozeService
+	prewarm       prewarm.PrewarmService
+	history       history.HistoryService
+	now           func() time.Time
 }
 
 // New creates a new notifications handler
@@ -68,21 +79,60 @@ func New(
 		syncService:   syncService,
 		scheduler:     scheduler,
 		authSvc:       authSvc,
+		now:           time.Now,
 	}
 }
 
+// WithSavedReplies lets replies be posted with a saved reply instead of a body
+func (h *Handler) WithSavedReplies(savedReplies savedreply.SavedReplyService) *Handler {
+	h.savedReplies = savedReplies
+	return h
+}
+
+// WithSnoozeDefaults lets notifications be snoozed without a time, using the default
+// snooze of their repository or the user's default preset
+func (h *Handler) WithSnoozeDefaults(snoozes snooze.SnoozeService) *Handler {
+	h.snoozes = snoozes
+	return h
+}
+
+// WithPrewarm records which lists and notifications are opened and which queries are run,
+// and serves timeline pages warmed for them at startup
+func (h *Handler) WithPrewarm(prewarmSvc prewarm.PrewarmService) *Handler {
+	h.prewarm = prewarmSvc
+	return h
+}
+
+// WithHistory serves each notification's history of changes
+func (h *Handler) WithHistory(historySvc history.HistoryService) *Handler {
+	h.history = historySvc
+	return h
+}
+
 // Register registers notification routes on the provided router
 func (h *Handler) Register(r chi.Router) {
 	r.Route("/notifications", func(r chi.Router) {
 		r.Get("/", h.handleListNotifications)
 		r.Get("/poll", h.handlePollNotifications) // Poll endpoint for service worker polling
+		r.Get("/plain", h.handlePlainNotifications)
+		r.Get("/groups", h.handleGroupNotifications)
+		r.Get("/next", h.handleNextNotification)
 		r.Get("/{githubID}", h.handleGetNotification)
 		r.Get("/{githubID}/timeline", h.handleGetNotificationTimeline)
+		r.Get("/{githubID}/history", h.handleGetNotificationHistory)
+		r.Get("/{githubID}/conversation", h.handleGetConversation)
 		r.Post("/{githubID}/refresh-subject", h.handleRefreshNotificationSubject)
+		r.Post("/{githubID}/reply", h.handleReply)
+		r.Post("/{githubID}/comments", h.handleCreateComment)
+		r.Post("/{githubID}/reviews", h.handleSubmitReview)
+		r.Post("/{githubID}/merge", h.handleMergePullRequest)
+		r.Post("/{githubID}/close", h.handleCloseSubject)
+		r.Post("/{githubID}/reopen", h.handleReopenSubject)
 
 		// Bulk operations - MUST come before individual routes to avoid "bulk" being treated as a githubID
 		r.Post("/bulk/mark-read", h.handleBulkMarkNotificationsRead)
 		r.Post("/bulk/mark-unread", h.handleBulkMarkNotificationsUnread)
+		r.Post("/bulk/mark-group-read", h.handleMarkGroupRead)
 		r.Post("/bulk/archive", h.handleBulkArchiveNotifications)
 		r.Post("/bulk/unarchive", h.handleBulkUnarchiveNotifications)
 		r.Post("/bulk/mute", h.handleBulkMuteNotifications)
@@ -94,6 +144,7 @@ func (h *Handler) Register(r chi.Router) {
 		r.Post("/bulk/unfilter", h.handleBulkUnfilterNotifications)
 		r.Post("/bulk/assign-tag", h.handleBulkAssignTag)
 		r.Post("/bulk/remove-tag", h.handleBulkRemoveTag)
+		r.Post("/bulk/undo/{id}", h.handleUndoBulkOperation)
 
 		// Action-based endpoints
 		r.Post("/{githubID}/mark-read", h.handleMarkNotificationRead)
@@ -108,9 +159,21 @@ func (h *Handler) Register(r chi.Router) {
 		r.Post("/{githubID}/unstar", h.handleUnstarNotification)
 		r.Post("/{githubID}/unfilter", h.handleUnfilterNotification)
 
+		// Thread subscription on GitHub
+		r.Put("/{githubID}/subscription", h.handleSetSubscription)
+		r.Delete("/{githubID}/subscription", h.handleDeleteSubscription)
+
 		// Tag operations
 		r.Post("/{githubID}/tags", h.handleAssignTagToNotification)
 		r.Post("/{githubID}/tags-by-name", h.handleAssignTagByName)
 		r.Delete("/{githubID}/tags/{tagId}", h.handleRemoveTagFromNotification)
 	})
+
+	r.Post("/query/compare", h.handleCompareQueries)
+	r.Post("/query/run", h.handleRunQuery)
+	r.Post("/query/explain", h.handleExplainQuery)
+	if h.prewarm != nil {
+		r.Get("/query/recent", h.handleListRecentQueries)
+		r.Delete("/query/recent", h.handleClearRecentQueries)
+	}
 }
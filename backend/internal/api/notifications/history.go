@@ -0,0 +1,62 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notifications
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// HistoryResponse lists a notification's history, most recent first
+type HistoryResponse struct {
+	Events []models.NotificationEvent `json:"events"`
+}
+
+// handleGetNotificationHistory handles GET /api/notifications/{githubID}/history
+func (h *Handler) handleGetNotificationHistory(w http.ResponseWriter, r *http.Request) {
+	if h.history == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Notification history not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	githubID := chi.URLParam(r, "githubID")
+
+	events, err := h.history.List(ctx, userID, githubID)
+	if err != nil {
+		if errors.Is(err, history.ErrNotificationNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "notification not found")
+			return
+		}
+		h.logger.Error("failed to list notification history",
+			zap.String("github_id", githubID),
+			zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to list notification history")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, HistoryResponse{Events: events})
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	historymocks "github.com/octobud-hq/octobud/backend/internal/core/history/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleGetNotificationHistory(t *testing.T) {
+	archivedAt := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		setupMock      func(*historymocks.MockHistoryService)
+		expectedStatus int
+		expectedEvents []models.NotificationEvent
+	}{
+		{
+			name: "lists the history",
+			setupMock: func(m *historymocks.MockHistoryService) {
+				m.EXPECT().List(gomock.Any(), "test-user-id", "thread-1").Return([]models.NotificationEvent{
+					{
+						Action:    models.HistoryArchived,
+						Source:    models.HistorySourceRule,
+						Detail:    "Archive bots",
+						CreatedAt: archivedAt,
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedEvents: []models.NotificationEvent{
+				{
+					Action:    models.HistoryArchived,
+					Source:    models.HistorySourceRule,
+					Detail:    "Archive bots",
+					CreatedAt: archivedAt,
+				},
+			},
+		},
+		{
+			name: "unknown notification returns 404",
+			setupMock: func(m *historymocks.MockHistoryService) {
+				m.EXPECT().List(gomock.Any(), "test-user-id", "thread-1").
+					Return(nil, history.ErrNotificationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "store error returns 500",
+			setupMock: func(m *historymocks.MockHistoryService) {
+				m.EXPECT().List(gomock.Any(), "test-user-id", "thread-1").
+					Return(nil, errors.Join(history.ErrFailedToListHistory, errors.New("database is locked")))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			mockHistory := historymocks.NewMockHistoryService(ctrl)
+			tt.setupMock(mockHistory)
+			handler = handler.WithHistory(mockHistory)
+
+			req := createRequest(http.MethodGet, "/notifications/thread-1/history", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.handleGetNotificationHistory(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedEvents != nil {
+				var response HistoryResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tt.expectedEvents, response.Events)
+			}
+		})
+	}
+}
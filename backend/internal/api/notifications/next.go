@@ -0,0 +1,77 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ErrFailedToLoadNextNotification is returned when the next notification cannot be found
+var ErrFailedToLoadNextNotification = errors.New("failed to load next notification")
+
+// NextNotificationResponse holds the notification next to the cursor, or null past either
+// end of the list
+type NextNotificationResponse struct {
+	Notification *NotificationResponse `json:"notification"`
+}
+
+// handleNextNotification handles GET /api/notifications/next?query=...&after=<githubID>,
+// returning the notification after the cursor in the query's list order. before=<githubID>
+// steps back instead; with neither, the first notification is returned.
+func (h *Handler) handleNextNotification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	params := r.URL.Query()
+	opts := models.NextOptions{
+		Query:  params.Get("query"),
+		After:  params.Get("after"),
+		Before: params.Get("before"),
+	}
+	if opts.After != "" && opts.Before != "" {
+		helpers.WriteError(w, http.StatusBadRequest, "after and before cannot both be set")
+		return
+	}
+
+	next, err := h.notifications.NextNotification(ctx, userID, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, notification.ErrInvalidQuery):
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+		case errors.Is(err, sql.ErrNoRows):
+			helpers.WriteError(w, http.StatusNotFound, "cursor notification not found")
+		default:
+			h.logger.Error("failed to load next notification",
+				zap.String("query", opts.Query),
+				zap.Error(errors.Join(ErrFailedToLoadNextNotification, err)))
+			helpers.WriteError(w, http.StatusInternalServerError, "failed to load next notification")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, NextNotificationResponse{Notification: next})
+}
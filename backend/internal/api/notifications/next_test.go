@@ -0,0 +1,120 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleNextNotification(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedOpts   *models.NextOptions
+		result         *models.Notification
+		err            error
+		expectedStatus int
+		expectedID     string
+	}{
+		{
+			name:           "returns the notification after the cursor",
+			path:           "/notifications/next?query=is%3Aunread&after=thread-1",
+			expectedOpts:   &models.NextOptions{Query: "is:unread", After: "thread-1"},
+			result:         &models.Notification{GithubID: "thread-2"},
+			expectedStatus: http.StatusOK,
+			expectedID:     "thread-2",
+		},
+		{
+			name:           "end of the list returns null",
+			path:           "/notifications/next?before=thread-1",
+			expectedOpts:   &models.NextOptions{Before: "thread-1"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "after and before together return 400",
+			path:           "/notifications/next?after=thread-1&before=thread-2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid query returns 400",
+			path:           "/notifications/next?query=is%3A",
+			expectedOpts:   &models.NextOptions{Query: "is:"},
+			err:            errors.Join(notification.ErrInvalidQuery, errors.New("bad")),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown cursor returns 404",
+			path:           "/notifications/next?after=missing",
+			expectedOpts:   &models.NextOptions{After: "missing"},
+			err:            sql.ErrNoRows,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "store error returns 500",
+			path:           "/notifications/next",
+			expectedOpts:   &models.NextOptions{},
+			err:            errors.New("database is locked"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockNotificationSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			if tt.expectedOpts != nil {
+				mockNotificationSvc.EXPECT().
+					NextNotification(gomock.Any(), "test-user-id", *tt.expectedOpts).
+					Return(tt.result, tt.err)
+			}
+
+			req := createRequest(http.MethodGet, tt.path, nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.handleNextNotification(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response NextNotificationResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				if tt.expectedID == "" {
+					require.Nil(t, response.Notification)
+				} else {
+					require.Equal(t, tt.expectedID, response.Notification.GithubID)
+				}
+			}
+		})
+	}
+}
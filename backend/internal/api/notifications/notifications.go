@@ -29,7 +29,9 @@ import (
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
 	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
 )
 
 // Error definitions
@@ -63,6 +65,14 @@ func (h *Handler) handleListNotifications(w http.ResponseWriter, r *http.Request
 	result, err := h.notifications.ListNotifications(ctx, userID, options)
 	if err != nil {
 		// Check if this is an invalid query error
+		if errors.Is(err, notification.ErrInvalidCursor) {
+			helpers.WriteError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		if errors.Is(err, notification.ErrInvalidSort) {
+			helpers.WriteError(w, http.StatusBadRequest, "invalid sort (expected priority)")
+			return
+		}
 		if errors.Is(err, notification.ErrInvalidQuery) {
 			h.logger.Warn(
 				"invalid query in notification list request",
@@ -89,11 +99,17 @@ func (h *Handler) handleListNotifications(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if options.Page <= 1 && options.Cursor == "" {
+		h.recordAccess(ctx, userID, prewarm.KindQuery, strings.TrimSpace(options.Query))
+	}
+
 	helpers.WriteJSON(w, http.StatusOK, listNotificationsResponse{
 		Notifications: result.Notifications,
 		Total:         result.Total,
 		Page:          result.Page,
 		PageSize:      result.PageSize,
+		Defaults:      result.Defaults,
+		NextCursor:    result.NextCursor,
 	})
 }
 
@@ -181,9 +197,22 @@ func (h *Handler) handleGetNotification(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.recordAccess(ctx, userID, prewarm.KindNotification, githubID)
+
 	helpers.WriteJSON(w, http.StatusOK, notificationDetailResponse{Notification: notif})
 }
 
+// recordAccess remembers what was opened so the next launch can warm it. Failures only
+// cost the warm-up, so they're logged rather than returned.
+func (h *Handler) recordAccess(ctx context.Context, userID, kind, target string) {
+	if h.prewarm == nil {
+		return
+	}
+	if err := h.prewarm.RecordAccess(ctx, userID, kind, target); err != nil {
+		h.logger.Debug("failed to record recent access", zap.String("kind", kind), zap.Error(err))
+	}
+}
+
 func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
@@ -237,6 +266,10 @@ func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *htt
 
 	// Refresh the subject by fetching fresh data from GitHub
 	wasMissing, err := h.refreshSubjectData(ctx, userID, githubID)
+	if errors.Is(err, sync.ErrSubjectDetailsDisabled) {
+		helpers.WriteError(w, http.StatusConflict, "subject details are turned off in sync settings")
+		return
+	}
 	if err != nil {
 		// Check if this is a 403 Forbidden error (likely due to organization restrictions)
 		errStr := err.Error()
@@ -311,6 +344,10 @@ func parseNotificationListOptions(r *http.Request) models.ListOptions {
 		IncludeSubject: parseBoolDefault(
 			query.Get("includeSubject"),
 		), // Default: false to reduce payload size
+		ExplainDefaults: parseBoolDefault(query.Get("explainDefaults")),
+		Conversations:   parseBoolDefault(query.Get("conversations")),
+		Cursor:          strings.TrimSpace(query.Get("cursor")),
+		Sort:            strings.TrimSpace(query.Get("sort")),
 	}
 
 	return opts
@@ -25,6 +25,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/require"
@@ -33,11 +34,13 @@ import (
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
 	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
 	repositorymocks "github.com/octobud-hq/octobud/backend/internal/core/repository/mocks"
 	tagmocks "github.com/octobud-hq/octobud/backend/internal/core/tag/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
 	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
 )
 
@@ -62,6 +65,7 @@ func setupTestHandler(
 		syncService:   mockSyncService,
 		scheduler:     nil, // Set in individual tests if needed
 		authSvc:       mockAuthSvc,
+		now:           time.Now,
 	}
 
 	return handler, mockNotificationSvc, mockTagSvc, mockAuthSvc
@@ -130,6 +134,40 @@ func TestHandler_handleListNotifications(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name:        "cursor is passed through and the next one returned",
+			queryParams: map[string]string{"cursor": "abc"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", models.ListOptions{Cursor: "abc"}).
+					Return(models.ListDetailsResult{
+						Notifications: []models.Notification{{ID: 2, GithubID: "test-2"}},
+						Total:         2,
+						NextCursor:    "def",
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response listNotificationsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "def", response.NextCursor)
+			},
+		},
+		{
+			name:        "invalid cursor returns 400",
+			queryParams: map[string]string{"cursor": "garbage"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.ListDetailsResult{}, notification.ErrInvalidCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "invalid cursor", response["error"])
+			},
+		},
 		{
 			name:        "service error returns 400",
 			queryParams: map[string]string{},
@@ -291,6 +329,7 @@ func TestHandler_handleRefreshNotificationSubject_Basic(t *testing.T) {
 		githubID           string
 		setupMocks         func(*notificationmocks.MockNotificationService)
 		excludeSyncService bool
+		subjectErr         error
 		expectedStatus     int
 	}{
 		{
@@ -312,6 +351,17 @@ func TestHandler_handleRefreshNotificationSubject_Basic(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:     "subject details turned off returns 409",
+			githubID: "notif-123",
+			setupMocks: func(mockNotifSvc *notificationmocks.MockNotificationService) {
+				mockNotifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+					Return(db.Notification{GithubID: "notif-123"}, nil)
+			},
+			subjectErr:     sync.ErrSubjectDetailsDisabled,
+			expectedStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,6 +371,11 @@ func TestHandler_handleRefreshNotificationSubject_Basic(t *testing.T) {
 
 			const testUserID = "test-user-id"
 			handler, mockNotifSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			if tt.subjectErr != nil {
+				handler.syncService.(*syncmocks.MockSyncOperations).EXPECT().
+					RefreshSubjectData(gomock.Any(), testUserID, tt.githubID).
+					Return(false, tt.subjectErr)
+			}
 			mockAuthSvc.EXPECT().
 				GetUser(gomock.Any()).
 				Return(&models.User{GithubUserID: testUserID}, nil).
@@ -0,0 +1,258 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// maxPlainTitleLength caps how many characters of a title go into a summary
+const maxPlainTitleLength = 80
+
+// plainNotification is a flat notification for the plain stream. Field order is part of
+// the format: lines are read aloud or scrolled on a braille display, so it must not change.
+type plainNotification struct {
+	ID        string `json:"id"`
+	Repo      string `json:"repo"`
+	Type      string `json:"type"`
+	Number    *int64 `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	Reason    string `json:"reason"`
+	Author    string `json:"author"`
+	Unread    bool   `json:"unread"`
+	Starred   bool   `json:"starred"`
+	UpdatedAt string `json:"updatedAt"`
+	Summary   string `json:"summary"`
+}
+
+// handlePlainNotifications lists notifications as plain text, one sentence per line, or as
+// JSON lines with format=jsonl. It accepts the same query and paging parameters as the list.
+func (h *Handler) handlePlainNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "text" && format != "jsonl" {
+		helpers.WriteError(w, http.StatusBadRequest, "unsupported format: "+format)
+		return
+	}
+
+	options := parseNotificationListOptions(r)
+	options.IncludeSubject = false
+	options.ExplainDefaults = false
+
+	result, err := h.notifications.ListNotifications(ctx, userID, options)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidQuery) {
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+			return
+		}
+		h.logger.Error(
+			"failed to load plain notifications",
+			zap.Error(errors.Join(ErrFailedToLoadNotifications, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load notifications")
+		return
+	}
+
+	now := h.now()
+	items := make([]plainNotification, 0, len(result.Notifications))
+	for _, n := range result.Notifications {
+		items = append(items, newPlainNotification(n, now))
+	}
+
+	var buf bytes.Buffer
+	contentType := "text/plain; charset=utf-8"
+	if format == "jsonl" {
+		contentType = "application/x-ndjson"
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				h.logger.Error("failed to encode plain notification", zap.Error(err))
+				helpers.WriteError(w, http.StatusInternalServerError, "failed to load notifications")
+				return
+			}
+		}
+	} else {
+		buf.WriteString(plainHeading(result, len(items)))
+		buf.WriteByte('\n')
+		for _, item := range items {
+			buf.WriteString(item.Summary)
+			buf.WriteByte('\n')
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Total-Count", strconv.FormatInt(result.Total, 10))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func newPlainNotification(n models.Notification, now time.Time) plainNotification {
+	item := plainNotification{
+		ID:        n.GithubID,
+		Type:      n.SubjectType,
+		Number:    n.SubjectNumber,
+		Title:     singleLine(n.SubjectTitle),
+		Unread:    !n.IsRead,
+		Starred:   n.Starred,
+		UpdatedAt: n.EffectiveSortDate.UTC().Format(time.RFC3339),
+	}
+	if n.Repository != nil {
+		item.Repo = n.Repository.FullName
+	}
+	if n.SubjectState != nil {
+		item.State = *n.SubjectState
+	}
+	if n.SubjectMerged != nil && *n.SubjectMerged {
+		item.State = "merged"
+	}
+	if n.Reason != nil {
+		item.Reason = *n.Reason
+	}
+	if n.AuthorLogin != nil {
+		item.Author = *n.AuthorLogin
+	}
+	item.Summary = plainSummary(item, now.Sub(n.EffectiveSortDate))
+	return item
+}
+
+// plainSummary builds a short sentence-per-field summary, like
+// "Unread. Pull request 12 in cli/cli: Fix login. Open. Review requested. By octocat. 2 hours ago."
+func plainSummary(item plainNotification, age time.Duration) string {
+	var parts []string
+	if item.Unread {
+		parts = append(parts, "Unread")
+	}
+	if item.Starred {
+		parts = append(parts, "Starred")
+	}
+
+	subject := spokenSubjectType(item.Type)
+	if item.Number != nil {
+		subject += " " + strconv.FormatInt(*item.Number, 10)
+	}
+	if item.Repo != "" {
+		subject += " in " + item.Repo
+	}
+	if title := strings.TrimRight(truncateTitle(item.Title), "."); title != "" {
+		subject += ": " + title
+	}
+	parts = append(parts, subject)
+
+	if item.State != "" {
+		parts = append(parts, capitalize(strings.ReplaceAll(item.State, "_", " ")))
+	}
+	if item.Reason != "" {
+		parts = append(parts, capitalize(strings.ReplaceAll(item.Reason, "_", " ")))
+	}
+	if item.Author != "" {
+		parts = append(parts, "By "+item.Author)
+	}
+	parts = append(parts, spokenAge(age))
+
+	return strings.Join(parts, ". ") + "."
+}
+
+// plainHeading introduces the text stream, like "Showing 1 to 50 of 120 notifications."
+func plainHeading(result models.ListDetailsResult, count int) string {
+	if count == 0 {
+		return "No notifications."
+	}
+	noun := "notifications"
+	if result.Total == 1 {
+		noun = "notification"
+	}
+	first := 1
+	if result.Page > 1 {
+		first = (result.Page-1)*result.PageSize + 1
+	}
+	return fmt.Sprintf("Showing %d to %d of %d %s.", first, first+count-1, result.Total, noun)
+}
+
+func spokenSubjectType(subjectType string) string {
+	switch subjectType {
+	case "PullRequest":
+		return "Pull request"
+	case "CheckSuite":
+		return "Check suite"
+	case "RepositoryVulnerabilityAlert":
+		return "Security alert"
+	case "":
+		return "Notification"
+	default:
+		return subjectType
+	}
+}
+
+// spokenAge renders a duration in words, like "just now", "1 minute ago", or "3 days ago"
+func spokenAge(d time.Duration) string {
+	unit, count := "", 0
+	switch {
+	case d < time.Minute:
+		return "Just now"
+	case d < time.Hour:
+		unit, count = "minute", int(d.Minutes())
+	case d < 24*time.Hour:
+		unit, count = "hour", int(d.Hours())
+	case d < 14*24*time.Hour:
+		unit, count = "day", int(d.Hours()/24)
+	default:
+		unit, count = "week", int(d.Hours()/(24*7))
+	}
+	if count != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", count, unit)
+}
+
+// singleLine collapses whitespace so a title can't break the one-line-per-notification layout
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncateTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) <= maxPlainTitleLength {
+		return title
+	}
+	return strings.TrimSpace(string(runes[:maxPlainTitleLength])) + "…"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handlePlainNotifications(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	number := int64(12)
+	state := "open"
+	merged := true
+	reason := "review_requested"
+	author := "octocat"
+	listed := models.ListDetailsResult{
+		Notifications: []models.Notification{
+			{
+				GithubID:          "n1",
+				SubjectType:       "PullRequest",
+				SubjectTitle:      "Fix the\nlogin flow.",
+				SubjectNumber:     &number,
+				SubjectState:      &state,
+				Reason:            &reason,
+				AuthorLogin:       &author,
+				Starred:           true,
+				EffectiveSortDate: now.Add(-2 * time.Hour),
+				Repository:        &models.Repository{FullName: "cli/cli"},
+			},
+			{
+				GithubID:          "n2",
+				SubjectType:       "Issue",
+				SubjectTitle:      "Crash on start",
+				SubjectMerged:     &merged,
+				IsRead:            true,
+				EffectiveSortDate: now.Add(-time.Minute),
+			},
+		},
+		Total:    5,
+		Page:     2,
+		PageSize: 2,
+	}
+
+	tests := []struct {
+		name           string
+		rawQuery       string
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{
+			name:     "text lists one summary per line",
+			rawQuery: "query=repo%3Acli%2Fcli&page=2&pageSize=2",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", models.ListOptions{
+						Query:    "repo:cli/cli",
+						Page:     2,
+						PageSize: 2,
+					}).
+					Return(listed, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/plain; charset=utf-8",
+			expectedBody: "Showing 3 to 4 of 5 notifications.\n" +
+				"Unread. Starred. Pull request 12 in cli/cli: Fix the login flow. Open. " +
+				"Review requested. By octocat. 2 hours ago.\n" +
+				"Issue: Crash on start. Merged. 1 minute ago.\n",
+		},
+		{
+			name:     "jsonl keeps a stable field order",
+			rawQuery: "format=jsonl",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(listed, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/x-ndjson",
+			expectedBody: `{"id":"n1","repo":"cli/cli","type":"PullRequest","number":12,` +
+				`"title":"Fix the login flow.","state":"open","reason":"review_requested",` +
+				`"author":"octocat","unread":true,"starred":true,"updatedAt":"2025-06-01T10:00:00Z",` +
+				`"summary":"Unread. Starred. Pull request 12 in cli/cli: Fix the login flow. Open. ` +
+				`Review requested. By octocat. 2 hours ago."}` + "\n" +
+				`{"id":"n2","repo":"","type":"Issue","number":null,"title":"Crash on start",` +
+				`"state":"merged","reason":"","author":"","unread":false,"starred":false,` +
+				`"updatedAt":"2025-06-01T11:59:00Z",` +
+				`"summary":"Issue: Crash on start. Merged. 1 minute ago."}` + "\n",
+		},
+		{
+			name:     "empty inbox",
+			rawQuery: "",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.ListDetailsResult{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/plain; charset=utf-8",
+			expectedBody:   "No notifications.\n",
+		},
+		{
+			name:           "unsupported format",
+			rawQuery:       "format=xml",
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "application/json",
+			expectedBody:   `{"error":"unsupported format: xml"}` + "\n",
+		},
+		{
+			name:     "invalid query",
+			rawQuery: "query=repo%3A",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.ListDetailsResult{}, notification.ErrInvalidQuery)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			handler.now = func() time.Time { return now }
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/notifications/plain?"+tt.rawQuery, nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handlePlainNotifications(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+			if tt.expectedBody != "" {
+				require.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPlainSummary_TruncatesLongTitles(t *testing.T) {
+	item := plainNotification{
+		Type:  "Issue",
+		Title: strings.Repeat("word ", 40),
+	}
+
+	summary := plainSummary(item, 3*24*time.Hour)
+
+	require.Equal(t, "Issue: "+strings.TrimSpace(strings.Repeat("word ", 16))+"…. 3 days ago.", summary)
+}
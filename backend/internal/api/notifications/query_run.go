@@ -0,0 +1,157 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ErrFailedToRunQuery is returned when an ad-hoc query cannot be run
+var ErrFailedToRunQuery = errors.New("failed to run query")
+
+// runQueryRequest is an ad-hoc query to run without saving it as a view. Fields mean the
+// same as the list endpoint's parameters.
+type runQueryRequest struct {
+	Query         string `json:"query"`
+	Sort          string `json:"sort"`
+	PageSize      int    `json:"pageSize"`
+	Cursor        string `json:"cursor"`
+	Conversations bool   `json:"conversations"`
+}
+
+// runQueryResponse is a page of results for an ad-hoc query, with how many notifications
+// match and how many of those are unread
+type runQueryResponse struct {
+	listNotificationsResponse
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+// recentQuery is a query from the user's recent query history
+type recentQuery struct {
+	Query string    `json:"query"`
+	RanAt time.Time `json:"ranAt"`
+}
+
+type recentQueriesResponse struct {
+	Queries []recentQuery `json:"queries"`
+}
+
+// handleRunQuery runs a query the way a view would, without saving a view. The first
+// page of a non-empty query is added to the user's recent query history.
+func (h *Handler) handleRunQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req runQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	queryStr := strings.TrimSpace(req.Query)
+
+	result, err := h.notifications.RunQuery(ctx, userID, models.ListOptions{
+		Query:         queryStr,
+		PageSize:      req.PageSize,
+		Conversations: req.Conversations,
+		Cursor:        strings.TrimSpace(req.Cursor),
+		Sort:          strings.TrimSpace(req.Sort),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, notification.ErrInvalidQuery):
+			helpers.WriteError(w, http.StatusBadRequest, getQueryErrorMessage(err))
+		case errors.Is(err, notification.ErrInvalidSort):
+			helpers.WriteError(w, http.StatusBadRequest, "invalid sort (expected priority)")
+		case errors.Is(err, notification.ErrInvalidCursor):
+			helpers.WriteError(w, http.StatusBadRequest, "invalid cursor")
+		default:
+			h.logger.Error(
+				"failed to run query",
+				zap.String("query", queryStr),
+				zap.Error(errors.Join(ErrFailedToRunQuery, err)),
+			)
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to run query")
+		}
+		return
+	}
+
+	if queryStr != "" && req.Cursor == "" {
+		h.recordAccess(ctx, userID, prewarm.KindAdHocQuery, queryStr)
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, runQueryResponse{
+		listNotificationsResponse: listNotificationsResponse{
+			Notifications: result.Notifications,
+			Total:         result.Total,
+			Page:          result.Page,
+			PageSize:      result.PageSize,
+			NextCursor:    result.NextCursor,
+		},
+		UnreadCount: result.UnreadCount,
+	})
+}
+
+// handleListRecentQueries lists the user's recent ad-hoc queries, newest first
+func (h *Handler) handleListRecentQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	entries, err := h.prewarm.RecentAccess(ctx, userID, prewarm.KindAdHocQuery)
+	if err != nil {
+		h.logger.Error("failed to list recent queries", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list recent queries")
+		return
+	}
+
+	queries := make([]recentQuery, 0, len(entries))
+	for _, entry := range entries {
+		queries = append(queries, recentQuery{Query: entry.Target, RanAt: entry.AccessedAt})
+	}
+	helpers.WriteJSON(w, http.StatusOK, recentQueriesResponse{Queries: queries})
+}
+
+// handleClearRecentQueries forgets the user's recent ad-hoc queries
+func (h *Handler) handleClearRecentQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	if err := h.prewarm.ClearAccess(ctx, userID, prewarm.KindAdHocQuery); err != nil {
+		h.logger.Error("failed to clear recent queries", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to clear recent queries")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
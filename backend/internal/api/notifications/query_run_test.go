@@ -0,0 +1,197 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_QueryRun(t *testing.T) {
+	const testUserID = "test-user-id"
+	ranAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           interface{}
+		setupMocks     func(*notificationmocks.MockNotificationService, *prewarmmocks.MockPrewarmService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "run records the query in history",
+			method: http.MethodPost,
+			url:    "/query/run",
+			body:   runQueryRequest{Query: " repo:cli/cli ", Sort: "priority", PageSize: 10},
+			setupMocks: func(
+				svc *notificationmocks.MockNotificationService,
+				pw *prewarmmocks.MockPrewarmService,
+			) {
+				svc.EXPECT().
+					RunQuery(gomock.Any(), testUserID, models.ListOptions{
+						Query:    "repo:cli/cli",
+						Sort:     "priority",
+						PageSize: 10,
+					}).
+					Return(models.QueryRunResult{
+						ListDetailsResult: models.ListDetailsResult{Total: 12, Page: 1, PageSize: 10},
+						UnreadCount:       5,
+					}, nil)
+				pw.EXPECT().RecordAccess(gomock.Any(), testUserID, prewarm.KindAdHocQuery, "repo:cli/cli")
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response runQueryResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(12), response.Total)
+				require.Equal(t, int64(5), response.UnreadCount)
+			},
+		},
+		{
+			name:   "later pages aren't recorded",
+			method: http.MethodPost,
+			url:    "/query/run",
+			body:   runQueryRequest{Query: "repo:cli/cli", Cursor: "abc"},
+			setupMocks: func(
+				svc *notificationmocks.MockNotificationService,
+				_ *prewarmmocks.MockPrewarmService,
+			) {
+				svc.EXPECT().
+					RunQuery(gomock.Any(), testUserID, models.ListOptions{Query: "repo:cli/cli", Cursor: "abc"}).
+					Return(models.QueryRunResult{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "invalid query returns 400",
+			method: http.MethodPost,
+			url:    "/query/run",
+			body:   runQueryRequest{Query: "repo:("},
+			setupMocks: func(
+				svc *notificationmocks.MockNotificationService,
+				_ *prewarmmocks.MockPrewarmService,
+			) {
+				svc.EXPECT().
+					RunQuery(gomock.Any(), testUserID, models.ListOptions{Query: "repo:("}).
+					Return(models.QueryRunResult{}, errors.Join(
+						notification.ErrInvalidQuery,
+						errors.New("unexpected token"),
+					))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid sort returns 400",
+			method: http.MethodPost,
+			url:    "/query/run",
+			body:   runQueryRequest{Query: "is:unread", Sort: "oldest"},
+			setupMocks: func(
+				svc *notificationmocks.MockNotificationService,
+				_ *prewarmmocks.MockPrewarmService,
+			) {
+				svc.EXPECT().
+					RunQuery(gomock.Any(), testUserID, models.ListOptions{Query: "is:unread", Sort: "oldest"}).
+					Return(models.QueryRunResult{}, notification.ErrInvalidSort)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			method:         http.MethodPost,
+			url:            "/query/run",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list recent queries",
+			method: http.MethodGet,
+			url:    "/query/recent",
+			setupMocks: func(
+				_ *notificationmocks.MockNotificationService,
+				pw *prewarmmocks.MockPrewarmService,
+			) {
+				pw.EXPECT().
+					RecentAccess(gomock.Any(), testUserID, prewarm.KindAdHocQuery).
+					Return([]db.RecentAccess{{Target: "repo:cli/cli", AccessedAt: ranAt}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response recentQueriesResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, []recentQuery{{Query: "repo:cli/cli", RanAt: ranAt}}, response.Queries)
+			},
+		},
+		{
+			name:   "clear recent queries",
+			method: http.MethodDelete,
+			url:    "/query/recent",
+			setupMocks: func(
+				_ *notificationmocks.MockNotificationService,
+				pw *prewarmmocks.MockPrewarmService,
+			) {
+				pw.EXPECT().ClearAccess(gomock.Any(), testUserID, prewarm.KindAdHocQuery).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockPrewarm)
+			}
+			router := chi.NewRouter()
+			handler.WithPrewarm(mockPrewarm).Register(router)
+
+			req := createRequest(tt.method, tt.url, tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// Error definitions
+var (
+	ErrFailedToComposeReply = errors.New("failed to compose saved reply")
+	ErrFailedToPostReply    = errors.New("failed to post reply")
+)
+
+// replyRequest is the body of POST /notifications/{githubID}/reply. Exactly one of Body
+// and SavedReplyID is set.
+type replyRequest struct {
+	Body         string `json:"body"`
+	SavedReplyID string `json:"savedReplyId"`
+}
+
+// ReplyComment is the comment posted by a reply
+type ReplyComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"htmlUrl"`
+}
+
+type replyResponse struct {
+	Comment ReplyComment `json:"comment"`
+}
+
+// handleReply posts a comment on the notification's issue or pull request, either with
+// the given body or with a saved reply whose template variables are filled in from the
+// notification.
+func (h *Handler) handleReply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID encoding")
+		return
+	}
+
+	var req replyRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		h.logger.Error(
+			"invalid request body",
+			zap.Error(errors.Join(ErrFailedToDecodeRequest, decodeErr)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	hasBody := strings.TrimSpace(req.Body) != ""
+	if hasBody == (req.SavedReplyID != "") {
+		helpers.WriteError(w, http.StatusBadRequest, "provide either body or savedReplyId")
+		return
+	}
+
+	if h.githubClient == nil || (req.SavedReplyID != "" && h.savedReplies == nil) {
+		h.logger.Warn("replies not available", zap.Error(ErrGitHubClientNotConfigured))
+		helpers.WriteError(w, http.StatusServiceUnavailable, "replies not available")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	notification, subjectInfo, ok := h.loadCommentTarget(w, r, userID, githubID)
+	if !ok {
+		return
+	}
+
+	body := req.Body
+	if req.SavedReplyID != "" {
+		body, err = h.savedReplies.Compose(ctx, userID, req.SavedReplyID, savedreply.Variables{
+			Author:  notification.AuthorLogin.String,
+			PRTitle: notification.SubjectTitle,
+		})
+		if err != nil {
+			if errors.Is(err, savedreply.ErrNotFound) {
+				helpers.WriteError(w, http.StatusNotFound, "saved reply not found")
+				return
+			}
+			h.logger.Error(
+				"failed to compose saved reply",
+				zap.String("saved_reply_id", req.SavedReplyID),
+				zap.Error(errors.Join(ErrFailedToComposeReply, err)),
+			)
+			helpers.WriteError(w, http.StatusInternalServerError, "failed to compose saved reply")
+			return
+		}
+	}
+
+	comment, ok := h.postComment(w, r, userID, notification, subjectInfo, body)
+	if !ok {
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, replyResponse{Comment: replyComment(comment)})
+}
+
+// loadCommentTarget looks up the notification and where on GitHub to comment on it,
+// writing the error response when it can't be commented on.
+func (h *Handler) loadCommentTarget(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID, githubID string,
+) (db.Notification, *types.SubjectInfo, bool) {
+	ctx := r.Context()
+
+	notification, err := h.notifications.GetByGithubID(ctx, userID, githubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			helpers.WriteError(w, http.StatusNotFound, "notification not found")
+			return db.Notification{}, nil, false
+		}
+		h.logger.Error(
+			"failed to fetch notification for reply",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToFetchNotification, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to fetch notification")
+		return db.Notification{}, nil, false
+	}
+
+	// Discussions need GraphQL mutations and commits have no issue thread to comment on
+	if notification.SubjectType != "Issue" && notification.SubjectType != "PullRequest" {
+		helpers.WriteError(
+			w,
+			http.StatusBadRequest,
+			"only issues and pull requests are supported",
+		)
+		return db.Notification{}, nil, false
+	}
+
+	var subjectRaw json.RawMessage
+	if notification.SubjectRaw.Valid {
+		subjectRaw = notification.SubjectRaw.RawMessage
+	}
+	subjectInfo, err := github.ExtractSubjectInfo(notification.SubjectURL.String, subjectRaw)
+	if err != nil {
+		h.logger.Error(
+			"failed to parse subject info for reply",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToParseSubjectInfo, err)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "could not determine where to reply")
+		return db.Notification{}, nil, false
+	}
+
+	return notification, subjectInfo, true
+}
+
+// postComment posts body on the notification's issue or pull request, writing the error
+// response when it can't.
+func (h *Handler) postComment(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID string,
+	notification db.Notification,
+	subjectInfo *types.SubjectInfo,
+	body string,
+) (*types.IssueComment, bool) {
+	ctx := r.Context()
+
+	if !h.requireWriteScope(w, r, userID, notification) {
+		return nil, false
+	}
+
+	comment, err := h.githubClient.CreateIssueComment(
+		ctx,
+		subjectInfo.Owner,
+		subjectInfo.Repo,
+		subjectInfo.Number,
+		body,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 403") || strings.Contains(err.Error(), "status 404") {
+			h.logger.Warn(
+				"permission error posting reply",
+				zap.String("github_id", notification.GithubID),
+				zap.Error(errors.Join(ErrFailedToPostReply, err)),
+			)
+			helpers.WriteError(w, http.StatusForbidden, "permission denied to post reply")
+			return nil, false
+		}
+		h.logger.Error(
+			"failed to post reply",
+			zap.String("github_id", notification.GithubID),
+			zap.Error(errors.Join(ErrFailedToPostReply, err)),
+		)
+		helpers.WriteError(w, http.StatusBadGateway, "failed to post reply")
+		return nil, false
+	}
+
+	return comment, true
+}
+
+// requireWriteScope checks the token can write to the notification's repository,
+// writing the error response when it's known it can't.
+func (h *Handler) requireWriteScope(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID string,
+	notification db.Notification,
+) bool {
+	ctx := r.Context()
+
+	// Classic tokens report their scopes, so a missing one can be named instead of
+	// surfacing as GitHub's 404 for private repositories
+	if scopes, known := h.githubClient.TokenScopes(); known {
+		private := false
+		if repo, err := h.repositorySvc.GetRepositoryByID(
+			ctx,
+			userID,
+			notification.RepositoryID,
+		); err == nil {
+			private = repo.Private.Valid && repo.Private.Bool
+		}
+		if !github.CanWriteRepository(scopes, private) {
+			scope := "public_repo"
+			if private {
+				scope = "repo"
+			}
+			helpers.WriteError(
+				w,
+				http.StatusForbidden,
+				fmt.Sprintf("token needs the %s scope for this repository", scope),
+			)
+			return false
+		}
+	}
+	return true
+}
+
+// replyComment converts a posted GitHub comment to its API form
+func replyComment(comment *types.IssueComment) ReplyComment {
+	return ReplyComment{
+		ID:      comment.ID,
+		Body:    comment.Body,
+		HTMLURL: comment.HTMLURL,
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	savedreplymocks "github.com/octobud-hq/octobud/backend/internal/core/savedreply/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func replyNotification(subjectType string) db.Notification {
+	return db.Notification{
+		GithubID:     "thread-1",
+		SubjectType:  subjectType,
+		SubjectTitle: "Fix the flaky test",
+		SubjectURL: sql.NullString{
+			String: "https://api.github.com/repos/cli/cli/pulls/42",
+			Valid:  true,
+		},
+		AuthorLogin: sql.NullString{String: "octocat", Valid: true},
+	}
+}
+
+func TestHandler_handleReply(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMocks     func(*notificationmocks.MockNotificationService, *savedreplymocks.MockSavedReplyService, *githubmocks.MockClient)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "posts body as a comment",
+			body: replyRequest{Body: "Looks good"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *savedreplymocks.MockSavedReplyService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "Looks good").
+					Return(&types.IssueComment{ID: 7, Body: "Looks good"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response replyResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(7), response.Comment.ID)
+			},
+		},
+		{
+			name: "posts composed saved reply",
+			body: replyRequest{SavedReplyID: "SR_1"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				replies *savedreplymocks.MockSavedReplyService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				replies.EXPECT().
+					Compose(gomock.Any(), "test-user-id", "SR_1", savedreply.Variables{
+						Author:  "octocat",
+						PRTitle: "Fix the flaky test",
+					}).
+					Return("Thanks @octocat!", nil)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "Thanks @octocat!").
+					Return(&types.IssueComment{ID: 8, Body: "Thanks @octocat!"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "body and saved reply together returns 400",
+			body:           replyRequest{Body: "hi", SavedReplyID: "SR_1"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown saved reply returns 404",
+			body: replyRequest{SavedReplyID: "SR_missing"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				replies *savedreplymocks.MockSavedReplyService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				replies.EXPECT().
+					Compose(gomock.Any(), "test-user-id", "SR_missing", gomock.Any()).
+					Return("", savedreply.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "discussion returns 400",
+			body: replyRequest{Body: "hi"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *savedreplymocks.MockSavedReplyService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Discussion"), nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "github error returns 502",
+			body: replyRequest{Body: "hi"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *savedreplymocks.MockSavedReplyService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				client.EXPECT().
+					CreateIssueComment(gomock.Any(), "cli", "cli", 42, "hi").
+					Return(nil, errors.New("github: comment status 500: boom"))
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockReplies := savedreplymocks.NewMockSavedReplyService(ctrl)
+			mockClient := githubmocks.NewMockClient(ctrl)
+			handler.githubClient = mockClient
+			handler.WithSavedReplies(mockReplies)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockReplies, mockClient)
+			}
+			mockClient.EXPECT().TokenScopes().Return(nil, false).AnyTimes()
+
+			req := createRequest(http.MethodPost, "/notifications/thread-1/reply", tt.body)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleReply(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
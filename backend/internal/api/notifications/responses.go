@@ -87,6 +87,11 @@ type listNotificationsResponse struct {
 	Total         int64                  `json:"total"`
 	Page          int                    `json:"page"`
 	PageSize      int                    `json:"pageSize"`
+	// Defaults is only set when the request passes explainDefaults=true
+	Defaults *models.QueryDefaults `json:"defaults,omitempty"`
+	// NextCursor fetches the page after this one with cursor=..., and is omitted on the
+	// last page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type notificationDetailResponse struct {
@@ -103,6 +108,8 @@ type notificationActionResponse struct {
 
 type bulkNotificationsResponse struct {
 	Count int `json:"count"`
+	// UndoID undoes the operation through POST /bulk/undo/{id}, for a while
+	UndoID int64 `json:"undoId,omitempty"`
 }
 
 // PollNotificationResponse contains only the minimal fields needed for polling
@@ -0,0 +1,171 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// Error definitions
+var (
+	ErrFailedToSubmitReview = errors.New("failed to submit review")
+)
+
+// reviewEvents maps the review events accepted by the API to GitHub's
+var reviewEvents = map[string]string{
+	"approve":         types.ReviewEventApprove,
+	"request_changes": types.ReviewEventRequestChanges,
+	"comment":         types.ReviewEventComment,
+}
+
+// reviewRequest is the body of POST /notifications/{githubID}/reviews. Body is required
+// unless approving.
+type reviewRequest struct {
+	Event string `json:"event"`
+	Body  string `json:"body"`
+}
+
+// Review is a pull request review submitted from Octobud
+type Review struct {
+	ID      int64  `json:"id"`
+	State   string `json:"state"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"htmlUrl"`
+}
+
+// reviewResponse is the submitted review and, when it could be fetched, the first
+// timeline page including it.
+type reviewResponse struct {
+	Review   Review            `json:"review"`
+	Timeline *TimelineResponse `json:"timeline,omitempty"`
+}
+
+// handleSubmitReview approves, requests changes on, or comments on the notification's
+// pull request, so a review request can be handled without leaving Octobud.
+func (h *Handler) handleSubmitReview(w http.ResponseWriter, r *http.Request) { //nolint:gocyclo
+	ctx := r.Context()
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID encoding")
+		return
+	}
+
+	var req reviewRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		h.logger.Error(
+			"invalid request body",
+			zap.Error(errors.Join(ErrFailedToDecodeRequest, decodeErr)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	event, ok := reviewEvents[req.Event]
+	if !ok {
+		helpers.WriteError(
+			w,
+			http.StatusBadRequest,
+			"event must be approve, request_changes, or comment",
+		)
+		return
+	}
+	// GitHub rejects reviews that request changes or comment without saying anything
+	if event != types.ReviewEventApprove && strings.TrimSpace(req.Body) == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "body is required unless approving")
+		return
+	}
+
+	if h.githubClient == nil {
+		h.logger.Warn("reviews not available", zap.Error(ErrGitHubClientNotConfigured))
+		helpers.WriteError(w, http.StatusServiceUnavailable, "reviews not available")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	notification, subjectInfo, ok := h.loadCommentTarget(w, r, userID, githubID)
+	if !ok {
+		return
+	}
+	if notification.SubjectType != "PullRequest" {
+		helpers.WriteError(w, http.StatusBadRequest, "reviews are only supported on pull requests")
+		return
+	}
+
+	if !h.requireWriteScope(w, r, userID, notification) {
+		return
+	}
+
+	review, err := h.githubClient.SubmitPullRequestReview(
+		ctx,
+		subjectInfo.Owner,
+		subjectInfo.Repo,
+		subjectInfo.Number,
+		event,
+		req.Body,
+	)
+	if err != nil {
+		errStr := err.Error()
+		switch {
+		case strings.Contains(errStr, "status 403") || strings.Contains(errStr, "status 404"):
+			h.logger.Warn(
+				"permission error submitting review",
+				zap.String("github_id", githubID),
+				zap.Error(errors.Join(ErrFailedToSubmitReview, err)),
+			)
+			helpers.WriteError(w, http.StatusForbidden, "permission denied to submit review")
+		case strings.Contains(errStr, "status 422"):
+			// Such as approving your own pull request or reviewing a closed one
+			h.logger.Warn(
+				"review rejected",
+				zap.String("github_id", githubID),
+				zap.Error(errors.Join(ErrFailedToSubmitReview, err)),
+			)
+			helpers.WriteError(w, http.StatusUnprocessableEntity, "GitHub rejected the review")
+		default:
+			h.logger.Error(
+				"failed to submit review",
+				zap.String("github_id", githubID),
+				zap.Error(errors.Join(ErrFailedToSubmitReview, err)),
+			)
+			helpers.WriteError(w, http.StatusBadGateway, "failed to submit review")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, reviewResponse{
+		Review: Review{
+			ID:      review.ID,
+			State:   review.State,
+			Body:    review.Body,
+			HTMLURL: review.HTMLURL,
+		},
+		Timeline: h.refreshTimeline(ctx, userID, notification, subjectInfo),
+	})
+}
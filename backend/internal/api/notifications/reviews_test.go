@@ -0,0 +1,180 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleSubmitReview(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMocks     func(*notificationmocks.MockNotificationService, *githubmocks.MockClient)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "approves pull request",
+			body: reviewRequest{Event: "approve"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					SubmitPullRequestReview(gomock.Any(), "cli", "cli", 42, types.ReviewEventApprove, "").
+					Return(&types.PullRequestReview{ID: 9, State: "APPROVED"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response reviewResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(9), response.Review.ID)
+				require.Equal(t, "APPROVED", response.Review.State)
+			},
+		},
+		{
+			name: "requests changes with body",
+			body: reviewRequest{Event: "request_changes", Body: "Needs a test"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					SubmitPullRequestReview(
+						gomock.Any(), "cli", "cli", 42, types.ReviewEventRequestChanges, "Needs a test",
+					).
+					Return(&types.PullRequestReview{ID: 10, State: "CHANGES_REQUESTED"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "request changes without body returns 400",
+			body:           reviewRequest{Event: "request_changes"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown event returns 400",
+			body:           reviewRequest{Event: "merge"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "issue returns 400",
+			body: reviewRequest{Event: "approve"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "review rejected by GitHub returns 422",
+			body: reviewRequest{Event: "approve"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					SubmitPullRequestReview(gomock.Any(), "cli", "cli", 42, types.ReviewEventApprove, "").
+					Return(nil, errors.New("github: review status 422: own pull request"))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "github permission error returns 403",
+			body: reviewRequest{Event: "comment", Body: "Nice"},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					SubmitPullRequestReview(gomock.Any(), "cli", "cli", 42, types.ReviewEventComment, "Nice").
+					Return(nil, errors.New("github: review status 404: not found"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+			handler.githubClient = mockClient
+			handler.prewarm = mockPrewarm
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockClient)
+			}
+			mockClient.EXPECT().TokenScopes().Return(nil, false).AnyTimes()
+			if tt.expectedStatus == http.StatusCreated {
+				mockPrewarm.EXPECT().ForgetTimelines(testUserID, "thread-1")
+			}
+
+			req := createRequest(http.MethodPost, "/notifications/thread-1/reviews", tt.body)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			handler.handleSubmitReview(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// Error definitions
+var (
+	ErrFailedToMergePullRequest = errors.New("failed to merge pull request")
+	ErrFailedToUpdateIssueState = errors.New("failed to update issue state")
+)
+
+// mergeMethods are the merge methods accepted by POST /notifications/{githubID}/merge
+var mergeMethods = map[string]bool{
+	types.MergeMethodMerge:  true,
+	types.MergeMethodSquash: true,
+	types.MergeMethodRebase: true,
+}
+
+// mergeRequest is the body of POST /notifications/{githubID}/merge. Method defaults to
+// a merge commit.
+type mergeRequest struct {
+	Method string `json:"method"`
+}
+
+type mergeResponse struct {
+	Merged   bool              `json:"merged"`
+	SHA      string            `json:"sha"`
+	Message  string            `json:"message"`
+	Timeline *TimelineResponse `json:"timeline,omitempty"`
+}
+
+type subjectStateResponse struct {
+	State    string            `json:"state"`
+	Timeline *TimelineResponse `json:"timeline,omitempty"`
+}
+
+// handleMergePullRequest merges the notification's pull request. It needs confirm=true,
+// so a stray request can't merge anything.
+func (h *Handler) handleMergePullRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// The body is optional
+	var req mergeRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil &&
+		!errors.Is(decodeErr, io.EOF) {
+		h.logger.Error(
+			"invalid request body",
+			zap.Error(errors.Join(ErrFailedToDecodeRequest, decodeErr)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Method == "" {
+		req.Method = types.MergeMethodMerge
+	}
+	if !mergeMethods[req.Method] {
+		helpers.WriteError(w, http.StatusBadRequest, "method must be merge, squash, or rebase")
+		return
+	}
+
+	userID, notification, subjectInfo, ok := h.loadSubjectActionTarget(w, r)
+	if !ok {
+		return
+	}
+	if notification.SubjectType != "PullRequest" {
+		helpers.WriteError(w, http.StatusBadRequest, "only pull requests can be merged")
+		return
+	}
+
+	result, err := h.githubClient.MergePullRequest(
+		ctx,
+		subjectInfo.Owner,
+		subjectInfo.Repo,
+		subjectInfo.Number,
+		req.Method,
+	)
+	if err != nil {
+		h.writeSubjectActionError(
+			w,
+			notification.GithubID,
+			"merge pull request",
+			err,
+			ErrFailedToMergePullRequest,
+		)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, mergeResponse{
+		Merged:   result.Merged,
+		SHA:      result.SHA,
+		Message:  result.Message,
+		Timeline: h.refreshTimeline(ctx, userID, notification, subjectInfo),
+	})
+}
+
+// handleCloseSubject closes the notification's issue or pull request. It needs
+// confirm=true.
+func (h *Handler) handleCloseSubject(w http.ResponseWriter, r *http.Request) {
+	h.updateSubjectState(w, r, "closed")
+}
+
+// handleReopenSubject reopens the notification's issue or pull request. It needs
+// confirm=true.
+func (h *Handler) handleReopenSubject(w http.ResponseWriter, r *http.Request) {
+	h.updateSubjectState(w, r, "open")
+}
+
+// updateSubjectState closes or reopens the notification's issue or pull request
+func (h *Handler) updateSubjectState(w http.ResponseWriter, r *http.Request, state string) {
+	ctx := r.Context()
+
+	userID, notification, subjectInfo, ok := h.loadSubjectActionTarget(w, r)
+	if !ok {
+		return
+	}
+
+	action := "close"
+	if state == "open" {
+		action = "reopen"
+	}
+	err := h.githubClient.UpdateIssueState(
+		ctx,
+		subjectInfo.Owner,
+		subjectInfo.Repo,
+		subjectInfo.Number,
+		state,
+	)
+	if err != nil {
+		h.writeSubjectActionError(w, notification.GithubID, action, err, ErrFailedToUpdateIssueState)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, subjectStateResponse{
+		State:    state,
+		Timeline: h.refreshTimeline(ctx, userID, notification, subjectInfo),
+	})
+}
+
+// loadSubjectActionTarget checks the action is confirmed and allowed, and looks up the
+// notification's issue or pull request, writing the error response when it can't go ahead.
+func (h *Handler) loadSubjectActionTarget(
+	w http.ResponseWriter,
+	r *http.Request,
+) (string, db.Notification, *types.SubjectInfo, bool) {
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID encoding")
+		return "", db.Notification{}, nil, false
+	}
+
+	if r.URL.Query().Get("confirm") != "true" {
+		helpers.WriteError(w, http.StatusBadRequest, "add confirm=true to change this on GitHub")
+		return "", db.Notification{}, nil, false
+	}
+
+	if h.githubClient == nil {
+		h.logger.Warn("subject actions not available", zap.Error(ErrGitHubClientNotConfigured))
+		helpers.WriteError(w, http.StatusServiceUnavailable, "subject actions not available")
+		return "", db.Notification{}, nil, false
+	}
+
+	userID, ok := helpers.RequireUserID(r.Context(), w, h.authSvc)
+	if !ok {
+		return "", db.Notification{}, nil, false
+	}
+
+	notification, subjectInfo, ok := h.loadCommentTarget(w, r, userID, githubID)
+	if !ok {
+		return "", db.Notification{}, nil, false
+	}
+
+	if !h.requireWriteScope(w, r, userID, notification) {
+		return "", db.Notification{}, nil, false
+	}
+
+	return userID, notification, subjectInfo, true
+}
+
+// writeSubjectActionError maps GitHub's refusal of a merge, close, or reopen to a response
+func (h *Handler) writeSubjectActionError(
+	w http.ResponseWriter,
+	githubID, action string,
+	err, sentinel error,
+) {
+	errStr := err.Error()
+	switch {
+	case strings.Contains(errStr, "status 403") || strings.Contains(errStr, "status 404"):
+		h.logger.Warn(
+			"permission error on subject action",
+			zap.String("github_id", githubID),
+			zap.String("action", action),
+			zap.Error(errors.Join(sentinel, err)),
+		)
+		helpers.WriteError(w, http.StatusForbidden, "permission denied to "+action)
+	case strings.Contains(errStr, "status 405") || strings.Contains(errStr, "status 409"):
+		// Not mergeable, such as failing required checks, or the head moved meanwhile
+		helpers.WriteError(w, http.StatusConflict, "GitHub could not "+action+" in its current state")
+	case strings.Contains(errStr, "status 422"):
+		helpers.WriteError(w, http.StatusUnprocessableEntity, "GitHub rejected the request to "+action)
+	default:
+		h.logger.Error(
+			"failed subject action",
+			zap.String("github_id", githubID),
+			zap.String("action", action),
+			zap.Error(errors.Join(sentinel, err)),
+		)
+		helpers.WriteError(w, http.StatusBadGateway, "failed to "+action)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_subjectActions(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		body           interface{}
+		handle         func(*Handler) http.HandlerFunc
+		setupMocks     func(*notificationmocks.MockNotificationService, *githubmocks.MockClient)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "merges pull request with chosen method",
+			url:    "/notifications/thread-1/merge?confirm=true",
+			body:   mergeRequest{Method: types.MergeMethodSquash},
+			handle: func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					MergePullRequest(gomock.Any(), "cli", "cli", 42, types.MergeMethodSquash).
+					Return(&types.MergeResult{SHA: "abc123", Merged: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response mergeResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.True(t, response.Merged)
+				require.Equal(t, "abc123", response.SHA)
+			},
+		},
+		{
+			name:   "merge without body uses a merge commit",
+			url:    "/notifications/thread-1/merge?confirm=true",
+			handle: func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					MergePullRequest(gomock.Any(), "cli", "cli", 42, types.MergeMethodMerge).
+					Return(&types.MergeResult{SHA: "abc123", Merged: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "merge without confirmation returns 400",
+			url:            "/notifications/thread-1/merge",
+			body:           mergeRequest{Method: types.MergeMethodSquash},
+			handle:         func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown merge method returns 400",
+			url:            "/notifications/thread-1/merge?confirm=true",
+			body:           mergeRequest{Method: "octopus"},
+			handle:         func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "merging an issue returns 400",
+			url:    "/notifications/thread-1/merge?confirm=true",
+			handle: func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "unmergeable pull request returns 409",
+			url:    "/notifications/thread-1/merge?confirm=true",
+			handle: func(h *Handler) http.HandlerFunc { return h.handleMergePullRequest },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					MergePullRequest(gomock.Any(), "cli", "cli", 42, types.MergeMethodMerge).
+					Return(nil, errors.New("github: merge status 405: not mergeable"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "closes issue",
+			url:    "/notifications/thread-1/close?confirm=true",
+			handle: func(h *Handler) http.HandlerFunc { return h.handleCloseSubject },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("Issue"), nil)
+				client.EXPECT().
+					UpdateIssueState(gomock.Any(), "cli", "cli", 42, "closed").
+					Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response subjectStateResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "closed", response.State)
+			},
+		},
+		{
+			name:   "reopen without permission returns 403",
+			url:    "/notifications/thread-1/reopen?confirm=true",
+			handle: func(h *Handler) http.HandlerFunc { return h.handleReopenSubject },
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(replyNotification("PullRequest"), nil)
+				client.EXPECT().
+					UpdateIssueState(gomock.Any(), "cli", "cli", 42, "open").
+					Return(errors.New("github: issue state status 403: forbidden"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "close without confirmation returns 400",
+			url:            "/notifications/thread-1/close?confirm=false",
+			handle:         func(h *Handler) http.HandlerFunc { return h.handleCloseSubject },
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+			handler.githubClient = mockClient
+			handler.prewarm = mockPrewarm
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockClient)
+			}
+			mockClient.EXPECT().TokenScopes().Return(nil, false).AnyTimes()
+			if tt.expectedStatus == http.StatusOK {
+				mockPrewarm.EXPECT().ForgetTimelines(testUserID, "thread-1")
+			}
+
+			req := createRequest(http.MethodPost, tt.url, tt.body)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			tt.handle(handler)(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// ErrFailedToUpdateSubscription is returned when GitHub won't change a thread subscription
+var ErrFailedToUpdateSubscription = errors.New("failed to update thread subscription")
+
+// subscriptionRequest is the body of PUT /notifications/{githubID}/subscription
+type subscriptionRequest struct {
+	Ignored bool `json:"ignored"`
+}
+
+// ThreadSubscription is the user's subscription to a notification thread on GitHub
+type ThreadSubscription struct {
+	Subscribed bool       `json:"subscribed"`
+	Ignored    bool       `json:"ignored"`
+	Reason     *string    `json:"reason,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+}
+
+type subscriptionResponse struct {
+	Subscription ThreadSubscription `json:"subscription"`
+}
+
+// handleMuteNotification mutes a notification. With ?unsubscribe=true the thread is first
+// ignored on GitHub as well, so no more notifications are sent for it at the source. The
+// notification isn't muted if GitHub refuses.
+func (h *Handler) handleMuteNotification(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("unsubscribe") == "true" {
+		githubID, ok := h.requireThread(w, r)
+		if !ok {
+			return
+		}
+		if _, err := h.githubClient.SetThreadSubscription(r.Context(), githubID, true); err != nil {
+			h.writeSubscriptionError(w, githubID, err)
+			return
+		}
+	}
+	h.handleNotificationAction(w, r, ActionMute)
+}
+
+// handleSetSubscription subscribes to the notification's thread on GitHub, or ignores it
+func (h *Handler) handleSetSubscription(w http.ResponseWriter, r *http.Request) {
+	var req subscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error(
+			"invalid request body",
+			zap.Error(errors.Join(ErrFailedToDecodeRequest, err)),
+		)
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	githubID, ok := h.requireThread(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.githubClient.SetThreadSubscription(r.Context(), githubID, req.Ignored)
+	if err != nil {
+		h.writeSubscriptionError(w, githubID, err)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, subscriptionResponse{
+		Subscription: newThreadSubscription(subscription),
+	})
+}
+
+// handleDeleteSubscription removes the subscription to the notification's thread on
+// GitHub. Notifications stop until the user comments or is mentioned, unless they watch
+// the repository.
+func (h *Handler) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	githubID, ok := h.requireThread(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.githubClient.DeleteThreadSubscription(r.Context(), githubID); err != nil {
+		h.writeSubscriptionError(w, githubID, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireThread returns the GitHub ID of the notification in the path, writing an error
+// and returning false when it isn't one of the user's notifications or GitHub can't be
+// reached
+func (h *Handler) requireThread(w http.ResponseWriter, r *http.Request) (string, bool) {
+	ctx := r.Context()
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil || githubID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid githubID encoding")
+		return "", false
+	}
+
+	if h.githubClient == nil {
+		h.logger.Warn("thread subscriptions not available", zap.Error(ErrGitHubClientNotConfigured))
+		helpers.WriteError(w, http.StatusServiceUnavailable, "thread subscriptions not available")
+		return "", false
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return "", false
+	}
+
+	if _, err := h.notifications.GetByGithubID(ctx, userID, githubID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			helpers.WriteError(w, http.StatusNotFound, "notification not found")
+			return "", false
+		}
+		h.logger.Error(
+			"failed to fetch notification for subscription",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToFetchNotification, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to fetch notification")
+		return "", false
+	}
+	return githubID, true
+}
+
+// writeSubscriptionError reports a failed subscription change, telling permission errors
+// apart from GitHub being unavailable
+func (h *Handler) writeSubscriptionError(w http.ResponseWriter, githubID string, err error) {
+	if strings.Contains(err.Error(), "status 403") || strings.Contains(err.Error(), "status 404") {
+		h.logger.Warn(
+			"permission error updating thread subscription",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToUpdateSubscription, err)),
+		)
+		helpers.WriteError(w, http.StatusForbidden, "permission denied to update subscription")
+		return
+	}
+	h.logger.Error(
+		"failed to update thread subscription",
+		zap.String("github_id", githubID),
+		zap.Error(errors.Join(ErrFailedToUpdateSubscription, err)),
+	)
+	helpers.WriteError(w, http.StatusBadGateway, "failed to update subscription on GitHub")
+}
+
+// newThreadSubscription converts a GitHub thread subscription to its response
+func newThreadSubscription(subscription *types.ThreadSubscription) ThreadSubscription {
+	return ThreadSubscription{
+		Subscribed: subscription.Subscribed,
+		Ignored:    subscription.Ignored,
+		Reason:     subscription.Reason,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
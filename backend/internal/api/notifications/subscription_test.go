@@ -0,0 +1,232 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_ThreadSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           interface{}
+		handle         func(*Handler) http.HandlerFunc
+		setupMocks     func(*notificationmocks.MockNotificationService, *githubmocks.MockClient)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "mute with unsubscribe ignores the thread on GitHub first",
+			path: "/notifications/thread-1/mute?unsubscribe=true",
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleMuteNotification
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				gomock.InOrder(
+					notifSvc.EXPECT().
+						GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+						Return(db.Notification{GithubID: "thread-1"}, nil),
+					client.EXPECT().
+						SetThreadSubscription(gomock.Any(), "thread-1", true).
+						Return(&types.ThreadSubscription{Ignored: true}, nil),
+					notifSvc.EXPECT().
+						MuteNotification(gomock.Any(), "test-user-id", "thread-1").
+						Return(db.Notification{GithubID: "thread-1", Muted: true}, nil),
+					notifSvc.EXPECT().
+						GetNotificationWithDetails(gomock.Any(), "test-user-id", "thread-1", "").
+						Return(models.Notification{GithubID: "thread-1", Muted: true}, nil),
+				)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "mute without unsubscribe stays local",
+			path: "/notifications/thread-1/mute",
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleMuteNotification
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					MuteNotification(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{GithubID: "thread-1", Muted: true}, nil)
+				notifSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), "test-user-id", "thread-1", "").
+					Return(models.Notification{GithubID: "thread-1", Muted: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "mute is not applied when GitHub fails",
+			path: "/notifications/thread-1/mute?unsubscribe=true",
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleMuteNotification
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{GithubID: "thread-1"}, nil)
+				client.EXPECT().
+					SetThreadSubscription(gomock.Any(), "thread-1", true).
+					Return(nil, errors.New("github: thread status 502: bad gateway"))
+			},
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:   "set subscription returns the new subscription",
+			method: http.MethodPut,
+			path:   "/notifications/thread-1/subscription",
+			body:   subscriptionRequest{Ignored: false},
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleSetSubscription
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{GithubID: "thread-1"}, nil)
+				client.EXPECT().
+					SetThreadSubscription(gomock.Any(), "thread-1", false).
+					Return(&types.ThreadSubscription{Subscribed: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response subscriptionResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, ThreadSubscription{Subscribed: true}, response.Subscription)
+			},
+		},
+		{
+			name:   "permission errors return 403",
+			method: http.MethodPut,
+			path:   "/notifications/thread-1/subscription",
+			body:   subscriptionRequest{Ignored: true},
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleSetSubscription
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{GithubID: "thread-1"}, nil)
+				client.EXPECT().
+					SetThreadSubscription(gomock.Any(), "thread-1", true).
+					Return(nil, errors.New("github: thread status 403: forbidden"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "delete subscription returns 204",
+			method: http.MethodDelete,
+			path:   "/notifications/thread-1/subscription",
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleDeleteSubscription
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				client *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{GithubID: "thread-1"}, nil)
+				client.EXPECT().DeleteThreadSubscription(gomock.Any(), "thread-1").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "unknown notification returns 404",
+			method: http.MethodDelete,
+			path:   "/notifications/thread-1/subscription",
+			handle: func(h *Handler) http.HandlerFunc {
+				return h.handleDeleteSubscription
+			},
+			setupMocks: func(
+				notifSvc *notificationmocks.MockNotificationService,
+				_ *githubmocks.MockClient,
+			) {
+				notifSvc.EXPECT().
+					GetByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			mockClient := githubmocks.NewMockClient(ctrl)
+			handler.githubClient = mockClient
+			tt.setupMocks(mockSvc, mockClient)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := createRequest(method, tt.path, tt.body)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "thread-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+
+			w := httptest.NewRecorder()
+			tt.handle(handler)(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -29,9 +29,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
 	"github.com/octobud-hq/octobud/backend/internal/core/timeline"
 	"github.com/octobud-hq/octobud/backend/internal/github"
 	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/sanitize"
 )
 
 func (h *Handler) handleGetNotificationTimeline( //nolint:gocyclo // This is a complex function
@@ -146,43 +148,56 @@ func (h *Handler) handleGetNotificationTimeline( //nolint:gocyclo // This is a c
 		return
 	}
 
-	// Fetch filtered timeline using the core timeline service
-	// Discussions use GraphQL API, issues/PRs use REST API
-	result, err := h.timelineSvc.FetchFilteredTimeline(
-		ctx,
-		h.githubClient,
-		subjectInfo,
-		notification.SubjectType,
-		perPage,
-		page,
-	)
-	if err != nil {
-		// Check if this is a 403 Forbidden error (likely due to organization restrictions)
-		// This applies to both REST and GraphQL APIs
-		errStr := err.Error()
-		isForbidden := strings.Contains(errStr, "status 403") ||
-			strings.Contains(errStr, "403") ||
-			(strings.Contains(errStr, "graphql errors") && strings.Contains(errStr, "FORBIDDEN"))
-		if isForbidden {
-			h.logger.Warn(
-				"permission error fetching timeline",
+	// A page warmed at startup spares the first open a round trip to GitHub
+	var result *models.TimelineResult
+	if h.prewarm != nil {
+		if cached, ok := h.prewarm.TakeTimeline(
+			userID,
+			prewarm.TimelineKey(notification, perPage, page),
+		); ok {
+			result = cached
+		}
+	}
+
+	if result == nil {
+		// Fetch filtered timeline using the core timeline service
+		// Discussions use GraphQL API, issues/PRs use REST API
+		result, err = h.timelineSvc.FetchFilteredTimeline(
+			ctx,
+			h.githubClient,
+			subjectInfo,
+			notification.SubjectType,
+			perPage,
+			page,
+		)
+		if err != nil {
+			// Check if this is a 403 Forbidden error (likely due to organization restrictions)
+			// This applies to both REST and GraphQL APIs
+			errStr := err.Error()
+			isForbidden := strings.Contains(errStr, "status 403") ||
+				strings.Contains(errStr, "403") ||
+				(strings.Contains(errStr, "graphql errors") && strings.Contains(errStr, "FORBIDDEN"))
+			if isForbidden {
+				h.logger.Warn(
+					"permission error fetching timeline",
+					zap.String("github_id", githubID),
+					zap.String("subject_type", notification.SubjectType),
+					zap.String("api_type", apiType),
+					zap.Error(errors.Join(ErrFailedToFetchTimeline, err)),
+				)
+				helpers.WriteError(w, http.StatusForbidden, "permission denied to fetch timeline")
+				return
+			}
+			h.logger.Error(
+				"failed to fetch timeline",
 				zap.String("github_id", githubID),
 				zap.String("subject_type", notification.SubjectType),
 				zap.String("api_type", apiType),
 				zap.Error(errors.Join(ErrFailedToFetchTimeline, err)),
 			)
-			helpers.WriteError(w, http.StatusForbidden, "permission denied to fetch timeline")
+			helpers.WriteError(w, http.StatusInternalServerError, "failed to fetch timeline")
 			return
 		}
-		h.logger.Error(
-			"failed to fetch timeline",
-			zap.String("github_id", githubID),
-			zap.String("subject_type", notification.SubjectType),
-			zap.String("api_type", apiType),
-			zap.Error(errors.Join(ErrFailedToFetchTimeline, err)),
-		)
-		helpers.WriteError(w, http.StatusInternalServerError, "failed to fetch timeline")
-		return
 	}
 
 	// Convert timeline items to API response format
@@ -205,7 +220,7 @@ func convertTimelineItemToThreadItem(item models.TimelineItem) ThreadItem {
 	threadItem := ThreadItem{
 		Type: item.Event,
 		ID:   item.ID,
-		Body: item.Body,
+		Body: sanitize.Markdown(item.Body),
 		Author: ThreadAuthor{
 			Login:     item.AuthorLogin,
 			AvatarURL: item.AuthorAvatarURL,
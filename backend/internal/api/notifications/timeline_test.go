@@ -17,17 +17,80 @@ package notifications
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/core/timeline"
+	"github.com/octobud-hq/octobud/backend/internal/db"
 	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
+func TestHandler_handleGetNotificationTimeline_ServesWarmedPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserID = "test-user-id"
+	handler, mockSvc, _, mockAuthSvc := setupTestHandler(ctrl)
+	mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+	handler = handler.WithPrewarm(mockPrewarm)
+	// GitHub isn't called for a warmed page
+	handler.githubClient = githubmocks.NewMockClient(ctrl)
+	handler.timelineSvc = timeline.NewService(zap.NewNop())
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+
+	notification := db.Notification{
+		GithubID:    "thread-1",
+		SubjectType: "Issue",
+		SubjectURL: sql.NullString{
+			String: "https://api.github.com/repos/octo/repo/issues/7",
+			Valid:  true,
+		},
+		GithubUpdatedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+	}
+	mockSvc.EXPECT().GetByGithubID(gomock.Any(), testUserID, "thread-1").Return(notification, nil)
+	mockPrewarm.EXPECT().
+		TakeTimeline(testUserID, prewarm.TimelineKey(notification, 10, 1)).
+		Return(&models.TimelineResult{
+			Items:   []models.TimelineItem{{Event: "commented", ID: 42, Body: "Looks good"}},
+			Total:   1,
+			Page:    1,
+			PerPage: 10,
+		}, true)
+
+	req := createRequest(http.MethodGet, "/notifications/thread-1/timeline?per_page=10&page=1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("githubID", "thread-1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+
+	handler.handleGetNotificationTimeline(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response TimelineResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Items, 1)
+	require.Equal(t, "Looks good", response.Items[0].Body)
+	require.Equal(t, 1, response.Total)
+}
+
 func TestFetchAndFilterTimelineEvents(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -294,3 +357,12 @@ func TestFetchAndFilterTimelineEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTimelineItemToThreadItem_SanitizesBody(t *testing.T) {
+	item := convertTimelineItemToThreadItem(models.TimelineItem{
+		Event: "commented",
+		ID:    1,
+		Body:  "LGTM <img src=x onerror=alert(1)><script>alert(2)</script> `<script>`",
+	})
+	require.Equal(t, "LGTM  `<script>`", item.Body)
+}
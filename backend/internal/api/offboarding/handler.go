@@ -0,0 +1,147 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+// Package offboarding provides the API for offboarding organizations the user has left.
+package offboarding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/offboarding"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Handler handles org offboarding HTTP routes
+type Handler struct {
+	logger         *zap.Logger
+	offboardingSvc offboarding.OffboardingService
+	authSvc        authsvc.AuthService
+}
+
+// New creates a new org offboarding handler
+func New(
+	logger *zap.Logger,
+	offboardingSvc offboarding.OffboardingService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:         logger,
+		offboardingSvc: offboardingSvc,
+		authSvc:        authSvc,
+	}
+}
+
+// offboardRequest names the org to offboard and what to do with its notifications
+type offboardRequest struct {
+	Org    string                `json:"org"`
+	Action models.OffboardAction `json:"action,omitempty"`
+}
+
+// listResponse wraps the offboarded orgs
+type listResponse struct {
+	Offboardings []models.OrgOffboarding `json:"offboardings"`
+}
+
+// Register registers org offboarding routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/offboarding/orgs", func(r chi.Router) {
+		r.Get("/", h.handleList)
+		r.Post("/", h.handleOffboard)
+		r.Delete("/{org}", h.handleRejoin)
+	})
+}
+
+// handleList returns the orgs the user offboarded, most recent first
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	offboardings, err := h.offboardingSvc.ListOffboardings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to list offboarded orgs", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list offboarded orgs")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Offboardings: offboardings})
+}
+
+// handleOffboard archives or deletes an org's notifications and stops syncing it.
+// The action defaults to archive.
+func (h *Handler) handleOffboard(w http.ResponseWriter, r *http.Request) {
+	var req offboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode offboard request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Action == "" {
+		req.Action = models.OffboardArchive
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	summary, err := h.offboardingSvc.OffboardOrg(ctx, userID, req.Org, req.Action)
+	if err != nil {
+		switch {
+		case errors.Is(err, offboarding.ErrInvalidOrg):
+			helpers.WriteError(w, http.StatusBadRequest, "org must be a GitHub organization name")
+		case errors.Is(err, offboarding.ErrInvalidAction):
+			helpers.WriteError(w, http.StatusBadRequest, "action must be one of archive, delete")
+		default:
+			h.logger.Error("failed to offboard org", zap.String("org", req.Org), zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to offboard org")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, summary)
+}
+
+// handleRejoin syncs an offboarded org again
+func (h *Handler) handleRejoin(w http.ResponseWriter, r *http.Request) {
+	org := chi.URLParam(r, "org")
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	if err := h.offboardingSvc.RejoinOrg(ctx, userID, org); err != nil {
+		if errors.Is(err, offboarding.ErrOrgNotOffboarded) {
+			helpers.WriteError(w, http.StatusNotFound, "Org is not offboarded")
+			return
+		}
+		h.logger.Error("failed to rejoin org", zap.String("org", org), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to rejoin org")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
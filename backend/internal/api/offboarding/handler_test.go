@@ -0,0 +1,179 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package offboarding
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/offboarding"
+	offboardingmocks "github.com/octobud-hq/octobud/backend/internal/core/offboarding/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*offboardingmocks.MockOffboardingService),
+	method, path, body string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := offboardingmocks.NewMockOffboardingService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reqBody)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleList(t *testing.T) {
+	t.Run("returns offboardings", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				ListOffboardings(gomock.Any(), testUserID).
+				Return([]models.OrgOffboarding{{
+					Org:                   "acme",
+					Action:                models.OffboardArchive,
+					NotificationsAffected: 4,
+					Repositories:          2,
+					OffboardedAt:          time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+				}}, nil)
+		}, http.MethodGet, "/offboarding/orgs", "")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response listResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Offboardings, 1)
+		require.Equal(t, "acme", response.Offboardings[0].Org)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				ListOffboardings(gomock.Any(), testUserID).
+				Return(nil, errors.New("database error"))
+		}, http.MethodGet, "/offboarding/orgs", "")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_handleOffboard(t *testing.T) {
+	t.Run("defaults to archive", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				OffboardOrg(gomock.Any(), testUserID, "acme", models.OffboardArchive).
+				Return(models.OrgOffboarding{
+					Org:                   "acme",
+					Action:                models.OffboardArchive,
+					NotificationsAffected: 4,
+				}, nil)
+		}, http.MethodPost, "/offboarding/orgs", `{"org":"acme"}`)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var summary models.OrgOffboarding
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+		require.Equal(t, int64(4), summary.NotificationsAffected)
+	})
+
+	t.Run("passes the action through", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				OffboardOrg(gomock.Any(), testUserID, "acme", models.OffboardDelete).
+				Return(models.OrgOffboarding{Org: "acme", Action: models.OffboardDelete}, nil)
+		}, http.MethodPost, "/offboarding/orgs", `{"org":"acme","action":"delete"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid body returns 400", func(t *testing.T) {
+		w := serve(t, nil, http.MethodPost, "/offboarding/orgs", `{`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid org returns 400", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				OffboardOrg(gomock.Any(), testUserID, "not/an/org", models.OffboardArchive).
+				Return(models.OrgOffboarding{}, offboarding.ErrInvalidOrg)
+		}, http.MethodPost, "/offboarding/orgs", `{"org":"not/an/org"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid action returns 400", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				OffboardOrg(gomock.Any(), testUserID, "acme", models.OffboardAction("mute")).
+				Return(models.OrgOffboarding{}, offboarding.ErrInvalidAction)
+		}, http.MethodPost, "/offboarding/orgs", `{"org":"acme","action":"mute"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				OffboardOrg(gomock.Any(), testUserID, "acme", models.OffboardArchive).
+				Return(models.OrgOffboarding{}, errors.New("database error"))
+		}, http.MethodPost, "/offboarding/orgs", `{"org":"acme"}`)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_handleRejoin(t *testing.T) {
+	t.Run("rejoins the org", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().RejoinOrg(gomock.Any(), testUserID, "acme").Return(nil)
+		}, http.MethodDelete, "/offboarding/orgs/acme", "")
+		require.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("unknown org returns 404", func(t *testing.T) {
+		w := serve(t, func(m *offboardingmocks.MockOffboardingService) {
+			m.EXPECT().
+				RejoinOrg(gomock.Any(), testUserID, "acme").
+				Return(offboarding.ErrOrgNotOffboarded)
+		}, http.MethodDelete, "/offboarding/orgs/acme", "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
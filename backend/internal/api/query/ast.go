@@ -0,0 +1,77 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import "github.com/octobud-hq/octobud/backend/internal/query/parse"
+
+// AST node types
+const (
+	nodeTypeBinary = "binary" // AND/OR with left and right
+	nodeTypeNot    = "not"    // negation of expr
+	nodeTypeGroup  = "group"  // parenthesized expr
+	nodeTypeTerm   = "term"   // field:value1,value2
+	nodeTypeText   = "text"   // free text
+)
+
+// astNode is the JSON form of a parse.Node. Start and End are byte offsets into the
+// query, with End exclusive.
+type astNode struct {
+	Type   string   `json:"type"`
+	Op     string   `json:"op,omitempty"`
+	Left   *astNode `json:"left,omitempty"`
+	Right  *astNode `json:"right,omitempty"`
+	Expr   *astNode `json:"expr,omitempty"`
+	Field  string   `json:"field,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Start  int      `json:"start"`
+	End    int      `json:"end"`
+}
+
+// toASTNode converts a parsed node to its JSON form
+func toASTNode(node parse.Node) *astNode {
+	if node == nil {
+		return nil
+	}
+
+	span := parse.SpanOf(node)
+	result := &astNode{Start: span.Start, End: span.End}
+	switch n := node.(type) {
+	case *parse.BinaryExpr:
+		result.Type = nodeTypeBinary
+		result.Op = n.Op
+		result.Left = toASTNode(n.Left)
+		result.Right = toASTNode(n.Right)
+	case *parse.NotExpr:
+		result.Type = nodeTypeNot
+		result.Expr = toASTNode(n.Expr)
+	case *parse.ParenExpr:
+		result.Type = nodeTypeGroup
+		result.Expr = toASTNode(n.Expr)
+	case *parse.Term:
+		result.Type = nodeTypeTerm
+		result.Field = n.Field
+		result.Values = n.Values
+		if n.Negated {
+			// Negated terms aren't produced by the parser; keep the tree uniform anyway
+			return &astNode{Type: nodeTypeNot, Expr: result, Start: span.Start, End: span.End}
+		}
+	case *parse.FreeText:
+		result.Type = nodeTypeText
+		result.Text = n.Text
+	}
+	return result
+}
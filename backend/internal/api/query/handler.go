@@ -0,0 +1,142 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package query provides the handler for query language routes.
+package query
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/suggest"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	querycore "github.com/octobud-hq/octobud/backend/internal/query"
+	"github.com/octobud-hq/octobud/backend/internal/query/parse"
+)
+
+// Handler handles query language HTTP routes
+type Handler struct {
+	logger      *zap.Logger
+	suggestions suggest.SuggestService
+	authSvc     authsvc.AuthService
+}
+
+// New creates a new query handler
+func New(logger *zap.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// WithSuggestions enables query autocomplete from the user's local data
+func (h *Handler) WithSuggestions(
+	suggestions suggest.SuggestService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	h.suggestions = suggestions
+	h.authSvc = authSvc
+	return h
+}
+
+// Register registers query routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/query", func(r chi.Router) {
+		r.Post("/parse", h.handleParse)
+		if h.suggestions != nil {
+			r.Post("/suggest", h.handleSuggest)
+		}
+	})
+}
+
+type parseRequest struct {
+	Query string `json:"query"`
+}
+
+type parseResponse struct {
+	Query string `json:"query"`
+	// Canonical is the query as the backend would write it; it parses to the same AST
+	Canonical string   `json:"canonical"`
+	AST       *astNode `json:"ast"`
+	// Defaults are the implicit filters applied when the query lists notifications
+	Defaults models.QueryDefaults `json:"defaults"`
+}
+
+// handleParse parses and validates a query, returning its AST. An empty query has a null AST.
+func (h *Handler) handleParse(w http.ResponseWriter, r *http.Request) {
+	var req parseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	node, err := querycore.ParseAndValidate(req.Query)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defaults, err := querycore.ExplainDefaults(req.Query)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, parseResponse{
+		Query:     req.Query,
+		Canonical: parse.Format(node),
+		AST:       toASTNode(node),
+		Defaults:  defaults,
+	})
+}
+
+type suggestRequest struct {
+	Query string `json:"query"`
+	// Cursor is a 0-based byte offset into the query; it defaults to the end
+	Cursor *int `json:"cursor"`
+}
+
+// handleSuggest returns completions for the field name or value being typed at the cursor.
+// The query may be incomplete, e.g. `repo:cli/` or `author:"oct`.
+func (h *Handler) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	var req suggestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cursor := len(req.Query)
+	if req.Cursor != nil {
+		cursor = *req.Cursor
+	}
+	if cursor < 0 || cursor > len(req.Query) {
+		helpers.WriteError(w, http.StatusBadRequest, "cursor must be within the query")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.suggestions.Suggest(ctx, userID, req.Query, cursor)
+	if err != nil {
+		h.logger.Error("failed to suggest query completions", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load suggestions")
+		return
+	}
+	helpers.WriteJSON(w, http.StatusOK, suggestions)
+}
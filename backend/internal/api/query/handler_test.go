@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	suggestmocks "github.com/octobud-hq/octobud/backend/internal/core/suggest/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func serve(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := chi.NewRouter()
+	New(zap.NewNop()).Register(router)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/query/parse", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleParse(t *testing.T) {
+	w := serve(t, `{"query": "repo:cli,go  (is:unread OR NOT \"wip\")"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp parseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, `repo:cli,go  (is:unread OR NOT "wip")`, resp.Query)
+	require.Equal(t, "repo:cli,go AND (is:unread OR NOT wip)", resp.Canonical)
+
+	expected := &astNode{
+		Type: nodeTypeBinary, Op: "AND", Start: 0, End: 37,
+		Left: &astNode{
+			Type: nodeTypeTerm, Field: "repo", Values: []string{"cli", "go"}, Start: 0, End: 11,
+		},
+		Right: &astNode{
+			Type: nodeTypeGroup, Start: 13, End: 37,
+			Expr: &astNode{
+				Type: nodeTypeBinary, Op: "OR", Start: 14, End: 36,
+				Left: &astNode{
+					Type: nodeTypeTerm, Field: "is", Values: []string{"unread"}, Start: 14, End: 23,
+				},
+				Right: &astNode{
+					Type: nodeTypeNot, Start: 27, End: 36,
+					Expr: &astNode{Type: nodeTypeText, Text: "wip", Start: 31, End: 36},
+				},
+			},
+		},
+	}
+	require.Equal(t, expected, resp.AST)
+	require.Equal(t, models.QueryDefaultScopeMutedOnly, resp.Defaults.Scope)
+	require.Equal(t, []string{"muted"}, resp.Defaults.Excluded)
+}
+
+func TestHandler_handleParse_Empty(t *testing.T) {
+	w := serve(t, `{"query": ""}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"query": "", "canonical": "", "ast": null, "defaults": {
+		"scope": "inbox",
+		"excluded": ["archived", "snoozed", "muted", "filtered"],
+		"reason": "an empty query shows the inbox"
+	}}`, w.Body.String())
+}
+
+func TestHandler_handleParse_NegatedInKeepsMutedDefault(t *testing.T) {
+	w := serve(t, `{"query": "-in:archive"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp parseResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, models.QueryDefaultScopeMutedOnly, resp.Defaults.Scope)
+}
+
+func TestHandler_handleParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{"invalid body", `{`, "invalid request body"},
+		{"syntax error", `{"query": "(is:unread"}`, "expected closing parenthesis"},
+		{"unknown field", `{"query": "color:blue"}`, "validation failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, tt.body)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), tt.message)
+		})
+	}
+}
+
+func serveSuggest(
+	t *testing.T,
+	body string,
+	setupMock func(*suggestmocks.MockSuggestService),
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := suggestmocks.NewMockSuggestService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "user"}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop()).WithSuggestions(mockSvc, mockAuthSvc).Register(router)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/query/suggest", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleSuggest(t *testing.T) {
+	suggestions := models.QuerySuggestions{
+		Field:  "repo",
+		Prefix: "cl",
+		Start:  5,
+		End:    7,
+		Suggestions: []models.QuerySuggestion{
+			{Kind: models.QuerySuggestionValue, Value: "cli/cli"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*suggestmocks.MockSuggestService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "cursor defaults to the end of the query",
+			body: `{"query": "repo:cl"}`,
+			setupMock: func(m *suggestmocks.MockSuggestService) {
+				m.EXPECT().Suggest(gomock.Any(), "user", "repo:cl", 7).Return(suggestions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"field": "repo", "prefix": "cl", "start": 5, "end": 7,
+				"suggestions": [{"kind": "value", "value": "cli/cli"}]}`,
+		},
+		{
+			name: "cursor inside the query",
+			body: `{"query": "repo:cl is:unread", "cursor": 7}`,
+			setupMock: func(m *suggestmocks.MockSuggestService) {
+				m.EXPECT().
+					Suggest(gomock.Any(), "user", "repo:cl is:unread", 7).
+					Return(suggestions, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "cursor past the end returns 400",
+			body:           `{"query": "repo:", "cursor": 6}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "cursor must be within the query"}`,
+		},
+		{
+			name: "service error returns 500",
+			body: `{"query": "author:"}`,
+			setupMock: func(m *suggestmocks.MockSuggestService) {
+				m.EXPECT().
+					Suggest(gomock.Any(), "user", "author:", 7).
+					Return(models.QuerySuggestions{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveSuggest(t, tt.body, tt.setupMock)
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_SuggestRequiresSuggestions(t *testing.T) {
+	router := chi.NewRouter()
+	New(zap.NewNop()).Register(router)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/query/suggest", strings.NewReader(`{}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
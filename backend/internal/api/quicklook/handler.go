@@ -0,0 +1,145 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package quicklook provides the quick look API for launcher plugins.
+package quicklook
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/quicklook"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadSummary         = errors.New("failed to load quick look summary")
+	ErrFailedToArchiveNotification = errors.New("failed to archive notification")
+)
+
+// Handler handles quick look HTTP routes. These routes are a stable contract for
+// third-party launcher plugins; see docs/guides/quick-look-api.md before changing them.
+type Handler struct {
+	logger       *zap.Logger
+	quickLookSvc quicklook.QuickLookService
+	authSvc      authsvc.AuthService
+}
+
+// New creates a new quick look handler
+func New(
+	logger *zap.Logger,
+	quickLookSvc quicklook.QuickLookService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:       logger,
+		quickLookSvc: quickLookSvc,
+		authSvc:      authSvc,
+	}
+}
+
+// Register registers quick look routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/quick", func(r chi.Router) {
+		r.Get("/unread-count", h.handleUnreadCount)
+		r.Get("/inbox", h.handleInbox)
+		r.Post("/{githubID}/archive", h.handleArchive)
+	})
+}
+
+type unreadCountResponse struct {
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+// handleUnreadCount returns the inbox unread count
+func (h *Handler) handleUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	summary, err := h.quickLookSvc.Summary(ctx, userID)
+	if err != nil {
+		h.logger.Error(
+			"failed to load quick look summary",
+			zap.Error(errors.Join(ErrFailedToLoadSummary, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load unread count")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, unreadCountResponse{UnreadCount: summary.UnreadCount})
+}
+
+// handleInbox returns the inbox unread count and newest inbox items
+func (h *Handler) handleInbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	summary, err := h.quickLookSvc.Summary(ctx, userID)
+	if err != nil {
+		h.logger.Error(
+			"failed to load quick look summary",
+			zap.Error(errors.Join(ErrFailedToLoadSummary, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load inbox")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, summary)
+}
+
+// handleArchive archives a notification and returns the refreshed inbox summary
+func (h *Handler) handleArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	githubID, err := url.PathUnescape(chi.URLParam(r, "githubID"))
+	if err != nil || githubID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid githubID")
+		return
+	}
+
+	summary, err := h.quickLookSvc.Archive(ctx, userID, githubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			helpers.WriteError(w, http.StatusNotFound, "Notification not found")
+			return
+		}
+		h.logger.Error(
+			"failed to archive notification",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToArchiveNotification, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to archive notification")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, summary)
+}
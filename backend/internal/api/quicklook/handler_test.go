@@ -0,0 +1,133 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package quicklook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	quicklookmocks "github.com/octobud-hq/octobud/backend/internal/core/quicklook/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*quicklookmocks.MockQuickLookService),
+	method, path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := quicklookmocks.NewMockQuickLookService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(method, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleUnreadCount(t *testing.T) {
+	w := serve(t, func(m *quicklookmocks.MockQuickLookService) {
+		m.EXPECT().
+			Summary(gomock.Any(), testUserID).
+			Return(models.QuickLookSummary{UnreadCount: 4}, nil)
+	}, http.MethodGet, "/quick/unread-count")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"unreadCount":4}`, w.Body.String())
+}
+
+func TestHandler_handleInbox(t *testing.T) {
+	w := serve(t, func(m *quicklookmocks.MockQuickLookService) {
+		m.EXPECT().
+			Summary(gomock.Any(), testUserID).
+			Return(models.QuickLookSummary{
+				UnreadCount: 1,
+				Items: []models.QuickLookItem{
+					{GithubID: "n1", Title: "Fix the thing", Repository: "octo/repo"},
+				},
+			}, nil)
+	}, http.MethodGet, "/quick/inbox")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response models.QuickLookSummary
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, int64(1), response.UnreadCount)
+	require.Len(t, response.Items, 1)
+	require.Equal(t, "octo/repo", response.Items[0].Repository)
+}
+
+func TestHandler_handleInbox_Error(t *testing.T) {
+	w := serve(t, func(m *quicklookmocks.MockQuickLookService) {
+		m.EXPECT().
+			Summary(gomock.Any(), testUserID).
+			Return(models.QuickLookSummary{}, errors.New("database error"))
+	}, http.MethodGet, "/quick/inbox")
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHandler_handleArchive(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success returns refreshed summary", expectedStatus: http.StatusOK},
+		{name: "not found", err: sql.ErrNoRows, expectedStatus: http.StatusNotFound},
+		{
+			name:           "service error",
+			err:            errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, func(m *quicklookmocks.MockQuickLookService) {
+				m.EXPECT().
+					Archive(gomock.Any(), testUserID, "thread/1").
+					Return(models.QuickLookSummary{}, tt.err)
+			}, http.MethodPost, "/quick/thread%2F1/archive")
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package ratelimit serves the GitHub API rate limit budget that background syncing spends.
+package ratelimit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+)
+
+// Handler handles rate limit routes
+type Handler struct {
+	logger *zap.Logger
+	client githubinterfaces.Client
+	now    func() time.Time
+}
+
+// New creates a new rate limit handler
+func New(logger *zap.Logger, client githubinterfaces.Client, now func() time.Time) *Handler {
+	return &Handler{
+		logger: logger,
+		client: client,
+		now:    now,
+	}
+}
+
+// Register registers rate limit routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/sync/rate-limit", h.handleGetRateLimit)
+}
+
+// rateLimitResponse is the core REST API budget as of GitHub's latest response. Known is
+// false until a response has reported it.
+type rateLimitResponse struct {
+	Known     bool       `json:"known"`
+	Limit     int        `json:"limit"`
+	Remaining int        `json:"remaining"`
+	Used      int        `json:"used"`
+	ResetAt   *time.Time `json:"resetAt,omitempty"`
+	// Reserve is the budget left for syncs and the user's own actions. Below it,
+	// enrichment and backfills are throttled.
+	Reserve   int  `json:"reserve"`
+	Throttled bool `json:"throttled"`
+}
+
+func (h *Handler) handleGetRateLimit(w http.ResponseWriter, _ *http.Request) {
+	response := rateLimitResponse{Reserve: jobs.RateLimitReserve}
+	limit, ok := h.client.RateLimit()
+	if ok {
+		resetAt := limit.Reset
+		response.Known = true
+		response.Limit = limit.Limit
+		response.Remaining = limit.Remaining
+		response.Used = limit.Used
+		response.ResetAt = &resetAt
+		response.Throttled = jobs.ThrottleDelay(limit, h.now()) > 0
+	}
+	helpers.WriteJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+)
+
+func TestHandler_handleGetRateLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(30 * time.Minute)
+
+	tests := []struct {
+		name     string
+		limit    types.RateLimit
+		known    bool
+		expected rateLimitResponse
+	}{
+		{
+			name:     "not known yet",
+			expected: rateLimitResponse{Reserve: jobs.RateLimitReserve},
+		},
+		{
+			name:  "plenty left",
+			limit: types.RateLimit{Limit: 5000, Remaining: 4800, Used: 200, Reset: reset},
+			known: true,
+			expected: rateLimitResponse{
+				Known:     true,
+				Limit:     5000,
+				Remaining: 4800,
+				Used:      200,
+				ResetAt:   &reset,
+				Reserve:   jobs.RateLimitReserve,
+			},
+		},
+		{
+			name:  "below reserve is throttled",
+			limit: types.RateLimit{Limit: 5000, Remaining: 100, Used: 4900, Reset: reset},
+			known: true,
+			expected: rateLimitResponse{
+				Known:     true,
+				Limit:     5000,
+				Remaining: 100,
+				Used:      4900,
+				ResetAt:   &reset,
+				Reserve:   jobs.RateLimitReserve,
+				Throttled: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := githubmocks.NewMockClient(ctrl)
+			client.EXPECT().RateLimit().Return(tt.limit, tt.known)
+
+			router := chi.NewRouter()
+			New(zap.NewNop(), client, func() time.Time { return now }).Register(router)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sync/rate-limit", nil))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var response rateLimitResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, tt.expected, response)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package repositories
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -31,6 +32,7 @@ import (
 // Error definitions
 var (
 	ErrFailedToLoadRepositories = errors.New("failed to load repositories")
+	ErrFailedToLoadRepository   = errors.New("failed to load repository")
 )
 
 // Handler handles repository-related HTTP routes
@@ -56,6 +58,9 @@ func New(
 // Register registers repository routes on the provided router
 func (h *Handler) Register(r chi.Router) {
 	r.Get("/repositories", h.handleListRepositories)
+	r.Get("/repositories/{id}", h.handleGetRepository)
+	r.Get("/repositories/{id}/settings", h.handleGetRepositorySettings)
+	r.Put("/repositories/{id}/settings", h.handleUpdateRepositorySettings)
 }
 
 func (h *Handler) handleListRepositories(w http.ResponseWriter, r *http.Request) {
@@ -66,7 +71,7 @@ func (h *Handler) handleListRepositories(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	repos, err := h.repositorySvc.ListRepositories(ctx, userID)
+	repos, err := h.repositorySvc.ListRepositoriesWithDigests(ctx, userID)
 	if err != nil {
 		h.logger.Error(
 			"failed to load repositories",
@@ -78,3 +83,35 @@ func (h *Handler) handleListRepositories(w http.ResponseWriter, r *http.Request)
 
 	helpers.WriteJSON(w, http.StatusOK, listRepositoriesResponse{Repositories: repos})
 }
+
+func (h *Handler) handleGetRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid repository id")
+		return
+	}
+
+	repo, err := h.repositorySvc.GetRepository(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRepositoryNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+		h.logger.Error(
+			"failed to load repository",
+			zap.Int64("repositoryID", id),
+			zap.Error(errors.Join(ErrFailedToLoadRepository, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load repository")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, repositoryEnvelope{Repository: repo})
+}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
 	"github.com/octobud-hq/octobud/backend/internal/core/repository/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
@@ -64,7 +65,9 @@ func TestHandler_handleListRepositories(t *testing.T) {
 						FullName: "owner/another-repo",
 					},
 				}
-				m.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return(repos, nil)
+				m.EXPECT().
+					ListRepositoriesWithDigests(gomock.Any(), "test-user-id").
+					Return(repos, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -80,7 +83,7 @@ func TestHandler_handleListRepositories(t *testing.T) {
 			name: "success returns empty list when no repositories",
 			setupMock: func(m *mocks.MockRepositoryService) {
 				m.EXPECT().
-					ListRepositories(gomock.Any(), "test-user-id").
+					ListRepositoriesWithDigests(gomock.Any(), "test-user-id").
 					Return([]models.Repository{}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -95,7 +98,7 @@ func TestHandler_handleListRepositories(t *testing.T) {
 			name: "error returns 500 when service fails",
 			setupMock: func(m *mocks.MockRepositoryService) {
 				m.EXPECT().
-					ListRepositories(gomock.Any(), "test-user-id").
+					ListRepositoriesWithDigests(gomock.Any(), "test-user-id").
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -135,3 +138,98 @@ func TestHandler_handleListRepositories(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_handleGetRepository(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*mocks.MockRepositoryService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success returns repository with digest",
+			path: "/repositories/1",
+			setupMock: func(m *mocks.MockRepositoryService) {
+				m.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{
+						ID:       1,
+						Name:     "test-repo",
+						FullName: "owner/test-repo",
+						Digest: &models.RepositoryDigest{
+							UnreadCount:  2,
+							ReasonCounts: map[string]int64{"mention": 2},
+							RecentUnread: []models.RepositoryDigestItem{
+								{GithubID: "n1", Title: "Fix the build"},
+							},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response repositoryEnvelope
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "owner/test-repo", response.Repository.FullName)
+				require.NotNil(t, response.Repository.Digest)
+				require.Equal(t, int64(2), response.Repository.Digest.UnreadCount)
+				require.Equal(t, int64(2), response.Repository.Digest.ReasonCounts["mention"])
+				require.Len(t, response.Repository.Digest.RecentUnread, 1)
+			},
+		},
+		{
+			name:           "invalid id returns 400",
+			path:           "/repositories/abc",
+			setupMock:      func(_ *mocks.MockRepositoryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing repository returns 404",
+			path: "/repositories/42",
+			setupMock: func(m *mocks.MockRepositoryService) {
+				m.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(42)).
+					Return(models.Repository{}, repository.ErrRepositoryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "service error returns 500",
+			path: "/repositories/1",
+			setupMock: func(m *mocks.MockRepositoryService) {
+				m.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			tt.setupMock(mockSvc)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			router := chi.NewRouter()
+			handler.Register(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
@@ -26,3 +26,8 @@ type RepositoryResponse = models.Repository
 type listRepositoriesResponse struct {
 	Repositories []RepositoryResponse `json:"repositories"`
 }
+
+// repositoryEnvelope is the response type for a single repository
+type repositoryEnvelope struct {
+	Repository RepositoryResponse `json:"repository"`
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadRepositorySettings   = errors.New("failed to load repository settings")
+	ErrFailedToUpdateRepositorySettings = errors.New("failed to update repository settings")
+)
+
+// repositorySettingsRequest is the request body for updating a repository's settings. It
+// replaces the saved settings, and a null field falls back to the user's default again.
+type repositorySettingsRequest struct {
+	DefaultSnooze               *string `json:"defaultSnooze"`
+	ResurfaceSuppressionMinutes *int64  `json:"resurfaceSuppressionMinutes"`
+	SyncMode                    string  `json:"syncMode"` // Empty or null syncs the repository in full
+}
+
+// repositorySettingsEnvelope is the response type for a repository's settings
+type repositorySettingsEnvelope struct {
+	Settings models.RepositorySettings `json:"settings"`
+}
+
+func (h *Handler) handleGetRepositorySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	id, ok := h.requireRepository(w, r, userID)
+	if !ok {
+		return
+	}
+
+	settings, err := h.repositorySvc.GetRepositorySettings(ctx, userID, id)
+	if err != nil {
+		h.logger.Error(
+			"failed to load repository settings",
+			zap.Int64("repositoryID", id),
+			zap.Error(errors.Join(ErrFailedToLoadRepositorySettings, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load repository settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, repositorySettingsEnvelope{Settings: settings})
+}
+
+func (h *Handler) handleUpdateRepositorySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req repositorySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	id, ok := h.requireRepository(w, r, userID)
+	if !ok {
+		return
+	}
+
+	settings, err := h.repositorySvc.UpdateRepositorySettings(ctx, userID, models.RepositorySettings{
+		RepositoryID:                id,
+		DefaultSnooze:               req.DefaultSnooze,
+		ResurfaceSuppressionMinutes: req.ResurfaceSuppressionMinutes,
+		SyncMode:                    req.SyncMode,
+	})
+	if err != nil {
+		if errors.Is(err, snooze.ErrInvalidSnooze) ||
+			errors.Is(err, repository.ErrInvalidResurfaceSuppression) ||
+			errors.Is(err, repository.ErrInvalidSyncMode) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error(
+			"failed to update repository settings",
+			zap.Int64("repositoryID", id),
+			zap.Error(errors.Join(ErrFailedToUpdateRepositorySettings, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to update repository settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, repositorySettingsEnvelope{Settings: settings})
+}
+
+// requireRepository parses the repository ID from the URL and checks that the repository
+// exists, writing an error response if it doesn't
+func (h *Handler) requireRepository(
+	w http.ResponseWriter,
+	r *http.Request,
+	userID string,
+) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid repository id")
+		return 0, false
+	}
+
+	if _, err := h.repositorySvc.GetRepository(r.Context(), userID, id); err != nil {
+		if errors.Is(err, repository.ErrRepositoryNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "repository not found")
+			return 0, false
+		}
+		h.logger.Error(
+			"failed to load repository",
+			zap.Int64("repositoryID", id),
+			zap.Error(errors.Join(ErrFailedToLoadRepository, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load repository")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package repositories
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository"
+	"github.com/octobud-hq/octobud/backend/internal/core/repository/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_RepositorySettings(t *testing.T) {
+	weekly := "1w"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setupMocks     func(*mocks.MockRepositoryService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "get returns settings",
+			method: http.MethodGet,
+			path:   "/repositories/1/settings",
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.RepositorySettings{
+						RepositoryID:  1,
+						DefaultSnooze: &weekly,
+						SyncMode:      models.RepositorySyncFull,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.JSONEq(
+					t,
+					`{"settings":{"repositoryId":1,"defaultSnooze":"1w","resurfaceSuppressionMinutes":null,`+
+						`"syncMode":"full"}}`,
+					w.Body.String(),
+				)
+			},
+		},
+		{
+			name:   "put saves default snooze",
+			method: http.MethodPut,
+			path:   "/repositories/1/settings",
+			body:   `{"defaultSnooze":"1w"}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					UpdateRepositorySettings(gomock.Any(), "test-user-id", models.RepositorySettings{
+						RepositoryID:  1,
+						DefaultSnooze: &weekly,
+					}).
+					Return(models.RepositorySettings{RepositoryID: 1, DefaultSnooze: &weekly}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response repositorySettingsEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "1w", *response.Settings.DefaultSnooze)
+			},
+		},
+		{
+			name:   "invalid default snooze returns 400",
+			method: http.MethodPut,
+			path:   "/repositories/1/settings",
+			body:   `{"defaultSnooze":"forever"}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					UpdateRepositorySettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.RepositorySettings{}, snooze.ErrInvalidSnooze)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid resurface suppression returns 400",
+			method: http.MethodPut,
+			path:   "/repositories/1/settings",
+			body:   `{"resurfaceSuppressionMinutes":-5}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					UpdateRepositorySettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.RepositorySettings{}, repository.ErrInvalidResurfaceSuppression)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "put saves sync mode",
+			method: http.MethodPut,
+			path:   "/repositories/1/settings",
+			body:   `{"syncMode":"excluded"}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					UpdateRepositorySettings(gomock.Any(), "test-user-id", models.RepositorySettings{
+						RepositoryID: 1,
+						SyncMode:     models.RepositorySyncExcluded,
+					}).
+					Return(models.RepositorySettings{RepositoryID: 1, SyncMode: models.RepositorySyncExcluded}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response repositorySettingsEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, models.RepositorySyncExcluded, response.Settings.SyncMode)
+			},
+		},
+		{
+			name:   "invalid sync mode returns 400",
+			method: http.MethodPut,
+			path:   "/repositories/1/settings",
+			body:   `{"syncMode":"sometimes"}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(1)).
+					Return(models.Repository{ID: 1}, nil)
+				repos.EXPECT().
+					UpdateRepositorySettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.RepositorySettings{}, repository.ErrInvalidSyncMode)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "missing repository returns 404",
+			method: http.MethodPut,
+			path:   "/repositories/42/settings",
+			body:   `{"defaultSnooze":null}`,
+			setupMocks: func(repos *mocks.MockRepositoryService) {
+				repos.EXPECT().
+					GetRepository(gomock.Any(), "test-user-id", int64(42)).
+					Return(models.Repository{}, repository.ErrRepositoryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			tt.setupMocks(mockSvc)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			router := chi.NewRouter()
+			handler.Register(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
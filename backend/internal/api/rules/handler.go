@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -70,9 +71,11 @@ func (h *Handler) Register(r chi.Router) {
 		r.Get("/", h.handleListRules)
 		r.Post("/", h.handleCreateRule)
 		r.Post("/reorder", h.handleReorderRules)
+		r.Get("/activity", h.handleListActivity)
 		r.Get("/{id}", h.handleGetRule)
 		r.Put("/{id}", h.handleUpdateRule)
 		r.Delete("/{id}", h.handleDeleteRule)
+		r.Post("/{id}/run", h.handleRunRule)
 	})
 }
 
@@ -83,16 +86,22 @@ type createRuleRequest struct {
 	ViewID          *string     `json:"viewId,omitempty"`
 	Actions         RuleActions `json:"actions"`
 	Enabled         *bool       `json:"enabled"`
+	Priority        int         `json:"priority,omitempty"`
+	StopProcessing  bool        `json:"stopProcessing,omitempty"`
+	Condition       *string     `json:"condition,omitempty"`
 	ApplyToExisting bool        `json:"applyToExisting,omitempty"`
 }
 
 type updateRuleRequest struct {
-	Name        *string      `json:"name"`
-	Description *string      `json:"description"`
-	Query       *string      `json:"query"`
-	ViewID      *string      `json:"viewId,omitempty"`
-	Actions     *RuleActions `json:"actions"`
-	Enabled     *bool        `json:"enabled"`
+	Name           *string      `json:"name"`
+	Description    *string      `json:"description"`
+	Query          *string      `json:"query"`
+	ViewID         *string      `json:"viewId,omitempty"`
+	Actions        *RuleActions `json:"actions"`
+	Enabled        *bool        `json:"enabled"`
+	Priority       *int         `json:"priority,omitempty"`
+	StopProcessing *bool        `json:"stopProcessing,omitempty"`
+	Condition      *string      `json:"condition,omitempty"`
 }
 
 type reorderRulesRequest struct {
@@ -170,6 +179,9 @@ func (h *Handler) handleCreateRule(w http.ResponseWriter, r *http.Request) {
 		ViewID:          req.ViewID,
 		Actions:         req.Actions,
 		Enabled:         req.Enabled,
+		Priority:        req.Priority,
+		StopProcessing:  req.StopProcessing,
+		Condition:       req.Condition,
 		ApplyToExisting: req.ApplyToExisting,
 	}
 
@@ -188,7 +200,12 @@ func (h *Handler) handleCreateRule(w http.ResponseWriter, r *http.Request) {
 			errors.Is(err, rulescore.ErrQueryOrViewIDRequired) ||
 			errors.Is(err, rulescore.ErrQueryAndViewIDMutuallyExclusive) ||
 			errors.Is(err, rulescore.ErrQueryCannotBeEmpty) ||
-			errors.Is(err, rulescore.ErrInvalidViewID) {
+			errors.Is(err, rulescore.ErrInvalidViewID) ||
+			errors.Is(err, rulescore.ErrInvalidAlert) ||
+			errors.Is(err, rulescore.ErrInvalidTagSlug) ||
+			errors.Is(err, rulescore.ErrUnknownIntegration) ||
+			errors.Is(err, rulescore.ErrInvalidPriority) ||
+			errors.Is(err, rulescore.ErrInvalidCondition) {
 			helpers.WriteError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -234,11 +251,14 @@ func (h *Handler) handleUpdateRule(w http.ResponseWriter, r *http.Request) {
 
 	// Convert request to service params
 	updateParams := models.UpdateRuleParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Query:       req.Query,
-		ViewID:      req.ViewID,
-		Enabled:     req.Enabled,
+		Name:           req.Name,
+		Description:    req.Description,
+		Query:          req.Query,
+		ViewID:         req.ViewID,
+		Enabled:        req.Enabled,
+		Priority:       req.Priority,
+		StopProcessing: req.StopProcessing,
+		Condition:      req.Condition,
 	}
 	if req.Actions != nil {
 		actions := *req.Actions
@@ -262,7 +282,12 @@ func (h *Handler) handleUpdateRule(w http.ResponseWriter, r *http.Request) {
 		// Check for validation errors
 		if errors.Is(err, rulescore.ErrNameCannotBeEmpty) ||
 			errors.Is(err, rulescore.ErrQueryCannotBeEmpty) ||
-			errors.Is(err, rulescore.ErrInvalidViewID) {
+			errors.Is(err, rulescore.ErrInvalidViewID) ||
+			errors.Is(err, rulescore.ErrInvalidAlert) ||
+			errors.Is(err, rulescore.ErrInvalidTagSlug) ||
+			errors.Is(err, rulescore.ErrUnknownIntegration) ||
+			errors.Is(err, rulescore.ErrInvalidPriority) ||
+			errors.Is(err, rulescore.ErrInvalidCondition) {
 			helpers.WriteError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -299,6 +324,43 @@ func (h *Handler) handleDeleteRule(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRunRule applies a rule to the existing notifications its query matches. With
+// dryRun=true it only reports how many would be affected.
+func (h *Handler) handleRunRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	ruleID := chi.URLParam(r, "id")
+	if ruleID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	result, err := h.ruleSvc.RunRule(ctx, userID, ruleID, dryRun)
+	if err != nil {
+		if errors.Is(err, rulescore.ErrRuleNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, rulescore.ErrViewNotFound) ||
+			errors.Is(err, rulescore.ErrInvalidQuery) ||
+			errors.Is(err, rulescore.ErrRuleHasNoQuery) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to run rule")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, result)
+}
+
 func (h *Handler) handleReorderRules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -334,3 +396,37 @@ func (h *Handler) handleReorderRules(w http.ResponseWriter, r *http.Request) {
 
 	helpers.WriteJSON(w, http.StatusOK, listRulesResponse{Rules: response})
 }
+
+// handleListActivity lists recent rule evaluations: for each notification, which rules
+// matched, which didn't and which were skipped after a rule stopped processing
+func (h *Handler) handleListActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			helpers.WriteError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	evaluations, err := h.ruleSvc.ListEvaluations(
+		ctx,
+		userID,
+		r.URL.Query().Get("notification"),
+		limit,
+	)
+	if err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load rule activity")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, ruleActivityResponse{Evaluations: evaluations})
+}
@@ -657,6 +657,102 @@ func TestHandler_handleDeleteRule(t *testing.T) {
 	}
 }
 
+func TestHandler_handleRunRule(t *testing.T) {
+	actionsJSON, err := json.Marshal(models.RuleActions{Archive: true})
+	require.NoError(t, err)
+	rule := db.Rule{
+		ID:      "1",
+		Query:   sql.NullString{String: "is:unread", Valid: true},
+		Actions: actionsJSON,
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*mocks.MockStore)
+		expectedStatus int
+		expectedResult models.RuleRunResult
+	}{
+		{
+			name: "dry run returns match count",
+			url:  "/rules/1/run?dryRun=true",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetRule(gomock.Any(), "test-user-id", "1").Return(rule, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: models.RuleRunResult{Matched: 3, DryRun: true},
+		},
+		{
+			name: "run applies actions",
+			url:  "/rules/1/run",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetRule(gomock.Any(), "test-user-id", "1").Return(rule, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 3}, nil)
+				m.EXPECT().
+					BulkArchiveNotificationsByQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(int64(3), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: models.RuleRunResult{Matched: 3},
+		},
+		{
+			name: "not found returns 404",
+			url:  "/rules/1/run",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetRule(gomock.Any(), "test-user-id", "1").Return(db.Rule{}, sql.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "store error returns 500",
+			url:  "/rules/1/run",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetRule(gomock.Any(), "test-user-id", "1").Return(rule, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockStore, mockAuthSvc := setupTestHandler(ctrl)
+			tt.setupMock(mockStore)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+
+			req := createRequest(http.MethodPost, tt.url, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+
+			w := httptest.NewRecorder()
+
+			handler.handleRunRule(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.RuleRunResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				require.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
 func TestHandler_handleReorderRules(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -809,6 +905,82 @@ func TestHandler_handleReorderRules(t *testing.T) {
 }
 
 // Helper functions
+func TestHandler_handleListActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*mocks.MockStore)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success returns evaluations for a notification",
+			url:  "/rules/activity?notification=notif-1&limit=10",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListRuleEvaluations(gomock.Any(), "test-user-id", "notif-1", int64(10)).
+					Return([]db.RuleEvaluation{{
+						GithubID: "notif-1",
+						Trace: json.RawMessage(
+							`[{"ruleId":"1","ruleName":"Test Rule","priority":0,"result":"skipped"}]`,
+						),
+						EvaluatedAt: time.Now(),
+					}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response ruleActivityResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.Evaluations, 1)
+				require.Equal(t, "notif-1", response.Evaluations[0].GithubID)
+				require.Equal(t, models.RuleResultSkipped, response.Evaluations[0].Rules[0].Result)
+			},
+		},
+		{
+			name:           "invalid limit returns 400",
+			url:            "/rules/activity?limit=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error returns 500",
+			url:  "/rules/activity",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListRuleEvaluations(gomock.Any(), "test-user-id", "", gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockStore, mockAuthSvc := setupTestHandler(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockStore)
+			}
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+
+			req := createRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.handleListActivity(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -33,3 +33,8 @@ type listRulesResponse struct {
 type ruleEnvelope struct {
 	Rule RuleResponse `json:"rule"`
 }
+
+// ruleActivityResponse is the response type for recent rule evaluations
+type ruleActivityResponse struct {
+	Evaluations []models.RuleEvaluation `json:"evaluations"`
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package savedreplies provides the saved reply API.
+package savedreplies
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToListSavedReplies = errors.New("failed to list saved replies")
+)
+
+// Handler handles saved reply HTTP routes
+type Handler struct {
+	logger       *zap.Logger
+	savedReplies savedreply.SavedReplyService
+	authSvc      authsvc.AuthService
+}
+
+// New creates a new saved replies handler
+func New(
+	logger *zap.Logger,
+	savedReplies savedreply.SavedReplyService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:       logger,
+		savedReplies: savedReplies,
+		authSvc:      authSvc,
+	}
+}
+
+// Register registers saved reply routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/saved-replies", h.handleList)
+}
+
+type listResponse struct {
+	SavedReplies []models.SavedReply `json:"savedReplies"`
+}
+
+// handleList returns the user's saved replies. ?refresh=true imports them from GitHub
+// again instead of using the cached copy.
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	refresh := r.URL.Query().Get("refresh") == "true"
+	replies, err := h.savedReplies.List(ctx, userID, refresh)
+	if err != nil {
+		h.logger.Error(
+			"failed to list saved replies",
+			zap.Bool("refresh", refresh),
+			zap.Error(errors.Join(ErrFailedToListSavedReplies, err)),
+		)
+		if errors.Is(err, savedreply.ErrFailedToImport) {
+			helpers.WriteError(w, http.StatusBadGateway, "Failed to import saved replies from GitHub")
+			return
+		}
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load saved replies")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listResponse{SavedReplies: replies})
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package savedreplies
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	savedreplymocks "github.com/octobud-hq/octobud/backend/internal/core/savedreply/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*savedreplymocks.MockSavedReplyService),
+	path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := savedreplymocks.NewMockSavedReplyService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	setupMock(mockSvc)
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleList(t *testing.T) {
+	w := serve(t, func(m *savedreplymocks.MockSavedReplyService) {
+		m.EXPECT().List(gomock.Any(), testUserID, false).Return([]models.SavedReply{
+			{ID: "SR_1", Title: "Thanks", Body: "Thanks @{{author}}!"},
+		}, nil)
+	}, "/saved-replies")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t,
+		`{"savedReplies": [{"id": "SR_1", "title": "Thanks", "body": "Thanks @{{author}}!"}]}`,
+		w.Body.String())
+}
+
+func TestHandler_handleList_Refresh(t *testing.T) {
+	w := serve(t, func(m *savedreplymocks.MockSavedReplyService) {
+		m.EXPECT().List(gomock.Any(), testUserID, true).Return([]models.SavedReply{}, nil)
+	}, "/saved-replies?refresh=true")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"savedReplies": []}`, w.Body.String())
+}
+
+func TestHandler_handleList_Errors(t *testing.T) {
+	w := serve(t, func(m *savedreplymocks.MockSavedReplyService) {
+		m.EXPECT().List(gomock.Any(), testUserID, true).
+			Return(nil, errors.Join(savedreply.ErrFailedToImport, errors.New("401")))
+	}, "/saved-replies?refresh=true")
+	require.Equal(t, http.StatusBadGateway, w.Code)
+
+	w = serve(t, func(m *savedreplymocks.MockSavedReplyService) {
+		m.EXPECT().List(gomock.Any(), testUserID, false).Return(nil, errors.New("db"))
+	}, "/saved-replies")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package sharedviews provides the handler for views other users of a multi-user server
+// have shared with the signed-in user. Shared views and their notifications stay in the
+// owner's workspace; requests are checked against the share and then served there.
+package sharedviews
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// listParams are the notification list parameters passed through to the owner's
+// workspace. The query always comes from the view.
+var listParams = []string{"page", "pageSize", "cursor", "sort", "includeSubject", "conversations"}
+
+// Workspaces gives access to the running workspaces of the server's users
+type Workspaces interface {
+	// ViewShares returns the view sharing of each running workspace, by username
+	ViewShares() map[string]viewshare.ViewShareService
+	// ServeHTTP serves a request from the workspace of the user in its session
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+// Handler handles shared view HTTP routes
+type Handler struct {
+	logger     *zap.Logger
+	workspaces Workspaces
+}
+
+// New creates a new shared views handler
+func New(logger *zap.Logger, workspaces Workspaces) *Handler {
+	return &Handler{
+		logger:     logger,
+		workspaces: workspaces,
+	}
+}
+
+// Register registers shared view routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/shared-views", func(r chi.Router) {
+		r.Get("/", h.handleList)
+		r.Get("/{owner}/{id}", h.handleGet)
+		r.Put("/{owner}/{id}", h.handleUpdate)
+		r.Delete("/{owner}/{id}", h.handleLeave)
+		r.Get("/{owner}/{id}/notifications", h.handleListNotifications)
+	})
+}
+
+type listResponse struct {
+	Views []models.SharedView `json:"views"`
+}
+
+type sharedViewEnvelope struct {
+	View models.SharedView `json:"view"`
+}
+
+// updateRequest holds the fields of a shared view its grantees may change. The query isn't
+// one of them: it decides which of the owner's notifications grantees can see, so only the
+// owner may change it.
+type updateRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+}
+
+// handleList returns the views shared with the signed-in user, by owner
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	grantee, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	sharesByOwner := h.workspaces.ViewShares()
+	owners := make([]string, 0, len(sharesByOwner))
+	for owner := range sharesByOwner {
+		if owner != grantee {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+
+	views := make([]models.SharedView, 0)
+	for _, owner := range owners {
+		shared, err := sharesByOwner[owner].SharedWith(r.Context(), grantee)
+		if err != nil {
+			h.logger.Error("failed to list shared views",
+				zap.String("owner", owner), zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to list shared views")
+			return
+		}
+		views = append(views, shared...)
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Views: views})
+}
+
+func (h *Handler) handleGet(w http.ResponseWriter, r *http.Request) {
+	grantee, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	view, ok := h.sharedView(w, r, grantee)
+	if !ok {
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, sharedViewEnvelope{View: view})
+}
+
+// handleUpdate changes a view shared with write access. Only the name, description and
+// icon can be changed; the query, the owner's default view and sidebar are left alone.
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	grantee, ok := requireWritable(w, r)
+	if !ok {
+		return
+	}
+	var req updateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	view, ok := h.sharedView(w, r, grantee)
+	if !ok {
+		return
+	}
+	if !view.CanWrite() {
+		helpers.WriteError(w, http.StatusForbidden, "This view is shared with you read-only")
+		return
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	h.logger.Info("updating shared view",
+		zap.String("owner", view.Owner),
+		zap.String("view_id", view.View.ID),
+		zap.String("username", grantee))
+	h.forward(w, r, view.Owner, http.MethodPut, "/api/views/"+url.PathEscape(view.View.ID), body)
+}
+
+// handleLeave removes a view shared with the signed-in user from their shared views
+func (h *Handler) handleLeave(w http.ResponseWriter, r *http.Request) {
+	grantee, ok := requireWritable(w, r)
+	if !ok {
+		return
+	}
+	shares, ok := h.ownerShares(w, r)
+	if !ok {
+		return
+	}
+
+	if err := shares.LeaveView(r.Context(), chi.URLParam(r, "id"), grantee); err != nil {
+		h.writeError(w, "failed to leave shared view", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListNotifications lists the owner's notifications that match a shared view
+func (h *Handler) handleListNotifications(w http.ResponseWriter, r *http.Request) {
+	grantee, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	view, ok := h.sharedView(w, r, grantee)
+	if !ok {
+		return
+	}
+
+	params := url.Values{}
+	params.Set("query", view.View.Query)
+	for _, name := range listParams {
+		if value := r.URL.Query().Get(name); value != "" {
+			params.Set(name, value)
+		}
+	}
+	h.forward(w, r, view.Owner, http.MethodGet, "/api/notifications?"+params.Encode(), nil)
+}
+
+// sharedView looks up the view in the request, writing a 404 unless it's shared with
+// the grantee
+func (h *Handler) sharedView(
+	w http.ResponseWriter,
+	r *http.Request,
+	grantee string,
+) (models.SharedView, bool) {
+	shares, ok := h.ownerShares(w, r)
+	if !ok {
+		return models.SharedView{}, false
+	}
+	view, err := shares.GetSharedView(r.Context(), chi.URLParam(r, "id"), grantee)
+	if err != nil {
+		h.writeError(w, "failed to get shared view", err)
+		return models.SharedView{}, false
+	}
+	return view, true
+}
+
+// ownerShares returns the view sharing of the owner in the request's path
+func (h *Handler) ownerShares(
+	w http.ResponseWriter,
+	r *http.Request,
+) (viewshare.ViewShareService, bool) {
+	shares, ok := h.workspaces.ViewShares()[chi.URLParam(r, "owner")]
+	if !ok {
+		helpers.WriteError(w, http.StatusNotFound, "Shared view not found")
+		return nil, false
+	}
+	return shares, true
+}
+
+// forward serves a request built here from the owner's workspace, as the owner. Only
+// these requests reach the owner's workspace, so grantees can't get at anything else.
+// Requests that only read are marked read-only.
+func (h *Handler) forward(
+	w http.ResponseWriter,
+	r *http.Request,
+	owner, method, target string,
+	body []byte,
+) {
+	session := &authn.Session{
+		Subject:  owner,
+		Account:  authn.AnyAccount,
+		ReadOnly: method == http.MethodGet,
+	}
+	ctx := authn.ContextWithSession(r.Context(), session)
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		h.logger.Error("failed to build shared view request", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load shared view")
+		return
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	h.workspaces.ServeHTTP(w, req)
+}
+
+// writeError maps shared view errors to status codes, logging unexpected ones
+func (h *Handler) writeError(w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, viewshare.ErrShareNotFound) {
+		helpers.WriteError(w, http.StatusNotFound, "Shared view not found")
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	helpers.WriteError(w, http.StatusInternalServerError, "Failed to load shared view")
+}
+
+// requireWritable returns the signed-in user's username, unless they signed in with a
+// read-only API token. Their own workspace would refuse such requests, but these never
+// reach it.
+func requireWritable(w http.ResponseWriter, r *http.Request) (string, bool) {
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return "", false
+	}
+	if authn.SessionFromContext(r.Context()).ReadOnly {
+		helpers.WriteError(w, http.StatusForbidden, "This API token is read-only")
+		return "", false
+	}
+	return username, true
+}
+
+// requireUsername returns the signed-in user's username
+func requireUsername(w http.ResponseWriter, r *http.Request) (string, bool) {
+	session := authn.SessionFromContext(r.Context())
+	if session == nil || session.Subject == "" {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return "", false
+	}
+	return session.Subject, true
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sharedviews
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	viewsharemocks "github.com/octobud-hq/octobud/backend/internal/core/viewshare/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// fakeWorkspaces records the requests forwarded to owners' workspaces
+type fakeWorkspaces struct {
+	shares    map[string]viewshare.ViewShareService
+	forwarded []*http.Request
+	bodies    []string
+}
+
+func (f *fakeWorkspaces) ViewShares() map[string]viewshare.ViewShareService {
+	return f.shares
+}
+
+func (f *fakeWorkspaces) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.forwarded = append(f.forwarded, r)
+	f.bodies = append(f.bodies, string(body))
+	w.WriteHeader(http.StatusOK)
+}
+
+var bob = &authn.Session{Subject: "bob", Account: authn.AnyAccount}
+
+func sharedView(permission string) models.SharedView {
+	return models.SharedView{
+		Owner:      "alice",
+		Permission: permission,
+		View:       models.View{ID: "v1", Name: "Reviews", Query: "reason:review_requested"},
+	}
+}
+
+func setup(t *testing.T) (chi.Router, *viewsharemocks.MockViewShareService, *fakeWorkspaces) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	aliceShares := viewsharemocks.NewMockViewShareService(ctrl)
+	bobShares := viewsharemocks.NewMockViewShareService(ctrl)
+	workspaces := &fakeWorkspaces{shares: map[string]viewshare.ViewShareService{
+		"alice": aliceShares,
+		"bob":   bobShares,
+	}}
+	router := chi.NewRouter()
+	New(zap.NewNop(), workspaces).Register(router)
+	return router, aliceShares, workspaces
+}
+
+func serve(router chi.Router, session *authn.Session, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if session != nil {
+		req = req.WithContext(authn.ContextWithSession(req.Context(), session))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_RequiresSession(t *testing.T) {
+	router, _, _ := setup(t)
+	w := serve(router, nil, http.MethodGet, "/shared-views", "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestHandler_List(t *testing.T) {
+	router, aliceShares, _ := setup(t)
+	// Bob's own workspace isn't asked for views shared with him
+	aliceShares.EXPECT().
+		SharedWith(gomock.Any(), "bob").
+		Return([]models.SharedView{sharedView("read")}, nil)
+
+	w := serve(router, bob, http.MethodGet, "/shared-views", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp listResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Views, 1)
+	require.Equal(t, "alice", resp.Views[0].Owner)
+}
+
+func TestHandler_ListNotifications(t *testing.T) {
+	router, aliceShares, workspaces := setup(t)
+	aliceShares.EXPECT().GetSharedView(gomock.Any(), "v1", "bob").Return(sharedView("read"), nil)
+
+	w := serve(router, bob, http.MethodGet,
+		"/shared-views/alice/v1/notifications?page=2&query=in:anywhere&explainDefaults=true", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The view's query is used and the request reaches Alice's workspace as her, read-only
+	require.Len(t, workspaces.forwarded, 1)
+	forwarded := workspaces.forwarded[0]
+	require.Equal(t, http.MethodGet, forwarded.Method)
+	require.Equal(t, "/api/notifications", forwarded.URL.Path)
+	require.Equal(t, "reason:review_requested", forwarded.URL.Query().Get("query"))
+	require.Equal(t, "2", forwarded.URL.Query().Get("page"))
+	require.Empty(t, forwarded.URL.Query().Get("explainDefaults"))
+	session := authn.SessionFromContext(forwarded.Context())
+	require.Equal(t, "alice", session.Subject)
+	require.True(t, session.ReadOnly)
+}
+
+func TestHandler_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		permission     string
+		expectedStatus int
+		forwarded      bool
+	}{
+		{name: "write access", permission: "write", expectedStatus: http.StatusOK, forwarded: true},
+		{name: "read access", permission: "read", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, aliceShares, workspaces := setup(t)
+			aliceShares.EXPECT().
+				GetSharedView(gomock.Any(), "v1", "bob").
+				Return(sharedView(tt.permission), nil)
+
+			w := serve(router, bob, http.MethodPut, "/shared-views/alice/v1",
+				`{"name":"Mine","query":"reason:mention","isDefault":true}`)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if !tt.forwarded {
+				require.Empty(t, workspaces.forwarded)
+				return
+			}
+
+			// Only the fields grantees may change are passed on
+			require.Len(t, workspaces.forwarded, 1)
+			require.Equal(t, http.MethodPut, workspaces.forwarded[0].Method)
+			require.Equal(t, "/api/views/v1", workspaces.forwarded[0].URL.Path)
+			require.JSONEq(t, `{"name":"Mine"}`, workspaces.bodies[0])
+			require.False(t, authn.SessionFromContext(workspaces.forwarded[0].Context()).ReadOnly)
+		})
+	}
+}
+
+func TestHandler_UpdateCannotWidenQuery(t *testing.T) {
+	router, aliceShares, workspaces := setup(t)
+	aliceShares.EXPECT().
+		GetSharedView(gomock.Any(), "v1", "bob").
+		Return(sharedView("write"), nil).
+		Times(2)
+
+	// A grantee with write access tries to make the view match Alice's whole inbox
+	w := serve(router, bob, http.MethodPut, "/shared-views/alice/v1", `{"query":"in:anywhere"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.JSONEq(t, `{}`, workspaces.bodies[0])
+
+	// Listing still only reaches the notifications of the query Alice shared
+	w = serve(router, bob, http.MethodGet, "/shared-views/alice/v1/notifications", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, workspaces.forwarded, 2)
+	require.Equal(t, "reason:review_requested", workspaces.forwarded[1].URL.Query().Get("query"))
+}
+
+func TestHandler_NotShared(t *testing.T) {
+	router, aliceShares, workspaces := setup(t)
+	aliceShares.EXPECT().
+		GetSharedView(gomock.Any(), "v2", "bob").
+		Return(models.SharedView{}, viewshare.ErrShareNotFound)
+
+	w := serve(router, bob, http.MethodGet, "/shared-views/alice/v2/notifications", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve(router, bob, http.MethodGet, "/shared-views/carol/v1", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Empty(t, workspaces.forwarded)
+}
+
+func TestHandler_Leave(t *testing.T) {
+	router, aliceShares, _ := setup(t)
+	aliceShares.EXPECT().LeaveView(gomock.Any(), "v1", "bob").Return(nil)
+
+	w := serve(router, bob, http.MethodDelete, "/shared-views/alice/v1", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandler_ReadOnlyToken(t *testing.T) {
+	router, _, workspaces := setup(t)
+	token := &authn.Session{Subject: "bob", TokenID: "tok-1", ReadOnly: true}
+
+	w := serve(router, token, http.MethodPut, "/shared-views/alice/v1", `{"name":"Mine"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	w = serve(router, token, http.MethodDelete, "/shared-views/alice/v1", "")
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Empty(t, workspaces.forwarded)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package syncprogress serves how far the initial sync has got, for the setup screen.
+package syncprogress
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+)
+
+// Phases of the initial sync
+const (
+	PhaseWaiting    = "waiting"    // Setup is done but no page has been fetched yet
+	PhaseFetching   = "fetching"   // Pages are being fetched from GitHub and queued
+	PhaseProcessing = "processing" // Everything is fetched and the queue is draining
+	PhaseComplete   = "complete"
+)
+
+// Backlog reports the notification processing queue
+type Backlog interface {
+	NotificationBacklog(ctx context.Context) (jobs.QueueStats, error)
+}
+
+// Handler handles the sync progress route
+type Handler struct {
+	logger       *zap.Logger
+	syncStateSvc syncstate.SyncStateService
+	backlog      Backlog
+	authSvc      authsvc.AuthService
+	now          func() time.Time
+}
+
+// New creates a sync progress handler
+func New(
+	logger *zap.Logger,
+	syncStateSvc syncstate.SyncStateService,
+	backlog Backlog,
+	authSvc authsvc.AuthService,
+	now func() time.Time,
+) *Handler {
+	return &Handler{
+		logger:       logger,
+		syncStateSvc: syncStateSvc,
+		backlog:      backlog,
+		authSvc:      authSvc,
+		now:          now,
+	}
+}
+
+// Register registers the sync progress route
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/sync/progress", h.handleGetProgress)
+}
+
+type progressResponse struct {
+	Phase          string     `json:"phase"`
+	StartedAt      *time.Time `json:"startedAt,omitempty"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+	PagesFetched   int64      `json:"pagesFetched"`
+	Queued         int64      `json:"queued"`
+	Processed      int64      `json:"processed"`
+	Pending        int64      `json:"pending"`
+	OldestSyncedAt *time.Time `json:"oldestSyncedAt,omitempty"`
+	// ETASeconds estimates how long the notifications queued so far take to process,
+	// from the rate they have been processed at since the sync started
+	ETASeconds *int64 `json:"etaSeconds,omitempty"`
+}
+
+func (h *Handler) handleGetProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	state, err := h.syncStateSvc.GetSyncState(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get sync state", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	backlog, err := h.backlog.NotificationBacklog(ctx)
+	if err != nil {
+		h.logger.Error("failed to get notification backlog", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	remaining := backlog.Pending + backlog.Processing
+	response := progressResponse{
+		PagesFetched: state.InitialSyncPages,
+		Queued:       state.InitialSyncQueued,
+		Processed:    max(state.InitialSyncQueued-remaining, 0),
+		Pending:      remaining,
+	}
+	if state.InitialSyncStartedAt.Valid {
+		startedAt := state.InitialSyncStartedAt.Time
+		response.StartedAt = &startedAt
+	}
+	if state.InitialSyncCompletedAt.Valid {
+		completedAt := state.InitialSyncCompletedAt.Time
+		response.CompletedAt = &completedAt
+	}
+	if state.OldestNotificationSyncedAt.Valid {
+		oldest := state.OldestNotificationSyncedAt.Time
+		response.OldestSyncedAt = &oldest
+	}
+
+	switch {
+	case state.InitialSyncCompletedAt.Valid && (remaining == 0 || !state.InitialSyncStartedAt.Valid):
+		// Syncs completed before progress was recorded have nothing left to report
+		response.Phase = PhaseComplete
+	case state.InitialSyncCompletedAt.Valid:
+		response.Phase = PhaseProcessing
+	case state.InitialSyncPages == 0:
+		response.Phase = PhaseWaiting
+	default:
+		response.Phase = PhaseFetching
+	}
+
+	if response.Phase != PhaseComplete && response.StartedAt != nil &&
+		response.Processed > 0 && remaining > 0 {
+		elapsed := h.now().Sub(*response.StartedAt)
+		eta := int64(elapsed.Seconds() * float64(remaining) / float64(response.Processed))
+		response.ETASeconds = &eta
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package syncprogress
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	syncstatemocks "github.com/octobud-hq/octobud/backend/internal/core/syncstate/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "12345"
+
+type fakeBacklog struct {
+	stats jobs.QueueStats
+}
+
+func (f fakeBacklog) NotificationBacklog(context.Context) (jobs.QueueStats, error) {
+	return f.stats, nil
+}
+
+func TestHandler_handleGetProgress(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := now.Add(-10 * time.Minute)
+	completedAt := now.Add(-2 * time.Minute)
+	oldest := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	eta := int64(600) // 300 processed in 10 minutes, 300 to go
+
+	tests := []struct {
+		name     string
+		state    models.SyncState
+		backlog  jobs.QueueStats
+		expected progressResponse
+	}{
+		{
+			name:     "not started",
+			expected: progressResponse{Phase: PhaseWaiting},
+		},
+		{
+			name: "fetching",
+			state: models.SyncState{
+				InitialSyncStartedAt:       sql.NullTime{Time: startedAt, Valid: true},
+				InitialSyncPages:           12,
+				InitialSyncQueued:          600,
+				OldestNotificationSyncedAt: sql.NullTime{Time: oldest, Valid: true},
+			},
+			backlog: jobs.QueueStats{Pending: 296, Processing: 4},
+			expected: progressResponse{
+				Phase:          PhaseFetching,
+				StartedAt:      &startedAt,
+				PagesFetched:   12,
+				Queued:         600,
+				Processed:      300,
+				Pending:        300,
+				OldestSyncedAt: &oldest,
+				ETASeconds:     &eta,
+			},
+		},
+		{
+			name: "processing",
+			state: models.SyncState{
+				InitialSyncStartedAt:   sql.NullTime{Time: startedAt, Valid: true},
+				InitialSyncCompletedAt: sql.NullTime{Time: completedAt, Valid: true},
+				InitialSyncPages:       12,
+				InitialSyncQueued:      600,
+			},
+			backlog: jobs.QueueStats{Pending: 300},
+			expected: progressResponse{
+				Phase:        PhaseProcessing,
+				StartedAt:    &startedAt,
+				CompletedAt:  &completedAt,
+				PagesFetched: 12,
+				Queued:       600,
+				Processed:    300,
+				Pending:      300,
+				ETASeconds:   &eta,
+			},
+		},
+		{
+			name: "complete",
+			state: models.SyncState{
+				InitialSyncStartedAt:   sql.NullTime{Time: startedAt, Valid: true},
+				InitialSyncCompletedAt: sql.NullTime{Time: completedAt, Valid: true},
+				InitialSyncPages:       12,
+				InitialSyncQueued:      600,
+			},
+			expected: progressResponse{
+				Phase:        PhaseComplete,
+				StartedAt:    &startedAt,
+				CompletedAt:  &completedAt,
+				PagesFetched: 12,
+				Queued:       600,
+				Processed:    600,
+			},
+		},
+		{
+			name: "completed before progress was recorded",
+			state: models.SyncState{
+				InitialSyncCompletedAt: sql.NullTime{Time: completedAt, Valid: true},
+			},
+			backlog: jobs.QueueStats{Pending: 3},
+			expected: progressResponse{
+				Phase:       PhaseComplete,
+				CompletedAt: &completedAt,
+				Pending:     3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			syncStateSvc := syncstatemocks.NewMockSyncStateService(ctrl)
+			syncStateSvc.EXPECT().GetSyncState(gomock.Any(), testUserID).Return(tt.state, nil)
+			authSvc := authmocks.NewMockAuthService(ctrl)
+			authSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+
+			router := chi.NewRouter()
+			New(
+				zap.NewNop(),
+				syncStateSvc,
+				fakeBacklog{stats: tt.backlog},
+				authSvc,
+				func() time.Time { return now },
+			).Register(router)
+
+			req := httptest.NewRequest(http.MethodGet, "/sync/progress", nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var response progressResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, tt.expected, response)
+		})
+	}
+}
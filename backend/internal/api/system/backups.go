@@ -0,0 +1,107 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+)
+
+// listBackupsResponse lists backups, newest first, and whether a restore is waiting for
+// the next start
+type listBackupsResponse struct {
+	Backups        []backup.Info `json:"backups"`
+	PendingRestore bool          `json:"pendingRestore"`
+}
+
+// restoreBackupResponse describes a restore staged for the next start
+type restoreBackupResponse struct {
+	Backup          backup.Info `json:"backup"`
+	RestartRequired bool        `json:"restartRequired"`
+}
+
+func (h *Handler) handleListBackups(w http.ResponseWriter, _ *http.Request) {
+	backups, err := h.backups.List()
+	if err != nil {
+		h.logger.Error("failed to list backups", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list backups")
+		return
+	}
+	pending, err := h.backups.PendingRestore()
+	if err != nil {
+		h.logger.Error("failed to check for a pending restore", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list backups")
+		return
+	}
+	if backups == nil {
+		backups = []backup.Info{}
+	}
+	helpers.WriteJSON(w, http.StatusOK, listBackupsResponse{Backups: backups, PendingRestore: pending})
+}
+
+// handleCreateBackup backs up the database now, deleting the oldest backups beyond the
+// number the backup settings keep
+func (h *Handler) handleCreateBackup(w http.ResponseWriter, r *http.Request) {
+	info, err := h.backups.Create(r.Context())
+	if err != nil {
+		h.logger.Error("failed to back up database", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to back up database")
+		return
+	}
+	h.logger.Info("backed up database",
+		zap.String("path", info.Path),
+		zap.Int64("sizeBytes", info.SizeBytes))
+	helpers.WriteJSON(w, http.StatusCreated, info)
+}
+
+// handleRestoreBackup stages a backup to replace the database. The live database can't be
+// swapped out from under open connections, so the restore happens on the next start.
+func (h *Handler) handleRestoreBackup(w http.ResponseWriter, r *http.Request) {
+	info, err := h.backups.Restore(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		switch {
+		case errors.Is(err, backup.ErrBackupNotFound):
+			helpers.WriteError(w, http.StatusNotFound, "Backup not found")
+		case errors.Is(err, backup.ErrUnusableBackup):
+			helpers.WriteError(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.logger.Error("failed to stage restore", zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to stage restore")
+		}
+		return
+	}
+	h.logger.Info("staged database restore", zap.String("backup", info.Name))
+	helpers.WriteJSON(w, http.StatusAccepted, restoreBackupResponse{Backup: info, RestartRequired: true})
+}
+
+func (h *Handler) handleCancelRestore(w http.ResponseWriter, _ *http.Request) {
+	if err := h.backups.CancelRestore(); err != nil {
+		if errors.Is(err, backup.ErrNoPendingRestore) {
+			helpers.WriteError(w, http.StatusNotFound, "No restore is pending")
+			return
+		}
+		h.logger.Error("failed to cancel restore", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to cancel restore")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	backupmocks "github.com/octobud-hq/octobud/backend/internal/core/backup/mocks"
+)
+
+var testBackup = backup.Info{
+	Name:      "octobud-20250615T120000.000Z.db",
+	Path:      "/data/backups/octobud-20250615T120000.000Z.db",
+	SizeBytes: 4096,
+	CreatedAt: time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC),
+}
+
+func setupBackupRouter(t *testing.T) (chi.Router, *backupmocks.MockBackupService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockBackups := backupmocks.NewMockBackupService(ctrl)
+	router := chi.NewRouter()
+	New(zap.NewNop(), nil).WithBackups(mockBackups).Register(router)
+	return router, mockBackups
+}
+
+func TestHandler_ListAndCreateBackups(t *testing.T) {
+	router, mockBackups := setupBackupRouter(t)
+	mockBackups.EXPECT().List().Return(nil, nil)
+	mockBackups.EXPECT().PendingRestore().Return(false, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/system/backups", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"backups":[],"pendingRestore":false}`, w.Body.String())
+
+	mockBackups.EXPECT().Create(gomock.Any()).Return(testBackup, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/system/backups", nil))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created backup.Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, testBackup, created)
+}
+
+func TestHandler_RestoreBackup(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "stages restore", expectedStatus: http.StatusAccepted},
+		{name: "unknown backup", err: backup.ErrBackupNotFound, expectedStatus: http.StatusNotFound},
+		{
+			name:           "unusable backup",
+			err:            fmt.Errorf("%w: integrity check failed", backup.ErrUnusableBackup),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "staging fails", err: backup.ErrFailedToRestore, expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockBackups := setupBackupRouter(t)
+			info := testBackup
+			if tt.err != nil {
+				info = backup.Info{}
+			}
+			mockBackups.EXPECT().Restore(gomock.Any(), testBackup.Name).Return(info, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(
+				http.MethodPost, "/system/backups/"+testBackup.Name+"/restore", nil,
+			))
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.err == nil {
+				var resp restoreBackupResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.True(t, resp.RestartRequired)
+				require.Equal(t, testBackup, resp.Backup)
+			}
+		})
+	}
+}
+
+func TestHandler_CancelRestore(t *testing.T) {
+	router, mockBackups := setupBackupRouter(t)
+	gomock.InOrder(
+		mockBackups.EXPECT().CancelRestore().Return(nil),
+		mockBackups.EXPECT().CancelRestore().Return(backup.ErrNoPendingRestore),
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/system/backups/restore", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/system/backups/restore", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package system provides the handler for application-level system routes.
+package system
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+	"github.com/octobud-hq/octobud/backend/internal/recovery"
+)
+
+// Handler handles system-related HTTP routes
+type Handler struct {
+	logger        *zap.Logger
+	startupReport *recovery.Report
+	snapshots     *snapshot.Service
+	backups       backup.BackupService
+}
+
+// New creates a new system handler
+func New(logger *zap.Logger, startupReport *recovery.Report) *Handler {
+	return &Handler{
+		logger:        logger,
+		startupReport: startupReport,
+	}
+}
+
+// WithSnapshots enables the database snapshot endpoints
+func (h *Handler) WithSnapshots(snapshots *snapshot.Service) *Handler {
+	h.snapshots = snapshots
+	return h
+}
+
+// WithBackups enables the database backup endpoints
+func (h *Handler) WithBackups(backups backup.BackupService) *Handler {
+	h.backups = backups
+	return h
+}
+
+// Register registers system routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/system", func(r chi.Router) {
+		r.Get("/last-startup", h.handleGetLastStartup)
+		if h.snapshots != nil {
+			r.Get("/snapshot", h.handleGetSnapshot)
+			r.Post("/snapshot", h.handleCreateSnapshot)
+		}
+		if h.backups != nil {
+			r.Get("/backups", h.handleListBackups)
+			r.Post("/backups", h.handleCreateBackup)
+			r.Post("/backups/{name}/restore", h.handleRestoreBackup)
+			r.Delete("/backups/restore", h.handleCancelRestore)
+		}
+	})
+}
+
+// lastStartupResponse wraps the startup report with a summary flag for clients
+type lastStartupResponse struct {
+	*recovery.Report
+	DataLossPossible bool `json:"dataLossPossible"`
+}
+
+func (h *Handler) handleGetLastStartup(w http.ResponseWriter, _ *http.Request) {
+	if h.startupReport == nil {
+		helpers.WriteError(w, http.StatusNotFound, "No startup report available")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, lastStartupResponse{
+		Report:           h.startupReport,
+		DataLossPossible: h.startupReport.DataLossPossible(),
+	})
+}
+
+func (h *Handler) handleGetSnapshot(w http.ResponseWriter, _ *http.Request) {
+	info, err := h.snapshots.Latest()
+	if errors.Is(err, snapshot.ErrNoSnapshot) {
+		helpers.WriteError(w, http.StatusNotFound, "No snapshot has been written yet")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to read snapshot", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to read snapshot")
+		return
+	}
+	helpers.WriteJSON(w, http.StatusOK, info)
+}
+
+// handleCreateSnapshot writes a fresh read-only copy of the database to the configured
+// snapshot path, replacing the previous one
+func (h *Handler) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	info, err := h.snapshots.Create(r.Context())
+	if err != nil {
+		h.logger.Error("failed to write snapshot", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to write snapshot")
+		return
+	}
+	h.logger.Info("wrote database snapshot",
+		zap.String("path", info.Path),
+		zap.Int64("sizeBytes", info.SizeBytes))
+	helpers.WriteJSON(w, http.StatusCreated, info)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestHandler_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "octobud.db")
+	live, err := db.OpenDatabase(srcPath)
+	require.NoError(t, err)
+	defer live.Close()
+	_, err = live.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+
+	dstPath := filepath.Join(dir, snapshot.FileName)
+	handler := New(zap.NewNop(), nil).WithSnapshots(snapshot.NewService(srcPath, dstPath))
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/system/snapshot", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/system/snapshot", nil))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created snapshot.Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, dstPath, created.Path)
+	require.Positive(t, created.SizeBytes)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/system/snapshot", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var latest snapshot.Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &latest))
+	require.Equal(t, created.SizeBytes, latest.SizeBytes)
+}
+
+func TestHandler_SnapshotRoutesRequireService(t *testing.T) {
+	router := chi.NewRouter()
+	New(zap.NewNop(), nil).Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/system/snapshot", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -202,7 +202,9 @@ func (h *Handler) handleDeleteTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.tagSvc.DeleteTag(ctx, userID, tagID)
+	force := r.URL.Query().Get("force") == "true"
+
+	dependentRules, err := h.tagSvc.DeleteTag(ctx, userID, tagID, force)
 	if err != nil {
 		h.logger.Error(
 			"handleDeleteTag - failed to delete tag",
@@ -212,7 +214,23 @@ func (h *Handler) handleDeleteTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	// Without force the tag is kept; the frontend lists the rules and asks to confirm
+	if len(dependentRules) > 0 && !force {
+		helpers.WriteDependentRulesConflict(
+			w,
+			"This tag is used by rules that will be disabled",
+			dependentRules,
+		)
+		return
+	}
+
+	if len(dependentRules) > 0 {
+		h.logger.Info("disabled rules that used deleted tag",
+			zap.String("tag_id", tagID),
+			zap.Strings("rule_ids", helpers.RuleIDs(dependentRules)))
+	}
+
+	helpers.WriteDeleted(w, dependentRules)
 }
 
 // handleReorderTags updates the display order of tags
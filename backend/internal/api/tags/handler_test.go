@@ -410,17 +410,59 @@ func TestHandler_handleDeleteTag(t *testing.T) {
 	tests := []struct {
 		name           string
 		tagID          string
+		force          bool
 		setupMock      func(*tagmocks.MockTagService, string)
 		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
 	}{
 		{
 			name:  "success deletes tag",
 			tagID: "1",
 			setupMock: func(m *tagmocks.MockTagService, _ string) {
-				m.EXPECT().DeleteTag(gomock.Any(), "test-user-id", "1").Return(nil)
+				m.EXPECT().DeleteTag(gomock.Any(), "test-user-id", "1", false).Return(nil, nil)
 			},
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name:  "tag used by rules returns 409 with the rules",
+			tagID: "1",
+			setupMock: func(m *tagmocks.MockTagService, _ string) {
+				m.EXPECT().
+					DeleteTag(gomock.Any(), "test-user-id", "1", false).
+					Return([]models.Rule{{ID: "rule-1", Name: "Label bugs", Enabled: true}}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					LinkedRuleCount int                     `json:"linkedRuleCount"`
+					DependentRules  []helpers.DependentRule `json:"dependentRules"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, 1, resp.LinkedRuleCount)
+				require.Equal(t, []helpers.DependentRule{
+					{ID: "rule-1", Name: "Label bugs", Enabled: true},
+				}, resp.DependentRules)
+			},
+		},
+		{
+			name:  "force returns the disabled rules",
+			tagID: "1",
+			force: true,
+			setupMock: func(m *tagmocks.MockTagService, _ string) {
+				m.EXPECT().
+					DeleteTag(gomock.Any(), "test-user-id", "1", true).
+					Return([]models.Rule{{ID: "rule-1", Name: "Label bugs"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					DisabledRules []helpers.DependentRule `json:"disabledRules"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp.DisabledRules, 1)
+				require.Equal(t, "rule-1", resp.DisabledRules[0].ID)
+			},
+		},
 		{
 			name:           "missing tag ID returns 400",
 			tagID:          "",
@@ -432,8 +474,8 @@ func TestHandler_handleDeleteTag(t *testing.T) {
 			tagID: "invalid",
 			setupMock: func(m *tagmocks.MockTagService, tagID string) {
 				m.EXPECT().
-					DeleteTag(gomock.Any(), "test-user-id", tagID).
-					Return(errors.New("database error"))
+					DeleteTag(gomock.Any(), "test-user-id", tagID, false).
+					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -442,8 +484,8 @@ func TestHandler_handleDeleteTag(t *testing.T) {
 			tagID: "1",
 			setupMock: func(m *tagmocks.MockTagService, _ string) {
 				m.EXPECT().
-					DeleteTag(gomock.Any(), "test-user-id", "1").
-					Return(errors.New("database error"))
+					DeleteTag(gomock.Any(), "test-user-id", "1", false).
+					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -464,7 +506,11 @@ func TestHandler_handleDeleteTag(t *testing.T) {
 				Return(&models.User{GithubUserID: testUserID}, nil).
 				AnyTimes()
 
-			req := createRequest(http.MethodDelete, "/tags/"+tt.tagID, nil)
+			url := "/tags/" + tt.tagID
+			if tt.force {
+				url += "?force=true"
+			}
+			req := createRequest(http.MethodDelete, url, nil)
 			rctx := chi.NewRouteContext()
 			if tt.tagID != "" {
 				rctx.URLParams.Add("id", tt.tagID)
@@ -477,6 +523,9 @@ func TestHandler_handleDeleteTag(t *testing.T) {
 			handler.handleDeleteTag(w, req)
 
 			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
 		})
 	}
 }
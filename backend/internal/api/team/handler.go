@@ -0,0 +1,92 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package team provides the team review load API.
+package team
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/team"
+)
+
+// defaultDays is the review load window when none is given
+const defaultDays = 14
+
+// Error definitions
+var (
+	ErrFailedToLoadReviewLoad = errors.New("failed to load review load")
+)
+
+// Handler handles team HTTP routes
+type Handler struct {
+	logger  *zap.Logger
+	teamSvc team.TeamService
+	authSvc authsvc.AuthService
+}
+
+// New creates a new team handler
+func New(logger *zap.Logger, teamSvc team.TeamService, authSvc authsvc.AuthService) *Handler {
+	return &Handler{
+		logger:  logger,
+		teamSvc: teamSvc,
+		authSvc: authSvc,
+	}
+}
+
+// Register registers team routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/team/review-load", h.handleReviewLoad)
+}
+
+// handleReviewLoad returns review requests from the last ?days=N days (default 14)
+// aggregated by requested reviewer, flagging overloaded team members.
+func (h *Handler) handleReviewLoad(w http.ResponseWriter, r *http.Request) {
+	days := defaultDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < team.MinDays || parsed > team.MaxDays {
+			helpers.WriteError(w, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = parsed
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	report, err := h.teamSvc.ReviewLoad(ctx, userID, days)
+	if err != nil {
+		h.logger.Error(
+			"failed to load review load",
+			zap.Int("days", days),
+			zap.Error(errors.Join(ErrFailedToLoadReviewLoad, err)),
+		)
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load review load")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, report)
+}
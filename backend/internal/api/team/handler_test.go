@@ -0,0 +1,107 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	teammocks "github.com/octobud-hq/octobud/backend/internal/core/team/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*teammocks.MockTeamService),
+	path string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := teammocks.NewMockTeamService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	if setupMock != nil {
+		setupMock(mockSvc)
+	}
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_handleReviewLoad(t *testing.T) {
+	t.Run("defaults to fourteen days", func(t *testing.T) {
+		w := serve(t, func(m *teammocks.MockTeamService) {
+			m.EXPECT().
+				ReviewLoad(gomock.Any(), testUserID, 14).
+				Return(&models.ReviewLoadReport{
+					Days: 14,
+					Reviewers: []models.ReviewerLoad{
+						{Login: "alice", Requests: 5, Overloaded: true},
+					},
+				}, nil)
+		}, "/team/review-load")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var report models.ReviewLoadReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		require.Equal(t, 14, report.Days)
+		require.True(t, report.Reviewers[0].Overloaded)
+	})
+
+	t.Run("uses the given window", func(t *testing.T) {
+		w := serve(t, func(m *teammocks.MockTeamService) {
+			m.EXPECT().
+				ReviewLoad(gomock.Any(), testUserID, 30).
+				Return(&models.ReviewLoadReport{Days: 30}, nil)
+		}, "/team/review-load?days=30")
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid window returns 400", func(t *testing.T) {
+		w := serve(t, nil, "/team/review-load?days=365")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		w := serve(t, func(m *teammocks.MockTeamService) {
+			m.EXPECT().
+				ReviewLoad(gomock.Any(), testUserID, 14).
+				Return(nil, errors.New("database error"))
+		}, "/team/review-load")
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
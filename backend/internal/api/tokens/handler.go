@@ -0,0 +1,189 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package tokens provides the API for managing personal API tokens.
+package tokens
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// maxExpiresInDays bounds how far ahead a token's expiry can be set
+const maxExpiresInDays = 3650
+
+// Handler handles API token routes
+type Handler struct {
+	logger  *zap.Logger
+	tokens  apitoken.APITokenService
+	authSvc authsvc.AuthService
+}
+
+// New creates a new API tokens handler
+func New(
+	logger *zap.Logger,
+	tokens apitoken.APITokenService,
+	authSvc authsvc.AuthService,
+) *Handler {
+	return &Handler{
+		logger:  logger,
+		tokens:  tokens,
+		authSvc: authSvc,
+	}
+}
+
+// Register registers API token routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/tokens", func(r chi.Router) {
+		r.Get("/", h.handleList)
+		r.Post("/", h.handleCreate)
+		r.Delete("/{id}", h.handleRevoke)
+	})
+}
+
+// createRequest is the body for creating a token. Leaving expiresInDays out creates a
+// token that doesn't expire.
+type createRequest struct {
+	Name          string `json:"name"`
+	Scope         string `json:"scope"`
+	ExpiresInDays *int   `json:"expiresInDays"`
+}
+
+type listResponse struct {
+	Tokens []models.APIToken `json:"tokens"`
+}
+
+// createResponse carries the token's value, which is only ever shown this once
+type createResponse struct {
+	Token models.APIToken `json:"token"`
+	Value string          `json:"value"`
+}
+
+// handleList handles GET /api/tokens
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokens.ListTokens(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to list API tokens", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to load API tokens")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Tokens: tokens})
+}
+
+// handleCreate handles POST /api/tokens
+// The token inherits the GitHub account the signed-in user may access.
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSession(w, r) {
+		return
+	}
+
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode API token request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	params := models.APITokenParams{Name: req.Name, Scope: req.Scope}
+	if req.ExpiresInDays != nil {
+		days := *req.ExpiresInDays
+		if days < 1 || days > maxExpiresInDays {
+			helpers.WriteError(w, http.StatusBadRequest, "expiresInDays must be between 1 and 3650")
+			return
+		}
+		expiresAt := time.Now().AddDate(0, 0, days)
+		params.ExpiresAt = &expiresAt
+	}
+	if session := authn.SessionFromContext(r.Context()); session != nil {
+		params.Account = session.Account
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	token, value, err := h.tokens.CreateToken(ctx, userID, params)
+	if err != nil {
+		h.writeError(w, err, "Failed to create API token")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, createResponse{Token: token, Value: value})
+}
+
+// handleRevoke handles DELETE /api/tokens/{id}
+func (h *Handler) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if !h.requireSession(w, r) {
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	if err := h.tokens.RevokeToken(ctx, userID, chi.URLParam(r, "id")); err != nil {
+		h.writeError(w, err, "Failed to revoke API token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireSession rejects requests signed in with an API token, so a leaked token can't
+// be used to mint more
+func (h *Handler) requireSession(w http.ResponseWriter, r *http.Request) bool {
+	if session := authn.SessionFromContext(r.Context()); session != nil && session.TokenID != "" {
+		helpers.WriteError(w, http.StatusForbidden, "API tokens can't manage API tokens")
+		return false
+	}
+	return true
+}
+
+// writeError maps API token errors to responses, logging unexpected ones
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, apitoken.ErrTokenNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "API token not found")
+	case errors.Is(err, apitoken.ErrNameTaken):
+		helpers.WriteError(w, http.StatusConflict, "An API token with that name already exists")
+	case errors.Is(err, apitoken.ErrInvalidParams):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(msg, zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, msg)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package tokens
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	tokenmocks "github.com/octobud-hq/octobud/backend/internal/core/apitoken/mocks"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func serve(
+	t *testing.T,
+	setupMock func(*tokenmocks.MockAPITokenService),
+	method, path, body string,
+	session *authn.Session,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSvc := tokenmocks.NewMockAPITokenService(ctrl)
+	mockAuthSvc := authmocks.NewMockAuthService(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+	setupMock(mockSvc)
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), mockSvc, mockAuthSvc).Register(router)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	ctx := helpers.ContextWithUserID(req.Context(), testUserID)
+	if session != nil {
+		ctx = authn.ContextWithSession(ctx, session)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+	return w
+}
+
+func TestHandler_handleList(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	w := serve(t, func(m *tokenmocks.MockAPITokenService) {
+		m.EXPECT().ListTokens(gomock.Any(), testUserID).Return([]models.APIToken{
+			{ID: "t1", Name: "CI", Prefix: "obt_abcd1234", Scope: "read", Account: "*", CreatedAt: created},
+		}, nil)
+	}, http.MethodGet, "/tokens", "", nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"tokens": [{
+		"id": "t1", "name": "CI", "prefix": "obt_abcd1234", "scope": "read", "account": "*",
+		"createdAt": "2025-03-01T12:00:00Z"
+	}]}`, w.Body.String())
+}
+
+func TestHandler_handleCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		session        *authn.Session
+		setupMock      func(*tokenmocks.MockAPITokenService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "inherits the session's account",
+			body:    `{"name": "CLI", "scope": "write", "expiresInDays": 30}`,
+			session: &authn.Session{Subject: "alice", Account: "monalisa"},
+			setupMock: func(m *tokenmocks.MockAPITokenService) {
+				m.EXPECT().
+					CreateToken(gomock.Any(), testUserID, gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						_ string,
+						params models.APITokenParams,
+					) (models.APIToken, string, error) {
+						require.Equal(t, "monalisa", params.Account)
+						require.NotNil(t, params.ExpiresAt)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, 30), *params.ExpiresAt, time.Minute)
+						return models.APIToken{ID: "t1", Name: "CLI", Scope: "write"}, "obt_secret", nil
+					})
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"value":"obt_secret"`,
+		},
+		{
+			name:           "bad expiry",
+			body:           `{"name": "CLI", "expiresInDays": 0}`,
+			setupMock:      func(*tokenmocks.MockAPITokenService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "expiresInDays",
+		},
+		{
+			name: "invalid params",
+			body: `{"name": "", "scope": "read"}`,
+			setupMock: func(m *tokenmocks.MockAPITokenService) {
+				m.EXPECT().
+					CreateToken(gomock.Any(), testUserID, gomock.Any()).
+					Return(models.APIToken{}, "", apitoken.ErrInvalidParams)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "name taken",
+			body: `{"name": "CLI"}`,
+			setupMock: func(m *tokenmocks.MockAPITokenService) {
+				m.EXPECT().
+					CreateToken(gomock.Any(), testUserID, gomock.Any()).
+					Return(models.APIToken{}, "", apitoken.ErrNameTaken)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "tokens can't create tokens",
+			body:           `{"name": "CLI"}`,
+			session:        &authn.Session{Subject: "token:t1", TokenID: "t1"},
+			setupMock:      func(*tokenmocks.MockAPITokenService) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, tt.setupMock, http.MethodPost, "/tokens", tt.body, tt.session)
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestHandler_handleRevoke(t *testing.T) {
+	w := serve(t, func(m *tokenmocks.MockAPITokenService) {
+		m.EXPECT().RevokeToken(gomock.Any(), testUserID, "t1").Return(nil)
+	}, http.MethodDelete, "/tokens/t1", "", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = serve(t, func(m *tokenmocks.MockAPITokenService) {
+		m.EXPECT().RevokeToken(gomock.Any(), testUserID, "missing").Return(apitoken.ErrTokenNotFound)
+	}, http.MethodDelete, "/tokens/missing", "", nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
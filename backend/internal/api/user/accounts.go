@@ -0,0 +1,150 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// LinkedAccountsResponse lists the GitHub accounts linked alongside the primary account
+type LinkedAccountsResponse struct {
+	Accounts []models.LinkedAccount `json:"accounts"`
+}
+
+// LinkAccountRequest represents the request to link a GitHub account
+type LinkAccountRequest struct {
+	Token string `json:"token"`
+}
+
+// LinkAccountResponse represents the response after linking a GitHub account
+type LinkAccountResponse struct {
+	Account models.LinkedAccount `json:"account"`
+}
+
+// HandleListLinkedAccounts handles GET /api/user/accounts
+func (h *Handler) HandleListLinkedAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.authSvc.ListLinkedAccounts(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list linked accounts", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to list linked accounts")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, LinkedAccountsResponse{Accounts: accounts})
+}
+
+// HandleLinkAccount handles POST /api/user/accounts
+func (h *Handler) HandleLinkAccount(w http.ResponseWriter, r *http.Request) {
+	if h.tokenManager == nil {
+		helpers.WriteError(
+			w,
+			http.StatusServiceUnavailable,
+			"GitHub token management not configured",
+		)
+		return
+	}
+
+	var req LinkAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode link account request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Token) == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	ctx := r.Context()
+	linked, err := h.tokenManager.LinkAccount(ctx, req.Token)
+	if err != nil {
+		h.logger.Debug("failed to link GitHub account", zap.Error(err))
+		switch {
+		case errors.Is(err, coregithub.ErrNoPrimaryAccount),
+			errors.Is(err, coregithub.ErrAccountIsPrimary):
+			helpers.WriteError(w, http.StatusConflict, err.Error())
+		case strings.Contains(err.Error(), "rate limit"):
+			helpers.WriteError(
+				w,
+				http.StatusTooManyRequests,
+				"GitHub API rate limit exceeded. Please try again later.",
+			)
+		default:
+			helpers.WriteError(w, http.StatusBadRequest, "Failed to validate token: "+err.Error())
+		}
+		return
+	}
+
+	h.logger.Info("GitHub account linked",
+		zap.String("github_username", linked.Login),
+		zap.String("ip", getClientIP(r)),
+	)
+
+	// Fetch the new account's notifications without waiting for the next interval
+	if h.scheduler != nil {
+		if err := h.scheduler.EnqueueSyncNotifications(ctx, linked.UserID); err != nil {
+			h.logger.Warn("failed to enqueue sync after linking account", zap.Error(err))
+		}
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, LinkAccountResponse{
+		Account: models.LinkedAccount{
+			GithubUserID: linked.GithubUserID,
+			Login:        linked.Login,
+			LastSyncedAt: models.NullTimePtr(linked.LastSyncedAt),
+		},
+	})
+}
+
+// HandleUnlinkAccount handles DELETE /api/user/accounts/{login}
+func (h *Handler) HandleUnlinkAccount(w http.ResponseWriter, r *http.Request) {
+	if h.tokenManager == nil {
+		helpers.WriteError(
+			w,
+			http.StatusServiceUnavailable,
+			"GitHub token management not configured",
+		)
+		return
+	}
+
+	login := chi.URLParam(r, "login")
+	if err := h.tokenManager.UnlinkAccount(r.Context(), login); err != nil {
+		if errors.Is(err, coregithub.ErrAccountNotLinked) {
+			helpers.WriteError(w, http.StatusNotFound, "Account is not linked")
+			return
+		}
+		h.logger.Error("failed to unlink GitHub account", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to unlink account")
+		return
+	}
+
+	h.logger.Info("GitHub account unlinked",
+		zap.String("github_username", login),
+		zap.String("ip", getClientIP(r)),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// fakeAccountLinker implements the account linking part of TokenManagerInterface
+type fakeAccountLinker struct {
+	TokenManagerInterface
+	linkErr   error
+	unlinkErr error
+	unlinked  string
+}
+
+func (f *fakeAccountLinker) LinkAccount(_ context.Context, _ string) (db.LinkedAccount, error) {
+	if f.linkErr != nil {
+		return db.LinkedAccount{}, f.linkErr
+	}
+	return db.LinkedAccount{UserID: "test-user-id", GithubUserID: "5151", Login: "octocat-work"}, nil
+}
+
+func (f *fakeAccountLinker) UnlinkAccount(_ context.Context, login string) error {
+	if f.unlinkErr != nil {
+		return f.unlinkErr
+	}
+	f.unlinked = login
+	return nil
+}
+
+func TestHandler_HandleListLinkedAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		ListLinkedAccounts(gomock.Any()).
+		Return([]models.LinkedAccount{{GithubUserID: "5151", Login: "octocat-work"}}, nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleListLinkedAccounts(w, createRequest(http.MethodGet, "/api/user/accounts", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp LinkedAccountsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Accounts, 1)
+	require.Equal(t, "octocat-work", resp.Accounts[0].Login)
+}
+
+func TestHandler_HandleLinkAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		linkErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "links account",
+			body:           LinkAccountRequest{Token: "ghp_work"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing token returns 400",
+			body:           LinkAccountRequest{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "primary account returns 409",
+			body:           LinkAccountRequest{Token: "ghp_primary"},
+			linkErr:        coregithub.ErrAccountIsPrimary,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid token returns 400",
+			body:           LinkAccountRequest{Token: "ghp_bad"},
+			linkErr:        errors.New("token validation failed: invalid token: unauthorized"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _ := setupTestHandler(ctrl)
+			handler.WithTokenManager(&fakeAccountLinker{linkErr: tt.linkErr})
+
+			w := httptest.NewRecorder()
+			handler.HandleLinkAccount(w, createRequest(http.MethodPost, "/api/user/accounts", tt.body))
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var resp LinkAccountResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, "octocat-work", resp.Account.Login)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleUnlinkAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		unlinkErr      error
+		expectedStatus int
+	}{
+		{name: "unlinks account", expectedStatus: http.StatusNoContent},
+		{
+			name:           "unknown account returns 404",
+			unlinkErr:      coregithub.ErrAccountNotLinked,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _ := setupTestHandler(ctrl)
+			linker := &fakeAccountLinker{unlinkErr: tt.unlinkErr}
+			handler.WithTokenManager(linker)
+
+			req := createRequest(http.MethodDelete, "/api/user/accounts/octocat-work", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("login", "octocat-work")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.HandleUnlinkAccount(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.unlinkErr == nil {
+				require.Equal(t, "octocat-work", linker.unlinked)
+			}
+		})
+	}
+}
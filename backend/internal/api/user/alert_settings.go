@@ -0,0 +1,87 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// HandleGetAlertSettings handles GET /api/user/alert-settings
+func (h *Handler) HandleGetAlertSettings(w http.ResponseWriter, r *http.Request) {
+	if h.alertSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Alert settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.alertSvc.GetSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get alert settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get alert settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, settings)
+}
+
+// HandleUpdateAlertSettings handles PUT /api/user/alert-settings
+func (h *Handler) HandleUpdateAlertSettings(w http.ResponseWriter, r *http.Request) {
+	var req models.AlertSettings
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode alert settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.alertSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Alert settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	if req.Views == nil {
+		req.Views = map[string]models.AlertConfig{}
+	}
+	settings, err := h.alertSvc.UpdateSettings(ctx, userID, &req)
+	if err != nil {
+		if errors.Is(err, alert.ErrInvalidSettings) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update alert settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update alert settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	alertmocks "github.com/octobud-hq/octobud/backend/internal/core/alert/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupAlertHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *alertmocks.MockAlertService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockAlerts := alertmocks.NewMockAlertService(ctrl)
+	handler.WithAlertService(mockAlerts)
+	return handler, mockAlerts
+}
+
+func TestHandler_HandleGetAlertSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockAlerts := setupAlertHandler(t, ctrl)
+	settings := models.DefaultAlertSettings()
+	mockAlerts.EXPECT().GetSettings(gomock.Any(), "test-user-id").Return(settings, nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetAlertSettings(w, createRequest(http.MethodGet, "/api/user/alert-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.AlertSettings
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, models.AlertLevelDesktop, resp.Default.Level)
+}
+
+func TestHandler_HandleUpdateAlertSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*alertmocks.MockAlertService)
+		expectedStatus int
+	}{
+		{
+			name: "saves settings",
+			body: models.AlertSettings{
+				Default: models.AlertConfig{Level: models.AlertLevelBadge},
+			},
+			setupMock: func(m *alertmocks.MockAlertService) {
+				m.EXPECT().
+					UpdateSettings(gomock.Any(), "test-user-id", &models.AlertSettings{
+						Default: models.AlertConfig{Level: models.AlertLevelBadge},
+						Views:   map[string]models.AlertConfig{},
+					}).
+					DoAndReturn(func(_, _ interface{}, s *models.AlertSettings) (*models.AlertSettings, error) {
+						return s, nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid settings return 400",
+			body: models.AlertSettings{Default: models.AlertConfig{Level: "loud"}},
+			setupMock: func(m *alertmocks.MockAlertService) {
+				m.EXPECT().
+					UpdateSettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad level", alert.ErrInvalidSettings))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockAlerts := setupAlertHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockAlerts)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdateAlertSettings(
+				w, createRequest(http.MethodPut, "/api/user/alert-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// BackupSettingsResponse represents the response for backup settings
+type BackupSettingsResponse struct {
+	Enabled       bool `json:"enabled"`
+	IntervalHours int  `json:"intervalHours"`
+	Keep          int  `json:"keep"`
+}
+
+// BackupSettingsRequest represents the request for updating backup settings
+type BackupSettingsRequest struct {
+	Enabled       bool `json:"enabled"`
+	IntervalHours int  `json:"intervalHours"`
+	Keep          int  `json:"keep"`
+}
+
+// HandleGetBackupSettings handles GET /api/user/backup-settings
+func (h *Handler) HandleGetBackupSettings(w http.ResponseWriter, r *http.Request) {
+	if h.backupSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Backups not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.backupSvc.GetBackupSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get backup settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get backup settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, BackupSettingsResponse{
+		Enabled:       settings.Enabled,
+		IntervalHours: settings.IntervalHours,
+		Keep:          settings.Keep,
+	})
+}
+
+// HandleUpdateBackupSettings handles PUT /api/user/backup-settings
+func (h *Handler) HandleUpdateBackupSettings(w http.ResponseWriter, r *http.Request) {
+	var req BackupSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode backup settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.backupSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Backups not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	settings, err := h.backupSvc.UpdateBackupSettings(ctx, userID, &models.BackupSettings{
+		Enabled:       req.Enabled,
+		IntervalHours: req.IntervalHours,
+		Keep:          req.Keep,
+	})
+	if err != nil {
+		if errors.Is(err, backup.ErrInvalidSettings) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update backup settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update backup settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, BackupSettingsResponse{
+		Enabled:       settings.Enabled,
+		IntervalHours: settings.IntervalHours,
+		Keep:          settings.Keep,
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	backupmocks "github.com/octobud-hq/octobud/backend/internal/core/backup/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupBackupHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *backupmocks.MockBackupService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockBackup := backupmocks.NewMockBackupService(ctrl)
+	handler.WithBackupService(mockBackup)
+	return handler, mockBackup
+}
+
+func TestHandler_HandleGetBackupSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	handler, mockBackup := setupBackupHandler(t, ctrl)
+	mockBackup.EXPECT().
+		GetBackupSettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultBackupSettings(), nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetBackupSettings(w, createRequest(http.MethodGet, "/api/user/backup-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp BackupSettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, BackupSettingsResponse{Enabled: true, IntervalHours: 24, Keep: 7}, resp)
+}
+
+func TestHandler_HandleUpdateBackupSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*backupmocks.MockBackupService)
+		expectedStatus int
+	}{
+		{
+			name: "saves schedule",
+			body: BackupSettingsRequest{Enabled: true, IntervalHours: 6, Keep: 3},
+			setupMock: func(m *backupmocks.MockBackupService) {
+				settings := &models.BackupSettings{Enabled: true, IntervalHours: 6, Keep: 3}
+				m.EXPECT().
+					UpdateBackupSettings(gomock.Any(), "test-user-id", settings).
+					Return(settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid settings return 400",
+			body: BackupSettingsRequest{Enabled: true, IntervalHours: 0, Keep: 3},
+			setupMock: func(m *backupmocks.MockBackupService) {
+				m.EXPECT().
+					UpdateBackupSettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: intervalHours must be between 1 and 720", backup.ErrInvalidSettings))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockBackup := setupBackupHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockBackup)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdateBackupSettings(
+				w, createRequest(http.MethodPut, "/api/user/backup-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
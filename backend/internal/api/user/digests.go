@@ -0,0 +1,175 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// DigestSettingsResponse represents the response for digest settings
+type DigestSettingsResponse struct {
+	Enabled   bool   `json:"enabled"`
+	Frequency string `json:"frequency"`
+	Hour      int    `json:"hour"`
+	Weekday   int    `json:"weekday"`
+	Query     string `json:"query"`
+	EmailTo   string `json:"emailTo"`
+	// CanEmail is whether a mail server is configured, without which emailTo can't be set
+	CanEmail bool `json:"canEmail"`
+}
+
+// DigestSettingsRequest represents the request for updating digest settings
+type DigestSettingsRequest struct {
+	Enabled   bool   `json:"enabled"`
+	Frequency string `json:"frequency"`
+	Hour      int    `json:"hour"`
+	Weekday   int    `json:"weekday"`
+	Query     string `json:"query"`
+	EmailTo   string `json:"emailTo"`
+}
+
+// HandleGetDigestSettings handles GET /api/user/digest-settings
+func (h *Handler) HandleGetDigestSettings(w http.ResponseWriter, r *http.Request) {
+	if h.digestSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Digests not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.digestSvc.GetDigestSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get digest settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get digest settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, h.toDigestSettingsResponse(settings))
+}
+
+// HandleUpdateDigestSettings handles PUT /api/user/digest-settings
+func (h *Handler) HandleUpdateDigestSettings(w http.ResponseWriter, r *http.Request) {
+	var req DigestSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode digest settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.digestSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Digests not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	settings, err := h.digestSvc.UpdateDigestSettings(ctx, userID, &models.DigestSettings{
+		Enabled:   req.Enabled,
+		Frequency: req.Frequency,
+		Hour:      req.Hour,
+		Weekday:   req.Weekday,
+		Query:     req.Query,
+		EmailTo:   req.EmailTo,
+	})
+	if err != nil {
+		if errors.Is(err, digest.ErrInvalidSettings) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update digest settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update digest settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, h.toDigestSettingsResponse(settings))
+}
+
+// HandleGetLatestDigest handles GET /api/user/digests/latest
+func (h *Handler) HandleGetLatestDigest(w http.ResponseWriter, r *http.Request) {
+	if h.digestSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Digests not configured")
+		return
+	}
+
+	latest, err := h.digestSvc.Latest()
+	if err != nil {
+		if errors.Is(err, digest.ErrNoDigest) {
+			helpers.WriteError(w, http.StatusNotFound, "No digest has been generated yet")
+			return
+		}
+		h.logger.Error("failed to read latest digest", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to read latest digest")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, latest)
+}
+
+// HandleGenerateDigest handles POST /api/user/digests, generating a digest now and emailing
+// it when the settings have an address
+func (h *Handler) HandleGenerateDigest(w http.ResponseWriter, r *http.Request) {
+	if h.digestSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Digests not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	generated, err := h.digestSvc.Generate(ctx, userID)
+	if err != nil {
+		if errors.Is(err, digest.ErrFailedToEmailDigest) {
+			h.logger.Warn("failed to email digest", zap.Error(err))
+			helpers.WriteError(w, http.StatusBadGateway, "Digest was saved but couldn't be emailed")
+			return
+		}
+		h.logger.Error("failed to generate digest", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to generate digest")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, generated)
+}
+
+func (h *Handler) toDigestSettingsResponse(settings *models.DigestSettings) DigestSettingsResponse {
+	return DigestSettingsResponse{
+		Enabled:   settings.Enabled,
+		Frequency: settings.Frequency,
+		Hour:      settings.Hour,
+		Weekday:   settings.Weekday,
+		Query:     settings.Query,
+		EmailTo:   settings.EmailTo,
+		CanEmail:  h.digestSvc.CanEmail(),
+	}
+}
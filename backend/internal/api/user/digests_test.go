@@ -0,0 +1,186 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	digestmocks "github.com/octobud-hq/octobud/backend/internal/core/digest/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupDigestHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *digestmocks.MockDigestService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockDigest := digestmocks.NewMockDigestService(ctrl)
+	mockDigest.EXPECT().CanEmail().Return(true).AnyTimes()
+	handler.WithDigestService(mockDigest)
+	return handler, mockDigest
+}
+
+func TestHandler_HandleGetDigestSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	handler, mockDigest := setupDigestHandler(t, ctrl)
+	mockDigest.EXPECT().
+		GetDigestSettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultDigestSettings(), nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetDigestSettings(w, createRequest(http.MethodGet, "/api/user/digest-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp DigestSettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, models.DigestDaily, resp.Frequency)
+	require.Equal(t, "in:inbox is:unread", resp.Query)
+	require.True(t, resp.CanEmail)
+}
+
+func TestHandler_HandleUpdateDigestSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*digestmocks.MockDigestService)
+		expectedStatus int
+	}{
+		{
+			name: "saves schedule",
+			body: DigestSettingsRequest{Enabled: true, Frequency: "weekly", Hour: 9, Weekday: 1, Query: "is:unread"},
+			setupMock: func(m *digestmocks.MockDigestService) {
+				settings := &models.DigestSettings{
+					Enabled: true, Frequency: "weekly", Hour: 9, Weekday: 1, Query: "is:unread",
+				}
+				m.EXPECT().
+					UpdateDigestSettings(gomock.Any(), "test-user-id", settings).
+					Return(settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid settings return 400",
+			body: DigestSettingsRequest{Frequency: "hourly"},
+			setupMock: func(m *digestmocks.MockDigestService) {
+				m.EXPECT().
+					UpdateDigestSettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad frequency", digest.ErrInvalidSettings))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockDigest := setupDigestHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockDigest)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdateDigestSettings(
+				w, createRequest(http.MethodPut, "/api/user/digest-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_HandleGetLatestDigest(t *testing.T) {
+	t.Run("returns the latest digest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockDigest := setupDigestHandler(t, ctrl)
+		createdAt := time.Date(2025, time.March, 10, 13, 0, 0, 0, time.UTC)
+		mockDigest.EXPECT().Latest().Return(digest.Digest{
+			Name:      "digest-20250310T130000Z",
+			CreatedAt: createdAt,
+			Markdown:  "# Daily digest",
+		}, nil)
+
+		w := httptest.NewRecorder()
+		handler.HandleGetLatestDigest(w, createRequest(http.MethodGet, "/api/user/digests/latest", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp digest.Digest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, "# Daily digest", resp.Markdown)
+		require.True(t, createdAt.Equal(resp.CreatedAt))
+	})
+
+	t.Run("404 before the first digest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockDigest := setupDigestHandler(t, ctrl)
+		mockDigest.EXPECT().Latest().Return(digest.Digest{}, digest.ErrNoDigest)
+
+		w := httptest.NewRecorder()
+		handler.HandleGetLatestDigest(w, createRequest(http.MethodGet, "/api/user/digests/latest", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_HandleGenerateDigest(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "generates a digest", expectedStatus: http.StatusCreated},
+		{
+			name:           "email failure returns 502",
+			err:            errors.Join(digest.ErrFailedToEmailDigest, errors.New("connection refused")),
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "generation failure returns 500",
+			err:            digest.ErrFailedToGenerateDigest,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			handler, mockDigest := setupDigestHandler(t, ctrl)
+			mockDigest.EXPECT().
+				Generate(gomock.Any(), "test-user-id").
+				Return(digest.Digest{Name: "digest-20250310T130000Z"}, tt.err)
+
+			w := httptest.NewRecorder()
+			handler.HandleGenerateDigest(w, createRequest(http.MethodPost, "/api/user/digests", nil))
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
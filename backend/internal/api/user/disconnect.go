@@ -0,0 +1,216 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	gosync "sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// Disconnect states reported by the status endpoint
+const (
+	DisconnectStateIdle      = "idle"
+	DisconnectStateRunning   = "running"
+	DisconnectStateCompleted = "completed"
+	DisconnectStateFailed    = "failed"
+)
+
+// DisconnectRequest represents the request to disconnect GitHub
+type DisconnectRequest struct {
+	Mode       string `json:"mode"`       // "full_wipe", "keep_triage", or "keep_starred_tagged"
+	ClearToken bool   `json:"clearToken"` // Also remove the stored GitHub token
+}
+
+// DisconnectStatusResponse represents the progress of a disconnect
+type DisconnectStatusResponse struct {
+	State          string  `json:"state"`
+	Mode           string  `json:"mode,omitempty"`
+	CurrentStep    string  `json:"currentStep,omitempty"`
+	StepsCompleted int     `json:"stepsCompleted"`
+	TotalSteps     int     `json:"totalSteps"`
+	RowsAffected   int64   `json:"rowsAffected"`
+	Error          string  `json:"error,omitempty"`
+	StartedAt      *string `json:"startedAt,omitempty"`
+	CompletedAt    *string `json:"completedAt,omitempty"`
+}
+
+// disconnectTracker holds the progress of the most recent disconnect.
+// Only one disconnect may run at a time.
+type disconnectTracker struct {
+	mu     gosync.Mutex
+	status DisconnectStatusResponse
+}
+
+func newDisconnectTracker() *disconnectTracker {
+	return &disconnectTracker{
+		status: DisconnectStatusResponse{State: DisconnectStateIdle},
+	}
+}
+
+// start marks a disconnect as running, returning false if one is already in progress
+func (t *disconnectTracker) start(mode db.DisconnectMode) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.status.State == DisconnectStateRunning {
+		return false
+	}
+
+	startedAt := time.Now().UTC().Format(time.RFC3339)
+	t.status = DisconnectStatusResponse{
+		State:     DisconnectStateRunning,
+		Mode:      string(mode),
+		StartedAt: &startedAt,
+	}
+	return true
+}
+
+func (t *disconnectTracker) progress(p db.DeleteGitHubDataProgress) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The store restarts from the first step if its transaction is retried
+	if p.StepsCompleted == 1 {
+		t.status.RowsAffected = 0
+	}
+	t.status.CurrentStep = p.Step
+	t.status.StepsCompleted = p.StepsCompleted
+	t.status.TotalSteps = p.TotalSteps
+	t.status.RowsAffected += p.RowsAffected
+}
+
+func (t *disconnectTracker) finish(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	completedAt := time.Now().UTC().Format(time.RFC3339)
+	t.status.CompletedAt = &completedAt
+	t.status.CurrentStep = ""
+	if err != nil {
+		t.status.State = DisconnectStateFailed
+		t.status.Error = err.Error()
+		return
+	}
+	t.status.State = DisconnectStateCompleted
+}
+
+func (t *disconnectTracker) snapshot() DisconnectStatusResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// HandleDisconnect handles POST /api/user/disconnect
+// Starts deleting GitHub data in the background according to the chosen retention mode.
+// Progress is available from GET /api/user/disconnect/status.
+func (h *Handler) HandleDisconnect(w http.ResponseWriter, r *http.Request) {
+	if h.store == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Database store not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req DisconnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode disconnect request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	mode := db.DisconnectMode(req.Mode)
+	if !mode.Valid() {
+		helpers.WriteError(
+			w,
+			http.StatusBadRequest,
+			"Mode must be one of full_wipe, keep_triage, keep_starred_tagged",
+		)
+		return
+	}
+
+	if req.ClearToken && h.tokenManager == nil {
+		helpers.WriteError(
+			w,
+			http.StatusServiceUnavailable,
+			"GitHub token management not configured",
+		)
+		return
+	}
+
+	if !h.disconnect.start(mode) {
+		helpers.WriteError(w, http.StatusConflict, "A disconnect is already in progress")
+		return
+	}
+
+	h.logger.Info("Starting GitHub disconnect",
+		zap.String("user_id", userID),
+		zap.String("mode", string(mode)),
+		zap.Bool("clear_token", req.ClearToken),
+		zap.String("ip", getClientIP(r)),
+	)
+
+	// Use a background context so the disconnect isn't canceled when the request completes
+	go h.runDisconnect(context.Background(), userID, mode, req.ClearToken)
+
+	helpers.WriteJSON(w, http.StatusAccepted, h.disconnect.snapshot())
+}
+
+// HandleGetDisconnectStatus handles GET /api/user/disconnect/status
+func (h *Handler) HandleGetDisconnectStatus(w http.ResponseWriter, _ *http.Request) {
+	helpers.WriteJSON(w, http.StatusOK, h.disconnect.snapshot())
+}
+
+// runDisconnect clears the token (so sync stops) and then deletes GitHub data
+func (h *Handler) runDisconnect(
+	ctx context.Context,
+	userID string,
+	mode db.DisconnectMode,
+	clearToken bool,
+) {
+	if clearToken {
+		if err := h.tokenManager.ClearToken(ctx); err != nil {
+			h.logger.Error("failed to clear GitHub token during disconnect", zap.Error(err))
+			h.disconnect.finish(err)
+			return
+		}
+	}
+
+	err := h.store.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+		Mode:       mode,
+		OnProgress: h.disconnect.progress,
+	})
+	if err != nil {
+		h.logger.Error("failed to delete GitHub data during disconnect", zap.Error(err))
+	} else {
+		h.logger.Info("GitHub disconnect completed",
+			zap.String("user_id", userID),
+			zap.String("mode", string(mode)),
+		)
+	}
+	h.disconnect.finish(err)
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func getDisconnectStatus(t *testing.T, h *Handler) DisconnectStatusResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := createRequest(http.MethodGet, "/api/user/disconnect/status", nil)
+	h.HandleGetDisconnectStatus(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var status DisconnectStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	return status
+}
+
+func waitForDisconnect(t *testing.T, h *Handler) DisconnectStatusResponse {
+	t.Helper()
+	var status DisconnectStatusResponse
+	require.Eventually(t, func() bool {
+		status = getDisconnectStatus(t, h)
+		return status.State != DisconnectStateRunning
+	}, time.Second, 5*time.Millisecond)
+	return status
+}
+
+func TestHandler_HandleDisconnect_InvalidMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	handler.WithStore(dbmocks.NewMockStore(ctrl))
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleDisconnect(
+		w,
+		createRequest(http.MethodPost, "/api/user/disconnect", DisconnectRequest{Mode: "keep_all"}),
+	)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, DisconnectStateIdle, getDisconnectStatus(t, handler).State)
+}
+
+func TestHandler_HandleDisconnect_ReportsProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	handler.WithStore(mockStore)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil)
+
+	mockStore.EXPECT().
+		DeleteGitHubData(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.DeleteGitHubDataParams) error {
+			require.Equal(t, db.DisconnectModeKeepStarredTagged, params.Mode)
+			params.OnProgress(db.DeleteGitHubDataProgress{
+				Step:           "Deleting notifications that are not starred or tagged",
+				StepsCompleted: 1,
+				TotalSteps:     2,
+				RowsAffected:   40,
+			})
+			params.OnProgress(db.DeleteGitHubDataProgress{
+				Step:           "Deleting sync state",
+				StepsCompleted: 2,
+				TotalSteps:     2,
+				RowsAffected:   1,
+			})
+			return nil
+		})
+
+	w := httptest.NewRecorder()
+	handler.HandleDisconnect(w, createRequest(
+		http.MethodPost,
+		"/api/user/disconnect",
+		DisconnectRequest{Mode: string(db.DisconnectModeKeepStarredTagged)},
+	))
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	status := waitForDisconnect(t, handler)
+	require.Equal(t, DisconnectStateCompleted, status.State)
+	require.Equal(t, string(db.DisconnectModeKeepStarredTagged), status.Mode)
+	require.Equal(t, 2, status.StepsCompleted)
+	require.Equal(t, 2, status.TotalSteps)
+	require.Equal(t, int64(41), status.RowsAffected)
+	require.NotNil(t, status.CompletedAt)
+}
+
+func TestHandler_HandleDisconnect_Failure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	handler.WithStore(mockStore)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil)
+
+	mockStore.EXPECT().
+		DeleteGitHubData(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(errors.New("database is locked"))
+
+	w := httptest.NewRecorder()
+	handler.HandleDisconnect(w, createRequest(
+		http.MethodPost,
+		"/api/user/disconnect",
+		DisconnectRequest{Mode: string(db.DisconnectModeFullWipe)},
+	))
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	status := waitForDisconnect(t, handler)
+	require.Equal(t, DisconnectStateFailed, status.State)
+	require.Equal(t, "database is locked", status.Error)
+}
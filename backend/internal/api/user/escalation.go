@@ -0,0 +1,149 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Bounds for how long a review request may sit unread before it is escalated
+const (
+	minEscalationDays = 1
+	maxEscalationDays = 90
+)
+
+// EscalationSettingsResponse represents the response for escalation settings
+type EscalationSettingsResponse struct {
+	Enabled      bool   `json:"enabled"`
+	AfterDays    int    `json:"afterDays"`
+	TagEscalated bool   `json:"tagEscalated"`
+	LastRunAt    string `json:"lastRunAt,omitempty"`
+}
+
+// EscalationSettingsRequest represents the request for updating escalation settings
+type EscalationSettingsRequest struct {
+	Enabled      bool `json:"enabled"`
+	AfterDays    int  `json:"afterDays"`
+	TagEscalated bool `json:"tagEscalated"`
+}
+
+// HandleGetEscalationSettings handles GET /api/user/escalation-settings
+func (h *Handler) HandleGetEscalationSettings(w http.ResponseWriter, r *http.Request) {
+	escalationHandler := h.getEscalationHandler()
+	if escalationHandler == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Escalation handler not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := escalationHandler.GetEscalationSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get escalation settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get escalation settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, escalationSettingsResponse(settings))
+}
+
+// HandleUpdateEscalationSettings handles PUT /api/user/escalation-settings
+func (h *Handler) HandleUpdateEscalationSettings(w http.ResponseWriter, r *http.Request) {
+	var req EscalationSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode escalation settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.AfterDays < minEscalationDays || req.AfterDays > maxEscalationDays {
+		helpers.WriteError(w, http.StatusBadRequest, "afterDays must be between 1 and 90")
+		return
+	}
+
+	escalationHandler := h.getEscalationHandler()
+	if escalationHandler == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Escalation handler not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	// Get existing settings to preserve lastRunAt
+	existingSettings, err := escalationHandler.GetEscalationSettings(ctx, userID)
+	if err != nil {
+		// Log but continue - we'll just use empty lastRunAt
+		h.logger.Warn("failed to get existing escalation settings", zap.Error(err))
+	}
+	var lastRunAt string
+	if existingSettings != nil {
+		lastRunAt = existingSettings.LastRunAt
+	}
+
+	settings := &models.EscalationSettings{
+		Enabled:      req.Enabled,
+		AfterDays:    req.AfterDays,
+		TagEscalated: req.TagEscalated,
+		LastRunAt:    lastRunAt,
+	}
+
+	if err := escalationHandler.UpdateEscalationSettings(ctx, userID, settings); err != nil {
+		h.logger.Error("failed to update escalation settings", zap.Error(err))
+		helpers.WriteError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to update escalation settings",
+		)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, escalationSettingsResponse(settings))
+}
+
+func escalationSettingsResponse(settings *models.EscalationSettings) EscalationSettingsResponse {
+	return EscalationSettingsResponse{
+		Enabled:      settings.Enabled,
+		AfterDays:    settings.AfterDays,
+		TagEscalated: settings.TagEscalated,
+		LastRunAt:    settings.LastRunAt,
+	}
+}
+
+// getEscalationHandler retrieves the escalation handler from the scheduler
+func (h *Handler) getEscalationHandler() *jobs.EscalateReviewRequestsHandler {
+	if h.scheduler == nil {
+		return nil
+	}
+
+	if sqliteScheduler, ok := h.scheduler.(*jobs.SQLiteScheduler); ok {
+		return sqliteScheduler.GetEscalationHandler()
+	}
+
+	return nil
+}
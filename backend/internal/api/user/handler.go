@@ -29,9 +29,17 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
 	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
 	"github.com/octobud-hq/octobud/backend/internal/core/syncstate"
+	"github.com/octobud-hq/octobud/backend/internal/core/team"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
 	"github.com/octobud-hq/octobud/backend/internal/core/update"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/jobs"
 	"github.com/octobud-hq/octobud/backend/internal/osactions"
@@ -47,6 +55,15 @@ type Handler struct {
 	tokenManager  TokenManagerInterface      // For GitHub token management
 	updateService *update.Service            // For update checking
 	osActionsSvc  osactions.Service          // For OS-specific actions (restart, browser tabs, etc.)
+	disconnect    *disconnectTracker         // Progress of the most recent GitHub disconnect
+	workHoursSvc  workhours.WorkingHoursService
+	teamSvc       team.TeamService
+	triageSvc     triage.TriageService
+	alertSvc      alert.AlertService
+	snoozeSvc     snooze.SnoozeService
+	backupSvc     backup.BackupService
+	prioritySvc   priority.PriorityService
+	digestSvc     digest.DigestService
 }
 
 // New creates a new user handler
@@ -55,8 +72,9 @@ func New(
 	authSvc authsvc.AuthService,
 ) *Handler {
 	return &Handler{
-		logger:  logger,
-		authSvc: authSvc,
+		logger:     logger,
+		authSvc:    authSvc,
+		disconnect: newDisconnectTracker(),
 	}
 }
 
@@ -83,6 +101,8 @@ type TokenManagerInterface interface {
 	SetToken(ctx context.Context, token string) (string, error)
 	SetTokenFromOAuth(ctx context.Context, token string) (string, error)
 	ClearToken(ctx context.Context) error
+	LinkAccount(ctx context.Context, token string) (db.LinkedAccount, error)
+	UnlinkAccount(ctx context.Context, login string) error
 }
 
 // WithTokenManager sets the token manager for GitHub token operations
@@ -109,6 +129,54 @@ func (h *Handler) WithOSActionsService(service osactions.Service) *Handler {
 	return h
 }
 
+// WithWorkingHoursService sets the working hours service for business-day calculations
+func (h *Handler) WithWorkingHoursService(service workhours.WorkingHoursService) *Handler {
+	h.workHoursSvc = service
+	return h
+}
+
+// WithTeamService sets the team service for team member settings
+func (h *Handler) WithTeamService(service team.TeamService) *Handler {
+	h.teamSvc = service
+	return h
+}
+
+// WithTriageService sets the triage service for exporting and importing triage state
+func (h *Handler) WithTriageService(service triage.TriageService) *Handler {
+	h.triageSvc = service
+	return h
+}
+
+// WithAlertService sets the alert service for alert levels, sounds, and quiet hours
+func (h *Handler) WithAlertService(service alert.AlertService) *Handler {
+	h.alertSvc = service
+	return h
+}
+
+// WithSnoozeService sets the snooze service for the default snooze preset
+func (h *Handler) WithSnoozeService(service snooze.SnoozeService) *Handler {
+	h.snoozeSvc = service
+	return h
+}
+
+// WithBackupService sets the backup service for the backup schedule
+func (h *Handler) WithBackupService(service backup.BackupService) *Handler {
+	h.backupSvc = service
+	return h
+}
+
+// WithPriorityService sets the priority service for the priority weights
+func (h *Handler) WithPriorityService(service priority.PriorityService) *Handler {
+	h.prioritySvc = service
+	return h
+}
+
+// WithDigestService sets the digest service for the digest schedule and generated digests
+func (h *Handler) WithDigestService(service digest.DigestService) *Handler {
+	h.digestSvc = service
+	return h
+}
+
 // Register registers user routes on the provided router.
 func (h *Handler) Register(r chi.Router) {
 	r.Route("/user", func(r chi.Router) {
@@ -118,7 +186,9 @@ func (h *Handler) Register(r chi.Router) {
 		// Sync settings
 		r.Get("/sync-settings", h.HandleGetSyncSettings)
 		r.Put("/sync-settings", h.HandleUpdateSyncSettings)
+		r.Get("/sync-settings/orgs", h.HandleListSyncOrgs)
 		r.Get("/sync-state", h.HandleGetSyncState)
+		r.Post("/sync", h.HandleSyncNow)
 		r.Post("/sync-older", h.HandleSyncOlder)
 
 		// GitHub token management
@@ -126,13 +196,64 @@ func (h *Handler) Register(r chi.Router) {
 		r.Put("/github-token", h.HandleSetGitHubToken)
 		r.Delete("/github-token", h.HandleClearGitHubToken)
 
+		// Additional GitHub accounts synced alongside the primary one
+		r.Get("/accounts", h.HandleListLinkedAccounts)
+		r.Post("/accounts", h.HandleLinkAccount)
+		r.Delete("/accounts/{login}", h.HandleUnlinkAccount)
+
 		// Storage management
 		r.Get("/storage-stats", h.HandleGetStorageStats)
 		r.Get("/retention-settings", h.HandleGetRetentionSettings)
 		r.Put("/retention-settings", h.HandleUpdateRetentionSettings)
 		r.Post("/cleanup", h.HandleRunCleanup)
 		r.Get("/cleanup-preview", h.HandleGetEligibleForCleanup)
+		r.Get("/cleanup-preview/items", h.HandleGetCleanupPreviewItems)
+		r.Post("/cleanup/approve", h.HandleApproveCleanup)
+		r.Post("/repositories/merge-duplicates", h.HandleMergeDuplicateRepositories)
 		r.Delete("/github-data", h.HandleDeleteAllGitHubData)
+		r.Post("/disconnect", h.HandleDisconnect)
+		r.Get("/disconnect/status", h.HandleGetDisconnectStatus)
+
+		// Triage backups, for restoring read/archive/tag state after a reset
+		r.Get("/triage-export", h.HandleExportTriage)
+		r.Post("/triage-import", h.HandleImportTriage)
+		r.Delete("/triage-import", h.HandleDiscardTriageImport)
+
+		// Review request escalation
+		r.Get("/escalation-settings", h.HandleGetEscalationSettings)
+		r.Put("/escalation-settings", h.HandleUpdateEscalationSettings)
+
+		// Working hours
+		r.Get("/working-hours", h.HandleGetWorkingHours)
+		r.Put("/working-hours", h.HandleUpdateWorkingHours)
+		r.Get("/working-hours/next-business-day", h.HandleGetNextBusinessDay)
+		r.Get("/working-hours/add-business-days", h.HandleAddBusinessDays)
+
+		// Default snooze when the snooze API is called without a time
+		r.Get("/snooze-settings", h.HandleGetSnoozeSettings)
+		r.Put("/snooze-settings", h.HandleUpdateSnoozeSettings)
+
+		// Scheduled database backups
+		r.Get("/backup-settings", h.HandleGetBackupSettings)
+		r.Put("/backup-settings", h.HandleUpdateBackupSettings)
+
+		// Priority weights for scoring notifications
+		r.Get("/priority-settings", h.HandleGetPrioritySettings)
+		r.Put("/priority-settings", h.HandleUpdatePrioritySettings)
+
+		// Periodic notification digests
+		r.Get("/digest-settings", h.HandleGetDigestSettings)
+		r.Put("/digest-settings", h.HandleUpdateDigestSettings)
+		r.Get("/digests/latest", h.HandleGetLatestDigest)
+		r.Post("/digests", h.HandleGenerateDigest)
+
+		// Team members for review load reporting
+		r.Get("/team-settings", h.HandleGetTeamSettings)
+		r.Put("/team-settings", h.HandleUpdateTeamSettings)
+
+		// Alert levels, sounds, and quiet hours
+		r.Get("/alert-settings", h.HandleGetAlertSettings)
+		r.Put("/alert-settings", h.HandleUpdateAlertSettings)
 
 		// Mute management
 		r.Get("/mute-status", h.HandleGetMuteStatus)
@@ -167,12 +288,8 @@ func (h *Handler) HandleGetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 	// Include sync settings if they exist
 	if user.SyncSettings != nil {
-		response.SyncSettings = &SyncSettingsResponse{
-			InitialSyncDays:       user.SyncSettings.InitialSyncDays,
-			InitialSyncMaxCount:   user.SyncSettings.InitialSyncMaxCount,
-			InitialSyncUnreadOnly: user.SyncSettings.InitialSyncUnreadOnly,
-			SetupCompleted:        user.SyncSettings.SetupCompleted,
-		}
+		syncSettings := newSyncSettingsResponse(user.SyncSettings)
+		response.SyncSettings = &syncSettings
 	}
 
 	helpers.WriteJSON(w, http.StatusOK, response)
@@ -244,7 +361,8 @@ func (h *Handler) HandleSetGitHubToken(w http.ResponseWriter, r *http.Request) {
 			helpers.WriteError(
 				w,
 				http.StatusBadRequest,
-				"Token does not have required permissions (needs 'repo', 'notifications', and 'read:discussions' scopes)",
+				"Token does not have required permissions "+
+					"(needs 'repo', 'notifications', and 'read:discussions' scopes)",
 			)
 			return
 		}
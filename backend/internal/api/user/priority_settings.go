@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// PrioritySettingsResponse represents the response for priority settings
+type PrioritySettingsResponse struct {
+	Reasons          map[string]int `json:"reasons"`
+	Repositories     map[string]int `json:"repositories"`
+	Authors          map[string]int `json:"authors"`
+	CIStates         map[string]int `json:"ciStates"`
+	StalenessPerDay  int            `json:"stalenessPerDay"`
+	MaxStalenessDays int            `json:"maxStalenessDays"`
+}
+
+// PrioritySettingsRequest represents the request for updating priority settings
+type PrioritySettingsRequest struct {
+	Reasons          map[string]int `json:"reasons"`
+	Repositories     map[string]int `json:"repositories"`
+	Authors          map[string]int `json:"authors"`
+	CIStates         map[string]int `json:"ciStates"`
+	StalenessPerDay  int            `json:"stalenessPerDay"`
+	MaxStalenessDays int            `json:"maxStalenessDays"`
+}
+
+// HandleGetPrioritySettings handles GET /api/user/priority-settings
+func (h *Handler) HandleGetPrioritySettings(w http.ResponseWriter, r *http.Request) {
+	if h.prioritySvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Priority settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.prioritySvc.GetPrioritySettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get priority settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get priority settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, toPrioritySettingsResponse(settings))
+}
+
+// HandleUpdatePrioritySettings handles PUT /api/user/priority-settings
+func (h *Handler) HandleUpdatePrioritySettings(w http.ResponseWriter, r *http.Request) {
+	var req PrioritySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode priority settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.prioritySvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Priority settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	settings, err := h.prioritySvc.UpdatePrioritySettings(ctx, userID, &models.PrioritySettings{
+		Reasons:          req.Reasons,
+		Repositories:     req.Repositories,
+		Authors:          req.Authors,
+		CIStates:         req.CIStates,
+		StalenessPerDay:  req.StalenessPerDay,
+		MaxStalenessDays: req.MaxStalenessDays,
+	})
+	if err != nil {
+		if errors.Is(err, priority.ErrInvalidPrioritySettings) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update priority settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update priority settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, toPrioritySettingsResponse(settings))
+}
+
+func toPrioritySettingsResponse(settings *models.PrioritySettings) PrioritySettingsResponse {
+	return PrioritySettingsResponse{
+		Reasons:          settings.Reasons,
+		Repositories:     settings.Repositories,
+		Authors:          settings.Authors,
+		CIStates:         settings.CIStates,
+		StalenessPerDay:  settings.StalenessPerDay,
+		MaxStalenessDays: settings.MaxStalenessDays,
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
+	prioritymocks "github.com/octobud-hq/octobud/backend/internal/core/priority/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupPriorityHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *prioritymocks.MockPriorityService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockPriority := prioritymocks.NewMockPriorityService(ctrl)
+	handler.WithPriorityService(mockPriority)
+	return handler, mockPriority
+}
+
+func TestHandler_HandleGetPrioritySettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	handler, mockPriority := setupPriorityHandler(t, ctrl)
+	mockPriority.EXPECT().
+		GetPrioritySettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultPrioritySettings(), nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetPrioritySettings(w, createRequest(http.MethodGet, "/api/user/priority-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp PrioritySettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 30, resp.Reasons["review_requested"])
+	require.Equal(t, 14, resp.MaxStalenessDays)
+}
+
+func TestHandler_HandleUpdatePrioritySettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*prioritymocks.MockPriorityService)
+		expectedStatus int
+	}{
+		{
+			name: "saves weights",
+			body: PrioritySettingsRequest{
+				Repositories:     map[string]int{"cli/cli": 20},
+				StalenessPerDay:  2,
+				MaxStalenessDays: 7,
+			},
+			setupMock: func(m *prioritymocks.MockPriorityService) {
+				settings := &models.PrioritySettings{
+					Repositories:     map[string]int{"cli/cli": 20},
+					StalenessPerDay:  2,
+					MaxStalenessDays: 7,
+				}
+				m.EXPECT().
+					UpdatePrioritySettings(gomock.Any(), "test-user-id", settings).
+					Return(settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid weights return 400",
+			body: PrioritySettingsRequest{Repositories: map[string]int{"cli": 20}},
+			setupMock: func(m *prioritymocks.MockPriorityService) {
+				m.EXPECT().
+					UpdatePrioritySettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad repository", priority.ErrInvalidPrioritySettings))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockPriority := setupPriorityHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockPriority)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdatePrioritySettings(
+				w, createRequest(http.MethodPut, "/api/user/priority-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 
 package user
 
+import "github.com/octobud-hq/octobud/backend/internal/models"
+
 // UserResponse represents the current user information
 // Identity is based on GitHub - no local username/password
 type UserResponse struct {
@@ -29,6 +31,27 @@ type SyncSettingsResponse struct {
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
 	SetupCompleted        bool `json:"setupCompleted"`
+	WebhooksEnabled       bool `json:"webhooksEnabled"`
+	// ResurfaceSuppressionMinutes is how long after an archive new activity stays out of the inbox
+	ResurfaceSuppressionMinutes int `json:"resurfaceSuppressionMinutes"`
+	// AwaitingReplyDays is how long a thread waits on the user's comment before it counts as awaiting a reply
+	AwaitingReplyDays int `json:"awaitingReplyDays"`
+	// ResurfaceAwaitingReplies brings threads back to the inbox once they are awaiting a reply
+	ResurfaceAwaitingReplies bool `json:"resurfaceAwaitingReplies"`
+	// SkipPayloadStorage drops raw GitHub payloads once their metadata is extracted
+	SkipPayloadStorage bool `json:"skipPayloadStorage"`
+	// SkipCommentCaching keeps the body of the latest comment out of storage
+	SkipCommentCaching bool `json:"skipCommentCaching"`
+	// TitlesOnly stops sync from fetching subjects and comments at all
+	TitlesOnly bool `json:"titlesOnly"`
+	// MarkOnGitHub marks threads read on GitHub when they're read here, and done when archived
+	MarkOnGitHub bool `json:"markOnGitHub"`
+	// OrgAllowlist limits sync to these orgs; empty syncs every org not blocked
+	OrgAllowlist []string `json:"orgAllowlist"`
+	// OrgBlocklist lists orgs whose notifications are never synced
+	OrgBlocklist []string `json:"orgBlocklist"`
+	// Capabilities reports which features the storage settings leave available
+	Capabilities models.StorageCapabilities `json:"capabilities"`
 }
 
 // SyncSettingsRequest represents the request to update sync settings
@@ -37,6 +60,38 @@ type SyncSettingsRequest struct {
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
 	SetupCompleted        bool `json:"setupCompleted"`
+	// WebhooksEnabled is left unchanged when omitted
+	WebhooksEnabled *bool `json:"webhooksEnabled,omitempty"`
+	// ResurfaceSuppressionMinutes is left unchanged when omitted
+	ResurfaceSuppressionMinutes *int `json:"resurfaceSuppressionMinutes,omitempty"`
+	// AwaitingReplyDays is left unchanged when omitted
+	AwaitingReplyDays *int `json:"awaitingReplyDays,omitempty"`
+	// ResurfaceAwaitingReplies is left unchanged when omitted
+	ResurfaceAwaitingReplies *bool `json:"resurfaceAwaitingReplies,omitempty"`
+	// SkipPayloadStorage is left unchanged when omitted
+	SkipPayloadStorage *bool `json:"skipPayloadStorage,omitempty"`
+	// SkipCommentCaching is left unchanged when omitted
+	SkipCommentCaching *bool `json:"skipCommentCaching,omitempty"`
+	// TitlesOnly is left unchanged when omitted
+	TitlesOnly *bool `json:"titlesOnly,omitempty"`
+	// MarkOnGitHub is left unchanged when omitted
+	MarkOnGitHub *bool `json:"markOnGitHub,omitempty"`
+	// OrgAllowlist is left unchanged when omitted
+	OrgAllowlist *[]string `json:"orgAllowlist,omitempty"`
+	// OrgBlocklist is left unchanged when omitted
+	OrgBlocklist *[]string `json:"orgBlocklist,omitempty"`
+}
+
+// SyncOrg is an org whose repositories have notifications, or that the sync settings name
+type SyncOrg struct {
+	Login        string `json:"login"`
+	Repositories int    `json:"repositories"` // Repositories of the org seen so far
+	Synced       bool   `json:"synced"`       // Whether the org filter lets its notifications sync
+}
+
+// SyncOrgsResponse lists the orgs the org filter can choose from
+type SyncOrgsResponse struct {
+	Orgs []SyncOrg `json:"orgs"`
 }
 
 // SyncOlderRequest represents the request to sync older notifications
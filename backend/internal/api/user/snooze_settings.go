@@ -0,0 +1,105 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// SnoozeSettingsResponse represents the response for snooze settings
+type SnoozeSettingsResponse struct {
+	DefaultPreset      string `json:"defaultPreset"`
+	MarkUnreadOnReturn bool   `json:"markUnreadOnReturn"`
+}
+
+// SnoozeSettingsRequest represents the request for updating snooze settings
+type SnoozeSettingsRequest struct {
+	DefaultPreset      string `json:"defaultPreset"`
+	MarkUnreadOnReturn bool   `json:"markUnreadOnReturn"`
+}
+
+// HandleGetSnoozeSettings handles GET /api/user/snooze-settings
+func (h *Handler) HandleGetSnoozeSettings(w http.ResponseWriter, r *http.Request) {
+	if h.snoozeSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Snooze settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.snoozeSvc.GetSnoozeSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get snooze settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get snooze settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, SnoozeSettingsResponse{
+		DefaultPreset:      settings.DefaultPreset,
+		MarkUnreadOnReturn: settings.MarkUnreadOnReturn,
+	})
+}
+
+// HandleUpdateSnoozeSettings handles PUT /api/user/snooze-settings
+func (h *Handler) HandleUpdateSnoozeSettings(w http.ResponseWriter, r *http.Request) {
+	var req SnoozeSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode snooze settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.snoozeSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Snooze settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	settings, err := h.snoozeSvc.UpdateSnoozeSettings(ctx, userID, &models.SnoozeSettings{
+		DefaultPreset:      req.DefaultPreset,
+		MarkUnreadOnReturn: req.MarkUnreadOnReturn,
+	})
+	if err != nil {
+		if errors.Is(err, snooze.ErrInvalidSnooze) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update snooze settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update snooze settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, SnoozeSettingsResponse{
+		DefaultPreset:      settings.DefaultPreset,
+		MarkUnreadOnReturn: settings.MarkUnreadOnReturn,
+	})
+}
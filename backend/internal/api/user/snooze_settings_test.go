@@ -0,0 +1,124 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	snoozemocks "github.com/octobud-hq/octobud/backend/internal/core/snooze/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupSnoozeHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *snoozemocks.MockSnoozeService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockSnooze := snoozemocks.NewMockSnoozeService(ctrl)
+	handler.WithSnoozeService(mockSnooze)
+	return handler, mockSnooze
+}
+
+func TestHandler_HandleGetSnoozeSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	handler, mockSnooze := setupSnoozeHandler(t, ctrl)
+	mockSnooze.EXPECT().
+		GetSnoozeSettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultSnoozeSettings(), nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetSnoozeSettings(w, createRequest(http.MethodGet, "/api/user/snooze-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp SnoozeSettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "tomorrow", resp.DefaultPreset)
+}
+
+func TestHandler_HandleUpdateSnoozeSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*snoozemocks.MockSnoozeService)
+		expectedStatus int
+	}{
+		{
+			name: "saves default preset",
+			body: SnoozeSettingsRequest{DefaultPreset: "next_business_day"},
+			setupMock: func(m *snoozemocks.MockSnoozeService) {
+				settings := &models.SnoozeSettings{DefaultPreset: "next_business_day"}
+				m.EXPECT().
+					UpdateSnoozeSettings(gomock.Any(), "test-user-id", settings).
+					Return(settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "saves mark unread on return",
+			body: SnoozeSettingsRequest{DefaultPreset: "tomorrow", MarkUnreadOnReturn: true},
+			setupMock: func(m *snoozemocks.MockSnoozeService) {
+				settings := &models.SnoozeSettings{DefaultPreset: "tomorrow", MarkUnreadOnReturn: true}
+				m.EXPECT().
+					UpdateSnoozeSettings(gomock.Any(), "test-user-id", settings).
+					Return(settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid preset returns 400",
+			body: SnoozeSettingsRequest{DefaultPreset: "someday"},
+			setupMock: func(m *snoozemocks.MockSnoozeService) {
+				m.EXPECT().
+					UpdateSnoozeSettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad preset", snooze.ErrInvalidSnooze))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockSnooze := setupSnoozeHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockSnooze)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdateSnoozeSettings(
+				w, createRequest(http.MethodPut, "/api/user/snooze-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
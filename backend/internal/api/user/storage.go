@@ -17,12 +17,16 @@ package user
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
@@ -67,6 +71,52 @@ type CleanupResponse struct {
 	PullRequestsDeleted  int64 `json:"pullRequestsDeleted"`
 }
 
+// RepositoryMergeResponse describes a duplicate repository merged into the kept row
+type RepositoryMergeResponse struct {
+	KeptID             int64  `json:"keptId"`
+	KeptFullName       string `json:"keptFullName"`
+	MergedID           int64  `json:"mergedId"`
+	MergedFullName     string `json:"mergedFullName"`
+	NotificationsMoved int64  `json:"notificationsMoved"`
+	PullRequestsMoved  int64  `json:"pullRequestsMoved"`
+}
+
+// MergeDuplicateRepositoriesResponse represents the response for merging duplicate repositories
+type MergeDuplicateRepositoriesResponse struct {
+	Merges []RepositoryMergeResponse `json:"merges"`
+}
+
+// CleanupPreviewItemResponse represents a notification considered by a cleanup dry run
+type CleanupPreviewItemResponse struct {
+	ID                int64    `json:"id"`
+	GithubID          string   `json:"githubId"`
+	SubjectTitle      string   `json:"subjectTitle"`
+	SubjectType       string   `json:"subjectType"`
+	RepoFullName      string   `json:"repoFullName"`
+	Archived          bool     `json:"archived"`
+	Muted             bool     `json:"muted"`
+	EffectiveSortDate string   `json:"effectiveSortDate"`
+	Eligible          bool     `json:"eligible"`
+	ProtectionReasons []string `json:"protectionReasons,omitempty"`
+}
+
+// CleanupPreviewResponse represents the response for a cleanup dry run
+type CleanupPreviewResponse struct {
+	Items          []CleanupPreviewItemResponse `json:"items"`
+	CandidateCount int64                        `json:"candidateCount"`
+	EligibleCount  int64                        `json:"eligibleCount"`
+	CutoffDate     string                       `json:"cutoffDate"`
+	Page           int                          `json:"page"`
+	PageSize       int                          `json:"pageSize"`
+	ApprovalToken  string                       `json:"approvalToken"`
+	ExpiresAt      string                       `json:"expiresAt"`
+}
+
+// ApproveCleanupRequest represents the request to run a previously previewed cleanup
+type ApproveCleanupRequest struct {
+	ApprovalToken string `json:"approvalToken"`
+}
+
 // HandleGetStorageStats handles GET /api/user/storage-stats
 func (h *Handler) HandleGetStorageStats(w http.ResponseWriter, r *http.Request) {
 	// Get cleanup handler from scheduler
@@ -282,6 +332,43 @@ func (h *Handler) HandleRunCleanup(w http.ResponseWriter, r *http.Request) {
 	helpers.WriteJSON(w, http.StatusOK, response)
 }
 
+// HandleMergeDuplicateRepositories handles POST /api/user/repositories/merge-duplicates
+func (h *Handler) HandleMergeDuplicateRepositories(w http.ResponseWriter, r *http.Request) {
+	mergeHandler := h.getMergeRepositoriesHandler()
+	if mergeHandler == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Repository merge handler not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	result, err := mergeHandler.Handle(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to merge duplicate repositories", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to merge duplicate repositories")
+		return
+	}
+
+	response := MergeDuplicateRepositoriesResponse{
+		Merges: make([]RepositoryMergeResponse, 0, len(result.Merges)),
+	}
+	for _, merge := range result.Merges {
+		response.Merges = append(response.Merges, RepositoryMergeResponse{
+			KeptID:             merge.KeptID,
+			KeptFullName:       merge.KeptFullName,
+			MergedID:           merge.MergedID,
+			MergedFullName:     merge.MergedFullName,
+			NotificationsMoved: merge.NotificationsMoved,
+			PullRequestsMoved:  merge.PullRequestsMoved,
+		})
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, response)
+}
+
 // HandleGetEligibleForCleanup handles GET /api/user/cleanup-preview
 func (h *Handler) HandleGetEligibleForCleanup(w http.ResponseWriter, r *http.Request) {
 	cleanupHandler := h.getCleanupHandler()
@@ -328,6 +415,180 @@ func (h *Handler) HandleGetEligibleForCleanup(w http.ResponseWriter, r *http.Req
 	helpers.WriteJSON(w, http.StatusOK, map[string]int64{"count": count})
 }
 
+// HandleGetCleanupPreviewItems handles GET /api/user/cleanup-preview/items
+// It lists exactly which notifications a cleanup would delete (and which it would skip)
+// without deleting anything. Parameters default to the saved retention settings.
+func (h *Handler) HandleGetCleanupPreviewItems(w http.ResponseWriter, r *http.Request) {
+	cleanupHandler := h.getCleanupHandler()
+	if cleanupHandler == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Cleanup handler not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	settings, err := cleanupHandler.GetRetentionSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get retention settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get retention settings")
+		return
+	}
+
+	opts, err := parseCleanupPreviewOptions(r, settings)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.RetentionDays <= 0 {
+		helpers.WriteError(w, http.StatusBadRequest, "Retention days must be greater than 0")
+		return
+	}
+
+	preview, err := cleanupHandler.PreviewCleanup(ctx, userID, opts)
+	if err != nil {
+		h.logger.Error("failed to preview cleanup", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to preview cleanup")
+		return
+	}
+
+	items := make([]CleanupPreviewItemResponse, 0, len(preview.Items))
+	for _, item := range preview.Items {
+		items = append(items, CleanupPreviewItemResponse{
+			ID:                item.ID,
+			GithubID:          item.GithubID,
+			SubjectTitle:      item.SubjectTitle,
+			SubjectType:       item.SubjectType,
+			RepoFullName:      item.RepoFullName,
+			Archived:          item.Archived,
+			Muted:             item.Muted,
+			EffectiveSortDate: item.EffectiveSortDate.Format(time.RFC3339),
+			Eligible:          item.Eligible,
+			ProtectionReasons: item.ProtectionReasons,
+		})
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, CleanupPreviewResponse{
+		Items:          items,
+		CandidateCount: preview.CandidateCount,
+		EligibleCount:  preview.EligibleCount,
+		CutoffDate:     preview.CutoffDate.Format(time.RFC3339),
+		Page:           preview.Page,
+		PageSize:       preview.PageSize,
+		ApprovalToken:  preview.ApprovalToken,
+		ExpiresAt:      preview.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// HandleApproveCleanup handles POST /api/user/cleanup/approve
+// It deletes exactly the notifications from a preview identified by its approval token.
+func (h *Handler) HandleApproveCleanup(w http.ResponseWriter, r *http.Request) {
+	var req ApproveCleanupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode approve cleanup request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ApprovalToken == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "Approval token is required")
+		return
+	}
+
+	cleanupHandler := h.getCleanupHandler()
+	if cleanupHandler == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Cleanup handler not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	result, err := cleanupHandler.RunApprovedCleanup(ctx, userID, req.ApprovalToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, handlers.ErrInvalidApprovalToken):
+			helpers.WriteError(w, http.StatusBadRequest, "Invalid approval token")
+		case errors.Is(err, handlers.ErrApprovalTokenExpired):
+			helpers.WriteError(w, http.StatusGone, "Approval token expired, preview again")
+		case errors.Is(err, handlers.ErrCleanupPreviewStale):
+			helpers.WriteError(
+				w,
+				http.StatusConflict,
+				"Eligible notifications changed since the preview, preview again",
+			)
+		default:
+			h.logger.Error("failed to run approved cleanup", zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to run cleanup")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, CleanupResponse{
+		NotificationsDeleted: result.NotificationsDeleted,
+		PullRequestsDeleted:  result.PullRequestsDeleted,
+	})
+}
+
+// parseCleanupPreviewOptions reads preview options from query params, falling back to settings
+func parseCleanupPreviewOptions(
+	r *http.Request,
+	settings *models.RetentionSettings,
+) (handlers.CleanupPreviewOptions, error) {
+	if settings == nil {
+		settings = models.DefaultRetentionSettings()
+	}
+	opts := handlers.CleanupPreviewOptions{
+		RetentionDays:  settings.RetentionDays,
+		ProtectStarred: settings.ProtectStarred,
+		ProtectTagged:  settings.ProtectTagged,
+	}
+
+	query := r.URL.Query()
+	if v := query.Get("retentionDays"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, errors.New("invalid retentionDays")
+		}
+		opts.RetentionDays = days
+	}
+	if v := query.Get("protectStarred"); v != "" {
+		protect, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, errors.New("invalid protectStarred")
+		}
+		opts.ProtectStarred = protect
+	}
+	if v := query.Get("protectTagged"); v != "" {
+		protect, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, errors.New("invalid protectTagged")
+		}
+		opts.ProtectTagged = protect
+	}
+	if v := query.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, errors.New("invalid page")
+		}
+		opts.Page = page
+	}
+	if v := query.Get("pageSize"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, errors.New("invalid pageSize")
+		}
+		opts.PageSize = pageSize
+	}
+	return opts, nil
+}
+
 // getCleanupHandler retrieves the cleanup handler from the scheduler
 func (h *Handler) getCleanupHandler() *jobs.CleanupNotificationsHandler {
 	if h.scheduler == nil {
@@ -341,3 +602,16 @@ func (h *Handler) getCleanupHandler() *jobs.CleanupNotificationsHandler {
 
 	return nil
 }
+
+// getMergeRepositoriesHandler retrieves the duplicate repository merge handler from the scheduler
+func (h *Handler) getMergeRepositoriesHandler() *jobs.MergeDuplicateRepositoriesHandler {
+	if h.scheduler == nil {
+		return nil
+	}
+
+	if sqliteScheduler, ok := h.scheduler.(*jobs.SQLiteScheduler); ok {
+		return sqliteScheduler.GetMergeRepositoriesHandler()
+	}
+
+	return nil
+}
@@ -18,6 +18,8 @@ package user
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -37,17 +39,40 @@ func (h *Handler) HandleGetSyncSettings(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response := SyncSettingsResponse{}
-	if settings != nil {
-		response = SyncSettingsResponse{
-			InitialSyncDays:       settings.InitialSyncDays,
-			InitialSyncMaxCount:   settings.InitialSyncMaxCount,
-			InitialSyncUnreadOnly: settings.InitialSyncUnreadOnly,
-			SetupCompleted:        settings.SetupCompleted,
-		}
+	helpers.WriteJSON(w, http.StatusOK, newSyncSettingsResponse(settings))
+}
+
+// newSyncSettingsResponse converts sync settings to their response; nil settings report
+// the defaults
+func newSyncSettingsResponse(settings *models.SyncSettings) SyncSettingsResponse {
+	response := SyncSettingsResponse{
+		OrgAllowlist: []string{},
+		OrgBlocklist: []string{},
+		Capabilities: settings.Capabilities(),
+	}
+	if settings == nil {
+		return response
 	}
 
-	helpers.WriteJSON(w, http.StatusOK, response)
+	response.InitialSyncDays = settings.InitialSyncDays
+	response.InitialSyncMaxCount = settings.InitialSyncMaxCount
+	response.InitialSyncUnreadOnly = settings.InitialSyncUnreadOnly
+	response.SetupCompleted = settings.SetupCompleted
+	response.WebhooksEnabled = settings.WebhooksEnabled
+	response.ResurfaceSuppressionMinutes = settings.ResurfaceSuppressionMinutes
+	response.AwaitingReplyDays = settings.AwaitingReplyDays
+	response.ResurfaceAwaitingReplies = settings.ResurfaceAwaitingReplies
+	response.SkipPayloadStorage = settings.SkipPayloadStorage
+	response.SkipCommentCaching = settings.SkipCommentCaching
+	response.TitlesOnly = settings.TitlesOnly
+	response.MarkOnGitHub = settings.MarkOnGitHub
+	if settings.OrgAllowlist != nil {
+		response.OrgAllowlist = settings.OrgAllowlist
+	}
+	if settings.OrgBlocklist != nil {
+		response.OrgBlocklist = settings.OrgBlocklist
+	}
+	return response
 }
 
 // HandleUpdateSyncSettings handles PUT /api/user/sync-settings
@@ -90,6 +115,38 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	if req.ResurfaceSuppressionMinutes != nil &&
+		(*req.ResurfaceSuppressionMinutes < 0 ||
+			*req.ResurfaceSuppressionMinutes > models.MaxResurfaceSuppressionMinutes) {
+		helpers.WriteError(
+			w,
+			http.StatusBadRequest,
+			"resurfaceSuppressionMinutes must be between 0 and 10080 (1 week)",
+		)
+		return
+	}
+
+	if req.AwaitingReplyDays != nil &&
+		(*req.AwaitingReplyDays < 0 || *req.AwaitingReplyDays > models.MaxAwaitingReplyDays) {
+		helpers.WriteError(w, http.StatusBadRequest, "awaitingReplyDays must be between 0 and 90")
+		return
+	}
+
+	var orgAllowlist, orgBlocklist []string
+	var validOrgs bool
+	if req.OrgAllowlist != nil {
+		if orgAllowlist, validOrgs = normalizeOrgs(*req.OrgAllowlist); !validOrgs {
+			helpers.WriteError(w, http.StatusBadRequest, "orgAllowlist must only contain org names")
+			return
+		}
+	}
+	if req.OrgBlocklist != nil {
+		if orgBlocklist, validOrgs = normalizeOrgs(*req.OrgBlocklist); !validOrgs {
+			helpers.WriteError(w, http.StatusBadRequest, "orgBlocklist must only contain org names")
+			return
+		}
+	}
+
 	ctx := r.Context()
 
 	// Get userID first for scheduler calls
@@ -100,10 +157,50 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 
 	// Check current sync settings to see if setup was already completed
 	// We only want to trigger sync on initial setup completion, not on subsequent updates
-	var wasAlreadyCompleted bool
+	var wasAlreadyCompleted, webhooksEnabled, resurfaceAwaitingReplies bool
+	var skipPayloadStorage, skipCommentCaching, titlesOnly, markOnGitHub bool
+	var resurfaceSuppressionMinutes, awaitingReplyDays int
 	currentSettings, err := h.authSvc.GetUserSyncSettings(ctx)
 	if err == nil && currentSettings != nil {
 		wasAlreadyCompleted = currentSettings.SetupCompleted
+		webhooksEnabled = currentSettings.WebhooksEnabled
+		resurfaceSuppressionMinutes = currentSettings.ResurfaceSuppressionMinutes
+		awaitingReplyDays = currentSettings.AwaitingReplyDays
+		resurfaceAwaitingReplies = currentSettings.ResurfaceAwaitingReplies
+		skipPayloadStorage = currentSettings.SkipPayloadStorage
+		skipCommentCaching = currentSettings.SkipCommentCaching
+		titlesOnly = currentSettings.TitlesOnly
+		markOnGitHub = currentSettings.MarkOnGitHub
+		if req.OrgAllowlist == nil {
+			orgAllowlist = currentSettings.OrgAllowlist
+		}
+		if req.OrgBlocklist == nil {
+			orgBlocklist = currentSettings.OrgBlocklist
+		}
+	}
+	if req.WebhooksEnabled != nil {
+		webhooksEnabled = *req.WebhooksEnabled
+	}
+	if req.ResurfaceSuppressionMinutes != nil {
+		resurfaceSuppressionMinutes = *req.ResurfaceSuppressionMinutes
+	}
+	if req.AwaitingReplyDays != nil {
+		awaitingReplyDays = *req.AwaitingReplyDays
+	}
+	if req.ResurfaceAwaitingReplies != nil {
+		resurfaceAwaitingReplies = *req.ResurfaceAwaitingReplies
+	}
+	if req.SkipPayloadStorage != nil {
+		skipPayloadStorage = *req.SkipPayloadStorage
+	}
+	if req.SkipCommentCaching != nil {
+		skipCommentCaching = *req.SkipCommentCaching
+	}
+	if req.TitlesOnly != nil {
+		titlesOnly = *req.TitlesOnly
+	}
+	if req.MarkOnGitHub != nil {
+		markOnGitHub = *req.MarkOnGitHub
 	}
 
 	settings := &models.SyncSettings{
@@ -111,6 +208,17 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		InitialSyncMaxCount:   req.InitialSyncMaxCount,
 		InitialSyncUnreadOnly: req.InitialSyncUnreadOnly,
 		SetupCompleted:        req.SetupCompleted,
+		WebhooksEnabled:       webhooksEnabled,
+
+		ResurfaceSuppressionMinutes: resurfaceSuppressionMinutes,
+		AwaitingReplyDays:           awaitingReplyDays,
+		ResurfaceAwaitingReplies:    resurfaceAwaitingReplies,
+		SkipPayloadStorage:          skipPayloadStorage,
+		SkipCommentCaching:          skipCommentCaching,
+		TitlesOnly:                  titlesOnly,
+		MarkOnGitHub:                markOnGitHub,
+		OrgAllowlist:                orgAllowlist,
+		OrgBlocklist:                orgBlocklist,
 	}
 
 	if err := h.authSvc.UpdateUserSyncSettings(ctx, settings); err != nil {
@@ -128,12 +236,83 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	response := SyncSettingsResponse{
-		InitialSyncDays:       settings.InitialSyncDays,
-		InitialSyncMaxCount:   settings.InitialSyncMaxCount,
-		InitialSyncUnreadOnly: settings.InitialSyncUnreadOnly,
-		SetupCompleted:        settings.SetupCompleted,
+	helpers.WriteJSON(w, http.StatusOK, newSyncSettingsResponse(settings))
+}
+
+// normalizeOrgs trims org names and drops duplicates, which GitHub compares
+// case-insensitively. It reports false when a name is empty or isn't an org.
+func normalizeOrgs(orgs []string) ([]string, bool) {
+	normalized := make([]string, 0, len(orgs))
+	seen := make(map[string]bool, len(orgs))
+	for _, org := range orgs {
+		org = strings.TrimSpace(org)
+		if org == "" || strings.ContainsAny(org, "/ ") {
+			return nil, false
+		}
+		if key := strings.ToLower(org); !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, org)
+		}
 	}
+	return normalized, true
+}
+
+// HandleListSyncOrgs handles GET /api/user/sync-settings/orgs
+// Lists the orgs discovered from synced repositories along with those the org filter
+// names, so the filter can be chosen from orgs the user actually gets notifications from
+func (h *Handler) HandleListSyncOrgs(w http.ResponseWriter, r *http.Request) {
+	if h.store == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Database store not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.authSvc.GetUserSyncSettings(ctx)
+	if err != nil {
+		h.logger.Error("failed to get sync settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	repositories, err := h.store.ListRepositories(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to list repositories", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	orgs := make(map[string]*SyncOrg)
+	addOrg := func(login string) *SyncOrg {
+		key := strings.ToLower(login)
+		if orgs[key] == nil {
+			orgs[key] = &SyncOrg{Login: login, Synced: settings.SyncsOrg(login)}
+		}
+		return orgs[key]
+	}
+	for _, repository := range repositories {
+		if login, _, found := strings.Cut(repository.FullName, "/"); found {
+			addOrg(login).Repositories++
+		}
+	}
+	if settings != nil {
+		for _, login := range settings.OrgAllowlist {
+			addOrg(login)
+		}
+		for _, login := range settings.OrgBlocklist {
+			addOrg(login)
+		}
+	}
+
+	response := SyncOrgsResponse{Orgs: make([]SyncOrg, 0, len(orgs))}
+	for _, org := range orgs {
+		response.Orgs = append(response.Orgs, *org)
+	}
+	sort.Slice(response.Orgs, func(i, j int) bool {
+		return strings.ToLower(response.Orgs[i].Login) < strings.ToLower(response.Orgs[j].Login)
+	})
 
 	helpers.WriteJSON(w, http.StatusOK, response)
 }
@@ -171,6 +350,29 @@ func (h *Handler) HandleGetSyncState(w http.ResponseWriter, r *http.Request) {
 	helpers.WriteJSON(w, http.StatusOK, response)
 }
 
+// HandleSyncNow handles POST /api/user/sync
+// Queues a sync of new notifications without waiting for the next interval
+func (h *Handler) HandleSyncNow(w http.ResponseWriter, r *http.Request) {
+	if h.scheduler == nil {
+		helpers.WriteError(w, http.StatusServiceUnavailable, "Job queue not available")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	if err := h.scheduler.EnqueueSyncNotifications(ctx, userID); err != nil {
+		h.logger.Error("failed to queue sync job", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to queue sync job")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
 // HandleSyncOlder handles POST /api/user/sync-older
 // Queues a job to sync notifications older than the current oldest synced notification
 func (h *Handler) HandleSyncOlder(w http.ResponseWriter, r *http.Request) {
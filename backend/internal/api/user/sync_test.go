@@ -33,6 +33,8 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
 	syncstatemocks "github.com/octobud-hq/octobud/backend/internal/core/syncstate/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/jobs"
 	jobsmocks "github.com/octobud-hq/octobud/backend/internal/jobs/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/models"
@@ -66,6 +68,10 @@ func intPtr(i int) *int {
 	return &i
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -216,6 +222,184 @@ func TestHandler_HandleUpdateSyncSettings(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "resurface suppression is kept when omitted and replaced when sent",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted:              true,
+				ResurfaceSuppressionMinutes: intPtr(120),
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted:              true,
+					WebhooksEnabled:             true,
+					ResurfaceSuppressionMinutes: 60,
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.True(t, settings.WebhooksEnabled)
+						require.Equal(t, 120, settings.ResurfaceSuppressionMinutes)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, 120, response.ResurfaceSuppressionMinutes)
+			},
+		},
+		{
+			name: "resurface suppression over a week returns 400",
+			requestBody: SyncSettingsRequest{
+				ResurfaceSuppressionMinutes: intPtr(models.MaxResurfaceSuppressionMinutes + 1),
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response errorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Contains(t, response.Error, "resurfaceSuppressionMinutes must be between")
+			},
+		},
+		{
+			name: "awaiting reply settings are kept when omitted and replaced when sent",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted:    true,
+				AwaitingReplyDays: intPtr(5),
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted:           true,
+					AwaitingReplyDays:        3,
+					ResurfaceAwaitingReplies: true,
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.Equal(t, 5, settings.AwaitingReplyDays)
+						require.True(t, settings.ResurfaceAwaitingReplies)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, 5, response.AwaitingReplyDays)
+				require.True(t, response.ResurfaceAwaitingReplies)
+			},
+		},
+		{
+			name: "storage settings are kept when omitted and report reduced capabilities",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted:     true,
+				SkipCommentCaching: boolPtr(true),
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted:     true,
+					SkipPayloadStorage: true,
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.True(t, settings.SkipPayloadStorage)
+						require.True(t, settings.SkipCommentCaching)
+						require.False(t, settings.TitlesOnly)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.SkipPayloadStorage)
+				require.True(t, response.SkipCommentCaching)
+				require.Equal(t, models.StorageCapabilities{
+					SubjectDetails: true,
+					AwaitingReply:  true,
+				}, response.Capabilities)
+			},
+		},
+		{
+			name: "mark on GitHub can be turned off",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted: true,
+				MarkOnGitHub:   boolPtr(false),
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted: true,
+					MarkOnGitHub:   true,
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.False(t, settings.MarkOnGitHub)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.False(t, response.MarkOnGitHub)
+			},
+		},
+		{
+			name: "org filter is normalized and kept when omitted",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted: true,
+				OrgAllowlist:   &[]string{" acme ", "Globex", "ACME"},
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted: true,
+					OrgBlocklist:   []string{"initech"},
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.Equal(t, []string{"acme", "Globex"}, settings.OrgAllowlist)
+						require.Equal(t, []string{"initech"}, settings.OrgBlocklist)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, []string{"acme", "Globex"}, response.OrgAllowlist)
+				require.Equal(t, []string{"initech"}, response.OrgBlocklist)
+			},
+		},
+		{
+			name: "org filter with a repository name returns 400",
+			requestBody: SyncSettingsRequest{
+				OrgBlocklist: &[]string{"acme/api"},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response errorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Contains(t, response.Error, "orgBlocklist must only contain org names")
+			},
+		},
+		{
+			name: "awaiting reply days over the maximum returns 400",
+			requestBody: SyncSettingsRequest{
+				AwaitingReplyDays: intPtr(models.MaxAwaitingReplyDays + 1),
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response errorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Contains(t, response.Error, "awaitingReplyDays must be between")
+			},
+		},
 		{
 			name:           "invalid request body returns 400",
 			requestBody:    "invalid json",
@@ -316,6 +500,43 @@ func TestHandler_HandleUpdateSyncSettings(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleListSyncOrgs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockService := setupTestHandler(ctrl)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	handler.WithStore(mockStore)
+
+	mockService.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockService.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+		OrgAllowlist: []string{"acme", "hooli"},
+	}, nil)
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return([]db.Repository{
+		{ID: 1, FullName: "acme/api"},
+		{ID: 2, FullName: "acme/web"},
+		{ID: 3, FullName: "Globex/site"},
+	}, nil)
+
+	req := createRequest(http.MethodGet, "/api/user/sync-settings/orgs", nil)
+	req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+	w := httptest.NewRecorder()
+
+	handler.HandleListSyncOrgs(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response SyncOrgsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, []SyncOrg{
+		{Login: "acme", Repositories: 2, Synced: true},
+		{Login: "Globex", Repositories: 1, Synced: false},
+		{Login: "hooli", Repositories: 0, Synced: true},
+	}, response.Orgs)
+}
+
 func TestHandler_HandleGetSyncState(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -696,3 +917,64 @@ func TestHandler_HandleSyncOlder(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_HandleSyncNow(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupHandler   func(*Handler, *gomock.Controller)
+		expectedStatus int
+	}{
+		{
+			name: "success queues sync job",
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockScheduler := jobsmocks.NewMockScheduler(ctrl)
+				mockScheduler.EXPECT().
+					EnqueueSyncNotifications(gomock.Any(), "test-user-id").
+					Return(nil).
+					Times(1)
+				h.scheduler = mockScheduler
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "scheduler unavailable returns 503",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "job queue error returns 500",
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockScheduler := jobsmocks.NewMockScheduler(ctrl)
+				mockScheduler.EXPECT().
+					EnqueueSyncNotifications(gomock.Any(), "test-user-id").
+					Return(errors.New("queue error")).
+					Times(1)
+				h.scheduler = mockScheduler
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockAuthSvc := setupTestHandler(ctrl)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			if tt.setupHandler != nil {
+				tt.setupHandler(handler, ctrl)
+			}
+
+			req := createRequest(http.MethodPost, "/api/user/sync", nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+			w := httptest.NewRecorder()
+
+			handler.HandleSyncNow(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/team"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// TeamSettingsResponse represents the response for team settings
+type TeamSettingsResponse struct {
+	Members []string `json:"members"`
+}
+
+// TeamSettingsRequest represents the request for updating team settings
+type TeamSettingsRequest struct {
+	Members []string `json:"members"`
+}
+
+// HandleGetTeamSettings handles GET /api/user/team-settings
+func (h *Handler) HandleGetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	if h.teamSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Team settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.teamSvc.GetTeamSettings(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get team settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get team settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, TeamSettingsResponse{Members: settings.Members})
+}
+
+// HandleUpdateTeamSettings handles PUT /api/user/team-settings
+func (h *Handler) HandleUpdateTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var req TeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode team settings request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.teamSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Team settings not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	members := req.Members
+	if members == nil {
+		members = []string{}
+	}
+	settings, err := h.teamSvc.UpdateTeamSettings(ctx, userID, &models.TeamSettings{
+		Members: members,
+	})
+	if err != nil {
+		if errors.Is(err, team.ErrInvalidTeamSettings) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update team settings", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update team settings")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, TeamSettingsResponse{Members: settings.Members})
+}
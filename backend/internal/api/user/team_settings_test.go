@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/team"
+	teammocks "github.com/octobud-hq/octobud/backend/internal/core/team/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupTeamHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *teammocks.MockTeamService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockTeam := teammocks.NewMockTeamService(ctrl)
+	handler.WithTeamService(mockTeam)
+	return handler, mockTeam
+}
+
+func TestHandler_HandleGetTeamSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockTeam := setupTeamHandler(t, ctrl)
+	mockTeam.EXPECT().
+		GetTeamSettings(gomock.Any(), "test-user-id").
+		Return(&models.TeamSettings{Members: []string{"alice"}}, nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetTeamSettings(w, createRequest(http.MethodGet, "/api/user/team-settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp TeamSettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []string{"alice"}, resp.Members)
+}
+
+func TestHandler_HandleUpdateTeamSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*teammocks.MockTeamService)
+		expectedStatus int
+	}{
+		{
+			name: "saves members",
+			body: TeamSettingsRequest{Members: []string{"@alice"}},
+			setupMock: func(m *teammocks.MockTeamService) {
+				m.EXPECT().
+					UpdateTeamSettings(gomock.Any(), "test-user-id", &models.TeamSettings{
+						Members: []string{"@alice"},
+					}).
+					Return(&models.TeamSettings{Members: []string{"alice"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invalid member returns 400",
+			body: TeamSettingsRequest{Members: []string{"not a login"}},
+			setupMock: func(m *teammocks.MockTeamService) {
+				m.EXPECT().
+					UpdateTeamSettings(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: bad login", team.ErrInvalidTeamSettings))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body returns 400",
+			body:           "not-an-object",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockTeam := setupTeamHandler(t, ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockTeam)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleUpdateTeamSettings(
+				w, createRequest(http.MethodPut, "/api/user/team-settings", tt.body),
+			)
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
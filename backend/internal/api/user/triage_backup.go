@@ -0,0 +1,213 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// TriageImportRequest represents the request for importing a triage backup
+type TriageImportRequest struct {
+	Backup *models.TriageBackup `json:"backup"`
+	// Resync queues a sync so notifications missing locally are fetched again and pick up
+	// their imported state as they arrive
+	Resync bool `json:"resync"`
+}
+
+// TriageImportResponse represents the response for importing a triage backup
+type TriageImportResponse struct {
+	models.TriageImportResult
+	ResyncQueued bool `json:"resyncQueued"`
+}
+
+// TriageDiscardResponse represents the response for discarding pending imported state
+type TriageDiscardResponse struct {
+	Discarded int64 `json:"discarded"`
+}
+
+// errTriageExportChanged is reported when triage state changes while an export is being
+// resumed, so the bytes no longer match the ETag the client is resuming
+var errTriageExportChanged = errors.New("triage state changed during export")
+
+// HandleExportTriage handles GET /api/user/triage-export
+// Streams the triage state of every notification as a downloadable backup. The backup is
+// generated twice: once to learn its size and ETag, then again to send it. Interrupted
+// downloads can resume with a Range request naming the ETag in If-Range.
+func (h *Handler) HandleExportTriage(w http.ResponseWriter, r *http.Request) {
+	if h.triageSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Triage backups not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	// A resumed download regenerates the backup it started with
+	var exportedAt time.Time
+	ifRange := r.Header.Get("If-Range")
+	if r.Header.Get("Range") != "" {
+		exportedAt = exportedAtFromETag(ifRange)
+	}
+	summary, err := h.triageSvc.WriteExport(ctx, userID, exportedAt, io.Discard)
+	if err == nil && !exportedAt.IsZero() && triageExportETag(summary) != ifRange {
+		// Triage state changed since the interrupted download, so start a fresh one
+		summary, err = h.triageSvc.WriteExport(ctx, userID, time.Time{}, io.Discard)
+	}
+	if err != nil {
+		h.logger.Error("failed to export triage state", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to export triage state")
+		return
+	}
+
+	w.Header().Set("X-Export-Entries", strconv.Itoa(summary.Entries))
+	helpers.ServeDownload(w, r, helpers.Download{
+		Filename:    fmt.Sprintf("octobud-triage-%s.json", summary.ExportedAt.Format("2006-01-02")),
+		ContentType: "application/json",
+		ETag:        triageExportETag(summary),
+		Size:        summary.Size,
+		Write: func(ctx context.Context, w io.Writer) error {
+			written, err := h.triageSvc.WriteExport(ctx, userID, summary.ExportedAt, w)
+			if err != nil {
+				if !errors.Is(err, io.ErrClosedPipe) && !errors.Is(err, context.Canceled) {
+					h.logger.Error("failed to stream triage export", zap.Error(err))
+				}
+				return err
+			}
+			if written.Digest != summary.Digest {
+				h.logger.Warn("triage state changed during export")
+				return errTriageExportChanged
+			}
+			return nil
+		},
+	})
+}
+
+// triageExportETag identifies an export by when it was taken and what it contains. The
+// time lets a resumed download regenerate the same backup.
+func triageExportETag(summary *models.TriageExportSummary) string {
+	return fmt.Sprintf(`"%d-%s"`, summary.ExportedAt.Unix(), summary.Digest[:16])
+}
+
+// exportedAtFromETag returns the export time recorded in a triage export ETag, or the
+// zero time if etag isn't one
+func exportedAtFromETag(etag string) time.Time {
+	seconds, _, found := strings.Cut(strings.Trim(etag, `"`), "-")
+	if !found {
+		return time.Time{}
+	}
+	unix, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || unix <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0).UTC()
+}
+
+// HandleImportTriage handles POST /api/user/triage-import
+// Merges a triage backup into existing notifications and keeps the rest to merge as they sync
+func (h *Handler) HandleImportTriage(w http.ResponseWriter, r *http.Request) {
+	var req TriageImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode triage import request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Backup == nil {
+		helpers.WriteError(w, http.StatusBadRequest, "backup is required")
+		return
+	}
+
+	if h.triageSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Triage backups not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	result, err := h.triageSvc.Import(ctx, userID, req.Backup)
+	if err != nil {
+		if errors.Is(err, triage.ErrUnsupportedVersion) || errors.Is(err, triage.ErrInvalidBackup) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to import triage state", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to import triage state")
+		return
+	}
+
+	response := TriageImportResponse{TriageImportResult: *result}
+	if req.Resync && result.Pending > 0 && h.scheduler != nil {
+		if err := h.scheduler.EnqueueSyncNotifications(ctx, userID); err != nil {
+			h.logger.Warn("failed to queue sync job after triage import", zap.Error(err))
+		} else {
+			response.ResyncQueued = true
+		}
+	}
+
+	h.logger.Info("imported triage state",
+		zap.Int("staged", result.Staged),
+		zap.Int64("applied", result.Applied),
+		zap.Int64("pending", result.Pending),
+		zap.Int("tagsCreated", result.Tags.Created),
+		zap.Int("viewsCreated", result.Views.Created),
+		zap.Int("rulesCreated", result.Rules.Created),
+	)
+	helpers.WriteJSON(w, http.StatusOK, response)
+}
+
+// HandleDiscardTriageImport handles DELETE /api/user/triage-import
+// Drops imported state for notifications that have not synced yet
+func (h *Handler) HandleDiscardTriageImport(w http.ResponseWriter, r *http.Request) {
+	if h.triageSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Triage backups not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	discarded, err := h.triageSvc.DiscardPending(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to discard pending triage state", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to discard imported state")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, TriageDiscardResponse{Discarded: discarded})
+}
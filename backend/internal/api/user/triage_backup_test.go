@@ -0,0 +1,256 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	triagemocks "github.com/octobud-hq/octobud/backend/internal/core/triage/mocks"
+	jobsmocks "github.com/octobud-hq/octobud/backend/internal/jobs/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupTriageHandler(t *testing.T, ctrl *gomock.Controller) (*Handler, *triagemocks.MockTriageService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockTriage := triagemocks.NewMockTriageService(ctrl)
+	handler.WithTriageService(mockTriage)
+	return handler, mockTriage
+}
+
+// fakeTriageExport writes body as the export, with a summary describing it
+func fakeTriageExport(body string) func(
+	context.Context,
+	string,
+	time.Time,
+	io.Writer,
+) (*models.TriageExportSummary, error) {
+	return func(
+		_ context.Context,
+		_ string,
+		exportedAt time.Time,
+		w io.Writer,
+	) (*models.TriageExportSummary, error) {
+		if exportedAt.IsZero() {
+			exportedAt = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256([]byte(body))
+		return &models.TriageExportSummary{
+			ExportedAt: exportedAt,
+			Entries:    1,
+			Size:       int64(len(body)),
+			Digest:     hex.EncodeToString(digest[:]),
+		}, nil
+	}
+}
+
+func TestHandler_HandleExportTriage(t *testing.T) {
+	const body = `{"version":1,"exportedAt":"2025-06-15T12:00:00Z",` +
+		`"notifications":[{"githubId":"n1","isRead":false,"archived":true,"starred":false,"muted":false}]}`
+	digest := sha256.Sum256([]byte(body))
+	etag := fmt.Sprintf(`"%d-%s"`, int64(1749988800), hex.EncodeToString(digest[:])[:16])
+
+	t.Run("streams the whole backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockTriage := setupTriageHandler(t, ctrl)
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Time{}, gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+		// The backup is sent as it was sized, at the same export time
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC), gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+
+		w := httptest.NewRecorder()
+		handler.HandleExportTriage(w, createRequest(http.MethodGet, "/api/user/triage-export", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `attachment; filename="octobud-triage-2025-06-15.json"`, w.Header().Get("Content-Disposition"))
+		require.Equal(t, etag, w.Header().Get("ETag"))
+		require.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+		require.Equal(t, "1", w.Header().Get("X-Export-Entries"))
+		require.Equal(t, strconv.Itoa(len(body)), w.Header().Get("X-Download-Size"))
+		var backup models.TriageBackup
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &backup))
+		require.Len(t, backup.Notifications, 1)
+		require.True(t, backup.Notifications[0].Archived)
+	})
+
+	t.Run("resumes from a byte offset", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockTriage := setupTriageHandler(t, ctrl)
+		exportedAt := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", exportedAt, gomock.Any()).
+			DoAndReturn(fakeTriageExport(body)).
+			Times(2)
+
+		req := createRequest(http.MethodGet, "/api/user/triage-export", nil)
+		req.Header.Set("Range", "bytes=10-")
+		req.Header.Set("If-Range", etag)
+		w := httptest.NewRecorder()
+		handler.HandleExportTriage(w, req)
+
+		require.Equal(t, http.StatusPartialContent, w.Code)
+		require.Equal(t, fmt.Sprintf("bytes 10-%d/%d", len(body)-1, len(body)), w.Header().Get("Content-Range"))
+		require.Equal(t, body[10:], w.Body.String())
+	})
+
+	t.Run("stale resume starts over", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockTriage := setupTriageHandler(t, ctrl)
+		stale := fmt.Sprintf(`"%d-0123456789abcdef"`, int64(1749900000))
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Unix(1749900000, 0).UTC(), gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Time{}, gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC), gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+
+		req := createRequest(http.MethodGet, "/api/user/triage-export", nil)
+		req.Header.Set("Range", "bytes=10-")
+		req.Header.Set("If-Range", stale)
+		w := httptest.NewRecorder()
+		handler.HandleExportTriage(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, etag, w.Header().Get("ETag"))
+		require.Equal(t, body, w.Body.String())
+	})
+
+	t.Run("range past the end returns 416", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handler, mockTriage := setupTriageHandler(t, ctrl)
+		mockTriage.EXPECT().
+			WriteExport(gomock.Any(), "test-user-id", time.Time{}, gomock.Any()).
+			DoAndReturn(fakeTriageExport(body))
+
+		req := createRequest(http.MethodGet, "/api/user/triage-export", nil)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", len(body)))
+		w := httptest.NewRecorder()
+		handler.HandleExportTriage(w, req)
+
+		require.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+		require.Equal(t, fmt.Sprintf("bytes */%d", len(body)), w.Header().Get("Content-Range"))
+	})
+}
+
+func TestHandler_HandleImportTriage(t *testing.T) {
+	backup := &models.TriageBackup{
+		Version:       models.TriageBackupVersion,
+		Notifications: []models.TriageEntry{{GithubID: "n1", IsRead: true}},
+	}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMocks     func(*triagemocks.MockTriageService, *jobsmocks.MockScheduler)
+		expectedStatus int
+		expected       *TriageImportResponse
+	}{
+		{
+			name: "imports and queues a resync while notifications are pending",
+			body: TriageImportRequest{Backup: backup, Resync: true},
+			setupMocks: func(m *triagemocks.MockTriageService, s *jobsmocks.MockScheduler) {
+				m.EXPECT().
+					Import(gomock.Any(), "test-user-id", backup).
+					Return(&models.TriageImportResult{Staged: 1, Pending: 1}, nil)
+				s.EXPECT().EnqueueSyncNotifications(gomock.Any(), "test-user-id").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expected: &TriageImportResponse{
+				TriageImportResult: models.TriageImportResult{Staged: 1, Pending: 1},
+				ResyncQueued:       true,
+			},
+		},
+		{
+			name: "no resync when everything was applied",
+			body: TriageImportRequest{Backup: backup, Resync: true},
+			setupMocks: func(m *triagemocks.MockTriageService, _ *jobsmocks.MockScheduler) {
+				m.EXPECT().
+					Import(gomock.Any(), "test-user-id", backup).
+					Return(&models.TriageImportResult{Staged: 1, Applied: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expected: &TriageImportResponse{
+				TriageImportResult: models.TriageImportResult{Staged: 1, Applied: 1},
+			},
+		},
+		{
+			name:           "missing backup returns 400",
+			body:           TriageImportRequest{Resync: true},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported version returns 400",
+			body: TriageImportRequest{Backup: &models.TriageBackup{Version: 99}},
+			setupMocks: func(m *triagemocks.MockTriageService, _ *jobsmocks.MockScheduler) {
+				m.EXPECT().
+					Import(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(nil, fmt.Errorf("%w: 99", triage.ErrUnsupportedVersion))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockTriage := setupTriageHandler(t, ctrl)
+			mockScheduler := jobsmocks.NewMockScheduler(ctrl)
+			handler.WithScheduler(mockScheduler)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockTriage, mockScheduler)
+			}
+
+			w := httptest.NewRecorder()
+			handler.HandleImportTriage(w, createRequest(http.MethodPost, "/api/user/triage-import", tt.body))
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expected != nil {
+				var resp TriageImportResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, *tt.expected, resp)
+			}
+		})
+	}
+}
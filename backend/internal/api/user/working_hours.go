@@ -0,0 +1,214 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// maxBusinessDays bounds how far add-business-days will look ahead or back
+const maxBusinessDays = 365
+
+// WorkingHoursResponse represents the response for working hours
+type WorkingHoursResponse struct {
+	Enabled  bool     `json:"enabled"`
+	Days     []int    `json:"days"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone,omitempty"`
+	Holidays []string `json:"holidays"`
+}
+
+// WorkingHoursRequest represents the request for updating working hours
+type WorkingHoursRequest struct {
+	Enabled  bool     `json:"enabled"`
+	Days     []int    `json:"days"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone"`
+	Holidays []string `json:"holidays"`
+}
+
+// BusinessTimeResponse represents a point in time computed from working hours
+type BusinessTimeResponse struct {
+	At time.Time `json:"at"`
+}
+
+// HandleGetWorkingHours handles GET /api/user/working-hours
+func (h *Handler) HandleGetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	if h.workHoursSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Working hours not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	settings, err := h.workHoursSvc.GetWorkingHours(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get working hours", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get working hours")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, workingHoursResponse(settings))
+}
+
+// HandleUpdateWorkingHours handles PUT /api/user/working-hours
+func (h *Handler) HandleUpdateWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var req WorkingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode working hours request", zap.Error(err))
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if h.workHoursSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Working hours not configured")
+		return
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	holidays := req.Holidays
+	if holidays == nil {
+		holidays = []string{}
+	}
+	settings, err := h.workHoursSvc.UpdateWorkingHours(ctx, userID, &models.WorkingHours{
+		Enabled:  req.Enabled,
+		Days:     req.Days,
+		Start:    req.Start,
+		End:      req.End,
+		Timezone: req.Timezone,
+		Holidays: holidays,
+	})
+	if err != nil {
+		if errors.Is(err, workhours.ErrInvalidWorkingHours) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to update working hours", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update working hours")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, workingHoursResponse(settings))
+}
+
+// HandleGetNextBusinessDay handles GET /api/user/working-hours/next-business-day
+// Returns the start of working hours on the first business day after "from" (default now)
+func (h *Handler) HandleGetNextBusinessDay(w http.ResponseWriter, r *http.Request) {
+	from, ok := parseFrom(w, r)
+	if !ok {
+		return
+	}
+
+	calendar, ok := h.loadCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, BusinessTimeResponse{
+		At: calendar.NextBusinessDayStart(from),
+	})
+}
+
+// HandleAddBusinessDays handles GET /api/user/working-hours/add-business-days
+// Returns "from" (default now) moved by "days" business days, e.g. an SLA deadline
+func (h *Handler) HandleAddBusinessDays(w http.ResponseWriter, r *http.Request) {
+	days, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil || days < -maxBusinessDays || days > maxBusinessDays {
+		helpers.WriteError(w, http.StatusBadRequest, "days must be between -365 and 365")
+		return
+	}
+
+	from, ok := parseFrom(w, r)
+	if !ok {
+		return
+	}
+
+	calendar, ok := h.loadCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, BusinessTimeResponse{
+		At: calendar.AddBusinessDays(from, days),
+	})
+}
+
+// loadCalendar loads the calendar for the current user's working hours, writing an
+// error response if it can't
+func (h *Handler) loadCalendar(w http.ResponseWriter, r *http.Request) (*workhours.Calendar, bool) {
+	if h.workHoursSvc == nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Working hours not configured")
+		return nil, false
+	}
+
+	ctx := r.Context()
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return nil, false
+	}
+	calendar, err := h.workHoursSvc.Calendar(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to load working hours calendar", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to get working hours")
+		return nil, false
+	}
+	return calendar, true
+}
+
+// parseFrom reads the optional RFC3339 "from" query parameter, defaulting to now
+func parseFrom(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	value := r.URL.Query().Get("from")
+	if value == "" {
+		return time.Now(), true
+	}
+	from, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "from must be an RFC3339 timestamp")
+		return time.Time{}, false
+	}
+	return from, true
+}
+
+func workingHoursResponse(settings *models.WorkingHours) WorkingHoursResponse {
+	return WorkingHoursResponse{
+		Enabled:  settings.Enabled,
+		Days:     settings.Days,
+		Start:    settings.Start,
+		End:      settings.End,
+		Timezone: settings.Timezone,
+		Holidays: settings.Holidays,
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	workhoursmocks "github.com/octobud-hq/octobud/backend/internal/core/workhours/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func setupWorkingHoursHandler(
+	t *testing.T,
+	ctrl *gomock.Controller,
+) (*Handler, *workhoursmocks.MockWorkingHoursService) {
+	t.Helper()
+	handler, mockAuthSvc := setupTestHandler(ctrl)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: "test-user-id"}, nil).
+		AnyTimes()
+	mockWorkHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+	handler.WithWorkingHoursService(mockWorkHours)
+	return handler, mockWorkHours
+}
+
+func testCalendar(t *testing.T) *workhours.Calendar {
+	t.Helper()
+	settings := models.DefaultWorkingHours()
+	settings.Enabled = true
+	settings.Timezone = "UTC"
+	calendar, err := workhours.NewCalendar(settings)
+	require.NoError(t, err)
+	return calendar
+}
+
+func TestHandler_HandleGetWorkingHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockWorkHours := setupWorkingHoursHandler(t, ctrl)
+	mockWorkHours.EXPECT().
+		GetWorkingHours(gomock.Any(), "test-user-id").
+		Return(models.DefaultWorkingHours(), nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetWorkingHours(
+		w, createRequest(http.MethodGet, "/api/user/working-hours", nil),
+	)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp WorkingHoursResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.False(t, resp.Enabled)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, resp.Days)
+	require.Equal(t, "09:00", resp.Start)
+	require.Equal(t, "17:00", resp.End)
+}
+
+func TestHandler_HandleUpdateWorkingHours(t *testing.T) {
+	t.Run("saves settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler, mockWorkHours := setupWorkingHoursHandler(t, ctrl)
+		mockWorkHours.EXPECT().
+			UpdateWorkingHours(gomock.Any(), "test-user-id", gomock.Any()).
+			DoAndReturn(func(
+				_ any,
+				_ string,
+				settings *models.WorkingHours,
+			) (*models.WorkingHours, error) {
+				require.True(t, settings.Enabled)
+				require.Equal(t, "Europe/Berlin", settings.Timezone)
+				require.Equal(t, []string{}, settings.Holidays)
+				return settings, nil
+			})
+
+		w := httptest.NewRecorder()
+		handler.HandleUpdateWorkingHours(w, createRequest(
+			http.MethodPut, "/api/user/working-hours", WorkingHoursRequest{
+				Enabled:  true,
+				Days:     []int{1, 2, 3, 4, 5},
+				Start:    "08:30",
+				End:      "16:30",
+				Timezone: "Europe/Berlin",
+			},
+		))
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid settings return bad request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler, mockWorkHours := setupWorkingHoursHandler(t, ctrl)
+		mockWorkHours.EXPECT().
+			UpdateWorkingHours(gomock.Any(), "test-user-id", gomock.Any()).
+			DoAndReturn(func(
+				_ any,
+				_ string,
+				settings *models.WorkingHours,
+			) (*models.WorkingHours, error) {
+				_, err := workhours.NewCalendar(settings)
+				return nil, err
+			})
+
+		w := httptest.NewRecorder()
+		handler.HandleUpdateWorkingHours(w, createRequest(
+			http.MethodPut, "/api/user/working-hours", WorkingHoursRequest{
+				Days:  []int{1},
+				Start: "17:00",
+				End:   "09:00",
+			},
+		))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var resp errorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Contains(t, resp.Error, "end must be after start")
+	})
+}
+
+func TestHandler_HandleGetNextBusinessDay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockWorkHours := setupWorkingHoursHandler(t, ctrl)
+	mockWorkHours.EXPECT().
+		Calendar(gomock.Any(), "test-user-id").
+		Return(testCalendar(t), nil)
+
+	// Friday afternoon resolves to Monday morning
+	w := httptest.NewRecorder()
+	handler.HandleGetNextBusinessDay(w, createRequest(
+		http.MethodGet,
+		"/api/user/working-hours/next-business-day?from=2025-12-19T15:00:00Z",
+		nil,
+	))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp BusinessTimeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.True(t, time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC).Equal(resp.At))
+}
+
+func TestHandler_HandleAddBusinessDays(t *testing.T) {
+	t.Run("adds business days", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler, mockWorkHours := setupWorkingHoursHandler(t, ctrl)
+		mockWorkHours.EXPECT().
+			Calendar(gomock.Any(), "test-user-id").
+			Return(testCalendar(t), nil)
+
+		w := httptest.NewRecorder()
+		handler.HandleAddBusinessDays(w, createRequest(
+			http.MethodGet,
+			"/api/user/working-hours/add-business-days?days=2&from=2025-12-19T15:00:00Z",
+			nil,
+		))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp BusinessTimeResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.True(t, time.Date(2025, 12, 23, 15, 0, 0, 0, time.UTC).Equal(resp.At))
+	})
+
+	for _, query := range []string{"", "?days=abc", "?days=400", "?days=1&from=yesterday"} {
+		t.Run("bad request "+query, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _ := setupWorkingHoursHandler(t, ctrl)
+
+			w := httptest.NewRecorder()
+			handler.HandleAddBusinessDays(w, createRequest(
+				http.MethodGet, "/api/user/working-hours/add-business-days"+query, nil,
+			))
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package users provides the handler for managing the users of a multi-user server.
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+// Handler handles user management HTTP routes
+type Handler struct {
+	logger  *zap.Logger
+	manager *users.Manager
+}
+
+// New creates a new users handler
+func New(logger *zap.Logger, manager *users.Manager) *Handler {
+	return &Handler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+// Register registers user management routes on the provided router. Only admins may
+// use them.
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/users", func(r chi.Router) {
+		r.Use(authn.RequireAdmin)
+		r.Get("/", h.handleList)
+		r.Post("/", h.handleCreate)
+		r.Patch("/{username}", h.handleUpdate)
+		r.Delete("/{username}", h.handleDelete)
+	})
+}
+
+type listResponse struct {
+	Users []models.ServerUser `json:"users"`
+}
+
+type createRequest struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Password string `json:"password"`
+}
+
+type updateRequest struct {
+	Name     *string `json:"name"`
+	Role     *string `json:"role"`
+	Password *string `json:"password"`
+}
+
+type userResponse struct {
+	User models.ServerUser `json:"user"`
+}
+
+func (h *Handler) handleList(w http.ResponseWriter, _ *http.Request) {
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Users: h.manager.List()})
+}
+
+// handleCreate adds a user. It returns once their workspace is running, so they can
+// sign in straight away.
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Role == "" {
+		req.Role = models.ServerUserRoleMember
+	}
+
+	user, err := h.manager.Create(users.CreateParams{
+		Username: req.Username,
+		Name:     req.Name,
+		Role:     req.Role,
+		Password: req.Password,
+	})
+	if err != nil {
+		h.writeError(w, "failed to create user", err)
+		return
+	}
+
+	h.logger.Info("added user",
+		zap.String("username", user.Username), zap.String("role", user.Role))
+	helpers.WriteJSON(w, http.StatusCreated, userResponse{User: user})
+}
+
+// handleUpdate changes a user's name, role or password. A new password signs the user
+// out everywhere.
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req updateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, err := h.manager.Update(chi.URLParam(r, "username"), users.UpdateParams{
+		Name:     req.Name,
+		Role:     req.Role,
+		Password: req.Password,
+	})
+	if err != nil {
+		h.writeError(w, "failed to update user", err)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, userResponse{User: user})
+}
+
+// handleDelete removes a user along with their workspace and its data
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	if err := h.manager.Delete(username); err != nil {
+		h.writeError(w, "failed to delete user", err)
+		return
+	}
+
+	h.logger.Info("deleted user", zap.String("username", username))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps user errors to status codes, logging unexpected ones
+func (h *Handler) writeError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, users.ErrNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "User not found")
+	case errors.Is(err, users.ErrInvalidUsername),
+		errors.Is(err, users.ErrInvalidName),
+		errors.Is(err, users.ErrInvalidRole),
+		errors.Is(err, users.ErrInvalidPassword):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, users.ErrAlreadyExists):
+		helpers.WriteError(w, http.StatusConflict, "A user with that username already exists")
+	case errors.Is(err, users.ErrLastAdmin):
+		helpers.WriteError(w, http.StatusConflict, "Make someone else an admin first")
+	case errors.Is(err, users.ErrDeleteDefault):
+		helpers.WriteError(
+			w, http.StatusConflict, "The user with the default workspace can't be deleted",
+		)
+	default:
+		h.logger.Error(msg, zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update users")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/authn"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+func newRouter(t *testing.T) (chi.Router, *users.Manager) {
+	t.Helper()
+	manager, err := users.NewManager(t.TempDir(), time.Now)
+	require.NoError(t, err)
+	_, err = manager.Create(users.CreateParams{Username: "alice", Role: models.ServerUserRoleAdmin})
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), manager).Register(router)
+	return router, manager
+}
+
+func serve(router chi.Router, session *authn.Session, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if session != nil {
+		req = req.WithContext(authn.ContextWithSession(req.Context(), session))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+var admin = &authn.Session{Subject: "alice", Admin: true}
+
+func TestHandler_RequiresAdmin(t *testing.T) {
+	router, _ := newRouter(t)
+
+	member := &authn.Session{Subject: "bob"}
+	require.Equal(t, http.StatusForbidden, serve(router, member, http.MethodGet, "/users", "").Code)
+	require.Equal(t, http.StatusForbidden, serve(router, nil, http.MethodGet, "/users", "").Code)
+	require.Equal(t, http.StatusForbidden,
+		serve(router, member, http.MethodPost, "/users", `{"username": "mallory"}`).Code)
+}
+
+func TestHandler_CreateListUpdateDelete(t *testing.T) {
+	router, manager := newRouter(t)
+
+	w := serve(router, admin, http.MethodPost, "/users",
+		`{"username": "Bob", "name": "Bob", "password": "correct horse"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created userResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.Equal(t, "bob", created.User.Username)
+	require.Equal(t, models.ServerUserRoleMember, created.User.Role)
+	require.True(t, created.User.HasPassword)
+	require.NotContains(t, w.Body.String(), "correct horse")
+
+	w = serve(router, admin, http.MethodGet, "/users", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list listResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Users, 2)
+	require.NotContains(t, w.Body.String(), "passwordHash")
+
+	w = serve(router, admin, http.MethodPatch, "/users/bob", `{"role": "admin"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	bob, err := manager.Get("bob")
+	require.NoError(t, err)
+	require.True(t, bob.IsAdmin())
+
+	require.Equal(t, http.StatusNoContent, serve(router, admin, http.MethodDelete, "/users/bob", "").Code)
+	require.Len(t, manager.List(), 1)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	router, _ := newRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{"invalid body", http.MethodPost, "/users", `{`, http.StatusBadRequest},
+		{"invalid username", http.MethodPost, "/users", `{"username": "no spaces"}`, http.StatusBadRequest},
+		{"short password", http.MethodPost, "/users", `{"username": "bob", "password": "x"}`, http.StatusBadRequest},
+		{"invalid role", http.MethodPost, "/users", `{"username": "bob", "role": "owner"}`, http.StatusBadRequest},
+		{"duplicate", http.MethodPost, "/users", `{"username": "ALICE"}`, http.StatusConflict},
+		{"unknown user", http.MethodPatch, "/users/nobody", `{"name": "x"}`, http.StatusNotFound},
+		{"last admin", http.MethodPatch, "/users/alice", `{"role": "member"}`, http.StatusConflict},
+		{"default workspace", http.MethodDelete, "/users/alice", "", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, admin, tt.method, tt.path, tt.body)
+			require.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+}
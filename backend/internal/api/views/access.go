@@ -0,0 +1,136 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// shareViewRequest grants a user read or write access to a view
+type shareViewRequest struct {
+	Permission string `json:"permission"`
+}
+
+// listViewGrantsResponse is the response type for who a view is shared with.
+type listViewGrantsResponse struct {
+	Shares []models.ViewGrant `json:"shares"`
+}
+
+// viewGrantEnvelope is the envelope type for a view's share with one user.
+type viewGrantEnvelope struct {
+	Share models.ViewGrant `json:"share"`
+}
+
+// handleListViewShares handles GET /api/views/{id}/shares
+func (h *Handler) handleListViewShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shares, err := h.shares.ListShares(ctx, userID, viewID)
+	if err != nil {
+		h.writeShareError(w, viewID, "failed to list view shares", err)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listViewGrantsResponse{Shares: shares})
+}
+
+// handleShareView handles PUT /api/views/{id}/shares/{username}
+func (h *Handler) handleShareView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req shareViewRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	share, err := h.shares.ShareView(
+		ctx, userID, viewID, chi.URLParam(r, "username"), req.Permission,
+	)
+	if err != nil {
+		h.writeShareError(w, viewID, "failed to share view", err)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, viewGrantEnvelope{Share: share})
+}
+
+// handleUnshareView handles DELETE /api/views/{id}/shares/{username}
+func (h *Handler) handleUnshareView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.shares.UnshareView(ctx, userID, viewID, chi.URLParam(r, "username"))
+	if err != nil {
+		h.writeShareError(w, viewID, "failed to stop sharing view", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeShareError maps view sharing errors to status codes, logging unexpected ones
+func (h *Handler) writeShareError(w http.ResponseWriter, viewID, msg string, err error) {
+	switch {
+	case errors.Is(err, viewshare.ErrViewNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "view not found")
+	case errors.Is(err, viewshare.ErrShareNotFound), errors.Is(err, viewshare.ErrUnknownUser):
+		helpers.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, viewshare.ErrInvalidPermission), errors.Is(err, viewshare.ErrShareWithSelf):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.Error(msg, zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, msg)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
+	viewsharemocks "github.com/octobud-hq/octobud/backend/internal/core/viewshare/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_ViewShares(t *testing.T) {
+	const testUserID = "test-user-id"
+	share := models.ViewGrant{ViewID: "v1", Grantee: "alice", Permission: "read"}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           interface{}
+		setupMock      func(*viewsharemocks.MockViewShareService)
+		expectedStatus int
+	}{
+		{
+			name:   "list shares",
+			method: http.MethodGet,
+			url:    "/views/v1/shares",
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().ListShares(gomock.Any(), testUserID, "v1").
+					Return([]models.ViewGrant{share}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list shares of missing view returns 404",
+			method: http.MethodGet,
+			url:    "/views/missing/shares",
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().ListShares(gomock.Any(), testUserID, "missing").
+					Return(nil, viewshare.ErrViewNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "share view",
+			method: http.MethodPut,
+			url:    "/views/v1/shares/alice",
+			body:   shareViewRequest{Permission: "read"},
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().ShareView(gomock.Any(), testUserID, "v1", "alice", "read").
+					Return(share, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "share with unknown user returns 404",
+			method: http.MethodPut,
+			url:    "/views/v1/shares/mallory",
+			body:   shareViewRequest{Permission: "read"},
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().ShareView(gomock.Any(), testUserID, "v1", "mallory", "read").
+					Return(models.ViewGrant{}, viewshare.ErrUnknownUser)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "share with bad permission returns 400",
+			method: http.MethodPut,
+			url:    "/views/v1/shares/alice",
+			body:   shareViewRequest{Permission: "admin"},
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().ShareView(gomock.Any(), testUserID, "v1", "alice", "admin").
+					Return(models.ViewGrant{}, viewshare.ErrInvalidPermission)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "stop sharing",
+			method: http.MethodDelete,
+			url:    "/views/v1/shares/alice",
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().UnshareView(gomock.Any(), testUserID, "v1", "alice").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "service error returns 500",
+			method: http.MethodDelete,
+			url:    "/views/v1/shares/alice",
+			setupMock: func(m *viewsharemocks.MockViewShareService) {
+				m.EXPECT().UnshareView(gomock.Any(), testUserID, "v1", "alice").
+					Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockShares := viewsharemocks.NewMockViewShareService(ctrl)
+			handler.WithSharing(mockShares)
+			tt.setupMock(mockShares)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			router := chi.NewRouter()
+			handler.Register(router)
+
+			req := createRequest(tt.method, tt.url, tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_ViewShares_DisabledWithoutSharing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler, _, _ := setupTestHandler(ctrl)
+	router := chi.NewRouter()
+	handler.Register(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createRequest(http.MethodGet, "/views/v1/shares", nil))
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
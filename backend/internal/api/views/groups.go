@@ -0,0 +1,230 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	viewcore "github.com/octobud-hq/octobud/backend/internal/core/view"
+)
+
+type createViewGroupRequest struct {
+	Name string `json:"name"`
+}
+
+type updateViewGroupRequest struct {
+	Name      *string `json:"name"`
+	Collapsed *bool   `json:"collapsed"`
+}
+
+type reorderViewGroupsRequest struct {
+	GroupIDs []string `json:"groupIDs"`
+}
+
+// setViewGroupRequest moves a view into a group; a null groupId ungroups it
+type setViewGroupRequest struct {
+	GroupID *string `json:"groupId"`
+}
+
+func (h *Handler) handleListViewGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	groups, err := h.viewSvc.ListViewGroups(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to load view groups", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load view groups")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listViewGroupsResponse{Groups: groups})
+}
+
+func (h *Handler) handleCreateViewGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req createViewGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	group, err := h.viewSvc.CreateViewGroup(ctx, userID, req.Name)
+	if err != nil {
+		if writeViewGroupError(w, err) {
+			return
+		}
+		h.logger.Error("failed to create view group", zap.String("name", req.Name), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to create view group")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, viewGroupEnvelope{Group: group})
+}
+
+func (h *Handler) handleUpdateViewGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	groupID := chi.URLParam(r, "groupID")
+	if groupID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "groupID is required")
+		return
+	}
+
+	var req updateViewGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	group, err := h.viewSvc.UpdateViewGroup(ctx, userID, groupID, req.Name, req.Collapsed)
+	if err != nil {
+		if writeViewGroupError(w, err) {
+			return
+		}
+		h.logger.Error("failed to update view group", zap.String("group_id", groupID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to update view group")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, viewGroupEnvelope{Group: group})
+}
+
+func (h *Handler) handleDeleteViewGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	groupID := chi.URLParam(r, "groupID")
+	if groupID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "groupID is required")
+		return
+	}
+
+	if err := h.viewSvc.DeleteViewGroup(ctx, userID, groupID); err != nil {
+		if writeViewGroupError(w, err) {
+			return
+		}
+		h.logger.Error("failed to delete view group", zap.String("group_id", groupID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to delete view group")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleReorderViewGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var req reorderViewGroupsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	groups, err := h.viewSvc.ReorderViewGroups(ctx, userID, req.GroupIDs)
+	if err != nil {
+		if writeViewGroupError(w, err) {
+			return
+		}
+		h.logger.Error("failed to reorder view groups", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to reorder view groups")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, listViewGroupsResponse{Groups: groups})
+}
+
+func (h *Handler) handleSetViewGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req setViewGroupRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	view, err := h.viewSvc.SetViewGroup(ctx, userID, viewID, req.GroupID)
+	if err != nil {
+		if errors.Is(err, viewcore.ErrViewNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "view not found")
+			return
+		}
+		if writeViewGroupError(w, err) {
+			return
+		}
+		h.logger.Error("failed to set view group", zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to set view group")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, viewEnvelope{View: view})
+}
+
+// writeViewGroupError writes the response for view group errors the client can act on and
+// reports whether it did
+func writeViewGroupError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, viewcore.ErrViewGroupNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "view group not found")
+	case errors.Is(err, viewcore.ErrViewGroupNameAlreadyExists):
+		helpers.WriteError(w, http.StatusConflict, viewcore.ErrViewGroupNameAlreadyExists.Error())
+	case errors.Is(err, viewcore.ErrGroupNameRequired), errors.Is(err, viewcore.ErrGroupIDsRequired):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	viewcore "github.com/octobud-hq/octobud/backend/internal/core/view"
+	viewmocks "github.com/octobud-hq/octobud/backend/internal/core/view/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_ViewGroups(t *testing.T) {
+	const testUserID = "test-user-id"
+	group := models.ViewGroup{ID: "g1", Name: "Work", DisplayOrder: 100}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           interface{}
+		setupMock      func(*viewmocks.MockViewService)
+		expectedStatus int
+	}{
+		{
+			name:   "list groups",
+			method: http.MethodGet,
+			url:    "/views/groups",
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().ListViewGroups(gomock.Any(), testUserID).
+					Return([]models.ViewGroup{group}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "create group",
+			method: http.MethodPost,
+			url:    "/views/groups",
+			body:   createViewGroupRequest{Name: "Work"},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().CreateViewGroup(gomock.Any(), testUserID, "Work").Return(group, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "create group with taken name returns 409",
+			method: http.MethodPost,
+			url:    "/views/groups",
+			body:   createViewGroupRequest{Name: "Work"},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().CreateViewGroup(gomock.Any(), testUserID, "Work").
+					Return(models.ViewGroup{}, viewcore.ErrViewGroupNameAlreadyExists)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "create group without a name returns 400",
+			method: http.MethodPost,
+			url:    "/views/groups",
+			body:   createViewGroupRequest{},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().CreateViewGroup(gomock.Any(), testUserID, "").
+					Return(models.ViewGroup{}, viewcore.ErrGroupNameRequired)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "collapse group",
+			method: http.MethodPut,
+			url:    "/views/groups/g1",
+			body:   map[string]bool{"collapsed": true},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().
+					UpdateViewGroup(gomock.Any(), testUserID, "g1", nil, gomock.Any()).
+					DoAndReturn(func(_, _, _ interface{}, _ *string, collapsed *bool) (models.ViewGroup, error) {
+						require.True(t, *collapsed)
+						return models.ViewGroup{ID: "g1", Name: "Work", Collapsed: true}, nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "update missing group returns 404",
+			method: http.MethodPut,
+			url:    "/views/groups/missing",
+			body:   updateViewGroupRequest{Name: models.StrPtr("Home")},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().
+					UpdateViewGroup(gomock.Any(), testUserID, "missing", gomock.Any(), nil).
+					Return(models.ViewGroup{}, viewcore.ErrViewGroupNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "delete group",
+			method: http.MethodDelete,
+			url:    "/views/groups/g1",
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().DeleteViewGroup(gomock.Any(), testUserID, "g1").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "reorder groups",
+			method: http.MethodPost,
+			url:    "/views/groups/reorder",
+			body:   reorderViewGroupsRequest{GroupIDs: []string{"g2", "g1"}},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().ReorderViewGroups(gomock.Any(), testUserID, []string{"g2", "g1"}).
+					Return([]models.ViewGroup{group}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "move view into group",
+			method: http.MethodPut,
+			url:    "/views/v1/group",
+			body:   setViewGroupRequest{GroupID: models.StrPtr("g1")},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().SetViewGroup(gomock.Any(), testUserID, "v1", gomock.Any()).
+					Return(models.View{ID: "v1", GroupID: models.StrPtr("g1")}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "move view into missing group returns 404",
+			method: http.MethodPut,
+			url:    "/views/v1/group",
+			body:   setViewGroupRequest{GroupID: models.StrPtr("missing")},
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().SetViewGroup(gomock.Any(), testUserID, "v1", gomock.Any()).
+					Return(models.View{}, viewcore.ErrViewGroupNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "service error returns 500",
+			method: http.MethodDelete,
+			url:    "/views/groups/g1",
+			setupMock: func(m *viewmocks.MockViewService) {
+				m.EXPECT().DeleteViewGroup(gomock.Any(), testUserID, "g1").
+					Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			tt.setupMock(mockSvc)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			router := chi.NewRouter()
+			handler.Register(router)
+
+			req := createRequest(tt.method, tt.url, tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
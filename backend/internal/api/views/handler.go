@@ -17,18 +17,29 @@
 package views
 
 import (
+	"time"
+
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
 	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/core/viewshare"
 )
 
 // Handler handles view-related HTTP routes
 type Handler struct {
-	logger  *zap.Logger
-	viewSvc view.ViewService
-	authSvc authsvc.AuthService
+	logger        *zap.Logger
+	viewSvc       view.ViewService
+	authSvc       authsvc.AuthService
+	notifications notification.NotificationService
+	prewarm       prewarm.PrewarmService
+	triage        triage.TriageService
+	shares        viewshare.ViewShareService
+	now           func() time.Time
 }
 
 // New creates a new views handler
@@ -37,16 +48,67 @@ func New(logger *zap.Logger, viewSvc view.ViewService, authSvc authsvc.AuthServi
 		logger:  logger,
 		viewSvc: viewSvc,
 		authSvc: authSvc,
+		now:     time.Now,
 	}
 }
 
+// WithNotifications enables rendering a view's notifications as a printable page
+func (h *Handler) WithNotifications(notifications notification.NotificationService) *Handler {
+	h.notifications = notifications
+	return h
+}
+
+// WithPrewarm serves view counts warmed at startup to the first request for them
+func (h *Handler) WithPrewarm(prewarmSvc prewarm.PrewarmService) *Handler {
+	h.prewarm = prewarmSvc
+	return h
+}
+
+// WithTriage enables exporting views as documents to share and importing them
+func (h *Handler) WithTriage(triageSvc triage.TriageService) *Handler {
+	h.triage = triageSvc
+	return h
+}
+
+// WithSharing enables sharing views with the other users of a multi-user server
+func (h *Handler) WithSharing(shares viewshare.ViewShareService) *Handler {
+	h.shares = shares
+	return h
+}
+
 // Register registers view routes on the provided router
 func (h *Handler) Register(r chi.Router) {
 	r.Route("/views", func(r chi.Router) {
 		r.Get("/", h.handleListViews)
+		r.Get("/counts", h.handleViewCounts)
 		r.Post("/", h.handleCreateView)
 		r.Post("/reorder", h.handleReorderViews)
+		if h.triage != nil {
+			r.Post("/import", h.handleImportView)
+		}
+		r.Route("/groups", func(r chi.Router) {
+			r.Get("/", h.handleListViewGroups)
+			r.Post("/", h.handleCreateViewGroup)
+			r.Post("/reorder", h.handleReorderViewGroups)
+			r.Put("/{groupID}", h.handleUpdateViewGroup)
+			r.Delete("/{groupID}", h.handleDeleteViewGroup)
+		})
 		r.Put("/{id}", h.handleUpdateView)
+		r.Put("/{id}/group", h.handleSetViewGroup)
+		r.Put("/{id}/wip-limit", h.handleSetWIPLimit)
 		r.Delete("/{id}", h.handleDeleteView)
+		if h.notifications != nil {
+			r.Get("/{id}/render", h.handleRenderView)
+		}
+		if h.triage != nil {
+			r.Get("/{id}/export", h.handleExportView)
+		}
+		if h.shares != nil {
+			r.Route("/{id}/shares", func(r chi.Router) {
+				r.Get("/", h.handleListViewShares)
+				r.Put("/{username}", h.handleShareView)
+				r.Delete("/{username}", h.handleUnshareView)
+			})
+		}
 	})
 }
@@ -31,6 +31,7 @@ import (
 
 	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
 	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	prewarmmocks "github.com/octobud-hq/octobud/backend/internal/core/prewarm/mocks"
 	viewcore "github.com/octobud-hq/octobud/backend/internal/core/view"
 	viewmocks "github.com/octobud-hq/octobud/backend/internal/core/view/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/models"
@@ -82,8 +83,12 @@ func TestHandler_handleListViews(t *testing.T) {
 							IsDefault:    false,
 							DisplayOrder: 100,
 							UnreadCount:  5,
+							GroupID:      models.StrPtr("g1"),
 						},
 					}, nil)
+				mockSvc.EXPECT().
+					ListViewGroups(gomock.Any(), "test-user-id").
+					Return([]models.ViewGroup{{ID: "g1", Name: "Work", DisplayOrder: 100}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -93,6 +98,9 @@ func TestHandler_handleListViews(t *testing.T) {
 				require.GreaterOrEqual(t, len(response.Views), 1)
 				require.Equal(t, "1", response.Views[0].ID)
 				require.Equal(t, "Test View", response.Views[0].Name)
+				require.Equal(t, "g1", *response.Views[0].GroupID)
+				require.Len(t, response.Groups, 1)
+				require.Equal(t, "Work", response.Groups[0].Name)
 			},
 		},
 		{
@@ -141,6 +149,96 @@ func TestHandler_handleListViews(t *testing.T) {
 	}
 }
 
+func TestHandler_handleViewCounts(t *testing.T) {
+	count := int64(12)
+	tests := []struct {
+		name           string
+		counts         []models.ViewCount
+		err            error
+		expectedStatus int
+	}{
+		{
+			name: "returns every view's counts",
+			counts: []models.ViewCount{
+				{ID: "1", Slug: "reviews", UnreadCount: 3, Count: &count, OverLimit: true},
+				{ID: "inbox", Slug: "inbox", UnreadCount: 7},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "service error returns 500",
+			err:            errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			const testUserID = "test-user-id"
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			mockSvc.EXPECT().ViewCounts(gomock.Any(), testUserID).Return(tt.counts, tt.err)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+
+			req := createRequest(http.MethodGet, "/views/counts", nil)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+
+			handler.handleViewCounts(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.counts != nil {
+				var response viewCountsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tt.counts, response.Counts)
+			}
+		})
+	}
+}
+
+func TestHandler_handleListViews_ServesWarmedCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const testUserID = "test-user-id"
+	handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+	mockPrewarm := prewarmmocks.NewMockPrewarmService(ctrl)
+	handler = handler.WithPrewarm(mockPrewarm)
+	mockAuthSvc.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{GithubUserID: testUserID}, nil).
+		AnyTimes()
+
+	// The first request is served from the warm-up, the next from the service
+	gomock.InOrder(
+		mockPrewarm.EXPECT().TakeViewCounts(testUserID).
+			Return([]models.View{{ID: "1", UnreadCount: 3}}, true),
+		mockPrewarm.EXPECT().TakeViewCounts(testUserID).Return(nil, false),
+	)
+	mockSvc.EXPECT().
+		ListViewsWithCounts(gomock.Any(), testUserID).
+		Return([]models.View{{ID: "1", UnreadCount: 4}}, nil)
+	mockSvc.EXPECT().ListViewGroups(gomock.Any(), testUserID).Return(nil, nil).Times(2)
+
+	for _, want := range []int64{3, 4} {
+		req := createRequest(http.MethodGet, "/views", nil)
+		req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+		w := httptest.NewRecorder()
+
+		handler.handleListViews(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response listViewsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, want, response.Views[0].UnreadCount)
+	}
+}
+
 func TestHandler_handleCreateView(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -512,7 +610,7 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "1", false).
-					Return(0, nil) // No linked rules
+					Return(nil, nil) // No linked rules
 			},
 			expectedStatus: http.StatusNoContent,
 		},
@@ -523,7 +621,7 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "invalid", false).
-					Return(0, viewcore.ErrViewNotFound)
+					Return(nil, viewcore.ErrViewNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 		},
@@ -534,7 +632,7 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "999", false).
-					Return(0, viewcore.ErrViewNotFound)
+					Return(nil, viewcore.ErrViewNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 		},
@@ -545,7 +643,10 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "1", false).
-					Return(2, nil) // 2 linked rules
+					Return([]models.Rule{
+						{ID: "rule-1", Name: "Rule 1", Enabled: true},
+						{ID: "rule-2", Name: "Rule 2", Enabled: false},
+					}, nil)
 			},
 			expectedStatus: http.StatusConflict,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -554,6 +655,15 @@ func TestHandler_handleDeleteView(t *testing.T) {
 				require.NoError(t, err)
 				require.Contains(t, response["error"], "linked rules")
 				require.Equal(t, float64(2), response["linkedRuleCount"])
+
+				var rules struct {
+					DependentRules []helpers.DependentRule `json:"dependentRules"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rules))
+				require.Equal(t, []helpers.DependentRule{
+					{ID: "rule-1", Name: "Rule 1", Enabled: true},
+					{ID: "rule-2", Name: "Rule 2", Enabled: false},
+				}, rules.DependentRules)
 			},
 		},
 		{
@@ -563,7 +673,7 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "1", false).
-					Return(0, errors.New("database error"))
+					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -574,7 +684,28 @@ func TestHandler_handleDeleteView(t *testing.T) {
 			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
 				mockSvc.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", "1", true).
-					Return(2, nil) // 2 linked rules, but force=true so deletion proceeds
+					Return([]models.Rule{
+						{ID: "rule-1", Name: "Rule 1"},
+						{ID: "rule-2", Name: "Rule 2"},
+					}, nil) // Linked rules were disabled and the view deleted
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response struct {
+					DisabledRules []helpers.DependentRule `json:"disabledRules"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Len(t, response.DisabledRules, 2)
+			},
+		},
+		{
+			name:   "force delete without linked rules returns no content",
+			viewID: "1",
+			force:  true,
+			setupMock: func(mockSvc *viewmocks.MockViewService, _ string) {
+				mockSvc.EXPECT().
+					DeleteView(gomock.Any(), "test-user-id", "1", true).
+					Return(nil, nil)
 			},
 			expectedStatus: http.StatusNoContent,
 		},
@@ -618,6 +749,87 @@ func TestHandler_handleDeleteView(t *testing.T) {
 	}
 }
 
+func TestHandler_handleSetWIPLimit(t *testing.T) {
+	limit := int64(10)
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMock      func(*viewmocks.MockViewService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "sets the limit",
+			requestBody: setWIPLimitRequest{Limit: &limit, AutoSnooze: true},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				count := int64(12)
+				mockSvc.EXPECT().
+					SetWIPLimit(gomock.Any(), "test-user-id", "1", &limit, true).
+					Return(models.View{
+						ID:            "1",
+						WIPLimit:      &limit,
+						WIPAutoSnooze: true,
+						Count:         &count,
+						OverLimit:     true,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp viewEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, &limit, resp.View.WIPLimit)
+				require.True(t, resp.View.OverLimit)
+			},
+		},
+		{
+			name:        "invalid limit returns bad request",
+			requestBody: map[string]int{"limit": 0},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().
+					SetWIPLimit(gomock.Any(), "test-user-id", "1", gomock.Any(), false).
+					Return(models.View{}, viewcore.ErrInvalidWIPLimit)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown view returns not found",
+			requestBody: map[string]any{"limit": nil},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().
+					SetWIPLimit(gomock.Any(), "test-user-id", "1", nil, false).
+					Return(models.View{}, viewcore.ErrViewNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			tt.setupMock(mockSvc)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+
+			req := createRequest(http.MethodPut, "/views/1/wip-limit", tt.requestBody)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+
+			w := httptest.NewRecorder()
+			handler.handleSetWIPLimit(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
 func TestHandler_handleReorderViews(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -665,6 +877,9 @@ func TestHandler_handleReorderViews(t *testing.T) {
 							UnreadCount:  0,
 						},
 					}, nil)
+				mockSvc.EXPECT().
+					ListViewGroups(gomock.Any(), "test-user-id").
+					Return([]models.ViewGroup{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
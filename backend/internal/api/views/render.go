@@ -0,0 +1,204 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	viewcore "github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// maxRenderItems caps how many notifications a rendered view lists
+const maxRenderItems = 200
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var renderTemplate = template.Must(template.ParseFS(templatesFS, "templates/render.html.tmpl"))
+
+// renderPage is the data behind a rendered view
+type renderPage struct {
+	Name        string
+	Query       string
+	GeneratedAt string
+	Total       int64
+	Truncated   bool
+	Items       []renderItem
+}
+
+// renderItem is a single notification in a rendered view
+type renderItem struct {
+	Title      string
+	URL        string
+	State      string
+	Repository string
+	Number     int64
+	Type       string
+	Reason     string
+	Age        string
+	IsRead     bool
+}
+
+func (h *Handler) handleRenderView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if format := r.URL.Query().Get("format"); format != "" && format != "html" {
+		helpers.WriteError(w, http.StatusBadRequest, "unsupported format: "+format)
+		return
+	}
+
+	view, err := h.viewSvc.ResolveView(ctx, userID, viewID)
+	if err != nil {
+		if errors.Is(err, viewcore.ErrViewNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "view not found")
+			return
+		}
+		h.logger.Error("failed to load view", zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load view")
+		return
+	}
+
+	result, err := h.notifications.ListNotifications(ctx, userID, models.ListOptions{
+		Query:    view.Query,
+		PageSize: maxRenderItems,
+	})
+	if err != nil {
+		h.logger.Error("failed to list view notifications",
+			zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to list notifications")
+		return
+	}
+
+	now := h.now()
+	page := renderPage{
+		Name:        view.Name,
+		Query:       view.Query,
+		GeneratedAt: now.Format("Jan 2, 2006 3:04 PM"),
+		Total:       result.Total,
+		Truncated:   result.Total > int64(len(result.Notifications)),
+		Items:       make([]renderItem, 0, len(result.Notifications)),
+	}
+	for _, n := range result.Notifications {
+		page.Items = append(page.Items, newRenderItem(n, now))
+	}
+
+	var buf bytes.Buffer
+	if err := renderTemplate.Execute(&buf, page); err != nil {
+		h.logger.Error("failed to render view", zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to render view")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func newRenderItem(n models.Notification, now time.Time) renderItem {
+	item := renderItem{
+		Title:  n.SubjectTitle,
+		Type:   subjectTypeLabel(n.SubjectType),
+		Age:    formatAge(now.Sub(n.EffectiveSortDate)),
+		IsRead: n.IsRead,
+	}
+	if n.SubjectNumber != nil {
+		item.Number = *n.SubjectNumber
+	}
+	if n.Reason != nil {
+		item.Reason = strings.ReplaceAll(*n.Reason, "_", " ")
+	}
+	if n.SubjectState != nil {
+		item.State = *n.SubjectState
+	}
+	if n.SubjectMerged != nil && *n.SubjectMerged {
+		item.State = "merged"
+	}
+	if n.Repository != nil {
+		item.Repository = n.Repository.FullName
+		item.URL = subjectLink(n)
+	}
+	return item
+}
+
+// subjectLink links to the subject on GitHub when it has a number, otherwise to the repository
+func subjectLink(n models.Notification) string {
+	if n.Repository.HTMLURL == nil || *n.Repository.HTMLURL == "" {
+		return ""
+	}
+	base := strings.TrimSuffix(*n.Repository.HTMLURL, "/")
+	if n.SubjectNumber == nil {
+		return base
+	}
+	path := "issues"
+	switch n.SubjectType {
+	case "PullRequest":
+		path = "pull"
+	case "Discussion":
+		path = "discussions"
+	}
+	return fmt.Sprintf("%s/%s/%d", base, path, *n.SubjectNumber)
+}
+
+func subjectTypeLabel(subjectType string) string {
+	switch subjectType {
+	case "PullRequest":
+		return "Pull request"
+	case "CheckSuite":
+		return "Check suite"
+	case "RepositoryVulnerabilityAlert":
+		return "Security alert"
+	default:
+		return subjectType
+	}
+}
+
+// formatAge renders a duration compactly, like "5m", "3h", "2d", or "6w"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dw", int(d.Hours()/(24*7)))
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	viewcore "github.com/octobud-hq/octobud/backend/internal/core/view"
+	viewmocks "github.com/octobud-hq/octobud/backend/internal/core/view/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_handleRenderView(t *testing.T) {
+	number := int64(42)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		viewID         string
+		url            string
+		setupMocks     func(*viewmocks.MockViewService, *notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:   "renders notifications as html",
+			viewID: "v1",
+			url:    "/views/v1/render?format=html",
+			setupMocks: func(
+				viewSvc *viewmocks.MockViewService,
+				notifSvc *notificationmocks.MockNotificationService,
+			) {
+				viewSvc.EXPECT().
+					ResolveView(gomock.Any(), "test-user-id", "v1").
+					Return(models.View{ID: "v1", Name: "Reviews", Query: "reason:review_requested"}, nil)
+				notifSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", models.ListOptions{
+						Query:    "reason:review_requested",
+						PageSize: maxRenderItems,
+					}).
+					Return(models.ListDetailsResult{
+						Total: 1,
+						Notifications: []models.Notification{{
+							SubjectTitle:      "Fix <script> escaping",
+							SubjectType:       "PullRequest",
+							SubjectNumber:     &number,
+							Reason:            models.StrPtr("review_requested"),
+							EffectiveSortDate: now.Add(-3 * time.Hour),
+							Repository: &models.Repository{
+								FullName: "cli/cli",
+								HTMLURL:  models.StrPtr("https://github.com/cli/cli"),
+							},
+						}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				"<h1>Reviews</h1>",
+				"1 notification &middot;",
+				`<a href="https://github.com/cli/cli/pull/42">Fix &lt;script&gt; escaping</a>`,
+				"review requested",
+				">3h<",
+			},
+		},
+		{
+			name:   "notes when the list is cut short",
+			viewID: "inbox",
+			url:    "/views/inbox/render",
+			setupMocks: func(
+				viewSvc *viewmocks.MockViewService,
+				notifSvc *notificationmocks.MockNotificationService,
+			) {
+				viewSvc.EXPECT().
+					ResolveView(gomock.Any(), "test-user-id", "inbox").
+					Return(models.View{ID: "inbox", Name: "Inbox", Query: "in:inbox"}, nil)
+				notifSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.ListDetailsResult{
+						Total: 250,
+						Notifications: []models.Notification{{
+							SubjectTitle:      "Release v2",
+							SubjectType:       "Release",
+							EffectiveSortDate: now.Add(-20 * 24 * time.Hour),
+						}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"1 of 250 notifications", "Release v2", ">2w<"},
+		},
+		{
+			name:   "empty view",
+			viewID: "v1",
+			url:    "/views/v1/render",
+			setupMocks: func(
+				viewSvc *viewmocks.MockViewService,
+				notifSvc *notificationmocks.MockNotificationService,
+			) {
+				viewSvc.EXPECT().
+					ResolveView(gomock.Any(), "test-user-id", "v1").
+					Return(models.View{ID: "v1", Name: "Reviews", Query: "is:unread"}, nil)
+				notifSvc.EXPECT().
+					ListNotifications(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(models.ListDetailsResult{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"Nothing in this view."},
+		},
+		{
+			name:           "unsupported format returns 400",
+			viewID:         "v1",
+			url:            "/views/v1/render?format=pdf",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown view returns 404",
+			viewID: "v1",
+			url:    "/views/v1/render",
+			setupMocks: func(
+				viewSvc *viewmocks.MockViewService,
+				_ *notificationmocks.MockNotificationService,
+			) {
+				viewSvc.EXPECT().
+					ResolveView(gomock.Any(), "test-user-id", "v1").
+					Return(models.View{}, viewcore.ErrViewNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			handler, mockSvc, mockAuthSvc := setupTestHandler(ctrl)
+			mockNotifSvc := notificationmocks.NewMockNotificationService(ctrl)
+			handler.WithNotifications(mockNotifSvc)
+			handler.now = func() time.Time { return now }
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: "test-user-id"}, nil).
+				AnyTimes()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc, mockNotifSvc)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.viewID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), "test-user-id"))
+
+			w := httptest.NewRecorder()
+			handler.handleRenderView(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			for _, fragment := range tt.expectedBody {
+				require.Contains(t, w.Body.String(), fragment)
+			}
+		})
+	}
+}
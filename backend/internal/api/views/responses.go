@@ -22,9 +22,26 @@ import (
 // ViewResponse is the response type for a view.
 type ViewResponse = models.View
 
-// listViewsResponse is the response type for a list of views.
+// listViewsResponse is the response type for a list of views, with the groups they're
+// organized into.
 type listViewsResponse struct {
-	Views []ViewResponse `json:"views"`
+	Views  []ViewResponse     `json:"views"`
+	Groups []models.ViewGroup `json:"groups"`
+}
+
+// viewGroupEnvelope is the envelope type for a view group.
+type viewGroupEnvelope struct {
+	Group models.ViewGroup `json:"group"`
+}
+
+// listViewGroupsResponse is the response type for a list of view groups.
+type listViewGroupsResponse struct {
+	Groups []models.ViewGroup `json:"groups"`
+}
+
+// viewCountsResponse is the response type for every view's counts.
+type viewCountsResponse struct {
+	Counts []models.ViewCount `json:"counts"`
 }
 
 // viewEnvelope is the envelope type for a view.
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// handleExportView handles GET /api/views/{id}/export
+// Returns a custom view as a JSON document to share. With ?rules=true the rules that
+// follow the view are included.
+func (h *Handler) handleExportView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	viewID := chi.URLParam(r, "id")
+	if viewID == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+	includeRules := r.URL.Query().Get("rules") == "true"
+
+	share, err := h.triage.ExportView(ctx, userID, viewID, includeRules)
+	if err != nil {
+		if errors.Is(err, triage.ErrViewNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "view not found")
+			return
+		}
+		h.logger.Error("failed to export view", zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to export view")
+		return
+	}
+
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="octobud-view-%s.json"`, share.View.Slug))
+	helpers.WriteJSON(w, http.StatusOK, share)
+}
+
+// handleImportView handles POST /api/views/import
+// Creates the view and rules in a shared view document, renaming them if their names are
+// already taken
+func (h *Handler) handleImportView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	var share models.ViewShare
+	if err := json.NewDecoder(r.Body).Decode(&share); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.triage.ImportView(ctx, userID, &share)
+	if err != nil {
+		if errors.Is(err, triage.ErrInvalidViewShare) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to import view", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to import view")
+		return
+	}
+
+	h.logger.Info("imported shared view",
+		zap.String("view_id", result.View.ID),
+		zap.Bool("renamed", result.Renamed),
+		zap.Int("rulesCreated", result.Rules.Created),
+		zap.Int("tagsCreated", result.Tags.Created),
+	)
+	helpers.WriteJSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/triage"
+	triagemocks "github.com/octobud-hq/octobud/backend/internal/core/triage/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestHandler_ShareViews(t *testing.T) {
+	const testUserID = "test-user-id"
+	share := &models.ViewShare{
+		Version: models.ViewShareVersion,
+		View:    models.ViewBackup{Name: "Reviews", Slug: "reviews", Query: "reason:review_requested"},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           interface{}
+		setupMock      func(*triagemocks.MockTriageService)
+		expectedStatus int
+		expectedHeader string
+	}{
+		{
+			name:   "export view",
+			method: http.MethodGet,
+			url:    "/views/view-1/export",
+			setupMock: func(m *triagemocks.MockTriageService) {
+				m.EXPECT().ExportView(gomock.Any(), testUserID, "view-1", false).Return(share, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeader: `attachment; filename="octobud-view-reviews.json"`,
+		},
+		{
+			name:   "export view with rules",
+			method: http.MethodGet,
+			url:    "/views/view-1/export?rules=true",
+			setupMock: func(m *triagemocks.MockTriageService) {
+				m.EXPECT().ExportView(gomock.Any(), testUserID, "view-1", true).Return(share, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "export missing view returns 404",
+			method: http.MethodGet,
+			url:    "/views/gone/export",
+			setupMock: func(m *triagemocks.MockTriageService) {
+				m.EXPECT().ExportView(gomock.Any(), testUserID, "gone", false).
+					Return(nil, triage.ErrViewNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "import view",
+			method: http.MethodPost,
+			url:    "/views/import",
+			body:   share,
+			setupMock: func(m *triagemocks.MockTriageService) {
+				m.EXPECT().ImportView(gomock.Any(), testUserID, share).
+					Return(&models.ViewShareImportResult{View: models.View{ID: "view-2"}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "import invalid view returns 400",
+			method: http.MethodPost,
+			url:    "/views/import",
+			body:   share,
+			setupMock: func(m *triagemocks.MockTriageService) {
+				m.EXPECT().ImportView(gomock.Any(), testUserID, share).
+					Return(nil, fmt.Errorf("%w: view query is required", triage.ErrInvalidViewShare))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "import with invalid body returns 400",
+			method:         http.MethodPost,
+			url:            "/views/import",
+			body:           "not a document",
+			setupMock:      func(*triagemocks.MockTriageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _, mockAuthSvc := setupTestHandler(ctrl)
+			mockTriage := triagemocks.NewMockTriageService(ctrl)
+			tt.setupMock(mockTriage)
+			mockAuthSvc.EXPECT().
+				GetUser(gomock.Any()).
+				Return(&models.User{GithubUserID: testUserID}, nil).
+				AnyTimes()
+			router := chi.NewRouter()
+			handler.WithTriage(mockTriage).Register(router)
+
+			req := createRequest(tt.method, tt.url, tt.body)
+			req = req.WithContext(helpers.ContextWithUserID(req.Context(), testUserID))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedHeader != "" {
+				require.Equal(t, tt.expectedHeader, w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
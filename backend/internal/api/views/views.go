@@ -16,6 +16,7 @@
 package views
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -45,6 +46,12 @@ type updateViewRequest struct {
 	Query       *string `json:"query"`
 }
 
+// setWIPLimitRequest sets a view's WIP limit; a null limit clears it
+type setWIPLimitRequest struct {
+	Limit      *int64 `json:"limit"`
+	AutoSnooze bool   `json:"autoSnooze"`
+}
+
 type reorderViewsRequest struct {
 	ViewIDs []string `json:"viewIDs"`
 }
@@ -57,8 +64,25 @@ func (h *Handler) handleListViews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	views, err := h.viewSvc.ListViewsWithCounts(ctx, userID)
+	views, err := h.listViewsWithCounts(ctx, userID)
+	if err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load views")
+		return
+	}
+
+	h.writeViews(ctx, w, userID, views)
+}
+
+// writeViews writes a list of views along with the user's view groups
+func (h *Handler) writeViews(
+	ctx context.Context,
+	w http.ResponseWriter,
+	userID string,
+	views []models.View,
+) {
+	groups, err := h.viewSvc.ListViewGroups(ctx, userID)
 	if err != nil {
+		h.logger.Error("failed to load view groups", zap.Error(err))
 		helpers.WriteError(w, http.StatusInternalServerError, "failed to load views")
 		return
 	}
@@ -66,7 +90,46 @@ func (h *Handler) handleListViews(w http.ResponseWriter, r *http.Request) {
 	response := make([]ViewResponse, 0, len(views))
 	response = append(response, views...)
 
-	helpers.WriteJSON(w, http.StatusOK, listViewsResponse{Views: response})
+	helpers.WriteJSON(w, http.StatusOK, listViewsResponse{Views: response, Groups: groups})
+}
+
+// handleViewCounts returns the counts of every view, for refreshing badges without
+// reloading the views themselves
+func (h *Handler) handleViewCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+
+	counts, err := h.viewSvc.ViewCounts(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to load view counts", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to load view counts")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, viewCountsResponse{Counts: counts})
+}
+
+// listViewsWithCounts returns the counts warmed at startup the first time they're asked
+// for, and live counts after that
+func (h *Handler) listViewsWithCounts(ctx context.Context, userID string) ([]models.View, error) {
+	if h.prewarm != nil {
+		if views, ok := h.prewarm.TakeViewCounts(userID); ok {
+			return views, nil
+		}
+	}
+	return h.viewSvc.ListViewsWithCounts(ctx, userID)
+}
+
+// discardWarmedCounts drops warmed counts before views change, so they're never served
+// without the change
+func (h *Handler) discardWarmedCounts(userID string) {
+	if h.prewarm != nil {
+		h.prewarm.TakeViewCounts(userID)
+	}
 }
 
 func (h *Handler) handleCreateView(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +139,7 @@ func (h *Handler) handleCreateView(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	h.discardWarmedCounts(userID)
 
 	var req createViewRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -139,6 +203,7 @@ func (h *Handler) handleUpdateView(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	h.discardWarmedCounts(userID)
 
 	viewID, err := parseViewIDParam(r)
 	if err != nil {
@@ -225,6 +290,45 @@ func (h *Handler) handleUpdateView(w http.ResponseWriter, r *http.Request) {
 	helpers.WriteJSON(w, http.StatusOK, viewEnvelope{View: view})
 }
 
+func (h *Handler) handleSetWIPLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := helpers.RequireUserID(ctx, w, h.authSvc)
+	if !ok {
+		return
+	}
+	h.discardWarmedCounts(userID)
+
+	viewID, err := parseViewIDParam(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req setWIPLimitRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	view, err := h.viewSvc.SetWIPLimit(ctx, userID, viewID, req.Limit, req.AutoSnooze)
+	if err != nil {
+		if errors.Is(err, viewcore.ErrViewNotFound) {
+			helpers.WriteError(w, http.StatusNotFound, "view not found")
+			return
+		}
+		if errors.Is(err, viewcore.ErrInvalidWIPLimit) {
+			helpers.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to set WIP limit", zap.String("view_id", viewID), zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "failed to set WIP limit")
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, viewEnvelope{View: view})
+}
+
 func (h *Handler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -232,6 +336,7 @@ func (h *Handler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	h.discardWarmedCounts(userID)
 
 	viewID, err := parseViewIDParam(r)
 	if err != nil {
@@ -242,34 +347,34 @@ func (h *Handler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 	// Check if force parameter is set
 	force := r.URL.Query().Get("force") == "true"
 
-	// Check if there are any rules linked to this view
-	linkedRuleCount, err := h.viewSvc.DeleteView(ctx, userID, viewID, force)
+	dependentRules, err := h.viewSvc.DeleteView(ctx, userID, viewID, force)
 	if err != nil {
 		if errors.Is(err, viewcore.ErrViewNotFound) {
-			helpers.WriteError(w, http.StatusNotFound, err.Error())
+			helpers.WriteError(w, http.StatusNotFound, "view not found")
 			return
 		}
+		h.logger.Error("failed to delete view", zap.String("view_id", viewID), zap.Error(err))
 		helpers.WriteError(w, http.StatusInternalServerError, "failed to delete view")
 		return
 	}
 
-	// If there are linked rules and force is not set, return an error with the count
-	// The frontend will show a confirmation dialog
-	if linkedRuleCount > 0 && !force {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-
-		if err := json.NewEncoder(w).Encode(map[string]interface{}{
-			"error":           "This view has linked rules that will also be deleted",
-			"linkedRuleCount": linkedRuleCount,
-		}); err != nil {
-			// Best effort - status already written
-			_ = err
-		}
+	// Without force the view is kept; the frontend lists the rules and asks to confirm
+	if len(dependentRules) > 0 && !force {
+		helpers.WriteDependentRulesConflict(
+			w,
+			"This view has linked rules that will be disabled",
+			dependentRules,
+		)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	if len(dependentRules) > 0 {
+		h.logger.Info("disabled rules linked to deleted view",
+			zap.String("view_id", viewID),
+			zap.Strings("rule_ids", helpers.RuleIDs(dependentRules)))
+	}
+
+	helpers.WriteDeleted(w, dependentRules)
 }
 
 func (h *Handler) handleReorderViews(w http.ResponseWriter, r *http.Request) {
@@ -279,6 +384,7 @@ func (h *Handler) handleReorderViews(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	h.discardWarmedCounts(userID)
 
 	var req reorderViewsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -307,11 +413,7 @@ func (h *Handler) handleReorderViews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := make([]ViewResponse, 0, len(views))
-
-	response = append(response, views...)
-
-	helpers.WriteJSON(w, http.StatusOK, listViewsResponse{Views: response})
+	h.writeViews(ctx, w, userID, views)
 }
 
 func parseViewIDParam(r *http.Request) (string, error) {
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package webhooks provides the API for receiving GitHub webhook deliveries.
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+)
+
+// eventHeader names the event a delivery is for
+const eventHeader = "X-GitHub-Event"
+
+// Handler handles webhook HTTP routes
+type Handler struct {
+	logger   *zap.Logger
+	secret   string
+	webhooks webhook.WebhookService
+}
+
+// New creates a new webhook handler that accepts deliveries signed with secret
+func New(logger *zap.Logger, secret string, webhooks webhook.WebhookService) *Handler {
+	return &Handler{
+		logger:   logger,
+		secret:   secret,
+		webhooks: webhooks,
+	}
+}
+
+// Register registers webhook routes on the provided router. Deliveries are
+// authenticated by their signature, so these routes sit outside the session check.
+func (h *Handler) Register(r chi.Router) {
+	r.Post("/webhooks/github", h.handleGitHub)
+}
+
+// handleGitHub handles POST /api/webhooks/github
+func (h *Handler) handleGitHub(w http.ResponseWriter, r *http.Request) {
+	// The router's body limit comfortably covers issue and pull request deliveries
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			helpers.WriteError(w, http.StatusRequestEntityTooLarge, "Payload too large")
+			return
+		}
+		helpers.WriteError(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	if err := webhook.ValidateSignature(
+		h.secret,
+		r.Header.Get(webhook.SignatureHeader),
+		body,
+	); err != nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Invalid signature")
+		return
+	}
+
+	event := r.Header.Get(eventHeader)
+	if event == "" {
+		helpers.WriteError(w, http.StatusBadRequest, "Missing "+eventHeader+" header")
+		return
+	}
+
+	result, err := h.webhooks.HandleDelivery(r.Context(), event, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrDisabled):
+			helpers.WriteError(w, http.StatusForbidden, "Webhook sync is disabled")
+		case errors.Is(err, webhook.ErrInvalidPayload):
+			helpers.WriteError(w, http.StatusBadRequest, "Invalid payload")
+		default:
+			h.logger.Error(
+				"failed to handle webhook delivery",
+				zap.String("event", event),
+				zap.Error(err),
+			)
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to handle delivery")
+		}
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusOK, result)
+}
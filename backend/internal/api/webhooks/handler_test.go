@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/webhook"
+	webhookmocks "github.com/octobud-hq/octobud/backend/internal/core/webhook/mocks"
+)
+
+const testSecret = "s3cret"
+
+func TestHandler_handleGitHub(t *testing.T) {
+	payload := []byte(`{"action":"closed","issue":{"url":"https://api.github.com/repos/cli/cli/issues/7"}}`)
+
+	tests := []struct {
+		name           string
+		event          string
+		signature      string
+		setupMocks     func(*webhookmocks.MockWebhookService)
+		expectedStatus int
+	}{
+		{
+			name:      "applies signed delivery",
+			event:     webhook.EventIssues,
+			signature: webhook.Sign(testSecret, payload),
+			setupMocks: func(svc *webhookmocks.MockWebhookService) {
+				svc.EXPECT().
+					HandleDelivery(gomock.Any(), webhook.EventIssues, payload).
+					Return(webhook.Result{Event: webhook.EventIssues, Updated: []string{"n1"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "bad signature returns 401",
+			event:          webhook.EventIssues,
+			signature:      webhook.Sign("wrong", payload),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing event returns 400",
+			signature:      webhook.Sign(testSecret, payload),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "disabled returns 403",
+			event:     webhook.EventIssues,
+			signature: webhook.Sign(testSecret, payload),
+			setupMocks: func(svc *webhookmocks.MockWebhookService) {
+				svc.EXPECT().
+					HandleDelivery(gomock.Any(), webhook.EventIssues, payload).
+					Return(webhook.Result{}, webhook.ErrDisabled)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "invalid payload returns 400",
+			event:     webhook.EventIssues,
+			signature: webhook.Sign(testSecret, payload),
+			setupMocks: func(svc *webhookmocks.MockWebhookService) {
+				svc.EXPECT().
+					HandleDelivery(gomock.Any(), webhook.EventIssues, payload).
+					Return(webhook.Result{}, webhook.ErrInvalidPayload)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "service error returns 500",
+			event:     webhook.EventIssues,
+			signature: webhook.Sign(testSecret, payload),
+			setupMocks: func(svc *webhookmocks.MockWebhookService) {
+				svc.EXPECT().
+					HandleDelivery(gomock.Any(), webhook.EventIssues, payload).
+					Return(webhook.Result{}, errors.Join(webhook.ErrFailedToList, errors.New("db")))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSvc := webhookmocks.NewMockWebhookService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockSvc)
+			}
+
+			router := chi.NewRouter()
+			New(zap.NewNop(), testSecret, mockSvc).Register(router)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(payload))
+			if tt.event != "" {
+				req.Header.Set("X-GitHub-Event", tt.event)
+			}
+			req.Header.Set(webhook.SignatureHeader, tt.signature)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
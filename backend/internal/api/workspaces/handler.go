@@ -0,0 +1,131 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package workspaces provides the handler for workspace routes.
+package workspaces
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+// Handler handles workspace HTTP routes
+type Handler struct {
+	logger  *zap.Logger
+	manager *workspace.Manager
+}
+
+// New creates a new workspaces handler
+func New(logger *zap.Logger, manager *workspace.Manager) *Handler {
+	return &Handler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+// Register registers workspace routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Route("/workspaces", func(r chi.Router) {
+		r.Get("/", h.handleList)
+		r.Post("/", h.handleCreate)
+		r.Post("/{slug}/activate", h.handleActivate)
+		r.Delete("/{slug}", h.handleDelete)
+	})
+}
+
+type listResponse struct {
+	Workspaces []models.Workspace `json:"workspaces"`
+}
+
+type createRequest struct {
+	Name string `json:"name"`
+}
+
+type workspaceResponse struct {
+	Workspace models.Workspace `json:"workspace"`
+}
+
+func (h *Handler) handleList(w http.ResponseWriter, _ *http.Request) {
+	helpers.WriteJSON(w, http.StatusOK, listResponse{Workspaces: h.manager.List()})
+}
+
+func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ws, err := h.manager.Create(req.Name)
+	if err != nil {
+		h.writeError(w, "failed to create workspace", err)
+		return
+	}
+
+	helpers.WriteJSON(w, http.StatusCreated, workspaceResponse{Workspace: ws})
+}
+
+// handleActivate switches the server to a workspace. It returns once the workspace is
+// serving requests, so the client can reload straight away.
+func (h *Handler) handleActivate(w http.ResponseWriter, r *http.Request) {
+	ws, err := h.manager.Activate(chi.URLParam(r, "slug"))
+	if err != nil {
+		h.writeError(w, "failed to switch workspace", err)
+		return
+	}
+
+	h.logger.Info("switched workspace", zap.String("workspace", ws.Slug))
+	helpers.WriteJSON(w, http.StatusOK, workspaceResponse{Workspace: ws})
+}
+
+func (h *Handler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.manager.Delete(chi.URLParam(r, "slug")); err != nil {
+		h.writeError(w, "failed to delete workspace", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps workspace errors to status codes, logging unexpected ones
+func (h *Handler) writeError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, workspace.ErrNotFound):
+		helpers.WriteError(w, http.StatusNotFound, "Workspace not found")
+	case errors.Is(err, workspace.ErrInvalidName):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, workspace.ErrAlreadyExists):
+		helpers.WriteError(w, http.StatusConflict, "A workspace with that name already exists")
+	case errors.Is(err, workspace.ErrDeleteActive):
+		helpers.WriteError(
+			w, http.StatusConflict, "Switch to another workspace before deleting this one",
+		)
+	case errors.Is(err, workspace.ErrDeleteDefault):
+		helpers.WriteError(w, http.StatusConflict, "The default workspace can't be deleted")
+	case errors.Is(err, workspace.ErrNoSwitcher):
+		helpers.WriteError(w, http.StatusNotImplemented, "Workspace switching is not available")
+	default:
+		h.logger.Error(msg, zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to update workspaces")
+	}
+}
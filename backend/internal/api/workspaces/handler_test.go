@@ -0,0 +1,129 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workspaces
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+func newRouter(t *testing.T) (chi.Router, *workspace.Manager) {
+	t.Helper()
+	manager, err := workspace.NewManager(t.TempDir())
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	New(zap.NewNop(), manager).Register(router)
+	return router, manager
+}
+
+func serve(router chi.Router, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestHandler_CreateAndList(t *testing.T) {
+	router, _ := newRouter(t)
+
+	w := serve(router, http.MethodPost, "/workspaces", `{"name": "Open Source"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.JSONEq(t,
+		`{"workspace": {"slug": "open-source", "name": "Open Source", "active": false}}`,
+		w.Body.String())
+
+	w = serve(router, http.MethodGet, "/workspaces", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp listResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []models.Workspace{
+		{Slug: workspace.DefaultSlug, Name: "Default", Active: true},
+		{Slug: "open-source", Name: "Open Source"},
+	}, resp.Workspaces)
+}
+
+func TestHandler_CreateErrors(t *testing.T) {
+	router, _ := newRouter(t)
+	require.Equal(t, http.StatusCreated,
+		serve(router, http.MethodPost, "/workspaces", `{"name": "Work"}`).Code)
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"invalid body", `{`, http.StatusBadRequest},
+		{"empty name", `{"name": " "}`, http.StatusBadRequest},
+		{"duplicate", `{"name": "work"}`, http.StatusConflict},
+		{"reserved", `{"name": "Default"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.code, serve(router, http.MethodPost, "/workspaces", tt.body).Code)
+		})
+	}
+}
+
+func TestHandler_Activate(t *testing.T) {
+	router, manager := newRouter(t)
+	_, err := manager.Create("Work")
+	require.NoError(t, err)
+
+	// Without a switcher the server can't change workspaces
+	w := serve(router, http.MethodPost, "/workspaces/work/activate", "")
+	require.Equal(t, http.StatusNotImplemented, w.Code)
+
+	manager.SetSwitcher(func(models.Workspace, string) error { return nil })
+	w = serve(router, http.MethodPost, "/workspaces/work/activate", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t,
+		`{"workspace": {"slug": "work", "name": "Work", "active": true}}`,
+		w.Body.String())
+	require.Equal(t, "work", manager.Active().Slug)
+
+	w = serve(router, http.MethodPost, "/workspaces/missing/activate", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	manager.SetSwitcher(func(models.Workspace, string) error { return errors.New("boom") })
+	w = serve(router, http.MethodPost, "/workspaces/default/activate", "")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "work", manager.Active().Slug)
+}
+
+func TestHandler_Delete(t *testing.T) {
+	router, manager := newRouter(t)
+	_, err := manager.Create("Work")
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusConflict,
+		serve(router, http.MethodDelete, "/workspaces/default", "").Code)
+	require.Equal(t, http.StatusNotFound,
+		serve(router, http.MethodDelete, "/workspaces/missing", "").Code)
+	require.Equal(t, http.StatusNoContent,
+		serve(router, http.MethodDelete, "/workspaces/work", "").Code)
+	require.Len(t, manager.List(), 1)
+}
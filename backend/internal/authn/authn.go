@@ -0,0 +1,92 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package authn provides pluggable request authentication for the HTTP server.
+// The desktop app uses NoAuth since it only listens on localhost; server deployments
+// can put the API behind an OpenID Connect identity provider instead, or sign in
+// separate users with passwords or through an authenticating reverse proxy.
+package authn
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+)
+
+// Authenticator authenticates requests to the API and frontend
+type Authenticator interface {
+	// Register mounts the authenticator's own routes (login, callback, logout).
+	// They are registered outside Middleware.
+	Register(r chi.Router)
+
+	// Middleware rejects requests without a valid session. API requests get a 401;
+	// browser page loads are redirected to the login flow.
+	Middleware(next http.Handler) http.Handler
+}
+
+// Session is the identity of an authenticated user
+type Session struct {
+	Subject   string   `json:"sub"`
+	Name      string   `json:"name,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+	Account   string   `json:"account"`         // GitHub login the user may access, or "*"
+	Admin     bool     `json:"admin,omitempty"` // May manage users on a multi-user server
+	ExpiresAt int64    `json:"exp"`
+
+	// Set when the request was signed in with an API token rather than a session cookie
+	TokenID  string `json:"tokenId,omitempty"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+type sessionContextKey struct{}
+
+// ContextWithSession returns a new context carrying the session
+func ContextWithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the authenticated session, or nil when authentication
+// is disabled
+func SessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionContextKey{}).(*Session)
+	return session
+}
+
+// NoAuth allows every request. It is used when no auth provider is configured.
+type NoAuth struct{}
+
+// Register does nothing since there is no login flow
+func (NoAuth) Register(chi.Router) {}
+
+// Middleware passes requests through unchanged
+func (NoAuth) Middleware(next http.Handler) http.Handler {
+	return next
+}
+
+// RequireAdmin rejects requests whose session isn't an admin's
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := SessionFromContext(r.Context())
+		if session == nil || !session.Admin {
+			helpers.WriteError(w, http.StatusForbidden, "Only admins can manage users")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
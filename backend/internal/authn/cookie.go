@@ -0,0 +1,65 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errInvalidCookie = errors.New("invalid signed cookie")
+
+// cookieSigner encodes values as "<payload>.<signature>" with an HMAC-SHA256 signature
+type cookieSigner struct {
+	key []byte
+}
+
+func (s cookieSigner) mac(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s cookieSigner) encode(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + s.mac(payload), nil
+}
+
+func (s cookieSigner) decode(value string, v any) error {
+	payload, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return errInvalidCookie
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.mac(payload))) {
+		return errInvalidCookie
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return errInvalidCookie
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errInvalidCookie
+	}
+	return nil
+}
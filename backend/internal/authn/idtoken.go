@@ -0,0 +1,304 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Error definitions
+var (
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	ErrUnknownKey     = errors.New("ID token signed with unknown key")
+)
+
+// jwksRefreshInterval limits how often unknown key IDs trigger a JWKS refetch
+const jwksRefreshInterval = time.Minute
+
+// providerMetadata is the subset of the OpenID discovery document we use
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// idTokenClaims holds the standard claims we check plus the raw claim set,
+// which is needed to read the configurable groups claim
+type idTokenClaims struct {
+	Issuer            string          `json:"iss"`
+	Subject           string          `json:"sub"`
+	Audience          audience        `json:"aud"`
+	ExpiresAt         int64           `json:"exp"`
+	Nonce             string          `json:"nonce"`
+	Name              string          `json:"name"`
+	PreferredUsername string          `json:"preferred_username"`
+	Email             string          `json:"email"`
+	raw               json.RawMessage `json:"-"`
+}
+
+// audience accepts both a single string and an array, as allowed by the spec
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// stringsClaim reads a claim that is either a string or an array of strings
+func (c *idTokenClaims) stringsClaim(name string) []string {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(c.raw, &all); err != nil {
+		return nil
+	}
+	value, ok := all[name]
+	if !ok {
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(value, &many); err == nil {
+		return many
+	}
+	var single string
+	if err := json.Unmarshal(value, &single); err == nil && single != "" {
+		return []string{single}
+	}
+	return nil
+}
+
+// jsonWebKey is a single key from a JWKS document
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// keySet caches the provider's signing keys, refetching when an unknown key ID appears
+type keySet struct {
+	client      *http.Client
+	uri         string
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+}
+
+func (ks *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(ks.lastFetched) < jwksRefreshInterval && ks.keys != nil {
+		return nil, ErrUnknownKey
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, ks.client, ks.uri, &doc); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	ks.keys = keys
+	ks.lastFetched = time.Now()
+
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// verifyIDToken checks the signature, issuer, audience and expiry of a compact JWT
+func verifyIDToken(
+	ctx context.Context,
+	keys *keySet,
+	rawToken, issuer, clientID string,
+	now time.Time,
+) (*idTokenClaims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	key, err := keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	switch header.Alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) != nil {
+			return nil, ErrInvalidIDToken
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return nil, ErrInvalidIDToken
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return nil, ErrInvalidIDToken
+		}
+	default:
+		// Rejects "none" and HMAC algorithms, which would let a client forge tokens
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidIDToken, header.Alg)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	claims := &idTokenClaims{raw: payload}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	switch {
+	case claims.Issuer != issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidIDToken)
+	case !claims.Audience.contains(clientID):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(time.Minute)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidIDToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,442 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+// Failed logins for a username are limited to maxLoginFailures per loginFailureWindow
+const (
+	maxLoginFailures   = 5
+	loginFailureWindow = 15 * time.Minute
+)
+
+// userSession is stored in the session cookie of a multi-user server. Version must
+// match the user's current session version, so a new password signs them out elsewhere.
+type userSession struct {
+	Username  string `json:"u"`
+	Version   int    `json:"v"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Local authenticates the users of a multi-user server with the passwords kept by
+// users.Manager. A successful login issues a signed session cookie.
+type Local struct {
+	logger  *zap.Logger
+	users   *users.Manager
+	signer  cookieSigner
+	ttl     time.Duration
+	limiter *loginLimiter
+	now     func() time.Time
+}
+
+// NewLocal returns an authenticator for the users in manager
+func NewLocal(
+	logger *zap.Logger,
+	cfg config.LocalAuthConfig,
+	manager *users.Manager,
+) (*Local, error) {
+	signer, err := newCookieSigner(logger, cfg.SessionSecret, "auth.local.sessionSecret")
+	if err != nil {
+		return nil, err
+	}
+	return &Local{
+		logger:  logger,
+		users:   manager,
+		signer:  signer,
+		ttl:     time.Duration(cfg.SessionTTL),
+		limiter: &loginLimiter{failures: make(map[string]loginFailures)},
+		now:     time.Now,
+	}, nil
+}
+
+// Register mounts /auth/login, /auth/logout, /auth/session and /auth/password
+func (l *Local) Register(r chi.Router) {
+	r.Route("/auth", func(r chi.Router) {
+		r.Get("/login", l.HandleLoginPage)
+		r.Post("/login", l.HandleLogin)
+		r.Post("/logout", l.HandleLogout)
+		r.Get("/session", l.HandleGetSession)
+		r.Put("/password", l.HandleChangePassword)
+	})
+}
+
+// Middleware requires a valid session for a user who still exists
+func (l *Local) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := l.sessionFromRequest(r)
+		if err != nil {
+			if isPageRequest(r) {
+				loginURL := "/api/auth/login?returnTo=" + url.QueryEscape(r.URL.RequestURI())
+				http.Redirect(w, r, loginURL, http.StatusFound)
+				return
+			}
+			helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithSession(r.Context(), session)))
+	})
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// HandleLoginPage handles GET /api/auth/login
+// Shows the sign-in form. The optional returnTo query parameter is the local path to
+// return to after login.
+func (l *Local) HandleLoginPage(w http.ResponseWriter, r *http.Request) {
+	l.renderLoginPage(w, http.StatusOK, safeReturnTo(r.URL.Query().Get("returnTo")), "", "")
+}
+
+// HandleLogin handles POST /api/auth/login
+// Accepts the sign-in form, which is redirected to returnTo, or a JSON body, which gets
+// the new session back.
+func (l *Local) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	isForm := !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+
+	var req loginRequest
+	returnTo := "/"
+	if isForm {
+		if err := r.ParseForm(); err != nil {
+			helpers.WriteError(w, http.StatusBadRequest, "invalid form")
+			return
+		}
+		req = loginRequest{
+			Username: r.PostForm.Get("username"),
+			Password: r.PostForm.Get("password"),
+		}
+		returnTo = safeReturnTo(r.PostForm.Get("returnTo"))
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	username := strings.ToLower(strings.TrimSpace(req.Username))
+	if !l.limiter.allow(username, l.now()) {
+		l.loginFailed(w, isForm, returnTo, username, http.StatusTooManyRequests,
+			"Too many failed sign-ins, try again later")
+		return
+	}
+	user, version, err := l.users.Authenticate(username, req.Password)
+	if err != nil {
+		l.limiter.fail(username, l.now())
+		l.logger.Info("login failed", zap.String("username", username))
+		l.loginFailed(w, isForm, returnTo, username, http.StatusUnauthorized,
+			"Incorrect username or password")
+		return
+	}
+	l.limiter.reset(username)
+
+	session, err := l.issueSession(w, r, user, version)
+	if err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+	l.logger.Info("login succeeded", zap.String("username", user.Username))
+
+	if isForm {
+		http.Redirect(w, r, returnTo, http.StatusSeeOther)
+		return
+	}
+	helpers.WriteJSON(w, http.StatusOK, session)
+}
+
+// HandleLogout handles POST /api/auth/logout
+func (l *Local) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	clearSessionCookie(w, r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetSession handles GET /api/auth/session
+// Returns the current session so the frontend can show who is signed in.
+func (l *Local) HandleGetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := l.sessionFromRequest(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	helpers.WriteJSON(w, http.StatusOK, session)
+}
+
+// HandleChangePassword handles PUT /api/auth/password
+// Changes the signed-in user's password. Their other sessions are signed out; this one
+// gets a new cookie.
+func (l *Local) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	session, err := l.sessionFromRequest(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req changePasswordRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		helpers.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	version, err := l.users.ChangePassword(session.Subject, req.CurrentPassword, req.NewPassword)
+	switch {
+	case errors.Is(err, users.ErrInvalidCredentials):
+		helpers.WriteError(w, http.StatusForbidden, "Current password is incorrect")
+		return
+	case errors.Is(err, users.ErrInvalidPassword):
+		helpers.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		l.logger.Error("failed to change password", zap.Error(err))
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+
+	user, err := l.users.Get(session.Subject)
+	if err != nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	if _, err := l.issueSession(w, r, user, version); err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// issueSession sets a session cookie for user and returns the session it stands for
+func (l *Local) issueSession(
+	w http.ResponseWriter,
+	r *http.Request,
+	user models.ServerUser,
+	version int,
+) (*Session, error) {
+	expiresAt := l.now().Add(l.ttl).Unix()
+	value, err := l.signer.encode(userSession{
+		Username:  user.Username,
+		Version:   version,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	setSessionCookie(w, r, value, l.ttl)
+	return userSessionFor(user, expiresAt), nil
+}
+
+func (l *Local) sessionFromRequest(r *http.Request) (*Session, error) {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return nil, err
+	}
+	var stored userSession
+	if err := l.signer.decode(cookie.Value, &stored); err != nil {
+		return nil, err
+	}
+	if l.now().Unix() > stored.ExpiresAt {
+		return nil, errors.New("session expired")
+	}
+	user, err := l.users.CheckSession(stored.Username, stored.Version)
+	if err != nil {
+		return nil, err
+	}
+	return userSessionFor(user, stored.ExpiresAt), nil
+}
+
+func (l *Local) loginFailed(
+	w http.ResponseWriter,
+	isForm bool,
+	returnTo, username string,
+	status int,
+	message string,
+) {
+	if isForm {
+		l.renderLoginPage(w, status, returnTo, username, message)
+		return
+	}
+	helpers.WriteError(w, status, message)
+}
+
+func (l *Local) renderLoginPage(
+	w http.ResponseWriter,
+	status int,
+	returnTo, username, message string,
+) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := loginPage.Execute(w, map[string]string{
+		"ReturnTo": returnTo,
+		"Username": username,
+		"Error":    message,
+	}); err != nil {
+		l.logger.Warn("failed to render login page", zap.Error(err))
+	}
+}
+
+// userSessionFor describes a multi-user server's user as a session. Each user has their
+// own workspace, so any GitHub account they connect is theirs.
+func userSessionFor(user models.ServerUser, expiresAt int64) *Session {
+	name := user.Name
+	if name == "" {
+		name = user.Username
+	}
+	return &Session{
+		Subject:   user.Username,
+		Name:      name,
+		Account:   AnyAccount,
+		Admin:     user.IsAdmin(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// newCookieSigner signs cookies with secret, or with a random key if it's empty
+func newCookieSigner(logger *zap.Logger, secret, setting string) (cookieSigner, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return cookieSigner{}, fmt.Errorf("failed to generate session secret: %w", err)
+		}
+		logger.Warn(setting + " not set, sessions will end when the server restarts")
+	}
+	return cookieSigner{key: key}, nil
+}
+
+func setSessionCookie(w http.ResponseWriter, r *http.Request, value string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   helpers.IsHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   helpers.IsHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// loginFailures counts failed logins for a username since windowStart
+type loginFailures struct {
+	count       int
+	windowStart time.Time
+}
+
+// loginLimiter slows password guessing by refusing logins for a username after too many
+// recent failures
+type loginLimiter struct {
+	mu       sync.Mutex
+	failures map[string]loginFailures
+}
+
+func (l *loginLimiter) allow(username string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.failures[username]
+	if !ok || now.Sub(f.windowStart) > loginFailureWindow {
+		return true
+	}
+	return f.count < maxLoginFailures
+}
+
+func (l *loginLimiter) fail(username string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.failures[username]
+	if !ok || now.Sub(f.windowStart) > loginFailureWindow {
+		f = loginFailures{windowStart: now}
+	}
+	f.count++
+	l.failures[username] = f
+
+	// Forget stale entries so guessing many usernames doesn't grow the map for good
+	for name, other := range l.failures {
+		if now.Sub(other.windowStart) > loginFailureWindow {
+			delete(l.failures, name)
+		}
+	}
+}
+
+func (l *loginLimiter) reset(username string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, username)
+}
+
+// loginPage is the sign-in form for the local provider
+var loginPage = template.Must(template.New("login").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in · Octobud</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f6f7f9; color: #1f2328; margin: 0;
+  display: flex; min-height: 100vh; align-items: center; justify-content: center; }
+form { background: #fff; border: 1px solid #d0d7de; border-radius: 8px; padding: 24px;
+  width: 300px; display: flex; flex-direction: column; gap: 12px; }
+h1 { font-size: 20px; margin: 0 0 4px; }
+label { font-size: 14px; display: flex; flex-direction: column; gap: 4px; }
+input { font: inherit; padding: 6px 8px; border: 1px solid #d0d7de; border-radius: 6px; }
+button { font: inherit; padding: 8px; border: 0; border-radius: 6px; background: #1f883d;
+  color: #fff; cursor: pointer; }
+.error { color: #cf222e; font-size: 14px; margin: 0; }
+</style>
+</head>
+<body>
+<form method="post" action="/api/auth/login">
+<h1>Sign in to Octobud</h1>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<input type="hidden" name="returnTo" value="{{.ReturnTo}}">
+<label>Username <input name="username" value="{{.Username}}" autocomplete="username" required autofocus></label>
+<label>Password <input name="password" type="password" autocomplete="current-password" required></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
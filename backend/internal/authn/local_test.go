@@ -0,0 +1,255 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+func newTestUsers(t *testing.T) *users.Manager {
+	t.Helper()
+	manager, err := users.NewManager(t.TempDir(), time.Now)
+	require.NoError(t, err)
+	return manager
+}
+
+// newLocalRouter mounts a Local authenticator in front of an endpoint that echoes the
+// session
+func newLocalRouter(t *testing.T, manager *users.Manager) (*Local, chi.Router) {
+	t.Helper()
+	local, err := NewLocal(zap.NewNop(), config.LocalAuthConfig{
+		SessionSecret: "test-secret",
+		SessionTTL:    config.Duration(time.Hour),
+	}, manager)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Route("/api", func(r chi.Router) {
+		local.Register(r)
+		r.With(local.Middleware).Get("/whoami", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(SessionFromContext(r.Context()))
+		})
+	})
+	return local, router
+}
+
+func jsonRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestLocal_LoginAndSession(t *testing.T) {
+	manager := newTestUsers(t)
+	_, err := manager.Create(users.CreateParams{
+		Username: "alice",
+		Name:     "Alice",
+		Role:     models.ServerUserRoleAdmin,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	_, router := newLocalRouter(t, manager)
+
+	// Without a session, API calls are rejected and page loads go to the login form
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/whoami", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, jsonRequest(http.MethodPost, "/api/auth/login",
+		`{"username": "alice", "password": "wrong horse"}`))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, jsonRequest(http.MethodPost, "/api/auth/login",
+		`{"username": "Alice", "password": "correct horse"}`))
+	require.Equal(t, http.StatusOK, rec.Code)
+	cookie := sessionCookie(t, rec)
+	require.True(t, cookie.HttpOnly)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/whoami", nil)
+	req.AddCookie(cookie)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var session Session
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+	require.Equal(t, "alice", session.Subject)
+	require.Equal(t, "Alice", session.Name)
+	require.Equal(t, AnyAccount, session.Account)
+	require.True(t, session.Admin)
+
+	// A tampered cookie is rejected
+	req = httptest.NewRequest(http.MethodGet, "/api/whoami", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: cookie.Value + "x"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestLocal_LoginForm(t *testing.T) {
+	manager := newTestUsers(t)
+	_, err := manager.Create(users.CreateParams{
+		Username: "alice",
+		Role:     models.ServerUserRoleMember,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	local, router := newLocalRouter(t, manager)
+
+	page := httptest.NewRequest(http.MethodGet, "/inbox", nil)
+	page.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	local.Middleware(http.NotFoundHandler()).ServeHTTP(rec, page)
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "/api/auth/login?returnTo=%2Finbox", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(
+		rec, httptest.NewRequest(http.MethodGet, "/api/auth/login?returnTo=/inbox", nil),
+	)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `name="returnTo" value="/inbox"`)
+
+	form := url.Values{"username": {"alice"}, "password": {"correct horse"}, "returnTo": {"/inbox"}}
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	require.Equal(t, "/inbox", rec.Header().Get("Location"))
+	sessionCookie(t, rec)
+
+	// Failed form logins show the form again, keeping the username
+	form.Set("password", "nope")
+	form.Set("returnTo", "https://evil.example.com")
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Contains(t, rec.Body.String(), "Incorrect username or password")
+	require.Contains(t, rec.Body.String(), `value="alice"`)
+	require.Contains(t, rec.Body.String(), `name="returnTo" value="/"`)
+}
+
+func TestLocal_LoginRateLimit(t *testing.T) {
+	manager := newTestUsers(t)
+	_, err := manager.Create(users.CreateParams{
+		Username: "alice",
+		Role:     models.ServerUserRoleMember,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	local, router := newLocalRouter(t, manager)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	local.now = func() time.Time { return now }
+
+	login := func(password string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, jsonRequest(http.MethodPost, "/api/auth/login",
+			`{"username": "alice", "password": "`+password+`"}`))
+		return rec.Code
+	}
+	for range maxLoginFailures {
+		require.Equal(t, http.StatusUnauthorized, login("guess"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, login("correct horse"))
+
+	now = now.Add(loginFailureWindow + time.Second)
+	require.Equal(t, http.StatusOK, login("correct horse"))
+}
+
+func TestLocal_ChangePassword(t *testing.T) {
+	manager := newTestUsers(t)
+	_, err := manager.Create(users.CreateParams{
+		Username: "alice",
+		Role:     models.ServerUserRoleMember,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	_, router := newLocalRouter(t, manager)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, jsonRequest(http.MethodPost, "/api/auth/login",
+		`{"username": "alice", "password": "correct horse"}`))
+	require.Equal(t, http.StatusOK, rec.Code)
+	oldCookie := sessionCookie(t, rec)
+
+	req := jsonRequest(http.MethodPut, "/api/auth/password",
+		`{"currentPassword": "wrong", "newPassword": "battery staple"}`)
+	req.AddCookie(oldCookie)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = jsonRequest(http.MethodPut, "/api/auth/password",
+		`{"currentPassword": "correct horse", "newPassword": "battery staple"}`)
+	req.AddCookie(oldCookie)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	newCookie := sessionCookie(t, rec)
+
+	// The old session is signed out, the one that changed the password isn't
+	for cookie, want := range map[*http.Cookie]int{
+		oldCookie: http.StatusUnauthorized,
+		newCookie: http.StatusOK,
+	} {
+		req = httptest.NewRequest(http.MethodGet, "/api/whoami", nil)
+		req.AddCookie(cookie)
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, want, rec.Code)
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	handler := RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, tt := range []struct {
+		session *Session
+		want    int
+	}{
+		{nil, http.StatusForbidden},
+		{&Session{Subject: "bob"}, http.StatusForbidden},
+		{&Session{Subject: "alice", Admin: true}, http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		if tt.session != nil {
+			req = req.WithContext(ContextWithSession(req.Context(), tt.session))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, tt.want, rec.Code)
+	}
+}
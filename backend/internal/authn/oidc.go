@@ -0,0 +1,442 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+)
+
+// Cookie names
+const (
+	SessionCookieName = "octobud_session"
+	loginCookieName   = "octobud_oidc_login"
+)
+
+// AnyAccount in a group mapping grants access regardless of the connected GitHub account
+const AnyAccount = "*"
+
+// loginStateTTL is how long a user has to complete the identity provider login
+const loginStateTTL = 10 * time.Minute
+
+// ErrNoMappedAccount is returned when none of the user's groups map to an account
+var ErrNoMappedAccount = errors.New("no account is mapped to the user's groups")
+
+// loginState is stored in a short-lived cookie between login and callback
+type loginState struct {
+	State     string `json:"state"`
+	Nonce     string `json:"nonce"`
+	Verifier  string `json:"verifier"`
+	ReturnTo  string `json:"returnTo"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// OIDC authenticates users with an OpenID Connect identity provider using the
+// authorization code flow with PKCE. After login, the user's groups are mapped to
+// the GitHub account they may access and a signed session cookie is issued.
+type OIDC struct {
+	logger   *zap.Logger
+	cfg      config.OIDCConfig
+	authSvc  authsvc.AuthService
+	client   *http.Client
+	metadata providerMetadata
+	keys     *keySet
+	signer   cookieSigner
+	secure   bool
+	now      func() time.Time
+}
+
+// NewOIDC discovers the identity provider's endpoints and returns an authenticator.
+// authSvc is used to check session accounts against the connected GitHub account.
+func NewOIDC(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.OIDCConfig,
+	authSvc authsvc.AuthService,
+) (*OIDC, error) {
+	return newOIDC(ctx, logger, cfg, authSvc, &http.Client{Timeout: 10 * time.Second})
+}
+
+func newOIDC(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.OIDCConfig,
+	authSvc authsvc.AuthService,
+	client *http.Client,
+) (*OIDC, error) {
+	discoveryURL := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	var metadata providerMetadata
+	if err := getJSON(ctx, client, discoveryURL, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+	if metadata.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf(
+			"OIDC issuer mismatch: configured %q, provider reports %q",
+			cfg.Issuer,
+			metadata.Issuer,
+		)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" ||
+		metadata.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing required endpoints")
+	}
+
+	signer, err := newCookieSigner(logger, cfg.SessionSecret, "auth.oidc.sessionSecret")
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDC{
+		logger:   logger,
+		cfg:      cfg,
+		authSvc:  authSvc,
+		client:   client,
+		metadata: metadata,
+		keys:     &keySet{client: client, uri: metadata.JWKSURI},
+		signer:   signer,
+		secure:   strings.HasPrefix(cfg.RedirectURL, "https://"),
+		now:      time.Now,
+	}, nil
+}
+
+// Register mounts /auth/login, /auth/callback, /auth/logout and /auth/session
+func (o *OIDC) Register(r chi.Router) {
+	r.Route("/auth", func(r chi.Router) {
+		r.Get("/login", o.HandleLogin)
+		r.Get("/callback", o.HandleCallback)
+		r.Post("/logout", o.HandleLogout)
+		r.Get("/session", o.HandleGetSession)
+	})
+}
+
+// Middleware requires a valid session whose account matches the connected GitHub account
+func (o *OIDC) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := o.sessionFromRequest(r)
+		if err != nil {
+			if isPageRequest(r) {
+				loginURL := "/api/auth/login?returnTo=" + url.QueryEscape(r.URL.RequestURI())
+				http.Redirect(w, r, loginURL, http.StatusFound)
+				return
+			}
+			helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		if !o.accountAllowed(r.Context(), session.Account) {
+			helpers.WriteError(
+				w,
+				http.StatusForbidden,
+				"Not authorized for the connected GitHub account",
+			)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithSession(r.Context(), session)))
+	})
+}
+
+// HandleLogin handles GET /api/auth/login
+// Redirects to the identity provider. The optional returnTo query parameter is the
+// local path to return to after login.
+func (o *OIDC) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	state := loginState{
+		State:     randomToken(),
+		Nonce:     randomToken(),
+		Verifier:  randomToken(),
+		ReturnTo:  safeReturnTo(r.URL.Query().Get("returnTo")),
+		ExpiresAt: o.now().Add(loginStateTTL).Unix(),
+	}
+	value, err := o.signer.encode(state)
+	if err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+	o.setCookie(w, loginCookieName, value, loginStateTTL)
+
+	challenge := sha256.Sum256([]byte(state.Verifier))
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.cfg.ClientID},
+		"redirect_uri":          {o.cfg.RedirectURL},
+		"scope":                 {strings.Join(o.cfg.Scopes, " ")},
+		"state":                 {state.State},
+		"nonce":                 {state.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	http.Redirect(w, r, o.metadata.AuthorizationEndpoint+"?"+params.Encode(), http.StatusFound)
+}
+
+// HandleCallback handles GET /api/auth/callback
+// Exchanges the authorization code, verifies the ID token, maps the user's groups to
+// an account and issues a session cookie.
+func (o *OIDC) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	if errCode := query.Get("error"); errCode != "" {
+		o.logger.Warn("OIDC login failed at provider",
+			zap.String("error", errCode),
+			zap.String("description", query.Get("error_description")))
+		helpers.WriteError(w, http.StatusUnauthorized, "Login failed at identity provider")
+		return
+	}
+
+	var state loginState
+	cookie, err := r.Cookie(loginCookieName)
+	if err != nil || o.signer.decode(cookie.Value, &state) != nil ||
+		o.now().Unix() > state.ExpiresAt || query.Get("state") != state.State {
+		helpers.WriteError(w, http.StatusBadRequest, "Invalid or expired login state")
+		return
+	}
+	o.clearCookie(w, loginCookieName)
+
+	rawIDToken, err := o.exchangeCode(ctx, query.Get("code"), state.Verifier)
+	if err != nil {
+		o.logger.Warn("OIDC code exchange failed", zap.Error(err))
+		helpers.WriteError(w, http.StatusUnauthorized, "Failed to complete login")
+		return
+	}
+
+	claims, err := verifyIDToken(ctx, o.keys, rawIDToken, o.cfg.Issuer, o.cfg.ClientID, o.now())
+	if err != nil || claims.Nonce != state.Nonce {
+		o.logger.Warn("OIDC ID token rejected", zap.Error(err))
+		helpers.WriteError(w, http.StatusUnauthorized, "Invalid ID token")
+		return
+	}
+
+	groups := claims.stringsClaim(o.cfg.GroupsClaim)
+	account, err := MapAccount(o.cfg.GroupAccounts, groups)
+	if err != nil {
+		o.logger.Info("OIDC login denied, no mapped group",
+			zap.String("subject", claims.Subject),
+			zap.Strings("groups", groups))
+		helpers.WriteError(w, http.StatusForbidden, "Your groups do not grant access to Octobud")
+		return
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+	ttl := time.Duration(o.cfg.SessionTTL)
+	session := Session{
+		Subject:   claims.Subject,
+		Name:      name,
+		Email:     claims.Email,
+		Groups:    groups,
+		Account:   account,
+		ExpiresAt: o.now().Add(ttl).Unix(),
+	}
+	value, err := o.signer.encode(session)
+	if err != nil {
+		helpers.WriteError(w, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+	o.setCookie(w, SessionCookieName, value, ttl)
+
+	o.logger.Info("OIDC login succeeded",
+		zap.String("subject", session.Subject),
+		zap.String("account", session.Account))
+
+	http.Redirect(w, r, state.ReturnTo, http.StatusFound)
+}
+
+// HandleLogout handles POST /api/auth/logout
+func (o *OIDC) HandleLogout(w http.ResponseWriter, _ *http.Request) {
+	o.clearCookie(w, SessionCookieName)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetSession handles GET /api/auth/session
+// Returns the current session so the frontend can show who is signed in.
+func (o *OIDC) HandleGetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := o.sessionFromRequest(r)
+	if err != nil {
+		helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	helpers.WriteJSON(w, http.StatusOK, session)
+}
+
+// MapAccount returns the account mapped to the first matching group. Groups are
+// checked in sorted order so the result doesn't depend on map iteration.
+func MapAccount(groupAccounts map[string]string, groups []string) (string, error) {
+	member := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		member[g] = true
+	}
+
+	configured := make([]string, 0, len(groupAccounts))
+	for g := range groupAccounts {
+		configured = append(configured, g)
+	}
+	sort.Strings(configured)
+
+	for _, g := range configured {
+		if member[g] {
+			return groupAccounts[g], nil
+		}
+	}
+	return "", ErrNoMappedAccount
+}
+
+func (o *OIDC) sessionFromRequest(r *http.Request) (*Session, error) {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return nil, err
+	}
+	var session Session
+	if err := o.signer.decode(cookie.Value, &session); err != nil {
+		return nil, err
+	}
+	if o.now().Unix() > session.ExpiresAt {
+		return nil, errors.New("session expired")
+	}
+	return &session, nil
+}
+
+// accountAllowed checks the session's account against the connected GitHub login.
+// Before a GitHub account is connected any mapped user may connect one.
+func (o *OIDC) accountAllowed(ctx context.Context, account string) bool {
+	if account == AnyAccount {
+		return true
+	}
+	user, err := o.authSvc.GetUser(ctx)
+	if err != nil {
+		o.logger.Warn("failed to load user for account check", zap.Error(err))
+		return false
+	}
+	if user.GithubUsername == "" {
+		return true
+	}
+	return strings.EqualFold(user.GithubUsername, account)
+}
+
+// exchangeCode trades an authorization code for an ID token
+func (o *OIDC) exchangeCode(ctx context.Context, code, verifier string) (string, error) {
+	if code == "" {
+		return "", errors.New("missing authorization code")
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {o.cfg.RedirectURL},
+		"client_id":     {o.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		o.metadata.TokenEndpoint,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.cfg.ClientID), url.QueryEscape(o.cfg.ClientSecret))
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+	return tokens.IDToken, nil
+}
+
+func (o *OIDC) setCookie(w http.ResponseWriter, name, value string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   o.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (o *OIDC) clearCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   o.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// isPageRequest reports whether the request is a browser navigation rather than
+// an API call
+func isPageRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		!strings.HasPrefix(r.URL.Path, "/api/") &&
+		strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// safeReturnTo only allows local paths, so the login flow can't be used as an open redirect
+func safeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") ||
+		strings.Contains(returnTo, `\`) {
+		return "/"
+	}
+	return returnTo
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
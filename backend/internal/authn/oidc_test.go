@@ -0,0 +1,351 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// fakeProvider is a minimal OpenID Connect provider for tests
+type fakeProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// Set by the test before the callback
+	groups []string
+	nonce  string // overrides the nonce from the authorization request when set
+
+	// Captured from the authorization request
+	challenge    string
+	requestNonce string
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &fakeProvider{t: t, key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) {
+		_ = json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                p.server.URL,
+			AuthorizationEndpoint: p.server.URL + "/authorize",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "test-key",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "good-code", r.PostForm.Get("code"))
+
+		// PKCE: the verifier must hash to the challenge sent to /authorize
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		require.Equal(t, p.challenge, base64.RawURLEncoding.EncodeToString(sum[:]))
+
+		nonce := p.requestNonce
+		if p.nonce != "" {
+			nonce = p.nonce
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id_token": p.sign(map[string]any{
+				"iss":    p.server.URL,
+				"sub":    "user-123",
+				"aud":    "octobud",
+				"exp":    time.Now().Add(time.Hour).Unix(),
+				"nonce":  nonce,
+				"name":   "Mona Lisa",
+				"email":  "mona@example.com",
+				"groups": p.groups,
+			}),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeProvider) sign(claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test-key", "typ": "JWT"})
+	require.NoError(p.t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(p.t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	require.NoError(p.t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func setupOIDC(
+	t *testing.T,
+	ctrl *gomock.Controller,
+) (*fakeProvider, *authmocks.MockAuthService, http.Handler) {
+	t.Helper()
+	provider := newFakeProvider(t)
+	authSvc := authmocks.NewMockAuthService(ctrl)
+
+	cfg := config.OIDCConfig{
+		Issuer:      provider.server.URL,
+		ClientID:    "octobud",
+		RedirectURL: "http://localhost:8808/api/auth/callback",
+		Scopes:      []string{"openid", "groups"},
+		GroupsClaim: "groups",
+		GroupAccounts: map[string]string{
+			"octobud-admins": AnyAccount,
+			"octobud-users":  "monalisa",
+		},
+		SessionSecret: "test-secret",
+		SessionTTL:    config.Duration(time.Hour),
+	}
+	oidc, err := newOIDC(context.Background(), zap.NewNop(), cfg, authSvc, provider.server.Client())
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	router.Route("/api", func(r chi.Router) {
+		oidc.Register(r)
+		r.Group(func(r chi.Router) {
+			r.Use(oidc.Middleware)
+			r.Get("/notifications", func(w http.ResponseWriter, r *http.Request) {
+				session := SessionFromContext(r.Context())
+				require.NotNil(t, session)
+				w.WriteHeader(http.StatusOK)
+			})
+		})
+	})
+	router.With(oidc.Middleware).Get("/*", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return provider, authSvc, router
+}
+
+// login runs the login flow and returns the callback response
+func login(
+	t *testing.T,
+	provider *fakeProvider,
+	router http.Handler,
+	state string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(
+		w,
+		httptest.NewRequest(http.MethodGet, "/api/auth/login?returnTo=/views/inbox", nil),
+	)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	query := location.Query()
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	provider.challenge = query.Get("code_challenge")
+	provider.requestNonce = query.Get("nonce")
+	if state == "" {
+		state = query.Get("state")
+	}
+	location.RawQuery = ""
+	require.Equal(t, provider.server.URL+"/authorize", location.String())
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/api/auth/callback?code=good-code&state="+url.QueryEscape(state),
+		nil,
+	)
+	for _, c := range w.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	callback := httptest.NewRecorder()
+	router.ServeHTTP(callback, req)
+	return callback
+}
+
+func sessionCookie(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	t.Helper()
+	for _, c := range w.Result().Cookies() {
+		if c.Name == SessionCookieName && c.Value != "" {
+			return c
+		}
+	}
+	t.Fatal("no session cookie set")
+	return nil
+}
+
+func TestOIDC_LoginFlowIssuesSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider, authSvc, router := setupOIDC(t, ctrl)
+	provider.groups = []string{"engineering", "octobud-users"}
+
+	callback := login(t, provider, router, "")
+	require.Equal(t, http.StatusFound, callback.Code)
+	require.Equal(t, "/views/inbox", callback.Header().Get("Location"))
+	cookie := sessionCookie(t, callback)
+	require.True(t, cookie.HttpOnly)
+
+	// Session works for API requests when the account matches the connected login
+	authSvc.EXPECT().GetUser(gomock.Any()).Return(&models.User{GithubUsername: "MonaLisa"}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The session endpoint reports the mapped account
+	req = httptest.NewRequest(http.MethodGet, "/api/auth/session", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var session Session
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	require.Equal(t, "user-123", session.Subject)
+	require.Equal(t, "Mona Lisa", session.Name)
+	require.Equal(t, "monalisa", session.Account)
+}
+
+func TestOIDC_RejectsSessionForOtherAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider, authSvc, router := setupOIDC(t, ctrl)
+	provider.groups = []string{"octobud-users"}
+	cookie := sessionCookie(t, login(t, provider, router, ""))
+
+	authSvc.EXPECT().GetUser(gomock.Any()).Return(&models.User{GithubUsername: "hubot"}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	req.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestOIDC_UnmappedGroupsDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider, _, router := setupOIDC(t, ctrl)
+	provider.groups = []string{"marketing"}
+
+	callback := login(t, provider, router, "")
+	require.Equal(t, http.StatusForbidden, callback.Code)
+	for _, c := range callback.Result().Cookies() {
+		require.NotEqual(t, SessionCookieName, c.Name)
+	}
+}
+
+func TestOIDC_CallbackRejectsWrongStateAndNonce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider, _, router := setupOIDC(t, ctrl)
+	provider.groups = []string{"octobud-admins"}
+
+	callback := login(t, provider, router, "forged-state")
+	require.Equal(t, http.StatusBadRequest, callback.Code)
+
+	provider.nonce = "replayed-nonce"
+	callback = login(t, provider, router, "")
+	require.Equal(t, http.StatusUnauthorized, callback.Code)
+}
+
+func TestOIDC_MiddlewareWithoutSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, _, router := setupOIDC(t, ctrl)
+
+	// API calls get a 401
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/notifications", nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Browser navigation is sent to the login flow
+	req := httptest.NewRequest(http.MethodGet, "/views/inbox", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "/api/auth/login?returnTo=%2Fviews%2Finbox", w.Header().Get("Location"))
+
+	// A tampered cookie is rejected
+	req = httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "eyJhY2NvdW50IjoiKiJ9.forged"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMapAccount(t *testing.T) {
+	mapping := map[string]string{
+		"b-team": "hubot",
+		"a-team": "monalisa",
+	}
+
+	account, err := MapAccount(mapping, []string{"b-team", "a-team"})
+	require.NoError(t, err)
+	require.Equal(t, "monalisa", account)
+
+	_, err = MapAccount(mapping, []string{"c-team"})
+	require.ErrorIs(t, err, ErrNoMappedAccount)
+}
+
+func TestSafeReturnTo(t *testing.T) {
+	require.Equal(t, "/views/inbox?q=1", safeReturnTo("/views/inbox?q=1"))
+	require.Equal(t, "/", safeReturnTo("https://evil.example.com"))
+	require.Equal(t, "/", safeReturnTo("//evil.example.com"))
+	require.Equal(t, "/", safeReturnTo(`/\evil.example.com`))
+	require.Equal(t, "/", safeReturnTo(""))
+}
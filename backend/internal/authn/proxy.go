@@ -0,0 +1,170 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+// Proxy authenticates the users of a multi-user server by a header set by an
+// authenticating reverse proxy. The header is only believed on requests that come from
+// one of the trusted proxy addresses, so the server must not be reachable around the
+// proxy from those addresses.
+type Proxy struct {
+	logger  *zap.Logger
+	cfg     config.ProxyAuthConfig
+	users   *users.Manager
+	trusted []netip.Prefix
+	admins  map[string]bool
+}
+
+// NewProxy returns an authenticator that trusts cfg.UserHeader from cfg.TrustedProxies
+func NewProxy(
+	logger *zap.Logger,
+	cfg config.ProxyAuthConfig,
+	manager *users.Manager,
+) (*Proxy, error) {
+	trusted, err := cfg.TrustedPrefixes()
+	if err != nil {
+		return nil, err
+	}
+	admins := make(map[string]bool, len(cfg.Admins))
+	for _, admin := range cfg.Admins {
+		admins[strings.ToLower(strings.TrimSpace(admin))] = true
+	}
+	return &Proxy{
+		logger:  logger,
+		cfg:     cfg,
+		users:   manager,
+		trusted: trusted,
+		admins:  admins,
+	}, nil
+}
+
+// Register mounts /auth/session. Signing in and out is up to the proxy.
+func (p *Proxy) Register(r chi.Router) {
+	r.Route("/auth", func(r chi.Router) {
+		r.With(p.Middleware).Get("/session", p.HandleGetSession)
+	})
+}
+
+// Middleware requires a trusted proxy to name a known user. Usernames listed as admins,
+// and with AutoCreate any others, are added the first time they're seen.
+func (p *Proxy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.fromTrustedProxy(r) {
+			p.logger.Warn("request did not come through a trusted proxy",
+				zap.String("remoteAddr", r.RemoteAddr))
+			helpers.WriteError(
+				w, http.StatusForbidden, "Requests must come through the sign-in proxy",
+			)
+			return
+		}
+		username := strings.ToLower(strings.TrimSpace(r.Header.Get(p.cfg.UserHeader)))
+		if username == "" {
+			helpers.WriteError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		user, err := p.users.Get(username)
+		if errors.Is(err, users.ErrNotFound) {
+			user, err = p.addUser(username, r.Header.Get(p.cfg.NameHeader))
+		}
+		switch {
+		case errors.Is(err, users.ErrNotFound):
+			p.logger.Info("proxy user is not a member", zap.String("username", username))
+			helpers.WriteError(w, http.StatusForbidden, "Ask an admin to add you to Octobud")
+			return
+		case errors.Is(err, users.ErrInvalidUsername):
+			helpers.WriteError(w, http.StatusForbidden, err.Error())
+			return
+		case err != nil:
+			p.logger.Error("failed to add proxy user",
+				zap.String("username", username), zap.Error(err))
+			helpers.WriteError(w, http.StatusInternalServerError, "Failed to set up your account")
+			return
+		}
+
+		session := userSessionFor(user, 0)
+		next.ServeHTTP(w, r.WithContext(ContextWithSession(r.Context(), session)))
+	})
+}
+
+// HandleGetSession handles GET /api/auth/session
+// Returns the current session so the frontend can show who is signed in.
+func (p *Proxy) HandleGetSession(w http.ResponseWriter, r *http.Request) {
+	helpers.WriteJSON(w, http.StatusOK, SessionFromContext(r.Context()))
+}
+
+// addUser creates a user the proxy named for the first time, or returns ErrNotFound if
+// they aren't allowed in
+func (p *Proxy) addUser(username, name string) (models.ServerUser, error) {
+	role := models.ServerUserRoleMember
+	switch {
+	case p.admins[username]:
+		role = models.ServerUserRoleAdmin
+	case !p.cfg.AutoCreate:
+		return models.ServerUser{}, users.ErrNotFound
+	}
+
+	user, err := p.users.Create(users.CreateParams{
+		Username: username,
+		Name:     strings.TrimSpace(name),
+		Role:     role,
+	})
+	if errors.Is(err, users.ErrAlreadyExists) {
+		// Another request added them first
+		return p.users.Get(username)
+	}
+	if err != nil {
+		return models.ServerUser{}, err
+	}
+	p.logger.Info("added proxy user",
+		zap.String("username", user.Username), zap.String("role", role))
+	return user, nil
+}
+
+// fromTrustedProxy checks the address the request was received from. It must not have
+// been rewritten from forwarding headers, which a client can set.
+func (p *Proxy) fromTrustedProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/config"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/users"
+)
+
+func TestProxy_Middleware(t *testing.T) {
+	manager := newTestUsers(t)
+	_, err := manager.Create(users.CreateParams{Username: "bob", Role: models.ServerUserRoleMember})
+	require.NoError(t, err)
+
+	proxy, err := NewProxy(zap.NewNop(), config.ProxyAuthConfig{
+		UserHeader:     "Remote-User",
+		NameHeader:     "Remote-Name",
+		TrustedProxies: []string{"10.0.0.0/8"},
+		Admins:         []string{"Alice"},
+	}, manager)
+	require.NoError(t, err)
+	handler := proxy.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SessionFromContext(r.Context()))
+	}))
+
+	request := func(remoteAddr, user, name string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+		req.RemoteAddr = remoteAddr
+		if user != "" {
+			req.Header.Set("Remote-User", user)
+		}
+		if name != "" {
+			req.Header.Set("Remote-Name", name)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The header is ignored from anywhere but the proxy
+	require.Equal(t, http.StatusForbidden, request("192.168.1.5:4000", "bob", "").Code)
+	require.Equal(t, http.StatusUnauthorized, request("10.0.0.2:4000", "", "").Code)
+
+	rec := request("10.0.0.2:4000", "Bob", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var session Session
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+	require.Equal(t, "bob", session.Subject)
+	require.False(t, session.Admin)
+
+	// Admins are added on their first visit, others only with AutoCreate
+	rec = request("10.0.0.2:4000", "alice", "Alice Liddell")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+	require.True(t, session.Admin)
+	require.Equal(t, "Alice Liddell", session.Name)
+
+	require.Equal(t, http.StatusForbidden, request("10.0.0.2:4000", "mallory", "").Code)
+	proxy.cfg.AutoCreate = true
+	require.Equal(t, http.StatusOK, request("10.0.0.2:4000", "carol", "").Code)
+
+	carol, err := manager.Get("carol")
+	require.NoError(t, err)
+	require.Equal(t, models.ServerUserRoleMember, carol.Role)
+	require.False(t, carol.HasPassword)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/api/helpers"
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// TokenVerifier checks the API tokens sent in "Authorization: Bearer" headers. Tokens
+// that don't sign anyone in are apitoken.ErrInvalidToken.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, value string) (models.APIToken, error)
+}
+
+// accountChecker is implemented by authenticators that restrict sessions to a GitHub
+// account, so tokens inheriting that account are held to it too
+type accountChecker interface {
+	accountAllowed(ctx context.Context, account string) bool
+}
+
+// BearerToken returns the token in a request's "Authorization: Bearer" header
+func BearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// tokenAuthenticator accepts API tokens alongside the sessions of the authenticator it
+// wraps
+type tokenAuthenticator struct {
+	Authenticator
+	logger *zap.Logger
+	tokens TokenVerifier
+}
+
+// WithTokens returns an authenticator that also signs in requests carrying an API token
+// in an "Authorization: Bearer" header. Requests without one are left to authenticator.
+// Read-only tokens may only make GET and HEAD requests.
+func WithTokens(logger *zap.Logger, authenticator Authenticator, tokens TokenVerifier) Authenticator {
+	return &tokenAuthenticator{Authenticator: authenticator, logger: logger, tokens: tokens}
+}
+
+// Middleware signs in requests with a valid API token and hands the rest to the wrapped
+// authenticator
+func (t *tokenAuthenticator) Middleware(next http.Handler) http.Handler {
+	withSession := t.Authenticator.Middleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := BearerToken(r)
+		if !ok {
+			withSession.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		token, err := t.tokens.VerifyToken(ctx, value)
+		if err != nil {
+			if !errors.Is(err, apitoken.ErrInvalidToken) {
+				t.logger.Error("failed to verify API token", zap.Error(err))
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			helpers.WriteError(w, http.StatusUnauthorized, "Invalid or expired API token")
+			return
+		}
+		if checker, ok := t.Authenticator.(accountChecker); ok &&
+			!checker.accountAllowed(ctx, token.Account) {
+			helpers.WriteError(
+				w, http.StatusForbidden, "Not authorized for the connected GitHub account",
+			)
+			return
+		}
+		if token.ReadOnly() && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			helpers.WriteError(w, http.StatusForbidden, "This API token is read-only")
+			return
+		}
+
+		session := &Session{
+			Subject:  "token:" + token.ID,
+			Name:     token.Name,
+			Account:  token.Account,
+			TokenID:  token.ID,
+			ReadOnly: token.ReadOnly(),
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithSession(ctx, session)))
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/apitoken"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// fakeTokens knows a fixed set of tokens by value
+type fakeTokens map[string]models.APIToken
+
+func (f fakeTokens) VerifyToken(_ context.Context, value string) (models.APIToken, error) {
+	token, ok := f[value]
+	if !ok {
+		return models.APIToken{}, apitoken.ErrInvalidToken
+	}
+	return token, nil
+}
+
+func TestWithTokens_Middleware(t *testing.T) {
+	tokens := fakeTokens{
+		"obt_read":  {ID: "t1", Name: "Dashboard", Scope: models.APITokenScopeRead, Account: "*"},
+		"obt_write": {ID: "t2", Name: "CLI", Scope: models.APITokenScopeWrite, Account: "*"},
+	}
+	authenticator := WithTokens(zap.NewNop(), NoAuth{}, tokens)
+	handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SessionFromContext(r.Context()))
+	}))
+
+	request := func(method, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/notifications", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Requests without a token are left to the wrapped authenticator
+	rec := request(http.MethodPost, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, "null", rec.Body.String())
+
+	rec = request(http.MethodGet, "Bearer obt_read")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var session Session
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &session))
+	require.Equal(t, "token:t1", session.Subject)
+	require.Equal(t, "Dashboard", session.Name)
+	require.Equal(t, "t1", session.TokenID)
+	require.True(t, session.ReadOnly)
+
+	rec = request(http.MethodPost, "Bearer obt_read")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Body.String(), "read-only")
+
+	rec = request(http.MethodPost, "bearer obt_write")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = request(http.MethodGet, "Bearer obt_unknown")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+
+	// Other schemes aren't tokens
+	rec = request(http.MethodGet, "Basic b2N0bzpjYXQ=")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, "null", rec.Body.String())
+}
@@ -0,0 +1,284 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+)
+
+// FileName is the name of the optional config file in the data directory
+const FileName = "config.json"
+
+// Auth providers. The local and proxy providers run the server in multi-user mode.
+const (
+	AuthProviderNone  = "none"
+	AuthProviderOIDC  = "oidc"
+	AuthProviderLocal = "local"
+	AuthProviderProxy = "proxy"
+)
+
+// File is the optional config file for server deployments. The desktop app
+// runs without one.
+type File struct {
+	Auth     AuthConfig     `json:"auth"`
+	Webhooks WebhooksConfig `json:"webhooks"`
+	Sync     SyncConfig     `json:"sync"`
+	SMTP     SMTPConfig     `json:"smtp"`
+}
+
+// AuthConfig selects how API requests are authenticated
+type AuthConfig struct {
+	// Provider is "none" (default, localhost desktop use), "oidc", "local" or "proxy"
+	Provider string          `json:"provider"`
+	OIDC     OIDCConfig      `json:"oidc"`
+	Local    LocalAuthConfig `json:"local"`
+	Proxy    ProxyAuthConfig `json:"proxy"`
+}
+
+// MultiUser reports whether the provider identifies separate users, each of whom gets
+// a workspace of their own
+func (c AuthConfig) MultiUser() bool {
+	return c.Provider == AuthProviderLocal || c.Provider == AuthProviderProxy
+}
+
+// LocalAuthConfig configures sign-in with usernames and passwords kept by Octobud
+type LocalAuthConfig struct {
+	// SessionSecret signs session cookies. If empty, a random secret is generated
+	// on startup and sessions end when the server restarts.
+	SessionSecret string   `json:"sessionSecret"`
+	SessionTTL    Duration `json:"sessionTtl"` // defaults to 168h
+}
+
+// ProxyAuthConfig configures sign-in through an authenticating reverse proxy, such as
+// oauth2-proxy or Authelia, that passes the signed-in username in a header
+type ProxyAuthConfig struct {
+	UserHeader string `json:"userHeader"` // defaults to "Remote-User"
+	NameHeader string `json:"nameHeader"` // optional display name header, e.g. "Remote-Name"
+
+	// TrustedProxies are the IPs or CIDR ranges the proxy connects from. The user header
+	// is only believed on requests from these addresses.
+	TrustedProxies []string `json:"trustedProxies"`
+
+	// Admins are usernames that are created as admins the first time they sign in
+	Admins []string `json:"admins"`
+	// AutoCreate creates any other username the proxy passes as a member. Otherwise an
+	// admin has to add them first.
+	AutoCreate bool `json:"autoCreate"`
+}
+
+// TrustedPrefixes parses TrustedProxies, treating single IPs as one-address ranges
+func (c ProxyAuthConfig) TrustedPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, proxy := range c.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			addr, err := netip.ParseAddr(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// WebhooksConfig configures the GitHub webhook receiver. The receiver is only
+// mounted when a secret is set, and deliveries are only acted on once webhook
+// sync is turned on in the sync settings.
+type WebhooksConfig struct {
+	// Secret is the webhook secret configured on GitHub, used to verify the
+	// X-Hub-Signature-256 header of each delivery
+	Secret string `json:"secret"`
+}
+
+// MaxSyncWorkers is the most notifications that can be processed at once. More workers
+// mostly wait on the GitHub rate limit and the database.
+const MaxSyncWorkers = 32
+
+// SyncConfig tunes how synced notifications are processed
+type SyncConfig struct {
+	// Workers is how many notifications are processed at once (defaults to 4). Raising
+	// it speeds up the initial sync of a large backlog.
+	Workers int `json:"workers"`
+}
+
+// SMTPConfig is the mail server notification digests are emailed through. Digests are
+// only emailed when a host is set.
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"` // defaults to 587
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"` // e.g. "Octobud <octobud@example.com>"
+}
+
+// Configured reports whether a mail server is set
+func (c SMTPConfig) Configured() bool {
+	return c.Host != ""
+}
+
+// OIDCConfig configures login through an external OpenID Connect identity provider
+type OIDCConfig struct {
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	RedirectURL  string   `json:"redirectUrl"` // e.g. https://octobud.example.com/api/auth/callback
+	Scopes       []string `json:"scopes"`      // defaults to openid, profile, email, groups
+	GroupsClaim  string   `json:"groupsClaim"` // defaults to "groups"
+
+	// GroupAccounts maps identity provider groups to the GitHub login whose
+	// notifications members may access. Use "*" to allow any account.
+	GroupAccounts map[string]string `json:"groupAccounts"`
+
+	// SessionSecret signs session cookies. If empty, a random secret is generated
+	// on startup and sessions end when the server restarts.
+	SessionSecret string   `json:"sessionSecret"`
+	SessionTTL    Duration `json:"sessionTtl"` // defaults to 12h
+}
+
+// Duration is a time.Duration that is written as a string such as "12h" in JSON
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadFile reads the config file at path. A missing file yields the defaults.
+func LoadFile(path string) (*File, error) {
+	cfg := &File{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			cfg.applyDefaults()
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	cfg.applyDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (f *File) applyDefaults() {
+	if f.Auth.Provider == "" {
+		f.Auth.Provider = AuthProviderNone
+	}
+	oidc := &f.Auth.OIDC
+	if len(oidc.Scopes) == 0 {
+		oidc.Scopes = []string{"openid", "profile", "email", "groups"}
+	}
+	if oidc.GroupsClaim == "" {
+		oidc.GroupsClaim = "groups"
+	}
+	if oidc.SessionTTL == 0 {
+		oidc.SessionTTL = Duration(12 * time.Hour)
+	}
+	if f.Auth.Local.SessionTTL == 0 {
+		f.Auth.Local.SessionTTL = Duration(7 * 24 * time.Hour)
+	}
+	if f.Auth.Proxy.UserHeader == "" {
+		f.Auth.Proxy.UserHeader = "Remote-User"
+	}
+	if f.SMTP.Configured() && f.SMTP.Port == 0 {
+		f.SMTP.Port = 587
+	}
+}
+
+// Validate checks that the selected auth provider is fully configured and that sync
+// and mail settings are in range
+func (f *File) Validate() error {
+	if f.Sync.Workers < 0 || f.Sync.Workers > MaxSyncWorkers {
+		return fmt.Errorf("sync.workers must be between 1 and %d", MaxSyncWorkers)
+	}
+	if f.SMTP.Configured() {
+		switch {
+		case f.SMTP.Port < 1 || f.SMTP.Port > 65535:
+			return errors.New("smtp.port must be between 1 and 65535")
+		case f.SMTP.From == "":
+			return errors.New("smtp.from is required")
+		}
+	}
+
+	if f.Auth.MultiUser() && f.Webhooks.Secret != "" {
+		return errors.New("webhooks.secret isn't supported with the local or proxy auth providers")
+	}
+
+	switch f.Auth.Provider {
+	case AuthProviderNone, AuthProviderLocal:
+		return nil
+	case AuthProviderProxy:
+		proxy := f.Auth.Proxy
+		switch {
+		case len(proxy.TrustedProxies) == 0:
+			return errors.New("auth.proxy.trustedProxies must list at least one address")
+		case len(proxy.Admins) == 0:
+			return errors.New("auth.proxy.admins must list at least one username")
+		}
+		if _, err := proxy.TrustedPrefixes(); err != nil {
+			return fmt.Errorf("auth.proxy.trustedProxies: %w", err)
+		}
+		return nil
+	case AuthProviderOIDC:
+		oidc := f.Auth.OIDC
+		switch {
+		case oidc.Issuer == "":
+			return errors.New("auth.oidc.issuer is required")
+		case oidc.ClientID == "":
+			return errors.New("auth.oidc.clientId is required")
+		case oidc.RedirectURL == "":
+			return errors.New("auth.oidc.redirectUrl is required")
+		case len(oidc.GroupAccounts) == 0:
+			return errors.New("auth.oidc.groupAccounts must map at least one group")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown auth provider %q", f.Auth.Provider)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/config"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), config.FileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFile_MissingFileUsesDefaults(t *testing.T) {
+	cfg, err := config.LoadFile(filepath.Join(t.TempDir(), config.FileName))
+	require.NoError(t, err)
+	require.Equal(t, config.AuthProviderNone, cfg.Auth.Provider)
+	require.Empty(t, cfg.Webhooks.Secret)
+}
+
+func TestLoadFile_Webhooks(t *testing.T) {
+	path := writeConfig(t, `{"webhooks": {"secret": "s3cret"}}`)
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, config.AuthProviderNone, cfg.Auth.Provider)
+	require.Equal(t, "s3cret", cfg.Webhooks.Secret)
+}
+
+func TestLoadFile_SyncWorkers(t *testing.T) {
+	path := writeConfig(t, `{"sync": {"workers": 8}}`)
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 8, cfg.Sync.Workers)
+}
+
+func TestLoadFile_SMTP(t *testing.T) {
+	path := writeConfig(t, `{"smtp": {"host": "smtp.example.com", "from": "octobud@example.com"}}`)
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	require.True(t, cfg.SMTP.Configured())
+	require.Equal(t, 587, cfg.SMTP.Port)
+}
+
+func TestLoadFile_OIDC(t *testing.T) {
+	path := writeConfig(t, `{
+		"auth": {
+			"provider": "oidc",
+			"oidc": {
+				"issuer": "https://id.example.com",
+				"clientId": "octobud",
+				"redirectUrl": "https://octobud.example.com/api/auth/callback",
+				"groupAccounts": {"octobud-users": "monalisa"},
+				"sessionTtl": "8h"
+			}
+		}
+	}`)
+
+	cfg, err := config.LoadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, config.AuthProviderOIDC, cfg.Auth.Provider)
+	require.Equal(t, "groups", cfg.Auth.OIDC.GroupsClaim)
+	require.Equal(t, []string{"openid", "profile", "email", "groups"}, cfg.Auth.OIDC.Scopes)
+	require.Equal(t, config.Duration(8*time.Hour), cfg.Auth.OIDC.SessionTTL)
+}
+
+func TestLoadFile_MultiUser(t *testing.T) {
+	cfg, err := config.LoadFile(writeConfig(t, `{"auth": {"provider": "local"}}`))
+	require.NoError(t, err)
+	require.True(t, cfg.Auth.MultiUser())
+	require.Equal(t, config.Duration(7*24*time.Hour), cfg.Auth.Local.SessionTTL)
+
+	cfg, err = config.LoadFile(writeConfig(t, `{
+		"auth": {
+			"provider": "proxy",
+			"proxy": {"trustedProxies": ["10.0.0.0/8", "127.0.0.1"], "admins": ["alice"]}
+		}
+	}`))
+	require.NoError(t, err)
+	require.True(t, cfg.Auth.MultiUser())
+	require.Equal(t, "Remote-User", cfg.Auth.Proxy.UserHeader)
+	prefixes, err := cfg.Auth.Proxy.TrustedPrefixes()
+	require.NoError(t, err)
+	require.Len(t, prefixes, 2)
+	require.Equal(t, "127.0.0.1/32", prefixes[1].String())
+
+	cfg, err = config.LoadFile(writeConfig(t, `{}`))
+	require.NoError(t, err)
+	require.False(t, cfg.Auth.MultiUser())
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "unknown provider",
+			content: `{"auth": {"provider": "saml"}}`,
+			errMsg:  `unknown auth provider "saml"`,
+		},
+		{
+			name:    "missing issuer",
+			content: `{"auth": {"provider": "oidc", "oidc": {"clientId": "octobud"}}}`,
+			errMsg:  "auth.oidc.issuer is required",
+		},
+		{
+			name: "no group mapping",
+			content: `{"auth": {"provider": "oidc", "oidc": {
+				"issuer": "https://id.example.com",
+				"clientId": "octobud",
+				"redirectUrl": "https://octobud.example.com/api/auth/callback"
+			}}}`,
+			errMsg: "auth.oidc.groupAccounts must map at least one group",
+		},
+		{
+			name:    "proxy without trusted proxies",
+			content: `{"auth": {"provider": "proxy", "proxy": {"admins": ["alice"]}}}`,
+			errMsg:  "auth.proxy.trustedProxies must list at least one address",
+		},
+		{
+			name:    "proxy without admins",
+			content: `{"auth": {"provider": "proxy", "proxy": {"trustedProxies": ["10.0.0.1"]}}}`,
+			errMsg:  "auth.proxy.admins must list at least one username",
+		},
+		{
+			name: "bad trusted proxy",
+			content: `{"auth": {"provider": "proxy", "proxy": {
+				"trustedProxies": ["proxy.local"], "admins": ["alice"]
+			}}}`,
+			errMsg: `invalid trusted proxy "proxy.local"`,
+		},
+		{
+			name:    "webhooks with multiple users",
+			content: `{"auth": {"provider": "local"}, "webhooks": {"secret": "s3cret"}}`,
+			errMsg:  "webhooks.secret isn't supported",
+		},
+		{
+			name:    "too many sync workers",
+			content: `{"sync": {"workers": 100}}`,
+			errMsg:  "sync.workers must be between 1 and 32",
+		},
+		{
+			name:    "smtp without a sender",
+			content: `{"smtp": {"host": "smtp.example.com"}}`,
+			errMsg:  "smtp.from is required",
+		},
+		{
+			name:    "bad duration",
+			content: `{"auth": {"oidc": {"sessionTtl": "forever"}}}`,
+			errMsg:  "failed to parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.LoadFile(writeConfig(t, tt.content))
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
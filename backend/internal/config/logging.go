@@ -13,7 +13,7 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package config provides logging configuration for the application.
+// Package config provides logging and config file handling for the application.
 package config
 
 import (
@@ -0,0 +1,315 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// Where an alert's configuration came from
+const (
+	SourceRule    = "rule"
+	SourceView    = "view"
+	SourceDefault = "default"
+)
+
+// Retention is how long recorded alerts are kept. Clients only need them until they've
+// polled, so a week covers a laptop left closed over a long weekend.
+const Retention = 7 * 24 * time.Hour
+
+// MaxListLimit caps how many alerts one request may list
+const MaxListLimit = 200
+
+// Error definitions
+var (
+	ErrFailedToLoadSettings   = errors.New("failed to load alert settings")
+	ErrFailedToUpdateSettings = errors.New("failed to update alert settings")
+	ErrInvalidSettings        = errors.New("invalid alert settings")
+	ErrFailedToRecordAlert    = errors.New("failed to record alert")
+	ErrFailedToListAlerts     = errors.New("failed to list alerts")
+)
+
+// Match is the alert configuration that applies to a notification, and where it came from
+type Match struct {
+	Config   models.AlertConfig
+	Source   string
+	SourceID string
+}
+
+// GetSettings returns the user's alert settings, or the defaults if none are saved
+func (s *Service) GetSettings(ctx context.Context, userID string) (*models.AlertSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultAlertSettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadSettings, err)
+	}
+	return settingsFromUser(user)
+}
+
+// UpdateSettings validates and saves the user's alert settings
+func (s *Service) UpdateSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.AlertSettings,
+) (*models.AlertSettings, error) {
+	if err := s.validate(ctx, userID, settings); err != nil {
+		return nil, err
+	}
+
+	data, err := settings.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateSettings, err)
+	}
+	user, err := s.queries.UpdateUserAlertSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateSettings, err)
+	}
+	return settingsFromUser(user)
+}
+
+func (s *Service) validate(ctx context.Context, userID string, settings *models.AlertSettings) error {
+	if err := settings.Default.Validate(); err != nil {
+		return errors.Join(ErrInvalidSettings, fmt.Errorf("default: %w", err))
+	}
+	for viewID, config := range settings.Views {
+		if err := config.Validate(); err != nil {
+			return errors.Join(ErrInvalidSettings, fmt.Errorf("view %s: %w", viewID, err))
+		}
+		if _, err := s.queries.GetView(ctx, userID, viewID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.Join(ErrInvalidSettings, fmt.Errorf("view %s not found", viewID))
+			}
+			return errors.Join(ErrFailedToUpdateSettings, err)
+		}
+	}
+	if settings.QuietHours.Enabled {
+		if _, err := newQuietWindow(settings.QuietHours); err != nil {
+			return errors.Join(ErrInvalidSettings, err)
+		}
+	}
+	if settings.Native.Query != "" {
+		if _, err := query.ParseAndValidate(settings.Native.Query); err != nil {
+			return errors.Join(ErrInvalidSettings, fmt.Errorf("native notification query: %w", err))
+		}
+	}
+	return nil
+}
+
+// Record applies quiet hours and do-not-disturb to a matched alert and stores it. Alerts
+// older than Retention are pruned along the way.
+func (s *Service) Record(
+	ctx context.Context,
+	userID string,
+	notificationID int64,
+	match Match,
+) (models.Alert, error) {
+	now := s.now()
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		return models.Alert{}, errors.Join(ErrFailedToRecordAlert, err)
+	}
+	settings, err := settingsFromUser(user)
+	if err != nil {
+		return models.Alert{}, errors.Join(ErrFailedToRecordAlert, err)
+	}
+	workingHours, err := models.WorkingHoursFromJSON(user.WorkingHours.RawMessage)
+	if err != nil {
+		return models.Alert{}, errors.Join(ErrFailedToRecordAlert, err)
+	}
+	calendar, err := workhours.NewCalendar(workingHours)
+	if err != nil {
+		return models.Alert{}, errors.Join(ErrFailedToRecordAlert, err)
+	}
+
+	config, suppressed := Decide(match.Config, settings, calendar, user.MutedUntil, now)
+
+	created, err := s.queries.CreateNotificationAlert(ctx, userID, db.CreateNotificationAlertParams{
+		NotificationID: notificationID,
+		Level:          config.Level,
+		Sound:          sql.NullString{String: config.Sound, Valid: config.Sound != ""},
+		Source:         match.Source,
+		SourceID:       sql.NullString{String: match.SourceID, Valid: match.SourceID != ""},
+		Suppressed:     sql.NullString{String: suppressed, Valid: suppressed != ""},
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return models.Alert{}, errors.Join(ErrFailedToRecordAlert, err)
+	}
+
+	// Pruning is housekeeping; a failure here shouldn't lose the alert
+	_, _ = s.queries.DeleteNotificationAlertsBefore(ctx, userID, now.Add(-Retention))
+
+	return toAlert(created), nil
+}
+
+// ListAlerts lists alerts recorded after afterID, oldest first. A negative afterID lists
+// the most recent alerts instead, for clients that haven't stored a cursor yet.
+func (s *Service) ListAlerts(
+	ctx context.Context,
+	userID string,
+	afterID int64,
+	limit int,
+) ([]models.Alert, error) {
+	if limit <= 0 || limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	var alerts []db.NotificationAlert
+	var err error
+	if afterID < 0 {
+		alerts, err = s.queries.ListRecentNotificationAlerts(ctx, userID, int64(limit))
+	} else {
+		alerts, err = s.queries.ListNotificationAlertsAfter(ctx, userID, afterID, int64(limit))
+	}
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListAlerts, err)
+	}
+
+	result := make([]models.Alert, len(alerts))
+	for i, alert := range alerts {
+		result[i] = toAlert(alert)
+	}
+	return result, nil
+}
+
+// Decide downgrades a desktop alert to a badge while notifications are muted (DND) or during
+// quiet hours, returning the alert to record and why it was downgraded, if it was.
+// Quieter alerts never play a sound.
+func Decide(
+	config models.AlertConfig,
+	settings *models.AlertSettings,
+	calendar *workhours.Calendar,
+	mutedUntil sql.NullTime,
+	now time.Time,
+) (models.AlertConfig, string) {
+	if config.Level != models.AlertLevelDesktop {
+		return models.AlertConfig{Level: config.Level}, ""
+	}
+
+	suppressed := ""
+	switch {
+	case mutedUntil.Valid && mutedUntil.Time.After(now):
+		suppressed = models.AlertSuppressedDND
+	case isQuiet(settings, calendar, now):
+		suppressed = models.AlertSuppressedQuietHours
+	default:
+		return config, ""
+	}
+	return models.AlertConfig{Level: models.AlertLevelBadge}, suppressed
+}
+
+// isQuiet reports whether now falls in the user's quiet hours
+func isQuiet(settings *models.AlertSettings, calendar *workhours.Calendar, now time.Time) bool {
+	if settings.QuietOutsideWorkingHours && !calendar.IsWorkingTime(now) {
+		return true
+	}
+	if !settings.QuietHours.Enabled {
+		return false
+	}
+	window, err := newQuietWindow(settings.QuietHours)
+	if err != nil {
+		// Settings are validated on save, so this only happens if a time zone disappears
+		return false
+	}
+	return window.contains(now)
+}
+
+// quietWindow is a parsed QuietHours
+type quietWindow struct {
+	start    time.Duration // Offset from midnight
+	end      time.Duration // Offset from midnight
+	location *time.Location
+}
+
+func newQuietWindow(hours models.QuietHours) (*quietWindow, error) {
+	w := &quietWindow{location: time.Local}
+	var err error
+	if w.start, err = parseClock(hours.Start); err != nil {
+		return nil, fmt.Errorf("quiet hours start %q must be formatted HH:MM", hours.Start)
+	}
+	if w.end, err = parseClock(hours.End); err != nil {
+		return nil, fmt.Errorf("quiet hours end %q must be formatted HH:MM", hours.End)
+	}
+	if w.start == w.end {
+		return nil, errors.New("quiet hours start and end must differ")
+	}
+	if hours.Timezone != "" {
+		if w.location, err = time.LoadLocation(hours.Timezone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", hours.Timezone)
+		}
+	}
+	return w, nil
+}
+
+// contains reports whether t falls in the window, which wraps past midnight when it
+// ends earlier in the day than it starts
+func (w *quietWindow) contains(t time.Time) bool {
+	local := t.In(w.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	return offset >= w.start || offset < w.end
+}
+
+// parseClock parses an "HH:MM" time of day into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func settingsFromUser(user db.User) (*models.AlertSettings, error) {
+	if !user.AlertSettings.Valid {
+		return models.DefaultAlertSettings(), nil
+	}
+	settings, err := models.AlertSettingsFromJSON(user.AlertSettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadSettings, err)
+	}
+	return settings, nil
+}
+
+func toAlert(a db.NotificationAlert) models.Alert {
+	return models.Alert{
+		ID:         a.ID,
+		GithubID:   a.GithubID,
+		Level:      a.Level,
+		Sound:      a.Sound.String,
+		Source:     a.Source,
+		SourceID:   a.SourceID.String,
+		Suppressed: a.Suppressed.String,
+		CreatedAt:  a.CreatedAt,
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// A Sunday, 23:30 UTC
+var testNow = time.Date(2025, 6, 15, 23, 30, 0, 0, time.UTC)
+
+func TestDecide(t *testing.T) {
+	desktop := models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "chime"}
+	quiet := models.DefaultAlertSettings()
+	quiet.QuietHours = models.QuietHours{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"}
+
+	workingHours := models.DefaultWorkingHours()
+	workingHours.Enabled = true
+	workingHours.Timezone = "UTC"
+	workCalendar, err := workhours.NewCalendar(workingHours)
+	require.NoError(t, err)
+	alwaysOn, err := workhours.NewCalendar(nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		config         models.AlertConfig
+		settings       *models.AlertSettings
+		calendar       *workhours.Calendar
+		mutedUntil     sql.NullTime
+		now            time.Time
+		expected       models.AlertConfig
+		expectedReason string
+	}{
+		{
+			name:     "desktop alert passes through",
+			config:   desktop,
+			settings: models.DefaultAlertSettings(),
+			calendar: alwaysOn,
+			now:      testNow,
+			expected: desktop,
+		},
+		{
+			name:           "global mute downgrades to badge",
+			config:         desktop,
+			settings:       models.DefaultAlertSettings(),
+			calendar:       alwaysOn,
+			mutedUntil:     sql.NullTime{Time: testNow.Add(time.Hour), Valid: true},
+			now:            testNow,
+			expected:       models.AlertConfig{Level: models.AlertLevelBadge},
+			expectedReason: models.AlertSuppressedDND,
+		},
+		{
+			name:       "expired mute is ignored",
+			config:     desktop,
+			settings:   models.DefaultAlertSettings(),
+			calendar:   alwaysOn,
+			mutedUntil: sql.NullTime{Time: testNow.Add(-time.Hour), Valid: true},
+			now:        testNow,
+			expected:   desktop,
+		},
+		{
+			name:           "quiet hours wrapping midnight downgrade late evening",
+			config:         desktop,
+			settings:       quiet,
+			calendar:       alwaysOn,
+			now:            testNow,
+			expected:       models.AlertConfig{Level: models.AlertLevelBadge},
+			expectedReason: models.AlertSuppressedQuietHours,
+		},
+		{
+			name:           "quiet hours wrapping midnight downgrade early morning",
+			config:         desktop,
+			settings:       quiet,
+			calendar:       alwaysOn,
+			now:            time.Date(2025, 6, 16, 6, 59, 0, 0, time.UTC),
+			expected:       models.AlertConfig{Level: models.AlertLevelBadge},
+			expectedReason: models.AlertSuppressedQuietHours,
+		},
+		{
+			name:     "quiet hours end at the end time",
+			config:   desktop,
+			settings: quiet,
+			calendar: alwaysOn,
+			now:      time.Date(2025, 6, 16, 7, 0, 0, 0, time.UTC),
+			expected: desktop,
+		},
+		{
+			name:   "outside working hours counts as quiet when enabled",
+			config: desktop,
+			settings: &models.AlertSettings{
+				Default:                  desktop,
+				QuietOutsideWorkingHours: true,
+			},
+			calendar:       workCalendar,
+			now:            testNow,
+			expected:       models.AlertConfig{Level: models.AlertLevelBadge},
+			expectedReason: models.AlertSuppressedQuietHours,
+		},
+		{
+			name:   "working hours alert normally",
+			config: desktop,
+			settings: &models.AlertSettings{
+				Default:                  desktop,
+				QuietOutsideWorkingHours: true,
+			},
+			calendar: workCalendar,
+			now:      time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC),
+			expected: desktop,
+		},
+		{
+			name:     "badge alerts drop their sound and are never suppressed",
+			config:   models.AlertConfig{Level: models.AlertLevelBadge, Sound: "ping"},
+			settings: quiet,
+			calendar: alwaysOn,
+			now:      testNow,
+			expected: models.AlertConfig{Level: models.AlertLevelBadge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, reason := Decide(tt.config, tt.settings, tt.calendar, tt.mutedUntil, tt.now)
+			require.Equal(t, tt.expected, config)
+			require.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestService_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  *models.AlertSettings
+		setupMock func(*mocks.MockStore)
+		expectErr error
+	}{
+		{
+			name: "valid settings are saved",
+			settings: &models.AlertSettings{
+				Default: models.AlertConfig{Level: models.AlertLevelBadge},
+				Views: map[string]models.AlertConfig{
+					"view-1": {Level: models.AlertLevelDesktop, Sound: "bell"},
+				},
+				QuietHours: models.QuietHours{Enabled: true, Start: "22:00", End: "07:00"},
+				Native:     models.NativeNotifications{Enabled: true, Query: "reason:review_requested"},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetView(gomock.Any(), "user-1", "view-1").Return(db.View{ID: "view-1"}, nil)
+				m.EXPECT().
+					UpdateUserAlertSettings(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, data db.NullRawMessage) (db.User, error) {
+						return db.User{AlertSettings: data}, nil
+					})
+			},
+		},
+		{
+			name:      "unknown level is rejected",
+			settings:  &models.AlertSettings{Default: models.AlertConfig{Level: "loud"}},
+			expectErr: ErrInvalidSettings,
+		},
+		{
+			name: "unknown sound is rejected",
+			settings: &models.AlertSettings{
+				Default: models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "airhorn"},
+			},
+			expectErr: ErrInvalidSettings,
+		},
+		{
+			name: "unknown view is rejected",
+			settings: &models.AlertSettings{
+				Default: models.AlertConfig{Level: models.AlertLevelDesktop},
+				Views:   map[string]models.AlertConfig{"gone": {Level: models.AlertLevelNone}},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetView(gomock.Any(), "user-1", "gone").Return(db.View{}, sql.ErrNoRows)
+			},
+			expectErr: ErrInvalidSettings,
+		},
+		{
+			name: "empty quiet hours window is rejected",
+			settings: &models.AlertSettings{
+				Default:    models.AlertConfig{Level: models.AlertLevelDesktop},
+				QuietHours: models.QuietHours{Enabled: true, Start: "22:00", End: "22:00"},
+			},
+			expectErr: ErrInvalidSettings,
+		},
+		{
+			name: "invalid native notification query is rejected",
+			settings: &models.AlertSettings{
+				Default: models.AlertConfig{Level: models.AlertLevelDesktop},
+				Native:  models.NativeNotifications{Enabled: true, Query: "colour:red"},
+			},
+			expectErr: ErrInvalidSettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(store)
+			}
+
+			svc := NewService(store, func() time.Time { return testNow })
+			saved, err := svc.UpdateSettings(context.Background(), "user-1", tt.settings)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.settings, saved)
+		})
+	}
+}
+
+func TestService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+
+	settings, err := json.Marshal(models.AlertSettings{
+		Default:    models.AlertConfig{Level: models.AlertLevelDesktop},
+		QuietHours: models.QuietHours{Enabled: true, Start: "22:00", End: "07:00", Timezone: "UTC"},
+	})
+	require.NoError(t, err)
+	store.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		AlertSettings: db.NullRawMessage{RawMessage: settings, Valid: true},
+	}, nil)
+	store.EXPECT().
+		CreateNotificationAlert(gomock.Any(), "user-1", db.CreateNotificationAlertParams{
+			NotificationID: 42,
+			Level:          models.AlertLevelBadge,
+			Source:         SourceRule,
+			SourceID:       sql.NullString{String: "rule-1", Valid: true},
+			Suppressed:     sql.NullString{String: models.AlertSuppressedQuietHours, Valid: true},
+			CreatedAt:      testNow,
+		}).
+		Return(db.NotificationAlert{
+			ID:         7,
+			Level:      models.AlertLevelBadge,
+			Source:     SourceRule,
+			SourceID:   sql.NullString{String: "rule-1", Valid: true},
+			Suppressed: sql.NullString{String: models.AlertSuppressedQuietHours, Valid: true},
+			CreatedAt:  testNow,
+		}, nil)
+	store.EXPECT().DeleteNotificationAlertsBefore(gomock.Any(), "user-1", testNow.Add(-Retention))
+
+	svc := NewService(store, func() time.Time { return testNow })
+	alert, err := svc.Record(context.Background(), "user-1", 42, Match{
+		Config:   models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "chime"},
+		Source:   SourceRule,
+		SourceID: "rule-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), alert.ID)
+	require.Equal(t, models.AlertLevelBadge, alert.Level)
+	require.Equal(t, models.AlertSuppressedQuietHours, alert.Suppressed)
+}
+
+func TestService_ListAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	svc := NewService(store, time.Now)
+	ctx := context.Background()
+
+	store.EXPECT().ListRecentNotificationAlerts(gomock.Any(), "user-1", int64(MaxListLimit)).
+		Return([]db.NotificationAlert{{ID: 3, GithubID: "n3", Level: "desktop"}}, nil)
+	alerts, err := svc.ListAlerts(ctx, "user-1", -1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []models.Alert{{ID: 3, GithubID: "n3", Level: "desktop"}}, alerts)
+
+	store.EXPECT().ListNotificationAlertsAfter(gomock.Any(), "user-1", int64(3), int64(10)).
+		Return(nil, nil)
+	alerts, err = svc.ListAlerts(ctx, "user-1", 3, 10)
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+}
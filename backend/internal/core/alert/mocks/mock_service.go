@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/alert/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/alert/service.go -destination=internal/core/alert/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	alert "github.com/octobud-hq/octobud/backend/internal/core/alert"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertService is a mock of AlertService interface.
+type MockAlertService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertServiceMockRecorder is the mock recorder for MockAlertService.
+type MockAlertServiceMockRecorder struct {
+	mock *MockAlertService
+}
+
+// NewMockAlertService creates a new mock instance.
+func NewMockAlertService(ctrl *gomock.Controller) *MockAlertService {
+	mock := &MockAlertService{ctrl: ctrl}
+	mock.recorder = &MockAlertServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertService) EXPECT() *MockAlertServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSettings mocks base method.
+func (m *MockAlertService) GetSettings(ctx context.Context, userID string) (*models.AlertSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userID)
+	ret0, _ := ret[0].(*models.AlertSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockAlertServiceMockRecorder) GetSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockAlertService)(nil).GetSettings), ctx, userID)
+}
+
+// ListAlerts mocks base method.
+func (m *MockAlertService) ListAlerts(ctx context.Context, userID string, afterID int64, limit int) ([]models.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlerts", ctx, userID, afterID, limit)
+	ret0, _ := ret[0].([]models.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlerts indicates an expected call of ListAlerts.
+func (mr *MockAlertServiceMockRecorder) ListAlerts(ctx, userID, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlerts", reflect.TypeOf((*MockAlertService)(nil).ListAlerts), ctx, userID, afterID, limit)
+}
+
+// Record mocks base method.
+func (m *MockAlertService) Record(ctx context.Context, userID string, notificationID int64, match alert.Match) (models.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, userID, notificationID, match)
+	ret0, _ := ret[0].(models.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAlertServiceMockRecorder) Record(ctx, userID, notificationID, match any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAlertService)(nil).Record), ctx, userID, notificationID, match)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockAlertService) UpdateSettings(ctx context.Context, userID string, settings *models.AlertSettings) (*models.AlertSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.AlertSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockAlertServiceMockRecorder) UpdateSettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockAlertService)(nil).UpdateSettings), ctx, userID, settings)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package alert decides how loudly new notifications alert, applying quiet hours and
+// do-not-disturb, and records the decision for clients to act on.
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// AlertService is the interface for alert settings and recorded alerts.
+type AlertService interface {
+	GetSettings(ctx context.Context, userID string) (*models.AlertSettings, error)
+	UpdateSettings(
+		ctx context.Context,
+		userID string,
+		settings *models.AlertSettings,
+	) (*models.AlertSettings, error)
+	// Record applies quiet hours and do-not-disturb to a matched alert and stores it.
+	Record(ctx context.Context, userID string, notificationID int64, match Match) (models.Alert, error)
+	// ListAlerts lists alerts recorded after afterID, oldest first. A negative afterID
+	// lists the most recent alerts instead.
+	ListAlerts(ctx context.Context, userID string, afterID int64, limit int) ([]models.Alert, error)
+}
+
+// Service provides alert settings and records alerts
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Bounds for the reporting window
+const (
+	MinDays = 1
+	MaxDays = 90
+)
+
+// Error definitions
+var (
+	ErrInvalidWindow         = errors.New("invalid analytics window")
+	ErrInvalidDimension      = errors.New("invalid breakdown dimension")
+	ErrFailedToLoadActivity  = errors.New("failed to load notification activity")
+	ErrFailedToLoadBreakdown = errors.New("failed to load notification breakdown")
+)
+
+// Activity counts notifications received, read and archived on each of the last days,
+// today included. Days are UTC.
+func (s *Service) Activity(
+	ctx context.Context,
+	userID string,
+	days int,
+) (*models.ActivityReport, error) {
+	since, err := s.windowStart(days)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListDailyNotificationActivity(ctx, userID, since)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadActivity, err)
+	}
+	mean, err := s.queries.GetMeanTimeToRead(ctx, userID, since)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadActivity, err)
+	}
+
+	byDay := make(map[string]models.DailyActivity, len(rows))
+	for _, row := range rows {
+		date := row.Day.Format(time.DateOnly)
+		byDay[date] = models.DailyActivity{
+			Date:     date,
+			Received: row.Received,
+			Read:     row.Read,
+			Archived: row.Archived,
+		}
+	}
+	daily := make([]models.DailyActivity, days)
+	for i := range daily {
+		date := since.AddDate(0, 0, i).Format(time.DateOnly)
+		activity, ok := byDay[date]
+		if !ok {
+			activity = models.DailyActivity{Date: date}
+		}
+		daily[i] = activity
+	}
+
+	return &models.ActivityReport{
+		Since:                 since,
+		Days:                  days,
+		Daily:                 daily,
+		MeanTimeToReadSeconds: int64(mean.Mean / time.Second),
+		ReadMeasured:          mean.Notifications,
+	}, nil
+}
+
+// Breakdown counts notifications updated in the last days by repository, org or reason.
+// Notifications without a reason are grouped under "unknown".
+func (s *Service) Breakdown(
+	ctx context.Context,
+	userID string,
+	days int,
+	by models.BreakdownDimension,
+) (*models.VolumeBreakdown, error) {
+	if !by.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDimension, by)
+	}
+	since, err := s.windowStart(days)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListNotificationVolume(ctx, userID, since)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadBreakdown, err)
+	}
+
+	report := &models.VolumeBreakdown{Since: since, Days: days, By: by}
+	index := make(map[string]int)
+	for _, row := range rows {
+		key := breakdownKey(by, row.Repository, row.Reason)
+		i, ok := index[key]
+		if !ok {
+			i = len(report.Entries)
+			index[key] = i
+			report.Entries = append(report.Entries, models.VolumeEntry{Key: key})
+		}
+		report.Entries[i].Total += row.Total
+		report.Entries[i].Unread += row.Unread
+		report.Total += row.Total
+	}
+	if report.Entries == nil {
+		report.Entries = []models.VolumeEntry{}
+	}
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		if report.Entries[i].Total != report.Entries[j].Total {
+			return report.Entries[i].Total > report.Entries[j].Total
+		}
+		return report.Entries[i].Key < report.Entries[j].Key
+	})
+	return report, nil
+}
+
+// windowStart returns the start of the UTC day days-1 days ago, so the window covers days
+// whole days with today last.
+func (s *Service) windowStart(days int) (time.Time, error) {
+	if days < MinDays || days > MaxDays {
+		return time.Time{}, fmt.Errorf(
+			"%w: days must be between %d and %d", ErrInvalidWindow, MinDays, MaxDays)
+	}
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(days - 1)), nil
+}
+
+// breakdownKey returns the group a repository and reason fall in
+func breakdownKey(by models.BreakdownDimension, repository, reason string) string {
+	switch by {
+	case models.BreakdownByOrg:
+		org, _, _ := strings.Cut(repository, "/")
+		return org
+	case models.BreakdownByReason:
+		if reason == "" {
+			return "unknown"
+		}
+		return reason
+	default:
+		return repository
+	}
+}
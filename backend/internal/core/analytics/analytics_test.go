@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*Service, *mocks.MockStore) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	return NewService(store, func() time.Time { return testNow }), store
+}
+
+func TestService_Activity(t *testing.T) {
+	since := time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC)
+
+	t.Run("fills days without activity", func(t *testing.T) {
+		svc, store := newTestService(t)
+		store.EXPECT().
+			ListDailyNotificationActivity(gomock.Any(), "user", since).
+			Return([]db.DailyNotificationActivity{
+				{Day: since, Received: 5, Read: 2},
+				{Day: since.AddDate(0, 0, 2), Received: 1, Archived: 3},
+			}, nil)
+		store.EXPECT().
+			GetMeanTimeToRead(gomock.Any(), "user", since).
+			Return(db.MeanTimeToRead{Notifications: 2, Mean: 90 * time.Minute}, nil)
+
+		report, err := svc.Activity(context.Background(), "user", 3)
+		require.NoError(t, err)
+		require.Equal(t, since, report.Since)
+		require.Equal(t, []models.DailyActivity{
+			{Date: "2025-06-13", Received: 5, Read: 2},
+			{Date: "2025-06-14"},
+			{Date: "2025-06-15", Received: 1, Archived: 3},
+		}, report.Daily)
+		require.Equal(t, int64(5400), report.MeanTimeToReadSeconds)
+		require.Equal(t, int64(2), report.ReadMeasured)
+	})
+
+	t.Run("window out of range", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		_, err := svc.Activity(context.Background(), "user", MaxDays+1)
+		require.ErrorIs(t, err, ErrInvalidWindow)
+	})
+
+	t.Run("database error is wrapped", func(t *testing.T) {
+		svc, store := newTestService(t)
+		store.EXPECT().
+			ListDailyNotificationActivity(gomock.Any(), "user", gomock.Any()).
+			Return(nil, errors.New("disk full"))
+
+		_, err := svc.Activity(context.Background(), "user", 3)
+		require.ErrorIs(t, err, ErrFailedToLoadActivity)
+	})
+}
+
+func TestService_Breakdown(t *testing.T) {
+	volume := []db.NotificationVolume{
+		{Repository: "acme/api", Reason: "mention", Total: 2, Unread: 1},
+		{Repository: "acme/api", Reason: "review_requested", Total: 3, Unread: 3},
+		{Repository: "acme/web", Reason: "", Total: 1},
+		{Repository: "cli/cli", Reason: "mention", Total: 4, Unread: 2},
+	}
+
+	tests := []struct {
+		name     string
+		by       models.BreakdownDimension
+		expected []models.VolumeEntry
+	}{
+		{
+			name: "by repository",
+			by:   models.BreakdownByRepository,
+			expected: []models.VolumeEntry{
+				{Key: "acme/api", Total: 5, Unread: 4},
+				{Key: "cli/cli", Total: 4, Unread: 2},
+				{Key: "acme/web", Total: 1},
+			},
+		},
+		{
+			name: "by org",
+			by:   models.BreakdownByOrg,
+			expected: []models.VolumeEntry{
+				{Key: "acme", Total: 6, Unread: 4},
+				{Key: "cli", Total: 4, Unread: 2},
+			},
+		},
+		{
+			name: "by reason",
+			by:   models.BreakdownByReason,
+			expected: []models.VolumeEntry{
+				{Key: "mention", Total: 6, Unread: 3},
+				{Key: "review_requested", Total: 3, Unread: 3},
+				{Key: "unknown", Total: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newTestService(t)
+			store.EXPECT().
+				ListNotificationVolume(gomock.Any(), "user", testNow.Truncate(24*time.Hour).AddDate(0, 0, -6)).
+				Return(volume, nil)
+
+			report, err := svc.Breakdown(context.Background(), "user", 7, tt.by)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, report.Entries)
+			require.Equal(t, int64(10), report.Total)
+		})
+	}
+
+	t.Run("unknown dimension", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		_, err := svc.Breakdown(context.Background(), "user", 7, "author")
+		require.ErrorIs(t, err, ErrInvalidDimension)
+	})
+}
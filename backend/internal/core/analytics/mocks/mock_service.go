@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/analytics/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/analytics/service.go -destination=internal/core/analytics/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsService is a mock of AnalyticsService interface.
+type MockAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsServiceMockRecorder is the mock recorder for MockAnalyticsService.
+type MockAnalyticsServiceMockRecorder struct {
+	mock *MockAnalyticsService
+}
+
+// NewMockAnalyticsService creates a new mock instance.
+func NewMockAnalyticsService(ctrl *gomock.Controller) *MockAnalyticsService {
+	mock := &MockAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsService) EXPECT() *MockAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// Activity mocks base method.
+func (m *MockAnalyticsService) Activity(ctx context.Context, userID string, days int) (*models.ActivityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activity", ctx, userID, days)
+	ret0, _ := ret[0].(*models.ActivityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activity indicates an expected call of Activity.
+func (mr *MockAnalyticsServiceMockRecorder) Activity(ctx, userID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activity", reflect.TypeOf((*MockAnalyticsService)(nil).Activity), ctx, userID, days)
+}
+
+// Breakdown mocks base method.
+func (m *MockAnalyticsService) Breakdown(ctx context.Context, userID string, days int, by models.BreakdownDimension) (*models.VolumeBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Breakdown", ctx, userID, days, by)
+	ret0, _ := ret[0].(*models.VolumeBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Breakdown indicates an expected call of Breakdown.
+func (mr *MockAnalyticsServiceMockRecorder) Breakdown(ctx, userID, days, by any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Breakdown", reflect.TypeOf((*MockAnalyticsService)(nil).Breakdown), ctx, userID, days, by)
+}
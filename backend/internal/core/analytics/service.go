@@ -0,0 +1,54 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package analytics reports notification volume over time: what arrived, what was read
+// and archived, and where it came from.
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// AnalyticsService is the interface for notification volume reporting.
+type AnalyticsService interface {
+	// Activity counts notifications received, read and archived on each of the last days,
+	// with the mean time to read over them.
+	Activity(ctx context.Context, userID string, days int) (*models.ActivityReport, error)
+	// Breakdown counts notifications updated in the last days by repository, org or reason.
+	Breakdown(
+		ctx context.Context,
+		userID string,
+		days int,
+		by models.BreakdownDimension,
+	) (*models.VolumeBreakdown, error)
+}
+
+// Service provides notification volume reporting
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrTokenNotFound       = errors.New("API token not found")
+	ErrNameTaken           = errors.New("an API token with that name already exists")
+	ErrInvalidParams       = errors.New("invalid API token")
+	ErrInvalidToken        = errors.New("invalid or expired API token")
+	ErrFailedToListTokens  = errors.New("failed to list API tokens")
+	ErrFailedToCreateToken = errors.New("failed to create API token")
+	ErrFailedToRevokeToken = errors.New("failed to revoke API token")
+	ErrFailedToVerifyToken = errors.New("failed to verify API token")
+)
+
+// Prefix starts every token, so tokens are easy to recognize in scripts and secret
+// scanners
+const Prefix = "obt_"
+
+const (
+	maxNameLength = 100
+	secretBytes   = 32
+	// shownPrefixLength is how much of a token is kept to tell tokens apart
+	shownPrefixLength = len(Prefix) + 8
+	// lastUsedResolution limits how often the last used time is written, so a busy
+	// script doesn't write on every request
+	lastUsedResolution = time.Minute
+)
+
+// anyAccount is the account of tokens that may access any GitHub account, matching
+// the session of a server that doesn't require signing in
+const anyAccount = "*"
+
+// ListTokens returns the user's tokens, newest first
+func (s *Service) ListTokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	tokens, err := s.queries.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListTokens, err)
+	}
+	result := make([]models.APIToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = models.APITokenFromDB(token)
+	}
+	return result, nil
+}
+
+// CreateToken validates params and creates a token. The scope defaults to read and the
+// account to any account.
+func (s *Service) CreateToken(
+	ctx context.Context,
+	userID string,
+	params models.APITokenParams,
+) (models.APIToken, string, error) {
+	now := s.now()
+	params, err := normalizeParams(params, now)
+	if err != nil {
+		return models.APIToken{}, "", err
+	}
+
+	secret := make([]byte, secretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return models.APIToken{}, "", errors.Join(ErrFailedToCreateToken, err)
+	}
+	value := Prefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	var expiresAt sql.NullTime
+	if params.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *params.ExpiresAt, Valid: true}
+	}
+	token, err := s.queries.CreateAPIToken(ctx, userID, db.CreateAPITokenParams{
+		Name:        params.Name,
+		TokenHash:   hashToken(value),
+		TokenPrefix: value[:shownPrefixLength],
+		Scope:       params.Scope,
+		Account:     params.Account,
+		CreatedAt:   now,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		if models.IsUniqueViolation(err) {
+			return models.APIToken{}, "", ErrNameTaken
+		}
+		return models.APIToken{}, "", errors.Join(ErrFailedToCreateToken, err)
+	}
+	return models.APITokenFromDB(token), value, nil
+}
+
+// RevokeToken deletes a token
+func (s *Service) RevokeToken(ctx context.Context, userID, id string) error {
+	deleted, err := s.queries.DeleteAPIToken(ctx, userID, id)
+	if err != nil {
+		return errors.Join(ErrFailedToRevokeToken, err)
+	}
+	if deleted == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// VerifyToken looks a token up by its hash and checks it hasn't expired
+func (s *Service) VerifyToken(ctx context.Context, value string) (models.APIToken, error) {
+	if !strings.HasPrefix(value, Prefix) || len(value) < shownPrefixLength {
+		return models.APIToken{}, ErrInvalidToken
+	}
+	token, err := s.queries.GetAPITokenByHash(ctx, hashToken(value))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIToken{}, ErrInvalidToken
+		}
+		return models.APIToken{}, errors.Join(ErrFailedToVerifyToken, err)
+	}
+
+	now := s.now()
+	if token.ExpiresAt.Valid && !now.Before(token.ExpiresAt.Time) {
+		return models.APIToken{}, ErrInvalidToken
+	}
+	if !token.LastUsedAt.Valid || now.Sub(token.LastUsedAt.Time) >= lastUsedResolution {
+		// A failed write only leaves the last used time behind, so the request goes ahead
+		if updateErr := s.queries.UpdateAPITokenLastUsed(ctx, token.ID, now); updateErr == nil {
+			token.LastUsedAt = sql.NullTime{Time: now, Valid: true}
+		}
+	}
+	return models.APITokenFromDB(token), nil
+}
+
+// hashToken returns the hex SHA-256 of a token. Tokens are long and random, so a fast
+// hash is enough to keep them out of the database.
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeParams trims and validates a token's name, scope, account and expiry
+func normalizeParams(params models.APITokenParams, now time.Time) (models.APITokenParams, error) {
+	params.Name = strings.TrimSpace(params.Name)
+	params.Scope = strings.ToLower(strings.TrimSpace(params.Scope))
+	if params.Scope == "" {
+		params.Scope = models.APITokenScopeRead
+	}
+	if params.Account == "" {
+		params.Account = anyAccount
+	}
+
+	switch {
+	case params.Name == "":
+		return models.APITokenParams{}, fmt.Errorf("%w: name is required", ErrInvalidParams)
+	case len(params.Name) > maxNameLength:
+		return models.APITokenParams{}, fmt.Errorf(
+			"%w: name must be at most %d characters", ErrInvalidParams, maxNameLength)
+	case params.Scope != models.APITokenScopeRead && params.Scope != models.APITokenScopeWrite:
+		return models.APITokenParams{}, fmt.Errorf(
+			"%w: scope must be read or write", ErrInvalidParams)
+	case params.ExpiresAt != nil && !params.ExpiresAt.After(now):
+		return models.APITokenParams{}, fmt.Errorf(
+			"%w: expiry must be in the future", ErrInvalidParams)
+	}
+	return params, nil
+}
@@ -0,0 +1,235 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package apitoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "user-1"
+
+func TestNormalizeParams(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name      string
+		params    models.APITokenParams
+		expected  models.APITokenParams
+		expectErr string
+	}{
+		{
+			name:     "scope defaults to read and account to any",
+			params:   models.APITokenParams{Name: " CI "},
+			expected: models.APITokenParams{Name: "CI", Scope: "read", Account: "*"},
+		},
+		{
+			name:     "write scope keeps the account",
+			params:   models.APITokenParams{Name: "CLI", Scope: "Write", Account: "monalisa"},
+			expected: models.APITokenParams{Name: "CLI", Scope: "write", Account: "monalisa"},
+		},
+		{
+			name:      "name is required",
+			params:    models.APITokenParams{Scope: "read"},
+			expectErr: "name is required",
+		},
+		{
+			name:      "unknown scope",
+			params:    models.APITokenParams{Name: "x", Scope: "admin"},
+			expectErr: "scope must be read or write",
+		},
+		{
+			name:      "expired already",
+			params:    models.APITokenParams{Name: "x", ExpiresAt: &past},
+			expectErr: "expiry must be in the future",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeParams(tt.params, now)
+			if tt.expectErr != "" {
+				require.ErrorIs(t, err, ErrInvalidParams)
+				require.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestService_CreateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(store, func() time.Time { return now })
+
+	var stored db.CreateAPITokenParams
+	store.EXPECT().
+		CreateAPIToken(gomock.Any(), testUserID, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			arg db.CreateAPITokenParams,
+		) (db.APIToken, error) {
+			stored = arg
+			return db.APIToken{
+				ID:          "tok-1",
+				Name:        arg.Name,
+				TokenPrefix: arg.TokenPrefix,
+				Scope:       arg.Scope,
+				Account:     arg.Account,
+				CreatedAt:   arg.CreatedAt,
+			}, nil
+		})
+
+	token, value, err := svc.CreateToken(context.Background(), testUserID, models.APITokenParams{
+		Name:  "CI",
+		Scope: "write",
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(value, Prefix))
+	require.Equal(t, value[:shownPrefixLength], token.Prefix)
+	require.Equal(t, "write", token.Scope)
+
+	// Only the hash of the value is stored
+	require.Equal(t, hashToken(value), stored.TokenHash)
+	require.NotContains(t, stored.TokenHash, value)
+}
+
+func TestService_CreateToken_NameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	svc := NewService(store, time.Now)
+
+	store.EXPECT().
+		CreateAPIToken(gomock.Any(), testUserID, gomock.Any()).
+		Return(db.APIToken{}, errors.New("UNIQUE constraint failed: api_tokens.user_id, api_tokens.name"))
+
+	_, _, err := svc.CreateToken(context.Background(), testUserID, models.APITokenParams{Name: "CI"})
+	require.ErrorIs(t, err, ErrNameTaken)
+}
+
+func TestService_VerifyToken(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	value := Prefix + "abcdefghijklmnopqrstuvwxyz"
+
+	tests := []struct {
+		name      string
+		value     string
+		setup     func(store *mocks.MockStore)
+		expectErr error
+	}{
+		{
+			name:  "valid token records its use",
+			value: value,
+			setup: func(store *mocks.MockStore) {
+				store.EXPECT().GetAPITokenByHash(gomock.Any(), hashToken(value)).
+					Return(db.APIToken{ID: "tok-1", Scope: "read"}, nil)
+				store.EXPECT().UpdateAPITokenLastUsed(gomock.Any(), "tok-1", now).Return(nil)
+			},
+		},
+		{
+			name:  "recently used token isn't written again",
+			value: value,
+			setup: func(store *mocks.MockStore) {
+				store.EXPECT().GetAPITokenByHash(gomock.Any(), hashToken(value)).
+					Return(db.APIToken{
+						ID:         "tok-1",
+						LastUsedAt: sql.NullTime{Time: now.Add(-time.Second), Valid: true},
+					}, nil)
+			},
+		},
+		{
+			name:      "wrong prefix",
+			value:     "ghp_abcdefghijklmnop",
+			setup:     func(*mocks.MockStore) {},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name:  "unknown token",
+			value: value,
+			setup: func(store *mocks.MockStore) {
+				store.EXPECT().GetAPITokenByHash(gomock.Any(), gomock.Any()).
+					Return(db.APIToken{}, sql.ErrNoRows)
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name:  "expired token",
+			value: value,
+			setup: func(store *mocks.MockStore) {
+				store.EXPECT().GetAPITokenByHash(gomock.Any(), gomock.Any()).
+					Return(db.APIToken{
+						ID:        "tok-1",
+						ExpiresAt: sql.NullTime{Time: now, Valid: true},
+					}, nil)
+			},
+			expectErr: ErrInvalidToken,
+		},
+		{
+			name:  "store error",
+			value: value,
+			setup: func(store *mocks.MockStore) {
+				store.EXPECT().GetAPITokenByHash(gomock.Any(), gomock.Any()).
+					Return(db.APIToken{}, errors.New("disk I/O error"))
+			},
+			expectErr: ErrFailedToVerifyToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockStore(ctrl)
+			tt.setup(store)
+			svc := NewService(store, func() time.Time { return now })
+
+			token, err := svc.VerifyToken(context.Background(), tt.value)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "tok-1", token.ID)
+			require.NotNil(t, token.LastUsedAt)
+		})
+	}
+}
+
+func TestService_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	svc := NewService(store, time.Now)
+
+	store.EXPECT().DeleteAPIToken(gomock.Any(), testUserID, "tok-1").Return(int64(1), nil)
+	store.EXPECT().DeleteAPIToken(gomock.Any(), testUserID, "missing").Return(int64(0), nil)
+
+	require.NoError(t, svc.RevokeToken(context.Background(), testUserID, "tok-1"))
+	require.ErrorIs(t, svc.RevokeToken(context.Background(), testUserID, "missing"), ErrTokenNotFound)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/apitoken/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/apitoken/service.go -destination=internal/core/apitoken/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPITokenService is a mock of APITokenService interface.
+type MockAPITokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAPITokenServiceMockRecorder is the mock recorder for MockAPITokenService.
+type MockAPITokenServiceMockRecorder struct {
+	mock *MockAPITokenService
+}
+
+// NewMockAPITokenService creates a new mock instance.
+func NewMockAPITokenService(ctrl *gomock.Controller) *MockAPITokenService {
+	mock := &MockAPITokenService{ctrl: ctrl}
+	mock.recorder = &MockAPITokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokenService) EXPECT() *MockAPITokenServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateToken mocks base method.
+func (m *MockAPITokenService) CreateToken(ctx context.Context, userID string, params models.APITokenParams) (models.APIToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, userID, params)
+	ret0, _ := ret[0].(models.APIToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockAPITokenServiceMockRecorder) CreateToken(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockAPITokenService)(nil).CreateToken), ctx, userID, params)
+}
+
+// ListTokens mocks base method.
+func (m *MockAPITokenService) ListTokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTokens", ctx, userID)
+	ret0, _ := ret[0].([]models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTokens indicates an expected call of ListTokens.
+func (mr *MockAPITokenServiceMockRecorder) ListTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTokens", reflect.TypeOf((*MockAPITokenService)(nil).ListTokens), ctx, userID)
+}
+
+// RevokeToken mocks base method.
+func (m *MockAPITokenService) RevokeToken(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAPITokenServiceMockRecorder) RevokeToken(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAPITokenService)(nil).RevokeToken), ctx, userID, id)
+}
+
+// VerifyToken mocks base method.
+func (m *MockAPITokenService) VerifyToken(ctx context.Context, value string) (models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyToken", ctx, value)
+	ret0, _ := ret[0].(models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyToken indicates an expected call of VerifyToken.
+func (mr *MockAPITokenServiceMockRecorder) VerifyToken(ctx, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockAPITokenService)(nil).VerifyToken), ctx, value)
+}
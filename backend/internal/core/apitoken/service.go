@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package apitoken issues and verifies the personal API tokens scripts and CLI tools use
+// instead of a browser session.
+package apitoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// APITokenService is the interface for API tokens.
+type APITokenService interface {
+	ListTokens(ctx context.Context, userID string) ([]models.APIToken, error)
+	// CreateToken creates a token and returns it along with the token value, which can't
+	// be recovered later
+	CreateToken(
+		ctx context.Context,
+		userID string,
+		params models.APITokenParams,
+	) (models.APIToken, string, error)
+	// RevokeToken deletes a token so it can no longer be used
+	RevokeToken(ctx context.Context, userID, id string) error
+	// VerifyToken returns the token a value belongs to, recording that it was used.
+	// Unknown, malformed and expired tokens are all ErrInvalidToken.
+	VerifyToken(ctx context.Context, value string) (models.APIToken, error)
+}
+
+// Service provides API tokens
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
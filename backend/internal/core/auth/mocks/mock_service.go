@@ -131,6 +131,21 @@ func (mr *MockAuthServiceMockRecorder) HasSyncSettings(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSyncSettings", reflect.TypeOf((*MockAuthService)(nil).HasSyncSettings), ctx)
 }
 
+// ListLinkedAccounts mocks base method.
+func (m *MockAuthService) ListLinkedAccounts(ctx context.Context) ([]models.LinkedAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinkedAccounts", ctx)
+	ret0, _ := ret[0].([]models.LinkedAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinkedAccounts indicates an expected call of ListLinkedAccounts.
+func (mr *MockAuthServiceMockRecorder) ListLinkedAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinkedAccounts", reflect.TypeOf((*MockAuthService)(nil).ListLinkedAccounts), ctx)
+}
+
 // UpdateGitHubIdentity mocks base method.
 func (m *MockAuthService) UpdateGitHubIdentity(ctx context.Context, githubUserID, githubUsername string) error {
 	m.ctrl.T.Helper()
@@ -48,6 +48,7 @@ type AuthService interface {
 	HasSyncSettings(ctx context.Context) (bool, error)
 	HasGitHubIdentity(ctx context.Context) (bool, error)
 	UpdateUserMutedUntil(ctx context.Context, mutedUntil sql.NullTime) (*models.User, error)
+	ListLinkedAccounts(ctx context.Context) ([]models.LinkedAccount, error)
 }
 
 // Service provides business logic for authentication operations
@@ -263,3 +264,29 @@ func (s *Service) UpdateUserMutedUntil(
 		MutedUntil:        updatedUser.MutedUntil,
 	}, nil
 }
+
+// ListLinkedAccounts lists the GitHub accounts linked alongside the primary account
+func (s *Service) ListLinkedAccounts(ctx context.Context) ([]models.LinkedAccount, error) {
+	user, err := s.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.GithubUserID == "" {
+		return []models.LinkedAccount{}, nil
+	}
+
+	accounts, err := s.queries.ListLinkedAccounts(ctx, user.GithubUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked accounts: %w", err)
+	}
+
+	result := make([]models.LinkedAccount, len(accounts))
+	for i, account := range accounts {
+		result[i] = models.LinkedAccount{
+			GithubUserID: account.GithubUserID,
+			Login:        account.Login,
+			LastSyncedAt: models.NullTimePtr(account.LastSyncedAt),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authorprofile
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const (
+	// DefaultTTL is how long a cached profile is used before it's refreshed
+	DefaultTTL = 7 * 24 * time.Hour
+	// DefaultBatchInterval is the minimum time between profile batches sent to GitHub
+	DefaultBatchInterval = 5 * time.Second
+	// errorBackoff is how long refreshing pauses after GitHub returns an error
+	errorBackoff = time.Minute
+	// maxPending caps the refresh queue so a burst of new authors can't grow it unbounded
+	maxPending = 1000
+)
+
+// pendingKey identifies a queued refresh
+type pendingKey struct {
+	userID string
+	login  string
+}
+
+// Service caches author profiles and refreshes them lazily in rate-limited batches
+type Service struct {
+	logger        *zap.Logger
+	queries       db.Store
+	client        githubinterfaces.Client
+	now           func() time.Time
+	ttl           time.Duration
+	batchInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[pendingKey]struct{}
+	wake    chan struct{}
+}
+
+// NewService constructs a Service that fetches profiles with the given GitHub client
+func NewService(
+	logger *zap.Logger,
+	queries db.Store,
+	client githubinterfaces.Client,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		logger:        logger,
+		queries:       queries,
+		client:        client,
+		now:           now,
+		ttl:           DefaultTTL,
+		batchInterval: DefaultBatchInterval,
+		pending:       make(map[pendingKey]struct{}),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// WithTTL sets how long cached profiles are used before they're refreshed
+func (s *Service) WithTTL(ttl time.Duration) *Service {
+	s.ttl = ttl
+	return s
+}
+
+// WithBatchInterval sets the minimum time between profile batches
+func (s *Service) WithBatchInterval(interval time.Duration) *Service {
+	s.batchInterval = interval
+	return s
+}
+
+// Profiles returns a profile for each login. Logins without a cached profile get a
+// placeholder with only the login and a type guessed from it, so callers can always
+// tell bots apart. Cache read errors are logged rather than returned, since profiles
+// only decorate responses.
+func (s *Service) Profiles(
+	ctx context.Context,
+	userID string,
+	logins []string,
+) map[string]models.AuthorProfile {
+	result := make(map[string]models.AuthorProfile, len(logins))
+	if len(logins) == 0 {
+		return result
+	}
+
+	cached, err := s.queries.ListAuthorProfiles(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to list author profiles", zap.Error(err))
+	}
+	byLogin := make(map[string]db.AuthorProfile, len(cached))
+	for _, profile := range cached {
+		byLogin[strings.ToLower(profile.Login)] = profile
+	}
+
+	var refresh []string
+	now := s.now()
+	for _, login := range logins {
+		if login == "" {
+			continue
+		}
+		if _, seen := result[login]; seen {
+			continue
+		}
+
+		profile, ok := byLogin[strings.ToLower(login)]
+		if !ok {
+			result[login] = models.AuthorProfile{
+				Login: login,
+				Type:  models.AuthorTypeFromLogin(login),
+			}
+			refresh = append(refresh, login)
+			continue
+		}
+
+		result[login] = models.AuthorProfileFromDB(profile)
+		if now.Sub(profile.FetchedAt) > s.ttl {
+			refresh = append(refresh, login)
+		}
+	}
+
+	s.enqueue(userID, refresh)
+	return result
+}
+
+// enqueue queues logins for refresh and wakes the refresh loop
+func (s *Service) enqueue(userID string, logins []string) {
+	if len(logins) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	for _, login := range logins {
+		if len(s.pending) >= maxPending {
+			break
+		}
+		s.pending[pendingKey{userID: userID, login: login}] = struct{}{}
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// nextBatch removes and returns up to one batch of queued logins for a single user
+func (s *Service) nextBatch() (string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var userID string
+	var logins []string
+	for key := range s.pending {
+		if userID == "" {
+			userID = key.userID
+		}
+		if key.userID != userID {
+			continue
+		}
+		logins = append(logins, key.login)
+		delete(s.pending, key)
+		if len(logins) == github.MaxProfileBatchSize {
+			break
+		}
+	}
+	return userID, logins
+}
+
+// Run refreshes queued profiles until ctx is cancelled, sending at most one batch
+// to GitHub per batch interval and backing off after errors.
+func (s *Service) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		}
+
+		for {
+			userID, logins := s.nextBatch()
+			if len(logins) == 0 {
+				break
+			}
+
+			wait := s.batchInterval
+			if err := s.refresh(ctx, userID, logins); err != nil {
+				s.logger.Warn("failed to refresh author profiles",
+					zap.Int("count", len(logins)),
+					zap.Error(err))
+				wait = errorBackoff
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+// refresh fetches one batch of profiles and stores them. Logins GitHub doesn't know
+// (deleted accounts) are stored without a name or avatar so they aren't refetched
+// until the TTL expires.
+func (s *Service) refresh(ctx context.Context, userID string, logins []string) error {
+	fetched, err := s.client.FetchUserProfiles(ctx, logins)
+	if err != nil {
+		return err
+	}
+
+	fetchedAt := s.now()
+	found := make(map[string]types.UserProfile, len(fetched))
+	for _, profile := range fetched {
+		found[strings.ToLower(profile.Login)] = profile
+	}
+
+	for _, login := range logins {
+		params := db.UpsertAuthorProfileParams{
+			Login:     login,
+			Type:      models.AuthorTypeFromLogin(login),
+			FetchedAt: fetchedAt,
+		}
+		if profile, ok := found[strings.ToLower(login)]; ok {
+			params.Login = profile.Login
+			params.Name = nullString(profile.Name)
+			params.AvatarURL = nullString(profile.AvatarURL)
+			params.Type = authorType(profile.Type)
+		}
+		if err := s.queries.UpsertAuthorProfile(ctx, userID, params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authorType maps a GitHub account type to an author type
+func authorType(accountType string) string {
+	switch accountType {
+	case types.AccountTypeBot:
+		return models.AuthorTypeBot
+	case types.AccountTypeOrganization:
+		return models.AuthorTypeOrg
+	default:
+		return models.AuthorTypeUser
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package authorprofile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*Service, *mocks.MockStore, *githubmocks.MockClient) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	client := githubmocks.NewMockClient(ctrl)
+	svc := NewService(zap.NewNop(), store, client, func() time.Time { return testNow })
+	return svc, store, client
+}
+
+func pendingLogins(s *Service) []string {
+	var logins []string
+	for key := range s.pending {
+		logins = append(logins, key.login)
+	}
+	return logins
+}
+
+func TestService_Profiles(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	store.EXPECT().ListAuthorProfiles(gomock.Any(), "user-1").Return([]db.AuthorProfile{
+		{
+			Login:     "Octocat",
+			Name:      sql.NullString{String: "The Octocat", Valid: true},
+			AvatarURL: sql.NullString{String: "https://avatars.example.com/u/1", Valid: true},
+			Type:      models.AuthorTypeUser,
+			FetchedAt: testNow.Add(-time.Hour),
+		},
+		{
+			Login:     "renovate[bot]",
+			Type:      models.AuthorTypeBot,
+			FetchedAt: testNow.Add(-DefaultTTL - time.Hour),
+		},
+	}, nil)
+
+	profiles := svc.Profiles(
+		context.Background(),
+		"user-1",
+		[]string{"octocat", "octocat", "renovate[bot]", "newcomer", "dependabot[bot]", ""},
+	)
+
+	require.Len(t, profiles, 4)
+	require.Equal(t, "The Octocat", *profiles["octocat"].Name)
+	require.True(t, profiles["renovate[bot]"].IsBot())
+	require.Equal(t, models.AuthorProfile{Login: "newcomer", Type: models.AuthorTypeUser},
+		profiles["newcomer"])
+	require.True(t, profiles["dependabot[bot]"].IsBot())
+	require.Nil(t, profiles["dependabot[bot]"].AvatarURL)
+
+	// Fresh profiles aren't refetched; stale and missing ones are queued
+	require.ElementsMatch(t, []string{"renovate[bot]", "newcomer", "dependabot[bot]"},
+		pendingLogins(svc))
+}
+
+func TestService_Profiles_CacheErrorFallsBackToPlaceholders(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	store.EXPECT().ListAuthorProfiles(gomock.Any(), "user-1").
+		Return(nil, errors.New("database is locked"))
+
+	profiles := svc.Profiles(context.Background(), "user-1", []string{"octocat"})
+	require.Equal(t, models.AuthorProfile{Login: "octocat", Type: models.AuthorTypeUser},
+		profiles["octocat"])
+	require.Equal(t, []string{"octocat"}, pendingLogins(svc))
+}
+
+func TestService_Refresh(t *testing.T) {
+	svc, store, client := newTestService(t)
+	svc.enqueue("user-1", []string{"octocat", "acme", "ghost"})
+
+	userID, logins := svc.nextBatch()
+	require.Equal(t, "user-1", userID)
+	require.Len(t, logins, 3)
+	require.Empty(t, svc.pending)
+
+	client.EXPECT().FetchUserProfiles(gomock.Any(), logins).Return([]types.UserProfile{
+		{Login: "Octocat", Name: "The Octocat", Type: types.AccountTypeUser},
+		{Login: "acme", AvatarURL: "https://avatars.example.com/u/2",
+			Type: types.AccountTypeOrganization},
+	}, nil)
+
+	stored := map[string]db.UpsertAuthorProfileParams{}
+	store.EXPECT().UpsertAuthorProfile(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertAuthorProfileParams) error {
+			stored[arg.Login] = arg
+			return nil
+		}).Times(3)
+
+	require.NoError(t, svc.refresh(context.Background(), userID, logins))
+
+	require.Equal(t, db.UpsertAuthorProfileParams{
+		Login:     "Octocat",
+		Name:      sql.NullString{String: "The Octocat", Valid: true},
+		Type:      models.AuthorTypeUser,
+		FetchedAt: testNow,
+	}, stored["Octocat"])
+	require.Equal(t, models.AuthorTypeOrg, stored["acme"].Type)
+	require.True(t, stored["acme"].AvatarURL.Valid)
+
+	// Unknown logins are cached too, so they aren't refetched on every list
+	require.Equal(t, db.UpsertAuthorProfileParams{
+		Login:     "ghost",
+		Type:      models.AuthorTypeUser,
+		FetchedAt: testNow,
+	}, stored["ghost"])
+}
+
+func TestService_Refresh_FetchError(t *testing.T) {
+	svc, _, client := newTestService(t)
+	client.EXPECT().FetchUserProfiles(gomock.Any(), []string{"octocat"}).
+		Return(nil, errors.New("github: graphql status 403: rate limited"))
+
+	err := svc.refresh(context.Background(), "user-1", []string{"octocat"})
+	require.ErrorContains(t, err, "rate limited")
+}
+
+func TestService_NextBatch_LimitsBatchSize(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	logins := make([]string, 0, 120)
+	for i := range 120 {
+		logins = append(logins, fmt.Sprintf("user%d", i))
+	}
+	svc.enqueue("user-1", logins)
+
+	_, first := svc.nextBatch()
+	_, second := svc.nextBatch()
+	_, third := svc.nextBatch()
+	_, empty := svc.nextBatch()
+	require.Len(t, first, 50)
+	require.Len(t, second, 50)
+	require.Len(t, third, 20)
+	require.Empty(t, empty)
+}
+
+func TestService_Run_RefreshesQueuedProfiles(t *testing.T) {
+	svc, store, client := newTestService(t)
+	svc.WithBatchInterval(time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	client.EXPECT().FetchUserProfiles(gomock.Any(), []string{"octocat"}).
+		Return([]types.UserProfile{{Login: "octocat", Type: types.AccountTypeUser}}, nil)
+	store.EXPECT().UpsertAuthorProfile(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(context.Context, string, db.UpsertAuthorProfileParams) error {
+			cancel()
+			return nil
+		})
+
+	svc.enqueue("user-1", []string{"octocat"})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresh loop did not run")
+	}
+}
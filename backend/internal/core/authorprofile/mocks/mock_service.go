@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/authorprofile/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/authorprofile/service.go -destination=internal/core/authorprofile/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorProfileService is a mock of AuthorProfileService interface.
+type MockAuthorProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorProfileServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorProfileServiceMockRecorder is the mock recorder for MockAuthorProfileService.
+type MockAuthorProfileServiceMockRecorder struct {
+	mock *MockAuthorProfileService
+}
+
+// NewMockAuthorProfileService creates a new mock instance.
+func NewMockAuthorProfileService(ctrl *gomock.Controller) *MockAuthorProfileService {
+	mock := &MockAuthorProfileService{ctrl: ctrl}
+	mock.recorder = &MockAuthorProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorProfileService) EXPECT() *MockAuthorProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// Profiles mocks base method.
+func (m *MockAuthorProfileService) Profiles(ctx context.Context, userID string, logins []string) map[string]models.AuthorProfile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profiles", ctx, userID, logins)
+	ret0, _ := ret[0].(map[string]models.AuthorProfile)
+	return ret0
+}
+
+// Profiles indicates an expected call of Profiles.
+func (mr *MockAuthorProfileServiceMockRecorder) Profiles(ctx, userID, logins any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profiles", reflect.TypeOf((*MockAuthorProfileService)(nil).Profiles), ctx, userID, logins)
+}
+
+// Run mocks base method.
+func (m *MockAuthorProfileService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAuthorProfileServiceMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAuthorProfileService)(nil).Run), ctx)
+}
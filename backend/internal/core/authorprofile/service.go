@@ -0,0 +1,32 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package authorprofile provides a cache of GitHub profiles for notification authors.
+package authorprofile
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// AuthorProfileService is the interface for the author profile service.
+type AuthorProfileService interface {
+	// Profiles returns a profile for each login, keyed by login. Logins that aren't
+	// cached yet, or whose profile has expired, are queued for a background refresh.
+	Profiles(ctx context.Context, userID string, logins []string) map[string]models.AuthorProfile
+	// Run refreshes queued profiles until ctx is cancelled.
+	Run(ctx context.Context)
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Bounds on backup settings
+const (
+	MaxIntervalHours = 30 * 24
+	MaxKeep          = 100
+)
+
+// Error definitions
+var (
+	ErrInvalidSettings            = errors.New("invalid backup settings")
+	ErrFailedToLoadBackupSettings = errors.New("failed to load backup settings")
+	ErrFailedToSaveBackupSettings = errors.New("failed to save backup settings")
+	ErrFailedToBackUp             = errors.New("failed to back up database")
+	ErrFailedToListBackups        = errors.New("failed to list backups")
+	ErrBackupNotFound             = errors.New("backup not found")
+	ErrUnusableBackup             = errors.New("backup can't be restored")
+	ErrFailedToRestore            = errors.New("failed to stage restore")
+	ErrNoPendingRestore           = errors.New("no restore is pending")
+)
+
+// GetBackupSettings returns the user's backup settings, or the defaults if none are saved
+func (s *Service) GetBackupSettings(
+	ctx context.Context,
+	userID string,
+) (*models.BackupSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	return s.loadSettings(ctx)
+}
+
+// UpdateBackupSettings validates and saves the user's backup settings
+func (s *Service) UpdateBackupSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.BackupSettings,
+) (*models.BackupSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	if settings.IntervalHours < 1 || settings.IntervalHours > MaxIntervalHours {
+		return nil, fmt.Errorf("%w: intervalHours must be between 1 and %d", ErrInvalidSettings, MaxIntervalHours)
+	}
+	if settings.Keep < 1 || settings.Keep > MaxKeep {
+		return nil, fmt.Errorf("%w: keep must be between 1 and %d", ErrInvalidSettings, MaxKeep)
+	}
+
+	data, err := settings.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveBackupSettings, err)
+	}
+	_, err = s.queries.UpdateUserBackupSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveBackupSettings, err)
+	}
+	return settings, nil
+}
+
+func (s *Service) loadSettings(ctx context.Context) (*models.BackupSettings, error) {
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultBackupSettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadBackupSettings, err)
+	}
+	if !user.BackupSettings.Valid {
+		return models.DefaultBackupSettings(), nil
+	}
+	settings, err := models.BackupSettingsFromJSON(user.BackupSettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadBackupSettings, err)
+	}
+	return settings, nil
+}
+
+// Create backs up the database now and prunes old backups
+func (s *Service) Create(ctx context.Context) (Info, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return Info{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(ctx, settings)
+}
+
+// RunScheduled backs up the database if a scheduled backup is due
+func (s *Service) RunScheduled(ctx context.Context) (*Info, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backups, err := list(s.dir)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListBackups, err)
+	}
+	interval := time.Duration(settings.IntervalHours) * time.Hour
+	if len(backups) > 0 && s.now().Sub(backups[0].CreatedAt) < interval {
+		return nil, nil
+	}
+
+	info, err := s.create(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// create writes a backup and deletes the oldest beyond settings.Keep; callers must hold mu
+func (s *Service) create(ctx context.Context, settings *models.BackupSettings) (Info, error) {
+	info, err := write(ctx, s.dbPath, s.dir, s.now())
+	if err != nil {
+		return Info{}, errors.Join(ErrFailedToBackUp, err)
+	}
+	if err := prune(s.dir, settings.Keep); err != nil {
+		return Info{}, errors.Join(ErrFailedToBackUp, err)
+	}
+	return info, nil
+}
+
+// List returns the backups on disk, newest first
+func (s *Service) List() ([]Info, error) {
+	backups, err := list(s.dir)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListBackups, err)
+	}
+	return backups, nil
+}
+
+// Restore checks that the named backup is a healthy database no newer than the live one,
+// then stages a copy of it next to the live database. The copy replaces the database the
+// next time the workspace opens, after the current database is itself backed up.
+func (s *Service) Restore(ctx context.Context, name string) (Info, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backups, err := list(s.dir)
+	if err != nil {
+		return Info{}, errors.Join(ErrFailedToListBackups, err)
+	}
+	var info *Info
+	for i := range backups {
+		if backups[i].Name == name {
+			info = &backups[i]
+			break
+		}
+	}
+	if info == nil {
+		return Info{}, ErrBackupNotFound
+	}
+
+	if err := checkRestorable(ctx, info.Path, s.dbPath); err != nil {
+		return Info{}, err
+	}
+	if err := stage(info.Path, pendingPath(s.dbPath)); err != nil {
+		return Info{}, errors.Join(ErrFailedToRestore, err)
+	}
+	return *info, nil
+}
+
+// PendingRestore reports whether a restore is staged for the next start
+func (s *Service) PendingRestore() (bool, error) {
+	return exists(pendingPath(s.dbPath))
+}
+
+// CancelRestore removes a staged restore
+func (s *Service) CancelRestore() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, err := exists(pendingPath(s.dbPath))
+	if err != nil {
+		return errors.Join(ErrFailedToRestore, err)
+	}
+	if !pending {
+		return ErrNoPendingRestore
+	}
+	if err := removeFile(pendingPath(s.dbPath)); err != nil {
+		return errors.Join(ErrFailedToRestore, err)
+	}
+	return nil
+}
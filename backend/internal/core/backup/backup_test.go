@@ -0,0 +1,314 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+// createDatabase writes a database at path at the given schema version holding one item
+func createDatabase(t *testing.T, path string, version int64, item string) {
+	t.Helper()
+	conn, err := db.OpenDatabase(path)
+	require.NoError(t, err)
+	defer conn.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY, version_id INTEGER NOT NULL)",
+		"CREATE TABLE items (name TEXT NOT NULL)",
+	} {
+		_, err = conn.Exec(stmt)
+		require.NoError(t, err)
+	}
+	_, err = conn.Exec("INSERT INTO goose_db_version (version_id) VALUES (?)", version)
+	require.NoError(t, err)
+	_, err = conn.Exec("INSERT INTO items (name) VALUES (?)", item)
+	require.NoError(t, err)
+}
+
+func readItem(t *testing.T, path string) string {
+	t.Helper()
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	require.NoError(t, err)
+	defer conn.Close()
+	var name string
+	require.NoError(t, conn.QueryRow("SELECT name FROM items").Scan(&name))
+	return name
+}
+
+// expectSettings makes the store return settings, or nothing saved when settings is nil
+func expectSettings(t *testing.T, m *mocks.MockStore, settings *models.BackupSettings) {
+	t.Helper()
+	user := db.User{}
+	if settings != nil {
+		data, err := json.Marshal(settings)
+		require.NoError(t, err)
+		user.BackupSettings = db.NullRawMessage{RawMessage: data, Valid: true}
+	}
+	m.EXPECT().GetUser(gomock.Any()).Return(user, nil).AnyTimes()
+}
+
+func TestService_UpdateBackupSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  models.BackupSettings
+		expectErr error
+	}{
+		{name: "saves valid settings", settings: models.BackupSettings{Enabled: true, IntervalHours: 6, Keep: 3}},
+		{
+			name:      "interval too short",
+			settings:  models.BackupSettings{IntervalHours: 0, Keep: 3},
+			expectErr: ErrInvalidSettings,
+		},
+		{
+			name:      "interval too long",
+			settings:  models.BackupSettings{IntervalHours: 24 * 31, Keep: 3},
+			expectErr: ErrInvalidSettings,
+		},
+		{name: "keeps nothing", settings: models.BackupSettings{IntervalHours: 24, Keep: 0}, expectErr: ErrInvalidSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			if tt.expectErr == nil {
+				mockStore.EXPECT().
+					UpdateUserBackupSettings(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, raw db.NullRawMessage) (db.User, error) {
+						saved, err := models.BackupSettingsFromJSON(raw.RawMessage)
+						require.NoError(t, err)
+						require.Equal(t, tt.settings, *saved)
+						return db.User{}, nil
+					})
+			}
+
+			service := NewService(mockStore, "", "", func() time.Time { return testNow })
+			_, err := service.UpdateBackupSettings(context.Background(), "user-1", &tt.settings)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_GetBackupSettingsDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	expectSettings(t, mockStore, nil)
+
+	settings, err := NewService(mockStore, "", "", time.Now).GetBackupSettings(context.Background(), "user-1")
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultBackupSettings(), settings)
+}
+
+func TestService_CreateKeepsNewestBackups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	expectSettings(t, mockStore, &models.BackupSettings{Enabled: true, IntervalHours: 24, Keep: 2})
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "octobud.db")
+	createDatabase(t, dbPath, 30, "live")
+	backupDir := filepath.Join(dir, DirName)
+	// Files that aren't backups are left alone
+	require.NoError(t, os.MkdirAll(backupDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(backupDir, "notes.txt"), []byte("keep me"), 0o600))
+
+	now := testNow
+	service := NewService(mockStore, dbPath, backupDir, func() time.Time { return now })
+	var created []Info
+	for range 3 {
+		info, err := service.Create(context.Background())
+		require.NoError(t, err)
+		require.Positive(t, info.SizeBytes)
+		created = append(created, info)
+		now = now.Add(time.Hour)
+	}
+
+	backups, err := service.List()
+	require.NoError(t, err)
+	require.Equal(t, []Info{created[2], created[1]}, backups)
+	require.Equal(t, "octobud-20250615T140000.000Z.db", backups[0].Name)
+	require.Equal(t, "live", readItem(t, backups[0].Path))
+	require.FileExists(t, filepath.Join(backupDir, "notes.txt"))
+}
+
+func TestService_RunScheduled(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings models.BackupSettings
+		// How long ago the newest existing backup was taken, or zero for none
+		lastBackup time.Duration
+		expectRun  bool
+	}{
+		{
+			name:      "first backup",
+			settings:  models.BackupSettings{Enabled: true, IntervalHours: 24, Keep: 7},
+			expectRun: true,
+		},
+		{
+			name:       "due",
+			settings:   models.BackupSettings{Enabled: true, IntervalHours: 24, Keep: 7},
+			lastBackup: 25 * time.Hour,
+			expectRun:  true,
+		},
+		{
+			name:       "not due yet",
+			settings:   models.BackupSettings{Enabled: true, IntervalHours: 24, Keep: 7},
+			lastBackup: 23 * time.Hour,
+		},
+		{
+			name:     "disabled",
+			settings: models.BackupSettings{IntervalHours: 24, Keep: 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			expectSettings(t, mockStore, &tt.settings)
+
+			dir := t.TempDir()
+			dbPath := filepath.Join(dir, "octobud.db")
+			createDatabase(t, dbPath, 30, "live")
+			backupDir := filepath.Join(dir, DirName)
+			if tt.lastBackup > 0 {
+				_, err := write(context.Background(), dbPath, backupDir, testNow.Add(-tt.lastBackup))
+				require.NoError(t, err)
+			}
+
+			service := NewService(mockStore, dbPath, backupDir, func() time.Time { return testNow })
+			info, err := service.RunScheduled(context.Background())
+			require.NoError(t, err)
+			if !tt.expectRun {
+				require.Nil(t, info)
+				return
+			}
+			require.NotNil(t, info)
+			require.Equal(t, testNow, info.CreatedAt)
+		})
+	}
+}
+
+func TestService_RestoreAppliesOnNextOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	expectSettings(t, mockStore, nil)
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "octobud.db")
+	createDatabase(t, dbPath, 30, "before")
+	backupDir := filepath.Join(dir, DirName)
+
+	service := NewService(mockStore, dbPath, backupDir, func() time.Time { return testNow })
+	saved, err := service.Create(ctx)
+	require.NoError(t, err)
+
+	// Change the live database after the backup
+	conn, err := db.OpenDatabase(dbPath)
+	require.NoError(t, err)
+	_, err = conn.Exec("UPDATE items SET name = 'after'")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	_, err = service.Restore(ctx, "octobud-missing.db")
+	require.ErrorIs(t, err, ErrBackupNotFound)
+
+	restored, err := service.Restore(ctx, saved.Name)
+	require.NoError(t, err)
+	require.Equal(t, saved, restored)
+	pending, err := service.PendingRestore()
+	require.NoError(t, err)
+	require.True(t, pending)
+	// Nothing changes until the workspace opens again
+	require.Equal(t, "after", readItem(t, dbPath))
+
+	previous, err := ApplyPending(ctx, dbPath, backupDir, testNow.Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, previous)
+	require.Equal(t, "before", readItem(t, dbPath))
+	require.Equal(t, "after", readItem(t, previous.Path))
+
+	// The restored database is writable and nothing is left staged
+	conn, err = db.OpenDatabase(dbPath)
+	require.NoError(t, err)
+	_, err = conn.Exec("UPDATE items SET name = 'again'")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	pending, err = service.PendingRestore()
+	require.NoError(t, err)
+	require.False(t, pending)
+	previous, err = ApplyPending(ctx, dbPath, backupDir, testNow.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Nil(t, previous)
+}
+
+func TestService_RestoreRejectsUnusableBackups(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "octobud.db")
+	createDatabase(t, dbPath, 30, "live")
+	backupDir := filepath.Join(dir, DirName)
+	require.NoError(t, os.MkdirAll(backupDir, 0o700))
+
+	// A backup from a newer release, and a file that isn't a database
+	createDatabase(t, filepath.Join(backupDir, "octobud-20250615T100000.000Z.db"), 31, "newer")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(backupDir, "octobud-20250615T110000.000Z.db"),
+		[]byte("not a database"),
+		0o600,
+	))
+
+	service := NewService(nil, dbPath, backupDir, func() time.Time { return testNow })
+	for _, name := range []string{"octobud-20250615T100000.000Z.db", "octobud-20250615T110000.000Z.db"} {
+		_, err := service.Restore(ctx, name)
+		require.ErrorIs(t, err, ErrUnusableBackup, name)
+	}
+	pending, err := service.PendingRestore()
+	require.NoError(t, err)
+	require.False(t, pending)
+}
+
+func TestService_CancelRestore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "octobud.db")
+	service := NewService(nil, dbPath, filepath.Join(dir, DirName), time.Now)
+
+	require.ErrorIs(t, service.CancelRestore(), ErrNoPendingRestore)
+
+	require.NoError(t, os.WriteFile(dbPath+PendingSuffix, []byte("staged"), 0o600))
+	require.NoError(t, service.CancelRestore())
+	require.NoFileExists(t, dbPath+PendingSuffix)
+}
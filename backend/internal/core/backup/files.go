@@ -0,0 +1,242 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+)
+
+// DirName is the directory in a workspace's data directory that holds its backups
+const DirName = "backups"
+
+// PendingSuffix is added to the database path to name a restore staged for the next start
+const PendingSuffix = ".restore"
+
+// Backups are named for when they were taken, e.g. octobud-20250615T120000.000Z.db
+const (
+	filePrefix = "octobud-"
+	fileSuffix = ".db"
+	timeLayout = "20060102T150405.000Z"
+)
+
+// Info describes a backup on disk
+type Info struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	SizeBytes int64     `json:"sizeBytes"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// write copies the database at dbPath into dir as a read-only backup named for at
+func write(ctx context.Context, dbPath, dir string, at time.Time) (Info, error) {
+	at = at.UTC()
+	name := filePrefix + at.Format(timeLayout) + fileSuffix
+	written, err := snapshot.Write(ctx, dbPath, filepath.Join(dir, name))
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{Name: name, Path: written.Path, SizeBytes: written.SizeBytes, CreatedAt: at}, nil
+}
+
+// list returns the backups in dir, newest first. Other files are ignored, and a missing
+// dir means there are no backups yet.
+func list(dir string) ([]Info, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Info
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		createdAt, err := time.Parse(timeLayout, strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix))
+		if err != nil {
+			continue
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, Info{
+			Name:      name,
+			Path:      filepath.Join(dir, name),
+			SizeBytes: fi.Size(),
+			CreatedAt: createdAt,
+		})
+	}
+	slices.SortFunc(backups, func(a, b Info) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return backups, nil
+}
+
+// prune deletes the oldest backups in dir beyond keep
+func prune(dir string, keep int) error {
+	backups, err := list(dir)
+	if err != nil {
+		return err
+	}
+	for _, old := range backups[min(keep, len(backups)):] {
+		if err := removeFile(old.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRestorable checks that the backup at path passes SQLite's quick check and wasn't
+// written by a newer schema than the live database at dbPath, which migrations can't undo
+func checkRestorable(ctx context.Context, path, dbPath string) error {
+	backupVersion, err := schemaVersion(ctx, path, true)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnusableBackup, err)
+	}
+	liveVersion, err := schemaVersion(ctx, dbPath, false)
+	if err != nil {
+		return errors.Join(ErrFailedToRestore, err)
+	}
+	if backupVersion > liveVersion {
+		return fmt.Errorf(
+			"%w: it was written by a newer version of Octobud (schema %d, this one has %d)",
+			ErrUnusableBackup, backupVersion, liveVersion,
+		)
+	}
+	return nil
+}
+
+// schemaVersion reads the migration version of the database at path, optionally running
+// SQLite's quick check on it first
+func schemaVersion(ctx context.Context, path string, check bool) (int64, error) {
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(2000)")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if check {
+		var result string
+		if err := conn.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+			return 0, err
+		}
+		if result != "ok" {
+			return 0, fmt.Errorf("integrity check failed: %s", result)
+		}
+	}
+	var version int64
+	if err := conn.QueryRowContext(ctx, "SELECT MAX(version_id) FROM goose_db_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// stage copies the backup at src to dst as a writable file, through a temporary file so a
+// failed copy never leaves a partial restore behind
+func stage(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	tmpPath := dst + ".tmp"
+	out, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// ApplyPending replaces the database at dbPath with a restore staged by Service.Restore,
+// if there is one. The current database is backed up into dir first, and the restore is
+// left staged if that fails. It must run before the database is opened, and returns the
+// backup of the replaced database, or nil when nothing was staged or replaced.
+func ApplyPending(ctx context.Context, dbPath, dir string, now time.Time) (*Info, error) {
+	pending := pendingPath(dbPath)
+	staged, err := exists(pending)
+	if err != nil || !staged {
+		return nil, err
+	}
+
+	live, err := exists(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	var previous *Info
+	if live {
+		info, err := write(ctx, dbPath, dir, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up the database before restoring: %w", err)
+		}
+		previous = &info
+	}
+	// The old database's WAL would otherwise be replayed into the restored one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove %s: %w", dbPath+suffix, err)
+		}
+	}
+	if err := os.Rename(pending, dbPath); err != nil {
+		return nil, fmt.Errorf("failed to restore database: %w", err)
+	}
+	return previous, nil
+}
+
+// pendingPath is where a restore of the database at dbPath is staged
+func pendingPath(dbPath string) string {
+	return dbPath + PendingSuffix
+}
+
+func exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// removeFile deletes a file that may be read-only, which Windows otherwise refuses to delete
+func removeFile(path string) error {
+	_ = os.Chmod(path, 0o600)
+	return os.Remove(path)
+}
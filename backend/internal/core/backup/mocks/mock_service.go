@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/backup/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/backup/service.go -destination=internal/core/backup/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	backup "github.com/octobud-hq/octobud/backend/internal/core/backup"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupService is a mock of BackupService interface.
+type MockBackupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupServiceMockRecorder is the mock recorder for MockBackupService.
+type MockBackupServiceMockRecorder struct {
+	mock *MockBackupService
+}
+
+// NewMockBackupService creates a new mock instance.
+func NewMockBackupService(ctrl *gomock.Controller) *MockBackupService {
+	mock := &MockBackupService{ctrl: ctrl}
+	mock.recorder = &MockBackupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupService) EXPECT() *MockBackupServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelRestore mocks base method.
+func (m *MockBackupService) CancelRestore() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRestore")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelRestore indicates an expected call of CancelRestore.
+func (mr *MockBackupServiceMockRecorder) CancelRestore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRestore", reflect.TypeOf((*MockBackupService)(nil).CancelRestore))
+}
+
+// Create mocks base method.
+func (m *MockBackupService) Create(ctx context.Context) (backup.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx)
+	ret0, _ := ret[0].(backup.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBackupServiceMockRecorder) Create(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBackupService)(nil).Create), ctx)
+}
+
+// GetBackupSettings mocks base method.
+func (m *MockBackupService) GetBackupSettings(ctx context.Context, userID string) (*models.BackupSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackupSettings", ctx, userID)
+	ret0, _ := ret[0].(*models.BackupSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackupSettings indicates an expected call of GetBackupSettings.
+func (mr *MockBackupServiceMockRecorder) GetBackupSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackupSettings", reflect.TypeOf((*MockBackupService)(nil).GetBackupSettings), ctx, userID)
+}
+
+// List mocks base method.
+func (m *MockBackupService) List() ([]backup.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]backup.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBackupServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBackupService)(nil).List))
+}
+
+// PendingRestore mocks base method.
+func (m *MockBackupService) PendingRestore() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingRestore")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingRestore indicates an expected call of PendingRestore.
+func (mr *MockBackupServiceMockRecorder) PendingRestore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingRestore", reflect.TypeOf((*MockBackupService)(nil).PendingRestore))
+}
+
+// Restore mocks base method.
+func (m *MockBackupService) Restore(ctx context.Context, name string) (backup.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, name)
+	ret0, _ := ret[0].(backup.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupServiceMockRecorder) Restore(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackupService)(nil).Restore), ctx, name)
+}
+
+// RunScheduled mocks base method.
+func (m *MockBackupService) RunScheduled(ctx context.Context) (*backup.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunScheduled", ctx)
+	ret0, _ := ret[0].(*backup.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunScheduled indicates an expected call of RunScheduled.
+func (mr *MockBackupServiceMockRecorder) RunScheduled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduled", reflect.TypeOf((*MockBackupService)(nil).RunScheduled), ctx)
+}
+
+// UpdateBackupSettings mocks base method.
+func (m *MockBackupService) UpdateBackupSettings(ctx context.Context, userID string, settings *models.BackupSettings) (*models.BackupSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBackupSettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.BackupSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBackupSettings indicates an expected call of UpdateBackupSettings.
+func (mr *MockBackupServiceMockRecorder) UpdateBackupSettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBackupSettings", reflect.TypeOf((*MockBackupService)(nil).UpdateBackupSettings), ctx, userID, settings)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package backup keeps rotating copies of the database and stages one to be restored the
+// next time the workspace opens.
+package backup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// BackupService is the interface for database backups.
+type BackupService interface {
+	GetBackupSettings(ctx context.Context, userID string) (*models.BackupSettings, error)
+	UpdateBackupSettings(
+		ctx context.Context,
+		userID string,
+		settings *models.BackupSettings,
+	) (*models.BackupSettings, error)
+	// Create backs up the database now, then deletes the oldest backups beyond the
+	// number the settings keep.
+	Create(ctx context.Context) (Info, error)
+	// RunScheduled backs up the database when scheduled backups are on and the newest
+	// backup is older than the interval. It returns nil when no backup was due.
+	RunScheduled(ctx context.Context) (*Info, error)
+	// List returns the backups on disk, newest first.
+	List() ([]Info, error)
+	// Restore stages a backup to replace the database the next time the workspace opens.
+	Restore(ctx context.Context, name string) (Info, error)
+	// PendingRestore reports whether a restore is staged.
+	PendingRestore() (bool, error)
+	// CancelRestore drops a staged restore, returning ErrNoPendingRestore if there isn't one.
+	CancelRestore() error
+}
+
+// Service provides database backups
+type Service struct {
+	queries db.Store
+	dbPath  string
+	dir     string
+	now     func() time.Time
+	// Serializes backups, pruning and staging so they don't race on the same files
+	mu sync.Mutex
+}
+
+// NewService constructs a Service that backs up the database at dbPath into dir
+func NewService(queries db.Store, dbPath, dir string, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		dbPath:  dbPath,
+		dir:     dir,
+		now:     now,
+	}
+}
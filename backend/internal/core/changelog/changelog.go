@@ -0,0 +1,109 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package changelog
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToRecordEntry         = errors.New("failed to record changelog entry")
+	ErrFailedToListEntries         = errors.New("failed to list changelog entries")
+	ErrFailedToAcknowledgeEntries  = errors.New("failed to acknowledge changelog entries")
+	ErrInvalidAcknowledgeThreshold = errors.New("upToId must not be negative")
+)
+
+// Service records and serves changelog entries
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+	entries []Entry
+}
+
+// NewService constructs a Service backed by the provided store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+		entries: Entries,
+	}
+}
+
+// Record stores the entries for migrations applied when upgrading from fromVersion to
+// toVersion. A fresh database (fromVersion 0) records nothing: new users have nothing to be
+// surprised by. Recording is idempotent.
+func (s *Service) Record(ctx context.Context, fromVersion, toVersion int64) (int, error) {
+	if fromVersion <= 0 {
+		return 0, nil
+	}
+
+	now := s.now()
+	recorded := 0
+	for _, entry := range s.entries {
+		if entry.SchemaVersion <= fromVersion || entry.SchemaVersion > toVersion {
+			continue
+		}
+		err := s.queries.InsertChangelogEntry(ctx, db.InsertChangelogEntryParams{
+			Key:           entry.Key,
+			SchemaVersion: entry.SchemaVersion,
+			Kind:          string(entry.Kind),
+			Title:         entry.Title,
+			Description:   entry.Description,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return recorded, errors.Join(ErrFailedToRecordEntry, err)
+		}
+		recorded++
+	}
+	return recorded, nil
+}
+
+// ListUnseen returns entries the user hasn't acknowledged, oldest first
+func (s *Service) ListUnseen(ctx context.Context) ([]models.ChangelogEntry, error) {
+	entries, err := s.queries.ListUnseenChangelogEntries(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListEntries, err)
+	}
+	result := make([]models.ChangelogEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, models.ChangelogEntryFromDB(entry))
+	}
+	return result, nil
+}
+
+// Acknowledge marks entries up to and including upToID as seen. Passing the last ID the
+// client displayed avoids dismissing entries recorded after it loaded the list.
+func (s *Service) Acknowledge(ctx context.Context, upToID int64) (int64, error) {
+	if upToID < 0 {
+		return 0, ErrInvalidAcknowledgeThreshold
+	}
+	if upToID == 0 {
+		upToID = math.MaxInt64
+	}
+	count, err := s.queries.AcknowledgeChangelogEntries(ctx, upToID, s.now())
+	if err != nil {
+		return 0, errors.Join(ErrFailedToAcknowledgeEntries, err)
+	}
+	return count, nil
+}
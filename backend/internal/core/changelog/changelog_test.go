@@ -0,0 +1,151 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package changelog
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db/sqlite"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(ctrl *gomock.Controller) (*Service, *mocks.MockStore) {
+	mockStore := mocks.NewMockStore(ctrl)
+	svc := NewService(mockStore, func() time.Time { return testNow })
+	svc.entries = []Entry{
+		{Key: "two", SchemaVersion: 2, Kind: KindFeature, Title: "Two"},
+		{Key: "four", SchemaVersion: 4, Kind: KindBehaviorChange, Title: "Four"},
+		{Key: "five", SchemaVersion: 5, Kind: KindQueryField, Title: "Five"},
+	}
+	return svc, mockStore
+}
+
+func TestService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, mockStore := newTestService(ctrl)
+
+	var recorded []string
+	mockStore.EXPECT().
+		InsertChangelogEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.InsertChangelogEntryParams) error {
+			require.Equal(t, testNow, arg.CreatedAt)
+			recorded = append(recorded, arg.Key)
+			return nil
+		}).
+		Times(2)
+
+	count, err := svc.Record(context.Background(), 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, []string{"four", "five"}, recorded)
+}
+
+func TestService_Record_FreshDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, _ := newTestService(ctrl)
+
+	count, err := svc.Record(context.Background(), 0, 5)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestService_Record_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, mockStore := newTestService(ctrl)
+
+	mockStore.EXPECT().
+		InsertChangelogEntry(gomock.Any(), gomock.Any()).
+		Return(errors.New("database error"))
+
+	_, err := svc.Record(context.Background(), 1, 5)
+	require.ErrorIs(t, err, ErrFailedToRecordEntry)
+}
+
+func TestService_ListUnseen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, mockStore := newTestService(ctrl)
+
+	mockStore.EXPECT().
+		ListUnseenChangelogEntries(gomock.Any()).
+		Return([]db.ChangelogEntry{
+			{ID: 3, Key: "four", SchemaVersion: 4, Kind: "behavior_change", Title: "Four"},
+		}, nil)
+
+	entries, err := svc.ListUnseen(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, int64(3), entries[0].ID)
+	require.Equal(t, "behavior_change", entries[0].Kind)
+}
+
+func TestService_Acknowledge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, mockStore := newTestService(ctrl)
+
+	mockStore.EXPECT().
+		AcknowledgeChangelogEntries(gomock.Any(), int64(7), testNow).
+		Return(int64(2), nil)
+	count, err := svc.Acknowledge(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// Zero acknowledges everything
+	mockStore.EXPECT().
+		AcknowledgeChangelogEntries(gomock.Any(), int64(math.MaxInt64), testNow).
+		Return(int64(1), nil)
+	_, err = svc.Acknowledge(context.Background(), 0)
+	require.NoError(t, err)
+
+	_, err = svc.Acknowledge(context.Background(), -1)
+	require.ErrorIs(t, err, ErrInvalidAcknowledgeThreshold)
+}
+
+// TestEntries guards the registry: keys must be unique and every entry must point at a
+// migration that exists.
+func TestEntries(t *testing.T) {
+	files, err := fs.Glob(sqlite.MigrationsFS, "migrations/*.sql")
+	require.NoError(t, err)
+	versions := make(map[int64]bool)
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err)
+		versions[version] = true
+	}
+
+	keys := make(map[string]bool)
+	for _, entry := range Entries {
+		require.False(t, keys[entry.Key], "duplicate key %q", entry.Key)
+		keys[entry.Key] = true
+		require.True(t, versions[entry.SchemaVersion], "no migration %d", entry.SchemaVersion)
+		require.NotEmpty(t, entry.Title)
+		require.NotEmpty(t, entry.Description)
+	}
+}
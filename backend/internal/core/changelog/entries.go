@@ -0,0 +1,400 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package changelog
+
+// Kind describes what sort of change an entry announces
+type Kind string
+
+// Kind constants
+const (
+	// KindQueryField is a new field or value that can be used in queries
+	KindQueryField Kind = "query_field"
+	// KindBehaviorChange is a change to what existing queries, views, or defaults match
+	KindBehaviorChange Kind = "behavior_change"
+	// KindFeature is a new capability that doesn't change existing behavior
+	KindFeature Kind = "feature"
+)
+
+// Entry is a changelog note tied to the migration that introduced the change
+type Entry struct {
+	// Key uniquely identifies the entry so it's only ever recorded once
+	Key string
+	// SchemaVersion is the migration version that ships the change
+	SchemaVersion int64
+	Kind          Kind
+	Title         string
+	Description   string
+}
+
+// Entries lists user-facing changes by migration version. When a migration adds something
+// users can query on, or changes what existing queries match, add an entry for it here.
+var Entries = []Entry{
+	{
+		Key:           "global-mute",
+		SchemaVersion: 2,
+		Kind:          KindFeature,
+		Title:         "Mute all notifications for a while",
+		Description: "You can pause every notification until a time you choose. " +
+			"Notifications keep syncing while muted, so nothing is lost.",
+	},
+	{
+		Key:           "renamed-repository-queries",
+		SchemaVersion: 4,
+		Kind:          KindBehaviorChange,
+		Title:         "repo: and org: match renamed repositories",
+		Description: "When a repository is renamed or transferred, repo: and org: queries " +
+			"that use its old name keep matching its notifications. Views filtering on the " +
+			"old name may now show more results.",
+	},
+	{
+		Key:           "author-profiles",
+		SchemaVersion: 5,
+		Kind:          KindFeature,
+		Title:         "Author names and avatars",
+		Description: "Notifications show the author's display name and avatar, and mark " +
+			"authors that are bots. Profiles are fetched from GitHub in the background.",
+	},
+	{
+		Key:           "review-request-escalation",
+		SchemaVersion: 7,
+		Kind:          KindFeature,
+		Title:         "Escalate ignored review requests",
+		Description: "Review requests left unread for a number of days you choose can be " +
+			"brought back to the top of your inbox, unarchived and unsnoozed, with a " +
+			"desktop alert. Turn it on in settings; it is off by default.",
+	},
+	{
+		Key:           "working-hours",
+		SchemaVersion: 8,
+		Kind:          KindFeature,
+		Title:         "Working hours and business days",
+		Description: "Set your working days, hours and holidays. When they're on, snoozing " +
+			"until the next business day skips weekends and holidays, and review request " +
+			"escalation counts business days instead of calendar days.",
+	},
+	{
+		Key:           "saved-replies",
+		SchemaVersion: 10,
+		Kind:          KindFeature,
+		Title:         "Reply with your GitHub saved replies",
+		Description: "Your GitHub saved replies are imported and can be used when replying " +
+			"to a notification. {{author}} and {{pr_title}} in a reply are filled in with " +
+			"the notification's author and title.",
+	},
+	{
+		Key:           "review-load",
+		SchemaVersion: 11,
+		Kind:          KindFeature,
+		Title:         "Review load across your team",
+		Description: "List your team members in settings to see how many review requests " +
+			"are waiting on each of them and who is overloaded. Reviewers are recorded " +
+			"as pull requests sync, so older review requests fill in over time.",
+	},
+	{
+		Key:           "content-kind",
+		SchemaVersion: 12,
+		Kind:          KindFeature,
+		Title:         "Filter code changes from discussions",
+		Description: "Notifications are classified as code (pull requests and commits), " +
+			"discussion (issues and discussions) or other. Use kind:code or " +
+			"kind:discussion in views and rules to separate review work from conversations.",
+	},
+	{
+		Key:           "triage-restore",
+		SchemaVersion: 13,
+		Kind:          KindFeature,
+		Title:         "Back up and restore triage state",
+		Description: "Export read, archived, starred, muted, snoozed and tag state to a file " +
+			"before a reset, then import it afterwards. State is merged into notifications " +
+			"as they sync again, so nothing you've triaged since is overwritten.",
+	},
+	{
+		Key:           "alerts",
+		SchemaVersion: 14,
+		Kind:          KindFeature,
+		Title:         "Choose how loudly notifications alert",
+		Description: "Give rules and views an alert level (none, badge only or desktop " +
+			"notification) and a sound. Alerts are decided as notifications sync, so every " +
+			"open client follows the same policy, and quiet hours and do-not-disturb turn " +
+			"desktop alerts into badges.",
+	},
+	{
+		Key:           "system-notifications",
+		SchemaVersion: 14,
+		Kind:          KindQueryField,
+		Title:         "App events in your inbox",
+		Description: "An expiring GitHub token, sync failing repeatedly, cleanups and new " +
+			"versions now arrive as notifications from octobud/system, so you can triage " +
+			"them like anything else. Use is:system to find or hide them.",
+	},
+	{
+		Key:           "rule-ordering",
+		SchemaVersion: 15,
+		Kind:          KindFeature,
+		Title:         "Control the order rules run in",
+		Description: "Give rules a priority so important ones run first, and mark a rule " +
+			"to stop processing so later rules skip notifications it matches. The rule " +
+			"activity log shows which rules matched and which were skipped.",
+	},
+	{
+		Key:           "branch-filter",
+		SchemaVersion: 16,
+		Kind:          KindQueryField,
+		Title:         "Filter pull requests by branch",
+		Description: "Pull request notifications now carry their head and base branch. " +
+			"Use branch:release/* to follow everything going into or out of release " +
+			"branches.",
+	},
+	{
+		Key:           "linked-accounts",
+		SchemaVersion: 17,
+		Kind:          KindFeature,
+		Title:         "Sync more than one GitHub account",
+		Description: "Link a work and a personal account in Settings to get both accounts' " +
+			"notifications in one inbox. Each notification remembers its account, so " +
+			"account:my-work-login shows just that account.",
+	},
+	{
+		Key:           "repository-default-snooze",
+		SchemaVersion: 18,
+		Kind:          KindFeature,
+		Title:         "Default snooze per repository",
+		Description: "Give a noisy repository a longer default snooze, like a week. Snoozing " +
+			"through the API without a time uses the repository's default, or your own " +
+			"default from Settings.",
+	},
+	{
+		Key:           "resurface-suppression",
+		SchemaVersion: 19,
+		Kind:          KindFeature,
+		Title:         "Keep just-archived threads archived",
+		Description: "Set a window under Settings → Notifications, like 2 hours, and new " +
+			"activity on a thread you just archived won't bring it back to the inbox " +
+			"unless you're mentioned directly. Repositories can set their own window.",
+	},
+	{
+		Key:           "body-search",
+		SchemaVersion: 20,
+		Kind:          KindQueryField,
+		Title:         "Search descriptions and comments with body:",
+		Description: "body:\"flaky test\" finds notifications whose issue or pull request " +
+			"description or latest comment mentions the words. Free text searches them too, " +
+			"so existing queries with free text may match more notifications.",
+	},
+	{
+		Key:           "awaiting-reply",
+		SchemaVersion: 21,
+		Kind:          KindQueryField,
+		Title:         "Find threads awaiting your reply with awaiting:reply",
+		Description: "Set how many days a thread can wait on your latest comment under " +
+			"Settings → Notifications, and awaiting:reply finds the ones nobody has answered. " +
+			"They can optionally come back to the inbox.",
+	},
+	{
+		Key:           "prewarm",
+		SchemaVersion: 22,
+		Kind:          KindFeature,
+		Title:         "Faster first page after launch",
+		Description: "Octobud remembers the lists and notifications you opened last and " +
+			"prepares their counts, subjects and timelines on startup, before the browser " +
+			"opens, so the first page shows up without waiting on GitHub.",
+	},
+	{
+		Key:           "pull-request-filters",
+		SchemaVersion: 23,
+		Kind:          KindQueryField,
+		Title:         "Filter pull requests by draft, label and review state",
+		Description: "draft:false, label:bug and review:pending|approved|changes_requested " +
+			"work in views and rules, so reason:review_requested draft:false review:pending " +
+			"finds ready pull requests still waiting on a review.",
+	},
+	{
+		Key:           "view-wip-limits",
+		SchemaVersion: 24,
+		Kind:          KindFeature,
+		Title:         "WIP limits for views",
+		Description: "Give a view a limit, like 10 for a review queue, and Octobud tells you " +
+			"when it holds more than that. Views can also snooze the newest overflow after " +
+			"each sync, keeping starred notifications and the ones that have waited longest.",
+	},
+	{
+		Key:           "org-offboarding",
+		SchemaVersion: 25,
+		Kind:          KindFeature,
+		Title:         "Offboard an organization you left",
+		Description: "Archive or delete everything from an organization in one step and stop " +
+			"syncing its repositories. Octobud keeps a summary of what was cleared, and you " +
+			"can rejoin the org later to sync it again.",
+	},
+	{
+		Key:           "snooze-until-updated",
+		SchemaVersion: 26,
+		Kind:          KindFeature,
+		Title:         "Snooze until a thread is updated",
+		Description: "Snooze a pull request until someone pushes or comments on it, optionally " +
+			"with a preset like next_week as the latest it can stay away. Presets sent to " +
+			"the snooze API are worked out in your time zone.",
+	},
+	{
+		Key:           "snooze-returns",
+		SchemaVersion: 27,
+		Kind:          KindFeature,
+		Title:         "Snoozed notifications come back on time",
+		Description: "Notifications return to the inbox within a minute of their snooze running " +
+			"out and show when they came back. Turn on marking them unread under Default Snooze.",
+	},
+	{
+		Key:           "notification-history",
+		SchemaVersion: 28,
+		Kind:          KindFeature,
+		Title:         "See what happened to a notification",
+		Description: "Octobud now records each notification's history: reads, archives, snoozes, " +
+			"rules that applied and syncs, and whether you, a rule or a sync did it.",
+	},
+	{
+		Key:           "bulk-undo",
+		SchemaVersion: 29,
+		Kind:          KindFeature,
+		Title:         "Undo bulk actions",
+		Description: "Bulk actions now return an undo ID. For 10 minutes afterwards, undoing one " +
+			"puts back what it changed, even when it matched hundreds of notifications by query.",
+	},
+	{
+		Key:           "scheduled-backups",
+		SchemaVersion: 30,
+		Kind:          KindFeature,
+		Title:         "Automatic database backups",
+		Description: "Octobud now backs up its database every day and keeps the last 7 copies. " +
+			"Change the schedule in backup settings, and restore a backup on the next start.",
+	},
+	{
+		Key:           "resumable-initial-sync",
+		SchemaVersion: 31,
+		Kind:          KindFeature,
+		Title:         "Initial syncs pick up where they left off",
+		Description: "If Octobud stops during your first sync, it now carries on from the oldest " +
+			"notification it reached. Setup shows how many notifications are in and how long is left.",
+	},
+	{
+		Key:           "repository-sync-mode",
+		SchemaVersion: 32,
+		Kind:          KindFeature,
+		Title:         "Choose what syncs for each repository",
+		Description: "A repository can now be synced in full, with titles only, or left out of " +
+			"sync. Notifications already synced from a left-out repository are kept.",
+	},
+	{
+		Key:           "requested-review-queries",
+		SchemaVersion: 35,
+		Kind:          KindQueryField,
+		Title:         "Filter by requested reviewer or team",
+		Description: "reviewer:octocat and team-review-requested:acme/core find pull requests still " +
+			"waiting on a review from that person or team, so a view can hold your team's review queue.",
+	},
+	{
+		Key:           "milestone-project-queries",
+		SchemaVersion: 36,
+		Kind:          KindQueryField,
+		Title:         "Filter by milestone or project",
+		Description: "milestone:v2.0 and project:\"Q3 Roadmap\" find issues and pull requests in that " +
+			"milestone or project, so a view can hold everything going into a release.",
+	},
+	{
+		Key:           "ci-state-query",
+		SchemaVersion: 37,
+		Kind:          KindQueryField,
+		Title:         "Filter pull requests by CI",
+		Description: "ci:failing finds open pull requests whose checks failed, so red pull requests " +
+			"can come first. ci:pending and ci:passing work too.",
+	},
+	{
+		Key:           "mergeable-query",
+		SchemaVersion: 38,
+		Kind:          KindQueryField,
+		Title:         "Find pull requests with conflicts",
+		Description: "mergeable:false finds open pull requests blocked on merge conflicts, " +
+			"so a view can list the ones that need a rebase.",
+	},
+	{
+		Key:           "waiting-on-query",
+		SchemaVersion: 39,
+		Kind:          KindQueryField,
+		Title:         "See whose turn it is",
+		Description: "waiting-on:me finds open issues and pull requests where the ball is in " +
+			"your court, from review requests, requested changes and who commented last. " +
+			"waiting-on:them finds the ones waiting on someone else.",
+	},
+	{
+		Key:           "priority-scoring",
+		SchemaVersion: 40,
+		Kind:          KindQueryField,
+		Title:         "Score notifications by priority",
+		Description: "Each notification now gets a priority score from its reason, repository, " +
+			"author, CI state and how long it has waited. priority:>30 filters by score, and " +
+			"the weights can be changed in your priority settings.",
+	},
+	{
+		Key:           "digests",
+		SchemaVersion: 41,
+		Kind:          KindFeature,
+		Title:         "Daily and weekly digests",
+		Description: "Octobud can now write a daily or weekly digest of the notifications matching " +
+			"a query, and email it when a mail server is set in the smtp section of config.json.",
+	},
+	{
+		Key:           "webhook-integrations",
+		SchemaVersion: 42,
+		Kind:          KindFeature,
+		Title:         "Send notifications to webhooks and Slack",
+		Description: "Rules can now send the notifications they match to your own webhooks or a " +
+			"Slack channel with the notifyWebhook action. Failed deliveries are retried, and " +
+			"each webhook keeps a delivery log.",
+	},
+	{
+		Key:           "rule-conditions",
+		SchemaVersion: 43,
+		Kind:          KindFeature,
+		Title:         "Rule conditions",
+		Description: "Rules can now have a condition, a small expression such as " +
+			"len(labels) >= 3, checked after the query for cases the query language " +
+			"can't express.",
+	},
+	{
+		Key:           "view-groups",
+		SchemaVersion: 44,
+		Kind:          KindFeature,
+		Title:         "View groups",
+		Description: "Custom views can now be organized into named groups that can be " +
+			"reordered and collapsed, to keep a long list of views tidy.",
+	},
+	{
+		Key:           "api-tokens",
+		SchemaVersion: 45,
+		Kind:          KindFeature,
+		Title:         "API tokens",
+		Description: "Scripts and command-line tools can now call the API with a personal " +
+			"token in an Authorization: Bearer header. Tokens are read-only or read-write, " +
+			"can expire, and can be revoked at any time.",
+	},
+	{
+		Key:           "shared-views",
+		SchemaVersion: 46,
+		Kind:          KindFeature,
+		Title:         "Shared views",
+		Description: "On a server with several users, views can be shared with other " +
+			"users, read-only or with permission to change the view's query.",
+	},
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/changelog/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/changelog/service.go -destination=internal/core/changelog/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangelogService is a mock of ChangelogService interface.
+type MockChangelogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangelogServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChangelogServiceMockRecorder is the mock recorder for MockChangelogService.
+type MockChangelogServiceMockRecorder struct {
+	mock *MockChangelogService
+}
+
+// NewMockChangelogService creates a new mock instance.
+func NewMockChangelogService(ctrl *gomock.Controller) *MockChangelogService {
+	mock := &MockChangelogService{ctrl: ctrl}
+	mock.recorder = &MockChangelogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangelogService) EXPECT() *MockChangelogServiceMockRecorder {
+	return m.recorder
+}
+
+// Acknowledge mocks base method.
+func (m *MockChangelogService) Acknowledge(ctx context.Context, upToID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", ctx, upToID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockChangelogServiceMockRecorder) Acknowledge(ctx, upToID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockChangelogService)(nil).Acknowledge), ctx, upToID)
+}
+
+// ListUnseen mocks base method.
+func (m *MockChangelogService) ListUnseen(ctx context.Context) ([]models.ChangelogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnseen", ctx)
+	ret0, _ := ret[0].([]models.ChangelogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnseen indicates an expected call of ListUnseen.
+func (mr *MockChangelogServiceMockRecorder) ListUnseen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnseen", reflect.TypeOf((*MockChangelogService)(nil).ListUnseen), ctx)
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package changelog records user-facing notes about schema and behavior changes when an
+// update applies new migrations.
+package changelog
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ChangelogService is the interface for the changelog service.
+type ChangelogService interface {
+	// ListUnseen returns entries the user hasn't acknowledged, oldest first.
+	ListUnseen(ctx context.Context) ([]models.ChangelogEntry, error)
+	// Acknowledge marks entries up to and including upToID as seen. An upToID of 0
+	// acknowledges every entry.
+	Acknowledge(ctx context.Context, upToID int64) (int64, error)
+}
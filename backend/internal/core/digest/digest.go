@@ -0,0 +1,258 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// Error definitions
+var (
+	ErrInvalidSettings            = errors.New("invalid digest settings")
+	ErrFailedToLoadDigestSettings = errors.New("failed to load digest settings")
+	ErrFailedToSaveDigestSettings = errors.New("failed to save digest settings")
+	ErrFailedToGenerateDigest     = errors.New("failed to generate digest")
+	ErrFailedToEmailDigest        = errors.New("failed to email digest")
+	ErrNoDigest                   = errors.New("no digest yet")
+)
+
+// GetDigestSettings returns the user's digest settings, or the defaults if none are saved
+func (s *Service) GetDigestSettings(
+	ctx context.Context,
+	userID string,
+) (*models.DigestSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	return s.loadSettings(ctx)
+}
+
+// UpdateDigestSettings validates and saves the user's digest settings
+func (s *Service) UpdateDigestSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.DigestSettings,
+) (*models.DigestSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	normalized, err := s.normalizeSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := normalized.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveDigestSettings, err)
+	}
+	_, err = s.queries.UpdateUserDigestSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveDigestSettings, err)
+	}
+	return normalized, nil
+}
+
+// Generate puts a digest together now
+func (s *Service) Generate(ctx context.Context, userID string) (Digest, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return Digest{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	digests, err := list(s.dir)
+	if err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+	return s.generate(ctx, userID, settings, previous(digests))
+}
+
+// RunScheduled generates a digest if one is due
+func (s *Service) RunScheduled(ctx context.Context, userID string) (*Digest, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, nil
+	}
+	calendar, err := s.workHours.Calendar(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	digests, err := list(s.dir)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+	since := previous(digests)
+	if !since.Before(lastSlot(settings, s.now(), calendar.Location())) {
+		return nil, nil
+	}
+
+	digest, err := s.generate(ctx, userID, settings, since)
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// Latest returns the most recent digest
+func (s *Service) Latest() (Digest, error) {
+	digests, err := list(s.dir)
+	if err != nil {
+		return Digest{}, err
+	}
+	if len(digests) == 0 {
+		return Digest{}, ErrNoDigest
+	}
+	return read(s.dir, digests[0])
+}
+
+// generate renders a digest of the notifications matching the settings' query, marking
+// those updated after since as new, writes it and emails it; callers must hold mu. A digest
+// that was written but couldn't be emailed is kept, and the error says so.
+func (s *Service) generate(
+	ctx context.Context,
+	userID string,
+	settings *models.DigestSettings,
+	since time.Time,
+) (Digest, error) {
+	calendar, err := s.workHours.Calendar(ctx, userID)
+	if err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+	result, err := s.notifications.ListNotifications(ctx, userID, models.ListOptions{
+		Query:    settings.Query,
+		PageSize: maxItems,
+	})
+	if err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+
+	now := s.now()
+	content := newContent(settings, result, since, now.In(calendar.Location()))
+	markdown, html, err := render(content)
+	if err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+	digest, err := write(s.dir, now, markdown, html)
+	if err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+	if err := prune(s.dir, maxKept); err != nil {
+		return Digest{}, errors.Join(ErrFailedToGenerateDigest, err)
+	}
+
+	if settings.EmailTo != "" && s.mailer != nil {
+		if err := s.mailer.Send(ctx, settings.EmailTo, content.subject(), markdown, html); err != nil {
+			return digest, errors.Join(ErrFailedToEmailDigest, err)
+		}
+		digest.EmailedTo = settings.EmailTo
+	}
+	return digest, nil
+}
+
+func (s *Service) loadSettings(ctx context.Context) (*models.DigestSettings, error) {
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultDigestSettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadDigestSettings, err)
+	}
+	if !user.DigestSettings.Valid {
+		return models.DefaultDigestSettings(), nil
+	}
+	settings, err := models.DigestSettingsFromJSON(user.DigestSettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadDigestSettings, err)
+	}
+	return settings, nil
+}
+
+// normalizeSettings checks the schedule, query and address, trimming the query and address
+func (s *Service) normalizeSettings(settings *models.DigestSettings) (*models.DigestSettings, error) {
+	normalized := *settings
+	normalized.Query = strings.TrimSpace(settings.Query)
+	normalized.EmailTo = strings.TrimSpace(settings.EmailTo)
+
+	switch {
+	case normalized.Frequency != models.DigestDaily && normalized.Frequency != models.DigestWeekly:
+		return nil, fmt.Errorf(
+			"%w: frequency must be %s or %s", ErrInvalidSettings, models.DigestDaily, models.DigestWeekly,
+		)
+	case normalized.Hour < 0 || normalized.Hour > 23:
+		return nil, fmt.Errorf("%w: hour must be between 0 and 23", ErrInvalidSettings)
+	case normalized.Weekday < int(time.Sunday) || normalized.Weekday > int(time.Saturday):
+		return nil, fmt.Errorf("%w: weekday must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidSettings)
+	}
+	if _, err := query.BuildQuery(normalized.Query, 1, 0); err != nil {
+		return nil, fmt.Errorf("%w: invalid query: %w", ErrInvalidSettings, err)
+	}
+	if normalized.EmailTo != "" {
+		address, err := mail.ParseAddress(normalized.EmailTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q isn't an email address", ErrInvalidSettings, normalized.EmailTo)
+		}
+		if s.mailer == nil {
+			return nil, fmt.Errorf(
+				"%w: emailing digests needs a mail server in the smtp section of config.json",
+				ErrInvalidSettings,
+			)
+		}
+		normalized.EmailTo = address.Address
+	}
+	return &normalized, nil
+}
+
+// lastSlot returns the most recent scheduled digest time at or before now, in loc
+func lastSlot(settings *models.DigestSettings, now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	slot := time.Date(local.Year(), local.Month(), local.Day(), settings.Hour, 0, 0, 0, loc)
+	step := 1
+	if settings.Frequency == models.DigestWeekly {
+		step = 7
+		slot = slot.AddDate(0, 0, -((int(local.Weekday()) - settings.Weekday + 7) % 7))
+	}
+	if slot.After(local) {
+		slot = slot.AddDate(0, 0, -step)
+	}
+	return slot
+}
+
+// previous returns when the newest of digests was generated, or the zero time if there
+// are none
+func previous(digests []Digest) time.Time {
+	if len(digests) == 0 {
+		return time.Time{}
+	}
+	return digests[0].CreatedAt
+}
@@ -0,0 +1,396 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	workhoursmocks "github.com/octobud-hq/octobud/backend/internal/core/workhours/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// fakeMailer records the emails it's asked to send
+type fakeMailer struct {
+	to, subject, text, html string
+	sent                    int
+}
+
+func (m *fakeMailer) Send(_ context.Context, to, subject, text, html string) error {
+	m.to, m.subject, m.text, m.html = to, subject, text, html
+	m.sent++
+	return nil
+}
+
+func newTestCalendar(t *testing.T) *workhours.Calendar {
+	t.Helper()
+	settings := models.DefaultWorkingHours()
+	settings.Timezone = "America/New_York"
+	calendar, err := workhours.NewCalendar(settings)
+	require.NoError(t, err)
+	return calendar
+}
+
+func digestUser(t *testing.T, settings *models.DigestSettings) db.User {
+	t.Helper()
+	data, err := settings.ToJSON()
+	require.NoError(t, err)
+	return db.User{DigestSettings: db.NullRawMessage{RawMessage: data, Valid: true}}
+}
+
+func TestLastSlot(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Wednesday morning in New York
+	wednesday := time.Date(2025, time.March, 12, 9, 30, 0, 0, newYork)
+
+	tests := []struct {
+		name     string
+		settings models.DigestSettings
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "daily after the hour is today",
+			settings: models.DigestSettings{Frequency: models.DigestDaily, Hour: 8},
+			now:      wednesday,
+			expected: time.Date(2025, time.March, 12, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "daily before the hour is yesterday",
+			settings: models.DigestSettings{Frequency: models.DigestDaily, Hour: 17},
+			now:      wednesday,
+			expected: time.Date(2025, time.March, 11, 17, 0, 0, 0, newYork),
+		},
+		{
+			name:     "weekly goes back to the weekday",
+			settings: models.DigestSettings{Frequency: models.DigestWeekly, Hour: 8, Weekday: int(time.Monday)},
+			now:      wednesday,
+			expected: time.Date(2025, time.March, 10, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "weekly on the day before the hour is last week",
+			settings: models.DigestSettings{Frequency: models.DigestWeekly, Hour: 10, Weekday: int(time.Wednesday)},
+			now:      wednesday,
+			expected: time.Date(2025, time.March, 5, 10, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// now is passed in UTC to check the slot is worked out in the given zone
+			require.True(t, tt.expected.Equal(lastSlot(&tt.settings, tt.now.UTC(), newYork)))
+		})
+	}
+}
+
+func TestService_RunScheduled(t *testing.T) {
+	const userID = "test-user-id"
+	ctx := context.Background()
+	calendar := newTestCalendar(t)
+	// Monday at 9am in New York, an hour after the default digest time
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, calendar.Location())
+
+	settings := models.DefaultDigestSettings()
+	settings.Enabled = true
+	settings.EmailTo = "me@example.com"
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	notifications := notificationmocks.NewMockNotificationService(ctrl)
+	workHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+	mailer := &fakeMailer{}
+	dir := filepath.Join(t.TempDir(), DirName)
+	service := NewService(store, notifications, workHours, dir, func() time.Time { return now }).
+		WithMailer(mailer)
+
+	store.EXPECT().GetUser(gomock.Any()).Return(digestUser(t, settings), nil).AnyTimes()
+	workHours.EXPECT().Calendar(gomock.Any(), userID).Return(calendar, nil).AnyTimes()
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), userID, models.ListOptions{Query: settings.Query, PageSize: maxItems}).
+		Return(models.ListDetailsResult{Total: 0}, nil)
+
+	digest, err := service.RunScheduled(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	require.Equal(t, "me@example.com", digest.EmailedTo)
+	require.Equal(t, 1, mailer.sent)
+	require.Equal(t, "Octobud daily digest for Monday, March 10: 0 notifications", mailer.subject)
+
+	// The digest for this morning has been generated, so nothing more is due today
+	digest, err = service.RunScheduled(ctx, userID)
+	require.NoError(t, err)
+	require.Nil(t, digest)
+
+	latest, err := service.Latest()
+	require.NoError(t, err)
+	require.Equal(t, now.UTC(), latest.CreatedAt)
+	require.Contains(t, latest.Markdown, "Nothing matches `in:inbox is:unread`.")
+	require.Contains(t, latest.HTML, "<code>in:inbox is:unread</code>")
+}
+
+func TestService_RunScheduled_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	dir := t.TempDir()
+	service := NewService(store, nil, nil, dir, time.Now)
+
+	store.EXPECT().GetUser(gomock.Any()).Return(db.User{}, sql.ErrNoRows)
+
+	digest, err := service.RunScheduled(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Nil(t, digest)
+
+	_, err = service.Latest()
+	require.ErrorIs(t, err, ErrNoDigest)
+}
+
+func TestService_Generate_Prunes(t *testing.T) {
+	const userID = "test-user-id"
+	calendar := newTestCalendar(t)
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	notifications := notificationmocks.NewMockNotificationService(ctrl)
+	workHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+	dir := t.TempDir()
+	service := NewService(store, notifications, workHours, dir, func() time.Time { return now })
+
+	for i := range maxKept {
+		_, err := write(dir, now.Add(-time.Duration(i+1)*time.Hour), "old", "")
+		require.NoError(t, err)
+	}
+
+	store.EXPECT().GetUser(gomock.Any()).Return(db.User{}, sql.ErrNoRows)
+	workHours.EXPECT().Calendar(gomock.Any(), userID).Return(calendar, nil)
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), userID, gomock.Any()).
+		Return(models.ListDetailsResult{}, nil)
+
+	digest, err := service.Generate(context.Background(), userID)
+	require.NoError(t, err)
+	require.Empty(t, digest.EmailedTo)
+
+	digests, err := list(dir)
+	require.NoError(t, err)
+	require.Len(t, digests, maxKept)
+	require.Equal(t, digest.Name, digests[0].Name)
+	_, err = os.Stat(filepath.Join(dir, digests[len(digests)-1].Name+htmlExt))
+	require.NoError(t, err)
+}
+
+func TestService_UpdateDigestSettings(t *testing.T) {
+	valid := func() *models.DigestSettings {
+		settings := models.DefaultDigestSettings()
+		settings.Enabled = true
+		return settings
+	}
+
+	tests := []struct {
+		name      string
+		settings  func() *models.DigestSettings
+		withMail  bool
+		expectErr string
+	}{
+		{
+			name:     "defaults",
+			settings: valid,
+		},
+		{
+			name: "weekly with an email",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.Frequency = models.DigestWeekly
+				s.EmailTo = " Me <me@example.com> "
+				return s
+			},
+			withMail: true,
+		},
+		{
+			name: "unknown frequency",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.Frequency = "hourly"
+				return s
+			},
+			expectErr: "frequency must be daily or weekly",
+		},
+		{
+			name: "hour out of range",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.Hour = 24
+				return s
+			},
+			expectErr: "hour must be between 0 and 23",
+		},
+		{
+			name: "invalid query",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.Query = "ci:red"
+				return s
+			},
+			expectErr: "invalid query",
+		},
+		{
+			name: "email without a mail server",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.EmailTo = "me@example.com"
+				return s
+			},
+			expectErr: "needs a mail server",
+		},
+		{
+			name: "bad email",
+			settings: func() *models.DigestSettings {
+				s := valid()
+				s.EmailTo = "me"
+				return s
+			},
+			withMail:  true,
+			expectErr: "isn't an email address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockStore(ctrl)
+			service := NewService(store, nil, nil, t.TempDir(), time.Now)
+			if tt.withMail {
+				service.WithMailer(&fakeMailer{})
+			}
+
+			if tt.expectErr == "" {
+				store.EXPECT().UpdateUserDigestSettings(gomock.Any(), gomock.Any()).Return(db.User{}, nil)
+			}
+
+			saved, err := service.UpdateDigestSettings(context.Background(), "test-user-id", tt.settings())
+			if tt.expectErr != "" {
+				require.ErrorIs(t, err, ErrInvalidSettings)
+				require.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.withMail {
+				require.Equal(t, "me@example.com", saved.EmailTo)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	since := time.Date(2025, time.March, 9, 8, 0, 0, 0, time.UTC)
+	now := time.Date(2025, time.March, 10, 8, 0, 0, 0, time.UTC)
+	repoURL := "https://github.com/cli/cli"
+	number := int64(12)
+	reason := "review_requested"
+
+	result := models.ListDetailsResult{
+		Total: 3,
+		Notifications: []models.Notification{
+			{
+				SubjectTitle:      "Fix [flaky] *tests*",
+				SubjectType:       "PullRequest",
+				SubjectNumber:     &number,
+				Reason:            &reason,
+				EffectiveSortDate: now.Add(-time.Hour),
+				Repository:        &models.Repository{FullName: "cli/cli", HTMLURL: &repoURL},
+			},
+			{
+				SubjectTitle:      "v2.0.0",
+				SubjectType:       "Release",
+				EffectiveSortDate: since.Add(-time.Hour),
+				Repository:        &models.Repository{FullName: "cli/cli", HTMLURL: &repoURL},
+			},
+		},
+	}
+	settings := models.DefaultDigestSettings()
+	settings.Frequency = models.DigestWeekly
+
+	c := newContent(settings, result, since, now)
+	require.Equal(t, int64(1), c.More)
+	require.Equal(t, 1, c.New)
+
+	markdown, html, err := render(c)
+	require.NoError(t, err)
+	require.Equal(t, `# Weekly digest for Monday, March 10
+
+3 notifications match `+"`in:inbox is:unread`"+`, 1 new since the last digest.
+
+## cli/cli
+
+- [Fix \[flaky\] \*tests\*](https://github.com/cli/cli/pull/12) · Pull request #12 · review requested · **new**
+- [v2.0.0](https://github.com/cli/cli) · Release
+
+…and 1 more.
+`, markdown)
+	require.Contains(t, html, `<a href="https://github.com/cli/cli/pull/12">Fix [flaky] *tests*</a>`)
+	require.Contains(t, html, "3 notifications match <code>in:inbox is:unread</code>, 1 new since the last digest.")
+}
+
+func TestBuildMessage(t *testing.T) {
+	from := &mail.Address{Name: "Octobud", Address: "octobud@example.com"}
+	to := &mail.Address{Address: "me@example.com"}
+	date := time.Date(2025, time.March, 10, 8, 0, 0, 0, time.UTC)
+
+	raw, err := buildMessage(from, to, "Octobud daily digest — 3 notifications", "# Digest", "<h1>Digest</h1>", date)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Octobud daily digest — 3 notifications", subject)
+	require.Equal(t, `"Octobud" <octobud@example.com>`, msg.Header.Get("From"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+	}
+	require.Equal(t, []string{"# Digest", "<h1>Digest</h1>"}, bodies)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DirName is the directory in a workspace's data directory that holds its digests
+const DirName = "digests"
+
+// Most digests kept; older ones are deleted after each new one
+const maxKept = 30
+
+// Digests are named for when they were generated, e.g. digest-20250615T080000Z, and kept
+// as a Markdown and an HTML file with that name
+const (
+	filePrefix  = "digest-"
+	markdownExt = ".md"
+	htmlExt     = ".html"
+	timeLayout  = "20060102T150405Z"
+	filePerm    = 0o600
+	dirPerm     = 0o700
+)
+
+// Digest is a generated digest
+type Digest struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Markdown  string    `json:"markdown"`
+	HTML      string    `json:"html"`
+	// EmailedTo is the address the digest was just emailed to. It's only set on the
+	// digest returned when it's generated.
+	EmailedTo string `json:"emailedTo,omitempty"`
+}
+
+// write saves a digest generated at into dir
+func write(dir string, at time.Time, markdown, html string) (Digest, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return Digest{}, err
+	}
+	at = at.UTC().Truncate(time.Second)
+	name := filePrefix + at.Format(timeLayout)
+	if err := os.WriteFile(filepath.Join(dir, name+markdownExt), []byte(markdown), filePerm); err != nil {
+		return Digest{}, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+htmlExt), []byte(html), filePerm); err != nil {
+		return Digest{}, err
+	}
+	return Digest{Name: name, CreatedAt: at, Markdown: markdown, HTML: html}, nil
+}
+
+// list returns the digests in dir without their content, newest first. Other files are
+// ignored, and a missing dir means there are no digests yet.
+func list(dir string) ([]Digest, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var digests []Digest
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), markdownExt)
+		if entry.IsDir() || !ok || !strings.HasPrefix(name, filePrefix) {
+			continue
+		}
+		createdAt, err := time.Parse(timeLayout, strings.TrimPrefix(name, filePrefix))
+		if err != nil {
+			continue
+		}
+		digests = append(digests, Digest{Name: name, CreatedAt: createdAt})
+	}
+	slices.SortFunc(digests, func(a, b Digest) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return digests, nil
+}
+
+// read fills in the content of a listed digest. A missing HTML file leaves HTML empty.
+func read(dir string, digest Digest) (Digest, error) {
+	markdown, err := os.ReadFile(filepath.Join(dir, digest.Name+markdownExt))
+	if err != nil {
+		return Digest{}, err
+	}
+	digest.Markdown = string(markdown)
+	html, err := os.ReadFile(filepath.Join(dir, digest.Name+htmlExt))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Digest{}, err
+	}
+	digest.HTML = string(html)
+	return digest, nil
+}
+
+// prune deletes the oldest digests in dir beyond keep
+func prune(dir string, keep int) error {
+	digests, err := list(dir)
+	if err != nil {
+		return err
+	}
+	for _, old := range digests[min(keep, len(digests)):] {
+		for _, ext := range []string{markdownExt, htmlExt} {
+			err := os.Remove(filepath.Join(dir, old.Name+ext))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
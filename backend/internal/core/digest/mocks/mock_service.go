@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/digest/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/digest/service.go -destination=internal/core/digest/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	digest "github.com/octobud-hq/octobud/backend/internal/core/digest"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDigestService is a mock of DigestService interface.
+type MockDigestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDigestServiceMockRecorder is the mock recorder for MockDigestService.
+type MockDigestServiceMockRecorder struct {
+	mock *MockDigestService
+}
+
+// NewMockDigestService creates a new mock instance.
+func NewMockDigestService(ctrl *gomock.Controller) *MockDigestService {
+	mock := &MockDigestService{ctrl: ctrl}
+	mock.recorder = &MockDigestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestService) EXPECT() *MockDigestServiceMockRecorder {
+	return m.recorder
+}
+
+// CanEmail mocks base method.
+func (m *MockDigestService) CanEmail() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanEmail")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanEmail indicates an expected call of CanEmail.
+func (mr *MockDigestServiceMockRecorder) CanEmail() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanEmail", reflect.TypeOf((*MockDigestService)(nil).CanEmail))
+}
+
+// Generate mocks base method.
+func (m *MockDigestService) Generate(ctx context.Context, userID string) (digest.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx, userID)
+	ret0, _ := ret[0].(digest.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockDigestServiceMockRecorder) Generate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockDigestService)(nil).Generate), ctx, userID)
+}
+
+// GetDigestSettings mocks base method.
+func (m *MockDigestService) GetDigestSettings(ctx context.Context, userID string) (*models.DigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestSettings", ctx, userID)
+	ret0, _ := ret[0].(*models.DigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestSettings indicates an expected call of GetDigestSettings.
+func (mr *MockDigestServiceMockRecorder) GetDigestSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestSettings", reflect.TypeOf((*MockDigestService)(nil).GetDigestSettings), ctx, userID)
+}
+
+// Latest mocks base method.
+func (m *MockDigestService) Latest() (digest.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest")
+	ret0, _ := ret[0].(digest.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockDigestServiceMockRecorder) Latest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockDigestService)(nil).Latest))
+}
+
+// RunScheduled mocks base method.
+func (m *MockDigestService) RunScheduled(ctx context.Context, userID string) (*digest.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunScheduled", ctx, userID)
+	ret0, _ := ret[0].(*digest.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunScheduled indicates an expected call of RunScheduled.
+func (mr *MockDigestServiceMockRecorder) RunScheduled(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduled", reflect.TypeOf((*MockDigestService)(nil).RunScheduled), ctx, userID)
+}
+
+// UpdateDigestSettings mocks base method.
+func (m *MockDigestService) UpdateDigestSettings(ctx context.Context, userID string, settings *models.DigestSettings) (*models.DigestSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDigestSettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.DigestSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDigestSettings indicates an expected call of UpdateDigestSettings.
+func (mr *MockDigestServiceMockRecorder) UpdateDigestSettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDigestSettings", reflect.TypeOf((*MockDigestService)(nil).UpdateDigestSettings), ctx, userID, settings)
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Most notifications a digest lists; the rest are only counted
+const maxItems = 100
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var markdownTemplate = texttemplate.Must(texttemplate.New("digest.md.tmpl").
+	Funcs(texttemplate.FuncMap{"md": markdownEscaper.Replace}).
+	ParseFS(templatesFS, "templates/digest.md.tmpl"))
+
+var htmlTemplate = template.Must(template.ParseFS(templatesFS, "templates/digest.html.tmpl"))
+
+// content is what a digest says, shared by its Markdown and HTML renderings
+type content struct {
+	Title  string // e.g. "Daily digest for Monday, June 16"
+	Query  string // Shown as "your inbox" when empty
+	Total  int64
+	More   int64 // Matching notifications beyond those listed
+	New    int   // Listed notifications updated since the previous digest
+	Groups []group
+}
+
+// group is the listed notifications from one repository
+type group struct {
+	Repository string
+	Items      []item
+}
+
+type item struct {
+	Title  string
+	URL    string // Empty when the subject has no page on GitHub
+	Detail string // e.g. "Pull request #12 · review requested"
+	New    bool
+}
+
+// newContent lists result's notifications by repository, in list order, marking those
+// updated after since as new
+func newContent(
+	settings *models.DigestSettings,
+	result models.ListDetailsResult,
+	since time.Time,
+	now time.Time,
+) content {
+	frequency := "Daily"
+	if settings.Frequency == models.DigestWeekly {
+		frequency = "Weekly"
+	}
+	c := content{
+		Title: fmt.Sprintf("%s digest for %s", frequency, now.Format("Monday, January 2")),
+		Query: settings.Query,
+		Total: result.Total,
+		More:  max(result.Total-int64(len(result.Notifications)), 0),
+	}
+
+	positions := map[string]int{}
+	for _, n := range result.Notifications {
+		repository := "Other"
+		if n.Repository != nil {
+			repository = n.Repository.FullName
+		}
+		position, ok := positions[repository]
+		if !ok {
+			position = len(c.Groups)
+			positions[repository] = position
+			c.Groups = append(c.Groups, group{Repository: repository})
+		}
+
+		isNew := !since.IsZero() && n.EffectiveSortDate.After(since)
+		if isNew {
+			c.New++
+		}
+		c.Groups[position].Items = append(c.Groups[position].Items, item{
+			Title:  n.SubjectTitle,
+			URL:    subjectLink(n),
+			Detail: detail(n),
+			New:    isNew,
+		})
+	}
+	return c
+}
+
+// subject is the email subject line
+func (c content) subject() string {
+	noun := "notifications"
+	if c.Total == 1 {
+		noun = "notification"
+	}
+	return fmt.Sprintf("Octobud %s: %d %s", strings.ToLower(c.Title[:1])+c.Title[1:], c.Total, noun)
+}
+
+// summary is the sentence under the title
+func (c content) summary() string {
+	query := "your inbox"
+	if c.Query != "" {
+		query = "`" + c.Query + "`"
+	}
+	if c.Total == 0 {
+		return fmt.Sprintf("Nothing matches %s.", query)
+	}
+	noun := "notifications match"
+	if c.Total == 1 {
+		noun = "notification matches"
+	}
+	summary := fmt.Sprintf("%d %s %s", c.Total, noun, query)
+	if c.New > 0 {
+		summary += fmt.Sprintf(", %d new since the last digest", c.New)
+	}
+	return summary + "."
+}
+
+// render returns the digest as Markdown and as an HTML page
+func render(c content) (string, string, error) {
+	var markdown, html bytes.Buffer
+	data := struct {
+		content
+		Summary string
+	}{c, c.summary()}
+	if err := markdownTemplate.Execute(&markdown, data); err != nil {
+		return "", "", err
+	}
+	if err := htmlTemplate.Execute(&html, c); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(markdown.String()) + "\n", html.String(), nil
+}
+
+// detail describes a notification's subject and why it was sent
+func detail(n models.Notification) string {
+	kind := subjectTypeLabel(n.SubjectType)
+	if n.SubjectNumber != nil {
+		kind += fmt.Sprintf(" #%d", *n.SubjectNumber)
+	}
+	if n.Reason != nil && *n.Reason != "" {
+		kind += " · " + strings.ReplaceAll(*n.Reason, "_", " ")
+	}
+	return kind
+}
+
+func subjectTypeLabel(subjectType string) string {
+	switch subjectType {
+	case "PullRequest":
+		return "Pull request"
+	case "CheckSuite":
+		return "Check suite"
+	case "RepositoryVulnerabilityAlert":
+		return "Security alert"
+	default:
+		return subjectType
+	}
+}
+
+// subjectLink links to the subject on GitHub when it has a number, otherwise to the repository
+func subjectLink(n models.Notification) string {
+	if n.Repository == nil || n.Repository.HTMLURL == nil || *n.Repository.HTMLURL == "" {
+		return ""
+	}
+	base := strings.TrimSuffix(*n.Repository.HTMLURL, "/")
+	if n.SubjectNumber == nil {
+		return base
+	}
+	path := "issues"
+	switch n.SubjectType {
+	case "PullRequest":
+		path = "pull"
+	case "Discussion":
+		path = "discussions"
+	}
+	return fmt.Sprintf("%s/%s/%d", base, path, *n.SubjectNumber)
+}
+
+// markdownEscaper stops titles from being read as links or emphasis
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`,
+)
@@ -0,0 +1,89 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package digest puts together periodic summaries of the notifications matching a query,
+// keeps them as Markdown and HTML files, and emails them.
+package digest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// DigestService is the interface for notification digests.
+type DigestService interface {
+	GetDigestSettings(ctx context.Context, userID string) (*models.DigestSettings, error)
+	UpdateDigestSettings(
+		ctx context.Context,
+		userID string,
+		settings *models.DigestSettings,
+	) (*models.DigestSettings, error)
+	// Generate puts a digest together now and keeps it, then emails it when the settings
+	// have an address and a mail server is configured.
+	Generate(ctx context.Context, userID string) (Digest, error)
+	// RunScheduled generates a digest when digests are on and the latest scheduled time
+	// has passed since the last one. It returns nil when no digest was due.
+	RunScheduled(ctx context.Context, userID string) (*Digest, error)
+	// Latest returns the most recent digest, or ErrNoDigest if there isn't one yet.
+	Latest() (Digest, error)
+	// CanEmail reports whether a mail server is configured to email digests through.
+	CanEmail() bool
+}
+
+// Service provides notification digests
+type Service struct {
+	queries       db.Store
+	notifications notification.NotificationService
+	workHours     workhours.WorkingHoursService
+	mailer        Mailer // nil when no mail server is configured
+	dir           string
+	now           func() time.Time
+	// Serializes generating and pruning so they don't race on the same files
+	mu sync.Mutex
+}
+
+// NewService constructs a Service that keeps digests in dir
+func NewService(
+	queries db.Store,
+	notifications notification.NotificationService,
+	workHours workhours.WorkingHoursService,
+	dir string,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		queries:       queries,
+		notifications: notifications,
+		workHours:     workHours,
+		dir:           dir,
+		now:           now,
+	}
+}
+
+// WithMailer sets the mailer digests are emailed with
+func (s *Service) WithMailer(mailer Mailer) *Service {
+	s.mailer = mailer
+	return s
+}
+
+// CanEmail reports whether a mailer is set
+func (s *Service) CanEmail() bool {
+	return s.mailer != nil
+}
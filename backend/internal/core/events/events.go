@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package events
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// EventBuffer is how many events a subscriber can fall behind before events are
+// dropped and it's sent a reset instead
+const EventBuffer = 64
+
+// Subscriber receives a user's events until it's removed
+type Subscriber struct {
+	UserID string
+
+	events chan models.Event
+
+	mu     sync.Mutex
+	lagged bool
+	closed bool
+}
+
+// Events delivers the subscriber's events until it's removed
+func (sub *Subscriber) Events() <-chan models.Event {
+	return sub.events
+}
+
+// Subscribe starts delivering the user's events to a new subscriber
+func (s *Service) Subscribe(userID string) *Subscriber {
+	sub := &Subscriber{
+		UserID: userID,
+		events: make(chan models.Event, EventBuffer),
+	}
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	return sub
+}
+
+// Unsubscribe removes a subscriber and closes its events
+func (s *Service) Unsubscribe(sub *Subscriber) {
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if !sub.closed {
+		sub.closed = true
+		close(sub.events)
+	}
+}
+
+// Publish sends an event to each of the user's subscribers. A missing timestamp is
+// filled in with the current time.
+func (s *Service) Publish(userID string, event models.Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = s.now()
+	}
+	for _, sub := range s.userSubscribers(userID) {
+		sub.mu.Lock()
+		s.send(sub, event)
+		sub.mu.Unlock()
+	}
+}
+
+// userSubscribers returns the user's subscribers
+func (s *Service) userSubscribers(userID string) []*Subscriber {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subs := make([]*Subscriber, 0, len(s.subscribers))
+	for sub := range s.subscribers {
+		if sub.UserID == userID {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// send delivers an event without blocking. When the subscriber has fallen behind the
+// event is dropped, and a reset is sent once there's room so the client reloads.
+// The caller must hold sub.mu.
+func (s *Service) send(sub *Subscriber, event models.Event) {
+	if sub.closed {
+		return
+	}
+	if sub.lagged {
+		select {
+		case sub.events <- models.Event{Type: models.EventReset, Timestamp: s.now()}:
+			sub.lagged = false
+		default:
+			return
+		}
+	}
+	select {
+	case sub.events <- event:
+	default:
+		sub.lagged = true
+		s.logger.Warn("event subscriber fell behind, dropping events",
+			zap.String("userID", sub.UserID))
+	}
+}
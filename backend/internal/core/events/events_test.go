@@ -0,0 +1,98 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func setupService() *Service {
+	return NewService(zap.NewNop(), func() time.Time { return testNow })
+}
+
+func receive(t *testing.T, sub *Subscriber) []models.Event {
+	t.Helper()
+	var events []models.Event
+	for {
+		select {
+		case event := <-sub.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestService_Publish(t *testing.T) {
+	svc := setupService()
+	sub := svc.Subscribe("user-1")
+	other := svc.Subscribe("user-2")
+
+	svc.Publish("user-1", models.Event{Type: models.EventNotificationUpserted, GithubID: "n1"})
+	svc.Publish("user-1", models.Event{
+		Type: models.EventBulkCompleted,
+		Bulk: &models.BulkResult{Operation: "archive", Count: 3},
+	})
+
+	require.Equal(t, []models.Event{
+		{Type: models.EventNotificationUpserted, GithubID: "n1", Timestamp: testNow},
+		{
+			Type:      models.EventBulkCompleted,
+			Bulk:      &models.BulkResult{Operation: "archive", Count: 3},
+			Timestamp: testNow,
+		},
+	}, receive(t, sub))
+	// Other users' subscribers don't see the events
+	require.Empty(t, receive(t, other))
+}
+
+func TestService_SendsResetWhenSubscriberFallsBehind(t *testing.T) {
+	svc := setupService()
+	sub := svc.Subscribe("user-1")
+
+	// One more event than fits, so the last is dropped
+	for range EventBuffer + 1 {
+		svc.Publish("user-1", models.Event{Type: models.EventNotificationUpserted, GithubID: "n1"})
+	}
+	require.Len(t, receive(t, sub), EventBuffer)
+
+	// The next event is preceded by a reset
+	svc.Publish("user-1", models.Event{Type: models.EventNotificationUpserted, GithubID: "n2"})
+	require.Equal(t, []models.Event{
+		{Type: models.EventReset, Timestamp: testNow},
+		{Type: models.EventNotificationUpserted, GithubID: "n2", Timestamp: testNow},
+	}, receive(t, sub))
+}
+
+func TestService_Unsubscribe(t *testing.T) {
+	svc := setupService()
+	sub := svc.Subscribe("user-1")
+
+	svc.Unsubscribe(sub)
+	// Removed subscribers aren't sent events
+	svc.Publish("user-1", models.Event{Type: models.EventNotificationUpserted, GithubID: "n1"})
+
+	_, open := <-sub.Events()
+	require.False(t, open)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/events/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/events/service.go -destination=internal/core/events/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	events "github.com/octobud-hq/octobud/backend/internal/core/events"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventBus is a mock of EventBus interface.
+type MockEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBusMockRecorder
+	isgomock struct{}
+}
+
+// MockEventBusMockRecorder is the mock recorder for MockEventBus.
+type MockEventBusMockRecorder struct {
+	mock *MockEventBus
+}
+
+// NewMockEventBus creates a new mock instance.
+func NewMockEventBus(ctrl *gomock.Controller) *MockEventBus {
+	mock := &MockEventBus{ctrl: ctrl}
+	mock.recorder = &MockEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBus) EXPECT() *MockEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventBus) Publish(userID string, event models.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", userID, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventBusMockRecorder) Publish(userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventBus)(nil).Publish), userID, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockEventBus) Subscribe(userID string) *events.Subscriber {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID)
+	ret0, _ := ret[0].(*events.Subscriber)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventBusMockRecorder) Subscribe(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventBus)(nil).Subscribe), userID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockEventBus) Unsubscribe(sub *events.Subscriber) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockEventBusMockRecorder) Unsubscribe(sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockEventBus)(nil).Unsubscribe), sub)
+}
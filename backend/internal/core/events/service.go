@@ -0,0 +1,55 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package events is an in-process bus that fans inbox changes out to the clients
+// listening for live updates.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// EventBus is the interface for publishing and subscribing to inbox events.
+type EventBus interface {
+	// Subscribe starts delivering the user's events to a new subscriber.
+	Subscribe(userID string) *Subscriber
+	// Unsubscribe removes a subscriber and closes its events.
+	Unsubscribe(sub *Subscriber)
+	// Publish sends an event to each of the user's subscribers without blocking.
+	Publish(userID string, event models.Event)
+}
+
+// Service fans events out to subscribers
+type Service struct {
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu          sync.RWMutex
+	subscribers map[*Subscriber]struct{}
+}
+
+// NewService constructs a Service
+func NewService(logger *zap.Logger, now func() time.Time) *Service {
+	return &Service{
+		logger:      logger,
+		now:         now,
+		subscribers: make(map[*Subscriber]struct{}),
+	}
+}
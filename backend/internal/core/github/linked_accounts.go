@@ -0,0 +1,218 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/xcrypto"
+)
+
+// Linked account errors
+var (
+	ErrNoPrimaryAccount    = errors.New("connect a primary GitHub account first")
+	ErrAccountIsPrimary    = errors.New("token belongs to the primary GitHub account")
+	ErrAccountNotLinked    = errors.New("GitHub account is not linked")
+	ErrLinkedTokenNotFound = errors.New("no token stored for linked GitHub account")
+)
+
+// LinkAccount validates a token for an additional GitHub account and links the account,
+// so its notifications are synced alongside the primary account's. Linking an account
+// again replaces its token.
+func (m *TokenManager) LinkAccount(ctx context.Context, token string) (db.LinkedAccount, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return db.LinkedAccount{}, fmt.Errorf("token cannot be empty")
+	}
+
+	primaryUserID, err := m.primaryUserID(ctx)
+	if err != nil {
+		return db.LinkedAccount{}, err
+	}
+
+	githubUserID, login, err := m.validateAndGetUser(ctx, token)
+	if err != nil {
+		return db.LinkedAccount{}, fmt.Errorf("token validation failed: %w", err)
+	}
+	if githubUserID == primaryUserID {
+		return db.LinkedAccount{}, ErrAccountIsPrimary
+	}
+
+	// Stored like the primary token: in the keychain on macOS, encrypted in SQLite otherwise
+	var tokenEncrypted sql.NullString
+	if m.keychain != nil && runtime.GOOS == darwinOS {
+		account := xcrypto.GetKeychainAccount(login)
+		if storeErr := m.keychain.StoreToken("io.octobud", account, token); storeErr != nil {
+			return db.LinkedAccount{}, fmt.Errorf("failed to store token in keychain: %w", storeErr)
+		}
+	} else {
+		encrypted, encryptErr := m.encryptor.Encrypt(token)
+		if encryptErr != nil {
+			return db.LinkedAccount{}, fmt.Errorf("failed to encrypt token: %w", encryptErr)
+		}
+		tokenEncrypted = sql.NullString{String: encrypted, Valid: true}
+	}
+
+	linked, err := m.store.UpsertLinkedAccount(ctx, primaryUserID, db.UpsertLinkedAccountParams{
+		GithubUserID:   githubUserID,
+		Login:          login,
+		TokenEncrypted: tokenEncrypted,
+	})
+	if err != nil {
+		return db.LinkedAccount{}, fmt.Errorf("failed to link account: %w", err)
+	}
+
+	client := github.NewClient()
+	if err := client.SetToken(ctx, token); err != nil {
+		return db.LinkedAccount{}, fmt.Errorf("failed to set token on GitHub client: %w", err)
+	}
+	m.mu.Lock()
+	m.accountClients[strings.ToLower(login)] = client
+	m.mu.Unlock()
+
+	return linked, nil
+}
+
+// UnlinkAccount removes a linked GitHub account, its stored token and the notifications
+// synced for it.
+func (m *TokenManager) UnlinkAccount(ctx context.Context, login string) error {
+	primaryUserID, err := m.primaryUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := m.store.DeleteLinkedAccount(ctx, primaryUserID, login)
+	if err != nil {
+		return fmt.Errorf("failed to unlink account: %w", err)
+	}
+	if deleted == 0 {
+		return ErrAccountNotLinked
+	}
+
+	m.mu.Lock()
+	delete(m.accountClients, strings.ToLower(login))
+	m.mu.Unlock()
+
+	if m.keychain != nil && runtime.GOOS == darwinOS {
+		account := xcrypto.GetKeychainAccount(login)
+		if deleteErr := m.keychain.DeleteToken("io.octobud", account); deleteErr != nil {
+			// Log warning but don't fail - the account is already unlinked
+			m.logger.Warn("failed to delete linked account token from keychain",
+				zap.String("login", login),
+				zap.Error(deleteErr),
+			)
+		}
+	}
+
+	if _, err := m.store.DeleteNotificationsByAccount(ctx, primaryUserID, login); err != nil {
+		return fmt.Errorf("failed to delete linked account notifications: %w", err)
+	}
+	return nil
+}
+
+// AccountClient returns a GitHub client authenticated as a linked account.
+func (m *TokenManager) AccountClient(
+	ctx context.Context,
+	login string,
+) (githubinterfaces.Client, error) {
+	m.mu.RLock()
+	client, ok := m.accountClients[strings.ToLower(login)]
+	m.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	primaryUserID, err := m.primaryUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := m.store.ListLinkedAccounts(ctx, primaryUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked accounts: %w", err)
+	}
+	var linked *db.LinkedAccount
+	for i := range accounts {
+		if strings.EqualFold(accounts[i].Login, login) {
+			linked = &accounts[i]
+			break
+		}
+	}
+	if linked == nil {
+		return nil, ErrAccountNotLinked
+	}
+
+	token, err := m.linkedAccountToken(*linked)
+	if err != nil {
+		return nil, err
+	}
+
+	client = github.NewClient()
+	if err := client.SetToken(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to set token on GitHub client: %w", err)
+	}
+	m.mu.Lock()
+	m.accountClients[strings.ToLower(login)] = client
+	m.mu.Unlock()
+
+	return client, nil
+}
+
+// primaryUserID returns the GitHub user ID of the primary account, which linked accounts
+// are stored under
+func (m *TokenManager) primaryUserID(ctx context.Context) (string, error) {
+	user, err := m.store.GetUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.GithubUserID.Valid || user.GithubUserID.String == "" {
+		return "", ErrNoPrimaryAccount
+	}
+	return user.GithubUserID.String, nil
+}
+
+// linkedAccountToken loads a linked account's token from the keychain on macOS, falling
+// back to the encrypted copy in SQLite
+func (m *TokenManager) linkedAccountToken(linked db.LinkedAccount) (string, error) {
+	if m.keychain != nil && runtime.GOOS == darwinOS {
+		account := xcrypto.GetKeychainAccount(linked.Login)
+		if token, err := m.keychain.GetToken("io.octobud", account); err == nil && token != "" {
+			return token, nil
+		}
+	}
+
+	if !linked.TokenEncrypted.Valid || linked.TokenEncrypted.String == "" {
+		return "", ErrLinkedTokenNotFound
+	}
+	token, err := m.encryptor.Decrypt(linked.TokenEncrypted.String)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+	if token == "" {
+		return "", ErrLinkedTokenNotFound
+	}
+	return token, nil
+}
@@ -79,6 +79,9 @@ type TokenManager struct {
 	currentSource  TokenSource
 	githubUsername string
 	githubUserID   string
+
+	// Clients for linked accounts, keyed by lowercased login and created on first use
+	accountClients map[string]githubinterfaces.Client
 }
 
 // NewTokenManager creates a new TokenManager
@@ -100,7 +103,8 @@ func NewTokenManager(
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		currentSource: TokenSourceNone,
+		currentSource:  TokenSourceNone,
+		accountClients: map[string]githubinterfaces.Client{},
 	}
 }
 
@@ -0,0 +1,263 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package githubsettings suggests views and rules that mirror the user's GitHub
+// notification settings. GitHub's API only exposes whether a repository is watched,
+// not watched or ignored; custom routing and which events a custom watch covers aren't
+// available, so those are inferred from the notifications that arrived.
+package githubsettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrSuggestionNotFound       = errors.New("suggestion not found")
+	ErrFailedToLoadRepositories = errors.New("failed to load repositories")
+	ErrFailedToFetchSettings    = errors.New("failed to read notification settings from GitHub")
+	ErrFailedToCheckAdopted     = errors.New("failed to check adopted suggestions")
+	ErrFailedToAdopt            = errors.New("failed to adopt suggestion")
+)
+
+// Suggestion IDs. Watching suggestions are per owner: "watching:<owner>".
+const (
+	releasesSuggestionID       = "releases"
+	ignoredSuggestionID        = "ignored"
+	watchingSuggestionIDPrefix = "watching:"
+)
+
+// MinWatchedPerOwner is how many watched repositories an owner needs before a view of
+// them is suggested
+const MinWatchedPerOwner = 2
+
+// releaseSubjectType is the subject type of release notifications
+const releaseSubjectType = "Release"
+
+// Suggestions suggests views and rules mirroring the user's GitHub watch settings:
+//   - a Releases view of repositories that aren't watched but only ever sent release
+//     notifications, which is what watching for releases only looks like
+//   - a view per owner of the repositories watched for all activity
+//   - a rule muting repositories ignored on GitHub
+func (s *Service) Suggestions(
+	ctx context.Context,
+	userID string,
+) ([]models.SettingsSuggestion, error) {
+	repositories, err := s.queries.ListRepositories(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadRepositories, err)
+	}
+	counts, err := s.queries.ListRepositorySubjectTypeCounts(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadRepositories, err)
+	}
+
+	// Only repositories with notifications are looked up; the rest say nothing about how
+	// the user is notified
+	var fullNames []string
+	typeCounts := make(map[string]map[string]int64)
+	for _, repository := range repositories {
+		if len(counts[repository.ID]) == 0 {
+			continue
+		}
+		fullNames = append(fullNames, repository.FullName)
+		typeCounts[repository.FullName] = counts[repository.ID]
+	}
+
+	subscriptions, err := s.fetchSubscriptions(ctx, fullNames)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToFetchSettings, err)
+	}
+
+	var releasesOnly, ignored []string
+	watchedByOwner := make(map[string][]string)
+	for _, subscription := range subscriptions {
+		fullName := subscription.FullName
+		switch subscription.Subscription {
+		case types.SubscriptionSubscribed:
+			owner, _, _ := strings.Cut(fullName, "/")
+			watchedByOwner[owner] = append(watchedByOwner[owner], fullName)
+		case types.SubscriptionIgnored:
+			ignored = append(ignored, fullName)
+		case types.SubscriptionUnsubscribed:
+			if onlyReleases(typeCounts[fullName]) {
+				releasesOnly = append(releasesOnly, fullName)
+			}
+		}
+	}
+
+	var suggestions []models.SettingsSuggestion
+	if len(releasesOnly) > 0 {
+		suggestions = append(suggestions, models.SettingsSuggestion{
+			ID:           releasesSuggestionID,
+			Kind:         models.SettingsSuggestionView,
+			Name:         "Releases",
+			Reason:       "You only get release notifications from these repositories on GitHub",
+			Query:        "type:release " + repoFilter(releasesOnly),
+			Repositories: releasesOnly,
+		})
+	}
+
+	owners := make([]string, 0, len(watchedByOwner))
+	for owner, watched := range watchedByOwner {
+		if len(watched) >= MinWatchedPerOwner {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		watched := watchedByOwner[owner]
+		suggestions = append(suggestions, models.SettingsSuggestion{
+			ID:           watchingSuggestionIDPrefix + owner,
+			Kind:         models.SettingsSuggestionView,
+			Name:         "Watching " + owner,
+			Reason:       fmt.Sprintf("You watch %d of %s's repositories on GitHub", len(watched), owner),
+			Query:        repoFilter(watched),
+			Repositories: watched,
+		})
+	}
+
+	if len(ignored) > 0 {
+		suggestions = append(suggestions, models.SettingsSuggestion{
+			ID:           ignoredSuggestionID,
+			Kind:         models.SettingsSuggestionRule,
+			Name:         "Ignored on GitHub",
+			Reason:       "You ignore these repositories on GitHub",
+			Query:        repoFilter(ignored),
+			Actions:      &models.RuleActions{Mute: true},
+			Repositories: ignored,
+		})
+	}
+
+	return s.markAdopted(ctx, userID, suggestions)
+}
+
+// Adopt creates the view or rule of a suggestion. Suggestions are worked out again from
+// GitHub, so a suggestion that no longer applies can't be adopted.
+func (s *Service) Adopt(
+	ctx context.Context,
+	userID, suggestionID string,
+) (models.AdoptedSuggestion, error) {
+	suggestions, err := s.Suggestions(ctx, userID)
+	if err != nil {
+		return models.AdoptedSuggestion{}, err
+	}
+
+	for _, suggestion := range suggestions {
+		if suggestion.ID != suggestionID {
+			continue
+		}
+
+		description := suggestion.Reason
+		if suggestion.Kind == models.SettingsSuggestionRule {
+			rule, err := s.rules.CreateRule(ctx, userID, models.CreateRuleParams{
+				Name:        suggestion.Name,
+				Description: &description,
+				Query:       &suggestion.Query,
+				Actions:     *suggestion.Actions,
+			})
+			if err != nil {
+				return models.AdoptedSuggestion{}, errors.Join(ErrFailedToAdopt, err)
+			}
+			return models.AdoptedSuggestion{Rule: &rule}, nil
+		}
+
+		created, err := s.views.CreateView(
+			ctx,
+			userID,
+			suggestion.Name,
+			&description,
+			nil,
+			nil,
+			suggestion.Query,
+		)
+		if err != nil {
+			return models.AdoptedSuggestion{}, errors.Join(ErrFailedToAdopt, err)
+		}
+		return models.AdoptedSuggestion{View: &created}, nil
+	}
+
+	return models.AdoptedSuggestion{}, ErrSuggestionNotFound
+}
+
+// fetchSubscriptions looks up watch settings in batches GitHub accepts
+func (s *Service) fetchSubscriptions(
+	ctx context.Context,
+	fullNames []string,
+) ([]types.RepositorySubscription, error) {
+	var subscriptions []types.RepositorySubscription
+	for start := 0; start < len(fullNames); start += github.MaxSubscriptionBatchSize {
+		end := min(start+github.MaxSubscriptionBatchSize, len(fullNames))
+		batch, err := s.client.FetchRepositorySubscriptions(ctx, fullNames[start:end])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, batch...)
+	}
+	return subscriptions, nil
+}
+
+// markAdopted flags suggestions whose view or rule already exists
+func (s *Service) markAdopted(
+	ctx context.Context,
+	userID string,
+	suggestions []models.SettingsSuggestion,
+) ([]models.SettingsSuggestion, error) {
+	if len(suggestions) == 0 {
+		return []models.SettingsSuggestion{}, nil
+	}
+
+	existing := make(map[string]bool)
+	views, err := s.queries.ListViews(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCheckAdopted, err)
+	}
+	for _, v := range views {
+		existing[models.SettingsSuggestionView+":"+v.Name] = true
+	}
+	rules, err := s.queries.ListRules(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCheckAdopted, err)
+	}
+	for _, r := range rules {
+		existing[models.SettingsSuggestionRule+":"+r.Name] = true
+	}
+
+	for i := range suggestions {
+		suggestions[i].Adopted = existing[suggestions[i].Kind+":"+suggestions[i].Name]
+	}
+	return suggestions, nil
+}
+
+// onlyReleases reports whether every notification counted was a release
+func onlyReleases(typeCounts map[string]int64) bool {
+	if typeCounts[releaseSubjectType] == 0 {
+		return false
+	}
+	return len(typeCounts) == 1
+}
+
+// repoFilter builds a repo: filter matching any of fullNames
+func repoFilter(fullNames []string) string {
+	return "repo:" + strings.Join(fullNames, ",")
+}
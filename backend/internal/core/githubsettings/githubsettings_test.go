@@ -0,0 +1,252 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package githubsettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	rulesmocks "github.com/octobud-hq/octobud/backend/internal/core/rules/mocks"
+	viewmocks "github.com/octobud-hq/octobud/backend/internal/core/view/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+const testUserID = "test-user-id"
+
+type testDeps struct {
+	store  *mocks.MockStore
+	client *githubmocks.MockClient
+	views  *viewmocks.MockViewService
+	rules  *rulesmocks.MockRuleService
+}
+
+func newTestService(t *testing.T) (*Service, testDeps) {
+	ctrl := gomock.NewController(t)
+	deps := testDeps{
+		store:  mocks.NewMockStore(ctrl),
+		client: githubmocks.NewMockClient(ctrl),
+		views:  viewmocks.NewMockViewService(ctrl),
+		rules:  rulesmocks.NewMockRuleService(ctrl),
+	}
+	return NewService(deps.store, deps.client, deps.views, deps.rules), deps
+}
+
+// expectSettings sets up repositories with the given subject type counts and GitHub
+// watch settings
+func expectSettings(
+	deps testDeps,
+	counts map[string]map[string]int64,
+	subscriptions []types.RepositorySubscription,
+) {
+	var repositories []db.Repository
+	byID := make(map[int64]map[string]int64)
+	var fullNames []string
+	for i, fullName := range []string{
+		"acme/api", "acme/docs", "acme/legacy", "acme/web", "octo/cli", "octo/unused",
+	} {
+		id := int64(i + 1)
+		repositories = append(repositories, db.Repository{ID: id, FullName: fullName})
+		if counts[fullName] != nil {
+			byID[id] = counts[fullName]
+			fullNames = append(fullNames, fullName)
+		}
+	}
+	deps.store.EXPECT().ListRepositories(gomock.Any(), testUserID).Return(repositories, nil)
+	deps.store.EXPECT().ListRepositorySubjectTypeCounts(gomock.Any(), testUserID).Return(byID, nil)
+	deps.client.EXPECT().
+		FetchRepositorySubscriptions(gomock.Any(), fullNames).
+		Return(subscriptions, nil)
+}
+
+func TestService_Suggestions(t *testing.T) {
+	service, deps := newTestService(t)
+
+	expectSettings(deps,
+		map[string]map[string]int64{
+			"acme/api":    {"PullRequest": 4, "Issue": 2},
+			"acme/docs":   {"Release": 3},
+			"acme/legacy": {"Issue": 1},
+			"acme/web":    {"PullRequest": 1},
+			"octo/cli":    {"Release": 1, "Issue": 1},
+		},
+		[]types.RepositorySubscription{
+			{FullName: "acme/api", Subscription: types.SubscriptionSubscribed},
+			{FullName: "acme/docs", Subscription: types.SubscriptionUnsubscribed},
+			{FullName: "acme/legacy", Subscription: types.SubscriptionIgnored},
+			{FullName: "acme/web", Subscription: types.SubscriptionSubscribed},
+			{FullName: "octo/cli", Subscription: types.SubscriptionUnsubscribed},
+		},
+	)
+	deps.store.EXPECT().
+		ListViews(gomock.Any(), testUserID).
+		Return([]db.View{{Name: "Releases"}}, nil)
+	deps.store.EXPECT().ListRules(gomock.Any(), testUserID).Return(nil, nil)
+
+	suggestions, err := service.Suggestions(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Equal(t, []models.SettingsSuggestion{
+		{
+			ID:           "releases",
+			Kind:         models.SettingsSuggestionView,
+			Name:         "Releases",
+			Reason:       "You only get release notifications from these repositories on GitHub",
+			Query:        "type:release repo:acme/docs",
+			Repositories: []string{"acme/docs"},
+			Adopted:      true,
+		},
+		{
+			ID:           "watching:acme",
+			Kind:         models.SettingsSuggestionView,
+			Name:         "Watching acme",
+			Reason:       "You watch 2 of acme's repositories on GitHub",
+			Query:        "repo:acme/api,acme/web",
+			Repositories: []string{"acme/api", "acme/web"},
+		},
+		{
+			ID:           "ignored",
+			Kind:         models.SettingsSuggestionRule,
+			Name:         "Ignored on GitHub",
+			Reason:       "You ignore these repositories on GitHub",
+			Query:        "repo:acme/legacy",
+			Actions:      &models.RuleActions{Mute: true},
+			Repositories: []string{"acme/legacy"},
+		},
+	}, suggestions)
+
+	// Every suggested query is valid
+	for _, suggestion := range suggestions {
+		_, err := query.ParseAndValidate(suggestion.Query)
+		require.NoError(t, err, suggestion.Query)
+	}
+}
+
+func TestService_Suggestions_Batches(t *testing.T) {
+	service, deps := newTestService(t)
+
+	total := github.MaxSubscriptionBatchSize + 1
+	repositories := make([]db.Repository, total)
+	counts := make(map[int64]map[string]int64, total)
+	for i := range repositories {
+		repositories[i] = db.Repository{ID: int64(i + 1), FullName: fmt.Sprintf("acme/r%03d", i)}
+		counts[int64(i+1)] = map[string]int64{"Issue": 1}
+	}
+	deps.store.EXPECT().ListRepositories(gomock.Any(), testUserID).Return(repositories, nil)
+	deps.store.EXPECT().ListRepositorySubjectTypeCounts(gomock.Any(), testUserID).Return(counts, nil)
+	deps.client.EXPECT().
+		FetchRepositorySubscriptions(gomock.Any(), gomock.Len(github.MaxSubscriptionBatchSize)).
+		Return(nil, nil)
+	deps.client.EXPECT().
+		FetchRepositorySubscriptions(gomock.Any(), []string{repositories[total-1].FullName}).
+		Return(nil, nil)
+
+	suggestions, err := service.Suggestions(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Empty(t, suggestions)
+	require.NotNil(t, suggestions)
+}
+
+func TestService_Suggestions_GitHubError(t *testing.T) {
+	service, deps := newTestService(t)
+
+	deps.store.EXPECT().
+		ListRepositories(gomock.Any(), testUserID).
+		Return([]db.Repository{{ID: 1, FullName: "acme/api"}}, nil)
+	deps.store.EXPECT().
+		ListRepositorySubjectTypeCounts(gomock.Any(), testUserID).
+		Return(map[int64]map[string]int64{1: {"Issue": 1}}, nil)
+	deps.client.EXPECT().
+		FetchRepositorySubscriptions(gomock.Any(), []string{"acme/api"}).
+		Return(nil, errors.New("API rate limit exceeded"))
+
+	_, err := service.Suggestions(context.Background(), testUserID)
+	require.ErrorIs(t, err, ErrFailedToFetchSettings)
+}
+
+func TestService_Adopt(t *testing.T) {
+	settings := map[string]map[string]int64{
+		"acme/docs":   {"Release": 3},
+		"acme/legacy": {"Issue": 1},
+	}
+	subscriptions := []types.RepositorySubscription{
+		{FullName: "acme/docs", Subscription: types.SubscriptionUnsubscribed},
+		{FullName: "acme/legacy", Subscription: types.SubscriptionIgnored},
+	}
+
+	t.Run("view suggestion creates a view", func(t *testing.T) {
+		service, deps := newTestService(t)
+		expectSettings(deps, settings, subscriptions)
+		deps.store.EXPECT().ListViews(gomock.Any(), testUserID).Return(nil, nil)
+		deps.store.EXPECT().ListRules(gomock.Any(), testUserID).Return(nil, nil)
+
+		reason := "You only get release notifications from these repositories on GitHub"
+		deps.views.EXPECT().
+			CreateView(
+				gomock.Any(), testUserID, "Releases", &reason, nil, nil,
+				"type:release repo:acme/docs",
+			).
+			Return(models.View{ID: "view-1", Name: "Releases"}, nil)
+
+		adopted, err := service.Adopt(context.Background(), testUserID, "releases")
+		require.NoError(t, err)
+		require.Equal(t, models.AdoptedSuggestion{View: &models.View{ID: "view-1", Name: "Releases"}}, adopted)
+	})
+
+	t.Run("rule suggestion creates a rule", func(t *testing.T) {
+		service, deps := newTestService(t)
+		expectSettings(deps, settings, subscriptions)
+		deps.store.EXPECT().ListViews(gomock.Any(), testUserID).Return(nil, nil)
+		deps.store.EXPECT().ListRules(gomock.Any(), testUserID).Return(nil, nil)
+
+		deps.rules.EXPECT().
+			CreateRule(gomock.Any(), testUserID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				params models.CreateRuleParams,
+			) (models.Rule, error) {
+				require.Equal(t, "Ignored on GitHub", params.Name)
+				require.Equal(t, "repo:acme/legacy", *params.Query)
+				require.Equal(t, models.RuleActions{Mute: true}, params.Actions)
+				return models.Rule{ID: "rule-1", Name: params.Name}, nil
+			})
+
+		adopted, err := service.Adopt(context.Background(), testUserID, "ignored")
+		require.NoError(t, err)
+		require.Equal(t, "rule-1", adopted.Rule.ID)
+		require.Nil(t, adopted.View)
+	})
+
+	t.Run("unknown suggestion returns ErrSuggestionNotFound", func(t *testing.T) {
+		service, deps := newTestService(t)
+		expectSettings(deps, settings, subscriptions)
+		deps.store.EXPECT().ListViews(gomock.Any(), testUserID).Return(nil, nil)
+		deps.store.EXPECT().ListRules(gomock.Any(), testUserID).Return(nil, nil)
+
+		_, err := service.Adopt(context.Background(), testUserID, "watching:acme")
+		require.ErrorIs(t, err, ErrSuggestionNotFound)
+	})
+}
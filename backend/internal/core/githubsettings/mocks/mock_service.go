@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/githubsettings/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/githubsettings/service.go -destination=internal/core/githubsettings/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGitHubSettingsService is a mock of GitHubSettingsService interface.
+type MockGitHubSettingsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitHubSettingsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGitHubSettingsServiceMockRecorder is the mock recorder for MockGitHubSettingsService.
+type MockGitHubSettingsServiceMockRecorder struct {
+	mock *MockGitHubSettingsService
+}
+
+// NewMockGitHubSettingsService creates a new mock instance.
+func NewMockGitHubSettingsService(ctrl *gomock.Controller) *MockGitHubSettingsService {
+	mock := &MockGitHubSettingsService{ctrl: ctrl}
+	mock.recorder = &MockGitHubSettingsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitHubSettingsService) EXPECT() *MockGitHubSettingsServiceMockRecorder {
+	return m.recorder
+}
+
+// Adopt mocks base method.
+func (m *MockGitHubSettingsService) Adopt(ctx context.Context, userID, suggestionID string) (models.AdoptedSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adopt", ctx, userID, suggestionID)
+	ret0, _ := ret[0].(models.AdoptedSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adopt indicates an expected call of Adopt.
+func (mr *MockGitHubSettingsServiceMockRecorder) Adopt(ctx, userID, suggestionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adopt", reflect.TypeOf((*MockGitHubSettingsService)(nil).Adopt), ctx, userID, suggestionID)
+}
+
+// Suggestions mocks base method.
+func (m *MockGitHubSettingsService) Suggestions(ctx context.Context, userID string) ([]models.SettingsSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggestions", ctx, userID)
+	ret0, _ := ret[0].([]models.SettingsSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggestions indicates an expected call of Suggestions.
+func (mr *MockGitHubSettingsServiceMockRecorder) Suggestions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggestions", reflect.TypeOf((*MockGitHubSettingsService)(nil).Suggestions), ctx, userID)
+}
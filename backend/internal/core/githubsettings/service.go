@@ -0,0 +1,60 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package githubsettings
+
+import (
+	"context"
+
+	rulescore "github.com/octobud-hq/octobud/backend/internal/core/rules"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// GitHubSettingsService is the interface for suggesting views and rules from GitHub
+// notification settings.
+type GitHubSettingsService interface {
+	// Suggestions reads the user's watch settings from GitHub for the repositories they
+	// have notifications from and suggests views and rules that mirror them.
+	Suggestions(ctx context.Context, userID string) ([]models.SettingsSuggestion, error)
+	// Adopt creates the view or rule of the suggestion with the given ID.
+	Adopt(ctx context.Context, userID, suggestionID string) (models.AdoptedSuggestion, error)
+}
+
+// Service suggests views and rules mirroring the user's GitHub notification settings
+type Service struct {
+	queries db.Store
+	client  githubinterfaces.Client
+	views   view.ViewService
+	rules   rulescore.RuleService
+}
+
+// NewService constructs a Service that reads settings with the given GitHub client and
+// adopts suggestions through the view and rule services
+func NewService(
+	queries db.Store,
+	client githubinterfaces.Client,
+	views view.ViewService,
+	rules rulescore.RuleService,
+) *Service {
+	return &Service{
+		queries: queries,
+		client:  client,
+		views:   views,
+		rules:   rules,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package hidden
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// GroupLimit is how many notifications each group includes
+const GroupLimit = 50
+
+// Error definitions
+var (
+	ErrFailedToListHidden = errors.New("failed to list hidden notifications")
+	ErrFailedToWake       = errors.New("failed to wake hidden notifications")
+	ErrUnknownMechanism   = errors.New("unknown hidden mechanism")
+)
+
+// group describes how a mechanism's notifications are found and woken. The queries
+// don't overlap: snoozed and filtered exclude muted notifications, and filtered
+// excludes snoozed ones, so each notification is counted once.
+type group struct {
+	mechanism models.HiddenMechanism
+	query     string
+	wake      models.BulkOperationType
+}
+
+var groups = []group{
+	{mechanism: models.HiddenSnoozed, query: "in:snoozed", wake: models.BulkOpUnsnooze},
+	{mechanism: models.HiddenMuted, query: "in:anywhere is:muted", wake: models.BulkOpUnmute},
+	{mechanism: models.HiddenFiltered, query: "in:filtered", wake: models.BulkOpUnfilter},
+}
+
+// List returns snoozed, muted and filtered notifications grouped by mechanism
+func (s *Service) List(ctx context.Context, userID string) (*models.HiddenSummary, error) {
+	summary := &models.HiddenSummary{Groups: make([]models.HiddenGroup, 0, len(groups))}
+	for _, g := range groups {
+		result, err := s.notifications.ListNotifications(ctx, userID, models.ListOptions{
+			Query:    g.query,
+			Page:     1,
+			PageSize: GroupLimit,
+		})
+		if err != nil {
+			return nil, errors.Join(
+				ErrFailedToListHidden,
+				fmt.Errorf("mechanism %s: %w", g.mechanism, err),
+			)
+		}
+		notifications := result.Notifications
+		if notifications == nil {
+			notifications = []models.Notification{}
+		}
+		summary.Groups = append(summary.Groups, models.HiddenGroup{
+			Mechanism:     g.mechanism,
+			Query:         g.query,
+			Total:         result.Total,
+			Notifications: notifications,
+		})
+	}
+
+	user, err := s.auth.GetUser(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListHidden, err)
+	}
+	if user.MutedUntil.Valid && user.MutedUntil.Time.After(s.now()) {
+		mutedUntil := user.MutedUntil.Time
+		summary.AccountMutedUntil = &mutedUntil
+	}
+	return summary, nil
+}
+
+// Wake brings back everything hidden by a mechanism
+func (s *Service) Wake(
+	ctx context.Context,
+	userID string,
+	mechanism models.HiddenMechanism,
+) (int64, error) {
+	if mechanism == models.HiddenAccount {
+		return s.wakeAccount(ctx)
+	}
+
+	for _, g := range groups {
+		if g.mechanism != mechanism {
+			continue
+		}
+		count, err := s.notifications.BulkUpdate(
+			ctx,
+			userID,
+			g.wake,
+			models.BulkOperationTarget{Query: g.query},
+			models.BulkUpdateParams{},
+		)
+		if err != nil {
+			return 0, errors.Join(ErrFailedToWake, err)
+		}
+		return count, nil
+	}
+	return 0, errors.Join(ErrUnknownMechanism, fmt.Errorf("mechanism: %s", mechanism))
+}
+
+// wakeAccount clears an active account-wide mute
+func (s *Service) wakeAccount(ctx context.Context) (int64, error) {
+	user, err := s.auth.GetUser(ctx)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToWake, err)
+	}
+	if !user.MutedUntil.Valid || !user.MutedUntil.Time.After(s.now()) {
+		return 0, nil
+	}
+	if _, err := s.auth.UpdateUserMutedUntil(ctx, sql.NullTime{}); err != nil {
+		return 0, errors.Join(ErrFailedToWake, err)
+	}
+	return 1, nil
+}
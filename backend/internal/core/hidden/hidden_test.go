@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package hidden
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func setupService(
+	t *testing.T,
+) (*Service, *notificationmocks.MockNotificationService, *authmocks.MockAuthService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	notifications := notificationmocks.NewMockNotificationService(ctrl)
+	auth := authmocks.NewMockAuthService(ctrl)
+	return NewService(notifications, auth, func() time.Time { return testNow }), notifications, auth
+}
+
+func TestService_List(t *testing.T) {
+	svc, notifications, auth := setupService(t)
+
+	wakeAt := testNow.Add(2 * time.Hour)
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), "user-1", models.ListOptions{
+			Query: "in:snoozed", Page: 1, PageSize: GroupLimit,
+		}).
+		Return(models.ListDetailsResult{
+			Notifications: []models.Notification{{GithubID: "n1", SnoozedUntil: &wakeAt}},
+			Total:         1,
+		}, nil)
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), "user-1", models.ListOptions{
+			Query: "in:anywhere is:muted", Page: 1, PageSize: GroupLimit,
+		}).
+		Return(models.ListDetailsResult{}, nil)
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), "user-1", models.ListOptions{
+			Query: "in:filtered", Page: 1, PageSize: GroupLimit,
+		}).
+		Return(models.ListDetailsResult{
+			Notifications: []models.Notification{{GithubID: "n2"}, {GithubID: "n3"}},
+			Total:         120,
+		}, nil)
+	mutedUntil := testNow.Add(30 * time.Minute)
+	auth.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{MutedUntil: sql.NullTime{Time: mutedUntil, Valid: true}}, nil)
+
+	summary, err := svc.List(context.Background(), "user-1")
+	require.NoError(t, err)
+	require.Len(t, summary.Groups, 3)
+
+	require.Equal(t, models.HiddenSnoozed, summary.Groups[0].Mechanism)
+	require.Equal(t, int64(1), summary.Groups[0].Total)
+	require.Equal(t, &wakeAt, summary.Groups[0].Notifications[0].SnoozedUntil)
+
+	require.Equal(t, models.HiddenMuted, summary.Groups[1].Mechanism)
+	require.NotNil(t, summary.Groups[1].Notifications)
+	require.Empty(t, summary.Groups[1].Notifications)
+
+	require.Equal(t, models.HiddenFiltered, summary.Groups[2].Mechanism)
+	require.Equal(t, int64(120), summary.Groups[2].Total)
+	require.Equal(t, "in:filtered", summary.Groups[2].Query)
+
+	require.Equal(t, &mutedUntil, summary.AccountMutedUntil)
+}
+
+func TestService_List_IgnoresExpiredAccountMute(t *testing.T) {
+	svc, notifications, auth := setupService(t)
+
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), "user-1", gomock.Any()).
+		Return(models.ListDetailsResult{}, nil).
+		Times(3)
+	auth.EXPECT().
+		GetUser(gomock.Any()).
+		Return(&models.User{
+			MutedUntil: sql.NullTime{Time: testNow.Add(-time.Minute), Valid: true},
+		}, nil)
+
+	summary, err := svc.List(context.Background(), "user-1")
+	require.NoError(t, err)
+	require.Nil(t, summary.AccountMutedUntil)
+}
+
+func TestService_List_WrapsErrors(t *testing.T) {
+	svc, notifications, _ := setupService(t)
+
+	notifications.EXPECT().
+		ListNotifications(gomock.Any(), "user-1", gomock.Any()).
+		Return(models.ListDetailsResult{}, errors.New("database is locked"))
+
+	_, err := svc.List(context.Background(), "user-1")
+	require.ErrorIs(t, err, ErrFailedToListHidden)
+}
+
+func TestService_Wake(t *testing.T) {
+	tests := []struct {
+		name      string
+		mechanism models.HiddenMechanism
+		setup     func(*notificationmocks.MockNotificationService, *authmocks.MockAuthService)
+		expected  int64
+		expectErr error
+	}{
+		{
+			name:      "snoozed notifications are unsnoozed",
+			mechanism: models.HiddenSnoozed,
+			setup: func(n *notificationmocks.MockNotificationService, _ *authmocks.MockAuthService) {
+				n.EXPECT().
+					BulkUpdate(
+						gomock.Any(),
+						"user-1",
+						models.BulkOpUnsnooze,
+						models.BulkOperationTarget{Query: "in:snoozed"},
+						models.BulkUpdateParams{},
+					).
+					Return(int64(4), nil)
+			},
+			expected: 4,
+		},
+		{
+			name:      "muted notifications are unmuted",
+			mechanism: models.HiddenMuted,
+			setup: func(n *notificationmocks.MockNotificationService, _ *authmocks.MockAuthService) {
+				n.EXPECT().
+					BulkUpdate(
+						gomock.Any(),
+						"user-1",
+						models.BulkOpUnmute,
+						models.BulkOperationTarget{Query: "in:anywhere is:muted"},
+						models.BulkUpdateParams{},
+					).
+					Return(int64(2), nil)
+			},
+			expected: 2,
+		},
+		{
+			name:      "filtered notifications are moved to the inbox",
+			mechanism: models.HiddenFiltered,
+			setup: func(n *notificationmocks.MockNotificationService, _ *authmocks.MockAuthService) {
+				n.EXPECT().
+					BulkUpdate(
+						gomock.Any(),
+						"user-1",
+						models.BulkOpUnfilter,
+						models.BulkOperationTarget{Query: "in:filtered"},
+						models.BulkUpdateParams{},
+					).
+					Return(int64(0), errors.New("database is locked"))
+			},
+			expectErr: ErrFailedToWake,
+		},
+		{
+			name:      "active account mute is cleared",
+			mechanism: models.HiddenAccount,
+			setup: func(_ *notificationmocks.MockNotificationService, a *authmocks.MockAuthService) {
+				a.EXPECT().
+					GetUser(gomock.Any()).
+					Return(&models.User{
+						MutedUntil: sql.NullTime{Time: testNow.Add(time.Hour), Valid: true},
+					}, nil)
+				a.EXPECT().
+					UpdateUserMutedUntil(gomock.Any(), sql.NullTime{}).
+					Return(&models.User{}, nil)
+			},
+			expected: 1,
+		},
+		{
+			name:      "inactive account mute is left alone",
+			mechanism: models.HiddenAccount,
+			setup: func(_ *notificationmocks.MockNotificationService, a *authmocks.MockAuthService) {
+				a.EXPECT().GetUser(gomock.Any()).Return(&models.User{}, nil)
+			},
+			expected: 0,
+		},
+		{
+			name:      "unknown mechanism is rejected",
+			mechanism: "archived",
+			expectErr: ErrUnknownMechanism,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, notifications, auth := setupService(t)
+			if tt.setup != nil {
+				tt.setup(notifications, auth)
+			}
+
+			count, err := svc.Wake(context.Background(), "user-1", tt.mechanism)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, count)
+		})
+	}
+}
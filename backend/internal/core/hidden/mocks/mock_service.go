@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/hidden/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/hidden/service.go -destination=internal/core/hidden/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHiddenService is a mock of HiddenService interface.
+type MockHiddenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHiddenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHiddenServiceMockRecorder is the mock recorder for MockHiddenService.
+type MockHiddenServiceMockRecorder struct {
+	mock *MockHiddenService
+}
+
+// NewMockHiddenService creates a new mock instance.
+func NewMockHiddenService(ctrl *gomock.Controller) *MockHiddenService {
+	mock := &MockHiddenService{ctrl: ctrl}
+	mock.recorder = &MockHiddenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHiddenService) EXPECT() *MockHiddenServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockHiddenService) List(ctx context.Context, userID string) (*models.HiddenSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].(*models.HiddenSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHiddenServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHiddenService)(nil).List), ctx, userID)
+}
+
+// Wake mocks base method.
+func (m *MockHiddenService) Wake(ctx context.Context, userID string, mechanism models.HiddenMechanism) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wake", ctx, userID, mechanism)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Wake indicates an expected call of Wake.
+func (mr *MockHiddenServiceMockRecorder) Wake(ctx, userID, mechanism any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wake", reflect.TypeOf((*MockHiddenService)(nil).Wake), ctx, userID, mechanism)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package hidden lists everything temporarily kept out of the inbox and wakes it on demand.
+package hidden
+
+import (
+	"context"
+	"time"
+
+	authsvc "github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// HiddenService is the interface for listing and waking hidden notifications.
+type HiddenService interface {
+	// List returns snoozed, muted and filtered notifications grouped by mechanism,
+	// along with the account-wide mute.
+	List(ctx context.Context, userID string) (*models.HiddenSummary, error)
+	// Wake brings back everything hidden by a mechanism and returns how many
+	// notifications (or, for the account mute, mutes) were cleared.
+	Wake(ctx context.Context, userID string, mechanism models.HiddenMechanism) (int64, error)
+}
+
+// Service lists and wakes hidden notifications
+type Service struct {
+	notifications notification.NotificationService
+	auth          authsvc.AuthService
+	now           func() time.Time
+}
+
+// NewService constructs a Service backed by the notification and auth services
+func NewService(
+	notifications notification.NotificationService,
+	auth authsvc.AuthService,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		notifications: notifications,
+		auth:          auth,
+		now:           now,
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrFailedToListHistory  = errors.New("failed to list notification history")
+)
+
+// Limit is how many of a notification's most recent events are listed
+const Limit = 200
+
+// Record adds the same change to the history of each notification. GitHub IDs without a
+// notification are skipped.
+func (s *Service) Record(
+	ctx context.Context,
+	userID string,
+	githubIDs []string,
+	action models.HistoryAction,
+	source models.HistorySource,
+	detail string,
+) {
+	if len(githubIDs) == 0 {
+		return
+	}
+	err := s.queries.RecordNotificationEvents(ctx, userID, db.RecordNotificationEventsParams{
+		GithubIDs: githubIDs,
+		Action:    string(action),
+		Source:    string(source),
+		Detail:    sql.NullString{String: detail, Valid: detail != ""},
+		CreatedAt: s.now().UTC(),
+	})
+	if err != nil {
+		s.logger.Warn("failed to record notification history",
+			zap.String("action", string(action)),
+			zap.Int("count", len(githubIDs)),
+			zap.Error(err))
+	}
+}
+
+// List returns a notification's history, most recent first
+func (s *Service) List(
+	ctx context.Context,
+	userID, githubID string,
+) ([]models.NotificationEvent, error) {
+	if _, err := s.queries.GetNotificationByGithubID(ctx, userID, githubID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, errors.Join(ErrFailedToListHistory, err)
+	}
+
+	rows, err := s.queries.ListNotificationEvents(ctx, userID, githubID, Limit)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListHistory, err)
+	}
+	events := make([]models.NotificationEvent, len(rows))
+	for i, row := range rows {
+		events[i] = models.NotificationEvent{
+			Action:    models.HistoryAction(row.Action),
+			Source:    models.HistorySource(row.Source),
+			Detail:    row.Detail.String,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+	mockStore := mocks.NewMockStore(ctrl)
+	service := NewService(mockStore, func() time.Time { return now }, zap.NewNop())
+
+	mockStore.EXPECT().RecordNotificationEvents(gomock.Any(), "test-user-id", db.RecordNotificationEventsParams{
+		GithubIDs: []string{"thread-1", "thread-2"},
+		Action:    "rule_applied",
+		Source:    "rule",
+		Detail:    sql.NullString{String: "Archive bots", Valid: true},
+		CreatedAt: now,
+	}).Return(nil)
+
+	service.Record(context.Background(), "test-user-id", []string{"thread-1", "thread-2"},
+		models.HistoryRuleApplied, models.HistorySourceRule, "Archive bots")
+}
+
+func TestService_Record_SkipsEmptyAndSwallowsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	service := NewService(mockStore, time.Now, zap.NewNop())
+
+	// Nothing to record, so the store isn't called
+	service.Record(context.Background(), "test-user-id", nil,
+		models.HistoryRead, models.HistorySourceUser, "")
+
+	mockStore.EXPECT().RecordNotificationEvents(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(errors.New("database is locked"))
+	service.Record(context.Background(), "test-user-id", []string{"thread-1"},
+		models.HistoryRead, models.HistorySourceUser, "")
+}
+
+func TestService_List(t *testing.T) {
+	createdAt := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expected  []models.NotificationEvent
+		expectErr error
+	}{
+		{
+			name: "converts events",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{ID: 1, GithubID: "thread-1"}, nil)
+				m.EXPECT().ListNotificationEvents(gomock.Any(), "test-user-id", "thread-1", int64(Limit)).
+					Return([]db.NotificationEvent{
+						{Action: "synced", Source: "sync", Detail: sql.NullString{String: "mention", Valid: true},
+							CreatedAt: createdAt},
+						{Action: "read", Source: "user", CreatedAt: createdAt.Add(-time.Hour)},
+					}, nil)
+			},
+			expected: []models.NotificationEvent{
+				{Action: models.HistorySynced, Source: models.HistorySourceSync, Detail: "mention", CreatedAt: createdAt},
+				{Action: models.HistoryRead, Source: models.HistorySourceUser, CreatedAt: createdAt.Add(-time.Hour)},
+			},
+		},
+		{
+			name: "unknown notification",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{}, sql.ErrNoRows)
+			},
+			expectErr: ErrNotificationNotFound,
+		},
+		{
+			name: "store failure",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "thread-1").
+					Return(db.Notification{ID: 1}, nil)
+				m.EXPECT().ListNotificationEvents(gomock.Any(), "test-user-id", "thread-1", gomock.Any()).
+					Return(nil, errors.New("database is locked"))
+			},
+			expectErr: ErrFailedToListHistory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+			service := NewService(mockStore, time.Now, zap.NewNop())
+
+			events, err := service.List(context.Background(), "test-user-id", "thread-1")
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, events)
+		})
+	}
+}
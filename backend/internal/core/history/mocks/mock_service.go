@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/history/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/history/service.go -destination=internal/core/history/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryService is a mock of HistoryService interface.
+type MockHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryServiceMockRecorder is the mock recorder for MockHistoryService.
+type MockHistoryServiceMockRecorder struct {
+	mock *MockHistoryService
+}
+
+// NewMockHistoryService creates a new mock instance.
+func NewMockHistoryService(ctrl *gomock.Controller) *MockHistoryService {
+	mock := &MockHistoryService{ctrl: ctrl}
+	mock.recorder = &MockHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService) EXPECT() *MockHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockHistoryService) List(ctx context.Context, userID, githubID string) ([]models.NotificationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, githubID)
+	ret0, _ := ret[0].([]models.NotificationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryServiceMockRecorder) List(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryService)(nil).List), ctx, userID, githubID)
+}
+
+// Record mocks base method.
+func (m *MockHistoryService) Record(ctx context.Context, userID string, githubIDs []string, action models.HistoryAction, source models.HistorySource, detail string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", ctx, userID, githubIDs, action, source, detail)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockHistoryServiceMockRecorder) Record(ctx, userID, githubIDs, action, source, detail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockHistoryService)(nil).Record), ctx, userID, githubIDs, action, source, detail)
+}
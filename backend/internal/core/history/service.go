@@ -0,0 +1,59 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+// Package history keeps each notification's history: the changes made to its state and
+// whether the user, a rule, sync or a scheduled job made them.
+package history
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// HistoryService is the interface for recording and reading notification history.
+type HistoryService interface {
+	// Record adds the same change to the history of each notification. It's called after
+	// the change is made, so failures are logged rather than returned.
+	Record(
+		ctx context.Context,
+		userID string,
+		githubIDs []string,
+		action models.HistoryAction,
+		source models.HistorySource,
+		detail string,
+	)
+	// List returns a notification's history, most recent first.
+	List(ctx context.Context, userID, githubID string) ([]models.NotificationEvent, error)
+}
+
+// Service records and reads notification history
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+	logger  *zap.Logger
+}
+
+// NewService constructs a Service backed by the store
+func NewService(queries db.Store, now func() time.Time, logger *zap.Logger) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Headers sent with each delivery
+const (
+	// HeaderDelivery carries the delivery's ID, which stays the same across retries
+	HeaderDelivery = "X-Octobud-Delivery"
+	// HeaderSignature carries "sha256=" and the hex HMAC-SHA256 of the body keyed with the
+	// integration's secret. It's only sent when the integration has a secret.
+	HeaderSignature = "X-Octobud-Signature-256"
+)
+
+// EventRuleMatched is the event sent when a rule matched a newly synced notification
+const EventRuleMatched = "rule_matched"
+
+// Delivery retries. With the delay doubling from a minute, the last attempt is made about
+// half an hour after the first.
+const (
+	maxDeliveryAttempts = 6
+	retryBaseDelay      = time.Minute
+	maxRetryDelay       = time.Hour
+)
+
+const (
+	// How long to wait for an endpoint to respond
+	deliveryTimeout = 10 * time.Second
+	// Deliveries attempted per pass
+	deliveryBatchSize = 50
+	// Finished deliveries older than this are removed from the log
+	deliveryLogRetention = 30 * 24 * time.Hour
+	// Longest error message kept in the log
+	maxErrorLength = 500
+)
+
+// Payload is the body of a json format delivery
+type Payload struct {
+	Event        string              `json:"event"`
+	DeliveryID   int64               `json:"deliveryId"`
+	Rule         PayloadRule         `json:"rule"`
+	Notification PayloadNotification `json:"notification"`
+}
+
+// PayloadRule is the rule that sent a delivery
+type PayloadRule struct {
+	ID   string `json:"id,omitempty"` // Empty if the rule has since been deleted
+	Name string `json:"name"`
+}
+
+// PayloadNotification is the notification a rule matched
+type PayloadNotification struct {
+	GithubID    string     `json:"githubId"`
+	Title       string     `json:"title"`
+	SubjectType string     `json:"subjectType"`
+	Reason      string     `json:"reason,omitempty"`
+	Repository  string     `json:"repository,omitempty"` // Full name, e.g. "cli/cli"
+	Number      *int64     `json:"number,omitempty"`
+	State       *string    `json:"state,omitempty"`
+	Author      *string    `json:"author,omitempty"`
+	URL         string     `json:"url,omitempty"` // The subject on GitHub
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// slackMessage is the body of a slack format delivery
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// DeliverDue attempts the deliveries that are due, oldest first, and prunes old entries
+// from the delivery log
+func (s *Service) DeliverDue(ctx context.Context, userID string) (int, error) {
+	now := s.now()
+	if _, err := s.queries.DeleteIntegrationDeliveriesBefore(
+		ctx,
+		userID,
+		now.Add(-deliveryLogRetention),
+	); err != nil {
+		return 0, errors.Join(ErrFailedToDeliver, err)
+	}
+
+	due, err := s.queries.ListDueIntegrationDeliveries(ctx, userID, now, deliveryBatchSize)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToDeliver, err)
+	}
+
+	integrations := make(map[string]db.Integration)
+	for i, delivery := range due {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		outcome := s.attempt(ctx, userID, delivery, integrations)
+		if err := s.queries.UpdateIntegrationDelivery(ctx, userID, delivery.ID, outcome); err != nil {
+			return i, errors.Join(ErrFailedToDeliver, err)
+		}
+	}
+	return len(due), nil
+}
+
+// attempt sends a delivery once and returns its new state
+func (s *Service) attempt(
+	ctx context.Context,
+	userID string,
+	delivery db.IntegrationDelivery,
+	integrations map[string]db.Integration,
+) db.UpdateIntegrationDeliveryParams {
+	outcome := db.UpdateIntegrationDeliveryParams{
+		Status:   models.DeliveryFailed,
+		Attempts: delivery.Attempts + 1,
+	}
+	fail := func(retriable bool, err error) db.UpdateIntegrationDeliveryParams {
+		message := err.Error()
+		if len(message) > maxErrorLength {
+			message = message[:maxErrorLength]
+		}
+		outcome.LastError = sql.NullString{String: message, Valid: true}
+		if retriable && outcome.Attempts < maxDeliveryAttempts {
+			outcome.Status = models.DeliveryPending
+			outcome.NextAttemptAt = s.now().Add(retryDelay(outcome.Attempts))
+		}
+		return outcome
+	}
+
+	integration, ok := integrations[delivery.IntegrationID]
+	if !ok {
+		var err error
+		integration, err = s.queries.GetIntegration(ctx, userID, delivery.IntegrationID)
+		if err != nil {
+			return fail(!errors.Is(err, sql.ErrNoRows), fmt.Errorf("failed to get integration: %w", err))
+		}
+		integrations[delivery.IntegrationID] = integration
+	}
+
+	body, err := s.body(ctx, userID, integration.Format, delivery)
+	if err != nil {
+		return fail(!errors.Is(err, sql.ErrNoRows), err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, integration.URL, bytes.NewReader(body))
+	if err != nil {
+		return fail(false, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Octobud")
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	if integration.SecretEncrypted.Valid && integration.SecretEncrypted.String != "" {
+		if s.encryptor == nil {
+			return fail(false, ErrEncryptionNotConfigured)
+		}
+		secret, err := s.encryptor.Decrypt(integration.SecretEncrypted.String)
+		if err != nil {
+			return fail(false, errors.Join(ErrFailedToDecryptSecret, err))
+		}
+		req.Header.Set(HeaderSignature, Sign(secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fail(true, err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	outcome.ResponseStatus = sql.NullInt64{Int64: int64(resp.StatusCode), Valid: true}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		outcome.Status = models.DeliveryDelivered
+		outcome.DeliveredAt = sql.NullTime{Time: s.now(), Valid: true}
+		return outcome
+	}
+	retriable := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return fail(retriable, fmt.Errorf("endpoint responded with %s", resp.Status))
+}
+
+// body builds a delivery's body in the integration's format from the notification as it
+// is now. It returns sql.ErrNoRows if the notification has since been deleted.
+func (s *Service) body(
+	ctx context.Context,
+	userID, format string,
+	delivery db.IntegrationDelivery,
+) ([]byte, error) {
+	dbNotification, err := s.queries.GetNotificationByGithubID(ctx, userID, delivery.GithubID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	notification := models.NotificationFromDB(dbNotification)
+	if repository, err := s.queries.GetRepositoryByID(ctx, userID, dbNotification.RepositoryID); err == nil {
+		repo := models.RepositoryFromDB(repository)
+		notification.Repository = &repo
+	}
+
+	payload := Payload{
+		Event:      EventRuleMatched,
+		DeliveryID: delivery.ID,
+		Rule: PayloadRule{
+			ID:   delivery.RuleID.String,
+			Name: delivery.RuleName,
+		},
+		Notification: PayloadNotification{
+			GithubID:    notification.GithubID,
+			Title:       notification.SubjectTitle,
+			SubjectType: notification.SubjectType,
+			Number:      notification.SubjectNumber,
+			State:       notification.SubjectState,
+			Author:      notification.AuthorLogin,
+			URL:         subjectLink(notification),
+			UpdatedAt:   notification.GithubUpdatedAt,
+		},
+	}
+	if notification.Reason != nil {
+		payload.Notification.Reason = *notification.Reason
+	}
+	if notification.Repository != nil {
+		payload.Notification.Repository = notification.Repository.FullName
+	}
+
+	if format == models.IntegrationFormatSlack {
+		return json.Marshal(slackMessage{Text: slackText(payload)})
+	}
+	return json.Marshal(payload)
+}
+
+// Sign returns the value of HeaderSignature for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns how long to wait after the given number of failed attempts
+func retryDelay(attempts int64) time.Duration {
+	delay := retryBaseDelay
+	for i := int64(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// slackText formats a payload as a Slack message, linking the title to GitHub
+func slackText(p Payload) string {
+	n := p.Notification
+	title := slackEscaper.Replace(n.Title)
+	if n.URL != "" {
+		title = fmt.Sprintf("<%s|%s>", n.URL, strings.ReplaceAll(title, "|", "¦"))
+	}
+
+	details := make([]string, 0, 3)
+	if n.Repository != "" {
+		details = append(details, slackEscaper.Replace(n.Repository))
+	}
+	kind := subjectTypeLabel(n.SubjectType)
+	if n.Number != nil {
+		kind += fmt.Sprintf(" #%d", *n.Number)
+	}
+	details = append(details, kind)
+	if n.Reason != "" {
+		details = append(details, strings.ReplaceAll(n.Reason, "_", " "))
+	}
+
+	return fmt.Sprintf("*%s*\n%s\nMatched rule: %s",
+		title, strings.Join(details, " · "), slackEscaper.Replace(p.Rule.Name))
+}
+
+// slackEscaper escapes the characters Slack treats as control characters in message text
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func subjectTypeLabel(subjectType string) string {
+	switch subjectType {
+	case "PullRequest":
+		return "Pull request"
+	case "CheckSuite":
+		return "Check suite"
+	case "RepositoryVulnerabilityAlert":
+		return "Security alert"
+	default:
+		return subjectType
+	}
+}
+
+// subjectLink links to the subject on GitHub when it has a number, otherwise to the repository
+func subjectLink(n models.Notification) string {
+	if n.Repository == nil || n.Repository.HTMLURL == nil || *n.Repository.HTMLURL == "" {
+		return ""
+	}
+	base := strings.TrimSuffix(*n.Repository.HTMLURL, "/")
+	if n.SubjectNumber == nil {
+		return base
+	}
+	path := "issues"
+	switch n.SubjectType {
+	case "PullRequest":
+		path = "pull"
+	case "Discussion":
+		path = "discussions"
+	}
+	return fmt.Sprintf("%s/%s/%d", base, path, *n.SubjectNumber)
+}
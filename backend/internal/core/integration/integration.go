@@ -0,0 +1,255 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrIntegrationNotFound       = errors.New("integration not found")
+	ErrNameTaken                 = errors.New("an integration with that name already exists")
+	ErrInvalidIntegration        = errors.New("invalid integration")
+	ErrInvalidLimit              = errors.New("limit must be between 1 and 500")
+	ErrEncryptionNotConfigured   = errors.New("secrets can't be stored without an encryption key")
+	ErrFailedToListIntegrations  = errors.New("failed to list integrations")
+	ErrFailedToGetIntegration    = errors.New("failed to get integration")
+	ErrFailedToSaveIntegration   = errors.New("failed to save integration")
+	ErrFailedToDeleteIntegration = errors.New("failed to delete integration")
+	ErrFailedToListDeliveries    = errors.New("failed to list deliveries")
+	ErrFailedToDeliver           = errors.New("failed to deliver to integrations")
+	ErrFailedToEncryptSecret     = errors.New("failed to encrypt secret")
+	ErrFailedToDecryptSecret     = errors.New("failed to decrypt secret")
+)
+
+// Bounds for ListDeliveries
+const (
+	DefaultDeliveryLimit = 50
+	MaxDeliveryLimit     = 500
+)
+
+// Limits on what an integration can hold
+const (
+	maxNameLength   = 100
+	maxSecretLength = 256
+)
+
+// ListIntegrations returns the user's integrations, ordered by name
+func (s *Service) ListIntegrations(ctx context.Context, userID string) ([]models.Integration, error) {
+	integrations, err := s.queries.ListIntegrations(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListIntegrations, err)
+	}
+	result := make([]models.Integration, len(integrations))
+	for i, integration := range integrations {
+		result[i] = models.IntegrationFromDB(integration)
+	}
+	return result, nil
+}
+
+// GetIntegration returns an integration by ID
+func (s *Service) GetIntegration(ctx context.Context, userID, id string) (models.Integration, error) {
+	integration, err := s.get(ctx, userID, id)
+	if err != nil {
+		return models.Integration{}, err
+	}
+	return models.IntegrationFromDB(integration), nil
+}
+
+// CreateIntegration validates and creates an integration
+func (s *Service) CreateIntegration(
+	ctx context.Context,
+	userID string,
+	params models.IntegrationParams,
+) (models.Integration, error) {
+	params, err := normalizeParams(params)
+	if err != nil {
+		return models.Integration{}, err
+	}
+	secret, err := s.encryptSecret(params.Secret, sql.NullString{})
+	if err != nil {
+		return models.Integration{}, err
+	}
+
+	integration, err := s.queries.CreateIntegration(ctx, userID, db.CreateIntegrationParams{
+		Name:            params.Name,
+		Format:          params.Format,
+		URL:             params.URL,
+		SecretEncrypted: secret,
+		CreatedAt:       s.now(),
+	})
+	if err != nil {
+		if models.IsUniqueViolation(err) {
+			return models.Integration{}, ErrNameTaken
+		}
+		return models.Integration{}, errors.Join(ErrFailedToSaveIntegration, err)
+	}
+	return models.IntegrationFromDB(integration), nil
+}
+
+// UpdateIntegration validates and saves an integration, keeping its secret unless
+// params.Secret is set
+func (s *Service) UpdateIntegration(
+	ctx context.Context,
+	userID, id string,
+	params models.IntegrationParams,
+) (models.Integration, error) {
+	params, err := normalizeParams(params)
+	if err != nil {
+		return models.Integration{}, err
+	}
+	existing, err := s.get(ctx, userID, id)
+	if err != nil {
+		return models.Integration{}, err
+	}
+	secret, err := s.encryptSecret(params.Secret, existing.SecretEncrypted)
+	if err != nil {
+		return models.Integration{}, err
+	}
+
+	integration, err := s.queries.UpdateIntegration(ctx, userID, id, db.UpdateIntegrationParams{
+		Name:            params.Name,
+		Format:          params.Format,
+		URL:             params.URL,
+		SecretEncrypted: secret,
+		UpdatedAt:       s.now(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Integration{}, ErrIntegrationNotFound
+		}
+		if models.IsUniqueViolation(err) {
+			return models.Integration{}, ErrNameTaken
+		}
+		return models.Integration{}, errors.Join(ErrFailedToSaveIntegration, err)
+	}
+	return models.IntegrationFromDB(integration), nil
+}
+
+// DeleteIntegration deletes an integration along with its deliveries
+func (s *Service) DeleteIntegration(ctx context.Context, userID, id string) error {
+	deleted, err := s.queries.DeleteIntegration(ctx, userID, id)
+	if err != nil {
+		return errors.Join(ErrFailedToDeleteIntegration, err)
+	}
+	if deleted == 0 {
+		return ErrIntegrationNotFound
+	}
+	return nil
+}
+
+// ListDeliveries returns an integration's most recent deliveries, newest first. A limit of
+// zero uses DefaultDeliveryLimit.
+func (s *Service) ListDeliveries(
+	ctx context.Context,
+	userID, integrationID string,
+	limit int,
+) ([]models.IntegrationDelivery, error) {
+	if limit == 0 {
+		limit = DefaultDeliveryLimit
+	}
+	if limit < 1 || limit > MaxDeliveryLimit {
+		return nil, ErrInvalidLimit
+	}
+	if _, err := s.get(ctx, userID, integrationID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.queries.ListIntegrationDeliveries(ctx, userID, integrationID, int64(limit))
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListDeliveries, err)
+	}
+	result := make([]models.IntegrationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = models.IntegrationDeliveryFromDB(delivery)
+	}
+	return result, nil
+}
+
+func (s *Service) get(ctx context.Context, userID, id string) (db.Integration, error) {
+	integration, err := s.queries.GetIntegration(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.Integration{}, ErrIntegrationNotFound
+		}
+		return db.Integration{}, errors.Join(ErrFailedToGetIntegration, err)
+	}
+	return integration, nil
+}
+
+// encryptSecret returns the secret to store: current when secret is nil, nothing when
+// it's empty, and the encrypted secret otherwise
+func (s *Service) encryptSecret(secret *string, current sql.NullString) (sql.NullString, error) {
+	if secret == nil {
+		return current, nil
+	}
+	if *secret == "" {
+		return sql.NullString{}, nil
+	}
+	if s.encryptor == nil {
+		return sql.NullString{}, ErrEncryptionNotConfigured
+	}
+	encrypted, err := s.encryptor.Encrypt(*secret)
+	if err != nil {
+		return sql.NullString{}, errors.Join(ErrFailedToEncryptSecret, err)
+	}
+	return sql.NullString{String: encrypted, Valid: true}, nil
+}
+
+// normalizeParams trims and validates an integration's name, format, URL and secret.
+// The format defaults to json.
+func normalizeParams(params models.IntegrationParams) (models.IntegrationParams, error) {
+	params.Name = strings.TrimSpace(params.Name)
+	params.URL = strings.TrimSpace(params.URL)
+	params.Format = strings.ToLower(strings.TrimSpace(params.Format))
+	if params.Format == "" {
+		params.Format = models.IntegrationFormatJSON
+	}
+
+	switch {
+	case params.Name == "":
+		return models.IntegrationParams{}, fmt.Errorf("%w: name is required", ErrInvalidIntegration)
+	case len(params.Name) > maxNameLength:
+		return models.IntegrationParams{}, fmt.Errorf(
+			"%w: name must be at most %d characters", ErrInvalidIntegration, maxNameLength)
+	case params.Format != models.IntegrationFormatJSON && params.Format != models.IntegrationFormatSlack:
+		return models.IntegrationParams{}, fmt.Errorf(
+			"%w: format must be json or slack", ErrInvalidIntegration)
+	case params.Secret != nil && len(*params.Secret) > maxSecretLength:
+		return models.IntegrationParams{}, fmt.Errorf(
+			"%w: secret must be at most %d characters", ErrInvalidIntegration, maxSecretLength)
+	}
+
+	parsed, err := url.Parse(params.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return models.IntegrationParams{}, fmt.Errorf(
+			"%w: url must be an http or https URL", ErrInvalidIntegration)
+	}
+	if parsed.User != nil {
+		return models.IntegrationParams{}, fmt.Errorf(
+			"%w: url can't include a username or password", ErrInvalidIntegration)
+	}
+	return params, nil
+}
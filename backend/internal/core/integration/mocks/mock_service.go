@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/integration/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/integration/service.go -destination=internal/core/integration/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIntegrationService is a mock of IntegrationService interface.
+type MockIntegrationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntegrationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIntegrationServiceMockRecorder is the mock recorder for MockIntegrationService.
+type MockIntegrationServiceMockRecorder struct {
+	mock *MockIntegrationService
+}
+
+// NewMockIntegrationService creates a new mock instance.
+func NewMockIntegrationService(ctrl *gomock.Controller) *MockIntegrationService {
+	mock := &MockIntegrationService{ctrl: ctrl}
+	mock.recorder = &MockIntegrationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIntegrationService) EXPECT() *MockIntegrationServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateIntegration mocks base method.
+func (m *MockIntegrationService) CreateIntegration(ctx context.Context, userID string, params models.IntegrationParams) (models.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntegration", ctx, userID, params)
+	ret0, _ := ret[0].(models.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntegration indicates an expected call of CreateIntegration.
+func (mr *MockIntegrationServiceMockRecorder) CreateIntegration(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntegration", reflect.TypeOf((*MockIntegrationService)(nil).CreateIntegration), ctx, userID, params)
+}
+
+// DeleteIntegration mocks base method.
+func (m *MockIntegrationService) DeleteIntegration(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIntegration", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIntegration indicates an expected call of DeleteIntegration.
+func (mr *MockIntegrationServiceMockRecorder) DeleteIntegration(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIntegration", reflect.TypeOf((*MockIntegrationService)(nil).DeleteIntegration), ctx, userID, id)
+}
+
+// DeliverDue mocks base method.
+func (m *MockIntegrationService) DeliverDue(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockIntegrationServiceMockRecorder) DeliverDue(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockIntegrationService)(nil).DeliverDue), ctx, userID)
+}
+
+// GetIntegration mocks base method.
+func (m *MockIntegrationService) GetIntegration(ctx context.Context, userID, id string) (models.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntegration", ctx, userID, id)
+	ret0, _ := ret[0].(models.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntegration indicates an expected call of GetIntegration.
+func (mr *MockIntegrationServiceMockRecorder) GetIntegration(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntegration", reflect.TypeOf((*MockIntegrationService)(nil).GetIntegration), ctx, userID, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockIntegrationService) ListDeliveries(ctx context.Context, userID, integrationID string, limit int) ([]models.IntegrationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, userID, integrationID, limit)
+	ret0, _ := ret[0].([]models.IntegrationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockIntegrationServiceMockRecorder) ListDeliveries(ctx, userID, integrationID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockIntegrationService)(nil).ListDeliveries), ctx, userID, integrationID, limit)
+}
+
+// ListIntegrations mocks base method.
+func (m *MockIntegrationService) ListIntegrations(ctx context.Context, userID string) ([]models.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntegrations", ctx, userID)
+	ret0, _ := ret[0].([]models.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntegrations indicates an expected call of ListIntegrations.
+func (mr *MockIntegrationServiceMockRecorder) ListIntegrations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntegrations", reflect.TypeOf((*MockIntegrationService)(nil).ListIntegrations), ctx, userID)
+}
+
+// UpdateIntegration mocks base method.
+func (m *MockIntegrationService) UpdateIntegration(ctx context.Context, userID, id string, params models.IntegrationParams) (models.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIntegration", ctx, userID, id, params)
+	ret0, _ := ret[0].(models.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIntegration indicates an expected call of UpdateIntegration.
+func (mr *MockIntegrationServiceMockRecorder) UpdateIntegration(ctx, userID, id, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIntegration", reflect.TypeOf((*MockIntegrationService)(nil).UpdateIntegration), ctx, userID, id, params)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package integration manages the outbound webhooks rules can notify, and delivers the
+// notifications rules send to them.
+package integration
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/xcrypto"
+)
+
+// IntegrationService is the interface for outbound integrations.
+type IntegrationService interface {
+	ListIntegrations(ctx context.Context, userID string) ([]models.Integration, error)
+	GetIntegration(ctx context.Context, userID, id string) (models.Integration, error)
+	CreateIntegration(
+		ctx context.Context,
+		userID string,
+		params models.IntegrationParams,
+	) (models.Integration, error)
+	UpdateIntegration(
+		ctx context.Context,
+		userID, id string,
+		params models.IntegrationParams,
+	) (models.Integration, error)
+	// DeleteIntegration deletes an integration and its delivery log. Rules that name it
+	// stop notifying it.
+	DeleteIntegration(ctx context.Context, userID, id string) error
+	// ListDeliveries lists an integration's most recent deliveries, newest first
+	ListDeliveries(
+		ctx context.Context,
+		userID, integrationID string,
+		limit int,
+	) ([]models.IntegrationDelivery, error)
+	// DeliverDue attempts every delivery that's due and returns how many were attempted.
+	// Failed attempts are scheduled to be retried with backoff.
+	DeliverDue(ctx context.Context, userID string) (int, error)
+}
+
+// Service provides outbound integrations
+type Service struct {
+	queries   db.Store
+	encryptor *xcrypto.Encryptor
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewService constructs a Service. Secrets are encrypted with encryptor.
+func NewService(queries db.Store, encryptor *xcrypto.Encryptor, now func() time.Time) *Service {
+	return &Service{
+		queries:   queries,
+		encryptor: encryptor,
+		client:    &http.Client{Timeout: deliveryTimeout},
+		now:       now,
+	}
+}
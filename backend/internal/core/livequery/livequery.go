@@ -0,0 +1,220 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package livequery
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// EventBuffer is how many events a subscriber can fall behind before events are
+// dropped and it's sent a reset instead
+const EventBuffer = 64
+
+// Error definitions
+var (
+	ErrInvalidQuery      = errors.New("invalid live query")
+	ErrFailedToSubscribe = errors.New("failed to subscribe to live query")
+)
+
+// Subscription is a registered query along with the notifications it currently matches
+type Subscription struct {
+	ID     string
+	UserID string
+	Query  string
+
+	events  chan models.LiveQueryEvent
+	dbQuery db.NotificationQuery
+
+	mu       sync.Mutex
+	matching map[string]struct{}
+	lagged   bool
+	closed   bool
+}
+
+// Events delivers changes to the subscription's matches until it's removed
+func (sub *Subscription) Events() <-chan models.LiveQueryEvent {
+	return sub.events
+}
+
+// Matching returns how many notifications currently match the subscription's query
+func (sub *Subscription) Matching() int {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return len(sub.matching)
+}
+
+// Subscribe registers a query and records the notifications it matches now. The query
+// gets the same defaults as the notification list, so in:inbox is implied unless
+// the query says otherwise.
+func (s *Service) Subscribe(ctx context.Context, userID, queryStr string) (*Subscription, error) {
+	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidQuery, err)
+	}
+
+	githubIDs, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSubscribe, err)
+	}
+
+	sub := &Subscription{
+		UserID:   userID,
+		Query:    queryStr,
+		events:   make(chan models.LiveQueryEvent, EventBuffer),
+		dbQuery:  dbQuery,
+		matching: make(map[string]struct{}, len(githubIDs)),
+	}
+	for _, githubID := range githubIDs {
+		sub.matching[githubID] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	sub.ID = strconv.FormatInt(s.nextID, 10)
+	s.subscriptions[sub] = struct{}{}
+	s.mu.Unlock()
+
+	return sub, nil
+}
+
+// Unsubscribe removes a subscription and closes its events
+func (s *Service) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	delete(s.subscriptions, sub)
+	s.mu.Unlock()
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if !sub.closed {
+		sub.closed = true
+		close(sub.events)
+	}
+}
+
+// NotificationChanged checks one notification against each of the user's subscriptions,
+// sending an event to those it started or stopped matching
+func (s *Service) NotificationChanged(ctx context.Context, userID, githubID string) {
+	for _, sub := range s.userSubscriptions(userID) {
+		// Narrow the subscription's query down to this notification
+		narrowed := sub.dbQuery
+		narrowed.Where = append(append([]string{}, sub.dbQuery.Where...), "n.github_id = ?")
+		narrowed.Args = append(append([]interface{}{}, sub.dbQuery.Args...), githubID)
+
+		sub.mu.Lock()
+		githubIDs, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, narrowed)
+		if err != nil {
+			sub.mu.Unlock()
+			s.logger.Warn("failed to check notification against live query",
+				zap.String("subscription", sub.ID),
+				zap.String("githubID", githubID),
+				zap.Error(err))
+			continue
+		}
+		_, wasMatching := sub.matching[githubID]
+		switch {
+		case len(githubIDs) > 0 && !wasMatching:
+			sub.matching[githubID] = struct{}{}
+			s.send(sub, models.LiveQueryMatch, githubID)
+		case len(githubIDs) == 0 && wasMatching:
+			delete(sub.matching, githubID)
+			s.send(sub, models.LiveQueryUnmatch, githubID)
+		}
+		sub.mu.Unlock()
+	}
+}
+
+// Refresh runs each of the user's subscriptions again and sends events for every
+// notification that started or stopped matching since the last check
+func (s *Service) Refresh(ctx context.Context, userID string) {
+	for _, sub := range s.userSubscriptions(userID) {
+		sub.mu.Lock()
+		githubIDs, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, sub.dbQuery)
+		if err != nil {
+			sub.mu.Unlock()
+			s.logger.Warn("failed to refresh live query",
+				zap.String("subscription", sub.ID),
+				zap.Error(err))
+			continue
+		}
+
+		current := make(map[string]struct{}, len(githubIDs))
+		for _, githubID := range githubIDs {
+			current[githubID] = struct{}{}
+			if _, ok := sub.matching[githubID]; !ok {
+				s.send(sub, models.LiveQueryMatch, githubID)
+			}
+		}
+		unmatched := make([]string, 0)
+		for githubID := range sub.matching {
+			if _, ok := current[githubID]; !ok {
+				unmatched = append(unmatched, githubID)
+			}
+		}
+		sort.Strings(unmatched)
+		for _, githubID := range unmatched {
+			s.send(sub, models.LiveQueryUnmatch, githubID)
+		}
+		sub.matching = current
+		sub.mu.Unlock()
+	}
+}
+
+// userSubscriptions returns the user's subscriptions
+func (s *Service) userSubscriptions(userID string) []*Subscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	subs := make([]*Subscription, 0, len(s.subscriptions))
+	for sub := range s.subscriptions {
+		if sub.UserID == userID {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// send delivers an event without blocking. When the subscriber has fallen behind the
+// event is dropped, and a reset is sent once there's room so the client reloads.
+// The caller must hold sub.mu.
+func (s *Service) send(sub *Subscription, eventType models.LiveQueryEventType, githubID string) {
+	if sub.closed {
+		return
+	}
+	if sub.lagged {
+		select {
+		case sub.events <- models.LiveQueryEvent{Type: models.LiveQueryReset, Timestamp: s.now()}:
+			sub.lagged = false
+		default:
+			return
+		}
+	}
+	select {
+	case sub.events <- models.LiveQueryEvent{Type: eventType, GithubID: githubID, Timestamp: s.now()}:
+	default:
+		sub.lagged = true
+		s.logger.Warn("live query subscriber fell behind, dropping events",
+			zap.String("subscription", sub.ID))
+	}
+}
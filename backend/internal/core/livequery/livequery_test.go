@@ -0,0 +1,198 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package livequery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func setupService(t *testing.T) (*Service, *mocks.MockStore) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	return NewService(store, zap.NewNop(), func() time.Time { return testNow }), store
+}
+
+// narrowedTo matches a query narrowed down to a single notification
+func narrowedTo(githubID string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		q, ok := x.(db.NotificationQuery)
+		return ok && len(q.Args) > 0 && q.Args[len(q.Args)-1] == githubID &&
+			q.Where[len(q.Where)-1] == "n.github_id = ?"
+	})
+}
+
+func receive(t *testing.T, sub *Subscription) []models.LiveQueryEvent {
+	t.Helper()
+	var events []models.LiveQueryEvent
+	for {
+		select {
+		case event := <-sub.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestService_Subscribe(t *testing.T) {
+	svc, store := setupService(t)
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return([]string{"n1", "n2"}, nil)
+
+	sub, err := svc.Subscribe(context.Background(), "user-1", "repo:cli/cli")
+	require.NoError(t, err)
+	require.Equal(t, "1", sub.ID)
+	require.Equal(t, 2, sub.Matching())
+	require.Empty(t, receive(t, sub))
+}
+
+func TestService_SubscribeInvalidQuery(t *testing.T) {
+	svc, _ := setupService(t)
+
+	_, err := svc.Subscribe(context.Background(), "user-1", "repo:(")
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestService_SubscribeStoreError(t *testing.T) {
+	svc, store := setupService(t)
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return(nil, errors.New("boom"))
+
+	_, err := svc.Subscribe(context.Background(), "user-1", "is:unread")
+	require.ErrorIs(t, err, ErrFailedToSubscribe)
+}
+
+func TestService_NotificationChanged(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupService(t)
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return([]string{"n1"}, nil)
+	sub, err := svc.Subscribe(ctx, "user-1", "is:unread")
+	require.NoError(t, err)
+
+	// A new match, an unchanged match and a notification that stopped matching
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", narrowedTo("n2")).
+		Return([]string{"n2"}, nil).
+		Times(2)
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", narrowedTo("n1")).
+		Return([]string{}, nil)
+
+	svc.NotificationChanged(ctx, "user-1", "n2")
+	svc.NotificationChanged(ctx, "user-1", "n2")
+	svc.NotificationChanged(ctx, "user-1", "n1")
+	// Other users' changes are ignored without querying
+	svc.NotificationChanged(ctx, "user-2", "n3")
+
+	require.Equal(t, []models.LiveQueryEvent{
+		{Type: models.LiveQueryMatch, GithubID: "n2", Timestamp: testNow},
+		{Type: models.LiveQueryUnmatch, GithubID: "n1", Timestamp: testNow},
+	}, receive(t, sub))
+	require.Equal(t, 1, sub.Matching())
+}
+
+func TestService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupService(t)
+	gomock.InOrder(
+		store.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return([]string{"n1", "n2", "n3"}, nil),
+		store.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return([]string{"n4", "n2"}, nil),
+	)
+	sub, err := svc.Subscribe(ctx, "user-1", "in:inbox")
+	require.NoError(t, err)
+
+	svc.Refresh(ctx, "user-1")
+
+	require.Equal(t, []models.LiveQueryEvent{
+		{Type: models.LiveQueryMatch, GithubID: "n4", Timestamp: testNow},
+		{Type: models.LiveQueryUnmatch, GithubID: "n1", Timestamp: testNow},
+		{Type: models.LiveQueryUnmatch, GithubID: "n3", Timestamp: testNow},
+	}, receive(t, sub))
+	require.Equal(t, 2, sub.Matching())
+}
+
+func TestService_SendsResetWhenSubscriberFallsBehind(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupService(t)
+
+	matches := make([]string, EventBuffer+1)
+	for i := range matches {
+		matches[i] = "n" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	gomock.InOrder(
+		store.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return([]string{}, nil),
+		store.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return(matches, nil),
+		store.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", narrowedTo("z")).
+			Return([]string{"z"}, nil),
+	)
+	sub, err := svc.Subscribe(ctx, "user-1", "in:inbox")
+	require.NoError(t, err)
+
+	// One more match than fits, so the last is dropped
+	svc.Refresh(ctx, "user-1")
+	require.Len(t, receive(t, sub), EventBuffer)
+
+	// The next event is preceded by a reset
+	svc.NotificationChanged(ctx, "user-1", "z")
+	require.Equal(t, []models.LiveQueryEvent{
+		{Type: models.LiveQueryReset, Timestamp: testNow},
+		{Type: models.LiveQueryMatch, GithubID: "z", Timestamp: testNow},
+	}, receive(t, sub))
+}
+
+func TestService_Unsubscribe(t *testing.T) {
+	ctx := context.Background()
+	svc, store := setupService(t)
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return([]string{}, nil)
+	sub, err := svc.Subscribe(ctx, "user-1", "in:inbox")
+	require.NoError(t, err)
+
+	svc.Unsubscribe(sub)
+	// Removed subscriptions aren't checked again
+	svc.Refresh(ctx, "user-1")
+
+	_, open := <-sub.Events()
+	require.False(t, open)
+}
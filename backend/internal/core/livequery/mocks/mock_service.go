@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/livequery/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/livequery/service.go -destination=internal/core/livequery/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	livequery "github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLiveQueryService is a mock of LiveQueryService interface.
+type MockLiveQueryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveQueryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLiveQueryServiceMockRecorder is the mock recorder for MockLiveQueryService.
+type MockLiveQueryServiceMockRecorder struct {
+	mock *MockLiveQueryService
+}
+
+// NewMockLiveQueryService creates a new mock instance.
+func NewMockLiveQueryService(ctrl *gomock.Controller) *MockLiveQueryService {
+	mock := &MockLiveQueryService{ctrl: ctrl}
+	mock.recorder = &MockLiveQueryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveQueryService) EXPECT() *MockLiveQueryServiceMockRecorder {
+	return m.recorder
+}
+
+// NotificationChanged mocks base method.
+func (m *MockLiveQueryService) NotificationChanged(ctx context.Context, userID, githubID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotificationChanged", ctx, userID, githubID)
+}
+
+// NotificationChanged indicates an expected call of NotificationChanged.
+func (mr *MockLiveQueryServiceMockRecorder) NotificationChanged(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationChanged", reflect.TypeOf((*MockLiveQueryService)(nil).NotificationChanged), ctx, userID, githubID)
+}
+
+// Refresh mocks base method.
+func (m *MockLiveQueryService) Refresh(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Refresh", ctx, userID)
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockLiveQueryServiceMockRecorder) Refresh(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockLiveQueryService)(nil).Refresh), ctx, userID)
+}
+
+// Subscribe mocks base method.
+func (m *MockLiveQueryService) Subscribe(ctx context.Context, userID, query string) (*livequery.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, query)
+	ret0, _ := ret[0].(*livequery.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockLiveQueryServiceMockRecorder) Subscribe(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockLiveQueryService)(nil).Subscribe), ctx, userID, query)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockLiveQueryService) Unsubscribe(sub *livequery.Subscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockLiveQueryServiceMockRecorder) Unsubscribe(sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockLiveQueryService)(nil).Unsubscribe), sub)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package livequery keeps track of which notifications match registered queries and
+// reports when a synced or updated notification starts or stops matching.
+package livequery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// LiveQueryService is the interface for live query subscriptions.
+type LiveQueryService interface {
+	// Subscribe registers a query and records the notifications it matches now.
+	Subscribe(ctx context.Context, userID, query string) (*Subscription, error)
+	// Unsubscribe removes a subscription and closes its events.
+	Unsubscribe(sub *Subscription)
+	// NotificationChanged checks one synced or updated notification against the user's
+	// subscriptions.
+	NotificationChanged(ctx context.Context, userID, githubID string)
+	// Refresh checks all of the user's subscriptions again, for changes that may touch
+	// many notifications at once like bulk actions, rules and snoozes ending.
+	Refresh(ctx context.Context, userID string)
+}
+
+// Service tracks live query subscriptions
+type Service struct {
+	queries db.Store
+	logger  *zap.Logger
+	now     func() time.Time
+
+	mu            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+	nextID        int64
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, logger *zap.Logger, now func() time.Time) *Service {
+	return &Service{
+		queries:       queries,
+		logger:        logger,
+		now:           now,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 // Error definitions
@@ -34,7 +35,9 @@ func (s *Service) MarkNotificationRead(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationRead(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryRead)(
+		s.queries.MarkNotificationRead(ctx, userID, githubID),
+	)
 }
 
 // MarkNotificationUnread marks a notification as unread.
@@ -42,7 +45,9 @@ func (s *Service) MarkNotificationUnread(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationUnread(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnread)(
+		s.queries.MarkNotificationUnread(ctx, userID, githubID),
+	)
 }
 
 // ArchiveNotification archives a notification.
@@ -50,7 +55,9 @@ func (s *Service) ArchiveNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.ArchiveNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryArchived)(
+		s.queries.ArchiveNotification(ctx, userID, githubID),
+	)
 }
 
 // UnarchiveNotification unarchives a notification.
@@ -58,7 +65,9 @@ func (s *Service) UnarchiveNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnarchiveNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnarchived)(
+		s.queries.UnarchiveNotification(ctx, userID, githubID),
+	)
 }
 
 // MuteNotification mutes a notification.
@@ -66,7 +75,9 @@ func (s *Service) MuteNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.MuteNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryMuted)(
+		s.queries.MuteNotification(ctx, userID, githubID),
+	)
 }
 
 // UnmuteNotification unmutes a notification.
@@ -74,13 +85,17 @@ func (s *Service) UnmuteNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnmuteNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnmuted)(
+		s.queries.UnmuteNotification(ctx, userID, githubID),
+	)
 }
 
-// SnoozeNotification snoozes a notification until a specified time.
+// SnoozeNotification snoozes a notification until a specified time. With untilUpdated,
+// the snooze also ends as soon as sync sees the thread updated on GitHub.
 func (s *Service) SnoozeNotification(
 	ctx context.Context,
 	userID, githubID, snoozedUntil string,
+	untilUpdated bool,
 ) (db.Notification, error) {
 	// Parse the time string
 	t, err := time.Parse(time.RFC3339, snoozedUntil)
@@ -88,10 +103,13 @@ func (s *Service) SnoozeNotification(
 		return db.Notification{}, errors.Join(ErrInvalidSnoozedUntilFormat, err)
 	}
 
-	return s.queries.SnoozeNotification(ctx, userID, db.SnoozeNotificationParams{
-		GithubID:     githubID,
-		SnoozedUntil: sql.NullTime{Time: t, Valid: true},
-	})
+	return s.recordChange(ctx, userID, githubID, models.HistorySnoozed)(
+		s.queries.SnoozeNotification(ctx, userID, db.SnoozeNotificationParams{
+			GithubID:     githubID,
+			SnoozedUntil: sql.NullTime{Time: t, Valid: true},
+			UntilUpdated: untilUpdated,
+		}),
+	)
 }
 
 // UnsnoozeNotification clears the snooze on a notification.
@@ -99,7 +117,9 @@ func (s *Service) UnsnoozeNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnsnoozeNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnsnoozed)(
+		s.queries.UnsnoozeNotification(ctx, userID, githubID),
+	)
 }
 
 // StarNotification stars a notification.
@@ -107,7 +127,9 @@ func (s *Service) StarNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.StarNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryStarred)(
+		s.queries.StarNotification(ctx, userID, githubID),
+	)
 }
 
 // UnstarNotification unstars a notification.
@@ -115,7 +137,9 @@ func (s *Service) UnstarNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnstarNotification(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnstarred)(
+		s.queries.UnstarNotification(ctx, userID, githubID),
+	)
 }
 
 // UnfilterNotification unfilters a notification (moves it to inbox).
@@ -123,5 +147,24 @@ func (s *Service) UnfilterNotification(
 	ctx context.Context,
 	userID, githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationUnfiltered(ctx, userID, githubID)
+	return s.recordChange(ctx, userID, githubID, models.HistoryUnfiltered)(
+		s.queries.MarkNotificationUnfiltered(ctx, userID, githubID),
+	)
+}
+
+// recordChange returns a function that passes on the result of a single notification
+// action, first adding the change to the notification's history and marking it on GitHub
+// if the action succeeded
+func (s *Service) recordChange(
+	ctx context.Context,
+	userID, githubID string,
+	action models.HistoryAction,
+) func(db.Notification, error) (db.Notification, error) {
+	return func(notification db.Notification, err error) (db.Notification, error) {
+		if err == nil {
+			s.recordHistory(ctx, userID, []string{githubID}, action)
+			s.markOnGitHub(ctx, userID, []string{githubID}, action)
+		}
+		return notification, err
+	}
 }
@@ -25,8 +25,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	historymocks "github.com/octobud-hq/octobud/backend/internal/core/history/mocks"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 func TestService_MarkNotificationRead(t *testing.T) {
@@ -823,6 +826,7 @@ func TestService_SnoozeNotification(t *testing.T) {
 					DoAndReturn(func(_ context.Context, _ string, arg db.SnoozeNotificationParams) (db.Notification, error) {
 						require.Equal(t, id, arg.GithubID)
 						require.True(t, arg.SnoozedUntil.Valid)
+						require.False(t, arg.UntilUpdated)
 						return expectedNotification, nil
 					})
 			},
@@ -856,7 +860,7 @@ func TestService_SnoozeNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.SnoozeNotification(ctx, testUserID, tt.githubID, tt.snoozedUntil)
+			result, err := service.SnoozeNotification(ctx, testUserID, tt.githubID, tt.snoozedUntil, false)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -872,3 +876,120 @@ func TestService_SnoozeNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ArchiveNotification_RecordsHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockHistory := historymocks.NewMockHistoryService(ctrl)
+	service := NewService(mockStore).WithHistory(mockHistory)
+
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), "test-user-id", "abc").
+		Return(db.Notification{GithubID: "abc", Archived: true}, nil)
+	mockHistory.EXPECT().Record(
+		gomock.Any(), "test-user-id", []string{"abc"},
+		models.HistoryArchived, models.HistorySourceUser, "",
+	)
+
+	_, err := service.ArchiveNotification(context.Background(), "test-user-id", "abc")
+	require.NoError(t, err)
+}
+
+func TestService_ArchiveNotification_FailureIsNotRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockHistory := historymocks.NewMockHistoryService(ctrl)
+	service := NewService(mockStore).WithHistory(mockHistory)
+
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), "test-user-id", "abc").
+		Return(db.Notification{}, sql.ErrNoRows)
+
+	_, err := service.ArchiveNotification(context.Background(), "test-user-id", "abc")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func markOnGitHubUser(enabled bool) db.User {
+	data := []byte(`{"markOnGitHub": false}`)
+	if enabled {
+		data = []byte(`{"markOnGitHub": true}`)
+	}
+	return db.User{SyncSettings: db.NullRawMessage{RawMessage: data, Valid: true}}
+}
+
+func TestService_MarksReadAndArchivedOnGitHub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockMarker := notificationmocks.NewMockGitHubMarker(ctrl)
+	service := NewService(mockStore).WithGitHubMarker(mockMarker)
+	ctx := context.Background()
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(true), nil).Times(2)
+	mockStore.EXPECT().
+		MarkNotificationRead(gomock.Any(), "test-user-id", "abc").
+		Return(db.Notification{GithubID: "abc", IsRead: true}, nil)
+	mockMarker.EXPECT().EnqueueMarkOnGitHub(gomock.Any(), "test-user-id", []string{"abc"}, false)
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), "test-user-id", "abc").
+		Return(db.Notification{GithubID: "abc", Archived: true}, nil)
+	mockMarker.EXPECT().EnqueueMarkOnGitHub(gomock.Any(), "test-user-id", []string{"abc"}, true)
+
+	_, err := service.MarkNotificationRead(ctx, "test-user-id", "abc")
+	require.NoError(t, err)
+	_, err = service.ArchiveNotification(ctx, "test-user-id", "abc")
+	require.NoError(t, err)
+}
+
+func TestService_DoesNotMarkOnGitHub(t *testing.T) {
+	t.Run("when turned off", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStore(ctrl)
+		service := NewService(mockStore).WithGitHubMarker(notificationmocks.NewMockGitHubMarker(ctrl))
+
+		mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(false), nil)
+		mockStore.EXPECT().
+			MarkNotificationRead(gomock.Any(), "test-user-id", "abc").
+			Return(db.Notification{GithubID: "abc", IsRead: true}, nil)
+
+		_, err := service.MarkNotificationRead(context.Background(), "test-user-id", "abc")
+		require.NoError(t, err)
+	})
+
+	t.Run("for changes GitHub has no equivalent of", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStore(ctrl)
+		service := NewService(mockStore).WithGitHubMarker(notificationmocks.NewMockGitHubMarker(ctrl))
+
+		mockStore.EXPECT().
+			MarkNotificationUnread(gomock.Any(), "test-user-id", "abc").
+			Return(db.Notification{GithubID: "abc"}, nil)
+
+		_, err := service.MarkNotificationUnread(context.Background(), "test-user-id", "abc")
+		require.NoError(t, err)
+	})
+
+	t.Run("when the change failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStore(ctrl)
+		service := NewService(mockStore).WithGitHubMarker(notificationmocks.NewMockGitHubMarker(ctrl))
+
+		mockStore.EXPECT().
+			ArchiveNotification(gomock.Any(), "test-user-id", "abc").
+			Return(db.Notification{}, sql.ErrNoRows)
+
+		_, err := service.ArchiveNotification(context.Background(), "test-user-id", "abc")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
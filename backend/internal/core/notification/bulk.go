@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query"
@@ -31,8 +32,28 @@ import (
 // Error definitions
 var (
 	ErrNoNotificationIDs = errors.New("notifications: no notification ids provided")
+	ErrNoSubjectURL      = errors.New("notifications: no subject url provided")
 )
 
+// MarkGroupRead marks every unread notification in a thread group as read. Notifications are
+// grouped by subject URL, so all notifications about the same issue or pull request are marked
+// together in one transaction. Returns the GitHub IDs that changed.
+func (s *Service) MarkGroupRead(ctx context.Context, userID, subjectURL string) ([]string, error) {
+	if strings.TrimSpace(subjectURL) == "" {
+		return nil, ErrNoSubjectURL
+	}
+	githubIDs, err := s.queries.MarkNotificationsReadBySubjectURL(ctx, userID, subjectURL)
+	if err != nil {
+		return nil, err
+	}
+	if githubIDs == nil {
+		githubIDs = []string{}
+	}
+	s.recordHistory(ctx, userID, githubIDs, models.HistoryRead)
+	s.markOnGitHub(ctx, userID, githubIDs, models.HistoryRead)
+	return githubIDs, nil
+}
+
 // BulkUpdate performs a unified bulk operation on notifications.
 // This method consolidates the many individual bulk operation methods into a single unified interface.
 // It handles operations that can target notifications by IDs or by query string.
@@ -53,24 +74,120 @@ func (s *Service) BulkUpdate(
 	target models.BulkOperationTarget,
 	params models.BulkUpdateParams,
 ) (int64, error) {
+	count, _, err := s.bulkUpdate(ctx, userID, op, target, params, false)
+	return count, err
+}
+
+// BulkUpdateWithUndo is BulkUpdate for changes the user can take back. The state of every
+// notification it targets is captured first, and the returned undo ID restores it through
+// UndoBulkOperation. The undo ID is 0 when undo isn't set up.
+func (s *Service) BulkUpdateWithUndo(
+	ctx context.Context,
+	userID string,
+	op models.BulkOperationType,
+	target models.BulkOperationTarget,
+	params models.BulkUpdateParams,
+) (int64, int64, error) {
+	return s.bulkUpdate(ctx, userID, op, target, params, s.undo != nil)
+}
+
+// UndoBulkOperation puts back what a bulk operation made with BulkUpdateWithUndo changed
+func (s *Service) UndoBulkOperation(
+	ctx context.Context,
+	userID string,
+	id int64,
+) (models.BulkUndoResult, error) {
+	if s.undo == nil {
+		return models.BulkUndoResult{}, undo.ErrOperationNotFound
+	}
+	result, err := s.undo.Undo(ctx, userID, id)
+	if err != nil {
+		return models.BulkUndoResult{}, err
+	}
+	if s.history != nil {
+		s.history.Record(ctx, userID, result.GithubIDs, models.HistoryUndone, models.HistorySourceUser,
+			string(result.Operation))
+	}
+	s.publishBulkCompleted(userID, "undo", int64(len(result.GithubIDs)))
+	return result, nil
+}
+
+// bulkUpdate runs a bulk operation, capturing it for undo first when asked
+func (s *Service) bulkUpdate(
+	ctx context.Context,
+	userID string,
+	op models.BulkOperationType,
+	target models.BulkOperationTarget,
+	params models.BulkUpdateParams,
+	undoable bool,
+) (int64, int64, error) {
 	// Validate target
 	if len(target.IDs) == 0 && target.Query == "" {
-		return 0, ErrNoNotificationIDs
+		return 0, 0, ErrNoNotificationIDs
 	}
 	if len(target.IDs) > 0 && target.Query != "" {
-		return 0, errors.New("cannot specify both IDs and Query")
+		return 0, 0, errors.New("cannot specify both IDs and Query")
 	}
 
 	// Handle snooze-specific validation
 	if op == models.BulkOpSnooze && params.SnoozedUntil == "" {
-		return 0, errors.New("SnoozedUntil parameter is required for snooze operations")
+		return 0, 0, errors.New("SnoozedUntil parameter is required for snooze operations")
+	}
+
+	// A query is resolved before anything changes, since the change can stop notifications
+	// from matching it
+	changed, err := s.bulkTargets(ctx, userID, target, undoable || s.history != nil || s.github != nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	var undoID int64
+	if undoable {
+		if undoID, err = s.undo.Capture(ctx, userID, op, changed); err != nil {
+			return 0, 0, err
+		}
 	}
 
 	// Execute based on target type
+	var count int64
+	if len(target.IDs) > 0 {
+		count, err = s.executeBulkUpdateByIDs(ctx, userID, op, target.IDs, params)
+	} else {
+		count, err = s.executeBulkUpdateByQuery(ctx, userID, op, target.Query, params)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if action, ok := models.HistoryActionForBulkOp(op); ok {
+		s.recordHistory(ctx, userID, changed, action)
+		s.markOnGitHub(ctx, userID, changed, action)
+	}
+	s.publishBulkCompleted(userID, string(op), count)
+	return count, undoID, nil
+}
+
+// bulkTargets returns the GitHub IDs a bulk operation changes, for their history and undo.
+// Nothing is looked up unless they're needed.
+func (s *Service) bulkTargets(
+	ctx context.Context,
+	userID string,
+	target models.BulkOperationTarget,
+	needed bool,
+) ([]string, error) {
+	if !needed {
+		return nil, nil
+	}
 	if len(target.IDs) > 0 {
-		return s.executeBulkUpdateByIDs(ctx, userID, op, target.IDs, params)
+		return dedupeAndSort(target.IDs), nil
 	}
-	return s.executeBulkUpdateByQuery(ctx, userID, op, target.Query, params)
+	dbQuery, err := query.BuildQuery(target.Query, 0, 0)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildQuery, err)
+	}
+	githubIDs, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListNotifications, err)
+	}
+	return githubIDs, nil
 }
 
 // executeBulkUpdateByIDs executes a bulk operation using notification IDs
@@ -153,17 +270,13 @@ func (s *Service) executeBulkUpdateByQuery(
 		return s.queries.BulkUnstarNotificationsByQuery(ctx, userID, dbQuery)
 	case models.BulkOpUnfilter:
 		// Special case: unfilter needs to fetch notifications first, then call the ID-based method
-		result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
+		githubIDs, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, dbQuery)
 		if err != nil {
 			return 0, errors.Join(ErrFailedToListNotifications, err)
 		}
-		if len(result.Notifications) == 0 {
+		if len(githubIDs) == 0 {
 			return 0, nil
 		}
-		githubIDs := make([]string, 0, len(result.Notifications))
-		for _, n := range result.Notifications {
-			githubIDs = append(githubIDs, n.GithubID)
-		}
 		return s.executeBulkUpdateByIDs(
 			ctx,
 			userID,
@@ -216,6 +329,7 @@ func (s *Service) BulkAssignTag(
 
 		count++
 	}
+	s.publishBulkCompleted(userID, "assign-tag", int64(count))
 	return count, nil
 }
 
@@ -239,9 +353,60 @@ func (s *Service) BulkRemoveTag(
 
 		count++
 	}
+	s.publishBulkCompleted(userID, "remove-tag", int64(count))
+	return count, nil
+}
+
+// BulkAssignTagByQuery assigns a tag to every notification matching a query in one statement.
+// An empty query matches the inbox. Returns how many notifications didn't have the tag already.
+func (s *Service) BulkAssignTagByQuery(
+	ctx context.Context,
+	userID, queryStr, tagID string,
+) (int64, error) {
+	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidQuery, err)
+	}
+	count, err := s.queries.BulkAssignTagByQuery(ctx, userID, db.BulkTagNotificationsByQueryParams{
+		Query: dbQuery,
+		TagID: tagID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.publishBulkCompleted(userID, "assign-tag", count)
+	return count, nil
+}
+
+// BulkRemoveTagByQuery removes a tag from every notification matching a query in one
+// statement. An empty query matches the inbox. Returns how many notifications had the tag.
+func (s *Service) BulkRemoveTagByQuery(
+	ctx context.Context,
+	userID, queryStr, tagID string,
+) (int64, error) {
+	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidQuery, err)
+	}
+	count, err := s.queries.BulkRemoveTagByQuery(ctx, userID, db.BulkTagNotificationsByQueryParams{
+		Query: dbQuery,
+		TagID: tagID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.publishBulkCompleted(userID, "remove-tag", count)
 	return count, nil
 }
 
+// publishBulkCompleted tells the user's live update subscribers a bulk operation finished
+func (s *Service) publishBulkCompleted(userID, operation string, count int64) {
+	s.publish(userID, models.Event{
+		Type: models.EventBulkCompleted,
+		Bulk: &models.BulkResult{Operation: operation, Count: count},
+	})
+}
+
 // dedupeAndSort removes duplicates and sorts notification IDs
 func dedupeAndSort(ids []string) []string {
 	seen := make(map[string]struct{}, len(ids))
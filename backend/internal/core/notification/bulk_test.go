@@ -24,7 +24,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
-	"github.com/octobud-hq/octobud/backend/internal/db"
+	eventmocks "github.com/octobud-hq/octobud/backend/internal/core/events/mocks"
+	historymocks "github.com/octobud-hq/octobud/backend/internal/core/history/mocks"
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
+	undomocks "github.com/octobud-hq/octobud/backend/internal/core/undo/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
@@ -1536,18 +1540,10 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 			name:     "success unfilters notifications by query",
 			queryStr: "is:filtered",
 			setupMock: func(m *mocks.MockStore, userID string, _ string) {
-				// First call to ListNotificationsFromQuery to get matching notifications
-				now := time.Now().UTC()
-				notifications := []db.Notification{
-					{ID: 1, UserID: userID, GithubID: "notif-1", Filtered: true, ImportedAt: now},
-					{ID: 2, UserID: userID, GithubID: "notif-2", Filtered: true, ImportedAt: now},
-				}
+				// First list the GitHub IDs of every matching notification
 				m.EXPECT().
-					ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
-					Return(db.ListNotificationsFromQueryResult{
-						Notifications: notifications,
-						Total:         2,
-					}, nil)
+					ListNotificationGithubIDsFromQuery(gomock.Any(), userID, gomock.Any()).
+					Return([]string{"notif-1", "notif-2"}, nil)
 				// Then call to models.BulkUnfilterNotifications
 				m.EXPECT().
 					BulkMarkNotificationsUnfiltered(gomock.Any(), userID, []string{"notif-1", "notif-2"}).
@@ -1563,11 +1559,8 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 			queryStr: "is:filtered",
 			setupMock: func(m *mocks.MockStore, userID string, _ string) {
 				m.EXPECT().
-					ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
-					Return(db.ListNotificationsFromQueryResult{
-						Notifications: []db.Notification{},
-						Total:         0,
-					}, nil)
+					ListNotificationGithubIDsFromQuery(gomock.Any(), userID, gomock.Any()).
+					Return([]string{}, nil)
 			},
 			expectErr: false,
 			checkResult: func(t *testing.T, count int64) {
@@ -1591,8 +1584,8 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, userID string, _ string) {
 				dbError := errors.New("database error")
 				m.EXPECT().
-					ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
-					Return(db.ListNotificationsFromQueryResult{}, dbError)
+					ListNotificationGithubIDsFromQuery(gomock.Any(), userID, gomock.Any()).
+					Return(nil, dbError)
 			},
 			expectErr: true,
 			checkErr: func(t *testing.T, err error) {
@@ -1664,3 +1657,222 @@ func TestDedupeAndSort_Comprehensive(t *testing.T) {
 		})
 	}
 }
+
+func TestService_MarkGroupRead(t *testing.T) {
+	const testUserID = "test-user-id"
+	const subjectURL = "https://api.github.com/repos/octo/repo/pulls/1"
+
+	tests := []struct {
+		name        string
+		subjectURL  string
+		setupMock   func(*mocks.MockStore)
+		expectedIDs []string
+		expectedErr error
+	}{
+		{
+			name:       "marks every unread notification in the group",
+			subjectURL: subjectURL,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationsReadBySubjectURL(gomock.Any(), testUserID, subjectURL).
+					Return([]string{"n1", "n2"}, nil)
+			},
+			expectedIDs: []string{"n1", "n2"},
+		},
+		{
+			name:       "already read group returns empty list",
+			subjectURL: subjectURL,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationsReadBySubjectURL(gomock.Any(), testUserID, subjectURL).
+					Return(nil, nil)
+			},
+			expectedIDs: []string{},
+		},
+		{
+			name:        "empty subject url",
+			subjectURL:  "  ",
+			expectedErr: ErrNoSubjectURL,
+		},
+		{
+			name:       "store error",
+			subjectURL: subjectURL,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationsReadBySubjectURL(gomock.Any(), testUserID, subjectURL).
+					Return(nil, errors.New("database error"))
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockQuerier)
+			}
+			service := NewService(mockQuerier)
+
+			ids, err := service.MarkGroupRead(context.Background(), testUserID, tt.subjectURL)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestService_BulkUpdate_PublishesResult(t *testing.T) {
+	const testUserID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	bus := eventmocks.NewMockEventBus(ctrl)
+	service := NewService(mockQuerier).WithEvents(bus)
+
+	mockQuerier.EXPECT().
+		BulkArchiveNotifications(gomock.Any(), testUserID, []string{"notif-1", "notif-2"}).
+		Return(int64(2), nil)
+	bus.EXPECT().Publish(testUserID, models.Event{
+		Type: models.EventBulkCompleted,
+		Bulk: &models.BulkResult{Operation: "archive", Count: 2},
+	})
+
+	count, err := service.BulkUpdate(
+		context.Background(),
+		testUserID,
+		models.BulkOpArchive,
+		models.BulkOperationTarget{IDs: []string{"notif-1", "notif-2"}},
+		models.BulkUpdateParams{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestService_BulkUpdate_RecordsQueryMatchesBeforeTheChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockHistory := historymocks.NewMockHistoryService(ctrl)
+	service := NewService(mockStore).WithHistory(mockHistory)
+
+	gomock.InOrder(
+		mockStore.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+			Return([]string{"notif-1", "notif-2"}, nil),
+		mockStore.EXPECT().
+			BulkArchiveNotificationsByQuery(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(int64(2), nil),
+		mockHistory.EXPECT().Record(
+			gomock.Any(), "test-user-id", []string{"notif-1", "notif-2"},
+			models.HistoryArchived, models.HistorySourceUser, "",
+		),
+	)
+
+	count, err := service.BulkUpdate(
+		context.Background(),
+		"test-user-id",
+		models.BulkOpArchive,
+		models.BulkOperationTarget{Query: "in:inbox"},
+		models.BulkUpdateParams{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestService_BulkUpdate_MarksQueryMatchesDoneOnGitHub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockMarker := notificationmocks.NewMockGitHubMarker(ctrl)
+	service := NewService(mockStore).WithGitHubMarker(mockMarker)
+
+	gomock.InOrder(
+		mockStore.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+			Return([]string{"notif-1", "notif-2"}, nil),
+		mockStore.EXPECT().
+			BulkArchiveNotificationsByQuery(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(int64(2), nil),
+		mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(true), nil),
+		mockMarker.EXPECT().
+			EnqueueMarkOnGitHub(gomock.Any(), "test-user-id", []string{"notif-1", "notif-2"}, true),
+	)
+
+	count, err := service.BulkUpdate(
+		context.Background(),
+		"test-user-id",
+		models.BulkOpArchive,
+		models.BulkOperationTarget{Query: "in:inbox"},
+		models.BulkUpdateParams{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestService_BulkUpdateWithUndo_CapturesBeforeTheChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockUndo := undomocks.NewMockUndoService(ctrl)
+	service := NewService(mockStore).WithUndo(mockUndo)
+
+	gomock.InOrder(
+		mockUndo.EXPECT().
+			Capture(gomock.Any(), "test-user-id", models.BulkOpMarkRead, []string{"notif-1", "notif-2"}).
+			Return(int64(12), nil),
+		mockStore.EXPECT().
+			BulkMarkNotificationsRead(gomock.Any(), "test-user-id", []string{"notif-1", "notif-2"}).
+			Return(int64(2), nil),
+	)
+
+	count, undoID, err := service.BulkUpdateWithUndo(
+		context.Background(),
+		"test-user-id",
+		models.BulkOpMarkRead,
+		models.BulkOperationTarget{IDs: []string{"notif-2", "notif-1", "notif-2"}},
+		models.BulkUpdateParams{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	require.Equal(t, int64(12), undoID)
+}
+
+func TestService_UndoBulkOperation_RecordsHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUndo := undomocks.NewMockUndoService(ctrl)
+	mockHistory := historymocks.NewMockHistoryService(ctrl)
+	service := NewService(mocks.NewMockStore(ctrl)).WithUndo(mockUndo).WithHistory(mockHistory)
+
+	mockUndo.EXPECT().Undo(gomock.Any(), "test-user-id", int64(12)).Return(models.BulkUndoResult{
+		Operation: models.BulkOpArchive,
+		GithubIDs: []string{"notif-1"},
+	}, nil)
+	mockHistory.EXPECT().Record(
+		gomock.Any(), "test-user-id", []string{"notif-1"},
+		models.HistoryUndone, models.HistorySourceUser, "archive",
+	)
+
+	result, err := service.UndoBulkOperation(context.Background(), "test-user-id", 12)
+	require.NoError(t, err)
+	require.Equal(t, models.BulkOpArchive, result.Operation)
+}
+
+func TestService_UndoBulkOperation_WithoutUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(mocks.NewMockStore(ctrl))
+
+	_, err := service.UndoBulkOperation(context.Background(), "test-user-id", 12)
+	require.ErrorIs(t, err, undo.ErrOperationNotFound)
+}
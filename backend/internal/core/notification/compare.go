@@ -0,0 +1,103 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// MaxComparisonIDs caps how many GitHub IDs are returned per set in a query comparison.
+// Counts are always exact.
+const MaxComparisonIDs = 500
+
+// CompareQueries evaluates two queries and returns which notifications match only the left
+// query, only the right query, or both. Both queries go through the same defaults as the
+// notification list, so the result explains exactly why two views differ.
+func (s *Service) CompareQueries(
+	ctx context.Context,
+	userID, leftQuery, rightQuery string,
+) (models.QueryComparison, error) {
+	leftIDs, err := s.matchingGithubIDs(ctx, userID, leftQuery)
+	if err != nil {
+		return models.QueryComparison{}, err
+	}
+	rightIDs, err := s.matchingGithubIDs(ctx, userID, rightQuery)
+	if err != nil {
+		return models.QueryComparison{}, err
+	}
+
+	inRight := make(map[string]struct{}, len(rightIDs))
+	for _, id := range rightIDs {
+		inRight[id] = struct{}{}
+	}
+	inLeft := make(map[string]struct{}, len(leftIDs))
+
+	var onlyLeft, both, onlyRight []string
+	for _, id := range leftIDs {
+		inLeft[id] = struct{}{}
+		if _, ok := inRight[id]; ok {
+			both = append(both, id)
+		} else {
+			onlyLeft = append(onlyLeft, id)
+		}
+	}
+	for _, id := range rightIDs {
+		if _, ok := inLeft[id]; !ok {
+			onlyRight = append(onlyRight, id)
+		}
+	}
+
+	return models.QueryComparison{
+		Left:      models.QueryComparisonSide{Query: leftQuery, Count: len(leftIDs)},
+		Right:     models.QueryComparisonSide{Query: rightQuery, Count: len(rightIDs)},
+		OnlyLeft:  newQueryIDSet(onlyLeft),
+		OnlyRight: newQueryIDSet(onlyRight),
+		Both:      newQueryIDSet(both),
+	}, nil
+}
+
+// matchingGithubIDs returns the GitHub IDs of all notifications matching a query string.
+func (s *Service) matchingGithubIDs(
+	ctx context.Context,
+	userID, queryStr string,
+) ([]string, error) {
+	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidQuery, err)
+	}
+
+	ids, err := s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListNotifications, err)
+	}
+	return ids, nil
+}
+
+func newQueryIDSet(ids []string) models.QueryIDSet {
+	set := models.QueryIDSet{Count: len(ids), IDs: ids}
+	if set.IDs == nil {
+		set.IDs = []string{}
+	}
+	if len(set.IDs) > MaxComparisonIDs {
+		set.IDs = set.IDs[:MaxComparisonIDs]
+		set.Truncated = true
+	}
+	return set
+}
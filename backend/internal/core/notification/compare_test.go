@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+)
+
+func TestService_CompareQueries(t *testing.T) {
+	const testUserID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	service := NewService(mockQuerier)
+
+	gomock.InOrder(
+		mockQuerier.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return([]string{"a", "b", "c"}, nil),
+		mockQuerier.EXPECT().
+			ListNotificationGithubIDsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return([]string{"b", "d"}, nil),
+	)
+
+	result, err := service.CompareQueries(
+		context.Background(),
+		testUserID,
+		"repo:cli/cli",
+		"repo:cli/cli state:open",
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "repo:cli/cli", result.Left.Query)
+	require.Equal(t, 3, result.Left.Count)
+	require.Equal(t, "repo:cli/cli state:open", result.Right.Query)
+	require.Equal(t, 2, result.Right.Count)
+	require.Equal(t, []string{"a", "c"}, result.OnlyLeft.IDs)
+	require.Equal(t, []string{"d"}, result.OnlyRight.IDs)
+	require.Equal(t, []string{"b"}, result.Both.IDs)
+	require.Equal(t, 1, result.Both.Count)
+}
+
+func TestService_CompareQueries_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	service := NewService(mockQuerier)
+
+	_, err := service.CompareQueries(context.Background(), "test-user-id", "repo:(", "")
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestNewQueryIDSet(t *testing.T) {
+	empty := newQueryIDSet(nil)
+	require.Equal(t, 0, empty.Count)
+	require.NotNil(t, empty.IDs)
+	require.False(t, empty.Truncated)
+
+	ids := make([]string, MaxComparisonIDs+10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+	set := newQueryIDSet(ids)
+	require.Equal(t, MaxComparisonIDs+10, set.Count)
+	require.Len(t, set.IDs, MaxComparisonIDs)
+	require.True(t, set.Truncated)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// ErrFailedToExplainQuery is returned when SQLite can't plan a query's statement
+var ErrFailedToExplainQuery = errors.New("failed to explain query")
+
+// ExplainQuery shows how a query is run for its first page in the list, sorted the same
+// way, without running it. Useful for working out why a view shows unexpected results.
+func (s *Service) ExplainQuery(
+	ctx context.Context,
+	userID, queryStr, sort string,
+) (models.QueryExplanation, error) {
+	limit, offset, _, _ := normalizedPagination(models.ListOptions{})
+	dbQuery, err := query.BuildQueryWithOptions(queryStr, limit, offset, false)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrInvalidQuery, err)
+	}
+	switch sort {
+	case "":
+	case models.SortPriority:
+		dbQuery.ByPriority = true
+	default:
+		return models.QueryExplanation{}, fmt.Errorf("%w: %q", ErrInvalidSort, sort)
+	}
+	defaults, err := query.ExplainDefaults(queryStr)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrInvalidQuery, err)
+	}
+
+	plan, err := s.queries.ExplainNotificationQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrFailedToExplainQuery, err)
+	}
+
+	explanation := models.QueryExplanation{
+		Query:    queryStr,
+		Sort:     sort,
+		Joins:    nonNil(dbQuery.Joins),
+		Where:    nonNil(dbQuery.Where),
+		Args:     nonNil(dbQuery.Args),
+		Defaults: defaults,
+		SQL:      plan.SQL,
+		SQLArgs:  nonNil(plan.Args),
+		Plan:     make([]models.QueryPlanStep, 0, len(plan.Steps)),
+	}
+	for _, step := range plan.Steps {
+		explanation.Plan = append(explanation.Plan, models.QueryPlanStep{
+			ID:     step.ID,
+			Parent: step.Parent,
+			Detail: step.Detail,
+		})
+	}
+	return explanation, nil
+}
+
+// nonNil returns an empty slice for nil, so it's written as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_ExplainQuery(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	store.EXPECT().
+		ExplainNotificationQuery(gomock.Any(), userID, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			q db.NotificationQuery,
+		) (db.NotificationQueryPlan, error) {
+			require.True(t, q.ByPriority)
+			require.Equal(t, int32(defaultListPageSize), q.Limit)
+			return db.NotificationQueryPlan{
+				SQL:   "SELECT ...",
+				Args:  append([]interface{}{userID}, q.Args...),
+				Steps: []db.QueryPlanStep{{ID: 2, Detail: "SCAN n"}},
+			}, nil
+		})
+
+	explanation, err := service.ExplainQuery(context.Background(), userID, "repo:cli/cli", models.SortPriority)
+	require.NoError(t, err)
+	require.Equal(t, "repo:cli/cli", explanation.Query)
+	require.NotEmpty(t, explanation.Where)
+	require.Equal(t, userID, explanation.SQLArgs[0])
+	require.Equal(t, models.QueryDefaultScopeMutedOnly, explanation.Defaults.Scope)
+	require.Equal(t, []models.QueryPlanStep{{ID: 2, Detail: "SCAN n"}}, explanation.Plan)
+}
+
+func TestService_ExplainQuery_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := NewService(mocks.NewMockStore(ctrl))
+
+	_, err := service.ExplainQuery(context.Background(), "test-user-id", "repo:(", "")
+	require.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = service.ExplainQuery(context.Background(), "test-user-id", "is:unread", "oldest")
+	require.ErrorIs(t, err, ErrInvalidSort)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notification
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// MaxGroupSize caps how many notifications are returned per group
+const MaxGroupSize = 50
+
+// ErrInvalidGroupKey is returned when notifications are grouped by an unknown key
+var ErrInvalidGroupKey = errors.New("invalid group key: use repo, org, reason, author or type")
+
+// GroupNotifications groups the notifications matching a query and returns each group's
+// count with its newest notifications. The query goes through the same defaults as the
+// notification list, so an empty query groups the inbox.
+func (s *Service) GroupNotifications(
+	ctx context.Context,
+	userID string,
+	opts models.GroupOptions,
+) (models.GroupResult, error) {
+	groupBy := db.NotificationGroupKey(strings.ToLower(strings.TrimSpace(opts.GroupBy)))
+	if !groupBy.Valid() {
+		return models.GroupResult{}, ErrInvalidGroupKey
+	}
+	perGroup := min(max(opts.PerGroup, 0), MaxGroupSize)
+
+	dbQuery, err := query.BuildQueryWithOptions(opts.Query, 0, 0, false)
+	if err != nil {
+		return models.GroupResult{}, errors.Join(ErrInvalidQuery, err)
+	}
+
+	groups, err := s.queries.ListNotificationGroupsFromQuery(ctx, userID, db.NotificationGroupsParams{
+		Query:    dbQuery,
+		GroupBy:  groupBy,
+		PerGroup: int32(perGroup),
+	})
+	if err != nil {
+		return models.GroupResult{}, errors.Join(ErrFailedToListNotifications, err)
+	}
+
+	repoMap, err := s.IndexRepositories(ctx, userID)
+	if err != nil {
+		return models.GroupResult{}, errors.Join(ErrFailedToIndexRepositories, err)
+	}
+
+	evaluator, err := query.NewEvaluator(opts.Query)
+	if err != nil {
+		// Continue with nil evaluator - hints will be conservative (empty)
+		evaluator = nil
+	}
+
+	// Build every group's notifications into one slice so tags and authors are looked up once
+	notifications := make([]db.Notification, 0)
+	ends := make([]int, len(groups))
+	result := models.GroupResult{
+		GroupBy: string(groupBy),
+		Groups:  make([]models.NotificationGroup, len(groups)),
+	}
+	for i, group := range groups {
+		notifications = append(notifications, group.Notifications...)
+		ends[i] = len(notifications)
+		result.Total += group.Count
+	}
+	items, err := s.buildResponses(ctx, userID, notifications, repoMap, evaluator)
+	if err != nil {
+		return models.GroupResult{}, errors.Join(ErrFailedToBuildNotificationResponse, err)
+	}
+	for i := range items {
+		items[i].SubjectRaw = nil
+	}
+	s.attachAuthors(ctx, userID, items)
+
+	start := 0
+	for i, group := range groups {
+		result.Groups[i] = models.NotificationGroup{
+			Key:           group.Key,
+			Count:         group.Count,
+			Notifications: items[start:ends[i]:ends[i]],
+		}
+		start = ends[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_GroupNotifications(t *testing.T) {
+	const testUserID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	service := NewService(mockQuerier)
+
+	mockQuerier.EXPECT().
+		ListNotificationGroupsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.NotificationGroupsParams,
+		) ([]db.NotificationGroup, error) {
+			require.Equal(t, db.NotificationGroupByRepo, params.GroupBy)
+			require.Equal(t, int32(MaxGroupSize), params.PerGroup)
+			require.False(t, params.Query.IncludeSubject)
+			return []db.NotificationGroup{
+				{
+					Key:   "cli/cli",
+					Count: 4,
+					Notifications: []db.Notification{
+						{ID: 1, GithubID: "a", RepositoryID: 7},
+						{ID: 2, GithubID: "b", RepositoryID: 7},
+					},
+				},
+				{Key: "octo/widgets", Count: 1},
+			}, nil
+		})
+	mockQuerier.EXPECT().
+		ListRepositories(gomock.Any(), testUserID).
+		Return([]db.Repository{{ID: 7, FullName: "cli/cli"}}, nil)
+	mockQuerier.EXPECT().
+		ListTagsForNotifications(gomock.Any(), testUserID, []int64{1, 2}).
+		Return(map[int64][]db.Tag{}, nil)
+
+	result, err := service.GroupNotifications(context.Background(), testUserID, models.GroupOptions{
+		GroupBy:  " Repo ",
+		PerGroup: MaxGroupSize + 10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "repo", result.GroupBy)
+	require.Equal(t, int64(5), result.Total)
+	require.Len(t, result.Groups, 2)
+	require.Equal(t, "cli/cli", result.Groups[0].Key)
+	require.Len(t, result.Groups[0].Notifications, 2)
+	require.Equal(t, "a", result.Groups[0].Notifications[0].GithubID)
+	require.Equal(t, "cli/cli", result.Groups[0].Notifications[0].Repository.FullName)
+	require.NotNil(t, result.Groups[1].Notifications)
+	require.Empty(t, result.Groups[1].Notifications)
+}
+
+func TestService_GroupNotifications_InvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	service := NewService(mockQuerier)
+
+	_, err := service.GroupNotifications(context.Background(), "test-user-id", models.GroupOptions{
+		GroupBy: "label",
+	})
+	require.ErrorIs(t, err, ErrInvalidGroupKey)
+
+	_, err = service.GroupNotifications(context.Background(), "test-user-id", models.GroupOptions{
+		Query:   "repo:(",
+		GroupBy: "repo",
+	})
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
@@ -23,6 +23,9 @@ const (
 	defaultListPage     = 1
 	defaultListPageSize = 50
 	maxListPageSize     = 200
+
+	// MaxConversationSize caps how many notifications an expanded conversation returns
+	MaxConversationSize = 200
 )
 
 // normalizedPagination extracts and normalizes pagination parameters
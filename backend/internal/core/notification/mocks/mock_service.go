@@ -58,6 +58,36 @@ func (mr *MockNotificationReaderMockRecorder) BuildResponse(ctx, userID, notific
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildResponse", reflect.TypeOf((*MockNotificationReader)(nil).BuildResponse), ctx, userID, notification, repoMap, evaluator)
 }
 
+// CompareQueries mocks base method.
+func (m *MockNotificationReader) CompareQueries(ctx context.Context, userID, leftQuery, rightQuery string) (models.QueryComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareQueries", ctx, userID, leftQuery, rightQuery)
+	ret0, _ := ret[0].(models.QueryComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareQueries indicates an expected call of CompareQueries.
+func (mr *MockNotificationReaderMockRecorder) CompareQueries(ctx, userID, leftQuery, rightQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareQueries", reflect.TypeOf((*MockNotificationReader)(nil).CompareQueries), ctx, userID, leftQuery, rightQuery)
+}
+
+// ExplainQuery mocks base method.
+func (m *MockNotificationReader) ExplainQuery(ctx context.Context, userID, queryStr, sort string) (models.QueryExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQuery", ctx, userID, queryStr, sort)
+	ret0, _ := ret[0].(models.QueryExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockNotificationReaderMockRecorder) ExplainQuery(ctx, userID, queryStr, sort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockNotificationReader)(nil).ExplainQuery), ctx, userID, queryStr, sort)
+}
+
 // GetByGithubID mocks base method.
 func (m *MockNotificationReader) GetByGithubID(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +133,21 @@ func (mr *MockNotificationReaderMockRecorder) GetTagsForNotification(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsForNotification", reflect.TypeOf((*MockNotificationReader)(nil).GetTagsForNotification), ctx, userID, notificationID)
 }
 
+// GroupNotifications mocks base method.
+func (m *MockNotificationReader) GroupNotifications(ctx context.Context, userID string, opts models.GroupOptions) (models.GroupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupNotifications", ctx, userID, opts)
+	ret0, _ := ret[0].(models.GroupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupNotifications indicates an expected call of GroupNotifications.
+func (mr *MockNotificationReaderMockRecorder) GroupNotifications(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupNotifications", reflect.TypeOf((*MockNotificationReader)(nil).GroupNotifications), ctx, userID, opts)
+}
+
 // IndexRepositories mocks base method.
 func (m *MockNotificationReader) IndexRepositories(ctx context.Context, userID string) (map[int64]db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +163,21 @@ func (mr *MockNotificationReaderMockRecorder) IndexRepositories(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexRepositories", reflect.TypeOf((*MockNotificationReader)(nil).IndexRepositories), ctx, userID)
 }
 
+// ListConversation mocks base method.
+func (m *MockNotificationReader) ListConversation(ctx context.Context, userID, githubID string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversation", ctx, userID, githubID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversation indicates an expected call of ListConversation.
+func (mr *MockNotificationReaderMockRecorder) ListConversation(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversation", reflect.TypeOf((*MockNotificationReader)(nil).ListConversation), ctx, userID, githubID)
+}
+
 // ListNotifications mocks base method.
 func (m *MockNotificationReader) ListNotifications(ctx context.Context, userID string, opts models.ListOptions) (models.ListDetailsResult, error) {
 	m.ctrl.T.Helper()
@@ -178,6 +238,36 @@ func (mr *MockNotificationReaderMockRecorder) NewEvaluator(queryStr any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationReader)(nil).NewEvaluator), queryStr)
 }
 
+// NextNotification mocks base method.
+func (m *MockNotificationReader) NextNotification(ctx context.Context, userID string, opts models.NextOptions) (*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextNotification", ctx, userID, opts)
+	ret0, _ := ret[0].(*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextNotification indicates an expected call of NextNotification.
+func (mr *MockNotificationReaderMockRecorder) NextNotification(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextNotification", reflect.TypeOf((*MockNotificationReader)(nil).NextNotification), ctx, userID, opts)
+}
+
+// RunQuery mocks base method.
+func (m *MockNotificationReader) RunQuery(ctx context.Context, userID string, opts models.ListOptions) (models.QueryRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunQuery", ctx, userID, opts)
+	ret0, _ := ret[0].(models.QueryRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunQuery indicates an expected call of RunQuery.
+func (mr *MockNotificationReaderMockRecorder) RunQuery(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunQuery", reflect.TypeOf((*MockNotificationReader)(nil).RunQuery), ctx, userID, opts)
+}
+
 // MockNotificationWriter is a mock of NotificationWriter interface.
 type MockNotificationWriter struct {
 	ctrl     *gomock.Controller
@@ -263,18 +353,18 @@ func (mr *MockNotificationWriterMockRecorder) MuteNotification(ctx, userID, gith
 }
 
 // SnoozeNotification mocks base method.
-func (m *MockNotificationWriter) SnoozeNotification(ctx context.Context, userID, githubID, snoozedUntil string) (db.Notification, error) {
+func (m *MockNotificationWriter) SnoozeNotification(ctx context.Context, userID, githubID, snoozedUntil string, untilUpdated bool) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, userID, githubID, snoozedUntil)
+	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, userID, githubID, snoozedUntil, untilUpdated)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SnoozeNotification indicates an expected call of SnoozeNotification.
-func (mr *MockNotificationWriterMockRecorder) SnoozeNotification(ctx, userID, githubID, snoozedUntil any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) SnoozeNotification(ctx, userID, githubID, snoozedUntil, untilUpdated any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).SnoozeNotification), ctx, userID, githubID, snoozedUntil)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).SnoozeNotification), ctx, userID, githubID, snoozedUntil, untilUpdated)
 }
 
 // StarNotification mocks base method.
@@ -504,6 +594,21 @@ func (mr *MockBulkOperationsMockRecorder) BulkAssignTag(ctx, userID, notificatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignTag", reflect.TypeOf((*MockBulkOperations)(nil).BulkAssignTag), ctx, userID, notifications, tagID)
 }
 
+// BulkAssignTagByQuery mocks base method.
+func (m *MockBulkOperations) BulkAssignTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAssignTagByQuery", ctx, userID, queryStr, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAssignTagByQuery indicates an expected call of BulkAssignTagByQuery.
+func (mr *MockBulkOperationsMockRecorder) BulkAssignTagByQuery(ctx, userID, queryStr, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignTagByQuery", reflect.TypeOf((*MockBulkOperations)(nil).BulkAssignTagByQuery), ctx, userID, queryStr, tagID)
+}
+
 // BulkRemoveTag mocks base method.
 func (m *MockBulkOperations) BulkRemoveTag(ctx context.Context, userID string, notifications []db.Notification, tagID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -519,6 +624,21 @@ func (mr *MockBulkOperationsMockRecorder) BulkRemoveTag(ctx, userID, notificatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRemoveTag", reflect.TypeOf((*MockBulkOperations)(nil).BulkRemoveTag), ctx, userID, notifications, tagID)
 }
 
+// BulkRemoveTagByQuery mocks base method.
+func (m *MockBulkOperations) BulkRemoveTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkRemoveTagByQuery", ctx, userID, queryStr, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkRemoveTagByQuery indicates an expected call of BulkRemoveTagByQuery.
+func (mr *MockBulkOperationsMockRecorder) BulkRemoveTagByQuery(ctx, userID, queryStr, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRemoveTagByQuery", reflect.TypeOf((*MockBulkOperations)(nil).BulkRemoveTagByQuery), ctx, userID, queryStr, tagID)
+}
+
 // BulkUpdate mocks base method.
 func (m *MockBulkOperations) BulkUpdate(ctx context.Context, userID string, op models.BulkOperationType, target models.BulkOperationTarget, params models.BulkUpdateParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -534,6 +654,52 @@ func (mr *MockBulkOperationsMockRecorder) BulkUpdate(ctx, userID, op, target, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockBulkOperations)(nil).BulkUpdate), ctx, userID, op, target, params)
 }
 
+// BulkUpdateWithUndo mocks base method.
+func (m *MockBulkOperations) BulkUpdateWithUndo(ctx context.Context, userID string, op models.BulkOperationType, target models.BulkOperationTarget, params models.BulkUpdateParams) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateWithUndo", ctx, userID, op, target, params)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BulkUpdateWithUndo indicates an expected call of BulkUpdateWithUndo.
+func (mr *MockBulkOperationsMockRecorder) BulkUpdateWithUndo(ctx, userID, op, target, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateWithUndo", reflect.TypeOf((*MockBulkOperations)(nil).BulkUpdateWithUndo), ctx, userID, op, target, params)
+}
+
+// MarkGroupRead mocks base method.
+func (m *MockBulkOperations) MarkGroupRead(ctx context.Context, userID, subjectURL string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGroupRead", ctx, userID, subjectURL)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkGroupRead indicates an expected call of MarkGroupRead.
+func (mr *MockBulkOperationsMockRecorder) MarkGroupRead(ctx, userID, subjectURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGroupRead", reflect.TypeOf((*MockBulkOperations)(nil).MarkGroupRead), ctx, userID, subjectURL)
+}
+
+// UndoBulkOperation mocks base method.
+func (m *MockBulkOperations) UndoBulkOperation(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoBulkOperation", ctx, userID, id)
+	ret0, _ := ret[0].(models.BulkUndoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoBulkOperation indicates an expected call of UndoBulkOperation.
+func (mr *MockBulkOperationsMockRecorder) UndoBulkOperation(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoBulkOperation", reflect.TypeOf((*MockBulkOperations)(nil).UndoBulkOperation), ctx, userID, id)
+}
+
 // MockNotificationService is a mock of NotificationService interface.
 type MockNotificationService struct {
 	ctrl     *gomock.Controller
@@ -633,6 +799,21 @@ func (mr *MockNotificationServiceMockRecorder) BulkAssignTag(ctx, userID, notifi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignTag", reflect.TypeOf((*MockNotificationService)(nil).BulkAssignTag), ctx, userID, notifications, tagID)
 }
 
+// BulkAssignTagByQuery mocks base method.
+func (m *MockNotificationService) BulkAssignTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAssignTagByQuery", ctx, userID, queryStr, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAssignTagByQuery indicates an expected call of BulkAssignTagByQuery.
+func (mr *MockNotificationServiceMockRecorder) BulkAssignTagByQuery(ctx, userID, queryStr, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignTagByQuery", reflect.TypeOf((*MockNotificationService)(nil).BulkAssignTagByQuery), ctx, userID, queryStr, tagID)
+}
+
 // BulkRemoveTag mocks base method.
 func (m *MockNotificationService) BulkRemoveTag(ctx context.Context, userID string, notifications []db.Notification, tagID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -648,6 +829,21 @@ func (mr *MockNotificationServiceMockRecorder) BulkRemoveTag(ctx, userID, notifi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRemoveTag", reflect.TypeOf((*MockNotificationService)(nil).BulkRemoveTag), ctx, userID, notifications, tagID)
 }
 
+// BulkRemoveTagByQuery mocks base method.
+func (m *MockNotificationService) BulkRemoveTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkRemoveTagByQuery", ctx, userID, queryStr, tagID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkRemoveTagByQuery indicates an expected call of BulkRemoveTagByQuery.
+func (mr *MockNotificationServiceMockRecorder) BulkRemoveTagByQuery(ctx, userID, queryStr, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRemoveTagByQuery", reflect.TypeOf((*MockNotificationService)(nil).BulkRemoveTagByQuery), ctx, userID, queryStr, tagID)
+}
+
 // BulkUpdate mocks base method.
 func (m *MockNotificationService) BulkUpdate(ctx context.Context, userID string, op models.BulkOperationType, target models.BulkOperationTarget, params models.BulkUpdateParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -663,6 +859,52 @@ func (mr *MockNotificationServiceMockRecorder) BulkUpdate(ctx, userID, op, targe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockNotificationService)(nil).BulkUpdate), ctx, userID, op, target, params)
 }
 
+// BulkUpdateWithUndo mocks base method.
+func (m *MockNotificationService) BulkUpdateWithUndo(ctx context.Context, userID string, op models.BulkOperationType, target models.BulkOperationTarget, params models.BulkUpdateParams) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateWithUndo", ctx, userID, op, target, params)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BulkUpdateWithUndo indicates an expected call of BulkUpdateWithUndo.
+func (mr *MockNotificationServiceMockRecorder) BulkUpdateWithUndo(ctx, userID, op, target, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateWithUndo", reflect.TypeOf((*MockNotificationService)(nil).BulkUpdateWithUndo), ctx, userID, op, target, params)
+}
+
+// CompareQueries mocks base method.
+func (m *MockNotificationService) CompareQueries(ctx context.Context, userID, leftQuery, rightQuery string) (models.QueryComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareQueries", ctx, userID, leftQuery, rightQuery)
+	ret0, _ := ret[0].(models.QueryComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareQueries indicates an expected call of CompareQueries.
+func (mr *MockNotificationServiceMockRecorder) CompareQueries(ctx, userID, leftQuery, rightQuery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareQueries", reflect.TypeOf((*MockNotificationService)(nil).CompareQueries), ctx, userID, leftQuery, rightQuery)
+}
+
+// ExplainQuery mocks base method.
+func (m *MockNotificationService) ExplainQuery(ctx context.Context, userID, queryStr, sort string) (models.QueryExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQuery", ctx, userID, queryStr, sort)
+	ret0, _ := ret[0].(models.QueryExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockNotificationServiceMockRecorder) ExplainQuery(ctx, userID, queryStr, sort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockNotificationService)(nil).ExplainQuery), ctx, userID, queryStr, sort)
+}
+
 // GetByGithubID mocks base method.
 func (m *MockNotificationService) GetByGithubID(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -708,6 +950,21 @@ func (mr *MockNotificationServiceMockRecorder) GetTagsForNotification(ctx, userI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsForNotification", reflect.TypeOf((*MockNotificationService)(nil).GetTagsForNotification), ctx, userID, notificationID)
 }
 
+// GroupNotifications mocks base method.
+func (m *MockNotificationService) GroupNotifications(ctx context.Context, userID string, opts models.GroupOptions) (models.GroupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupNotifications", ctx, userID, opts)
+	ret0, _ := ret[0].(models.GroupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupNotifications indicates an expected call of GroupNotifications.
+func (mr *MockNotificationServiceMockRecorder) GroupNotifications(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupNotifications", reflect.TypeOf((*MockNotificationService)(nil).GroupNotifications), ctx, userID, opts)
+}
+
 // IndexRepositories mocks base method.
 func (m *MockNotificationService) IndexRepositories(ctx context.Context, userID string) (map[int64]db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -723,6 +980,21 @@ func (mr *MockNotificationServiceMockRecorder) IndexRepositories(ctx, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexRepositories", reflect.TypeOf((*MockNotificationService)(nil).IndexRepositories), ctx, userID)
 }
 
+// ListConversation mocks base method.
+func (m *MockNotificationService) ListConversation(ctx context.Context, userID, githubID string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversation", ctx, userID, githubID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversation indicates an expected call of ListConversation.
+func (mr *MockNotificationServiceMockRecorder) ListConversation(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversation", reflect.TypeOf((*MockNotificationService)(nil).ListConversation), ctx, userID, githubID)
+}
+
 // ListNotifications mocks base method.
 func (m *MockNotificationService) ListNotifications(ctx context.Context, userID string, opts models.ListOptions) (models.ListDetailsResult, error) {
 	m.ctrl.T.Helper()
@@ -768,6 +1040,21 @@ func (mr *MockNotificationServiceMockRecorder) ListPollNotifications(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPollNotifications", reflect.TypeOf((*MockNotificationService)(nil).ListPollNotifications), ctx, userID, opts)
 }
 
+// MarkGroupRead mocks base method.
+func (m *MockNotificationService) MarkGroupRead(ctx context.Context, userID, subjectURL string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGroupRead", ctx, userID, subjectURL)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkGroupRead indicates an expected call of MarkGroupRead.
+func (mr *MockNotificationServiceMockRecorder) MarkGroupRead(ctx, userID, subjectURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGroupRead", reflect.TypeOf((*MockNotificationService)(nil).MarkGroupRead), ctx, userID, subjectURL)
+}
+
 // MarkNotificationRead mocks base method.
 func (m *MockNotificationService) MarkNotificationRead(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -828,6 +1115,21 @@ func (mr *MockNotificationServiceMockRecorder) NewEvaluator(queryStr any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationService)(nil).NewEvaluator), queryStr)
 }
 
+// NextNotification mocks base method.
+func (m *MockNotificationService) NextNotification(ctx context.Context, userID string, opts models.NextOptions) (*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextNotification", ctx, userID, opts)
+	ret0, _ := ret[0].(*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextNotification indicates an expected call of NextNotification.
+func (mr *MockNotificationServiceMockRecorder) NextNotification(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextNotification", reflect.TypeOf((*MockNotificationService)(nil).NextNotification), ctx, userID, opts)
+}
+
 // RemoveTag mocks base method.
 func (m *MockNotificationService) RemoveTag(ctx context.Context, userID, githubID, tagID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -843,19 +1145,34 @@ func (mr *MockNotificationServiceMockRecorder) RemoveTag(ctx, userID, githubID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockNotificationService)(nil).RemoveTag), ctx, userID, githubID, tagID)
 }
 
+// RunQuery mocks base method.
+func (m *MockNotificationService) RunQuery(ctx context.Context, userID string, opts models.ListOptions) (models.QueryRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunQuery", ctx, userID, opts)
+	ret0, _ := ret[0].(models.QueryRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunQuery indicates an expected call of RunQuery.
+func (mr *MockNotificationServiceMockRecorder) RunQuery(ctx, userID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunQuery", reflect.TypeOf((*MockNotificationService)(nil).RunQuery), ctx, userID, opts)
+}
+
 // SnoozeNotification mocks base method.
-func (m *MockNotificationService) SnoozeNotification(ctx context.Context, userID, githubID, snoozedUntil string) (db.Notification, error) {
+func (m *MockNotificationService) SnoozeNotification(ctx context.Context, userID, githubID, snoozedUntil string, untilUpdated bool) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, userID, githubID, snoozedUntil)
+	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, userID, githubID, snoozedUntil, untilUpdated)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SnoozeNotification indicates an expected call of SnoozeNotification.
-func (mr *MockNotificationServiceMockRecorder) SnoozeNotification(ctx, userID, githubID, snoozedUntil any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) SnoozeNotification(ctx, userID, githubID, snoozedUntil, untilUpdated any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).SnoozeNotification), ctx, userID, githubID, snoozedUntil)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).SnoozeNotification), ctx, userID, githubID, snoozedUntil, untilUpdated)
 }
 
 // StarNotification mocks base method.
@@ -888,6 +1205,21 @@ func (mr *MockNotificationServiceMockRecorder) UnarchiveNotification(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockNotificationService)(nil).UnarchiveNotification), ctx, userID, githubID)
 }
 
+// UndoBulkOperation mocks base method.
+func (m *MockNotificationService) UndoBulkOperation(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoBulkOperation", ctx, userID, id)
+	ret0, _ := ret[0].(models.BulkUndoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoBulkOperation indicates an expected call of UndoBulkOperation.
+func (mr *MockNotificationServiceMockRecorder) UndoBulkOperation(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoBulkOperation", reflect.TypeOf((*MockNotificationService)(nil).UndoBulkOperation), ctx, userID, id)
+}
+
 // UnfilterNotification mocks base method.
 func (m *MockNotificationService) UnfilterNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -976,3 +1308,41 @@ func (mr *MockNotificationServiceMockRecorder) UpsertNotification(ctx, userID, p
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotification", reflect.TypeOf((*MockNotificationService)(nil).UpsertNotification), ctx, userID, params)
 }
+
+// MockGitHubMarker is a mock of GitHubMarker interface.
+type MockGitHubMarker struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitHubMarkerMockRecorder
+	isgomock struct{}
+}
+
+// MockGitHubMarkerMockRecorder is the mock recorder for MockGitHubMarker.
+type MockGitHubMarkerMockRecorder struct {
+	mock *MockGitHubMarker
+}
+
+// NewMockGitHubMarker creates a new mock instance.
+func NewMockGitHubMarker(ctrl *gomock.Controller) *MockGitHubMarker {
+	mock := &MockGitHubMarker{ctrl: ctrl}
+	mock.recorder = &MockGitHubMarkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitHubMarker) EXPECT() *MockGitHubMarkerMockRecorder {
+	return m.recorder
+}
+
+// EnqueueMarkOnGitHub mocks base method.
+func (m *MockGitHubMarker) EnqueueMarkOnGitHub(ctx context.Context, userID string, githubIDs []string, done bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueMarkOnGitHub", ctx, userID, githubIDs, done)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueMarkOnGitHub indicates an expected call of EnqueueMarkOnGitHub.
+func (mr *MockGitHubMarkerMockRecorder) EnqueueMarkOnGitHub(ctx, userID, githubIDs, done any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueMarkOnGitHub", reflect.TypeOf((*MockGitHubMarker)(nil).EnqueueMarkOnGitHub), ctx, userID, githubIDs, done)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// Cursor conditions select the notifications listed after or before the cursor
+// notification, comparing the same columns the list is ordered by. The cursor is looked up
+// by GitHub ID, so it doesn't need to match the query.
+const (
+	afterCursorCondition = "(n.effective_sort_date, n.imported_at, n.id) < " +
+		"(SELECT c.effective_sort_date, c.imported_at, c.id FROM notifications c " +
+		"WHERE c.user_id = n.user_id AND c.github_id = ?)"
+	beforeCursorCondition = "(n.effective_sort_date, n.imported_at, n.id) > " +
+		"(SELECT c.effective_sort_date, c.imported_at, c.id FROM notifications c " +
+		"WHERE c.user_id = n.user_id AND c.github_id = ?)"
+)
+
+// NextNotification returns the notification after (or before) a cursor in the query's list
+// order, or the first one without a cursor, so the list can be stepped through one
+// notification at a time. Archiving the cursor and asking for the one after it still
+// advances. Returns nil past either end of the list.
+func (s *Service) NextNotification(
+	ctx context.Context,
+	userID string,
+	opts models.NextOptions,
+) (*models.Notification, error) {
+	dbQuery, err := query.BuildQuery(opts.Query, 1, 0)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidQuery, err)
+	}
+
+	cursor, condition := opts.After, afterCursorCondition
+	if opts.Before != "" {
+		cursor, condition = opts.Before, beforeCursorCondition
+		dbQuery.Reverse = true
+	}
+	if cursor != "" {
+		// An unknown cursor has no position in the list
+		if _, err := s.GetByGithubID(ctx, userID, cursor); err != nil {
+			return nil, err
+		}
+		dbQuery.Where = append(dbQuery.Where, condition)
+		dbQuery.Args = append(dbQuery.Args, cursor)
+	}
+
+	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListNotifications, err)
+	}
+	if len(result.Notifications) == 0 {
+		return nil, nil
+	}
+
+	notification, err := s.buildDetails(ctx, userID, result.Notifications[0], opts.Query)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildNotificationResponse, err)
+	}
+	return &notification, nil
+}
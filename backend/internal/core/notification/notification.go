@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
@@ -31,6 +32,8 @@ import (
 // Error definitions
 var (
 	ErrInvalidQuery                      = errors.New("invalid query")
+	ErrInvalidCursor                     = errors.New("invalid cursor")
+	ErrInvalidSort                       = errors.New("invalid sort")
 	ErrFailedToBuildQuery                = errors.New("failed to build query")
 	ErrFailedToListNotifications         = errors.New("failed to list notifications")
 	ErrFailedToIndexRepositories         = errors.New("failed to index repositories")
@@ -67,6 +70,21 @@ func (s *Service) ListNotifications(
 		// Wrap query errors in a high-level error type
 		return models.ListDetailsResult{}, errors.Join(ErrInvalidQuery, err)
 	}
+	dbQuery.CollapseBySubject = opts.Conversations
+	switch opts.Sort {
+	case "":
+	case models.SortPriority:
+		dbQuery.ByPriority = true
+	default:
+		return models.ListDetailsResult{}, fmt.Errorf("%w: %q", ErrInvalidSort, opts.Sort)
+	}
+	if opts.Cursor != "" {
+		cursor, err := db.DecodeNotificationCursor(opts.Cursor)
+		if err != nil {
+			return models.ListDetailsResult{}, errors.Join(ErrInvalidCursor, err)
+		}
+		dbQuery.After = &cursor
+	}
 
 	// Execute query
 	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
@@ -88,23 +106,42 @@ func (s *Service) ListNotifications(
 		evaluator = nil
 	}
 
-	// Build responses for each notification
-	responses := make([]models.Notification, 0, len(result.Notifications))
-	for _, notification := range result.Notifications {
-		item, err := s.BuildResponse(ctx, userID, notification, repoMap, evaluator)
-		if err != nil {
-			return models.ListDetailsResult{}, errors.Join(
-				ErrFailedToBuildNotificationResponse,
-				err,
-			)
-		}
-
+	// Build responses for the whole page at once so tags are fetched in one query
+	responses, err := s.buildResponses(ctx, userID, result.Notifications, repoMap, evaluator)
+	if err != nil {
+		return models.ListDetailsResult{}, errors.Join(
+			ErrFailedToBuildNotificationResponse,
+			err,
+		)
+	}
+	for i, notification := range result.Notifications {
 		// Conditionally exclude subjectRaw to reduce payload size for list views
 		if !opts.IncludeSubject {
-			item.SubjectRaw = nil
+			responses[i].SubjectRaw = nil
 		}
 
-		responses = append(responses, item)
+		if opts.Conversations {
+			responses[i].Conversation = &models.Conversation{
+				Count:        result.ConversationSizes[notification.ID],
+				NewestReason: responses[i].Reason,
+			}
+		}
+	}
+
+	s.attachAuthors(ctx, userID, responses)
+
+	var defaults *models.QueryDefaults
+	if opts.ExplainDefaults {
+		explained, err := query.ExplainDefaults(opts.Query)
+		if err != nil {
+			return models.ListDetailsResult{}, errors.Join(ErrInvalidQuery, err)
+		}
+		defaults = &explained
+	}
+
+	var next string
+	if result.NextCursor != nil {
+		next = result.NextCursor.Encode()
 	}
 
 	return models.ListDetailsResult{
@@ -112,6 +149,8 @@ func (s *Service) ListNotifications(
 		Total:         result.Total,
 		Page:          page,
 		PageSize:      pageSize,
+		Defaults:      defaults,
+		NextCursor:    next,
 	}, nil
 }
 
@@ -228,6 +267,10 @@ func (s *Service) UpsertNotification(
 	if err != nil {
 		return db.Notification{}, errors.Join(ErrFailedToUpsertNotification, err)
 	}
+	s.publish(userID, models.Event{
+		Type:     models.EventNotificationUpserted,
+		GithubID: notification.GithubID,
+	})
 	return notification, nil
 }
 
@@ -243,6 +286,47 @@ func (s *Service) UpdateNotificationSubject(
 	return nil
 }
 
+// ListConversation returns every notification sharing the notification's subject, newest
+// first, so a collapsed conversation can be expanded. A notification without a subject URL
+// is a conversation of its own.
+func (s *Service) ListConversation(
+	ctx context.Context,
+	userID, githubID string,
+) ([]models.Notification, error) {
+	notification, err := s.GetByGithubID(ctx, userID, githubID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []db.Notification{notification}
+	if notification.SubjectURL.Valid && notification.SubjectURL.String != "" {
+		result, err := s.queries.ListNotificationsFromQuery(ctx, userID, db.NotificationQuery{
+			Where: []string{"n.subject_url = ?"},
+			Args:  []interface{}{notification.SubjectURL.String},
+			Limit: MaxConversationSize,
+		})
+		if err != nil {
+			return nil, errors.Join(ErrFailedToListNotifications, err)
+		}
+		notifications = result.Notifications
+	}
+
+	repoMap, err := s.IndexRepositories(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToIndexRepositories, err)
+	}
+
+	items, err := s.buildResponses(ctx, userID, notifications, repoMap, nil)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildNotificationResponse, err)
+	}
+	for i := range items {
+		items[i].SubjectRaw = nil
+	}
+	s.attachAuthors(ctx, userID, items)
+	return items, nil
+}
+
 // GetNotificationWithDetails retrieves a single notification with all enriched data
 func (s *Service) GetNotificationWithDetails(
 	ctx context.Context, userID, githubID, queryStr string,
@@ -251,7 +335,17 @@ func (s *Service) GetNotificationWithDetails(
 	if err != nil {
 		return models.Notification{}, err
 	}
+	return s.buildDetails(ctx, userID, notification, queryStr)
+}
 
+// buildDetails builds the enriched response for a single notification, with action hints
+// for the query it's viewed in
+func (s *Service) buildDetails(
+	ctx context.Context,
+	userID string,
+	notification db.Notification,
+	queryStr string,
+) (models.Notification, error) {
 	// Always create evaluator, even for empty queries, so action hints work correctly
 	evaluator, err := query.NewEvaluator(queryStr)
 	if err != nil {
@@ -260,5 +354,12 @@ func (s *Service) GetNotificationWithDetails(
 	}
 
 	// Build response (no repoMap needed for single notification)
-	return s.BuildResponse(ctx, userID, notification, nil, evaluator)
+	item, err := s.BuildResponse(ctx, userID, notification, nil, evaluator)
+	if err != nil {
+		return models.Notification{}, err
+	}
+
+	items := []models.Notification{item}
+	s.attachAuthors(ctx, userID, items)
+	return items[0], nil
 }
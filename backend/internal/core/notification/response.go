@@ -23,6 +23,7 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query/eval"
+	"github.com/octobud-hq/octobud/backend/internal/sanitize"
 )
 
 // BuildResponse builds a notification response with all enriched data
@@ -32,8 +33,62 @@ func (s *Service) BuildResponse(
 	notification db.Notification,
 	repoMap map[int64]db.Repository,
 	evaluator *eval.Evaluator,
+) (models.Notification, error) {
+	// Fetch tags for this notification
+	tags, err := s.queries.ListTagsForEntity(ctx, userID, db.ListTagsForEntityParams{
+		EntityType: "notification",
+		EntityID:   notification.ID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.Notification{}, errors.Join(ErrFailedToFetchTags, err)
+	}
+	return s.buildResponse(ctx, userID, notification, tags, repoMap, evaluator)
+}
+
+// buildResponses builds responses for a page of notifications, fetching all of their tags
+// in one query
+func (s *Service) buildResponses(
+	ctx context.Context,
+	userID string,
+	notifications []db.Notification,
+	repoMap map[int64]db.Repository,
+	evaluator *eval.Evaluator,
+) ([]models.Notification, error) {
+	if len(notifications) == 0 {
+		return []models.Notification{}, nil
+	}
+	ids := make([]int64, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.ID
+	}
+	tags, err := s.queries.ListTagsForNotifications(ctx, userID, ids)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToFetchTags, err)
+	}
+
+	items := make([]models.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		item, err := s.buildResponse(ctx, userID, notification, tags[notification.ID], repoMap, evaluator)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// buildResponse builds a notification response given the notification's tags
+func (s *Service) buildResponse(
+	ctx context.Context,
+	userID string,
+	notification db.Notification,
+	tags []db.Tag,
+	repoMap map[int64]db.Repository,
+	evaluator *eval.Evaluator,
 ) (models.Notification, error) {
 	item := models.NotificationFromDB(notification)
+	// The subject body is rendered as markdown by the browser
+	item.SubjectRaw = sanitize.SubjectBody(item.SubjectRaw)
 
 	var repo *db.Repository
 
@@ -67,15 +122,6 @@ func (s *Service) BuildResponse(
 		}
 	}
 
-	// Fetch tags for this notification
-	tags, err := s.queries.ListTagsForEntity(ctx, userID, db.ListTagsForEntityParams{
-		EntityType: "notification",
-		EntityID:   notification.ID,
-	})
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return models.Notification{}, errors.Join(ErrFailedToFetchTags, err)
-	}
-
 	if len(tags) > 0 {
 		item.Tags = make([]models.Tag, 0, len(tags))
 		for _, tag := range tags {
@@ -89,6 +135,9 @@ func (s *Service) BuildResponse(
 			if tag.Description.Valid {
 				tagResp.Description = &tag.Description.String
 			}
+			if tag.AssignedAt.Valid {
+				tagResp.AssignedAt = &tag.AssignedAt.Time
+			}
 			item.Tags = append(item.Tags, tagResp)
 		}
 	}
@@ -101,6 +150,30 @@ func (s *Service) BuildResponse(
 	return item, nil
 }
 
+// attachAuthors sets the author profile on each notification that has an author login
+func (s *Service) attachAuthors(ctx context.Context, userID string, items []models.Notification) {
+	if s.authorProfiles == nil {
+		return
+	}
+
+	logins := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.AuthorLogin != nil {
+			logins = append(logins, *item.AuthorLogin)
+		}
+	}
+
+	profiles := s.authorProfiles.Profiles(ctx, userID, logins)
+	for i := range items {
+		if items[i].AuthorLogin == nil {
+			continue
+		}
+		if profile, ok := profiles[*items[i].AuthorLogin]; ok {
+			items[i].Author = &profile
+		}
+	}
+}
+
 // IndexRepositories creates a map of repository ID to repository for efficient lookup
 func (s *Service) IndexRepositories(
 	ctx context.Context,
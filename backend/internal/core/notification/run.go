@@ -0,0 +1,58 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// RunQuery lists a page of the notifications matching an ad-hoc query, the same way the
+// list does, and counts how many of all the matches are unread. An empty query runs the
+// inbox, matching the list.
+func (s *Service) RunQuery(
+	ctx context.Context,
+	userID string,
+	opts models.ListOptions,
+) (models.QueryRunResult, error) {
+	list, err := s.ListNotifications(ctx, userID, opts)
+	if err != nil {
+		return models.QueryRunResult{}, err
+	}
+
+	unreadQuery := "in:inbox is:unread"
+	if queryStr := strings.TrimSpace(opts.Query); queryStr != "" {
+		unreadQuery = fmt.Sprintf("(%s) AND is:unread", queryStr)
+	}
+	dbQuery, err := query.BuildQuery(unreadQuery, 1, 0)
+	if err != nil {
+		return models.QueryRunResult{}, errors.Join(ErrInvalidQuery, err)
+	}
+	// Count conversations when the list does, so both counts use the same unit
+	dbQuery.CollapseBySubject = opts.Conversations
+
+	unread, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return models.QueryRunResult{}, errors.Join(ErrFailedToListNotifications, err)
+	}
+
+	return models.QueryRunResult{ListDetailsResult: list, UnreadCount: unread.Total}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+func TestService_RunQuery(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	unreadQuery, err := query.BuildQuery("(repo:cli/cli) AND is:unread", 1, 0)
+	require.NoError(t, err)
+	unreadQuery.CollapseBySubject = true
+
+	gomock.InOrder(
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				q db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				require.True(t, q.ByPriority)
+				require.True(t, q.CollapseBySubject)
+				return db.ListNotificationsFromQueryResult{Total: 12}, nil
+			}),
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, unreadQuery).
+			Return(db.ListNotificationsFromQueryResult{Total: 5}, nil),
+	)
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil).AnyTimes()
+
+	result, err := service.RunQuery(context.Background(), userID, models.ListOptions{
+		Query:         "repo:cli/cli",
+		Sort:          models.SortPriority,
+		Conversations: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(12), result.Total)
+	require.Equal(t, int64(5), result.UnreadCount)
+}
+
+func TestService_RunQuery_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := NewService(mocks.NewMockStore(ctrl))
+
+	_, err := service.RunQuery(context.Background(), "test-user-id", models.ListOptions{Query: "repo:("})
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
@@ -18,6 +18,10 @@ package notification
 import (
 	"context"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/authorprofile"
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/undo"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query/eval"
@@ -43,6 +47,24 @@ type NotificationReader interface {
 		userID, queryStr string,
 		limit int32,
 	) ([]db.Notification, error)
+	CompareQueries(
+		ctx context.Context,
+		userID, leftQuery, rightQuery string,
+	) (models.QueryComparison, error)
+	RunQuery(
+		ctx context.Context,
+		userID string,
+		opts models.ListOptions,
+	) (models.QueryRunResult, error)
+	ExplainQuery(
+		ctx context.Context,
+		userID, queryStr, sort string,
+	) (models.QueryExplanation, error)
+	GroupNotifications(
+		ctx context.Context,
+		userID string,
+		opts models.GroupOptions,
+	) (models.GroupResult, error)
 	GetTagsForNotification(
 		ctx context.Context,
 		userID string,
@@ -53,6 +75,12 @@ type NotificationReader interface {
 		ctx context.Context,
 		userID, githubID, queryStr string,
 	) (models.Notification, error)
+	ListConversation(ctx context.Context, userID, githubID string) ([]models.Notification, error)
+	NextNotification(
+		ctx context.Context,
+		userID string,
+		opts models.NextOptions,
+	) (*models.Notification, error)
 	BuildResponse(
 		ctx context.Context,
 		userID string,
@@ -84,6 +112,7 @@ type NotificationWriter interface {
 	SnoozeNotification(
 		ctx context.Context,
 		userID, githubID, snoozedUntil string,
+		untilUpdated bool,
 	) (db.Notification, error)
 	UnsnoozeNotification(ctx context.Context, userID, githubID string) (db.Notification, error)
 	MuteNotification(ctx context.Context, userID, githubID string) (db.Notification, error)
@@ -116,6 +145,9 @@ type BulkOperations interface {
 		notifications []db.Notification,
 		tagID string,
 	) (int, error)
+	BulkAssignTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error)
+	BulkRemoveTagByQuery(ctx context.Context, userID, queryStr, tagID string) (int64, error)
+	MarkGroupRead(ctx context.Context, userID, subjectURL string) ([]string, error)
 	BulkUpdate(
 		ctx context.Context,
 		userID string,
@@ -123,6 +155,14 @@ type BulkOperations interface {
 		target models.BulkOperationTarget,
 		params models.BulkUpdateParams,
 	) (int64, error)
+	BulkUpdateWithUndo(
+		ctx context.Context,
+		userID string,
+		op models.BulkOperationType,
+		target models.BulkOperationTarget,
+		params models.BulkUpdateParams,
+	) (int64, int64, error)
+	UndoBulkOperation(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error)
 }
 
 // NotificationService is the composed interface containing all notification operations.
@@ -138,7 +178,17 @@ type NotificationService interface {
 
 // Service provides higher-level operations over notification records.
 type Service struct {
-	queries db.Store
+	queries        db.Store
+	authorProfiles authorprofile.AuthorProfileService
+	events         events.EventBus
+	history        history.HistoryService
+	undo           undo.UndoService
+	github         GitHubMarker
+}
+
+// GitHubMarker queues threads to be marked read, or done, on GitHub
+type GitHubMarker interface {
+	EnqueueMarkOnGitHub(ctx context.Context, userID string, githubIDs []string, done bool) error
 }
 
 // NewService constructs a Service backed by the provided queries.
@@ -147,3 +197,81 @@ func NewService(queries db.Store) *Service {
 		queries: queries,
 	}
 }
+
+// WithAuthorProfiles joins cached author profiles into notification responses.
+func (s *Service) WithAuthorProfiles(profiles authorprofile.AuthorProfileService) *Service {
+	s.authorProfiles = profiles
+	return s
+}
+
+// WithEvents publishes notification upserts and bulk operation results to the event bus.
+func (s *Service) WithEvents(bus events.EventBus) *Service {
+	s.events = bus
+	return s
+}
+
+// WithHistory records the user's changes in each notification's history.
+func (s *Service) WithHistory(h history.HistoryService) *Service {
+	s.history = h
+	return s
+}
+
+// WithUndo lets bulk operations made through BulkUpdateWithUndo be undone.
+func (s *Service) WithUndo(u undo.UndoService) *Service {
+	s.undo = u
+	return s
+}
+
+// WithGitHubMarker marks notifications the user reads or archives as read or done on
+// GitHub, when they have that turned on in their sync settings.
+func (s *Service) WithGitHubMarker(m GitHubMarker) *Service {
+	s.github = m
+	return s
+}
+
+// recordHistory adds a change the user made to the notifications' history, if it's kept
+func (s *Service) recordHistory(
+	ctx context.Context,
+	userID string,
+	githubIDs []string,
+	action models.HistoryAction,
+) {
+	if s.history == nil {
+		return
+	}
+	s.history.Record(ctx, userID, githubIDs, action, models.HistorySourceUser, "")
+}
+
+// markOnGitHub queues read notifications to be marked read on GitHub and archived ones to
+// be marked done, if the user has that turned on. Other changes have no GitHub equivalent.
+func (s *Service) markOnGitHub(
+	ctx context.Context,
+	userID string,
+	githubIDs []string,
+	action models.HistoryAction,
+) {
+	if s.github == nil || len(githubIDs) == 0 {
+		return
+	}
+	if action != models.HistoryRead && action != models.HistoryArchived {
+		return
+	}
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		return
+	}
+	settings, err := models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	if err != nil || settings == nil || !settings.MarkOnGitHub {
+		return
+	}
+	// The queue logs what it fails to enqueue; the change made here stands either way
+	_ = s.github.EnqueueMarkOnGitHub(ctx, userID, githubIDs, action == models.HistoryArchived)
+}
+
+// publish sends an event to the user's live update subscribers, if there's a bus
+func (s *Service) publish(userID string, event models.Event) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(userID, event)
+}
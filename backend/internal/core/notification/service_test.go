@@ -25,8 +25,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	authorprofilemocks "github.com/octobud-hq/octobud/backend/internal/core/authorprofile/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 func TestService_GetByGithubID(t *testing.T) {
@@ -501,3 +503,395 @@ func TestService_IndexRepositories(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ListNotifications_AttachesAuthors(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	profiles := authorprofilemocks.NewMockAuthorProfileService(ctrl)
+	service := NewService(store).WithAuthorProfiles(profiles)
+
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{
+				{
+					ID:          1,
+					GithubID:    "n1",
+					AuthorLogin: sql.NullString{String: "dependabot[bot]", Valid: true},
+				},
+				{ID: 2, GithubID: "n2"},
+			},
+			Total: 2,
+		}, nil)
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+	store.EXPECT().
+		ListTagsForNotifications(gomock.Any(), userID, []int64{1, 2}).
+		Return(map[int64][]db.Tag{}, nil)
+	profiles.EXPECT().
+		Profiles(gomock.Any(), userID, []string{"dependabot[bot]"}).
+		Return(map[string]models.AuthorProfile{
+			"dependabot[bot]": {Login: "dependabot[bot]", Type: models.AuthorTypeBot},
+		})
+
+	result, err := service.ListNotifications(context.Background(), userID, models.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Notifications, 2)
+	require.NotNil(t, result.Notifications[0].Author)
+	require.True(t, result.Notifications[0].Author.IsBot())
+	require.Nil(t, result.Notifications[1].Author)
+}
+
+func TestService_ListNotifications_ExplainDefaults(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{}, nil).
+		Times(2)
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil).Times(2)
+
+	result, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+		Query: "-in:archive",
+	})
+	require.NoError(t, err)
+	require.Nil(t, result.Defaults)
+
+	result, err = service.ListNotifications(context.Background(), userID, models.ListOptions{
+		Query:           "-in:archive",
+		ExplainDefaults: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Defaults)
+	require.Equal(t, models.QueryDefaultScopeMutedOnly, result.Defaults.Scope)
+	require.Equal(t, []string{"muted"}, result.Defaults.Excluded)
+}
+
+func TestService_ListNotifications_Conversations(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			query db.NotificationQuery,
+		) (db.ListNotificationsFromQueryResult, error) {
+			require.True(t, query.CollapseBySubject)
+			return db.ListNotificationsFromQueryResult{
+				Notifications: []db.Notification{
+					{ID: 1, GithubID: "n1", Reason: sql.NullString{String: "mention", Valid: true}},
+					{ID: 2, GithubID: "n2"},
+				},
+				Total:             2,
+				ConversationSizes: map[int64]int64{1: 3, 2: 1},
+			}, nil
+		})
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+	store.EXPECT().
+		ListTagsForNotifications(gomock.Any(), userID, []int64{1, 2}).
+		Return(map[int64][]db.Tag{}, nil)
+
+	result, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+		Conversations: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Notifications, 2)
+	require.Equal(t, int64(3), result.Notifications[0].Conversation.Count)
+	require.Equal(t, "mention", *result.Notifications[0].Conversation.NewestReason)
+	require.Equal(t, int64(1), result.Notifications[1].Conversation.Count)
+	require.Nil(t, result.Notifications[1].Conversation.NewestReason)
+}
+
+func TestService_ListNotifications_FetchesTagsOnce(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{{ID: 1, GithubID: "n1"}, {ID: 2, GithubID: "n2"}},
+			Total:         2,
+		}, nil)
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+	store.EXPECT().
+		ListTagsForNotifications(gomock.Any(), userID, []int64{1, 2}).
+		Return(map[int64][]db.Tag{
+			2: {{ID: "tag-1", Name: "urgent", Color: sql.NullString{String: "red", Valid: true}}},
+		}, nil)
+
+	result, err := service.ListNotifications(context.Background(), userID, models.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Notifications, 2)
+	require.Empty(t, result.Notifications[0].Tags)
+	require.Len(t, result.Notifications[1].Tags, 1)
+	require.Equal(t, "urgent", result.Notifications[1].Tags[0].Name)
+	require.Equal(t, "red", *result.Notifications[1].Tags[0].Color)
+}
+
+func TestService_ListNotifications_TagError(t *testing.T) {
+	const userID = "test-user-id"
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	service := NewService(store)
+
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{{ID: 1, GithubID: "n1"}},
+			Total:         1,
+		}, nil)
+	store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+	store.EXPECT().
+		ListTagsForNotifications(gomock.Any(), userID, []int64{1}).
+		Return(nil, errors.New("database is locked"))
+
+	_, err := service.ListNotifications(context.Background(), userID, models.ListOptions{})
+	require.ErrorIs(t, err, ErrFailedToFetchTags)
+}
+
+func TestService_ListConversation(t *testing.T) {
+	const userID = "test-user-id"
+	subjectURL := "https://api.github.com/repos/cli/cli/pulls/1"
+
+	t.Run("lists notifications sharing the subject", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "n1").Return(db.Notification{
+			ID:         1,
+			GithubID:   "n1",
+			SubjectURL: sql.NullString{String: subjectURL, Valid: true},
+		}, nil)
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, db.NotificationQuery{
+				Where: []string{"n.subject_url = ?"},
+				Args:  []interface{}{subjectURL},
+				Limit: MaxConversationSize,
+			}).
+			Return(db.ListNotificationsFromQueryResult{
+				Notifications: []db.Notification{{ID: 2, GithubID: "n2"}, {ID: 1, GithubID: "n1"}},
+				Total:         2,
+			}, nil)
+		store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+		store.EXPECT().
+			ListTagsForNotifications(gomock.Any(), userID, []int64{2, 1}).
+			Return(map[int64][]db.Tag{}, nil)
+
+		notifications, err := service.ListConversation(context.Background(), userID, "n1")
+		require.NoError(t, err)
+		require.Len(t, notifications, 2)
+		require.Equal(t, "n2", notifications[0].GithubID)
+		require.Equal(t, "n1", notifications[1].GithubID)
+	})
+
+	t.Run("a notification without a subject is its own conversation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "n1").
+			Return(db.Notification{ID: 1, GithubID: "n1"}, nil)
+		store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+		store.EXPECT().
+			ListTagsForNotifications(gomock.Any(), userID, []int64{1}).
+			Return(map[int64][]db.Tag{}, nil)
+
+		notifications, err := service.ListConversation(context.Background(), userID, "n1")
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		require.Equal(t, "n1", notifications[0].GithubID)
+	})
+
+	t.Run("unknown notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "missing").
+			Return(db.Notification{}, sql.ErrNoRows)
+
+		_, err := service.ListConversation(context.Background(), userID, "missing")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestService_NextNotification(t *testing.T) {
+	const userID = "test-user-id"
+
+	t.Run("steps forward from the cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "n1").
+			Return(db.Notification{ID: 1, GithubID: "n1"}, nil)
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				query db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				require.False(t, query.Reverse)
+				require.Equal(t, int32(1), query.Limit)
+				require.Contains(t, query.Where, afterCursorCondition)
+				require.Equal(t, "n1", query.Args[len(query.Args)-1])
+				return db.ListNotificationsFromQueryResult{
+					Notifications: []db.Notification{{ID: 2, GithubID: "n2"}},
+					Total:         1,
+				}, nil
+			})
+		store.EXPECT().ListTagsForEntity(gomock.Any(), userID, gomock.Any()).Return(nil, nil)
+
+		next, err := service.NextNotification(context.Background(), userID, models.NextOptions{
+			Query: "is:unread",
+			After: "n1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "n2", next.GithubID)
+	})
+
+	t.Run("steps back in reverse order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "n2").
+			Return(db.Notification{ID: 2, GithubID: "n2"}, nil)
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				query db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				require.True(t, query.Reverse)
+				require.Contains(t, query.Where, beforeCursorCondition)
+				return db.ListNotificationsFromQueryResult{}, nil
+			})
+
+		next, err := service.NextNotification(context.Background(), userID, models.NextOptions{
+			Before: "n2",
+		})
+		require.NoError(t, err)
+		require.Nil(t, next)
+	})
+
+	t.Run("unknown cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().GetNotificationByGithubID(gomock.Any(), userID, "missing").
+			Return(db.Notification{}, sql.ErrNoRows)
+
+		_, err := service.NextNotification(context.Background(), userID, models.NextOptions{
+			After: "missing",
+		})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.NextNotification(context.Background(), userID, models.NextOptions{
+			Query: "is:",
+		})
+		require.ErrorIs(t, err, ErrInvalidQuery)
+	})
+}
+
+func TestService_ListNotifications_Cursor(t *testing.T) {
+	const userID = "test-user-id"
+	cursor := db.NotificationCursor{SortDate: "2025-01-02T00:00:00Z", ImportedAt: "2025-01-01T00:00:00Z", ID: 7}
+	next := db.NotificationCursor{SortDate: "2025-01-01T00:00:00Z", ImportedAt: "2025-01-01T00:00:00Z", ID: 3}
+
+	t.Run("pages after the cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				query db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				require.Equal(t, &cursor, query.After)
+				return db.ListNotificationsFromQueryResult{
+					Notifications: []db.Notification{{ID: 3, GithubID: "n3"}},
+					Total:         5,
+					NextCursor:    &next,
+				}, nil
+			})
+		store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil)
+		store.EXPECT().
+			ListTagsForNotifications(gomock.Any(), userID, []int64{3}).
+			Return(map[int64][]db.Tag{}, nil)
+
+		result, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+			Cursor: cursor.Encode(),
+		})
+		require.NoError(t, err)
+		require.Equal(t, next.Encode(), result.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+			Cursor: "garbage",
+		})
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestService_ListNotifications_Sort(t *testing.T) {
+	const userID = "test-user-id"
+
+	t.Run("by priority", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		service := NewService(store)
+
+		store.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				query db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				require.True(t, query.ByPriority)
+				return db.ListNotificationsFromQueryResult{}, nil
+			})
+		store.EXPECT().ListRepositories(gomock.Any(), userID).Return(nil, nil).AnyTimes()
+
+		_, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+			Sort: models.SortPriority,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.ListNotifications(context.Background(), userID, models.ListOptions{
+			Sort: "oldest",
+		})
+		require.ErrorIs(t, err, ErrInvalidSort)
+	})
+}
@@ -781,3 +781,65 @@ func TestService_BulkAssignTag(t *testing.T) {
 		})
 	}
 }
+
+func TestService_BulkTagByQuery(t *testing.T) {
+	const testUserID = "test-user-id"
+
+	t.Run("assigns tag to notifications matching the query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			BulkAssignTagByQuery(gomock.Any(), testUserID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, arg db.BulkTagNotificationsByQueryParams) (int64, error) {
+				require.Equal(t, "10", arg.TagID)
+				require.NotEmpty(t, arg.Query.Where)
+				return 3, nil
+			})
+		service := NewService(mockQuerier)
+
+		count, err := service.BulkAssignTagByQuery(context.Background(), testUserID, "is:unread", "10")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("removes tag from notifications matching the query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			BulkRemoveTagByQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(int64(2), nil)
+		service := NewService(mockQuerier)
+
+		count, err := service.BulkRemoveTagByQuery(context.Background(), testUserID, "", "10")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), count)
+	})
+
+	t.Run("invalid query is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.BulkAssignTagByQuery(context.Background(), testUserID, "is:", "10")
+		require.ErrorIs(t, err, ErrInvalidQuery)
+	})
+
+	t.Run("store error is returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			BulkRemoveTagByQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(int64(0), errors.New("database error"))
+		service := NewService(mockQuerier)
+
+		_, err := service.BulkRemoveTagByQuery(context.Background(), testUserID, "is:unread", "10")
+		require.Error(t, err)
+	})
+}
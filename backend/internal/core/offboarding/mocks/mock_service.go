@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/offboarding/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/offboarding/service.go -destination=internal/core/offboarding/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOffboardingService is a mock of OffboardingService interface.
+type MockOffboardingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOffboardingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOffboardingServiceMockRecorder is the mock recorder for MockOffboardingService.
+type MockOffboardingServiceMockRecorder struct {
+	mock *MockOffboardingService
+}
+
+// NewMockOffboardingService creates a new mock instance.
+func NewMockOffboardingService(ctrl *gomock.Controller) *MockOffboardingService {
+	mock := &MockOffboardingService{ctrl: ctrl}
+	mock.recorder = &MockOffboardingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOffboardingService) EXPECT() *MockOffboardingServiceMockRecorder {
+	return m.recorder
+}
+
+// ListOffboardings mocks base method.
+func (m *MockOffboardingService) ListOffboardings(ctx context.Context, userID string) ([]models.OrgOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOffboardings", ctx, userID)
+	ret0, _ := ret[0].([]models.OrgOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOffboardings indicates an expected call of ListOffboardings.
+func (mr *MockOffboardingServiceMockRecorder) ListOffboardings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOffboardings", reflect.TypeOf((*MockOffboardingService)(nil).ListOffboardings), ctx, userID)
+}
+
+// OffboardOrg mocks base method.
+func (m *MockOffboardingService) OffboardOrg(ctx context.Context, userID, org string, action models.OffboardAction) (models.OrgOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffboardOrg", ctx, userID, org, action)
+	ret0, _ := ret[0].(models.OrgOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OffboardOrg indicates an expected call of OffboardOrg.
+func (mr *MockOffboardingServiceMockRecorder) OffboardOrg(ctx, userID, org, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffboardOrg", reflect.TypeOf((*MockOffboardingService)(nil).OffboardOrg), ctx, userID, org, action)
+}
+
+// RejoinOrg mocks base method.
+func (m *MockOffboardingService) RejoinOrg(ctx context.Context, userID, org string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejoinOrg", ctx, userID, org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejoinOrg indicates an expected call of RejoinOrg.
+func (mr *MockOffboardingServiceMockRecorder) RejoinOrg(ctx, userID, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejoinOrg", reflect.TypeOf((*MockOffboardingService)(nil).RejoinOrg), ctx, userID, org)
+}
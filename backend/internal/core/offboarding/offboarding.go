@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package offboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrInvalidOrg        = errors.New("invalid organization name")
+	ErrInvalidAction     = errors.New("invalid offboarding action")
+	ErrOrgNotOffboarded  = errors.New("organization is not offboarded")
+	ErrFailedToOffboard  = errors.New("failed to offboard organization")
+	ErrFailedToList      = errors.New("failed to list offboarded organizations")
+	ErrFailedToRejoinOrg = errors.New("failed to rejoin organization")
+)
+
+// orgPattern matches GitHub organization and user names
+var orgPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// archiveQuery finds the org's notifications that are still to be archived
+func archiveQuery(org string) string {
+	return "org:" + org + " archived:false in:anywhere"
+}
+
+// OffboardOrg archives or deletes the org's notifications, stops syncing its repositories
+// and records a summary. Offboarding an org again replaces the earlier summary.
+func (s *Service) OffboardOrg(
+	ctx context.Context,
+	userID, org string,
+	action models.OffboardAction,
+) (models.OrgOffboarding, error) {
+	if !orgPattern.MatchString(org) {
+		return models.OrgOffboarding{}, errors.Join(ErrInvalidOrg, fmt.Errorf("org: %q", org))
+	}
+	if action != models.OffboardArchive && action != models.OffboardDelete {
+		return models.OrgOffboarding{}, errors.Join(ErrInvalidAction, fmt.Errorf("action: %q", action))
+	}
+
+	repositories, err := s.queries.CountRepositoriesByOrg(ctx, userID, org)
+	if err != nil {
+		return models.OrgOffboarding{}, errors.Join(ErrFailedToOffboard, err)
+	}
+
+	// Stop syncing first so nothing arrives between clearing the org out and recording it
+	if _, err := s.record(ctx, userID, org, action, 0, repositories); err != nil {
+		return models.OrgOffboarding{}, err
+	}
+
+	var affected int64
+	if action == models.OffboardDelete {
+		affected, err = s.queries.DeleteNotificationsByOrg(ctx, userID, org)
+	} else {
+		affected, err = s.notifications.BulkUpdate(
+			ctx,
+			userID,
+			models.BulkOpArchive,
+			models.BulkOperationTarget{Query: archiveQuery(org)},
+			models.BulkUpdateParams{},
+		)
+	}
+	if err != nil {
+		return models.OrgOffboarding{}, errors.Join(ErrFailedToOffboard, err)
+	}
+
+	return s.record(ctx, userID, org, action, affected, repositories)
+}
+
+// record saves the summary of an offboarding
+func (s *Service) record(
+	ctx context.Context,
+	userID, org string,
+	action models.OffboardAction,
+	affected, repositories int64,
+) (models.OrgOffboarding, error) {
+	offboarding, err := s.queries.UpsertOrgOffboarding(ctx, userID, db.UpsertOrgOffboardingParams{
+		Org:                   org,
+		Action:                string(action),
+		NotificationsAffected: affected,
+		Repositories:          repositories,
+	})
+	if err != nil {
+		return models.OrgOffboarding{}, errors.Join(ErrFailedToOffboard, err)
+	}
+	return toModel(offboarding), nil
+}
+
+// ListOffboardings lists the orgs the user left, most recent first
+func (s *Service) ListOffboardings(
+	ctx context.Context,
+	userID string,
+) ([]models.OrgOffboarding, error) {
+	offboardings, err := s.queries.ListOrgOffboardings(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToList, err)
+	}
+	result := make([]models.OrgOffboarding, len(offboardings))
+	for i, o := range offboardings {
+		result[i] = toModel(o)
+	}
+	return result, nil
+}
+
+// RejoinOrg syncs an offboarded org again
+func (s *Service) RejoinOrg(ctx context.Context, userID, org string) error {
+	deleted, err := s.queries.DeleteOrgOffboarding(ctx, userID, org)
+	if err != nil {
+		return errors.Join(ErrFailedToRejoinOrg, err)
+	}
+	if deleted == 0 {
+		return errors.Join(ErrOrgNotOffboarded, fmt.Errorf("org: %q", org))
+	}
+	return nil
+}
+
+func toModel(o db.OrgOffboarding) models.OrgOffboarding {
+	return models.OrgOffboarding{
+		Org:                   o.Org,
+		Action:                models.OffboardAction(o.Action),
+		NotificationsAffected: o.NotificationsAffected,
+		Repositories:          o.Repositories,
+		OffboardedAt:          o.CreatedAt,
+	}
+}
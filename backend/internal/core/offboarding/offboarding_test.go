@@ -0,0 +1,165 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package offboarding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	notificationmocks "github.com/octobud-hq/octobud/backend/internal/core/notification/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func setupService(
+	t *testing.T,
+) (*Service, *dbmocks.MockStore, *notificationmocks.MockNotificationService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	store := dbmocks.NewMockStore(ctrl)
+	notifications := notificationmocks.NewMockNotificationService(ctrl)
+	return NewService(store, notifications), store, notifications
+}
+
+// recorded returns what the store hands back for an upserted offboarding
+func recorded(arg db.UpsertOrgOffboardingParams) db.OrgOffboarding {
+	return db.OrgOffboarding{
+		Org:                   arg.Org,
+		Action:                arg.Action,
+		NotificationsAffected: arg.NotificationsAffected,
+		Repositories:          arg.Repositories,
+		CreatedAt:             testNow,
+	}
+}
+
+func TestService_OffboardOrg_Archive(t *testing.T) {
+	svc, store, notifications := setupService(t)
+	ctx := context.Background()
+
+	store.EXPECT().CountRepositoriesByOrg(gomock.Any(), "user-1", "acme").Return(int64(3), nil)
+	gomock.InOrder(
+		// Sync stops before anything is archived
+		store.EXPECT().
+			UpsertOrgOffboarding(gomock.Any(), "user-1", db.UpsertOrgOffboardingParams{
+				Org: "acme", Action: "archive", Repositories: 3,
+			}).
+			DoAndReturn(func(_ context.Context, _ string, arg db.UpsertOrgOffboardingParams) (db.OrgOffboarding, error) {
+				return recorded(arg), nil
+			}),
+		notifications.EXPECT().
+			BulkUpdate(
+				gomock.Any(),
+				"user-1",
+				models.BulkOpArchive,
+				models.BulkOperationTarget{Query: "org:acme archived:false in:anywhere"},
+				models.BulkUpdateParams{},
+			).
+			Return(int64(42), nil),
+		store.EXPECT().
+			UpsertOrgOffboarding(gomock.Any(), "user-1", db.UpsertOrgOffboardingParams{
+				Org: "acme", Action: "archive", NotificationsAffected: 42, Repositories: 3,
+			}).
+			DoAndReturn(func(_ context.Context, _ string, arg db.UpsertOrgOffboardingParams) (db.OrgOffboarding, error) {
+				return recorded(arg), nil
+			}),
+	)
+
+	summary, err := svc.OffboardOrg(ctx, "user-1", "acme", models.OffboardArchive)
+	require.NoError(t, err)
+	require.Equal(t, models.OrgOffboarding{
+		Org:                   "acme",
+		Action:                models.OffboardArchive,
+		NotificationsAffected: 42,
+		Repositories:          3,
+		OffboardedAt:          testNow,
+	}, summary)
+}
+
+func TestService_OffboardOrg_Delete(t *testing.T) {
+	svc, store, _ := setupService(t)
+	ctx := context.Background()
+
+	store.EXPECT().CountRepositoriesByOrg(gomock.Any(), "user-1", "acme").Return(int64(1), nil)
+	store.EXPECT().
+		UpsertOrgOffboarding(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertOrgOffboardingParams) (db.OrgOffboarding, error) {
+			return recorded(arg), nil
+		}).
+		Times(2)
+	store.EXPECT().DeleteNotificationsByOrg(gomock.Any(), "user-1", "acme").Return(int64(7), nil)
+
+	summary, err := svc.OffboardOrg(ctx, "user-1", "acme", models.OffboardDelete)
+	require.NoError(t, err)
+	require.Equal(t, models.OffboardDelete, summary.Action)
+	require.Equal(t, int64(7), summary.NotificationsAffected)
+	require.Equal(t, int64(1), summary.Repositories)
+}
+
+func TestService_OffboardOrg_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		org     string
+		action  models.OffboardAction
+		wantErr error
+	}{
+		{name: "empty org", org: "", action: models.OffboardArchive, wantErr: ErrInvalidOrg},
+		{name: "org with a slash", org: "acme/widgets", action: models.OffboardArchive, wantErr: ErrInvalidOrg},
+		{name: "org with a query", org: "acme in:inbox", action: models.OffboardArchive, wantErr: ErrInvalidOrg},
+		{name: "unknown action", org: "acme", action: "trash", wantErr: ErrInvalidAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := setupService(t)
+			_, err := svc.OffboardOrg(context.Background(), "user-1", tt.org, tt.action)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_OffboardOrg_ArchiveFails(t *testing.T) {
+	svc, store, notifications := setupService(t)
+
+	store.EXPECT().CountRepositoriesByOrg(gomock.Any(), "user-1", "acme").Return(int64(0), nil)
+	store.EXPECT().
+		UpsertOrgOffboarding(gomock.Any(), "user-1", gomock.Any()).
+		Return(db.OrgOffboarding{}, nil)
+	notifications.EXPECT().
+		BulkUpdate(gomock.Any(), "user-1", models.BulkOpArchive, gomock.Any(), gomock.Any()).
+		Return(int64(0), errors.New("database error"))
+
+	_, err := svc.OffboardOrg(context.Background(), "user-1", "acme", models.OffboardArchive)
+	require.ErrorIs(t, err, ErrFailedToOffboard)
+}
+
+func TestService_RejoinOrg(t *testing.T) {
+	svc, store, _ := setupService(t)
+	ctx := context.Background()
+
+	store.EXPECT().DeleteOrgOffboarding(gomock.Any(), "user-1", "acme").Return(int64(1), nil)
+	require.NoError(t, svc.RejoinOrg(ctx, "user-1", "acme"))
+
+	store.EXPECT().DeleteOrgOffboarding(gomock.Any(), "user-1", "other").Return(int64(0), nil)
+	require.ErrorIs(t, svc.RejoinOrg(ctx, "user-1", "other"), ErrOrgNotOffboarded)
+}
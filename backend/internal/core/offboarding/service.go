@@ -0,0 +1,56 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package offboarding clears out organizations the user has left: their notifications
+// are archived or deleted and their repositories stop being synced.
+package offboarding
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/notification"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// OffboardingService is the interface for offboarding organizations.
+type OffboardingService interface {
+	// OffboardOrg archives or deletes the org's notifications, stops syncing its
+	// repositories and records a summary.
+	OffboardOrg(
+		ctx context.Context,
+		userID, org string,
+		action models.OffboardAction,
+	) (models.OrgOffboarding, error)
+	// ListOffboardings lists the orgs the user left, most recent first.
+	ListOffboardings(ctx context.Context, userID string) ([]models.OrgOffboarding, error)
+	// RejoinOrg syncs an offboarded org again. Notifications archived or deleted when it
+	// was offboarded aren't brought back.
+	RejoinOrg(ctx context.Context, userID, org string) error
+}
+
+// Service offboards organizations
+type Service struct {
+	queries       db.Store
+	notifications notification.BulkOperations
+}
+
+// NewService constructs a Service backed by the store and notification service
+func NewService(queries db.Store, notifications notification.BulkOperations) *Service {
+	return &Service{
+		queries:       queries,
+		notifications: notifications,
+	}
+}
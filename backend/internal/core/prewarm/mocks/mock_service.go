@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/prewarm/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/prewarm/service.go -destination=internal/core/prewarm/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	db "github.com/octobud-hq/octobud/backend/internal/db"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPrewarmService is a mock of PrewarmService interface.
+type MockPrewarmService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrewarmServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPrewarmServiceMockRecorder is the mock recorder for MockPrewarmService.
+type MockPrewarmServiceMockRecorder struct {
+	mock *MockPrewarmService
+}
+
+// NewMockPrewarmService creates a new mock instance.
+func NewMockPrewarmService(ctrl *gomock.Controller) *MockPrewarmService {
+	mock := &MockPrewarmService{ctrl: ctrl}
+	mock.recorder = &MockPrewarmServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrewarmService) EXPECT() *MockPrewarmServiceMockRecorder {
+	return m.recorder
+}
+
+// ClearAccess mocks base method.
+func (m *MockPrewarmService) ClearAccess(ctx context.Context, userID, kind string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearAccess", ctx, userID, kind)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearAccess indicates an expected call of ClearAccess.
+func (mr *MockPrewarmServiceMockRecorder) ClearAccess(ctx, userID, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearAccess", reflect.TypeOf((*MockPrewarmService)(nil).ClearAccess), ctx, userID, kind)
+}
+
+// ForgetTimelines mocks base method.
+func (m *MockPrewarmService) ForgetTimelines(userID, githubID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgetTimelines", userID, githubID)
+}
+
+// ForgetTimelines indicates an expected call of ForgetTimelines.
+func (mr *MockPrewarmServiceMockRecorder) ForgetTimelines(userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetTimelines", reflect.TypeOf((*MockPrewarmService)(nil).ForgetTimelines), userID, githubID)
+}
+
+// PutTimeline mocks base method.
+func (m *MockPrewarmService) PutTimeline(userID, key string, result *models.TimelineResult) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PutTimeline", userID, key, result)
+}
+
+// PutTimeline indicates an expected call of PutTimeline.
+func (mr *MockPrewarmServiceMockRecorder) PutTimeline(userID, key, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTimeline", reflect.TypeOf((*MockPrewarmService)(nil).PutTimeline), userID, key, result)
+}
+
+// PutViewCounts mocks base method.
+func (m *MockPrewarmService) PutViewCounts(userID string, views []models.View) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PutViewCounts", userID, views)
+}
+
+// PutViewCounts indicates an expected call of PutViewCounts.
+func (mr *MockPrewarmServiceMockRecorder) PutViewCounts(userID, views any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutViewCounts", reflect.TypeOf((*MockPrewarmService)(nil).PutViewCounts), userID, views)
+}
+
+// RecentAccess mocks base method.
+func (m *MockPrewarmService) RecentAccess(ctx context.Context, userID, kind string) ([]db.RecentAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentAccess", ctx, userID, kind)
+	ret0, _ := ret[0].([]db.RecentAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentAccess indicates an expected call of RecentAccess.
+func (mr *MockPrewarmServiceMockRecorder) RecentAccess(ctx, userID, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentAccess", reflect.TypeOf((*MockPrewarmService)(nil).RecentAccess), ctx, userID, kind)
+}
+
+// RecordAccess mocks base method.
+func (m *MockPrewarmService) RecordAccess(ctx context.Context, userID, kind, target string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAccess", ctx, userID, kind, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAccess indicates an expected call of RecordAccess.
+func (mr *MockPrewarmServiceMockRecorder) RecordAccess(ctx, userID, kind, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAccess", reflect.TypeOf((*MockPrewarmService)(nil).RecordAccess), ctx, userID, kind, target)
+}
+
+// TakeTimeline mocks base method.
+func (m *MockPrewarmService) TakeTimeline(userID, key string) (*models.TimelineResult, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeTimeline", userID, key)
+	ret0, _ := ret[0].(*models.TimelineResult)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// TakeTimeline indicates an expected call of TakeTimeline.
+func (mr *MockPrewarmServiceMockRecorder) TakeTimeline(userID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeTimeline", reflect.TypeOf((*MockPrewarmService)(nil).TakeTimeline), userID, key)
+}
+
+// TakeViewCounts mocks base method.
+func (m *MockPrewarmService) TakeViewCounts(userID string) ([]models.View, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeViewCounts", userID)
+	ret0, _ := ret[0].([]models.View)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// TakeViewCounts indicates an expected call of TakeViewCounts.
+func (mr *MockPrewarmServiceMockRecorder) TakeViewCounts(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeViewCounts", reflect.TypeOf((*MockPrewarmService)(nil).TakeViewCounts), userID)
+}
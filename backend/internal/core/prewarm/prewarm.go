@@ -0,0 +1,220 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package prewarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const (
+	// KindQuery is a notification list the user opened; its target is the list's query
+	KindQuery = "query"
+	// KindNotification is a notification the user opened; its target is the GitHub ID
+	KindNotification = "notification"
+	// KindAdHocQuery is a query the user ran without saving it as a view; its target is
+	// the query. These make up the user's recent query history.
+	KindAdHocQuery = "adhoc_query"
+
+	// KeepRecent is how many entries of each kind are remembered
+	KeepRecent = 10
+	// KeepRecentQueries is how many ad-hoc queries are remembered
+	KeepRecentQueries = 25
+	// DefaultTTL is how long a warmed result can be served. Warming runs just before the
+	// browser opens, so anything older belongs to a launch nobody looked at.
+	DefaultTTL = 2 * time.Minute
+	// TimelinePerPage matches the first timeline page the notification detail loads
+	TimelinePerPage = 10
+)
+
+// Error definitions
+var (
+	ErrInvalidKind               = errors.New("invalid recent access kind")
+	ErrFailedToRecordAccess      = errors.New("failed to record recent access")
+	ErrFailedToListRecentAccess  = errors.New("failed to list recent access")
+	ErrFailedToClearRecentAccess = errors.New("failed to clear recent access")
+)
+
+// cachedViews are warmed views with their counts and when they stop being fresh
+type cachedViews struct {
+	views   []models.View
+	expires time.Time
+}
+
+// cachedTimeline is a warmed timeline page and when it stops being fresh
+type cachedTimeline struct {
+	result  *models.TimelineResult
+	expires time.Time
+}
+
+// Service records recent access and holds warmed results. Each warmed result is served
+// once, so every request after the first sees live data.
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	views     map[string]cachedViews
+	timelines map[string]cachedTimeline
+}
+
+// NewService constructs a Service backed by the provided store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries:   queries,
+		now:       now,
+		ttl:       DefaultTTL,
+		views:     make(map[string]cachedViews),
+		timelines: make(map[string]cachedTimeline),
+	}
+}
+
+// WithTTL sets how long warmed results can be served
+func (s *Service) WithTTL(ttl time.Duration) *Service {
+	s.ttl = ttl
+	return s
+}
+
+// TimelineKey identifies a timeline page for a notification. It includes when GitHub last
+// updated the thread, so a page warmed before new activity is never served after it.
+func TimelineKey(n db.Notification, perPage, page int) string {
+	updated := ""
+	if n.GithubUpdatedAt.Valid {
+		updated = n.GithubUpdatedAt.Time.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s|%s|%d|%d", n.GithubID, updated, perPage, page)
+}
+
+// keepRecent returns how many entries of a kind are remembered, or false if the kind
+// isn't known
+func keepRecent(kind string) (int64, bool) {
+	switch kind {
+	case KindQuery, KindNotification:
+		return KeepRecent, true
+	case KindAdHocQuery:
+		return KeepRecentQueries, true
+	default:
+		return 0, false
+	}
+}
+
+// RecordAccess records that the user opened a notification list or notification, or ran
+// an ad-hoc query, forgetting all but the most recent entries of its kind
+func (s *Service) RecordAccess(ctx context.Context, userID, kind, target string) error {
+	keep, ok := keepRecent(kind)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+	err := s.queries.RecordRecentAccess(ctx, userID, db.RecordRecentAccessParams{
+		Kind:       kind,
+		Target:     target,
+		AccessedAt: s.now().UTC(),
+		Keep:       keep,
+	})
+	if err != nil {
+		return errors.Join(ErrFailedToRecordAccess, err)
+	}
+	return nil
+}
+
+// RecentAccess lists the most recently opened entries of a kind, newest first
+func (s *Service) RecentAccess(ctx context.Context, userID, kind string) ([]db.RecentAccess, error) {
+	keep, ok := keepRecent(kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+	entries, err := s.queries.ListRecentAccess(ctx, userID, kind, keep)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListRecentAccess, err)
+	}
+	return entries, nil
+}
+
+// ClearAccess forgets every entry of a kind, such as the user's recent query history
+func (s *Service) ClearAccess(ctx context.Context, userID, kind string) error {
+	if _, ok := keepRecent(kind); !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+	if err := s.queries.ClearRecentAccess(ctx, userID, kind); err != nil {
+		return errors.Join(ErrFailedToClearRecentAccess, err)
+	}
+	return nil
+}
+
+// PutViewCounts stores views with their unread counts for the user's next request
+func (s *Service) PutViewCounts(userID string, views []models.View) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.views[userID] = cachedViews{views: views, expires: s.now().Add(s.ttl)}
+}
+
+// TakeViewCounts returns the warmed view counts and forgets them, if they're still fresh
+func (s *Service) TakeViewCounts(userID string) ([]models.View, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.views[userID]
+	if !ok {
+		return nil, false
+	}
+	delete(s.views, userID)
+	if !s.now().Before(cached.expires) {
+		return nil, false
+	}
+	return cached.views, true
+}
+
+// PutTimeline stores a warmed timeline page under a key from TimelineKey
+func (s *Service) PutTimeline(userID, key string, result *models.TimelineResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timelines[userID+"|"+key] = cachedTimeline{result: result, expires: s.now().Add(s.ttl)}
+}
+
+// TakeTimeline returns a warmed timeline page and forgets it, if it's still fresh
+func (s *Service) TakeTimeline(userID, key string) (*models.TimelineResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.timelines[userID+"|"+key]
+	if !ok {
+		return nil, false
+	}
+	delete(s.timelines, userID+"|"+key)
+	if !s.now().Before(cached.expires) {
+		return nil, false
+	}
+	return cached.result, true
+}
+
+// ForgetTimelines drops every warmed timeline page for a notification, such as after the
+// user adds to the thread and the warmed pages no longer show the latest activity.
+func (s *Service) ForgetTimelines(userID, githubID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prefix := userID + "|" + githubID + "|"
+	for key := range s.timelines {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.timelines, key)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package prewarm
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const testUserID = "test-user-id"
+
+func TestService_RecordAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mockStore, func() time.Time { return now })
+
+	mockStore.EXPECT().
+		RecordRecentAccess(gomock.Any(), testUserID, db.RecordRecentAccessParams{
+			Kind:       KindNotification,
+			Target:     "n1",
+			AccessedAt: now,
+			Keep:       KeepRecent,
+		}).
+		Return(nil)
+
+	require.NoError(t, svc.RecordAccess(context.Background(), testUserID, KindNotification, "n1"))
+}
+
+func TestService_RecordAccess_RejectsUnknownKind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc := NewService(mocks.NewMockStore(ctrl), time.Now)
+
+	err := svc.RecordAccess(context.Background(), testUserID, "view", "inbox")
+	require.ErrorIs(t, err, ErrInvalidKind)
+}
+
+func TestService_AdHocQueryHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mockStore, func() time.Time { return now })
+
+	// Ad-hoc queries keep a longer history than the lists warmed at launch
+	mockStore.EXPECT().
+		RecordRecentAccess(gomock.Any(), testUserID, db.RecordRecentAccessParams{
+			Kind:       KindAdHocQuery,
+			Target:     "repo:cli/cli is:unread",
+			AccessedAt: now,
+			Keep:       KeepRecentQueries,
+		}).
+		Return(nil)
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), testUserID, KindAdHocQuery, int64(KeepRecentQueries)).
+		Return([]db.RecentAccess{{Kind: KindAdHocQuery, Target: "repo:cli/cli is:unread"}}, nil)
+	mockStore.EXPECT().ClearRecentAccess(gomock.Any(), testUserID, KindAdHocQuery).Return(nil)
+
+	ctx := context.Background()
+	require.NoError(t, svc.RecordAccess(ctx, testUserID, KindAdHocQuery, "repo:cli/cli is:unread"))
+	entries, err := svc.RecentAccess(ctx, testUserID, KindAdHocQuery)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NoError(t, svc.ClearAccess(ctx, testUserID, KindAdHocQuery))
+
+	require.ErrorIs(t, svc.ClearAccess(ctx, testUserID, "view"), ErrInvalidKind)
+}
+
+func TestService_TakeViewCounts_ServedOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(nil, func() time.Time { return now })
+
+	views := []models.View{{ID: "v1", UnreadCount: 3}}
+	svc.PutViewCounts(testUserID, views)
+
+	got, ok := svc.TakeViewCounts(testUserID)
+	require.True(t, ok)
+	require.Equal(t, views, got)
+
+	// The next request computes live counts
+	_, ok = svc.TakeViewCounts(testUserID)
+	require.False(t, ok)
+}
+
+func TestService_TakeViewCounts_Expired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(nil, func() time.Time { return now })
+
+	svc.PutViewCounts(testUserID, []models.View{{ID: "v1"}})
+	now = now.Add(DefaultTTL)
+
+	_, ok := svc.TakeViewCounts(testUserID)
+	require.False(t, ok)
+}
+
+func TestService_TakeTimeline(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(nil, func() time.Time { return now })
+
+	n := db.Notification{
+		GithubID:        "n1",
+		GithubUpdatedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+	}
+	result := &models.TimelineResult{Total: 1, Page: 1, PerPage: TimelinePerPage}
+	svc.PutTimeline(testUserID, TimelineKey(n, TimelinePerPage, 1), result)
+
+	// Other users and pages don't see it
+	_, ok := svc.TakeTimeline("other-user", TimelineKey(n, TimelinePerPage, 1))
+	require.False(t, ok)
+	_, ok = svc.TakeTimeline(testUserID, TimelineKey(n, TimelinePerPage, 2))
+	require.False(t, ok)
+
+	got, ok := svc.TakeTimeline(testUserID, TimelineKey(n, TimelinePerPage, 1))
+	require.True(t, ok)
+	require.Equal(t, result, got)
+
+	_, ok = svc.TakeTimeline(testUserID, TimelineKey(n, TimelinePerPage, 1))
+	require.False(t, ok)
+}
+
+func TestService_ForgetTimelines(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(nil, func() time.Time { return now })
+
+	n1 := db.Notification{GithubID: "n1"}
+	n10 := db.Notification{GithubID: "n10"}
+	result := &models.TimelineResult{Total: 1, Page: 1, PerPage: TimelinePerPage}
+	svc.PutTimeline(testUserID, TimelineKey(n1, TimelinePerPage, 1), result)
+	svc.PutTimeline(testUserID, TimelineKey(n1, TimelinePerPage, 2), result)
+	svc.PutTimeline(testUserID, TimelineKey(n10, TimelinePerPage, 1), result)
+	svc.PutTimeline("other-user", TimelineKey(n1, TimelinePerPage, 1), result)
+
+	svc.ForgetTimelines(testUserID, "n1")
+
+	_, ok := svc.TakeTimeline(testUserID, TimelineKey(n1, TimelinePerPage, 1))
+	require.False(t, ok)
+	_, ok = svc.TakeTimeline(testUserID, TimelineKey(n1, TimelinePerPage, 2))
+	require.False(t, ok)
+
+	// Notifications sharing a prefix and other users keep theirs
+	_, ok = svc.TakeTimeline(testUserID, TimelineKey(n10, TimelinePerPage, 1))
+	require.True(t, ok)
+	_, ok = svc.TakeTimeline("other-user", TimelineKey(n1, TimelinePerPage, 1))
+	require.True(t, ok)
+}
+
+func TestTimelineKey_ChangesWithNewActivity(t *testing.T) {
+	n := db.Notification{
+		GithubID:        "n1",
+		GithubUpdatedAt: sql.NullTime{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	before := TimelineKey(n, TimelinePerPage, 1)
+
+	n.GithubUpdatedAt.Time = n.GithubUpdatedAt.Time.Add(time.Minute)
+	require.NotEqual(t, before, TimelineKey(n, TimelinePerPage, 1))
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package prewarm remembers what the user opened recently and holds results warmed for it
+// at launch, so the first paint after starting the app doesn't wait on GitHub.
+package prewarm
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// PrewarmService is the interface for the prewarm service.
+type PrewarmService interface {
+	// RecordAccess records that the user opened a notification list or notification.
+	RecordAccess(ctx context.Context, userID, kind, target string) error
+	// RecentAccess lists the most recently opened entries of a kind, newest first.
+	RecentAccess(ctx context.Context, userID, kind string) ([]db.RecentAccess, error)
+	// ClearAccess forgets every entry of a kind.
+	ClearAccess(ctx context.Context, userID, kind string) error
+	// PutViewCounts stores views with their unread counts, warmed for the user's next request.
+	PutViewCounts(userID string, views []models.View)
+	// TakeViewCounts returns the warmed view counts once, if they're still fresh.
+	TakeViewCounts(userID string) ([]models.View, bool)
+	// PutTimeline stores a warmed timeline page under a key from TimelineKey.
+	PutTimeline(userID, key string, result *models.TimelineResult)
+	// TakeTimeline returns a warmed timeline page once, if it's still fresh.
+	TakeTimeline(userID, key string) (*models.TimelineResult, bool)
+	// ForgetTimelines drops every warmed timeline page for a notification.
+	ForgetTimelines(userID, githubID string)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/priority/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/priority/service.go -destination=internal/core/priority/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriorityService is a mock of PriorityService interface.
+type MockPriorityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriorityServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPriorityServiceMockRecorder is the mock recorder for MockPriorityService.
+type MockPriorityServiceMockRecorder struct {
+	mock *MockPriorityService
+}
+
+// NewMockPriorityService creates a new mock instance.
+func NewMockPriorityService(ctrl *gomock.Controller) *MockPriorityService {
+	mock := &MockPriorityService{ctrl: ctrl}
+	mock.recorder = &MockPriorityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriorityService) EXPECT() *MockPriorityServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPrioritySettings mocks base method.
+func (m *MockPriorityService) GetPrioritySettings(ctx context.Context, userID string) (*models.PrioritySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrioritySettings", ctx, userID)
+	ret0, _ := ret[0].(*models.PrioritySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrioritySettings indicates an expected call of GetPrioritySettings.
+func (mr *MockPriorityServiceMockRecorder) GetPrioritySettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrioritySettings", reflect.TypeOf((*MockPriorityService)(nil).GetPrioritySettings), ctx, userID)
+}
+
+// Recalculate mocks base method.
+func (m *MockPriorityService) Recalculate(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recalculate", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recalculate indicates an expected call of Recalculate.
+func (mr *MockPriorityServiceMockRecorder) Recalculate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recalculate", reflect.TypeOf((*MockPriorityService)(nil).Recalculate), ctx, userID)
+}
+
+// UpdatePrioritySettings mocks base method.
+func (m *MockPriorityService) UpdatePrioritySettings(ctx context.Context, userID string, settings *models.PrioritySettings) (*models.PrioritySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrioritySettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.PrioritySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrioritySettings indicates an expected call of UpdatePrioritySettings.
+func (mr *MockPriorityServiceMockRecorder) UpdatePrioritySettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrioritySettings", reflect.TypeOf((*MockPriorityService)(nil).UpdatePrioritySettings), ctx, userID, settings)
+}
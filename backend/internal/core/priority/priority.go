@@ -0,0 +1,239 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package priority
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Bounds on priority weights
+const (
+	MaxWeight           = 1000
+	MaxStalenessDays    = 365
+	recalculatePageSize = 500
+)
+
+// Error definitions
+var (
+	ErrInvalidPrioritySettings      = errors.New("invalid priority settings")
+	ErrFailedToLoadPrioritySettings = errors.New("failed to load priority settings")
+	ErrFailedToSavePrioritySettings = errors.New("failed to save priority settings")
+	ErrFailedToRecalculate          = errors.New("failed to recalculate priorities")
+)
+
+// ciStates lists the CI states weights can be given for, as stored by sync
+var ciStates = map[string]bool{"failure": true, "pending": true, "success": true}
+
+// GetPrioritySettings returns the user's priority weights, or the defaults if none are saved
+func (s *Service) GetPrioritySettings(
+	ctx context.Context,
+	userID string,
+) (*models.PrioritySettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	return s.loadSettings(ctx)
+}
+
+// UpdatePrioritySettings validates and saves the user's priority weights, then scores
+// every notification again. Keys are trimmed, authors of a leading @, and reasons and CI
+// states are lowercased.
+func (s *Service) UpdatePrioritySettings(
+	ctx context.Context,
+	userID string,
+	settings *models.PrioritySettings,
+) (*models.PrioritySettings, error) {
+	normalized, err := normalizeSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := normalized.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSavePrioritySettings, err)
+	}
+	_, err = s.queries.UpdateUserPrioritySettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSavePrioritySettings, err)
+	}
+
+	if _, err := s.Recalculate(ctx, userID); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Recalculate scores every notification of the user with the saved weights, a page at a
+// time, and stores the scores that changed
+func (s *Service) Recalculate(ctx context.Context, userID string) (int, error) {
+	settings, err := s.loadSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+	repos, err := s.queries.ListRepositories(ctx, userID)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToRecalculate, err)
+	}
+	repoNames := make(map[int64]string, len(repos))
+	for _, repo := range repos {
+		repoNames[repo.ID] = repo.FullName
+	}
+
+	now := s.now()
+	changed := 0
+	query := db.NotificationQuery{Limit: recalculatePageSize}
+	for {
+		result, err := s.queries.ListNotificationsFromQuery(ctx, userID, query)
+		if err != nil {
+			return changed, errors.Join(ErrFailedToRecalculate, err)
+		}
+		for _, notification := range result.Notifications {
+			score := settings.Score(Inputs(notification, repoNames[notification.RepositoryID]), now)
+			if notification.Priority.Valid && notification.Priority.Int64 == score {
+				continue
+			}
+			err := s.queries.UpdateNotificationPriority(
+				ctx, userID, notification.ID, sql.NullInt64{Int64: score, Valid: true},
+			)
+			if err != nil {
+				return changed, errors.Join(ErrFailedToRecalculate, err)
+			}
+			changed++
+		}
+		if result.NextCursor == nil {
+			return changed, nil
+		}
+		query.After = result.NextCursor
+	}
+}
+
+// Inputs returns what a stored notification's priority score is worked out from. repoName
+// is the full name of its repository.
+func Inputs(notification db.Notification, repoName string) models.PriorityInputs {
+	return models.PriorityInputs{
+		Reason:     notification.Reason.String,
+		Repository: repoName,
+		Author:     notification.AuthorLogin.String,
+		CIState:    notification.CIState.String,
+		UpdatedAt:  notification.GithubUpdatedAt.Time,
+	}
+}
+
+func (s *Service) loadSettings(ctx context.Context) (*models.PrioritySettings, error) {
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultPrioritySettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadPrioritySettings, err)
+	}
+	if !user.PrioritySettings.Valid {
+		return models.DefaultPrioritySettings(), nil
+	}
+	settings, err := models.PrioritySettingsFromJSON(user.PrioritySettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadPrioritySettings, err)
+	}
+	return settings, nil
+}
+
+// normalizeSettings checks that every weight is in bounds and returns a copy with the keys
+// cleaned up
+func normalizeSettings(settings *models.PrioritySettings) (*models.PrioritySettings, error) {
+	if settings.StalenessPerDay < 0 || settings.StalenessPerDay > MaxWeight {
+		return nil, fmt.Errorf(
+			"%w: stalenessPerDay must be between 0 and %d", ErrInvalidPrioritySettings, MaxWeight,
+		)
+	}
+	if settings.MaxStalenessDays < 0 || settings.MaxStalenessDays > MaxStalenessDays {
+		return nil, fmt.Errorf(
+			"%w: maxStalenessDays must be between 0 and %d", ErrInvalidPrioritySettings, MaxStalenessDays,
+		)
+	}
+
+	reasons, err := normalizeWeights("reasons", settings.Reasons, true)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := normalizeWeights("repositories", settings.Repositories, false)
+	if err != nil {
+		return nil, err
+	}
+	for name := range repositories {
+		if owner, repo, ok := strings.Cut(name, "/"); !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("%w: repository %q isn't an owner/name", ErrInvalidPrioritySettings, name)
+		}
+	}
+	logins := make(map[string]int, len(settings.Authors))
+	for login, points := range settings.Authors {
+		logins[strings.TrimPrefix(strings.TrimSpace(login), "@")] = points
+	}
+	authors, err := normalizeWeights("authors", logins, false)
+	if err != nil {
+		return nil, err
+	}
+	ci, err := normalizeWeights("ciStates", settings.CIStates, true)
+	if err != nil {
+		return nil, err
+	}
+	for state := range ci {
+		if !ciStates[state] {
+			return nil, fmt.Errorf(
+				"%w: CI state %q isn't one of failure, pending or success", ErrInvalidPrioritySettings, state,
+			)
+		}
+	}
+
+	return &models.PrioritySettings{
+		Reasons:          reasons,
+		Repositories:     repositories,
+		Authors:          authors,
+		CIStates:         ci,
+		StalenessPerDay:  settings.StalenessPerDay,
+		MaxStalenessDays: settings.MaxStalenessDays,
+	}, nil
+}
+
+// normalizeWeights trims the keys of a weight map, lowercasing them if asked, and checks
+// the points are within MaxWeight either way
+func normalizeWeights(field string, weights map[string]int, lower bool) (map[string]int, error) {
+	normalized := make(map[string]int, len(weights))
+	for key, points := range weights {
+		key = strings.TrimSpace(key)
+		if lower {
+			key = strings.ToLower(key)
+		}
+		if key == "" {
+			return nil, fmt.Errorf("%w: %s can't have an empty key", ErrInvalidPrioritySettings, field)
+		}
+		if points < -MaxWeight || points > MaxWeight {
+			return nil, fmt.Errorf(
+				"%w: %s weights must be between %d and %d", ErrInvalidPrioritySettings, field, -MaxWeight, MaxWeight,
+			)
+		}
+		normalized[key] = points
+	}
+	return normalized, nil
+}
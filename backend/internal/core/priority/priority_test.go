@@ -0,0 +1,240 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package priority
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func TestPrioritySettings_Score(t *testing.T) {
+	settings := &models.PrioritySettings{
+		Reasons:          map[string]int{"review_requested": 30, "subscribed": -5},
+		Repositories:     map[string]int{"octo/api": 20},
+		Authors:          map[string]int{"dependabot[bot]": -10},
+		CIStates:         map[string]int{"failure": 15},
+		StalenessPerDay:  2,
+		MaxStalenessDays: 5,
+	}
+
+	tests := []struct {
+		name     string
+		inputs   models.PriorityInputs
+		expected int64
+	}{
+		{
+			name:     "nothing known scores nothing",
+			inputs:   models.PriorityInputs{},
+			expected: 0,
+		},
+		{
+			name: "weights add up",
+			inputs: models.PriorityInputs{
+				Reason:     "review_requested",
+				Repository: "octo/api",
+				CIState:    "failure",
+				UpdatedAt:  testNow,
+			},
+			expected: 65,
+		},
+		{
+			name:     "names match case-insensitively",
+			inputs:   models.PriorityInputs{Repository: "Octo/API", Author: "Dependabot[bot]"},
+			expected: 10,
+		},
+		{
+			name:     "negative weights lower the score",
+			inputs:   models.PriorityInputs{Reason: "subscribed", UpdatedAt: testNow},
+			expected: -5,
+		},
+		{
+			name:     "staleness counts whole days",
+			inputs:   models.PriorityInputs{UpdatedAt: testNow.Add(-3*24*time.Hour - time.Hour)},
+			expected: 6,
+		},
+		{
+			name:     "staleness is capped",
+			inputs:   models.PriorityInputs{UpdatedAt: testNow.AddDate(0, -1, 0)},
+			expected: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, settings.Score(tt.inputs, testNow))
+		})
+	}
+}
+
+func TestService_UpdatePrioritySettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  *models.PrioritySettings
+		setupMock func(*mocks.MockStore)
+		expectErr error
+		expected  *models.PrioritySettings
+	}{
+		{
+			name: "keys are cleaned up and notifications rescored",
+			settings: &models.PrioritySettings{
+				Reasons:          map[string]int{" Mention ": 25},
+				Repositories:     map[string]int{"octo/api": 20},
+				Authors:          map[string]int{"@alice": 10},
+				CIStates:         map[string]int{"FAILURE": 15},
+				StalenessPerDay:  1,
+				MaxStalenessDays: 7,
+			},
+			setupMock: func(m *mocks.MockStore) {
+				saved := json.RawMessage(`{"reasons":{"mention":25},"repositories":{"octo/api":20},` +
+					`"authors":{"alice":10},"ciStates":{"failure":15},"stalenessPerDay":1,"maxStalenessDays":7}`)
+				m.EXPECT().
+					UpdateUserPrioritySettings(gomock.Any(), db.NullRawMessage{RawMessage: saved, Valid: true}).
+					Return(db.User{}, nil)
+				m.EXPECT().
+					GetUser(gomock.Any()).
+					Return(db.User{PrioritySettings: db.NullRawMessage{RawMessage: saved, Valid: true}}, nil)
+				m.EXPECT().ListRepositories(gomock.Any(), "user").Return(nil, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "user", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{}, nil)
+			},
+			expected: &models.PrioritySettings{
+				Reasons:          map[string]int{"mention": 25},
+				Repositories:     map[string]int{"octo/api": 20},
+				Authors:          map[string]int{"alice": 10},
+				CIStates:         map[string]int{"failure": 15},
+				StalenessPerDay:  1,
+				MaxStalenessDays: 7,
+			},
+		},
+		{
+			name:      "weight out of bounds is rejected",
+			settings:  &models.PrioritySettings{Reasons: map[string]int{"mention": MaxWeight + 1}},
+			expectErr: ErrInvalidPrioritySettings,
+		},
+		{
+			name:      "repository must be owner/name",
+			settings:  &models.PrioritySettings{Repositories: map[string]int{"api": 5}},
+			expectErr: ErrInvalidPrioritySettings,
+		},
+		{
+			name:      "unknown CI state is rejected",
+			settings:  &models.PrioritySettings{CIStates: map[string]int{"red": 5}},
+			expectErr: ErrInvalidPrioritySettings,
+		},
+		{
+			name:      "negative staleness is rejected",
+			settings:  &models.PrioritySettings{StalenessPerDay: -1},
+			expectErr: ErrInvalidPrioritySettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(store)
+			}
+
+			svc := NewService(store, func() time.Time { return testNow })
+			settings, err := svc.UpdatePrioritySettings(context.Background(), "user", tt.settings)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, settings)
+		})
+	}
+}
+
+func TestService_Recalculate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+	store.EXPECT().
+		ListRepositories(gomock.Any(), "user").
+		Return([]db.Repository{{ID: 7, FullName: "octo/api"}}, nil)
+
+	updated := sql.NullTime{Time: testNow, Valid: true}
+	cursor := &db.NotificationCursor{SortDate: "2025-06-15T12:00:00Z", ID: 2}
+	first := store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "user", db.NotificationQuery{Limit: recalculatePageSize}).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{
+				// Already scored with the current weights
+				{
+					ID:              1,
+					RepositoryID:    7,
+					Reason:          sql.NullString{String: "mention", Valid: true},
+					GithubUpdatedAt: updated,
+					Priority:        sql.NullInt64{Int64: 25, Valid: true},
+				},
+				// Never scored
+				{
+					ID:              2,
+					RepositoryID:    7,
+					Reason:          sql.NullString{String: "review_requested", Valid: true},
+					CIState:         sql.NullString{String: "failure", Valid: true},
+					GithubUpdatedAt: updated,
+				},
+			},
+			NextCursor: cursor,
+		}, nil)
+	store.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "user", db.NotificationQuery{
+			Limit: recalculatePageSize,
+			After: cursor,
+		}).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{
+				// Scored before it went stale
+				{
+					ID:              3,
+					Reason:          sql.NullString{String: "subscribed", Valid: true},
+					GithubUpdatedAt: sql.NullTime{Time: testNow.AddDate(0, 0, -3), Valid: true},
+					Priority:        sql.NullInt64{Int64: 0, Valid: true},
+				},
+			},
+		}, nil).
+		After(first)
+
+	store.EXPECT().
+		UpdateNotificationPriority(gomock.Any(), "user", int64(2), sql.NullInt64{Int64: 45, Valid: true}).
+		Return(nil)
+	store.EXPECT().
+		UpdateNotificationPriority(gomock.Any(), "user", int64(3), sql.NullInt64{Int64: 3, Valid: true}).
+		Return(nil)
+
+	svc := NewService(store, func() time.Time { return testNow })
+	changed, err := svc.Recalculate(context.Background(), "user")
+	require.NoError(t, err)
+	require.Equal(t, 2, changed)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package priority scores notifications from the user's priority weights.
+package priority
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// PriorityService is the interface for priority weights and scoring.
+type PriorityService interface {
+	GetPrioritySettings(ctx context.Context, userID string) (*models.PrioritySettings, error)
+	// UpdatePrioritySettings validates and saves the weights, then scores every
+	// notification again with them.
+	UpdatePrioritySettings(
+		ctx context.Context,
+		userID string,
+		settings *models.PrioritySettings,
+	) (*models.PrioritySettings, error)
+	// Recalculate scores every notification again, storing the scores that changed, and
+	// returns how many did. Staleness adds points by the day, so scores drift between syncs.
+	Recalculate(ctx context.Context, userID string) (int, error)
+}
+
+// Service provides priority weights and scoring
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
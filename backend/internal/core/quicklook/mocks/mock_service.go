@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/quicklook/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/quicklook/service.go -destination=internal/core/quicklook/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuickLookService is a mock of QuickLookService interface.
+type MockQuickLookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuickLookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockQuickLookServiceMockRecorder is the mock recorder for MockQuickLookService.
+type MockQuickLookServiceMockRecorder struct {
+	mock *MockQuickLookService
+}
+
+// NewMockQuickLookService creates a new mock instance.
+func NewMockQuickLookService(ctrl *gomock.Controller) *MockQuickLookService {
+	mock := &MockQuickLookService{ctrl: ctrl}
+	mock.recorder = &MockQuickLookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuickLookService) EXPECT() *MockQuickLookServiceMockRecorder {
+	return m.recorder
+}
+
+// Archive mocks base method.
+func (m *MockQuickLookService) Archive(ctx context.Context, userID, githubID string) (models.QuickLookSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, userID, githubID)
+	ret0, _ := ret[0].(models.QuickLookSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockQuickLookServiceMockRecorder) Archive(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockQuickLookService)(nil).Archive), ctx, userID, githubID)
+}
+
+// Summary mocks base method.
+func (m *MockQuickLookService) Summary(ctx context.Context, userID string) (models.QuickLookSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", ctx, userID)
+	ret0, _ := ret[0].(models.QuickLookSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockQuickLookServiceMockRecorder) Summary(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockQuickLookService)(nil).Summary), ctx, userID)
+}
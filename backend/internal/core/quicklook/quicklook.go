@@ -0,0 +1,214 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package quicklook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+const (
+	// DefaultTTL is how long a cached summary is served before it's recomputed. Short enough
+	// that changes made in the app show up almost immediately, long enough that a launcher
+	// polling on every keystroke never touches the database.
+	DefaultTTL = 2 * time.Second
+	// MaxItems is how many inbox items a summary includes
+	MaxItems = 5
+
+	// inboxQuery and unreadQuery match the inbox view and its badge count
+	inboxQuery  = "in:inbox"
+	unreadQuery = "in:inbox is:unread"
+)
+
+// Error definitions
+var (
+	ErrFailedToBuildQuery          = errors.New("failed to build query")
+	ErrFailedToListNotifications   = errors.New("failed to list notifications")
+	ErrFailedToArchiveNotification = errors.New("failed to archive notification")
+)
+
+// cachedSummary is a computed summary and when it stops being fresh
+type cachedSummary struct {
+	summary models.QuickLookSummary
+	expires time.Time
+}
+
+// Service computes quick look summaries and caches them per user
+type Service struct {
+	queries db.Store
+	history history.HistoryService
+	now     func() time.Time
+	ttl     time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedSummary
+}
+
+// NewService constructs a Service backed by the provided store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+		ttl:     DefaultTTL,
+		cache:   make(map[string]cachedSummary),
+	}
+}
+
+// WithHistory records archives from launchers in the notification's history
+func (s *Service) WithHistory(h history.HistoryService) *Service {
+	s.history = h
+	return s
+}
+
+// WithTTL sets how long summaries are cached
+func (s *Service) WithTTL(ttl time.Duration) *Service {
+	s.ttl = ttl
+	return s
+}
+
+// Summary returns the inbox unread count and newest inbox items. The lock is held while
+// recomputing so concurrent requests for a stale entry share one database round trip.
+func (s *Service) Summary(ctx context.Context, userID string) (models.QuickLookSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if cached, ok := s.cache[userID]; ok && now.Before(cached.expires) {
+		return cached.summary, nil
+	}
+
+	summary, err := s.compute(ctx, userID)
+	if err != nil {
+		return models.QuickLookSummary{}, err
+	}
+	summary.GeneratedAt = now
+	s.cache[userID] = cachedSummary{summary: summary, expires: now.Add(s.ttl)}
+	return summary, nil
+}
+
+// Archive archives a notification and returns the refreshed summary
+func (s *Service) Archive(
+	ctx context.Context,
+	userID, githubID string,
+) (models.QuickLookSummary, error) {
+	if _, err := s.queries.ArchiveNotification(ctx, userID, githubID); err != nil {
+		return models.QuickLookSummary{}, errors.Join(ErrFailedToArchiveNotification, err)
+	}
+	if s.history != nil {
+		s.history.Record(ctx, userID, []string{githubID}, models.HistoryArchived, models.HistorySourceUser, "")
+	}
+	s.invalidate(userID)
+	return s.Summary(ctx, userID)
+}
+
+// invalidate drops a user's cached summary so the next read recomputes it
+func (s *Service) invalidate(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, userID)
+}
+
+// compute queries the unread count and newest inbox items
+func (s *Service) compute(ctx context.Context, userID string) (models.QuickLookSummary, error) {
+	unread, err := s.run(ctx, userID, unreadQuery, 1)
+	if err != nil {
+		return models.QuickLookSummary{}, err
+	}
+	inbox, err := s.run(ctx, userID, inboxQuery, MaxItems)
+	if err != nil {
+		return models.QuickLookSummary{}, err
+	}
+
+	repoNames := make(map[int64]db.Repository)
+	items := make([]models.QuickLookItem, 0, len(inbox.Notifications))
+	for _, n := range inbox.Notifications {
+		repo, ok := repoNames[n.RepositoryID]
+		if !ok {
+			// A missing repository only costs the item its name and link
+			repo, _ = s.queries.GetRepositoryByID(ctx, userID, n.RepositoryID)
+			repoNames[n.RepositoryID] = repo
+		}
+		items = append(items, toItem(n, repo))
+	}
+
+	return models.QuickLookSummary{
+		UnreadCount: unread.Total,
+		Items:       items,
+	}, nil
+}
+
+// run executes a query string with the list endpoint's defaults
+func (s *Service) run(
+	ctx context.Context,
+	userID, queryStr string,
+	limit int32,
+) (db.ListNotificationsFromQueryResult, error) {
+	dbQuery, err := query.BuildQueryWithOptions(queryStr, limit, 0, false)
+	if err != nil {
+		return db.ListNotificationsFromQueryResult{}, errors.Join(ErrFailedToBuildQuery, err)
+	}
+	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return db.ListNotificationsFromQueryResult{},
+			errors.Join(ErrFailedToListNotifications, err)
+	}
+	return result, nil
+}
+
+// toItem converts a notification to its quick look form
+func toItem(n db.Notification, repo db.Repository) models.QuickLookItem {
+	return models.QuickLookItem{
+		GithubID:    n.GithubID,
+		Title:       n.SubjectTitle,
+		Type:        n.SubjectType,
+		Repository:  repo.FullName,
+		AuthorLogin: models.NullStringPtr(n.AuthorLogin),
+		Reason:      models.NullStringPtr(n.Reason),
+		Number:      models.NullInt32ToInt64Ptr(n.SubjectNumber),
+		IsRead:      n.IsRead,
+		UpdatedAt:   n.EffectiveSortDate,
+		URL:         itemURL(n, repo),
+	}
+}
+
+// itemURL links to the subject on GitHub when it has a number, otherwise to the repository
+func itemURL(n db.Notification, repo db.Repository) *string {
+	if !repo.HTMLURL.Valid || repo.HTMLURL.String == "" {
+		return nil
+	}
+	base := strings.TrimSuffix(repo.HTMLURL.String, "/")
+	if !n.SubjectNumber.Valid {
+		return &base
+	}
+	path := "issues"
+	switch n.SubjectType {
+	case "PullRequest":
+		path = "pull"
+	case "Discussion":
+		path = "discussions"
+	}
+	url := fmt.Sprintf("%s/%s/%d", base, path, n.SubjectNumber.Int32)
+	return &url
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package quicklook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+)
+
+const testUserID = "test-user-id"
+
+// expectCompute sets up the store calls for one summary computation
+func expectCompute(mockStore *mocks.MockStore, unread int64, items []db.Notification) {
+	gomock.InOrder(
+		mockStore.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: unread}, nil),
+		mockStore.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				q db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				if q.Limit != MaxItems {
+					return db.ListNotificationsFromQueryResult{}, errors.New("unexpected limit")
+				}
+				return db.ListNotificationsFromQueryResult{
+					Notifications: items,
+					Total:         int64(len(items)),
+				}, nil
+			}),
+	)
+}
+
+func TestService_Summary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mockStore, func() time.Time { return now })
+
+	expectCompute(mockStore, 3, []db.Notification{{
+		GithubID:      "n1",
+		RepositoryID:  7,
+		SubjectType:   "PullRequest",
+		SubjectTitle:  "Fix the thing",
+		SubjectNumber: sql.NullInt32{Int32: 42, Valid: true},
+	}})
+	mockStore.EXPECT().
+		GetRepositoryByID(gomock.Any(), testUserID, int64(7)).
+		Return(db.Repository{
+			FullName: "octo/repo",
+			HTMLURL:  sql.NullString{String: "https://github.com/octo/repo", Valid: true},
+		}, nil)
+
+	summary, err := svc.Summary(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), summary.UnreadCount)
+	require.Len(t, summary.Items, 1)
+	require.Equal(t, "octo/repo", summary.Items[0].Repository)
+	require.Equal(t, "https://github.com/octo/repo/pull/42", *summary.Items[0].URL)
+	require.Equal(t, now, summary.GeneratedAt)
+
+	// A second read within the TTL is served from cache without touching the store
+	cached, err := svc.Summary(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Equal(t, summary, cached)
+}
+
+func TestService_Summary_RecomputesAfterTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mockStore, func() time.Time { return now })
+
+	expectCompute(mockStore, 3, nil)
+	summary, err := svc.Summary(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), summary.UnreadCount)
+	require.NotNil(t, summary.Items)
+
+	now = now.Add(DefaultTTL)
+	expectCompute(mockStore, 1, nil)
+	summary, err = svc.Summary(context.Background(), testUserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.UnreadCount)
+}
+
+func TestService_Archive_InvalidatesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(mockStore, func() time.Time { return now })
+
+	expectCompute(mockStore, 2, nil)
+	_, err := svc.Summary(context.Background(), testUserID)
+	require.NoError(t, err)
+
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), testUserID, "n1").
+		Return(db.Notification{GithubID: "n1", Archived: true}, nil)
+	expectCompute(mockStore, 1, nil)
+
+	summary, err := svc.Archive(context.Background(), testUserID, "n1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.UnreadCount)
+}
+
+func TestService_Archive_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	svc := NewService(mockStore, time.Now)
+
+	mockStore.EXPECT().
+		ArchiveNotification(gomock.Any(), testUserID, "missing").
+		Return(db.Notification{}, sql.ErrNoRows)
+
+	_, err := svc.Archive(context.Background(), testUserID, "missing")
+	require.ErrorIs(t, err, ErrFailedToArchiveNotification)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestItemURL(t *testing.T) {
+	repo := db.Repository{
+		HTMLURL: sql.NullString{String: "https://github.com/octo/repo/", Valid: true},
+	}
+	number := sql.NullInt32{Int32: 9, Valid: true}
+
+	tests := []struct {
+		name     string
+		n        db.Notification
+		repo     db.Repository
+		expected *string
+	}{
+		{
+			name:     "issue",
+			n:        db.Notification{SubjectType: "Issue", SubjectNumber: number},
+			repo:     repo,
+			expected: strPtr("https://github.com/octo/repo/issues/9"),
+		},
+		{
+			name:     "discussion",
+			n:        db.Notification{SubjectType: "Discussion", SubjectNumber: number},
+			repo:     repo,
+			expected: strPtr("https://github.com/octo/repo/discussions/9"),
+		},
+		{
+			name:     "no number links to repository",
+			n:        db.Notification{SubjectType: "Release"},
+			repo:     repo,
+			expected: strPtr("https://github.com/octo/repo"),
+		},
+		{
+			name:     "unknown repository",
+			n:        db.Notification{SubjectType: "Issue", SubjectNumber: number},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, itemURL(tt.n, tt.repo))
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
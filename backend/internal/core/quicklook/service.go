@@ -0,0 +1,33 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package quicklook serves cached inbox summaries for launcher plugins such as Raycast,
+// Alfred, and Ulauncher.
+package quicklook
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// QuickLookService is the interface for the quick look service.
+type QuickLookService interface {
+	// Summary returns the inbox unread count and newest inbox items, served from cache
+	// when fresh.
+	Summary(ctx context.Context, userID string) (models.QuickLookSummary, error)
+	// Archive archives a notification and returns the refreshed summary.
+	Archive(ctx context.Context, userID, githubID string) (models.QuickLookSummary, error)
+}
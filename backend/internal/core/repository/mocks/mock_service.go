@@ -42,6 +42,21 @@ func (m *MockRepositoryService) EXPECT() *MockRepositoryServiceMockRecorder {
 	return m.recorder
 }
 
+// GetRepository mocks base method.
+func (m *MockRepositoryService) GetRepository(ctx context.Context, userID string, id int64) (models.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepository", ctx, userID, id)
+	ret0, _ := ret[0].(models.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepository indicates an expected call of GetRepository.
+func (mr *MockRepositoryServiceMockRecorder) GetRepository(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*MockRepositoryService)(nil).GetRepository), ctx, userID, id)
+}
+
 // GetRepositoryByID mocks base method.
 func (m *MockRepositoryService) GetRepositoryByID(ctx context.Context, userID string, id int64) (db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,21 @@ func (mr *MockRepositoryServiceMockRecorder) GetRepositoryByID(ctx, userID, id a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByID", reflect.TypeOf((*MockRepositoryService)(nil).GetRepositoryByID), ctx, userID, id)
 }
 
+// GetRepositorySettings mocks base method.
+func (m *MockRepositoryService) GetRepositorySettings(ctx context.Context, userID string, repositoryID int64) (models.RepositorySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositorySettings", ctx, userID, repositoryID)
+	ret0, _ := ret[0].(models.RepositorySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositorySettings indicates an expected call of GetRepositorySettings.
+func (mr *MockRepositoryServiceMockRecorder) GetRepositorySettings(ctx, userID, repositoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositorySettings", reflect.TypeOf((*MockRepositoryService)(nil).GetRepositorySettings), ctx, userID, repositoryID)
+}
+
 // ListRepositories mocks base method.
 func (m *MockRepositoryService) ListRepositories(ctx context.Context, userID string) ([]models.Repository, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +117,36 @@ func (mr *MockRepositoryServiceMockRecorder) ListRepositoriesAsMap(ctx, userID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoriesAsMap", reflect.TypeOf((*MockRepositoryService)(nil).ListRepositoriesAsMap), ctx, userID)
 }
 
+// ListRepositoriesWithDigests mocks base method.
+func (m *MockRepositoryService) ListRepositoriesWithDigests(ctx context.Context, userID string) ([]models.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRepositoriesWithDigests", ctx, userID)
+	ret0, _ := ret[0].([]models.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoriesWithDigests indicates an expected call of ListRepositoriesWithDigests.
+func (mr *MockRepositoryServiceMockRecorder) ListRepositoriesWithDigests(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoriesWithDigests", reflect.TypeOf((*MockRepositoryService)(nil).ListRepositoriesWithDigests), ctx, userID)
+}
+
+// UpdateRepositorySettings mocks base method.
+func (m *MockRepositoryService) UpdateRepositorySettings(ctx context.Context, userID string, settings models.RepositorySettings) (models.RepositorySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepositorySettings", ctx, userID, settings)
+	ret0, _ := ret[0].(models.RepositorySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepositorySettings indicates an expected call of UpdateRepositorySettings.
+func (mr *MockRepositoryServiceMockRecorder) UpdateRepositorySettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepositorySettings", reflect.TypeOf((*MockRepositoryService)(nil).UpdateRepositorySettings), ctx, userID, settings)
+}
+
 // UpsertRepository mocks base method.
 func (m *MockRepositoryService) UpsertRepository(ctx context.Context, userID string, params db.UpsertRepositoryParams) (db.Repository, error) {
 	m.ctrl.T.Helper()
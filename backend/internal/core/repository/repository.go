@@ -18,8 +18,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
@@ -27,8 +30,16 @@ import (
 // Error definitions
 var (
 	ErrFailedToLoadRepositories      = errors.New("failed to load repositories")
+	ErrFailedToLoadRepository        = errors.New("failed to load repository")
+	ErrFailedToLoadDigests           = errors.New("failed to load repository digests")
+	ErrRepositoryNotFound            = errors.New("repository not found")
 	ErrFailedToListRepositoriesAsMap = errors.New("failed to list repositories as map")
 	ErrFailedToUpsertRepository      = errors.New("failed to upsert repository")
+	ErrFailedToRenameRepository      = errors.New("failed to rename repository")
+	ErrFailedToLoadSettings          = errors.New("failed to load repository settings")
+	ErrFailedToSaveSettings          = errors.New("failed to save repository settings")
+	ErrInvalidResurfaceSuppression   = errors.New("invalid resurface suppression window")
+	ErrInvalidSyncMode               = errors.New("invalid sync mode")
 )
 
 // ListRepositories returns all repositories
@@ -49,6 +60,56 @@ func (s *Service) ListRepositories(
 	return response, nil
 }
 
+// ListRepositoriesWithDigests returns all repositories, each with a digest of its unread
+// notifications. Digests for the whole list come from a single query.
+func (s *Service) ListRepositoriesWithDigests(
+	ctx context.Context,
+	userID string,
+) ([]models.Repository, error) {
+	repositories, err := s.ListRepositories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	digests, err := s.queries.ListRepositoryDigests(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadDigests, err)
+	}
+
+	now := s.now()
+	for i := range repositories {
+		digest := models.RepositoryDigestFromDB(digests[repositories[i].ID], now)
+		repositories[i].Digest = &digest
+	}
+
+	return repositories, nil
+}
+
+// GetRepository returns a repository with a digest of its unread notifications
+func (s *Service) GetRepository(
+	ctx context.Context,
+	userID string,
+	id int64,
+) (models.Repository, error) {
+	repository, err := s.queries.GetRepositoryByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Repository{}, errors.Join(ErrRepositoryNotFound, err)
+		}
+		return models.Repository{}, errors.Join(ErrFailedToLoadRepository, err)
+	}
+
+	digest, err := s.queries.GetRepositoryDigest(ctx, userID, id)
+	if err != nil {
+		return models.Repository{}, errors.Join(ErrFailedToLoadDigests, err)
+	}
+
+	result := models.RepositoryFromDB(repository)
+	converted := models.RepositoryDigestFromDB(digest, s.now())
+	result.Digest = &converted
+	return result, nil
+}
+
 // ListRepositoriesAsMap returns all repositories as a map keyed by ID for efficient lookup
 func (s *Service) ListRepositoriesAsMap(
 	ctx context.Context,
@@ -82,9 +143,117 @@ func (s *Service) UpsertRepository(
 	userID string,
 	params db.UpsertRepositoryParams,
 ) (db.Repository, error) {
+	if err := s.applyRename(ctx, userID, params); err != nil {
+		return db.Repository{}, errors.Join(ErrFailedToRenameRepository, err)
+	}
+
 	repo, err := s.queries.UpsertRepository(ctx, userID, params)
 	if err != nil {
 		return db.Repository{}, errors.Join(ErrFailedToUpsertRepository, err)
 	}
 	return repo, nil
 }
+
+// applyRename detects a renamed or transferred repository (same GitHub ID, different
+// full name) and renames the stored row in place before it is upserted. The old name is
+// kept as an alias so existing notifications still match repo: and org: queries for it.
+func (s *Service) applyRename(
+	ctx context.Context,
+	userID string,
+	params db.UpsertRepositoryParams,
+) error {
+	if !params.GithubID.Valid {
+		return nil
+	}
+
+	existing, err := s.queries.GetRepositoryByGithubID(ctx, userID, params.GithubID.Int64)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if existing.FullName == params.FullName {
+		return nil
+	}
+
+	// If a row already exists under the new name (e.g. created by a sync before renames
+	// were detected), leave both rows alone; the upsert updates the new-name row.
+	if _, err := s.queries.GetRepositoryByFullName(ctx, userID, params.FullName); err == nil {
+		return nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return s.queries.RenameRepository(ctx, userID, db.RenameRepositoryParams{
+		ID:          existing.ID,
+		OldFullName: existing.FullName,
+		FullName:    params.FullName,
+		Name:        params.Name,
+		OwnerLogin:  params.OwnerLogin,
+	})
+}
+
+// GetRepositorySettings returns the user's settings for a repository. Repositories
+// without saved settings use the user's defaults.
+func (s *Service) GetRepositorySettings(
+	ctx context.Context,
+	userID string,
+	repositoryID int64,
+) (models.RepositorySettings, error) {
+	settings, err := s.queries.GetRepositorySettings(ctx, userID, repositoryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RepositorySettings{
+				RepositoryID: repositoryID,
+				SyncMode:     models.RepositorySyncFull,
+			}, nil
+		}
+		return models.RepositorySettings{}, errors.Join(ErrFailedToLoadSettings, err)
+	}
+	return models.RepositorySettingsFromDB(settings), nil
+}
+
+// UpdateRepositorySettings validates and saves the user's settings for a repository,
+// replacing what was saved before. Nil fields fall back to the user's defaults, and an
+// empty sync mode syncs the repository in full.
+func (s *Service) UpdateRepositorySettings(
+	ctx context.Context,
+	userID string,
+	settings models.RepositorySettings,
+) (models.RepositorySettings, error) {
+	if settings.DefaultSnooze != nil {
+		if err := snooze.Validate(*settings.DefaultSnooze); err != nil {
+			return models.RepositorySettings{}, err
+		}
+	}
+	if minutes := settings.ResurfaceSuppressionMinutes; minutes != nil &&
+		(*minutes < 0 || *minutes > models.MaxResurfaceSuppressionMinutes) {
+		return models.RepositorySettings{}, fmt.Errorf(
+			"%w: must be between 0 and %d minutes",
+			ErrInvalidResurfaceSuppression, models.MaxResurfaceSuppressionMinutes,
+		)
+	}
+	switch settings.SyncMode {
+	case "":
+		settings.SyncMode = models.RepositorySyncFull
+	case models.RepositorySyncFull, models.RepositorySyncHeadersOnly, models.RepositorySyncExcluded:
+	default:
+		return models.RepositorySettings{}, fmt.Errorf(
+			"%w: must be %s, %s or %s",
+			ErrInvalidSyncMode, models.RepositorySyncFull,
+			models.RepositorySyncHeadersOnly, models.RepositorySyncExcluded,
+		)
+	}
+
+	saved, err := s.queries.UpsertRepositorySettings(ctx, userID, db.UpsertRepositorySettingsParams{
+		RepositoryID:                settings.RepositoryID,
+		DefaultSnooze:               models.SQLNullStringPtr(settings.DefaultSnooze),
+		ResurfaceSuppressionMinutes: models.SQLNullInt64Ptr(settings.ResurfaceSuppressionMinutes),
+		SyncMode:                    settings.SyncMode,
+	})
+	if err != nil {
+		return models.RepositorySettings{}, errors.Join(ErrFailedToSaveSettings, err)
+	}
+	return models.RepositorySettingsFromDB(saved), nil
+}
@@ -17,6 +17,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
@@ -27,23 +28,37 @@ import (
 
 type RepositoryService interface {
 	ListRepositories(ctx context.Context, userID string) ([]models.Repository, error)
+	ListRepositoriesWithDigests(ctx context.Context, userID string) ([]models.Repository, error)
 	ListRepositoriesAsMap(ctx context.Context, userID string) (map[int64]db.Repository, error)
+	GetRepository(ctx context.Context, userID string, id int64) (models.Repository, error)
 	GetRepositoryByID(ctx context.Context, userID string, id int64) (db.Repository, error)
 	UpsertRepository(
 		ctx context.Context,
 		userID string,
 		params db.UpsertRepositoryParams,
 	) (db.Repository, error)
+	GetRepositorySettings(
+		ctx context.Context,
+		userID string,
+		repositoryID int64,
+	) (models.RepositorySettings, error)
+	UpdateRepositorySettings(
+		ctx context.Context,
+		userID string,
+		settings models.RepositorySettings,
+	) (models.RepositorySettings, error)
 }
 
 // Service provides business logic for repository operations
 type Service struct {
 	queries db.Store
+	now     func() time.Time
 }
 
 // NewService constructs a Service backed by the provided queries
 func NewService(queries db.Store) *Service {
 	return &Service{
 		queries: queries,
+		now:     time.Now,
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/models"
@@ -257,6 +258,114 @@ func TestService_GetRepositoryByID(t *testing.T) {
 	}
 }
 
+func TestService_UpsertRepository_DetectsRename(t *testing.T) {
+	params := db.UpsertRepositoryParams{
+		GithubID:   sql.NullInt64{Int64: 42, Valid: true},
+		Name:       "widget",
+		FullName:   "new-org/widget",
+		OwnerLogin: sql.NullString{String: "new-org", Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+	}{
+		{
+			name: "unknown repository is upserted without rename",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetRepositoryByGithubID(gomock.Any(), "test-user-id", int64(42)).
+					Return(db.Repository{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "unchanged name is upserted without rename",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetRepositoryByGithubID(gomock.Any(), "test-user-id", int64(42)).
+					Return(db.Repository{ID: 7, FullName: "new-org/widget"}, nil)
+			},
+		},
+		{
+			name: "changed name renames existing row and records alias",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetRepositoryByGithubID(gomock.Any(), "test-user-id", int64(42)).
+					Return(db.Repository{ID: 7, FullName: "old-org/widget"}, nil)
+				m.EXPECT().
+					GetRepositoryByFullName(gomock.Any(), "test-user-id", "new-org/widget").
+					Return(db.Repository{}, sql.ErrNoRows)
+				m.EXPECT().
+					RenameRepository(gomock.Any(), "test-user-id", db.RenameRepositoryParams{
+						ID:          7,
+						OldFullName: "old-org/widget",
+						FullName:    "new-org/widget",
+						Name:        "widget",
+						OwnerLogin:  sql.NullString{String: "new-org", Valid: true},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name: "existing row under new name skips rename",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetRepositoryByGithubID(gomock.Any(), "test-user-id", int64(42)).
+					Return(db.Repository{ID: 7, FullName: "old-org/widget"}, nil)
+				m.EXPECT().
+					GetRepositoryByFullName(gomock.Any(), "test-user-id", "new-org/widget").
+					Return(db.Repository{ID: 9, FullName: "new-org/widget"}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			mockQuerier.EXPECT().
+				UpsertRepository(gomock.Any(), "test-user-id", params).
+				Return(db.Repository{ID: 7, FullName: "new-org/widget"}, nil)
+			service := NewService(mockQuerier)
+
+			repo, err := service.UpsertRepository(context.Background(), "test-user-id", params)
+			require.NoError(t, err)
+			require.Equal(t, "new-org/widget", repo.FullName)
+		})
+	}
+}
+
+func TestService_UpsertRepository_RenameFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		GetRepositoryByGithubID(gomock.Any(), "test-user-id", int64(42)).
+		Return(db.Repository{ID: 7, FullName: "old-org/widget"}, nil)
+	mockQuerier.EXPECT().
+		GetRepositoryByFullName(gomock.Any(), "test-user-id", "new-org/widget").
+		Return(db.Repository{}, sql.ErrNoRows)
+	mockQuerier.EXPECT().
+		RenameRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(errors.New("database is locked"))
+	service := NewService(mockQuerier)
+
+	_, err := service.UpsertRepository(
+		context.Background(),
+		"test-user-id",
+		db.UpsertRepositoryParams{
+			GithubID: sql.NullInt64{Int64: 42, Valid: true},
+			Name:     "widget",
+			FullName: "new-org/widget",
+		},
+	)
+	require.ErrorIs(t, err, ErrFailedToRenameRepository)
+}
+
 func TestRepositoryFromModel_DataTransformation(t *testing.T) {
 	// Test that RepositoryFromDB correctly transforms db.Repository to Repository
 	now := time.Now().UTC()
@@ -307,3 +416,200 @@ func TestRepositoryFromModel_DataTransformation(t *testing.T) {
 	require.Equal(t, now, *result.PushedAt)
 	require.NotEmpty(t, result.Raw)
 }
+
+func TestService_ListRepositoriesWithDigests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-90 * time.Minute)
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		ListRepositories(gomock.Any(), "test-user-id").
+		Return([]db.Repository{
+			{ID: 1, Name: "busy", FullName: "owner/busy"},
+			{ID: 2, Name: "quiet", FullName: "owner/quiet"},
+		}, nil)
+	mockQuerier.EXPECT().
+		ListRepositoryDigests(gomock.Any(), "test-user-id").
+		Return(map[int64]db.RepositoryDigest{
+			1: {
+				RepositoryID:   1,
+				UnreadCount:    3,
+				ReasonCounts:   map[string]int64{"mention": 2, "review_requested": 1},
+				OldestUnreadAt: oldest,
+				RecentUnread: []db.RepositoryDigestItem{
+					{GithubID: "n3", SubjectTitle: "Newest", UpdatedAt: now},
+					{GithubID: "n2", SubjectTitle: "Middle", UpdatedAt: oldest},
+				},
+			},
+		}, nil)
+
+	service := NewService(mockQuerier)
+	service.now = func() time.Time { return now }
+
+	repos, err := service.ListRepositoriesWithDigests(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+
+	busy := repos[0].Digest
+	require.NotNil(t, busy)
+	require.Equal(t, int64(3), busy.UnreadCount)
+	require.Equal(t, map[string]int64{"mention": 2, "review_requested": 1}, busy.ReasonCounts)
+	require.NotNil(t, busy.OldestUnreadAt)
+	require.Equal(t, int64(90*60), busy.OldestUnreadAgeSeconds)
+	require.Len(t, busy.RecentUnread, 2)
+	require.Equal(t, "Newest", busy.RecentUnread[0].Title)
+
+	quiet := repos[1].Digest
+	require.NotNil(t, quiet)
+	require.Zero(t, quiet.UnreadCount)
+	require.Nil(t, quiet.OldestUnreadAt)
+	require.Empty(t, quiet.ReasonCounts)
+	require.NotNil(t, quiet.RecentUnread)
+}
+
+func TestService_ListRepositoriesWithDigests_DigestError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		ListRepositories(gomock.Any(), "test-user-id").
+		Return([]db.Repository{{ID: 1}}, nil)
+	mockQuerier.EXPECT().
+		ListRepositoryDigests(gomock.Any(), "test-user-id").
+		Return(nil, errors.New("database error"))
+
+	service := NewService(mockQuerier)
+	_, err := service.ListRepositoriesWithDigests(context.Background(), "test-user-id")
+	require.ErrorIs(t, err, ErrFailedToLoadDigests)
+}
+
+func TestService_GetRepository(t *testing.T) {
+	t.Run("returns repository with digest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+			Return(db.Repository{ID: 1, Name: "repo1", FullName: "owner/repo1"}, nil)
+		mockQuerier.EXPECT().
+			GetRepositoryDigest(gomock.Any(), "test-user-id", int64(1)).
+			Return(db.RepositoryDigest{
+				RepositoryID: 1,
+				UnreadCount:  1,
+				ReasonCounts: map[string]int64{"author": 1},
+			}, nil)
+
+		service := NewService(mockQuerier)
+		repo, err := service.GetRepository(context.Background(), "test-user-id", 1)
+		require.NoError(t, err)
+		require.Equal(t, "owner/repo1", repo.FullName)
+		require.NotNil(t, repo.Digest)
+		require.Equal(t, int64(1), repo.Digest.ReasonCounts["author"])
+	})
+
+	t.Run("missing repository returns not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			GetRepositoryByID(gomock.Any(), "test-user-id", int64(999)).
+			Return(db.Repository{}, sql.ErrNoRows)
+
+		service := NewService(mockQuerier)
+		_, err := service.GetRepository(context.Background(), "test-user-id", 999)
+		require.ErrorIs(t, err, ErrRepositoryNotFound)
+	})
+}
+
+func TestService_UpdateRepositorySettings(t *testing.T) {
+	weekly := "1w"
+	forever := "forever"
+	twoHours := int64(120)
+	tooLong := int64(models.MaxResurfaceSuppressionMinutes + 1)
+
+	t.Run("invalid values are rejected without saving", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.UpdateRepositorySettings(context.Background(), "user",
+			models.RepositorySettings{RepositoryID: 42, DefaultSnooze: &forever})
+		require.ErrorIs(t, err, snooze.ErrInvalidSnooze)
+
+		_, err = service.UpdateRepositorySettings(context.Background(), "user",
+			models.RepositorySettings{RepositoryID: 42, ResurfaceSuppressionMinutes: &tooLong})
+		require.ErrorIs(t, err, ErrInvalidResurfaceSuppression)
+
+		_, err = service.UpdateRepositorySettings(context.Background(), "user",
+			models.RepositorySettings{RepositoryID: 42, SyncMode: "sometimes"})
+		require.ErrorIs(t, err, ErrInvalidSyncMode)
+	})
+
+	t.Run("saves every field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().
+			UpsertRepositorySettings(gomock.Any(), "user", db.UpsertRepositorySettingsParams{
+				RepositoryID:                42,
+				DefaultSnooze:               sql.NullString{String: "1w", Valid: true},
+				ResurfaceSuppressionMinutes: sql.NullInt64{Int64: 120, Valid: true},
+				SyncMode:                    models.RepositorySyncHeadersOnly,
+			}).
+			Return(db.RepositorySetting{
+				RepositoryID:                42,
+				DefaultSnooze:               sql.NullString{String: "1w", Valid: true},
+				ResurfaceSuppressionMinutes: sql.NullInt64{Int64: 120, Valid: true},
+				SyncMode:                    models.RepositorySyncHeadersOnly,
+			}, nil)
+
+		saved, err := NewService(mockStore).UpdateRepositorySettings(context.Background(), "user",
+			models.RepositorySettings{
+				RepositoryID:                42,
+				DefaultSnooze:               &weekly,
+				ResurfaceSuppressionMinutes: &twoHours,
+				SyncMode:                    models.RepositorySyncHeadersOnly,
+			})
+		require.NoError(t, err)
+		require.Equal(t, "1w", *saved.DefaultSnooze)
+		require.Equal(t, int64(120), *saved.ResurfaceSuppressionMinutes)
+		require.Equal(t, models.RepositorySyncHeadersOnly, saved.SyncMode)
+	})
+
+	t.Run("nil fields clear them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().
+			UpsertRepositorySettings(gomock.Any(), "user", db.UpsertRepositorySettingsParams{
+				RepositoryID: 42,
+				SyncMode:     models.RepositorySyncFull,
+			}).
+			Return(db.RepositorySetting{RepositoryID: 42, SyncMode: models.RepositorySyncFull}, nil)
+
+		saved, err := NewService(mockStore).UpdateRepositorySettings(
+			context.Background(), "user", models.RepositorySettings{RepositoryID: 42},
+		)
+		require.NoError(t, err)
+		require.Nil(t, saved.DefaultSnooze)
+		require.Nil(t, saved.ResurfaceSuppressionMinutes)
+	})
+}
+
+func TestService_GetRepositorySettings_Unsaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		GetRepositorySettings(gomock.Any(), "user", int64(42)).
+		Return(db.RepositorySetting{}, sql.ErrNoRows)
+
+	settings, err := NewService(mockStore).GetRepositorySettings(context.Background(), "user", 42)
+	require.NoError(t, err)
+	require.Equal(t, models.RepositorySettings{
+		RepositoryID: 42,
+		SyncMode:     models.RepositorySyncFull,
+	}, settings)
+}
@@ -101,6 +101,21 @@ func (mr *MockRuleServiceMockRecorder) GetRulesByViewID(ctx, userID, viewID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByViewID", reflect.TypeOf((*MockRuleService)(nil).GetRulesByViewID), ctx, userID, viewID)
 }
 
+// ListEvaluations mocks base method.
+func (m *MockRuleService) ListEvaluations(ctx context.Context, userID, githubID string, limit int) ([]models.RuleEvaluation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvaluations", ctx, userID, githubID, limit)
+	ret0, _ := ret[0].([]models.RuleEvaluation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvaluations indicates an expected call of ListEvaluations.
+func (mr *MockRuleServiceMockRecorder) ListEvaluations(ctx, userID, githubID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluations", reflect.TypeOf((*MockRuleService)(nil).ListEvaluations), ctx, userID, githubID, limit)
+}
+
 // ListRules mocks base method.
 func (m *MockRuleService) ListRules(ctx context.Context, userID string) ([]models.Rule, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +146,21 @@ func (mr *MockRuleServiceMockRecorder) ReorderRules(ctx, userID, ruleIDs any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRules", reflect.TypeOf((*MockRuleService)(nil).ReorderRules), ctx, userID, ruleIDs)
 }
 
+// RunRule mocks base method.
+func (m *MockRuleService) RunRule(ctx context.Context, userID, ruleID string, dryRun bool) (models.RuleRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRule", ctx, userID, ruleID, dryRun)
+	ret0, _ := ret[0].(models.RuleRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunRule indicates an expected call of RunRule.
+func (mr *MockRuleServiceMockRecorder) RunRule(ctx, userID, ruleID, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRule", reflect.TypeOf((*MockRuleService)(nil).RunRule), ctx, userID, ruleID, dryRun)
+}
+
 // UpdateRule mocks base method.
 func (m *MockRuleService) UpdateRule(ctx context.Context, userID, ruleID string, params models.UpdateRuleParams) (models.Rule, error) {
 	m.ctrl.T.Helper()
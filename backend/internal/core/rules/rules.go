@@ -27,6 +27,7 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query"
+	"github.com/octobud-hq/octobud/backend/internal/query/expr"
 )
 
 // Error definitions
@@ -45,6 +46,9 @@ var (
 	ErrFailedToDeleteRule            = errors.New("failed to delete rule")
 	ErrFailedToReorderRules          = errors.New("failed to reorder rules")
 	ErrRuleNameAlreadyExists         = errors.New("a rule with that name already exists")
+	ErrFailedToListEvaluations       = errors.New("failed to list rule evaluations")
+	ErrRuleHasNoQuery                = errors.New("rule has no query to run")
+	ErrFailedToRunRule               = errors.New("failed to run rule")
 	// Validation errors
 	ErrNameRequired                    = errors.New("name is required")
 	ErrNameCannotBeEmpty               = errors.New("name cannot be empty")
@@ -52,6 +56,20 @@ var (
 	ErrQueryOrViewIDRequired           = errors.New("either query or viewId is required")
 	ErrQueryAndViewIDMutuallyExclusive = errors.New("only one of query or viewId can be provided")
 	ErrInvalidViewID                   = errors.New("invalid viewId")
+	ErrInvalidAlert                    = errors.New("invalid alert")
+	ErrInvalidTagSlug                  = errors.New("invalid tag slug")
+	ErrUnknownIntegration              = errors.New("unknown integration")
+	ErrInvalidPriority                 = errors.New("priority must be between -1000 and 1000")
+	ErrInvalidCondition                = errors.New("invalid condition")
+)
+
+// MaxPriority bounds rule priorities in both directions
+const MaxPriority = 1000
+
+// Bounds for ListEvaluations
+const (
+	DefaultEvaluationLimit = 50
+	MaxEvaluationLimit     = 500
 )
 
 // GetRulesByViewID returns all rules linked to a view
@@ -152,6 +170,25 @@ func (s *Service) CreateRule(
 		}
 	}
 
+	if alert := params.Actions.Alert; alert != nil {
+		if err := alert.Validate(); err != nil {
+			return models.Rule{}, errors.Join(ErrInvalidAlert, err)
+		}
+	}
+	if err := params.Actions.ValidateTagSlugs(); err != nil {
+		return models.Rule{}, errors.Join(ErrInvalidTagSlug, err)
+	}
+	if err := s.checkIntegrations(ctx, userID, params.Actions.NotifyWebhook); err != nil {
+		return models.Rule{}, err
+	}
+	if params.Priority < -MaxPriority || params.Priority > MaxPriority {
+		return models.Rule{}, ErrInvalidPriority
+	}
+	condition, err := validateCondition(params.Condition)
+	if err != nil {
+		return models.Rule{}, err
+	}
+
 	// Marshal actions to JSON
 	actionsJSON, err := json.Marshal(params.Actions)
 	if err != nil {
@@ -178,13 +215,16 @@ func (s *Service) CreateRule(
 	displayOrder := maxOrder + 100
 
 	dbParams := db.CreateRuleParams{
-		Name:         name,
-		Description:  models.StringPtrToNull(params.Description),
-		Query:        sql.NullString{String: queryStr, Valid: hasQuery},
-		ViewID:       viewID,
-		Enabled:      enabled,
-		Actions:      actionsJSON,
-		DisplayOrder: displayOrder,
+		Name:           name,
+		Description:    models.StringPtrToNull(params.Description),
+		Query:          sql.NullString{String: queryStr, Valid: hasQuery},
+		ViewID:         viewID,
+		Enabled:        enabled,
+		Actions:        actionsJSON,
+		DisplayOrder:   displayOrder,
+		Priority:       int32(params.Priority),
+		StopProcessing: params.StopProcessing,
+		Condition:      condition,
 	}
 
 	rule, err := s.queries.CreateRule(ctx, userID, dbParams)
@@ -254,6 +294,17 @@ func (s *Service) UpdateRule(
 		}
 	}
 	if params.Actions != nil {
+		if alert := params.Actions.Alert; alert != nil {
+			if err := alert.Validate(); err != nil {
+				return models.Rule{}, errors.Join(ErrInvalidAlert, err)
+			}
+		}
+		if err := params.Actions.ValidateTagSlugs(); err != nil {
+			return models.Rule{}, errors.Join(ErrInvalidTagSlug, err)
+		}
+		if err := s.checkIntegrations(ctx, userID, params.Actions.NotifyWebhook); err != nil {
+			return models.Rule{}, err
+		}
 		actionsJSON, err := json.Marshal(*params.Actions)
 		if err != nil {
 			return models.Rule{}, errors.Join(ErrFailedToProcessActions, err)
@@ -263,6 +314,23 @@ func (s *Service) UpdateRule(
 	if params.Enabled != nil {
 		dbParams.Enabled = sql.NullBool{Bool: *params.Enabled, Valid: true}
 	}
+	if params.Priority != nil {
+		if *params.Priority < -MaxPriority || *params.Priority > MaxPriority {
+			return models.Rule{}, ErrInvalidPriority
+		}
+		dbParams.Priority = sql.NullInt32{Int32: int32(*params.Priority), Valid: true}
+	}
+	if params.StopProcessing != nil {
+		dbParams.StopProcessing = sql.NullBool{Bool: *params.StopProcessing, Valid: true}
+	}
+	if params.Condition != nil {
+		condition, err := validateCondition(params.Condition)
+		if err != nil {
+			return models.Rule{}, err
+		}
+		// An empty condition clears the stored one
+		dbParams.Condition = sql.NullString{String: condition.String, Valid: true}
+	}
 
 	rule, err := s.queries.UpdateRule(ctx, userID, dbParams)
 	if err != nil {
@@ -329,3 +397,56 @@ func (s *Service) ReorderRules(
 
 	return ruleResponses, nil
 }
+
+// ListEvaluations returns the most recent rule evaluations, newest first. An empty
+// githubID lists evaluations for every notification.
+func (s *Service) ListEvaluations(
+	ctx context.Context,
+	userID string,
+	githubID string,
+	limit int,
+) ([]models.RuleEvaluation, error) {
+	if limit <= 0 {
+		limit = DefaultEvaluationLimit
+	}
+	if limit > MaxEvaluationLimit {
+		limit = MaxEvaluationLimit
+	}
+
+	evaluations, err := s.queries.ListRuleEvaluations(ctx, userID, githubID, int64(limit))
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListEvaluations, err)
+	}
+
+	response := make([]models.RuleEvaluation, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		response = append(response, models.RuleEvaluationFromDB(evaluation))
+	}
+	return response, nil
+}
+
+// checkIntegrations makes sure every integration a rule notifies exists
+func (s *Service) checkIntegrations(ctx context.Context, userID string, ids []string) error {
+	for _, id := range ids {
+		if _, err := s.queries.GetIntegration(ctx, userID, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w %q", ErrUnknownIntegration, id)
+			}
+			return errors.Join(ErrFailedToProcessActions, err)
+		}
+	}
+	return nil
+}
+
+// validateCondition checks that a condition compiles. It returns a null string when
+// there's no condition.
+func validateCondition(condition *string) (sql.NullString, error) {
+	if condition == nil || strings.TrimSpace(*condition) == "" {
+		return sql.NullString{}, nil
+	}
+	source := strings.TrimSpace(*condition)
+	if _, err := expr.Compile(source); err != nil {
+		return sql.NullString{}, errors.Join(ErrInvalidCondition, err)
+	}
+	return sql.NullString{String: source, Valid: true}, nil
+}
@@ -437,6 +437,91 @@ func TestService_CreateRule(t *testing.T) {
 				require.Contains(t, err.Error(), "only one of query or viewId can be provided")
 			},
 		},
+		{
+			name: "unknown alert level returns ErrInvalidAlert before DB call",
+			params: models.CreateRuleParams{
+				Name:  "My Rule",
+				Query: stringPtr("is:unread"),
+				Actions: models.RuleActions{
+					Alert: &models.AlertConfig{Level: "loud"},
+				},
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ models.CreateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidAlert)
+			},
+		},
+		{
+			name: "tag slug that isn't a slug returns ErrInvalidTagSlug before DB call",
+			params: models.CreateRuleParams{
+				Name:  "My Rule",
+				Query: stringPtr("is:unread"),
+				Actions: models.RuleActions{
+					AddTagSlugs: []string{"Security Alerts"},
+				},
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ models.CreateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidTagSlug)
+				require.Contains(t, err.Error(), `try "security-alerts"`)
+			},
+		},
+		{
+			name: "unknown integration returns ErrUnknownIntegration",
+			params: models.CreateRuleParams{
+				Name:  "My Rule",
+				Query: stringPtr("is:unread"),
+				Actions: models.RuleActions{
+					NotifyWebhook: []string{"missing"},
+				},
+			},
+			setupMock: func(m *mocks.MockStore, userID string, _ models.CreateRuleParams) {
+				m.EXPECT().
+					GetIntegration(gomock.Any(), userID, "missing").
+					Return(db.Integration{}, sql.ErrNoRows)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrUnknownIntegration)
+			},
+		},
+		{
+			name: "out of range priority returns ErrInvalidPriority before DB call",
+			params: models.CreateRuleParams{
+				Name:     "My Rule",
+				Query:    stringPtr("is:unread"),
+				Priority: MaxPriority + 1,
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ models.CreateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidPriority)
+			},
+		},
+		{
+			name: "invalid condition returns ErrInvalidCondition before DB call",
+			params: models.CreateRuleParams{
+				Name:      "My Rule",
+				Query:     stringPtr("is:unread"),
+				Condition: stringPtr("comments > 3"),
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ models.CreateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidCondition)
+				require.Contains(t, err.Error(), "comments")
+			},
+		},
 		{
 			name: "invalid viewID returns error",
 			params: models.CreateRuleParams{
@@ -567,6 +652,97 @@ func TestService_UpdateRule(t *testing.T) {
 				require.Equal(t, "Updated Rule", rule.Name)
 			},
 		},
+		{
+			name:   "success updates priority and stop processing",
+			ruleID: "rule-1",
+			params: models.UpdateRuleParams{
+				Priority:       intPtr(50),
+				StopProcessing: boolPtr(true),
+			},
+			setupMock: func(m *mocks.MockStore, userID, id string, _ models.UpdateRuleParams) {
+				m.EXPECT().
+					UpdateRule(gomock.Any(), userID, db.UpdateRuleParams{
+						ID:             id,
+						Priority:       sql.NullInt32{Int32: 50, Valid: true},
+						StopProcessing: sql.NullBool{Bool: true, Valid: true},
+					}).
+					Return(db.Rule{ID: id, Priority: 50, StopProcessing: true}, nil)
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, rule models.Rule) {
+				require.Equal(t, 50, rule.Priority)
+				require.True(t, rule.StopProcessing)
+			},
+		},
+		{
+			name:   "out of range priority returns ErrInvalidPriority before DB call",
+			ruleID: "rule-1",
+			params: models.UpdateRuleParams{
+				Priority: intPtr(-MaxPriority - 1),
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ string, _ models.UpdateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidPriority)
+			},
+		},
+		{
+			name:   "success sets a trimmed condition",
+			ruleID: "rule-1",
+			params: models.UpdateRuleParams{
+				Condition: stringPtr("  len(labels) > 2  "),
+			},
+			setupMock: func(m *mocks.MockStore, userID, id string, _ models.UpdateRuleParams) {
+				m.EXPECT().
+					UpdateRule(gomock.Any(), userID, db.UpdateRuleParams{
+						ID:        id,
+						Condition: sql.NullString{String: "len(labels) > 2", Valid: true},
+					}).
+					Return(db.Rule{
+						ID:        id,
+						Condition: sql.NullString{String: "len(labels) > 2", Valid: true},
+					}, nil)
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, rule models.Rule) {
+				require.Equal(t, "len(labels) > 2", rule.Condition)
+			},
+		},
+		{
+			name:   "empty condition clears it",
+			ruleID: "rule-1",
+			params: models.UpdateRuleParams{
+				Condition: stringPtr(""),
+			},
+			setupMock: func(m *mocks.MockStore, userID, id string, _ models.UpdateRuleParams) {
+				m.EXPECT().
+					UpdateRule(gomock.Any(), userID, db.UpdateRuleParams{
+						ID:        id,
+						Condition: sql.NullString{String: "", Valid: true},
+					}).
+					Return(db.Rule{ID: id}, nil)
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, rule models.Rule) {
+				require.Empty(t, rule.Condition)
+			},
+		},
+		{
+			name:   "invalid condition returns ErrInvalidCondition before DB call",
+			ruleID: "rule-1",
+			params: models.UpdateRuleParams{
+				Condition: stringPtr("title > 3"),
+			},
+			setupMock: func(_ *mocks.MockStore, _ string, _ string, _ models.UpdateRuleParams) {
+				// No mock expectations - should fail before DB call
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidCondition)
+			},
+		},
 		{
 			name:   "empty name returns error before DB call",
 			ruleID: "rule-1",
@@ -848,6 +1024,292 @@ func TestService_ReorderRules(t *testing.T) {
 }
 
 // Helper functions
+func TestService_ListEvaluations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	service := NewService(mockQuerier)
+
+	evaluatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockQuerier.EXPECT().
+		ListRuleEvaluations(gomock.Any(), "test-user-id", "notif-1", int64(MaxEvaluationLimit)).
+		Return([]db.RuleEvaluation{{
+			GithubID:     "notif-1",
+			SubjectTitle: "Fix the flaky test",
+			Trace: json.RawMessage(`[{"ruleId":"r1","ruleName":"Stop","priority":5,` +
+				`"result":"matched","stoppedProcessing":true}]`),
+			EvaluatedAt: evaluatedAt,
+		}}, nil)
+
+	evaluations, err := service.ListEvaluations(
+		context.Background(),
+		"test-user-id",
+		"notif-1",
+		MaxEvaluationLimit+1,
+	)
+	require.NoError(t, err)
+	require.Equal(t, []models.RuleEvaluation{{
+		GithubID:     "notif-1",
+		SubjectTitle: "Fix the flaky test",
+		EvaluatedAt:  "2025-06-01T12:00:00Z",
+		Rules: []models.RuleTraceEntry{{
+			RuleID:            "r1",
+			RuleName:          "Stop",
+			Priority:          5,
+			Result:            models.RuleResultMatched,
+			StoppedProcessing: true,
+		}},
+	}}, evaluations)
+
+	mockQuerier.EXPECT().
+		ListRuleEvaluations(gomock.Any(), "test-user-id", "", int64(DefaultEvaluationLimit)).
+		Return(nil, errors.New("database is locked"))
+	_, err = service.ListEvaluations(context.Background(), "test-user-id", "", 0)
+	require.ErrorIs(t, err, ErrFailedToListEvaluations)
+}
+
+func TestService_RunRule(t *testing.T) {
+	const testUserID = "test-user-id"
+	ruleWith := func(actions models.RuleActions) db.Rule {
+		actionsJSON, err := json.Marshal(actions)
+		require.NoError(t, err)
+		return db.Rule{
+			ID:      "rule-1",
+			UserID:  testUserID,
+			Query:   sql.NullString{String: "repo:octobud is:unread", Valid: true},
+			Actions: actionsJSON,
+		}
+	}
+
+	t.Run("dry run only counts matches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(ruleWith(models.RuleActions{Archive: true}), nil)
+		mockQuerier.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: 7}, nil)
+
+		result, err := service.RunRule(context.Background(), testUserID, "rule-1", true)
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Matched: 7, DryRun: true}, result)
+	})
+
+	t.Run("applies actions in bulk with mark read last", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(ruleWith(models.RuleActions{
+				SkipInbox:  true,
+				MarkRead:   true,
+				Archive:    true,
+				AssignTags: []string{"tag-1", "tag-gone"},
+			}), nil)
+		gomock.InOrder(
+			mockQuerier.EXPECT().
+				ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					_ string,
+					q db.NotificationQuery,
+				) (db.ListNotificationsFromQueryResult, error) {
+					require.Equal(t, int32(1), q.Limit)
+					return db.ListNotificationsFromQueryResult{Total: 2}, nil
+				}),
+			mockQuerier.EXPECT().
+				ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					_ string,
+					q db.NotificationQuery,
+				) (db.ListNotificationsFromQueryResult, error) {
+					require.Equal(t, int32(2), q.Limit)
+					return db.ListNotificationsFromQueryResult{
+						Notifications: []db.Notification{{ID: 1}, {ID: 2}},
+						Total:         2,
+					}, nil
+				}),
+			mockQuerier.EXPECT().
+				BulkMarkNotificationsFilteredByQuery(gomock.Any(), testUserID, gomock.Any()).
+				Return(int64(2), nil),
+			mockQuerier.EXPECT().
+				BulkArchiveNotificationsByQuery(gomock.Any(), testUserID, gomock.Any()).
+				Return(int64(2), nil),
+			mockQuerier.EXPECT().
+				BulkMarkNotificationsReadByQuery(gomock.Any(), testUserID, gomock.Any()).
+				Return(int64(2), nil),
+		)
+		mockQuerier.EXPECT().GetTag(gomock.Any(), testUserID, "tag-1").Return(db.Tag{ID: "tag-1"}, nil)
+		mockQuerier.EXPECT().GetTag(gomock.Any(), testUserID, "tag-gone").Return(db.Tag{}, sql.ErrNoRows)
+		for _, id := range []int64{1, 2} {
+			mockQuerier.EXPECT().
+				AssignTagToEntity(gomock.Any(), testUserID, db.AssignTagToEntityParams{
+					TagID:      "tag-1",
+					EntityType: "notification",
+					EntityID:   id,
+				}).
+				Return(db.TagAssignment{}, nil)
+		}
+
+		result, err := service.RunRule(context.Background(), testUserID, "rule-1", false)
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Matched: 2}, result)
+	})
+
+	t.Run("tag slugs create missing tags and skip missing removals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(ruleWith(models.RuleActions{
+				AddTagSlugs:    []string{"security"},
+				RemoveTagSlugs: []string{"triage", "never-made"},
+			}), nil)
+		mockQuerier.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: 1}, nil)
+		mockQuerier.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{
+				Notifications: []db.Notification{{ID: 1}},
+				Total:         1,
+			}, nil)
+		mockQuerier.EXPECT().
+			GetTagBySlug(gomock.Any(), testUserID, "security").
+			Return(db.Tag{}, sql.ErrNoRows)
+		mockQuerier.EXPECT().
+			UpsertTag(gomock.Any(), testUserID, db.UpsertTagParams{Name: "security", Slug: "security"}).
+			Return(db.Tag{ID: "tag-new", Slug: "security"}, nil)
+		mockQuerier.EXPECT().
+			GetTagBySlug(gomock.Any(), testUserID, "triage").
+			Return(db.Tag{ID: "tag-triage", Slug: "triage"}, nil)
+		mockQuerier.EXPECT().
+			GetTagBySlug(gomock.Any(), testUserID, "never-made").
+			Return(db.Tag{}, sql.ErrNoRows)
+		mockQuerier.EXPECT().
+			AssignTagToEntity(gomock.Any(), testUserID, db.AssignTagToEntityParams{
+				TagID:      "tag-new",
+				EntityType: "notification",
+				EntityID:   1,
+			}).
+			Return(db.TagAssignment{}, nil)
+		mockQuerier.EXPECT().
+			RemoveTagAssignment(gomock.Any(), testUserID, db.RemoveTagAssignmentParams{
+				TagID:      "tag-triage",
+				EntityType: "notification",
+				EntityID:   1,
+			}).
+			Return(nil)
+
+		result, err := service.RunRule(context.Background(), testUserID, "rule-1", false)
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Matched: 1}, result)
+	})
+
+	t.Run("condition narrows the matches the actions apply to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		rule := ruleWith(models.RuleActions{Archive: true})
+		rule.Condition = sql.NullString{String: `number > 10`, Valid: true}
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(rule, nil)
+		gomock.InOrder(
+			mockQuerier.EXPECT().
+				ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+				Return(db.ListNotificationsFromQueryResult{Total: 3}, nil),
+			mockQuerier.EXPECT().
+				ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+				Return(db.ListNotificationsFromQueryResult{
+					Notifications: []db.Notification{
+						{ID: 1, RepositoryID: 5, SubjectNumber: sql.NullInt32{Int32: 4, Valid: true}},
+						{ID: 2, RepositoryID: 5, SubjectNumber: sql.NullInt32{Int32: 40, Valid: true}},
+						{ID: 3, RepositoryID: 5, SubjectNumber: sql.NullInt32{Int32: 400, Valid: true}},
+					},
+					Total: 3,
+				}, nil),
+			mockQuerier.EXPECT().
+				BulkArchiveNotificationsByQuery(gomock.Any(), testUserID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, q db.NotificationQuery) (int64, error) {
+					require.Contains(t, q.Where, "n.id IN (?, ?)")
+					require.Equal(t, []interface{}{int64(2), int64(3)}, q.Args[len(q.Args)-2:])
+					return 2, nil
+				}),
+		)
+		// Notifications in the same repository share one lookup
+		mockQuerier.EXPECT().
+			GetRepositoryByID(gomock.Any(), testUserID, int64(5)).
+			Return(db.Repository{ID: 5, FullName: "octobud/octobud"}, nil)
+
+		result, err := service.RunRule(context.Background(), testUserID, "rule-1", false)
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{Matched: 2}, result)
+	})
+
+	t.Run("nothing matched applies nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(ruleWith(models.RuleActions{Archive: true}), nil)
+		mockQuerier.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), testUserID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{}, nil)
+
+		result, err := service.RunRule(context.Background(), testUserID, "rule-1", false)
+		require.NoError(t, err)
+		require.Equal(t, models.RuleRunResult{}, result)
+	})
+
+	t.Run("view without a query returns ErrRuleHasNoQuery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(db.Rule{ID: "rule-1", ViewID: sql.NullString{String: "view-1", Valid: true}}, nil)
+		mockQuerier.EXPECT().
+			GetView(gomock.Any(), testUserID, "view-1").
+			Return(db.View{ID: "view-1"}, nil)
+
+		_, err := service.RunRule(context.Background(), testUserID, "rule-1", true)
+		require.ErrorIs(t, err, ErrRuleHasNoQuery)
+	})
+
+	t.Run("missing rule returns ErrRuleNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		service := NewService(mockQuerier)
+
+		mockQuerier.EXPECT().
+			GetRule(gomock.Any(), testUserID, "rule-1").
+			Return(db.Rule{}, sql.ErrNoRows)
+
+		_, err := service.RunRule(context.Background(), testUserID, "rule-1", true)
+		require.ErrorIs(t, err, ErrRuleNotFound)
+	})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,325 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+	"github.com/octobud-hq/octobud/backend/internal/query/expr"
+)
+
+// RunRule applies a rule's actions to every existing notification its query and condition
+// match, including archived, snoozed and muted ones, the same as applying a new rule to
+// existing notifications. Rules run whether or not they are enabled, since running one is
+// an explicit request. With dryRun set nothing is changed and only the match count is
+// returned.
+func (s *Service) RunRule(
+	ctx context.Context,
+	userID string,
+	ruleID string,
+	dryRun bool,
+) (models.RuleRunResult, error) {
+	rule, err := s.queries.GetRule(ctx, userID, ruleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RuleRunResult{}, errors.Join(ErrRuleNotFound, err)
+		}
+		return models.RuleRunResult{}, errors.Join(ErrFailedToGetRule, err)
+	}
+
+	queryStr, err := s.resolveRuleQuery(ctx, userID, rule)
+	if err != nil {
+		return models.RuleRunResult{}, err
+	}
+	fullQueryStr := fmt.Sprintf("(%s) AND in:anywhere", queryStr)
+
+	countQuery, err := query.BuildQuery(fullQueryStr, 1, 0)
+	if err != nil {
+		return models.RuleRunResult{}, errors.Join(ErrInvalidQuery, err)
+	}
+	counted, err := s.queries.ListNotificationsFromQuery(ctx, userID, countQuery)
+	if err != nil {
+		return models.RuleRunResult{}, errors.Join(ErrFailedToRunRule, err)
+	}
+
+	target := ruleTarget{query: fullQueryStr}
+	matched := counted.Total
+	if rule.Condition.Valid && matched > 0 {
+		target.ids, err = s.filterByCondition(ctx, userID, target, matched, rule.Condition.String)
+		if err != nil {
+			return models.RuleRunResult{}, err
+		}
+		matched = int64(len(target.ids))
+	}
+
+	result := models.RuleRunResult{Matched: matched, DryRun: dryRun}
+	if dryRun || result.Matched == 0 {
+		return result, nil
+	}
+
+	var actions models.RuleActions
+	if len(rule.Actions) > 0 {
+		if err := json.Unmarshal(rule.Actions, &actions); err != nil {
+			return models.RuleRunResult{}, errors.Join(ErrFailedToProcessActions, err)
+		}
+	}
+
+	// The matches are listed before the actions can stop them from matching
+	var matchedIDs []string
+	if s.history != nil {
+		listQuery, err := target.build(0)
+		if err != nil {
+			return models.RuleRunResult{}, errors.Join(ErrInvalidQuery, err)
+		}
+		matchedIDs, err = s.queries.ListNotificationGithubIDsFromQuery(ctx, userID, listQuery)
+		if err != nil {
+			return models.RuleRunResult{}, errors.Join(ErrFailedToRunRule, err)
+		}
+	}
+
+	if err := s.applyRuleActionsByQuery(ctx, userID, target, result.Matched, actions); err != nil {
+		return models.RuleRunResult{}, errors.Join(ErrFailedToRunRule, err)
+	}
+	if s.history != nil {
+		s.history.Record(ctx, userID, matchedIDs, models.HistoryRuleApplied, models.HistorySourceRule, rule.Name)
+	}
+	return result, nil
+}
+
+// resolveRuleQuery returns the query a rule matches on, preferring its linked view's
+func (s *Service) resolveRuleQuery(ctx context.Context, userID string, rule db.Rule) (string, error) {
+	if rule.ViewID.Valid {
+		view, err := s.queries.GetView(ctx, userID, rule.ViewID.String)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", errors.Join(ErrViewNotFound, err)
+			}
+			return "", errors.Join(ErrFailedToVerifyView, err)
+		}
+		if !view.Query.Valid || view.Query.String == "" {
+			return "", ErrRuleHasNoQuery
+		}
+		return view.Query.String, nil
+	}
+
+	if !rule.Query.Valid || rule.Query.String == "" {
+		return "", ErrRuleHasNoQuery
+	}
+	return rule.Query.String, nil
+}
+
+// ruleTarget is the notifications a rule run acts on: those matching query, narrowed to
+// ids when the rule has a condition
+type ruleTarget struct {
+	query string
+	ids   []int64
+}
+
+// build builds the query for the target's notifications
+func (t ruleTarget) build(limit int32) (db.NotificationQuery, error) {
+	dbQuery, err := query.BuildQuery(t.query, limit, 0)
+	if err != nil || t.ids == nil {
+		return dbQuery, err
+	}
+	placeholders := make([]string, len(t.ids))
+	for i, id := range t.ids {
+		placeholders[i] = "?"
+		dbQuery.Args = append(dbQuery.Args, id)
+	}
+	dbQuery.Where = append(dbQuery.Where, "n.id IN ("+strings.Join(placeholders, ", ")+")")
+	return dbQuery, nil
+}
+
+// filterByCondition returns the IDs of the notifications matching target that also
+// satisfy condition. Notifications the condition fails to evaluate on don't match, the
+// same as when rules run at sync.
+func (s *Service) filterByCondition(
+	ctx context.Context,
+	userID string,
+	target ruleTarget,
+	matched int64,
+	condition string,
+) ([]int64, error) {
+	program, err := expr.Compile(condition)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCondition, err)
+	}
+	listQuery, err := target.build(int32(matched))
+	if err != nil {
+		return nil, errors.Join(ErrInvalidQuery, err)
+	}
+	listed, err := s.queries.ListNotificationsFromQuery(ctx, userID, listQuery)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToRunRule, err)
+	}
+
+	now := time.Now()
+	repos := make(map[int64]*db.Repository)
+	ids := make([]int64, 0, len(listed.Notifications))
+	for i := range listed.Notifications {
+		notification := &listed.Notifications[i]
+		repo, ok := repos[notification.RepositoryID]
+		if !ok {
+			r, err := s.queries.GetRepositoryByID(ctx, userID, notification.RepositoryID)
+			switch {
+			case err == nil:
+				repo = &r
+			case !errors.Is(err, sql.ErrNoRows):
+				return nil, errors.Join(ErrFailedToRunRule, err)
+			}
+			repos[notification.RepositoryID] = repo
+		}
+		satisfied, evalErr := program.Eval(expr.NotificationEnv(notification, repo, now))
+		if evalErr == nil && satisfied {
+			ids = append(ids, notification.ID)
+		}
+	}
+	return ids, nil
+}
+
+// applyRuleActionsByQuery applies actions to the notifications in target. Tags
+// are applied first, to the notifications matched before anything changes. Marking read
+// runs last because it is the action most likely to stop a notification from matching
+// (an is:unread rule, say) for the actions after it.
+func (s *Service) applyRuleActionsByQuery(
+	ctx context.Context,
+	userID string,
+	target ruleTarget,
+	matched int64,
+	actions models.RuleActions,
+) error {
+	if actions.HasTagActions() {
+		listQuery, err := target.build(int32(matched))
+		if err != nil {
+			return err
+		}
+		listed, err := s.queries.ListNotificationsFromQuery(ctx, userID, listQuery)
+		if err != nil {
+			return err
+		}
+		if err := s.applyRuleTags(ctx, userID, listed.Notifications, actions); err != nil {
+			return err
+		}
+	}
+
+	dbQuery, err := target.build(0)
+	if err != nil {
+		return err
+	}
+
+	bulkActions := []struct {
+		enabled bool
+		apply   func(context.Context, string, db.NotificationQuery) (int64, error)
+	}{
+		{actions.SkipInbox, s.queries.BulkMarkNotificationsFilteredByQuery},
+		{actions.Star, s.queries.BulkStarNotificationsByQuery},
+		{actions.Mute, s.queries.BulkMuteNotificationsByQuery},
+		{actions.Archive, s.queries.BulkArchiveNotificationsByQuery},
+		{actions.MarkRead, s.queries.BulkMarkNotificationsReadByQuery},
+	}
+	for _, action := range bulkActions {
+		if !action.enabled {
+			continue
+		}
+		if _, err := action.apply(ctx, userID, dbQuery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRuleTags assigns and removes a rule's tags on notifications. Tags to add by slug
+// are created if missing; other tags that no longer exist are skipped.
+func (s *Service) applyRuleTags(
+	ctx context.Context,
+	userID string,
+	notifications []db.Notification,
+	actions models.RuleActions,
+) error {
+	assign, err := s.existingTagIDs(ctx, userID, actions.AssignTags)
+	if err != nil {
+		return err
+	}
+	remove, err := s.existingTagIDs(ctx, userID, actions.RemoveTags)
+	if err != nil {
+		return err
+	}
+	for _, slug := range actions.AddTagSlugs {
+		t, err := s.tags.EnsureTagBySlug(ctx, userID, slug)
+		if err != nil {
+			return fmt.Errorf("failed to get or create tag %s: %w", slug, err)
+		}
+		assign = append(assign, t.ID)
+	}
+	for _, slug := range actions.RemoveTagSlugs {
+		t, err := s.queries.GetTagBySlug(ctx, userID, slug)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("failed to get tag %s: %w", slug, err)
+		}
+		remove = append(remove, t.ID)
+	}
+
+	for _, notification := range notifications {
+		for _, tagID := range assign {
+			_, err := s.queries.AssignTagToEntity(ctx, userID, db.AssignTagToEntityParams{
+				TagID:      tagID,
+				EntityType: "notification",
+				EntityID:   notification.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to assign tag %s: %w", tagID, err)
+			}
+		}
+		for _, tagID := range remove {
+			err := s.queries.RemoveTagAssignment(ctx, userID, db.RemoveTagAssignmentParams{
+				TagID:      tagID,
+				EntityType: "notification",
+				EntityID:   notification.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove tag %s: %w", tagID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// existingTagIDs filters tagIDs down to the tags that still exist
+func (s *Service) existingTagIDs(ctx context.Context, userID string, tagIDs []string) ([]string, error) {
+	existing := make([]string, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		if _, err := s.queries.GetTag(ctx, userID, tagID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get tag %s: %w", tagID, err)
+		}
+		existing = append(existing, tagID)
+	}
+	return existing, nil
+}
@@ -18,6 +18,8 @@ package rules
 import (
 	"context"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/tag"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
@@ -40,16 +42,41 @@ type RuleService interface {
 	) (models.Rule, error)
 	DeleteRule(ctx context.Context, userID string, ruleID string) error
 	ReorderRules(ctx context.Context, userID string, ruleIDs []string) ([]models.Rule, error)
+	// RunRule applies a rule's actions to the existing notifications its query matches.
+	// With dryRun set, it only counts them.
+	RunRule(
+		ctx context.Context,
+		userID string,
+		ruleID string,
+		dryRun bool,
+	) (models.RuleRunResult, error)
+	// ListEvaluations lists the most recent rule evaluations, newest first, optionally
+	// only for the notification with githubID
+	ListEvaluations(
+		ctx context.Context,
+		userID string,
+		githubID string,
+		limit int,
+	) ([]models.RuleEvaluation, error)
 }
 
 // Service provides business logic for rule operations
 type Service struct {
 	queries db.Store
+	tags    tag.TagService
+	history history.HistoryService
 }
 
 // NewService constructs a Service backed by the provided queries
 func NewService(queries db.Store) *Service {
 	return &Service{
 		queries: queries,
+		tags:    tag.NewService(queries),
 	}
 }
+
+// WithHistory records rules run by hand in the history of the notifications they change
+func (s *Service) WithHistory(h history.HistoryService) *Service {
+	s.history = h
+	return s
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/savedreply/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/savedreply/service.go -destination=internal/core/savedreply/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	savedreply "github.com/octobud-hq/octobud/backend/internal/core/savedreply"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSavedReplyService is a mock of SavedReplyService interface.
+type MockSavedReplyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedReplyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSavedReplyServiceMockRecorder is the mock recorder for MockSavedReplyService.
+type MockSavedReplyServiceMockRecorder struct {
+	mock *MockSavedReplyService
+}
+
+// NewMockSavedReplyService creates a new mock instance.
+func NewMockSavedReplyService(ctrl *gomock.Controller) *MockSavedReplyService {
+	mock := &MockSavedReplyService{ctrl: ctrl}
+	mock.recorder = &MockSavedReplyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedReplyService) EXPECT() *MockSavedReplyServiceMockRecorder {
+	return m.recorder
+}
+
+// Compose mocks base method.
+func (m *MockSavedReplyService) Compose(ctx context.Context, userID, id string, vars savedreply.Variables) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compose", ctx, userID, id, vars)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compose indicates an expected call of Compose.
+func (mr *MockSavedReplyServiceMockRecorder) Compose(ctx, userID, id, vars any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compose", reflect.TypeOf((*MockSavedReplyService)(nil).Compose), ctx, userID, id, vars)
+}
+
+// List mocks base method.
+func (m *MockSavedReplyService) List(ctx context.Context, userID string, refresh bool) ([]models.SavedReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, refresh)
+	ret0, _ := ret[0].([]models.SavedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSavedReplyServiceMockRecorder) List(ctx, userID, refresh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSavedReplyService)(nil).List), ctx, userID, refresh)
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package savedreply
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// DefaultTTL is how long imported saved replies are used before they're imported again
+const DefaultTTL = time.Hour
+
+// Error definitions
+var (
+	ErrNotFound        = errors.New("saved reply not found")
+	ErrFailedToImport  = errors.New("failed to import saved replies")
+	ErrFailedToPersist = errors.New("failed to store saved replies")
+)
+
+// variablePattern matches a template variable such as {{author}} or {{ pr_title }}
+var variablePattern = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// Variables are the values substituted into a saved reply's template
+type Variables struct {
+	// Author is the login of the notification's author, filled in for {{author}}
+	Author string
+	// PRTitle is the title of the notification's subject, filled in for {{pr_title}}
+	PRTitle string
+}
+
+// Render fills in the template variables in body. Unknown variables are left as written,
+// so replies that happen to contain braces aren't mangled.
+func Render(body string, vars Variables) string {
+	values := map[string]string{
+		"author":   vars.Author,
+		"pr_title": vars.PRTitle,
+	}
+	return variablePattern.ReplaceAllStringFunc(body, func(match string) string {
+		if value, ok := values[variablePattern.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// Service imports saved replies from GitHub and caches them in the database
+type Service struct {
+	logger  *zap.Logger
+	queries db.Store
+	client  githubinterfaces.Client
+	now     func() time.Time
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	checkedAt map[string]time.Time
+}
+
+// NewService constructs a Service that imports saved replies with the given GitHub client
+func NewService(
+	logger *zap.Logger,
+	queries db.Store,
+	client githubinterfaces.Client,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		logger:    logger,
+		queries:   queries,
+		client:    client,
+		now:       now,
+		ttl:       DefaultTTL,
+		checkedAt: make(map[string]time.Time),
+	}
+}
+
+// WithTTL sets how long imported saved replies are used before they're imported again
+func (s *Service) WithTTL(ttl time.Duration) *Service {
+	s.ttl = ttl
+	return s
+}
+
+// List returns the user's saved replies. They're imported from GitHub when refresh is set
+// or the last import is older than the TTL. A failed background import is logged and the
+// cached replies are returned instead; a failed forced refresh is returned as an error.
+func (s *Service) List(
+	ctx context.Context,
+	userID string,
+	refresh bool,
+) ([]models.SavedReply, error) {
+	cached, err := s.queries.ListSavedReplies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !refresh && !s.stale(userID, cached) {
+		return toModels(cached), nil
+	}
+
+	imported, err := s.importReplies(ctx, userID)
+	if err != nil {
+		if refresh {
+			return nil, err
+		}
+		s.logger.Warn("failed to import saved replies", zap.Error(err))
+		return toModels(cached), nil
+	}
+	return toModels(imported), nil
+}
+
+// Compose returns the body of a cached saved reply with its template variables filled in
+func (s *Service) Compose(
+	ctx context.Context,
+	userID, id string,
+	vars Variables,
+) (string, error) {
+	reply, err := s.queries.GetSavedReply(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return Render(reply.Body, vars), nil
+}
+
+// stale reports whether the user's saved replies are due to be imported again. Before
+// the first check in this process, the cached replies' fetch time is used.
+func (s *Service) stale(userID string, cached []db.SavedReply) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkedAt, ok := s.checkedAt[userID]
+	if !ok && len(cached) > 0 {
+		checkedAt = cached[0].FetchedAt
+	}
+	return checkedAt.IsZero() || s.now().Sub(checkedAt) > s.ttl
+}
+
+// importReplies fetches the user's saved replies and replaces the cached ones. Failed
+// imports also count as a check, so an unreachable GitHub isn't retried on every request.
+func (s *Service) importReplies(ctx context.Context, userID string) ([]db.SavedReply, error) {
+	now := s.now()
+	s.mu.Lock()
+	s.checkedAt[userID] = now
+	s.mu.Unlock()
+
+	fetched, err := s.client.FetchSavedReplies(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+
+	params := db.ReplaceSavedRepliesParams{FetchedAt: now}
+	for _, reply := range fetched {
+		params.Replies = append(params.Replies, db.SavedReplyParams{
+			GithubID: reply.ID,
+			Title:    reply.Title,
+			Body:     reply.Body,
+		})
+	}
+	if err := s.queries.ReplaceSavedReplies(ctx, userID, params); err != nil {
+		return nil, errors.Join(ErrFailedToPersist, err)
+	}
+
+	return s.queries.ListSavedReplies(ctx, userID)
+}
+
+func toModels(replies []db.SavedReply) []models.SavedReply {
+	result := make([]models.SavedReply, len(replies))
+	for i, reply := range replies {
+		result[i] = models.SavedReplyFromDB(reply)
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package savedreply
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService(t *testing.T) (*Service, *mocks.MockStore, *githubmocks.MockClient) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	client := githubmocks.NewMockClient(ctrl)
+	svc := NewService(zap.NewNop(), store, client, func() time.Time { return testNow })
+	return svc, store, client
+}
+
+func TestRender(t *testing.T) {
+	vars := Variables{Author: "octocat", PRTitle: "Fix the flaky test"}
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"author", "Thanks @{{author}}!", "Thanks @octocat!"},
+		{"title", "Re: {{pr_title}}", "Re: Fix the flaky test"},
+		{"spaces inside braces", "{{ author }} / {{  pr_title }}", "octocat / Fix the flaky test"},
+		{"repeated", "{{author}} {{author}}", "octocat octocat"},
+		{"unknown variable kept", "Hi {{reviewer}}", "Hi {{reviewer}}"},
+		{"no variables", "LGTM", "LGTM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Render(tt.body, vars))
+		})
+	}
+}
+
+func TestService_List_ImportsWhenNeverImported(t *testing.T) {
+	svc, store, client := newTestService(t)
+	imported := []db.SavedReply{{GithubID: "SR_1", Title: "Thanks", Body: "Thanks!"}}
+
+	gomock.InOrder(
+		store.EXPECT().ListSavedReplies(gomock.Any(), "user-1").Return(nil, nil),
+		client.EXPECT().FetchSavedReplies(gomock.Any()).Return([]types.SavedReply{
+			{ID: "SR_1", Title: "Thanks", Body: "Thanks!"},
+		}, nil),
+		store.EXPECT().ReplaceSavedReplies(gomock.Any(), "user-1", db.ReplaceSavedRepliesParams{
+			Replies:   []db.SavedReplyParams{{GithubID: "SR_1", Title: "Thanks", Body: "Thanks!"}},
+			FetchedAt: testNow,
+		}).Return(nil),
+		store.EXPECT().ListSavedReplies(gomock.Any(), "user-1").Return(imported, nil),
+	)
+
+	replies, err := svc.List(context.Background(), "user-1", false)
+	require.NoError(t, err)
+	require.Equal(t, []models.SavedReply{{ID: "SR_1", Title: "Thanks", Body: "Thanks!"}}, replies)
+}
+
+func TestService_List_UsesFreshCache(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	store.EXPECT().ListSavedReplies(gomock.Any(), "user-1").Return([]db.SavedReply{
+		{GithubID: "SR_1", Title: "Thanks", Body: "Thanks!", FetchedAt: testNow.Add(-time.Minute)},
+	}, nil)
+
+	replies, err := svc.List(context.Background(), "user-1", false)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+}
+
+func TestService_List_ImportFailureFallsBackToCache(t *testing.T) {
+	svc, store, client := newTestService(t)
+	cached := []db.SavedReply{
+		{GithubID: "SR_1", Title: "Thanks", FetchedAt: testNow.Add(-DefaultTTL - time.Minute)},
+	}
+	store.EXPECT().ListSavedReplies(gomock.Any(), "user-1").Return(cached, nil).Times(2)
+	client.EXPECT().FetchSavedReplies(gomock.Any()).Return(nil, errors.New("offline"))
+
+	replies, err := svc.List(context.Background(), "user-1", false)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+
+	// The failed import counts as a check, so the next list doesn't hit GitHub again
+	replies, err = svc.List(context.Background(), "user-1", false)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+}
+
+func TestService_List_RefreshFailureIsReturned(t *testing.T) {
+	svc, store, client := newTestService(t)
+	store.EXPECT().ListSavedReplies(gomock.Any(), "user-1").Return(nil, nil)
+	client.EXPECT().FetchSavedReplies(gomock.Any()).Return(nil, errors.New("offline"))
+
+	_, err := svc.List(context.Background(), "user-1", true)
+	require.ErrorIs(t, err, ErrFailedToImport)
+}
+
+func TestService_Compose(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	store.EXPECT().GetSavedReply(gomock.Any(), "user-1", "SR_1").Return(db.SavedReply{
+		GithubID: "SR_1",
+		Body:     "Thanks @{{author}} for {{pr_title}}",
+	}, nil)
+	store.EXPECT().GetSavedReply(gomock.Any(), "user-1", "SR_missing").
+		Return(db.SavedReply{}, sql.ErrNoRows)
+
+	body, err := svc.Compose(context.Background(), "user-1", "SR_1", Variables{
+		Author:  "octocat",
+		PRTitle: "Add retries",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Thanks @octocat for Add retries", body)
+
+	_, err = svc.Compose(context.Background(), "user-1", "SR_missing", Variables{})
+	require.ErrorIs(t, err, ErrNotFound)
+}
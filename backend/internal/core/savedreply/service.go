@@ -0,0 +1,32 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package savedreply imports the user's GitHub saved replies and fills in their templates.
+package savedreply
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// SavedReplyService is the interface for the saved reply service.
+type SavedReplyService interface {
+	// List returns the user's saved replies, importing them from GitHub first when they
+	// haven't been imported recently or refresh is set.
+	List(ctx context.Context, userID string, refresh bool) ([]models.SavedReply, error)
+	// Compose returns the body of a saved reply with its template variables filled in.
+	Compose(ctx context.Context, userID, id string, vars Variables) (string, error)
+}
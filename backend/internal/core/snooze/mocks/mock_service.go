@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/snooze/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/snooze/service.go -destination=internal/core/snooze/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	db "github.com/octobud-hq/octobud/backend/internal/db"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnoozeService is a mock of SnoozeService interface.
+type MockSnoozeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnoozeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSnoozeServiceMockRecorder is the mock recorder for MockSnoozeService.
+type MockSnoozeServiceMockRecorder struct {
+	mock *MockSnoozeService
+}
+
+// NewMockSnoozeService creates a new mock instance.
+func NewMockSnoozeService(ctrl *gomock.Controller) *MockSnoozeService {
+	mock := &MockSnoozeService{ctrl: ctrl}
+	mock.recorder = &MockSnoozeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnoozeService) EXPECT() *MockSnoozeServiceMockRecorder {
+	return m.recorder
+}
+
+// DefaultUntil mocks base method.
+func (m *MockSnoozeService) DefaultUntil(ctx context.Context, userID string, notification db.Notification) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultUntil", ctx, userID, notification)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefaultUntil indicates an expected call of DefaultUntil.
+func (mr *MockSnoozeServiceMockRecorder) DefaultUntil(ctx, userID, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultUntil", reflect.TypeOf((*MockSnoozeService)(nil).DefaultUntil), ctx, userID, notification)
+}
+
+// GetSnoozeSettings mocks base method.
+func (m *MockSnoozeService) GetSnoozeSettings(ctx context.Context, userID string) (*models.SnoozeSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnoozeSettings", ctx, userID)
+	ret0, _ := ret[0].(*models.SnoozeSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnoozeSettings indicates an expected call of GetSnoozeSettings.
+func (mr *MockSnoozeServiceMockRecorder) GetSnoozeSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnoozeSettings", reflect.TypeOf((*MockSnoozeService)(nil).GetSnoozeSettings), ctx, userID)
+}
+
+// Until mocks base method.
+func (m *MockSnoozeService) Until(ctx context.Context, userID, value string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Until", ctx, userID, value)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Until indicates an expected call of Until.
+func (mr *MockSnoozeServiceMockRecorder) Until(ctx, userID, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Until", reflect.TypeOf((*MockSnoozeService)(nil).Until), ctx, userID, value)
+}
+
+// UpdateSnoozeSettings mocks base method.
+func (m *MockSnoozeService) UpdateSnoozeSettings(ctx context.Context, userID string, settings *models.SnoozeSettings) (*models.SnoozeSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSnoozeSettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.SnoozeSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSnoozeSettings indicates an expected call of UpdateSnoozeSettings.
+func (mr *MockSnoozeServiceMockRecorder) UpdateSnoozeSettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnoozeSettings", reflect.TypeOf((*MockSnoozeService)(nil).UpdateSnoozeSettings), ctx, userID, settings)
+}
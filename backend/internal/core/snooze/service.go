@@ -0,0 +1,62 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package snooze resolves snooze presets and the default snooze for a notification.
+package snooze
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// SnoozeService is the interface for the snooze service.
+type SnoozeService interface {
+	GetSnoozeSettings(ctx context.Context, userID string) (*models.SnoozeSettings, error)
+	UpdateSnoozeSettings(
+		ctx context.Context,
+		userID string,
+		settings *models.SnoozeSettings,
+	) (*models.SnoozeSettings, error)
+	// DefaultUntil returns when a notification snoozed without an explicit time should
+	// wake up, using its repository's default snooze or else the user's default preset
+	DefaultUntil(ctx context.Context, userID string, notification db.Notification) (time.Time, error)
+	// Until returns when a snooze of value, a preset or a duration, started now ends
+	Until(ctx context.Context, userID, value string) (time.Time, error)
+}
+
+// Service provides business logic for snooze defaults
+type Service struct {
+	queries   db.Store
+	workHours workhours.WorkingHoursService
+	now       func() time.Time
+}
+
+// NewService constructs a Service backed by the provided queries. Wall-clock presets use
+// the time zone and business days of the user's working hours.
+func NewService(
+	queries db.Store,
+	workHours workhours.WorkingHoursService,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		queries:   queries,
+		workHours: workHours,
+		now:       now,
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package snooze
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Presets accepted wherever a default snooze is configured. They match the presets of the
+// snooze menu and are resolved in the time zone of the user's working hours.
+const (
+	PresetLaterToday      = "later_today"       // 6pm today, or tomorrow morning after 6pm
+	PresetTomorrow        = "tomorrow"          // 8am tomorrow
+	PresetNextWeek        = "next_week"         // 8am next Monday
+	PresetNextBusinessDay = "next_business_day" // Start of working hours on the next business day
+)
+
+// UntilUpdatedFallback is how long a snooze until the thread is updated lasts if it never is
+const UntilUpdatedFallback = "52w"
+
+const (
+	morningHour = 8
+	eveningHour = 18
+
+	// maxSnooze bounds durations such as "400d" to something a snooze can reasonably mean
+	maxSnooze = 366 * 24 * time.Hour
+)
+
+// Error definitions
+var (
+	ErrInvalidSnooze                = errors.New("invalid snooze")
+	ErrFailedToLoadSnoozeSettings   = errors.New("failed to load snooze settings")
+	ErrFailedToSaveSnoozeSettings   = errors.New("failed to save snooze settings")
+	ErrFailedToResolveDefaultSnooze = errors.New("failed to resolve default snooze")
+	ErrFailedToResolveSnooze        = errors.New("failed to resolve snooze")
+)
+
+// durationPattern matches durations such as "90m", "4h", "3d" and "1w"
+var durationPattern = regexp.MustCompile(`^([1-9][0-9]*)([mhdw])$`)
+
+var durationUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// Validate checks that value is a preset or a duration such as "4h", "3d" or "1w"
+func Validate(value string) error {
+	switch value {
+	case PresetLaterToday, PresetTomorrow, PresetNextWeek, PresetNextBusinessDay:
+		return nil
+	}
+	if _, _, err := parseDuration(value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Resolve returns when a snooze of value starting at now ends. Day and week durations
+// keep the wall-clock time, so "1w" set at 3pm ends at 3pm a week later across DST changes.
+func Resolve(value string, now time.Time, calendar *workhours.Calendar) (time.Time, error) {
+	local := now.In(calendar.Location())
+	switch value {
+	case PresetLaterToday:
+		evening := atHour(local, 0, eveningHour)
+		if local.Before(evening) {
+			return evening, nil
+		}
+		return atHour(local, 1, morningHour), nil
+	case PresetTomorrow:
+		return atHour(local, 1, morningHour), nil
+	case PresetNextWeek:
+		// Monday is 1, so from a Monday this is the Monday after
+		days := (8 - int(local.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return atHour(local, days, morningHour), nil
+	case PresetNextBusinessDay:
+		return calendar.NextBusinessDayStart(now), nil
+	}
+
+	count, unit, err := parseDuration(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch unit {
+	case "d":
+		return local.AddDate(0, 0, count), nil
+	case "w":
+		return local.AddDate(0, 0, 7*count), nil
+	default:
+		return now.Add(time.Duration(count) * durationUnits[unit]), nil
+	}
+}
+
+// parseDuration splits a duration into its count and unit
+func parseDuration(value string) (int, string, error) {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, "", fmt.Errorf(
+			"%w: %q must be a preset (%s, %s, %s, %s) or a duration such as 4h, 3d or 1w",
+			ErrInvalidSnooze, value,
+			PresetLaterToday, PresetTomorrow, PresetNextWeek, PresetNextBusinessDay,
+		)
+	}
+	count, err := strconv.Atoi(match[1])
+	if err != nil || time.Duration(count) > maxSnooze/durationUnits[match[2]] {
+		return 0, "", fmt.Errorf("%w: %q is longer than a year", ErrInvalidSnooze, value)
+	}
+	return count, match[2], nil
+}
+
+// atHour returns the given hour, days after the day t falls on
+func atHour(t time.Time, days, hour int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+days, hour, 0, 0, 0, t.Location())
+}
+
+// GetSnoozeSettings returns the user's snooze settings, or the defaults if none are saved
+func (s *Service) GetSnoozeSettings(
+	ctx context.Context,
+	userID string,
+) (*models.SnoozeSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultSnoozeSettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadSnoozeSettings, err)
+	}
+
+	if !user.SnoozeSettings.Valid {
+		return models.DefaultSnoozeSettings(), nil
+	}
+	settings, err := models.SnoozeSettingsFromJSON(user.SnoozeSettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadSnoozeSettings, err)
+	}
+	return settings, nil
+}
+
+// UpdateSnoozeSettings validates and saves the user's snooze settings
+func (s *Service) UpdateSnoozeSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.SnoozeSettings,
+) (*models.SnoozeSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	if err := Validate(settings.DefaultPreset); err != nil {
+		return nil, err
+	}
+
+	data, err := settings.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveSnoozeSettings, err)
+	}
+
+	_, err = s.queries.UpdateUserSnoozeSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToSaveSnoozeSettings, err)
+	}
+	return settings, nil
+}
+
+// Until resolves a preset or duration in the time zone of the user's working hours
+func (s *Service) Until(ctx context.Context, userID, value string) (time.Time, error) {
+	if err := Validate(value); err != nil {
+		return time.Time{}, err
+	}
+	calendar, err := s.workHours.Calendar(ctx, userID)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrFailedToResolveSnooze, err)
+	}
+	return Resolve(value, s.now(), calendar)
+}
+
+// DefaultUntil resolves the repository's default snooze, falling back to the user's
+// default preset. A saved value that no longer validates falls back the same way.
+func (s *Service) DefaultUntil(
+	ctx context.Context,
+	userID string,
+	notification db.Notification,
+) (time.Time, error) {
+	repoSettings, err := s.queries.GetRepositorySettings(ctx, userID, notification.RepositoryID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, errors.Join(ErrFailedToResolveDefaultSnooze, err)
+	}
+	userSettings, err := s.GetSnoozeSettings(ctx, userID)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrFailedToResolveDefaultSnooze, err)
+	}
+	calendar, err := s.workHours.Calendar(ctx, userID)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrFailedToResolveDefaultSnooze, err)
+	}
+
+	now := s.now()
+	candidates := []string{userSettings.DefaultPreset, models.DefaultSnoozeSettings().DefaultPreset}
+	if repoSettings.DefaultSnooze.Valid {
+		candidates = append([]string{repoSettings.DefaultSnooze.String}, candidates...)
+	}
+	for _, value := range candidates {
+		if until, err := Resolve(value, now, calendar); err == nil {
+			return until, nil
+		}
+	}
+	return time.Time{}, ErrFailedToResolveDefaultSnooze
+}
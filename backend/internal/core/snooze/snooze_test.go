@@ -0,0 +1,233 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package snooze
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	workhoursmocks "github.com/octobud-hq/octobud/backend/internal/core/workhours/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func newTestCalendar(t *testing.T) *workhours.Calendar {
+	t.Helper()
+	settings := models.DefaultWorkingHours()
+	settings.Enabled = true
+	settings.Timezone = "America/New_York"
+	calendar, err := workhours.NewCalendar(settings)
+	require.NoError(t, err)
+	return calendar
+}
+
+func TestResolve(t *testing.T) {
+	calendar := newTestCalendar(t)
+	newYork := calendar.Location()
+	// Friday afternoon in New York
+	friday := time.Date(2025, time.March, 7, 15, 30, 0, 0, newYork)
+
+	tests := []struct {
+		name     string
+		value    string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "later today is this evening",
+			value:    PresetLaterToday,
+			now:      friday,
+			expected: time.Date(2025, time.March, 7, 18, 0, 0, 0, newYork),
+		},
+		{
+			name:     "later today after the evening is tomorrow morning",
+			value:    PresetLaterToday,
+			now:      time.Date(2025, time.March, 7, 19, 0, 0, 0, newYork),
+			expected: time.Date(2025, time.March, 8, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "tomorrow",
+			value:    PresetTomorrow,
+			now:      friday,
+			expected: time.Date(2025, time.March, 8, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "next week from a Friday is Monday",
+			value:    PresetNextWeek,
+			now:      friday,
+			expected: time.Date(2025, time.March, 10, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "next week from a Monday is the Monday after",
+			value:    PresetNextWeek,
+			now:      time.Date(2025, time.March, 10, 9, 0, 0, 0, newYork),
+			expected: time.Date(2025, time.March, 17, 8, 0, 0, 0, newYork),
+		},
+		{
+			name:     "next business day skips the weekend",
+			value:    PresetNextBusinessDay,
+			now:      friday,
+			expected: time.Date(2025, time.March, 10, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:     "hours are exact",
+			value:    "4h",
+			now:      friday,
+			expected: friday.Add(4 * time.Hour),
+		},
+		{
+			name:     "weeks keep the time of day across DST",
+			value:    "1w",
+			now:      friday,
+			expected: time.Date(2025, time.March, 14, 15, 30, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, err := Resolve(tt.value, tt.now, calendar)
+			require.NoError(t, err)
+			require.True(t, tt.expected.Equal(until), "expected %s, got %s", tt.expected, until)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, value := range []string{"tomorrow", "next_business_day", "90m", "3d", "52w"} {
+		require.NoError(t, Validate(value), value)
+	}
+	for _, value := range []string{"", "soon", "0d", "1.5h", "-1d", "3 days", "53w", "400d"} {
+		require.ErrorIs(t, Validate(value), ErrInvalidSnooze, value)
+	}
+}
+
+func TestService_DefaultUntil(t *testing.T) {
+	calendar := newTestCalendar(t)
+	now := time.Date(2025, time.March, 7, 15, 30, 0, 0, calendar.Location())
+	notification := db.Notification{GithubID: "thread-1", RepositoryID: 42}
+
+	tests := []struct {
+		name          string
+		repoSnooze    sql.NullString
+		repoErr       error
+		userSettings  db.NullRawMessage
+		expectedUntil time.Time
+	}{
+		{
+			name:          "repository default wins",
+			repoSnooze:    sql.NullString{String: "1w", Valid: true},
+			userSettings:  db.NullRawMessage{RawMessage: json.RawMessage(`{"defaultPreset":"3d"}`), Valid: true},
+			expectedUntil: now.AddDate(0, 0, 7),
+		},
+		{
+			name:          "repository without settings falls back to the user's preset",
+			repoErr:       sql.ErrNoRows,
+			userSettings:  db.NullRawMessage{RawMessage: json.RawMessage(`{"defaultPreset":"3d"}`), Valid: true},
+			expectedUntil: now.AddDate(0, 0, 3),
+		},
+		{
+			name:          "cleared repository default falls back to the user's preset",
+			userSettings:  db.NullRawMessage{RawMessage: json.RawMessage(`{"defaultPreset":"4h"}`), Valid: true},
+			expectedUntil: now.Add(4 * time.Hour),
+		},
+		{
+			name:          "nothing configured snoozes until tomorrow",
+			repoErr:       sql.ErrNoRows,
+			expectedUntil: time.Date(2025, time.March, 8, 8, 0, 0, 0, calendar.Location()),
+		},
+		{
+			name:          "invalid saved values are skipped",
+			repoSnooze:    sql.NullString{String: "whenever", Valid: true},
+			userSettings:  db.NullRawMessage{RawMessage: json.RawMessage(`{"defaultPreset":"4h"}`), Valid: true},
+			expectedUntil: now.Add(4 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			mockWorkHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+
+			mockStore.EXPECT().
+				GetRepositorySettings(gomock.Any(), "user", int64(42)).
+				Return(db.RepositorySetting{RepositoryID: 42, DefaultSnooze: tt.repoSnooze}, tt.repoErr)
+			mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{SnoozeSettings: tt.userSettings}, nil)
+			mockWorkHours.EXPECT().Calendar(gomock.Any(), "user").Return(calendar, nil)
+
+			svc := NewService(mockStore, mockWorkHours, func() time.Time { return now })
+			until, err := svc.DefaultUntil(context.Background(), "user", notification)
+			require.NoError(t, err)
+			require.True(t, tt.expectedUntil.Equal(until), "expected %s, got %s", tt.expectedUntil, until)
+		})
+	}
+
+	t.Run("database error is wrapped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().
+			GetRepositorySettings(gomock.Any(), "user", int64(42)).
+			Return(db.RepositorySetting{}, errors.New("database is locked"))
+
+		svc := NewService(mockStore, workhoursmocks.NewMockWorkingHoursService(ctrl), time.Now)
+		_, err := svc.DefaultUntil(context.Background(), "user", notification)
+		require.ErrorIs(t, err, ErrFailedToResolveDefaultSnooze)
+	})
+}
+
+func TestService_Until(t *testing.T) {
+	calendar := newTestCalendar(t)
+	// A Friday afternoon
+	now := time.Date(2025, time.March, 7, 15, 30, 0, 0, calendar.Location())
+
+	t.Run("resolves presets in the user's time zone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWorkHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+		mockWorkHours.EXPECT().Calendar(gomock.Any(), "user").Return(calendar, nil)
+
+		svc := NewService(mocks.NewMockStore(ctrl), mockWorkHours, func() time.Time { return now })
+		until, err := svc.Until(context.Background(), "user", PresetNextWeek)
+		require.NoError(t, err)
+		expected := time.Date(2025, time.March, 10, morningHour, 0, 0, 0, calendar.Location())
+		require.True(t, expected.Equal(until), "expected %s, got %s", expected, until)
+	})
+
+	t.Run("unknown preset is rejected before loading the calendar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewService(mocks.NewMockStore(ctrl), workhoursmocks.NewMockWorkingHoursService(ctrl), time.Now)
+		_, err := svc.Until(context.Background(), "user", "someday")
+		require.ErrorIs(t, err, ErrInvalidSnooze)
+	})
+
+	t.Run("calendar error is wrapped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWorkHours := workhoursmocks.NewMockWorkingHoursService(ctrl)
+		mockWorkHours.EXPECT().Calendar(gomock.Any(), "user").Return(nil, errors.New("database is locked"))
+
+		svc := NewService(mocks.NewMockStore(ctrl), mockWorkHours, time.Now)
+		_, err := svc.Until(context.Background(), "user", PresetTomorrow)
+		require.ErrorIs(t, err, ErrFailedToResolveSnooze)
+	})
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/suggest/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/suggest/service.go -destination=internal/core/suggest/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuggestService is a mock of SuggestService interface.
+type MockSuggestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSuggestServiceMockRecorder is the mock recorder for MockSuggestService.
+type MockSuggestServiceMockRecorder struct {
+	mock *MockSuggestService
+}
+
+// NewMockSuggestService creates a new mock instance.
+func NewMockSuggestService(ctrl *gomock.Controller) *MockSuggestService {
+	mock := &MockSuggestService{ctrl: ctrl}
+	mock.recorder = &MockSuggestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuggestService) EXPECT() *MockSuggestServiceMockRecorder {
+	return m.recorder
+}
+
+// Suggest mocks base method.
+func (m *MockSuggestService) Suggest(ctx context.Context, userID, query string, cursor int) (models.QuerySuggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, userID, query, cursor)
+	ret0, _ := ret[0].(models.QuerySuggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSuggestServiceMockRecorder) Suggest(ctx, userID, query, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSuggestService)(nil).Suggest), ctx, userID, query, cursor)
+}
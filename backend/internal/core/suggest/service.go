@@ -0,0 +1,46 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+// Package suggest completes partly typed queries with fields and values from the local
+// database.
+package suggest
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// SuggestService is the interface for query autocomplete.
+type SuggestService interface {
+	// Suggest returns completions for the text at cursor, a 0-based byte offset into query.
+	Suggest(
+		ctx context.Context,
+		userID, query string,
+		cursor int,
+	) (models.QuerySuggestions, error)
+}
+
+// Service completes queries from known repositories, authors, and tags
+type Service struct {
+	queries db.Store
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package suggest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query/parse"
+)
+
+// MaxSuggestions caps how many completions are returned
+const MaxSuggestions = 10
+
+// Error definitions
+var (
+	ErrFailedToLoadSuggestions = errors.New("failed to load suggestions")
+)
+
+// Suggest completes the field name or value being typed at cursor. Values come from the
+// local database for repo:, org:, author:, label:, and tags:; other fields get no suggestions.
+func (s *Service) Suggest(
+	ctx context.Context,
+	userID, query string,
+	cursor int,
+) (models.QuerySuggestions, error) {
+	partial := parse.ParsePartial(query, cursor)
+	result := models.QuerySuggestions{
+		Field:       partial.Field,
+		Prefix:      partial.Prefix,
+		Start:       partial.Start,
+		End:         partial.End,
+		Suggestions: []models.QuerySuggestion{},
+	}
+
+	var (
+		values []string
+		err    error
+	)
+	switch partial.Field {
+	case "":
+		// Quoted text is free text, never a field name
+		if !partial.Quoted {
+			result.Suggestions = fieldSuggestions(partial.Prefix)
+		}
+		return result, nil
+	case "repo", "repository":
+		values, err = s.repositoryNames(ctx, userID, partial.Prefix)
+	case "org":
+		values, err = s.orgNames(ctx, userID, partial.Prefix)
+	case "author":
+		values, err = s.queries.ListNotificationAuthors(ctx, userID, partial.Prefix, MaxSuggestions)
+	case "label":
+		values, err = s.queries.ListNotificationLabels(ctx, userID, partial.Prefix, MaxSuggestions)
+	case "tags":
+		values, err = s.tagSlugs(ctx, userID, partial.Prefix)
+	default:
+		return result, nil
+	}
+	if err != nil {
+		return models.QuerySuggestions{}, errors.Join(ErrFailedToLoadSuggestions, err)
+	}
+
+	for _, value := range values {
+		result.Suggestions = append(result.Suggestions, models.QuerySuggestion{
+			Kind: models.QuerySuggestionValue,
+			// Labels may contain spaces, so quote values the lexer wouldn't read as one word
+			Value: parse.QuoteIfNeeded(value),
+		})
+	}
+	return result, nil
+}
+
+// fieldSuggestions returns the field names starting with prefix, ignoring case
+func fieldSuggestions(prefix string) []models.QuerySuggestion {
+	suggestions := []models.QuerySuggestion{}
+	for _, field := range parse.KnownFields() {
+		if hasPrefixFold(field, prefix) {
+			suggestions = append(suggestions, models.QuerySuggestion{
+				Kind:  models.QuerySuggestionField,
+				Value: field + ":",
+			})
+		}
+	}
+	return suggestions
+}
+
+// repositoryNames returns full names of known repositories containing prefix, since repo:
+// matches anywhere in the name. Names where the owner or repository starts with the prefix
+// come first.
+func (s *Service) repositoryNames(ctx context.Context, userID, prefix string) ([]string, error) {
+	repos, err := s.queries.ListRepositories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerPrefix := strings.ToLower(prefix)
+	var starts, contains []string
+	for _, repo := range repos {
+		fullName := strings.ToLower(repo.FullName)
+		switch {
+		case strings.HasPrefix(fullName, lowerPrefix) ||
+			strings.HasPrefix(strings.ToLower(repo.Name), lowerPrefix):
+			starts = append(starts, repo.FullName)
+		case strings.Contains(fullName, lowerPrefix):
+			contains = append(contains, repo.FullName)
+		}
+	}
+	sort.Strings(starts)
+	sort.Strings(contains)
+	return limit(append(starts, contains...)), nil
+}
+
+// orgNames returns the owners of known repositories starting with prefix
+func (s *Service) orgNames(ctx context.Context, userID, prefix string) ([]string, error) {
+	repos, err := s.queries.ListRepositories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var orgs []string
+	for _, repo := range repos {
+		org, _, found := strings.Cut(repo.FullName, "/")
+		if !found || seen[strings.ToLower(org)] || !hasPrefixFold(org, prefix) {
+			continue
+		}
+		seen[strings.ToLower(org)] = true
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+	return limit(orgs), nil
+}
+
+// tagSlugs returns the slugs of tags whose slug or name starts with prefix, in the user's
+// tag order
+func (s *Service) tagSlugs(ctx context.Context, userID, prefix string) ([]string, error) {
+	tags, err := s.queries.ListAllTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var slugs []string
+	for _, tag := range tags {
+		if hasPrefixFold(tag.Slug, prefix) || hasPrefixFold(tag.Name, prefix) {
+			slugs = append(slugs, tag.Slug)
+		}
+	}
+	return limit(slugs), nil
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// limit trims values to MaxSuggestions
+func limit(values []string) []string {
+	if len(values) > MaxSuggestions {
+		return values[:MaxSuggestions]
+	}
+	return values
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package suggest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testRepositories = []db.Repository{
+	{FullName: "cli/cli", Name: "cli"},
+	{FullName: "acme/cli-tools", Name: "cli-tools"},
+	{FullName: "acme/api", Name: "api"},
+	{FullName: "Acme/web", Name: "web"},
+	{FullName: "octo/client-lib", Name: "client-lib"},
+}
+
+func values(suggestions []models.QuerySuggestion) []string {
+	result := []string{}
+	for _, suggestion := range suggestions {
+		result = append(result, suggestion.Value)
+	}
+	return result
+}
+
+func TestService_Suggest(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		cursor    int
+		setupMock func(*mocks.MockStore)
+		expected  []string
+		kind      string
+	}{
+		{
+			name:     "field names",
+			query:    "is:unread RE",
+			cursor:   12,
+			expected: []string{"read:", "reason:", "repo:", "repository:", "review:", "reviewer:"},
+			kind:     models.QuerySuggestionField,
+		},
+		{
+			name:     "quoted free text gets nothing",
+			query:    `"re`,
+			cursor:   3,
+			expected: []string{},
+		},
+		{
+			name:   "repositories starting with the prefix come first",
+			query:  "repo:cli",
+			cursor: 8,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListRepositories(gomock.Any(), "user").Return(testRepositories, nil)
+			},
+			expected: []string{"acme/cli-tools", "cli/cli", "octo/client-lib"},
+			kind:     models.QuerySuggestionValue,
+		},
+		{
+			name:   "orgs are deduplicated ignoring case",
+			query:  "org:a",
+			cursor: 5,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListRepositories(gomock.Any(), "user").Return(testRepositories, nil)
+			},
+			expected: []string{"acme"},
+			kind:     models.QuerySuggestionValue,
+		},
+		{
+			name:   "authors come from the store",
+			query:  "author:oc",
+			cursor: 9,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationAuthors(gomock.Any(), "user", "oc", MaxSuggestions).
+					Return([]string{"octocat", "octo-bot"}, nil)
+			},
+			expected: []string{"octocat", "octo-bot"},
+			kind:     models.QuerySuggestionValue,
+		},
+		{
+			name:   "labels with spaces are quoted",
+			query:  `label:"goo`,
+			cursor: 10,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationLabels(gomock.Any(), "user", "goo", MaxSuggestions).
+					Return([]string{"good first issue", "good-to-merge"}, nil)
+			},
+			expected: []string{`"good first issue"`, "good-to-merge"},
+			kind:     models.QuerySuggestionValue,
+		},
+		{
+			name:   "tags match by slug or name",
+			query:  "tags:bug,",
+			cursor: 9,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListAllTags(gomock.Any(), "user").Return([]db.Tag{
+					{Name: "Needs Review", Slug: "needs-review"},
+					{Name: "Bug", Slug: "bug"},
+				}, nil)
+			},
+			expected: []string{"needs-review", "bug"},
+			kind:     models.QuerySuggestionValue,
+		},
+		{
+			name:     "fields without known values get nothing",
+			query:    "state:op",
+			cursor:   8,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockStore)
+			}
+
+			result, err := NewService(mockStore).Suggest(context.Background(), "user", tt.query, tt.cursor)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, values(result.Suggestions))
+			for _, suggestion := range result.Suggestions {
+				require.Equal(t, tt.kind, suggestion.Kind)
+			}
+		})
+	}
+}
+
+func TestService_Suggest_ReportsReplacementSpan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "user").Return(testRepositories, nil)
+
+	result, err := NewService(mockStore).Suggest(context.Background(), "user", "repo:cl is:unread", 7)
+	require.NoError(t, err)
+	require.Equal(t, "repo", result.Field)
+	require.Equal(t, "cl", result.Prefix)
+	require.Equal(t, 5, result.Start)
+	require.Equal(t, 7, result.End)
+}
+
+func TestService_Suggest_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListNotificationAuthors(gomock.Any(), "user", "", MaxSuggestions).
+		Return(nil, errors.New("database error"))
+
+	_, err := NewService(mockStore).Suggest(context.Background(), "user", "author:", 7)
+	require.ErrorIs(t, err, ErrFailedToLoadSuggestions)
+}
@@ -57,6 +57,34 @@ func (mr *MockSyncStateServiceMockRecorder) GetSyncState(ctx, userID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockSyncStateService)(nil).GetSyncState), ctx, userID)
 }
 
+// SaveInitialSyncProgress mocks base method.
+func (m *MockSyncStateService) SaveInitialSyncProgress(ctx context.Context, userID string, progress models.InitialSyncProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInitialSyncProgress", ctx, userID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInitialSyncProgress indicates an expected call of SaveInitialSyncProgress.
+func (mr *MockSyncStateServiceMockRecorder) SaveInitialSyncProgress(ctx, userID, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInitialSyncProgress", reflect.TypeOf((*MockSyncStateService)(nil).SaveInitialSyncProgress), ctx, userID, progress)
+}
+
+// SetNotificationETag mocks base method.
+func (m *MockSyncStateService) SetNotificationETag(ctx context.Context, userID, etag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationETag", ctx, userID, etag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationETag indicates an expected call of SetNotificationETag.
+func (mr *MockSyncStateServiceMockRecorder) SetNotificationETag(ctx, userID, etag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationETag", reflect.TypeOf((*MockSyncStateService)(nil).SetNotificationETag), ctx, userID, etag)
+}
+
 // UpsertSyncState mocks base method.
 func (m *MockSyncStateService) UpsertSyncState(ctx context.Context, userID string, lastSuccessfulPoll, latestNotificationAt *time.Time) (models.SyncState, error) {
 	m.ctrl.T.Helper()
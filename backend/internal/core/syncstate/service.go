@@ -41,6 +41,12 @@ type SyncStateService interface {
 		initialSyncCompletedAt *time.Time,
 		oldestNotificationSyncedAt *time.Time,
 	) (models.SyncState, error)
+	SetNotificationETag(ctx context.Context, userID, etag string) error
+	SaveInitialSyncProgress(
+		ctx context.Context,
+		userID string,
+		progress models.InitialSyncProgress,
+	) error
 }
 
 // Service provides business logic for sync state operations
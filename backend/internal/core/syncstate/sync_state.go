@@ -47,6 +47,10 @@ func (s *Service) GetSyncState(ctx context.Context, userID string) (models.SyncS
 		UpdatedAt:                  toTime(state.UpdatedAt),
 		InitialSyncCompletedAt:     state.InitialSyncCompletedAt,
 		OldestNotificationSyncedAt: state.OldestNotificationSyncedAt,
+		LastNotificationETag:       state.LastNotificationEtag,
+		InitialSyncStartedAt:       state.InitialSyncStartedAt,
+		InitialSyncPages:           state.InitialSyncPages,
+		InitialSyncQueued:          state.InitialSyncQueued,
 	}, nil
 }
 
@@ -117,5 +121,45 @@ func (s *Service) UpsertSyncStateWithInitialSync(
 		UpdatedAt:                  toTime(result.UpdatedAt),
 		InitialSyncCompletedAt:     result.InitialSyncCompletedAt,
 		OldestNotificationSyncedAt: result.OldestNotificationSyncedAt,
+		LastNotificationETag:       result.LastNotificationEtag,
+		InitialSyncStartedAt:       result.InitialSyncStartedAt,
+		InitialSyncPages:           result.InitialSyncPages,
+		InitialSyncQueued:          result.InitialSyncQueued,
 	}, nil
 }
+
+// SetNotificationETag stores the ETag of the latest notifications response so the next
+// poll can ask GitHub whether anything changed. Other sync state is left untouched.
+func (s *Service) SetNotificationETag(ctx context.Context, userID, etag string) error {
+	params := db.UpsertSyncStateParams{
+		LastNotificationEtag: sql.NullString{String: etag, Valid: true},
+	}
+	if _, err := s.queries.UpsertSyncState(ctx, userID, params); err != nil {
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+	return nil
+}
+
+// SaveInitialSyncProgress records how far the initial sync got, so an interrupted one
+// resumes from the oldest notification it queued rather than starting over
+func (s *Service) SaveInitialSyncProgress(
+	ctx context.Context,
+	userID string,
+	progress models.InitialSyncProgress,
+) error {
+	params := db.SaveInitialSyncProgressParams{
+		StartedAt: progress.StartedAt,
+		Pages:     int64(progress.Pages),
+		Queued:    int64(progress.Queued),
+	}
+	if !progress.LatestNotification.IsZero() {
+		params.LatestNotificationAt = models.SQLNullTime(&progress.LatestNotification)
+	}
+	if !progress.OldestNotification.IsZero() {
+		params.OldestNotificationSyncedAt = models.SQLNullTime(&progress.OldestNotification)
+	}
+	if err := s.queries.SaveInitialSyncProgress(ctx, userID, params); err != nil {
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+	return nil
+}
@@ -329,6 +329,91 @@ func TestService_UpsertSyncStateWithInitialSync(t *testing.T) {
 	}
 }
 
+func TestService_SetNotificationETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		UpsertSyncState(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpsertSyncStateParams) (db.UpsertSyncStateRow, error) {
+			// Only the ETag is set so the other columns keep their stored values
+			require.Equal(t, sql.NullString{String: `W/"abc"`, Valid: true}, params.LastNotificationEtag)
+			require.False(t, params.LastSuccessfulPoll.Valid)
+			require.False(t, params.LatestNotificationAt.Valid)
+			return db.UpsertSyncStateRow{}, nil
+		})
+	service := NewSyncStateService(mockQuerier)
+
+	require.NoError(t, service.SetNotificationETag(context.Background(), "test-user-id", `W/"abc"`))
+}
+
+func TestService_SetNotificationETag_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		UpsertSyncState(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.UpsertSyncStateRow{}, errors.New("database error"))
+	service := NewSyncStateService(mockQuerier)
+
+	err := service.SetNotificationETag(context.Background(), "test-user-id", `W/"abc"`)
+	require.ErrorIs(t, err, ErrFailedToUpdateSyncState)
+}
+
+func TestService_SaveInitialSyncProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startedAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	latest := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	oldest := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		SaveInitialSyncProgress(gomock.Any(), "test-user-id", db.SaveInitialSyncProgressParams{
+			LatestNotificationAt:       sql.NullTime{Time: latest, Valid: true},
+			OldestNotificationSyncedAt: sql.NullTime{Time: oldest, Valid: true},
+			StartedAt:                  startedAt,
+			Pages:                      2,
+			Queued:                     100,
+		}).
+		Return(nil)
+	service := NewSyncStateService(mockQuerier)
+
+	err := service.SaveInitialSyncProgress(context.Background(), "test-user-id", models.InitialSyncProgress{
+		StartedAt:          startedAt,
+		Pages:              2,
+		Queued:             100,
+		LatestNotification: latest,
+		OldestNotification: oldest,
+	})
+	require.NoError(t, err)
+}
+
+func TestService_SaveInitialSyncProgress_NothingQueued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		SaveInitialSyncProgress(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.SaveInitialSyncProgressParams) error {
+			// No notifications yet, so the stored timestamps are left alone
+			require.False(t, params.LatestNotificationAt.Valid)
+			require.False(t, params.OldestNotificationSyncedAt.Valid)
+			return errors.New("database error")
+		})
+	service := NewSyncStateService(mockQuerier)
+
+	err := service.SaveInitialSyncProgress(context.Background(), "test-user-id", models.InitialSyncProgress{
+		StartedAt: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+		Pages:     1,
+	})
+	require.ErrorIs(t, err, ErrFailedToUpdateSyncState)
+}
+
 // Helper function to create time pointers
 func timePtr(t time.Time) *time.Time {
 	return &t
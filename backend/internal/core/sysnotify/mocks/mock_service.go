@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/sysnotify/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/sysnotify/service.go -destination=internal/core/sysnotify/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	sysnotify "github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	db "github.com/octobud-hq/octobud/backend/internal/db"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSystemNotifier is a mock of SystemNotifier interface.
+type MockSystemNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemNotifierMockRecorder is the mock recorder for MockSystemNotifier.
+type MockSystemNotifierMockRecorder struct {
+	mock *MockSystemNotifier
+}
+
+// NewMockSystemNotifier creates a new mock instance.
+func NewMockSystemNotifier(ctrl *gomock.Controller) *MockSystemNotifier {
+	mock := &MockSystemNotifier{ctrl: ctrl}
+	mock.recorder = &MockSystemNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemNotifier) EXPECT() *MockSystemNotifierMockRecorder {
+	return m.recorder
+}
+
+// CleanupCompleted mocks base method.
+func (m *MockSystemNotifier) CleanupCompleted(ctx context.Context, userID string, deleted int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupCompleted", ctx, userID, deleted)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupCompleted indicates an expected call of CleanupCompleted.
+func (mr *MockSystemNotifierMockRecorder) CleanupCompleted(ctx, userID, deleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupCompleted", reflect.TypeOf((*MockSystemNotifier)(nil).CleanupCompleted), ctx, userID, deleted)
+}
+
+// Notify mocks base method.
+func (m *MockSystemNotifier) Notify(ctx context.Context, userID string, event sysnotify.Event) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, userID, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockSystemNotifierMockRecorder) Notify(ctx, userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockSystemNotifier)(nil).Notify), ctx, userID, event)
+}
+
+// RepositoriesMerged mocks base method.
+func (m *MockSystemNotifier) RepositoriesMerged(ctx context.Context, userID string, merged int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepositoriesMerged", ctx, userID, merged)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepositoriesMerged indicates an expected call of RepositoriesMerged.
+func (mr *MockSystemNotifierMockRecorder) RepositoriesMerged(ctx, userID, merged any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoriesMerged", reflect.TypeOf((*MockSystemNotifier)(nil).RepositoriesMerged), ctx, userID, merged)
+}
+
+// SyncFailing mocks base method.
+func (m *MockSystemNotifier) SyncFailing(ctx context.Context, userID string, since time.Time, failures int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncFailing", ctx, userID, since, failures)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncFailing indicates an expected call of SyncFailing.
+func (mr *MockSystemNotifierMockRecorder) SyncFailing(ctx, userID, since, failures any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncFailing", reflect.TypeOf((*MockSystemNotifier)(nil).SyncFailing), ctx, userID, since, failures)
+}
+
+// TokenExpiring mocks base method.
+func (m *MockSystemNotifier) TokenExpiring(ctx context.Context, userID string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenExpiring", ctx, userID, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenExpiring indicates an expected call of TokenExpiring.
+func (mr *MockSystemNotifierMockRecorder) TokenExpiring(ctx, userID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenExpiring", reflect.TypeOf((*MockSystemNotifier)(nil).TokenExpiring), ctx, userID, expiresAt)
+}
+
+// UpdateAvailable mocks base method.
+func (m *MockSystemNotifier) UpdateAvailable(ctx context.Context, userID, version string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvailable", ctx, userID, version)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvailable indicates an expected call of UpdateAvailable.
+func (mr *MockSystemNotifierMockRecorder) UpdateAvailable(ctx, userID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvailable", reflect.TypeOf((*MockSystemNotifier)(nil).UpdateAvailable), ctx, userID, version)
+}
+
+// ViewOverLimit mocks base method.
+func (m *MockSystemNotifier) ViewOverLimit(ctx context.Context, userID string, view db.View, count int64, snoozed int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewOverLimit", ctx, userID, view, count, snoozed)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewOverLimit indicates an expected call of ViewOverLimit.
+func (mr *MockSystemNotifierMockRecorder) ViewOverLimit(ctx, userID, view, count, snoozed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewOverLimit", reflect.TypeOf((*MockSystemNotifier)(nil).ViewOverLimit), ctx, userID, view, count, snoozed)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package sysnotify creates system notifications: notifications from Octobud itself for
+// app events, such as sync failing or a token expiring, that are triaged like any other.
+package sysnotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// SystemNotifier is the interface for creating system notifications. Each method reports
+// whether a notification was created; events that were already reported are skipped.
+type SystemNotifier interface {
+	Notify(ctx context.Context, userID string, event Event) (bool, error)
+	// TokenExpiring reports a GitHub token that expires within TokenExpiryWarning.
+	TokenExpiring(ctx context.Context, userID string, expiresAt time.Time) (bool, error)
+	// SyncFailing reports sync failing repeatedly since the first failure.
+	SyncFailing(ctx context.Context, userID string, since time.Time, failures int) (bool, error)
+	// CleanupCompleted reports the number of old notifications a cleanup deleted.
+	CleanupCompleted(ctx context.Context, userID string, deleted int64) (bool, error)
+	// RepositoriesMerged reports the number of duplicate repository rows a merge removed.
+	RepositoriesMerged(ctx context.Context, userID string, merged int) (bool, error)
+	// UpdateAvailable reports a new version of Octobud.
+	UpdateAvailable(ctx context.Context, userID, version string) (bool, error)
+	// ViewOverLimit reports a view holding more notifications than its WIP limit.
+	ViewOverLimit(ctx context.Context, userID string, view db.View, count int64, snoozed int) (bool, error)
+}
+
+// Service creates system notifications
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sysnotify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// Event kinds, stored in the notification payload for clients
+const (
+	EventTokenExpiring      = "token_expiring"
+	EventSyncFailing        = "sync_failing"
+	EventCleanup            = "cleanup"
+	EventRepositoriesMerged = "repositories_merged"
+	EventUpdateAvailable    = "update_available"
+	EventViewOverLimit      = "view_over_limit"
+)
+
+// TokenExpiryWarning is how long before a token expires it's reported
+const TokenExpiryWarning = 7 * 24 * time.Hour
+
+// Error definitions
+var (
+	ErrFailedToNotify = errors.New("failed to create system notification")
+	ErrInvalidEvent   = errors.New("invalid system notification event")
+)
+
+// Event is an app event to report. Key identifies the occurrence: reporting an event
+// with the same key again does nothing, even if the first notification was archived.
+type Event struct {
+	Kind  string
+	Key   string
+	Title string
+}
+
+// eventPayload is stored as the notification payload
+type eventPayload struct {
+	Event string `json:"event"`
+}
+
+// Notify creates a system notification for event unless one with the same key exists
+func (s *Service) Notify(ctx context.Context, userID string, event Event) (bool, error) {
+	if event.Kind == "" || event.Key == "" || event.Title == "" {
+		return false, ErrInvalidEvent
+	}
+	githubID := db.SystemGithubIDPrefix + event.Key
+
+	_, err := s.queries.GetNotificationByGithubID(ctx, userID, githubID)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, errors.Join(ErrFailedToNotify, err)
+	}
+
+	repo, err := s.systemRepository(ctx, userID)
+	if err != nil {
+		return false, errors.Join(ErrFailedToNotify, err)
+	}
+
+	payload, err := json.Marshal(eventPayload{Event: event.Kind})
+	if err != nil {
+		return false, errors.Join(ErrFailedToNotify, err)
+	}
+
+	now := s.now().UTC()
+	_, err = s.queries.UpsertNotification(ctx, userID, db.UpsertNotificationParams{
+		GithubID:        githubID,
+		RepositoryID:    repo.ID,
+		SubjectType:     db.SubjectTypeSystem,
+		SubjectTitle:    event.Title,
+		Reason:          sql.NullString{String: db.ReasonSystem, Valid: true},
+		GithubUnread:    sql.NullBool{Bool: true, Valid: true},
+		GithubUpdatedAt: sql.NullTime{Time: now, Valid: true},
+		Payload:         db.NullRawMessage{RawMessage: payload, Valid: true},
+	})
+	if err != nil {
+		return false, errors.Join(ErrFailedToNotify, err)
+	}
+	return true, nil
+}
+
+// TokenExpiring reports a token that expires within TokenExpiryWarning, once per expiry
+func (s *Service) TokenExpiring(
+	ctx context.Context,
+	userID string,
+	expiresAt time.Time,
+) (bool, error) {
+	if expiresAt.IsZero() || expiresAt.Sub(s.now()) > TokenExpiryWarning {
+		return false, nil
+	}
+	expiresAt = expiresAt.UTC()
+
+	title := "Your GitHub token expires on " + expiresAt.Format("Jan 2, 2006") +
+		". Replace it in settings to keep syncing."
+	if !expiresAt.After(s.now()) {
+		title = "Your GitHub token expired on " + expiresAt.Format("Jan 2, 2006") +
+			". Replace it in settings to resume syncing."
+	}
+	return s.Notify(ctx, userID, Event{
+		Kind:  EventTokenExpiring,
+		Key:   "token-expiring:" + expiresAt.Format(time.RFC3339),
+		Title: title,
+	})
+}
+
+// SyncFailing reports sync failing repeatedly, once per run of failures
+func (s *Service) SyncFailing(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+	failures int,
+) (bool, error) {
+	return s.Notify(ctx, userID, Event{
+		Kind: EventSyncFailing,
+		Key:  "sync-failing:" + since.UTC().Format(time.RFC3339),
+		Title: fmt.Sprintf(
+			"Syncing with GitHub has failed %d times in a row since %s",
+			failures,
+			since.UTC().Format("Jan 2 15:04 UTC"),
+		),
+	})
+}
+
+// CleanupCompleted reports a cleanup that deleted notifications
+func (s *Service) CleanupCompleted(ctx context.Context, userID string, deleted int64) (bool, error) {
+	if deleted <= 0 {
+		return false, nil
+	}
+	noun := "notifications"
+	if deleted == 1 {
+		noun = "notification"
+	}
+	return s.Notify(ctx, userID, Event{
+		Kind:  EventCleanup,
+		Key:   "cleanup:" + s.now().UTC().Format(time.RFC3339),
+		Title: fmt.Sprintf("Cleanup deleted %d old archived %s", deleted, noun),
+	})
+}
+
+// RepositoriesMerged reports duplicate repository rows that were merged into their
+// canonical rows
+func (s *Service) RepositoriesMerged(ctx context.Context, userID string, merged int) (bool, error) {
+	if merged <= 0 {
+		return false, nil
+	}
+	noun := "repositories"
+	if merged == 1 {
+		noun = "repository"
+	}
+	return s.Notify(ctx, userID, Event{
+		Kind:  EventRepositoriesMerged,
+		Key:   "repositories_merged:" + s.now().UTC().Format(time.RFC3339),
+		Title: fmt.Sprintf("Merged %d duplicate %s", merged, noun),
+	})
+}
+
+// UpdateAvailable reports a new version, once per version
+func (s *Service) UpdateAvailable(ctx context.Context, userID, version string) (bool, error) {
+	if version == "" {
+		return false, ErrInvalidEvent
+	}
+	return s.Notify(ctx, userID, Event{
+		Kind:  EventUpdateAvailable,
+		Key:   "update:" + version,
+		Title: "Octobud " + version + " is available",
+	})
+}
+
+// ViewOverLimit reports a view holding more notifications than its WIP limit, at most once
+// a day per view. snoozed is how many notifications past the limit were snoozed.
+func (s *Service) ViewOverLimit(
+	ctx context.Context,
+	userID string,
+	view db.View,
+	count int64,
+	snoozed int,
+) (bool, error) {
+	if !view.WIPLimit.Valid || count <= view.WIPLimit.Int64 {
+		return false, nil
+	}
+	title := fmt.Sprintf("%s has %d notifications, over its limit of %d",
+		view.Name, count, view.WIPLimit.Int64)
+	if snoozed > 0 {
+		title += fmt.Sprintf("; snoozed the %d newest", snoozed)
+	}
+	return s.Notify(ctx, userID, Event{
+		Kind:  EventViewOverLimit,
+		Key:   "view-over-limit:" + view.ID + ":" + s.now().UTC().Format(time.DateOnly),
+		Title: title,
+	})
+}
+
+// systemRepository returns the placeholder repository system notifications belong to,
+// creating it the first time
+func (s *Service) systemRepository(ctx context.Context, userID string) (db.Repository, error) {
+	repo, err := s.queries.GetRepositoryByFullName(ctx, userID, db.SystemRepositoryFullName)
+	if err == nil {
+		return repo, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return db.Repository{}, err
+	}
+	return s.queries.UpsertRepository(ctx, userID, db.UpsertRepositoryParams{
+		Name:        "system",
+		FullName:    db.SystemRepositoryFullName,
+		OwnerLogin:  sql.NullString{String: "octobud", Valid: true},
+		Description: sql.NullString{String: "Notifications from Octobud itself", Valid: true},
+	})
+}
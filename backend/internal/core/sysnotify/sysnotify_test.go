@@ -0,0 +1,224 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sysnotify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestService(ctrl *gomock.Controller) (*Service, *mocks.MockStore) {
+	store := mocks.NewMockStore(ctrl)
+	return NewService(store, func() time.Time { return testNow }), store
+}
+
+func TestNotify_SkipsExistingKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, store := newTestService(ctrl)
+
+	store.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", "system:update:v1.2.0").
+		Return(db.Notification{ID: 1}, nil)
+
+	created, err := svc.UpdateAvailable(context.Background(), "user-1", "v1.2.0")
+	require.NoError(t, err)
+	require.False(t, created)
+}
+
+func TestNotify_CreatesSystemRepositoryAndNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, store := newTestService(ctrl)
+
+	store.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", "system:update:v1.2.0").
+		Return(db.Notification{}, sql.ErrNoRows)
+	store.EXPECT().
+		GetRepositoryByFullName(gomock.Any(), "user-1", db.SystemRepositoryFullName).
+		Return(db.Repository{}, sql.ErrNoRows)
+	store.EXPECT().
+		UpsertRepository(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertRepositoryParams) (db.Repository, error) {
+			require.Equal(t, db.SystemRepositoryFullName, arg.FullName)
+			return db.Repository{ID: 9, FullName: arg.FullName}, nil
+		})
+	store.EXPECT().
+		UpsertNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertNotificationParams) (db.Notification, error) {
+			require.Equal(t, int64(9), arg.RepositoryID)
+			require.Equal(t, db.SubjectTypeSystem, arg.SubjectType)
+			require.Equal(t, db.ReasonSystem, arg.Reason.String)
+			require.Equal(t, "Octobud v1.2.0 is available", arg.SubjectTitle)
+			require.True(t, arg.GithubUnread.Bool)
+			require.JSONEq(t, `{"event":"update_available"}`, string(arg.Payload.RawMessage))
+			return db.Notification{ID: 1}, nil
+		})
+
+	created, err := svc.UpdateAvailable(context.Background(), "user-1", "v1.2.0")
+	require.NoError(t, err)
+	require.True(t, created)
+}
+
+func TestNotify_ReturnsStoreErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, store := newTestService(ctrl)
+
+	store.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", gomock.Any()).
+		Return(db.Notification{}, errors.New("database is locked"))
+
+	_, err := svc.Notify(context.Background(), "user-1", Event{
+		Kind:  EventCleanup,
+		Key:   "cleanup:now",
+		Title: "Cleanup deleted 3 old archived notifications",
+	})
+	require.ErrorIs(t, err, ErrFailedToNotify)
+
+	_, err = svc.Notify(context.Background(), "user-1", Event{Kind: EventCleanup})
+	require.ErrorIs(t, err, ErrInvalidEvent)
+}
+
+func TestTokenExpiring(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresAt     time.Time
+		expectedTitle string
+	}{
+		{name: "no expiration", expiresAt: time.Time{}},
+		{name: "expires later", expiresAt: testNow.Add(30 * 24 * time.Hour)},
+		{
+			name:          "expires soon",
+			expiresAt:     testNow.Add(3 * 24 * time.Hour),
+			expectedTitle: "Your GitHub token expires on Jun 18, 2025. Replace it in settings to keep syncing.",
+		},
+		{
+			name:          "already expired",
+			expiresAt:     testNow.Add(-time.Hour),
+			expectedTitle: "Your GitHub token expired on Jun 15, 2025. Replace it in settings to resume syncing.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc, store := newTestService(ctrl)
+
+			if tt.expectedTitle != "" {
+				store.EXPECT().
+					GetNotificationByGithubID(gomock.Any(), "user-1", gomock.Any()).
+					Return(db.Notification{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetRepositoryByFullName(gomock.Any(), "user-1", db.SystemRepositoryFullName).
+					Return(db.Repository{ID: 9}, nil)
+				store.EXPECT().
+					UpsertNotification(gomock.Any(), "user-1", gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						_ string,
+						arg db.UpsertNotificationParams,
+					) (db.Notification, error) {
+						require.Equal(t, tt.expectedTitle, arg.SubjectTitle)
+						return db.Notification{ID: 1}, nil
+					})
+			}
+
+			created, err := svc.TokenExpiring(context.Background(), "user-1", tt.expiresAt)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTitle != "", created)
+		})
+	}
+}
+
+func TestCleanupCompleted_SkipsWhenNothingDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, _ := newTestService(ctrl)
+
+	created, err := svc.CleanupCompleted(context.Background(), "user-1", 0)
+	require.NoError(t, err)
+	require.False(t, created)
+}
+
+func TestRepositoriesMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, store := newTestService(ctrl)
+
+	created, err := svc.RepositoriesMerged(context.Background(), "user-1", 0)
+	require.NoError(t, err)
+	require.False(t, created)
+
+	store.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", "system:repositories_merged:2025-06-15T12:00:00Z").
+		Return(db.Notification{}, sql.ErrNoRows)
+	store.EXPECT().
+		GetRepositoryByFullName(gomock.Any(), "user-1", db.SystemRepositoryFullName).
+		Return(db.Repository{ID: 9}, nil)
+	store.EXPECT().
+		UpsertNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertNotificationParams) (db.Notification, error) {
+			require.Equal(t, "Merged 2 duplicate repositories", arg.SubjectTitle)
+			require.JSONEq(t, `{"event":"repositories_merged"}`, string(arg.Payload.RawMessage))
+			return db.Notification{ID: 1}, nil
+		})
+
+	created, err = svc.RepositoriesMerged(context.Background(), "user-1", 2)
+	require.NoError(t, err)
+	require.True(t, created)
+}
+
+func TestViewOverLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	svc, store := newTestService(ctrl)
+
+	view := db.View{
+		ID:       "view-1",
+		Name:     "Review queue",
+		WIPLimit: sql.NullInt64{Int64: 10, Valid: true},
+	}
+
+	created, err := svc.ViewOverLimit(context.Background(), "user-1", view, 10, 0)
+	require.NoError(t, err)
+	require.False(t, created)
+
+	store.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", "system:view-over-limit:view-1:2025-06-15").
+		Return(db.Notification{}, sql.ErrNoRows)
+	store.EXPECT().
+		GetRepositoryByFullName(gomock.Any(), "user-1", db.SystemRepositoryFullName).
+		Return(db.Repository{ID: 9}, nil)
+	store.EXPECT().
+		UpsertNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertNotificationParams) (db.Notification, error) {
+			require.Equal(t,
+				"Review queue has 14 notifications, over its limit of 10; snoozed the 4 newest",
+				arg.SubjectTitle)
+			require.JSONEq(t, `{"event":"view_over_limit"}`, string(arg.Payload.RawMessage))
+			return db.Notification{ID: 1}, nil
+		})
+
+	created, err = svc.ViewOverLimit(context.Background(), "user-1", view, 14, 4)
+	require.NoError(t, err)
+	require.True(t, created)
+}
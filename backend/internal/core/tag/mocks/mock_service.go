@@ -58,17 +58,33 @@ func (mr *MockTagServiceMockRecorder) CreateTag(ctx, userID, name, color, descri
 }
 
 // DeleteTag mocks base method.
-func (m *MockTagService) DeleteTag(ctx context.Context, userID, tagID string) error {
+func (m *MockTagService) DeleteTag(ctx context.Context, userID, tagID string, force bool) ([]models.Rule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID, force)
+	ret0, _ := ret[0].([]models.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteTag indicates an expected call of DeleteTag.
-func (mr *MockTagServiceMockRecorder) DeleteTag(ctx, userID, tagID any) *gomock.Call {
+func (mr *MockTagServiceMockRecorder) DeleteTag(ctx, userID, tagID, force any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagService)(nil).DeleteTag), ctx, userID, tagID, force)
+}
+
+// EnsureTagBySlug mocks base method.
+func (m *MockTagService) EnsureTagBySlug(ctx context.Context, userID, slug string) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureTagBySlug", ctx, userID, slug)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureTagBySlug indicates an expected call of EnsureTagBySlug.
+func (mr *MockTagServiceMockRecorder) EnsureTagBySlug(ctx, userID, slug any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockTagService)(nil).DeleteTag), ctx, userID, tagID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTagBySlug", reflect.TypeOf((*MockTagService)(nil).EnsureTagBySlug), ctx, userID, slug)
 }
 
 // GetTag mocks base method.
@@ -34,10 +34,15 @@ type TagService interface {
 		userID, tagID, name string,
 		color, description *string,
 	) (db.Tag, error)
-	DeleteTag(ctx context.Context, userID, tagID string) error
+	DeleteTag(
+		ctx context.Context,
+		userID, tagID string,
+		force bool,
+	) (dependentRules []models.Rule, err error)
 	ReorderTags(ctx context.Context, userID string, tagIDs []string) ([]db.Tag, error)
 	GetTag(ctx context.Context, userID, tagID string) (db.Tag, error)
 	GetTagByName(ctx context.Context, userID, name string) (db.Tag, error)
+	EnsureTagBySlug(ctx context.Context, userID, slug string) (db.Tag, error)
 	ListTags(ctx context.Context, userID string) ([]db.Tag, error)
 }
 
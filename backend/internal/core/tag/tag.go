@@ -34,10 +34,12 @@ var (
 	ErrFailedToUpdateTag             = errors.New("failed to update tag")
 	ErrTagNotFound                   = errors.New("tag not found")
 	ErrFailedToDeleteTag             = errors.New("failed to delete tag")
+	ErrFailedToCheckDependentRules   = errors.New("failed to check for dependent rules")
 	ErrTagIDsRequired                = errors.New("tagIDs is required")
 	ErrFailedToUpdateTagDisplayOrder = errors.New("failed to update tag display order")
 	ErrFailedToGetTag                = errors.New("failed to get tag")
 	ErrFailedToGetTagByName          = errors.New("failed to get tag by name")
+	ErrInvalidTagSlug                = errors.New("invalid tag slug")
 	ErrFailedToListTagsForEntity     = errors.New("failed to list tags for entity")
 	ErrFailedToBuildQuery            = errors.New("failed to build query")
 	ErrFailedToListNotifications     = errors.New("failed to list notifications")
@@ -139,13 +141,43 @@ func (s *Service) UpdateTag(
 	return tag, nil
 }
 
-// DeleteTag deletes a tag
-func (s *Service) DeleteTag(ctx context.Context, userID, tagID string) error {
-	err := s.queries.DeleteTag(ctx, userID, tagID)
+// DeleteTag deletes a tag. Rules that assign or remove the tag would silently stop doing
+// so, so unless force is set a tag used by rules is not deleted and those rules are returned
+// for the caller to report. With force, the dependent rules are disabled and the tag deleted
+// in one transaction, and the disabled rules are returned.
+func (s *Service) DeleteTag(
+	ctx context.Context,
+	userID, tagID string,
+	force bool,
+) (dependentRules []models.Rule, err error) {
+	if force {
+		disabled, err := s.queries.DeleteTagAndDisableRules(ctx, userID, tagID)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToDeleteTag, err)
+		}
+		return rulesFromDB(disabled), nil
+	}
+
+	dependents, err := s.queries.GetRulesByTagID(ctx, userID, tagID)
 	if err != nil {
-		return errors.Join(ErrFailedToDeleteTag, err)
+		return nil, errors.Join(ErrFailedToCheckDependentRules, err)
+	}
+	if len(dependents) > 0 {
+		return rulesFromDB(dependents), nil
 	}
-	return nil
+
+	if err := s.queries.DeleteTag(ctx, userID, tagID); err != nil {
+		return nil, errors.Join(ErrFailedToDeleteTag, err)
+	}
+	return nil, nil
+}
+
+func rulesFromDB(rules []db.Rule) []models.Rule {
+	result := make([]models.Rule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, models.RuleFromDB(rule))
+	}
+	return result
 }
 
 // ReorderTags updates the display order of tags
@@ -196,6 +228,31 @@ func (s *Service) GetTagByName(ctx context.Context, userID, name string) (db.Tag
 	return tag, nil
 }
 
+// EnsureTagBySlug returns the tag with slug, creating it (named after the slug) if it
+// doesn't exist yet
+func (s *Service) EnsureTagBySlug(ctx context.Context, userID, slug string) (db.Tag, error) {
+	if !models.IsSlug(slug) {
+		return db.Tag{}, ErrInvalidTagSlug
+	}
+
+	tag, err := s.queries.GetTagBySlug(ctx, userID, slug)
+	if err == nil {
+		return tag, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return db.Tag{}, errors.Join(ErrFailedToGetTag, err)
+	}
+
+	tag, err = s.queries.UpsertTag(ctx, userID, db.UpsertTagParams{
+		Name: slug,
+		Slug: slug,
+	})
+	if err != nil {
+		return db.Tag{}, errors.Join(ErrFailedToCreateTag, err)
+	}
+	return tag, nil
+}
+
 // ListTags returns all tags (without unread counts)
 func (s *Service) ListTags(ctx context.Context, userID string) ([]db.Tag, error) {
 	tags, err := s.queries.ListAllTags(ctx, userID)
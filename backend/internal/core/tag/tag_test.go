@@ -350,27 +350,71 @@ func TestService_UpdateTag(t *testing.T) {
 
 func TestService_DeleteTag(t *testing.T) {
 	tests := []struct {
-		name      string
-		tagID     string
-		setupMock func(*mocks.MockStore, string)
-		expectErr bool
-		checkErr  func(*testing.T, error)
+		name            string
+		tagID           string
+		force           bool
+		setupMock       func(*mocks.MockStore, string)
+		expectErr       bool
+		expectedRuleIDs []string
+		checkErr        func(*testing.T, error)
 	}{
 		{
 			name:  "success",
 			tagID: "1",
 			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					GetRulesByTagID(gomock.Any(), "test-user-id", id).
+					Return([]db.Rule{}, nil)
 				m.EXPECT().
 					DeleteTag(gomock.Any(), "test-user-id", id).
 					Return(nil)
 			},
 			expectErr: false,
 		},
+		{
+			name:  "returns dependent rules without deleting",
+			tagID: "1",
+			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					GetRulesByTagID(gomock.Any(), "test-user-id", id).
+					Return([]db.Rule{{ID: "rule-1", Name: "Label bugs", Enabled: true}}, nil)
+			},
+			expectErr:       false,
+			expectedRuleIDs: []string{"rule-1"},
+		},
+		{
+			name:  "force disables dependent rules and deletes tag",
+			tagID: "1",
+			force: true,
+			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					DeleteTagAndDisableRules(gomock.Any(), "test-user-id", id).
+					Return([]db.Rule{{ID: "rule-1", Name: "Label bugs"}}, nil)
+			},
+			expectErr:       false,
+			expectedRuleIDs: []string{"rule-1"},
+		},
+		{
+			name:  "error wrapping dependent rule check failure",
+			tagID: "1",
+			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					GetRulesByTagID(gomock.Any(), "test-user-id", id).
+					Return(nil, errors.New("malformed JSON"))
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrFailedToCheckDependentRules))
+			},
+		},
 		{
 			name:  "error wrapping database failure",
 			tagID: "1",
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("foreign key constraint violation")
+				m.EXPECT().
+					GetRulesByTagID(gomock.Any(), "test-user-id", id).
+					Return(nil, nil)
 				m.EXPECT().
 					DeleteTag(gomock.Any(), "test-user-id", id).
 					Return(dbError)
@@ -380,6 +424,20 @@ func TestService_DeleteTag(t *testing.T) {
 				require.True(t, errors.Is(err, ErrFailedToDeleteTag))
 			},
 		},
+		{
+			name:  "error wrapping forced delete failure",
+			tagID: "1",
+			force: true,
+			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					DeleteTagAndDisableRules(gomock.Any(), "test-user-id", id).
+					Return(nil, errors.New("database is locked"))
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrFailedToDeleteTag))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -392,7 +450,7 @@ func TestService_DeleteTag(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			err := service.DeleteTag(ctx, "test-user-id", tt.tagID)
+			dependentRules, err := service.DeleteTag(ctx, "test-user-id", tt.tagID, tt.force)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -401,6 +459,11 @@ func TestService_DeleteTag(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
+				ruleIDs := make([]string, 0, len(dependentRules))
+				for _, rule := range dependentRules {
+					ruleIDs = append(ruleIDs, rule.ID)
+				}
+				require.ElementsMatch(t, tt.expectedRuleIDs, ruleIDs)
 			}
 		})
 	}
@@ -635,6 +698,79 @@ func TestService_GetTagByName(t *testing.T) {
 	}
 }
 
+func TestService_EnsureTagBySlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		slug      string
+		setupMock func(*mocks.MockStore)
+		expectErr error
+		expectID  string
+	}{
+		{
+			name: "returns existing tag",
+			slug: "security",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetTagBySlug(gomock.Any(), "test-user-id", "security").
+					Return(db.Tag{ID: "1", Name: "Security", Slug: "security"}, nil)
+			},
+			expectID: "1",
+		},
+		{
+			name: "creates missing tag named after the slug",
+			slug: "security",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetTagBySlug(gomock.Any(), "test-user-id", "security").
+					Return(db.Tag{}, sql.ErrNoRows)
+				m.EXPECT().
+					UpsertTag(gomock.Any(), "test-user-id", db.UpsertTagParams{
+						Name: "security",
+						Slug: "security",
+					}).
+					Return(db.Tag{ID: "2", Name: "security", Slug: "security"}, nil)
+			},
+			expectID: "2",
+		},
+		{
+			name:      "rejects a value that isn't a slug",
+			slug:      "Security Alerts",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: ErrInvalidTagSlug,
+		},
+		{
+			name: "error wrapping create failure",
+			slug: "security",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetTagBySlug(gomock.Any(), "test-user-id", "security").
+					Return(db.Tag{}, sql.ErrNoRows)
+				m.EXPECT().
+					UpsertTag(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.Tag{}, errors.New("database error"))
+			},
+			expectErr: ErrFailedToCreateTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewService(mockQuerier)
+
+			tag, err := service.EnsureTagBySlug(context.Background(), "test-user-id", tt.slug)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectID, tag.ID)
+		})
+	}
+}
+
 func TestService_CalculateTagUnreadCount(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/team/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/team/service.go -destination=internal/core/team/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTeamService is a mock of TeamService interface.
+type MockTeamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamServiceMockRecorder is the mock recorder for MockTeamService.
+type MockTeamServiceMockRecorder struct {
+	mock *MockTeamService
+}
+
+// NewMockTeamService creates a new mock instance.
+func NewMockTeamService(ctrl *gomock.Controller) *MockTeamService {
+	mock := &MockTeamService{ctrl: ctrl}
+	mock.recorder = &MockTeamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamService) EXPECT() *MockTeamServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTeamSettings mocks base method.
+func (m *MockTeamService) GetTeamSettings(ctx context.Context, userID string) (*models.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSettings", ctx, userID)
+	ret0, _ := ret[0].(*models.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSettings indicates an expected call of GetTeamSettings.
+func (mr *MockTeamServiceMockRecorder) GetTeamSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSettings", reflect.TypeOf((*MockTeamService)(nil).GetTeamSettings), ctx, userID)
+}
+
+// ReviewLoad mocks base method.
+func (m *MockTeamService) ReviewLoad(ctx context.Context, userID string, days int) (*models.ReviewLoadReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewLoad", ctx, userID, days)
+	ret0, _ := ret[0].(*models.ReviewLoadReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewLoad indicates an expected call of ReviewLoad.
+func (mr *MockTeamServiceMockRecorder) ReviewLoad(ctx, userID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewLoad", reflect.TypeOf((*MockTeamService)(nil).ReviewLoad), ctx, userID, days)
+}
+
+// UpdateTeamSettings mocks base method.
+func (m *MockTeamService) UpdateTeamSettings(ctx context.Context, userID string, settings *models.TeamSettings) (*models.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamSettings", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamSettings indicates an expected call of UpdateTeamSettings.
+func (mr *MockTeamServiceMockRecorder) UpdateTeamSettings(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamSettings", reflect.TypeOf((*MockTeamService)(nil).UpdateTeamSettings), ctx, userID, settings)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package team reports how review requests are spread across the user's team.
+package team
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// TeamService is the interface for team settings and review load reporting.
+type TeamService interface {
+	GetTeamSettings(ctx context.Context, userID string) (*models.TeamSettings, error)
+	UpdateTeamSettings(
+		ctx context.Context,
+		userID string,
+		settings *models.TeamSettings,
+	) (*models.TeamSettings, error)
+	// ReviewLoad aggregates review requests updated in the last days by requested reviewer.
+	ReviewLoad(ctx context.Context, userID string, days int) (*models.ReviewLoadReport, error)
+}
+
+// Service provides team settings and review load reporting
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package team
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Bounds for team settings and the review load window
+const (
+	MaxMembers = 100
+	MinDays    = 1
+	MaxDays    = 90
+)
+
+// A reviewer is overloaded with more than overloadFactor times the average number of
+// requests, and at least overloadMinRequests of them, so one request over a quiet week
+// doesn't count.
+const (
+	overloadFactor      = 1.5
+	overloadMinRequests = 3
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadTeamSettings   = errors.New("failed to load team settings")
+	ErrFailedToUpdateTeamSettings = errors.New("failed to update team settings")
+	ErrInvalidTeamSettings        = errors.New("invalid team settings")
+	ErrInvalidWindow              = errors.New("invalid review load window")
+	ErrFailedToLoadReviewLoad     = errors.New("failed to load review load")
+)
+
+// loginPattern matches a GitHub login: alphanumerics and single hyphens, up to 39 characters
+var loginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38}$`)
+
+// GetTeamSettings returns the user's team settings
+func (s *Service) GetTeamSettings(ctx context.Context, userID string) (*models.TeamSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultTeamSettings(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadTeamSettings, err)
+	}
+
+	if !user.TeamSettings.Valid {
+		return models.DefaultTeamSettings(), nil
+	}
+	settings, err := models.TeamSettingsFromJSON(user.TeamSettings.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadTeamSettings, err)
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings validates and stores the user's team settings. Members are trimmed
+// of a leading @ and deduplicated case-insensitively, keeping the first spelling.
+func (s *Service) UpdateTeamSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.TeamSettings,
+) (*models.TeamSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	members, err := normalizeMembers(settings.Members)
+	if err != nil {
+		return nil, err
+	}
+	normalized := &models.TeamSettings{Members: members}
+
+	data, err := normalized.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateTeamSettings, err)
+	}
+	_, err = s.queries.UpdateUserTeamSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateTeamSettings, err)
+	}
+	return normalized, nil
+}
+
+// ReviewLoad counts, for each team member, the review requests updated in the last days
+// on which they're still a requested reviewer. Reviewers are matched case-insensitively.
+func (s *Service) ReviewLoad(
+	ctx context.Context,
+	userID string,
+	days int,
+) (*models.ReviewLoadReport, error) {
+	if days < MinDays || days > MaxDays {
+		return nil, fmt.Errorf("%w: days must be between %d and %d", ErrInvalidWindow, MinDays, MaxDays)
+	}
+
+	settings, err := s.GetTeamSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := s.now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+	requests, err := s.queries.ListReviewRequestReviewers(ctx, userID, since)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadReviewLoad, err)
+	}
+
+	report := buildReport(settings.Members, requests)
+	report.Since = since
+	report.Days = days
+	return report, nil
+}
+
+// buildReport aggregates requests by reviewer. With no members, every reviewer seen is
+// listed in the order first seen.
+func buildReport(members []string, requests []db.ReviewRequestReviewers) *models.ReviewLoadReport {
+	loads := make([]models.ReviewerLoad, 0, len(members))
+	index := make(map[string]int, len(members))
+	for _, member := range members {
+		index[strings.ToLower(member)] = len(loads)
+		loads = append(loads, models.ReviewerLoad{Login: member})
+	}
+	fixedMembers := len(members) > 0
+
+	report := &models.ReviewLoadReport{TotalRequests: len(requests)}
+	for _, request := range requests {
+		assigned := false
+		for _, reviewer := range request.Reviewers {
+			i, ok := index[strings.ToLower(reviewer)]
+			if !ok {
+				if fixedMembers {
+					continue
+				}
+				i = len(loads)
+				index[strings.ToLower(reviewer)] = i
+				loads = append(loads, models.ReviewerLoad{Login: reviewer})
+			}
+			assigned = true
+			loads[i].Requests++
+			if request.Open {
+				loads[i].Open++
+			}
+			if !request.IsRead {
+				loads[i].Unread++
+			}
+		}
+		if !assigned {
+			report.Unassigned++
+		}
+	}
+
+	if len(loads) > 0 {
+		total := 0
+		for _, load := range loads {
+			total += load.Requests
+		}
+		report.Average = float64(total) / float64(len(loads))
+	}
+	for i := range loads {
+		requests := loads[i].Requests
+		loads[i].Overloaded = requests >= overloadMinRequests &&
+			float64(requests) > report.Average*overloadFactor
+	}
+
+	sort.SliceStable(loads, func(i, j int) bool {
+		return loads[i].Requests > loads[j].Requests
+	})
+	report.Reviewers = loads
+	return report
+}
+
+// normalizeMembers trims, validates, and deduplicates team member logins
+func normalizeMembers(members []string) ([]string, error) {
+	if len(members) > MaxMembers {
+		return nil, fmt.Errorf("%w: at most %d members", ErrInvalidTeamSettings, MaxMembers)
+	}
+
+	result := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		login := strings.TrimPrefix(strings.TrimSpace(member), "@")
+		if !loginPattern.MatchString(login) {
+			return nil, fmt.Errorf("%w: %q is not a GitHub login", ErrInvalidTeamSettings, member)
+		}
+		if seen[strings.ToLower(login)] {
+			continue
+		}
+		seen[strings.ToLower(login)] = true
+		result = append(result, login)
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func teamUser(members string) db.User {
+	return db.User{TeamSettings: db.NullRawMessage{
+		RawMessage: json.RawMessage(`{"members":` + members + `}`),
+		Valid:      true,
+	}}
+}
+
+func TestService_UpdateTeamSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		members   []string
+		setupMock func(*mocks.MockStore)
+		expectErr error
+		expected  []string
+	}{
+		{
+			name:    "members are trimmed and deduplicated",
+			members: []string{" alice ", "@bob", "Alice", "carol-x"},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateUserTeamSettings(gomock.Any(), db.NullRawMessage{
+						RawMessage: json.RawMessage(`{"members":["alice","bob","carol-x"]}`),
+						Valid:      true,
+					}).
+					Return(db.User{}, nil)
+			},
+			expected: []string{"alice", "bob", "carol-x"},
+		},
+		{
+			name:      "invalid login is rejected",
+			members:   []string{"alice", "not a login"},
+			expectErr: ErrInvalidTeamSettings,
+		},
+		{
+			name:    "database error is wrapped",
+			members: []string{"alice"},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateUserTeamSettings(gomock.Any(), gomock.Any()).
+					Return(db.User{}, errors.New("disk full"))
+			},
+			expectErr: ErrFailedToUpdateTeamSettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(store)
+			}
+
+			svc := NewService(store, func() time.Time { return testNow })
+			settings, err := svc.UpdateTeamSettings(
+				context.Background(),
+				"user",
+				&models.TeamSettings{Members: tt.members},
+			)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, settings.Members)
+		})
+	}
+}
+
+func TestService_ReviewLoad(t *testing.T) {
+	requests := []db.ReviewRequestReviewers{
+		{GithubID: "1", Open: true, Reviewers: []string{"alice", "bob"}},
+		{GithubID: "2", Open: true, IsRead: true, Reviewers: []string{"Alice"}},
+		{GithubID: "3", Open: false, Reviewers: []string{"alice"}},
+		{GithubID: "4", Open: true, Reviewers: []string{"alice", "outsider"}},
+		{GithubID: "5", Open: true, Reviewers: nil},
+	}
+
+	t.Run("aggregates by team member and flags overload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		store.EXPECT().GetUser(gomock.Any()).Return(teamUser(`["alice","bob","carol"]`), nil)
+		store.EXPECT().
+			ListReviewRequestReviewers(gomock.Any(), "user", testNow.Add(-7*24*time.Hour)).
+			Return(requests, nil)
+
+		svc := NewService(store, func() time.Time { return testNow })
+		report, err := svc.ReviewLoad(context.Background(), "user", 7)
+		require.NoError(t, err)
+
+		require.Equal(t, 7, report.Days)
+		require.Equal(t, 5, report.TotalRequests)
+		require.Equal(t, 1, report.Unassigned)
+		require.InDelta(t, 5.0/3.0, report.Average, 0.001)
+		require.Equal(t, []models.ReviewerLoad{
+			{Login: "alice", Requests: 4, Open: 3, Unread: 3, Overloaded: true},
+			{Login: "bob", Requests: 1, Open: 1, Unread: 1},
+			{Login: "carol"},
+		}, report.Reviewers)
+	})
+
+	t.Run("without team members lists every reviewer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mocks.NewMockStore(ctrl)
+		store.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+		store.EXPECT().
+			ListReviewRequestReviewers(gomock.Any(), "user", gomock.Any()).
+			Return(requests, nil)
+
+		svc := NewService(store, func() time.Time { return testNow })
+		report, err := svc.ReviewLoad(context.Background(), "user", 14)
+		require.NoError(t, err)
+
+		logins := make([]string, len(report.Reviewers))
+		for i, r := range report.Reviewers {
+			logins[i] = r.Login
+		}
+		require.Equal(t, []string{"alice", "bob", "outsider"}, logins)
+		require.Equal(t, 1, report.Unassigned)
+	})
+
+	t.Run("window out of range is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewService(mocks.NewMockStore(ctrl), func() time.Time { return testNow })
+		_, err := svc.ReviewLoad(context.Background(), "user", 0)
+		require.ErrorIs(t, err, ErrInvalidWindow)
+		_, err = svc.ReviewLoad(context.Background(), "user", MaxDays+1)
+		require.ErrorIs(t, err, ErrInvalidWindow)
+	})
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/triage/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/triage/service.go -destination=internal/core/triage/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTriageService is a mock of TriageService interface.
+type MockTriageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTriageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTriageServiceMockRecorder is the mock recorder for MockTriageService.
+type MockTriageServiceMockRecorder struct {
+	mock *MockTriageService
+}
+
+// NewMockTriageService creates a new mock instance.
+func NewMockTriageService(ctrl *gomock.Controller) *MockTriageService {
+	mock := &MockTriageService{ctrl: ctrl}
+	mock.recorder = &MockTriageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTriageService) EXPECT() *MockTriageServiceMockRecorder {
+	return m.recorder
+}
+
+// DiscardPending mocks base method.
+func (m *MockTriageService) DiscardPending(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardPending", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardPending indicates an expected call of DiscardPending.
+func (mr *MockTriageServiceMockRecorder) DiscardPending(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardPending", reflect.TypeOf((*MockTriageService)(nil).DiscardPending), ctx, userID)
+}
+
+// ExportView mocks base method.
+func (m *MockTriageService) ExportView(ctx context.Context, userID, viewID string, includeRules bool) (*models.ViewShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportView", ctx, userID, viewID, includeRules)
+	ret0, _ := ret[0].(*models.ViewShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportView indicates an expected call of ExportView.
+func (mr *MockTriageServiceMockRecorder) ExportView(ctx, userID, viewID, includeRules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportView", reflect.TypeOf((*MockTriageService)(nil).ExportView), ctx, userID, viewID, includeRules)
+}
+
+// Import mocks base method.
+func (m *MockTriageService) Import(ctx context.Context, userID string, backup *models.TriageBackup) (*models.TriageImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, userID, backup)
+	ret0, _ := ret[0].(*models.TriageImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockTriageServiceMockRecorder) Import(ctx, userID, backup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockTriageService)(nil).Import), ctx, userID, backup)
+}
+
+// ImportView mocks base method.
+func (m *MockTriageService) ImportView(ctx context.Context, userID string, share *models.ViewShare) (*models.ViewShareImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportView", ctx, userID, share)
+	ret0, _ := ret[0].(*models.ViewShareImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportView indicates an expected call of ImportView.
+func (mr *MockTriageServiceMockRecorder) ImportView(ctx, userID, share any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportView", reflect.TypeOf((*MockTriageService)(nil).ImportView), ctx, userID, share)
+}
+
+// WriteExport mocks base method.
+func (m *MockTriageService) WriteExport(ctx context.Context, userID string, exportedAt time.Time, w io.Writer) (*models.TriageExportSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteExport", ctx, userID, exportedAt, w)
+	ret0, _ := ret[0].(*models.TriageExportSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteExport indicates an expected call of WriteExport.
+func (mr *MockTriageServiceMockRecorder) WriteExport(ctx, userID, exportedAt, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteExport", reflect.TypeOf((*MockTriageService)(nil).WriteExport), ctx, userID, exportedAt, w)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package triage exports notification triage state and restores it after a reset, and
+// shares custom views and their rules as portable documents.
+package triage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// TriageService is the interface for triage backups.
+type TriageService interface {
+	// WriteExport writes the triage state of every notification to w as a backup,
+	// reading it a page at a time. A zero exportedAt means now. Writing again with the
+	// returned ExportedAt produces the same bytes unless triage state changed.
+	WriteExport(
+		ctx context.Context,
+		userID string,
+		exportedAt time.Time,
+		w io.Writer,
+	) (*models.TriageExportSummary, error)
+	// Import stages a backup to be merged into notifications as they sync, and merges it
+	// into the notifications that already exist.
+	Import(
+		ctx context.Context,
+		userID string,
+		backup *models.TriageBackup,
+	) (*models.TriageImportResult, error)
+	// DiscardPending drops imported state that has not been merged yet.
+	DiscardPending(ctx context.Context, userID string) (int64, error)
+	// ExportView returns a custom view as a document to share, optionally with the rules
+	// that follow it.
+	ExportView(ctx context.Context, userID, viewID string, includeRules bool) (*models.ViewShare, error)
+	// ImportView creates the view and rules in a shared document, renaming them if their
+	// names are taken.
+	ImportView(
+		ctx context.Context,
+		userID string,
+		share *models.ViewShare,
+	) (*models.ViewShareImportResult, error)
+}
+
+// Service provides triage backups
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the given store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
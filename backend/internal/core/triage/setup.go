@@ -0,0 +1,347 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package triage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/rules"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+	"github.com/octobud-hq/octobud/backend/internal/query/expr"
+)
+
+// exportSetup returns the user's tags, custom views and rules as backup sections. Rules
+// name their view and tags by slug so the backup doesn't depend on local IDs.
+func (s *Service) exportSetup(ctx context.Context, userID string) (*models.TriageBackup, error) {
+	tags, err := s.queries.ListAllTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	views, err := s.queries.ListViews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleRows, err := s.queries.ListRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	setup := &models.TriageBackup{
+		Tags:  make([]models.TagBackup, 0, len(tags)),
+		Views: make([]models.ViewBackup, 0, len(views)),
+		Rules: make([]models.RuleBackup, 0, len(ruleRows)),
+	}
+
+	tagSlugs := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagSlugs[tag.ID] = tag.Slug
+		setup.Tags = append(setup.Tags, models.TagBackup{
+			Name:        tag.Name,
+			Color:       tag.Color.String,
+			Description: tag.Description.String,
+		})
+	}
+
+	viewSlugs := make(map[string]string, len(views))
+	for _, view := range views {
+		viewSlugs[view.ID] = view.Slug
+		setup.Views = append(setup.Views, viewBackup(view))
+	}
+
+	for _, rule := range ruleRows {
+		backupRule, err := ruleBackup(rule, tagSlugs, viewSlugs)
+		if err != nil {
+			return nil, err
+		}
+		setup.Rules = append(setup.Rules, backupRule)
+	}
+	return setup, nil
+}
+
+// viewBackup returns a view as a backup section
+func viewBackup(view db.View) models.ViewBackup {
+	return models.ViewBackup{
+		Name:          view.Name,
+		Slug:          view.Slug,
+		Description:   view.Description.String,
+		Icon:          view.Icon.String,
+		Query:         view.Query.String,
+		WIPLimit:      models.NullInt64Ptr(view.WIPLimit),
+		WIPAutoSnooze: view.WIPAutoSnooze,
+	}
+}
+
+// ruleBackup returns a rule as a backup section, naming its view and tags by slug using
+// the given maps from ID to slug
+func ruleBackup(rule db.Rule, tagSlugs, viewSlugs map[string]string) (models.RuleBackup, error) {
+	var actions models.RuleActions
+	if len(rule.Actions) > 0 {
+		if err := json.Unmarshal(rule.Actions, &actions); err != nil {
+			return models.RuleBackup{}, err
+		}
+	}
+	actions.AddTagSlugs = appendTagSlugs(actions.AddTagSlugs, actions.AssignTags, tagSlugs)
+	actions.RemoveTagSlugs = appendTagSlugs(actions.RemoveTagSlugs, actions.RemoveTags, tagSlugs)
+	actions.AssignTags = nil
+	actions.RemoveTags = nil
+
+	return models.RuleBackup{
+		Name:           rule.Name,
+		Description:    rule.Description.String,
+		Query:          rule.Query.String,
+		ViewSlug:       viewSlugs[rule.ViewID.String],
+		Enabled:        rule.Enabled,
+		Actions:        actions,
+		Priority:       int(rule.Priority),
+		StopProcessing: rule.StopProcessing,
+		Condition:      rule.Condition.String,
+	}, nil
+}
+
+// appendTagSlugs adds the slugs of the tags with the given IDs to slugs, skipping tags
+// that no longer exist and slugs already listed
+func appendTagSlugs(slugs, tagIDs []string, tagSlugs map[string]string) []string {
+	for _, id := range tagIDs {
+		if slug, ok := tagSlugs[id]; ok && !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+// importTags creates the backup's tags that are missing locally. A tag matching a local
+// tag by name or slug is skipped, keeping the local color and description.
+func (s *Service) importTags(
+	ctx context.Context,
+	userID string,
+	backupTags []models.TagBackup,
+) (models.TriageImportCounts, error) {
+	var counts models.TriageImportCounts
+	if len(backupTags) == 0 {
+		return counts, nil
+	}
+
+	tags, err := s.queries.ListAllTags(ctx, userID)
+	if err != nil {
+		return counts, err
+	}
+	names := make(map[string]bool, len(tags))
+	slugs := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		names[tag.Name] = true
+		slugs[tag.Slug] = true
+	}
+
+	for _, backupTag := range backupTags {
+		name := strings.TrimSpace(backupTag.Name)
+		slug := models.Slugify(name)
+		if slug == "" || names[name] || slugs[slug] {
+			counts.Skipped++
+			continue
+		}
+		if _, err := s.queries.UpsertTag(ctx, userID, db.UpsertTagParams{
+			Name:        name,
+			Slug:        slug,
+			Color:       models.StringPtrToNull(&backupTag.Color),
+			Description: models.StringPtrToNull(&backupTag.Description),
+		}); err != nil {
+			return counts, err
+		}
+		names[name] = true
+		slugs[slug] = true
+		counts.Created++
+	}
+	return counts, nil
+}
+
+// importViews creates the backup's views that are missing locally. A view matching a local
+// view by name or slug is skipped, as are views with a reserved slug or a query this
+// release can't parse. The returned map takes the slug a view had in the backup to the ID
+// of the local view it matched or created, for importing rules.
+func (s *Service) importViews(
+	ctx context.Context,
+	userID string,
+	backupViews []models.ViewBackup,
+) (models.TriageImportCounts, map[string]string, error) {
+	var counts models.TriageImportCounts
+	views, err := s.queries.ListViews(ctx, userID)
+	if err != nil {
+		return counts, nil, err
+	}
+	byName := make(map[string]string, len(views))
+	bySlug := make(map[string]string, len(views))
+	for _, view := range views {
+		byName[view.Name] = view.ID
+		bySlug[view.Slug] = view.ID
+	}
+
+	// Rules may also follow views that only exist locally
+	resolved := maps.Clone(bySlug)
+
+	for _, backupView := range backupViews {
+		name := strings.TrimSpace(backupView.Name)
+		slug := models.Slugify(name)
+		id, ok := byName[name]
+		if !ok && slug != "" {
+			id, ok = bySlug[slug]
+		}
+		if ok {
+			resolved[backupView.Slug] = id
+			counts.Skipped++
+			continue
+		}
+
+		queryStr := strings.TrimSpace(backupView.Query)
+		if slug == "" || db.IsSystemView(slug) || queryStr == "" {
+			counts.Skipped++
+			continue
+		}
+		if _, err := query.ParseAndValidate(queryStr); err != nil {
+			counts.Skipped++
+			continue
+		}
+
+		view, err := s.queries.CreateView(ctx, userID, db.CreateViewParams{
+			Name:        name,
+			Slug:        slug,
+			Description: models.StringPtrToNull(&backupView.Description),
+			Icon:        models.StringPtrToNull(&backupView.Icon),
+			Query:       models.StringPtrToNull(&queryStr),
+			IsDefault:   false,
+		})
+		if err != nil {
+			return counts, nil, err
+		}
+		if backupView.WIPLimit != nil && *backupView.WIPLimit >= 1 {
+			if _, err := s.queries.UpdateViewWIPLimit(ctx, userID, db.UpdateViewWIPLimitParams{
+				ID:         view.ID,
+				Limit:      sql.NullInt64{Int64: *backupView.WIPLimit, Valid: true},
+				AutoSnooze: backupView.WIPAutoSnooze,
+			}); err != nil {
+				return counts, nil, err
+			}
+		}
+		byName[name] = view.ID
+		bySlug[slug] = view.ID
+		resolved[backupView.Slug] = view.ID
+		counts.Created++
+	}
+	return counts, resolved, nil
+}
+
+// importRules creates the backup's rules that are missing locally, after the existing
+// rules and in backup order. A rule matching a local rule by name is skipped, as is one
+// whose view wasn't imported or whose query or actions don't validate. Tag actions are
+// only kept by slug, since tag IDs differ between databases.
+func (s *Service) importRules(
+	ctx context.Context,
+	userID string,
+	backupRules []models.RuleBackup,
+	viewIDs map[string]string,
+) (models.TriageImportCounts, error) {
+	var counts models.TriageImportCounts
+	existing, err := s.queries.ListRules(ctx, userID)
+	if err != nil {
+		return counts, err
+	}
+	names := make(map[string]bool, len(existing))
+	maxOrder := int32(0)
+	for _, rule := range existing {
+		names[rule.Name] = true
+		maxOrder = max(maxOrder, rule.DisplayOrder)
+	}
+
+	for _, backupRule := range backupRules {
+		params, ok := ruleParams(backupRule, viewIDs)
+		if !ok || names[params.Name] {
+			counts.Skipped++
+			continue
+		}
+		maxOrder += 100
+		params.DisplayOrder = maxOrder
+		if _, err := s.queries.CreateRule(ctx, userID, params); err != nil {
+			return counts, err
+		}
+		names[params.Name] = true
+		counts.Created++
+	}
+	return counts, nil
+}
+
+// ruleParams validates a rule from a backup the way the rules service validates a new
+// rule, and reports false if it can't be created here
+func ruleParams(backupRule models.RuleBackup, viewIDs map[string]string) (db.CreateRuleParams, bool) {
+	params := db.CreateRuleParams{
+		Name:           strings.TrimSpace(backupRule.Name),
+		Description:    models.StringPtrToNull(&backupRule.Description),
+		Enabled:        backupRule.Enabled,
+		Priority:       int32(backupRule.Priority),
+		StopProcessing: backupRule.StopProcessing,
+	}
+	if params.Name == "" {
+		return params, false
+	}
+
+	if backupRule.ViewSlug != "" {
+		viewID, ok := viewIDs[backupRule.ViewSlug]
+		if !ok {
+			return params, false
+		}
+		params.ViewID = sql.NullString{String: viewID, Valid: true}
+	} else {
+		queryStr := strings.TrimSpace(backupRule.Query)
+		if queryStr == "" {
+			return params, false
+		}
+		if _, err := query.ParseAndValidate(queryStr); err != nil {
+			return params, false
+		}
+		params.Query = sql.NullString{String: queryStr, Valid: true}
+	}
+
+	actions := backupRule.Actions
+	actions.AssignTags = nil
+	actions.RemoveTags = nil
+	if actions.Alert != nil && actions.Alert.Validate() != nil {
+		return params, false
+	}
+	if actions.ValidateTagSlugs() != nil {
+		return params, false
+	}
+	if backupRule.Priority < -rules.MaxPriority || backupRule.Priority > rules.MaxPriority {
+		return params, false
+	}
+	if condition := strings.TrimSpace(backupRule.Condition); condition != "" {
+		if _, err := expr.Compile(condition); err != nil {
+			return params, false
+		}
+		params.Condition = sql.NullString{String: condition, Valid: true}
+	}
+
+	actionsJSON, err := json.Marshal(actions)
+	if err != nil {
+		return params, false
+	}
+	params.Actions = actionsJSON
+	return params, true
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package triage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// MaxSharedRules bounds the number of rules in one shared view
+const MaxSharedRules = 100
+
+// Shared view errors
+var (
+	ErrViewNotFound       = errors.New("view not found")
+	ErrInvalidViewShare   = errors.New("invalid shared view")
+	ErrFailedToExportView = errors.New("failed to export view")
+	ErrFailedToImportView = errors.New("failed to import view")
+)
+
+// ExportView returns a custom view as a document to share. With includeRules, the rules
+// that follow the view come with it, along with the tags their actions name. Webhook
+// actions are left out since integrations belong to the user who set them up.
+func (s *Service) ExportView(
+	ctx context.Context,
+	userID, viewID string,
+	includeRules bool,
+) (*models.ViewShare, error) {
+	view, err := s.queries.GetView(ctx, userID, viewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrViewNotFound
+		}
+		return nil, errors.Join(ErrFailedToExportView, err)
+	}
+
+	share := &models.ViewShare{
+		Version:    models.ViewShareVersion,
+		ExportedAt: s.now().UTC().Truncate(time.Second),
+		View:       viewBackup(view),
+	}
+	if !includeRules {
+		return share, nil
+	}
+
+	ruleRows, err := s.queries.GetRulesByViewID(ctx, userID, sql.NullString{String: view.ID, Valid: true})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToExportView, err)
+	}
+	if len(ruleRows) == 0 {
+		return share, nil
+	}
+	tags, err := s.queries.ListAllTags(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToExportView, err)
+	}
+	tagSlugs := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagSlugs[tag.ID] = tag.Slug
+	}
+
+	viewSlugs := map[string]string{view.ID: view.Slug}
+	named := make(map[string]bool)
+	share.Rules = make([]models.RuleBackup, 0, len(ruleRows))
+	for _, rule := range ruleRows {
+		backupRule, err := ruleBackup(rule, tagSlugs, viewSlugs)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToExportView, err)
+		}
+		backupRule.Actions.NotifyWebhook = nil
+		for _, slug := range backupRule.Actions.AddTagSlugs {
+			named[slug] = true
+		}
+		for _, slug := range backupRule.Actions.RemoveTagSlugs {
+			named[slug] = true
+		}
+		share.Rules = append(share.Rules, backupRule)
+	}
+
+	for _, tag := range tags {
+		if named[tag.Slug] {
+			share.Tags = append(share.Tags, models.TagBackup{
+				Name:        tag.Name,
+				Color:       tag.Color.String,
+				Description: tag.Description.String,
+			})
+		}
+	}
+	return share, nil
+}
+
+// ImportView creates the view in a shared document, along with its rules and any of its
+// tags that are missing locally. Unlike a backup, a shared view is always created: if its
+// name or slug is taken it's imported as "Name (2)", and rules are renamed the same way.
+// Rules that don't follow the shared view, or that don't validate, are skipped.
+func (s *Service) ImportView(
+	ctx context.Context,
+	userID string,
+	share *models.ViewShare,
+) (*models.ViewShareImportResult, error) {
+	if share.Version < 1 || share.Version > models.ViewShareVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidViewShare, share.Version)
+	}
+	name := strings.TrimSpace(share.View.Name)
+	if models.Slugify(name) == "" {
+		return nil, fmt.Errorf("%w: view name is required", ErrInvalidViewShare)
+	}
+	queryStr := strings.TrimSpace(share.View.Query)
+	if queryStr == "" {
+		return nil, fmt.Errorf("%w: view query is required", ErrInvalidViewShare)
+	}
+	if _, err := query.ParseAndValidate(queryStr); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidViewShare, err)
+	}
+	if len(share.Rules) > MaxSharedRules {
+		return nil, fmt.Errorf("%w: more than %d rules", ErrInvalidViewShare, MaxSharedRules)
+	}
+
+	views, err := s.queries.ListViews(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImportView, err)
+	}
+	viewNames := make(map[string]bool, len(views))
+	viewSlugs := make(map[string]bool, len(views))
+	for _, view := range views {
+		viewNames[view.Name] = true
+		viewSlugs[view.Slug] = true
+	}
+	importName := uniqueName(name, func(candidate string) bool {
+		slug := models.Slugify(candidate)
+		return viewNames[candidate] || viewSlugs[slug] || db.IsSystemView(slug)
+	})
+
+	result := &models.ViewShareImportResult{Renamed: importName != name}
+	// Tags come first so tags the rules add keep their shared color
+	if result.Tags, err = s.importTags(ctx, userID, share.Tags); err != nil {
+		return nil, errors.Join(ErrFailedToImportView, err)
+	}
+
+	view, err := s.queries.CreateView(ctx, userID, db.CreateViewParams{
+		Name:        importName,
+		Slug:        models.Slugify(importName),
+		Description: models.StringPtrToNull(&share.View.Description),
+		Icon:        models.StringPtrToNull(&share.View.Icon),
+		Query:       models.StringPtrToNull(&queryStr),
+		IsDefault:   false,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImportView, err)
+	}
+	if share.View.WIPLimit != nil && *share.View.WIPLimit >= 1 {
+		if view, err = s.queries.UpdateViewWIPLimit(ctx, userID, db.UpdateViewWIPLimitParams{
+			ID:         view.ID,
+			Limit:      sql.NullInt64{Int64: *share.View.WIPLimit, Valid: true},
+			AutoSnooze: share.View.WIPAutoSnooze,
+		}); err != nil {
+			return nil, errors.Join(ErrFailedToImportView, err)
+		}
+	}
+	result.View = models.ViewFromDB(view)
+
+	if len(share.Rules) > 0 {
+		if result.Rules, err = s.importSharedRules(ctx, userID, share, view.ID); err != nil {
+			return nil, errors.Join(ErrFailedToImportView, err)
+		}
+	}
+	return result, nil
+}
+
+// importSharedRules creates the rules of a shared view so they follow the view it was
+// imported as, renaming rules whose names are taken
+func (s *Service) importSharedRules(
+	ctx context.Context,
+	userID string,
+	share *models.ViewShare,
+	viewID string,
+) (models.TriageImportCounts, error) {
+	existing, err := s.queries.ListRules(ctx, userID)
+	if err != nil {
+		return models.TriageImportCounts{}, err
+	}
+	names := make(map[string]bool, len(existing))
+	for _, rule := range existing {
+		names[rule.Name] = true
+	}
+
+	var skipped int
+	sharedRules := make([]models.RuleBackup, 0, len(share.Rules))
+	for _, sharedRule := range share.Rules {
+		name := strings.TrimSpace(sharedRule.Name)
+		if sharedRule.ViewSlug != share.View.Slug || name == "" {
+			skipped++
+			continue
+		}
+		sharedRule.Name = uniqueName(name, func(candidate string) bool { return names[candidate] })
+		sharedRule.Actions.NotifyWebhook = nil
+		names[sharedRule.Name] = true
+		sharedRules = append(sharedRules, sharedRule)
+	}
+
+	counts, err := s.importRules(ctx, userID, sharedRules, map[string]string{share.View.Slug: viewID})
+	counts.Skipped += skipped
+	return counts, err
+}
+
+// uniqueName returns name, or the first of "name (2)", "name (3)" and so on that isn't taken
+func uniqueName(name string, taken func(string) bool) string {
+	candidate := name
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return candidate
+}
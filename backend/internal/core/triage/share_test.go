@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package triage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_ExportView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	view := db.View{
+		ID:       "view-1",
+		Name:     "Reviews",
+		Slug:     "reviews",
+		Query:    sql.NullString{String: "reason:review_requested", Valid: true},
+		WIPLimit: sql.NullInt64{Int64: 5, Valid: true},
+	}
+	actions, err := json.Marshal(models.RuleActions{
+		AssignTags:    []string{"tag-1"},
+		NotifyWebhook: []string{"integration-1"},
+	})
+	require.NoError(t, err)
+
+	mockStore.EXPECT().GetView(gomock.Any(), "user-1", "view-1").Return(view, nil).Times(2)
+	mockStore.EXPECT().
+		GetRulesByViewID(gomock.Any(), "user-1", sql.NullString{String: "view-1", Valid: true}).
+		Return([]db.Rule{{
+			Name:    "Tag reviews",
+			ViewID:  sql.NullString{String: "view-1", Valid: true},
+			Enabled: true,
+			Actions: actions,
+		}}, nil)
+	mockStore.EXPECT().
+		ListAllTags(gomock.Any(), "user-1").
+		Return([]db.Tag{
+			{ID: "tag-1", Name: "Review", Slug: "review", Color: sql.NullString{String: "red", Valid: true}},
+			{ID: "tag-2", Name: "Other", Slug: "other"},
+		}, nil)
+	mockStore.EXPECT().GetView(gomock.Any(), "user-1", "gone").Return(db.View{}, sql.ErrNoRows)
+
+	svc := NewService(mockStore, func() time.Time { return testNow })
+	wipLimit := int64(5)
+	expectedView := models.ViewBackup{
+		Name:     "Reviews",
+		Slug:     "reviews",
+		Query:    "reason:review_requested",
+		WIPLimit: &wipLimit,
+	}
+
+	share, err := svc.ExportView(context.Background(), "user-1", "view-1", false)
+	require.NoError(t, err)
+	require.Equal(t, &models.ViewShare{
+		Version:    models.ViewShareVersion,
+		ExportedAt: testNow,
+		View:       expectedView,
+	}, share)
+
+	share, err = svc.ExportView(context.Background(), "user-1", "view-1", true)
+	require.NoError(t, err)
+	require.Equal(t, []models.RuleBackup{{
+		Name:     "Tag reviews",
+		ViewSlug: "reviews",
+		Enabled:  true,
+		Actions:  models.RuleActions{AddTagSlugs: []string{"review"}},
+	}}, share.Rules)
+	require.Equal(t, []models.TagBackup{{Name: "Review", Color: "red"}}, share.Tags)
+
+	_, err = svc.ExportView(context.Background(), "user-1", "gone", false)
+	require.ErrorIs(t, err, ErrViewNotFound)
+}
+
+func TestService_ImportView(t *testing.T) {
+	share := &models.ViewShare{
+		Version: models.ViewShareVersion,
+		View:    models.ViewBackup{Name: "Reviews", Slug: "reviews", Query: "reason:review_requested"},
+		Rules: []models.RuleBackup{
+			{
+				Name:     "Tag reviews",
+				ViewSlug: "reviews",
+				Enabled:  true,
+				Actions: models.RuleActions{
+					AddTagSlugs:   []string{"review"},
+					NotifyWebhook: []string{"integration-1"},
+				},
+			},
+			{Name: "Elsewhere", ViewSlug: "other"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		share     *models.ViewShare
+		setupMock func(*mocks.MockStore)
+		expectErr error
+		expected  *models.ViewShareImportResult
+	}{
+		{
+			name:  "renames the view and rules when their names are taken",
+			share: share,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListViews(gomock.Any(), "user-1").
+					Return([]db.View{
+						{ID: "view-a", Name: "Reviews", Slug: "reviews"},
+						{ID: "view-b", Name: "To review", Slug: "reviews-2"},
+					}, nil)
+				m.EXPECT().
+					CreateView(gomock.Any(), "user-1", db.CreateViewParams{
+						Name:      "Reviews (3)",
+						Slug:      "reviews-3",
+						Query:     sql.NullString{String: "reason:review_requested", Valid: true},
+						IsDefault: false,
+					}).
+					Return(db.View{ID: "view-new", Name: "Reviews (3)", Slug: "reviews-3"}, nil)
+				m.EXPECT().
+					ListRules(gomock.Any(), "user-1").
+					Return([]db.Rule{{Name: "Tag reviews", DisplayOrder: 100}}, nil).
+					Times(2)
+				m.EXPECT().
+					CreateRule(gomock.Any(), "user-1", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, arg db.CreateRuleParams) (db.Rule, error) {
+						require.Equal(t, "Tag reviews (2)", arg.Name)
+						require.Equal(t, sql.NullString{String: "view-new", Valid: true}, arg.ViewID)
+						require.Equal(t, int32(200), arg.DisplayOrder)
+						require.JSONEq(t, `{"skipInbox":false,"addTagSlugs":["review"]}`, string(arg.Actions))
+						return db.Rule{ID: "rule-new"}, nil
+					})
+			},
+			expected: &models.ViewShareImportResult{
+				View:    models.View{ID: "view-new", Name: "Reviews (3)", Slug: "reviews-3"},
+				Renamed: true,
+				Rules:   models.TriageImportCounts{Created: 1, Skipped: 1},
+			},
+		},
+		{
+			name: "renames a view with a reserved slug",
+			share: &models.ViewShare{
+				Version: models.ViewShareVersion,
+				View:    models.ViewBackup{Name: "Inbox", Query: "is:unread"},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListViews(gomock.Any(), "user-1").Return(nil, nil)
+				m.EXPECT().
+					CreateView(gomock.Any(), "user-1", db.CreateViewParams{
+						Name:      "Inbox (2)",
+						Slug:      "inbox-2",
+						Query:     sql.NullString{String: "is:unread", Valid: true},
+						IsDefault: false,
+					}).
+					Return(db.View{ID: "view-new", Name: "Inbox (2)", Slug: "inbox-2"}, nil)
+			},
+			expected: &models.ViewShareImportResult{
+				View:    models.View{ID: "view-new", Name: "Inbox (2)", Slug: "inbox-2"},
+				Renamed: true,
+			},
+		},
+		{
+			name:      "rejects an unsupported version",
+			share:     &models.ViewShare{Version: 99, View: share.View},
+			setupMock: func(*mocks.MockStore) {},
+			expectErr: ErrInvalidViewShare,
+		},
+		{
+			name: "rejects a view without a query",
+			share: &models.ViewShare{
+				Version: models.ViewShareVersion,
+				View:    models.ViewBackup{Name: "Reviews"},
+			},
+			setupMock: func(*mocks.MockStore) {},
+			expectErr: ErrInvalidViewShare,
+		},
+		{
+			name: "rejects a view with an invalid query",
+			share: &models.ViewShare{
+				Version: models.ViewShareVersion,
+				View:    models.ViewBackup{Name: "Reviews", Query: "bogus:"},
+			},
+			setupMock: func(*mocks.MockStore) {},
+			expectErr: ErrInvalidViewShare,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			svc := NewService(mockStore, func() time.Time { return testNow })
+			result, err := svc.ImportView(context.Background(), "user-1", tt.share)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package triage
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ExportPageSize is how many notifications an export reads at a time, which bounds the
+// memory an export uses however large the backup is
+const ExportPageSize = 500
+
+// exportBufferSize is the size of the buffer an export writes through
+const exportBufferSize = 64 * 1024
+
+// MaxEntries bounds the number of notifications in one import. Larger backups are imported
+// in several requests; staged state is keyed by notification, so parts can arrive in any order.
+const MaxEntries = 10000
+
+// Error definitions
+var (
+	ErrFailedToExport        = errors.New("failed to export triage state")
+	ErrFailedToImport        = errors.New("failed to import triage state")
+	ErrUnsupportedVersion    = errors.New("unsupported triage backup version")
+	ErrInvalidBackup         = errors.New("invalid triage backup")
+	ErrFailedToDiscardImport = errors.New("failed to discard pending triage state")
+)
+
+// WriteExport writes a backup as JSON in the same shape as models.TriageBackup. Only one
+// page of notifications is held in memory at a time, and the output is flushed to w after
+// each page. Tags, views and rules follow the notifications.
+func (s *Service) WriteExport(
+	ctx context.Context,
+	userID string,
+	exportedAt time.Time,
+	w io.Writer,
+) (*models.TriageExportSummary, error) {
+	if exportedAt.IsZero() {
+		exportedAt = s.now()
+	}
+	// Backups record the time to the second, so a resumed export can reproduce it exactly
+	exportedAt = exportedAt.UTC().Truncate(time.Second)
+
+	digest := sha256.New()
+	counter := &countingWriter{}
+	out := bufio.NewWriterSize(io.MultiWriter(w, digest, counter), exportBufferSize)
+
+	header, err := json.Marshal(exportedAt)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToExport, err)
+	}
+	fmt.Fprintf(out, `{"version":%d,"exportedAt":%s,"notifications":[`, models.TriageBackupVersion, header)
+
+	entries := 0
+	after := ""
+	for {
+		states, err := s.queries.ListTriageStatesAfter(ctx, userID, after, ExportPageSize)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToExport, err)
+		}
+		for _, state := range states {
+			entry, err := json.Marshal(models.TriageEntry{
+				GithubID:     state.GithubID,
+				IsRead:       state.IsRead,
+				Archived:     state.Archived,
+				Starred:      state.Starred,
+				Muted:        state.Muted,
+				SnoozedUntil: models.NullTimePtr(state.SnoozedUntil),
+				Tags:         state.TagNames,
+			})
+			if err != nil {
+				return nil, errors.Join(ErrFailedToExport, err)
+			}
+			if entries > 0 {
+				out.WriteByte(',')
+			}
+			out.Write(entry)
+			entries++
+		}
+		if err := out.Flush(); err != nil {
+			return nil, errors.Join(ErrFailedToExport, err)
+		}
+		if len(states) < ExportPageSize {
+			break
+		}
+		after = states[len(states)-1].GithubID
+	}
+
+	out.WriteByte(']')
+
+	setup, err := s.exportSetup(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToExport, err)
+	}
+	for _, section := range []struct {
+		key   string
+		value any
+	}{
+		{"tags", setup.Tags},
+		{"views", setup.Views},
+		{"rules", setup.Rules},
+	} {
+		data, err := json.Marshal(section.value)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToExport, err)
+		}
+		fmt.Fprintf(out, `,%q:%s`, section.key, data)
+	}
+
+	out.WriteString("}\n")
+	if err := out.Flush(); err != nil {
+		return nil, errors.Join(ErrFailedToExport, err)
+	}
+
+	return &models.TriageExportSummary{
+		ExportedAt: exportedAt,
+		Entries:    entries,
+		Size:       counter.n,
+		Digest:     hex.EncodeToString(digest.Sum(nil)),
+	}, nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// Import stages a backup and merges it into the notifications that already exist. Merging
+// only ever adds state: a notification that is read, archived, starred, muted or tagged
+// stays that way, and a snooze is only replaced by a later one. Tags missing locally are
+// created. Entries for notifications that have not synced yet are merged as they arrive.
+// Tags, views and rules in the backup are created when no local one has the same name;
+// existing ones are left as they are.
+func (s *Service) Import(
+	ctx context.Context,
+	userID string,
+	backup *models.TriageBackup,
+) (*models.TriageImportResult, error) {
+	if backup.Version < 1 || backup.Version > models.TriageBackupVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, backup.Version)
+	}
+	entries, err := normalizeEntries(backup.Notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TriageImportResult{}
+	// Tags come first so tags created for notifications keep their backed-up color
+	if result.Tags, err = s.importTags(ctx, userID, backup.Tags); err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+	if len(backup.Views) > 0 || len(backup.Rules) > 0 {
+		var viewIDs map[string]string
+		if result.Views, viewIDs, err = s.importViews(ctx, userID, backup.Views); err != nil {
+			return nil, errors.Join(ErrFailedToImport, err)
+		}
+		if result.Rules, err = s.importRules(ctx, userID, backup.Rules, viewIDs); err != nil {
+			return nil, errors.Join(ErrFailedToImport, err)
+		}
+	}
+
+	tagIDs, err := s.resolveTags(ctx, userID, entries)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+
+	restores := make([]db.TriageRestore, 0, len(entries))
+	for _, entry := range entries {
+		restore := db.TriageRestore{
+			GithubID:     entry.GithubID,
+			IsRead:       entry.IsRead,
+			Archived:     entry.Archived,
+			Starred:      entry.Starred,
+			Muted:        entry.Muted,
+			SnoozedUntil: models.SQLNullTime(entry.SnoozedUntil),
+		}
+		for _, name := range entry.Tags {
+			if id, ok := tagIDs[name]; ok {
+				restore.TagIDs = append(restore.TagIDs, id)
+			}
+		}
+		restores = append(restores, restore)
+	}
+
+	if err := s.queries.StageTriageRestores(ctx, userID, restores); err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+	applied, err := s.queries.ApplyTriageRestores(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+	pending, err := s.queries.CountTriageRestores(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToImport, err)
+	}
+
+	result.Staged = len(restores)
+	result.Applied = applied
+	result.Pending = pending
+	return result, nil
+}
+
+// DiscardPending drops imported state that has not been merged yet
+func (s *Service) DiscardPending(ctx context.Context, userID string) (int64, error) {
+	deleted, err := s.queries.DeleteTriageRestores(ctx, userID)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToDiscardImport, err)
+	}
+	return deleted, nil
+}
+
+// normalizeEntries validates entries, trims tag names, combines entries repeated for the
+// same notification and drops entries with no state to restore
+func normalizeEntries(entries []models.TriageEntry) ([]models.TriageEntry, error) {
+	if len(entries) > MaxEntries {
+		return nil, fmt.Errorf("%w: at most %d notifications", ErrInvalidBackup, MaxEntries)
+	}
+
+	var normalized []models.TriageEntry
+	index := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		githubID := strings.TrimSpace(entry.GithubID)
+		if githubID == "" {
+			return nil, fmt.Errorf("%w: notification without githubId", ErrInvalidBackup)
+		}
+
+		var tags []string
+		for _, name := range entry.Tags {
+			if name = strings.TrimSpace(name); name != "" {
+				tags = append(tags, name)
+			}
+		}
+
+		i, seen := index[githubID]
+		if !seen {
+			entry.GithubID = githubID
+			entry.Tags = tags
+			index[githubID] = len(normalized)
+			normalized = append(normalized, entry)
+			continue
+		}
+
+		existing := &normalized[i]
+		existing.IsRead = existing.IsRead || entry.IsRead
+		existing.Archived = existing.Archived || entry.Archived
+		existing.Starred = existing.Starred || entry.Starred
+		existing.Muted = existing.Muted || entry.Muted
+		if entry.SnoozedUntil != nil &&
+			(existing.SnoozedUntil == nil || entry.SnoozedUntil.After(*existing.SnoozedUntil)) {
+			existing.SnoozedUntil = entry.SnoozedUntil
+		}
+		existing.Tags = append(existing.Tags, tags...)
+	}
+
+	kept := normalized[:0]
+	for _, entry := range normalized {
+		if entry.IsRead || entry.Archived || entry.Starred || entry.Muted ||
+			entry.SnoozedUntil != nil || len(entry.Tags) > 0 {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
+
+// resolveTags maps every tag name used by entries to a local tag ID. A name matches a tag
+// with the same name, or failing that the same slug; tags that match neither are created.
+// Names that can't be turned into a slug are skipped.
+func (s *Service) resolveTags(
+	ctx context.Context,
+	userID string,
+	entries []models.TriageEntry,
+) (map[string]string, error) {
+	tags, err := s.queries.ListAllTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(tags))
+	bySlug := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag.ID
+		bySlug[tag.Slug] = tag.ID
+	}
+
+	resolved := make(map[string]string)
+	for _, entry := range entries {
+		for _, name := range entry.Tags {
+			if _, ok := resolved[name]; ok {
+				continue
+			}
+			if id, ok := byName[name]; ok {
+				resolved[name] = id
+				continue
+			}
+			slug := models.Slugify(name)
+			if slug == "" {
+				continue
+			}
+			if id, ok := bySlug[slug]; ok {
+				resolved[name] = id
+				continue
+			}
+			tag, err := s.queries.UpsertTag(ctx, userID, db.UpsertTagParams{
+				Name: name,
+				Slug: slug,
+			})
+			if err != nil {
+				return nil, err
+			}
+			resolved[name] = tag.ID
+			bySlug[slug] = tag.ID
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,317 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package triage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func TestService_WriteExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	snoozedUntil := testNow.Add(24 * time.Hour)
+
+	// A full first page means another page is read, after the last GitHub ID seen
+	firstPage := make([]db.TriageState, ExportPageSize)
+	for i := range firstPage {
+		firstPage[i] = db.TriageState{GithubID: fmt.Sprintf("a%04d", i), Archived: true}
+	}
+	firstPage[0] = db.TriageState{GithubID: "a0000", IsRead: true, TagNames: []string{"urgent"}}
+	lastID := firstPage[len(firstPage)-1].GithubID
+	for range 2 {
+		gomock.InOrder(
+			mockStore.EXPECT().
+				ListTriageStatesAfter(gomock.Any(), "user-1", "", int64(ExportPageSize)).
+				Return(firstPage, nil),
+			mockStore.EXPECT().
+				ListTriageStatesAfter(gomock.Any(), "user-1", lastID, int64(ExportPageSize)).
+				Return([]db.TriageState{
+					{GithubID: "b1", SnoozedUntil: sql.NullTime{Time: snoozedUntil, Valid: true}},
+				}, nil),
+		)
+	}
+
+	mockStore.EXPECT().
+		ListAllTags(gomock.Any(), "user-1").
+		Return([]db.Tag{{
+			ID:    "tag-1",
+			Name:  "Urgent",
+			Slug:  "urgent",
+			Color: sql.NullString{String: "red", Valid: true},
+		}}, nil).
+		Times(2)
+	mockStore.EXPECT().
+		ListViews(gomock.Any(), "user-1").
+		Return([]db.View{{
+			ID:       "view-1",
+			Name:     "Reviews",
+			Slug:     "reviews",
+			Query:    sql.NullString{String: "reason:review_requested", Valid: true},
+			WIPLimit: sql.NullInt64{Int64: 5, Valid: true},
+		}}, nil).
+		Times(2)
+	mockStore.EXPECT().
+		ListRules(gomock.Any(), "user-1").
+		Return([]db.Rule{{
+			Name:    "Tag reviews",
+			ViewID:  sql.NullString{String: "view-1", Valid: true},
+			Enabled: true,
+			Actions: json.RawMessage(`{"skipInbox":true,"assignTags":["tag-1","tag-gone"]}`),
+		}}, nil).
+		Times(2)
+
+	service := NewService(mockStore, func() time.Time { return testNow.Add(500 * time.Millisecond) })
+	var out bytes.Buffer
+	summary, err := service.WriteExport(context.Background(), "user-1", time.Time{}, &out)
+	require.NoError(t, err)
+	require.Equal(t, testNow, summary.ExportedAt)
+	require.Equal(t, ExportPageSize+1, summary.Entries)
+	require.Equal(t, int64(out.Len()), summary.Size)
+
+	var backup models.TriageBackup
+	require.NoError(t, json.Unmarshal(out.Bytes(), &backup))
+	require.Equal(t, models.TriageBackupVersion, backup.Version)
+	require.Equal(t, testNow, backup.ExportedAt)
+	require.Len(t, backup.Notifications, ExportPageSize+1)
+	require.Equal(t,
+		models.TriageEntry{GithubID: "a0000", IsRead: true, Tags: []string{"urgent"}},
+		backup.Notifications[0])
+	require.Equal(t, models.TriageEntry{GithubID: "b1", SnoozedUntil: &snoozedUntil}, backup.Notifications[ExportPageSize])
+
+	// Rules name their view and tags by slug; tags that no longer exist are dropped
+	wipLimit := int64(5)
+	require.Equal(t, []models.TagBackup{{Name: "Urgent", Color: "red"}}, backup.Tags)
+	require.Equal(t, []models.ViewBackup{{
+		Name:     "Reviews",
+		Slug:     "reviews",
+		Query:    "reason:review_requested",
+		WIPLimit: &wipLimit,
+	}}, backup.Views)
+	require.Equal(t, []models.RuleBackup{{
+		Name:     "Tag reviews",
+		ViewSlug: "reviews",
+		Enabled:  true,
+		Actions:  models.RuleActions{SkipInbox: true, AddTagSlugs: []string{"urgent"}},
+	}}, backup.Rules)
+
+	// Writing again at the same time reproduces the backup exactly
+	again, err := service.WriteExport(context.Background(), "user-1", summary.ExportedAt, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, summary, again)
+}
+
+func TestService_Import(t *testing.T) {
+	later := testNow.Add(48 * time.Hour)
+	earlier := testNow.Add(24 * time.Hour)
+	wipLimit := int64(3)
+
+	tests := []struct {
+		name      string
+		backup    *models.TriageBackup
+		setupMock func(*mocks.MockStore)
+		expectErr error
+		expected  *models.TriageImportResult
+	}{
+		{
+			name: "merges repeated entries, resolves tags and applies",
+			backup: &models.TriageBackup{
+				Version: models.TriageBackupVersion,
+				Notifications: []models.TriageEntry{
+					{GithubID: "n1", IsRead: true, SnoozedUntil: &earlier, Tags: []string{"Urgent "}},
+					{GithubID: " n1", Starred: true, SnoozedUntil: &later, Tags: []string{"New tag"}},
+					{GithubID: "n2"},
+				},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListAllTags(gomock.Any(), "user-1").
+					Return([]db.Tag{{ID: "tag-urgent", Name: "Urgent", Slug: "urgent"}}, nil)
+				m.EXPECT().
+					UpsertTag(gomock.Any(), "user-1", db.UpsertTagParams{Name: "New tag", Slug: "new-tag"}).
+					Return(db.Tag{ID: "tag-new"}, nil)
+				m.EXPECT().
+					StageTriageRestores(gomock.Any(), "user-1", []db.TriageRestore{{
+						GithubID:     "n1",
+						IsRead:       true,
+						Starred:      true,
+						SnoozedUntil: sql.NullTime{Time: later, Valid: true},
+						TagIDs:       []string{"tag-urgent", "tag-new"},
+					}}).
+					Return(nil)
+				m.EXPECT().ApplyTriageRestores(gomock.Any(), "user-1").Return(int64(1), nil)
+				m.EXPECT().CountTriageRestores(gomock.Any(), "user-1").Return(int64(0), nil)
+			},
+			expected: &models.TriageImportResult{Staged: 1, Applied: 1, Pending: 0},
+		},
+		{
+			name: "creates missing tags, views and rules and skips existing ones",
+			backup: &models.TriageBackup{
+				Version: models.TriageBackupVersion,
+				Tags: []models.TagBackup{
+					{Name: "urgent"},
+					{Name: "Later", Color: "blue"},
+				},
+				Views: []models.ViewBackup{
+					{Name: "Reviews", Slug: "reviews", Query: "reason:review_requested", WIPLimit: &wipLimit},
+					{Name: "Inbox", Slug: "inbox", Query: "is:unread"},
+					{Name: "Mine", Slug: "my-stuff", Query: "is:unread"},
+				},
+				Rules: []models.RuleBackup{
+					{
+						Name:     "Tag reviews",
+						ViewSlug: "reviews",
+						Enabled:  true,
+						Actions: models.RuleActions{
+							AssignTags:  []string{"tag-elsewhere"},
+							AddTagSlugs: []string{"later"},
+						},
+					},
+					{Name: "Existing", Query: "is:unread"},
+					{Name: "Missing view", ViewSlug: "gone"},
+					{Name: "Bad priority", Query: "is:unread", Priority: 5000},
+					{Name: "Bad condition", Query: "is:unread", Condition: "comments > 3"},
+				},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListAllTags(gomock.Any(), "user-1").
+					Return([]db.Tag{{ID: "tag-urgent", Name: "Urgent", Slug: "urgent"}}, nil).
+					Times(2)
+				m.EXPECT().
+					UpsertTag(gomock.Any(), "user-1", db.UpsertTagParams{
+						Name:  "Later",
+						Slug:  "later",
+						Color: sql.NullString{String: "blue", Valid: true},
+					}).
+					Return(db.Tag{ID: "tag-later"}, nil)
+				m.EXPECT().
+					ListViews(gomock.Any(), "user-1").
+					Return([]db.View{{ID: "view-mine", Name: "Mine", Slug: "mine"}}, nil)
+				m.EXPECT().
+					CreateView(gomock.Any(), "user-1", db.CreateViewParams{
+						Name:      "Reviews",
+						Slug:      "reviews",
+						Query:     sql.NullString{String: "reason:review_requested", Valid: true},
+						IsDefault: false,
+					}).
+					Return(db.View{ID: "view-reviews"}, nil)
+				m.EXPECT().
+					UpdateViewWIPLimit(gomock.Any(), "user-1", db.UpdateViewWIPLimitParams{
+						ID:    "view-reviews",
+						Limit: sql.NullInt64{Int64: 3, Valid: true},
+					}).
+					Return(db.View{}, nil)
+				m.EXPECT().
+					ListRules(gomock.Any(), "user-1").
+					Return([]db.Rule{{Name: "Existing", DisplayOrder: 200}}, nil)
+				m.EXPECT().
+					CreateRule(gomock.Any(), "user-1", db.CreateRuleParams{
+						Name:         "Tag reviews",
+						ViewID:       sql.NullString{String: "view-reviews", Valid: true},
+						Enabled:      true,
+						Actions:      []byte(`{"skipInbox":false,"addTagSlugs":["later"]}`),
+						DisplayOrder: 300,
+					}).
+					Return(db.Rule{}, nil)
+				m.EXPECT().StageTriageRestores(gomock.Any(), "user-1", []db.TriageRestore{}).Return(nil)
+				m.EXPECT().ApplyTriageRestores(gomock.Any(), "user-1").Return(int64(0), nil)
+				m.EXPECT().CountTriageRestores(gomock.Any(), "user-1").Return(int64(0), nil)
+			},
+			expected: &models.TriageImportResult{
+				Tags:  models.TriageImportCounts{Created: 1, Skipped: 1},
+				Views: models.TriageImportCounts{Created: 1, Skipped: 2},
+				Rules: models.TriageImportCounts{Created: 1, Skipped: 4},
+			},
+		},
+		{
+			name:   "version 1 backups are still accepted",
+			backup: &models.TriageBackup{Version: 1},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListAllTags(gomock.Any(), "user-1").Return(nil, nil)
+				m.EXPECT().StageTriageRestores(gomock.Any(), "user-1", []db.TriageRestore{}).Return(nil)
+				m.EXPECT().ApplyTriageRestores(gomock.Any(), "user-1").Return(int64(0), nil)
+				m.EXPECT().CountTriageRestores(gomock.Any(), "user-1").Return(int64(0), nil)
+			},
+			expected: &models.TriageImportResult{},
+		},
+		{
+			name:      "unknown version is rejected",
+			backup:    &models.TriageBackup{Version: 99},
+			expectErr: ErrUnsupportedVersion,
+		},
+		{
+			name: "entry without github ID is rejected",
+			backup: &models.TriageBackup{
+				Version:       models.TriageBackupVersion,
+				Notifications: []models.TriageEntry{{IsRead: true}},
+			},
+			expectErr: ErrInvalidBackup,
+		},
+		{
+			name: "staging error is wrapped",
+			backup: &models.TriageBackup{
+				Version:       models.TriageBackupVersion,
+				Notifications: []models.TriageEntry{{GithubID: "n1", Archived: true}},
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListAllTags(gomock.Any(), "user-1").Return(nil, nil)
+				m.EXPECT().
+					StageTriageRestores(gomock.Any(), "user-1", gomock.Any()).
+					Return(errors.New("disk full"))
+			},
+			expectErr: ErrFailedToImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockStore)
+			}
+
+			result, err := NewService(mockStore, func() time.Time { return testNow }).Import(
+				context.Background(),
+				"user-1",
+				tt.backup,
+			)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
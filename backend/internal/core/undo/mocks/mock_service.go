@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/undo/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/undo/service.go -destination=internal/core/undo/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUndoService is a mock of UndoService interface.
+type MockUndoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUndoServiceMockRecorder is the mock recorder for MockUndoService.
+type MockUndoServiceMockRecorder struct {
+	mock *MockUndoService
+}
+
+// NewMockUndoService creates a new mock instance.
+func NewMockUndoService(ctrl *gomock.Controller) *MockUndoService {
+	mock := &MockUndoService{ctrl: ctrl}
+	mock.recorder = &MockUndoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndoService) EXPECT() *MockUndoServiceMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockUndoService) Capture(ctx context.Context, userID string, op models.BulkOperationType, githubIDs []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, userID, op, githubIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockUndoServiceMockRecorder) Capture(ctx, userID, op, githubIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockUndoService)(nil).Capture), ctx, userID, op, githubIDs)
+}
+
+// Undo mocks base method.
+func (m *MockUndoService) Undo(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", ctx, userID, id)
+	ret0, _ := ret[0].(models.BulkUndoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockUndoServiceMockRecorder) Undo(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockUndoService)(nil).Undo), ctx, userID, id)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package undo lets bulk operations be taken back for a short while: the state of every
+// notification a bulk operation changes is captured first, and undoing puts it back.
+package undo
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// UndoService is the interface for capturing and undoing bulk operations.
+type UndoService interface {
+	// Capture saves the current state of the notifications a bulk operation is about to
+	// change and returns an ID that undoes it.
+	Capture(
+		ctx context.Context,
+		userID string,
+		op models.BulkOperationType,
+		githubIDs []string,
+	) (int64, error)
+	// Undo puts back what a captured bulk operation changed, within Window of capturing it.
+	Undo(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error)
+}
+
+// Service captures and undoes bulk operations
+type Service struct {
+	queries db.Store
+	now     func() time.Time
+}
+
+// NewService constructs a Service backed by the store
+func NewService(queries db.Store, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		now:     now,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package undo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrOperationNotFound = errors.New("bulk operation not found")
+	ErrOperationExpired  = errors.New("bulk operation can no longer be undone")
+	ErrAlreadyUndone     = errors.New("bulk operation was already undone")
+	ErrFailedToCapture   = errors.New("failed to capture bulk operation")
+	ErrFailedToUndo      = errors.New("failed to undo bulk operation")
+)
+
+// Window is how long after a bulk operation it can be undone
+const Window = 10 * time.Minute
+
+// Capture saves the current state of the notifications a bulk operation is about to change
+// and returns an ID that undoes it. Operations older than Window are pruned as it goes.
+func (s *Service) Capture(
+	ctx context.Context,
+	userID string,
+	op models.BulkOperationType,
+	githubIDs []string,
+) (int64, error) {
+	now := s.now().UTC()
+	id, err := s.queries.CaptureBulkOperation(ctx, userID, db.CaptureBulkOperationParams{
+		Operation:   string(op),
+		GithubIDs:   githubIDs,
+		CreatedAt:   now,
+		PruneBefore: now.Add(-Window),
+	})
+	if err != nil {
+		return 0, errors.Join(ErrFailedToCapture, err)
+	}
+	return id, nil
+}
+
+// Undo puts back what a captured bulk operation changed. Only the fields the operation
+// changes are restored, so anything else done to the notifications since is kept.
+func (s *Service) Undo(ctx context.Context, userID string, id int64) (models.BulkUndoResult, error) {
+	operation, err := s.queries.GetBulkOperation(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.BulkUndoResult{}, ErrOperationNotFound
+		}
+		return models.BulkUndoResult{}, errors.Join(ErrFailedToUndo, err)
+	}
+	if operation.UndoneAt.Valid {
+		return models.BulkUndoResult{}, ErrAlreadyUndone
+	}
+	now := s.now().UTC()
+	if now.Sub(operation.CreatedAt) > Window {
+		return models.BulkUndoResult{}, ErrOperationExpired
+	}
+
+	githubIDs, err := s.queries.UndoBulkOperation(ctx, userID, id, now)
+	if err != nil {
+		// It existed a moment ago, so another request undid it first
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.BulkUndoResult{}, ErrAlreadyUndone
+		}
+		return models.BulkUndoResult{}, errors.Join(ErrFailedToUndo, err)
+	}
+	if githubIDs == nil {
+		githubIDs = []string{}
+	}
+	return models.BulkUndoResult{
+		Operation: models.BulkOperationType(operation.Operation),
+		GithubIDs: githubIDs,
+	}, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package undo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_Capture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+	mockStore := mocks.NewMockStore(ctrl)
+	service := NewService(mockStore, func() time.Time { return now })
+
+	mockStore.EXPECT().CaptureBulkOperation(gomock.Any(), "test-user-id", db.CaptureBulkOperationParams{
+		Operation:   "archive",
+		GithubIDs:   []string{"thread-1", "thread-2"},
+		CreatedAt:   now,
+		PruneBefore: now.Add(-Window),
+	}).Return(int64(12), nil)
+
+	id, err := service.Capture(context.Background(), "test-user-id", models.BulkOpArchive,
+		[]string{"thread-1", "thread-2"})
+	require.NoError(t, err)
+	require.Equal(t, int64(12), id)
+
+	mockStore.EXPECT().CaptureBulkOperation(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(0), errors.New("database is locked"))
+	_, err = service.Capture(context.Background(), "test-user-id", models.BulkOpArchive, []string{"thread-1"})
+	require.ErrorIs(t, err, ErrFailedToCapture)
+}
+
+func TestService_Undo(t *testing.T) {
+	now := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expected  models.BulkUndoResult
+		expectErr error
+	}{
+		{
+			name: "restores the notifications",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(db.BulkOperation{ID: 12, Operation: "mark-read", CreatedAt: now.Add(-time.Minute)}, nil)
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12), now).
+					Return([]string{"thread-1", "thread-2"}, nil)
+			},
+			expected: models.BulkUndoResult{
+				Operation: models.BulkOpMarkRead,
+				GithubIDs: []string{"thread-1", "thread-2"},
+			},
+		},
+		{
+			name: "unknown operation",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(db.BulkOperation{}, sql.ErrNoRows)
+			},
+			expectErr: ErrOperationNotFound,
+		},
+		{
+			name: "outside the window",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(db.BulkOperation{ID: 12, Operation: "archive", CreatedAt: now.Add(-Window - time.Second)}, nil)
+			},
+			expectErr: ErrOperationExpired,
+		},
+		{
+			name: "already undone",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(db.BulkOperation{
+						ID:        12,
+						Operation: "archive",
+						CreatedAt: now.Add(-time.Minute),
+						UndoneAt:  sql.NullTime{Time: now.Add(-time.Second), Valid: true},
+					}, nil)
+			},
+			expectErr: ErrAlreadyUndone,
+		},
+		{
+			name: "undone by another request first",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetBulkOperation(gomock.Any(), "test-user-id", int64(12)).
+					Return(db.BulkOperation{ID: 12, Operation: "archive", CreatedAt: now.Add(-time.Minute)}, nil)
+				m.EXPECT().UndoBulkOperation(gomock.Any(), "test-user-id", int64(12), now).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectErr: ErrAlreadyUndone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+			service := NewService(mockStore, func() time.Time { return now })
+
+			result, err := service.Undo(context.Background(), "test-user-id", 12)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -21,20 +21,101 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/query"
 )
 
+// DefaultCountsTTL is how long cached counts are served while no notification changes.
+// Counts for queries with relative dates or snoozes drift as time passes, so they're
+// recomputed every so often even when nothing was written.
+const DefaultCountsTTL = 30 * time.Second
+
 // Error definitions
 var (
 	ErrFailedToBuildQuery        = errors.New("failed to build query")
 	ErrFailedToListNotifications = errors.New("failed to list notifications")
+	ErrFailedToGetRevision       = errors.New("failed to get notification revision")
 )
 
+// cachedCounts are a user's counts computed at one notification revision, keyed by query
+type cachedCounts struct {
+	revision int64
+	expires  time.Time
+	counts   map[string]int64
+}
+
+// countQuery counts the notifications matching a query. A count cached at the same
+// notification revision is served while fresh, since nothing it counts has changed.
+func (s *Service) countQuery(
+	ctx context.Context,
+	userID string,
+	revision int64,
+	queryStr string,
+) (int64, error) {
+	if count, ok := s.cachedCount(userID, revision, queryStr); ok {
+		return count, nil
+	}
+
+	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToListNotifications, err)
+	}
+
+	s.cacheCount(userID, revision, queryStr, result.Total)
+	return result.Total, nil
+}
+
+// cachedCount returns a count cached at the given revision, if it's still fresh
+func (s *Service) cachedCount(userID string, revision int64, queryStr string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.counts[userID]
+	if !ok || cached.revision != revision || !s.now().Before(cached.expires) {
+		return 0, false
+	}
+	count, ok := cached.counts[queryStr]
+	return count, ok
+}
+
+// cacheCount stores a count, replacing the user's cached counts when they were computed at
+// another revision or have gone stale
+func (s *Service) cacheCount(userID string, revision int64, queryStr string, count int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	cached, ok := s.counts[userID]
+	if !ok || cached.revision != revision || !now.Before(cached.expires) {
+		cached = cachedCounts{
+			revision: revision,
+			expires:  now.Add(s.countsTTL),
+			counts:   make(map[string]int64),
+		}
+	}
+	cached.counts[queryStr] = count
+	s.counts[userID] = cached
+}
+
+// notificationRevision returns the user's notification revision, which cached counts are
+// checked against
+func (s *Service) notificationRevision(ctx context.Context, userID string) (int64, error) {
+	revision, err := s.queries.GetNotificationRevision(ctx, userID)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToGetRevision, err)
+	}
+	return revision, nil
+}
+
 // calculateViewUnreadCount calculates the count of "new" (unread) notifications for a view with given query.
 func (s *Service) calculateViewUnreadCount(
 	ctx context.Context,
 	userID string,
+	revision int64,
 	viewQuery sql.NullString,
 ) (int64, error) {
 	queryStr := ""
@@ -51,110 +132,75 @@ func (s *Service) calculateViewUnreadCount(
 		queryStr = fmt.Sprintf("(%s) AND is:unread", queryStr)
 	}
 
-	// Use unified BuildQuery which applies business rules based on query content
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
+	return s.countQuery(ctx, userID, revision, queryStr)
+}
 
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
+// WIPQuery returns the query for the notifications counted against a view's WIP limit:
+// everything the view shows, read or not, that hasn't been archived or snoozed
+func WIPQuery(viewQuery string) string {
+	return fmt.Sprintf("(%s) -in:archive -in:snoozed", viewQuery)
+}
 
-	return result.Total, nil
+// calculateViewCount counts the notifications held against a view's WIP limit
+func (s *Service) calculateViewCount(
+	ctx context.Context,
+	userID string,
+	revision int64,
+	viewQuery sql.NullString,
+) (int64, error) {
+	return s.countQuery(ctx, userID, revision, WIPQuery(viewQuery.String))
 }
 
 // calculateInboxUnreadCount calculates the count of "new" (unread) notifications in the inbox.
-func (s *Service) calculateInboxUnreadCount(ctx context.Context, userID string) (int64, error) {
+func (s *Service) calculateInboxUnreadCount(ctx context.Context, userID string, revision int64) (int64, error) {
 	// Inbox uses explicit in:inbox query, badge count shows only unread items
 	queryStr := "in:inbox is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
-
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
-
-	return result.Total, nil
+	return s.countQuery(ctx, userID, revision, queryStr)
 }
 
 // calculateEverythingUnreadCount calculates the count of "new" (unread) notifications in everything view.
 func (s *Service) calculateEverythingUnreadCount(
 	ctx context.Context,
 	userID string,
+	revision int64,
 ) (int64, error) {
 	// Everything view shows all notifications, badge shows count of unread items including archived/muted/snoozed
 	queryStr := "is:unread in:anywhere"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
-
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
-
-	return result.Total, nil
+	return s.countQuery(ctx, userID, revision, queryStr)
 }
 
 // calculateArchiveUnreadCount calculates the count of "new" (unread) notifications in the archive view.
-func (s *Service) calculateArchiveUnreadCount(ctx context.Context, userID string) (int64, error) {
+func (s *Service) calculateArchiveUnreadCount(ctx context.Context, userID string, revision int64) (int64, error) {
 	// Archive view shows all archived notifications
 	queryStr := "in:archive is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
-
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
-
-	return result.Total, nil
+	return s.countQuery(ctx, userID, revision, queryStr)
 }
 
 // calculateSnoozedUnreadCount calculates the count of "new" (unread) notifications in the snoozed view.
-func (s *Service) calculateSnoozedUnreadCount(ctx context.Context, userID string) (int64, error) {
+func (s *Service) calculateSnoozedUnreadCount(ctx context.Context, userID string, revision int64) (int64, error) {
 	// Snoozed view shows all snoozed notifications
 	queryStr := "in:snoozed is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
-
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
-
-	return result.Total, nil
+	return s.countQuery(ctx, userID, revision, queryStr)
 }
 
 // calculateStarredUnreadCount calculates the count of "new" (unread) notifications in the starred view.
-func (s *Service) calculateStarredUnreadCount(ctx context.Context, userID string) (int64, error) {
+func (s *Service) calculateStarredUnreadCount(ctx context.Context, userID string, revision int64) (int64, error) {
 	// Starred view shows all starred notifications including archived/snoozed
 	queryStr := "is:starred is:unread in:anywhere"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToBuildQuery, err)
-	}
-
-	result, err := s.queries.ListNotificationsFromQuery(ctx, userID, dbQuery)
-	if err != nil {
-		return 0, errors.Join(ErrFailedToListNotifications, err)
-	}
+	return s.countQuery(ctx, userID, revision, queryStr)
+}
 
-	return result.Total, nil
+// calculateRecentlyTaggedUnreadCount calculates the count of "new" (unread) notifications in
+// the recently tagged view.
+func (s *Service) calculateRecentlyTaggedUnreadCount(
+	ctx context.Context,
+	userID string,
+	revision int64,
+) (int64, error) {
+	return s.countQuery(ctx, userID, revision, fmt.Sprintf("(%s) is:unread", recentlyTaggedQuery))
 }
@@ -0,0 +1,182 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// View group errors
+var (
+	ErrViewGroupNotFound          = errors.New("view group not found")
+	ErrViewGroupNameAlreadyExists = errors.New("a view group with that name already exists")
+	ErrGroupNameRequired          = errors.New("group name is required")
+	ErrGroupIDsRequired           = errors.New("groupIDs cannot be empty")
+	ErrFailedToLoadViewGroups     = errors.New("failed to load view groups")
+	ErrFailedToCreateViewGroup    = errors.New("failed to create view group")
+	ErrFailedToUpdateViewGroup    = errors.New("failed to update view group")
+	ErrFailedToDeleteViewGroup    = errors.New("failed to delete view group")
+	ErrFailedToReorderViewGroups  = errors.New("failed to reorder view groups")
+)
+
+// ListViewGroups returns the user's view groups in display order
+func (s *Service) ListViewGroups(ctx context.Context, userID string) ([]models.ViewGroup, error) {
+	groups, err := s.queries.ListViewGroups(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadViewGroups, err)
+	}
+	result := make([]models.ViewGroup, len(groups))
+	for i, group := range groups {
+		result[i] = models.ViewGroupFromDB(group)
+	}
+	return result, nil
+}
+
+// CreateViewGroup creates a view group after the existing ones
+func (s *Service) CreateViewGroup(
+	ctx context.Context,
+	userID, name string,
+) (models.ViewGroup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.ViewGroup{}, ErrGroupNameRequired
+	}
+
+	group, err := s.queries.CreateViewGroup(ctx, userID, name)
+	if err != nil {
+		if models.IsUniqueViolation(err) {
+			return models.ViewGroup{}, errors.Join(ErrViewGroupNameAlreadyExists, err)
+		}
+		return models.ViewGroup{}, errors.Join(ErrFailedToCreateViewGroup, err)
+	}
+	return models.ViewGroupFromDB(group), nil
+}
+
+// UpdateViewGroup renames a view group or sets whether it's collapsed in the sidebar
+func (s *Service) UpdateViewGroup(
+	ctx context.Context,
+	userID, groupID string,
+	name *string,
+	collapsed *bool,
+) (models.ViewGroup, error) {
+	params := db.UpdateViewGroupParams{ID: groupID}
+	if name != nil {
+		nameTrimmed := strings.TrimSpace(*name)
+		if nameTrimmed == "" {
+			return models.ViewGroup{}, ErrGroupNameRequired
+		}
+		params.Name = sql.NullString{String: nameTrimmed, Valid: true}
+	}
+	if collapsed != nil {
+		params.Collapsed = sql.NullBool{Bool: *collapsed, Valid: true}
+	}
+
+	group, err := s.queries.UpdateViewGroup(ctx, userID, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ViewGroup{}, errors.Join(ErrViewGroupNotFound, err)
+		}
+		if models.IsUniqueViolation(err) {
+			return models.ViewGroup{}, errors.Join(ErrViewGroupNameAlreadyExists, err)
+		}
+		return models.ViewGroup{}, errors.Join(ErrFailedToUpdateViewGroup, err)
+	}
+	return models.ViewGroupFromDB(group), nil
+}
+
+// DeleteViewGroup deletes a view group. Its views are kept and become ungrouped.
+func (s *Service) DeleteViewGroup(ctx context.Context, userID, groupID string) error {
+	deleted, err := s.queries.DeleteViewGroup(ctx, userID, groupID)
+	if err != nil {
+		return errors.Join(ErrFailedToDeleteViewGroup, err)
+	}
+	if deleted == 0 {
+		return ErrViewGroupNotFound
+	}
+	return nil
+}
+
+// ReorderViewGroups updates the display order of view groups
+func (s *Service) ReorderViewGroups(
+	ctx context.Context,
+	userID string,
+	groupIDs []string,
+) ([]models.ViewGroup, error) {
+	if len(groupIDs) == 0 {
+		return nil, ErrGroupIDsRequired
+	}
+
+	for _, id := range groupIDs {
+		if _, err := s.queries.GetViewGroup(ctx, userID, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.Join(ErrViewGroupNotFound, err)
+			}
+			return nil, errors.Join(ErrFailedToReorderViewGroups, err)
+		}
+	}
+
+	// Increments of 100 leave room for future insertions, as with views
+	for i, groupID := range groupIDs {
+		err := s.queries.UpdateViewGroupOrder(ctx, userID, db.UpdateViewGroupOrderParams{
+			ID:           groupID,
+			DisplayOrder: int32((i + 1) * 100),
+		})
+		if err != nil {
+			return nil, errors.Join(ErrFailedToReorderViewGroups, err)
+		}
+	}
+
+	return s.ListViewGroups(ctx, userID)
+}
+
+// SetViewGroup moves a view into a group, or out of its group when groupID is nil or empty
+func (s *Service) SetViewGroup(
+	ctx context.Context,
+	userID, viewID string,
+	groupID *string,
+) (models.View, error) {
+	params := db.SetViewGroupParams{ViewID: viewID}
+	if groupID != nil && *groupID != "" {
+		if _, err := s.queries.GetViewGroup(ctx, userID, *groupID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return models.View{}, errors.Join(ErrViewGroupNotFound, err)
+			}
+			return models.View{}, errors.Join(ErrFailedToUpdateView, err)
+		}
+		params.GroupID = sql.NullString{String: *groupID, Valid: true}
+	}
+
+	view, err := s.queries.SetViewGroup(ctx, userID, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.View{}, errors.Join(ErrViewNotFound, err)
+		}
+		return models.View{}, errors.Join(ErrFailedToUpdateView, err)
+	}
+
+	resp := models.ViewFromDB(view)
+	// Don't fail the move if count calculation fails
+	if unreadCount, countErr := s.singleViewUnreadCount(ctx, userID, view.Query); countErr == nil {
+		resp.UnreadCount = unreadCount
+	}
+	return resp, nil
+}
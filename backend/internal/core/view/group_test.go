@@ -0,0 +1,178 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const groupTestUserID = "test-user-id"
+
+func TestService_CreateViewGroup(t *testing.T) {
+	t.Run("trims the name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			CreateViewGroup(gomock.Any(), groupTestUserID, "Work").
+			Return(db.ViewGroup{ID: "g1", Name: "Work", DisplayOrder: 100}, nil)
+
+		group, err := NewService(mockQuerier).CreateViewGroup(context.Background(), groupTestUserID, "  Work ")
+		require.NoError(t, err)
+		require.Equal(t, models.ViewGroup{ID: "g1", Name: "Work", DisplayOrder: 100}, group)
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		_, err := service.CreateViewGroup(context.Background(), groupTestUserID, "   ")
+		require.ErrorIs(t, err, ErrGroupNameRequired)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			CreateViewGroup(gomock.Any(), groupTestUserID, "Work").
+			Return(db.ViewGroup{}, errors.New("UNIQUE constraint failed: view_groups.user_id, view_groups.name"))
+
+		_, err := NewService(mockQuerier).CreateViewGroup(context.Background(), groupTestUserID, "Work")
+		require.ErrorIs(t, err, ErrViewGroupNameAlreadyExists)
+	})
+}
+
+func TestService_UpdateViewGroup(t *testing.T) {
+	t.Run("collapses the group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			UpdateViewGroup(gomock.Any(), groupTestUserID, db.UpdateViewGroupParams{
+				ID:        "g1",
+				Collapsed: sql.NullBool{Bool: true, Valid: true},
+			}).
+			Return(db.ViewGroup{ID: "g1", Name: "Work", Collapsed: true}, nil)
+
+		collapsed := true
+		group, err := NewService(mockQuerier).
+			UpdateViewGroup(context.Background(), groupTestUserID, "g1", nil, &collapsed)
+		require.NoError(t, err)
+		require.True(t, group.Collapsed)
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			UpdateViewGroup(gomock.Any(), groupTestUserID, gomock.Any()).
+			Return(db.ViewGroup{}, sql.ErrNoRows)
+
+		name := "Home"
+		_, err := NewService(mockQuerier).
+			UpdateViewGroup(context.Background(), groupTestUserID, "missing", &name, nil)
+		require.ErrorIs(t, err, ErrViewGroupNotFound)
+	})
+}
+
+func TestService_DeleteViewGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().DeleteViewGroup(gomock.Any(), groupTestUserID, "g1").Return(int64(1), nil)
+	mockQuerier.EXPECT().DeleteViewGroup(gomock.Any(), groupTestUserID, "missing").Return(int64(0), nil)
+	service := NewService(mockQuerier)
+
+	require.NoError(t, service.DeleteViewGroup(context.Background(), groupTestUserID, "g1"))
+	require.ErrorIs(t,
+		service.DeleteViewGroup(context.Background(), groupTestUserID, "missing"),
+		ErrViewGroupNotFound)
+}
+
+func TestService_ReorderViewGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	for _, id := range []string{"g2", "g1"} {
+		mockQuerier.EXPECT().GetViewGroup(gomock.Any(), groupTestUserID, id).Return(db.ViewGroup{ID: id}, nil)
+	}
+	gomock.InOrder(
+		mockQuerier.EXPECT().UpdateViewGroupOrder(gomock.Any(), groupTestUserID,
+			db.UpdateViewGroupOrderParams{ID: "g2", DisplayOrder: 100}),
+		mockQuerier.EXPECT().UpdateViewGroupOrder(gomock.Any(), groupTestUserID,
+			db.UpdateViewGroupOrderParams{ID: "g1", DisplayOrder: 200}),
+	)
+	mockQuerier.EXPECT().ListViewGroups(gomock.Any(), groupTestUserID).Return([]db.ViewGroup{
+		{ID: "g2", DisplayOrder: 100},
+		{ID: "g1", DisplayOrder: 200},
+	}, nil)
+
+	groups, err := NewService(mockQuerier).
+		ReorderViewGroups(context.Background(), groupTestUserID, []string{"g2", "g1"})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, "g2", groups[0].ID)
+
+	_, err = NewService(mockQuerier).ReorderViewGroups(context.Background(), groupTestUserID, nil)
+	require.ErrorIs(t, err, ErrGroupIDsRequired)
+}
+
+func TestService_SetViewGroup(t *testing.T) {
+	t.Run("missing group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			GetViewGroup(gomock.Any(), groupTestUserID, "missing").
+			Return(db.ViewGroup{}, sql.ErrNoRows)
+
+		groupID := "missing"
+		_, err := NewService(mockQuerier).
+			SetViewGroup(context.Background(), groupTestUserID, "v1", &groupID)
+		require.ErrorIs(t, err, ErrViewGroupNotFound)
+	})
+
+	t.Run("ungroups the view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			SetViewGroup(gomock.Any(), groupTestUserID, db.SetViewGroupParams{ViewID: "v1"}).
+			Return(db.View{ID: "v1"}, nil)
+		mockQuerier.EXPECT().
+			GetNotificationRevision(gomock.Any(), groupTestUserID).
+			Return(int64(0), errors.New("database error"))
+
+		view, err := NewService(mockQuerier).SetViewGroup(context.Background(), groupTestUserID, "v1", nil)
+		require.NoError(t, err)
+		require.Nil(t, view.GroupID)
+	})
+
+	t.Run("missing view", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			SetViewGroup(gomock.Any(), groupTestUserID, gomock.Any()).
+			Return(db.View{}, sql.ErrNoRows)
+
+		_, err := NewService(mockQuerier).SetViewGroup(context.Background(), groupTestUserID, "missing", nil)
+		require.ErrorIs(t, err, ErrViewNotFound)
+	})
+}
@@ -57,11 +57,26 @@ func (mr *MockViewServiceMockRecorder) CreateView(ctx, userID, name, description
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockViewService)(nil).CreateView), ctx, userID, name, description, icon, isDefault, queryStr)
 }
 
+// CreateViewGroup mocks base method.
+func (m *MockViewService) CreateViewGroup(ctx context.Context, userID, name string) (models.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateViewGroup", ctx, userID, name)
+	ret0, _ := ret[0].(models.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateViewGroup indicates an expected call of CreateViewGroup.
+func (mr *MockViewServiceMockRecorder) CreateViewGroup(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateViewGroup", reflect.TypeOf((*MockViewService)(nil).CreateViewGroup), ctx, userID, name)
+}
+
 // DeleteView mocks base method.
-func (m *MockViewService) DeleteView(ctx context.Context, userID, viewID string, force bool) (int, error) {
+func (m *MockViewService) DeleteView(ctx context.Context, userID, viewID string, force bool) ([]models.Rule, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteView", ctx, userID, viewID, force)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].([]models.Rule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -72,6 +87,20 @@ func (mr *MockViewServiceMockRecorder) DeleteView(ctx, userID, viewID, force any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockViewService)(nil).DeleteView), ctx, userID, viewID, force)
 }
 
+// DeleteViewGroup mocks base method.
+func (m *MockViewService) DeleteViewGroup(ctx context.Context, userID, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewGroup", ctx, userID, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteViewGroup indicates an expected call of DeleteViewGroup.
+func (mr *MockViewServiceMockRecorder) DeleteViewGroup(ctx, userID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewGroup", reflect.TypeOf((*MockViewService)(nil).DeleteViewGroup), ctx, userID, groupID)
+}
+
 // GetView mocks base method.
 func (m *MockViewService) GetView(ctx context.Context, userID, id string) (db.View, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +116,21 @@ func (mr *MockViewServiceMockRecorder) GetView(ctx, userID, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockViewService)(nil).GetView), ctx, userID, id)
 }
 
+// ListViewGroups mocks base method.
+func (m *MockViewService) ListViewGroups(ctx context.Context, userID string) ([]models.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewGroups", ctx, userID)
+	ret0, _ := ret[0].([]models.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewGroups indicates an expected call of ListViewGroups.
+func (mr *MockViewServiceMockRecorder) ListViewGroups(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewGroups", reflect.TypeOf((*MockViewService)(nil).ListViewGroups), ctx, userID)
+}
+
 // ListViewsWithCounts mocks base method.
 func (m *MockViewService) ListViewsWithCounts(ctx context.Context, userID string) ([]models.View, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +146,21 @@ func (mr *MockViewServiceMockRecorder) ListViewsWithCounts(ctx, userID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewsWithCounts", reflect.TypeOf((*MockViewService)(nil).ListViewsWithCounts), ctx, userID)
 }
 
+// ReorderViewGroups mocks base method.
+func (m *MockViewService) ReorderViewGroups(ctx context.Context, userID string, groupIDs []string) ([]models.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderViewGroups", ctx, userID, groupIDs)
+	ret0, _ := ret[0].([]models.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderViewGroups indicates an expected call of ReorderViewGroups.
+func (mr *MockViewServiceMockRecorder) ReorderViewGroups(ctx, userID, groupIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderViewGroups", reflect.TypeOf((*MockViewService)(nil).ReorderViewGroups), ctx, userID, groupIDs)
+}
+
 // ReorderViews mocks base method.
 func (m *MockViewService) ReorderViews(ctx context.Context, userID string, viewIDs []string) ([]models.View, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +176,51 @@ func (mr *MockViewServiceMockRecorder) ReorderViews(ctx, userID, viewIDs any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderViews", reflect.TypeOf((*MockViewService)(nil).ReorderViews), ctx, userID, viewIDs)
 }
 
+// ResolveView mocks base method.
+func (m *MockViewService) ResolveView(ctx context.Context, userID, viewID string) (models.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveView", ctx, userID, viewID)
+	ret0, _ := ret[0].(models.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveView indicates an expected call of ResolveView.
+func (mr *MockViewServiceMockRecorder) ResolveView(ctx, userID, viewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveView", reflect.TypeOf((*MockViewService)(nil).ResolveView), ctx, userID, viewID)
+}
+
+// SetViewGroup mocks base method.
+func (m *MockViewService) SetViewGroup(ctx context.Context, userID, viewID string, groupID *string) (models.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetViewGroup", ctx, userID, viewID, groupID)
+	ret0, _ := ret[0].(models.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetViewGroup indicates an expected call of SetViewGroup.
+func (mr *MockViewServiceMockRecorder) SetViewGroup(ctx, userID, viewID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetViewGroup", reflect.TypeOf((*MockViewService)(nil).SetViewGroup), ctx, userID, viewID, groupID)
+}
+
+// SetWIPLimit mocks base method.
+func (m *MockViewService) SetWIPLimit(ctx context.Context, userID, viewID string, limit *int64, autoSnooze bool) (models.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWIPLimit", ctx, userID, viewID, limit, autoSnooze)
+	ret0, _ := ret[0].(models.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWIPLimit indicates an expected call of SetWIPLimit.
+func (mr *MockViewServiceMockRecorder) SetWIPLimit(ctx, userID, viewID, limit, autoSnooze any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWIPLimit", reflect.TypeOf((*MockViewService)(nil).SetWIPLimit), ctx, userID, viewID, limit, autoSnooze)
+}
+
 // UpdateView mocks base method.
 func (m *MockViewService) UpdateView(ctx context.Context, userID, viewID string, name, description, icon *string, isDefault *bool, queryStr *string) (models.View, error) {
 	m.ctrl.T.Helper()
@@ -131,3 +235,33 @@ func (mr *MockViewServiceMockRecorder) UpdateView(ctx, userID, viewID, name, des
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockViewService)(nil).UpdateView), ctx, userID, viewID, name, description, icon, isDefault, queryStr)
 }
+
+// UpdateViewGroup mocks base method.
+func (m *MockViewService) UpdateViewGroup(ctx context.Context, userID, groupID string, name *string, collapsed *bool) (models.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateViewGroup", ctx, userID, groupID, name, collapsed)
+	ret0, _ := ret[0].(models.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateViewGroup indicates an expected call of UpdateViewGroup.
+func (mr *MockViewServiceMockRecorder) UpdateViewGroup(ctx, userID, groupID, name, collapsed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewGroup", reflect.TypeOf((*MockViewService)(nil).UpdateViewGroup), ctx, userID, groupID, name, collapsed)
+}
+
+// ViewCounts mocks base method.
+func (m *MockViewService) ViewCounts(ctx context.Context, userID string) ([]models.ViewCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewCounts", ctx, userID)
+	ret0, _ := ret[0].([]models.ViewCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewCounts indicates an expected call of ViewCounts.
+func (mr *MockViewServiceMockRecorder) ViewCounts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCounts", reflect.TypeOf((*MockViewService)(nil).ViewCounts), ctx, userID)
+}
@@ -17,19 +17,22 @@ package view
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 var reservedSlugs = map[string]struct{}{
-	"inbox":          {},
-	"everything":     {},
-	"done":           {},
-	"archive":        {},
-	"snoozed":        {},
-	"starred":        {},
-	"search_results": {},
+	"inbox":           {},
+	"everything":      {},
+	"done":            {},
+	"archive":         {},
+	"snoozed":         {},
+	"starred":         {},
+	"recently-tagged": {},
+	"search_results":  {},
 }
 
 // ViewService is the interface for the view service.
@@ -37,7 +40,11 @@ var reservedSlugs = map[string]struct{}{
 
 type ViewService interface {
 	ListViewsWithCounts(ctx context.Context, userID string) ([]models.View, error)
+	// ViewCounts returns every view's unread count, served from cache while no
+	// notification has changed.
+	ViewCounts(ctx context.Context, userID string) ([]models.ViewCount, error)
 	GetView(ctx context.Context, userID string, id string) (db.View, error)
+	ResolveView(ctx context.Context, userID, viewID string) (models.View, error)
 	CreateView(
 		ctx context.Context,
 		userID, name string,
@@ -53,24 +60,58 @@ type ViewService interface {
 		isDefault *bool,
 		queryStr *string,
 	) (models.View, error)
+	// SetWIPLimit sets or, with a nil limit, clears how many notifications a view should hold
+	SetWIPLimit(
+		ctx context.Context,
+		userID, viewID string,
+		limit *int64,
+		autoSnooze bool,
+	) (models.View, error)
 	DeleteView(
 		ctx context.Context,
 		userID string,
 		viewID string,
 		force bool,
-	) (linkedRuleCount int, err error)
+	) (dependentRules []models.Rule, err error)
 	ReorderViews(ctx context.Context, userID string, viewIDs []string) ([]models.View, error)
+	// SetViewGroup moves a view into a group, or out of its group when groupID is nil
+	SetViewGroup(ctx context.Context, userID, viewID string, groupID *string) (models.View, error)
+
+	ListViewGroups(ctx context.Context, userID string) ([]models.ViewGroup, error)
+	CreateViewGroup(ctx context.Context, userID, name string) (models.ViewGroup, error)
+	UpdateViewGroup(
+		ctx context.Context,
+		userID, groupID string,
+		name *string,
+		collapsed *bool,
+	) (models.ViewGroup, error)
+	DeleteViewGroup(ctx context.Context, userID, groupID string) error
+	ReorderViewGroups(ctx context.Context, userID string, groupIDs []string) ([]models.ViewGroup, error)
 }
 
 // Service implements the ViewService interface, providing
 // business logic for view operations.
 type Service struct {
-	queries db.Store
+	queries   db.Store
+	now       func() time.Time
+	countsTTL time.Duration
+
+	mu     sync.Mutex
+	counts map[string]cachedCounts
 }
 
 // NewService constructs a Service backed by the provided queries
 func NewService(queries db.Store) *Service {
 	return &Service{
-		queries: queries,
+		queries:   queries,
+		now:       time.Now,
+		countsTTL: DefaultCountsTTL,
+		counts:    make(map[string]cachedCounts),
 	}
 }
+
+// WithCountsTTL sets how long counts are cached while no notification changes
+func (s *Service) WithCountsTTL(ttl time.Duration) *Service {
+	s.countsTTL = ttl
+	return s
+}
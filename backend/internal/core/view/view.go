@@ -29,24 +29,25 @@ import (
 
 // Error definitions
 var (
-	ErrFailedToGetView                  = errors.New("failed to get view")
-	ErrFailedToLoadViews                = errors.New("failed to load views")
-	ErrFailedToCalculateViewCounts      = errors.New("failed to calculate view counts")
-	ErrFailedToCalculateInboxCount      = errors.New("failed to calculate inbox count")
-	ErrFailedToCalculateEverythingCount = errors.New("failed to calculate everything count")
-	ErrFailedToCalculateArchiveCount    = errors.New("failed to calculate archive count")
-	ErrFailedToCalculateSnoozedCount    = errors.New("failed to calculate snoozed count")
-	ErrFailedToCalculateStarredCount    = errors.New("failed to calculate starred count")
-	ErrFailedToCreateView               = errors.New("failed to create view")
-	ErrFailedToUpdateView               = errors.New("failed to update view")
-	ErrFailedToDeleteView               = errors.New("failed to delete view")
-	ErrFailedToReorderViews             = errors.New("failed to reorder views")
-	ErrViewNotFound                     = errors.New("view not found")
-	ErrInvalidQuery                     = errors.New("invalid query")
-	ErrViewNameAlreadyExists            = errors.New("a view with that name already exists")
-	ErrFailedToCheckLinkedRules         = errors.New("failed to check for linked rules")
-	ErrFailedToValidateViews            = errors.New("failed to validate views")
-	ErrFailedToLoadUpdatedViews         = errors.New("failed to load updated views")
+	ErrFailedToGetView                      = errors.New("failed to get view")
+	ErrFailedToLoadViews                    = errors.New("failed to load views")
+	ErrFailedToCalculateViewCounts          = errors.New("failed to calculate view counts")
+	ErrFailedToCalculateInboxCount          = errors.New("failed to calculate inbox count")
+	ErrFailedToCalculateEverythingCount     = errors.New("failed to calculate everything count")
+	ErrFailedToCalculateArchiveCount        = errors.New("failed to calculate archive count")
+	ErrFailedToCalculateSnoozedCount        = errors.New("failed to calculate snoozed count")
+	ErrFailedToCalculateStarredCount        = errors.New("failed to calculate starred count")
+	ErrFailedToCalculateRecentlyTaggedCount = errors.New("failed to calculate recently tagged count")
+	ErrFailedToCreateView                   = errors.New("failed to create view")
+	ErrFailedToUpdateView                   = errors.New("failed to update view")
+	ErrFailedToDeleteView                   = errors.New("failed to delete view")
+	ErrFailedToReorderViews                 = errors.New("failed to reorder views")
+	ErrViewNotFound                         = errors.New("view not found")
+	ErrInvalidQuery                         = errors.New("invalid query")
+	ErrViewNameAlreadyExists                = errors.New("a view with that name already exists")
+	ErrFailedToCheckLinkedRules             = errors.New("failed to check for linked rules")
+	ErrFailedToValidateViews                = errors.New("failed to validate views")
+	ErrFailedToLoadUpdatedViews             = errors.New("failed to load updated views")
 	// Validation errors
 	ErrNameRequired                = errors.New("name is required")
 	ErrNameCannotBeEmpty           = errors.New("name cannot be empty")
@@ -57,6 +58,7 @@ var (
 	ErrQueryRequired           = errors.New("query is required")
 	ErrQueryCannotBeEmpty      = errors.New("query cannot be empty")
 	ErrCannotReorderSystemView = errors.New("cannot reorder system view")
+	ErrInvalidWIPLimit         = errors.New("WIP limit must be at least 1")
 )
 
 // GetView returns a view by ID
@@ -68,6 +70,43 @@ func (s *Service) GetView(ctx context.Context, userID, viewID string) (db.View,
 	return view, nil
 }
 
+// systemViewQueries are the queries behind the built-in views
+var systemViewQueries = map[string]string{
+	db.SystemViewInbox:          "in:inbox",
+	db.SystemViewEverything:     "in:anywhere",
+	db.SystemViewArchive:        "in:archive",
+	db.SystemViewSnoozed:        "in:snoozed",
+	db.SystemViewStarred:        "is:starred",
+	db.SystemViewRecentlyTagged: recentlyTaggedQuery,
+}
+
+// recentlyTaggedQuery shows notifications tagged within the last week wherever they are,
+// like a tag's own view
+const recentlyTaggedQuery = "tagged:<7d in:anywhere -is:muted"
+
+// ResolveView returns a custom or built-in view by ID, including the query it shows
+func (s *Service) ResolveView(ctx context.Context, userID, viewID string) (models.View, error) {
+	if queryStr, ok := systemViewQueries[viewID]; ok {
+		def := db.SystemViews[viewID]
+		return models.View{
+			ID:         viewID,
+			Name:       def.Name,
+			Slug:       def.Slug,
+			SystemView: true,
+			Query:      queryStr,
+		}, nil
+	}
+
+	view, err := s.queries.GetView(ctx, userID, viewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.View{}, errors.Join(ErrViewNotFound, err)
+		}
+		return models.View{}, errors.Join(ErrFailedToGetView, err)
+	}
+	return models.ViewFromDB(view), nil
+}
+
 // ListViewsWithCounts returns all views (custom + system) with their unread counts
 func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]models.View, error) {
 	views, err := s.queries.ListViews(ctx, userID)
@@ -75,22 +114,36 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		return nil, errors.Join(ErrFailedToLoadViews, err)
 	}
 
+	// Counts cached at the current revision are reused rather than re-running the view's query
+	revision, err := s.notificationRevision(ctx, userID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCalculateViewCounts, err)
+	}
+
 	// Build response for custom views with counts
 	response := make([]models.View, 0, len(views)+6)
 	for _, view := range views {
 		// Calculate "new" count for this view
-		unreadCount, countErr := s.calculateViewUnreadCount(ctx, userID, view.Query)
+		unreadCount, countErr := s.calculateViewUnreadCount(ctx, userID, revision, view.Query)
 		if countErr != nil {
 			return nil, errors.Join(ErrFailedToCalculateViewCounts, countErr)
 		}
 
 		viewResp := models.ViewFromDB(view)
 		viewResp.UnreadCount = unreadCount
+		if view.WIPLimit.Valid {
+			count, countErr := s.calculateViewCount(ctx, userID, revision, view.Query)
+			if countErr != nil {
+				return nil, errors.Join(ErrFailedToCalculateViewCounts, countErr)
+			}
+			viewResp.Count = &count
+			viewResp.OverLimit = count > view.WIPLimit.Int64
+		}
 		response = append(response, viewResp)
 	}
 
 	// Inject system views: inbox, everything, snoozed, archive, starred
-	inboxCount, err := s.calculateInboxUnreadCount(ctx, userID)
+	inboxCount, err := s.calculateInboxUnreadCount(ctx, userID, revision)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToCalculateInboxCount, err)
 	}
@@ -105,7 +158,7 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		UnreadCount: inboxCount,
 	})
 
-	everythingCount, err := s.calculateEverythingUnreadCount(ctx, userID)
+	everythingCount, err := s.calculateEverythingUnreadCount(ctx, userID, revision)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToCalculateEverythingCount, err)
 	}
@@ -120,7 +173,7 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		UnreadCount: everythingCount,
 	})
 
-	archiveCount, err := s.calculateArchiveUnreadCount(ctx, userID)
+	archiveCount, err := s.calculateArchiveUnreadCount(ctx, userID, revision)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToCalculateArchiveCount, err)
 	}
@@ -135,7 +188,7 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		UnreadCount: archiveCount,
 	})
 
-	snoozedCount, err := s.calculateSnoozedUnreadCount(ctx, userID)
+	snoozedCount, err := s.calculateSnoozedUnreadCount(ctx, userID, revision)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToCalculateSnoozedCount, err)
 	}
@@ -150,7 +203,7 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		UnreadCount: snoozedCount,
 	})
 
-	starredCount, err := s.calculateStarredUnreadCount(ctx, userID)
+	starredCount, err := s.calculateStarredUnreadCount(ctx, userID, revision)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToCalculateStarredCount, err)
 	}
@@ -165,9 +218,51 @@ func (s *Service) ListViewsWithCounts(ctx context.Context, userID string) ([]mod
 		UnreadCount: starredCount,
 	})
 
+	recentlyTaggedCount, err := s.calculateRecentlyTaggedUnreadCount(ctx, userID, revision)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCalculateRecentlyTaggedCount, err)
+	}
+
+	response = append(response, models.View{
+		ID:          db.SystemViewRecentlyTagged,
+		Name:        "Recently tagged",
+		Slug:        db.SystemViewRecentlyTagged,
+		Icon:        models.StrPtr("tag"),
+		SystemView:  true,
+		Query:       recentlyTaggedQuery,
+		UnreadCount: recentlyTaggedCount,
+	})
+
 	return response, nil
 }
 
+// ViewCounts returns the unread count of every view, and how many notifications views with
+// a WIP limit hold
+func (s *Service) ViewCounts(ctx context.Context, userID string) ([]models.ViewCount, error) {
+	views, err := s.ListViewsWithCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]models.ViewCount, len(views))
+	for i, view := range views {
+		counts[i] = models.ViewCountFromView(view)
+	}
+	return counts, nil
+}
+
+// singleViewUnreadCount counts a single view's unread notifications
+func (s *Service) singleViewUnreadCount(
+	ctx context.Context,
+	userID string,
+	viewQuery sql.NullString,
+) (int64, error) {
+	revision, err := s.notificationRevision(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return s.calculateViewUnreadCount(ctx, userID, revision, viewQuery)
+}
+
 // CreateView creates a new view
 func (s *Service) CreateView(
 	ctx context.Context,
@@ -223,7 +318,7 @@ func (s *Service) CreateView(
 	}
 
 	// Calculate initial count for the new view
-	unreadCount, err := s.calculateViewUnreadCount(ctx, userID, view.Query)
+	unreadCount, err := s.singleViewUnreadCount(ctx, userID, view.Query)
 	if err != nil {
 		// Don't fail creation if count calculation fails
 		unreadCount = 0
@@ -305,7 +400,7 @@ func (s *Service) UpdateView(
 	}
 
 	// Calculate count for the updated view
-	unreadCount, err := s.calculateViewUnreadCount(ctx, userID, view.Query)
+	unreadCount, err := s.singleViewUnreadCount(ctx, userID, view.Query)
 	if err != nil {
 		// Don't fail update if count calculation fails
 		unreadCount = 0
@@ -316,39 +411,100 @@ func (s *Service) UpdateView(
 	return resp, nil
 }
 
-// DeleteView deletes a view, returning the count of linked rules if any
-// If force is true, the view will be deleted even if it has linked rules (which will be cascade deleted)
+// SetWIPLimit sets how many notifications a view should hold, or clears the limit when
+// limit is nil. With autoSnooze, notifications past the limit are snoozed after each sync.
+func (s *Service) SetWIPLimit(
+	ctx context.Context,
+	userID, viewID string,
+	limit *int64,
+	autoSnooze bool,
+) (models.View, error) {
+	params := db.UpdateViewWIPLimitParams{ID: viewID}
+	if limit != nil {
+		if *limit < 1 {
+			return models.View{}, ErrInvalidWIPLimit
+		}
+		params.Limit = sql.NullInt64{Int64: *limit, Valid: true}
+		params.AutoSnooze = autoSnooze
+	}
+
+	view, err := s.queries.UpdateViewWIPLimit(ctx, userID, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.View{}, errors.Join(ErrViewNotFound, err)
+		}
+		return models.View{}, errors.Join(ErrFailedToUpdateView, err)
+	}
+
+	resp := models.ViewFromDB(view)
+
+	// Don't fail the update if count calculation fails
+	revision, err := s.notificationRevision(ctx, userID)
+	if err != nil {
+		return resp, nil
+	}
+	if unreadCount, err := s.calculateViewUnreadCount(ctx, userID, revision, view.Query); err == nil {
+		resp.UnreadCount = unreadCount
+	}
+	if view.WIPLimit.Valid {
+		if count, err := s.calculateViewCount(ctx, userID, revision, view.Query); err == nil {
+			resp.Count = &count
+			resp.OverLimit = count > view.WIPLimit.Int64
+		}
+	}
+	return resp, nil
+}
+
+// DeleteView deletes a view. Rules linked to the view would silently stop matching, so
+// unless force is set a view with linked rules is not deleted and those rules are returned
+// for the caller to report. With force, the linked rules are disabled and the view deleted
+// in one transaction, and the disabled rules are returned.
 func (s *Service) DeleteView(
 	ctx context.Context,
 	userID string,
 	viewID string,
 	force bool,
-) (linkedRuleCount int, err error) {
-	// Check if there are any rules linked to this view
+) (dependentRules []models.Rule, err error) {
+	if force {
+		disabled, err := s.queries.DeleteViewAndDisableRules(ctx, userID, viewID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.Join(ErrViewNotFound, err)
+			}
+			return nil, errors.Join(ErrFailedToDeleteView, err)
+		}
+		return rulesFromDB(disabled), nil
+	}
+
 	linkedRules, err := s.queries.GetRulesByViewID(
 		ctx,
 		userID,
 		sql.NullString{String: viewID, Valid: true},
 	)
 	if err != nil {
-		return 0, errors.Join(ErrFailedToCheckLinkedRules, err)
+		return nil, errors.Join(ErrFailedToCheckLinkedRules, err)
 	}
-
-	linkedRuleCount = len(linkedRules)
-
-	// If there are linked rules and force is not set, return the count (caller should handle the conflict)
-	if linkedRuleCount > 0 && !force {
-		return linkedRuleCount, nil
+	if len(linkedRules) > 0 {
+		return rulesFromDB(linkedRules), nil
 	}
 
-	if _, err := s.queries.DeleteView(ctx, userID, viewID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return 0, errors.Join(ErrViewNotFound, err)
-		}
-		return 0, errors.Join(ErrFailedToDeleteView, err)
+	deleted, err := s.queries.DeleteView(ctx, userID, viewID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToDeleteView, err)
 	}
+	if deleted == 0 {
+		return nil, ErrViewNotFound
+	}
+
+	return nil, nil
+}
 
-	return linkedRuleCount, nil
+func rulesFromDB(rules []db.Rule) []models.Rule {
+	result := make([]models.Rule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, models.RuleFromDB(rule))
+	}
+	return result
 }
 
 // ReorderViews updates the display order of views
@@ -100,6 +100,60 @@ func TestService_GetView(t *testing.T) {
 	}
 }
 
+func TestService_ResolveView(t *testing.T) {
+	t.Run("system view needs no lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		view, err := service.ResolveView(context.Background(), "test-user-id", "inbox")
+		require.NoError(t, err)
+		require.Equal(t, "Inbox", view.Name)
+		require.Equal(t, "in:inbox", view.Query)
+		require.True(t, view.SystemView)
+	})
+
+	t.Run("recently tagged view queries tagging time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := NewService(mocks.NewMockStore(ctrl))
+
+		view, err := service.ResolveView(context.Background(), "test-user-id", "recently-tagged")
+		require.NoError(t, err)
+		require.Equal(t, "Recently tagged", view.Name)
+		require.Equal(t, "tagged:<7d in:anywhere -is:muted", view.Query)
+		require.True(t, view.SystemView)
+	})
+
+	t.Run("custom view is loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			GetView(gomock.Any(), "test-user-id", "v1").
+			Return(db.View{
+				ID:    "v1",
+				Name:  "Reviews",
+				Query: sql.NullString{String: "reason:review_requested", Valid: true},
+			}, nil)
+		service := NewService(mockQuerier)
+
+		view, err := service.ResolveView(context.Background(), "test-user-id", "v1")
+		require.NoError(t, err)
+		require.Equal(t, "Reviews", view.Name)
+		require.Equal(t, "reason:review_requested", view.Query)
+	})
+
+	t.Run("missing view returns not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockQuerier := mocks.NewMockStore(ctrl)
+		mockQuerier.EXPECT().
+			GetView(gomock.Any(), "test-user-id", "missing").
+			Return(db.View{}, sql.ErrNoRows)
+		service := NewService(mockQuerier)
+
+		_, err := service.ResolveView(context.Background(), "test-user-id", "missing")
+		require.ErrorIs(t, err, ErrViewNotFound)
+	})
+}
+
 func TestService_CreateView(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -130,6 +184,9 @@ func TestService_CreateView(t *testing.T) {
 				m.EXPECT().
 					CreateView(gomock.Any(), "test-user-id", gomock.Any()).
 					Return(expectedView, nil)
+				m.EXPECT().
+					GetNotificationRevision(gomock.Any(), "test-user-id").
+					Return(int64(1), nil)
 				// calculateViewUnreadCount may be called
 				m.EXPECT().
 					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
@@ -296,6 +353,9 @@ func TestService_UpdateView(t *testing.T) {
 				m.EXPECT().
 					UpdateView(gomock.Any(), "test-user-id", gomock.Any()).
 					Return(expectedView, nil)
+				m.EXPECT().
+					GetNotificationRevision(gomock.Any(), "test-user-id").
+					Return(int64(1), nil)
 				// calculateViewUnreadCount may be called
 				m.EXPECT().
 					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
@@ -414,15 +474,125 @@ func TestService_UpdateView(t *testing.T) {
 	}
 }
 
+func TestService_SetWIPLimit(t *testing.T) {
+	limitedView := db.View{
+		ID:            "1",
+		Name:          "Review queue",
+		Query:         sql.NullString{String: "reason:review_requested", Valid: true},
+		WIPLimit:      sql.NullInt64{Int64: 10, Valid: true},
+		WIPAutoSnooze: true,
+	}
+
+	tests := []struct {
+		name        string
+		limit       *int64
+		autoSnooze  bool
+		setupMock   func(*mocks.MockStore)
+		expectErr   error
+		checkResult func(*testing.T, models.View)
+	}{
+		{
+			name:       "sets the limit and reports the view over it",
+			limit:      int64Ptr(10),
+			autoSnooze: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateViewWIPLimit(gomock.Any(), "test-user-id", db.UpdateViewWIPLimitParams{
+						ID:         "1",
+						Limit:      sql.NullInt64{Int64: 10, Valid: true},
+						AutoSnooze: true,
+					}).
+					Return(limitedView, nil)
+				m.EXPECT().
+					GetNotificationRevision(gomock.Any(), "test-user-id").
+					Return(int64(1), nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 3}, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 12}, nil)
+			},
+			checkResult: func(t *testing.T, view models.View) {
+				require.Equal(t, int64Ptr(10), view.WIPLimit)
+				require.True(t, view.WIPAutoSnooze)
+				require.Equal(t, int64(3), view.UnreadCount)
+				require.Equal(t, int64Ptr(12), view.Count)
+				require.True(t, view.OverLimit)
+			},
+		},
+		{
+			name:       "nil limit clears it along with auto-snooze",
+			autoSnooze: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateViewWIPLimit(gomock.Any(), "test-user-id", db.UpdateViewWIPLimitParams{ID: "1"}).
+					Return(db.View{ID: "1", Query: limitedView.Query}, nil)
+				m.EXPECT().
+					GetNotificationRevision(gomock.Any(), "test-user-id").
+					Return(int64(1), nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 3}, nil)
+			},
+			checkResult: func(t *testing.T, view models.View) {
+				require.Nil(t, view.WIPLimit)
+				require.Nil(t, view.Count)
+				require.False(t, view.OverLimit)
+			},
+		},
+		{
+			name:      "zero limit is rejected",
+			limit:     int64Ptr(0),
+			setupMock: func(*mocks.MockStore) {},
+			expectErr: ErrInvalidWIPLimit,
+		},
+		{
+			name:  "unknown view",
+			limit: int64Ptr(5),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateViewWIPLimit(gomock.Any(), "test-user-id", gomock.Any()).
+					Return(db.View{}, sql.ErrNoRows)
+			},
+			expectErr: ErrViewNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewService(mockQuerier)
+
+			result, err := service.SetWIPLimit(
+				context.Background(), "test-user-id", "1", tt.limit, tt.autoSnooze,
+			)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.checkResult(t, result)
+		})
+	}
+}
+
 func TestService_DeleteView(t *testing.T) {
+	linkedRules := []db.Rule{
+		{ID: "1", Name: "Rule 1", Enabled: true},
+		{ID: "2", Name: "Rule 2", Enabled: true},
+	}
+
 	tests := []struct {
-		name                    string
-		viewID                  string
-		force                   bool
-		setupMock               func(*mocks.MockStore, string)
-		expectErr               bool
-		expectedLinkedRuleCount int
-		checkErr                func(*testing.T, error)
+		name            string
+		viewID          string
+		force           bool
+		setupMock       func(*mocks.MockStore, string)
+		expectErr       bool
+		expectedRuleIDs []string
+		checkErr        func(*testing.T, error)
 	}{
 		{
 			name:   "success deletes view with no linked rules",
@@ -434,45 +604,37 @@ func TestService_DeleteView(t *testing.T) {
 					Return([]db.Rule{}, nil)
 				m.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", id).
-					Return(int64(0), nil)
+					Return(int64(1), nil)
 			},
-			expectErr:               false,
-			expectedLinkedRuleCount: 0,
+			expectErr: false,
 		},
 		{
-			name:   "returns linked rule count without deleting",
+			name:   "returns linked rules without deleting",
 			viewID: "1",
 			force:  false,
 			setupMock: func(m *mocks.MockStore, _ string) {
-				linkedRules := []db.Rule{
-					{ID: "1", Name: "Rule 1"},
-					{ID: "2", Name: "Rule 2"},
-				}
 				m.EXPECT().
 					GetRulesByViewID(gomock.Any(), "test-user-id", gomock.Any()).
 					Return(linkedRules, nil)
 			},
-			expectErr:               false,
-			expectedLinkedRuleCount: 2,
+			expectErr:       false,
+			expectedRuleIDs: []string{"1", "2"},
 		},
 		{
-			name:   "force delete with linked rules deletes view",
+			name:   "force delete disables linked rules and deletes view",
 			viewID: "1",
 			force:  true,
 			setupMock: func(m *mocks.MockStore, id string) {
-				linkedRules := []db.Rule{
-					{ID: "1", Name: "Rule 1"},
-					{ID: "2", Name: "Rule 2"},
+				disabled := []db.Rule{
+					{ID: "1", Name: "Rule 1", Enabled: false},
+					{ID: "2", Name: "Rule 2", Enabled: false},
 				}
 				m.EXPECT().
-					GetRulesByViewID(gomock.Any(), "test-user-id", gomock.Any()).
-					Return(linkedRules, nil)
-				m.EXPECT().
-					DeleteView(gomock.Any(), "test-user-id", id).
-					Return(int64(0), nil)
+					DeleteViewAndDisableRules(gomock.Any(), "test-user-id", id).
+					Return(disabled, nil)
 			},
-			expectErr:               false,
-			expectedLinkedRuleCount: 2,
+			expectErr:       false,
+			expectedRuleIDs: []string{"1", "2"},
 		},
 		{
 			name:   "not found returns ErrViewNotFound",
@@ -484,7 +646,21 @@ func TestService_DeleteView(t *testing.T) {
 					Return([]db.Rule{}, nil)
 				m.EXPECT().
 					DeleteView(gomock.Any(), "test-user-id", id).
-					Return(int64(0), sql.ErrNoRows)
+					Return(int64(0), nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrViewNotFound))
+			},
+		},
+		{
+			name:   "force delete of missing view returns ErrViewNotFound",
+			viewID: "999",
+			force:  true,
+			setupMock: func(m *mocks.MockStore, id string) {
+				m.EXPECT().
+					DeleteViewAndDisableRules(gomock.Any(), "test-user-id", id).
+					Return(nil, sql.ErrNoRows)
 			},
 			expectErr: true,
 			checkErr: func(t *testing.T, err error) {
@@ -536,7 +712,7 @@ func TestService_DeleteView(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			linkedRuleCount, err := service.DeleteView(ctx, "test-user-id", tt.viewID, tt.force)
+			dependentRules, err := service.DeleteView(ctx, "test-user-id", tt.viewID, tt.force)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -545,7 +721,11 @@ func TestService_DeleteView(t *testing.T) {
 				}
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.expectedLinkedRuleCount, linkedRuleCount)
+				ruleIDs := make([]string, 0, len(dependentRules))
+				for _, rule := range dependentRules {
+					ruleIDs = append(ruleIDs, rule.ID)
+				}
+				require.ElementsMatch(t, tt.expectedRuleIDs, ruleIDs)
 			}
 		})
 	}
@@ -651,6 +831,85 @@ func TestService_ReorderViews(t *testing.T) {
 	}
 }
 
+func TestService_ViewCounts(t *testing.T) {
+	const userID = "test-user-id"
+	// One custom view plus the six system views
+	const countedQueries = 7
+	views := []db.View{{ID: "1", Slug: "mine", Query: sql.NullString{String: "author:me", Valid: true}}}
+
+	t.Run("serves cached counts until a notification changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockStore(ctrl)
+		service := NewService(m)
+
+		m.EXPECT().ListViews(gomock.Any(), userID).Return(views, nil).Times(3)
+		gomock.InOrder(
+			m.EXPECT().GetNotificationRevision(gomock.Any(), userID).Return(int64(1), nil).Times(2),
+			m.EXPECT().GetNotificationRevision(gomock.Any(), userID).Return(int64(2), nil),
+		)
+		total := int64(4)
+		m.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ string,
+				_ db.NotificationQuery,
+			) (db.ListNotificationsFromQueryResult, error) {
+				return db.ListNotificationsFromQueryResult{Total: total}, nil
+			}).
+			Times(2 * countedQueries)
+
+		counts, err := service.ViewCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Len(t, counts, countedQueries)
+		require.Equal(t, models.ViewCount{ID: "1", Slug: "mine", UnreadCount: 4}, counts[0])
+
+		// Same revision: nothing is recounted
+		total = 9
+		counts, err = service.ViewCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), counts[0].UnreadCount)
+
+		// A notification changed: everything is recounted
+		counts, err = service.ViewCounts(context.Background(), userID)
+		require.NoError(t, err)
+		require.Equal(t, int64(9), counts[0].UnreadCount)
+	})
+
+	t.Run("recounts once cached counts expire", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockStore(ctrl)
+		service := NewService(m).WithCountsTTL(0)
+
+		m.EXPECT().ListViews(gomock.Any(), userID).Return(views, nil).Times(2)
+		m.EXPECT().GetNotificationRevision(gomock.Any(), userID).Return(int64(1), nil).Times(2)
+		m.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), userID, gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: 1}, nil).
+			Times(2 * countedQueries)
+
+		for range 2 {
+			_, err := service.ViewCounts(context.Background(), userID)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("revision error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockStore(ctrl)
+		service := NewService(m)
+
+		m.EXPECT().ListViews(gomock.Any(), userID).Return(views, nil)
+		m.EXPECT().
+			GetNotificationRevision(gomock.Any(), userID).
+			Return(int64(0), errors.New("database is locked"))
+
+		_, err := service.ViewCounts(context.Background(), userID)
+		require.ErrorIs(t, err, ErrFailedToCalculateViewCounts)
+		require.ErrorIs(t, err, ErrFailedToGetRevision)
+	})
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -659,3 +918,7 @@ func stringPtr(s string) *string {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
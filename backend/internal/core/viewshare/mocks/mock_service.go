@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/viewshare/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/viewshare/service.go -destination=internal/core/viewshare/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockViewShareService is a mock of ViewShareService interface.
+type MockViewShareService struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewShareServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockViewShareServiceMockRecorder is the mock recorder for MockViewShareService.
+type MockViewShareServiceMockRecorder struct {
+	mock *MockViewShareService
+}
+
+// NewMockViewShareService creates a new mock instance.
+func NewMockViewShareService(ctrl *gomock.Controller) *MockViewShareService {
+	mock := &MockViewShareService{ctrl: ctrl}
+	mock.recorder = &MockViewShareServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewShareService) EXPECT() *MockViewShareServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSharedView mocks base method.
+func (m *MockViewShareService) GetSharedView(ctx context.Context, viewID, grantee string) (models.SharedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedView", ctx, viewID, grantee)
+	ret0, _ := ret[0].(models.SharedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedView indicates an expected call of GetSharedView.
+func (mr *MockViewShareServiceMockRecorder) GetSharedView(ctx, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedView", reflect.TypeOf((*MockViewShareService)(nil).GetSharedView), ctx, viewID, grantee)
+}
+
+// LeaveView mocks base method.
+func (m *MockViewShareService) LeaveView(ctx context.Context, viewID, grantee string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveView", ctx, viewID, grantee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeaveView indicates an expected call of LeaveView.
+func (mr *MockViewShareServiceMockRecorder) LeaveView(ctx, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveView", reflect.TypeOf((*MockViewShareService)(nil).LeaveView), ctx, viewID, grantee)
+}
+
+// ListShares mocks base method.
+func (m *MockViewShareService) ListShares(ctx context.Context, userID, viewID string) ([]models.ViewGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShares", ctx, userID, viewID)
+	ret0, _ := ret[0].([]models.ViewGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShares indicates an expected call of ListShares.
+func (mr *MockViewShareServiceMockRecorder) ListShares(ctx, userID, viewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShares", reflect.TypeOf((*MockViewShareService)(nil).ListShares), ctx, userID, viewID)
+}
+
+// ShareView mocks base method.
+func (m *MockViewShareService) ShareView(ctx context.Context, userID, viewID, grantee, permission string) (models.ViewGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareView", ctx, userID, viewID, grantee, permission)
+	ret0, _ := ret[0].(models.ViewGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareView indicates an expected call of ShareView.
+func (mr *MockViewShareServiceMockRecorder) ShareView(ctx, userID, viewID, grantee, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareView", reflect.TypeOf((*MockViewShareService)(nil).ShareView), ctx, userID, viewID, grantee, permission)
+}
+
+// SharedWith mocks base method.
+func (m *MockViewShareService) SharedWith(ctx context.Context, grantee string) ([]models.SharedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedWith", ctx, grantee)
+	ret0, _ := ret[0].([]models.SharedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedWith indicates an expected call of SharedWith.
+func (mr *MockViewShareServiceMockRecorder) SharedWith(ctx, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedWith", reflect.TypeOf((*MockViewShareService)(nil).SharedWith), ctx, grantee)
+}
+
+// UnshareView mocks base method.
+func (m *MockViewShareService) UnshareView(ctx context.Context, userID, viewID, grantee string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareView", ctx, userID, viewID, grantee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareView indicates an expected call of UnshareView.
+func (mr *MockViewShareServiceMockRecorder) UnshareView(ctx, userID, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareView", reflect.TypeOf((*MockViewShareService)(nil).UnshareView), ctx, userID, viewID, grantee)
+}
+
+// MockDirectory is a mock of Directory interface.
+type MockDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockDirectoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDirectoryMockRecorder is the mock recorder for MockDirectory.
+type MockDirectoryMockRecorder struct {
+	mock *MockDirectory
+}
+
+// NewMockDirectory creates a new mock instance.
+func NewMockDirectory(ctrl *gomock.Controller) *MockDirectory {
+	mock := &MockDirectory{ctrl: ctrl}
+	mock.recorder = &MockDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDirectory) EXPECT() *MockDirectoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDirectory) Get(username string) (models.ServerUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", username)
+	ret0, _ := ret[0].(models.ServerUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDirectoryMockRecorder) Get(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDirectory)(nil).Get), username)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package viewshare shares views with the other users of a multi-user server. Each
+// user's data lives in a workspace of its own, so a share is kept in the owner's
+// database and looked up there by the username it was shared with.
+package viewshare
+
+import (
+	"context"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ViewShareService is the interface for sharing views.
+type ViewShareService interface {
+	// ListShares returns who one of the owner's views is shared with
+	ListShares(ctx context.Context, userID, viewID string) ([]models.ViewGrant, error)
+	// ShareView shares a view with another user, or changes the permission they have
+	ShareView(
+		ctx context.Context,
+		userID, viewID, grantee, permission string,
+	) (models.ViewGrant, error)
+	// UnshareView stops sharing a view with a user
+	UnshareView(ctx context.Context, userID, viewID, grantee string) error
+	// SharedWith returns the owner's views shared with a user
+	SharedWith(ctx context.Context, grantee string) ([]models.SharedView, error)
+	// GetSharedView returns one of the owner's views if it's shared with a user, and
+	// ErrShareNotFound otherwise
+	GetSharedView(ctx context.Context, viewID, grantee string) (models.SharedView, error)
+	// LeaveView removes a view shared with a user, on the user's behalf
+	LeaveView(ctx context.Context, viewID, grantee string) error
+}
+
+// Directory looks up the users of the server, so views are only shared with users
+// who exist
+type Directory interface {
+	Get(username string) (models.ServerUser, error)
+}
+
+// Service provides view sharing for one user's workspace
+type Service struct {
+	queries db.Store
+	owner   string
+	users   Directory
+	now     func() time.Time
+}
+
+// NewService constructs a Service for the workspace of owner, the username whose views
+// are shared
+func NewService(queries db.Store, owner string, users Directory, now func() time.Time) *Service {
+	return &Service{
+		queries: queries,
+		owner:   owner,
+		users:   users,
+		now:     now,
+	}
+}
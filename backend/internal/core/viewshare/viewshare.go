@@ -0,0 +1,177 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package viewshare
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrViewNotFound          = errors.New("view not found")
+	ErrShareNotFound         = errors.New("view is not shared with that user")
+	ErrUnknownUser           = errors.New("no user with that username")
+	ErrShareWithSelf         = errors.New("views can't be shared with their owner")
+	ErrInvalidPermission     = errors.New("permission must be read or write")
+	ErrFailedToListShares    = errors.New("failed to list view shares")
+	ErrFailedToShareView     = errors.New("failed to share view")
+	ErrFailedToUnshareView   = errors.New("failed to stop sharing view")
+	ErrFailedToGetSharedView = errors.New("failed to get shared view")
+)
+
+// ListShares returns who a view is shared with, by username
+func (s *Service) ListShares(
+	ctx context.Context,
+	userID, viewID string,
+) ([]models.ViewGrant, error) {
+	if err := s.checkView(ctx, userID, viewID); err != nil {
+		return nil, err
+	}
+	shares, err := s.queries.ListViewShares(ctx, userID, viewID)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListShares, err)
+	}
+	result := make([]models.ViewGrant, len(shares))
+	for i, share := range shares {
+		result[i] = models.ViewGrantFromDB(share)
+	}
+	return result, nil
+}
+
+// ShareView shares a view with another user of the server. Sharing a view with someone
+// it's already shared with changes their permission.
+func (s *Service) ShareView(
+	ctx context.Context,
+	userID, viewID, grantee, permission string,
+) (models.ViewGrant, error) {
+	permission = strings.ToLower(strings.TrimSpace(permission))
+	if permission != models.ViewPermissionRead &&
+		permission != models.ViewPermissionWrite {
+		return models.ViewGrant{}, ErrInvalidPermission
+	}
+	user, err := s.users.Get(grantee)
+	if err != nil {
+		return models.ViewGrant{}, ErrUnknownUser
+	}
+	if user.Username == s.owner {
+		return models.ViewGrant{}, ErrShareWithSelf
+	}
+	if err = s.checkView(ctx, userID, viewID); err != nil {
+		return models.ViewGrant{}, err
+	}
+
+	share, err := s.queries.UpsertViewShare(ctx, userID, db.UpsertViewShareParams{
+		ViewID:     viewID,
+		Grantee:    user.Username,
+		Permission: permission,
+		CreatedAt:  s.now(),
+	})
+	if err != nil {
+		return models.ViewGrant{}, errors.Join(ErrFailedToShareView, err)
+	}
+	return models.ViewGrantFromDB(share), nil
+}
+
+// UnshareView stops sharing a view with a user
+func (s *Service) UnshareView(ctx context.Context, userID, viewID, grantee string) error {
+	deleted, err := s.queries.DeleteViewShare(ctx, userID, viewID, normalizeUsername(grantee))
+	if err != nil {
+		return errors.Join(ErrFailedToUnshareView, err)
+	}
+	if deleted == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// SharedWith returns the views shared with a user, oldest share first
+func (s *Service) SharedWith(ctx context.Context, grantee string) ([]models.SharedView, error) {
+	shares, err := s.queries.ListViewSharesByGrantee(ctx, normalizeUsername(grantee))
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListShares, err)
+	}
+	result := make([]models.SharedView, 0, len(shares))
+	for _, share := range shares {
+		view, viewErr := s.queries.GetView(ctx, share.UserID, share.ViewID)
+		if viewErr != nil {
+			return nil, errors.Join(ErrFailedToListShares, viewErr)
+		}
+		result = append(result, s.sharedView(share, view))
+	}
+	return result, nil
+}
+
+// GetSharedView returns a view if it's shared with a user
+func (s *Service) GetSharedView(
+	ctx context.Context,
+	viewID, grantee string,
+) (models.SharedView, error) {
+	share, err := s.queries.GetViewShare(ctx, viewID, normalizeUsername(grantee))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SharedView{}, ErrShareNotFound
+		}
+		return models.SharedView{}, errors.Join(ErrFailedToGetSharedView, err)
+	}
+	view, err := s.queries.GetView(ctx, share.UserID, share.ViewID)
+	if err != nil {
+		return models.SharedView{}, errors.Join(ErrFailedToGetSharedView, err)
+	}
+	return s.sharedView(share, view), nil
+}
+
+// LeaveView removes a view shared with a user
+func (s *Service) LeaveView(ctx context.Context, viewID, grantee string) error {
+	deleted, err := s.queries.DeleteViewShareByGrantee(ctx, viewID, normalizeUsername(grantee))
+	if err != nil {
+		return errors.Join(ErrFailedToUnshareView, err)
+	}
+	if deleted == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// checkView returns ErrViewNotFound unless the user has a view with the ID
+func (s *Service) checkView(ctx context.Context, userID, viewID string) error {
+	if _, err := s.queries.GetView(ctx, userID, viewID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrViewNotFound
+		}
+		return errors.Join(ErrFailedToListShares, err)
+	}
+	return nil
+}
+
+func (s *Service) sharedView(share db.ViewShare, view db.View) models.SharedView {
+	return models.SharedView{
+		Owner:      s.owner,
+		Permission: share.Permission,
+		SharedAt:   share.CreatedAt,
+		View:       models.ViewFromDB(view),
+	}
+}
+
+// normalizeUsername matches usernames the way the server's users file does
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package viewshare
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const (
+	testUserID = "user-1"
+	testOwner  = "octocat"
+	testViewID = "view-1"
+)
+
+// fakeDirectory knows the users named in it
+type fakeDirectory map[string]bool
+
+func (d fakeDirectory) Get(username string) (models.ServerUser, error) {
+	username = normalizeUsername(username)
+	if !d[username] {
+		return models.ServerUser{}, sql.ErrNoRows
+	}
+	return models.ServerUser{Username: username}, nil
+}
+
+func newTestService(t *testing.T) (*Service, *mocks.MockStore, time.Time) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	users := fakeDirectory{testOwner: true, "alice": true}
+	return NewService(store, testOwner, users, func() time.Time { return now }), store, now
+}
+
+func TestService_ShareView(t *testing.T) {
+	tests := []struct {
+		name       string
+		grantee    string
+		permission string
+		setupMock  func(*mocks.MockStore, time.Time)
+		expectErr  error
+	}{
+		{
+			name:       "shares with another user",
+			grantee:    " Alice ",
+			permission: "Write",
+			setupMock: func(m *mocks.MockStore, now time.Time) {
+				m.EXPECT().GetView(gomock.Any(), testUserID, testViewID).Return(db.View{}, nil)
+				m.EXPECT().
+					UpsertViewShare(gomock.Any(), testUserID, db.UpsertViewShareParams{
+						ViewID:     testViewID,
+						Grantee:    "alice",
+						Permission: "write",
+						CreatedAt:  now,
+					}).
+					Return(db.ViewShare{
+						ViewID:     testViewID,
+						Grantee:    "alice",
+						Permission: "write",
+						CreatedAt:  now,
+					}, nil)
+			},
+		},
+		{
+			name:       "unknown permission",
+			grantee:    "alice",
+			permission: "admin",
+			expectErr:  ErrInvalidPermission,
+		},
+		{
+			name:       "unknown user",
+			grantee:    "mallory",
+			permission: "read",
+			expectErr:  ErrUnknownUser,
+		},
+		{
+			name:       "owner",
+			grantee:    testOwner,
+			permission: "read",
+			expectErr:  ErrShareWithSelf,
+		},
+		{
+			name:       "missing view",
+			grantee:    "alice",
+			permission: "read",
+			setupMock: func(m *mocks.MockStore, _ time.Time) {
+				m.EXPECT().
+					GetView(gomock.Any(), testUserID, testViewID).
+					Return(db.View{}, sql.ErrNoRows)
+			},
+			expectErr: ErrViewNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store, now := newTestService(t)
+			if tt.setupMock != nil {
+				tt.setupMock(store, now)
+			}
+
+			share, err := svc.ShareView(
+				context.Background(), testUserID, testViewID, tt.grantee, tt.permission,
+			)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, models.ViewGrant{
+				ViewID:     testViewID,
+				Grantee:    "alice",
+				Permission: "write",
+				CreatedAt:  now,
+			}, share)
+		})
+	}
+}
+
+func TestService_GetSharedView(t *testing.T) {
+	svc, store, now := newTestService(t)
+	ctx := context.Background()
+
+	store.EXPECT().
+		GetViewShare(gomock.Any(), testViewID, "alice").
+		Return(db.ViewShare{
+			UserID:     testUserID,
+			ViewID:     testViewID,
+			Grantee:    "alice",
+			Permission: "read",
+			CreatedAt:  now,
+		}, nil)
+	store.EXPECT().
+		GetView(gomock.Any(), testUserID, testViewID).
+		Return(db.View{
+			ID:    testViewID,
+			Name:  "Reviews",
+			Slug:  "reviews",
+			Query: sql.NullString{String: "reason:review_requested", Valid: true},
+		}, nil)
+
+	shared, err := svc.GetSharedView(ctx, testViewID, "Alice")
+	require.NoError(t, err)
+	require.Equal(t, testOwner, shared.Owner)
+	require.Equal(t, "reason:review_requested", shared.View.Query)
+	require.False(t, shared.CanWrite())
+
+	// Views that aren't shared with the user are as good as missing
+	store.EXPECT().
+		GetViewShare(gomock.Any(), testViewID, "bob").
+		Return(db.ViewShare{}, sql.ErrNoRows)
+	_, err = svc.GetSharedView(ctx, testViewID, "bob")
+	require.ErrorIs(t, err, ErrShareNotFound)
+}
+
+func TestService_UnshareAndLeave(t *testing.T) {
+	svc, store, _ := newTestService(t)
+	ctx := context.Background()
+
+	store.EXPECT().DeleteViewShare(gomock.Any(), testUserID, testViewID, "alice").Return(int64(1), nil)
+	require.NoError(t, svc.UnshareView(ctx, testUserID, testViewID, "Alice"))
+
+	store.EXPECT().DeleteViewShareByGrantee(gomock.Any(), testViewID, "alice").Return(int64(0), nil)
+	require.ErrorIs(t, svc.LeaveView(ctx, testViewID, "alice"), ErrShareNotFound)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/webhook/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/webhook/service.go -destination=internal/core/webhook/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	webhook "github.com/octobud-hq/octobud/backend/internal/core/webhook"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleDelivery mocks base method.
+func (m *MockWebhookService) HandleDelivery(ctx context.Context, event string, payload []byte) (webhook.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDelivery", ctx, event, payload)
+	ret0, _ := ret[0].(webhook.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDelivery indicates an expected call of HandleDelivery.
+func (mr *MockWebhookServiceMockRecorder) HandleDelivery(ctx, event, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDelivery", reflect.TypeOf((*MockWebhookService)(nil).HandleDelivery), ctx, event, payload)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package webhook applies GitHub webhook deliveries as they arrive, so issue and pull
+// request changes reach notifications without waiting for the next poll.
+package webhook
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/sync"
+)
+
+// WebhookService is the interface for applying webhook deliveries.
+type WebhookService interface {
+	// HandleDelivery applies one delivery whose signature has already been checked.
+	HandleDelivery(ctx context.Context, event string, payload []byte) (Result, error)
+}
+
+// Service applies webhook deliveries to the notifications they're about
+type Service struct {
+	logger      *zap.Logger
+	authSvc     auth.AuthService
+	queries     db.Store
+	syncOps     sync.SyncOperations
+	scheduler   jobs.Scheduler
+	liveQueries livequery.LiveQueryService
+}
+
+// NewService constructs a Service. liveQueries may be nil.
+func NewService(
+	logger *zap.Logger,
+	authSvc auth.AuthService,
+	queries db.Store,
+	syncOps sync.SyncOperations,
+	scheduler jobs.Scheduler,
+	liveQueries livequery.LiveQueryService,
+) *Service {
+	return &Service{
+		logger:      logger,
+		authSvc:     authSvc,
+		queries:     queries,
+		syncOps:     syncOps,
+		scheduler:   scheduler,
+		liveQueries: liveQueries,
+	}
+}
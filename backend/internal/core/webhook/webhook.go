@@ -0,0 +1,200 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// GitHub event names handled by the receiver
+const (
+	EventPing         = "ping"
+	EventIssues       = "issues"
+	EventPullRequest  = "pull_request"
+	EventIssueComment = "issue_comment"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of a delivery's body, keyed with the
+// webhook secret
+const SignatureHeader = "X-Hub-Signature-256"
+
+const signaturePrefix = "sha256="
+
+// Error definitions
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrDisabled         = errors.New("webhook sync is disabled")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	ErrFailedToGetUser  = errors.New("failed to get user")
+	ErrFailedToList     = errors.New("failed to list notifications for subject")
+)
+
+// Result describes what a delivery changed
+type Result struct {
+	Event      string   `json:"event"`
+	Ignored    bool     `json:"ignored,omitempty"`
+	SubjectURL string   `json:"subjectUrl,omitempty"`
+	Updated    []string `json:"updated"`
+}
+
+// Sign returns the signature header value GitHub sends for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateSignature checks a delivery's signature header against its body
+func ValidateSignature(secret, header string, body []byte) error {
+	if secret == "" || !strings.HasPrefix(header, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// subjectRef is the part of an issue or pull request a delivery is matched on
+type subjectRef struct {
+	URL         string `json:"url"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request"`
+}
+
+type deliveryPayload struct {
+	Issue       json.RawMessage `json:"issue"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+// subjectFromDelivery returns the API URL of the issue or pull request a delivery is
+// about, and its full data when the payload has it. Comments on pull requests only
+// carry the issue side of the pull request, so their data is left for a refetch.
+func subjectFromDelivery(event string, payload []byte) (string, json.RawMessage, error) {
+	var delivery deliveryPayload
+	if err := json.Unmarshal(payload, &delivery); err != nil {
+		return "", nil, errors.Join(ErrInvalidPayload, err)
+	}
+
+	raw := delivery.Issue
+	if event == EventPullRequest {
+		raw = delivery.PullRequest
+	}
+	if len(raw) == 0 {
+		return "", nil, ErrInvalidPayload
+	}
+
+	var ref subjectRef
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return "", nil, errors.Join(ErrInvalidPayload, err)
+	}
+	if ref.URL == "" {
+		return "", nil, ErrInvalidPayload
+	}
+
+	if event == EventIssueComment && ref.PullRequest != nil {
+		if ref.PullRequest.URL == "" {
+			return "", nil, ErrInvalidPayload
+		}
+		return ref.PullRequest.URL, nil, nil
+	}
+	return ref.URL, raw, nil
+}
+
+// HandleDelivery updates every notification about the delivery's issue or pull request
+// and then syncs, since new activity may also have created notification threads that
+// only the notifications API reports.
+func (s *Service) HandleDelivery(
+	ctx context.Context,
+	event string,
+	payload []byte,
+) (Result, error) {
+	result := Result{Event: event, Updated: []string{}}
+	switch event {
+	case EventIssues, EventPullRequest, EventIssueComment:
+	case EventPing:
+		return result, nil
+	default:
+		result.Ignored = true
+		return result, nil
+	}
+
+	user, err := s.authSvc.GetUser(ctx)
+	if err != nil {
+		return result, errors.Join(ErrFailedToGetUser, err)
+	}
+	if user == nil || user.GithubUserID == "" || user.SyncSettings == nil ||
+		!user.SyncSettings.WebhooksEnabled {
+		return result, ErrDisabled
+	}
+	userID := user.GithubUserID
+
+	subjectURL, subjectRaw, err := subjectFromDelivery(event, payload)
+	if err != nil {
+		return result, err
+	}
+	result.SubjectURL = subjectURL
+
+	githubIDs, err := s.queries.ListNotificationGithubIDsBySubjectURL(ctx, userID, subjectURL)
+	if err != nil {
+		return result, errors.Join(ErrFailedToList, err)
+	}
+
+	for _, githubID := range githubIDs {
+		if subjectRaw != nil {
+			err = s.syncOps.ApplySubjectData(ctx, userID, githubID, subjectRaw)
+		} else {
+			_, err = s.syncOps.RefreshSubjectData(ctx, userID, githubID)
+		}
+		if err != nil {
+			// Keep going - the next poll refreshes anything missed here
+			s.logger.Warn(
+				"failed to apply webhook delivery to notification",
+				zap.String("event", event),
+				zap.String("githubID", githubID),
+				zap.Error(err),
+			)
+			continue
+		}
+		result.Updated = append(result.Updated, githubID)
+		if s.liveQueries != nil {
+			s.liveQueries.NotificationChanged(ctx, userID, githubID)
+		}
+	}
+
+	if s.scheduler != nil {
+		if err := s.scheduler.EnqueueSyncNotifications(ctx, userID); err != nil {
+			s.logger.Warn("failed to queue sync after webhook delivery", zap.Error(err))
+		}
+	}
+
+	return result, nil
+}
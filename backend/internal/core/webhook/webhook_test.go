@@ -0,0 +1,181 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	authmocks "github.com/octobud-hq/octobud/backend/internal/core/auth/mocks"
+	livequerymocks "github.com/octobud-hq/octobud/backend/internal/core/livequery/mocks"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	jobmocks "github.com/octobud-hq/octobud/backend/internal/jobs/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
+)
+
+const (
+	issueURL = "https://api.github.com/repos/cli/cli/issues/7"
+	pullURL  = "https://api.github.com/repos/cli/cli/pulls/8"
+)
+
+type serviceMocks struct {
+	auth        *authmocks.MockAuthService
+	store       *dbmocks.MockStore
+	sync        *syncmocks.MockSyncOperations
+	scheduler   *jobmocks.MockScheduler
+	liveQueries *livequerymocks.MockLiveQueryService
+}
+
+func setupService(t *testing.T) (*Service, serviceMocks) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := serviceMocks{
+		auth:        authmocks.NewMockAuthService(ctrl),
+		store:       dbmocks.NewMockStore(ctrl),
+		sync:        syncmocks.NewMockSyncOperations(ctrl),
+		scheduler:   jobmocks.NewMockScheduler(ctrl),
+		liveQueries: livequerymocks.NewMockLiveQueryService(ctrl),
+	}
+	svc := NewService(zap.NewNop(), m.auth, m.store, m.sync, m.scheduler, m.liveQueries)
+	return svc, m
+}
+
+func webhooksUser(enabled bool) *models.User {
+	return &models.User{
+		GithubUserID: "user-1",
+		SyncSettings: &models.SyncSettings{SetupCompleted: true, WebhooksEnabled: enabled},
+	}
+}
+
+func TestValidateSignature(t *testing.T) {
+	body := []byte(`{"action":"closed"}`)
+
+	require.NoError(t, ValidateSignature("s3cret", Sign("s3cret", body), body))
+	require.ErrorIs(
+		t,
+		ValidateSignature("s3cret", Sign("other", body), body),
+		ErrInvalidSignature,
+	)
+	require.ErrorIs(
+		t,
+		ValidateSignature("s3cret", Sign("s3cret", body), []byte(`{"action":"opened"}`)),
+		ErrInvalidSignature,
+	)
+	require.ErrorIs(t, ValidateSignature("s3cret", "sha256=zz", body), ErrInvalidSignature)
+	require.ErrorIs(t, ValidateSignature("s3cret", "", body), ErrInvalidSignature)
+	require.ErrorIs(t, ValidateSignature("", Sign("", body), body), ErrInvalidSignature)
+}
+
+func TestService_HandleDelivery_AppliesIssueFromPayload(t *testing.T) {
+	svc, m := setupService(t)
+	payload := []byte(`{"action":"closed","issue":{"url":"` + issueURL + `","state":"closed"}}`)
+
+	m.auth.EXPECT().GetUser(gomock.Any()).Return(webhooksUser(true), nil)
+	m.store.EXPECT().
+		ListNotificationGithubIDsBySubjectURL(gomock.Any(), "user-1", issueURL).
+		Return([]string{"n1", "n2"}, nil)
+	m.sync.EXPECT().
+		ApplySubjectData(gomock.Any(), "user-1", "n1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, raw []byte) error {
+			require.JSONEq(t, `{"url":"`+issueURL+`","state":"closed"}`, string(raw))
+			return nil
+		})
+	m.sync.EXPECT().
+		ApplySubjectData(gomock.Any(), "user-1", "n2", gomock.Any()).
+		Return(errors.New("boom"))
+	m.liveQueries.EXPECT().NotificationChanged(gomock.Any(), "user-1", "n1")
+	m.scheduler.EXPECT().EnqueueSyncNotifications(gomock.Any(), "user-1").Return(nil)
+
+	result, err := svc.HandleDelivery(context.Background(), EventIssues, payload)
+	require.NoError(t, err)
+	require.Equal(t, issueURL, result.SubjectURL)
+	require.Equal(t, []string{"n1"}, result.Updated)
+}
+
+func TestService_HandleDelivery_PullRequest(t *testing.T) {
+	svc, m := setupService(t)
+	payload := []byte(`{"action":"synchronize","pull_request":{"url":"` + pullURL + `"}}`)
+
+	m.auth.EXPECT().GetUser(gomock.Any()).Return(webhooksUser(true), nil)
+	m.store.EXPECT().
+		ListNotificationGithubIDsBySubjectURL(gomock.Any(), "user-1", pullURL).
+		Return([]string{"n1"}, nil)
+	m.sync.EXPECT().ApplySubjectData(gomock.Any(), "user-1", "n1", gomock.Any()).Return(nil)
+	m.liveQueries.EXPECT().NotificationChanged(gomock.Any(), "user-1", "n1")
+	m.scheduler.EXPECT().EnqueueSyncNotifications(gomock.Any(), "user-1").Return(nil)
+
+	result, err := svc.HandleDelivery(context.Background(), EventPullRequest, payload)
+	require.NoError(t, err)
+	require.Equal(t, []string{"n1"}, result.Updated)
+}
+
+func TestService_HandleDelivery_PullRequestCommentRefetches(t *testing.T) {
+	svc, m := setupService(t)
+	payload := []byte(`{"action":"created","issue":{"url":"` + issueURL +
+		`","pull_request":{"url":"` + pullURL + `"}},"comment":{"id":1}}`)
+
+	m.auth.EXPECT().GetUser(gomock.Any()).Return(webhooksUser(true), nil)
+	m.store.EXPECT().
+		ListNotificationGithubIDsBySubjectURL(gomock.Any(), "user-1", pullURL).
+		Return([]string{"n1"}, nil)
+	m.sync.EXPECT().RefreshSubjectData(gomock.Any(), "user-1", "n1").Return(false, nil)
+	m.liveQueries.EXPECT().NotificationChanged(gomock.Any(), "user-1", "n1")
+	m.scheduler.EXPECT().EnqueueSyncNotifications(gomock.Any(), "user-1").Return(nil)
+
+	result, err := svc.HandleDelivery(context.Background(), EventIssueComment, payload)
+	require.NoError(t, err)
+	require.Equal(t, pullURL, result.SubjectURL)
+}
+
+func TestService_HandleDelivery_Disabled(t *testing.T) {
+	svc, m := setupService(t)
+	m.auth.EXPECT().GetUser(gomock.Any()).Return(webhooksUser(false), nil)
+
+	_, err := svc.HandleDelivery(
+		context.Background(),
+		EventIssues,
+		[]byte(`{"issue":{"url":"`+issueURL+`"}}`),
+	)
+	require.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestService_HandleDelivery_InvalidPayload(t *testing.T) {
+	svc, m := setupService(t)
+	m.auth.EXPECT().GetUser(gomock.Any()).Return(webhooksUser(true), nil).Times(2)
+
+	_, err := svc.HandleDelivery(context.Background(), EventPullRequest, []byte(`{"issue":{}}`))
+	require.ErrorIs(t, err, ErrInvalidPayload)
+	_, err = svc.HandleDelivery(context.Background(), EventIssues, []byte(`not json`))
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestService_HandleDelivery_PingAndOtherEvents(t *testing.T) {
+	svc, _ := setupService(t)
+
+	result, err := svc.HandleDelivery(context.Background(), EventPing, []byte(`{}`))
+	require.NoError(t, err)
+	require.False(t, result.Ignored)
+
+	result, err = svc.HandleDelivery(context.Background(), "push", []byte(`{}`))
+	require.NoError(t, err)
+	require.True(t, result.Ignored)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workhours
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+// Calendar answers business-day questions for a set of working hours. When working hours
+// are disabled every day is a business day, so callers can use a Calendar unconditionally.
+type Calendar struct {
+	enabled  bool
+	days     [7]bool
+	start    time.Duration // Offset from midnight
+	end      time.Duration // Offset from midnight
+	location *time.Location
+	holidays map[string]bool
+}
+
+// NewCalendar validates working hours and builds a Calendar from them
+func NewCalendar(settings *models.WorkingHours) (*Calendar, error) {
+	if settings == nil {
+		settings = models.DefaultWorkingHours()
+	}
+
+	c := &Calendar{
+		enabled:  settings.Enabled,
+		location: time.Local,
+		holidays: make(map[string]bool, len(settings.Holidays)),
+	}
+
+	if len(settings.Days) == 0 {
+		return nil, invalid("at least one working day is required")
+	}
+	for _, day := range settings.Days {
+		if day < int(time.Sunday) || day > int(time.Saturday) {
+			return nil, invalid("day %d must be between 0 (Sunday) and 6 (Saturday)", day)
+		}
+		c.days[day] = true
+	}
+
+	var err error
+	if c.start, err = parseClock(settings.Start); err != nil {
+		return nil, invalid("start %q must be formatted HH:MM", settings.Start)
+	}
+	if c.end, err = parseClock(settings.End); err != nil {
+		return nil, invalid("end %q must be formatted HH:MM", settings.End)
+	}
+	if c.end <= c.start {
+		return nil, invalid("end must be after start")
+	}
+
+	if settings.Timezone != "" {
+		if c.location, err = time.LoadLocation(settings.Timezone); err != nil {
+			return nil, invalid("unknown time zone %q", settings.Timezone)
+		}
+	}
+
+	for _, holiday := range settings.Holidays {
+		if _, err := time.Parse(dateLayout, holiday); err != nil {
+			return nil, invalid("holiday %q must be formatted YYYY-MM-DD", holiday)
+		}
+		c.holidays[holiday] = true
+	}
+
+	return c, nil
+}
+
+// IsBusinessDay reports whether t falls on a working day that isn't a holiday
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if !c.enabled {
+		return true
+	}
+	local := t.In(c.location)
+	return c.days[local.Weekday()] && !c.holidays[local.Format(dateLayout)]
+}
+
+// IsWorkingTime reports whether t falls within working hours on a business day
+func (c *Calendar) IsWorkingTime(t time.Time) bool {
+	if !c.enabled {
+		return true
+	}
+	if !c.IsBusinessDay(t) {
+		return false
+	}
+	local := t.In(c.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	return offset >= c.start && offset < c.end
+}
+
+// NextBusinessDayStart returns the start of working hours on the first business day after
+// the day t falls on. This is what "snooze until next business day" resolves to.
+func (c *Calendar) NextBusinessDayStart(t time.Time) time.Time {
+	day := c.midnight(t)
+	for {
+		day = c.addDays(day, 1)
+		if c.IsBusinessDay(day) {
+			return c.atOffset(day, c.start)
+		}
+	}
+}
+
+// AddBusinessDays moves t forward by n business days (backward if n is negative), keeping
+// its time of day. Non-business days are skipped without being counted, so a two business
+// day deadline set on a Friday falls on Tuesday.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	local := t.In(c.location)
+	for n > 0 {
+		local = c.addDays(local, step)
+		if c.IsBusinessDay(local) {
+			n--
+		}
+	}
+	return local
+}
+
+// Location returns the time zone of the working hours
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// midnight returns the start of the day t falls on in the calendar's time zone
+func (c *Calendar) midnight(t time.Time) time.Time {
+	local := t.In(c.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location)
+}
+
+// atOffset returns the time offset from midnight on the day t falls on, by wall clock
+func (c *Calendar) atOffset(t time.Time, offset time.Duration) time.Time {
+	hours := int(offset / time.Hour)
+	minutes := int((offset % time.Hour) / time.Minute)
+	return time.Date(t.Year(), t.Month(), t.Day(), hours, minutes, 0, 0, c.location)
+}
+
+// addDays moves t by n calendar days, keeping its wall-clock time across DST changes
+func (c *Calendar) addDays(t time.Time, n int) time.Time {
+	return time.Date(
+		t.Year(), t.Month(), t.Day()+n,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		c.location,
+	)
+}
+
+// parseClock parses an "HH:MM" time of day into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidWorkingHours, fmt.Sprintf(format, args...))
+}
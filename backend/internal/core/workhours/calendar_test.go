@@ -0,0 +1,181 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workhours
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func newTestCalendar(t *testing.T, mutate func(*models.WorkingHours)) *Calendar {
+	t.Helper()
+	settings := models.DefaultWorkingHours()
+	settings.Enabled = true
+	settings.Timezone = "America/New_York"
+	if mutate != nil {
+		mutate(settings)
+	}
+	calendar, err := NewCalendar(settings)
+	require.NoError(t, err)
+	return calendar
+}
+
+func newYork(t *testing.T, value string) time.Time {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestNewCalendar_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*models.WorkingHours)
+	}{
+		{name: "no days", mutate: func(s *models.WorkingHours) { s.Days = nil }},
+		{name: "day out of range", mutate: func(s *models.WorkingHours) { s.Days = []int{7} }},
+		{name: "bad start", mutate: func(s *models.WorkingHours) { s.Start = "9am" }},
+		{name: "bad end", mutate: func(s *models.WorkingHours) { s.End = "25:00" }},
+		{name: "end before start", mutate: func(s *models.WorkingHours) { s.End = "08:00" }},
+		{name: "unknown zone", mutate: func(s *models.WorkingHours) { s.Timezone = "Mars/Base" }},
+		{
+			name:   "bad holiday",
+			mutate: func(s *models.WorkingHours) { s.Holidays = []string{"12/25/2025"} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := models.DefaultWorkingHours()
+			tt.mutate(settings)
+			_, err := NewCalendar(settings)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrInvalidWorkingHours))
+		})
+	}
+
+	_, err := NewCalendar(models.DefaultWorkingHours())
+	require.NoError(t, err)
+}
+
+func TestCalendar_IsBusinessDay(t *testing.T) {
+	calendar := newTestCalendar(t, func(s *models.WorkingHours) {
+		s.Holidays = []string{"2025-12-25"}
+	})
+
+	require.True(t, calendar.IsBusinessDay(newYork(t, "2025-12-22 10:00")))  // Monday
+	require.False(t, calendar.IsBusinessDay(newYork(t, "2025-12-20 10:00"))) // Saturday
+	require.False(t, calendar.IsBusinessDay(newYork(t, "2025-12-25 10:00"))) // Holiday
+
+	// 02:00 UTC on Tuesday is still Monday evening in New York
+	require.True(t, calendar.IsBusinessDay(time.Date(2025, 12, 23, 2, 0, 0, 0, time.UTC)))
+	require.False(t, calendar.IsBusinessDay(time.Date(2025, 12, 21, 2, 0, 0, 0, time.UTC)))
+}
+
+func TestCalendar_IsWorkingTime(t *testing.T) {
+	calendar := newTestCalendar(t, nil)
+
+	require.False(t, calendar.IsWorkingTime(newYork(t, "2025-12-22 08:59")))
+	require.True(t, calendar.IsWorkingTime(newYork(t, "2025-12-22 09:00")))
+	require.True(t, calendar.IsWorkingTime(newYork(t, "2025-12-22 16:59")))
+	require.False(t, calendar.IsWorkingTime(newYork(t, "2025-12-22 17:00")))
+	require.False(t, calendar.IsWorkingTime(newYork(t, "2025-12-20 12:00")))
+}
+
+func TestCalendar_NextBusinessDayStart(t *testing.T) {
+	calendar := newTestCalendar(t, func(s *models.WorkingHours) {
+		s.Holidays = []string{"2025-12-25", "2025-12-26"}
+	})
+
+	tests := []struct {
+		name string
+		from string
+		want string
+	}{
+		{name: "weekday", from: "2025-12-22 14:00", want: "2025-12-23 09:00"},
+		{name: "before start still moves to the next day", from: "2025-12-22 07:00",
+			want: "2025-12-23 09:00"},
+		{name: "friday skips the weekend", from: "2025-12-19 16:00", want: "2025-12-22 09:00"},
+		{name: "saturday", from: "2025-12-20 11:00", want: "2025-12-22 09:00"},
+		{name: "holidays and weekend are skipped", from: "2025-12-24 12:00",
+			want: "2025-12-29 09:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calendar.NextBusinessDayStart(newYork(t, tt.from))
+			require.True(t, newYork(t, tt.want).Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestCalendar_NextBusinessDayStart_AcrossDST(t *testing.T) {
+	calendar := newTestCalendar(t, nil)
+
+	// Clocks go forward on Sunday 2025-03-09; Monday still starts at 09:00 local time
+	got := calendar.NextBusinessDayStart(newYork(t, "2025-03-07 12:00"))
+	require.True(t, newYork(t, "2025-03-10 09:00").Equal(got), "got %s", got)
+}
+
+func TestCalendar_AddBusinessDays(t *testing.T) {
+	calendar := newTestCalendar(t, func(s *models.WorkingHours) {
+		s.Holidays = []string{"2025-12-25"}
+	})
+
+	tests := []struct {
+		name string
+		from string
+		days int
+		want string
+	}{
+		{name: "zero days", from: "2025-12-22 10:00", days: 0, want: "2025-12-22 10:00"},
+		{name: "within the week", from: "2025-12-15 10:00", days: 2, want: "2025-12-17 10:00"},
+		{name: "friday plus two", from: "2025-12-19 15:30", days: 2, want: "2025-12-23 15:30"},
+		{name: "over a holiday", from: "2025-12-24 10:00", days: 1, want: "2025-12-26 10:00"},
+		{name: "from a weekend", from: "2025-12-20 10:00", days: 1, want: "2025-12-22 10:00"},
+		{name: "backwards", from: "2025-12-22 10:00", days: -2, want: "2025-12-18 10:00"},
+		{name: "backwards over a holiday", from: "2025-12-26 10:00", days: -1,
+			want: "2025-12-24 10:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calendar.AddBusinessDays(newYork(t, tt.from), tt.days)
+			require.True(t, newYork(t, tt.want).Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestCalendar_Disabled(t *testing.T) {
+	calendar := newTestCalendar(t, func(s *models.WorkingHours) {
+		s.Enabled = false
+		s.Holidays = []string{"2025-12-20"}
+	})
+
+	saturday := newYork(t, "2025-12-20 10:00")
+	require.True(t, calendar.IsBusinessDay(saturday))
+	require.True(t, calendar.IsWorkingTime(newYork(t, "2025-12-20 23:00")))
+	require.True(t, newYork(t, "2025-12-22 10:00").Equal(calendar.AddBusinessDays(saturday, 2)))
+	require.True(t, newYork(t, "2025-12-17 10:00").Equal(calendar.AddBusinessDays(saturday, -3)))
+	require.True(t, newYork(t, "2025-12-21 09:00").Equal(calendar.NextBusinessDayStart(saturday)))
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/workhours/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/workhours/service.go -destination=internal/core/workhours/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	workhours "github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkingHoursService is a mock of WorkingHoursService interface.
+type MockWorkingHoursService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkingHoursServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkingHoursServiceMockRecorder is the mock recorder for MockWorkingHoursService.
+type MockWorkingHoursServiceMockRecorder struct {
+	mock *MockWorkingHoursService
+}
+
+// NewMockWorkingHoursService creates a new mock instance.
+func NewMockWorkingHoursService(ctrl *gomock.Controller) *MockWorkingHoursService {
+	mock := &MockWorkingHoursService{ctrl: ctrl}
+	mock.recorder = &MockWorkingHoursServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkingHoursService) EXPECT() *MockWorkingHoursServiceMockRecorder {
+	return m.recorder
+}
+
+// Calendar mocks base method.
+func (m *MockWorkingHoursService) Calendar(ctx context.Context, userID string) (*workhours.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calendar", ctx, userID)
+	ret0, _ := ret[0].(*workhours.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calendar indicates an expected call of Calendar.
+func (mr *MockWorkingHoursServiceMockRecorder) Calendar(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calendar", reflect.TypeOf((*MockWorkingHoursService)(nil).Calendar), ctx, userID)
+}
+
+// GetWorkingHours mocks base method.
+func (m *MockWorkingHoursService) GetWorkingHours(ctx context.Context, userID string) (*models.WorkingHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkingHours", ctx, userID)
+	ret0, _ := ret[0].(*models.WorkingHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkingHours indicates an expected call of GetWorkingHours.
+func (mr *MockWorkingHoursServiceMockRecorder) GetWorkingHours(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkingHours", reflect.TypeOf((*MockWorkingHoursService)(nil).GetWorkingHours), ctx, userID)
+}
+
+// UpdateWorkingHours mocks base method.
+func (m *MockWorkingHoursService) UpdateWorkingHours(ctx context.Context, userID string, settings *models.WorkingHours) (*models.WorkingHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkingHours", ctx, userID, settings)
+	ret0, _ := ret[0].(*models.WorkingHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkingHours indicates an expected call of UpdateWorkingHours.
+func (mr *MockWorkingHoursServiceMockRecorder) UpdateWorkingHours(ctx, userID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkingHours", reflect.TypeOf((*MockWorkingHoursService)(nil).UpdateWorkingHours), ctx, userID, settings)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package workhours provides the user's working hours and business-day calculations.
+package workhours
+
+import (
+	"context"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// WorkingHoursService is the interface for the working hours service.
+type WorkingHoursService interface {
+	GetWorkingHours(ctx context.Context, userID string) (*models.WorkingHours, error)
+	UpdateWorkingHours(
+		ctx context.Context,
+		userID string,
+		settings *models.WorkingHours,
+	) (*models.WorkingHours, error)
+	// Calendar returns a calendar for the user's current working hours. Snooze presets,
+	// escalation windows and digests should use it for any "business day" arithmetic.
+	Calendar(ctx context.Context, userID string) (*Calendar, error)
+}
+
+// Service provides business logic for working hours
+type Service struct {
+	queries db.Store
+}
+
+// NewService constructs a Service backed by the provided queries
+func NewService(queries db.Store) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workhours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadWorkingHours   = errors.New("failed to load working hours")
+	ErrFailedToUpdateWorkingHours = errors.New("failed to update working hours")
+	ErrInvalidWorkingHours        = errors.New("invalid working hours")
+)
+
+// GetWorkingHours returns the user's working hours, or the defaults if none are saved
+func (s *Service) GetWorkingHours(
+	ctx context.Context,
+	userID string,
+) (*models.WorkingHours, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	user, err := s.queries.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultWorkingHours(), nil
+		}
+		return nil, errors.Join(ErrFailedToLoadWorkingHours, err)
+	}
+
+	if !user.WorkingHours.Valid {
+		return models.DefaultWorkingHours(), nil
+	}
+	settings, err := models.WorkingHoursFromJSON(user.WorkingHours.RawMessage)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadWorkingHours, err)
+	}
+	return settings, nil
+}
+
+// UpdateWorkingHours validates and saves the user's working hours
+func (s *Service) UpdateWorkingHours(
+	ctx context.Context,
+	userID string,
+	settings *models.WorkingHours,
+) (*models.WorkingHours, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	if _, err := NewCalendar(settings); err != nil {
+		return nil, err
+	}
+
+	data, err := settings.ToJSON()
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateWorkingHours, err)
+	}
+
+	_, err = s.queries.UpdateUserWorkingHours(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFailedToUpdateWorkingHours, err)
+	}
+	return settings, nil
+}
+
+// Calendar returns a calendar for the user's working hours. Saved settings that no longer
+// parse (for example a time zone the system doesn't know) fall back to the defaults.
+func (s *Service) Calendar(ctx context.Context, userID string) (*Calendar, error) {
+	settings, err := s.GetWorkingHours(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := NewCalendar(settings)
+	if err != nil {
+		return NewCalendar(models.DefaultWorkingHours())
+	}
+	return calendar, nil
+}
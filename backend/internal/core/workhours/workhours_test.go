@@ -0,0 +1,158 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workhours
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestService_GetWorkingHours(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+		check     func(*testing.T, *models.WorkingHours)
+	}{
+		{
+			name: "no saved settings returns defaults",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+			},
+			check: func(t *testing.T, s *models.WorkingHours) {
+				require.Equal(t, models.DefaultWorkingHours(), s)
+			},
+		},
+		{
+			name: "no user returns defaults",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetUser(gomock.Any()).Return(db.User{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, s *models.WorkingHours) {
+				require.False(t, s.Enabled)
+			},
+		},
+		{
+			name: "saved settings are returned",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetUser(gomock.Any()).Return(db.User{
+					WorkingHours: db.NullRawMessage{
+						RawMessage: json.RawMessage(
+							`{"enabled":true,"days":[1,2,3,4],"start":"08:00","end":"16:00",` +
+								`"holidays":["2025-12-25"]}`,
+						),
+						Valid: true,
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, s *models.WorkingHours) {
+				require.True(t, s.Enabled)
+				require.Equal(t, []int{1, 2, 3, 4}, s.Days)
+				require.Equal(t, "08:00", s.Start)
+				require.Equal(t, []string{"2025-12-25"}, s.Holidays)
+			},
+		},
+		{
+			name: "database error is wrapped",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetUser(gomock.Any()).Return(db.User{}, errors.New("database is locked"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			settings, err := NewService(mockStore).GetWorkingHours(context.Background(), "user")
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToLoadWorkingHours))
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, settings)
+		})
+	}
+}
+
+func TestService_UpdateWorkingHours(t *testing.T) {
+	t.Run("valid settings are saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+
+		settings := models.DefaultWorkingHours()
+		settings.Enabled = true
+		mockStore.EXPECT().
+			UpdateUserWorkingHours(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, data db.NullRawMessage) (db.User, error) {
+				require.True(t, data.Valid)
+				require.JSONEq(t,
+					`{"enabled":true,"days":[1,2,3,4,5],"start":"09:00","end":"17:00",`+
+						`"holidays":[]}`,
+					string(data.RawMessage))
+				return db.User{}, nil
+			})
+
+		saved, err := NewService(mockStore).UpdateWorkingHours(
+			context.Background(), "user", settings,
+		)
+		require.NoError(t, err)
+		require.Equal(t, settings, saved)
+	})
+
+	t.Run("invalid settings are rejected without saving", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+
+		settings := models.DefaultWorkingHours()
+		settings.Start = "18:00"
+
+		_, err := NewService(mockStore).UpdateWorkingHours(context.Background(), "user", settings)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrInvalidWorkingHours))
+	})
+}
+
+func TestService_Calendar_FallsBackToDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		WorkingHours: db.NullRawMessage{
+			RawMessage: json.RawMessage(
+				`{"enabled":true,"days":[1],"start":"09:00","end":"17:00","timezone":"Mars/Base"}`,
+			),
+			Valid: true,
+		},
+	}, nil)
+
+	calendar, err := NewService(mockStore).Calendar(context.Background(), "user")
+	require.NoError(t, err)
+	require.False(t, calendar.enabled)
+}
@@ -0,0 +1,893 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package anonymize produces copies of an Octobud database that are safe to attach
+// to bug reports. Titles, logins, repository names and user IDs are replaced with
+// consistent hashes and raw GitHub payloads are dropped, while triage state, subject
+// types/states and the relationships between rows are preserved.
+package anonymize
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrOutputExists is returned when the output database already exists
+var ErrOutputExists = errors.New("output database already exists")
+
+// userScopedTables lists every table with a user_id column. Notification revisions come
+// first: rewriting notifications and tag assignments bumps the revision under the new ID.
+var userScopedTables = []string{
+	"notification_revisions",
+	"repositories",
+	"repository_aliases",
+	"pull_requests",
+	"notifications",
+	"tags",
+	"tag_assignments",
+	"view_groups",
+	"views",
+	"rules",
+	"sync_state",
+	"notification_alerts",
+	"rule_evaluations",
+	"repository_settings",
+	"notification_events",
+	"bulk_operations",
+	"bulk_operation_states",
+}
+
+// queryTermPattern matches query terms whose values name repositories or people
+var queryTermPattern = regexp.MustCompile(
+	`(?i)\b(repo|repository|org|author|account|branch|reviewer|team-review-requested):("[^"]*"|[^\s()]+)`,
+)
+
+// Result summarizes what was anonymized
+type Result struct {
+	Users           int64
+	Repositories    int64
+	PullRequests    int64
+	Notifications   int64
+	Queries         int64
+	JobsDeleted     int64
+	ProfilesDeleted int64
+	RepliesDeleted  int64
+	RestoresDeleted int64
+	AccountsDeleted int64
+	AccessDeleted   int64
+	OrgsDeleted     int64
+	HooksDeleted    int64
+	TokensDeleted   int64
+	SharesDeleted   int64
+}
+
+// CopyDatabase writes a consistent snapshot of the database at srcPath to dstPath.
+// The source is only read, so this is safe to run while Octobud is open.
+func CopyDatabase(ctx context.Context, srcPath, dstPath string) error {
+	if _, err := os.Stat(dstPath); err == nil {
+		return ErrOutputExists
+	}
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("failed to open source database: %w", err)
+	}
+
+	src, err := sql.Open("sqlite", "file:"+srcPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open source database: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	if _, err := src.ExecContext(ctx, "VACUUM INTO ?", dstPath); err != nil {
+		return fmt.Errorf("failed to copy database: %w", err)
+	}
+	return nil
+}
+
+// Anonymizer rewrites sensitive columns of an Octobud database in place.
+// The same input always maps to the same output within one Anonymizer, so a
+// repository keeps a single anonymized name across notifications, aliases and
+// saved queries.
+type Anonymizer struct {
+	key []byte
+}
+
+// New creates an Anonymizer keyed with salt. If salt is empty a random key is used,
+// so anonymized names can't be matched against a list of known repositories.
+func New(salt string) (*Anonymizer, error) {
+	if salt != "" {
+		return &Anonymizer{key: []byte(salt)}, nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate anonymization key: %w", err)
+	}
+	return &Anonymizer{key: key}, nil
+}
+
+func (a *Anonymizer) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// hash returns a short stable token for value, namespaced by kind
+func (a *Anonymizer) hash(kind, value string) string {
+	return hex.EncodeToString(a.sum(kind, value))[:10]
+}
+
+// hashID maps a value to a positive integer that fits in a JavaScript number
+func (a *Anonymizer) hashID(kind, value string) int64 {
+	return int64(binary.BigEndian.Uint64(a.sum(kind, value)[:8]) >> 12)
+}
+
+func (a *Anonymizer) nullableID(kind string, id sql.NullInt64) sql.NullInt64 {
+	if !id.Valid {
+		return id
+	}
+	return sql.NullInt64{Int64: a.hashID(kind, strconv.FormatInt(id.Int64, 10)), Valid: true}
+}
+
+func (a *Anonymizer) nullableBranch(name sql.NullString) sql.NullString {
+	if !name.Valid {
+		return name
+	}
+	return sql.NullString{String: a.Branch(name.String), Valid: true}
+}
+
+func (a *Anonymizer) nullableLogin(login sql.NullString) sql.NullString {
+	if !login.Valid {
+		return login
+	}
+	return sql.NullString{String: a.Login(login.String), Valid: true}
+}
+
+// Login anonymizes a GitHub user or organization login
+func (a *Anonymizer) Login(login string) string {
+	if login == "" {
+		return ""
+	}
+	return "user-" + a.hash("login", strings.ToLower(login))
+}
+
+// RepoName anonymizes the name part of a repository
+func (a *Anonymizer) RepoName(name string) string {
+	return "repo-" + a.hash("repo", strings.ToLower(name))
+}
+
+// FullName anonymizes an "owner/name" repository name
+func (a *Anonymizer) FullName(fullName string) string {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return a.RepoName(fullName)
+	}
+	return a.Login(owner) + "/" + a.RepoName(name)
+}
+
+// UserID anonymizes the GitHub user ID that scopes every row
+func (a *Anonymizer) UserID(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	return strconv.FormatInt(a.hashID("user_id", userID), 10)
+}
+
+// Title anonymizes a notification or pull request title. The subject type is kept
+// as a prefix so lists stay readable.
+func (a *Anonymizer) Title(subjectType, title string) string {
+	if subjectType == "" {
+		subjectType = "Item"
+	}
+	return subjectType + " " + a.hash("title", title)
+}
+
+// Team anonymizes an "org/team" name, keeping the org consistent with repository owners
+func (a *Anonymizer) Team(name string) string {
+	org, slug, ok := strings.Cut(name, "/")
+	if !ok {
+		return "team-" + a.hash("team", strings.ToLower(name))
+	}
+	return a.Login(org) + "/team-" + a.hash("team", strings.ToLower(slug))
+}
+
+// Branch anonymizes a git branch name. Common default branch names and the first
+// segment of slash-separated names are kept, so release/1.2 becomes release/branch-…
+// and branch:release/* filters still match.
+func (a *Anonymizer) Branch(name string) string {
+	switch strings.ToLower(name) {
+	case "", "*", "main", "master", "develop", "trunk":
+		return name
+	}
+	prefix, rest, ok := strings.Cut(name, "/")
+	if !ok {
+		return "branch-" + a.hash("branch", name)
+	}
+	if rest == "*" {
+		return name
+	}
+	return prefix + "/branch-" + a.hash("branch", rest)
+}
+
+// Query rewrites repo:, org:, author:, account:, branch:, reviewer: and team-review-requested:
+// values in a saved query. Everything else, including state and tag filters, is kept as written.
+func (a *Anonymizer) Query(query string) string {
+	return queryTermPattern.ReplaceAllStringFunc(query, func(term string) string {
+		field, value, _ := strings.Cut(term, ":")
+		quoted := strings.HasPrefix(value, `"`)
+		value = strings.Trim(value, `"`)
+
+		values := strings.Split(value, ",")
+		for i, v := range values {
+			switch {
+			case v == "":
+			case strings.EqualFold(field, "org"), strings.EqualFold(field, "author"),
+				strings.EqualFold(field, "account"), strings.EqualFold(field, "reviewer"):
+				values[i] = a.Login(v)
+			case strings.EqualFold(field, "team-review-requested"):
+				values[i] = a.Team(v)
+			case strings.EqualFold(field, "branch"):
+				values[i] = a.Branch(v)
+			default:
+				values[i] = a.FullName(v)
+			}
+		}
+
+		value = strings.Join(values, ",")
+		if quoted {
+			value = `"` + value + `"`
+		}
+		return field + ":" + value
+	})
+}
+
+// Anonymize rewrites the database. It must be run on a copy, never the live database.
+func (a *Anonymizer) Anonymize(ctx context.Context, dbConn *sql.DB) (*Result, error) {
+	// Make sure overwritten values aren't left in free pages
+	if _, err := dbConn.ExecContext(ctx, "PRAGMA secure_delete = ON"); err != nil {
+		return nil, fmt.Errorf("failed to enable secure delete: %w", err)
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result := &Result{}
+	steps := []func(context.Context, *sql.Tx, *Result) error{
+		a.anonymizeRepositories,
+		a.anonymizeRepositoryAliases,
+		a.anonymizePullRequests,
+		a.anonymizeNotifications,
+		a.rebuildSearchIndex,
+		a.anonymizeSavedQueries,
+		a.anonymizeUsers,
+		a.clearJobs,
+		a.clearAuthorProfiles,
+		a.clearSavedReplies,
+		a.clearTriageRestores,
+		a.clearLinkedAccounts,
+		a.clearRecentAccess,
+		a.clearOrgOffboardings,
+		a.clearIntegrations,
+		a.clearAPITokens,
+		a.clearViewShares,
+	}
+	for _, step := range steps {
+		if err := step(ctx, tx, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Rebuild the file so none of the original content remains
+	if _, err := dbConn.ExecContext(ctx, "VACUUM"); err != nil {
+		return nil, fmt.Errorf("failed to vacuum anonymized database: %w", err)
+	}
+
+	return result, nil
+}
+
+func (a *Anonymizer) anonymizeRepositories(
+	ctx context.Context,
+	tx *sql.Tx,
+	result *Result,
+) error {
+	type repo struct {
+		id       int64
+		fullName string
+		ownerID  sql.NullInt64
+		githubID sql.NullInt64
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT id, full_name, owner_id, github_id FROM repositories")
+	if err != nil {
+		return fmt.Errorf("failed to list repositories: %w", err)
+	}
+	var repos []repo
+	for rows.Next() {
+		var r repo
+		if err := rows.Scan(&r.id, &r.fullName, &r.ownerID, &r.githubID); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		repos = append(repos, r)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, r := range repos {
+		fullName := a.FullName(r.fullName)
+		owner, name, _ := strings.Cut(fullName, "/")
+		_, err := tx.ExecContext(ctx, `
+			UPDATE repositories SET
+				full_name = ?,
+				name = ?,
+				owner_login = ?,
+				owner_id = ?,
+				github_id = ?,
+				node_id = NULL,
+				description = NULL,
+				html_url = ?,
+				owner_avatar_url = NULL,
+				owner_html_url = ?,
+				raw = NULL
+			WHERE id = ?`,
+			fullName,
+			name,
+			owner,
+			a.nullableID("owner_id", r.ownerID),
+			a.nullableID("repo_id", r.githubID),
+			"https://github.com/"+fullName,
+			"https://github.com/"+owner,
+			r.id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize repository %d: %w", r.id, err)
+		}
+		result.Repositories++
+	}
+	return nil
+}
+
+func (a *Anonymizer) anonymizeRepositoryAliases(
+	ctx context.Context,
+	tx *sql.Tx,
+	_ *Result,
+) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, full_name FROM repository_aliases")
+	if err != nil {
+		return fmt.Errorf("failed to list repository aliases: %w", err)
+	}
+	aliases := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var fullName string
+		if err := rows.Scan(&id, &fullName); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		aliases[id] = fullName
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for id, fullName := range aliases {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE repository_aliases SET full_name = ? WHERE id = ?",
+			a.FullName(fullName), id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize repository alias %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (a *Anonymizer) anonymizePullRequests(
+	ctx context.Context,
+	tx *sql.Tx,
+	result *Result,
+) error {
+	type pr struct {
+		id        int64
+		title     string
+		author    string
+		authorID  sql.NullInt64
+		githubID  sql.NullInt64
+		reviewers sql.NullString
+		teams     sql.NullString
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, COALESCE(title, ''), COALESCE(author_login, ''), author_id, github_id,
+			requested_reviewers, requested_teams
+		FROM pull_requests`)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	var prs []pr
+	for rows.Next() {
+		var p pr
+		if err := rows.Scan(
+			&p.id, &p.title, &p.author, &p.authorID, &p.githubID, &p.reviewers, &p.teams,
+		); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		prs = append(prs, p)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, p := range prs {
+		reviewers, err := a.loginList(p.reviewers)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize pull request %d reviewers: %w", p.id, err)
+		}
+		teams, err := a.nameList(p.teams, a.Team)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize pull request %d teams: %w", p.id, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE pull_requests SET
+				title = ?,
+				author_login = NULLIF(?, ''),
+				author_id = ?,
+				github_id = ?,
+				node_id = NULL,
+				raw = NULL,
+				requested_reviewers = ?,
+				requested_teams = ?
+			WHERE id = ?`,
+			a.Title("PullRequest", p.title),
+			a.Login(p.author),
+			a.nullableID("account_id", p.authorID),
+			a.nullableID("pr_id", p.githubID),
+			reviewers,
+			teams,
+			p.id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize pull request %d: %w", p.id, err)
+		}
+		result.PullRequests++
+	}
+	return nil
+}
+
+// anonymizeNotifications must run after anonymizeRepositories, since URLs are
+// rebuilt from the already anonymized repository name
+func (a *Anonymizer) anonymizeNotifications(
+	ctx context.Context,
+	tx *sql.Tx,
+	result *Result,
+) error {
+	type notification struct {
+		id            int64
+		subjectType   string
+		subjectTitle  string
+		author        string
+		authorID      sql.NullInt64
+		subjectNumber sql.NullInt64
+		repoFullName  string
+		headBranch    sql.NullString
+		baseBranch    sql.NullString
+		account       sql.NullString
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT n.id, n.subject_type, n.subject_title, COALESCE(n.author_login, ''),
+			n.author_id, n.subject_number, r.full_name, n.head_branch, n.base_branch,
+			n.account
+		FROM notifications n
+		JOIN repositories r ON r.id = n.repository_id`)
+	if err != nil {
+		return fmt.Errorf("failed to list notifications: %w", err)
+	}
+	var notifications []notification
+	for rows.Next() {
+		var n notification
+		if err := rows.Scan(
+			&n.id,
+			&n.subjectType,
+			&n.subjectTitle,
+			&n.author,
+			&n.authorID,
+			&n.subjectNumber,
+			&n.repoFullName,
+			&n.headBranch,
+			&n.baseBranch,
+			&n.account,
+		); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	for _, n := range notifications {
+		subjectURL, htmlURL := subjectURLs(n.repoFullName, n.subjectType, n.subjectNumber)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE notifications SET
+				subject_title = ?,
+				subject_url = ?,
+				subject_latest_comment_url = NULL,
+				github_url = ?,
+				github_subscription_url = NULL,
+				author_login = NULLIF(?, ''),
+				author_id = ?,
+				payload = NULL,
+				subject_raw = NULL,
+				head_branch = ?,
+				base_branch = ?,
+				account = ?
+			WHERE id = ?`,
+			a.Title(n.subjectType, n.subjectTitle),
+			subjectURL,
+			htmlURL,
+			a.Login(n.author),
+			a.nullableID("account_id", n.authorID),
+			a.nullableBranch(n.headBranch),
+			a.nullableBranch(n.baseBranch),
+			a.nullableLogin(n.account),
+			n.id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize notification %d: %w", n.id, err)
+		}
+		result.Notifications++
+	}
+	return nil
+}
+
+// rebuildSearchIndex reindexes notifications from their anonymized titles, dropping
+// indexed descriptions and comments, then merges the index so the old entries are gone
+func (a *Anonymizer) rebuildSearchIndex(ctx context.Context, tx *sql.Tx, _ *Result) error {
+	statements := []string{
+		"DELETE FROM notification_search",
+		`INSERT INTO notification_search (rowid, title, body, comment)
+			SELECT id, subject_title, '', '' FROM notifications`,
+		"INSERT INTO notification_search (notification_search) VALUES ('optimize')",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+	}
+	return nil
+}
+
+// anonymizeSavedQueries rewrites view and rule queries. Tag, view and rule names
+// are kept since they're usually needed to reproduce filtering issues. Rule conditions
+// are removed, since any string in them could name a repository or person.
+func (a *Anonymizer) anonymizeSavedQueries(
+	ctx context.Context,
+	tx *sql.Tx,
+	result *Result,
+) error {
+	for _, table := range []string{"views", "rules"} {
+		queries := map[string]string{}
+		rows, err := tx.QueryContext(ctx,
+			"SELECT id, query FROM "+table+" WHERE query IS NOT NULL AND query != ''")
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", table, err)
+		}
+		for rows.Next() {
+			var id, query string
+			if err := rows.Scan(&id, &query); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			queries[id] = query
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		for id, query := range queries {
+			rewritten := a.Query(query)
+			if rewritten == query {
+				continue
+			}
+			_, err := tx.ExecContext(ctx,
+				"UPDATE "+table+" SET query = ? WHERE id = ?", rewritten, id)
+			if err != nil {
+				return fmt.Errorf("failed to anonymize %s query: %w", table, err)
+			}
+			result.Queries++
+		}
+	}
+
+	cleared, err := tx.ExecContext(ctx, "UPDATE rules SET condition = NULL WHERE condition IS NOT NULL")
+	if err != nil {
+		return fmt.Errorf("failed to remove rule conditions: %w", err)
+	}
+	n, err := cleared.RowsAffected()
+	if err != nil {
+		return err
+	}
+	result.Queries += n
+	return nil
+}
+
+// anonymizeUsers replaces the user's identity and clears the stored token.
+// The GitHub user ID scopes every row, so it is rewritten in all tables.
+func (a *Anonymizer) anonymizeUsers(ctx context.Context, tx *sql.Tx, result *Result) error {
+	var userID, username sql.NullString
+	err := tx.QueryRowContext(ctx,
+		"SELECT github_user_id, github_username FROM users WHERE id = 1",
+	).Scan(&userID, &username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to read user: %w", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET
+			github_user_id = NULLIF(?, ''),
+			github_username = NULLIF(?, ''),
+			github_token_encrypted = NULL
+		WHERE id = 1`,
+		a.UserID(userID.String),
+		a.Login(username.String),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	result.Users++
+
+	if err := a.anonymizeTeamSettings(ctx, tx); err != nil {
+		return err
+	}
+
+	if userID.String == "" {
+		return nil
+	}
+	for _, table := range userScopedTables {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE "+table+" SET user_id = ? WHERE user_id = ?",
+			a.UserID(userID.String), userID.String,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize %s user IDs: %w", table, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE sync_state SET last_notification_etag = NULL",
+	); err != nil {
+		return fmt.Errorf("failed to clear sync etag: %w", err)
+	}
+	return nil
+}
+
+// anonymizeTeamSettings replaces the logins of configured team members
+func (a *Anonymizer) anonymizeTeamSettings(ctx context.Context, tx *sql.Tx) error {
+	var raw sql.NullString
+	err := tx.QueryRowContext(ctx, "SELECT team_settings FROM users WHERE id = 1").Scan(&raw)
+	if err != nil {
+		return fmt.Errorf("failed to read team settings: %w", err)
+	}
+	if !raw.Valid || raw.String == "" {
+		return nil
+	}
+
+	var settings struct {
+		Members []string `json:"members"`
+	}
+	if err := json.Unmarshal([]byte(raw.String), &settings); err != nil {
+		return fmt.Errorf("failed to parse team settings: %w", err)
+	}
+	for i, member := range settings.Members {
+		settings.Members[i] = a.Login(member)
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE users SET team_settings = ? WHERE id = 1", string(data),
+	); err != nil {
+		return fmt.Errorf("failed to anonymize team settings: %w", err)
+	}
+	return nil
+}
+
+// loginList anonymizes a JSON array of logins, as stored for requested reviewers
+func (a *Anonymizer) loginList(raw sql.NullString) (sql.NullString, error) {
+	return a.nameList(raw, a.Login)
+}
+
+// nameList anonymizes each name in a JSON array of names
+func (a *Anonymizer) nameList(
+	raw sql.NullString,
+	anonymize func(string) string,
+) (sql.NullString, error) {
+	if !raw.Valid || raw.String == "" {
+		return raw, nil
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(raw.String), &names); err != nil {
+		return sql.NullString{}, err
+	}
+	for i, name := range names {
+		names[i] = anonymize(name)
+	}
+	data, err := json.Marshal(names)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// clearJobs drops queued jobs, whose payloads contain raw notification data
+func (a *Anonymizer) clearJobs(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM jobs")
+	if err != nil {
+		return fmt.Errorf("failed to clear jobs: %w", err)
+	}
+	result.JobsDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearAuthorProfiles drops the author profile cache; it's refetched on demand
+func (a *Anonymizer) clearAuthorProfiles(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM author_profiles")
+	if err != nil {
+		return fmt.Errorf("failed to clear author profiles: %w", err)
+	}
+	result.ProfilesDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearSavedReplies drops imported saved replies, whose bodies are the user's own text;
+// they're imported again on demand
+func (a *Anonymizer) clearSavedReplies(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM saved_replies")
+	if err != nil {
+		return fmt.Errorf("failed to clear saved replies: %w", err)
+	}
+	result.RepliesDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearTriageRestores drops triage state staged by a backup import that hasn't matched a
+// notification yet
+func (a *Anonymizer) clearTriageRestores(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM triage_restores")
+	if err != nil {
+		return fmt.Errorf("failed to clear triage restores: %w", err)
+	}
+	result.RestoresDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearLinkedAccounts drops linked GitHub accounts along with their stored tokens
+func (a *Anonymizer) clearLinkedAccounts(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM linked_accounts")
+	if err != nil {
+		return fmt.Errorf("failed to clear linked accounts: %w", err)
+	}
+	result.AccountsDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearRecentAccess drops the record of recently opened lists and notifications, whose
+// queries name repositories and people
+func (a *Anonymizer) clearRecentAccess(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM recent_access")
+	if err != nil {
+		return fmt.Errorf("failed to clear recent access: %w", err)
+	}
+	result.AccessDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearOrgOffboardings drops the record of organizations the user left, which are named
+// in the clear
+func (a *Anonymizer) clearOrgOffboardings(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM org_offboardings")
+	if err != nil {
+		return fmt.Errorf("failed to clear org offboardings: %w", err)
+	}
+	result.OrgsDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearIntegrations drops webhooks, whose URLs and secrets belong to the user, along with
+// the delivery log
+func (a *Anonymizer) clearIntegrations(ctx context.Context, tx *sql.Tx, result *Result) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM integration_deliveries"); err != nil {
+		return fmt.Errorf("failed to clear integration deliveries: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM integrations")
+	if err != nil {
+		return fmt.Errorf("failed to clear integrations: %w", err)
+	}
+	result.HooksDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearAPITokens drops API tokens so a shared copy can't be used to sign in to the
+// original server, even by someone who knows a token
+func (a *Anonymizer) clearAPITokens(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM api_tokens")
+	if err != nil {
+		return fmt.Errorf("failed to clear API tokens: %w", err)
+	}
+	result.TokensDeleted, err = res.RowsAffected()
+	return err
+}
+
+// clearViewShares drops view shares, which name the other users of a multi-user server
+func (a *Anonymizer) clearViewShares(ctx context.Context, tx *sql.Tx, result *Result) error {
+	res, err := tx.ExecContext(ctx, "DELETE FROM view_shares")
+	if err != nil {
+		return fmt.Errorf("failed to clear view shares: %w", err)
+	}
+	result.SharesDeleted, err = res.RowsAffected()
+	return err
+}
+
+// subjectURLs rebuilds the API and HTML URLs of a subject from its anonymized
+// repository so links keep their shape
+func subjectURLs(
+	repoFullName, subjectType string,
+	number sql.NullInt64,
+) (sql.NullString, sql.NullString) {
+	var path, htmlPath string
+	switch subjectType {
+	case "PullRequest":
+		path, htmlPath = "pulls", "pull"
+	case "Issue":
+		path, htmlPath = "issues", "issues"
+	case "Discussion":
+		path, htmlPath = "discussions", "discussions"
+	default:
+		return sql.NullString{}, sql.NullString{}
+	}
+	if !number.Valid {
+		return sql.NullString{}, sql.NullString{}
+	}
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/%d", repoFullName, path, number.Int64)
+	htmlURL := fmt.Sprintf("https://github.com/%s/%s/%d", repoFullName, htmlPath, number.Int64)
+	return sql.NullString{String: apiURL, Valid: true}, sql.NullString{String: htmlURL, Valid: true}
+}
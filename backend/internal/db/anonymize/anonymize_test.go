@@ -0,0 +1,333 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package anonymize_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/anonymize"
+	"github.com/octobud-hq/octobud/backend/internal/db/sqlite"
+
+	_ "modernc.org/sqlite"
+)
+
+func createSourceDatabase(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "octobud.db")
+	dbConn, err := db.OpenDatabase(path)
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	goose.SetBaseFS(sqlite.MigrationsFS)
+	require.NoError(t, goose.Up(dbConn, "migrations"))
+
+	statements := []string{
+		`INSERT INTO users (id, github_user_id, github_username, github_token_encrypted,
+				team_settings)
+			VALUES (1, '4242', 'secret-login', 'encrypted-token',
+				'{"members":["secret-teammate"]}')`,
+		`INSERT INTO repositories (id, user_id, github_id, name, full_name, owner_login,
+				description, raw)
+			VALUES (1, '4242', 99, 'secret-repo', 'acme-corp/secret-repo', 'acme-corp',
+				'Internal billing service', '{"private": true}')`,
+		`INSERT INTO repository_aliases (user_id, repository_id, full_name)
+			VALUES ('4242', 1, 'acme-corp/old-secret-repo')`,
+		`INSERT INTO pull_requests (id, user_id, repository_id, number, title, state,
+				author_login, raw, requested_reviewers, requested_teams)
+			VALUES (1, '4242', 1, 12, 'Fix billing leak', 'open', 'secret-login', '{}',
+				'["secret-teammate"]', '["acme-corp/billing-team"]')`,
+		`INSERT INTO notifications (user_id, github_id, repository_id, pull_request_id,
+				subject_type, subject_title, subject_url, reason, archived, starred,
+				subject_number, subject_state, payload, author_login, head_branch, base_branch)
+			VALUES ('4242', 'n1', 1, 1, 'PullRequest', 'Fix billing leak',
+				'https://api.github.com/repos/acme-corp/secret-repo/pulls/12', 'review_requested',
+				0, 1, 12, 'open', '{"secret": "payload"}', 'secret-login', 'fix/secret-branch',
+				'release/1.2')`,
+		`INSERT INTO notifications (user_id, github_id, repository_id, subject_type,
+				subject_title, reason, archived, subject_number, subject_state)
+			VALUES ('4242', 'n2', 1, 'Issue', 'Customer data export', 'mention', 1, 7,
+				'closed')`,
+		`INSERT INTO tags (id, user_id, name, slug) VALUES ('t1', '4242', 'Urgent', 'urgent')`,
+		`INSERT INTO tag_assignments (user_id, tag_id, entity_type, entity_id)
+			VALUES ('4242', 't1', 'notification', 1)`,
+		`INSERT INTO views (id, user_id, name, slug, query)
+			VALUES ('v1', '4242', 'Billing', 'billing',
+				'repo:acme-corp/secret-repo state:open author:secret-login')`,
+		`INSERT INTO rules (id, user_id, name, query, actions, condition, created_at, updated_at)
+			VALUES ('r1', '4242', 'Billing', 'is:unread', '{}', 'author == "secret-login"',
+				'2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO sync_state (user_id, last_notification_etag) VALUES ('4242', 'etag')`,
+		`INSERT INTO jobs (queue, payload) VALUES ('process_notification', '{"title": "secret"}')`,
+		`INSERT INTO author_profiles (user_id, login, name, avatar_url, type, fetched_at)
+			VALUES ('4242', 'secret-login', 'Secret Person', 'https://avatars.example.com/u/1',
+				'user', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO saved_replies (user_id, github_id, title, body, fetched_at)
+			VALUES ('4242', 'SR_1', 'Billing', 'See the secret-repo runbook',
+				'2025-01-01T00:00:00Z')`,
+		`INSERT INTO triage_restores (user_id, github_id, is_read, tag_ids)
+			VALUES ('4242', 'n9', 1, '["t1"]')`,
+		`INSERT INTO notification_alerts (user_id, notification_id, level, source)
+			VALUES ('4242', 1, 'desktop', 'default')`,
+		`INSERT INTO linked_accounts (user_id, github_user_id, login, token_encrypted)
+			VALUES ('4242', '5151', 'secret-work-login', 'encrypted-work-token')`,
+		`UPDATE notifications SET account = 'secret-work-login' WHERE github_id = 'n2'`,
+		`INSERT INTO recent_access (user_id, kind, target, accessed_at)
+			VALUES ('4242', 'query', 'repo:acme-corp/secret-repo', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO org_offboardings (user_id, org, action, notifications_affected, repositories)
+			VALUES ('4242', 'secret-org', 'archive', 3, 1)`,
+		`INSERT INTO integrations (id, user_id, name, format, url, secret_encrypted,
+				created_at, updated_at)
+			VALUES ('w1', '4242', 'Secret team', 'slack', 'https://hooks.slack.com/secret', 'enc',
+				'2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO integration_deliveries (user_id, integration_id, rule_name, github_id,
+				next_attempt_at, created_at)
+			VALUES ('4242', 'w1', 'Secret rule', 'n1', '2025-01-01T00:00:00Z',
+				'2025-01-01T00:00:00Z')`,
+		`INSERT INTO api_tokens (user_id, name, token_hash, token_prefix, scope, account,
+				created_at)
+			VALUES ('4242', 'Secret script', 'secret-hash', 'obt_abcd', 'read', '*',
+				'2025-01-01T00:00:00Z')`,
+		`INSERT INTO view_shares (user_id, view_id, grantee, permission, created_at)
+			VALUES ('4242', 'v1', 'secret-colleague', 'read', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO repository_settings (user_id, repository_id, default_snooze)
+			VALUES ('4242', 1, '1w')`,
+		`UPDATE notifications SET subject_raw = '{"body": "secret-description"}'
+			WHERE github_id = 'n2'`,
+		`UPDATE notification_search SET comment = 'secret-comment'
+			WHERE rowid = (SELECT id FROM notifications WHERE github_id = 'n1')`,
+	}
+	for _, stmt := range statements {
+		_, err := dbConn.Exec(stmt)
+		require.NoError(t, err, stmt)
+	}
+	return path
+}
+
+func TestAnonymize_ScramblesSensitiveDataAndKeepsState(t *testing.T) {
+	ctx := context.Background()
+	srcPath := createSourceDatabase(t)
+	dstPath := filepath.Join(t.TempDir(), "anonymized.db")
+
+	require.NoError(t, anonymize.CopyDatabase(ctx, srcPath, dstPath))
+
+	anonymizer, err := anonymize.New("test-salt")
+	require.NoError(t, err)
+
+	dbConn, err := db.OpenDatabase(dstPath)
+	require.NoError(t, err)
+
+	result, err := anonymizer.Anonymize(ctx, dbConn)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Repositories)
+	require.Equal(t, int64(1), result.PullRequests)
+	require.Equal(t, int64(2), result.Notifications)
+	require.Equal(t, int64(2), result.Queries)
+	require.Equal(t, int64(1), result.JobsDeleted)
+	require.Equal(t, int64(1), result.ProfilesDeleted)
+	require.Equal(t, int64(1), result.RepliesDeleted)
+	require.Equal(t, int64(1), result.RestoresDeleted)
+	require.Equal(t, int64(1), result.AccountsDeleted)
+	require.Equal(t, int64(1), result.AccessDeleted)
+	require.Equal(t, int64(1), result.OrgsDeleted)
+	require.Equal(t, int64(1), result.HooksDeleted)
+	require.Equal(t, int64(1), result.TokensDeleted)
+	require.Equal(t, int64(1), result.SharesDeleted)
+
+	anonUserID := anonymizer.UserID("4242")
+	anonRepo := anonymizer.FullName("acme-corp/secret-repo")
+
+	// Triage state and subject state are preserved
+	var archived, starred, open int
+	require.NoError(t, dbConn.QueryRow(`
+		SELECT SUM(archived), SUM(starred), SUM(subject_state = 'open')
+		FROM notifications WHERE user_id = ?`, anonUserID,
+	).Scan(&archived, &starred, &open))
+	require.Equal(t, 1, archived)
+	require.Equal(t, 1, starred)
+	require.Equal(t, 1, open)
+
+	// Relationships survive and names are consistent across tables
+	var repoName, aliasName string
+	require.NoError(t, dbConn.QueryRow(`
+		SELECT r.full_name, ra.full_name
+		FROM notifications n
+		JOIN repositories r ON r.id = n.repository_id
+		JOIN repository_aliases ra ON ra.repository_id = r.id
+		JOIN tag_assignments ta ON ta.entity_id = n.id
+		WHERE n.github_id = 'n1'`,
+	).Scan(&repoName, &aliasName))
+	require.Equal(t, anonRepo, repoName)
+	require.Equal(t, anonymizer.FullName("acme-corp/old-secret-repo"), aliasName)
+
+	var query string
+	require.NoError(t, dbConn.QueryRow("SELECT query FROM views WHERE id = 'v1'").Scan(&query))
+	require.Equal(
+		t,
+		"repo:"+anonRepo+" state:open author:"+anonymizer.Login("secret-login"),
+		query,
+	)
+	var condition sql.NullString
+	require.NoError(t, dbConn.QueryRow("SELECT condition FROM rules WHERE id = 'r1'").Scan(&condition))
+	require.False(t, condition.Valid)
+
+	var reviewers, teams, teamSettings string
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT requested_reviewers, requested_teams FROM pull_requests WHERE id = 1",
+	).Scan(&reviewers, &teams))
+	require.Equal(t, `["`+anonymizer.Login("secret-teammate")+`"]`, reviewers)
+	require.Equal(t, `["`+anonymizer.Team("acme-corp/billing-team")+`"]`, teams)
+	require.NotContains(t, teams, "billing-team")
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT team_settings FROM users WHERE id = 1",
+	).Scan(&teamSettings))
+	require.Equal(t, `{"members":["`+anonymizer.Login("secret-teammate")+`"]}`, teamSettings)
+
+	var headBranch, baseBranch string
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT head_branch, base_branch FROM notifications WHERE github_id = 'n1'",
+	).Scan(&headBranch, &baseBranch))
+	require.Equal(t, anonymizer.Branch("fix/secret-branch"), headBranch)
+	require.Regexp(t, `^release/branch-`, baseBranch)
+
+	var account string
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT account FROM notifications WHERE github_id = 'n2'",
+	).Scan(&account))
+	require.Equal(t, anonymizer.Login("secret-work-login"), account)
+
+	var subjectURL string
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT subject_url FROM notifications WHERE github_id = 'n1'",
+	).Scan(&subjectURL))
+	require.Equal(t, "https://api.github.com/repos/"+anonRepo+"/pulls/12", subjectURL)
+
+	// The search index follows the anonymized titles
+	var indexed int
+	require.NoError(t, dbConn.QueryRow(
+		"SELECT COUNT(*) FROM notification_search WHERE notification_search MATCH 'billing'",
+	).Scan(&indexed))
+	require.Zero(t, indexed)
+	require.NoError(t, dbConn.QueryRow("SELECT COUNT(*) FROM notification_search").Scan(&indexed))
+	require.Equal(t, 2, indexed)
+
+	require.NoError(t, dbConn.Close())
+
+	// Nothing sensitive is left anywhere in the file
+	data, err := os.ReadFile(dstPath)
+	require.NoError(t, err)
+	for _, secret := range []string{
+		"acme-corp",
+		"secret-repo",
+		"secret-login",
+		"secret-teammate",
+		"Fix billing leak",
+		"Customer data",
+		"encrypted-token",
+		"Secret Person",
+		`"secret": "payload"`,
+		"4242",
+		"secret-branch",
+		"secret-work-login",
+		"encrypted-work-token",
+		"secret-description",
+		"secret-comment",
+	} {
+		require.False(t, bytes.Contains(data, []byte(secret)), "found %q in file", secret)
+	}
+}
+
+func TestCopyDatabase_RefusesToOverwrite(t *testing.T) {
+	srcPath := createSourceDatabase(t)
+	dstPath := filepath.Join(t.TempDir(), "anonymized.db")
+	require.NoError(t, os.WriteFile(dstPath, []byte("existing"), 0o600))
+
+	err := anonymize.CopyDatabase(context.Background(), srcPath, dstPath)
+	require.ErrorIs(t, err, anonymize.ErrOutputExists)
+}
+
+func TestAnonymizer_Query(t *testing.T) {
+	anonymizer, err := anonymize.New("test-salt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "keeps non-identifying terms",
+			query:    "state:open is:unread tags:urgent",
+			expected: "state:open is:unread tags:urgent",
+		},
+		{
+			name:  "rewrites comma separated repos",
+			query: "repo:cli/cli,acme/api -org:acme",
+			expected: "repo:" + anonymizer.FullName("cli/cli") + "," +
+				anonymizer.FullName("acme/api") + " -org:" + anonymizer.Login("acme"),
+		},
+		{
+			name:     "rewrites branches but keeps prefixes and globs",
+			query:    "branch:release/*,fix/login",
+			expected: "branch:release/*," + anonymizer.Branch("fix/login"),
+		},
+		{
+			name:     "rewrites accounts",
+			query:    "account:octocat-work is:unread",
+			expected: "account:" + anonymizer.Login("octocat-work") + " is:unread",
+		},
+		{
+			name:  "rewrites reviewers and teams",
+			query: "reviewer:octocat -team-review-requested:acme/core",
+			expected: "reviewer:" + anonymizer.Login("octocat") +
+				" -team-review-requested:" + anonymizer.Team("acme/core"),
+		},
+		{
+			name:     "keeps quotes",
+			query:    `(author:"octocat") AND state:closed`,
+			expected: `(author:"` + anonymizer.Login("octocat") + `") AND state:closed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, anonymizer.Query(tt.query))
+		})
+	}
+}
+
+func TestAnonymizer_IsConsistentForSameSalt(t *testing.T) {
+	first, err := anonymize.New("salt")
+	require.NoError(t, err)
+	second, err := anonymize.New("salt")
+	require.NoError(t, err)
+	other, err := anonymize.New("")
+	require.NoError(t, err)
+
+	require.Equal(t, first.FullName("cli/cli"), second.FullName("CLI/cli"))
+	require.NotEqual(t, first.FullName("cli/cli"), other.FullName("cli/cli"))
+	require.NotEqual(t, first.Login("cli"), first.Login("cli2"))
+}
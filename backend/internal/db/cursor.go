@@ -0,0 +1,62 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned for a cursor token that wasn't produced by Encode
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// NotificationCursor is a position in the notification list order: the stored sort date,
+// import time and ID of the last notification on a page, and its priority score when
+// listed by priority. Keeping the values rather than the notification means the next page
+// starts in the same place even if that notification moves or is deleted in the meantime.
+type NotificationCursor struct {
+	SortDate   string `json:"s"`
+	ImportedAt string `json:"i"`
+	ID         int64  `json:"n"`
+	Priority   int64  `json:"p,omitempty"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe token
+func (c NotificationCursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// A struct of strings and an integer always marshals
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeNotificationCursor parses a token produced by NotificationCursor.Encode
+func DecodeNotificationCursor(token string) (NotificationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return NotificationCursor{}, errors.Join(ErrInvalidCursor, err)
+	}
+	var cursor NotificationCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return NotificationCursor{}, errors.Join(ErrInvalidCursor, err)
+	}
+	if cursor.SortDate == "" || cursor.ID <= 0 {
+		return NotificationCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationCursor_RoundTrip(t *testing.T) {
+	cursor := NotificationCursor{
+		SortDate:   "2025-01-02T03:04:05Z",
+		ImportedAt: "2025-01-01T00:00:00Z",
+		ID:         42,
+	}
+	decoded, err := DecodeNotificationCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+}
+
+func TestDecodeNotificationCursor_Invalid(t *testing.T) {
+	for _, token := range []string{
+		"",
+		"not base64!",
+		"bm90IGpzb24",          // "not json"
+		"eyJzIjoiIiwibiI6MX0",  // empty sort date
+		"eyJzIjoieCIsIm4iOjB9", // no ID
+	} {
+		t.Run(token, func(t *testing.T) {
+			_, err := DecodeNotificationCursor(token)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/octobud-hq/octobud/backend/internal/db"
 	gomock "go.uber.org/mock/gomock"
@@ -42,6 +43,51 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcknowledgeChangelogEntries mocks base method.
+func (m *MockStore) AcknowledgeChangelogEntries(ctx context.Context, upToID int64, acknowledgedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeChangelogEntries", ctx, upToID, acknowledgedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeChangelogEntries indicates an expected call of AcknowledgeChangelogEntries.
+func (mr *MockStoreMockRecorder) AcknowledgeChangelogEntries(ctx, upToID, acknowledgedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeChangelogEntries", reflect.TypeOf((*MockStore)(nil).AcknowledgeChangelogEntries), ctx, upToID, acknowledgedAt)
+}
+
+// ApplyTriageRestore mocks base method.
+func (m *MockStore) ApplyTriageRestore(ctx context.Context, userID, githubID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTriageRestore", ctx, userID, githubID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTriageRestore indicates an expected call of ApplyTriageRestore.
+func (mr *MockStoreMockRecorder) ApplyTriageRestore(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTriageRestore", reflect.TypeOf((*MockStore)(nil).ApplyTriageRestore), ctx, userID, githubID)
+}
+
+// ApplyTriageRestores mocks base method.
+func (m *MockStore) ApplyTriageRestores(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTriageRestores", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTriageRestores indicates an expected call of ApplyTriageRestores.
+func (mr *MockStoreMockRecorder) ApplyTriageRestores(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTriageRestores", reflect.TypeOf((*MockStore)(nil).ApplyTriageRestores), ctx, userID)
+}
+
 // ArchiveNotification mocks base method.
 func (m *MockStore) ArchiveNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +148,36 @@ func (mr *MockStoreMockRecorder) BulkArchiveNotificationsByQuery(ctx, userID, qu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkArchiveNotificationsByQuery", reflect.TypeOf((*MockStore)(nil).BulkArchiveNotificationsByQuery), ctx, userID, query)
 }
 
+// BulkAssignTagByQuery mocks base method.
+func (m *MockStore) BulkAssignTagByQuery(ctx context.Context, userID string, arg db.BulkTagNotificationsByQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAssignTagByQuery", ctx, userID, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAssignTagByQuery indicates an expected call of BulkAssignTagByQuery.
+func (mr *MockStoreMockRecorder) BulkAssignTagByQuery(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignTagByQuery", reflect.TypeOf((*MockStore)(nil).BulkAssignTagByQuery), ctx, userID, arg)
+}
+
+// BulkMarkNotificationsFilteredByQuery mocks base method.
+func (m *MockStore) BulkMarkNotificationsFilteredByQuery(ctx context.Context, userID string, query db.NotificationQuery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkMarkNotificationsFilteredByQuery", ctx, userID, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkMarkNotificationsFilteredByQuery indicates an expected call of BulkMarkNotificationsFilteredByQuery.
+func (mr *MockStoreMockRecorder) BulkMarkNotificationsFilteredByQuery(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsFilteredByQuery", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsFilteredByQuery), ctx, userID, query)
+}
+
 // BulkMarkNotificationsRead mocks base method.
 func (m *MockStore) BulkMarkNotificationsRead(ctx context.Context, userID string, githubIDs []string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +283,21 @@ func (mr *MockStoreMockRecorder) BulkMuteNotificationsByQuery(ctx, userID, query
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMuteNotificationsByQuery", reflect.TypeOf((*MockStore)(nil).BulkMuteNotificationsByQuery), ctx, userID, query)
 }
 
+// BulkRemoveTagByQuery mocks base method.
+func (m *MockStore) BulkRemoveTagByQuery(ctx context.Context, userID string, arg db.BulkTagNotificationsByQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkRemoveTagByQuery", ctx, userID, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkRemoveTagByQuery indicates an expected call of BulkRemoveTagByQuery.
+func (mr *MockStoreMockRecorder) BulkRemoveTagByQuery(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRemoveTagByQuery", reflect.TypeOf((*MockStore)(nil).BulkRemoveTagByQuery), ctx, userID, arg)
+}
+
 // BulkSnoozeNotifications mocks base method.
 func (m *MockStore) BulkSnoozeNotifications(ctx context.Context, userID string, arg db.BulkSnoozeNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -387,6 +478,50 @@ func (mr *MockStoreMockRecorder) BulkUnstarNotificationsByQuery(ctx, userID, que
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnstarNotificationsByQuery", reflect.TypeOf((*MockStore)(nil).BulkUnstarNotificationsByQuery), ctx, userID, query)
 }
 
+// CaptureBulkOperation mocks base method.
+func (m *MockStore) CaptureBulkOperation(ctx context.Context, userID string, arg db.CaptureBulkOperationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureBulkOperation", ctx, userID, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureBulkOperation indicates an expected call of CaptureBulkOperation.
+func (mr *MockStoreMockRecorder) CaptureBulkOperation(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureBulkOperation", reflect.TypeOf((*MockStore)(nil).CaptureBulkOperation), ctx, userID, arg)
+}
+
+// ClassifyAwaitingReplies mocks base method.
+func (m *MockStore) ClassifyAwaitingReplies(ctx context.Context, userID string, params db.AwaitingReplyParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassifyAwaitingReplies", ctx, userID, params)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassifyAwaitingReplies indicates an expected call of ClassifyAwaitingReplies.
+func (mr *MockStoreMockRecorder) ClassifyAwaitingReplies(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassifyAwaitingReplies", reflect.TypeOf((*MockStore)(nil).ClassifyAwaitingReplies), ctx, userID, params)
+}
+
+// ClearRecentAccess mocks base method.
+func (m *MockStore) ClearRecentAccess(ctx context.Context, userID, kind string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearRecentAccess", ctx, userID, kind)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearRecentAccess indicates an expected call of ClearRecentAccess.
+func (mr *MockStoreMockRecorder) ClearRecentAccess(ctx, userID, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRecentAccess", reflect.TypeOf((*MockStore)(nil).ClearRecentAccess), ctx, userID, kind)
+}
+
 // ClearUserGitHubToken mocks base method.
 func (m *MockStore) ClearUserGitHubToken(ctx context.Context) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -402,6 +537,21 @@ func (mr *MockStoreMockRecorder) ClearUserGitHubToken(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearUserGitHubToken", reflect.TypeOf((*MockStore)(nil).ClearUserGitHubToken), ctx)
 }
 
+// CountCleanupCandidates mocks base method.
+func (m *MockStore) CountCleanupCandidates(ctx context.Context, userID, cutoffDate string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCleanupCandidates", ctx, userID, cutoffDate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCleanupCandidates indicates an expected call of CountCleanupCandidates.
+func (mr *MockStoreMockRecorder) CountCleanupCandidates(ctx, userID, cutoffDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCleanupCandidates", reflect.TypeOf((*MockStore)(nil).CountCleanupCandidates), ctx, userID, cutoffDate)
+}
+
 // CountEligibleForCleanup mocks base method.
 func (m *MockStore) CountEligibleForCleanup(ctx context.Context, userID string, params db.CleanupParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -417,6 +567,96 @@ func (mr *MockStoreMockRecorder) CountEligibleForCleanup(ctx, userID, params any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEligibleForCleanup", reflect.TypeOf((*MockStore)(nil).CountEligibleForCleanup), ctx, userID, params)
 }
 
+// CountRepositoriesByOrg mocks base method.
+func (m *MockStore) CountRepositoriesByOrg(ctx context.Context, userID, org string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRepositoriesByOrg", ctx, userID, org)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRepositoriesByOrg indicates an expected call of CountRepositoriesByOrg.
+func (mr *MockStoreMockRecorder) CountRepositoriesByOrg(ctx, userID, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRepositoriesByOrg", reflect.TypeOf((*MockStore)(nil).CountRepositoriesByOrg), ctx, userID, org)
+}
+
+// CountTriageRestores mocks base method.
+func (m *MockStore) CountTriageRestores(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTriageRestores", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTriageRestores indicates an expected call of CountTriageRestores.
+func (mr *MockStoreMockRecorder) CountTriageRestores(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTriageRestores", reflect.TypeOf((*MockStore)(nil).CountTriageRestores), ctx, userID)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockStore) CreateAPIToken(ctx context.Context, userID string, arg db.CreateAPITokenParams) (db.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", ctx, userID, arg)
+	ret0, _ := ret[0].(db.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockStoreMockRecorder) CreateAPIToken(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockStore)(nil).CreateAPIToken), ctx, userID, arg)
+}
+
+// CreateIntegration mocks base method.
+func (m *MockStore) CreateIntegration(ctx context.Context, userID string, arg db.CreateIntegrationParams) (db.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntegration", ctx, userID, arg)
+	ret0, _ := ret[0].(db.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntegration indicates an expected call of CreateIntegration.
+func (mr *MockStoreMockRecorder) CreateIntegration(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntegration", reflect.TypeOf((*MockStore)(nil).CreateIntegration), ctx, userID, arg)
+}
+
+// CreateIntegrationDelivery mocks base method.
+func (m *MockStore) CreateIntegrationDelivery(ctx context.Context, userID string, arg db.CreateIntegrationDeliveryParams) (db.IntegrationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntegrationDelivery", ctx, userID, arg)
+	ret0, _ := ret[0].(db.IntegrationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntegrationDelivery indicates an expected call of CreateIntegrationDelivery.
+func (mr *MockStoreMockRecorder) CreateIntegrationDelivery(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntegrationDelivery", reflect.TypeOf((*MockStore)(nil).CreateIntegrationDelivery), ctx, userID, arg)
+}
+
+// CreateNotificationAlert mocks base method.
+func (m *MockStore) CreateNotificationAlert(ctx context.Context, userID string, arg db.CreateNotificationAlertParams) (db.NotificationAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationAlert", ctx, userID, arg)
+	ret0, _ := ret[0].(db.NotificationAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotificationAlert indicates an expected call of CreateNotificationAlert.
+func (mr *MockStoreMockRecorder) CreateNotificationAlert(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationAlert", reflect.TypeOf((*MockStore)(nil).CreateNotificationAlert), ctx, userID, arg)
+}
+
 // CreateRule mocks base method.
 func (m *MockStore) CreateRule(ctx context.Context, userID string, arg db.CreateRuleParams) (db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -462,286 +702,1064 @@ func (mr *MockStoreMockRecorder) CreateView(ctx, userID, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockStore)(nil).CreateView), ctx, userID, arg)
 }
 
-// DeleteAllGitHubData mocks base method.
-func (m *MockStore) DeleteAllGitHubData(ctx context.Context, userID string) error {
+// CreateViewGroup mocks base method.
+func (m *MockStore) CreateViewGroup(ctx context.Context, userID, name string) (db.ViewGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllGitHubData", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateViewGroup", ctx, userID, name)
+	ret0, _ := ret[0].(db.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAllGitHubData indicates an expected call of DeleteAllGitHubData.
-func (mr *MockStoreMockRecorder) DeleteAllGitHubData(ctx, userID any) *gomock.Call {
+// CreateViewGroup indicates an expected call of CreateViewGroup.
+func (mr *MockStoreMockRecorder) CreateViewGroup(ctx, userID, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllGitHubData", reflect.TypeOf((*MockStore)(nil).DeleteAllGitHubData), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateViewGroup", reflect.TypeOf((*MockStore)(nil).CreateViewGroup), ctx, userID, name)
 }
 
-// DeleteOldArchivedNotifications mocks base method.
-func (m *MockStore) DeleteOldArchivedNotifications(ctx context.Context, userID string, params db.CleanupParams) (int64, error) {
+// DeleteAPIToken mocks base method.
+func (m *MockStore) DeleteAPIToken(ctx context.Context, userID, id string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOldArchivedNotifications", ctx, userID, params)
+	ret := m.ctrl.Call(m, "DeleteAPIToken", ctx, userID, id)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteOldArchivedNotifications indicates an expected call of DeleteOldArchivedNotifications.
-func (mr *MockStoreMockRecorder) DeleteOldArchivedNotifications(ctx, userID, params any) *gomock.Call {
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockStoreMockRecorder) DeleteAPIToken(ctx, userID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldArchivedNotifications", reflect.TypeOf((*MockStore)(nil).DeleteOldArchivedNotifications), ctx, userID, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockStore)(nil).DeleteAPIToken), ctx, userID, id)
 }
 
-// DeleteOrphanedPullRequests mocks base method.
-func (m *MockStore) DeleteOrphanedPullRequests(ctx context.Context, userID string) (int64, error) {
+// DeleteAllGitHubData mocks base method.
+func (m *MockStore) DeleteAllGitHubData(ctx context.Context, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrphanedPullRequests", ctx, userID)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteAllGitHubData", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeleteOrphanedPullRequests indicates an expected call of DeleteOrphanedPullRequests.
-func (mr *MockStoreMockRecorder) DeleteOrphanedPullRequests(ctx, userID any) *gomock.Call {
+// DeleteAllGitHubData indicates an expected call of DeleteAllGitHubData.
+func (mr *MockStoreMockRecorder) DeleteAllGitHubData(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedPullRequests", reflect.TypeOf((*MockStore)(nil).DeleteOrphanedPullRequests), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllGitHubData", reflect.TypeOf((*MockStore)(nil).DeleteAllGitHubData), ctx, userID)
 }
 
-// DeleteRule mocks base method.
-func (m *MockStore) DeleteRule(ctx context.Context, userID, id string) error {
+// DeleteApprovedCleanup mocks base method.
+func (m *MockStore) DeleteApprovedCleanup(ctx context.Context, userID string, params db.CleanupParams, approve func([]int64) error) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRule", ctx, userID, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteApprovedCleanup", ctx, userID, params, approve)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteRule indicates an expected call of DeleteRule.
-func (mr *MockStoreMockRecorder) DeleteRule(ctx, userID, id any) *gomock.Call {
+// DeleteApprovedCleanup indicates an expected call of DeleteApprovedCleanup.
+func (mr *MockStoreMockRecorder) DeleteApprovedCleanup(ctx, userID, params, approve any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockStore)(nil).DeleteRule), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApprovedCleanup", reflect.TypeOf((*MockStore)(nil).DeleteApprovedCleanup), ctx, userID, params, approve)
 }
 
-// DeleteTag mocks base method.
-func (m *MockStore) DeleteTag(ctx context.Context, userID, id string) error {
+// DeleteGitHubData mocks base method.
+func (m *MockStore) DeleteGitHubData(ctx context.Context, userID string, params db.DeleteGitHubDataParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, id)
+	ret := m.ctrl.Call(m, "DeleteGitHubData", ctx, userID, params)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTag indicates an expected call of DeleteTag.
-func (mr *MockStoreMockRecorder) DeleteTag(ctx, userID, id any) *gomock.Call {
+// DeleteGitHubData indicates an expected call of DeleteGitHubData.
+func (mr *MockStoreMockRecorder) DeleteGitHubData(ctx, userID, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockStore)(nil).DeleteTag), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubData", reflect.TypeOf((*MockStore)(nil).DeleteGitHubData), ctx, userID, params)
 }
 
-// DeleteView mocks base method.
-func (m *MockStore) DeleteView(ctx context.Context, userID, id string) (int64, error) {
+// DeleteIntegration mocks base method.
+func (m *MockStore) DeleteIntegration(ctx context.Context, userID, id string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteView", ctx, userID, id)
+	ret := m.ctrl.Call(m, "DeleteIntegration", ctx, userID, id)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteView indicates an expected call of DeleteView.
-func (mr *MockStoreMockRecorder) DeleteView(ctx, userID, id any) *gomock.Call {
+// DeleteIntegration indicates an expected call of DeleteIntegration.
+func (mr *MockStoreMockRecorder) DeleteIntegration(ctx, userID, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockStore)(nil).DeleteView), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIntegration", reflect.TypeOf((*MockStore)(nil).DeleteIntegration), ctx, userID, id)
 }
 
-// GetNotificationByGithubID mocks base method.
-func (m *MockStore) GetNotificationByGithubID(ctx context.Context, userID, githubID string) (db.Notification, error) {
+// DeleteIntegrationDeliveriesBefore mocks base method.
+func (m *MockStore) DeleteIntegrationDeliveriesBefore(ctx context.Context, userID string, before time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationByGithubID", ctx, userID, githubID)
-	ret0, _ := ret[0].(db.Notification)
+	ret := m.ctrl.Call(m, "DeleteIntegrationDeliveriesBefore", ctx, userID, before)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNotificationByGithubID indicates an expected call of GetNotificationByGithubID.
-func (mr *MockStoreMockRecorder) GetNotificationByGithubID(ctx, userID, githubID any) *gomock.Call {
+// DeleteIntegrationDeliveriesBefore indicates an expected call of DeleteIntegrationDeliveriesBefore.
+func (mr *MockStoreMockRecorder) DeleteIntegrationDeliveriesBefore(ctx, userID, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByGithubID", reflect.TypeOf((*MockStore)(nil).GetNotificationByGithubID), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIntegrationDeliveriesBefore", reflect.TypeOf((*MockStore)(nil).DeleteIntegrationDeliveriesBefore), ctx, userID, before)
 }
 
-// GetNotificationByID mocks base method.
-func (m *MockStore) GetNotificationByID(ctx context.Context, userID string, id int64) (db.Notification, error) {
+// DeleteLinkedAccount mocks base method.
+func (m *MockStore) DeleteLinkedAccount(ctx context.Context, userID, login string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationByID", ctx, userID, id)
-	ret0, _ := ret[0].(db.Notification)
+	ret := m.ctrl.Call(m, "DeleteLinkedAccount", ctx, userID, login)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNotificationByID indicates an expected call of GetNotificationByID.
-func (mr *MockStoreMockRecorder) GetNotificationByID(ctx, userID, id any) *gomock.Call {
+// DeleteLinkedAccount indicates an expected call of DeleteLinkedAccount.
+func (mr *MockStoreMockRecorder) DeleteLinkedAccount(ctx, userID, login any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByID", reflect.TypeOf((*MockStore)(nil).GetNotificationByID), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinkedAccount", reflect.TypeOf((*MockStore)(nil).DeleteLinkedAccount), ctx, userID, login)
 }
 
-// GetRepositoryByID mocks base method.
-func (m *MockStore) GetRepositoryByID(ctx context.Context, userID string, id int64) (db.Repository, error) {
+// DeleteNotificationAlertsBefore mocks base method.
+func (m *MockStore) DeleteNotificationAlertsBefore(ctx context.Context, userID string, before time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRepositoryByID", ctx, userID, id)
-	ret0, _ := ret[0].(db.Repository)
+	ret := m.ctrl.Call(m, "DeleteNotificationAlertsBefore", ctx, userID, before)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRepositoryByID indicates an expected call of GetRepositoryByID.
-func (mr *MockStoreMockRecorder) GetRepositoryByID(ctx, userID, id any) *gomock.Call {
+// DeleteNotificationAlertsBefore indicates an expected call of DeleteNotificationAlertsBefore.
+func (mr *MockStoreMockRecorder) DeleteNotificationAlertsBefore(ctx, userID, before any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByID", reflect.TypeOf((*MockStore)(nil).GetRepositoryByID), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationAlertsBefore", reflect.TypeOf((*MockStore)(nil).DeleteNotificationAlertsBefore), ctx, userID, before)
 }
 
-// GetRule mocks base method.
-func (m *MockStore) GetRule(ctx context.Context, userID, id string) (db.Rule, error) {
+// DeleteNotificationsByAccount mocks base method.
+func (m *MockStore) DeleteNotificationsByAccount(ctx context.Context, userID, account string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRule", ctx, userID, id)
-	ret0, _ := ret[0].(db.Rule)
+	ret := m.ctrl.Call(m, "DeleteNotificationsByAccount", ctx, userID, account)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRule indicates an expected call of GetRule.
-func (mr *MockStoreMockRecorder) GetRule(ctx, userID, id any) *gomock.Call {
+// DeleteNotificationsByAccount indicates an expected call of DeleteNotificationsByAccount.
+func (mr *MockStoreMockRecorder) DeleteNotificationsByAccount(ctx, userID, account any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockStore)(nil).GetRule), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationsByAccount", reflect.TypeOf((*MockStore)(nil).DeleteNotificationsByAccount), ctx, userID, account)
 }
 
-// GetRulesByViewID mocks base method.
-func (m *MockStore) GetRulesByViewID(ctx context.Context, userID string, viewID sql.NullString) ([]db.Rule, error) {
+// DeleteNotificationsByOrg mocks base method.
+func (m *MockStore) DeleteNotificationsByOrg(ctx context.Context, userID, org string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRulesByViewID", ctx, userID, viewID)
-	ret0, _ := ret[0].([]db.Rule)
+	ret := m.ctrl.Call(m, "DeleteNotificationsByOrg", ctx, userID, org)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRulesByViewID indicates an expected call of GetRulesByViewID.
-func (mr *MockStoreMockRecorder) GetRulesByViewID(ctx, userID, viewID any) *gomock.Call {
+// DeleteNotificationsByOrg indicates an expected call of DeleteNotificationsByOrg.
+func (mr *MockStoreMockRecorder) DeleteNotificationsByOrg(ctx, userID, org any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByViewID", reflect.TypeOf((*MockStore)(nil).GetRulesByViewID), ctx, userID, viewID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationsByOrg", reflect.TypeOf((*MockStore)(nil).DeleteNotificationsByOrg), ctx, userID, org)
 }
 
-// GetStorageStats mocks base method.
-func (m *MockStore) GetStorageStats(ctx context.Context, userID string) (db.StorageStats, error) {
+// DeleteOldArchivedNotifications mocks base method.
+func (m *MockStore) DeleteOldArchivedNotifications(ctx context.Context, userID string, params db.CleanupParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStorageStats", ctx, userID)
-	ret0, _ := ret[0].(db.StorageStats)
+	ret := m.ctrl.Call(m, "DeleteOldArchivedNotifications", ctx, userID, params)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStorageStats indicates an expected call of GetStorageStats.
-func (mr *MockStoreMockRecorder) GetStorageStats(ctx, userID any) *gomock.Call {
+// DeleteOldArchivedNotifications indicates an expected call of DeleteOldArchivedNotifications.
+func (mr *MockStoreMockRecorder) DeleteOldArchivedNotifications(ctx, userID, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageStats", reflect.TypeOf((*MockStore)(nil).GetStorageStats), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldArchivedNotifications", reflect.TypeOf((*MockStore)(nil).DeleteOldArchivedNotifications), ctx, userID, params)
 }
 
-// GetSyncState mocks base method.
-func (m *MockStore) GetSyncState(ctx context.Context, userID string) (db.GetSyncStateRow, error) {
+// DeleteOrgOffboarding mocks base method.
+func (m *MockStore) DeleteOrgOffboarding(ctx context.Context, userID, org string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSyncState", ctx, userID)
-	ret0, _ := ret[0].(db.GetSyncStateRow)
+	ret := m.ctrl.Call(m, "DeleteOrgOffboarding", ctx, userID, org)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSyncState indicates an expected call of GetSyncState.
-func (mr *MockStoreMockRecorder) GetSyncState(ctx, userID any) *gomock.Call {
+// DeleteOrgOffboarding indicates an expected call of DeleteOrgOffboarding.
+func (mr *MockStoreMockRecorder) DeleteOrgOffboarding(ctx, userID, org any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockStore)(nil).GetSyncState), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrgOffboarding", reflect.TypeOf((*MockStore)(nil).DeleteOrgOffboarding), ctx, userID, org)
 }
 
-// GetTag mocks base method.
+// DeleteOrphanedPullRequests mocks base method.
+func (m *MockStore) DeleteOrphanedPullRequests(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedPullRequests", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphanedPullRequests indicates an expected call of DeleteOrphanedPullRequests.
+func (mr *MockStoreMockRecorder) DeleteOrphanedPullRequests(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedPullRequests", reflect.TypeOf((*MockStore)(nil).DeleteOrphanedPullRequests), ctx, userID)
+}
+
+// DeleteRule mocks base method.
+func (m *MockStore) DeleteRule(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockStoreMockRecorder) DeleteRule(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockStore)(nil).DeleteRule), ctx, userID, id)
+}
+
+// DeleteTag mocks base method.
+func (m *MockStore) DeleteTag(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockStoreMockRecorder) DeleteTag(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockStore)(nil).DeleteTag), ctx, userID, id)
+}
+
+// DeleteTagAndDisableRules mocks base method.
+func (m *MockStore) DeleteTagAndDisableRules(ctx context.Context, userID, id string) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagAndDisableRules", ctx, userID, id)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagAndDisableRules indicates an expected call of DeleteTagAndDisableRules.
+func (mr *MockStoreMockRecorder) DeleteTagAndDisableRules(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagAndDisableRules", reflect.TypeOf((*MockStore)(nil).DeleteTagAndDisableRules), ctx, userID, id)
+}
+
+// DeleteTriageRestores mocks base method.
+func (m *MockStore) DeleteTriageRestores(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTriageRestores", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTriageRestores indicates an expected call of DeleteTriageRestores.
+func (mr *MockStoreMockRecorder) DeleteTriageRestores(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTriageRestores", reflect.TypeOf((*MockStore)(nil).DeleteTriageRestores), ctx, userID)
+}
+
+// DeleteView mocks base method.
+func (m *MockStore) DeleteView(ctx context.Context, userID, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", ctx, userID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockStoreMockRecorder) DeleteView(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockStore)(nil).DeleteView), ctx, userID, id)
+}
+
+// DeleteViewAndDisableRules mocks base method.
+func (m *MockStore) DeleteViewAndDisableRules(ctx context.Context, userID, id string) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewAndDisableRules", ctx, userID, id)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteViewAndDisableRules indicates an expected call of DeleteViewAndDisableRules.
+func (mr *MockStoreMockRecorder) DeleteViewAndDisableRules(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewAndDisableRules", reflect.TypeOf((*MockStore)(nil).DeleteViewAndDisableRules), ctx, userID, id)
+}
+
+// DeleteViewGroup mocks base method.
+func (m *MockStore) DeleteViewGroup(ctx context.Context, userID, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewGroup", ctx, userID, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteViewGroup indicates an expected call of DeleteViewGroup.
+func (mr *MockStoreMockRecorder) DeleteViewGroup(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewGroup", reflect.TypeOf((*MockStore)(nil).DeleteViewGroup), ctx, userID, id)
+}
+
+// DeleteViewShare mocks base method.
+func (m *MockStore) DeleteViewShare(ctx context.Context, userID, viewID, grantee string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewShare", ctx, userID, viewID, grantee)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteViewShare indicates an expected call of DeleteViewShare.
+func (mr *MockStoreMockRecorder) DeleteViewShare(ctx, userID, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewShare", reflect.TypeOf((*MockStore)(nil).DeleteViewShare), ctx, userID, viewID, grantee)
+}
+
+// DeleteViewShareByGrantee mocks base method.
+func (m *MockStore) DeleteViewShareByGrantee(ctx context.Context, viewID, grantee string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewShareByGrantee", ctx, viewID, grantee)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteViewShareByGrantee indicates an expected call of DeleteViewShareByGrantee.
+func (mr *MockStoreMockRecorder) DeleteViewShareByGrantee(ctx, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewShareByGrantee", reflect.TypeOf((*MockStore)(nil).DeleteViewShareByGrantee), ctx, viewID, grantee)
+}
+
+// EscalateStaleReviewRequests mocks base method.
+func (m *MockStore) EscalateStaleReviewRequests(ctx context.Context, userID string, params db.EscalationParams) ([]db.EscalatedNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EscalateStaleReviewRequests", ctx, userID, params)
+	ret0, _ := ret[0].([]db.EscalatedNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EscalateStaleReviewRequests indicates an expected call of EscalateStaleReviewRequests.
+func (mr *MockStoreMockRecorder) EscalateStaleReviewRequests(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EscalateStaleReviewRequests", reflect.TypeOf((*MockStore)(nil).EscalateStaleReviewRequests), ctx, userID, params)
+}
+
+// ExplainNotificationQuery mocks base method.
+func (m *MockStore) ExplainNotificationQuery(ctx context.Context, userID string, query db.NotificationQuery) (db.NotificationQueryPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainNotificationQuery", ctx, userID, query)
+	ret0, _ := ret[0].(db.NotificationQueryPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainNotificationQuery indicates an expected call of ExplainNotificationQuery.
+func (mr *MockStoreMockRecorder) ExplainNotificationQuery(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainNotificationQuery", reflect.TypeOf((*MockStore)(nil).ExplainNotificationQuery), ctx, userID, query)
+}
+
+// GetAPITokenByHash mocks base method.
+func (m *MockStore) GetAPITokenByHash(ctx context.Context, tokenHash string) (db.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(db.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenByHash indicates an expected call of GetAPITokenByHash.
+func (mr *MockStoreMockRecorder) GetAPITokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenByHash", reflect.TypeOf((*MockStore)(nil).GetAPITokenByHash), ctx, tokenHash)
+}
+
+// GetBulkOperation mocks base method.
+func (m *MockStore) GetBulkOperation(ctx context.Context, userID string, id int64) (db.BulkOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkOperation", ctx, userID, id)
+	ret0, _ := ret[0].(db.BulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkOperation indicates an expected call of GetBulkOperation.
+func (mr *MockStoreMockRecorder) GetBulkOperation(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkOperation", reflect.TypeOf((*MockStore)(nil).GetBulkOperation), ctx, userID, id)
+}
+
+// GetIntegration mocks base method.
+func (m *MockStore) GetIntegration(ctx context.Context, userID, id string) (db.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntegration", ctx, userID, id)
+	ret0, _ := ret[0].(db.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntegration indicates an expected call of GetIntegration.
+func (mr *MockStoreMockRecorder) GetIntegration(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntegration", reflect.TypeOf((*MockStore)(nil).GetIntegration), ctx, userID, id)
+}
+
+// GetMeanTimeToRead mocks base method.
+func (m *MockStore) GetMeanTimeToRead(ctx context.Context, userID string, since time.Time) (db.MeanTimeToRead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeanTimeToRead", ctx, userID, since)
+	ret0, _ := ret[0].(db.MeanTimeToRead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeanTimeToRead indicates an expected call of GetMeanTimeToRead.
+func (mr *MockStoreMockRecorder) GetMeanTimeToRead(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeanTimeToRead", reflect.TypeOf((*MockStore)(nil).GetMeanTimeToRead), ctx, userID, since)
+}
+
+// GetNotificationByGithubID mocks base method.
+func (m *MockStore) GetNotificationByGithubID(ctx context.Context, userID, githubID string) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationByGithubID", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationByGithubID indicates an expected call of GetNotificationByGithubID.
+func (mr *MockStoreMockRecorder) GetNotificationByGithubID(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByGithubID", reflect.TypeOf((*MockStore)(nil).GetNotificationByGithubID), ctx, userID, githubID)
+}
+
+// GetNotificationByID mocks base method.
+func (m *MockStore) GetNotificationByID(ctx context.Context, userID string, id int64) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationByID", ctx, userID, id)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationByID indicates an expected call of GetNotificationByID.
+func (mr *MockStoreMockRecorder) GetNotificationByID(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByID", reflect.TypeOf((*MockStore)(nil).GetNotificationByID), ctx, userID, id)
+}
+
+// GetNotificationRevision mocks base method.
+func (m *MockStore) GetNotificationRevision(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationRevision", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationRevision indicates an expected call of GetNotificationRevision.
+func (mr *MockStoreMockRecorder) GetNotificationRevision(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationRevision", reflect.TypeOf((*MockStore)(nil).GetNotificationRevision), ctx, userID)
+}
+
+// GetRepositoryByFullName mocks base method.
+func (m *MockStore) GetRepositoryByFullName(ctx context.Context, userID, fullName string) (db.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryByFullName", ctx, userID, fullName)
+	ret0, _ := ret[0].(db.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryByFullName indicates an expected call of GetRepositoryByFullName.
+func (mr *MockStoreMockRecorder) GetRepositoryByFullName(ctx, userID, fullName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByFullName", reflect.TypeOf((*MockStore)(nil).GetRepositoryByFullName), ctx, userID, fullName)
+}
+
+// GetRepositoryByGithubID mocks base method.
+func (m *MockStore) GetRepositoryByGithubID(ctx context.Context, userID string, githubID int64) (db.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryByGithubID", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryByGithubID indicates an expected call of GetRepositoryByGithubID.
+func (mr *MockStoreMockRecorder) GetRepositoryByGithubID(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByGithubID", reflect.TypeOf((*MockStore)(nil).GetRepositoryByGithubID), ctx, userID, githubID)
+}
+
+// GetRepositoryByID mocks base method.
+func (m *MockStore) GetRepositoryByID(ctx context.Context, userID string, id int64) (db.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryByID", ctx, userID, id)
+	ret0, _ := ret[0].(db.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryByID indicates an expected call of GetRepositoryByID.
+func (mr *MockStoreMockRecorder) GetRepositoryByID(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByID", reflect.TypeOf((*MockStore)(nil).GetRepositoryByID), ctx, userID, id)
+}
+
+// GetRepositoryDigest mocks base method.
+func (m *MockStore) GetRepositoryDigest(ctx context.Context, userID string, repositoryID int64) (db.RepositoryDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryDigest", ctx, userID, repositoryID)
+	ret0, _ := ret[0].(db.RepositoryDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryDigest indicates an expected call of GetRepositoryDigest.
+func (mr *MockStoreMockRecorder) GetRepositoryDigest(ctx, userID, repositoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryDigest", reflect.TypeOf((*MockStore)(nil).GetRepositoryDigest), ctx, userID, repositoryID)
+}
+
+// GetRepositorySettings mocks base method.
+func (m *MockStore) GetRepositorySettings(ctx context.Context, userID string, repositoryID int64) (db.RepositorySetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositorySettings", ctx, userID, repositoryID)
+	ret0, _ := ret[0].(db.RepositorySetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositorySettings indicates an expected call of GetRepositorySettings.
+func (mr *MockStoreMockRecorder) GetRepositorySettings(ctx, userID, repositoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositorySettings", reflect.TypeOf((*MockStore)(nil).GetRepositorySettings), ctx, userID, repositoryID)
+}
+
+// GetRule mocks base method.
+func (m *MockStore) GetRule(ctx context.Context, userID, id string) (db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRule", ctx, userID, id)
+	ret0, _ := ret[0].(db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRule indicates an expected call of GetRule.
+func (mr *MockStoreMockRecorder) GetRule(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockStore)(nil).GetRule), ctx, userID, id)
+}
+
+// GetRulesByTagID mocks base method.
+func (m *MockStore) GetRulesByTagID(ctx context.Context, userID, tagID string) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesByTagID", ctx, userID, tagID)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesByTagID indicates an expected call of GetRulesByTagID.
+func (mr *MockStoreMockRecorder) GetRulesByTagID(ctx, userID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByTagID", reflect.TypeOf((*MockStore)(nil).GetRulesByTagID), ctx, userID, tagID)
+}
+
+// GetRulesByViewID mocks base method.
+func (m *MockStore) GetRulesByViewID(ctx context.Context, userID string, viewID sql.NullString) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesByViewID", ctx, userID, viewID)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesByViewID indicates an expected call of GetRulesByViewID.
+func (mr *MockStoreMockRecorder) GetRulesByViewID(ctx, userID, viewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByViewID", reflect.TypeOf((*MockStore)(nil).GetRulesByViewID), ctx, userID, viewID)
+}
+
+// GetSavedReply mocks base method.
+func (m *MockStore) GetSavedReply(ctx context.Context, userID, githubID string) (db.SavedReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedReply", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.SavedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedReply indicates an expected call of GetSavedReply.
+func (mr *MockStoreMockRecorder) GetSavedReply(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedReply", reflect.TypeOf((*MockStore)(nil).GetSavedReply), ctx, userID, githubID)
+}
+
+// GetStorageStats mocks base method.
+func (m *MockStore) GetStorageStats(ctx context.Context, userID string) (db.StorageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageStats", ctx, userID)
+	ret0, _ := ret[0].(db.StorageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageStats indicates an expected call of GetStorageStats.
+func (mr *MockStoreMockRecorder) GetStorageStats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageStats", reflect.TypeOf((*MockStore)(nil).GetStorageStats), ctx, userID)
+}
+
+// GetSyncState mocks base method.
+func (m *MockStore) GetSyncState(ctx context.Context, userID string) (db.GetSyncStateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncState", ctx, userID)
+	ret0, _ := ret[0].(db.GetSyncStateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncState indicates an expected call of GetSyncState.
+func (mr *MockStoreMockRecorder) GetSyncState(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockStore)(nil).GetSyncState), ctx, userID)
+}
+
+// GetTag mocks base method.
 func (m *MockStore) GetTag(ctx context.Context, userID, id string) (db.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTag", ctx, userID, id)
-	ret0, _ := ret[0].(db.Tag)
+	ret := m.ctrl.Call(m, "GetTag", ctx, userID, id)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockStoreMockRecorder) GetTag(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockStore)(nil).GetTag), ctx, userID, id)
+}
+
+// GetTagByName mocks base method.
+func (m *MockStore) GetTagByName(ctx context.Context, userID, name string) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagByName", ctx, userID, name)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagByName indicates an expected call of GetTagByName.
+func (mr *MockStoreMockRecorder) GetTagByName(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByName", reflect.TypeOf((*MockStore)(nil).GetTagByName), ctx, userID, name)
+}
+
+// GetTagBySlug mocks base method.
+func (m *MockStore) GetTagBySlug(ctx context.Context, userID, slug string) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagBySlug", ctx, userID, slug)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagBySlug indicates an expected call of GetTagBySlug.
+func (mr *MockStoreMockRecorder) GetTagBySlug(ctx, userID, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagBySlug", reflect.TypeOf((*MockStore)(nil).GetTagBySlug), ctx, userID, slug)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx)
+}
+
+// GetView mocks base method.
+func (m *MockStore) GetView(ctx context.Context, userID, id string) (db.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetView", ctx, userID, id)
+	ret0, _ := ret[0].(db.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetView indicates an expected call of GetView.
+func (mr *MockStoreMockRecorder) GetView(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockStore)(nil).GetView), ctx, userID, id)
+}
+
+// GetViewGroup mocks base method.
+func (m *MockStore) GetViewGroup(ctx context.Context, userID, id string) (db.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewGroup", ctx, userID, id)
+	ret0, _ := ret[0].(db.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewGroup indicates an expected call of GetViewGroup.
+func (mr *MockStoreMockRecorder) GetViewGroup(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewGroup", reflect.TypeOf((*MockStore)(nil).GetViewGroup), ctx, userID, id)
+}
+
+// GetViewShare mocks base method.
+func (m *MockStore) GetViewShare(ctx context.Context, viewID, grantee string) (db.ViewShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewShare", ctx, viewID, grantee)
+	ret0, _ := ret[0].(db.ViewShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewShare indicates an expected call of GetViewShare.
+func (mr *MockStoreMockRecorder) GetViewShare(ctx, viewID, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewShare", reflect.TypeOf((*MockStore)(nil).GetViewShare), ctx, viewID, grantee)
+}
+
+// InsertChangelogEntry mocks base method.
+func (m *MockStore) InsertChangelogEntry(ctx context.Context, arg db.InsertChangelogEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertChangelogEntry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertChangelogEntry indicates an expected call of InsertChangelogEntry.
+func (mr *MockStoreMockRecorder) InsertChangelogEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertChangelogEntry", reflect.TypeOf((*MockStore)(nil).InsertChangelogEntry), ctx, arg)
+}
+
+// IsOrgOffboarded mocks base method.
+func (m *MockStore) IsOrgOffboarded(ctx context.Context, userID, org string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOrgOffboarded", ctx, userID, org)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsOrgOffboarded indicates an expected call of IsOrgOffboarded.
+func (mr *MockStoreMockRecorder) IsOrgOffboarded(ctx, userID, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOrgOffboarded", reflect.TypeOf((*MockStore)(nil).IsOrgOffboarded), ctx, userID, org)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockStore) ListAPITokens(ctx context.Context, userID string) ([]db.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens", ctx, userID)
+	ret0, _ := ret[0].([]db.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockStoreMockRecorder) ListAPITokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockStore)(nil).ListAPITokens), ctx, userID)
+}
+
+// ListAllTags mocks base method.
+func (m *MockStore) ListAllTags(ctx context.Context, userID string) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllTags", ctx, userID)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllTags indicates an expected call of ListAllTags.
+func (mr *MockStoreMockRecorder) ListAllTags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllTags", reflect.TypeOf((*MockStore)(nil).ListAllTags), ctx, userID)
+}
+
+// ListAuthorProfiles mocks base method.
+func (m *MockStore) ListAuthorProfiles(ctx context.Context, userID string) ([]db.AuthorProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorProfiles", ctx, userID)
+	ret0, _ := ret[0].([]db.AuthorProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthorProfiles indicates an expected call of ListAuthorProfiles.
+func (mr *MockStoreMockRecorder) ListAuthorProfiles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorProfiles", reflect.TypeOf((*MockStore)(nil).ListAuthorProfiles), ctx, userID)
+}
+
+// ListCleanupCandidates mocks base method.
+func (m *MockStore) ListCleanupCandidates(ctx context.Context, userID string, params db.CleanupCandidatesParams) ([]db.CleanupCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCleanupCandidates", ctx, userID, params)
+	ret0, _ := ret[0].([]db.CleanupCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCleanupCandidates indicates an expected call of ListCleanupCandidates.
+func (mr *MockStoreMockRecorder) ListCleanupCandidates(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCleanupCandidates", reflect.TypeOf((*MockStore)(nil).ListCleanupCandidates), ctx, userID, params)
+}
+
+// ListDailyNotificationActivity mocks base method.
+func (m *MockStore) ListDailyNotificationActivity(ctx context.Context, userID string, since time.Time) ([]db.DailyNotificationActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailyNotificationActivity", ctx, userID, since)
+	ret0, _ := ret[0].([]db.DailyNotificationActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailyNotificationActivity indicates an expected call of ListDailyNotificationActivity.
+func (mr *MockStoreMockRecorder) ListDailyNotificationActivity(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailyNotificationActivity", reflect.TypeOf((*MockStore)(nil).ListDailyNotificationActivity), ctx, userID, since)
+}
+
+// ListDueIntegrationDeliveries mocks base method.
+func (m *MockStore) ListDueIntegrationDeliveries(ctx context.Context, userID string, now time.Time, limit int64) ([]db.IntegrationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueIntegrationDeliveries", ctx, userID, now, limit)
+	ret0, _ := ret[0].([]db.IntegrationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueIntegrationDeliveries indicates an expected call of ListDueIntegrationDeliveries.
+func (mr *MockStoreMockRecorder) ListDueIntegrationDeliveries(ctx, userID, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueIntegrationDeliveries", reflect.TypeOf((*MockStore)(nil).ListDueIntegrationDeliveries), ctx, userID, now, limit)
+}
+
+// ListEligibleForCleanupIDs mocks base method.
+func (m *MockStore) ListEligibleForCleanupIDs(ctx context.Context, userID string, params db.CleanupParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEligibleForCleanupIDs", ctx, userID, params)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEligibleForCleanupIDs indicates an expected call of ListEligibleForCleanupIDs.
+func (mr *MockStoreMockRecorder) ListEligibleForCleanupIDs(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEligibleForCleanupIDs", reflect.TypeOf((*MockStore)(nil).ListEligibleForCleanupIDs), ctx, userID, params)
+}
+
+// ListEnabledRulesOrdered mocks base method.
+func (m *MockStore) ListEnabledRulesOrdered(ctx context.Context, userID string) ([]db.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabledRulesOrdered", ctx, userID)
+	ret0, _ := ret[0].([]db.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabledRulesOrdered indicates an expected call of ListEnabledRulesOrdered.
+func (mr *MockStoreMockRecorder) ListEnabledRulesOrdered(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledRulesOrdered", reflect.TypeOf((*MockStore)(nil).ListEnabledRulesOrdered), ctx, userID)
+}
+
+// ListIntegrationDeliveries mocks base method.
+func (m *MockStore) ListIntegrationDeliveries(ctx context.Context, userID, integrationID string, limit int64) ([]db.IntegrationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntegrationDeliveries", ctx, userID, integrationID, limit)
+	ret0, _ := ret[0].([]db.IntegrationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntegrationDeliveries indicates an expected call of ListIntegrationDeliveries.
+func (mr *MockStoreMockRecorder) ListIntegrationDeliveries(ctx, userID, integrationID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntegrationDeliveries", reflect.TypeOf((*MockStore)(nil).ListIntegrationDeliveries), ctx, userID, integrationID, limit)
+}
+
+// ListIntegrations mocks base method.
+func (m *MockStore) ListIntegrations(ctx context.Context, userID string) ([]db.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntegrations", ctx, userID)
+	ret0, _ := ret[0].([]db.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntegrations indicates an expected call of ListIntegrations.
+func (mr *MockStoreMockRecorder) ListIntegrations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntegrations", reflect.TypeOf((*MockStore)(nil).ListIntegrations), ctx, userID)
+}
+
+// ListLinkedAccounts mocks base method.
+func (m *MockStore) ListLinkedAccounts(ctx context.Context, userID string) ([]db.LinkedAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinkedAccounts", ctx, userID)
+	ret0, _ := ret[0].([]db.LinkedAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinkedAccounts indicates an expected call of ListLinkedAccounts.
+func (mr *MockStoreMockRecorder) ListLinkedAccounts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinkedAccounts", reflect.TypeOf((*MockStore)(nil).ListLinkedAccounts), ctx, userID)
+}
+
+// ListNotificationAlertsAfter mocks base method.
+func (m *MockStore) ListNotificationAlertsAfter(ctx context.Context, userID string, afterID, limit int64) ([]db.NotificationAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationAlertsAfter", ctx, userID, afterID, limit)
+	ret0, _ := ret[0].([]db.NotificationAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationAlertsAfter indicates an expected call of ListNotificationAlertsAfter.
+func (mr *MockStoreMockRecorder) ListNotificationAlertsAfter(ctx, userID, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationAlertsAfter", reflect.TypeOf((*MockStore)(nil).ListNotificationAlertsAfter), ctx, userID, afterID, limit)
+}
+
+// ListNotificationAuthors mocks base method.
+func (m *MockStore) ListNotificationAuthors(ctx context.Context, userID, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationAuthors", ctx, userID, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationAuthors indicates an expected call of ListNotificationAuthors.
+func (mr *MockStoreMockRecorder) ListNotificationAuthors(ctx, userID, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationAuthors", reflect.TypeOf((*MockStore)(nil).ListNotificationAuthors), ctx, userID, prefix, limit)
+}
+
+// ListNotificationEvents mocks base method.
+func (m *MockStore) ListNotificationEvents(ctx context.Context, userID, githubID string, limit int64) ([]db.NotificationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationEvents", ctx, userID, githubID, limit)
+	ret0, _ := ret[0].([]db.NotificationEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTag indicates an expected call of GetTag.
-func (mr *MockStoreMockRecorder) GetTag(ctx, userID, id any) *gomock.Call {
+// ListNotificationEvents indicates an expected call of ListNotificationEvents.
+func (mr *MockStoreMockRecorder) ListNotificationEvents(ctx, userID, githubID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockStore)(nil).GetTag), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationEvents", reflect.TypeOf((*MockStore)(nil).ListNotificationEvents), ctx, userID, githubID, limit)
 }
 
-// GetTagByName mocks base method.
-func (m *MockStore) GetTagByName(ctx context.Context, userID, name string) (db.Tag, error) {
+// ListNotificationGithubIDsBySubjectURL mocks base method.
+func (m *MockStore) ListNotificationGithubIDsBySubjectURL(ctx context.Context, userID, subjectURL string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTagByName", ctx, userID, name)
-	ret0, _ := ret[0].(db.Tag)
+	ret := m.ctrl.Call(m, "ListNotificationGithubIDsBySubjectURL", ctx, userID, subjectURL)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTagByName indicates an expected call of GetTagByName.
-func (mr *MockStoreMockRecorder) GetTagByName(ctx, userID, name any) *gomock.Call {
+// ListNotificationGithubIDsBySubjectURL indicates an expected call of ListNotificationGithubIDsBySubjectURL.
+func (mr *MockStoreMockRecorder) ListNotificationGithubIDsBySubjectURL(ctx, userID, subjectURL any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagByName", reflect.TypeOf((*MockStore)(nil).GetTagByName), ctx, userID, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationGithubIDsBySubjectURL", reflect.TypeOf((*MockStore)(nil).ListNotificationGithubIDsBySubjectURL), ctx, userID, subjectURL)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context) (db.User, error) {
+// ListNotificationGithubIDsFromQuery mocks base method.
+func (m *MockStore) ListNotificationGithubIDsFromQuery(ctx context.Context, userID string, query db.NotificationQuery) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListNotificationGithubIDsFromQuery", ctx, userID, query)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx any) *gomock.Call {
+// ListNotificationGithubIDsFromQuery indicates an expected call of ListNotificationGithubIDsFromQuery.
+func (mr *MockStoreMockRecorder) ListNotificationGithubIDsFromQuery(ctx, userID, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationGithubIDsFromQuery", reflect.TypeOf((*MockStore)(nil).ListNotificationGithubIDsFromQuery), ctx, userID, query)
 }
 
-// GetView mocks base method.
-func (m *MockStore) GetView(ctx context.Context, userID, id string) (db.View, error) {
+// ListNotificationGroupsFromQuery mocks base method.
+func (m *MockStore) ListNotificationGroupsFromQuery(ctx context.Context, userID string, params db.NotificationGroupsParams) ([]db.NotificationGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetView", ctx, userID, id)
-	ret0, _ := ret[0].(db.View)
+	ret := m.ctrl.Call(m, "ListNotificationGroupsFromQuery", ctx, userID, params)
+	ret0, _ := ret[0].([]db.NotificationGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetView indicates an expected call of GetView.
-func (mr *MockStoreMockRecorder) GetView(ctx, userID, id any) *gomock.Call {
+// ListNotificationGroupsFromQuery indicates an expected call of ListNotificationGroupsFromQuery.
+func (mr *MockStoreMockRecorder) ListNotificationGroupsFromQuery(ctx, userID, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockStore)(nil).GetView), ctx, userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationGroupsFromQuery", reflect.TypeOf((*MockStore)(nil).ListNotificationGroupsFromQuery), ctx, userID, params)
 }
 
-// ListAllTags mocks base method.
-func (m *MockStore) ListAllTags(ctx context.Context, userID string) ([]db.Tag, error) {
+// ListNotificationLabels mocks base method.
+func (m *MockStore) ListNotificationLabels(ctx context.Context, userID, prefix string, limit int) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllTags", ctx, userID)
-	ret0, _ := ret[0].([]db.Tag)
+	ret := m.ctrl.Call(m, "ListNotificationLabels", ctx, userID, prefix, limit)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllTags indicates an expected call of ListAllTags.
-func (mr *MockStoreMockRecorder) ListAllTags(ctx, userID any) *gomock.Call {
+// ListNotificationLabels indicates an expected call of ListNotificationLabels.
+func (mr *MockStoreMockRecorder) ListNotificationLabels(ctx, userID, prefix, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllTags", reflect.TypeOf((*MockStore)(nil).ListAllTags), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationLabels", reflect.TypeOf((*MockStore)(nil).ListNotificationLabels), ctx, userID, prefix, limit)
 }
 
-// ListEnabledRulesOrdered mocks base method.
-func (m *MockStore) ListEnabledRulesOrdered(ctx context.Context, userID string) ([]db.Rule, error) {
+// ListNotificationVolume mocks base method.
+func (m *MockStore) ListNotificationVolume(ctx context.Context, userID string, since time.Time) ([]db.NotificationVolume, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEnabledRulesOrdered", ctx, userID)
-	ret0, _ := ret[0].([]db.Rule)
+	ret := m.ctrl.Call(m, "ListNotificationVolume", ctx, userID, since)
+	ret0, _ := ret[0].([]db.NotificationVolume)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEnabledRulesOrdered indicates an expected call of ListEnabledRulesOrdered.
-func (mr *MockStoreMockRecorder) ListEnabledRulesOrdered(ctx, userID any) *gomock.Call {
+// ListNotificationVolume indicates an expected call of ListNotificationVolume.
+func (mr *MockStoreMockRecorder) ListNotificationVolume(ctx, userID, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledRulesOrdered", reflect.TypeOf((*MockStore)(nil).ListEnabledRulesOrdered), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationVolume", reflect.TypeOf((*MockStore)(nil).ListNotificationVolume), ctx, userID, since)
 }
 
 // ListNotificationsFromQuery mocks base method.
@@ -759,6 +1777,51 @@ func (mr *MockStoreMockRecorder) ListNotificationsFromQuery(ctx, userID, query a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsFromQuery", reflect.TypeOf((*MockStore)(nil).ListNotificationsFromQuery), ctx, userID, query)
 }
 
+// ListOrgOffboardings mocks base method.
+func (m *MockStore) ListOrgOffboardings(ctx context.Context, userID string) ([]db.OrgOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrgOffboardings", ctx, userID)
+	ret0, _ := ret[0].([]db.OrgOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrgOffboardings indicates an expected call of ListOrgOffboardings.
+func (mr *MockStoreMockRecorder) ListOrgOffboardings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrgOffboardings", reflect.TypeOf((*MockStore)(nil).ListOrgOffboardings), ctx, userID)
+}
+
+// ListRecentAccess mocks base method.
+func (m *MockStore) ListRecentAccess(ctx context.Context, userID, kind string, limit int64) ([]db.RecentAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentAccess", ctx, userID, kind, limit)
+	ret0, _ := ret[0].([]db.RecentAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentAccess indicates an expected call of ListRecentAccess.
+func (mr *MockStoreMockRecorder) ListRecentAccess(ctx, userID, kind, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentAccess", reflect.TypeOf((*MockStore)(nil).ListRecentAccess), ctx, userID, kind, limit)
+}
+
+// ListRecentNotificationAlerts mocks base method.
+func (m *MockStore) ListRecentNotificationAlerts(ctx context.Context, userID string, limit int64) ([]db.NotificationAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentNotificationAlerts", ctx, userID, limit)
+	ret0, _ := ret[0].([]db.NotificationAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentNotificationAlerts indicates an expected call of ListRecentNotificationAlerts.
+func (mr *MockStoreMockRecorder) ListRecentNotificationAlerts(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentNotificationAlerts", reflect.TypeOf((*MockStore)(nil).ListRecentNotificationAlerts), ctx, userID, limit)
+}
+
 // ListRepositories mocks base method.
 func (m *MockStore) ListRepositories(ctx context.Context, userID string) ([]db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -774,6 +1837,66 @@ func (mr *MockStoreMockRecorder) ListRepositories(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositories", reflect.TypeOf((*MockStore)(nil).ListRepositories), ctx, userID)
 }
 
+// ListRepositoryDigests mocks base method.
+func (m *MockStore) ListRepositoryDigests(ctx context.Context, userID string) (map[int64]db.RepositoryDigest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRepositoryDigests", ctx, userID)
+	ret0, _ := ret[0].(map[int64]db.RepositoryDigest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoryDigests indicates an expected call of ListRepositoryDigests.
+func (mr *MockStoreMockRecorder) ListRepositoryDigests(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoryDigests", reflect.TypeOf((*MockStore)(nil).ListRepositoryDigests), ctx, userID)
+}
+
+// ListRepositorySubjectTypeCounts mocks base method.
+func (m *MockStore) ListRepositorySubjectTypeCounts(ctx context.Context, userID string) (map[int64]map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRepositorySubjectTypeCounts", ctx, userID)
+	ret0, _ := ret[0].(map[int64]map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositorySubjectTypeCounts indicates an expected call of ListRepositorySubjectTypeCounts.
+func (mr *MockStoreMockRecorder) ListRepositorySubjectTypeCounts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositorySubjectTypeCounts", reflect.TypeOf((*MockStore)(nil).ListRepositorySubjectTypeCounts), ctx, userID)
+}
+
+// ListReviewRequestReviewers mocks base method.
+func (m *MockStore) ListReviewRequestReviewers(ctx context.Context, userID string, since time.Time) ([]db.ReviewRequestReviewers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewRequestReviewers", ctx, userID, since)
+	ret0, _ := ret[0].([]db.ReviewRequestReviewers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewRequestReviewers indicates an expected call of ListReviewRequestReviewers.
+func (mr *MockStoreMockRecorder) ListReviewRequestReviewers(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewRequestReviewers", reflect.TypeOf((*MockStore)(nil).ListReviewRequestReviewers), ctx, userID, since)
+}
+
+// ListRuleEvaluations mocks base method.
+func (m *MockStore) ListRuleEvaluations(ctx context.Context, userID, githubID string, limit int64) ([]db.RuleEvaluation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleEvaluations", ctx, userID, githubID, limit)
+	ret0, _ := ret[0].([]db.RuleEvaluation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleEvaluations indicates an expected call of ListRuleEvaluations.
+func (mr *MockStoreMockRecorder) ListRuleEvaluations(ctx, userID, githubID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleEvaluations", reflect.TypeOf((*MockStore)(nil).ListRuleEvaluations), ctx, userID, githubID, limit)
+}
+
 // ListRules mocks base method.
 func (m *MockStore) ListRules(ctx context.Context, userID string) ([]db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -789,6 +1912,21 @@ func (mr *MockStoreMockRecorder) ListRules(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockStore)(nil).ListRules), ctx, userID)
 }
 
+// ListSavedReplies mocks base method.
+func (m *MockStore) ListSavedReplies(ctx context.Context, userID string) ([]db.SavedReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedReplies", ctx, userID)
+	ret0, _ := ret[0].([]db.SavedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedReplies indicates an expected call of ListSavedReplies.
+func (mr *MockStoreMockRecorder) ListSavedReplies(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedReplies", reflect.TypeOf((*MockStore)(nil).ListSavedReplies), ctx, userID)
+}
+
 // ListTagsForEntity mocks base method.
 func (m *MockStore) ListTagsForEntity(ctx context.Context, userID string, arg db.ListTagsForEntityParams) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -804,6 +1942,96 @@ func (mr *MockStoreMockRecorder) ListTagsForEntity(ctx, userID, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForEntity", reflect.TypeOf((*MockStore)(nil).ListTagsForEntity), ctx, userID, arg)
 }
 
+// ListTagsForNotifications mocks base method.
+func (m *MockStore) ListTagsForNotifications(ctx context.Context, userID string, notificationIDs []int64) (map[int64][]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForNotifications", ctx, userID, notificationIDs)
+	ret0, _ := ret[0].(map[int64][]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForNotifications indicates an expected call of ListTagsForNotifications.
+func (mr *MockStoreMockRecorder) ListTagsForNotifications(ctx, userID, notificationIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForNotifications", reflect.TypeOf((*MockStore)(nil).ListTagsForNotifications), ctx, userID, notificationIDs)
+}
+
+// ListTriageStatesAfter mocks base method.
+func (m *MockStore) ListTriageStatesAfter(ctx context.Context, userID, afterGithubID string, limit int64) ([]db.TriageState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTriageStatesAfter", ctx, userID, afterGithubID, limit)
+	ret0, _ := ret[0].([]db.TriageState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTriageStatesAfter indicates an expected call of ListTriageStatesAfter.
+func (mr *MockStoreMockRecorder) ListTriageStatesAfter(ctx, userID, afterGithubID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTriageStatesAfter", reflect.TypeOf((*MockStore)(nil).ListTriageStatesAfter), ctx, userID, afterGithubID, limit)
+}
+
+// ListUnseenChangelogEntries mocks base method.
+func (m *MockStore) ListUnseenChangelogEntries(ctx context.Context) ([]db.ChangelogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnseenChangelogEntries", ctx)
+	ret0, _ := ret[0].([]db.ChangelogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnseenChangelogEntries indicates an expected call of ListUnseenChangelogEntries.
+func (mr *MockStoreMockRecorder) ListUnseenChangelogEntries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnseenChangelogEntries", reflect.TypeOf((*MockStore)(nil).ListUnseenChangelogEntries), ctx)
+}
+
+// ListViewGroups mocks base method.
+func (m *MockStore) ListViewGroups(ctx context.Context, userID string) ([]db.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewGroups", ctx, userID)
+	ret0, _ := ret[0].([]db.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewGroups indicates an expected call of ListViewGroups.
+func (mr *MockStoreMockRecorder) ListViewGroups(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewGroups", reflect.TypeOf((*MockStore)(nil).ListViewGroups), ctx, userID)
+}
+
+// ListViewShares mocks base method.
+func (m *MockStore) ListViewShares(ctx context.Context, userID, viewID string) ([]db.ViewShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewShares", ctx, userID, viewID)
+	ret0, _ := ret[0].([]db.ViewShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewShares indicates an expected call of ListViewShares.
+func (mr *MockStoreMockRecorder) ListViewShares(ctx, userID, viewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewShares", reflect.TypeOf((*MockStore)(nil).ListViewShares), ctx, userID, viewID)
+}
+
+// ListViewSharesByGrantee mocks base method.
+func (m *MockStore) ListViewSharesByGrantee(ctx context.Context, grantee string) ([]db.ViewShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewSharesByGrantee", ctx, grantee)
+	ret0, _ := ret[0].([]db.ViewShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewSharesByGrantee indicates an expected call of ListViewSharesByGrantee.
+func (mr *MockStoreMockRecorder) ListViewSharesByGrantee(ctx, grantee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewSharesByGrantee", reflect.TypeOf((*MockStore)(nil).ListViewSharesByGrantee), ctx, grantee)
+}
+
 // ListViews mocks base method.
 func (m *MockStore) ListViews(ctx context.Context, userID string) ([]db.View, error) {
 	m.ctrl.T.Helper()
@@ -831,81 +2059,211 @@ func (m *MockStore) MarkNotificationFiltered(ctx context.Context, userID, github
 // MarkNotificationFiltered indicates an expected call of MarkNotificationFiltered.
 func (mr *MockStoreMockRecorder) MarkNotificationFiltered(ctx, userID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationFiltered), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationFiltered), ctx, userID, githubID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockStore) MarkNotificationRead(ctx context.Context, userID, githubID string) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockStoreMockRecorder) MarkNotificationRead(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationRead), ctx, userID, githubID)
+}
+
+// MarkNotificationUnfiltered mocks base method.
+func (m *MockStore) MarkNotificationUnfiltered(ctx context.Context, userID, githubID string) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationUnfiltered", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationUnfiltered indicates an expected call of MarkNotificationUnfiltered.
+func (mr *MockStoreMockRecorder) MarkNotificationUnfiltered(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnfiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnfiltered), ctx, userID, githubID)
+}
+
+// MarkNotificationUnread mocks base method.
+func (m *MockStore) MarkNotificationUnread(ctx context.Context, userID, githubID string) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationUnread indicates an expected call of MarkNotificationUnread.
+func (mr *MockStoreMockRecorder) MarkNotificationUnread(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnread), ctx, userID, githubID)
+}
+
+// MarkNotificationsReadBySubjectURL mocks base method.
+func (m *MockStore) MarkNotificationsReadBySubjectURL(ctx context.Context, userID, subjectURL string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsReadBySubjectURL", ctx, userID, subjectURL)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationsReadBySubjectURL indicates an expected call of MarkNotificationsReadBySubjectURL.
+func (mr *MockStoreMockRecorder) MarkNotificationsReadBySubjectURL(ctx, userID, subjectURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsReadBySubjectURL", reflect.TypeOf((*MockStore)(nil).MarkNotificationsReadBySubjectURL), ctx, userID, subjectURL)
+}
+
+// MergeRepositories mocks base method.
+func (m *MockStore) MergeRepositories(ctx context.Context, userID string, arg db.MergeRepositoriesParams) (db.MergeRepositoriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeRepositories", ctx, userID, arg)
+	ret0, _ := ret[0].(db.MergeRepositoriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeRepositories indicates an expected call of MergeRepositories.
+func (mr *MockStoreMockRecorder) MergeRepositories(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeRepositories", reflect.TypeOf((*MockStore)(nil).MergeRepositories), ctx, userID, arg)
+}
+
+// MuteNotification mocks base method.
+func (m *MockStore) MuteNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteNotification", ctx, userID, githubID)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MuteNotification indicates an expected call of MuteNotification.
+func (mr *MockStoreMockRecorder) MuteNotification(ctx, userID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockStore)(nil).MuteNotification), ctx, userID, githubID)
+}
+
+// RecordNotificationEvents mocks base method.
+func (m *MockStore) RecordNotificationEvents(ctx context.Context, userID string, arg db.RecordNotificationEventsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordNotificationEvents", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordNotificationEvents indicates an expected call of RecordNotificationEvents.
+func (mr *MockStoreMockRecorder) RecordNotificationEvents(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordNotificationEvents", reflect.TypeOf((*MockStore)(nil).RecordNotificationEvents), ctx, userID, arg)
+}
+
+// RecordRecentAccess mocks base method.
+func (m *MockStore) RecordRecentAccess(ctx context.Context, userID string, arg db.RecordRecentAccessParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRecentAccess", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRecentAccess indicates an expected call of RecordRecentAccess.
+func (mr *MockStoreMockRecorder) RecordRecentAccess(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRecentAccess", reflect.TypeOf((*MockStore)(nil).RecordRecentAccess), ctx, userID, arg)
+}
+
+// RemoveTagAssignment mocks base method.
+func (m *MockStore) RemoveTagAssignment(ctx context.Context, userID string, arg db.RemoveTagAssignmentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTagAssignment", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTagAssignment indicates an expected call of RemoveTagAssignment.
+func (mr *MockStoreMockRecorder) RemoveTagAssignment(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagAssignment", reflect.TypeOf((*MockStore)(nil).RemoveTagAssignment), ctx, userID, arg)
 }
 
-// MarkNotificationRead mocks base method.
-func (m *MockStore) MarkNotificationRead(ctx context.Context, userID, githubID string) (db.Notification, error) {
+// RenameRepository mocks base method.
+func (m *MockStore) RenameRepository(ctx context.Context, userID string, arg db.RenameRepositoryParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, userID, githubID)
-	ret0, _ := ret[0].(db.Notification)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RenameRepository", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MarkNotificationRead indicates an expected call of MarkNotificationRead.
-func (mr *MockStoreMockRecorder) MarkNotificationRead(ctx, userID, githubID any) *gomock.Call {
+// RenameRepository indicates an expected call of RenameRepository.
+func (mr *MockStoreMockRecorder) RenameRepository(ctx, userID, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationRead), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameRepository", reflect.TypeOf((*MockStore)(nil).RenameRepository), ctx, userID, arg)
 }
 
-// MarkNotificationUnfiltered mocks base method.
-func (m *MockStore) MarkNotificationUnfiltered(ctx context.Context, userID, githubID string) (db.Notification, error) {
+// ReplaceSavedReplies mocks base method.
+func (m *MockStore) ReplaceSavedReplies(ctx context.Context, userID string, arg db.ReplaceSavedRepliesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnfiltered", ctx, userID, githubID)
-	ret0, _ := ret[0].(db.Notification)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReplaceSavedReplies", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MarkNotificationUnfiltered indicates an expected call of MarkNotificationUnfiltered.
-func (mr *MockStoreMockRecorder) MarkNotificationUnfiltered(ctx, userID, githubID any) *gomock.Call {
+// ReplaceSavedReplies indicates an expected call of ReplaceSavedReplies.
+func (mr *MockStoreMockRecorder) ReplaceSavedReplies(ctx, userID, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnfiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnfiltered), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSavedReplies", reflect.TypeOf((*MockStore)(nil).ReplaceSavedReplies), ctx, userID, arg)
 }
 
-// MarkNotificationUnread mocks base method.
-func (m *MockStore) MarkNotificationUnread(ctx context.Context, userID, githubID string) (db.Notification, error) {
+// ReturnExpiredSnoozes mocks base method.
+func (m *MockStore) ReturnExpiredSnoozes(ctx context.Context, userID string, params db.SnoozeReturnParams) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, userID, githubID)
-	ret0, _ := ret[0].(db.Notification)
+	ret := m.ctrl.Call(m, "ReturnExpiredSnoozes", ctx, userID, params)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MarkNotificationUnread indicates an expected call of MarkNotificationUnread.
-func (mr *MockStoreMockRecorder) MarkNotificationUnread(ctx, userID, githubID any) *gomock.Call {
+// ReturnExpiredSnoozes indicates an expected call of ReturnExpiredSnoozes.
+func (mr *MockStoreMockRecorder) ReturnExpiredSnoozes(ctx, userID, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnread), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnExpiredSnoozes", reflect.TypeOf((*MockStore)(nil).ReturnExpiredSnoozes), ctx, userID, params)
 }
 
-// MuteNotification mocks base method.
-func (m *MockStore) MuteNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
+// SaveInitialSyncProgress mocks base method.
+func (m *MockStore) SaveInitialSyncProgress(ctx context.Context, userID string, arg db.SaveInitialSyncProgressParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MuteNotification", ctx, userID, githubID)
-	ret0, _ := ret[0].(db.Notification)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SaveInitialSyncProgress", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MuteNotification indicates an expected call of MuteNotification.
-func (mr *MockStoreMockRecorder) MuteNotification(ctx, userID, githubID any) *gomock.Call {
+// SaveInitialSyncProgress indicates an expected call of SaveInitialSyncProgress.
+func (mr *MockStoreMockRecorder) SaveInitialSyncProgress(ctx, userID, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockStore)(nil).MuteNotification), ctx, userID, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInitialSyncProgress", reflect.TypeOf((*MockStore)(nil).SaveInitialSyncProgress), ctx, userID, arg)
 }
 
-// RemoveTagAssignment mocks base method.
-func (m *MockStore) RemoveTagAssignment(ctx context.Context, userID string, arg db.RemoveTagAssignmentParams) error {
+// SetViewGroup mocks base method.
+func (m *MockStore) SetViewGroup(ctx context.Context, userID string, arg db.SetViewGroupParams) (db.View, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveTagAssignment", ctx, userID, arg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetViewGroup", ctx, userID, arg)
+	ret0, _ := ret[0].(db.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveTagAssignment indicates an expected call of RemoveTagAssignment.
-func (mr *MockStoreMockRecorder) RemoveTagAssignment(ctx, userID, arg any) *gomock.Call {
+// SetViewGroup indicates an expected call of SetViewGroup.
+func (mr *MockStoreMockRecorder) SetViewGroup(ctx, userID, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTagAssignment", reflect.TypeOf((*MockStore)(nil).RemoveTagAssignment), ctx, userID, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetViewGroup", reflect.TypeOf((*MockStore)(nil).SetViewGroup), ctx, userID, arg)
 }
 
 // SnoozeNotification mocks base method.
@@ -923,6 +2281,20 @@ func (mr *MockStoreMockRecorder) SnoozeNotification(ctx, userID, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockStore)(nil).SnoozeNotification), ctx, userID, arg)
 }
 
+// StageTriageRestores mocks base method.
+func (m *MockStore) StageTriageRestores(ctx context.Context, userID string, restores []db.TriageRestore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StageTriageRestores", ctx, userID, restores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StageTriageRestores indicates an expected call of StageTriageRestores.
+func (mr *MockStoreMockRecorder) StageTriageRestores(ctx, userID, restores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StageTriageRestores", reflect.TypeOf((*MockStore)(nil).StageTriageRestores), ctx, userID, restores)
+}
+
 // StarNotification mocks base method.
 func (m *MockStore) StarNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -953,6 +2325,21 @@ func (mr *MockStoreMockRecorder) UnarchiveNotification(ctx, userID, githubID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockStore)(nil).UnarchiveNotification), ctx, userID, githubID)
 }
 
+// UndoBulkOperation mocks base method.
+func (m *MockStore) UndoBulkOperation(ctx context.Context, userID string, id int64, undoneAt time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoBulkOperation", ctx, userID, id, undoneAt)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoBulkOperation indicates an expected call of UndoBulkOperation.
+func (mr *MockStoreMockRecorder) UndoBulkOperation(ctx, userID, id, undoneAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoBulkOperation", reflect.TypeOf((*MockStore)(nil).UndoBulkOperation), ctx, userID, id, undoneAt)
+}
+
 // UnmuteNotification mocks base method.
 func (m *MockStore) UnmuteNotification(ctx context.Context, userID, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -998,6 +2385,77 @@ func (mr *MockStoreMockRecorder) UnstarNotification(ctx, userID, githubID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockStore)(nil).UnstarNotification), ctx, userID, githubID)
 }
 
+// UpdateAPITokenLastUsed mocks base method.
+func (m *MockStore) UpdateAPITokenLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPITokenLastUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPITokenLastUsed indicates an expected call of UpdateAPITokenLastUsed.
+func (mr *MockStoreMockRecorder) UpdateAPITokenLastUsed(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPITokenLastUsed", reflect.TypeOf((*MockStore)(nil).UpdateAPITokenLastUsed), ctx, id, usedAt)
+}
+
+// UpdateIntegration mocks base method.
+func (m *MockStore) UpdateIntegration(ctx context.Context, userID, id string, arg db.UpdateIntegrationParams) (db.Integration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIntegration", ctx, userID, id, arg)
+	ret0, _ := ret[0].(db.Integration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIntegration indicates an expected call of UpdateIntegration.
+func (mr *MockStoreMockRecorder) UpdateIntegration(ctx, userID, id, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIntegration", reflect.TypeOf((*MockStore)(nil).UpdateIntegration), ctx, userID, id, arg)
+}
+
+// UpdateIntegrationDelivery mocks base method.
+func (m *MockStore) UpdateIntegrationDelivery(ctx context.Context, userID string, id int64, arg db.UpdateIntegrationDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIntegrationDelivery", ctx, userID, id, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIntegrationDelivery indicates an expected call of UpdateIntegrationDelivery.
+func (mr *MockStoreMockRecorder) UpdateIntegrationDelivery(ctx, userID, id, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIntegrationDelivery", reflect.TypeOf((*MockStore)(nil).UpdateIntegrationDelivery), ctx, userID, id, arg)
+}
+
+// UpdateLinkedAccountSyncedAt mocks base method.
+func (m *MockStore) UpdateLinkedAccountSyncedAt(ctx context.Context, userID, login string, syncedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLinkedAccountSyncedAt", ctx, userID, login, syncedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLinkedAccountSyncedAt indicates an expected call of UpdateLinkedAccountSyncedAt.
+func (mr *MockStoreMockRecorder) UpdateLinkedAccountSyncedAt(ctx, userID, login, syncedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkedAccountSyncedAt", reflect.TypeOf((*MockStore)(nil).UpdateLinkedAccountSyncedAt), ctx, userID, login, syncedAt)
+}
+
+// UpdateNotificationPriority mocks base method.
+func (m *MockStore) UpdateNotificationPriority(ctx context.Context, userID string, id int64, priority sql.NullInt64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPriority", ctx, userID, id, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationPriority indicates an expected call of UpdateNotificationPriority.
+func (mr *MockStoreMockRecorder) UpdateNotificationPriority(ctx, userID, id, priority any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPriority", reflect.TypeOf((*MockStore)(nil).UpdateNotificationPriority), ctx, userID, id, priority)
+}
+
 // UpdateNotificationSubject mocks base method.
 func (m *MockStore) UpdateNotificationSubject(ctx context.Context, userID string, arg db.UpdateNotificationSubjectParams) error {
 	m.ctrl.T.Helper()
@@ -1070,6 +2528,66 @@ func (mr *MockStoreMockRecorder) UpdateTagDisplayOrder(ctx, userID, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTagDisplayOrder", reflect.TypeOf((*MockStore)(nil).UpdateTagDisplayOrder), ctx, userID, arg)
 }
 
+// UpdateUserAlertSettings mocks base method.
+func (m *MockStore) UpdateUserAlertSettings(ctx context.Context, alertSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAlertSettings", ctx, alertSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserAlertSettings indicates an expected call of UpdateUserAlertSettings.
+func (mr *MockStoreMockRecorder) UpdateUserAlertSettings(ctx, alertSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAlertSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserAlertSettings), ctx, alertSettings)
+}
+
+// UpdateUserBackupSettings mocks base method.
+func (m *MockStore) UpdateUserBackupSettings(ctx context.Context, backupSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserBackupSettings", ctx, backupSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserBackupSettings indicates an expected call of UpdateUserBackupSettings.
+func (mr *MockStoreMockRecorder) UpdateUserBackupSettings(ctx, backupSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBackupSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserBackupSettings), ctx, backupSettings)
+}
+
+// UpdateUserDigestSettings mocks base method.
+func (m *MockStore) UpdateUserDigestSettings(ctx context.Context, digestSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserDigestSettings", ctx, digestSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserDigestSettings indicates an expected call of UpdateUserDigestSettings.
+func (mr *MockStoreMockRecorder) UpdateUserDigestSettings(ctx, digestSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserDigestSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserDigestSettings), ctx, digestSettings)
+}
+
+// UpdateUserEscalationSettings mocks base method.
+func (m *MockStore) UpdateUserEscalationSettings(ctx context.Context, escalationSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEscalationSettings", ctx, escalationSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserEscalationSettings indicates an expected call of UpdateUserEscalationSettings.
+func (mr *MockStoreMockRecorder) UpdateUserEscalationSettings(ctx, escalationSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEscalationSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserEscalationSettings), ctx, escalationSettings)
+}
+
 // UpdateUserGitHubIdentity mocks base method.
 func (m *MockStore) UpdateUserGitHubIdentity(ctx context.Context, arg db.UpdateUserGitHubIdentityParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1115,6 +2633,21 @@ func (mr *MockStoreMockRecorder) UpdateUserMutedUntil(ctx, mutedUntil any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMutedUntil", reflect.TypeOf((*MockStore)(nil).UpdateUserMutedUntil), ctx, mutedUntil)
 }
 
+// UpdateUserPrioritySettings mocks base method.
+func (m *MockStore) UpdateUserPrioritySettings(ctx context.Context, prioritySettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPrioritySettings", ctx, prioritySettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPrioritySettings indicates an expected call of UpdateUserPrioritySettings.
+func (mr *MockStoreMockRecorder) UpdateUserPrioritySettings(ctx, prioritySettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPrioritySettings", reflect.TypeOf((*MockStore)(nil).UpdateUserPrioritySettings), ctx, prioritySettings)
+}
+
 // UpdateUserRetentionSettings mocks base method.
 func (m *MockStore) UpdateUserRetentionSettings(ctx context.Context, retentionSettings sql.NullString) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1130,6 +2663,21 @@ func (mr *MockStoreMockRecorder) UpdateUserRetentionSettings(ctx, retentionSetti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRetentionSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserRetentionSettings), ctx, retentionSettings)
 }
 
+// UpdateUserSnoozeSettings mocks base method.
+func (m *MockStore) UpdateUserSnoozeSettings(ctx context.Context, snoozeSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSnoozeSettings", ctx, snoozeSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSnoozeSettings indicates an expected call of UpdateUserSnoozeSettings.
+func (mr *MockStoreMockRecorder) UpdateUserSnoozeSettings(ctx, snoozeSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSnoozeSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserSnoozeSettings), ctx, snoozeSettings)
+}
+
 // UpdateUserSyncSettings mocks base method.
 func (m *MockStore) UpdateUserSyncSettings(ctx context.Context, syncSettings db.NullRawMessage) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1145,6 +2693,21 @@ func (mr *MockStoreMockRecorder) UpdateUserSyncSettings(ctx, syncSettings any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSyncSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserSyncSettings), ctx, syncSettings)
 }
 
+// UpdateUserTeamSettings mocks base method.
+func (m *MockStore) UpdateUserTeamSettings(ctx context.Context, teamSettings db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTeamSettings", ctx, teamSettings)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserTeamSettings indicates an expected call of UpdateUserTeamSettings.
+func (mr *MockStoreMockRecorder) UpdateUserTeamSettings(ctx, teamSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTeamSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserTeamSettings), ctx, teamSettings)
+}
+
 // UpdateUserUpdateSettings mocks base method.
 func (m *MockStore) UpdateUserUpdateSettings(ctx context.Context, updateSettings db.NullRawMessage) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1160,6 +2723,21 @@ func (mr *MockStoreMockRecorder) UpdateUserUpdateSettings(ctx, updateSettings an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserUpdateSettings", reflect.TypeOf((*MockStore)(nil).UpdateUserUpdateSettings), ctx, updateSettings)
 }
 
+// UpdateUserWorkingHours mocks base method.
+func (m *MockStore) UpdateUserWorkingHours(ctx context.Context, workingHours db.NullRawMessage) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserWorkingHours", ctx, workingHours)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserWorkingHours indicates an expected call of UpdateUserWorkingHours.
+func (mr *MockStoreMockRecorder) UpdateUserWorkingHours(ctx, workingHours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserWorkingHours", reflect.TypeOf((*MockStore)(nil).UpdateUserWorkingHours), ctx, workingHours)
+}
+
 // UpdateView mocks base method.
 func (m *MockStore) UpdateView(ctx context.Context, userID string, arg db.UpdateViewParams) (db.View, error) {
 	m.ctrl.T.Helper()
@@ -1175,6 +2753,35 @@ func (mr *MockStoreMockRecorder) UpdateView(ctx, userID, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockStore)(nil).UpdateView), ctx, userID, arg)
 }
 
+// UpdateViewGroup mocks base method.
+func (m *MockStore) UpdateViewGroup(ctx context.Context, userID string, arg db.UpdateViewGroupParams) (db.ViewGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateViewGroup", ctx, userID, arg)
+	ret0, _ := ret[0].(db.ViewGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateViewGroup indicates an expected call of UpdateViewGroup.
+func (mr *MockStoreMockRecorder) UpdateViewGroup(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewGroup", reflect.TypeOf((*MockStore)(nil).UpdateViewGroup), ctx, userID, arg)
+}
+
+// UpdateViewGroupOrder mocks base method.
+func (m *MockStore) UpdateViewGroupOrder(ctx context.Context, userID string, arg db.UpdateViewGroupOrderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateViewGroupOrder", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateViewGroupOrder indicates an expected call of UpdateViewGroupOrder.
+func (mr *MockStoreMockRecorder) UpdateViewGroupOrder(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewGroupOrder", reflect.TypeOf((*MockStore)(nil).UpdateViewGroupOrder), ctx, userID, arg)
+}
+
 // UpdateViewOrder mocks base method.
 func (m *MockStore) UpdateViewOrder(ctx context.Context, userID string, arg db.UpdateViewOrderParams) error {
 	m.ctrl.T.Helper()
@@ -1189,6 +2796,50 @@ func (mr *MockStoreMockRecorder) UpdateViewOrder(ctx, userID, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewOrder", reflect.TypeOf((*MockStore)(nil).UpdateViewOrder), ctx, userID, arg)
 }
 
+// UpdateViewWIPLimit mocks base method.
+func (m *MockStore) UpdateViewWIPLimit(ctx context.Context, userID string, arg db.UpdateViewWIPLimitParams) (db.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateViewWIPLimit", ctx, userID, arg)
+	ret0, _ := ret[0].(db.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateViewWIPLimit indicates an expected call of UpdateViewWIPLimit.
+func (mr *MockStoreMockRecorder) UpdateViewWIPLimit(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewWIPLimit", reflect.TypeOf((*MockStore)(nil).UpdateViewWIPLimit), ctx, userID, arg)
+}
+
+// UpsertAuthorProfile mocks base method.
+func (m *MockStore) UpsertAuthorProfile(ctx context.Context, userID string, arg db.UpsertAuthorProfileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAuthorProfile", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertAuthorProfile indicates an expected call of UpsertAuthorProfile.
+func (mr *MockStoreMockRecorder) UpsertAuthorProfile(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAuthorProfile", reflect.TypeOf((*MockStore)(nil).UpsertAuthorProfile), ctx, userID, arg)
+}
+
+// UpsertLinkedAccount mocks base method.
+func (m *MockStore) UpsertLinkedAccount(ctx context.Context, userID string, arg db.UpsertLinkedAccountParams) (db.LinkedAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLinkedAccount", ctx, userID, arg)
+	ret0, _ := ret[0].(db.LinkedAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertLinkedAccount indicates an expected call of UpsertLinkedAccount.
+func (mr *MockStoreMockRecorder) UpsertLinkedAccount(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLinkedAccount", reflect.TypeOf((*MockStore)(nil).UpsertLinkedAccount), ctx, userID, arg)
+}
+
 // UpsertNotification mocks base method.
 func (m *MockStore) UpsertNotification(ctx context.Context, userID string, arg db.UpsertNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -1204,6 +2855,21 @@ func (mr *MockStoreMockRecorder) UpsertNotification(ctx, userID, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotification", reflect.TypeOf((*MockStore)(nil).UpsertNotification), ctx, userID, arg)
 }
 
+// UpsertOrgOffboarding mocks base method.
+func (m *MockStore) UpsertOrgOffboarding(ctx context.Context, userID string, arg db.UpsertOrgOffboardingParams) (db.OrgOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrgOffboarding", ctx, userID, arg)
+	ret0, _ := ret[0].(db.OrgOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrgOffboarding indicates an expected call of UpsertOrgOffboarding.
+func (mr *MockStoreMockRecorder) UpsertOrgOffboarding(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrgOffboarding", reflect.TypeOf((*MockStore)(nil).UpsertOrgOffboarding), ctx, userID, arg)
+}
+
 // UpsertPullRequest mocks base method.
 func (m *MockStore) UpsertPullRequest(ctx context.Context, userID string, arg db.UpsertPullRequestParams) (db.PullRequest, error) {
 	m.ctrl.T.Helper()
@@ -1234,6 +2900,35 @@ func (mr *MockStoreMockRecorder) UpsertRepository(ctx, userID, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRepository", reflect.TypeOf((*MockStore)(nil).UpsertRepository), ctx, userID, arg)
 }
 
+// UpsertRepositorySettings mocks base method.
+func (m *MockStore) UpsertRepositorySettings(ctx context.Context, userID string, arg db.UpsertRepositorySettingsParams) (db.RepositorySetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRepositorySettings", ctx, userID, arg)
+	ret0, _ := ret[0].(db.RepositorySetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRepositorySettings indicates an expected call of UpsertRepositorySettings.
+func (mr *MockStoreMockRecorder) UpsertRepositorySettings(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRepositorySettings", reflect.TypeOf((*MockStore)(nil).UpsertRepositorySettings), ctx, userID, arg)
+}
+
+// UpsertRuleEvaluation mocks base method.
+func (m *MockStore) UpsertRuleEvaluation(ctx context.Context, userID string, arg db.UpsertRuleEvaluationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRuleEvaluation", ctx, userID, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRuleEvaluation indicates an expected call of UpsertRuleEvaluation.
+func (mr *MockStoreMockRecorder) UpsertRuleEvaluation(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRuleEvaluation", reflect.TypeOf((*MockStore)(nil).UpsertRuleEvaluation), ctx, userID, arg)
+}
+
 // UpsertSyncState mocks base method.
 func (m *MockStore) UpsertSyncState(ctx context.Context, userID string, arg db.UpsertSyncStateParams) (db.UpsertSyncStateRow, error) {
 	m.ctrl.T.Helper()
@@ -1263,3 +2958,18 @@ func (mr *MockStoreMockRecorder) UpsertTag(ctx, userID, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTag", reflect.TypeOf((*MockStore)(nil).UpsertTag), ctx, userID, arg)
 }
+
+// UpsertViewShare mocks base method.
+func (m *MockStore) UpsertViewShare(ctx context.Context, userID string, arg db.UpsertViewShareParams) (db.ViewShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertViewShare", ctx, userID, arg)
+	ret0, _ := ret[0].(db.ViewShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertViewShare indicates an expected call of UpsertViewShare.
+func (mr *MockStoreMockRecorder) UpsertViewShare(ctx, userID, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertViewShare", reflect.TypeOf((*MockStore)(nil).UpsertViewShare), ctx, userID, arg)
+}
@@ -21,6 +21,94 @@ import (
 	"time"
 )
 
+// AuthorProfile represents a cached GitHub profile for a notification author
+type AuthorProfile struct {
+	ID        int64
+	UserID    string
+	Login     string
+	Name      sql.NullString
+	AvatarURL sql.NullString
+	Type      string
+	FetchedAt time.Time
+}
+
+// BulkOperation records a bulk operation that can be undone
+type BulkOperation struct {
+	ID        int64
+	UserID    string
+	Operation string // The bulk operation, e.g. "archive" or "mark-read"
+	CreatedAt time.Time
+	UndoneAt  sql.NullTime
+}
+
+// ChangelogEntry represents a user-facing note about a schema or behavior change
+type ChangelogEntry struct {
+	ID             int64
+	Key            string
+	SchemaVersion  int64
+	Kind           string
+	Title          string
+	Description    string
+	CreatedAt      time.Time
+	AcknowledgedAt sql.NullTime
+}
+
+// Integration is an outbound webhook that rules can notify about matched notifications
+type Integration struct {
+	ID              string
+	UserID          string
+	Name            string
+	Format          string // "json" or "slack"
+	URL             string
+	SecretEncrypted sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IntegrationDelivery is one notification a rule sent, or is still sending, to an
+// integration
+type IntegrationDelivery struct {
+	ID             int64
+	UserID         string
+	IntegrationID  string
+	RuleID         sql.NullString
+	RuleName       string
+	GithubID       string
+	Status         string // "pending", "delivered" or "failed"
+	Attempts       int64
+	ResponseStatus sql.NullInt64
+	LastError      sql.NullString
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    sql.NullTime
+}
+
+// APIToken is a personal API token. Only a hash of the token itself is stored.
+type APIToken struct {
+	ID          string
+	UserID      string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scope       string // "read" or "write"
+	Account     string // GitHub login the token may access, or "*"
+	CreatedAt   time.Time
+	ExpiresAt   sql.NullTime
+	LastUsedAt  sql.NullTime
+}
+
+// LinkedAccount is an additional GitHub account whose notifications are synced
+// alongside the primary account's
+type LinkedAccount struct {
+	ID             int64
+	UserID         string
+	GithubUserID   string
+	Login          string
+	TokenEncrypted sql.NullString
+	LastSyncedAt   sql.NullTime
+	CreatedAt      time.Time
+}
+
 // Notification represents a notification
 type Notification struct {
 	ID                      int64
@@ -52,32 +140,89 @@ type Notification struct {
 	SnoozedAt               sql.NullTime
 	Starred                 bool
 	Filtered                bool
-	TagIDs                  []string // Changed from []int64 to []string for UUID tags
+	TagIDs                  []string    // Changed from []int64 to []string for UUID tags
+	TagsAssignedAt          []time.Time // When each of TagIDs was assigned, in the same order
 	SubjectNumber           sql.NullInt32
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullBool
 	SubjectStateReason      sql.NullString
+	ContentKind             string
+	HeadBranch              sql.NullString
+	BaseBranch              sql.NullString
+	Account                 sql.NullString
+	ArchivedAt              sql.NullTime
+	AwaitingReplySince      sql.NullTime
+	AwaitingReply           bool
+	SubjectDraft            sql.NullBool
+	SubjectLabels           NullRawMessage // JSON array of label names
+	ReviewState             sql.NullString
+	SnoozeUntilUpdated      bool // The snooze also ends when sync sees the thread updated
+	ReturnedFromSnoozeAt    sql.NullTime
+	SubjectMilestone        sql.NullString
+	SubjectProjects         NullRawMessage // JSON array of project titles
+	CIState                 sql.NullString // Combined checks of an open pull request's head
+	SubjectMergeable        sql.NullBool   // False when an open pull request has conflicts
+	WaitingOn               sql.NullString // Whose turn it is on an open subject: me or them
+	Priority                sql.NullInt64  // Score from the user's priority weights
+}
+
+// NotificationAlert is the alert decided for a notification when it arrived during sync
+type NotificationAlert struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	GithubID       string // Only set when listed
+	Level          string
+	Sound          sql.NullString
+	Source         string         // "rule", "view" or "default"
+	SourceID       sql.NullString // Rule or view ID
+	Suppressed     sql.NullString // "dnd" or "quiet_hours" when the alert was downgraded
+	CreatedAt      time.Time
+}
+
+// NotificationEvent is one change to a notification's state in its history
+type NotificationEvent struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	Action         string         // What changed, e.g. "archived" or "rule_applied"
+	Source         string         // "user", "rule", "sync" or "system"
+	Detail         sql.NullString // The rule's name for rule events
+	CreatedAt      time.Time
+}
+
+// OrgOffboarding records an organization the user left and what leaving it changed
+type OrgOffboarding struct {
+	ID                    int64
+	UserID                string
+	Org                   string
+	Action                string // "archive" or "delete"
+	NotificationsAffected int64  // Notifications archived or deleted
+	Repositories          int64  // The org's repositories, no longer synced
+	CreatedAt             time.Time
 }
 
 // PullRequest represents a pull request
 type PullRequest struct {
-	ID           int64
-	UserID       string
-	RepositoryID int64
-	GithubID     sql.NullInt64
-	NodeID       sql.NullString
-	Number       int32
-	Title        sql.NullString
-	State        sql.NullString
-	Draft        sql.NullBool
-	Merged       sql.NullBool
-	AuthorLogin  sql.NullString
-	AuthorID     sql.NullInt64
-	CreatedAt    sql.NullTime
-	UpdatedAt    sql.NullTime
-	ClosedAt     sql.NullTime
-	MergedAt     sql.NullTime
-	Raw          NullRawMessage
+	ID                 int64
+	UserID             string
+	RepositoryID       int64
+	GithubID           sql.NullInt64
+	NodeID             sql.NullString
+	Number             int32
+	Title              sql.NullString
+	State              sql.NullString
+	Draft              sql.NullBool
+	Merged             sql.NullBool
+	AuthorLogin        sql.NullString
+	AuthorID           sql.NullInt64
+	CreatedAt          sql.NullTime
+	UpdatedAt          sql.NullTime
+	ClosedAt           sql.NullTime
+	MergedAt           sql.NullTime
+	Raw                NullRawMessage
+	RequestedReviewers NullRawMessage // JSON array of requested reviewer logins
+	RequestedTeams     NullRawMessage // JSON array of requested "org/team" names
 }
 
 // Repository represents a repository
@@ -106,19 +251,61 @@ type Repository struct {
 	OwnerHTMLURL   sql.NullString
 }
 
+// RecentAccess is a notification list or notification the user opened recently
+type RecentAccess struct {
+	UserID     string
+	Kind       string // "query", "notification" or "adhoc_query"
+	Target     string // The query or the notification's GitHub ID
+	AccessedAt time.Time
+}
+
+// RepositorySetting holds the user's settings for a repository
+type RepositorySetting struct {
+	UserID                      string
+	RepositoryID                int64
+	DefaultSnooze               sql.NullString // Snooze preset or duration, e.g. "1w"
+	ResurfaceSuppressionMinutes sql.NullInt64  // Overrides the user's window when set
+	SyncMode                    string         // "full", "headers_only", or "excluded"
+	UpdatedAt                   time.Time
+}
+
 // Rule represents a rule
 type Rule struct {
-	ID           string // UUID
-	UserID       string
-	Name         string
-	Description  sql.NullString
-	Query        sql.NullString
-	Enabled      bool
-	Actions      json.RawMessage
-	DisplayOrder int32
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	ViewID       sql.NullString // References views.id (UUID)
+	ID             string // UUID
+	UserID         string
+	Name           string
+	Description    sql.NullString
+	Query          sql.NullString
+	Enabled        bool
+	Actions        json.RawMessage
+	DisplayOrder   int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ViewID         sql.NullString // References views.id (UUID)
+	Priority       int32          // Higher runs first; ties run in display order
+	StopProcessing bool           // When the rule matches, later rules are skipped
+	Condition      sql.NullString // Expression a matching notification must also satisfy
+}
+
+// RuleEvaluation is the latest record of which rules ran against a notification
+type RuleEvaluation struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	GithubID       string // Only set when listed
+	SubjectTitle   string // Only set when listed
+	Trace          json.RawMessage
+	EvaluatedAt    time.Time
+}
+
+// SavedReply represents one of the user's GitHub saved replies
+type SavedReply struct {
+	ID        int64
+	UserID    string
+	GithubID  string
+	Title     string
+	Body      string
+	FetchedAt time.Time
 }
 
 // SyncState represents a sync state
@@ -132,6 +319,9 @@ type SyncState struct {
 	LatestNotificationAt       sql.NullTime
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	InitialSyncStartedAt       sql.NullTime
+	InitialSyncPages           int64
+	InitialSyncQueued          int64
 }
 
 // Tag represents a tag
@@ -144,6 +334,7 @@ type Tag struct {
 	CreatedAt    time.Time
 	DisplayOrder int32
 	Slug         string
+	AssignedAt   sql.NullTime // When the tag was assigned, set when listing an entity's tags
 }
 
 // TagAssignment represents a tag assignment
@@ -167,6 +358,14 @@ type User struct {
 	SyncSettings         NullRawMessage
 	RetentionSettings    NullRawMessage
 	UpdateSettings       NullRawMessage
+	EscalationSettings   NullRawMessage
+	WorkingHours         NullRawMessage
+	TeamSettings         NullRawMessage
+	AlertSettings        NullRawMessage
+	SnoozeSettings       NullRawMessage
+	BackupSettings       NullRawMessage
+	PrioritySettings     NullRawMessage
+	DigestSettings       NullRawMessage
 	MutedUntil           sql.NullTime
 }
 
@@ -182,4 +381,28 @@ type View struct {
 	Slug         string
 	Query        sql.NullString
 	DisplayOrder int32
+	// WIPLimit is how many notifications the view should hold at most (null = no limit)
+	WIPLimit sql.NullInt64
+	// WIPAutoSnooze snoozes notifications past the limit instead of only warning about them
+	WIPAutoSnooze bool
+	GroupID       sql.NullString // References view_groups.id (UUID); null when ungrouped
+}
+
+// ViewShare grants another user of a multi-user server access to a view
+type ViewShare struct {
+	UserID     string
+	ViewID     string // References views.id (UUID)
+	Grantee    string // Username of the user the view is shared with
+	Permission string // "read" or "write"
+	CreatedAt  time.Time
+}
+
+// ViewGroup represents a named group of views in the sidebar
+type ViewGroup struct {
+	ID           string // UUID
+	UserID       string
+	Name         string
+	DisplayOrder int32
+	Collapsed    bool
+	CreatedAt    time.Time
 }
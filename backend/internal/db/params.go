@@ -18,6 +18,8 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 // NotificationQuery represents a complete notification query ready for SQL execution
@@ -28,12 +30,61 @@ type NotificationQuery struct {
 	Limit          int32
 	Offset         int32
 	IncludeSubject bool // Whether to include subject_raw in SELECT (default: true for backward compatibility)
+	// Collapse notifications sharing a subject URL into their newest one, so each subject's
+	// conversation is listed once and the total counts conversations
+	CollapseBySubject bool
+	// Reverse lists notifications in the opposite order, oldest first. Ignored when
+	// collapsed by subject.
+	Reverse bool
+	// ByPriority lists the highest priority score first, most recently updated first among
+	// equal scores. Unscored notifications count as zero. Ignored when collapsed by subject.
+	ByPriority bool
+	// After switches to keyset pagination: only notifications listed after the cursor are
+	// returned, and Offset is ignored
+	After *NotificationCursor
 }
 
 // ListNotificationsFromQueryResult contains the notifications and total count
 type ListNotificationsFromQueryResult struct {
 	Notifications []Notification
 	Total         int64
+	// ConversationSizes is how many matching notifications share each listed notification's
+	// subject, keyed by notification ID. Only set when collapsed by subject.
+	ConversationSizes map[int64]int64
+	// NextCursor is the position of the last notification listed, set when the page is
+	// full so there may be more
+	NextCursor *NotificationCursor
+}
+
+// NotificationQueryPlan is the statement a notification query lists a page with, the
+// arguments bound to its placeholders in order, and how SQLite plans to run it
+type NotificationQueryPlan struct {
+	SQL   string
+	Args  []interface{}
+	Steps []QueryPlanStep
+}
+
+// QueryPlanStep is a row of SQLite's EXPLAIN QUERY PLAN output. Parent is the ID of the
+// step it's nested under, or 0 at the top level.
+type QueryPlanStep struct {
+	ID     int64
+	Parent int64
+	Detail string
+}
+
+// NotificationGroupsParams contains the parameters for grouping notifications matching a query.
+// The query's limit and offset are ignored; every matching notification is counted.
+type NotificationGroupsParams struct {
+	Query    NotificationQuery
+	GroupBy  NotificationGroupKey
+	PerGroup int32 // Newest notifications returned per group (0 = counts only)
+}
+
+// NotificationGroup is one group of notifications matching a query
+type NotificationGroup struct {
+	Key           string // Empty when the notifications have no value for the group key
+	Count         int64
+	Notifications []Notification
 }
 
 // BulkSnoozeNotificationsByQueryParams contains the parameters for snoozing by query
@@ -42,6 +93,12 @@ type BulkSnoozeNotificationsByQueryParams struct {
 	SnoozedUntil sql.NullTime
 }
 
+// BulkTagNotificationsByQueryParams contains the parameters for tagging or untagging by query
+type BulkTagNotificationsByQueryParams struct {
+	Query NotificationQuery
+	TagID string // UUID
+}
+
 // BulkSnoozeNotificationsParams contains the parameters for bulk snooze
 type BulkSnoozeNotificationsParams struct {
 	GithubIDs    []string
@@ -52,6 +109,7 @@ type BulkSnoozeNotificationsParams struct {
 type SnoozeNotificationParams struct {
 	GithubID     string
 	SnoozedUntil sql.NullTime
+	UntilUpdated bool // Also end the snooze when sync sees the thread updated
 }
 
 // UpsertTagParams contains the parameters for upserting a tag
@@ -118,34 +176,66 @@ type UpdateViewParams struct {
 	IsDefault   sql.NullBool
 }
 
+// UpdateViewWIPLimitParams contains the parameters for setting a view's WIP limit
+type UpdateViewWIPLimitParams struct {
+	ID         string        // UUID
+	Limit      sql.NullInt64 // Null clears the limit
+	AutoSnooze bool
+}
+
 // UpdateViewOrderParams contains the parameters for updating view display order
 type UpdateViewOrderParams struct {
 	ID           string // UUID
 	DisplayOrder int32
 }
 
+// SetViewGroupParams contains the parameters for moving a view into or out of a group
+type SetViewGroupParams struct {
+	ViewID  string         // UUID
+	GroupID sql.NullString // UUID; null ungroups the view
+}
+
+// UpdateViewGroupParams contains the parameters for updating a view group
+type UpdateViewGroupParams struct {
+	ID        string // UUID
+	Name      sql.NullString
+	Collapsed sql.NullBool
+}
+
+// UpdateViewGroupOrderParams contains the parameters for updating view group display order
+type UpdateViewGroupOrderParams struct {
+	ID           string // UUID
+	DisplayOrder int32
+}
+
 // CreateRuleParams contains the parameters for creating a rule
 type CreateRuleParams struct {
-	Name         string
-	Description  sql.NullString
-	Query        sql.NullString
-	ViewID       sql.NullString // UUID
-	Enabled      bool
-	Actions      []byte
-	DisplayOrder int32
+	Name           string
+	Description    sql.NullString
+	Query          sql.NullString
+	ViewID         sql.NullString // UUID
+	Enabled        bool
+	Actions        []byte
+	DisplayOrder   int32
+	Priority       int32
+	StopProcessing bool
+	Condition      sql.NullString
 }
 
 // UpdateRuleParams contains the parameters for updating a rule
 type UpdateRuleParams struct {
-	ID          string // UUID
-	Name        sql.NullString
-	Description sql.NullString
-	Query       sql.NullString
-	ClearQuery  sql.NullBool
-	ViewID      sql.NullString // UUID
-	ClearViewID sql.NullBool
-	Enabled     sql.NullBool
-	Actions     NullRawMessage
+	ID             string // UUID
+	Name           sql.NullString
+	Description    sql.NullString
+	Query          sql.NullString
+	ClearQuery     sql.NullBool
+	ViewID         sql.NullString // UUID
+	ClearViewID    sql.NullBool
+	Enabled        sql.NullBool
+	Actions        NullRawMessage
+	Priority       sql.NullInt32
+	StopProcessing sql.NullBool
+	Condition      sql.NullString // An empty string clears it
 }
 
 // UpdateRuleOrderParams contains the parameters for updating rule display order
@@ -178,23 +268,49 @@ type UpsertRepositoryParams struct {
 	OwnerHTMLURL   sql.NullString
 }
 
+// RenameRepositoryParams contains the parameters for renaming a repository.
+// OldFullName is recorded as an alias so queries against the previous name keep matching.
+type RenameRepositoryParams struct {
+	ID          int64
+	OldFullName string
+	FullName    string
+	Name        string
+	OwnerLogin  sql.NullString
+}
+
+// MergeRepositoriesParams contains the parameters for merging a duplicate repository row into
+// another row for the same GitHub repository. DuplicateID is deleted once everything pointing
+// at it has moved to KeepID.
+type MergeRepositoriesParams struct {
+	KeepID      int64
+	DuplicateID int64
+}
+
+// MergeRepositoriesResult reports how many rows were repointed at the kept repository.
+type MergeRepositoriesResult struct {
+	NotificationsMoved int64
+	PullRequestsMoved  int64
+}
+
 // UpsertPullRequestParams contains the parameters for upserting a pull request
 type UpsertPullRequestParams struct {
-	RepositoryID int64
-	GithubID     sql.NullInt64
-	NodeID       sql.NullString
-	Number       int32
-	Title        sql.NullString
-	State        sql.NullString
-	Draft        sql.NullBool
-	Merged       sql.NullBool
-	AuthorLogin  sql.NullString
-	AuthorID     sql.NullInt64
-	CreatedAt    sql.NullTime
-	UpdatedAt    sql.NullTime
-	ClosedAt     sql.NullTime
-	MergedAt     sql.NullTime
-	Raw          NullRawMessage
+	RepositoryID       int64
+	GithubID           sql.NullInt64
+	NodeID             sql.NullString
+	Number             int32
+	Title              sql.NullString
+	State              sql.NullString
+	Draft              sql.NullBool
+	Merged             sql.NullBool
+	AuthorLogin        sql.NullString
+	AuthorID           sql.NullInt64
+	CreatedAt          sql.NullTime
+	UpdatedAt          sql.NullTime
+	ClosedAt           sql.NullTime
+	MergedAt           sql.NullTime
+	Raw                NullRawMessage
+	RequestedReviewers NullRawMessage
+	RequestedTeams     NullRawMessage
 }
 
 // GetSyncStateRow contains the result of getting sync state
@@ -208,6 +324,9 @@ type GetSyncStateRow struct {
 	LatestNotificationAt       sql.NullTime
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	InitialSyncStartedAt       sql.NullTime
+	InitialSyncPages           int64
+	InitialSyncQueued          int64
 }
 
 // UpsertSyncStateParams contains the parameters for upserting sync state
@@ -230,6 +349,18 @@ type UpsertSyncStateRow struct {
 	LatestNotificationAt       sql.NullTime
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	InitialSyncStartedAt       sql.NullTime
+	InitialSyncPages           int64
+	InitialSyncQueued          int64
+}
+
+// SaveInitialSyncProgressParams contains the progress of the initial sync after a page
+type SaveInitialSyncProgressParams struct {
+	LatestNotificationAt       sql.NullTime
+	OldestNotificationSyncedAt sql.NullTime
+	StartedAt                  time.Time
+	Pages                      int64
+	Queued                     int64
 }
 
 // UpsertNotificationParams contains the parameters for upserting a notification
@@ -256,6 +387,27 @@ type UpsertNotificationParams struct {
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullBool
 	SubjectStateReason      sql.NullString
+	HeadBranch              sql.NullString
+	BaseBranch              sql.NullString
+	SubjectDraft            sql.NullBool
+	SubjectLabels           NullRawMessage
+	ReviewState             sql.NullString
+	SubjectMilestone        sql.NullString
+	SubjectProjects         NullRawMessage // JSON array of project titles; NULL keeps the stored ones
+	CIState                 sql.NullString
+	SubjectMergeable        sql.NullBool
+	WaitingOn               sql.NullString
+	Priority                sql.NullInt64
+	Account                 sql.NullString
+	// KeepArchivedSince suppresses resurfacing: a notification archived at or after this
+	// time stays archived even when the sync brings new activity.
+	KeepArchivedSince sql.NullTime
+	// LatestCommentBody replaces the body of the latest comment in the search index when
+	// valid. It is left as it was when not.
+	LatestCommentBody sql.NullString
+	// AwaitingReplySince is stored along with LatestCommentBody: when the latest comment was
+	// posted if the synced account wrote it, NULL otherwise.
+	AwaitingReplySince sql.NullTime
 }
 
 // UpdateNotificationSubjectParams contains the parameters for updating notification subject
@@ -268,12 +420,182 @@ type UpdateNotificationSubjectParams struct {
 	SubjectState       sql.NullString
 	SubjectMerged      sql.NullBool
 	SubjectStateReason sql.NullString
+	HeadBranch         sql.NullString
+	BaseBranch         sql.NullString
+	SubjectDraft       sql.NullBool
+	SubjectLabels      NullRawMessage
+	ReviewState        sql.NullString
+	SubjectMilestone   sql.NullString
+	SubjectProjects    NullRawMessage // NULL keeps the stored projects
+	CIState            sql.NullString
+	SubjectMergeable   sql.NullBool
+	WaitingOn          sql.NullString
+	Priority           sql.NullInt64
 	AuthorLogin        sql.NullString
 	AuthorID           sql.NullInt64
 }
 
+// UpsertAuthorProfileParams contains the parameters for upserting an author profile
+type UpsertAuthorProfileParams struct {
+	Login     string
+	Name      sql.NullString
+	AvatarURL sql.NullString
+	Type      string
+	FetchedAt time.Time
+}
+
+// InsertChangelogEntryParams contains the parameters for recording a changelog entry
+type InsertChangelogEntryParams struct {
+	Key           string
+	SchemaVersion int64
+	Kind          string
+	Title         string
+	Description   string
+	CreatedAt     time.Time
+}
+
+// SavedReplyParams contains the fields of one imported saved reply
+type SavedReplyParams struct {
+	GithubID string
+	Title    string
+	Body     string
+}
+
+// ReplaceSavedRepliesParams contains the parameters for replacing a user's saved replies
+type ReplaceSavedRepliesParams struct {
+	Replies   []SavedReplyParams
+	FetchedAt time.Time
+}
+
+// CreateNotificationAlertParams contains the parameters for recording a notification alert
+type CreateNotificationAlertParams struct {
+	NotificationID int64
+	Level          string
+	Sound          sql.NullString
+	Source         string
+	SourceID       sql.NullString
+	Suppressed     sql.NullString
+	CreatedAt      time.Time
+}
+
+// UpsertRuleEvaluationParams contains the parameters for recording a rule evaluation
+type UpsertRuleEvaluationParams struct {
+	NotificationID int64
+	Trace          json.RawMessage
+	EvaluatedAt    time.Time
+}
+
 // UpdateUserGitHubIdentityParams contains the parameters for updating user's GitHub identity
 type UpdateUserGitHubIdentityParams struct {
 	GithubUserID   sql.NullString
 	GithubUsername sql.NullString
 }
+
+// RecordNotificationEventsParams contains the parameters for recording the same event in
+// the history of several notifications
+type RecordNotificationEventsParams struct {
+	GithubIDs []string
+	Action    string
+	Source    string
+	Detail    sql.NullString
+	CreatedAt time.Time
+}
+
+// RecordRecentAccessParams contains the parameters for recording that something was opened
+type RecordRecentAccessParams struct {
+	Kind       string
+	Target     string
+	AccessedAt time.Time
+	Keep       int64 // How many entries of the kind to keep, most recent first
+}
+
+// UpsertLinkedAccountParams contains the parameters for linking a GitHub account
+type UpsertLinkedAccountParams struct {
+	GithubUserID   string
+	Login          string
+	TokenEncrypted sql.NullString
+}
+
+// UpsertOrgOffboardingParams contains the parameters for recording an org offboarding
+type UpsertOrgOffboardingParams struct {
+	Org                   string
+	Action                string
+	NotificationsAffected int64
+	Repositories          int64
+}
+
+// UpsertRepositorySettingsParams contains the parameters for saving a repository's settings
+type UpsertRepositorySettingsParams struct {
+	RepositoryID                int64
+	DefaultSnooze               sql.NullString
+	ResurfaceSuppressionMinutes sql.NullInt64
+	SyncMode                    string
+}
+
+// CaptureBulkOperationParams contains the parameters for saving the state of the
+// notifications a bulk operation is about to change
+type CaptureBulkOperationParams struct {
+	Operation string
+	GithubIDs []string
+	CreatedAt time.Time
+	// Operations captured before this can no longer be undone and are removed
+	PruneBefore time.Time
+}
+
+// UpsertViewShareParams contains the parameters for sharing a view
+type UpsertViewShareParams struct {
+	ViewID     string
+	Grantee    string
+	Permission string
+	CreatedAt  time.Time
+}
+
+// CreateAPITokenParams contains the parameters for creating an API token
+type CreateAPITokenParams struct {
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scope       string
+	Account     string
+	CreatedAt   time.Time
+	ExpiresAt   sql.NullTime
+}
+
+// CreateIntegrationParams contains the parameters for creating an integration
+type CreateIntegrationParams struct {
+	Name            string
+	Format          string
+	URL             string
+	SecretEncrypted sql.NullString
+	CreatedAt       time.Time
+}
+
+// UpdateIntegrationParams contains the parameters for updating an integration. Every field
+// is replaced.
+type UpdateIntegrationParams struct {
+	Name            string
+	Format          string
+	URL             string
+	SecretEncrypted sql.NullString
+	UpdatedAt       time.Time
+}
+
+// CreateIntegrationDeliveryParams contains the parameters for queueing a delivery to an
+// integration
+type CreateIntegrationDeliveryParams struct {
+	IntegrationID string
+	RuleID        sql.NullString
+	RuleName      string
+	GithubID      string
+	CreatedAt     time.Time
+}
+
+// UpdateIntegrationDeliveryParams contains the outcome of an attempt at a delivery
+type UpdateIntegrationDeliveryParams struct {
+	Status         string
+	Attempts       int64
+	ResponseStatus sql.NullInt64
+	LastError      sql.NullString
+	NextAttemptAt  time.Time
+	DeliveredAt    sql.NullTime
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package snapshot writes read-only copies of the database for running ad-hoc SQL
+// against, without holding locks on the live database.
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName is the default snapshot file name, written next to the live database
+const FileName = "octobud-snapshot.db"
+
+// ErrNoSnapshot is returned when no snapshot has been written yet
+var ErrNoSnapshot = errors.New("no snapshot has been written")
+
+// Info describes a snapshot on disk
+type Info struct {
+	Path      string    `json:"path"`
+	SizeBytes int64     `json:"sizeBytes"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Write copies the database at srcPath to dstPath with VACUUM INTO, replacing any
+// previous snapshot. The source is opened read-only on its own connection, so the
+// copy is a consistent point-in-time view and writers aren't blocked (in WAL mode).
+// The snapshot is written to a temporary file first and made read-only, so readers
+// never see a partial copy.
+func Write(ctx context.Context, srcPath, dstPath string) (Info, error) {
+	if _, err := os.Stat(srcPath); err != nil {
+		return Info{}, fmt.Errorf("failed to open source database: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o700); err != nil {
+		return Info{}, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	src, err := sql.Open("sqlite", "file:"+srcPath+"?mode=ro&_pragma=busy_timeout(2000)")
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to open source database: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	// VACUUM INTO refuses to overwrite, so clear out anything left by a failed run
+	tmpPath := dstPath + ".tmp"
+	_ = os.Remove(tmpPath)
+	if _, err := src.ExecContext(ctx, "VACUUM INTO ?", tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return Info{}, fmt.Errorf("failed to copy database: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o400); err != nil {
+		_ = os.Remove(tmpPath)
+		return Info{}, fmt.Errorf("failed to make snapshot read-only: %w", err)
+	}
+	// Windows can't rename over a read-only file
+	if err := os.Remove(dstPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		_ = os.Remove(tmpPath)
+		return Info{}, fmt.Errorf("failed to replace previous snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return Info{}, fmt.Errorf("failed to replace previous snapshot: %w", err)
+	}
+	return Stat(dstPath)
+}
+
+// Stat describes the snapshot at path, returning ErrNoSnapshot if there isn't one
+func Stat(path string) (Info, error) {
+	fi, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Info{}, ErrNoSnapshot
+	}
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return Info{Path: path, SizeBytes: fi.Size(), CreatedAt: fi.ModTime().UTC()}, nil
+}
+
+// Service writes snapshots of one database to a configured path on demand
+type Service struct {
+	srcPath string
+	dstPath string
+	mu      sync.Mutex
+}
+
+// NewService creates a Service that copies the database at srcPath to dstPath
+func NewService(srcPath, dstPath string) *Service {
+	return &Service{srcPath: srcPath, dstPath: dstPath}
+}
+
+// Create writes a fresh snapshot. Concurrent calls are serialized so they don't race
+// on the temporary file.
+func (s *Service) Create(ctx context.Context) (Info, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Write(ctx, s.srcPath, s.dstPath)
+}
+
+// Latest describes the most recent snapshot
+func (s *Service) Latest() (Info, error) {
+	return Stat(s.dstPath)
+}
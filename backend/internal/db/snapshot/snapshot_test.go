@@ -0,0 +1,96 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package snapshot_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/snapshot"
+
+	_ "modernc.org/sqlite"
+)
+
+func countRows(t *testing.T, path string) int {
+	t.Helper()
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var count int
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	return count
+}
+
+func TestService_CreateReplacesPreviousSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "octobud.db")
+	dstPath := filepath.Join(dir, "snapshots", snapshot.FileName)
+
+	live, err := db.OpenDatabase(srcPath)
+	require.NoError(t, err)
+	defer live.Close()
+	_, err = live.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+	_, err = live.Exec("INSERT INTO items (id) VALUES (1)")
+	require.NoError(t, err)
+
+	svc := snapshot.NewService(srcPath, dstPath)
+	_, err = svc.Latest()
+	require.ErrorIs(t, err, snapshot.ErrNoSnapshot)
+
+	info, err := svc.Create(ctx)
+	require.NoError(t, err)
+	require.Equal(t, dstPath, info.Path)
+	require.Positive(t, info.SizeBytes)
+	require.Equal(t, 1, countRows(t, dstPath))
+
+	fi, err := os.Stat(dstPath)
+	require.NoError(t, err)
+	require.Zero(t, fi.Mode().Perm()&0o222, "snapshot should be read-only")
+
+	// Later writes to the live database show up in the next snapshot only
+	_, err = live.Exec("INSERT INTO items (id) VALUES (2)")
+	require.NoError(t, err)
+	require.Equal(t, 1, countRows(t, dstPath))
+
+	_, err = svc.Create(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, countRows(t, dstPath))
+
+	latest, err := svc.Latest()
+	require.NoError(t, err)
+	require.Equal(t, dstPath, latest.Path)
+	_, err = os.Stat(dstPath + ".tmp")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWrite_MissingSource(t *testing.T) {
+	dir := t.TempDir()
+	_, err := snapshot.Write(
+		context.Background(),
+		filepath.Join(dir, "missing.db"),
+		filepath.Join(dir, snapshot.FileName),
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Repository aliases: previous full names of repositories that were renamed or transferred,
+-- so repo: and org: queries keep matching notifications stored under the old name
+CREATE TABLE IF NOT EXISTS repository_aliases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    full_name TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    UNIQUE(user_id, full_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_repository_aliases_repository_id ON repository_aliases(repository_id);
+CREATE INDEX IF NOT EXISTS idx_repositories_github_id ON repositories(user_id, github_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_repositories_github_id;
+DROP INDEX IF EXISTS idx_repository_aliases_repository_id;
+DROP TABLE IF EXISTS repository_aliases;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Author profiles: cached GitHub profiles for notification authors, refreshed lazily
+-- so list responses can show names and avatars without client-side GitHub calls
+CREATE TABLE IF NOT EXISTS author_profiles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    login TEXT NOT NULL COLLATE NOCASE,
+    name TEXT,
+    avatar_url TEXT,
+    type TEXT NOT NULL DEFAULT 'user',
+    fetched_at TEXT NOT NULL,
+    UNIQUE(user_id, login)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS author_profiles;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Changelog entries: user-facing notes about schema and behavior changes, recorded when an
+-- update applies new migrations so query-semantics changes don't go unnoticed
+CREATE TABLE IF NOT EXISTS changelog_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    key TEXT NOT NULL UNIQUE,
+    schema_version INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    acknowledged_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_changelog_entries_unseen ON changelog_entries(acknowledged_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_changelog_entries_unseen;
+DROP TABLE IF EXISTS changelog_entries;
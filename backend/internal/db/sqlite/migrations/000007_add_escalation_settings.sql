@@ -0,0 +1,7 @@
+-- +goose Up
+-- Add escalation_settings field to users table for review request escalation
+ALTER TABLE users ADD COLUMN escalation_settings TEXT;
+
+-- +goose Down
+-- Remove escalation_settings field
+ALTER TABLE users DROP COLUMN escalation_settings;
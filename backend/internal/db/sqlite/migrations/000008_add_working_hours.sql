@@ -0,0 +1,7 @@
+-- +goose Up
+-- Add working_hours field to users table for business-day calculations
+ALTER TABLE users ADD COLUMN working_hours TEXT;
+
+-- +goose Down
+-- Remove working_hours field
+ALTER TABLE users DROP COLUMN working_hours;
@@ -0,0 +1,121 @@
+-- +goose Up
+-- Normalize every stored timestamp to UTC RFC3339 with second precision
+-- (YYYY-MM-DDTHH:MM:SSZ), the format strftime('%Y-%m-%dT%H:%M:%SZ', ...) and the store
+-- write. Timestamps are compared and ordered as strings, so rows left in SQLite's
+-- 'YYYY-MM-DD HH:MM:SS' form, with fractional seconds or with a UTC offset sort
+-- incorrectly against the rest. NULLs and values SQLite can't parse compare as NULL and
+-- are left untouched.
+
+UPDATE users SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE users SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE users SET muted_until = strftime('%Y-%m-%dT%H:%M:%SZ', muted_until)
+WHERE muted_until <> strftime('%Y-%m-%dT%H:%M:%SZ', muted_until);
+
+UPDATE repositories SET pushed_at = strftime('%Y-%m-%dT%H:%M:%SZ', pushed_at)
+WHERE pushed_at <> strftime('%Y-%m-%dT%H:%M:%SZ', pushed_at);
+
+UPDATE repositories SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE repositories SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE pull_requests SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE pull_requests SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE pull_requests SET closed_at = strftime('%Y-%m-%dT%H:%M:%SZ', closed_at)
+WHERE closed_at <> strftime('%Y-%m-%dT%H:%M:%SZ', closed_at);
+
+UPDATE pull_requests SET merged_at = strftime('%Y-%m-%dT%H:%M:%SZ', merged_at)
+WHERE merged_at <> strftime('%Y-%m-%dT%H:%M:%SZ', merged_at);
+
+UPDATE notifications SET github_updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', github_updated_at)
+WHERE github_updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', github_updated_at);
+
+UPDATE notifications SET github_last_read_at = strftime('%Y-%m-%dT%H:%M:%SZ', github_last_read_at)
+WHERE github_last_read_at <> strftime('%Y-%m-%dT%H:%M:%SZ', github_last_read_at);
+
+UPDATE notifications SET imported_at = strftime('%Y-%m-%dT%H:%M:%SZ', imported_at)
+WHERE imported_at <> strftime('%Y-%m-%dT%H:%M:%SZ', imported_at);
+
+UPDATE notifications SET subject_fetched_at = strftime('%Y-%m-%dT%H:%M:%SZ', subject_fetched_at)
+WHERE subject_fetched_at <> strftime('%Y-%m-%dT%H:%M:%SZ', subject_fetched_at);
+
+UPDATE notifications SET snoozed_until = strftime('%Y-%m-%dT%H:%M:%SZ', snoozed_until)
+WHERE snoozed_until <> strftime('%Y-%m-%dT%H:%M:%SZ', snoozed_until);
+
+UPDATE notifications SET effective_sort_date = strftime('%Y-%m-%dT%H:%M:%SZ', effective_sort_date)
+WHERE effective_sort_date <> strftime('%Y-%m-%dT%H:%M:%SZ', effective_sort_date);
+
+UPDATE notifications SET snoozed_at = strftime('%Y-%m-%dT%H:%M:%SZ', snoozed_at)
+WHERE snoozed_at <> strftime('%Y-%m-%dT%H:%M:%SZ', snoozed_at);
+
+UPDATE tags SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE tag_assignments SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE views SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE sync_state SET last_successful_poll = strftime('%Y-%m-%dT%H:%M:%SZ', last_successful_poll)
+WHERE last_successful_poll <> strftime('%Y-%m-%dT%H:%M:%SZ', last_successful_poll);
+
+UPDATE sync_state SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE sync_state SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE sync_state SET latest_notification_at = strftime('%Y-%m-%dT%H:%M:%SZ', latest_notification_at)
+WHERE latest_notification_at <> strftime('%Y-%m-%dT%H:%M:%SZ', latest_notification_at);
+
+UPDATE sync_state SET initial_sync_completed_at = strftime('%Y-%m-%dT%H:%M:%SZ', initial_sync_completed_at)
+WHERE initial_sync_completed_at <> strftime('%Y-%m-%dT%H:%M:%SZ', initial_sync_completed_at);
+
+UPDATE sync_state SET oldest_notification_synced_at = strftime('%Y-%m-%dT%H:%M:%SZ', oldest_notification_synced_at)
+WHERE oldest_notification_synced_at <> strftime('%Y-%m-%dT%H:%M:%SZ', oldest_notification_synced_at);
+
+UPDATE rules SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE rules SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE jobs SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE jobs SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at <> strftime('%Y-%m-%dT%H:%M:%SZ', updated_at);
+
+UPDATE jobs SET scheduled_at = strftime('%Y-%m-%dT%H:%M:%SZ', scheduled_at)
+WHERE scheduled_at <> strftime('%Y-%m-%dT%H:%M:%SZ', scheduled_at);
+
+UPDATE jobs SET started_at = strftime('%Y-%m-%dT%H:%M:%SZ', started_at)
+WHERE started_at <> strftime('%Y-%m-%dT%H:%M:%SZ', started_at);
+
+UPDATE jobs SET completed_at = strftime('%Y-%m-%dT%H:%M:%SZ', completed_at)
+WHERE completed_at <> strftime('%Y-%m-%dT%H:%M:%SZ', completed_at);
+
+UPDATE repository_aliases SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE author_profiles SET fetched_at = strftime('%Y-%m-%dT%H:%M:%SZ', fetched_at)
+WHERE fetched_at <> strftime('%Y-%m-%dT%H:%M:%SZ', fetched_at);
+
+UPDATE changelog_entries SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at <> strftime('%Y-%m-%dT%H:%M:%SZ', created_at);
+
+UPDATE changelog_entries SET acknowledged_at = strftime('%Y-%m-%dT%H:%M:%SZ', acknowledged_at)
+WHERE acknowledged_at <> strftime('%Y-%m-%dT%H:%M:%SZ', acknowledged_at);
+
+-- +goose Down
+-- Normalized timestamps are equivalent to the originals, so there is nothing to undo
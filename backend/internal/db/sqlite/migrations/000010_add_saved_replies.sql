@@ -0,0 +1,15 @@
+-- +goose Up
+-- Saved replies: the user's GitHub saved replies, imported so they can be offered when
+-- replying to a notification without a GitHub round trip
+CREATE TABLE IF NOT EXISTS saved_replies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    github_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    fetched_at TEXT NOT NULL,
+    UNIQUE(user_id, github_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS saved_replies;
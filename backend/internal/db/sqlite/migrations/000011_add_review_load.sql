@@ -0,0 +1,9 @@
+-- +goose Up
+-- Requested reviewers of each pull request, stored at sync time as a JSON array of logins
+ALTER TABLE pull_requests ADD COLUMN requested_reviewers TEXT;
+-- Team members whose review load is reported
+ALTER TABLE users ADD COLUMN team_settings TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN team_settings;
+ALTER TABLE pull_requests DROP COLUMN requested_reviewers;
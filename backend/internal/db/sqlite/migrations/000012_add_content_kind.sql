@@ -0,0 +1,18 @@
+-- +goose Up
+-- Whether a notification's subject is a code change, a discussion, or something else.
+-- Set from the subject type on every sync; existing rows are backfilled here.
+ALTER TABLE notifications ADD COLUMN content_kind TEXT NOT NULL DEFAULT 'other';
+
+UPDATE notifications SET content_kind = CASE lower(subject_type)
+    WHEN 'pullrequest' THEN 'code'
+    WHEN 'commit' THEN 'code'
+    WHEN 'issue' THEN 'discussion'
+    WHEN 'discussion' THEN 'discussion'
+    ELSE 'other'
+END;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_content_kind ON notifications(user_id, content_kind);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_content_kind;
+ALTER TABLE notifications DROP COLUMN content_kind;
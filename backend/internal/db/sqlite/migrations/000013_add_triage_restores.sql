@@ -0,0 +1,19 @@
+-- +goose Up
+-- Triage state imported from a backup and waiting to be merged into notifications.
+-- A row is applied and removed once a notification with the same github_id exists,
+-- so state for notifications that have not been re-synced yet is kept until they are.
+CREATE TABLE IF NOT EXISTS triage_restores (
+    user_id TEXT NOT NULL,
+    github_id TEXT NOT NULL,
+    is_read INTEGER NOT NULL DEFAULT 0,
+    archived INTEGER NOT NULL DEFAULT 0,
+    starred INTEGER NOT NULL DEFAULT 0,
+    muted INTEGER NOT NULL DEFAULT 0,
+    snoozed_until TEXT,
+    tag_ids TEXT NOT NULL DEFAULT '[]',
+    staged_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    PRIMARY KEY (user_id, github_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS triage_restores;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Add alert_settings field to users table for default alert level, view alerts and quiet hours
+ALTER TABLE users ADD COLUMN alert_settings TEXT;
+
+-- Alert decisions made when notifications arrive during sync, read by clients to decide
+-- whether to show a desktop notification or play a sound
+CREATE TABLE notification_alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    notification_id INTEGER NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    level TEXT NOT NULL,
+    sound TEXT,
+    source TEXT NOT NULL,
+    source_id TEXT,
+    suppressed TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX idx_notification_alerts_user_id ON notification_alerts(user_id, id);
+CREATE INDEX idx_notification_alerts_notification_id ON notification_alerts(notification_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notification_alerts_notification_id;
+DROP INDEX IF EXISTS idx_notification_alerts_user_id;
+DROP TABLE IF EXISTS notification_alerts;
+ALTER TABLE users DROP COLUMN alert_settings;
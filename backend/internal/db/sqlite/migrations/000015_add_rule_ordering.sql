@@ -0,0 +1,23 @@
+-- +goose Up
+-- Rules run by priority (highest first), then display order. A matching rule with
+-- stop_processing set keeps later rules from running.
+ALTER TABLE rules ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE rules ADD COLUMN stop_processing INTEGER NOT NULL DEFAULT 0;
+
+-- The latest rule evaluation for each notification: which rules matched, which didn't
+-- and which were skipped after a rule stopped processing
+CREATE TABLE rule_evaluations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    notification_id INTEGER NOT NULL UNIQUE REFERENCES notifications(id) ON DELETE CASCADE,
+    trace TEXT NOT NULL DEFAULT '[]',
+    evaluated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_rule_evaluations_user_id ON rule_evaluations(user_id, evaluated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_rule_evaluations_user_id;
+DROP TABLE IF EXISTS rule_evaluations;
+ALTER TABLE rules DROP COLUMN stop_processing;
+ALTER TABLE rules DROP COLUMN priority;
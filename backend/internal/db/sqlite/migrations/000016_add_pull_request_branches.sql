@@ -0,0 +1,14 @@
+-- +goose Up
+-- Head and base branch of pull request notifications, taken from the pull request
+-- fetched at sync time. Existing rows are backfilled from the stored subject.
+ALTER TABLE notifications ADD COLUMN head_branch TEXT;
+ALTER TABLE notifications ADD COLUMN base_branch TEXT;
+
+UPDATE notifications SET
+    head_branch = json_extract(subject_raw, '$.head.ref'),
+    base_branch = json_extract(subject_raw, '$.base.ref')
+WHERE lower(subject_type) = 'pullrequest' AND json_valid(subject_raw);
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN base_branch;
+ALTER TABLE notifications DROP COLUMN head_branch;
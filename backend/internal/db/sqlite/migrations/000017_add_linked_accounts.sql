@@ -0,0 +1,29 @@
+-- +goose Up
+-- Additional GitHub accounts whose notifications are synced alongside the primary
+-- account's. Tokens are encrypted like the primary token, or kept in the keychain on
+-- macOS, in which case token_encrypted is NULL.
+CREATE TABLE linked_accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    github_user_id TEXT NOT NULL,
+    login TEXT NOT NULL,
+    token_encrypted TEXT,
+    last_synced_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, github_user_id)
+);
+
+-- Login of the GitHub account a notification was synced for. Existing rows all came
+-- from the primary account.
+ALTER TABLE notifications ADD COLUMN account TEXT;
+
+UPDATE notifications SET account = (
+    SELECT u.github_username FROM users u WHERE u.github_user_id = notifications.user_id
+);
+
+CREATE INDEX idx_notifications_user_account ON notifications(user_id, account);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_account;
+ALTER TABLE notifications DROP COLUMN account;
+DROP TABLE IF EXISTS linked_accounts;
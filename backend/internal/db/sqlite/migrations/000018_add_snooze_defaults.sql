@@ -0,0 +1,17 @@
+-- +goose Up
+-- Default snooze for the snooze API when no time is given, e.g. {"defaultPreset": "tomorrow"}
+ALTER TABLE users ADD COLUMN snooze_settings TEXT;
+
+-- Per-repository settings. default_snooze overrides the user's default snooze for
+-- notifications from the repository, e.g. "1w" for a noisy repository.
+CREATE TABLE repository_settings (
+    user_id TEXT NOT NULL,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    default_snooze TEXT,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, repository_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_settings;
+ALTER TABLE users DROP COLUMN snooze_settings;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- When a notification was last archived, so new activity shortly after can be kept quiet
+ALTER TABLE notifications ADD COLUMN archived_at DATETIME;
+
+-- Per-repository override of the resurface suppression window in the user's sync
+-- settings. NULL uses the user's window, 0 turns suppression off for the repository.
+ALTER TABLE repository_settings ADD COLUMN resurface_suppression_minutes INTEGER;
+
+-- +goose Down
+ALTER TABLE repository_settings DROP COLUMN resurface_suppression_minutes;
+ALTER TABLE notifications DROP COLUMN archived_at;
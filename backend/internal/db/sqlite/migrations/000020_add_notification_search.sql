@@ -0,0 +1,55 @@
+-- +goose Up
+-- Full-text index over notification titles, subject descriptions, and latest comment bodies.
+-- The rowid is the notification's id. Titles and descriptions are kept in step with the
+-- notifications table by the triggers below; comment bodies are written by the sync.
+CREATE VIRTUAL TABLE notification_search USING fts5(
+    title,
+    body,
+    comment,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO notification_search (rowid, title, body, comment)
+SELECT
+    id,
+    subject_title,
+    CASE WHEN json_valid(CAST(subject_raw AS TEXT))
+        THEN COALESCE(json_extract(CAST(subject_raw AS TEXT), '$.body'), '') ELSE '' END,
+    ''
+FROM notifications;
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_search_insert AFTER INSERT ON notifications BEGIN
+    INSERT INTO notification_search (rowid, title, body, comment)
+    VALUES (
+        new.id,
+        new.subject_title,
+        CASE WHEN json_valid(CAST(new.subject_raw AS TEXT))
+            THEN COALESCE(json_extract(CAST(new.subject_raw AS TEXT), '$.body'), '') ELSE '' END,
+        ''
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_search_update AFTER UPDATE OF subject_title, subject_raw ON notifications
+BEGIN
+    UPDATE notification_search SET
+        title = new.subject_title,
+        body = CASE WHEN json_valid(CAST(new.subject_raw AS TEXT))
+            THEN COALESCE(json_extract(CAST(new.subject_raw AS TEXT), '$.body'), '') ELSE '' END
+    WHERE rowid = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_search_delete AFTER DELETE ON notifications BEGIN
+    DELETE FROM notification_search WHERE rowid = old.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER notification_search_delete;
+DROP TRIGGER notification_search_update;
+DROP TRIGGER notification_search_insert;
+DROP TABLE notification_search;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- When the latest comment on a thread was written by the account the notification was
+-- synced for, the time it was posted. NULL when someone else spoke last or it isn't known.
+ALTER TABLE notifications ADD COLUMN awaiting_reply_since DATETIME;
+
+-- Set once a thread has gone without a reply for the user's awaiting-reply window, and
+-- cleared again by the next comment.
+ALTER TABLE notifications ADD COLUMN awaiting_reply INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_notifications_user_awaiting_reply
+    ON notifications(user_id, awaiting_reply, awaiting_reply_since);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_awaiting_reply;
+ALTER TABLE notifications DROP COLUMN awaiting_reply;
+ALTER TABLE notifications DROP COLUMN awaiting_reply_since;
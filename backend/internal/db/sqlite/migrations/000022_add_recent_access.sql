@@ -0,0 +1,17 @@
+-- +goose Up
+-- What the user opened most recently, so the next launch can warm it before the browser
+-- opens. kind is "query" (a notification list; target is its query) or "notification"
+-- (target is its GitHub ID). Only the latest few of each kind are kept.
+CREATE TABLE recent_access (
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    target TEXT NOT NULL,
+    accessed_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, kind, target)
+);
+
+CREATE INDEX idx_recent_access_user_kind ON recent_access(user_id, kind, accessed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_recent_access_user_kind;
+DROP TABLE IF EXISTS recent_access;
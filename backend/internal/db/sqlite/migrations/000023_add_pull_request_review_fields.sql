@@ -0,0 +1,24 @@
+-- +goose Up
+-- Pull request metadata the query language filters on. Draft and label names are taken
+-- from the subject fetched at sync time, and existing rows are backfilled from the stored
+-- subject. The review state is worked out from the pull request's reviews, so it is only
+-- known once a pull request has been synced again.
+ALTER TABLE notifications ADD COLUMN subject_draft INTEGER;
+ALTER TABLE notifications ADD COLUMN subject_labels TEXT;
+ALTER TABLE notifications ADD COLUMN review_state TEXT;
+
+UPDATE notifications SET
+    subject_draft = CASE
+        WHEN lower(subject_type) = 'pullrequest' THEN json_extract(subject_raw, '$.draft')
+    END,
+    subject_labels = (
+        SELECT json_group_array(json_extract(label.value, '$.name'))
+        FROM json_each(subject_raw, '$.labels') AS label
+        WHERE json_extract(label.value, '$.name') IS NOT NULL
+    )
+WHERE json_valid(subject_raw) AND json_type(subject_raw, '$.labels') = 'array';
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN review_state;
+ALTER TABLE notifications DROP COLUMN subject_labels;
+ALTER TABLE notifications DROP COLUMN subject_draft;
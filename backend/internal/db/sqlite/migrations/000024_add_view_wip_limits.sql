@@ -0,0 +1,10 @@
+-- +goose Up
+-- A work-in-progress limit on how many notifications a view should hold. wip_limit is NULL
+-- when the view has no limit. With wip_auto_snooze set, notifications past the limit are
+-- snoozed after each sync instead of only being warned about.
+ALTER TABLE views ADD COLUMN wip_limit INTEGER;
+ALTER TABLE views ADD COLUMN wip_auto_snooze INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE views DROP COLUMN wip_auto_snooze;
+ALTER TABLE views DROP COLUMN wip_limit;
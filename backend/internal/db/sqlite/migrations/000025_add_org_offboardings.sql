@@ -0,0 +1,17 @@
+-- +goose Up
+-- Organizations the user has left. Their notifications were archived or deleted when
+-- the org was offboarded, and new ones are dropped during sync until the row is removed.
+-- The counts summarize what the offboarding changed.
+CREATE TABLE org_offboardings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    org TEXT NOT NULL COLLATE NOCASE,
+    action TEXT NOT NULL,
+    notifications_affected INTEGER NOT NULL DEFAULT 0,
+    repositories INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, org)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS org_offboardings;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- A snooze that also ends as soon as sync sees the thread updated on GitHub, whichever
+-- comes first. Only meaningful while snoozed_until is set.
+ALTER TABLE notifications ADD COLUMN snooze_until_updated INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN snooze_until_updated;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- When a snooze last ran out and brought the notification back. Snoozes that ran out
+-- before this are returned by the snooze job the first time it runs.
+ALTER TABLE notifications ADD COLUMN returned_from_snooze_at DATETIME;
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN returned_from_snooze_at;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- A notification's history: each change to its state, what made it (the user, a rule,
+-- sync or a scheduled job) and, for rules, which one. Rows go when the notification does.
+CREATE TABLE notification_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    notification_id INTEGER NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    source TEXT NOT NULL,
+    detail TEXT,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_notification_events_notification ON notification_events(notification_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_events;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Bulk operations that can still be undone, with the state of every notification they
+-- touched as it was before. Old operations are pruned as new ones are captured.
+CREATE TABLE bulk_operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    undone_at DATETIME
+);
+
+CREATE INDEX idx_bulk_operations_user_created ON bulk_operations(user_id, created_at);
+
+CREATE TABLE bulk_operation_states (
+    operation_id INTEGER NOT NULL REFERENCES bulk_operations(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    github_id TEXT NOT NULL,
+    is_read INTEGER NOT NULL,
+    archived INTEGER NOT NULL,
+    archived_at DATETIME,
+    muted INTEGER NOT NULL,
+    starred INTEGER NOT NULL,
+    filtered INTEGER NOT NULL,
+    snoozed_until DATETIME,
+    snoozed_at DATETIME,
+    snooze_until_updated INTEGER NOT NULL,
+    returned_from_snooze_at DATETIME,
+    effective_sort_date DATETIME NOT NULL,
+    PRIMARY KEY (operation_id, github_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS bulk_operation_states;
+DROP TABLE IF EXISTS bulk_operations;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Scheduled database backups, e.g. {"enabled": true, "intervalHours": 24, "keep": 7}
+ALTER TABLE users ADD COLUMN backup_settings TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN backup_settings;
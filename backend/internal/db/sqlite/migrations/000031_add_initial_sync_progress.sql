@@ -0,0 +1,11 @@
+-- +goose Up
+-- How far the initial sync got, so one that was interrupted picks up where it left off.
+-- The oldest notification synced so far is where fetching resumes.
+ALTER TABLE sync_state ADD COLUMN initial_sync_started_at TEXT;
+ALTER TABLE sync_state ADD COLUMN initial_sync_pages INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sync_state ADD COLUMN initial_sync_queued INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE sync_state DROP COLUMN initial_sync_queued;
+ALTER TABLE sync_state DROP COLUMN initial_sync_pages;
+ALTER TABLE sync_state DROP COLUMN initial_sync_started_at;
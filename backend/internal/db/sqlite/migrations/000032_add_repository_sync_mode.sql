@@ -0,0 +1,7 @@
+-- +goose Up
+-- How a repository's notifications are synced: 'full', 'headers_only' to store what the
+-- notification carries without fetching its subject, or 'excluded' to skip them.
+ALTER TABLE repository_settings ADD COLUMN sync_mode TEXT NOT NULL DEFAULT 'full';
+
+-- +goose Down
+ALTER TABLE repository_settings DROP COLUMN sync_mode;
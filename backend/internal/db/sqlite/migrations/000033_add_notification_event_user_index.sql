@@ -0,0 +1,6 @@
+-- +goose Up
+-- Analytics count a user's events over a date range
+CREATE INDEX idx_notification_events_user_created ON notification_events(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notification_events_user_created;
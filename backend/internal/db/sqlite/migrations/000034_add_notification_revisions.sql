@@ -0,0 +1,55 @@
+-- +goose Up
+-- A counter per user that goes up whenever any of their notifications or notification tag
+-- assignments change, so cached view counts can tell they're stale without re-running
+-- every view's query.
+CREATE TABLE notification_revisions (
+    user_id TEXT PRIMARY KEY,
+    revision INTEGER NOT NULL DEFAULT 0
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_revisions_insert AFTER INSERT ON notifications BEGIN
+    INSERT INTO notification_revisions (user_id, revision) VALUES (new.user_id, 1)
+    ON CONFLICT(user_id) DO UPDATE SET revision = revision + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_revisions_update AFTER UPDATE ON notifications BEGIN
+    INSERT INTO notification_revisions (user_id, revision) VALUES (new.user_id, 1)
+    ON CONFLICT(user_id) DO UPDATE SET revision = revision + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_revisions_delete AFTER DELETE ON notifications BEGIN
+    INSERT INTO notification_revisions (user_id, revision) VALUES (old.user_id, 1)
+    ON CONFLICT(user_id) DO UPDATE SET revision = revision + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_revisions_tag_insert AFTER INSERT ON tag_assignments
+WHEN new.entity_type = 'notification'
+BEGIN
+    INSERT INTO notification_revisions (user_id, revision) VALUES (new.user_id, 1)
+    ON CONFLICT(user_id) DO UPDATE SET revision = revision + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notification_revisions_tag_delete AFTER DELETE ON tag_assignments
+WHEN old.entity_type = 'notification'
+BEGIN
+    INSERT INTO notification_revisions (user_id, revision) VALUES (old.user_id, 1)
+    ON CONFLICT(user_id) DO UPDATE SET revision = revision + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER notification_revisions_tag_delete;
+DROP TRIGGER notification_revisions_tag_insert;
+DROP TRIGGER notification_revisions_delete;
+DROP TRIGGER notification_revisions_update;
+DROP TRIGGER notification_revisions_insert;
+DROP TABLE notification_revisions;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Teams whose review each pull request is waiting on, stored at sync time as a JSON array of
+-- "org/team" names. Existing rows are backfilled from the stored subject, naming each team
+-- after the owner of the pull request's repository.
+ALTER TABLE pull_requests ADD COLUMN requested_teams TEXT;
+
+UPDATE pull_requests SET
+    requested_teams = (
+        SELECT json_group_array(
+            json_extract(raw, '$.base.repo.owner.login') || '/' || json_extract(team.value, '$.slug')
+        )
+        FROM json_each(raw, '$.requested_teams') AS team
+        WHERE json_extract(team.value, '$.slug') IS NOT NULL
+    )
+WHERE json_valid(raw)
+  AND json_type(raw, '$.requested_teams') = 'array'
+  AND json_extract(raw, '$.base.repo.owner.login') IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE pull_requests DROP COLUMN requested_teams;
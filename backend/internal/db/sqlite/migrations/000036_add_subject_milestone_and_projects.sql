@@ -0,0 +1,14 @@
+-- +goose Up
+-- The milestone and projects an issue or pull request belongs to, for the milestone: and
+-- project: query fields. The milestone title is part of the subject fetched at sync time, so
+-- existing rows are backfilled from the stored subject. Projects are looked up separately,
+-- so they are only known once a subject has been synced again.
+ALTER TABLE notifications ADD COLUMN subject_milestone TEXT;
+ALTER TABLE notifications ADD COLUMN subject_projects TEXT;
+
+UPDATE notifications SET subject_milestone = json_extract(subject_raw, '$.milestone.title')
+WHERE json_valid(subject_raw) AND json_type(subject_raw, '$.milestone.title') = 'text';
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN subject_projects;
+ALTER TABLE notifications DROP COLUMN subject_milestone;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- The combined CI state of each open pull request's head commit, worked out from its check
+-- runs and commit statuses at sync: success, failure or pending. It is only known once a
+-- pull request has been synced again.
+ALTER TABLE notifications ADD COLUMN ci_state TEXT;
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN ci_state;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Whether an open pull request can be merged without conflicts, for the mergeable: query
+-- field. GitHub includes it in the pull request fetched at sync time, so existing open pull
+-- requests are backfilled from the stored subject. It stays NULL while GitHub is still
+-- working it out and for closed pull requests.
+ALTER TABLE notifications ADD COLUMN subject_mergeable INTEGER;
+
+UPDATE notifications SET subject_mergeable = json_extract(subject_raw, '$.mergeable')
+WHERE lower(subject_type) = 'pullrequest'
+    AND json_valid(subject_raw)
+    AND json_extract(subject_raw, '$.state') = 'open'
+    AND json_type(subject_raw, '$.mergeable') IN ('true', 'false');
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN subject_mergeable;
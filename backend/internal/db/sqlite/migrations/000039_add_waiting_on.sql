@@ -0,0 +1,9 @@
+-- +goose Up
+-- Whose turn it is on an open issue or pull request, for the waiting-on: query field: "me"
+-- when the synced account needs to act, "them" when it is waiting on someone else. It is
+-- worked out at sync from the latest comment, review state and requested reviewers, so it is
+-- only known once a subject has been synced again.
+ALTER TABLE notifications ADD COLUMN waiting_on TEXT;
+
+-- +goose Down
+ALTER TABLE notifications DROP COLUMN waiting_on;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Priority score of each notification, for the priority: query field and sorting by
+-- priority. It is worked out at sync from the weights in users.priority_settings, and worked
+-- out again when the weights change, so existing notifications only have one once either
+-- has happened.
+ALTER TABLE notifications ADD COLUMN priority INTEGER;
+ALTER TABLE users ADD COLUMN priority_settings TEXT;
+
+CREATE INDEX idx_notifications_user_priority ON notifications(user_id, priority, effective_sort_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_priority;
+ALTER TABLE users DROP COLUMN priority_settings;
+ALTER TABLE notifications DROP COLUMN priority;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Schedule, query and delivery of the periodic notification digest. Rendered digests are
+-- written to the digests directory next to the database, so only the settings live here.
+ALTER TABLE users ADD COLUMN digest_settings TEXT;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN digest_settings;
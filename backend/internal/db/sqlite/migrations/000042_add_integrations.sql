@@ -0,0 +1,44 @@
+-- +goose Up
+-- Outbound integrations that rules can notify about matched notifications. Secrets sign
+-- each delivery and are encrypted like GitHub tokens.
+CREATE TABLE integrations (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    format TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret_encrypted TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE(user_id, name)
+);
+
+-- One row per notification a rule sent to an integration. Pending rows double as the
+-- retry queue: next_attempt_at is pushed back after each failed attempt until the
+-- delivery succeeds or runs out of attempts.
+CREATE TABLE integration_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    integration_id TEXT NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    rule_id TEXT,
+    rule_name TEXT NOT NULL,
+    github_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT,
+    next_attempt_at TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    delivered_at TEXT
+);
+
+CREATE INDEX idx_integration_deliveries_user_id ON integration_deliveries(user_id, id);
+CREATE INDEX idx_integration_deliveries_due ON integration_deliveries(status, next_attempt_at);
+CREATE INDEX idx_integration_deliveries_integration_id ON integration_deliveries(integration_id, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_integration_deliveries_integration_id;
+DROP INDEX IF EXISTS idx_integration_deliveries_due;
+DROP INDEX IF EXISTS idx_integration_deliveries_user_id;
+DROP TABLE IF EXISTS integration_deliveries;
+DROP TABLE IF EXISTS integrations;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Optional expression a notification must also satisfy for the rule to match, checked
+-- after the query. It covers conditions the query language can't express.
+ALTER TABLE rules ADD COLUMN condition TEXT;
+
+-- +goose Down
+ALTER TABLE rules DROP COLUMN condition;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Named groups that organize custom views in the sidebar. views.group_id isn't a foreign
+-- key so the column can be dropped again; deleting a group ungroups its views itself.
+CREATE TABLE view_groups (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    collapsed INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    UNIQUE(user_id, name)
+);
+
+ALTER TABLE views ADD COLUMN group_id TEXT;
+
+CREATE INDEX idx_views_group_id ON views(group_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_views_group_id;
+ALTER TABLE views DROP COLUMN group_id;
+DROP TABLE IF EXISTS view_groups;
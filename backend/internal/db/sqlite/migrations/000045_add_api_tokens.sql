@@ -0,0 +1,19 @@
+-- +goose Up
+-- Personal API tokens for scripts and CLI tools. Only a SHA-256 hash of each token is
+-- kept; the prefix is stored in the clear so users can tell their tokens apart.
+CREATE TABLE api_tokens (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    token_prefix TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    account TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    expires_at TEXT,
+    last_used_at TEXT,
+    UNIQUE(user_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_tokens;
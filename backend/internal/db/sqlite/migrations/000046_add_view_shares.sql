@@ -0,0 +1,17 @@
+-- +goose Up
+-- Views shared with other users of a multi-user server. Grantees are usernames from the
+-- server's users file, since each user's data lives in a database of its own.
+CREATE TABLE view_shares (
+    user_id TEXT NOT NULL,
+    view_id TEXT NOT NULL REFERENCES views(id) ON DELETE CASCADE,
+    grantee TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (view_id, grantee)
+);
+
+CREATE INDEX idx_view_shares_grantee ON view_shares(grantee);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_view_shares_grantee;
+DROP TABLE IF EXISTS view_shares;
@@ -8,6 +8,92 @@ import (
 	"database/sql"
 )
 
+type ApiToken struct {
+	ID          string
+	UserID      string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scope       string
+	Account     string
+	CreatedAt   string
+	ExpiresAt   sql.NullString
+	LastUsedAt  sql.NullString
+}
+
+type AuthorProfile struct {
+	ID        int64
+	UserID    string
+	Login     string
+	Name      sql.NullString
+	AvatarUrl sql.NullString
+	Type      string
+	FetchedAt string
+}
+
+type BulkOperation struct {
+	ID        int64
+	UserID    string
+	Operation string
+	CreatedAt string
+	UndoneAt  sql.NullString
+}
+
+type BulkOperationState struct {
+	OperationID          int64
+	UserID               string
+	GithubID             string
+	IsRead               int64
+	Archived             int64
+	ArchivedAt           sql.NullString
+	Muted                int64
+	Starred              int64
+	Filtered             int64
+	SnoozedUntil         sql.NullString
+	SnoozedAt            sql.NullString
+	SnoozeUntilUpdated   int64
+	ReturnedFromSnoozeAt sql.NullString
+	EffectiveSortDate    string
+}
+
+type ChangelogEntry struct {
+	ID             int64
+	Key            string
+	SchemaVersion  int64
+	Kind           string
+	Title          string
+	Description    string
+	CreatedAt      string
+	AcknowledgedAt sql.NullString
+}
+
+type Integration struct {
+	ID              string
+	UserID          string
+	Name            string
+	Format          string
+	Url             string
+	SecretEncrypted sql.NullString
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+type IntegrationDelivery struct {
+	ID             int64
+	UserID         string
+	IntegrationID  string
+	RuleID         sql.NullString
+	RuleName       string
+	GithubID       string
+	Status         string
+	Attempts       int64
+	ResponseStatus sql.NullInt64
+	LastError      sql.NullString
+	NextAttemptAt  string
+	CreatedAt      string
+	DeliveredAt    sql.NullString
+}
+
 type Job struct {
 	ID          int64
 	Queue       string
@@ -23,6 +109,16 @@ type Job struct {
 	LastError   sql.NullString
 }
 
+type LinkedAccount struct {
+	ID             int64
+	UserID         string
+	GithubUserID   string
+	Login          string
+	TokenEncrypted sql.NullString
+	LastSyncedAt   sql.NullString
+	CreatedAt      string
+}
+
 type Notification struct {
 	ID                      int64
 	UserID                  string
@@ -57,26 +153,90 @@ type Notification struct {
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullInt64
 	SubjectStateReason      sql.NullString
+	ContentKind             string
+	HeadBranch              sql.NullString
+	BaseBranch              sql.NullString
+	Account                 sql.NullString
+	ArchivedAt              sql.NullString
+	AwaitingReplySince      sql.NullString
+	AwaitingReply           int64
+	SubjectDraft            sql.NullInt64
+	SubjectLabels           sql.NullString
+	ReviewState             sql.NullString
+	SnoozeUntilUpdated      int64
+	ReturnedFromSnoozeAt    sql.NullString
+	SubjectMilestone        sql.NullString
+	SubjectProjects         sql.NullString
+	CiState                 sql.NullString
+	SubjectMergeable        sql.NullInt64
+	WaitingOn               sql.NullString
+	Priority                sql.NullInt64
+}
+
+type NotificationAlert struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	Level          string
+	Sound          sql.NullString
+	Source         string
+	SourceID       sql.NullString
+	Suppressed     sql.NullString
+	CreatedAt      string
+}
+
+type NotificationEvent struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	Action         string
+	Source         string
+	Detail         sql.NullString
+	CreatedAt      string
+}
+
+type NotificationRevision struct {
+	UserID   string
+	Revision int64
+}
+
+type OrgOffboarding struct {
+	ID                    int64
+	UserID                string
+	Org                   string
+	Action                string
+	NotificationsAffected int64
+	Repositories          int64
+	CreatedAt             string
 }
 
 type PullRequest struct {
-	ID           int64
-	UserID       string
-	RepositoryID int64
-	GithubID     sql.NullInt64
-	NodeID       sql.NullString
-	Number       int64
-	Title        sql.NullString
-	State        sql.NullString
-	Draft        sql.NullInt64
-	Merged       sql.NullInt64
-	AuthorLogin  sql.NullString
-	AuthorID     sql.NullInt64
-	CreatedAt    sql.NullString
-	UpdatedAt    sql.NullString
-	ClosedAt     sql.NullString
-	MergedAt     sql.NullString
-	Raw          sql.NullString
+	ID                 int64
+	UserID             string
+	RepositoryID       int64
+	GithubID           sql.NullInt64
+	NodeID             sql.NullString
+	Number             int64
+	Title              sql.NullString
+	State              sql.NullString
+	Draft              sql.NullInt64
+	Merged             sql.NullInt64
+	AuthorLogin        sql.NullString
+	AuthorID           sql.NullInt64
+	CreatedAt          sql.NullString
+	UpdatedAt          sql.NullString
+	ClosedAt           sql.NullString
+	MergedAt           sql.NullString
+	Raw                sql.NullString
+	RequestedReviewers sql.NullString
+	RequestedTeams     sql.NullString
+}
+
+type RecentAccess struct {
+	UserID     string
+	Kind       string
+	Target     string
+	AccessedAt string
 }
 
 type Repository struct {
@@ -104,18 +264,55 @@ type Repository struct {
 	OwnerHtmlUrl   sql.NullString
 }
 
-type Rule struct {
-	ID           string
+type RepositoryAlias struct {
+	ID           int64
 	UserID       string
-	Name         string
-	Description  sql.NullString
-	Query        sql.NullString
-	Enabled      int64
-	Actions      string
-	DisplayOrder int64
+	RepositoryID int64
+	FullName     string
 	CreatedAt    string
-	UpdatedAt    string
-	ViewID       sql.NullString
+}
+
+type RepositorySetting struct {
+	UserID                      string
+	RepositoryID                int64
+	DefaultSnooze               sql.NullString
+	UpdatedAt                   string
+	ResurfaceSuppressionMinutes sql.NullInt64
+	SyncMode                    string
+}
+
+type Rule struct {
+	ID             string
+	UserID         string
+	Name           string
+	Description    sql.NullString
+	Query          sql.NullString
+	Enabled        int64
+	Actions        string
+	DisplayOrder   int64
+	CreatedAt      string
+	UpdatedAt      string
+	ViewID         sql.NullString
+	Priority       int64
+	StopProcessing int64
+	Condition      sql.NullString
+}
+
+type RuleEvaluation struct {
+	ID             int64
+	UserID         string
+	NotificationID int64
+	Trace          string
+	EvaluatedAt    string
+}
+
+type SavedReply struct {
+	ID        int64
+	UserID    string
+	GithubID  string
+	Title     string
+	Body      string
+	FetchedAt string
 }
 
 type SyncState struct {
@@ -128,6 +325,9 @@ type SyncState struct {
 	LatestNotificationAt       sql.NullString
 	InitialSyncCompletedAt     sql.NullString
 	OldestNotificationSyncedAt sql.NullString
+	InitialSyncStartedAt       sql.NullString
+	InitialSyncPages           int64
+	InitialSyncQueued          int64
 }
 
 type Tag struct {
@@ -150,6 +350,18 @@ type TagAssignment struct {
 	CreatedAt  string
 }
 
+type TriageRestore struct {
+	UserID       string
+	GithubID     string
+	IsRead       int64
+	Archived     int64
+	Starred      int64
+	Muted        int64
+	SnoozedUntil sql.NullString
+	TagIds       string
+	StagedAt     string
+}
+
 type User struct {
 	ID                   int64
 	GithubUserID         sql.NullString
@@ -161,17 +373,45 @@ type User struct {
 	RetentionSettings    sql.NullString
 	MutedUntil           sql.NullString
 	UpdateSettings       sql.NullString
+	EscalationSettings   sql.NullString
+	WorkingHours         sql.NullString
+	TeamSettings         sql.NullString
+	AlertSettings        sql.NullString
+	SnoozeSettings       sql.NullString
+	BackupSettings       sql.NullString
+	PrioritySettings     sql.NullString
+	DigestSettings       sql.NullString
 }
 
 type View struct {
+	ID            string
+	UserID        string
+	Name          string
+	Description   sql.NullString
+	IsDefault     int64
+	CreatedAt     string
+	Icon          sql.NullString
+	Slug          string
+	Query         sql.NullString
+	DisplayOrder  int64
+	WipLimit      sql.NullInt64
+	WipAutoSnooze int64
+	GroupID       sql.NullString
+}
+
+type ViewGroup struct {
 	ID           string
 	UserID       string
 	Name         string
-	Description  sql.NullString
-	IsDefault    int64
-	CreatedAt    string
-	Icon         sql.NullString
-	Slug         string
-	Query        sql.NullString
 	DisplayOrder int64
+	Collapsed    int64
+	CreatedAt    string
+}
+
+type ViewShare struct {
+	UserID     string
+	ViewID     string
+	Grantee    string
+	Permission string
+	CreatedAt  string
 }
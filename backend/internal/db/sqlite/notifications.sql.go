@@ -13,10 +13,11 @@ import (
 const archiveNotification = `-- name: ArchiveNotification :one
 UPDATE notifications 
 SET archived = 1,
+    archived_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'),
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type ArchiveNotificationParams struct {
@@ -61,10 +62,109 @@ func (q *Queries) ArchiveNotification(ctx context.Context, arg ArchiveNotificati
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const classifyAwaitingReplies = `-- name: ClassifyAwaitingReplies :many
+UPDATE notifications
+SET awaiting_reply = 1,
+    archived = CASE WHEN ?1 = 1 AND muted = 0 THEN 0 ELSE archived END,
+    snoozed_until = CASE WHEN ?1 = 1 AND muted = 0 THEN NULL ELSE snoozed_until END,
+    snoozed_at = CASE WHEN ?1 = 1 AND muted = 0 THEN NULL ELSE snoozed_at END,
+    effective_sort_date = CASE
+        WHEN ?1 = 1 AND muted = 0 THEN ?2
+        ELSE effective_sort_date
+    END
+WHERE user_id = ?3
+  AND awaiting_reply = 0
+  AND awaiting_reply_since IS NOT NULL
+  AND awaiting_reply_since < ?4
+RETURNING id, github_id
+`
+
+type ClassifyAwaitingRepliesParams struct {
+	Resurface    interface{}
+	ClassifiedAt interface{}
+	UserID       string
+	CutoffDate   sql.NullString
+}
+
+type ClassifyAwaitingRepliesRow struct {
+	ID       int64
+	GithubID string
+}
+
+// Mark threads whose own latest comment has gone unanswered since the cutoff as awaiting a
+// reply. With resurface set, unmuted ones are also unarchived, unsnoozed and moved to the
+// top of the inbox, like escalated review requests.
+func (q *Queries) ClassifyAwaitingReplies(ctx context.Context, arg ClassifyAwaitingRepliesParams) ([]ClassifyAwaitingRepliesRow, error) {
+	rows, err := q.db.QueryContext(ctx, classifyAwaitingReplies,
+		arg.Resurface,
+		arg.ClassifiedAt,
+		arg.UserID,
+		arg.CutoffDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClassifyAwaitingRepliesRow
+	for rows.Next() {
+		var i ClassifyAwaitingRepliesRow
+		if err := rows.Scan(&i.ID, &i.GithubID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countCleanupCandidates = `-- name: CountCleanupCandidates :one
+SELECT COUNT(*) as count
+FROM notifications n
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < ?2
+`
+
+type CountCleanupCandidatesParams struct {
+	UserID     string
+	CutoffDate string
+}
+
+// Count archived/muted notifications older than the cutoff, including protected ones
+func (q *Queries) CountCleanupCandidates(ctx context.Context, arg CountCleanupCandidatesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCleanupCandidates, arg.UserID, arg.CutoffDate)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countEligibleForCleanup = `-- name: CountEligibleForCleanup :one
 SELECT COUNT(*) as count
 FROM notifications n
@@ -140,8 +240,71 @@ func (q *Queries) DeleteOldArchivedNotifications(ctx context.Context, arg Delete
 	return result.RowsAffected()
 }
 
+const escalateStaleReviewRequests = `-- name: EscalateStaleReviewRequests :many
+UPDATE notifications
+SET archived = 0,
+    snoozed_until = NULL,
+    snoozed_at = NULL,
+    effective_sort_date = ?1
+WHERE id IN (
+    SELECT n.id FROM notifications n
+    WHERE n.user_id = ?2
+      AND n.reason = 'review_requested'
+      AND n.is_read = 0
+      AND n.muted = 0
+      AND (n.subject_state IS NULL OR n.subject_state = 'open')
+      AND COALESCE(n.snoozed_at, n.effective_sort_date) < ?3
+    ORDER BY n.effective_sort_date ASC
+    LIMIT ?4
+)
+RETURNING id, github_id
+`
+
+type EscalateStaleReviewRequestsParams struct {
+	EscalatedAt string
+	UserID      string
+	CutoffDate  string
+	BatchSize   int64
+}
+
+type EscalateStaleReviewRequestsRow struct {
+	ID       int64
+	GithubID string
+}
+
+// Resurface unread review requests left untouched since the cutoff, in batches: unarchive,
+// unsnooze and move them to the top of the inbox. The fresh effective_sort_date also makes
+// them show up as new to the desktop notification poller.
+func (q *Queries) EscalateStaleReviewRequests(ctx context.Context, arg EscalateStaleReviewRequestsParams) ([]EscalateStaleReviewRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, escalateStaleReviewRequests,
+		arg.EscalatedAt,
+		arg.UserID,
+		arg.CutoffDate,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EscalateStaleReviewRequestsRow
+	for rows.Next() {
+		var i EscalateStaleReviewRequestsRow
+		if err := rows.Scan(&i.ID, &i.GithubID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationByGithubID = `-- name: GetNotificationByGithubID :one
-SELECT id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason FROM notifications WHERE user_id = ? AND github_id = ?
+SELECT id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority FROM notifications WHERE user_id = ? AND github_id = ?
 `
 
 type GetNotificationByGithubIDParams struct {
@@ -186,12 +349,30 @@ func (q *Queries) GetNotificationByGithubID(ctx context.Context, arg GetNotifica
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const getNotificationByID = `-- name: GetNotificationByID :one
-SELECT id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason FROM notifications WHERE user_id = ? AND id = ?
+SELECT id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority FROM notifications WHERE user_id = ? AND id = ?
 `
 
 type GetNotificationByIDParams struct {
@@ -236,6 +417,24 @@ func (q *Queries) GetNotificationByID(ctx context.Context, arg GetNotificationBy
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
@@ -281,8 +480,343 @@ func (q *Queries) GetStorageStats(ctx context.Context, arg GetStorageStatsParams
 	return i, err
 }
 
+const listCleanupCandidates = `-- name: ListCleanupCandidates :many
+SELECT
+    n.id,
+    n.github_id,
+    n.subject_title,
+    n.subject_type,
+    r.full_name AS repo_full_name,
+    n.archived,
+    n.muted,
+    n.starred,
+    n.effective_sort_date,
+    EXISTS (
+        SELECT 1 FROM tag_assignments ta
+        WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification' AND ta.entity_id = n.id
+    ) AS tagged
+FROM notifications n
+JOIN repositories r ON r.id = n.repository_id
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < ?2
+ORDER BY COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) ASC, n.id ASC
+LIMIT ?3 OFFSET ?4
+`
+
+type ListCleanupCandidatesParams struct {
+	UserID     string
+	CutoffDate string
+	Limit      int64
+	Offset     int64
+}
+
+type ListCleanupCandidatesRow struct {
+	ID                int64
+	GithubID          string
+	SubjectTitle      string
+	SubjectType       string
+	RepoFullName      string
+	Archived          int64
+	Muted             int64
+	Starred           int64
+	EffectiveSortDate string
+	Tagged            int64
+}
+
+// List archived/muted notifications older than the cutoff for cleanup previews
+// Protected (starred/tagged) rows are included so callers can explain why they are skipped
+func (q *Queries) ListCleanupCandidates(ctx context.Context, arg ListCleanupCandidatesParams) ([]ListCleanupCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCleanupCandidates,
+		arg.UserID,
+		arg.CutoffDate,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCleanupCandidatesRow
+	for rows.Next() {
+		var i ListCleanupCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GithubID,
+			&i.SubjectTitle,
+			&i.SubjectType,
+			&i.RepoFullName,
+			&i.Archived,
+			&i.Muted,
+			&i.Starred,
+			&i.EffectiveSortDate,
+			&i.Tagged,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEligibleForCleanupIDs = `-- name: ListEligibleForCleanupIDs :many
+SELECT n.id
+FROM notifications n
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND (?2 = 0 OR n.starred = 0)
+  AND (?3 = 0 OR n.id NOT IN (
+      SELECT ta.entity_id FROM tag_assignments ta WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification'
+  ))
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < ?4
+ORDER BY n.id ASC
+`
+
+type ListEligibleForCleanupIDsParams struct {
+	UserID         string
+	ProtectStarred interface{}
+	ProtectTagged  interface{}
+	CutoffDate     string
+}
+
+// List IDs of notifications that cleanup would delete, used to fingerprint a cleanup preview
+func (q *Queries) ListEligibleForCleanupIDs(ctx context.Context, arg ListEligibleForCleanupIDsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listEligibleForCleanupIDs,
+		arg.UserID,
+		arg.ProtectStarred,
+		arg.ProtectTagged,
+		arg.CutoffDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubIDsBySubjectURL = `-- name: ListGithubIDsBySubjectURL :many
+SELECT github_id FROM notifications
+WHERE user_id = ? AND subject_url = ?
+ORDER BY id
+`
+
+type ListGithubIDsBySubjectURLParams struct {
+	UserID     string
+	SubjectUrl sql.NullString
+}
+
+// List every notification about a subject, so a webhook delivery can update them all
+func (q *Queries) ListGithubIDsBySubjectURL(ctx context.Context, arg ListGithubIDsBySubjectURLParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubIDsBySubjectURL, arg.UserID, arg.SubjectUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var github_id string
+		if err := rows.Scan(&github_id); err != nil {
+			return nil, err
+		}
+		items = append(items, github_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationAuthors = `-- name: ListNotificationAuthors :many
+SELECT author_login FROM notifications
+WHERE user_id = ? AND author_login IS NOT NULL AND author_login LIKE ?2 ESCAPE '\'
+GROUP BY author_login
+ORDER BY COUNT(*) DESC, author_login
+LIMIT ?3
+`
+
+type ListNotificationAuthorsParams struct {
+	UserID  string
+	Pattern sql.NullString
+	Limit   int64
+}
+
+// Distinct notification authors matching a LIKE pattern, most frequent first
+func (q *Queries) ListNotificationAuthors(ctx context.Context, arg ListNotificationAuthorsParams) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationAuthors, arg.UserID, arg.Pattern, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullString
+	for rows.Next() {
+		var author_login sql.NullString
+		if err := rows.Scan(&author_login); err != nil {
+			return nil, err
+		}
+		items = append(items, author_login)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationLabels = `-- name: ListNotificationLabels :many
+SELECT CAST(label.value AS TEXT) AS label
+FROM notifications n, json_each(n.subject_labels) AS label
+WHERE n.user_id = ? AND n.subject_labels IS NOT NULL
+  AND label.value LIKE ?2 ESCAPE '\'
+GROUP BY label.value
+ORDER BY COUNT(*) DESC, label.value
+LIMIT ?3
+`
+
+type ListNotificationLabelsParams struct {
+	UserID  string
+	Pattern interface{}
+	Limit   int64
+}
+
+// Distinct issue and pull request labels matching a LIKE pattern, most used first
+func (q *Queries) ListNotificationLabels(ctx context.Context, arg ListNotificationLabelsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationLabels, arg.UserID, arg.Pattern, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+		items = append(items, label)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationVolume = `-- name: ListNotificationVolume :many
+SELECT r.full_name,
+    COALESCE(n.reason, '') AS reason,
+    COUNT(*) AS total,
+    CAST(SUM(n.is_read = 0) AS INTEGER) AS unread
+FROM notifications n
+JOIN repositories r ON r.id = n.repository_id
+WHERE n.user_id = ? AND COALESCE(n.github_updated_at, n.imported_at) >= ?2
+GROUP BY r.full_name, n.reason
+ORDER BY r.full_name, reason
+`
+
+type ListNotificationVolumeParams struct {
+	UserID string
+	Since  string
+}
+
+type ListNotificationVolumeRow struct {
+	FullName string
+	Reason   string
+	Total    int64
+	Unread   int64
+}
+
+// Notifications updated since the window start, counted by repository and reason
+func (q *Queries) ListNotificationVolume(ctx context.Context, arg ListNotificationVolumeParams) ([]ListNotificationVolumeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationVolume, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNotificationVolumeRow
+	for rows.Next() {
+		var i ListNotificationVolumeRow
+		if err := rows.Scan(
+			&i.FullName,
+			&i.Reason,
+			&i.Total,
+			&i.Unread,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnreadGithubIDsBySubjectURL = `-- name: ListUnreadGithubIDsBySubjectURL :many
+SELECT github_id FROM notifications
+WHERE user_id = ? AND subject_url = ? AND is_read = 0
+ORDER BY id
+`
+
+type ListUnreadGithubIDsBySubjectURLParams struct {
+	UserID     string
+	SubjectUrl sql.NullString
+}
+
+// List unread notifications about the same subject, so a thread can be marked read together
+func (q *Queries) ListUnreadGithubIDsBySubjectURL(ctx context.Context, arg ListUnreadGithubIDsBySubjectURLParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listUnreadGithubIDsBySubjectURL, arg.UserID, arg.SubjectUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var github_id string
+		if err := rows.Scan(&github_id); err != nil {
+			return nil, err
+		}
+		items = append(items, github_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markNotificationFiltered = `-- name: MarkNotificationFiltered :one
-UPDATE notifications SET filtered = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET filtered = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type MarkNotificationFilteredParams struct {
@@ -327,12 +861,30 @@ func (q *Queries) MarkNotificationFiltered(ctx context.Context, arg MarkNotifica
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const markNotificationRead = `-- name: MarkNotificationRead :one
-UPDATE notifications SET is_read = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET is_read = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type MarkNotificationReadParams struct {
@@ -377,12 +929,30 @@ func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const markNotificationUnfiltered = `-- name: MarkNotificationUnfiltered :one
-UPDATE notifications SET filtered = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET filtered = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type MarkNotificationUnfilteredParams struct {
@@ -427,12 +997,30 @@ func (q *Queries) MarkNotificationUnfiltered(ctx context.Context, arg MarkNotifi
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const markNotificationUnread = `-- name: MarkNotificationUnread :one
-UPDATE notifications SET is_read = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET is_read = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type MarkNotificationUnreadParams struct {
@@ -477,17 +1065,53 @@ func (q *Queries) MarkNotificationUnread(ctx context.Context, arg MarkNotificati
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const markNotificationsReadBySubjectURL = `-- name: MarkNotificationsReadBySubjectURL :execrows
+UPDATE notifications SET is_read = 1
+WHERE user_id = ? AND subject_url = ? AND is_read = 0
+`
+
+type MarkNotificationsReadBySubjectURLParams struct {
+	UserID     string
+	SubjectUrl sql.NullString
+}
+
+func (q *Queries) MarkNotificationsReadBySubjectURL(ctx context.Context, arg MarkNotificationsReadBySubjectURLParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markNotificationsReadBySubjectURL, arg.UserID, arg.SubjectUrl)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const muteNotification = `-- name: MuteNotification :one
 UPDATE notifications 
 SET muted = 1,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type MuteNotificationParams struct {
@@ -532,6 +1156,24 @@ func (q *Queries) MuteNotification(ctx context.Context, arg MuteNotificationPara
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
@@ -555,26 +1197,82 @@ func (q *Queries) ResetNotificationStatusOnSync(ctx context.Context, arg ResetNo
 	return err
 }
 
+const returnExpiredSnoozes = `-- name: ReturnExpiredSnoozes :many
+UPDATE notifications
+SET snoozed_until = NULL,
+    snoozed_at = NULL,
+    snooze_until_updated = 0,
+    returned_from_snooze_at = ?1,
+    is_read = CASE WHEN ?2 = 1 THEN 0 ELSE is_read END
+WHERE user_id = ?3
+  AND snoozed_until IS NOT NULL
+  AND snoozed_until <= ?1
+  AND archived = 0
+  AND muted = 0
+RETURNING id, github_id
+`
+
+type ReturnExpiredSnoozesParams struct {
+	ReturnedAt sql.NullString
+	MarkUnread interface{}
+	UserID     string
+}
+
+type ReturnExpiredSnoozesRow struct {
+	ID       int64
+	GithubID string
+}
+
+// Bring back notifications whose snooze has run out, recording when they returned and
+// optionally marking them unread. They keep the sort date of their snooze, so they show up
+// at the top of the inbox as of when they woke.
+func (q *Queries) ReturnExpiredSnoozes(ctx context.Context, arg ReturnExpiredSnoozesParams) ([]ReturnExpiredSnoozesRow, error) {
+	rows, err := q.db.QueryContext(ctx, returnExpiredSnoozes, arg.ReturnedAt, arg.MarkUnread, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReturnExpiredSnoozesRow
+	for rows.Next() {
+		var i ReturnExpiredSnoozesRow
+		if err := rows.Scan(&i.ID, &i.GithubID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const snoozeNotification = `-- name: SnoozeNotification :one
 UPDATE notifications 
 SET snoozed_until = ?,
     snoozed_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'),
-    effective_sort_date = ?
+    effective_sort_date = ?,
+    snooze_until_updated = ?,
+    returned_from_snooze_at = NULL
 WHERE user_id = ? AND github_id = ? 
-RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type SnoozeNotificationParams struct {
-	SnoozedUntil      sql.NullString
-	EffectiveSortDate string
-	UserID            string
-	GithubID          string
+	SnoozedUntil       sql.NullString
+	EffectiveSortDate  string
+	SnoozeUntilUpdated int64
+	UserID             string
+	GithubID           string
 }
 
 func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotificationParams) (Notification, error) {
 	row := q.db.QueryRowContext(ctx, snoozeNotification,
 		arg.SnoozedUntil,
 		arg.EffectiveSortDate,
+		arg.SnoozeUntilUpdated,
 		arg.UserID,
 		arg.GithubID,
 	)
@@ -613,12 +1311,30 @@ func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const starNotification = `-- name: StarNotification :one
-UPDATE notifications SET starred = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET starred = 1 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type StarNotificationParams struct {
@@ -663,12 +1379,30 @@ func (q *Queries) StarNotification(ctx context.Context, arg StarNotificationPara
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const unarchiveNotification = `-- name: UnarchiveNotification :one
-UPDATE notifications SET archived = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET archived = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type UnarchiveNotificationParams struct {
@@ -713,12 +1447,30 @@ func (q *Queries) UnarchiveNotification(ctx context.Context, arg UnarchiveNotifi
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const unmuteNotification = `-- name: UnmuteNotification :one
-UPDATE notifications SET muted = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET muted = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type UnmuteNotificationParams struct {
@@ -763,6 +1515,24 @@ func (q *Queries) UnmuteNotification(ctx context.Context, arg UnmuteNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
@@ -773,7 +1543,7 @@ SET snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
 WHERE user_id = ? AND github_id = ? 
-RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type UnsnoozeNotificationParams struct {
@@ -818,12 +1588,30 @@ func (q *Queries) UnsnoozeNotification(ctx context.Context, arg UnsnoozeNotifica
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const unstarNotification = `-- name: UnstarNotification :one
-UPDATE notifications SET starred = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+UPDATE notifications SET starred = 0 WHERE user_id = ? AND github_id = ? RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type UnstarNotificationParams struct {
@@ -868,10 +1656,82 @@ func (q *Queries) UnstarNotification(ctx context.Context, arg UnstarNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const updateNotificationAwaitingReply = `-- name: UpdateNotificationAwaitingReply :exec
+UPDATE notifications
+SET awaiting_reply = CASE
+        WHEN awaiting_reply_since IS ?1 THEN awaiting_reply
+        ELSE 0
+    END,
+    awaiting_reply_since = ?1
+WHERE user_id = ?2 AND id = ?3
+`
+
+type UpdateNotificationAwaitingReplyParams struct {
+	AwaitingReplySince sql.NullString
+	UserID             string
+	ID                 int64
+}
+
+// Records when the account's own comment became the thread's latest, or clears it when
+// someone else's did. A new comment starts the awaiting-reply window over.
+func (q *Queries) UpdateNotificationAwaitingReply(ctx context.Context, arg UpdateNotificationAwaitingReplyParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationAwaitingReply, arg.AwaitingReplySince, arg.UserID, arg.ID)
+	return err
+}
+
+const updateNotificationPriority = `-- name: UpdateNotificationPriority :exec
+UPDATE notifications SET priority = ? WHERE user_id = ? AND id = ?
+`
+
+type UpdateNotificationPriorityParams struct {
+	Priority sql.NullInt64
+	UserID   string
+	ID       int64
+}
+
+func (q *Queries) UpdateNotificationPriority(ctx context.Context, arg UpdateNotificationPriorityParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationPriority, arg.Priority, arg.UserID, arg.ID)
+	return err
+}
+
+const updateNotificationSearchComment = `-- name: UpdateNotificationSearchComment :exec
+UPDATE notification_search SET comment = ? WHERE rowid = ?
+`
+
+type UpdateNotificationSearchCommentParams struct {
+	Comment string
+	Rowid   int64
+}
+
+// Replaces the indexed latest comment body of a notification. Titles and subject
+// descriptions are indexed by triggers on the notifications table.
+func (q *Queries) UpdateNotificationSearchComment(ctx context.Context, arg UpdateNotificationSearchCommentParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationSearchComment, arg.Comment, arg.Rowid)
+	return err
+}
+
 const updateNotificationSubject = `-- name: UpdateNotificationSubject :exec
 UPDATE notifications SET
     subject_raw = ?,
@@ -881,6 +1741,17 @@ UPDATE notifications SET
     subject_state = ?,
     subject_merged = ?,
     subject_state_reason = ?,
+    head_branch = ?,
+    base_branch = ?,
+    subject_draft = ?,
+    subject_labels = ?,
+    review_state = ?,
+    subject_milestone = ?,
+    subject_projects = COALESCE(?, subject_projects),
+    ci_state = ?,
+    subject_mergeable = ?,
+    waiting_on = ?,
+    priority = ?,
     author_login = ?,
     author_id = ?
 WHERE user_id = ? AND github_id = ?
@@ -894,6 +1765,17 @@ type UpdateNotificationSubjectParams struct {
 	SubjectState       sql.NullString
 	SubjectMerged      sql.NullInt64
 	SubjectStateReason sql.NullString
+	HeadBranch         sql.NullString
+	BaseBranch         sql.NullString
+	SubjectDraft       sql.NullInt64
+	SubjectLabels      sql.NullString
+	ReviewState        sql.NullString
+	SubjectMilestone   sql.NullString
+	SubjectProjects    sql.NullString
+	CiState            sql.NullString
+	SubjectMergeable   sql.NullInt64
+	WaitingOn          sql.NullString
+	Priority           sql.NullInt64
 	AuthorLogin        sql.NullString
 	AuthorID           sql.NullInt64
 	UserID             string
@@ -909,6 +1791,17 @@ func (q *Queries) UpdateNotificationSubject(ctx context.Context, arg UpdateNotif
 		arg.SubjectState,
 		arg.SubjectMerged,
 		arg.SubjectStateReason,
+		arg.HeadBranch,
+		arg.BaseBranch,
+		arg.SubjectDraft,
+		arg.SubjectLabels,
+		arg.ReviewState,
+		arg.SubjectMilestone,
+		arg.SubjectProjects,
+		arg.CiState,
+		arg.SubjectMergeable,
+		arg.WaitingOn,
+		arg.Priority,
 		arg.AuthorLogin,
 		arg.AuthorID,
 		arg.UserID,
@@ -923,8 +1816,9 @@ INSERT INTO notifications (
     subject_latest_comment_url, reason, github_unread, github_updated_at,
     github_last_read_at, github_url, github_subscription_url, payload,
     subject_raw, subject_fetched_at, author_login, author_id,
-    subject_number, subject_state, subject_merged, subject_state_reason,
-    imported_at, effective_sort_date
+    subject_number, subject_state, subject_merged, subject_state_reason, content_kind,
+    head_branch, base_branch, subject_draft, subject_labels, review_state, subject_milestone,
+    subject_projects, ci_state, subject_mergeable, waiting_on, priority, account, imported_at, effective_sort_date
 ) VALUES (
     ?1,
     ?2, 
@@ -949,8 +1843,21 @@ INSERT INTO notifications (
     ?21,
     ?22,
     ?23,
+    ?24,
+    ?25,
+    ?26,
+    ?27,
+    ?28,
+    ?29,
+    ?30,
+    ?31,
+    ?32,
+    ?33,
+    ?34,
+    ?35,
+    COALESCE(?36, (SELECT github_username FROM users WHERE github_user_id = ?1)),
     strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), 
-    COALESCE(?24, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+    COALESCE(?37, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
 )
 ON CONFLICT(user_id, github_id) DO UPDATE SET
     pull_request_id = excluded.pull_request_id,
@@ -972,9 +1879,48 @@ ON CONFLICT(user_id, github_id) DO UPDATE SET
     subject_state = excluded.subject_state,
     subject_merged = excluded.subject_merged,
     subject_state_reason = excluded.subject_state_reason,
+    content_kind = excluded.content_kind,
+    head_branch = excluded.head_branch,
+    base_branch = excluded.base_branch,
+    subject_draft = excluded.subject_draft,
+    subject_labels = excluded.subject_labels,
+    review_state = excluded.review_state,
+    subject_milestone = excluded.subject_milestone,
+    -- Projects come from a separate best-effort lookup, so a failed one keeps what was known
+    subject_projects = COALESCE(excluded.subject_projects, notifications.subject_projects),
+    ci_state = excluded.ci_state,
+    subject_mergeable = excluded.subject_mergeable,
+    waiting_on = excluded.waiting_on,
+    priority = excluded.priority,
+    -- Only a sync for a specific account moves a notification to that account
+    account = COALESCE(?36, notifications.account),
+    -- A snooze until updated ends once GitHub reports a newer update
+    snoozed_until = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN NULL
+        ELSE notifications.snoozed_until
+    END,
+    snoozed_at = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN NULL
+        ELSE notifications.snoozed_at
+    END,
+    snooze_until_updated = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN 0
+        ELSE notifications.snooze_until_updated
+    END,
     -- Preserve snoozed_until as sort date if notification is snoozed, otherwise use new github_updated_at
-    effective_sort_date = COALESCE(notifications.snoozed_until, excluded.effective_sort_date)
-RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason
+    effective_sort_date = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN excluded.effective_sort_date
+        ELSE COALESCE(notifications.snoozed_until, excluded.effective_sort_date)
+    END
+RETURNING id, user_id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, subject_number, subject_state, subject_merged, subject_state_reason, content_kind, head_branch, base_branch, account, archived_at, awaiting_reply_since, awaiting_reply, subject_draft, subject_labels, review_state, snooze_until_updated, returned_from_snooze_at, subject_milestone, subject_projects, ci_state, subject_mergeable, waiting_on, priority
 `
 
 type UpsertNotificationParams struct {
@@ -1001,6 +1947,19 @@ type UpsertNotificationParams struct {
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullInt64
 	SubjectStateReason      sql.NullString
+	ContentKind             string
+	HeadBranch              sql.NullString
+	BaseBranch              sql.NullString
+	SubjectDraft            sql.NullInt64
+	SubjectLabels           sql.NullString
+	ReviewState             sql.NullString
+	SubjectMilestone        sql.NullString
+	SubjectProjects         sql.NullString
+	CiState                 sql.NullString
+	SubjectMergeable        sql.NullInt64
+	WaitingOn               sql.NullString
+	Priority                sql.NullInt64
+	Account                 sql.NullString
 	EffectiveSortDate       interface{}
 }
 
@@ -1029,6 +1988,19 @@ func (q *Queries) UpsertNotification(ctx context.Context, arg UpsertNotification
 		arg.SubjectState,
 		arg.SubjectMerged,
 		arg.SubjectStateReason,
+		arg.ContentKind,
+		arg.HeadBranch,
+		arg.BaseBranch,
+		arg.SubjectDraft,
+		arg.SubjectLabels,
+		arg.ReviewState,
+		arg.SubjectMilestone,
+		arg.SubjectProjects,
+		arg.CiState,
+		arg.SubjectMergeable,
+		arg.WaitingOn,
+		arg.Priority,
+		arg.Account,
 		arg.EffectiveSortDate,
 	)
 	var i Notification
@@ -1066,6 +2038,24 @@ func (q *Queries) UpsertNotification(ctx context.Context, arg UpsertNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.ContentKind,
+		&i.HeadBranch,
+		&i.BaseBranch,
+		&i.Account,
+		&i.ArchivedAt,
+		&i.AwaitingReplySince,
+		&i.AwaitingReply,
+		&i.SubjectDraft,
+		&i.SubjectLabels,
+		&i.ReviewState,
+		&i.SnoozeUntilUpdated,
+		&i.ReturnedFromSnoozeAt,
+		&i.SubjectMilestone,
+		&i.SubjectProjects,
+		&i.CiState,
+		&i.SubjectMergeable,
+		&i.WaitingOn,
+		&i.Priority,
 	)
 	return i, err
 }
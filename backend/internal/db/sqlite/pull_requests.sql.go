@@ -36,12 +36,67 @@ func (q *Queries) DeleteOrphanedPullRequests(ctx context.Context, arg DeleteOrph
 	return result.RowsAffected()
 }
 
+const listReviewRequestReviewers = `-- name: ListReviewRequestReviewers :many
+SELECT
+    n.github_id,
+    n.is_read,
+    COALESCE(n.subject_state, pr.state, '') AS state,
+    COALESCE(pr.requested_reviewers, '') AS requested_reviewers
+FROM notifications n
+JOIN pull_requests pr ON pr.id = n.pull_request_id
+WHERE n.user_id = ? AND n.reason = 'review_requested'
+  AND COALESCE(n.github_updated_at, n.imported_at) >= ?2
+ORDER BY n.id
+`
+
+type ListReviewRequestReviewersParams struct {
+	UserID string
+	Since  string
+}
+
+type ListReviewRequestReviewersRow struct {
+	GithubID           string
+	IsRead             int64
+	State              string
+	RequestedReviewers string
+}
+
+// Review requests updated since the window start, with the requested reviewers of their
+// pull request
+func (q *Queries) ListReviewRequestReviewers(ctx context.Context, arg ListReviewRequestReviewersParams) ([]ListReviewRequestReviewersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewRequestReviewers, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReviewRequestReviewersRow
+	for rows.Next() {
+		var i ListReviewRequestReviewersRow
+		if err := rows.Scan(
+			&i.GithubID,
+			&i.IsRead,
+			&i.State,
+			&i.RequestedReviewers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertPullRequest = `-- name: UpsertPullRequest :one
 INSERT INTO pull_requests (
     user_id, repository_id, github_id, node_id, number, title, state,
     draft, merged, author_login, author_id,
-    created_at, updated_at, closed_at, merged_at, raw
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    created_at, updated_at, closed_at, merged_at, raw, requested_reviewers, requested_teams
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(user_id, repository_id, number) DO UPDATE SET
     github_id = excluded.github_id,
     node_id = excluded.node_id,
@@ -54,27 +109,31 @@ ON CONFLICT(user_id, repository_id, number) DO UPDATE SET
     updated_at = excluded.updated_at,
     closed_at = excluded.closed_at,
     merged_at = excluded.merged_at,
-    raw = excluded.raw
-RETURNING id, user_id, repository_id, github_id, node_id, number, title, state, draft, merged, author_login, author_id, created_at, updated_at, closed_at, merged_at, raw
+    raw = excluded.raw,
+    requested_reviewers = excluded.requested_reviewers,
+    requested_teams = excluded.requested_teams
+RETURNING id, user_id, repository_id, github_id, node_id, number, title, state, draft, merged, author_login, author_id, created_at, updated_at, closed_at, merged_at, raw, requested_reviewers, requested_teams
 `
 
 type UpsertPullRequestParams struct {
-	UserID       string
-	RepositoryID int64
-	GithubID     sql.NullInt64
-	NodeID       sql.NullString
-	Number       int64
-	Title        sql.NullString
-	State        sql.NullString
-	Draft        sql.NullInt64
-	Merged       sql.NullInt64
-	AuthorLogin  sql.NullString
-	AuthorID     sql.NullInt64
-	CreatedAt    sql.NullString
-	UpdatedAt    sql.NullString
-	ClosedAt     sql.NullString
-	MergedAt     sql.NullString
-	Raw          sql.NullString
+	UserID             string
+	RepositoryID       int64
+	GithubID           sql.NullInt64
+	NodeID             sql.NullString
+	Number             int64
+	Title              sql.NullString
+	State              sql.NullString
+	Draft              sql.NullInt64
+	Merged             sql.NullInt64
+	AuthorLogin        sql.NullString
+	AuthorID           sql.NullInt64
+	CreatedAt          sql.NullString
+	UpdatedAt          sql.NullString
+	ClosedAt           sql.NullString
+	MergedAt           sql.NullString
+	Raw                sql.NullString
+	RequestedReviewers sql.NullString
+	RequestedTeams     sql.NullString
 }
 
 func (q *Queries) UpsertPullRequest(ctx context.Context, arg UpsertPullRequestParams) (PullRequest, error) {
@@ -95,6 +154,8 @@ func (q *Queries) UpsertPullRequest(ctx context.Context, arg UpsertPullRequestPa
 		arg.ClosedAt,
 		arg.MergedAt,
 		arg.Raw,
+		arg.RequestedReviewers,
+		arg.RequestedTeams,
 	)
 	var i PullRequest
 	err := row.Scan(
@@ -115,6 +176,8 @@ func (q *Queries) UpsertPullRequest(ctx context.Context, arg UpsertPullRequestPa
 		&i.ClosedAt,
 		&i.MergedAt,
 		&i.Raw,
+		&i.RequestedReviewers,
+		&i.RequestedTeams,
 	)
 	return i, err
 }
@@ -0,0 +1,19 @@
+-- name: ListAPITokens :many
+SELECT * FROM api_tokens WHERE user_id = ? ORDER BY created_at DESC, id;
+
+-- name: GetAPITokenByHash :one
+SELECT * FROM api_tokens WHERE token_hash = ?;
+
+-- name: CreateAPIToken :one
+INSERT INTO api_tokens (
+    user_id, name, token_hash, token_prefix, scope, account, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateAPITokenLastUsed :exec
+UPDATE api_tokens SET last_used_at = ? WHERE id = ?;
+
+-- name: DeleteAPIToken :execrows
+DELETE FROM api_tokens WHERE user_id = ? AND id = ?;
@@ -0,0 +1,15 @@
+-- name: ListAuthorProfiles :many
+SELECT * FROM author_profiles WHERE user_id = ? ORDER BY login;
+
+-- name: UpsertAuthorProfile :exec
+INSERT INTO author_profiles (
+    user_id, login, name, avatar_url, type, fetched_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT(user_id, login) DO UPDATE SET
+    login = excluded.login,
+    name = excluded.name,
+    avatar_url = excluded.avatar_url,
+    type = excluded.type,
+    fetched_at = excluded.fetched_at;
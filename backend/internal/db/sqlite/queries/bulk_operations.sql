@@ -0,0 +1,27 @@
+-- name: CreateBulkOperation :one
+INSERT INTO bulk_operations (user_id, operation, created_at)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetBulkOperation :one
+SELECT * FROM bulk_operations WHERE user_id = ? AND id = ?;
+
+-- name: MarkBulkOperationUndone :execrows
+-- Only the first undo counts, so an operation can't be undone twice
+UPDATE bulk_operations SET undone_at = sqlc.arg(undone_at)
+WHERE user_id = sqlc.arg(user_id) AND id = sqlc.arg(id) AND undone_at IS NULL;
+
+-- name: ListBulkOperationGithubIDs :many
+SELECT github_id FROM bulk_operation_states
+WHERE user_id = ? AND operation_id = ?
+ORDER BY github_id;
+
+-- name: DeleteBulkOperationStatesBefore :exec
+DELETE FROM bulk_operation_states
+WHERE operation_id IN (
+    SELECT id FROM bulk_operations
+    WHERE user_id = sqlc.arg(user_id) AND created_at < sqlc.arg(before)
+);
+
+-- name: DeleteBulkOperationsBefore :exec
+DELETE FROM bulk_operations WHERE user_id = ? AND created_at < ?;
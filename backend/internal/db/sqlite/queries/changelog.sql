@@ -0,0 +1,15 @@
+-- name: InsertChangelogEntry :exec
+INSERT INTO changelog_entries (
+    key, schema_version, kind, title, description, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT(key) DO NOTHING;
+
+-- name: ListUnseenChangelogEntries :many
+SELECT * FROM changelog_entries WHERE acknowledged_at IS NULL ORDER BY schema_version, id;
+
+-- name: AcknowledgeChangelogEntries :execrows
+UPDATE changelog_entries
+SET acknowledged_at = ?
+WHERE acknowledged_at IS NULL AND id <= ?;
@@ -0,0 +1,61 @@
+-- name: ListIntegrations :many
+SELECT * FROM integrations WHERE user_id = ? ORDER BY name COLLATE NOCASE, id;
+
+-- name: GetIntegration :one
+SELECT * FROM integrations WHERE user_id = ? AND id = ?;
+
+-- name: CreateIntegration :one
+INSERT INTO integrations (
+    user_id, name, format, url, secret_encrypted, created_at, updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateIntegration :one
+UPDATE integrations SET
+    name = ?,
+    format = ?,
+    url = ?,
+    secret_encrypted = ?,
+    updated_at = ?
+WHERE user_id = ? AND id = ?
+RETURNING *;
+
+-- name: DeleteIntegration :execrows
+DELETE FROM integrations WHERE user_id = ? AND id = ?;
+
+-- name: CreateIntegrationDelivery :one
+INSERT INTO integration_deliveries (
+    user_id, integration_id, rule_id, rule_name, github_id, next_attempt_at, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListDueIntegrationDeliveries :many
+-- Pending deliveries whose next attempt is due, oldest first
+SELECT * FROM integration_deliveries
+WHERE user_id = ? AND status = 'pending' AND next_attempt_at <= ?
+ORDER BY id
+LIMIT ?;
+
+-- name: UpdateIntegrationDelivery :exec
+UPDATE integration_deliveries SET
+    status = ?,
+    attempts = ?,
+    response_status = ?,
+    last_error = ?,
+    next_attempt_at = ?,
+    delivered_at = ?
+WHERE user_id = ? AND id = ?;
+
+-- name: ListIntegrationDeliveries :many
+-- An integration's deliveries, newest first
+SELECT * FROM integration_deliveries
+WHERE user_id = ? AND integration_id = ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: DeleteIntegrationDeliveriesBefore :execrows
+DELETE FROM integration_deliveries WHERE user_id = ? AND status != 'pending' AND created_at < ?;
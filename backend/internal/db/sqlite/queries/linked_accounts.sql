@@ -0,0 +1,31 @@
+-- name: ListLinkedAccounts :many
+SELECT * FROM linked_accounts WHERE user_id = ? ORDER BY login COLLATE NOCASE, id;
+
+-- name: UpsertLinkedAccount :one
+INSERT INTO linked_accounts (
+    user_id, github_user_id, login, token_encrypted
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT(user_id, github_user_id) DO UPDATE SET
+    login = excluded.login,
+    token_encrypted = excluded.token_encrypted
+RETURNING *;
+
+-- name: DeleteLinkedAccount :execrows
+DELETE FROM linked_accounts WHERE user_id = ? AND login = ? COLLATE NOCASE;
+
+-- name: UpdateLinkedAccountSyncedAt :exec
+UPDATE linked_accounts SET last_synced_at = ? WHERE user_id = ? AND login = ? COLLATE NOCASE;
+
+-- name: DeleteTagAssignmentsByAccount :exec
+DELETE FROM tag_assignments
+WHERE user_id = sqlc.arg(user_id)
+  AND entity_type = 'notification'
+  AND entity_id IN (
+      SELECT n.id FROM notifications n
+      WHERE n.user_id = sqlc.arg(user_id) AND n.account = sqlc.arg(account) COLLATE NOCASE
+  );
+
+-- name: DeleteNotificationsByAccount :execrows
+DELETE FROM notifications WHERE user_id = ? AND account = ? COLLATE NOCASE;
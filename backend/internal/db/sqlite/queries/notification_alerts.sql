@@ -0,0 +1,30 @@
+-- name: CreateNotificationAlert :one
+INSERT INTO notification_alerts (
+    user_id, notification_id, level, sound, source, source_id, suppressed, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListNotificationAlertsAfter :many
+-- Alerts recorded after a cursor, oldest first, with the GitHub ID clients key on
+SELECT a.id, a.user_id, a.notification_id, a.level, a.sound, a.source, a.source_id,
+    a.suppressed, a.created_at, n.github_id
+FROM notification_alerts a
+JOIN notifications n ON n.id = a.notification_id
+WHERE a.user_id = ? AND a.id > ?
+ORDER BY a.id
+LIMIT ?;
+
+-- name: ListRecentNotificationAlerts :many
+-- The most recent alerts, newest first, for clients that have no cursor yet
+SELECT a.id, a.user_id, a.notification_id, a.level, a.sound, a.source, a.source_id,
+    a.suppressed, a.created_at, n.github_id
+FROM notification_alerts a
+JOIN notifications n ON n.id = a.notification_id
+WHERE a.user_id = ?
+ORDER BY a.id DESC
+LIMIT ?;
+
+-- name: DeleteNotificationAlertsBefore :execrows
+DELETE FROM notification_alerts WHERE user_id = ? AND created_at < ?;
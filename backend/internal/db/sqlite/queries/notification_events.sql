@@ -0,0 +1,32 @@
+-- name: ListNotificationEvents :many
+SELECT e.* FROM notification_events e
+JOIN notifications n ON n.id = e.notification_id
+WHERE e.user_id = sqlc.arg(user_id) AND n.github_id = sqlc.arg(github_id)
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListDailyNotificationActivity :many
+-- Received counts syncs, so a notification updated twice in a day counts twice
+SELECT CAST(date(created_at) AS TEXT) AS day,
+    CAST(SUM(action = 'synced') AS INTEGER) AS received,
+    CAST(SUM(action = 'read') AS INTEGER) AS read_count,
+    CAST(SUM(action = 'archived') AS INTEGER) AS archived
+FROM notification_events
+WHERE user_id = sqlc.arg(user_id) AND created_at >= sqlc.arg(since)
+    AND action IN ('synced', 'read', 'archived')
+GROUP BY day
+ORDER BY day;
+
+-- name: GetMeanTimeToRead :one
+-- Each notification counts once: from its latest update on GitHub to the first time the
+-- user read it after that
+SELECT COUNT(*) AS notifications,
+    CAST(COALESCE(AVG(seconds), 0) AS REAL) AS mean_seconds
+FROM (
+    SELECT (julianday(MIN(e.created_at)) - julianday(n.github_updated_at)) * 86400 AS seconds
+    FROM notification_events e
+    JOIN notifications n ON n.id = e.notification_id
+    WHERE e.user_id = sqlc.arg(user_id) AND e.action = 'read' AND e.source = 'user'
+        AND e.created_at >= sqlc.arg(since) AND e.created_at >= n.github_updated_at
+    GROUP BY n.id
+);
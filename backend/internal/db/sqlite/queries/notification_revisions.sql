@@ -0,0 +1,2 @@
+-- name: GetNotificationRevision :one
+SELECT revision FROM notification_revisions WHERE user_id = ?;
@@ -13,6 +13,7 @@ UPDATE notifications SET is_read = 0 WHERE user_id = ? AND github_id = ? RETURNI
 -- name: ArchiveNotification :one
 UPDATE notifications 
 SET archived = 1,
+    archived_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'),
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
@@ -36,7 +37,9 @@ UPDATE notifications SET muted = 0 WHERE user_id = ? AND github_id = ? RETURNING
 UPDATE notifications 
 SET snoozed_until = ?,
     snoozed_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'),
-    effective_sort_date = ?
+    effective_sort_date = ?,
+    snooze_until_updated = ?,
+    returned_from_snooze_at = NULL
 WHERE user_id = ? AND github_id = ? 
 RETURNING *;
 
@@ -66,8 +69,9 @@ INSERT INTO notifications (
     subject_latest_comment_url, reason, github_unread, github_updated_at,
     github_last_read_at, github_url, github_subscription_url, payload,
     subject_raw, subject_fetched_at, author_login, author_id,
-    subject_number, subject_state, subject_merged, subject_state_reason,
-    imported_at, effective_sort_date
+    subject_number, subject_state, subject_merged, subject_state_reason, content_kind,
+    head_branch, base_branch, subject_draft, subject_labels, review_state, subject_milestone,
+    subject_projects, ci_state, subject_mergeable, waiting_on, priority, account, imported_at, effective_sort_date
 ) VALUES (
     sqlc.arg(user_id),
     sqlc.arg(github_id), 
@@ -92,6 +96,19 @@ INSERT INTO notifications (
     sqlc.narg(subject_state),
     sqlc.narg(subject_merged),
     sqlc.narg(subject_state_reason),
+    sqlc.arg(content_kind),
+    sqlc.narg(head_branch),
+    sqlc.narg(base_branch),
+    sqlc.narg(subject_draft),
+    sqlc.narg(subject_labels),
+    sqlc.narg(review_state),
+    sqlc.narg(subject_milestone),
+    sqlc.narg(subject_projects),
+    sqlc.narg(ci_state),
+    sqlc.narg(subject_mergeable),
+    sqlc.narg(waiting_on),
+    sqlc.narg(priority),
+    COALESCE(sqlc.narg(account), (SELECT github_username FROM users WHERE github_user_id = sqlc.arg(user_id))),
     strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), 
     COALESCE(sqlc.arg(effective_sort_date), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
 )
@@ -115,8 +132,47 @@ ON CONFLICT(user_id, github_id) DO UPDATE SET
     subject_state = excluded.subject_state,
     subject_merged = excluded.subject_merged,
     subject_state_reason = excluded.subject_state_reason,
+    content_kind = excluded.content_kind,
+    head_branch = excluded.head_branch,
+    base_branch = excluded.base_branch,
+    subject_draft = excluded.subject_draft,
+    subject_labels = excluded.subject_labels,
+    review_state = excluded.review_state,
+    subject_milestone = excluded.subject_milestone,
+    -- Projects come from a separate best-effort lookup, so a failed one keeps what was known
+    subject_projects = COALESCE(excluded.subject_projects, notifications.subject_projects),
+    ci_state = excluded.ci_state,
+    subject_mergeable = excluded.subject_mergeable,
+    waiting_on = excluded.waiting_on,
+    priority = excluded.priority,
+    -- Only a sync for a specific account moves a notification to that account
+    account = COALESCE(sqlc.narg(account), notifications.account),
+    -- A snooze until updated ends once GitHub reports a newer update
+    snoozed_until = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN NULL
+        ELSE notifications.snoozed_until
+    END,
+    snoozed_at = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN NULL
+        ELSE notifications.snoozed_at
+    END,
+    snooze_until_updated = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN 0
+        ELSE notifications.snooze_until_updated
+    END,
     -- Preserve snoozed_until as sort date if notification is snoozed, otherwise use new github_updated_at
-    effective_sort_date = COALESCE(notifications.snoozed_until, excluded.effective_sort_date)
+    effective_sort_date = CASE
+        WHEN notifications.snooze_until_updated = 1
+            AND excluded.github_updated_at > notifications.github_updated_at
+        THEN excluded.effective_sort_date
+        ELSE COALESCE(notifications.snoozed_until, excluded.effective_sort_date)
+    END
 RETURNING *;
 
 -- name: UpdateNotificationSubject :exec
@@ -128,10 +184,40 @@ UPDATE notifications SET
     subject_state = ?,
     subject_merged = ?,
     subject_state_reason = ?,
+    head_branch = ?,
+    base_branch = ?,
+    subject_draft = ?,
+    subject_labels = ?,
+    review_state = ?,
+    subject_milestone = ?,
+    subject_projects = COALESCE(?, subject_projects),
+    ci_state = ?,
+    subject_mergeable = ?,
+    waiting_on = ?,
+    priority = ?,
     author_login = ?,
     author_id = ?
 WHERE user_id = ? AND github_id = ?;
 
+-- name: UpdateNotificationPriority :exec
+UPDATE notifications SET priority = ? WHERE user_id = ? AND id = ?;
+
+-- name: UpdateNotificationSearchComment :exec
+-- Replaces the indexed latest comment body of a notification. Titles and subject
+-- descriptions are indexed by triggers on the notifications table.
+UPDATE notification_search SET comment = ? WHERE rowid = ?;
+
+-- name: UpdateNotificationAwaitingReply :exec
+-- Records when the account's own comment became the thread's latest, or clears it when
+-- someone else's did. A new comment starts the awaiting-reply window over.
+UPDATE notifications
+SET awaiting_reply = CASE
+        WHEN awaiting_reply_since IS sqlc.narg(awaiting_reply_since) THEN awaiting_reply
+        ELSE 0
+    END,
+    awaiting_reply_since = sqlc.narg(awaiting_reply_since)
+WHERE user_id = sqlc.arg(user_id) AND id = sqlc.arg(id);
+
 -- name: ResetNotificationStatusOnSync :exec
 -- Used by UpsertNotification to implement smart status updates.
 -- When new activity is detected (github_updated_at changed) and notification is not muted,
@@ -152,6 +238,64 @@ SELECT
 FROM notifications n
 WHERE n.user_id = ?;
 
+-- name: EscalateStaleReviewRequests :many
+-- Resurface unread review requests left untouched since the cutoff, in batches: unarchive,
+-- unsnooze and move them to the top of the inbox. The fresh effective_sort_date also makes
+-- them show up as new to the desktop notification poller.
+UPDATE notifications
+SET archived = 0,
+    snoozed_until = NULL,
+    snoozed_at = NULL,
+    effective_sort_date = sqlc.arg(escalated_at)
+WHERE id IN (
+    SELECT n.id FROM notifications n
+    WHERE n.user_id = sqlc.arg(user_id)
+      AND n.reason = 'review_requested'
+      AND n.is_read = 0
+      AND n.muted = 0
+      AND (n.subject_state IS NULL OR n.subject_state = 'open')
+      AND COALESCE(n.snoozed_at, n.effective_sort_date) < sqlc.arg(cutoff_date)
+    ORDER BY n.effective_sort_date ASC
+    LIMIT sqlc.arg(batch_size)
+)
+RETURNING id, github_id;
+
+-- name: ClassifyAwaitingReplies :many
+-- Mark threads whose own latest comment has gone unanswered since the cutoff as awaiting a
+-- reply. With resurface set, unmuted ones are also unarchived, unsnoozed and moved to the
+-- top of the inbox, like escalated review requests.
+UPDATE notifications
+SET awaiting_reply = 1,
+    archived = CASE WHEN sqlc.arg(resurface) = 1 AND muted = 0 THEN 0 ELSE archived END,
+    snoozed_until = CASE WHEN sqlc.arg(resurface) = 1 AND muted = 0 THEN NULL ELSE snoozed_until END,
+    snoozed_at = CASE WHEN sqlc.arg(resurface) = 1 AND muted = 0 THEN NULL ELSE snoozed_at END,
+    effective_sort_date = CASE
+        WHEN sqlc.arg(resurface) = 1 AND muted = 0 THEN sqlc.arg(classified_at)
+        ELSE effective_sort_date
+    END
+WHERE user_id = sqlc.arg(user_id)
+  AND awaiting_reply = 0
+  AND awaiting_reply_since IS NOT NULL
+  AND awaiting_reply_since < sqlc.arg(cutoff_date)
+RETURNING id, github_id;
+
+-- name: ReturnExpiredSnoozes :many
+-- Bring back notifications whose snooze has run out, recording when they returned and
+-- optionally marking them unread. They keep the sort date of their snooze, so they show up
+-- at the top of the inbox as of when they woke.
+UPDATE notifications
+SET snoozed_until = NULL,
+    snoozed_at = NULL,
+    snooze_until_updated = 0,
+    returned_from_snooze_at = sqlc.arg(returned_at),
+    is_read = CASE WHEN sqlc.arg(mark_unread) = 1 THEN 0 ELSE is_read END
+WHERE user_id = sqlc.arg(user_id)
+  AND snoozed_until IS NOT NULL
+  AND snoozed_until <= sqlc.arg(returned_at)
+  AND archived = 0
+  AND muted = 0
+RETURNING id, github_id;
+
 -- name: CountEligibleForCleanup :one
 -- Count notifications eligible for cleanup based on retention settings
 -- Eligible: (archived OR muted), not starred (if protected), not tagged (if protected)
@@ -183,3 +327,95 @@ WHERE id IN (
     ORDER BY COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) ASC
     LIMIT sqlc.arg(batch_size)
 );
+
+-- name: ListCleanupCandidates :many
+-- List archived/muted notifications older than the cutoff for cleanup previews
+-- Protected (starred/tagged) rows are included so callers can explain why they are skipped
+SELECT
+    n.id,
+    n.github_id,
+    n.subject_title,
+    n.subject_type,
+    r.full_name AS repo_full_name,
+    n.archived,
+    n.muted,
+    n.starred,
+    n.effective_sort_date,
+    EXISTS (
+        SELECT 1 FROM tag_assignments ta
+        WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification' AND ta.entity_id = n.id
+    ) AS tagged
+FROM notifications n
+JOIN repositories r ON r.id = n.repository_id
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < sqlc.arg(cutoff_date)
+ORDER BY COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) ASC, n.id ASC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountCleanupCandidates :one
+-- Count archived/muted notifications older than the cutoff, including protected ones
+SELECT COUNT(*) as count
+FROM notifications n
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < sqlc.arg(cutoff_date);
+
+-- name: ListEligibleForCleanupIDs :many
+-- List IDs of notifications that cleanup would delete, used to fingerprint a cleanup preview
+SELECT n.id
+FROM notifications n
+WHERE n.user_id = ?
+  AND (n.archived = 1 OR n.muted = 1)
+  AND (sqlc.arg(protect_starred) = 0 OR n.starred = 0)
+  AND (sqlc.arg(protect_tagged) = 0 OR n.id NOT IN (
+      SELECT ta.entity_id FROM tag_assignments ta WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification'
+  ))
+  AND COALESCE(n.effective_sort_date, n.github_updated_at, n.imported_at) < sqlc.arg(cutoff_date)
+ORDER BY n.id ASC;
+
+-- name: ListGithubIDsBySubjectURL :many
+-- List every notification about a subject, so a webhook delivery can update them all
+SELECT github_id FROM notifications
+WHERE user_id = ? AND subject_url = ?
+ORDER BY id;
+
+-- name: ListNotificationAuthors :many
+-- Distinct notification authors matching a LIKE pattern, most frequent first
+SELECT author_login FROM notifications
+WHERE user_id = ? AND author_login IS NOT NULL AND author_login LIKE sqlc.arg(pattern) ESCAPE '\'
+GROUP BY author_login
+ORDER BY COUNT(*) DESC, author_login
+LIMIT sqlc.arg(limit);
+
+-- name: ListNotificationLabels :many
+-- Distinct issue and pull request labels matching a LIKE pattern, most used first
+SELECT CAST(label.value AS TEXT) AS label
+FROM notifications n, json_each(n.subject_labels) AS label
+WHERE n.user_id = ? AND n.subject_labels IS NOT NULL
+  AND label.value LIKE sqlc.arg(pattern) ESCAPE '\'
+GROUP BY label.value
+ORDER BY COUNT(*) DESC, label.value
+LIMIT sqlc.arg(limit);
+
+-- name: ListUnreadGithubIDsBySubjectURL :many
+-- List unread notifications about the same subject, so a thread can be marked read together
+SELECT github_id FROM notifications
+WHERE user_id = ? AND subject_url = ? AND is_read = 0
+ORDER BY id;
+
+-- name: MarkNotificationsReadBySubjectURL :execrows
+UPDATE notifications SET is_read = 1
+WHERE user_id = ? AND subject_url = ? AND is_read = 0;
+
+-- name: ListNotificationVolume :many
+-- Notifications updated since the window start, counted by repository and reason
+SELECT r.full_name,
+    COALESCE(n.reason, '') AS reason,
+    COUNT(*) AS total,
+    CAST(SUM(n.is_read = 0) AS INTEGER) AS unread
+FROM notifications n
+JOIN repositories r ON r.id = n.repository_id
+WHERE n.user_id = ? AND COALESCE(n.github_updated_at, n.imported_at) >= sqlc.arg(since)
+GROUP BY r.full_name, n.reason
+ORDER BY r.full_name, reason;
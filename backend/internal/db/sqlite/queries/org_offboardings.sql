@@ -0,0 +1,46 @@
+-- name: ListOrgOffboardings :many
+SELECT * FROM org_offboardings WHERE user_id = ? ORDER BY created_at DESC, id DESC;
+
+-- name: IsOrgOffboarded :one
+SELECT EXISTS (
+    SELECT 1 FROM org_offboardings WHERE user_id = ? AND org = ?
+) AS offboarded;
+
+-- name: UpsertOrgOffboarding :one
+INSERT INTO org_offboardings (
+    user_id, org, action, notifications_affected, repositories
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON CONFLICT(user_id, org) DO UPDATE SET
+    action = excluded.action,
+    notifications_affected = excluded.notifications_affected,
+    repositories = excluded.repositories,
+    created_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteOrgOffboarding :execrows
+DELETE FROM org_offboardings WHERE user_id = ? AND org = ?;
+
+-- name: CountRepositoriesByOrg :one
+-- repo_pattern is the org's name followed by /%, matching every repository it owns
+SELECT COUNT(*) FROM repositories
+WHERE user_id = sqlc.arg(user_id) AND full_name LIKE sqlc.arg(repo_pattern);
+
+-- name: DeleteTagAssignmentsByOrg :exec
+DELETE FROM tag_assignments
+WHERE user_id = sqlc.arg(user_id)
+  AND entity_type = 'notification'
+  AND entity_id IN (
+      SELECT n.id FROM notifications n
+      JOIN repositories r ON r.id = n.repository_id
+      WHERE n.user_id = sqlc.arg(user_id) AND r.full_name LIKE sqlc.arg(repo_pattern)
+  );
+
+-- name: DeleteNotificationsByOrg :execrows
+DELETE FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND repository_id IN (
+      SELECT r.id FROM repositories r
+      WHERE r.user_id = sqlc.arg(user_id) AND r.full_name LIKE sqlc.arg(repo_pattern)
+  );
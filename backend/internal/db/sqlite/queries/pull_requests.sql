@@ -2,8 +2,8 @@
 INSERT INTO pull_requests (
     user_id, repository_id, github_id, node_id, number, title, state,
     draft, merged, author_login, author_id,
-    created_at, updated_at, closed_at, merged_at, raw
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    created_at, updated_at, closed_at, merged_at, raw, requested_reviewers, requested_teams
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(user_id, repository_id, number) DO UPDATE SET
     github_id = excluded.github_id,
     node_id = excluded.node_id,
@@ -16,7 +16,9 @@ ON CONFLICT(user_id, repository_id, number) DO UPDATE SET
     updated_at = excluded.updated_at,
     closed_at = excluded.closed_at,
     merged_at = excluded.merged_at,
-    raw = excluded.raw
+    raw = excluded.raw,
+    requested_reviewers = excluded.requested_reviewers,
+    requested_teams = excluded.requested_teams
 RETURNING *;
 
 -- name: DeleteOrphanedPullRequests :execrows
@@ -30,3 +32,17 @@ WHERE pull_requests.user_id = ?
     WHERE notifications.user_id = ?
       AND notifications.pull_request_id IS NOT NULL
 );
+
+-- name: ListReviewRequestReviewers :many
+-- Review requests updated since the window start, with the requested reviewers of their
+-- pull request
+SELECT
+    n.github_id,
+    n.is_read,
+    COALESCE(n.subject_state, pr.state, '') AS state,
+    COALESCE(pr.requested_reviewers, '') AS requested_reviewers
+FROM notifications n
+JOIN pull_requests pr ON pr.id = n.pull_request_id
+WHERE n.user_id = ? AND n.reason = 'review_requested'
+  AND COALESCE(n.github_updated_at, n.imported_at) >= sqlc.arg(since)
+ORDER BY n.id;
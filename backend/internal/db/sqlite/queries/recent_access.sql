@@ -0,0 +1,25 @@
+-- name: RecordRecentAccess :exec
+INSERT INTO recent_access (user_id, kind, target, accessed_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(user_id, kind, target) DO UPDATE SET
+    accessed_at = excluded.accessed_at;
+
+-- name: TrimRecentAccess :exec
+-- Keeps only the most recently accessed entries of a kind
+DELETE FROM recent_access
+WHERE user_id = sqlc.arg(user_id) AND kind = sqlc.arg(kind) AND target NOT IN (
+    SELECT target FROM recent_access
+    WHERE user_id = sqlc.arg(user_id) AND kind = sqlc.arg(kind)
+    ORDER BY accessed_at DESC
+    LIMIT sqlc.arg(keep)
+);
+
+-- name: ListRecentAccess :many
+SELECT * FROM recent_access
+WHERE user_id = ? AND kind = ?
+ORDER BY accessed_at DESC
+LIMIT ?;
+
+-- name: ClearRecentAccess :exec
+DELETE FROM recent_access
+WHERE user_id = ? AND kind = ?;
@@ -1,6 +1,12 @@
 -- name: GetRepositoryByID :one
 SELECT * FROM repositories WHERE user_id = ? AND id = ?;
 
+-- name: GetRepositoryByGithubID :one
+SELECT * FROM repositories WHERE user_id = ? AND github_id = ? ORDER BY id LIMIT 1;
+
+-- name: GetRepositoryByFullName :one
+SELECT * FROM repositories WHERE user_id = ? AND full_name = ?;
+
 -- name: ListRepositories :many
 SELECT * FROM repositories WHERE user_id = ? ORDER BY full_name;
 
@@ -31,3 +37,126 @@ ON CONFLICT(user_id, full_name) DO UPDATE SET
     owner_avatar_url = excluded.owner_avatar_url,
     owner_html_url = excluded.owner_html_url
 RETURNING *;
+
+-- name: RenameRepository :exec
+UPDATE repositories
+SET full_name = ?, name = ?, owner_login = ?
+WHERE user_id = ? AND id = ?;
+
+-- name: UpsertRepositoryAlias :exec
+INSERT INTO repository_aliases (user_id, repository_id, full_name)
+VALUES (?, ?, ?)
+ON CONFLICT(user_id, full_name) DO UPDATE SET
+    repository_id = excluded.repository_id,
+    created_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now');
+
+-- name: DeleteRepositoryAlias :exec
+DELETE FROM repository_aliases WHERE user_id = ? AND full_name = ?;
+
+-- name: RepointDuplicatePullRequestNotifications :exec
+-- Pull requests are unique per (repository, number), so when merging a duplicate repository
+-- row, notifications on a pull request the kept row also has are pointed at the kept copy.
+UPDATE notifications
+SET pull_request_id = (
+    SELECT keep.id FROM pull_requests keep
+    JOIN pull_requests dup ON dup.number = keep.number
+    WHERE keep.user_id = sqlc.arg(user_id) AND keep.repository_id = sqlc.arg(keep_id)
+      AND dup.id = notifications.pull_request_id
+)
+WHERE user_id = sqlc.arg(user_id) AND pull_request_id IN (
+    SELECT dup.id FROM pull_requests dup
+    JOIN pull_requests keep ON keep.user_id = dup.user_id AND keep.number = dup.number
+    WHERE dup.user_id = sqlc.arg(user_id) AND dup.repository_id = sqlc.arg(duplicate_id)
+      AND keep.repository_id = sqlc.arg(keep_id)
+);
+
+-- name: DeleteDuplicatePullRequests :execrows
+-- Runs after RepointDuplicatePullRequestNotifications, once nothing references these rows.
+DELETE FROM pull_requests
+WHERE user_id = sqlc.arg(user_id) AND repository_id = sqlc.arg(duplicate_id) AND number IN (
+    SELECT keep.number FROM pull_requests keep
+    WHERE keep.user_id = sqlc.arg(user_id) AND keep.repository_id = sqlc.arg(keep_id)
+);
+
+-- name: MoveRepositoryPullRequests :execrows
+UPDATE pull_requests SET repository_id = sqlc.arg(keep_id)
+WHERE user_id = sqlc.arg(user_id) AND repository_id = sqlc.arg(duplicate_id);
+
+-- name: MoveRepositoryNotifications :execrows
+UPDATE notifications SET repository_id = sqlc.arg(keep_id)
+WHERE user_id = sqlc.arg(user_id) AND repository_id = sqlc.arg(duplicate_id);
+
+-- name: MoveRepositoryAliases :exec
+UPDATE repository_aliases SET repository_id = sqlc.arg(keep_id)
+WHERE user_id = sqlc.arg(user_id) AND repository_id = sqlc.arg(duplicate_id);
+
+-- name: DeleteRepository :exec
+DELETE FROM repositories WHERE user_id = ? AND id = ?;
+
+-- name: ListRepositoryDigestRows :many
+-- Unread digest for every repository in a single scan: one row per (repository, reason)
+-- carrying that reason's count, plus the three most recently updated unread notifications.
+SELECT
+    repository_id, reason, reason_count, reason_rank, recent_rank,
+    github_id, subject_title, updated_at, oldest_unread_at, unread_count
+FROM (
+    SELECT
+        n.repository_id,
+        COALESCE(n.reason, '') AS reason,
+        COUNT(*) OVER (PARTITION BY n.repository_id, COALESCE(n.reason, '')) AS reason_count,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id, COALESCE(n.reason, '') ORDER BY n.id
+        ) AS reason_rank,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id
+            ORDER BY COALESCE(n.github_updated_at, n.imported_at) DESC, n.id DESC
+        ) AS recent_rank,
+        n.github_id,
+        n.subject_title,
+        COALESCE(n.github_updated_at, n.imported_at) AS updated_at,
+        MIN(COALESCE(n.github_updated_at, n.imported_at))
+            OVER (PARTITION BY n.repository_id) AS oldest_unread_at,
+        COUNT(*) OVER (PARTITION BY n.repository_id) AS unread_count
+    FROM notifications n
+    WHERE n.user_id = ? AND n.is_read = 0 AND n.archived = 0 AND n.muted = 0
+) AS unread
+WHERE reason_rank = 1 OR recent_rank <= 3
+ORDER BY repository_id, recent_rank;
+
+-- name: ListRepositorySubjectTypeCounts :many
+-- How many notifications each repository has of each subject type, read or not.
+SELECT repository_id, subject_type, COUNT(*) AS notification_count
+FROM notifications
+WHERE user_id = ?
+GROUP BY repository_id, subject_type
+ORDER BY repository_id, subject_type;
+
+-- name: GetRepositoryDigestRows :many
+-- Same as ListRepositoryDigestRows, restricted to a single repository.
+SELECT
+    repository_id, reason, reason_count, reason_rank, recent_rank,
+    github_id, subject_title, updated_at, oldest_unread_at, unread_count
+FROM (
+    SELECT
+        n.repository_id,
+        COALESCE(n.reason, '') AS reason,
+        COUNT(*) OVER (PARTITION BY n.repository_id, COALESCE(n.reason, '')) AS reason_count,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id, COALESCE(n.reason, '') ORDER BY n.id
+        ) AS reason_rank,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id
+            ORDER BY COALESCE(n.github_updated_at, n.imported_at) DESC, n.id DESC
+        ) AS recent_rank,
+        n.github_id,
+        n.subject_title,
+        COALESCE(n.github_updated_at, n.imported_at) AS updated_at,
+        MIN(COALESCE(n.github_updated_at, n.imported_at))
+            OVER (PARTITION BY n.repository_id) AS oldest_unread_at,
+        COUNT(*) OVER (PARTITION BY n.repository_id) AS unread_count
+    FROM notifications n
+    WHERE n.user_id = ? AND n.repository_id = ?
+        AND n.is_read = 0 AND n.archived = 0 AND n.muted = 0
+) AS unread
+WHERE reason_rank = 1 OR recent_rank <= 3
+ORDER BY recent_rank;
@@ -0,0 +1,22 @@
+-- name: GetRepositorySettings :one
+SELECT * FROM repository_settings WHERE user_id = ? AND repository_id = ?;
+
+-- name: UpsertRepositorySettings :one
+INSERT INTO repository_settings (
+    user_id, repository_id, default_snooze, resurface_suppression_minutes, sync_mode, updated_at
+)
+VALUES (?1, ?2, ?3, ?4, ?5, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+ON CONFLICT(user_id, repository_id) DO UPDATE SET
+    default_snooze = excluded.default_snooze,
+    resurface_suppression_minutes = excluded.resurface_suppression_minutes,
+    sync_mode = excluded.sync_mode,
+    updated_at = excluded.updated_at
+RETURNING *;
+
+-- name: MoveRepositorySettings :exec
+-- Settings already saved for the kept repository win over the duplicate's.
+UPDATE OR IGNORE repository_settings SET repository_id = sqlc.arg(keep_id)
+WHERE user_id = sqlc.arg(user_id) AND repository_id = sqlc.arg(duplicate_id);
+
+-- name: DeleteRepositorySettings :exec
+DELETE FROM repository_settings WHERE user_id = ? AND repository_id = ?;
@@ -0,0 +1,17 @@
+-- name: UpsertRuleEvaluation :exec
+-- Replaces the notification's previous evaluation, so only the latest is kept
+INSERT INTO rule_evaluations (user_id, notification_id, trace, evaluated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (notification_id) DO UPDATE SET
+    trace = excluded.trace,
+    evaluated_at = excluded.evaluated_at;
+
+-- name: ListRuleEvaluations :many
+-- The most recent evaluations, newest first, optionally for a single notification
+SELECT e.id, e.user_id, e.notification_id, e.trace, e.evaluated_at, n.github_id, n.subject_title
+FROM rule_evaluations e
+JOIN notifications n ON n.id = e.notification_id
+WHERE e.user_id = sqlc.arg(user_id)
+    AND (sqlc.arg(github_id) = '' OR n.github_id = sqlc.arg(github_id))
+ORDER BY e.evaluated_at DESC, e.id DESC
+LIMIT sqlc.arg(limit);
@@ -2,17 +2,18 @@
 SELECT * FROM rules WHERE user_id = ? AND id = ?;
 
 -- name: ListRules :many
-SELECT * FROM rules WHERE user_id = ? ORDER BY display_order, name;
+SELECT * FROM rules WHERE user_id = ? ORDER BY priority DESC, display_order, name;
 
 -- name: ListEnabledRulesOrdered :many
-SELECT * FROM rules WHERE user_id = ? AND enabled = 1 ORDER BY display_order;
+-- Enabled rules in the order they run: highest priority first, then display order
+SELECT * FROM rules WHERE user_id = ? AND enabled = 1 ORDER BY priority DESC, display_order;
 
 -- name: GetRulesByViewID :many
 SELECT * FROM rules WHERE user_id = ? AND view_id = ? ORDER BY display_order;
 
 -- name: CreateRule :one
-INSERT INTO rules (user_id, name, description, query, view_id, enabled, actions, display_order, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+INSERT INTO rules (user_id, name, description, query, view_id, enabled, actions, display_order, priority, stop_processing, condition, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
 RETURNING *;
 
 -- name: UpdateRule :one
@@ -23,6 +24,9 @@ UPDATE rules SET
     view_id = ?,
     enabled = COALESCE(?, enabled),
     actions = COALESCE(?, actions),
+    priority = COALESCE(?, priority),
+    stop_processing = COALESCE(?, stop_processing),
+    condition = NULLIF(COALESCE(?, condition), ''),
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
 WHERE user_id = ? AND id = ?
 RETURNING *;
@@ -32,3 +36,31 @@ DELETE FROM rules WHERE user_id = ? AND id = ?;
 
 -- name: UpdateRuleOrder :exec
 UPDATE rules SET display_order = ? WHERE user_id = ? AND id = ?;
+
+-- name: GetRulesByTagID :many
+-- Rules whose actions assign or remove the tag
+SELECT * FROM rules
+WHERE user_id = sqlc.arg(user_id) AND (
+    EXISTS (SELECT 1 FROM json_each(rules.actions, '$.assignTags') WHERE json_each.value = sqlc.arg(tag_id))
+    OR EXISTS (SELECT 1 FROM json_each(rules.actions, '$.removeTags') WHERE json_each.value = sqlc.arg(tag_id))
+)
+ORDER BY display_order;
+
+-- name: DisableRulesByTagID :many
+-- Disables rules whose actions assign or remove the tag, before the tag is deleted
+UPDATE rules SET enabled = 0, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+WHERE user_id = sqlc.arg(user_id) AND (
+    EXISTS (SELECT 1 FROM json_each(rules.actions, '$.assignTags') WHERE json_each.value = sqlc.arg(tag_id))
+    OR EXISTS (SELECT 1 FROM json_each(rules.actions, '$.removeTags') WHERE json_each.value = sqlc.arg(tag_id))
+)
+RETURNING *;
+
+-- name: DisableRulesByViewID :many
+-- Disables rules linked to the view, before the view is deleted. The view's query is copied
+-- onto each rule so it still has one once view_id is cleared and can be re-enabled later.
+UPDATE rules SET
+    enabled = 0,
+    query = COALESCE(query, (SELECT views.query FROM views WHERE views.id = rules.view_id)),
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+WHERE user_id = ? AND view_id = ?
+RETURNING *;
@@ -0,0 +1,15 @@
+-- name: ListSavedReplies :many
+SELECT * FROM saved_replies WHERE user_id = ? ORDER BY title COLLATE NOCASE, id;
+
+-- name: GetSavedReply :one
+SELECT * FROM saved_replies WHERE user_id = ? AND github_id = ?;
+
+-- name: DeleteSavedReplies :exec
+DELETE FROM saved_replies WHERE user_id = ?;
+
+-- name: InsertSavedReply :exec
+INSERT INTO saved_replies (
+    user_id, github_id, title, body, fetched_at
+) VALUES (
+    ?, ?, ?, ?, ?
+);
@@ -17,3 +17,23 @@ ON CONFLICT(user_id) DO UPDATE SET
     oldest_notification_synced_at = COALESCE(excluded.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
 RETURNING *;
+
+-- name: SaveInitialSyncProgress :exec
+-- Records a page of the initial sync once its notifications are queued. The start time
+-- is kept from the first page and the latest notification from the newest page.
+INSERT INTO sync_state (
+    user_id, latest_notification_at, oldest_notification_synced_at,
+    initial_sync_started_at, initial_sync_pages, initial_sync_queued,
+    created_at, updated_at
+) VALUES (
+    sqlc.arg(user_id), sqlc.narg(latest_notification_at), sqlc.narg(oldest_notification_synced_at),
+    sqlc.arg(started_at), sqlc.arg(pages), sqlc.arg(queued),
+    strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+)
+ON CONFLICT(user_id) DO UPDATE SET
+    latest_notification_at = COALESCE(sync_state.latest_notification_at, excluded.latest_notification_at),
+    oldest_notification_synced_at = COALESCE(excluded.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
+    initial_sync_started_at = COALESCE(sync_state.initial_sync_started_at, excluded.initial_sync_started_at),
+    initial_sync_pages = excluded.initial_sync_pages,
+    initial_sync_queued = excluded.initial_sync_queued,
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now');
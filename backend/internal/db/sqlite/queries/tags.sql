@@ -4,6 +4,9 @@ SELECT * FROM tags WHERE user_id = ? AND id = ?;
 -- name: GetTagByName :one
 SELECT * FROM tags WHERE user_id = ? AND name = ?;
 
+-- name: GetTagBySlug :one
+SELECT * FROM tags WHERE user_id = ? AND slug = ?;
+
 -- name: ListAllTags :many
 SELECT * FROM tags WHERE user_id = ? ORDER BY display_order, name;
 
@@ -32,7 +35,7 @@ DELETE FROM tags WHERE user_id = ? AND id = ?;
 UPDATE tags SET display_order = ? WHERE user_id = ? AND id = ?;
 
 -- name: ListTagsForEntity :many
-SELECT t.* FROM tags t
+SELECT t.*, ta.created_at AS assigned_at FROM tags t
 JOIN tag_assignments ta ON t.id = ta.tag_id
 WHERE t.user_id = ? AND ta.entity_type = ? AND ta.entity_id = ?
 ORDER BY t.display_order, t.name;
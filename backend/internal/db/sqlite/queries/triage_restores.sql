@@ -0,0 +1,111 @@
+-- name: ListTriageStatesAfter :many
+-- Notifications with any triage state worth keeping, with the names of their tags, in
+-- pages ordered by GitHub ID
+SELECT
+    n.github_id,
+    n.is_read,
+    n.archived,
+    n.starred,
+    n.muted,
+    n.snoozed_until,
+    CAST((
+        SELECT json_group_array(t.name)
+        FROM tag_assignments ta
+        JOIN tags t ON t.id = ta.tag_id
+        WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification' AND ta.entity_id = n.id
+    ) AS TEXT) AS tag_names
+FROM notifications n
+WHERE n.user_id = ? AND n.github_id > ?
+  AND (
+    n.is_read = 1 OR n.archived = 1 OR n.starred = 1 OR n.muted = 1
+    OR n.snoozed_until IS NOT NULL
+    OR EXISTS (
+        SELECT 1 FROM tag_assignments ta
+        WHERE ta.user_id = n.user_id AND ta.entity_type = 'notification' AND ta.entity_id = n.id
+    )
+  )
+ORDER BY n.github_id
+LIMIT ?;
+
+-- name: UpsertTriageRestore :exec
+INSERT INTO triage_restores (
+    user_id, github_id, is_read, archived, starred, muted, snoozed_until, tag_ids, staged_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+)
+ON CONFLICT(user_id, github_id) DO UPDATE SET
+    is_read = excluded.is_read,
+    archived = excluded.archived,
+    starred = excluded.starred,
+    muted = excluded.muted,
+    snoozed_until = excluded.snoozed_until,
+    tag_ids = excluded.tag_ids,
+    staged_at = excluded.staged_at;
+
+-- name: ListMatchedTriageRestores :many
+-- Staged triage state for notifications that exist locally
+SELECT r.github_id, r.is_read, r.archived, r.starred, r.muted, r.snoozed_until, r.tag_ids,
+    n.id AS notification_id
+FROM triage_restores r
+JOIN notifications n ON n.user_id = r.user_id AND n.github_id = r.github_id
+WHERE r.user_id = ?
+ORDER BY r.github_id;
+
+-- name: GetMatchedTriageRestore :one
+SELECT r.github_id, r.is_read, r.archived, r.starred, r.muted, r.snoozed_until, r.tag_ids,
+    n.id AS notification_id
+FROM triage_restores r
+JOIN notifications n ON n.user_id = r.user_id AND n.github_id = r.github_id
+WHERE r.user_id = ? AND r.github_id = ?;
+
+-- name: MergeNotificationTriage :exec
+-- Merges restored triage state into a notification without clearing anything already set.
+-- A restored snooze is only applied while it is still in the future and later than the
+-- notification's own snooze.
+UPDATE notifications SET
+    is_read = MAX(is_read, sqlc.arg(is_read)),
+    archived = MAX(archived, sqlc.arg(archived)),
+    starred = MAX(starred, sqlc.arg(starred)),
+    muted = MAX(muted, sqlc.arg(muted)),
+    snoozed_at = CASE
+        WHEN sqlc.narg(snoozed_until) > strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+            AND (snoozed_until IS NULL OR snoozed_until < sqlc.narg(snoozed_until))
+        THEN strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+        ELSE snoozed_at
+    END,
+    effective_sort_date = CASE
+        WHEN sqlc.narg(snoozed_until) > strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+            AND (snoozed_until IS NULL OR snoozed_until < sqlc.narg(snoozed_until))
+        THEN sqlc.narg(snoozed_until)
+        ELSE effective_sort_date
+    END,
+    snooze_until_updated = CASE
+        WHEN sqlc.narg(snoozed_until) > strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+            AND (snoozed_until IS NULL OR snoozed_until < sqlc.narg(snoozed_until))
+        THEN 0
+        ELSE snooze_until_updated
+    END,
+    snoozed_until = CASE
+        WHEN sqlc.narg(snoozed_until) > strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+            AND (snoozed_until IS NULL OR snoozed_until < sqlc.narg(snoozed_until))
+        THEN sqlc.narg(snoozed_until)
+        ELSE snoozed_until
+    END
+WHERE user_id = sqlc.arg(user_id) AND id = sqlc.arg(id);
+
+-- name: AssignExistingTagToNotification :exec
+-- Assigns a tag by ID, doing nothing if the tag has since been deleted
+INSERT INTO tag_assignments (user_id, tag_id, entity_type, entity_id, created_at)
+SELECT t.user_id, t.id, 'notification', sqlc.arg(entity_id), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+FROM tags t
+WHERE t.user_id = sqlc.arg(user_id) AND t.id = sqlc.arg(tag_id)
+ON CONFLICT(user_id, tag_id, entity_type, entity_id) DO NOTHING;
+
+-- name: DeleteTriageRestore :exec
+DELETE FROM triage_restores WHERE user_id = ? AND github_id = ?;
+
+-- name: DeleteTriageRestores :execrows
+DELETE FROM triage_restores WHERE user_id = ?;
+
+-- name: CountTriageRestores :one
+SELECT COUNT(*) FROM triage_restores WHERE user_id = ?;
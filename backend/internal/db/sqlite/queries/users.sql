@@ -37,3 +37,27 @@ UPDATE users SET muted_until = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'n
 
 -- name: UpdateUserUpdateSettings :one
 UPDATE users SET update_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserEscalationSettings :one
+UPDATE users SET escalation_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserWorkingHours :one
+UPDATE users SET working_hours = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserTeamSettings :one
+UPDATE users SET team_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserAlertSettings :one
+UPDATE users SET alert_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserBackupSettings :one
+UPDATE users SET backup_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserSnoozeSettings :one
+UPDATE users SET snooze_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserPrioritySettings :one
+UPDATE users SET priority_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
+
+-- name: UpdateUserDigestSettings :one
+UPDATE users SET digest_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING *;
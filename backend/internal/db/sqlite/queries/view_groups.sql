@@ -0,0 +1,24 @@
+-- name: ListViewGroups :many
+SELECT * FROM view_groups WHERE user_id = ? ORDER BY display_order, name;
+
+-- name: GetViewGroup :one
+SELECT * FROM view_groups WHERE user_id = ? AND id = ?;
+
+-- name: CreateViewGroup :one
+INSERT INTO view_groups (user_id, name, display_order, created_at)
+SELECT sqlc.arg(user_id), sqlc.arg(name), COALESCE(MAX(display_order), 0) + 100, strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+FROM view_groups WHERE user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: UpdateViewGroup :one
+UPDATE view_groups SET
+    name = COALESCE(?, name),
+    collapsed = COALESCE(?, collapsed)
+WHERE user_id = ? AND id = ?
+RETURNING *;
+
+-- name: UpdateViewGroupOrder :exec
+UPDATE view_groups SET display_order = ? WHERE user_id = ? AND id = ?;
+
+-- name: DeleteViewGroup :execrows
+DELETE FROM view_groups WHERE user_id = ? AND id = ?;
@@ -0,0 +1,23 @@
+-- name: ListViewShares :many
+SELECT * FROM view_shares WHERE user_id = ? AND view_id = ? ORDER BY grantee;
+
+-- name: ListViewSharesByGrantee :many
+SELECT * FROM view_shares WHERE grantee = ? ORDER BY created_at, view_id;
+
+-- name: GetViewShare :one
+SELECT * FROM view_shares WHERE view_id = ? AND grantee = ?;
+
+-- name: UpsertViewShare :one
+INSERT INTO view_shares (
+    user_id, view_id, grantee, permission, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON CONFLICT (view_id, grantee) DO UPDATE SET permission = excluded.permission
+RETURNING *;
+
+-- name: DeleteViewShare :execrows
+DELETE FROM view_shares WHERE user_id = ? AND view_id = ? AND grantee = ?;
+
+-- name: DeleteViewShareByGrantee :execrows
+DELETE FROM view_shares WHERE view_id = ? AND grantee = ?;
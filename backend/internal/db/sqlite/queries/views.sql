@@ -20,8 +20,20 @@ UPDATE views SET
 WHERE user_id = ? AND id = ?
 RETURNING *;
 
+-- name: UpdateViewWIPLimit :one
+UPDATE views SET wip_limit = ?, wip_auto_snooze = ?
+WHERE user_id = ? AND id = ?
+RETURNING *;
+
 -- name: DeleteView :execrows
 DELETE FROM views WHERE user_id = ? AND id = ?;
 
 -- name: UpdateViewOrder :exec
 UPDATE views SET display_order = ? WHERE user_id = ? AND id = ?;
+
+-- name: SetViewGroup :one
+UPDATE views SET group_id = ? WHERE user_id = ? AND id = ?
+RETURNING *;
+
+-- name: UngroupViews :exec
+UPDATE views SET group_id = NULL WHERE user_id = ? AND group_id = ?;
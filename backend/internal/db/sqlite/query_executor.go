@@ -19,6 +19,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
@@ -27,6 +28,12 @@ import (
 // notificationColumns returns the list of all notification table columns for SQLite.
 // Note: SQLite doesn't have tag_ids column - we use a junction table instead.
 func notificationColumns(includeSubject bool) string {
+	return "SELECT " + strings.Join(notificationColumnList(includeSubject), ", ") + " FROM notifications n"
+}
+
+// notificationColumnList returns the notification table columns in the order
+// notificationScanTargets scans them.
+func notificationColumnList(includeSubject bool) []string {
 	columns := []string{
 		"n.id",
 		"n.user_id",
@@ -60,6 +67,24 @@ func notificationColumns(includeSubject bool) string {
 		"n.subject_state",
 		"n.subject_merged",
 		"n.subject_state_reason",
+		"n.content_kind",
+		"n.head_branch",
+		"n.base_branch",
+		"n.account",
+		"n.archived_at",
+		"n.awaiting_reply_since",
+		"n.awaiting_reply",
+		"n.subject_draft",
+		"n.subject_labels",
+		"n.review_state",
+		"n.snooze_until_updated",
+		"n.returned_from_snooze_at",
+		"n.subject_milestone",
+		"n.subject_projects",
+		"n.ci_state",
+		"n.subject_mergeable",
+		"n.waiting_on",
+		"n.priority",
 	}
 
 	if includeSubject {
@@ -67,7 +92,69 @@ func notificationColumns(includeSubject bool) string {
 		columns = append(columns[:18], append([]string{"n.subject_raw"}, columns[18:]...)...)
 	}
 
-	return "SELECT " + strings.Join(columns, ", ") + " FROM notifications n"
+	return columns
+}
+
+// notificationScanTargets returns the scan destinations for the columns of notificationColumnList.
+func notificationScanTargets(n *Notification, includeSubject bool) []any {
+	targets := []any{
+		&n.ID,
+		&n.UserID,
+		&n.GithubID,
+		&n.RepositoryID,
+		&n.PullRequestID,
+		&n.SubjectType,
+		&n.SubjectTitle,
+		&n.SubjectUrl,
+		&n.SubjectLatestCommentUrl,
+		&n.Reason,
+		&n.Archived,
+		&n.GithubUnread,
+		&n.GithubUpdatedAt,
+		&n.GithubLastReadAt,
+		&n.GithubUrl,
+		&n.GithubSubscriptionUrl,
+		&n.ImportedAt,
+		&n.Payload,
+		&n.SubjectFetchedAt,
+		&n.AuthorLogin,
+		&n.AuthorID,
+		&n.IsRead,
+		&n.Muted,
+		&n.SnoozedUntil,
+		&n.EffectiveSortDate,
+		&n.SnoozedAt,
+		&n.Starred,
+		&n.Filtered,
+		&n.SubjectNumber,
+		&n.SubjectState,
+		&n.SubjectMerged,
+		&n.SubjectStateReason,
+		&n.ContentKind,
+		&n.HeadBranch,
+		&n.BaseBranch,
+		&n.Account,
+		&n.ArchivedAt,
+		&n.AwaitingReplySince,
+		&n.AwaitingReply,
+		&n.SubjectDraft,
+		&n.SubjectLabels,
+		&n.ReviewState,
+		&n.SnoozeUntilUpdated,
+		&n.ReturnedFromSnoozeAt,
+		&n.SubjectMilestone,
+		&n.SubjectProjects,
+		&n.CiState,
+		&n.SubjectMergeable,
+		&n.WaitingOn,
+		&n.Priority,
+	}
+
+	// For convenience, add subject_raw if requested
+	if includeSubject {
+		targets = append(targets[:18], append([]any{&n.SubjectRaw}, targets[18:]...)...)
+	}
+	return targets
 }
 
 // listNotificationsFromQuery executes a dynamic notification query for SQLite.
@@ -77,6 +164,117 @@ func listNotificationsFromQuery(
 	userID string,
 	query db.NotificationQuery,
 ) (db.ListNotificationsFromQueryResult, error) {
+	if query.CollapseBySubject {
+		return listConversationsFromQuery(ctx, s, userID, query)
+	}
+
+	list := notificationListSQL(userID, query)
+
+	// Execute query
+	var rows *sql.Rows
+	err := db.RetryVoidOnBusy(ctx, func() error {
+		var queryErr error
+		rows, queryErr = s.dbConn.QueryContext(ctx, list.selectQuery, list.selectArgs...)
+		return queryErr
+	})
+	if err != nil {
+		return db.ListNotificationsFromQueryResult{}, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			// Error closing rows - log if we had a logger, but can't return it
+			_ = closeErr
+		}
+	}()
+
+	var page []Notification
+	for rows.Next() {
+		var n Notification
+		if scanErr := rows.Scan(notificationScanTargets(&n, query.IncludeSubject)...); scanErr != nil {
+			return db.ListNotificationsFromQueryResult{}, fmt.Errorf(
+				"failed to scan notification: %w",
+				scanErr,
+			)
+		}
+		page = append(page, n)
+	}
+
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return db.ListNotificationsFromQueryResult{},
+			fmt.Errorf("error iterating rows: %w", rowsErr)
+	}
+	notifications := s.toDBNotifications(ctx, userID, page)
+
+	// Get total count
+	var total int64
+	err = db.RetryVoidOnBusy(ctx, func() error {
+		return s.dbConn.QueryRowContext(ctx, list.countQuery, list.countArgs...).Scan(&total)
+	})
+	if err != nil {
+		return db.ListNotificationsFromQueryResult{}, fmt.Errorf("failed to get count: %w", err)
+	}
+
+	return db.ListNotificationsFromQueryResult{
+		Notifications: notifications,
+		Total:         total,
+		NextCursor:    nextCursor(page, query.Limit),
+	}, nil
+}
+
+// explainNotificationQuery returns the statement listNotificationsFromQuery runs to list a
+// page of a query's notifications, and SQLite's plan for it. Queries collapsed by subject
+// are explained as the plain list.
+func explainNotificationQuery(
+	ctx context.Context,
+	s *Store,
+	userID string,
+	query db.NotificationQuery,
+) (db.NotificationQueryPlan, error) {
+	list := notificationListSQL(userID, query)
+
+	var steps []db.QueryPlanStep
+	err := db.RetryVoidOnBusy(ctx, func() error {
+		steps = nil
+		rows, queryErr := s.dbConn.QueryContext(ctx, "EXPLAIN QUERY PLAN "+list.selectQuery, list.selectArgs...)
+		if queryErr != nil {
+			return queryErr
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		for rows.Next() {
+			var step db.QueryPlanStep
+			var notUsed int64
+			if scanErr := rows.Scan(&step.ID, &step.Parent, &notUsed, &step.Detail); scanErr != nil {
+				return fmt.Errorf("failed to scan query plan: %w", scanErr)
+			}
+			steps = append(steps, step)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return db.NotificationQueryPlan{}, err
+	}
+
+	return db.NotificationQueryPlan{
+		SQL:   list.selectQuery,
+		Args:  list.selectArgs,
+		Steps: steps,
+	}, nil
+}
+
+// listSQL is the statement listing a page of notifications and the one counting every
+// match, with the arguments bound to each
+type listSQL struct {
+	selectQuery string
+	selectArgs  []interface{}
+	countQuery  string
+	countArgs   []interface{}
+}
+
+// notificationListSQL builds the statements listNotificationsFromQuery runs for a query
+// that isn't collapsed by subject
+func notificationListSQL(userID string, query db.NotificationQuery) listSQL {
 	// Build the SELECT query - conditionally exclude subject_raw to reduce data transfer
 	baseSelect := notificationColumns(query.IncludeSubject)
 
@@ -95,94 +293,165 @@ func listNotificationsFromQuery(
 	}
 	where := " WHERE " + strings.Join(whereConditions, " AND ")
 
-	// Add ORDER BY
-	orderBy := " ORDER BY n.effective_sort_date DESC, n.imported_at DESC"
+	// Add ORDER BY. The ID breaks ties so notifications have a stable position for cursors.
+	direction := "DESC"
+	if query.Reverse {
+		direction = "ASC"
+	}
+	orderBy := fmt.Sprintf(
+		" ORDER BY n.effective_sort_date %[1]s, n.imported_at %[1]s, n.id %[1]s",
+		direction,
+	)
+	if query.ByPriority {
+		orderBy = fmt.Sprintf(
+			" ORDER BY COALESCE(n.priority, 0) %[1]s, n.effective_sort_date %[1]s, n.imported_at %[1]s, n.id %[1]s",
+			direction,
+		)
+	}
 
-	// Add LIMIT and OFFSET
-	limitOffset := fmt.Sprintf(" LIMIT %d OFFSET %d", query.Limit, query.Offset)
+	// Add LIMIT and OFFSET, or start after the cursor. The total still counts every match.
+	pageWhere, pageArgs := where, args
+	offset := query.Offset
+	if query.After != nil {
+		condition, cursorArgs := cursorCondition("n.", *query.After, query.Reverse, query.ByPriority)
+		pageWhere += " AND " + condition
+		pageArgs = append(append([]interface{}{}, args...), cursorArgs...)
+		offset = 0
+	}
+	limitOffset := fmt.Sprintf(" LIMIT %d OFFSET %d", query.Limit, offset)
 
-	// Combine everything
-	selectQuery := baseSelect + joins + where + orderBy + limitOffset
+	return listSQL{
+		selectQuery: baseSelect + joins + pageWhere + orderBy + limitOffset,
+		selectArgs:  pageArgs,
+		countQuery:  "SELECT COUNT(*) FROM notifications n" + joins + where,
+		countArgs:   args,
+	}
+}
+
+// cursorCondition selects the notifications listed after the cursor, comparing the columns
+// the list is ordered by. prefix qualifies the columns, such as "n.". Unscored notifications
+// count as zero when listed by priority.
+func cursorCondition(
+	prefix string,
+	cursor db.NotificationCursor,
+	reverse bool,
+	byPriority bool,
+) (string, []interface{}) {
+	op := "<"
+	if reverse {
+		op = ">"
+	}
+	if byPriority {
+		condition := fmt.Sprintf(
+			"(COALESCE(%[1]spriority, 0), %[1]seffective_sort_date, %[1]simported_at, %[1]sid) %[2]s (?, ?, ?, ?)",
+			prefix,
+			op,
+		)
+		return condition, []interface{}{cursor.Priority, cursor.SortDate, cursor.ImportedAt, cursor.ID}
+	}
+	condition := fmt.Sprintf("(%[1]seffective_sort_date, %[1]simported_at, %[1]sid) %[2]s (?, ?, ?)", prefix, op)
+	return condition, []interface{}{cursor.SortDate, cursor.ImportedAt, cursor.ID}
+}
+
+// nextCursor returns the position of the last notification on a page, or nil when the page
+// isn't full and so is the last one
+func nextCursor(page []Notification, limit int32) *db.NotificationCursor {
+	if len(page) == 0 || len(page) < int(limit) {
+		return nil
+	}
+	last := page[len(page)-1]
+	return &db.NotificationCursor{
+		SortDate:   last.EffectiveSortDate,
+		ImportedAt: last.ImportedAt,
+		ID:         last.ID,
+		Priority:   last.Priority.Int64,
+	}
+}
+
+// conversationKeyExpr is what notifications are collapsed into conversations by. Notifications
+// without a subject URL are a conversation of their own.
+const conversationKeyExpr = "COALESCE(n.subject_url, n.github_id)"
+
+// listConversationsFromQuery executes a dynamic notification query keeping only the newest
+// notification of each subject, along with how many matching notifications share it.
+func listConversationsFromQuery(
+	ctx context.Context,
+	s *Store,
+	userID string,
+	query db.NotificationQuery,
+) (db.ListNotificationsFromQueryResult, error) {
+	joins := ""
+	if len(query.Joins) > 0 {
+		joins = " " + strings.Join(query.Joins, " ")
+	}
+
+	whereConditions := []string{"n.user_id = ?"}
+	args := []interface{}{userID}
+	args = append(args, query.Args...)
+	if len(query.Where) > 0 {
+		whereConditions = append(whereConditions, query.Where...)
+	}
+	where := " WHERE " + strings.Join(whereConditions, " AND ")
+
+	// Conversations are paged by their newest notification's position
+	outerWhere, pageArgs := "conversation_rank = 1", args
+	offset := query.Offset
+	if query.After != nil {
+		condition, cursorArgs := cursorCondition("", *query.After, false, false)
+		outerWhere += " AND " + condition
+		pageArgs = append(append([]interface{}{}, args...), cursorArgs...)
+		offset = 0
+	}
+
+	// Rank each subject's notifications in list order and keep the first of each
+	//nolint:gosec // G201: SQL string formatting is safe - joins and where are controlled
+	selectQuery := fmt.Sprintf(
+		"SELECT * FROM (SELECT %s, ROW_NUMBER() OVER (PARTITION BY %s "+
+			"ORDER BY n.effective_sort_date DESC, n.imported_at DESC, n.id DESC) AS conversation_rank, "+
+			"COUNT(*) OVER (PARTITION BY %s) AS conversation_size "+
+			"FROM notifications n%s%s) WHERE %s "+
+			"ORDER BY effective_sort_date DESC, imported_at DESC, id DESC LIMIT %d OFFSET %d",
+		strings.Join(notificationColumnList(query.IncludeSubject), ", "),
+		conversationKeyExpr, conversationKeyExpr, joins, where, outerWhere, query.Limit, offset,
+	)
 
-	// Execute query
 	var rows *sql.Rows
 	err := db.RetryVoidOnBusy(ctx, func() error {
 		var queryErr error
-		rows, queryErr = s.dbConn.QueryContext(ctx, selectQuery, args...)
+		rows, queryErr = s.dbConn.QueryContext(ctx, selectQuery, pageArgs...)
 		return queryErr
 	})
 	if err != nil {
 		return db.ListNotificationsFromQueryResult{}, err
 	}
 	defer func() {
-		if closeErr := rows.Close(); closeErr != nil {
-			// Error closing rows - log if we had a logger, but can't return it
-			_ = closeErr
-		}
+		_ = rows.Close()
 	}()
 
-	var notifications []db.Notification
+	var page []Notification
+	sizes := make(map[int64]int64)
 	for rows.Next() {
-		var n Notification
-		// Column order must match notificationColumns() function above
-		scanColumns := []any{
-			&n.ID,
-			&n.UserID,
-			&n.GithubID,
-			&n.RepositoryID,
-			&n.PullRequestID,
-			&n.SubjectType,
-			&n.SubjectTitle,
-			&n.SubjectUrl,
-			&n.SubjectLatestCommentUrl,
-			&n.Reason,
-			&n.Archived,
-			&n.GithubUnread,
-			&n.GithubUpdatedAt,
-			&n.GithubLastReadAt,
-			&n.GithubUrl,
-			&n.GithubSubscriptionUrl,
-			&n.ImportedAt,
-			&n.Payload,
-			&n.SubjectFetchedAt,
-			&n.AuthorLogin,
-			&n.AuthorID,
-			&n.IsRead,
-			&n.Muted,
-			&n.SnoozedUntil,
-			&n.EffectiveSortDate,
-			&n.SnoozedAt,
-			&n.Starred,
-			&n.Filtered,
-			&n.SubjectNumber,
-			&n.SubjectState,
-			&n.SubjectMerged,
-			&n.SubjectStateReason,
-		}
-
-		// For convenience, add subject_raw if requested
-		if query.IncludeSubject {
-			scanColumns = append(
-				scanColumns[:18],
-				append([]any{&n.SubjectRaw}, scanColumns[18:]...)...)
-		}
-
-		if scanErr := rows.Scan(scanColumns...); scanErr != nil {
+		var (
+			n          Notification
+			rank, size int64
+		)
+		targets := append(notificationScanTargets(&n, query.IncludeSubject), &rank, &size)
+		if scanErr := rows.Scan(targets...); scanErr != nil {
 			return db.ListNotificationsFromQueryResult{}, fmt.Errorf(
 				"failed to scan notification: %w",
 				scanErr,
 			)
 		}
-		notifications = append(notifications, s.toDBNotification(ctx, userID, n))
+		page = append(page, n)
+		sizes[n.ID] = size
 	}
-
 	if rowsErr := rows.Err(); rowsErr != nil {
 		return db.ListNotificationsFromQueryResult{},
 			fmt.Errorf("error iterating rows: %w", rowsErr)
 	}
+	notifications := s.toDBNotifications(ctx, userID, page)
 
-	// Get total count
-	countQuery := "SELECT COUNT(*) FROM notifications n" + joins + where
+	countQuery := "SELECT COUNT(DISTINCT " + conversationKeyExpr + ") FROM notifications n" + joins + where
 	var total int64
 	err = db.RetryVoidOnBusy(ctx, func() error {
 		return s.dbConn.QueryRowContext(ctx, countQuery, args...).Scan(&total)
@@ -192,11 +461,209 @@ func listNotificationsFromQuery(
 	}
 
 	return db.ListNotificationsFromQueryResult{
-		Notifications: notifications,
-		Total:         total,
+		Notifications:     notifications,
+		Total:             total,
+		ConversationSizes: sizes,
+		NextCursor:        nextCursor(page, query.Limit),
 	}, nil
 }
 
+// listNotificationGithubIDsFromQuery returns the GitHub IDs of every notification matching a
+// query, in list order. Limit and offset are ignored so callers see the full result set.
+func listNotificationGithubIDsFromQuery(
+	ctx context.Context,
+	s *Store,
+	userID string,
+	query db.NotificationQuery,
+) ([]string, error) {
+	whereConditions := []string{"n.user_id = ?"}
+	args := []interface{}{userID}
+	args = append(args, query.Args...)
+	if len(query.Where) > 0 {
+		whereConditions = append(whereConditions, query.Where...)
+	}
+	where := " WHERE " + strings.Join(whereConditions, " AND ")
+
+	joins := ""
+	if len(query.Joins) > 0 {
+		joins = " " + strings.Join(query.Joins, " ")
+	}
+
+	selectQuery := "SELECT n.github_id FROM notifications n" + joins + where +
+		" ORDER BY n.effective_sort_date DESC, n.imported_at DESC, n.id DESC"
+
+	var rows *sql.Rows
+	err := db.RetryVoidOnBusy(ctx, func() error {
+		var queryErr error
+		rows, queryErr = s.dbConn.QueryContext(ctx, selectQuery, args...)
+		return queryErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var githubIDs []string
+	for rows.Next() {
+		var githubID string
+		if scanErr := rows.Scan(&githubID); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan notification id: %w", scanErr)
+		}
+		githubIDs = append(githubIDs, githubID)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", rowsErr)
+	}
+	return githubIDs, nil
+}
+
+// repositoryJoin is the join the query builder adds when a query filters on repositories
+const repositoryJoin = "LEFT JOIN repositories r ON r.id = n.repository_id"
+
+// notificationGroupExpr returns the SQL expression notifications are grouped by for a key,
+// and whether it reads from the repository join.
+func notificationGroupExpr(key db.NotificationGroupKey) (string, bool, error) {
+	switch key {
+	case db.NotificationGroupByRepo:
+		return "COALESCE(r.full_name, '')", true, nil
+	case db.NotificationGroupByOrg:
+		return "COALESCE(substr(r.full_name, 1, instr(r.full_name, '/') - 1), '')", true, nil
+	case db.NotificationGroupByReason:
+		return "COALESCE(n.reason, '')", false, nil
+	case db.NotificationGroupByAuthor:
+		return "COALESCE(n.author_login, '')", false, nil
+	case db.NotificationGroupByType:
+		return "n.subject_type", false, nil
+	default:
+		return "", false, fmt.Errorf("unsupported group key: %s", key)
+	}
+}
+
+// listNotificationGroupsFromQuery counts the notifications matching a query per group and
+// returns the newest ones of each group. Groups are ordered by their most recent activity.
+func listNotificationGroupsFromQuery(
+	ctx context.Context,
+	s *Store,
+	userID string,
+	params db.NotificationGroupsParams,
+) ([]db.NotificationGroup, error) {
+	groupExpr, needsRepoJoin, err := notificationGroupExpr(params.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	joinClauses := params.Query.Joins
+	if needsRepoJoin && !slices.Contains(joinClauses, repositoryJoin) {
+		joinClauses = append(slices.Clone(joinClauses), repositoryJoin)
+	}
+	joins := ""
+	if len(joinClauses) > 0 {
+		joins = " " + strings.Join(joinClauses, " ")
+	}
+
+	whereConditions := []string{"n.user_id = ?"}
+	args := []interface{}{userID}
+	args = append(args, params.Query.Args...)
+	if len(params.Query.Where) > 0 {
+		whereConditions = append(whereConditions, params.Query.Where...)
+	}
+	where := " WHERE " + strings.Join(whereConditions, " AND ")
+
+	//nolint:gosec // G201: SQL string formatting is safe - groupExpr, joins, and where are controlled
+	countQuery := fmt.Sprintf(
+		"SELECT %s AS group_key, COUNT(*) FROM notifications n%s%s "+
+			"GROUP BY group_key ORDER BY MAX(n.effective_sort_date) DESC, group_key",
+		groupExpr, joins, where,
+	)
+	var rows *sql.Rows
+	err = db.RetryVoidOnBusy(ctx, func() error {
+		var queryErr error
+		rows, queryErr = s.dbConn.QueryContext(ctx, countQuery, args...)
+		return queryErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []db.NotificationGroup
+	groupIndex := make(map[string]int)
+	for rows.Next() {
+		var group db.NotificationGroup
+		if scanErr := rows.Scan(&group.Key, &group.Count); scanErr != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan notification group: %w", scanErr)
+		}
+		groupIndex[group.Key] = len(groups)
+		groups = append(groups, group)
+	}
+	rowsErr := rows.Err()
+	_ = rows.Close()
+	if rowsErr != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", rowsErr)
+	}
+
+	if params.PerGroup <= 0 || len(groups) == 0 {
+		return groups, nil
+	}
+
+	// Rank notifications within each group in list order and keep the first few of each
+	//nolint:gosec // G201: SQL string formatting is safe - groupExpr, joins, and where are controlled
+	topQuery := fmt.Sprintf(
+		"SELECT * FROM (SELECT %s AS group_key, ROW_NUMBER() OVER (PARTITION BY %s "+
+			"ORDER BY n.effective_sort_date DESC, n.imported_at DESC) AS group_rank, %s "+
+			"FROM notifications n%s%s) WHERE group_rank <= ? ORDER BY group_rank",
+		groupExpr, groupExpr, strings.Join(notificationColumnList(params.Query.IncludeSubject), ", "),
+		joins, where,
+	)
+	topArgs := append(slices.Clone(args), params.PerGroup)
+	err = db.RetryVoidOnBusy(ctx, func() error {
+		var queryErr error
+		rows, queryErr = s.dbConn.QueryContext(ctx, topQuery, topArgs...)
+		return queryErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		page       []Notification
+		pageGroups []int
+	)
+	for rows.Next() {
+		var (
+			key  string
+			rank int64
+			n    Notification
+		)
+		targets := append(
+			[]any{&key, &rank},
+			notificationScanTargets(&n, params.Query.IncludeSubject)...,
+		)
+		if scanErr := rows.Scan(targets...); scanErr != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", scanErr)
+		}
+		i, ok := groupIndex[key]
+		if !ok {
+			continue
+		}
+		page = append(page, n)
+		pageGroups = append(pageGroups, i)
+	}
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", rowsErr)
+	}
+	for j, notification := range s.toDBNotifications(ctx, userID, page) {
+		i := pageGroups[j]
+		groups[i].Notifications = append(groups[i].Notifications, notification)
+	}
+	return groups, nil
+}
+
 // bulkUpdateByQuery updates notifications matching a query.
 func bulkUpdateByQuery(
 	ctx context.Context,
@@ -237,6 +704,64 @@ func bulkUpdateByQuery(
 	return result.RowsAffected()
 }
 
+// bulkTagByQuery assigns a tag to, or with remove set removes it from, the notifications
+// matching a query in one statement
+func bulkTagByQuery(
+	ctx context.Context,
+	s *Store,
+	userID string,
+	arg db.BulkTagNotificationsByQueryParams,
+	remove bool,
+) (int64, error) {
+	// Build WHERE clause - always include user_id
+	whereConditions := []string{"n.user_id = ?"}
+	if len(arg.Query.Where) > 0 {
+		whereConditions = append(whereConditions, arg.Query.Where...)
+	}
+	where := " WHERE " + strings.Join(whereConditions, " AND ")
+
+	joins := ""
+	if len(arg.Query.Joins) > 0 {
+		joins = " " + strings.Join(arg.Query.Joins, " ")
+	}
+
+	// The assignment's user and tag come first, then the query's userID and args
+	args := make([]interface{}, 0, len(arg.Query.Args)+3)
+	args = append(args, userID, arg.TagID, userID)
+	args = append(args, arg.Query.Args...)
+
+	// The WHERE clause also keeps SQLite from reading ON CONFLICT as part of a join
+	//nolint:gosec // G201: SQL string formatting is safe - joins and where are controlled
+	sqlQuery := fmt.Sprintf(
+		"INSERT INTO tag_assignments (user_id, tag_id, entity_type, entity_id, created_at) "+
+			"SELECT ?, ?, 'notification', n.id, strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', 'now') "+
+			"FROM notifications n%s%s "+
+			"ON CONFLICT(user_id, tag_id, entity_type, entity_id) DO NOTHING",
+		joins,
+		where,
+	)
+	if remove {
+		//nolint:gosec // G201: SQL string formatting is safe - joins and where are controlled
+		sqlQuery = fmt.Sprintf(
+			"DELETE FROM tag_assignments WHERE user_id = ? AND tag_id = ? AND entity_type = 'notification' "+
+				"AND entity_id IN (SELECT n.id FROM notifications n%s%s)",
+			joins,
+			where,
+		)
+	}
+
+	var result sql.Result
+	err := db.RetryVoidOnBusy(ctx, func() error {
+		var execErr error
+		result, execErr = s.dbConn.ExecContext(ctx, sqlQuery, args...)
+		return execErr
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // bulkSnoozeByQuery snoozes notifications matching a query.
 func bulkSnoozeByQuery(
 	ctx context.Context,
@@ -267,7 +792,8 @@ func bulkSnoozeByQuery(
 	sqlQuery := fmt.Sprintf(
 		"UPDATE notifications SET snoozed_until = ?, "+
 			"snoozed_at = strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', 'now'), "+
-			"effective_sort_date = ? WHERE id IN (SELECT n.id FROM notifications n%s%s)",
+			"effective_sort_date = ?, snooze_until_updated = 0, returned_from_snooze_at = NULL "+
+			"WHERE id IN (SELECT n.id FROM notifications n%s%s)",
 		joins,
 		where,
 	)
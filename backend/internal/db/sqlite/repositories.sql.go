@@ -10,6 +10,135 @@ import (
 	"database/sql"
 )
 
+const deleteDuplicatePullRequests = `-- name: DeleteDuplicatePullRequests :execrows
+DELETE FROM pull_requests
+WHERE user_id = ?1 AND repository_id = ?2 AND number IN (
+    SELECT keep.number FROM pull_requests keep
+    WHERE keep.user_id = ?1 AND keep.repository_id = ?3
+)
+`
+
+type DeleteDuplicatePullRequestsParams struct {
+	UserID      string
+	DuplicateID int64
+	KeepID      int64
+}
+
+// Runs after RepointDuplicatePullRequestNotifications, once nothing references these rows.
+func (q *Queries) DeleteDuplicatePullRequests(ctx context.Context, arg DeleteDuplicatePullRequestsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDuplicatePullRequests, arg.UserID, arg.DuplicateID, arg.KeepID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRepository = `-- name: DeleteRepository :exec
+DELETE FROM repositories WHERE user_id = ? AND id = ?
+`
+
+type DeleteRepositoryParams struct {
+	UserID string
+	ID     int64
+}
+
+func (q *Queries) DeleteRepository(ctx context.Context, arg DeleteRepositoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRepository, arg.UserID, arg.ID)
+	return err
+}
+
+const deleteRepositoryAlias = `-- name: DeleteRepositoryAlias :exec
+DELETE FROM repository_aliases WHERE user_id = ? AND full_name = ?
+`
+
+type DeleteRepositoryAliasParams struct {
+	UserID   string
+	FullName string
+}
+
+func (q *Queries) DeleteRepositoryAlias(ctx context.Context, arg DeleteRepositoryAliasParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRepositoryAlias, arg.UserID, arg.FullName)
+	return err
+}
+
+const getRepositoryByFullName = `-- name: GetRepositoryByFullName :one
+SELECT id, user_id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url FROM repositories WHERE user_id = ? AND full_name = ?
+`
+
+type GetRepositoryByFullNameParams struct {
+	UserID   string
+	FullName string
+}
+
+func (q *Queries) GetRepositoryByFullName(ctx context.Context, arg GetRepositoryByFullNameParams) (Repository, error) {
+	row := q.db.QueryRowContext(ctx, getRepositoryByFullName, arg.UserID, arg.FullName)
+	var i Repository
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GithubID,
+		&i.NodeID,
+		&i.Name,
+		&i.FullName,
+		&i.OwnerLogin,
+		&i.OwnerID,
+		&i.Private,
+		&i.Description,
+		&i.HtmlUrl,
+		&i.Fork,
+		&i.Visibility,
+		&i.DefaultBranch,
+		&i.Archived,
+		&i.Disabled,
+		&i.PushedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Raw,
+		&i.OwnerAvatarUrl,
+		&i.OwnerHtmlUrl,
+	)
+	return i, err
+}
+
+const getRepositoryByGithubID = `-- name: GetRepositoryByGithubID :one
+SELECT id, user_id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url FROM repositories WHERE user_id = ? AND github_id = ? ORDER BY id LIMIT 1
+`
+
+type GetRepositoryByGithubIDParams struct {
+	UserID   string
+	GithubID sql.NullInt64
+}
+
+func (q *Queries) GetRepositoryByGithubID(ctx context.Context, arg GetRepositoryByGithubIDParams) (Repository, error) {
+	row := q.db.QueryRowContext(ctx, getRepositoryByGithubID, arg.UserID, arg.GithubID)
+	var i Repository
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GithubID,
+		&i.NodeID,
+		&i.Name,
+		&i.FullName,
+		&i.OwnerLogin,
+		&i.OwnerID,
+		&i.Private,
+		&i.Description,
+		&i.HtmlUrl,
+		&i.Fork,
+		&i.Visibility,
+		&i.DefaultBranch,
+		&i.Archived,
+		&i.Disabled,
+		&i.PushedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Raw,
+		&i.OwnerAvatarUrl,
+		&i.OwnerHtmlUrl,
+	)
+	return i, err
+}
+
 const getRepositoryByID = `-- name: GetRepositoryByID :one
 SELECT id, user_id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url FROM repositories WHERE user_id = ? AND id = ?
 `
@@ -49,6 +178,89 @@ func (q *Queries) GetRepositoryByID(ctx context.Context, arg GetRepositoryByIDPa
 	return i, err
 }
 
+const getRepositoryDigestRows = `-- name: GetRepositoryDigestRows :many
+SELECT
+    repository_id, reason, reason_count, reason_rank, recent_rank,
+    github_id, subject_title, updated_at, oldest_unread_at, unread_count
+FROM (
+    SELECT
+        n.repository_id,
+        COALESCE(n.reason, '') AS reason,
+        COUNT(*) OVER (PARTITION BY n.repository_id, COALESCE(n.reason, '')) AS reason_count,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id, COALESCE(n.reason, '') ORDER BY n.id
+        ) AS reason_rank,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id
+            ORDER BY COALESCE(n.github_updated_at, n.imported_at) DESC, n.id DESC
+        ) AS recent_rank,
+        n.github_id,
+        n.subject_title,
+        COALESCE(n.github_updated_at, n.imported_at) AS updated_at,
+        MIN(COALESCE(n.github_updated_at, n.imported_at))
+            OVER (PARTITION BY n.repository_id) AS oldest_unread_at,
+        COUNT(*) OVER (PARTITION BY n.repository_id) AS unread_count
+    FROM notifications n
+    WHERE n.user_id = ? AND n.repository_id = ?
+        AND n.is_read = 0 AND n.archived = 0 AND n.muted = 0
+) AS unread
+WHERE reason_rank = 1 OR recent_rank <= 3
+ORDER BY recent_rank
+`
+
+type GetRepositoryDigestRowsParams struct {
+	UserID       string
+	RepositoryID int64
+}
+
+type GetRepositoryDigestRowsRow struct {
+	RepositoryID   int64
+	Reason         string
+	ReasonCount    int64
+	ReasonRank     int64
+	RecentRank     int64
+	GithubID       string
+	SubjectTitle   string
+	UpdatedAt      string
+	OldestUnreadAt string
+	UnreadCount    int64
+}
+
+// Same as ListRepositoryDigestRows, restricted to a single repository.
+func (q *Queries) GetRepositoryDigestRows(ctx context.Context, arg GetRepositoryDigestRowsParams) ([]GetRepositoryDigestRowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRepositoryDigestRows, arg.UserID, arg.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRepositoryDigestRowsRow
+	for rows.Next() {
+		var i GetRepositoryDigestRowsRow
+		if err := rows.Scan(
+			&i.RepositoryID,
+			&i.Reason,
+			&i.ReasonCount,
+			&i.ReasonRank,
+			&i.RecentRank,
+			&i.GithubID,
+			&i.SubjectTitle,
+			&i.UpdatedAt,
+			&i.OldestUnreadAt,
+			&i.UnreadCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRepositories = `-- name: ListRepositories :many
 SELECT id, user_id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url FROM repositories WHERE user_id = ? ORDER BY full_name
 `
@@ -99,6 +311,230 @@ func (q *Queries) ListRepositories(ctx context.Context, userID string) ([]Reposi
 	return items, nil
 }
 
+const listRepositoryDigestRows = `-- name: ListRepositoryDigestRows :many
+SELECT
+    repository_id, reason, reason_count, reason_rank, recent_rank,
+    github_id, subject_title, updated_at, oldest_unread_at, unread_count
+FROM (
+    SELECT
+        n.repository_id,
+        COALESCE(n.reason, '') AS reason,
+        COUNT(*) OVER (PARTITION BY n.repository_id, COALESCE(n.reason, '')) AS reason_count,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id, COALESCE(n.reason, '') ORDER BY n.id
+        ) AS reason_rank,
+        ROW_NUMBER() OVER (
+            PARTITION BY n.repository_id
+            ORDER BY COALESCE(n.github_updated_at, n.imported_at) DESC, n.id DESC
+        ) AS recent_rank,
+        n.github_id,
+        n.subject_title,
+        COALESCE(n.github_updated_at, n.imported_at) AS updated_at,
+        MIN(COALESCE(n.github_updated_at, n.imported_at))
+            OVER (PARTITION BY n.repository_id) AS oldest_unread_at,
+        COUNT(*) OVER (PARTITION BY n.repository_id) AS unread_count
+    FROM notifications n
+    WHERE n.user_id = ? AND n.is_read = 0 AND n.archived = 0 AND n.muted = 0
+) AS unread
+WHERE reason_rank = 1 OR recent_rank <= 3
+ORDER BY repository_id, recent_rank
+`
+
+type ListRepositoryDigestRowsRow struct {
+	RepositoryID   int64
+	Reason         string
+	ReasonCount    int64
+	ReasonRank     int64
+	RecentRank     int64
+	GithubID       string
+	SubjectTitle   string
+	UpdatedAt      string
+	OldestUnreadAt string
+	UnreadCount    int64
+}
+
+// Unread digest for every repository in a single scan: one row per (repository, reason)
+// carrying that reason's count, plus the three most recently updated unread notifications.
+func (q *Queries) ListRepositoryDigestRows(ctx context.Context, userID string) ([]ListRepositoryDigestRowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRepositoryDigestRows, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRepositoryDigestRowsRow
+	for rows.Next() {
+		var i ListRepositoryDigestRowsRow
+		if err := rows.Scan(
+			&i.RepositoryID,
+			&i.Reason,
+			&i.ReasonCount,
+			&i.ReasonRank,
+			&i.RecentRank,
+			&i.GithubID,
+			&i.SubjectTitle,
+			&i.UpdatedAt,
+			&i.OldestUnreadAt,
+			&i.UnreadCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRepositorySubjectTypeCounts = `-- name: ListRepositorySubjectTypeCounts :many
+SELECT repository_id, subject_type, COUNT(*) AS notification_count
+FROM notifications
+WHERE user_id = ?
+GROUP BY repository_id, subject_type
+ORDER BY repository_id, subject_type
+`
+
+type ListRepositorySubjectTypeCountsRow struct {
+	RepositoryID      int64
+	SubjectType       string
+	NotificationCount int64
+}
+
+// How many notifications each repository has of each subject type, read or not.
+func (q *Queries) ListRepositorySubjectTypeCounts(ctx context.Context, userID string) ([]ListRepositorySubjectTypeCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRepositorySubjectTypeCounts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRepositorySubjectTypeCountsRow
+	for rows.Next() {
+		var i ListRepositorySubjectTypeCountsRow
+		if err := rows.Scan(&i.RepositoryID, &i.SubjectType, &i.NotificationCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveRepositoryAliases = `-- name: MoveRepositoryAliases :exec
+UPDATE repository_aliases SET repository_id = ?1
+WHERE user_id = ?2 AND repository_id = ?3
+`
+
+type MoveRepositoryAliasesParams struct {
+	KeepID      int64
+	UserID      string
+	DuplicateID int64
+}
+
+func (q *Queries) MoveRepositoryAliases(ctx context.Context, arg MoveRepositoryAliasesParams) error {
+	_, err := q.db.ExecContext(ctx, moveRepositoryAliases, arg.KeepID, arg.UserID, arg.DuplicateID)
+	return err
+}
+
+const moveRepositoryNotifications = `-- name: MoveRepositoryNotifications :execrows
+UPDATE notifications SET repository_id = ?1
+WHERE user_id = ?2 AND repository_id = ?3
+`
+
+type MoveRepositoryNotificationsParams struct {
+	KeepID      int64
+	UserID      string
+	DuplicateID int64
+}
+
+func (q *Queries) MoveRepositoryNotifications(ctx context.Context, arg MoveRepositoryNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveRepositoryNotifications, arg.KeepID, arg.UserID, arg.DuplicateID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveRepositoryPullRequests = `-- name: MoveRepositoryPullRequests :execrows
+UPDATE pull_requests SET repository_id = ?1
+WHERE user_id = ?2 AND repository_id = ?3
+`
+
+type MoveRepositoryPullRequestsParams struct {
+	KeepID      int64
+	UserID      string
+	DuplicateID int64
+}
+
+func (q *Queries) MoveRepositoryPullRequests(ctx context.Context, arg MoveRepositoryPullRequestsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveRepositoryPullRequests, arg.KeepID, arg.UserID, arg.DuplicateID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const renameRepository = `-- name: RenameRepository :exec
+UPDATE repositories
+SET full_name = ?, name = ?, owner_login = ?
+WHERE user_id = ? AND id = ?
+`
+
+type RenameRepositoryParams struct {
+	FullName   string
+	Name       string
+	OwnerLogin sql.NullString
+	UserID     string
+	ID         int64
+}
+
+func (q *Queries) RenameRepository(ctx context.Context, arg RenameRepositoryParams) error {
+	_, err := q.db.ExecContext(ctx, renameRepository,
+		arg.FullName,
+		arg.Name,
+		arg.OwnerLogin,
+		arg.UserID,
+		arg.ID,
+	)
+	return err
+}
+
+const repointDuplicatePullRequestNotifications = `-- name: RepointDuplicatePullRequestNotifications :exec
+UPDATE notifications
+SET pull_request_id = (
+    SELECT keep.id FROM pull_requests keep
+    JOIN pull_requests dup ON dup.number = keep.number
+    WHERE keep.user_id = ?1 AND keep.repository_id = ?2
+      AND dup.id = notifications.pull_request_id
+)
+WHERE user_id = ?1 AND pull_request_id IN (
+    SELECT dup.id FROM pull_requests dup
+    JOIN pull_requests keep ON keep.user_id = dup.user_id AND keep.number = dup.number
+    WHERE dup.user_id = ?1 AND dup.repository_id = ?3
+      AND keep.repository_id = ?2
+)
+`
+
+type RepointDuplicatePullRequestNotificationsParams struct {
+	UserID      string
+	KeepID      int64
+	DuplicateID int64
+}
+
+// Pull requests are unique per (repository, number), so when merging a duplicate repository
+// row, notifications on a pull request the kept row also has are pointed at the kept copy.
+func (q *Queries) RepointDuplicatePullRequestNotifications(ctx context.Context, arg RepointDuplicatePullRequestNotificationsParams) error {
+	_, err := q.db.ExecContext(ctx, repointDuplicatePullRequestNotifications, arg.UserID, arg.KeepID, arg.DuplicateID)
+	return err
+}
+
 const upsertRepository = `-- name: UpsertRepository :one
 INSERT INTO repositories (
     user_id, github_id, node_id, name, full_name, owner_login, owner_id,
@@ -203,3 +639,22 @@ func (q *Queries) UpsertRepository(ctx context.Context, arg UpsertRepositoryPara
 	)
 	return i, err
 }
+
+const upsertRepositoryAlias = `-- name: UpsertRepositoryAlias :exec
+INSERT INTO repository_aliases (user_id, repository_id, full_name)
+VALUES (?, ?, ?)
+ON CONFLICT(user_id, full_name) DO UPDATE SET
+    repository_id = excluded.repository_id,
+    created_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+`
+
+type UpsertRepositoryAliasParams struct {
+	UserID       string
+	RepositoryID int64
+	FullName     string
+}
+
+func (q *Queries) UpsertRepositoryAlias(ctx context.Context, arg UpsertRepositoryAliasParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRepositoryAlias, arg.UserID, arg.RepositoryID, arg.FullName)
+	return err
+}
@@ -11,20 +11,23 @@ import (
 )
 
 const createRule = `-- name: CreateRule :one
-INSERT INTO rules (user_id, name, description, query, view_id, enabled, actions, display_order, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
-RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id
+INSERT INTO rules (user_id, name, description, query, view_id, enabled, actions, display_order, priority, stop_processing, condition, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition
 `
 
 type CreateRuleParams struct {
-	UserID       string
-	Name         string
-	Description  sql.NullString
-	Query        sql.NullString
-	ViewID       sql.NullString
-	Enabled      int64
-	Actions      string
-	DisplayOrder int64
+	UserID         string
+	Name           string
+	Description    sql.NullString
+	Query          sql.NullString
+	ViewID         sql.NullString
+	Enabled        int64
+	Actions        string
+	DisplayOrder   int64
+	Priority       int64
+	StopProcessing int64
+	Condition      sql.NullString
 }
 
 func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, error) {
@@ -37,6 +40,9 @@ func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, e
 		arg.Enabled,
 		arg.Actions,
 		arg.DisplayOrder,
+		arg.Priority,
+		arg.StopProcessing,
+		arg.Condition,
 	)
 	var i Rule
 	err := row.Scan(
@@ -51,6 +57,9 @@ func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ViewID,
+		&i.Priority,
+		&i.StopProcessing,
+		&i.Condition,
 	)
 	return i, err
 }
@@ -69,8 +78,115 @@ func (q *Queries) DeleteRule(ctx context.Context, arg DeleteRuleParams) error {
 	return err
 }
 
+const disableRulesByTagID = `-- name: DisableRulesByTagID :many
+UPDATE rules SET enabled = 0, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+WHERE user_id = ?1 AND (
+    EXISTS (SELECT 1 FROM json_each(rules.actions, '$.assignTags') WHERE json_each.value = ?2)
+    OR EXISTS (SELECT 1 FROM json_each(rules.actions, '$.removeTags') WHERE json_each.value = ?2)
+)
+RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition
+`
+
+type DisableRulesByTagIDParams struct {
+	UserID string
+	TagID  string
+}
+
+// Disables rules whose actions assign or remove the tag, before the tag is deleted
+func (q *Queries) DisableRulesByTagID(ctx context.Context, arg DisableRulesByTagIDParams) ([]Rule, error) {
+	rows, err := q.db.QueryContext(ctx, disableRulesByTagID, arg.UserID, arg.TagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rule
+	for rows.Next() {
+		var i Rule
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Description,
+			&i.Query,
+			&i.Enabled,
+			&i.Actions,
+			&i.DisplayOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const disableRulesByViewID = `-- name: DisableRulesByViewID :many
+UPDATE rules SET
+    enabled = 0,
+    query = COALESCE(query, (SELECT views.query FROM views WHERE views.id = rules.view_id)),
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+WHERE user_id = ? AND view_id = ?
+RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition
+`
+
+type DisableRulesByViewIDParams struct {
+	UserID string
+	ViewID sql.NullString
+}
+
+// Disables rules linked to the view, before the view is deleted. The view's query is copied
+// onto each rule so it still has one once view_id is cleared and can be re-enabled later.
+func (q *Queries) DisableRulesByViewID(ctx context.Context, arg DisableRulesByViewIDParams) ([]Rule, error) {
+	rows, err := q.db.QueryContext(ctx, disableRulesByViewID, arg.UserID, arg.ViewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rule
+	for rows.Next() {
+		var i Rule
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Description,
+			&i.Query,
+			&i.Enabled,
+			&i.Actions,
+			&i.DisplayOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRule = `-- name: GetRule :one
-SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id FROM rules WHERE user_id = ? AND id = ?
+SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition FROM rules WHERE user_id = ? AND id = ?
 `
 
 type GetRuleParams struct {
@@ -93,12 +209,68 @@ func (q *Queries) GetRule(ctx context.Context, arg GetRuleParams) (Rule, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ViewID,
+		&i.Priority,
+		&i.StopProcessing,
+		&i.Condition,
 	)
 	return i, err
 }
 
+const getRulesByTagID = `-- name: GetRulesByTagID :many
+SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition FROM rules
+WHERE user_id = ?1 AND (
+    EXISTS (SELECT 1 FROM json_each(rules.actions, '$.assignTags') WHERE json_each.value = ?2)
+    OR EXISTS (SELECT 1 FROM json_each(rules.actions, '$.removeTags') WHERE json_each.value = ?2)
+)
+ORDER BY display_order
+`
+
+type GetRulesByTagIDParams struct {
+	UserID string
+	TagID  string
+}
+
+// Rules whose actions assign or remove the tag
+func (q *Queries) GetRulesByTagID(ctx context.Context, arg GetRulesByTagIDParams) ([]Rule, error) {
+	rows, err := q.db.QueryContext(ctx, getRulesByTagID, arg.UserID, arg.TagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rule
+	for rows.Next() {
+		var i Rule
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Description,
+			&i.Query,
+			&i.Enabled,
+			&i.Actions,
+			&i.DisplayOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRulesByViewID = `-- name: GetRulesByViewID :many
-SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id FROM rules WHERE user_id = ? AND view_id = ? ORDER BY display_order
+SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition FROM rules WHERE user_id = ? AND view_id = ? ORDER BY display_order
 `
 
 type GetRulesByViewIDParams struct {
@@ -127,6 +299,9 @@ func (q *Queries) GetRulesByViewID(ctx context.Context, arg GetRulesByViewIDPara
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
 		); err != nil {
 			return nil, err
 		}
@@ -142,9 +317,11 @@ func (q *Queries) GetRulesByViewID(ctx context.Context, arg GetRulesByViewIDPara
 }
 
 const listEnabledRulesOrdered = `-- name: ListEnabledRulesOrdered :many
-SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id FROM rules WHERE user_id = ? AND enabled = 1 ORDER BY display_order
+-- Enabled rules in the order they run: highest priority first, then display order
+SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition FROM rules WHERE user_id = ? AND enabled = 1 ORDER BY priority DESC, display_order
 `
 
+// Enabled rules in the order they run: highest priority first, then display order
 func (q *Queries) ListEnabledRulesOrdered(ctx context.Context, userID string) ([]Rule, error) {
 	rows, err := q.db.QueryContext(ctx, listEnabledRulesOrdered, userID)
 	if err != nil {
@@ -166,6 +343,9 @@ func (q *Queries) ListEnabledRulesOrdered(ctx context.Context, userID string) ([
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
 		); err != nil {
 			return nil, err
 		}
@@ -181,7 +361,7 @@ func (q *Queries) ListEnabledRulesOrdered(ctx context.Context, userID string) ([
 }
 
 const listRules = `-- name: ListRules :many
-SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id FROM rules WHERE user_id = ? ORDER BY display_order, name
+SELECT id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition FROM rules WHERE user_id = ? ORDER BY priority DESC, display_order, name
 `
 
 func (q *Queries) ListRules(ctx context.Context, userID string) ([]Rule, error) {
@@ -205,6 +385,9 @@ func (q *Queries) ListRules(ctx context.Context, userID string) ([]Rule, error)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ViewID,
+			&i.Priority,
+			&i.StopProcessing,
+			&i.Condition,
 		); err != nil {
 			return nil, err
 		}
@@ -227,20 +410,26 @@ UPDATE rules SET
     view_id = ?,
     enabled = COALESCE(?, enabled),
     actions = COALESCE(?, actions),
+    priority = COALESCE(?, priority),
+    stop_processing = COALESCE(?, stop_processing),
+    condition = NULLIF(COALESCE(?, condition), ''),
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
 WHERE user_id = ? AND id = ?
-RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id
+RETURNING id, user_id, name, description, "query", enabled, actions, display_order, created_at, updated_at, view_id, priority, stop_processing, condition
 `
 
 type UpdateRuleParams struct {
-	Name        string
-	Description sql.NullString
-	Query       sql.NullString
-	ViewID      sql.NullString
-	Enabled     int64
-	Actions     string
-	UserID      string
-	ID          string
+	Name           sql.NullString
+	Description    sql.NullString
+	Query          sql.NullString
+	ViewID         sql.NullString
+	Enabled        sql.NullInt64
+	Actions        sql.NullString
+	Priority       sql.NullInt64
+	StopProcessing sql.NullInt64
+	Condition      sql.NullString
+	UserID         string
+	ID             string
 }
 
 func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, error) {
@@ -251,6 +440,9 @@ func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, e
 		arg.ViewID,
 		arg.Enabled,
 		arg.Actions,
+		arg.Priority,
+		arg.StopProcessing,
+		arg.Condition,
 		arg.UserID,
 		arg.ID,
 	)
@@ -267,6 +459,9 @@ func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ViewID,
+		&i.Priority,
+		&i.StopProcessing,
+		&i.Condition,
 	)
 	return i, err
 }
@@ -21,6 +21,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
@@ -58,17 +60,11 @@ func parseTime(s string) time.Time {
 	if s == "" {
 		return time.Time{}
 	}
-	// Try RFC3339 first (ISO 8601 with timezone)
-	t, err := time.Parse(time.RFC3339, s)
-	if err == nil {
-		return t
-	}
-	// Try SQLite datetime format
-	t, err = time.Parse("2006-01-02 15:04:05", s)
-	if err == nil {
-		return t
+	t, err := db.ParseTimestamp(s)
+	if err != nil {
+		return time.Time{}
 	}
-	return time.Time{}
+	return t
 }
 
 // parseNullTime converts a nullable SQLite TEXT timestamp to sql.NullTime
@@ -85,10 +81,7 @@ func parseNullTime(ns sql.NullString) sql.NullTime {
 
 // formatTime formats a time.Time for SQLite storage
 func formatTime(t time.Time) string {
-	if t.IsZero() {
-		return ""
-	}
-	return t.UTC().Format(time.RFC3339)
+	return db.FormatTimestamp(t)
 }
 
 // formatNullTime formats a sql.NullTime for SQLite storage
@@ -99,6 +92,14 @@ func formatNullTime(nt sql.NullTime) sql.NullString {
 	return sql.NullString{String: formatTime(nt.Time), Valid: true}
 }
 
+// likeEscaper escapes LIKE wildcards for patterns that use ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes LIKE wildcards in a value so it matches literally
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
 // toBool converts SQLite int64 (0/1) to bool
 func toBool(i int64) bool {
 	return i != 0
@@ -176,13 +177,42 @@ func (s *Store) toDBNotification(
 ) db.Notification {
 	// Get tag IDs from junction table
 	// If this fails, we'll just return an empty tag list - not critical for conversion
-	tagIDs, err := s.getNotificationTagIDs(ctx, userID, n.ID)
+	tags, err := s.listNotificationTags(ctx, userID, []int64{n.ID})
 	if err != nil {
 		// Log error but continue - tag IDs are optional for notification conversion
-		tagIDs = []string{}
-		_ = err
+		return toDBNotificationWithTags(n, notificationTags{ids: []string{}})
 	}
 
+	return toDBNotificationWithTags(n, tags[n.ID])
+}
+
+// toDBNotifications converts a page of notifications, looking up all of their tags in one
+// query rather than one per notification
+func (s *Store) toDBNotifications(
+	ctx context.Context,
+	userID string,
+	rows []Notification,
+) []db.Notification {
+	ids := make([]int64, len(rows))
+	for i, n := range rows {
+		ids[i] = n.ID
+	}
+	tags, err := s.listNotificationTags(ctx, userID, ids)
+
+	notifications := make([]db.Notification, len(rows))
+	for i, n := range rows {
+		nTags := tags[n.ID]
+		if err != nil {
+			// As in toDBNotification, tag IDs are optional, so a failed lookup leaves them empty
+			nTags = notificationTags{ids: []string{}}
+		}
+		notifications[i] = toDBNotificationWithTags(n, nTags)
+	}
+	return notifications
+}
+
+// toDBNotificationWithTags converts a notification whose tags were already looked up
+func toDBNotificationWithTags(n Notification, tags notificationTags) db.Notification {
 	return db.Notification{
 		ID:                      n.ID,
 		UserID:                  n.UserID,
@@ -213,29 +243,110 @@ func (s *Store) toDBNotification(
 		SnoozedAt:               parseNullTime(n.SnoozedAt),
 		Starred:                 toBool(n.Starred),
 		Filtered:                toBool(n.Filtered),
-		TagIDs:                  tagIDs,
+		TagIDs:                  tags.ids,
+		TagsAssignedAt:          tags.assignedAt,
 		SubjectNumber:           toNullInt32(n.SubjectNumber),
 		SubjectState:            n.SubjectState,
 		SubjectMerged:           toNullBool(n.SubjectMerged),
 		SubjectStateReason:      n.SubjectStateReason,
+		ContentKind:             n.ContentKind,
+		HeadBranch:              n.HeadBranch,
+		BaseBranch:              n.BaseBranch,
+		Account:                 n.Account,
+		ArchivedAt:              parseNullTime(n.ArchivedAt),
+		AwaitingReplySince:      parseNullTime(n.AwaitingReplySince),
+		AwaitingReply:           toBool(n.AwaitingReply),
+		SubjectDraft:            toNullBool(n.SubjectDraft),
+		SubjectLabels:           toNullRawMessage(n.SubjectLabels),
+		ReviewState:             n.ReviewState,
+		SnoozeUntilUpdated:      toBool(n.SnoozeUntilUpdated),
+		ReturnedFromSnoozeAt:    parseNullTime(n.ReturnedFromSnoozeAt),
+		SubjectMilestone:        n.SubjectMilestone,
+		SubjectProjects:         toNullRawMessage(n.SubjectProjects),
+		CIState:                 n.CiState,
+		SubjectMergeable:        toNullBool(n.SubjectMergeable),
+		WaitingOn:               n.WaitingOn,
+		Priority:                n.Priority,
 	}
 }
 
-func (s *Store) getNotificationTagIDs(
+// inList fills the %s in query with a placeholder for each of n values, for an IN list
+// whose values are passed as arguments. Only placeholders are formatted into the query,
+// never the values themselves, so it can't be used to inject SQL.
+func inList(query string, n int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", n), ",")
+	//nolint:gosec // G201: the only value formatted in is the generated list of placeholders
+	return fmt.Sprintf(query, placeholders)
+}
+
+// inListBatchSize is how many values go in one IN list. SQLite limits how many variables a
+// statement can bind, so longer lists are split into batches of this size.
+const inListBatchSize = 500
+
+// inBatches calls fn with consecutive batches of at most inListBatchSize values, stopping at
+// the first error
+func inBatches[T any](values []T, fn func(batch []T) error) error {
+	for start := 0; start < len(values); start += inListBatchSize {
+		if err := fn(values[start:min(start+inListBatchSize, len(values))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notificationTags are the tags assigned to a notification
+type notificationTags struct {
+	ids        []string
+	assignedAt []time.Time // when each tag in ids was assigned
+}
+
+// listNotificationTags looks up the tags of several notifications in one query, keyed by
+// notification ID
+func (s *Store) listNotificationTags(
 	ctx context.Context,
 	userID string,
-	notificationID int64,
-) ([]string, error) {
-	tagIDs, err := db.RetryOnBusy(ctx, func() ([]string, error) {
-		return s.q.GetNotificationTagIDs(ctx, GetNotificationTagIDsParams{
-			UserID:   userID,
-			EntityID: notificationID,
-		})
-	})
-	if err != nil {
-		return nil, err
+	notificationIDs []int64,
+) (map[int64]notificationTags, error) {
+	if len(notificationIDs) == 0 {
+		return map[int64]notificationTags{}, nil
+	}
+	args := make([]interface{}, 0, len(notificationIDs)+1)
+	args = append(args, userID)
+	for _, id := range notificationIDs {
+		args = append(args, id)
 	}
-	return tagIDs, nil
+	query := inList(`SELECT entity_id, tag_id, created_at FROM tag_assignments
+		WHERE user_id = ? AND entity_type = 'notification' AND entity_id IN (%s)`,
+		len(notificationIDs),
+	)
+	return db.RetryOnBusy(ctx, func() (map[int64]notificationTags, error) {
+		rows, err := s.dbConn.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		result := make(map[int64]notificationTags)
+		for rows.Next() {
+			var (
+				notificationID int64
+				tagID          string
+				assignedAt     string
+			)
+			if err := rows.Scan(&notificationID, &tagID, &assignedAt); err != nil {
+				return nil, err
+			}
+			tags := result[notificationID]
+			tags.ids = append(tags.ids, tagID)
+			tags.assignedAt = append(tags.assignedAt, parseTime(assignedAt))
+			result[notificationID] = tags
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
 }
 
 // --- Repository type conversion ---
@@ -267,6 +378,17 @@ func toDBRepository(r Repository) db.Repository {
 	}
 }
 
+func toDBRepositorySetting(r RepositorySetting) db.RepositorySetting {
+	return db.RepositorySetting{
+		UserID:                      r.UserID,
+		RepositoryID:                r.RepositoryID,
+		DefaultSnooze:               r.DefaultSnooze,
+		ResurfaceSuppressionMinutes: r.ResurfaceSuppressionMinutes,
+		SyncMode:                    r.SyncMode,
+		UpdatedAt:                   parseTime(r.UpdatedAt),
+	}
+}
+
 // --- Tag type conversion ---
 
 func toDBTag(t Tag) db.Tag {
@@ -297,37 +419,80 @@ func toDBTagAssignment(ta TagAssignment) db.TagAssignment {
 
 func toDBView(v View) db.View {
 	return db.View{
-		ID:           v.ID,
-		UserID:       v.UserID,
-		Name:         v.Name,
-		Description:  v.Description,
-		IsDefault:    toBool(v.IsDefault),
-		CreatedAt:    parseTime(v.CreatedAt),
-		Icon:         v.Icon,
-		Slug:         v.Slug,
-		Query:        v.Query,
-		DisplayOrder: int32(v.DisplayOrder),
+		ID:            v.ID,
+		UserID:        v.UserID,
+		Name:          v.Name,
+		Description:   v.Description,
+		IsDefault:     toBool(v.IsDefault),
+		CreatedAt:     parseTime(v.CreatedAt),
+		Icon:          v.Icon,
+		Slug:          v.Slug,
+		Query:         v.Query,
+		DisplayOrder:  int32(v.DisplayOrder),
+		WIPLimit:      v.WipLimit,
+		WIPAutoSnooze: toBool(v.WipAutoSnooze),
+		GroupID:       v.GroupID,
+	}
+}
+
+func toDBViewGroup(g ViewGroup) db.ViewGroup {
+	return db.ViewGroup{
+		ID:           g.ID,
+		UserID:       g.UserID,
+		Name:         g.Name,
+		DisplayOrder: int32(g.DisplayOrder),
+		Collapsed:    toBool(g.Collapsed),
+		CreatedAt:    parseTime(g.CreatedAt),
+	}
+}
+
+func toDBViewShare(v ViewShare) db.ViewShare {
+	return db.ViewShare{
+		UserID:     v.UserID,
+		ViewID:     v.ViewID,
+		Grantee:    v.Grantee,
+		Permission: v.Permission,
+		CreatedAt:  parseTime(v.CreatedAt),
+	}
+}
+
+func toDBViewShares(shares []ViewShare) []db.ViewShare {
+	result := make([]db.ViewShare, len(shares))
+	for i, share := range shares {
+		result[i] = toDBViewShare(share)
 	}
+	return result
 }
 
 // --- Rule type conversion ---
 
 func toDBRule(r Rule) db.Rule {
 	return db.Rule{
-		ID:           r.ID,
-		UserID:       r.UserID,
-		Name:         r.Name,
-		Description:  r.Description,
-		Query:        r.Query,
-		Enabled:      toBool(r.Enabled),
-		Actions:      toRawMessage(r.Actions),
-		DisplayOrder: int32(r.DisplayOrder),
-		CreatedAt:    parseTime(r.CreatedAt),
-		UpdatedAt:    parseTime(r.UpdatedAt),
-		ViewID:       r.ViewID,
+		ID:             r.ID,
+		UserID:         r.UserID,
+		Name:           r.Name,
+		Description:    r.Description,
+		Query:          r.Query,
+		Enabled:        toBool(r.Enabled),
+		Actions:        toRawMessage(r.Actions),
+		DisplayOrder:   int32(r.DisplayOrder),
+		CreatedAt:      parseTime(r.CreatedAt),
+		UpdatedAt:      parseTime(r.UpdatedAt),
+		ViewID:         r.ViewID,
+		Priority:       int32(r.Priority),
+		StopProcessing: toBool(r.StopProcessing),
+		Condition:      r.Condition,
 	}
 }
 
+func toDBRules(rules []Rule) []db.Rule {
+	result := make([]db.Rule, len(rules))
+	for i, r := range rules {
+		result[i] = toDBRule(r)
+	}
+	return result
+}
+
 // --- User type conversion ---
 
 func toDBUser(u User) db.User {
@@ -341,6 +506,14 @@ func toDBUser(u User) db.User {
 		SyncSettings:         toNullRawMessage(u.SyncSettings),
 		RetentionSettings:    toNullRawMessage(u.RetentionSettings),
 		UpdateSettings:       toNullRawMessage(u.UpdateSettings),
+		EscalationSettings:   toNullRawMessage(u.EscalationSettings),
+		WorkingHours:         toNullRawMessage(u.WorkingHours),
+		TeamSettings:         toNullRawMessage(u.TeamSettings),
+		AlertSettings:        toNullRawMessage(u.AlertSettings),
+		SnoozeSettings:       toNullRawMessage(u.SnoozeSettings),
+		BackupSettings:       toNullRawMessage(u.BackupSettings),
+		PrioritySettings:     toNullRawMessage(u.PrioritySettings),
+		DigestSettings:       toNullRawMessage(u.DigestSettings),
 		MutedUntil:           parseNullTime(u.MutedUntil),
 	}
 }
@@ -349,24 +522,225 @@ func toDBUser(u User) db.User {
 
 func toDBPullRequest(pr PullRequest) db.PullRequest {
 	return db.PullRequest{
-		ID:           pr.ID,
-		UserID:       pr.UserID,
-		RepositoryID: pr.RepositoryID,
-		GithubID:     pr.GithubID,
-		NodeID:       pr.NodeID,
-		Number:       int32(pr.Number),
-		Title:        pr.Title,
-		State:        pr.State,
-		Draft:        toNullBool(pr.Draft),
-		Merged:       toNullBool(pr.Merged),
-		AuthorLogin:  pr.AuthorLogin,
-		AuthorID:     pr.AuthorID,
-		CreatedAt:    parseNullTime(pr.CreatedAt),
-		UpdatedAt:    parseNullTime(pr.UpdatedAt),
-		ClosedAt:     parseNullTime(pr.ClosedAt),
-		MergedAt:     parseNullTime(pr.MergedAt),
-		Raw:          toNullRawMessage(pr.Raw),
+		ID:                 pr.ID,
+		UserID:             pr.UserID,
+		RepositoryID:       pr.RepositoryID,
+		GithubID:           pr.GithubID,
+		NodeID:             pr.NodeID,
+		Number:             int32(pr.Number),
+		Title:              pr.Title,
+		State:              pr.State,
+		Draft:              toNullBool(pr.Draft),
+		Merged:             toNullBool(pr.Merged),
+		AuthorLogin:        pr.AuthorLogin,
+		AuthorID:           pr.AuthorID,
+		CreatedAt:          parseNullTime(pr.CreatedAt),
+		UpdatedAt:          parseNullTime(pr.UpdatedAt),
+		ClosedAt:           parseNullTime(pr.ClosedAt),
+		MergedAt:           parseNullTime(pr.MergedAt),
+		Raw:                toNullRawMessage(pr.Raw),
+		RequestedReviewers: toNullRawMessage(pr.RequestedReviewers),
+		RequestedTeams:     toNullRawMessage(pr.RequestedTeams),
+	}
+}
+
+// --- AuthorProfile type conversion ---
+
+func toDBAuthorProfile(p AuthorProfile) db.AuthorProfile {
+	return db.AuthorProfile{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Login:     p.Login,
+		Name:      p.Name,
+		AvatarURL: p.AvatarUrl,
+		Type:      p.Type,
+		FetchedAt: parseTime(p.FetchedAt),
+	}
+}
+
+func toDBBulkOperation(o BulkOperation) db.BulkOperation {
+	return db.BulkOperation{
+		ID:        o.ID,
+		UserID:    o.UserID,
+		Operation: o.Operation,
+		CreatedAt: parseTime(o.CreatedAt),
+		UndoneAt:  parseNullTime(o.UndoneAt),
+	}
+}
+
+// --- SavedReply type conversion ---
+
+func toDBSavedReply(r SavedReply) db.SavedReply {
+	return db.SavedReply{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		GithubID:  r.GithubID,
+		Title:     r.Title,
+		Body:      r.Body,
+		FetchedAt: parseTime(r.FetchedAt),
+	}
+}
+
+// --- LinkedAccount type conversion ---
+
+func toDBAPIToken(t ApiToken) db.APIToken {
+	return db.APIToken{
+		ID:          t.ID,
+		UserID:      t.UserID,
+		Name:        t.Name,
+		TokenHash:   t.TokenHash,
+		TokenPrefix: t.TokenPrefix,
+		Scope:       t.Scope,
+		Account:     t.Account,
+		CreatedAt:   parseTime(t.CreatedAt),
+		ExpiresAt:   parseNullTime(t.ExpiresAt),
+		LastUsedAt:  parseNullTime(t.LastUsedAt),
+	}
+}
+
+func toDBIntegration(i Integration) db.Integration {
+	return db.Integration{
+		ID:              i.ID,
+		UserID:          i.UserID,
+		Name:            i.Name,
+		Format:          i.Format,
+		URL:             i.Url,
+		SecretEncrypted: i.SecretEncrypted,
+		CreatedAt:       parseTime(i.CreatedAt),
+		UpdatedAt:       parseTime(i.UpdatedAt),
+	}
+}
+
+func toDBIntegrationDelivery(d IntegrationDelivery) db.IntegrationDelivery {
+	return db.IntegrationDelivery{
+		ID:             d.ID,
+		UserID:         d.UserID,
+		IntegrationID:  d.IntegrationID,
+		RuleID:         d.RuleID,
+		RuleName:       d.RuleName,
+		GithubID:       d.GithubID,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  parseTime(d.NextAttemptAt),
+		CreatedAt:      parseTime(d.CreatedAt),
+		DeliveredAt:    parseNullTime(d.DeliveredAt),
+	}
+}
+
+func toDBLinkedAccount(a LinkedAccount) db.LinkedAccount {
+	return db.LinkedAccount{
+		ID:             a.ID,
+		UserID:         a.UserID,
+		GithubUserID:   a.GithubUserID,
+		Login:          a.Login,
+		TokenEncrypted: a.TokenEncrypted,
+		LastSyncedAt:   parseNullTime(a.LastSyncedAt),
+		CreatedAt:      parseTime(a.CreatedAt),
+	}
+}
+
+func toDBOrgOffboarding(o OrgOffboarding) db.OrgOffboarding {
+	return db.OrgOffboarding{
+		ID:                    o.ID,
+		UserID:                o.UserID,
+		Org:                   o.Org,
+		Action:                o.Action,
+		NotificationsAffected: o.NotificationsAffected,
+		Repositories:          o.Repositories,
+		CreatedAt:             parseTime(o.CreatedAt),
+	}
+}
+
+func toDBRecentAccess(r RecentAccess) db.RecentAccess {
+	return db.RecentAccess{
+		UserID:     r.UserID,
+		Kind:       r.Kind,
+		Target:     r.Target,
+		AccessedAt: parseTime(r.AccessedAt),
+	}
+}
+
+func toDBNotificationAlert(a NotificationAlert) db.NotificationAlert {
+	return db.NotificationAlert{
+		ID:             a.ID,
+		UserID:         a.UserID,
+		NotificationID: a.NotificationID,
+		Level:          a.Level,
+		Sound:          a.Sound,
+		Source:         a.Source,
+		SourceID:       a.SourceID,
+		Suppressed:     a.Suppressed,
+		CreatedAt:      parseTime(a.CreatedAt),
+	}
+}
+
+func toDBNotificationEvent(e NotificationEvent) db.NotificationEvent {
+	return db.NotificationEvent{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		NotificationID: e.NotificationID,
+		Action:         e.Action,
+		Source:         e.Source,
+		Detail:         e.Detail,
+		CreatedAt:      parseTime(e.CreatedAt),
+	}
+}
+
+func toDBRuleEvaluation(e RuleEvaluation) db.RuleEvaluation {
+	return db.RuleEvaluation{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		NotificationID: e.NotificationID,
+		Trace:          toRawMessage(e.Trace),
+		EvaluatedAt:    parseTime(e.EvaluatedAt),
+	}
+}
+
+func toDBChangelogEntry(e ChangelogEntry) db.ChangelogEntry {
+	return db.ChangelogEntry{
+		ID:             e.ID,
+		Key:            e.Key,
+		SchemaVersion:  e.SchemaVersion,
+		Kind:           e.Kind,
+		Title:          e.Title,
+		Description:    e.Description,
+		CreatedAt:      parseTime(e.CreatedAt),
+		AcknowledgedAt: parseNullTime(e.AcknowledgedAt),
+	}
+}
+
+// --- RepositoryDigest type conversion ---
+
+// foldRepositoryDigestRows folds digest query rows into one digest per repository. Each
+// row carries either a reason's count (reason_rank 1), one of the recent unread
+// notifications (recent_rank <= 3), or both; rows arrive ordered by recent_rank.
+func foldRepositoryDigestRows(rows []ListRepositoryDigestRowsRow) map[int64]db.RepositoryDigest {
+	digests := make(map[int64]db.RepositoryDigest)
+	for _, r := range rows {
+		d, ok := digests[r.RepositoryID]
+		if !ok {
+			d = db.RepositoryDigest{
+				RepositoryID:   r.RepositoryID,
+				UnreadCount:    r.UnreadCount,
+				ReasonCounts:   make(map[string]int64),
+				OldestUnreadAt: parseTime(r.OldestUnreadAt),
+			}
+		}
+		if r.ReasonRank == 1 {
+			d.ReasonCounts[r.Reason] = r.ReasonCount
+		}
+		if r.RecentRank <= 3 {
+			d.RecentUnread = append(d.RecentUnread, db.RepositoryDigestItem{
+				GithubID:     r.GithubID,
+				SubjectTitle: r.SubjectTitle,
+				UpdatedAt:    parseTime(r.UpdatedAt),
+			})
+		}
+		digests[r.RepositoryID] = d
 	}
+	return digests
 }
 
 // --- SyncState type conversion ---
@@ -382,6 +756,9 @@ func toDBGetSyncStateRow(ss SyncState) db.GetSyncStateRow {
 		LatestNotificationAt:       parseNullTime(ss.LatestNotificationAt),
 		InitialSyncCompletedAt:     parseNullTime(ss.InitialSyncCompletedAt),
 		OldestNotificationSyncedAt: parseNullTime(ss.OldestNotificationSyncedAt),
+		InitialSyncStartedAt:       parseNullTime(ss.InitialSyncStartedAt),
+		InitialSyncPages:           ss.InitialSyncPages,
+		InitialSyncQueued:          ss.InitialSyncQueued,
 	}
 }
 
@@ -396,5 +773,8 @@ func toDBUpsertSyncStateRow(ss SyncState) db.UpsertSyncStateRow {
 		LatestNotificationAt:       parseNullTime(ss.LatestNotificationAt),
 		InitialSyncCompletedAt:     parseNullTime(ss.InitialSyncCompletedAt),
 		OldestNotificationSyncedAt: parseNullTime(ss.OldestNotificationSyncedAt),
+		InitialSyncStartedAt:       parseNullTime(ss.InitialSyncStartedAt),
+		InitialSyncPages:           ss.InitialSyncPages,
+		InitialSyncQueued:          ss.InitialSyncQueued,
 	}
 }
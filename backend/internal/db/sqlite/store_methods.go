@@ -18,8 +18,11 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
 )
@@ -70,6 +73,34 @@ func (s *Store) ListNotificationsFromQuery(
 	return listNotificationsFromQuery(ctx, s, userID, query)
 }
 
+// ListNotificationGithubIDsFromQuery lists the GitHub IDs of all notifications matching a query
+func (s *Store) ListNotificationGithubIDsFromQuery(
+	ctx context.Context,
+	userID string,
+	query db.NotificationQuery,
+) ([]string, error) {
+	return listNotificationGithubIDsFromQuery(ctx, s, userID, query)
+}
+
+// ListNotificationGroupsFromQuery groups notifications matching a query by a key
+func (s *Store) ListNotificationGroupsFromQuery(
+	ctx context.Context,
+	userID string,
+	params db.NotificationGroupsParams,
+) ([]db.NotificationGroup, error) {
+	return listNotificationGroupsFromQuery(ctx, s, userID, params)
+}
+
+// ExplainNotificationQuery returns the statement listing a page of a query's notifications
+// and SQLite's plan for it
+func (s *Store) ExplainNotificationQuery(
+	ctx context.Context,
+	userID string,
+	query db.NotificationQuery,
+) (db.NotificationQueryPlan, error) {
+	return explainNotificationQuery(ctx, s, userID, query)
+}
+
 // MarkNotificationRead marks a notification as read
 func (s *Store) MarkNotificationRead(
 	ctx context.Context,
@@ -185,10 +216,11 @@ func (s *Store) SnoozeNotification(
 	}
 	n, err := db.RetryOnBusy(ctx, func() (Notification, error) {
 		return s.q.SnoozeNotification(ctx, SnoozeNotificationParams{
-			SnoozedUntil:      snoozedUntil,
-			EffectiveSortDate: effectiveSortDate,
-			UserID:            userID,
-			GithubID:          arg.GithubID,
+			SnoozedUntil:       snoozedUntil,
+			EffectiveSortDate:  effectiveSortDate,
+			SnoozeUntilUpdated: boolToInt64(arg.UntilUpdated),
+			UserID:             userID,
+			GithubID:           arg.GithubID,
 		})
 	})
 	if err != nil {
@@ -306,7 +338,8 @@ func (s *Store) BulkSnoozeNotifications(
 	query := fmt.Sprintf(
 		`UPDATE notifications SET snoozed_until = ?, `+
 			`snoozed_at = strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', 'now'), `+
-			`effective_sort_date = ? WHERE user_id = ? AND github_id IN (%s)`,
+			`effective_sort_date = ?, snooze_until_updated = 0, returned_from_snooze_at = NULL `+
+			`WHERE user_id = ? AND github_id IN (%s)`,
 		strings.Join(placeholders, ","),
 	)
 	var result sql.Result
@@ -348,7 +381,8 @@ func (s *Store) BulkArchiveNotifications(
 	return s.bulkUpdate(
 		ctx,
 		userID,
-		"archived = 1, snoozed_until = NULL, snoozed_at = NULL, "+
+		"archived = 1, archived_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), "+
+			"snoozed_until = NULL, snoozed_at = NULL, "+
 			"effective_sort_date = COALESCE(github_updated_at, imported_at)",
 		githubIDs,
 	)
@@ -386,7 +420,8 @@ func (s *Store) BulkMuteNotifications(
 	return s.bulkUpdate(
 		ctx,
 		userID,
-		"muted = 1, snoozed_until = NULL, snoozed_at = NULL, effective_sort_date = COALESCE(github_updated_at, imported_at)",
+		"muted = 1, snoozed_until = NULL, snoozed_at = NULL, "+
+			"effective_sort_date = COALESCE(github_updated_at, imported_at)",
 		githubIDs,
 	)
 }
@@ -462,6 +497,112 @@ func (s *Store) bulkUpdate(
 	return result.RowsAffected()
 }
 
+// ListNotificationGithubIDsBySubjectURL returns the GitHub IDs of every notification about a
+// subject.
+func (s *Store) ListNotificationGithubIDsBySubjectURL(
+	ctx context.Context,
+	userID, subjectURL string,
+) ([]string, error) {
+	return db.RetryOnBusy(ctx, func() ([]string, error) {
+		return s.q.ListGithubIDsBySubjectURL(ctx, ListGithubIDsBySubjectURLParams{
+			UserID:     userID,
+			SubjectUrl: sql.NullString{String: subjectURL, Valid: true},
+		})
+	})
+}
+
+// ListNotificationAuthors lists up to limit distinct notification authors whose login
+// starts with prefix, ignoring case, most frequent first
+func (s *Store) ListNotificationAuthors(
+	ctx context.Context,
+	userID, prefix string,
+	limit int,
+) ([]string, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]sql.NullString, error) {
+		return s.q.ListNotificationAuthors(ctx, ListNotificationAuthorsParams{
+			UserID:  userID,
+			Pattern: sql.NullString{String: escapeLike(prefix) + "%", Valid: true},
+			Limit:   int64(limit),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make([]string, 0, len(rows))
+	for _, row := range rows {
+		authors = append(authors, row.String)
+	}
+	return authors, nil
+}
+
+// ListNotificationLabels lists up to limit distinct issue and pull request labels that
+// start with prefix, ignoring case, most used first
+func (s *Store) ListNotificationLabels(
+	ctx context.Context,
+	userID, prefix string,
+	limit int,
+) ([]string, error) {
+	labels, err := db.RetryOnBusy(ctx, func() ([]string, error) {
+		return s.q.ListNotificationLabels(ctx, ListNotificationLabelsParams{
+			UserID:  userID,
+			Pattern: escapeLike(prefix) + "%",
+			Limit:   int64(limit),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+	return labels, nil
+}
+
+// MarkNotificationsReadBySubjectURL marks every unread notification about a subject as read in
+// one transaction and returns the GitHub IDs it changed.
+func (s *Store) MarkNotificationsReadBySubjectURL(
+	ctx context.Context,
+	userID, subjectURL string,
+) ([]string, error) {
+	return db.RetryOnBusy(ctx, func() ([]string, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		subject := sql.NullString{String: subjectURL, Valid: true}
+
+		githubIDs, err := qtx.ListUnreadGithubIDsBySubjectURL(
+			ctx,
+			ListUnreadGithubIDsBySubjectURLParams{UserID: userID, SubjectUrl: subject},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(githubIDs) == 0 {
+			return nil, nil
+		}
+
+		if _, err := qtx.MarkNotificationsReadBySubjectURL(
+			ctx,
+			MarkNotificationsReadBySubjectURLParams{UserID: userID, SubjectUrl: subject},
+		); err != nil {
+			return nil, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return githubIDs, nil
+	})
+}
+
 // --- Bulk by query methods (implemented in query_executor.go) ---
 
 // BulkMarkNotificationsReadByQuery marks notifications as read by query
@@ -492,7 +633,8 @@ func (s *Store) BulkArchiveNotificationsByQuery(
 		ctx,
 		s,
 		userID,
-		"archived = 1, snoozed_until = NULL, snoozed_at = NULL, "+
+		"archived = 1, archived_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), "+
+			"snoozed_until = NULL, snoozed_at = NULL, "+
 			"effective_sort_date = COALESCE(github_updated_at, imported_at)",
 		query,
 	)
@@ -516,6 +658,26 @@ func (s *Store) BulkSnoozeNotificationsByQuery(
 	return bulkSnoozeByQuery(ctx, s, userID, arg)
 }
 
+// BulkAssignTagByQuery assigns a tag to the notifications matching a query. Returns how
+// many didn't have the tag already.
+func (s *Store) BulkAssignTagByQuery(
+	ctx context.Context,
+	userID string,
+	arg db.BulkTagNotificationsByQueryParams,
+) (int64, error) {
+	return bulkTagByQuery(ctx, s, userID, arg, false)
+}
+
+// BulkRemoveTagByQuery removes a tag from the notifications matching a query. Returns how
+// many had the tag.
+func (s *Store) BulkRemoveTagByQuery(
+	ctx context.Context,
+	userID string,
+	arg db.BulkTagNotificationsByQueryParams,
+) (int64, error) {
+	return bulkTagByQuery(ctx, s, userID, arg, true)
+}
+
 // BulkUnsnoozeNotificationsByQuery marks notifications as unsnoozed by query
 func (s *Store) BulkUnsnoozeNotificationsByQuery(
 	ctx context.Context,
@@ -541,7 +703,8 @@ func (s *Store) BulkMuteNotificationsByQuery(
 		ctx,
 		s,
 		userID,
-		"muted = 1, snoozed_until = NULL, snoozed_at = NULL, effective_sort_date = COALESCE(github_updated_at, imported_at)",
+		"muted = 1, snoozed_until = NULL, snoozed_at = NULL, "+
+			"effective_sort_date = COALESCE(github_updated_at, imported_at)",
 		query,
 	)
 }
@@ -573,6 +736,192 @@ func (s *Store) BulkUnstarNotificationsByQuery(
 	return bulkUpdateByQuery(ctx, s, userID, "starred = 0", query)
 }
 
+// BulkMarkNotificationsFilteredByQuery marks notifications as filtered by query
+func (s *Store) BulkMarkNotificationsFilteredByQuery(
+	ctx context.Context,
+	userID string,
+	query db.NotificationQuery,
+) (int64, error) {
+	return bulkUpdateByQuery(ctx, s, userID, "filtered = 1", query)
+}
+
+// --- Bulk undo methods ---
+
+// bulkUndoColumns lists the columns each bulk operation changes, which are the ones undoing
+// it puts back. Anything else changed since, like starring an archived notification, is kept.
+var bulkUndoColumns = map[string][]string{
+	"mark-read":   {"is_read"},
+	"mark-unread": {"is_read"},
+	"archive":     {"archived", "archived_at", "snoozed_until", "snoozed_at", "effective_sort_date"},
+	"unarchive":   {"archived"},
+	"mute":        {"muted", "snoozed_until", "snoozed_at", "effective_sort_date"},
+	"unmute":      {"muted"},
+	"star":        {"starred"},
+	"unstar":      {"starred"},
+	"unfilter":    {"filtered"},
+	"snooze": {
+		"snoozed_until", "snoozed_at", "effective_sort_date", "snooze_until_updated",
+		"returned_from_snooze_at",
+	},
+	"unsnooze": {"snoozed_until", "snoozed_at", "effective_sort_date"},
+}
+
+// CaptureBulkOperation saves the current state of the notifications a bulk operation is
+// about to change and returns the operation's ID. Operations older than arg.PruneBefore are
+// removed in the same transaction.
+func (s *Store) CaptureBulkOperation(
+	ctx context.Context,
+	userID string,
+	arg db.CaptureBulkOperationParams,
+) (int64, error) {
+	if _, ok := bulkUndoColumns[arg.Operation]; !ok {
+		return 0, fmt.Errorf("bulk operation %q can't be undone", arg.Operation)
+	}
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		pruneBefore := db.FormatTimestamp(arg.PruneBefore)
+
+		if err := qtx.DeleteBulkOperationStatesBefore(ctx, DeleteBulkOperationStatesBeforeParams{
+			UserID: userID,
+			Before: pruneBefore,
+		}); err != nil {
+			return 0, err
+		}
+		if err := qtx.DeleteBulkOperationsBefore(ctx, DeleteBulkOperationsBeforeParams{
+			UserID:    userID,
+			CreatedAt: pruneBefore,
+		}); err != nil {
+			return 0, err
+		}
+
+		operation, err := qtx.CreateBulkOperation(ctx, CreateBulkOperationParams{
+			UserID:    userID,
+			Operation: arg.Operation,
+			CreatedAt: db.FormatTimestamp(arg.CreatedAt),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		// The snapshot is inserted in batches so a large operation stays under SQLite's
+		// limit on bound variables
+		if err := inBatches(arg.GithubIDs, func(batch []string) error {
+			args := make([]interface{}, 0, len(batch)+2)
+			args = append(args, operation.ID, userID)
+			for _, id := range batch {
+				args = append(args, id)
+			}
+			query := inList(`INSERT INTO bulk_operation_states (
+					operation_id, user_id, github_id, is_read, archived, archived_at, muted, starred,
+					filtered, snoozed_until, snoozed_at, snooze_until_updated,
+					returned_from_snooze_at, effective_sort_date
+				)
+				SELECT ?, n.user_id, n.github_id, n.is_read, n.archived, n.archived_at, n.muted,
+					n.starred, n.filtered, n.snoozed_until, n.snoozed_at, n.snooze_until_updated,
+					n.returned_from_snooze_at, n.effective_sort_date
+				FROM notifications n
+				WHERE n.user_id = ? AND n.github_id IN (%s)`,
+				len(batch),
+			)
+			_, err := tx.ExecContext(ctx, query, args...)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return operation.ID, tx.Commit()
+	})
+}
+
+// GetBulkOperation gets a captured bulk operation by ID
+func (s *Store) GetBulkOperation(ctx context.Context, userID string, id int64) (db.BulkOperation, error) {
+	operation, err := db.RetryOnBusy(ctx, func() (BulkOperation, error) {
+		return s.q.GetBulkOperation(ctx, GetBulkOperationParams{UserID: userID, ID: id})
+	})
+	if err != nil {
+		return db.BulkOperation{}, err
+	}
+	return toDBBulkOperation(operation), nil
+}
+
+// UndoBulkOperation puts back the state a bulk operation changed, in one transaction, and
+// returns the GitHub IDs of the notifications it restored. It returns sql.ErrNoRows if the
+// operation doesn't exist or was already undone.
+func (s *Store) UndoBulkOperation(
+	ctx context.Context,
+	userID string,
+	id int64,
+	undoneAt time.Time,
+) ([]string, error) {
+	return db.RetryOnBusy(ctx, func() ([]string, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		operation, err := qtx.GetBulkOperation(ctx, GetBulkOperationParams{UserID: userID, ID: id})
+		if err != nil {
+			return nil, err
+		}
+		columns, ok := bulkUndoColumns[operation.Operation]
+		if !ok {
+			return nil, fmt.Errorf("bulk operation %q can't be undone", operation.Operation)
+		}
+
+		marked, err := qtx.MarkBulkOperationUndone(ctx, MarkBulkOperationUndoneParams{
+			UndoneAt: sql.NullString{String: db.FormatTimestamp(undoneAt), Valid: true},
+			UserID:   userID,
+			ID:       id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if marked == 0 {
+			return nil, sql.ErrNoRows
+		}
+
+		assignments := make([]string, len(columns))
+		for i, column := range columns {
+			assignments[i] = column + " = s." + column
+		}
+		//nolint:gosec // G201: SQL string formatting is safe - columns come from bulkUndoColumns
+		query := fmt.Sprintf(`UPDATE notifications SET %s
+			FROM bulk_operation_states s
+			WHERE s.operation_id = ? AND s.user_id = ?
+				AND notifications.user_id = s.user_id AND notifications.github_id = s.github_id`,
+			strings.Join(assignments, ", "),
+		)
+		if _, err := tx.ExecContext(ctx, query, id, userID); err != nil {
+			return nil, err
+		}
+
+		githubIDs, err := qtx.ListBulkOperationGithubIDs(ctx, ListBulkOperationGithubIDsParams{
+			UserID:      userID,
+			OperationID: id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return githubIDs, tx.Commit()
+	})
+}
+
 // GetTag gets a tag by ID
 func (s *Store) GetTag(ctx context.Context, userID, id string) (db.Tag, error) {
 	t, err := db.RetryOnBusy(ctx, func() (Tag, error) {
@@ -601,6 +950,20 @@ func (s *Store) GetTagByName(ctx context.Context, userID, name string) (db.Tag,
 	return toDBTag(t), nil
 }
 
+// GetTagBySlug gets a tag by slug
+func (s *Store) GetTagBySlug(ctx context.Context, userID, slug string) (db.Tag, error) {
+	t, err := db.RetryOnBusy(ctx, func() (Tag, error) {
+		return s.q.GetTagBySlug(ctx, GetTagBySlugParams{
+			UserID: userID,
+			Slug:   slug,
+		})
+	})
+	if err != nil {
+		return db.Tag{}, err
+	}
+	return toDBTag(t), nil
+}
+
 // ListAllTags lists all tags
 func (s *Store) ListAllTags(ctx context.Context, userID string) ([]db.Tag, error) {
 	tags, err := db.RetryOnBusy(ctx, func() ([]Tag, error) {
@@ -669,6 +1032,58 @@ func (s *Store) DeleteTag(ctx context.Context, userID, id string) error {
 	})
 }
 
+// GetRulesByTagID returns the rules whose actions assign or remove a tag
+func (s *Store) GetRulesByTagID(ctx context.Context, userID, tagID string) ([]db.Rule, error) {
+	rules, err := db.RetryOnBusy(ctx, func() ([]Rule, error) {
+		return s.q.GetRulesByTagID(ctx, GetRulesByTagIDParams{
+			UserID: userID,
+			TagID:  tagID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBRules(rules), nil
+}
+
+// DeleteTagAndDisableRules disables every rule that assigns or removes a tag and then
+// deletes the tag, in one transaction. It returns the rules that were disabled.
+func (s *Store) DeleteTagAndDisableRules(
+	ctx context.Context,
+	userID, id string,
+) ([]db.Rule, error) {
+	rules, err := db.RetryOnBusy(ctx, func() ([]Rule, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		disabled, err := qtx.DisableRulesByTagID(ctx, DisableRulesByTagIDParams{
+			UserID: userID,
+			TagID:  id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := qtx.DeleteTag(ctx, DeleteTagParams{UserID: userID, ID: id}); err != nil {
+			return nil, err
+		}
+
+		return disabled, tx.Commit()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBRules(rules), nil
+}
+
 // UpdateTagDisplayOrder updates the display order of a tag
 func (s *Store) UpdateTagDisplayOrder(
 	ctx context.Context,
@@ -690,7 +1105,7 @@ func (s *Store) ListTagsForEntity(
 	userID string,
 	arg db.ListTagsForEntityParams,
 ) ([]db.Tag, error) {
-	tags, err := db.RetryOnBusy(ctx, func() ([]Tag, error) {
+	tags, err := db.RetryOnBusy(ctx, func() ([]ListTagsForEntityRow, error) {
 		return s.q.ListTagsForEntity(ctx, ListTagsForEntityParams{
 			UserID:     userID,
 			EntityType: arg.EntityType,
@@ -702,11 +1117,84 @@ func (s *Store) ListTagsForEntity(
 	}
 	result := make([]db.Tag, len(tags))
 	for i, t := range tags {
-		result[i] = toDBTag(t)
+		result[i] = toDBTag(Tag{
+			ID:           t.ID,
+			UserID:       t.UserID,
+			Name:         t.Name,
+			Color:        t.Color,
+			Description:  t.Description,
+			CreatedAt:    t.CreatedAt,
+			DisplayOrder: t.DisplayOrder,
+			Slug:         t.Slug,
+		})
+		result[i].AssignedAt = parseNullTime(sql.NullString{String: t.AssignedAt, Valid: true})
 	}
 	return result, nil
 }
 
+// ListTagsForNotifications lists the tags of several notifications in one query, keyed by
+// notification ID. Notifications without tags are left out of the map.
+func (s *Store) ListTagsForNotifications(
+	ctx context.Context,
+	userID string,
+	notificationIDs []int64,
+) (map[int64][]db.Tag, error) {
+	if len(notificationIDs) == 0 {
+		return map[int64][]db.Tag{}, nil
+	}
+	args := make([]interface{}, 0, len(notificationIDs)+1)
+	args = append(args, userID)
+	for _, id := range notificationIDs {
+		args = append(args, id)
+	}
+	query := inList(`SELECT ta.entity_id, t.id, t.user_id, t.name, t.color, t.description,
+			t.created_at, t.display_order, t.slug, ta.created_at
+		FROM tags t
+		JOIN tag_assignments ta ON t.id = ta.tag_id
+		WHERE t.user_id = ? AND ta.entity_type = 'notification' AND ta.entity_id IN (%s)
+		ORDER BY t.display_order, t.name`,
+		len(notificationIDs),
+	)
+	return db.RetryOnBusy(ctx, func() (map[int64][]db.Tag, error) {
+		rows, err := s.dbConn.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+		result := make(map[int64][]db.Tag)
+		for rows.Next() {
+			var (
+				notificationID int64
+				t              Tag
+				assignedAt     string
+			)
+			if err := rows.Scan(
+				&notificationID,
+				&t.ID,
+				&t.UserID,
+				&t.Name,
+				&t.Color,
+				&t.Description,
+				&t.CreatedAt,
+				&t.DisplayOrder,
+				&t.Slug,
+				&assignedAt,
+			); err != nil {
+				return nil, err
+			}
+			tag := toDBTag(t)
+			tag.AssignedAt = parseNullTime(sql.NullString{String: assignedAt, Valid: true})
+			result[notificationID] = append(result[notificationID], tag)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
 // AssignTagToEntity assigns a tag to an entity
 func (s *Store) AssignTagToEntity(
 	ctx context.Context,
@@ -850,6 +1338,30 @@ func (s *Store) UpdateView(
 	return toDBView(v), nil
 }
 
+// UpdateViewWIPLimit sets or clears a view's WIP limit
+func (s *Store) UpdateViewWIPLimit(
+	ctx context.Context,
+	userID string,
+	arg db.UpdateViewWIPLimitParams,
+) (db.View, error) {
+	autoSnooze := int64(0)
+	if arg.AutoSnooze {
+		autoSnooze = 1
+	}
+	v, err := db.RetryOnBusy(ctx, func() (View, error) {
+		return s.q.UpdateViewWIPLimit(ctx, UpdateViewWIPLimitParams{
+			WipLimit:      arg.Limit,
+			WipAutoSnooze: autoSnooze,
+			UserID:        userID,
+			ID:            arg.ID,
+		})
+	})
+	if err != nil {
+		return db.View{}, err
+	}
+	return toDBView(v), nil
+}
+
 // DeleteView deletes a view
 func (s *Store) DeleteView(ctx context.Context, userID, id string) (int64, error) {
 	return db.RetryOnBusy(ctx, func() (int64, error) {
@@ -860,6 +1372,49 @@ func (s *Store) DeleteView(ctx context.Context, userID, id string) (int64, error
 	})
 }
 
+// DeleteViewAndDisableRules disables every rule linked to a view and then deletes the view,
+// in one transaction. It returns the rules that were disabled, or sql.ErrNoRows if the view
+// doesn't exist.
+func (s *Store) DeleteViewAndDisableRules(
+	ctx context.Context,
+	userID, id string,
+) ([]db.Rule, error) {
+	rules, err := db.RetryOnBusy(ctx, func() ([]Rule, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		disabled, err := qtx.DisableRulesByViewID(ctx, DisableRulesByViewIDParams{
+			UserID: userID,
+			ViewID: sql.NullString{String: id, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		deleted, err := qtx.DeleteView(ctx, DeleteViewParams{UserID: userID, ID: id})
+		if err != nil {
+			return nil, err
+		}
+		if deleted == 0 {
+			return nil, sql.ErrNoRows
+		}
+
+		return disabled, tx.Commit()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBRules(rules), nil
+}
+
 // UpdateViewOrder updates the display order of a view
 func (s *Store) UpdateViewOrder(
 	ctx context.Context,
@@ -875,28 +1430,242 @@ func (s *Store) UpdateViewOrder(
 	})
 }
 
-// GetRulesByViewID gets rules by view ID
-func (s *Store) GetRulesByViewID(
+// SetViewGroup moves a view into a group, or out of its group when GroupID is null
+func (s *Store) SetViewGroup(
 	ctx context.Context,
 	userID string,
-	viewID sql.NullString,
-) ([]db.Rule, error) {
-	rules, err := db.RetryOnBusy(ctx, func() ([]Rule, error) {
-		return s.q.GetRulesByViewID(ctx, GetRulesByViewIDParams{
-			UserID: userID,
-			ViewID: viewID,
-		})
+	arg db.SetViewGroupParams,
+) (db.View, error) {
+	v, err := db.RetryOnBusy(ctx, func() (View, error) {
+		return s.q.SetViewGroup(ctx, SetViewGroupParams{
+			GroupID: arg.GroupID,
+			UserID:  userID,
+			ID:      arg.ViewID,
+		})
+	})
+	if err != nil {
+		return db.View{}, err
+	}
+	return toDBView(v), nil
+}
+
+// GetViewGroup gets a view group by ID
+func (s *Store) GetViewGroup(ctx context.Context, userID, id string) (db.ViewGroup, error) {
+	g, err := db.RetryOnBusy(ctx, func() (ViewGroup, error) {
+		return s.q.GetViewGroup(ctx, GetViewGroupParams{
+			UserID: userID,
+			ID:     id,
+		})
+	})
+	if err != nil {
+		return db.ViewGroup{}, err
+	}
+	return toDBViewGroup(g), nil
+}
+
+// ListViewGroups lists all view groups in display order
+func (s *Store) ListViewGroups(ctx context.Context, userID string) ([]db.ViewGroup, error) {
+	groups, err := db.RetryOnBusy(ctx, func() ([]ViewGroup, error) {
+		return s.q.ListViewGroups(ctx, userID)
 	})
 	if err != nil {
 		return nil, err
 	}
-	result := make([]db.Rule, len(rules))
-	for i, r := range rules {
-		result[i] = toDBRule(r)
+	result := make([]db.ViewGroup, len(groups))
+	for i, g := range groups {
+		result[i] = toDBViewGroup(g)
 	}
 	return result, nil
 }
 
+// CreateViewGroup creates a view group after the existing ones
+func (s *Store) CreateViewGroup(ctx context.Context, userID, name string) (db.ViewGroup, error) {
+	g, err := db.RetryOnBusy(ctx, func() (ViewGroup, error) {
+		return s.q.CreateViewGroup(ctx, CreateViewGroupParams{
+			UserID: userID,
+			Name:   name,
+		})
+	})
+	if err != nil {
+		return db.ViewGroup{}, err
+	}
+	return toDBViewGroup(g), nil
+}
+
+// UpdateViewGroup renames a view group or sets whether it's collapsed
+func (s *Store) UpdateViewGroup(
+	ctx context.Context,
+	userID string,
+	arg db.UpdateViewGroupParams,
+) (db.ViewGroup, error) {
+	var collapsed sql.NullInt64
+	if arg.Collapsed.Valid {
+		collapsed = sql.NullInt64{Int64: boolToInt64(arg.Collapsed.Bool), Valid: true}
+	}
+	g, err := db.RetryOnBusy(ctx, func() (ViewGroup, error) {
+		return s.q.UpdateViewGroup(ctx, UpdateViewGroupParams{
+			Name:      arg.Name,
+			Collapsed: collapsed,
+			UserID:    userID,
+			ID:        arg.ID,
+		})
+	})
+	if err != nil {
+		return db.ViewGroup{}, err
+	}
+	return toDBViewGroup(g), nil
+}
+
+// UpdateViewGroupOrder updates the display order of a view group
+func (s *Store) UpdateViewGroupOrder(
+	ctx context.Context,
+	userID string,
+	arg db.UpdateViewGroupOrderParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpdateViewGroupOrder(ctx, UpdateViewGroupOrderParams{
+			DisplayOrder: int64(arg.DisplayOrder),
+			UserID:       userID,
+			ID:           arg.ID,
+		})
+	})
+}
+
+// DeleteViewGroup ungroups the views in a group and deletes the group, in one transaction.
+// Returns how many groups were deleted.
+func (s *Store) DeleteViewGroup(ctx context.Context, userID, id string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		err = qtx.UngroupViews(ctx, UngroupViewsParams{
+			UserID:  userID,
+			GroupID: sql.NullString{String: id, Valid: true},
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		deleted, err := qtx.DeleteViewGroup(ctx, DeleteViewGroupParams{UserID: userID, ID: id})
+		if err != nil {
+			return 0, err
+		}
+		return deleted, tx.Commit()
+	})
+}
+
+// --- View share methods ---
+
+// ListViewShares lists who a view is shared with
+func (s *Store) ListViewShares(ctx context.Context, userID, viewID string) ([]db.ViewShare, error) {
+	shares, err := db.RetryOnBusy(ctx, func() ([]ViewShare, error) {
+		return s.q.ListViewShares(ctx, ListViewSharesParams{UserID: userID, ViewID: viewID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBViewShares(shares), nil
+}
+
+// ListViewSharesByGrantee lists the views shared with a user, oldest share first
+func (s *Store) ListViewSharesByGrantee(
+	ctx context.Context,
+	grantee string,
+) ([]db.ViewShare, error) {
+	shares, err := db.RetryOnBusy(ctx, func() ([]ViewShare, error) {
+		return s.q.ListViewSharesByGrantee(ctx, grantee)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBViewShares(shares), nil
+}
+
+// GetViewShare gets the share of a view with a user
+func (s *Store) GetViewShare(ctx context.Context, viewID, grantee string) (db.ViewShare, error) {
+	share, err := db.RetryOnBusy(ctx, func() (ViewShare, error) {
+		return s.q.GetViewShare(ctx, GetViewShareParams{ViewID: viewID, Grantee: grantee})
+	})
+	if err != nil {
+		return db.ViewShare{}, err
+	}
+	return toDBViewShare(share), nil
+}
+
+// UpsertViewShare shares a view with a user, or changes the permission of an existing share
+func (s *Store) UpsertViewShare(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertViewShareParams,
+) (db.ViewShare, error) {
+	share, err := db.RetryOnBusy(ctx, func() (ViewShare, error) {
+		return s.q.UpsertViewShare(ctx, UpsertViewShareParams{
+			UserID:     userID,
+			ViewID:     arg.ViewID,
+			Grantee:    arg.Grantee,
+			Permission: arg.Permission,
+			CreatedAt:  formatTime(arg.CreatedAt),
+		})
+	})
+	if err != nil {
+		return db.ViewShare{}, err
+	}
+	return toDBViewShare(share), nil
+}
+
+// DeleteViewShare stops sharing a view with a user
+func (s *Store) DeleteViewShare(
+	ctx context.Context,
+	userID, viewID, grantee string,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteViewShare(ctx, DeleteViewShareParams{
+			UserID:  userID,
+			ViewID:  viewID,
+			Grantee: grantee,
+		})
+	})
+}
+
+// DeleteViewShareByGrantee removes a view shared with a user, on the user's behalf
+func (s *Store) DeleteViewShareByGrantee(
+	ctx context.Context,
+	viewID, grantee string,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteViewShareByGrantee(ctx, DeleteViewShareByGranteeParams{
+			ViewID:  viewID,
+			Grantee: grantee,
+		})
+	})
+}
+
+// GetRulesByViewID gets rules by view ID
+func (s *Store) GetRulesByViewID(
+	ctx context.Context,
+	userID string,
+	viewID sql.NullString,
+) ([]db.Rule, error) {
+	rules, err := db.RetryOnBusy(ctx, func() ([]Rule, error) {
+		return s.q.GetRulesByViewID(ctx, GetRulesByViewIDParams{
+			UserID: userID,
+			ViewID: viewID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDBRules(rules), nil
+}
+
 // --- Rule methods ---
 
 // GetRule gets a rule by ID
@@ -951,14 +1720,17 @@ func (s *Store) CreateRule(
 ) (db.Rule, error) {
 	r, err := db.RetryOnBusy(ctx, func() (Rule, error) {
 		return s.q.CreateRule(ctx, CreateRuleParams{
-			UserID:       userID,
-			Name:         arg.Name,
-			Description:  arg.Description,
-			Query:        arg.Query,
-			ViewID:       arg.ViewID,
-			Enabled:      boolToInt64(arg.Enabled),
-			Actions:      string(arg.Actions),
-			DisplayOrder: int64(arg.DisplayOrder),
+			UserID:         userID,
+			Name:           arg.Name,
+			Description:    arg.Description,
+			Query:          arg.Query,
+			ViewID:         arg.ViewID,
+			Enabled:        boolToInt64(arg.Enabled),
+			Actions:        string(arg.Actions),
+			DisplayOrder:   int64(arg.DisplayOrder),
+			Priority:       int64(arg.Priority),
+			StopProcessing: boolToInt64(arg.StopProcessing),
+			Condition:      arg.Condition,
 		})
 	})
 	if err != nil {
@@ -973,20 +1745,15 @@ func (s *Store) UpdateRule(
 	userID string,
 	arg db.UpdateRuleParams,
 ) (db.Rule, error) {
-	// Handle type conversions
-	name := ""
-	if arg.Name.Valid {
-		name = arg.Name.String
+	// Handle type conversions. Fields that aren't set stay NULL so they're left unchanged.
+	var enabled sql.NullInt64
+	if arg.Enabled.Valid {
+		enabled = sql.NullInt64{Int64: boolToInt64(arg.Enabled.Bool), Valid: true}
 	}
 
-	var enabled int64
-	if arg.Enabled.Valid && arg.Enabled.Bool {
-		enabled = 1
-	}
-
-	actions := ""
+	var actions sql.NullString
 	if arg.Actions.Valid {
-		actions = string(arg.Actions.RawMessage)
+		actions = sql.NullString{String: string(arg.Actions.RawMessage), Valid: true}
 	}
 
 	// Handle clearing fields
@@ -1000,16 +1767,27 @@ func (s *Store) UpdateRule(
 		viewID = sql.NullString{}
 	}
 
+	var priority, stopProcessing sql.NullInt64
+	if arg.Priority.Valid {
+		priority = sql.NullInt64{Int64: int64(arg.Priority.Int32), Valid: true}
+	}
+	if arg.StopProcessing.Valid {
+		stopProcessing = sql.NullInt64{Int64: boolToInt64(arg.StopProcessing.Bool), Valid: true}
+	}
+
 	r, err := db.RetryOnBusy(ctx, func() (Rule, error) {
 		return s.q.UpdateRule(ctx, UpdateRuleParams{
-			UserID:      userID,
-			ID:          arg.ID,
-			Name:        name,
-			Description: arg.Description,
-			Query:       query,
-			ViewID:      viewID,
-			Enabled:     enabled,
-			Actions:     actions,
+			UserID:         userID,
+			ID:             arg.ID,
+			Name:           arg.Name,
+			Description:    arg.Description,
+			Query:          query,
+			ViewID:         viewID,
+			Enabled:        enabled,
+			Actions:        actions,
+			Priority:       priority,
+			StopProcessing: stopProcessing,
+			Condition:      arg.Condition,
 		})
 	})
 	if err != nil {
@@ -1043,6 +1821,142 @@ func (s *Store) UpdateRuleOrder(
 	})
 }
 
+// --- Rule evaluation methods ---
+
+// UpsertRuleEvaluation records the latest rule evaluation for a notification
+func (s *Store) UpsertRuleEvaluation(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertRuleEvaluationParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpsertRuleEvaluation(ctx, UpsertRuleEvaluationParams{
+			UserID:         userID,
+			NotificationID: arg.NotificationID,
+			Trace:          string(arg.Trace),
+			EvaluatedAt:    formatTime(arg.EvaluatedAt),
+		})
+	})
+}
+
+// ListRuleEvaluations lists the most recent rule evaluations, newest first
+func (s *Store) ListRuleEvaluations(
+	ctx context.Context,
+	userID string,
+	githubID string,
+	limit int64,
+) ([]db.RuleEvaluation, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListRuleEvaluationsRow, error) {
+		return s.q.ListRuleEvaluations(ctx, ListRuleEvaluationsParams{
+			UserID:   userID,
+			GithubID: githubID,
+			Limit:    limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.RuleEvaluation, len(rows))
+	for i, row := range rows {
+		result[i] = toDBRuleEvaluation(RuleEvaluation{
+			ID:             row.ID,
+			UserID:         row.UserID,
+			NotificationID: row.NotificationID,
+			Trace:          row.Trace,
+			EvaluatedAt:    row.EvaluatedAt,
+		})
+		result[i].GithubID = row.GithubID
+		result[i].SubjectTitle = row.SubjectTitle
+	}
+	return result, nil
+}
+
+// --- Recent access methods ---
+
+// RecordRecentAccess records that something was opened and drops all but the most recent
+// entries of its kind in one transaction
+func (s *Store) RecordRecentAccess(
+	ctx context.Context,
+	userID string,
+	arg db.RecordRecentAccessParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		if err := qtx.RecordRecentAccess(ctx, RecordRecentAccessParams{
+			UserID:     userID,
+			Kind:       arg.Kind,
+			Target:     arg.Target,
+			AccessedAt: formatTime(arg.AccessedAt),
+		}); err != nil {
+			return err
+		}
+		if err := qtx.TrimRecentAccess(ctx, TrimRecentAccessParams{
+			UserID: userID,
+			Kind:   arg.Kind,
+			Keep:   arg.Keep,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// ListRecentAccess lists the most recently opened entries of a kind, newest first
+func (s *Store) ListRecentAccess(
+	ctx context.Context,
+	userID string,
+	kind string,
+	limit int64,
+) ([]db.RecentAccess, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]RecentAccess, error) {
+		return s.q.ListRecentAccess(ctx, ListRecentAccessParams{
+			UserID: userID,
+			Kind:   kind,
+			Limit:  limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.RecentAccess, len(rows))
+	for i, row := range rows {
+		result[i] = toDBRecentAccess(row)
+	}
+	return result, nil
+}
+
+// ClearRecentAccess forgets every entry of a kind
+func (s *Store) ClearRecentAccess(ctx context.Context, userID, kind string) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.ClearRecentAccess(ctx, ClearRecentAccessParams{
+			UserID: userID,
+			Kind:   kind,
+		})
+	})
+}
+
+// GetNotificationRevision returns a counter that goes up whenever any of the user's
+// notifications or their tags change, or 0 before the first change
+func (s *Store) GetNotificationRevision(ctx context.Context, userID string) (int64, error) {
+	revision, err := db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.GetNotificationRevision(ctx, userID)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return revision, err
+}
+
 // --- Repository methods ---
 
 // GetRepositoryByID gets a repository by ID
@@ -1063,6 +1977,41 @@ func (s *Store) GetRepositoryByID(
 	return toDBRepository(r), nil
 }
 
+// GetRepositoryByGithubID gets a repository by its GitHub ID
+func (s *Store) GetRepositoryByGithubID(
+	ctx context.Context,
+	userID string,
+	githubID int64,
+) (db.Repository, error) {
+	r, err := db.RetryOnBusy(ctx, func() (Repository, error) {
+		return s.q.GetRepositoryByGithubID(ctx, GetRepositoryByGithubIDParams{
+			UserID:   userID,
+			GithubID: sql.NullInt64{Int64: githubID, Valid: true},
+		})
+	})
+	if err != nil {
+		return db.Repository{}, err
+	}
+	return toDBRepository(r), nil
+}
+
+// GetRepositoryByFullName gets a repository by its current full name
+func (s *Store) GetRepositoryByFullName(
+	ctx context.Context,
+	userID, fullName string,
+) (db.Repository, error) {
+	r, err := db.RetryOnBusy(ctx, func() (Repository, error) {
+		return s.q.GetRepositoryByFullName(ctx, GetRepositoryByFullNameParams{
+			UserID:   userID,
+			FullName: fullName,
+		})
+	})
+	if err != nil {
+		return db.Repository{}, err
+	}
+	return toDBRepository(r), nil
+}
+
 // ListRepositories lists all repositories
 func (s *Store) ListRepositories(ctx context.Context, userID string) ([]db.Repository, error) {
 	repos, err := db.RetryOnBusy(ctx, func() ([]Repository, error) {
@@ -1128,40 +2077,1427 @@ func (s *Store) UpsertRepository(
 	return toDBRepository(r), nil
 }
 
-// --- Pull Request methods ---
-
-// UpsertPullRequest upserts a pull request
-func (s *Store) UpsertPullRequest(
+// RenameRepository updates a repository's name in place and records the previous
+// full name as an alias, all in one transaction.
+func (s *Store) RenameRepository(
 	ctx context.Context,
 	userID string,
-	arg db.UpsertPullRequestParams,
-) (db.PullRequest, error) {
-	pr, err := db.RetryOnBusy(ctx, func() (PullRequest, error) {
-		return s.q.UpsertPullRequest(ctx, UpsertPullRequestParams{
-			UserID:       userID,
-			RepositoryID: arg.RepositoryID,
-			GithubID:     arg.GithubID,
-			NodeID:       arg.NodeID,
-			Number:       int64(arg.Number),
-			Title:        arg.Title,
-			State:        arg.State,
-			Draft:        fromNullBool(arg.Draft),
-			Merged:       fromNullBool(arg.Merged),
-			AuthorLogin:  arg.AuthorLogin,
-			AuthorID:     arg.AuthorID,
-			CreatedAt:    formatNullTime(arg.CreatedAt),
-			UpdatedAt:    formatNullTime(arg.UpdatedAt),
-			ClosedAt:     formatNullTime(arg.ClosedAt),
-			MergedAt:     formatNullTime(arg.MergedAt),
-			Raw:          fromNullRawMessage(arg.Raw),
-		})
-	})
-	if err != nil {
-		return db.PullRequest{}, err
+	arg db.RenameRepositoryParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		if err := qtx.UpsertRepositoryAlias(ctx, UpsertRepositoryAliasParams{
+			UserID:       userID,
+			RepositoryID: arg.ID,
+			FullName:     arg.OldFullName,
+		}); err != nil {
+			return err
+		}
+
+		// A repository renamed back to a previous name must not keep that name as an alias
+		if err := qtx.DeleteRepositoryAlias(ctx, DeleteRepositoryAliasParams{
+			UserID:   userID,
+			FullName: arg.FullName,
+		}); err != nil {
+			return err
+		}
+
+		if err := qtx.RenameRepository(ctx, RenameRepositoryParams{
+			FullName:   arg.FullName,
+			Name:       arg.Name,
+			OwnerLogin: arg.OwnerLogin,
+			UserID:     userID,
+			ID:         arg.ID,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// MergeRepositories moves everything that references a duplicate repository row onto the kept
+// row and deletes the duplicate, all in one transaction. The duplicate's name is kept as an
+// alias so repo: filters written against it keep matching.
+func (s *Store) MergeRepositories(
+	ctx context.Context,
+	userID string,
+	arg db.MergeRepositoriesParams,
+) (db.MergeRepositoriesResult, error) {
+	return db.RetryOnBusy(ctx, func() (db.MergeRepositoriesResult, error) {
+		var result db.MergeRepositoriesResult
+
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return result, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		keep, err := qtx.GetRepositoryByID(ctx, GetRepositoryByIDParams{UserID: userID, ID: arg.KeepID})
+		if err != nil {
+			return result, err
+		}
+		duplicate, err := qtx.GetRepositoryByID(ctx, GetRepositoryByIDParams{
+			UserID: userID,
+			ID:     arg.DuplicateID,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		if err := qtx.RepointDuplicatePullRequestNotifications(
+			ctx,
+			RepointDuplicatePullRequestNotificationsParams{
+				UserID:      userID,
+				KeepID:      keep.ID,
+				DuplicateID: duplicate.ID,
+			},
+		); err != nil {
+			return result, err
+		}
+		collapsed, err := qtx.DeleteDuplicatePullRequests(ctx, DeleteDuplicatePullRequestsParams{
+			UserID:      userID,
+			DuplicateID: duplicate.ID,
+			KeepID:      keep.ID,
+		})
+		if err != nil {
+			return result, err
+		}
+		moved, err := qtx.MoveRepositoryPullRequests(ctx, MoveRepositoryPullRequestsParams{
+			KeepID:      keep.ID,
+			UserID:      userID,
+			DuplicateID: duplicate.ID,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.PullRequestsMoved = collapsed + moved
+
+		result.NotificationsMoved, err = qtx.MoveRepositoryNotifications(
+			ctx,
+			MoveRepositoryNotificationsParams{
+				KeepID:      keep.ID,
+				UserID:      userID,
+				DuplicateID: duplicate.ID,
+			},
+		)
+		if err != nil {
+			return result, err
+		}
+
+		if err := qtx.MoveRepositoryAliases(ctx, MoveRepositoryAliasesParams{
+			KeepID:      keep.ID,
+			UserID:      userID,
+			DuplicateID: duplicate.ID,
+		}); err != nil {
+			return result, err
+		}
+		// repo: filters match case-insensitively, so a name differing only in case needs no alias
+		if !strings.EqualFold(duplicate.FullName, keep.FullName) {
+			if err := qtx.UpsertRepositoryAlias(ctx, UpsertRepositoryAliasParams{
+				UserID:       userID,
+				RepositoryID: keep.ID,
+				FullName:     duplicate.FullName,
+			}); err != nil {
+				return result, err
+			}
+		}
+		if err := qtx.DeleteRepositoryAlias(ctx, DeleteRepositoryAliasParams{
+			UserID:   userID,
+			FullName: keep.FullName,
+		}); err != nil {
+			return result, err
+		}
+
+		if err := qtx.MoveRepositorySettings(ctx, MoveRepositorySettingsParams{
+			KeepID:      keep.ID,
+			UserID:      userID,
+			DuplicateID: duplicate.ID,
+		}); err != nil {
+			return result, err
+		}
+		if err := qtx.DeleteRepositorySettings(ctx, DeleteRepositorySettingsParams{
+			UserID:       userID,
+			RepositoryID: duplicate.ID,
+		}); err != nil {
+			return result, err
+		}
+
+		if err := qtx.DeleteRepository(ctx, DeleteRepositoryParams{
+			UserID: userID,
+			ID:     duplicate.ID,
+		}); err != nil {
+			return result, err
+		}
+
+		return result, tx.Commit()
+	})
+}
+
+// GetRepositorySettings returns the user's settings for a repository
+func (s *Store) GetRepositorySettings(
+	ctx context.Context,
+	userID string,
+	repositoryID int64,
+) (db.RepositorySetting, error) {
+	settings, err := db.RetryOnBusy(ctx, func() (RepositorySetting, error) {
+		return s.q.GetRepositorySettings(ctx, GetRepositorySettingsParams{
+			UserID:       userID,
+			RepositoryID: repositoryID,
+		})
+	})
+	if err != nil {
+		return db.RepositorySetting{}, err
+	}
+	return toDBRepositorySetting(settings), nil
+}
+
+// UpsertRepositorySettings saves the user's settings for a repository
+func (s *Store) UpsertRepositorySettings(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertRepositorySettingsParams,
+) (db.RepositorySetting, error) {
+	settings, err := db.RetryOnBusy(ctx, func() (RepositorySetting, error) {
+		return s.q.UpsertRepositorySettings(ctx, UpsertRepositorySettingsParams{
+			UserID:                      userID,
+			RepositoryID:                arg.RepositoryID,
+			DefaultSnooze:               arg.DefaultSnooze,
+			ResurfaceSuppressionMinutes: arg.ResurfaceSuppressionMinutes,
+			SyncMode:                    arg.SyncMode,
+		})
+	})
+	if err != nil {
+		return db.RepositorySetting{}, err
+	}
+	return toDBRepositorySetting(settings), nil
+}
+
+// --- Author profile methods ---
+
+// ListRepositoryDigests builds the unread digest of every repository with unread
+// notifications, keyed by repository ID
+func (s *Store) ListRepositoryDigests(
+	ctx context.Context,
+	userID string,
+) (map[int64]db.RepositoryDigest, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListRepositoryDigestRowsRow, error) {
+		return s.q.ListRepositoryDigestRows(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return foldRepositoryDigestRows(rows), nil
+}
+
+// ListRepositorySubjectTypeCounts counts each repository's notifications by subject type
+func (s *Store) ListRepositorySubjectTypeCounts(
+	ctx context.Context,
+	userID string,
+) (map[int64]map[string]int64, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListRepositorySubjectTypeCountsRow, error) {
+		return s.q.ListRepositorySubjectTypeCounts(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]map[string]int64)
+	for _, row := range rows {
+		if counts[row.RepositoryID] == nil {
+			counts[row.RepositoryID] = make(map[string]int64)
+		}
+		counts[row.RepositoryID][row.SubjectType] = row.NotificationCount
+	}
+	return counts, nil
+}
+
+// GetRepositoryDigest builds the unread digest of a single repository. A repository
+// without unread notifications yields an empty digest.
+func (s *Store) GetRepositoryDigest(
+	ctx context.Context,
+	userID string,
+	repositoryID int64,
+) (db.RepositoryDigest, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]GetRepositoryDigestRowsRow, error) {
+		return s.q.GetRepositoryDigestRows(ctx, GetRepositoryDigestRowsParams{
+			UserID:       userID,
+			RepositoryID: repositoryID,
+		})
+	})
+	if err != nil {
+		return db.RepositoryDigest{}, err
+	}
+
+	converted := make([]ListRepositoryDigestRowsRow, len(rows))
+	for i, r := range rows {
+		converted[i] = ListRepositoryDigestRowsRow(r)
+	}
+	if digest, ok := foldRepositoryDigestRows(converted)[repositoryID]; ok {
+		return digest, nil
+	}
+	return db.RepositoryDigest{RepositoryID: repositoryID, ReasonCounts: map[string]int64{}}, nil
+}
+
+// ListAuthorProfiles lists all cached author profiles
+func (s *Store) ListAuthorProfiles(ctx context.Context, userID string) ([]db.AuthorProfile, error) {
+	profiles, err := db.RetryOnBusy(ctx, func() ([]AuthorProfile, error) {
+		return s.q.ListAuthorProfiles(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.AuthorProfile, len(profiles))
+	for i, p := range profiles {
+		result[i] = toDBAuthorProfile(p)
+	}
+	return result, nil
+}
+
+// UpsertAuthorProfile upserts an author profile
+func (s *Store) UpsertAuthorProfile(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertAuthorProfileParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpsertAuthorProfile(ctx, UpsertAuthorProfileParams{
+			UserID:    userID,
+			Login:     arg.Login,
+			Name:      arg.Name,
+			AvatarUrl: arg.AvatarURL,
+			Type:      arg.Type,
+			FetchedAt: formatTime(arg.FetchedAt),
+		})
+	})
+}
+
+// --- Saved reply methods ---
+
+// ListSavedReplies lists a user's imported saved replies, ordered by title
+func (s *Store) ListSavedReplies(ctx context.Context, userID string) ([]db.SavedReply, error) {
+	replies, err := db.RetryOnBusy(ctx, func() ([]SavedReply, error) {
+		return s.q.ListSavedReplies(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.SavedReply, len(replies))
+	for i, r := range replies {
+		result[i] = toDBSavedReply(r)
+	}
+	return result, nil
+}
+
+// GetSavedReply gets an imported saved reply by its GitHub ID
+func (s *Store) GetSavedReply(
+	ctx context.Context,
+	userID, githubID string,
+) (db.SavedReply, error) {
+	reply, err := db.RetryOnBusy(ctx, func() (SavedReply, error) {
+		return s.q.GetSavedReply(ctx, GetSavedReplyParams{UserID: userID, GithubID: githubID})
+	})
+	if err != nil {
+		return db.SavedReply{}, err
+	}
+	return toDBSavedReply(reply), nil
+}
+
+// ReplaceSavedReplies deletes a user's saved replies and inserts the imported set in one
+// transaction, so replies deleted on GitHub disappear too.
+func (s *Store) ReplaceSavedReplies(
+	ctx context.Context,
+	userID string,
+	arg db.ReplaceSavedRepliesParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		if err := qtx.DeleteSavedReplies(ctx, userID); err != nil {
+			return err
+		}
+
+		fetchedAt := formatTime(arg.FetchedAt)
+		for _, reply := range arg.Replies {
+			if err := qtx.InsertSavedReply(ctx, InsertSavedReplyParams{
+				UserID:    userID,
+				GithubID:  reply.GithubID,
+				Title:     reply.Title,
+				Body:      reply.Body,
+				FetchedAt: fetchedAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
+
+// --- Linked account methods ---
+
+// ListLinkedAccounts lists a user's linked GitHub accounts, ordered by login
+func (s *Store) ListLinkedAccounts(ctx context.Context, userID string) ([]db.LinkedAccount, error) {
+	accounts, err := db.RetryOnBusy(ctx, func() ([]LinkedAccount, error) {
+		return s.q.ListLinkedAccounts(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.LinkedAccount, len(accounts))
+	for i, a := range accounts {
+		result[i] = toDBLinkedAccount(a)
+	}
+	return result, nil
+}
+
+// UpsertLinkedAccount links a GitHub account, or updates the login and token of an
+// account that's already linked
+func (s *Store) UpsertLinkedAccount(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertLinkedAccountParams,
+) (db.LinkedAccount, error) {
+	account, err := db.RetryOnBusy(ctx, func() (LinkedAccount, error) {
+		return s.q.UpsertLinkedAccount(ctx, UpsertLinkedAccountParams{
+			UserID:         userID,
+			GithubUserID:   arg.GithubUserID,
+			Login:          arg.Login,
+			TokenEncrypted: arg.TokenEncrypted,
+		})
+	})
+	if err != nil {
+		return db.LinkedAccount{}, err
+	}
+	return toDBLinkedAccount(account), nil
+}
+
+// DeleteLinkedAccount unlinks a GitHub account by login
+func (s *Store) DeleteLinkedAccount(ctx context.Context, userID, login string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteLinkedAccount(ctx, DeleteLinkedAccountParams{UserID: userID, Login: login})
+	})
+}
+
+// UpdateLinkedAccountSyncedAt records when a linked account's notifications were last synced
+func (s *Store) UpdateLinkedAccountSyncedAt(
+	ctx context.Context,
+	userID, login string,
+	syncedAt time.Time,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpdateLinkedAccountSyncedAt(ctx, UpdateLinkedAccountSyncedAtParams{
+			LastSyncedAt: sql.NullString{String: formatTime(syncedAt), Valid: true},
+			UserID:       userID,
+			Login:        login,
+		})
+	})
+}
+
+// DeleteNotificationsByAccount deletes an account's notifications and their tag
+// assignments in one transaction
+func (s *Store) DeleteNotificationsByAccount(
+	ctx context.Context,
+	userID, account string,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		accountArg := sql.NullString{String: account, Valid: true}
+
+		if err := qtx.DeleteTagAssignmentsByAccount(ctx, DeleteTagAssignmentsByAccountParams{
+			UserID:  userID,
+			Account: accountArg,
+		}); err != nil {
+			return 0, err
+		}
+		deleted, err := qtx.DeleteNotificationsByAccount(ctx, DeleteNotificationsByAccountParams{
+			UserID:  userID,
+			Account: accountArg,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return deleted, tx.Commit()
+	})
+}
+
+// --- Integration methods ---
+
+// ListIntegrations lists a user's integrations, ordered by name
+func (s *Store) ListIntegrations(ctx context.Context, userID string) ([]db.Integration, error) {
+	integrations, err := db.RetryOnBusy(ctx, func() ([]Integration, error) {
+		return s.q.ListIntegrations(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.Integration, len(integrations))
+	for i, integration := range integrations {
+		result[i] = toDBIntegration(integration)
+	}
+	return result, nil
+}
+
+// GetIntegration gets an integration by ID
+func (s *Store) GetIntegration(ctx context.Context, userID, id string) (db.Integration, error) {
+	integration, err := db.RetryOnBusy(ctx, func() (Integration, error) {
+		return s.q.GetIntegration(ctx, GetIntegrationParams{UserID: userID, ID: id})
+	})
+	if err != nil {
+		return db.Integration{}, err
+	}
+	return toDBIntegration(integration), nil
+}
+
+// CreateIntegration creates an integration
+func (s *Store) CreateIntegration(
+	ctx context.Context,
+	userID string,
+	arg db.CreateIntegrationParams,
+) (db.Integration, error) {
+	integration, err := db.RetryOnBusy(ctx, func() (Integration, error) {
+		return s.q.CreateIntegration(ctx, CreateIntegrationParams{
+			UserID:          userID,
+			Name:            arg.Name,
+			Format:          arg.Format,
+			Url:             arg.URL,
+			SecretEncrypted: arg.SecretEncrypted,
+			CreatedAt:       formatTime(arg.CreatedAt),
+			UpdatedAt:       formatTime(arg.CreatedAt),
+		})
+	})
+	if err != nil {
+		return db.Integration{}, err
+	}
+	return toDBIntegration(integration), nil
+}
+
+// UpdateIntegration replaces an integration's name, format, URL and secret
+func (s *Store) UpdateIntegration(
+	ctx context.Context,
+	userID, id string,
+	arg db.UpdateIntegrationParams,
+) (db.Integration, error) {
+	integration, err := db.RetryOnBusy(ctx, func() (Integration, error) {
+		return s.q.UpdateIntegration(ctx, UpdateIntegrationParams{
+			Name:            arg.Name,
+			Format:          arg.Format,
+			Url:             arg.URL,
+			SecretEncrypted: arg.SecretEncrypted,
+			UpdatedAt:       formatTime(arg.UpdatedAt),
+			UserID:          userID,
+			ID:              id,
+		})
+	})
+	if err != nil {
+		return db.Integration{}, err
+	}
+	return toDBIntegration(integration), nil
+}
+
+// DeleteIntegration deletes an integration; its deliveries go with it
+func (s *Store) DeleteIntegration(ctx context.Context, userID, id string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteIntegration(ctx, DeleteIntegrationParams{UserID: userID, ID: id})
+	})
+}
+
+// CreateIntegrationDelivery queues a delivery to an integration, due right away
+func (s *Store) CreateIntegrationDelivery(
+	ctx context.Context,
+	userID string,
+	arg db.CreateIntegrationDeliveryParams,
+) (db.IntegrationDelivery, error) {
+	delivery, err := db.RetryOnBusy(ctx, func() (IntegrationDelivery, error) {
+		return s.q.CreateIntegrationDelivery(ctx, CreateIntegrationDeliveryParams{
+			UserID:        userID,
+			IntegrationID: arg.IntegrationID,
+			RuleID:        arg.RuleID,
+			RuleName:      arg.RuleName,
+			GithubID:      arg.GithubID,
+			NextAttemptAt: formatTime(arg.CreatedAt),
+			CreatedAt:     formatTime(arg.CreatedAt),
+		})
+	})
+	if err != nil {
+		return db.IntegrationDelivery{}, err
+	}
+	return toDBIntegrationDelivery(delivery), nil
+}
+
+// ListDueIntegrationDeliveries lists up to limit pending deliveries due by now, oldest first
+func (s *Store) ListDueIntegrationDeliveries(
+	ctx context.Context,
+	userID string,
+	now time.Time,
+	limit int64,
+) ([]db.IntegrationDelivery, error) {
+	deliveries, err := db.RetryOnBusy(ctx, func() ([]IntegrationDelivery, error) {
+		return s.q.ListDueIntegrationDeliveries(ctx, ListDueIntegrationDeliveriesParams{
+			UserID:        userID,
+			NextAttemptAt: formatTime(now),
+			Limit:         limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.IntegrationDelivery, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = toDBIntegrationDelivery(d)
+	}
+	return result, nil
+}
+
+// UpdateIntegrationDelivery records the outcome of an attempt at a delivery
+func (s *Store) UpdateIntegrationDelivery(
+	ctx context.Context,
+	userID string,
+	id int64,
+	arg db.UpdateIntegrationDeliveryParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpdateIntegrationDelivery(ctx, UpdateIntegrationDeliveryParams{
+			Status:         arg.Status,
+			Attempts:       arg.Attempts,
+			ResponseStatus: arg.ResponseStatus,
+			LastError:      arg.LastError,
+			NextAttemptAt:  formatTime(arg.NextAttemptAt),
+			DeliveredAt:    formatNullTime(arg.DeliveredAt),
+			UserID:         userID,
+			ID:             id,
+		})
+	})
+}
+
+// ListIntegrationDeliveries lists an integration's most recent deliveries, newest first
+func (s *Store) ListIntegrationDeliveries(
+	ctx context.Context,
+	userID, integrationID string,
+	limit int64,
+) ([]db.IntegrationDelivery, error) {
+	deliveries, err := db.RetryOnBusy(ctx, func() ([]IntegrationDelivery, error) {
+		return s.q.ListIntegrationDeliveries(ctx, ListIntegrationDeliveriesParams{
+			UserID:        userID,
+			IntegrationID: integrationID,
+			Limit:         limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.IntegrationDelivery, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = toDBIntegrationDelivery(d)
+	}
+	return result, nil
+}
+
+// DeleteIntegrationDeliveriesBefore deletes finished deliveries created before the given time
+func (s *Store) DeleteIntegrationDeliveriesBefore(
+	ctx context.Context,
+	userID string,
+	before time.Time,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteIntegrationDeliveriesBefore(ctx, DeleteIntegrationDeliveriesBeforeParams{
+			UserID:    userID,
+			CreatedAt: formatTime(before),
+		})
+	})
+}
+
+// --- API token methods ---
+
+// ListAPITokens lists the user's API tokens, newest first
+func (s *Store) ListAPITokens(ctx context.Context, userID string) ([]db.APIToken, error) {
+	tokens, err := db.RetryOnBusy(ctx, func() ([]ApiToken, error) {
+		return s.q.ListAPITokens(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.APIToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = toDBAPIToken(token)
+	}
+	return result, nil
+}
+
+// GetAPITokenByHash finds an API token by the hash of its value
+func (s *Store) GetAPITokenByHash(ctx context.Context, tokenHash string) (db.APIToken, error) {
+	token, err := db.RetryOnBusy(ctx, func() (ApiToken, error) {
+		return s.q.GetAPITokenByHash(ctx, tokenHash)
+	})
+	if err != nil {
+		return db.APIToken{}, err
+	}
+	return toDBAPIToken(token), nil
+}
+
+// CreateAPIToken creates an API token
+func (s *Store) CreateAPIToken(
+	ctx context.Context,
+	userID string,
+	arg db.CreateAPITokenParams,
+) (db.APIToken, error) {
+	token, err := db.RetryOnBusy(ctx, func() (ApiToken, error) {
+		return s.q.CreateAPIToken(ctx, CreateAPITokenParams{
+			UserID:      userID,
+			Name:        arg.Name,
+			TokenHash:   arg.TokenHash,
+			TokenPrefix: arg.TokenPrefix,
+			Scope:       arg.Scope,
+			Account:     arg.Account,
+			CreatedAt:   formatTime(arg.CreatedAt),
+			ExpiresAt:   formatNullTime(arg.ExpiresAt),
+		})
+	})
+	if err != nil {
+		return db.APIToken{}, err
+	}
+	return toDBAPIToken(token), nil
+}
+
+// UpdateAPITokenLastUsed records when an API token was last used
+func (s *Store) UpdateAPITokenLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpdateAPITokenLastUsed(ctx, UpdateAPITokenLastUsedParams{
+			LastUsedAt: formatNullTime(sql.NullTime{Time: usedAt, Valid: true}),
+			ID:         id,
+		})
+	})
+}
+
+// DeleteAPIToken deletes an API token
+func (s *Store) DeleteAPIToken(ctx context.Context, userID, id string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteAPIToken(ctx, DeleteAPITokenParams{UserID: userID, ID: id})
+	})
+}
+
+// --- Org offboarding methods ---
+
+// orgRepoPattern matches the full names of every repository an org owns
+func orgRepoPattern(org string) string {
+	return org + "/%"
+}
+
+// RecordNotificationEvents adds an event to the history of each notification, skipping
+// GitHub IDs that don't exist
+func (s *Store) RecordNotificationEvents(
+	ctx context.Context,
+	userID string,
+	arg db.RecordNotificationEventsParams,
+) error {
+	if len(arg.GithubIDs) == 0 {
+		return nil
+	}
+	createdAt := db.FormatTimestamp(arg.CreatedAt)
+	return db.RetryVoidOnBusy(ctx, func() error {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		// Events are inserted in batches so a large bulk operation stays under SQLite's limit
+		// on bound variables
+		if err := inBatches(arg.GithubIDs, func(batch []string) error {
+			args := make([]interface{}, 0, len(batch)+5)
+			args = append(args, arg.Action, arg.Source, arg.Detail, createdAt, userID)
+			for _, id := range batch {
+				args = append(args, id)
+			}
+			query := inList(`INSERT INTO notification_events (
+					user_id, notification_id, action, source, detail, created_at
+				)
+				SELECT n.user_id, n.id, ?, ?, ?, ? FROM notifications n
+				WHERE n.user_id = ? AND n.github_id IN (%s)`,
+				len(batch),
+			)
+			_, err := tx.ExecContext(ctx, query, args...)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// ListNotificationEvents lists a notification's history, most recent first
+func (s *Store) ListNotificationEvents(
+	ctx context.Context,
+	userID, githubID string,
+	limit int64,
+) ([]db.NotificationEvent, error) {
+	events, err := db.RetryOnBusy(ctx, func() ([]NotificationEvent, error) {
+		return s.q.ListNotificationEvents(ctx, ListNotificationEventsParams{
+			UserID:   userID,
+			GithubID: githubID,
+			Limit:    limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.NotificationEvent, len(events))
+	for i, e := range events {
+		result[i] = toDBNotificationEvent(e)
+	}
+	return result, nil
+}
+
+// ListDailyNotificationActivity counts the notifications synced, read and archived on
+// each UTC day since the given time, skipping days without any
+func (s *Store) ListDailyNotificationActivity(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+) ([]db.DailyNotificationActivity, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListDailyNotificationActivityRow, error) {
+		return s.q.ListDailyNotificationActivity(ctx, ListDailyNotificationActivityParams{
+			UserID: userID,
+			Since:  formatTime(since),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.DailyNotificationActivity, len(rows))
+	for i, r := range rows {
+		day, err := time.Parse(time.DateOnly, r.Day)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = db.DailyNotificationActivity{
+			Day:      day,
+			Received: r.Received,
+			Read:     r.ReadCount,
+			Archived: r.Archived,
+		}
+	}
+	return result, nil
+}
+
+// GetMeanTimeToRead measures how long notifications read by the user since the given
+// time waited after their latest update on GitHub
+func (s *Store) GetMeanTimeToRead(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+) (db.MeanTimeToRead, error) {
+	row, err := db.RetryOnBusy(ctx, func() (GetMeanTimeToReadRow, error) {
+		return s.q.GetMeanTimeToRead(ctx, GetMeanTimeToReadParams{
+			UserID: userID,
+			Since:  formatTime(since),
+		})
+	})
+	if err != nil {
+		return db.MeanTimeToRead{}, err
+	}
+	return db.MeanTimeToRead{
+		Notifications: row.Notifications,
+		Mean:          time.Duration(row.MeanSeconds * float64(time.Second)).Round(time.Second),
+	}, nil
+}
+
+// ListNotificationVolume counts notifications updated since the given time by repository
+// and reason
+func (s *Store) ListNotificationVolume(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+) ([]db.NotificationVolume, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListNotificationVolumeRow, error) {
+		return s.q.ListNotificationVolume(ctx, ListNotificationVolumeParams{
+			UserID: userID,
+			Since:  formatTime(since),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.NotificationVolume, len(rows))
+	for i, r := range rows {
+		result[i] = db.NotificationVolume{
+			Repository: r.FullName,
+			Reason:     r.Reason,
+			Total:      r.Total,
+			Unread:     r.Unread,
+		}
+	}
+	return result, nil
+}
+
+// ListOrgOffboardings lists the orgs the user left, most recent first
+func (s *Store) ListOrgOffboardings(ctx context.Context, userID string) ([]db.OrgOffboarding, error) {
+	offboardings, err := db.RetryOnBusy(ctx, func() ([]OrgOffboarding, error) {
+		return s.q.ListOrgOffboardings(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.OrgOffboarding, len(offboardings))
+	for i, o := range offboardings {
+		result[i] = toDBOrgOffboarding(o)
+	}
+	return result, nil
+}
+
+// IsOrgOffboarded reports whether the user left an org
+func (s *Store) IsOrgOffboarded(ctx context.Context, userID, org string) (bool, error) {
+	offboarded, err := db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.IsOrgOffboarded(ctx, IsOrgOffboardedParams{UserID: userID, Org: org})
+	})
+	if err != nil {
+		return false, err
+	}
+	return offboarded != 0, nil
+}
+
+// UpsertOrgOffboarding records an org offboarding, replacing an earlier one of the same org
+func (s *Store) UpsertOrgOffboarding(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertOrgOffboardingParams,
+) (db.OrgOffboarding, error) {
+	offboarding, err := db.RetryOnBusy(ctx, func() (OrgOffboarding, error) {
+		return s.q.UpsertOrgOffboarding(ctx, UpsertOrgOffboardingParams{
+			UserID:                userID,
+			Org:                   arg.Org,
+			Action:                arg.Action,
+			NotificationsAffected: arg.NotificationsAffected,
+			Repositories:          arg.Repositories,
+		})
+	})
+	if err != nil {
+		return db.OrgOffboarding{}, err
+	}
+	return toDBOrgOffboarding(offboarding), nil
+}
+
+// DeleteOrgOffboarding removes an org offboarding so the org is synced again
+func (s *Store) DeleteOrgOffboarding(ctx context.Context, userID, org string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteOrgOffboarding(ctx, DeleteOrgOffboardingParams{UserID: userID, Org: org})
+	})
+}
+
+// CountRepositoriesByOrg counts the repositories owned by an org
+func (s *Store) CountRepositoriesByOrg(ctx context.Context, userID, org string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.CountRepositoriesByOrg(ctx, CountRepositoriesByOrgParams{
+			UserID:      userID,
+			RepoPattern: orgRepoPattern(org),
+		})
+	})
+}
+
+// DeleteNotificationsByOrg deletes the notifications of an org's repositories and their
+// tag assignments in one transaction
+func (s *Store) DeleteNotificationsByOrg(ctx context.Context, userID, org string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		pattern := orgRepoPattern(org)
+
+		if err := qtx.DeleteTagAssignmentsByOrg(ctx, DeleteTagAssignmentsByOrgParams{
+			UserID:      userID,
+			RepoPattern: pattern,
+		}); err != nil {
+			return 0, err
+		}
+		deleted, err := qtx.DeleteNotificationsByOrg(ctx, DeleteNotificationsByOrgParams{
+			UserID:      userID,
+			RepoPattern: pattern,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return deleted, tx.Commit()
+	})
+}
+
+// --- Notification alert methods ---
+
+// CreateNotificationAlert records the alert decided for a notification
+func (s *Store) CreateNotificationAlert(
+	ctx context.Context,
+	userID string,
+	arg db.CreateNotificationAlertParams,
+) (db.NotificationAlert, error) {
+	alert, err := db.RetryOnBusy(ctx, func() (NotificationAlert, error) {
+		return s.q.CreateNotificationAlert(ctx, CreateNotificationAlertParams{
+			UserID:         userID,
+			NotificationID: arg.NotificationID,
+			Level:          arg.Level,
+			Sound:          arg.Sound,
+			Source:         arg.Source,
+			SourceID:       arg.SourceID,
+			Suppressed:     arg.Suppressed,
+			CreatedAt:      formatTime(arg.CreatedAt),
+		})
+	})
+	if err != nil {
+		return db.NotificationAlert{}, err
+	}
+	return toDBNotificationAlert(alert), nil
+}
+
+// ListNotificationAlertsAfter lists alerts recorded after afterID, oldest first
+func (s *Store) ListNotificationAlertsAfter(
+	ctx context.Context,
+	userID string,
+	afterID, limit int64,
+) ([]db.NotificationAlert, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListNotificationAlertsAfterRow, error) {
+		return s.q.ListNotificationAlertsAfter(ctx, ListNotificationAlertsAfterParams{
+			UserID: userID,
+			ID:     afterID,
+			Limit:  limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.NotificationAlert, len(rows))
+	for i, row := range rows {
+		result[i] = toDBNotificationAlert(NotificationAlert{
+			ID:             row.ID,
+			UserID:         row.UserID,
+			NotificationID: row.NotificationID,
+			Level:          row.Level,
+			Sound:          row.Sound,
+			Source:         row.Source,
+			SourceID:       row.SourceID,
+			Suppressed:     row.Suppressed,
+			CreatedAt:      row.CreatedAt,
+		})
+		result[i].GithubID = row.GithubID
+	}
+	return result, nil
+}
+
+// ListRecentNotificationAlerts lists the most recent alerts, oldest first
+func (s *Store) ListRecentNotificationAlerts(
+	ctx context.Context,
+	userID string,
+	limit int64,
+) ([]db.NotificationAlert, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListRecentNotificationAlertsRow, error) {
+		return s.q.ListRecentNotificationAlerts(ctx, ListRecentNotificationAlertsParams{
+			UserID: userID,
+			Limit:  limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The query returns newest first so LIMIT keeps the latest; flip to match the cursor order
+	result := make([]db.NotificationAlert, len(rows))
+	for i, row := range rows {
+		alert := toDBNotificationAlert(NotificationAlert{
+			ID:             row.ID,
+			UserID:         row.UserID,
+			NotificationID: row.NotificationID,
+			Level:          row.Level,
+			Sound:          row.Sound,
+			Source:         row.Source,
+			SourceID:       row.SourceID,
+			Suppressed:     row.Suppressed,
+			CreatedAt:      row.CreatedAt,
+		})
+		alert.GithubID = row.GithubID
+		result[len(rows)-1-i] = alert
+	}
+	return result, nil
+}
+
+// DeleteNotificationAlertsBefore prunes alerts recorded before a cutoff
+func (s *Store) DeleteNotificationAlertsBefore(
+	ctx context.Context,
+	userID string,
+	before time.Time,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteNotificationAlertsBefore(ctx, DeleteNotificationAlertsBeforeParams{
+			UserID:    userID,
+			CreatedAt: formatTime(before),
+		})
+	})
+}
+
+// --- Triage restore methods ---
+
+// ListTriageStatesAfter lists up to limit notifications that have triage state worth
+// backing up, starting after afterGithubID in GitHub ID order
+func (s *Store) ListTriageStatesAfter(
+	ctx context.Context,
+	userID, afterGithubID string,
+	limit int64,
+) ([]db.TriageState, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListTriageStatesAfterRow, error) {
+		return s.q.ListTriageStatesAfter(ctx, ListTriageStatesAfterParams{
+			UserID:   userID,
+			GithubID: afterGithubID,
+			Limit:    limit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]db.TriageState, 0, len(rows))
+	for _, row := range rows {
+		var tagNames []string
+		if err := json.Unmarshal([]byte(row.TagNames), &tagNames); err != nil {
+			return nil, err
+		}
+		states = append(states, db.TriageState{
+			GithubID:     row.GithubID,
+			IsRead:       toBool(row.IsRead),
+			Archived:     toBool(row.Archived),
+			Starred:      toBool(row.Starred),
+			Muted:        toBool(row.Muted),
+			SnoozedUntil: parseNullTime(row.SnoozedUntil),
+			TagNames:     tagNames,
+		})
+	}
+	return states, nil
+}
+
+// StageTriageRestores saves restores in one transaction, replacing anything already staged
+// for the same GitHub IDs
+func (s *Store) StageTriageRestores(
+	ctx context.Context,
+	userID string,
+	restores []db.TriageRestore,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		for _, restore := range restores {
+			tagIDs := restore.TagIDs
+			if tagIDs == nil {
+				tagIDs = []string{}
+			}
+			encoded, err := json.Marshal(tagIDs)
+			if err != nil {
+				return err
+			}
+			if err := qtx.UpsertTriageRestore(ctx, UpsertTriageRestoreParams{
+				UserID:       userID,
+				GithubID:     restore.GithubID,
+				IsRead:       boolToInt64(restore.IsRead),
+				Archived:     boolToInt64(restore.Archived),
+				Starred:      boolToInt64(restore.Starred),
+				Muted:        boolToInt64(restore.Muted),
+				SnoozedUntil: formatNullTime(restore.SnoozedUntil),
+				TagIds:       string(encoded),
+			}); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
+
+// ApplyTriageRestores merges every staged restore whose notification exists, in one
+// transaction, and removes the applied restores
+func (s *Store) ApplyTriageRestores(ctx context.Context, userID string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		matched, err := qtx.ListMatchedTriageRestores(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		for _, restore := range matched {
+			if err := applyTriageRestore(ctx, qtx, userID, restore); err != nil {
+				return 0, err
+			}
+		}
+
+		return int64(len(matched)), tx.Commit()
+	})
+}
+
+// ApplyTriageRestore merges the staged restore for one notification, if there is one
+func (s *Store) ApplyTriageRestore(ctx context.Context, userID, githubID string) (bool, error) {
+	return db.RetryOnBusy(ctx, func() (bool, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return false, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+
+		restore, err := qtx.GetMatchedTriageRestore(ctx, GetMatchedTriageRestoreParams{
+			UserID:   userID,
+			GithubID: githubID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if err := applyTriageRestore(
+			ctx,
+			qtx,
+			userID,
+			ListMatchedTriageRestoresRow(restore),
+		); err != nil {
+			return false, err
+		}
+
+		return true, tx.Commit()
+	})
+}
+
+// applyTriageRestore merges a restore into its notification, assigns its tags and removes it
+func applyTriageRestore(
+	ctx context.Context,
+	qtx *Queries,
+	userID string,
+	restore ListMatchedTriageRestoresRow,
+) error {
+	var snoozedUntil interface{}
+	if restore.SnoozedUntil.Valid {
+		snoozedUntil = restore.SnoozedUntil.String
+	}
+	if err := qtx.MergeNotificationTriage(ctx, MergeNotificationTriageParams{
+		IsRead:       restore.IsRead,
+		Archived:     restore.Archived,
+		Starred:      restore.Starred,
+		Muted:        restore.Muted,
+		SnoozedUntil: snoozedUntil,
+		UserID:       userID,
+		ID:           restore.NotificationID,
+	}); err != nil {
+		return err
+	}
+
+	var tagIDs []string
+	if err := json.Unmarshal([]byte(restore.TagIds), &tagIDs); err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if err := qtx.AssignExistingTagToNotification(ctx, AssignExistingTagToNotificationParams{
+			EntityID: restore.NotificationID,
+			UserID:   userID,
+			TagID:    tagID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return qtx.DeleteTriageRestore(ctx, DeleteTriageRestoreParams{
+		UserID:   userID,
+		GithubID: restore.GithubID,
+	})
+}
+
+// CountTriageRestores counts the restores still waiting for their notification to sync
+func (s *Store) CountTriageRestores(ctx context.Context, userID string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.CountTriageRestores(ctx, userID)
+	})
+}
+
+// DeleteTriageRestores discards every staged restore
+func (s *Store) DeleteTriageRestores(ctx context.Context, userID string) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.DeleteTriageRestores(ctx, userID)
+	})
+}
+
+// --- Changelog methods ---
+
+// InsertChangelogEntry records a changelog entry, ignoring entries that were already recorded
+func (s *Store) InsertChangelogEntry(ctx context.Context, arg db.InsertChangelogEntryParams) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.InsertChangelogEntry(ctx, InsertChangelogEntryParams{
+			Key:           arg.Key,
+			SchemaVersion: arg.SchemaVersion,
+			Kind:          arg.Kind,
+			Title:         arg.Title,
+			Description:   arg.Description,
+			CreatedAt:     formatTime(arg.CreatedAt),
+		})
+	})
+}
+
+// ListUnseenChangelogEntries lists changelog entries that haven't been acknowledged
+func (s *Store) ListUnseenChangelogEntries(ctx context.Context) ([]db.ChangelogEntry, error) {
+	entries, err := db.RetryOnBusy(ctx, func() ([]ChangelogEntry, error) {
+		return s.q.ListUnseenChangelogEntries(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.ChangelogEntry, len(entries))
+	for i, e := range entries {
+		result[i] = toDBChangelogEntry(e)
+	}
+	return result, nil
+}
+
+// AcknowledgeChangelogEntries marks unseen changelog entries up to and including upToID as seen
+func (s *Store) AcknowledgeChangelogEntries(
+	ctx context.Context,
+	upToID int64,
+	acknowledgedAt time.Time,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.AcknowledgeChangelogEntries(ctx, AcknowledgeChangelogEntriesParams{
+			AcknowledgedAt: sql.NullString{String: formatTime(acknowledgedAt), Valid: true},
+			ID:             upToID,
+		})
+	})
+}
+
+// --- Pull Request methods ---
+
+// UpsertPullRequest upserts a pull request
+func (s *Store) UpsertPullRequest(
+	ctx context.Context,
+	userID string,
+	arg db.UpsertPullRequestParams,
+) (db.PullRequest, error) {
+	pr, err := db.RetryOnBusy(ctx, func() (PullRequest, error) {
+		return s.q.UpsertPullRequest(ctx, UpsertPullRequestParams{
+			UserID:             userID,
+			RepositoryID:       arg.RepositoryID,
+			GithubID:           arg.GithubID,
+			NodeID:             arg.NodeID,
+			Number:             int64(arg.Number),
+			Title:              arg.Title,
+			State:              arg.State,
+			Draft:              fromNullBool(arg.Draft),
+			Merged:             fromNullBool(arg.Merged),
+			AuthorLogin:        arg.AuthorLogin,
+			AuthorID:           arg.AuthorID,
+			CreatedAt:          formatNullTime(arg.CreatedAt),
+			UpdatedAt:          formatNullTime(arg.UpdatedAt),
+			ClosedAt:           formatNullTime(arg.ClosedAt),
+			MergedAt:           formatNullTime(arg.MergedAt),
+			Raw:                fromNullRawMessage(arg.Raw),
+			RequestedReviewers: fromNullRawMessage(arg.RequestedReviewers),
+			RequestedTeams:     fromNullRawMessage(arg.RequestedTeams),
+		})
+	})
+	if err != nil {
+		return db.PullRequest{}, err
 	}
 	return toDBPullRequest(pr), nil
 }
 
+// ListReviewRequestReviewers lists review requests updated since the given time with
+// their pull request's requested reviewers. Pull requests synced before reviewers were
+// recorded have none.
+func (s *Store) ListReviewRequestReviewers(
+	ctx context.Context,
+	userID string,
+	since time.Time,
+) ([]db.ReviewRequestReviewers, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListReviewRequestReviewersRow, error) {
+		return s.q.ListReviewRequestReviewers(ctx, ListReviewRequestReviewersParams{
+			UserID: userID,
+			Since:  formatTime(since),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]db.ReviewRequestReviewers, len(rows))
+	for i, r := range rows {
+		var reviewers []string
+		if r.RequestedReviewers != "" {
+			if err := json.Unmarshal([]byte(r.RequestedReviewers), &reviewers); err != nil {
+				return nil, err
+			}
+		}
+		result[i] = db.ReviewRequestReviewers{
+			GithubID:  r.GithubID,
+			IsRead:    r.IsRead != 0,
+			Open:      r.State != "closed" && r.State != "merged",
+			Reviewers: reviewers,
+		}
+	}
+	return result, nil
+}
+
 // --- Sync State methods ---
 
 // GetSyncState gets a sync state
@@ -1184,17 +3520,35 @@ func (s *Store) UpsertSyncState(
 	ss, err := db.RetryOnBusy(ctx, func() (SyncState, error) {
 		return s.q.UpsertSyncState(ctx, UpsertSyncStateParams{
 			UserID:                     userID,
-			LastSuccessfulPoll:         formatNullTime(arg.LastSuccessfulPoll),
-			LastNotificationEtag:       arg.LastNotificationEtag,
+			LastSuccessfulPoll:         formatNullTime(arg.LastSuccessfulPoll),
+			LastNotificationEtag:       arg.LastNotificationEtag,
+			LatestNotificationAt:       formatNullTime(arg.LatestNotificationAt),
+			InitialSyncCompletedAt:     formatNullTime(arg.InitialSyncCompletedAt),
+			OldestNotificationSyncedAt: formatNullTime(arg.OldestNotificationSyncedAt),
+		})
+	})
+	if err != nil {
+		return db.UpsertSyncStateRow{}, err
+	}
+	return toDBUpsertSyncStateRow(ss), nil
+}
+
+// SaveInitialSyncProgress records how far the initial sync got
+func (s *Store) SaveInitialSyncProgress(
+	ctx context.Context,
+	userID string,
+	arg db.SaveInitialSyncProgressParams,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.SaveInitialSyncProgress(ctx, SaveInitialSyncProgressParams{
+			UserID:                     userID,
 			LatestNotificationAt:       formatNullTime(arg.LatestNotificationAt),
-			InitialSyncCompletedAt:     formatNullTime(arg.InitialSyncCompletedAt),
 			OldestNotificationSyncedAt: formatNullTime(arg.OldestNotificationSyncedAt),
+			StartedAt:                  formatNullTime(sql.NullTime{Time: arg.StartedAt, Valid: true}),
+			Pages:                      arg.Pages,
+			Queued:                     arg.Queued,
 		})
 	})
-	if err != nil {
-		return db.UpsertSyncStateRow{}, err
-	}
-	return toDBUpsertSyncStateRow(ss), nil
 }
 
 // --- Notification upsert/update methods ---
@@ -1247,6 +3601,19 @@ func (s *Store) UpsertNotification(
 			SubjectState:            arg.SubjectState,
 			SubjectMerged:           fromNullBool(arg.SubjectMerged),
 			SubjectStateReason:      arg.SubjectStateReason,
+			ContentKind:             db.ContentKindForSubjectType(arg.SubjectType),
+			HeadBranch:              arg.HeadBranch,
+			BaseBranch:              arg.BaseBranch,
+			SubjectDraft:            fromNullBool(arg.SubjectDraft),
+			SubjectLabels:           fromNullRawMessage(arg.SubjectLabels),
+			ReviewState:             arg.ReviewState,
+			SubjectMilestone:        arg.SubjectMilestone,
+			SubjectProjects:         fromNullRawMessage(arg.SubjectProjects),
+			CiState:                 arg.CIState,
+			SubjectMergeable:        fromNullBool(arg.SubjectMergeable),
+			WaitingOn:               arg.WaitingOn,
+			Priority:                arg.Priority,
+			Account:                 arg.Account,
 			EffectiveSortDate:       effectiveSortDate,
 		})
 	})
@@ -1254,6 +3621,34 @@ func (s *Store) UpsertNotification(
 		return db.Notification{}, err
 	}
 
+	if arg.LatestCommentBody.Valid {
+		if searchErr := db.RetryVoidOnBusy(ctx, func() error {
+			return s.q.UpdateNotificationSearchComment(ctx, UpdateNotificationSearchCommentParams{
+				Comment: arg.LatestCommentBody.String,
+				Rowid:   n.ID,
+			})
+		}); searchErr != nil {
+			return db.Notification{},
+				fmt.Errorf("failed to index latest comment: %w", searchErr)
+		}
+		awaitingReplySince := formatNullTime(arg.AwaitingReplySince)
+		if awaitingErr := db.RetryVoidOnBusy(ctx, func() error {
+			return s.q.UpdateNotificationAwaitingReply(ctx, UpdateNotificationAwaitingReplyParams{
+				AwaitingReplySince: awaitingReplySince,
+				UserID:             userID,
+				ID:                 n.ID,
+			})
+		}); awaitingErr != nil {
+			return db.Notification{},
+				fmt.Errorf("failed to record awaiting reply: %w", awaitingErr)
+		}
+		// Mirror the update on the returned row, as the query does
+		if n.AwaitingReplySince != awaitingReplySince {
+			n.AwaitingReply = 0
+		}
+		n.AwaitingReplySince = awaitingReplySince
+	}
+
 	// Apply smart status updates
 	if existingNotif != nil && s.shouldResetStatusOnSync(existingNotif, arg) {
 		if resetErr := db.RetryVoidOnBusy(ctx, func() error {
@@ -1287,6 +3682,10 @@ func (s *Store) shouldResetStatusOnSync(
 	if existing.Muted != 0 {
 		return false
 	}
+	if existing.Archived != 0 && arg.KeepArchivedSince.Valid && existing.ArchivedAt.Valid &&
+		!parseTime(existing.ArchivedAt.String).Before(arg.KeepArchivedSince.Time) {
+		return false
+	}
 	if !arg.GithubUpdatedAt.Valid {
 		return false
 	}
@@ -1314,12 +3713,39 @@ func (s *Store) UpdateNotificationSubject(
 			SubjectState:       arg.SubjectState,
 			SubjectMerged:      fromNullBool(arg.SubjectMerged),
 			SubjectStateReason: arg.SubjectStateReason,
+			HeadBranch:         arg.HeadBranch,
+			BaseBranch:         arg.BaseBranch,
+			SubjectDraft:       fromNullBool(arg.SubjectDraft),
+			SubjectLabels:      fromNullRawMessage(arg.SubjectLabels),
+			ReviewState:        arg.ReviewState,
+			SubjectMilestone:   arg.SubjectMilestone,
+			SubjectProjects:    fromNullRawMessage(arg.SubjectProjects),
+			CiState:            arg.CIState,
+			SubjectMergeable:   fromNullBool(arg.SubjectMergeable),
+			WaitingOn:          arg.WaitingOn,
+			Priority:           arg.Priority,
 			AuthorLogin:        arg.AuthorLogin,
 			AuthorID:           arg.AuthorID,
 		})
 	})
 }
 
+// UpdateNotificationPriority stores a notification's priority score
+func (s *Store) UpdateNotificationPriority(
+	ctx context.Context,
+	userID string,
+	id int64,
+	priority sql.NullInt64,
+) error {
+	return db.RetryVoidOnBusy(ctx, func() error {
+		return s.q.UpdateNotificationPriority(ctx, UpdateNotificationPriorityParams{
+			Priority: priority,
+			UserID:   userID,
+			ID:       id,
+		})
+	})
+}
+
 // --- User methods ---
 
 // GetUser gets a user by ID
@@ -1428,6 +3854,120 @@ func (s *Store) UpdateUserUpdateSettings(
 	return toDBUser(u), nil
 }
 
+// UpdateUserEscalationSettings updates the escalation settings for a user
+func (s *Store) UpdateUserEscalationSettings(
+	ctx context.Context,
+	escalationSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserEscalationSettings(ctx, fromNullRawMessage(escalationSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserTeamSettings updates the team members whose review load is reported
+func (s *Store) UpdateUserTeamSettings(
+	ctx context.Context,
+	teamSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserTeamSettings(ctx, fromNullRawMessage(teamSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserAlertSettings updates the default alert level, view alerts and quiet hours
+func (s *Store) UpdateUserAlertSettings(
+	ctx context.Context,
+	alertSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserAlertSettings(ctx, fromNullRawMessage(alertSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserPrioritySettings updates the weights notifications' priority scores are
+// worked out from
+func (s *Store) UpdateUserPrioritySettings(
+	ctx context.Context,
+	prioritySettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserPrioritySettings(ctx, fromNullRawMessage(prioritySettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserDigestSettings updates the schedule, query and delivery of the notification digest
+func (s *Store) UpdateUserDigestSettings(
+	ctx context.Context,
+	digestSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserDigestSettings(ctx, fromNullRawMessage(digestSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserBackupSettings updates how often the database is backed up and how many
+// backups are kept
+func (s *Store) UpdateUserBackupSettings(
+	ctx context.Context,
+	backupSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserBackupSettings(ctx, fromNullRawMessage(backupSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserSnoozeSettings updates the default snooze used when no snooze time is given
+func (s *Store) UpdateUserSnoozeSettings(
+	ctx context.Context,
+	snoozeSettings db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserSnoozeSettings(ctx, fromNullRawMessage(snoozeSettings))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
+// UpdateUserWorkingHours updates the working hours configuration for a user
+func (s *Store) UpdateUserWorkingHours(
+	ctx context.Context,
+	workingHours db.NullRawMessage,
+) (db.User, error) {
+	u, err := db.RetryOnBusy(ctx, func() (User, error) {
+		return s.q.UpdateUserWorkingHours(ctx, fromNullRawMessage(workingHours))
+	})
+	if err != nil {
+		return db.User{}, err
+	}
+	return toDBUser(u), nil
+}
+
 // UpdateUserMutedUntil updates the muted until time for a user
 func (s *Store) UpdateUserMutedUntil(
 	ctx context.Context,
@@ -1466,6 +4006,77 @@ func (s *Store) GetStorageStats(ctx context.Context, userID string) (db.StorageS
 	}, nil
 }
 
+// EscalateStaleReviewRequests resurfaces one batch of stale review requests
+func (s *Store) EscalateStaleReviewRequests(
+	ctx context.Context,
+	userID string,
+	params db.EscalationParams,
+) ([]db.EscalatedNotification, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]EscalateStaleReviewRequestsRow, error) {
+		return s.q.EscalateStaleReviewRequests(ctx, EscalateStaleReviewRequestsParams{
+			EscalatedAt: params.EscalatedAt,
+			UserID:      userID,
+			CutoffDate:  params.CutoffDate,
+			BatchSize:   params.BatchSize,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.EscalatedNotification, len(rows))
+	for i, r := range rows {
+		result[i] = db.EscalatedNotification{ID: r.ID, GithubID: r.GithubID}
+	}
+	return result, nil
+}
+
+// ClassifyAwaitingReplies marks threads awaiting a reply since before the cutoff
+func (s *Store) ClassifyAwaitingReplies(
+	ctx context.Context,
+	userID string,
+	params db.AwaitingReplyParams,
+) ([]string, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ClassifyAwaitingRepliesRow, error) {
+		return s.q.ClassifyAwaitingReplies(ctx, ClassifyAwaitingRepliesParams{
+			Resurface:    boolToInt64(params.Resurface),
+			ClassifiedAt: params.ClassifiedAt,
+			UserID:       userID,
+			CutoffDate:   sql.NullString{String: params.CutoffDate, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	githubIDs := make([]string, len(rows))
+	for i, r := range rows {
+		githubIDs[i] = r.GithubID
+	}
+	return githubIDs, nil
+}
+
+// ReturnExpiredSnoozes brings back notifications whose snooze ran out
+func (s *Store) ReturnExpiredSnoozes(
+	ctx context.Context,
+	userID string,
+	params db.SnoozeReturnParams,
+) ([]string, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ReturnExpiredSnoozesRow, error) {
+		return s.q.ReturnExpiredSnoozes(ctx, ReturnExpiredSnoozesParams{
+			ReturnedAt: sql.NullString{String: params.ReturnedAt, Valid: true},
+			MarkUnread: boolToInt64(params.MarkUnread),
+			UserID:     userID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	githubIDs := make([]string, len(rows))
+	for i, r := range rows {
+		githubIDs[i] = r.GithubID
+	}
+	return githubIDs, nil
+}
+
 // CountEligibleForCleanup counts eligible notifications for cleanup
 func (s *Store) CountEligibleForCleanup(
 	ctx context.Context,
@@ -1482,6 +4093,71 @@ func (s *Store) CountEligibleForCleanup(
 	})
 }
 
+// ListEligibleForCleanupIDs lists the IDs of notifications that cleanup would delete
+func (s *Store) ListEligibleForCleanupIDs(
+	ctx context.Context,
+	userID string,
+	params db.CleanupParams,
+) ([]int64, error) {
+	return db.RetryOnBusy(ctx, func() ([]int64, error) {
+		return s.q.ListEligibleForCleanupIDs(ctx, ListEligibleForCleanupIDsParams{
+			UserID:         userID,
+			ProtectStarred: boolToInt64(params.ProtectStarred),
+			ProtectTagged:  boolToInt64(params.ProtectTagged),
+			CutoffDate:     params.CutoffDate,
+		})
+	})
+}
+
+// ListCleanupCandidates lists archived/muted notifications older than the cutoff,
+// including protected ones
+func (s *Store) ListCleanupCandidates(
+	ctx context.Context,
+	userID string,
+	params db.CleanupCandidatesParams,
+) ([]db.CleanupCandidate, error) {
+	rows, err := db.RetryOnBusy(ctx, func() ([]ListCleanupCandidatesRow, error) {
+		return s.q.ListCleanupCandidates(ctx, ListCleanupCandidatesParams{
+			UserID:     userID,
+			CutoffDate: params.CutoffDate,
+			Limit:      params.Limit,
+			Offset:     params.Offset,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.CleanupCandidate, len(rows))
+	for i, row := range rows {
+		result[i] = db.CleanupCandidate{
+			ID:                row.ID,
+			GithubID:          row.GithubID,
+			SubjectTitle:      row.SubjectTitle,
+			SubjectType:       row.SubjectType,
+			RepoFullName:      row.RepoFullName,
+			Archived:          toBool(row.Archived),
+			Muted:             toBool(row.Muted),
+			Starred:           toBool(row.Starred),
+			Tagged:            toBool(row.Tagged),
+			EffectiveSortDate: parseTime(row.EffectiveSortDate),
+		}
+	}
+	return result, nil
+}
+
+// CountCleanupCandidates counts archived/muted notifications older than the cutoff
+func (s *Store) CountCleanupCandidates(
+	ctx context.Context,
+	userID, cutoffDate string,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		return s.q.CountCleanupCandidates(ctx, CountCleanupCandidatesParams{
+			UserID:     userID,
+			CutoffDate: cutoffDate,
+		})
+	})
+}
+
 // DeleteOldArchivedNotifications deletes old archived notifications
 func (s *Store) DeleteOldArchivedNotifications(
 	ctx context.Context,
@@ -1499,6 +4175,60 @@ func (s *Store) DeleteOldArchivedNotifications(
 	})
 }
 
+// DeleteApprovedCleanup deletes the notifications eligible for cleanup in one transaction.
+// approve is called with the eligible IDs before anything is deleted, and returning an error
+// from it aborts the cleanup, so the set that's checked is exactly the set that's deleted.
+func (s *Store) DeleteApprovedCleanup(
+	ctx context.Context,
+	userID string,
+	params db.CleanupParams,
+	approve func(ids []int64) error,
+) (int64, error) {
+	return db.RetryOnBusy(ctx, func() (int64, error) {
+		tx, err := s.dbConn.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			// Rollback after a successful commit returns sql.ErrTxDone, which is safe to ignore
+			_ = tx.Rollback()
+		}()
+
+		qtx := s.q.WithTx(tx)
+		ids, err := qtx.ListEligibleForCleanupIDs(ctx, ListEligibleForCleanupIDsParams{
+			UserID:         userID,
+			ProtectStarred: boolToInt64(params.ProtectStarred),
+			ProtectTagged:  boolToInt64(params.ProtectTagged),
+			CutoffDate:     params.CutoffDate,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if err := approve(ids); err != nil {
+			return 0, err
+		}
+		if len(ids) == 0 {
+			return 0, nil
+		}
+
+		deleted, err := qtx.DeleteOldArchivedNotifications(ctx, DeleteOldArchivedNotificationsParams{
+			UserID:         userID,
+			ProtectStarred: boolToInt64(params.ProtectStarred),
+			ProtectTagged:  boolToInt64(params.ProtectTagged),
+			CutoffDate:     params.CutoffDate,
+			BatchSize:      int64(len(ids)),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+		return deleted, nil
+	})
+}
+
 // DeleteOrphanedPullRequests deletes orphaned pull requests
 func (s *Store) DeleteOrphanedPullRequests(ctx context.Context, userID string) (int64, error) {
 	return db.RetryOnBusy(ctx, func() (int64, error) {
@@ -1514,6 +4244,139 @@ func (s *Store) DeleteOrphanedPullRequests(ctx context.Context, userID string) (
 // Also clears sync settings so the user must set up sync again
 // Preserves: tags, views, rules, users
 func (s *Store) DeleteAllGitHubData(ctx context.Context, userID string) error {
+	return s.DeleteGitHubData(ctx, userID, db.DeleteGitHubDataParams{
+		Mode: db.DisconnectModeFullWipe,
+	})
+}
+
+// githubDataStep is a single statement run by DeleteGitHubData
+type githubDataStep struct {
+	name  string
+	query string
+	args  []interface{}
+}
+
+// githubDataSteps returns the statements for a disconnect mode, ordered to respect
+// foreign key constraints. Every mode ends by clearing sync state and sync settings
+// so the user has to set up sync again after reconnecting.
+func githubDataSteps(mode db.DisconnectMode, userID string) []githubDataStep {
+	var steps []githubDataStep
+
+	switch mode {
+	case db.DisconnectModeKeepTriage:
+		steps = []githubDataStep{
+			{
+				name: "Purging notification payloads",
+				query: "UPDATE notifications SET payload = NULL, subject_raw = NULL, " +
+					"subject_fetched_at = NULL, pull_request_id = NULL WHERE user_id = ?",
+				args: []interface{}{userID},
+			},
+			{
+				name:  "Deleting pull requests",
+				query: "DELETE FROM pull_requests WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+			{
+				name:  "Purging repository payloads",
+				query: "UPDATE repositories SET raw = NULL, description = NULL WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+		}
+	case db.DisconnectModeKeepStarredTagged:
+		steps = []githubDataStep{
+			{
+				name: "Deleting notifications that are not starred or tagged",
+				query: "DELETE FROM notifications WHERE user_id = ? AND starred = 0 " +
+					"AND NOT EXISTS (SELECT 1 FROM tag_assignments ta " +
+					"WHERE ta.entity_type = 'notification' AND ta.entity_id = notifications.id)",
+				args: []interface{}{userID},
+			},
+			{
+				name: "Deleting unreferenced pull requests",
+				query: "DELETE FROM pull_requests WHERE user_id = ? AND id NOT IN " +
+					"(SELECT pull_request_id FROM notifications " +
+					"WHERE user_id = ? AND pull_request_id IS NOT NULL)",
+				args: []interface{}{userID, userID},
+			},
+			{
+				name: "Deleting unreferenced repository aliases",
+				query: "DELETE FROM repository_aliases WHERE user_id = ? " +
+					"AND repository_id NOT IN (SELECT repository_id FROM notifications WHERE user_id = ?)",
+				args: []interface{}{userID, userID},
+			},
+			{
+				name: "Deleting unreferenced repositories",
+				query: "DELETE FROM repositories WHERE user_id = ? " +
+					"AND id NOT IN (SELECT repository_id FROM notifications WHERE user_id = ?) " +
+					"AND id NOT IN (SELECT repository_id FROM pull_requests WHERE user_id = ?)",
+				args: []interface{}{userID, userID, userID},
+			},
+		}
+	default:
+		steps = []githubDataStep{
+			{
+				name:  "Deleting tag assignments",
+				query: "DELETE FROM tag_assignments WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+			{
+				name:  "Deleting notifications",
+				query: "DELETE FROM notifications WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+			{
+				name:  "Deleting pull requests",
+				query: "DELETE FROM pull_requests WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+			{
+				name:  "Deleting repository aliases",
+				query: "DELETE FROM repository_aliases WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+			{
+				name:  "Deleting repositories",
+				query: "DELETE FROM repositories WHERE user_id = ?",
+				args:  []interface{}{userID},
+			},
+		}
+	}
+
+	return append(steps,
+		githubDataStep{
+			name:  "Deleting author profiles",
+			query: "DELETE FROM author_profiles WHERE user_id = ?",
+			args:  []interface{}{userID},
+		},
+		githubDataStep{
+			name:  "Deleting saved replies",
+			query: "DELETE FROM saved_replies WHERE user_id = ?",
+			args:  []interface{}{userID},
+		},
+		githubDataStep{
+			name:  "Deleting sync state",
+			query: "DELETE FROM sync_state WHERE user_id = ?",
+			args:  []interface{}{userID},
+		},
+		// Note: This is a single-user app, so we update the user with id = 1
+		githubDataStep{
+			name: "Clearing sync settings",
+			query: "UPDATE users SET sync_settings = NULL, " +
+				"updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1",
+		},
+	)
+}
+
+// DeleteGitHubData deletes GitHub data for a user according to the disconnect mode.
+// All steps run in a single transaction; OnProgress is called after each step.
+// Preserves: tags, views, rules, users
+func (s *Store) DeleteGitHubData(
+	ctx context.Context,
+	userID string,
+	params db.DeleteGitHubDataParams,
+) error {
+	steps := githubDataSteps(params.Mode, userID)
+
 	return db.RetryVoidOnBusy(ctx, func() error {
 		// Use a transaction to ensure all deletes happen atomically
 		tx, err := s.dbConn.BeginTx(ctx, nil)
@@ -1529,45 +4392,20 @@ func (s *Store) DeleteAllGitHubData(ctx context.Context, userID string) error {
 			}
 		}()
 
-		// Delete in order to respect foreign key constraints
-		// 1. Tag assignments (references notifications)
-		_, err = tx.ExecContext(ctx, "DELETE FROM tag_assignments WHERE user_id = ?", userID)
-		if err != nil {
-			return err
-		}
-
-		// 2. Notifications
-		_, err = tx.ExecContext(ctx, "DELETE FROM notifications WHERE user_id = ?", userID)
-		if err != nil {
-			return err
-		}
-
-		// 3. Pull requests
-		_, err = tx.ExecContext(ctx, "DELETE FROM pull_requests WHERE user_id = ?", userID)
-		if err != nil {
-			return err
-		}
-
-		// 4. Repositories
-		_, err = tx.ExecContext(ctx, "DELETE FROM repositories WHERE user_id = ?", userID)
-		if err != nil {
-			return err
-		}
-
-		// 5. Sync state
-		_, err = tx.ExecContext(ctx, "DELETE FROM sync_state WHERE user_id = ?", userID)
-		if err != nil {
-			return err
-		}
-
-		// 6. Clear sync settings (reset to NULL so user has to set up sync again)
-		// Note: This is a single-user app, so we update the user with id = 1
-		_, err = tx.ExecContext(
-			ctx,
-			"UPDATE users SET sync_settings = NULL, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1",
-		)
-		if err != nil {
-			return err
+		for i, step := range steps {
+			res, err := tx.ExecContext(ctx, step.query, step.args...)
+			if err != nil {
+				return err
+			}
+			if params.OnProgress != nil {
+				rows, _ := res.RowsAffected()
+				params.OnProgress(db.DeleteGitHubDataProgress{
+					Step:           step.name,
+					StepsCompleted: i + 1,
+					TotalSteps:     len(steps),
+					RowsAffected:   rows,
+				})
+			}
 		}
 
 		return tx.Commit()
@@ -0,0 +1,480 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+const testUserID = "test-user-id"
+
+// newTestStore opens a migrated SQLite database in a temporary directory
+func newTestStore(tb testing.TB) *Store {
+	tb.Helper()
+
+	dbConn, err := db.OpenDatabase(filepath.Join(tb.TempDir(), "octobud.db"))
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		_ = dbConn.Close()
+	})
+
+	goose.SetLogger(goose.NopLogger())
+	require.NoError(tb, goose.SetDialect("sqlite3"))
+	goose.SetBaseFS(MigrationsFS)
+	require.NoError(tb, goose.Up(dbConn, "migrations"))
+
+	return NewStore(dbConn)
+}
+
+// seedTaggedNotifications creates a page of notifications with a few tags each and returns
+// their IDs
+func seedTaggedNotifications(tb testing.TB, store *Store, count, tagsEach int) []int64 {
+	tb.Helper()
+	ctx := context.Background()
+
+	repo, err := store.UpsertRepository(ctx, testUserID, db.UpsertRepositoryParams{
+		GithubID: sql.NullInt64{Int64: 1, Valid: true},
+		Name:     "cli",
+		FullName: "cli/cli",
+	})
+	require.NoError(tb, err)
+
+	tags := make([]db.Tag, tagsEach)
+	for i := range tags {
+		tags[i], err = store.UpsertTag(ctx, testUserID, db.UpsertTagParams{
+			Name: fmt.Sprintf("tag-%d", i),
+			Slug: fmt.Sprintf("tag-%d", i),
+		})
+		require.NoError(tb, err)
+	}
+
+	ids := make([]int64, count)
+	for i := range ids {
+		notification, err := store.UpsertNotification(ctx, testUserID, db.UpsertNotificationParams{
+			GithubID:     fmt.Sprintf("thread-%d", i),
+			RepositoryID: repo.ID,
+			SubjectType:  "PullRequest",
+			SubjectTitle: fmt.Sprintf("Pull request %d", i),
+		})
+		require.NoError(tb, err)
+		ids[i] = notification.ID
+
+		// Leave every other notification untagged
+		if i%2 == 1 {
+			continue
+		}
+		for _, tag := range tags {
+			_, err := store.AssignTagToEntity(ctx, testUserID, db.AssignTagToEntityParams{
+				TagID:      tag.ID,
+				EntityType: "notification",
+				EntityID:   notification.ID,
+			})
+			require.NoError(tb, err)
+		}
+	}
+	return ids
+}
+
+func TestStore_ListTagsForNotifications(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	ids := seedTaggedNotifications(t, store, 4, 2)
+
+	batched, err := store.ListTagsForNotifications(ctx, testUserID, ids)
+	require.NoError(t, err)
+	require.Len(t, batched, 2)
+
+	for _, id := range ids {
+		tags, err := store.ListTagsForEntity(ctx, testUserID, db.ListTagsForEntityParams{
+			EntityType: "notification",
+			EntityID:   id,
+		})
+		require.NoError(t, err)
+		require.Equal(t, len(tags), len(batched[id]))
+		for i := range tags {
+			require.Equal(t, tags[i].ID, batched[id][i].ID)
+			require.Equal(t, tags[i].Name, batched[id][i].Name)
+			require.Equal(t, tags[i].AssignedAt, batched[id][i].AssignedAt)
+		}
+	}
+
+	empty, err := store.ListTagsForNotifications(ctx, testUserID, nil)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func TestStore_ListNotificationsFromQuery_TagIDs(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	seedTaggedNotifications(t, store, 4, 2)
+
+	result, err := store.ListNotificationsFromQuery(ctx, testUserID, db.NotificationQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Notifications, 4)
+	for _, notification := range result.Notifications {
+		tags, err := store.ListTagsForEntity(ctx, testUserID, db.ListTagsForEntityParams{
+			EntityType: "notification",
+			EntityID:   notification.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, notification.TagIDs, len(tags))
+		require.Len(t, notification.TagsAssignedAt, len(tags))
+		assignedAt := make(map[string]time.Time, len(tags))
+		for _, tag := range tags {
+			assignedAt[tag.ID] = tag.AssignedAt.Time
+		}
+		for i, tagID := range notification.TagIDs {
+			require.Contains(t, assignedAt, tagID)
+			require.True(t, assignedAt[tagID].Equal(notification.TagsAssignedAt[i]))
+		}
+	}
+}
+
+func TestStore_BulkTagByQuery(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	ids := seedTaggedNotifications(t, store, 4, 1)
+
+	tags, err := store.ListAllTags(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	arg := db.BulkTagNotificationsByQueryParams{
+		Query: db.NotificationQuery{
+			Where: []string{"n.subject_title LIKE ?"},
+			Args:  []interface{}{"Pull request %"},
+		},
+		TagID: tags[0].ID,
+	}
+
+	// Half the notifications already have the tag, so only the other half change
+	count, err := store.BulkAssignTagByQuery(ctx, testUserID, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = store.BulkAssignTagByQuery(ctx, testUserID, arg)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	batched, err := store.ListTagsForNotifications(ctx, testUserID, ids)
+	require.NoError(t, err)
+	require.Len(t, batched, 4)
+
+	count, err = store.BulkRemoveTagByQuery(ctx, testUserID, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count)
+
+	batched, err = store.ListTagsForNotifications(ctx, testUserID, ids)
+	require.NoError(t, err)
+	require.Empty(t, batched)
+}
+
+func TestStore_ExplainNotificationQuery(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	plan, err := store.ExplainNotificationQuery(ctx, testUserID, db.NotificationQuery{
+		Where: []string{"n.subject_title LIKE ?"},
+		Args:  []interface{}{"Pull request %"},
+		Limit: 25,
+	})
+	require.NoError(t, err)
+	require.Contains(t, plan.SQL, "WHERE n.user_id = ? AND n.subject_title LIKE ?")
+	require.Contains(t, plan.SQL, "LIMIT 25 OFFSET 0")
+	require.Equal(t, []interface{}{testUserID, "Pull request %"}, plan.Args)
+	require.NotEmpty(t, plan.Steps)
+	for _, step := range plan.Steps {
+		require.NotEmpty(t, step.Detail)
+	}
+}
+
+func TestStore_ViewGroups(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	work, err := store.CreateViewGroup(ctx, testUserID, "Work")
+	require.NoError(t, err)
+	home, err := store.CreateViewGroup(ctx, testUserID, "Home")
+	require.NoError(t, err)
+	require.Greater(t, home.DisplayOrder, work.DisplayOrder)
+
+	_, err = store.CreateViewGroup(ctx, testUserID, "Work")
+	require.Error(t, err)
+
+	view, err := store.CreateView(ctx, testUserID, db.CreateViewParams{
+		Name:  "Reviews",
+		Slug:  "reviews",
+		Query: sql.NullString{String: "reason:review_requested", Valid: true},
+	})
+	require.NoError(t, err)
+	view, err = store.SetViewGroup(ctx, testUserID, db.SetViewGroupParams{
+		ViewID:  view.ID,
+		GroupID: sql.NullString{String: work.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, work.ID, view.GroupID.String)
+
+	collapsed, err := store.UpdateViewGroup(ctx, testUserID, db.UpdateViewGroupParams{
+		ID:        work.ID,
+		Collapsed: sql.NullBool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, collapsed.Collapsed)
+	require.Equal(t, "Work", collapsed.Name)
+
+	// Deleting the group keeps its views, ungrouped
+	deleted, err := store.DeleteViewGroup(ctx, testUserID, work.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	view, err = store.GetView(ctx, testUserID, view.ID)
+	require.NoError(t, err)
+	require.False(t, view.GroupID.Valid)
+
+	groups, err := store.ListViewGroups(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, home.ID, groups[0].ID)
+}
+
+func TestStore_ViewShares(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	view, err := store.CreateView(ctx, testUserID, db.CreateViewParams{
+		Name:  "Reviews",
+		Slug:  "reviews",
+		Query: sql.NullString{String: "reason:review_requested", Valid: true},
+	})
+	require.NoError(t, err)
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	share, err := store.UpsertViewShare(ctx, testUserID, db.UpsertViewShareParams{
+		ViewID:     view.ID,
+		Grantee:    "alice",
+		Permission: "read",
+		CreatedAt:  created,
+	})
+	require.NoError(t, err)
+	require.Equal(t, created, share.CreatedAt)
+
+	// Sharing again changes the permission and keeps when it was first shared
+	share, err = store.UpsertViewShare(ctx, testUserID, db.UpsertViewShareParams{
+		ViewID:     view.ID,
+		Grantee:    "alice",
+		Permission: "write",
+		CreatedAt:  created.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "write", share.Permission)
+	require.Equal(t, created, share.CreatedAt)
+
+	_, err = store.UpsertViewShare(ctx, testUserID, db.UpsertViewShareParams{
+		ViewID:     view.ID,
+		Grantee:    "bob",
+		Permission: "read",
+		CreatedAt:  created,
+	})
+	require.NoError(t, err)
+
+	shares, err := store.ListViewShares(ctx, testUserID, view.ID)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+	require.Equal(t, "alice", shares[0].Grantee)
+	byGrantee, err := store.ListViewSharesByGrantee(ctx, "bob")
+	require.NoError(t, err)
+	require.Len(t, byGrantee, 1)
+	require.Equal(t, testUserID, byGrantee[0].UserID)
+
+	// Grantees can remove a share, but only owners can remove it by owner
+	deleted, err := store.DeleteViewShare(ctx, "someone-else", view.ID, "bob")
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+	deleted, err = store.DeleteViewShareByGrantee(ctx, view.ID, "bob")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	_, err = store.GetViewShare(ctx, view.ID, "bob")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Deleting the view removes its shares
+	deleted, err = store.DeleteView(ctx, testUserID, view.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	_, err = store.GetViewShare(ctx, view.ID, "alice")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStore_DeleteApprovedCleanup(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	ids := seedTaggedNotifications(t, store, 4, 1)
+	for i := range ids {
+		_, err := store.ArchiveNotification(ctx, testUserID, fmt.Sprintf("thread-%d", i))
+		require.NoError(t, err)
+	}
+
+	params := db.CleanupParams{
+		ProtectTagged: true,
+		CutoffDate:    db.FormatTimestamp(time.Now().Add(time.Hour)),
+	}
+
+	// A rejected approval leaves everything in place
+	errRejected := errors.New("rejected")
+	_, err := store.DeleteApprovedCleanup(ctx, testUserID, params, func([]int64) error {
+		return errRejected
+	})
+	require.ErrorIs(t, err, errRejected)
+	count, err := store.CountEligibleForCleanup(ctx, testUserID, params)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// Only the untagged notifications are approved and deleted
+	var approved []int64
+	deleted, err := store.DeleteApprovedCleanup(ctx, testUserID, params, func(ids []int64) error {
+		approved = ids
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{ids[1], ids[3]}, approved)
+	require.Equal(t, int64(2), deleted)
+
+	count, err = store.CountEligibleForCleanup(ctx, testUserID, params)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestStore_APITokens(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	token, err := store.CreateAPIToken(ctx, testUserID, db.CreateAPITokenParams{
+		Name:        "CI",
+		TokenHash:   "hash-1",
+		TokenPrefix: "obt_abcd",
+		Scope:       "read",
+		Account:     "*",
+		CreatedAt:   created,
+		ExpiresAt:   sql.NullTime{Time: created.Add(24 * time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, token.ID)
+	require.Equal(t, created, token.CreatedAt)
+	require.False(t, token.LastUsedAt.Valid)
+
+	_, err = store.CreateAPIToken(ctx, testUserID, db.CreateAPITokenParams{
+		Name:      "CI",
+		TokenHash: "hash-2",
+		CreatedAt: created,
+	})
+	require.Error(t, err)
+
+	require.NoError(t, store.UpdateAPITokenLastUsed(ctx, token.ID, created.Add(time.Hour)))
+	found, err := store.GetAPITokenByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, token.ID, found.ID)
+	require.Equal(t, created.Add(time.Hour), found.LastUsedAt.Time)
+	require.Equal(t, created.Add(24*time.Hour), found.ExpiresAt.Time)
+
+	// Tokens can only be deleted by their owner
+	deleted, err := store.DeleteAPIToken(ctx, "someone-else", token.ID)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+	deleted, err = store.DeleteAPIToken(ctx, testUserID, token.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	tokens, err := store.ListAPITokens(ctx, testUserID)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+	_, err = store.GetAPITokenByHash(ctx, "hash-1")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// BenchmarkNotificationTagIDs compares converting a list page with a tag lookup per
+// notification against one lookup for the whole page
+func BenchmarkNotificationTagIDs(b *testing.B) {
+	ctx := context.Background()
+	store := newTestStore(b)
+	ids := seedTaggedNotifications(b, store, 50, 3)
+
+	page := make([]Notification, 0, len(ids))
+	for _, id := range ids {
+		n, err := store.q.GetNotificationByID(ctx, GetNotificationByIDParams{UserID: testUserID, ID: id})
+		require.NoError(b, err)
+		page = append(page, n)
+	}
+
+	b.Run("per_notification", func(b *testing.B) {
+		for b.Loop() {
+			for _, n := range page {
+				_ = store.toDBNotification(ctx, testUserID, n)
+			}
+		}
+	})
+	b.Run("batched", func(b *testing.B) {
+		for b.Loop() {
+			_ = store.toDBNotifications(ctx, testUserID, page)
+		}
+	})
+}
+
+// BenchmarkListTagsForNotifications compares fetching the tags shown on a list page one
+// notification at a time against fetching them in one query
+func BenchmarkListTagsForNotifications(b *testing.B) {
+	ctx := context.Background()
+	store := newTestStore(b)
+	ids := seedTaggedNotifications(b, store, 50, 3)
+
+	b.Run("per_notification", func(b *testing.B) {
+		for b.Loop() {
+			for _, id := range ids {
+				_, err := store.ListTagsForEntity(ctx, testUserID, db.ListTagsForEntityParams{
+					EntityType: "notification",
+					EntityID:   id,
+				})
+				require.NoError(b, err)
+			}
+		}
+	})
+	b.Run("batched", func(b *testing.B) {
+		for b.Loop() {
+			_, err := store.ListTagsForNotifications(ctx, testUserID, ids)
+			require.NoError(b, err)
+		}
+	})
+}
+
+// BenchmarkListNotificationsFromQuery measures listing a page of tagged notifications
+func BenchmarkListNotificationsFromQuery(b *testing.B) {
+	ctx := context.Background()
+	store := newTestStore(b)
+	seedTaggedNotifications(b, store, 50, 3)
+
+	for b.Loop() {
+		_, err := store.ListNotificationsFromQuery(ctx, testUserID, db.NotificationQuery{Limit: 50})
+		require.NoError(b, err)
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 const getSyncState = `-- name: GetSyncState :one
-SELECT id, user_id, last_successful_poll, last_notification_etag, created_at, updated_at, latest_notification_at, initial_sync_completed_at, oldest_notification_synced_at FROM sync_state WHERE user_id = ?
+SELECT id, user_id, last_successful_poll, last_notification_etag, created_at, updated_at, latest_notification_at, initial_sync_completed_at, oldest_notification_synced_at, initial_sync_started_at, initial_sync_pages, initial_sync_queued FROM sync_state WHERE user_id = ?
 `
 
 func (q *Queries) GetSyncState(ctx context.Context, userID string) (SyncState, error) {
@@ -27,10 +27,55 @@ func (q *Queries) GetSyncState(ctx context.Context, userID string) (SyncState, e
 		&i.LatestNotificationAt,
 		&i.InitialSyncCompletedAt,
 		&i.OldestNotificationSyncedAt,
+		&i.InitialSyncStartedAt,
+		&i.InitialSyncPages,
+		&i.InitialSyncQueued,
 	)
 	return i, err
 }
 
+const saveInitialSyncProgress = `-- name: SaveInitialSyncProgress :exec
+INSERT INTO sync_state (
+    user_id, latest_notification_at, oldest_notification_synced_at,
+    initial_sync_started_at, initial_sync_pages, initial_sync_queued,
+    created_at, updated_at
+) VALUES (
+    ?1, ?2, ?3,
+    ?4, ?5, ?6,
+    strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+)
+ON CONFLICT(user_id) DO UPDATE SET
+    latest_notification_at = COALESCE(sync_state.latest_notification_at, excluded.latest_notification_at),
+    oldest_notification_synced_at = COALESCE(excluded.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
+    initial_sync_started_at = COALESCE(sync_state.initial_sync_started_at, excluded.initial_sync_started_at),
+    initial_sync_pages = excluded.initial_sync_pages,
+    initial_sync_queued = excluded.initial_sync_queued,
+    updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+`
+
+type SaveInitialSyncProgressParams struct {
+	UserID                     string
+	LatestNotificationAt       sql.NullString
+	OldestNotificationSyncedAt sql.NullString
+	StartedAt                  sql.NullString
+	Pages                      int64
+	Queued                     int64
+}
+
+// Records a page of the initial sync once its notifications are queued. The start time
+// is kept from the first page and the latest notification from the newest page.
+func (q *Queries) SaveInitialSyncProgress(ctx context.Context, arg SaveInitialSyncProgressParams) error {
+	_, err := q.db.ExecContext(ctx, saveInitialSyncProgress,
+		arg.UserID,
+		arg.LatestNotificationAt,
+		arg.OldestNotificationSyncedAt,
+		arg.StartedAt,
+		arg.Pages,
+		arg.Queued,
+	)
+	return err
+}
+
 const upsertSyncState = `-- name: UpsertSyncState :one
 INSERT INTO sync_state (
     user_id, last_successful_poll, last_notification_etag, 
@@ -46,7 +91,7 @@ ON CONFLICT(user_id) DO UPDATE SET
     initial_sync_completed_at = COALESCE(excluded.initial_sync_completed_at, sync_state.initial_sync_completed_at),
     oldest_notification_synced_at = COALESCE(excluded.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
-RETURNING id, user_id, last_successful_poll, last_notification_etag, created_at, updated_at, latest_notification_at, initial_sync_completed_at, oldest_notification_synced_at
+RETURNING id, user_id, last_successful_poll, last_notification_etag, created_at, updated_at, latest_notification_at, initial_sync_completed_at, oldest_notification_synced_at, initial_sync_started_at, initial_sync_pages, initial_sync_queued
 `
 
 type UpsertSyncStateParams struct {
@@ -78,6 +123,9 @@ func (q *Queries) UpsertSyncState(ctx context.Context, arg UpsertSyncStateParams
 		&i.LatestNotificationAt,
 		&i.InitialSyncCompletedAt,
 		&i.OldestNotificationSyncedAt,
+		&i.InitialSyncStartedAt,
+		&i.InitialSyncPages,
+		&i.InitialSyncQueued,
 	)
 	return i, err
 }
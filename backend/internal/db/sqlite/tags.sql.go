@@ -139,6 +139,31 @@ func (q *Queries) GetTagByName(ctx context.Context, arg GetTagByNameParams) (Tag
 	return i, err
 }
 
+const getTagBySlug = `-- name: GetTagBySlug :one
+SELECT id, user_id, name, color, description, created_at, display_order, slug FROM tags WHERE user_id = ? AND slug = ?
+`
+
+type GetTagBySlugParams struct {
+	UserID string
+	Slug   string
+}
+
+func (q *Queries) GetTagBySlug(ctx context.Context, arg GetTagBySlugParams) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTagBySlug, arg.UserID, arg.Slug)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Color,
+		&i.Description,
+		&i.CreatedAt,
+		&i.DisplayOrder,
+		&i.Slug,
+	)
+	return i, err
+}
+
 const listAllTags = `-- name: ListAllTags :many
 SELECT id, user_id, name, color, description, created_at, display_order, slug FROM tags WHERE user_id = ? ORDER BY display_order, name
 `
@@ -176,7 +201,7 @@ func (q *Queries) ListAllTags(ctx context.Context, userID string) ([]Tag, error)
 }
 
 const listTagsForEntity = `-- name: ListTagsForEntity :many
-SELECT t.id, t.user_id, t.name, t.color, t.description, t.created_at, t.display_order, t.slug FROM tags t
+SELECT t.id, t.user_id, t.name, t.color, t.description, t.created_at, t.display_order, t.slug, ta.created_at AS assigned_at FROM tags t
 JOIN tag_assignments ta ON t.id = ta.tag_id
 WHERE t.user_id = ? AND ta.entity_type = ? AND ta.entity_id = ?
 ORDER BY t.display_order, t.name
@@ -188,15 +213,27 @@ type ListTagsForEntityParams struct {
 	EntityID   int64
 }
 
-func (q *Queries) ListTagsForEntity(ctx context.Context, arg ListTagsForEntityParams) ([]Tag, error) {
+type ListTagsForEntityRow struct {
+	ID           string
+	UserID       string
+	Name         string
+	Color        sql.NullString
+	Description  sql.NullString
+	CreatedAt    string
+	DisplayOrder int64
+	Slug         string
+	AssignedAt   string
+}
+
+func (q *Queries) ListTagsForEntity(ctx context.Context, arg ListTagsForEntityParams) ([]ListTagsForEntityRow, error) {
 	rows, err := q.db.QueryContext(ctx, listTagsForEntity, arg.UserID, arg.EntityType, arg.EntityID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Tag
+	var items []ListTagsForEntityRow
 	for rows.Next() {
-		var i Tag
+		var i ListTagsForEntityRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -206,6 +243,7 @@ func (q *Queries) ListTagsForEntity(ctx context.Context, arg ListTagsForEntityPa
 			&i.CreatedAt,
 			&i.DisplayOrder,
 			&i.Slug,
+			&i.AssignedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -16,7 +16,7 @@ UPDATE users SET
     github_user_id = NULL,
     github_username = NULL,
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') 
-WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) ClearUserGitHubToken(ctx context.Context) (User, error) {
@@ -33,6 +33,14 @@ func (q *Queries) ClearUserGitHubToken(ctx context.Context) (User, error) {
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
@@ -40,7 +48,7 @@ func (q *Queries) ClearUserGitHubToken(ctx context.Context) (User, error) {
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, created_at, updated_at)
 VALUES (1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
-RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 // Creates the single user record (id is always 1)
@@ -58,12 +66,20 @@ func (q *Queries) CreateUser(ctx context.Context) (User, error) {
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings FROM users WHERE id = 1
+SELECT id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings FROM users WHERE id = 1
 `
 
 func (q *Queries) GetUser(ctx context.Context) (User, error) {
@@ -80,6 +96,134 @@ func (q *Queries) GetUser(ctx context.Context) (User, error) {
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserAlertSettings = `-- name: UpdateUserAlertSettings :one
+UPDATE users SET alert_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserAlertSettings(ctx context.Context, alertSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserAlertSettings, alertSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserBackupSettings = `-- name: UpdateUserBackupSettings :one
+UPDATE users SET backup_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserBackupSettings(ctx context.Context, backupSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserBackupSettings, backupSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserDigestSettings = `-- name: UpdateUserDigestSettings :one
+UPDATE users SET digest_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserDigestSettings(ctx context.Context, digestSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserDigestSettings, digestSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserEscalationSettings = `-- name: UpdateUserEscalationSettings :one
+UPDATE users SET escalation_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserEscalationSettings(ctx context.Context, escalationSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserEscalationSettings, escalationSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
@@ -89,7 +233,7 @@ UPDATE users SET
     github_user_id = ?, 
     github_username = ?,
     updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') 
-WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 type UpdateUserGitHubIdentityParams struct {
@@ -112,12 +256,20 @@ func (q *Queries) UpdateUserGitHubIdentity(ctx context.Context, arg UpdateUserGi
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const updateUserGitHubToken = `-- name: UpdateUserGitHubToken :one
-UPDATE users SET github_token_encrypted = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+UPDATE users SET github_token_encrypted = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) UpdateUserGitHubToken(ctx context.Context, githubTokenEncrypted sql.NullString) (User, error) {
@@ -134,12 +286,20 @@ func (q *Queries) UpdateUserGitHubToken(ctx context.Context, githubTokenEncrypte
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const updateUserMutedUntil = `-- name: UpdateUserMutedUntil :one
-UPDATE users SET muted_until = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+UPDATE users SET muted_until = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) UpdateUserMutedUntil(ctx context.Context, mutedUntil sql.NullString) (User, error) {
@@ -156,12 +316,50 @@ func (q *Queries) UpdateUserMutedUntil(ctx context.Context, mutedUntil sql.NullS
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserPrioritySettings = `-- name: UpdateUserPrioritySettings :one
+UPDATE users SET priority_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserPrioritySettings(ctx context.Context, prioritySettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPrioritySettings, prioritySettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const updateUserRetentionSettings = `-- name: UpdateUserRetentionSettings :one
-UPDATE users SET retention_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+UPDATE users SET retention_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) UpdateUserRetentionSettings(ctx context.Context, retentionSettings sql.NullString) (User, error) {
@@ -178,12 +376,50 @@ func (q *Queries) UpdateUserRetentionSettings(ctx context.Context, retentionSett
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserSnoozeSettings = `-- name: UpdateUserSnoozeSettings :one
+UPDATE users SET snooze_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserSnoozeSettings(ctx context.Context, snoozeSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserSnoozeSettings, snoozeSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const updateUserSyncSettings = `-- name: UpdateUserSyncSettings :one
-UPDATE users SET sync_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+UPDATE users SET sync_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) UpdateUserSyncSettings(ctx context.Context, syncSettings sql.NullString) (User, error) {
@@ -200,12 +436,50 @@ func (q *Queries) UpdateUserSyncSettings(ctx context.Context, syncSettings sql.N
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserTeamSettings = `-- name: UpdateUserTeamSettings :one
+UPDATE users SET team_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserTeamSettings(ctx context.Context, teamSettings sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserTeamSettings, teamSettings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
 
 const updateUserUpdateSettings = `-- name: UpdateUserUpdateSettings :one
-UPDATE users SET update_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings
+UPDATE users SET update_settings = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
 `
 
 func (q *Queries) UpdateUserUpdateSettings(ctx context.Context, updateSettings sql.NullString) (User, error) {
@@ -222,6 +496,44 @@ func (q *Queries) UpdateUserUpdateSettings(ctx context.Context, updateSettings s
 		&i.RetentionSettings,
 		&i.MutedUntil,
 		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
+	)
+	return i, err
+}
+
+const updateUserWorkingHours = `-- name: UpdateUserWorkingHours :one
+UPDATE users SET working_hours = ?, updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = 1 RETURNING id, github_user_id, github_username, github_token_encrypted, created_at, updated_at, sync_settings, retention_settings, muted_until, update_settings, escalation_settings, working_hours, team_settings, alert_settings, snooze_settings, backup_settings, priority_settings, digest_settings
+`
+
+func (q *Queries) UpdateUserWorkingHours(ctx context.Context, workingHours sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserWorkingHours, workingHours)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GithubUserID,
+		&i.GithubUsername,
+		&i.GithubTokenEncrypted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SyncSettings,
+		&i.RetentionSettings,
+		&i.MutedUntil,
+		&i.UpdateSettings,
+		&i.EscalationSettings,
+		&i.WorkingHours,
+		&i.TeamSettings,
+		&i.AlertSettings,
+		&i.SnoozeSettings,
+		&i.BackupSettings,
+		&i.PrioritySettings,
+		&i.DigestSettings,
 	)
 	return i, err
 }
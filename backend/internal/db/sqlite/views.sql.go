@@ -13,7 +13,7 @@ import (
 const createView = `-- name: CreateView :one
 INSERT INTO views (user_id, name, slug, description, is_default, icon, query, display_order, created_at)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
-RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order
+RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id
 `
 
 type CreateViewParams struct {
@@ -50,6 +50,9 @@ func (q *Queries) CreateView(ctx context.Context, arg CreateViewParams) (View, e
 		&i.Slug,
 		&i.Query,
 		&i.DisplayOrder,
+		&i.WipLimit,
+		&i.WipAutoSnooze,
+		&i.GroupID,
 	)
 	return i, err
 }
@@ -72,7 +75,7 @@ func (q *Queries) DeleteView(ctx context.Context, arg DeleteViewParams) (int64,
 }
 
 const getView = `-- name: GetView :one
-SELECT id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order FROM views WHERE user_id = ? AND id = ?
+SELECT id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id FROM views WHERE user_id = ? AND id = ?
 `
 
 type GetViewParams struct {
@@ -94,12 +97,15 @@ func (q *Queries) GetView(ctx context.Context, arg GetViewParams) (View, error)
 		&i.Slug,
 		&i.Query,
 		&i.DisplayOrder,
+		&i.WipLimit,
+		&i.WipAutoSnooze,
+		&i.GroupID,
 	)
 	return i, err
 }
 
 const listViews = `-- name: ListViews :many
-SELECT id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order FROM views WHERE user_id = ? ORDER BY display_order, name
+SELECT id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id FROM views WHERE user_id = ? ORDER BY display_order, name
 `
 
 func (q *Queries) ListViews(ctx context.Context, userID string) ([]View, error) {
@@ -122,6 +128,9 @@ func (q *Queries) ListViews(ctx context.Context, userID string) ([]View, error)
 			&i.Slug,
 			&i.Query,
 			&i.DisplayOrder,
+			&i.WipLimit,
+			&i.WipAutoSnooze,
+			&i.GroupID,
 		); err != nil {
 			return nil, err
 		}
@@ -136,6 +145,52 @@ func (q *Queries) ListViews(ctx context.Context, userID string) ([]View, error)
 	return items, nil
 }
 
+const setViewGroup = `-- name: SetViewGroup :one
+UPDATE views SET group_id = ? WHERE user_id = ? AND id = ?
+RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id
+`
+
+type SetViewGroupParams struct {
+	GroupID sql.NullString
+	UserID  string
+	ID      string
+}
+
+func (q *Queries) SetViewGroup(ctx context.Context, arg SetViewGroupParams) (View, error) {
+	row := q.db.QueryRowContext(ctx, setViewGroup, arg.GroupID, arg.UserID, arg.ID)
+	var i View
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.Icon,
+		&i.Slug,
+		&i.Query,
+		&i.DisplayOrder,
+		&i.WipLimit,
+		&i.WipAutoSnooze,
+		&i.GroupID,
+	)
+	return i, err
+}
+
+const ungroupViews = `-- name: UngroupViews :exec
+UPDATE views SET group_id = NULL WHERE user_id = ? AND group_id = ?
+`
+
+type UngroupViewsParams struct {
+	UserID  string
+	GroupID sql.NullString
+}
+
+func (q *Queries) UngroupViews(ctx context.Context, arg UngroupViewsParams) error {
+	_, err := q.db.ExecContext(ctx, ungroupViews, arg.UserID, arg.GroupID)
+	return err
+}
+
 const updateView = `-- name: UpdateView :one
 UPDATE views SET
     name = COALESCE(?, name),
@@ -145,7 +200,7 @@ UPDATE views SET
     query = COALESCE(?, query),
     is_default = COALESCE(?, is_default)
 WHERE user_id = ? AND id = ?
-RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order
+RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id
 `
 
 type UpdateViewParams struct {
@@ -182,6 +237,48 @@ func (q *Queries) UpdateView(ctx context.Context, arg UpdateViewParams) (View, e
 		&i.Slug,
 		&i.Query,
 		&i.DisplayOrder,
+		&i.WipLimit,
+		&i.WipAutoSnooze,
+		&i.GroupID,
+	)
+	return i, err
+}
+
+const updateViewWIPLimit = `-- name: UpdateViewWIPLimit :one
+UPDATE views SET wip_limit = ?, wip_auto_snooze = ?
+WHERE user_id = ? AND id = ?
+RETURNING id, user_id, name, description, is_default, created_at, icon, slug, "query", display_order, wip_limit, wip_auto_snooze, group_id
+`
+
+type UpdateViewWIPLimitParams struct {
+	WipLimit      sql.NullInt64
+	WipAutoSnooze int64
+	UserID        string
+	ID            string
+}
+
+func (q *Queries) UpdateViewWIPLimit(ctx context.Context, arg UpdateViewWIPLimitParams) (View, error) {
+	row := q.db.QueryRowContext(ctx, updateViewWIPLimit,
+		arg.WipLimit,
+		arg.WipAutoSnooze,
+		arg.UserID,
+		arg.ID,
+	)
+	var i View
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.Icon,
+		&i.Slug,
+		&i.Query,
+		&i.DisplayOrder,
+		&i.WipLimit,
+		&i.WipAutoSnooze,
+		&i.GroupID,
 	)
 	return i, err
 }
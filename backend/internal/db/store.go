@@ -18,6 +18,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 //go:generate mockgen -source=store.go -destination=mocks/mock_store.go -package=mocks
@@ -34,6 +35,25 @@ type Store interface {
 		userID string,
 		query NotificationQuery,
 	) (ListNotificationsFromQueryResult, error)
+	ListNotificationGithubIDsFromQuery(
+		ctx context.Context,
+		userID string,
+		query NotificationQuery,
+	) ([]string, error)
+	// ListNotificationGroupsFromQuery groups notifications matching a query, most recently
+	// active group first
+	ListNotificationGroupsFromQuery(
+		ctx context.Context,
+		userID string,
+		params NotificationGroupsParams,
+	) ([]NotificationGroup, error)
+	// ExplainNotificationQuery returns the statement that lists a page of a query's
+	// notifications and SQLite's plan for it, without running it
+	ExplainNotificationQuery(
+		ctx context.Context,
+		userID string,
+		query NotificationQuery,
+	) (NotificationQueryPlan, error)
 	MarkNotificationRead(ctx context.Context, userID, githubID string) (Notification, error)
 	MarkNotificationUnread(ctx context.Context, userID, githubID string) (Notification, error)
 	ArchiveNotification(ctx context.Context, userID, githubID string) (Notification, error)
@@ -57,6 +77,37 @@ type Store interface {
 	) (int64, error)
 
 	BulkMarkNotificationsRead(ctx context.Context, userID string, githubIDs []string) (int64, error)
+	ListNotificationGithubIDsBySubjectURL(
+		ctx context.Context,
+		userID, subjectURL string,
+	) ([]string, error)
+	// ListNotificationAuthors lists up to limit distinct notification authors whose login
+	// starts with prefix, ignoring case, most frequent first
+	ListNotificationAuthors(ctx context.Context, userID, prefix string, limit int) ([]string, error)
+	// ListNotificationLabels lists up to limit distinct issue and pull request labels that
+	// start with prefix, ignoring case, most used first
+	ListNotificationLabels(ctx context.Context, userID, prefix string, limit int) ([]string, error)
+	MarkNotificationsReadBySubjectURL(
+		ctx context.Context,
+		userID, subjectURL string,
+	) ([]string, error)
+	EscalateStaleReviewRequests(
+		ctx context.Context,
+		userID string,
+		params EscalationParams,
+	) ([]EscalatedNotification, error)
+	// ClassifyAwaitingReplies returns the GitHub IDs of threads that started awaiting a reply
+	ClassifyAwaitingReplies(
+		ctx context.Context,
+		userID string,
+		params AwaitingReplyParams,
+	) ([]string, error)
+	// ReturnExpiredSnoozes returns the GitHub IDs of notifications whose snooze ran out
+	ReturnExpiredSnoozes(
+		ctx context.Context,
+		userID string,
+		params SnoozeReturnParams,
+	) ([]string, error)
 
 	BulkMarkNotificationsUnread(
 		ctx context.Context,
@@ -96,6 +147,16 @@ type Store interface {
 		userID string,
 		arg BulkSnoozeNotificationsByQueryParams,
 	) (int64, error)
+	BulkAssignTagByQuery(
+		ctx context.Context,
+		userID string,
+		arg BulkTagNotificationsByQueryParams,
+	) (int64, error)
+	BulkRemoveTagByQuery(
+		ctx context.Context,
+		userID string,
+		arg BulkTagNotificationsByQueryParams,
+	) (int64, error)
 
 	BulkUnsnoozeNotifications(ctx context.Context, userID string, githubIDs []string) (int64, error)
 	BulkUnsnoozeNotificationsByQuery(
@@ -137,20 +198,42 @@ type Store interface {
 		userID string,
 		githubIDs []string,
 	) (int64, error)
+	BulkMarkNotificationsFilteredByQuery(
+		ctx context.Context,
+		userID string,
+		query NotificationQuery,
+	) (int64, error)
+
+	// Bulk undo methods
+	// CaptureBulkOperation saves the state of the notifications a bulk operation is about to
+	// change and returns the operation's ID, pruning operations captured before arg.PruneBefore
+	CaptureBulkOperation(ctx context.Context, userID string, arg CaptureBulkOperationParams) (int64, error)
+	GetBulkOperation(ctx context.Context, userID string, id int64) (BulkOperation, error)
+	// UndoBulkOperation restores what a bulk operation changed and returns the GitHub IDs it
+	// restored, or sql.ErrNoRows if the operation doesn't exist or was already undone
+	UndoBulkOperation(ctx context.Context, userID string, id int64, undoneAt time.Time) ([]string, error)
 
 	// Tag methods (IDs are now UUIDs/strings)
 	GetTag(ctx context.Context, userID, id string) (Tag, error)
 	GetTagByName(ctx context.Context, userID, name string) (Tag, error)
+	GetTagBySlug(ctx context.Context, userID, slug string) (Tag, error)
 	ListAllTags(ctx context.Context, userID string) ([]Tag, error)
 	UpsertTag(ctx context.Context, userID string, arg UpsertTagParams) (Tag, error)
 	UpdateTag(ctx context.Context, userID string, arg UpdateTagParams) (Tag, error)
 	DeleteTag(ctx context.Context, userID, id string) error
+	GetRulesByTagID(ctx context.Context, userID, tagID string) ([]Rule, error)
+	DeleteTagAndDisableRules(ctx context.Context, userID, id string) ([]Rule, error)
 	UpdateTagDisplayOrder(ctx context.Context, userID string, arg UpdateTagDisplayOrderParams) error
 	ListTagsForEntity(
 		ctx context.Context,
 		userID string,
 		arg ListTagsForEntityParams,
 	) ([]Tag, error)
+	ListTagsForNotifications(
+		ctx context.Context,
+		userID string,
+		notificationIDs []int64,
+	) (map[int64][]Tag, error)
 	AssignTagToEntity(
 		ctx context.Context,
 		userID string,
@@ -163,8 +246,29 @@ type Store interface {
 	ListViews(ctx context.Context, userID string) ([]View, error)
 	CreateView(ctx context.Context, userID string, arg CreateViewParams) (View, error)
 	UpdateView(ctx context.Context, userID string, arg UpdateViewParams) (View, error)
+	UpdateViewWIPLimit(ctx context.Context, userID string, arg UpdateViewWIPLimitParams) (View, error)
 	DeleteView(ctx context.Context, userID, id string) (int64, error)
+	DeleteViewAndDisableRules(ctx context.Context, userID, id string) ([]Rule, error)
 	UpdateViewOrder(ctx context.Context, userID string, arg UpdateViewOrderParams) error
+	SetViewGroup(ctx context.Context, userID string, arg SetViewGroupParams) (View, error)
+
+	// View share methods
+	ListViewShares(ctx context.Context, userID, viewID string) ([]ViewShare, error)
+	// ListViewSharesByGrantee and GetViewShare find shares by the user a view is shared
+	// with rather than its owner
+	ListViewSharesByGrantee(ctx context.Context, grantee string) ([]ViewShare, error)
+	GetViewShare(ctx context.Context, viewID, grantee string) (ViewShare, error)
+	UpsertViewShare(ctx context.Context, userID string, arg UpsertViewShareParams) (ViewShare, error)
+	DeleteViewShare(ctx context.Context, userID, viewID, grantee string) (int64, error)
+	DeleteViewShareByGrantee(ctx context.Context, viewID, grantee string) (int64, error)
+
+	// View group methods
+	GetViewGroup(ctx context.Context, userID, id string) (ViewGroup, error)
+	ListViewGroups(ctx context.Context, userID string) ([]ViewGroup, error)
+	CreateViewGroup(ctx context.Context, userID, name string) (ViewGroup, error)
+	UpdateViewGroup(ctx context.Context, userID string, arg UpdateViewGroupParams) (ViewGroup, error)
+	UpdateViewGroupOrder(ctx context.Context, userID string, arg UpdateViewGroupOrderParams) error
+	DeleteViewGroup(ctx context.Context, userID, id string) (int64, error)
 	GetRulesByViewID(ctx context.Context, userID string, viewID sql.NullString) ([]Rule, error)
 
 	// Rule methods (IDs are now UUIDs/strings)
@@ -178,12 +282,245 @@ type Store interface {
 
 	// Repository methods
 	GetRepositoryByID(ctx context.Context, userID string, id int64) (Repository, error)
+	GetRepositoryByGithubID(ctx context.Context, userID string, githubID int64) (Repository, error)
+	GetRepositoryByFullName(ctx context.Context, userID, fullName string) (Repository, error)
 	ListRepositories(ctx context.Context, userID string) ([]Repository, error)
 	UpsertRepository(
 		ctx context.Context,
 		userID string,
 		arg UpsertRepositoryParams,
 	) (Repository, error)
+	RenameRepository(ctx context.Context, userID string, arg RenameRepositoryParams) error
+	MergeRepositories(
+		ctx context.Context,
+		userID string,
+		arg MergeRepositoriesParams,
+	) (MergeRepositoriesResult, error)
+	ListRepositoryDigests(ctx context.Context, userID string) (map[int64]RepositoryDigest, error)
+	// ListRepositorySubjectTypeCounts counts each repository's notifications by subject
+	// type, keyed by repository ID and then subject type
+	ListRepositorySubjectTypeCounts(
+		ctx context.Context,
+		userID string,
+	) (map[int64]map[string]int64, error)
+	GetRepositoryDigest(
+		ctx context.Context,
+		userID string,
+		repositoryID int64,
+	) (RepositoryDigest, error)
+	// GetRepositorySettings returns sql.ErrNoRows when nothing was saved for the repository
+	GetRepositorySettings(
+		ctx context.Context,
+		userID string,
+		repositoryID int64,
+	) (RepositorySetting, error)
+	UpsertRepositorySettings(
+		ctx context.Context,
+		userID string,
+		arg UpsertRepositorySettingsParams,
+	) (RepositorySetting, error)
+
+	// Author profile methods
+	ListAuthorProfiles(ctx context.Context, userID string) ([]AuthorProfile, error)
+	UpsertAuthorProfile(ctx context.Context, userID string, arg UpsertAuthorProfileParams) error
+
+	// Saved reply methods
+	ListSavedReplies(ctx context.Context, userID string) ([]SavedReply, error)
+	GetSavedReply(ctx context.Context, userID, githubID string) (SavedReply, error)
+	// ReplaceSavedReplies swaps a user's saved replies for a freshly imported set
+	ReplaceSavedReplies(ctx context.Context, userID string, arg ReplaceSavedRepliesParams) error
+
+	// Triage backup and restore methods
+	// ListTriageStatesAfter lists up to limit notifications with triage state, ordered by
+	// GitHub ID and starting after afterGithubID
+	ListTriageStatesAfter(
+		ctx context.Context,
+		userID, afterGithubID string,
+		limit int64,
+	) ([]TriageState, error)
+	// StageTriageRestores saves restores to be merged into notifications as they sync,
+	// replacing anything already staged for the same GitHub IDs
+	StageTriageRestores(ctx context.Context, userID string, restores []TriageRestore) error
+	// ApplyTriageRestores merges every staged restore whose notification exists and
+	// returns how many were applied
+	ApplyTriageRestores(ctx context.Context, userID string) (int64, error)
+	// ApplyTriageRestore merges the staged restore for one notification, if there is one
+	ApplyTriageRestore(ctx context.Context, userID, githubID string) (bool, error)
+	CountTriageRestores(ctx context.Context, userID string) (int64, error)
+	DeleteTriageRestores(ctx context.Context, userID string) (int64, error)
+
+	// Linked account methods
+	ListLinkedAccounts(ctx context.Context, userID string) ([]LinkedAccount, error)
+	UpsertLinkedAccount(
+		ctx context.Context,
+		userID string,
+		arg UpsertLinkedAccountParams,
+	) (LinkedAccount, error)
+	DeleteLinkedAccount(ctx context.Context, userID, login string) (int64, error)
+	UpdateLinkedAccountSyncedAt(ctx context.Context, userID, login string, syncedAt time.Time) error
+	// DeleteNotificationsByAccount deletes the notifications synced for an account, along
+	// with their tag assignments
+	DeleteNotificationsByAccount(ctx context.Context, userID, account string) (int64, error)
+
+	// API token methods
+	ListAPITokens(ctx context.Context, userID string) ([]APIToken, error)
+	// GetAPITokenByHash finds a token by the hash of its value, whichever user it
+	// belongs to
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (APIToken, error)
+	CreateAPIToken(ctx context.Context, userID string, arg CreateAPITokenParams) (APIToken, error)
+	UpdateAPITokenLastUsed(ctx context.Context, id string, usedAt time.Time) error
+	DeleteAPIToken(ctx context.Context, userID, id string) (int64, error)
+
+	// Integration methods
+	ListIntegrations(ctx context.Context, userID string) ([]Integration, error)
+	GetIntegration(ctx context.Context, userID, id string) (Integration, error)
+	CreateIntegration(
+		ctx context.Context,
+		userID string,
+		arg CreateIntegrationParams,
+	) (Integration, error)
+	UpdateIntegration(
+		ctx context.Context,
+		userID, id string,
+		arg UpdateIntegrationParams,
+	) (Integration, error)
+	// DeleteIntegration deletes an integration along with its deliveries
+	DeleteIntegration(ctx context.Context, userID, id string) (int64, error)
+	// CreateIntegrationDelivery queues a delivery, due right away
+	CreateIntegrationDelivery(
+		ctx context.Context,
+		userID string,
+		arg CreateIntegrationDeliveryParams,
+	) (IntegrationDelivery, error)
+	// ListDueIntegrationDeliveries lists up to limit pending deliveries due by now, oldest
+	// first
+	ListDueIntegrationDeliveries(
+		ctx context.Context,
+		userID string,
+		now time.Time,
+		limit int64,
+	) ([]IntegrationDelivery, error)
+	UpdateIntegrationDelivery(
+		ctx context.Context,
+		userID string,
+		id int64,
+		arg UpdateIntegrationDeliveryParams,
+	) error
+	// ListIntegrationDeliveries lists an integration's most recent deliveries, newest first
+	ListIntegrationDeliveries(
+		ctx context.Context,
+		userID, integrationID string,
+		limit int64,
+	) ([]IntegrationDelivery, error)
+	// DeleteIntegrationDeliveriesBefore deletes finished deliveries created before the
+	// given time
+	DeleteIntegrationDeliveriesBefore(
+		ctx context.Context,
+		userID string,
+		before time.Time,
+	) (int64, error)
+
+	// Notification event methods
+	// RecordNotificationEvents adds an event to the history of each notification, skipping
+	// GitHub IDs that don't exist
+	RecordNotificationEvents(ctx context.Context, userID string, arg RecordNotificationEventsParams) error
+	// ListNotificationEvents lists a notification's history, most recent first
+	ListNotificationEvents(
+		ctx context.Context,
+		userID, githubID string,
+		limit int64,
+	) ([]NotificationEvent, error)
+	// ListDailyNotificationActivity counts the notifications synced, read and archived on
+	// each UTC day since the given time, skipping days without any
+	ListDailyNotificationActivity(
+		ctx context.Context,
+		userID string,
+		since time.Time,
+	) ([]DailyNotificationActivity, error)
+	// GetMeanTimeToRead measures how long notifications read by the user since the given
+	// time waited after their latest update on GitHub
+	GetMeanTimeToRead(ctx context.Context, userID string, since time.Time) (MeanTimeToRead, error)
+	// ListNotificationVolume counts notifications updated since the given time by
+	// repository and reason
+	ListNotificationVolume(
+		ctx context.Context,
+		userID string,
+		since time.Time,
+	) ([]NotificationVolume, error)
+
+	// Org offboarding methods
+	// ListOrgOffboardings lists the orgs the user left, most recent first
+	ListOrgOffboardings(ctx context.Context, userID string) ([]OrgOffboarding, error)
+	IsOrgOffboarded(ctx context.Context, userID, org string) (bool, error)
+	UpsertOrgOffboarding(
+		ctx context.Context,
+		userID string,
+		arg UpsertOrgOffboardingParams,
+	) (OrgOffboarding, error)
+	DeleteOrgOffboarding(ctx context.Context, userID, org string) (int64, error)
+	// CountRepositoriesByOrg counts the repositories owned by an org
+	CountRepositoriesByOrg(ctx context.Context, userID, org string) (int64, error)
+	// DeleteNotificationsByOrg deletes the notifications of an org's repositories, along
+	// with their tag assignments
+	DeleteNotificationsByOrg(ctx context.Context, userID, org string) (int64, error)
+
+	// Notification alert methods
+	CreateNotificationAlert(
+		ctx context.Context,
+		userID string,
+		arg CreateNotificationAlertParams,
+	) (NotificationAlert, error)
+	// ListNotificationAlertsAfter lists alerts recorded after afterID, oldest first
+	ListNotificationAlertsAfter(
+		ctx context.Context,
+		userID string,
+		afterID, limit int64,
+	) ([]NotificationAlert, error)
+	// ListRecentNotificationAlerts lists the most recent alerts, oldest first
+	ListRecentNotificationAlerts(
+		ctx context.Context,
+		userID string,
+		limit int64,
+	) ([]NotificationAlert, error)
+	DeleteNotificationAlertsBefore(ctx context.Context, userID string, before time.Time) (int64, error)
+
+	// Rule evaluation methods
+	UpsertRuleEvaluation(ctx context.Context, userID string, arg UpsertRuleEvaluationParams) error
+	// ListRuleEvaluations lists the most recent evaluations, newest first. An empty
+	// githubID lists them for every notification.
+	ListRuleEvaluations(
+		ctx context.Context,
+		userID string,
+		githubID string,
+		limit int64,
+	) ([]RuleEvaluation, error)
+
+	// Recent access methods
+	// RecordRecentAccess records that something was opened, dropping all but the most
+	// recent arg.Keep entries of its kind
+	RecordRecentAccess(ctx context.Context, userID string, arg RecordRecentAccessParams) error
+	// ListRecentAccess lists the most recently opened entries of a kind, newest first
+	ListRecentAccess(
+		ctx context.Context,
+		userID string,
+		kind string,
+		limit int64,
+	) ([]RecentAccess, error)
+	// ClearRecentAccess forgets every entry of a kind
+	ClearRecentAccess(ctx context.Context, userID, kind string) error
+
+	// GetNotificationRevision returns a counter that goes up whenever any of the user's
+	// notifications or their tags change. It's 0 before the first change.
+	GetNotificationRevision(ctx context.Context, userID string) (int64, error)
+
+	// Changelog methods
+	InsertChangelogEntry(ctx context.Context, arg InsertChangelogEntryParams) error
+	ListUnseenChangelogEntries(ctx context.Context) ([]ChangelogEntry, error)
+	AcknowledgeChangelogEntries(
+		ctx context.Context,
+		upToID int64,
+		acknowledgedAt time.Time,
+	) (int64, error)
 
 	// Pull Request methods
 	UpsertPullRequest(
@@ -191,6 +528,13 @@ type Store interface {
 		userID string,
 		arg UpsertPullRequestParams,
 	) (PullRequest, error)
+	// ListReviewRequestReviewers lists review requests updated since the given time with
+	// their pull request's requested reviewers
+	ListReviewRequestReviewers(
+		ctx context.Context,
+		userID string,
+		since time.Time,
+	) ([]ReviewRequestReviewers, error)
 
 	// Sync methods
 	GetSyncState(ctx context.Context, userID string) (GetSyncStateRow, error)
@@ -199,6 +543,11 @@ type Store interface {
 		userID string,
 		arg UpsertSyncStateParams,
 	) (UpsertSyncStateRow, error)
+	SaveInitialSyncProgress(
+		ctx context.Context,
+		userID string,
+		arg SaveInitialSyncProgressParams,
+	) error
 
 	// Notification upsert/update methods
 	UpsertNotification(
@@ -211,6 +560,9 @@ type Store interface {
 		userID string,
 		arg UpdateNotificationSubjectParams,
 	) error
+	// UpdateNotificationPriority stores a notification's priority score, clearing it when
+	// priority isn't valid
+	UpdateNotificationPriority(ctx context.Context, userID string, id int64, priority sql.NullInt64) error
 
 	// User methods (no userID param - these operate on the single user record)
 	GetUser(ctx context.Context) (User, error)
@@ -221,16 +573,45 @@ type Store interface {
 	ClearUserGitHubToken(ctx context.Context) (User, error)
 	UpdateUserRetentionSettings(ctx context.Context, retentionSettings sql.NullString) (User, error)
 	UpdateUserUpdateSettings(ctx context.Context, updateSettings NullRawMessage) (User, error)
+	UpdateUserEscalationSettings(
+		ctx context.Context,
+		escalationSettings NullRawMessage,
+	) (User, error)
+	UpdateUserWorkingHours(ctx context.Context, workingHours NullRawMessage) (User, error)
+	UpdateUserTeamSettings(ctx context.Context, teamSettings NullRawMessage) (User, error)
+	UpdateUserAlertSettings(ctx context.Context, alertSettings NullRawMessage) (User, error)
+	UpdateUserSnoozeSettings(ctx context.Context, snoozeSettings NullRawMessage) (User, error)
+	UpdateUserBackupSettings(ctx context.Context, backupSettings NullRawMessage) (User, error)
+	UpdateUserPrioritySettings(ctx context.Context, prioritySettings NullRawMessage) (User, error)
+	UpdateUserDigestSettings(ctx context.Context, digestSettings NullRawMessage) (User, error)
 	UpdateUserMutedUntil(ctx context.Context, mutedUntil sql.NullTime) (User, error)
 
 	// Storage management methods
 	GetStorageStats(ctx context.Context, userID string) (StorageStats, error)
 	CountEligibleForCleanup(ctx context.Context, userID string, params CleanupParams) (int64, error)
+	ListEligibleForCleanupIDs(
+		ctx context.Context,
+		userID string,
+		params CleanupParams,
+	) ([]int64, error)
+	ListCleanupCandidates(
+		ctx context.Context,
+		userID string,
+		params CleanupCandidatesParams,
+	) ([]CleanupCandidate, error)
+	CountCleanupCandidates(ctx context.Context, userID, cutoffDate string) (int64, error)
 	DeleteOldArchivedNotifications(
 		ctx context.Context,
 		userID string,
 		params CleanupParams,
 	) (int64, error)
+	DeleteApprovedCleanup(
+		ctx context.Context,
+		userID string,
+		params CleanupParams,
+		approve func(ids []int64) error,
+	) (int64, error)
 	DeleteOrphanedPullRequests(ctx context.Context, userID string) (int64, error)
 	DeleteAllGitHubData(ctx context.Context, userID string) error
+	DeleteGitHubData(ctx context.Context, userID string, params DeleteGitHubDataParams) error
 }
@@ -22,6 +22,8 @@ const (
 	SystemViewStarred    = "starred"
 	SystemViewArchive    = "archive"
 	SystemViewSnoozed    = "snoozed"
+	// SystemViewRecentlyTagged lists notifications tagged in the last week
+	SystemViewRecentlyTagged = "recently-tagged"
 )
 
 // SystemViewDefinition defines a virtual system view
@@ -73,6 +75,14 @@ var SystemViews = map[string]SystemViewDefinition{
 		Icon:        "snooze",
 		IsDefault:   false,
 	},
+	// Recently tagged view
+	SystemViewRecentlyTagged: {
+		Slug:        "recently-tagged",
+		Name:        "Recently tagged",
+		Description: "Notifications tagged in the last 7 days",
+		Icon:        "tag",
+		IsDefault:   false,
+	},
 }
 
 // IsSystemView checks if a slug is a system view
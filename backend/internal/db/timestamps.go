@@ -0,0 +1,49 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package db
+
+import "time"
+
+// TimestampLayout is the layout every timestamp is stored in: UTC RFC3339 with second
+// precision. It matches strftime('%Y-%m-%dT%H:%M:%SZ', ...) in SQL, so stored timestamps
+// can be compared and ordered as strings.
+const TimestampLayout = "2006-01-02T15:04:05Z"
+
+// legacyTimestampLayout is SQLite's datetime() format, found in rows written before
+// timestamps were normalized
+const legacyTimestampLayout = "2006-01-02 15:04:05"
+
+// FormatTimestamp formats t for storage, or returns "" for the zero time
+func FormatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Truncate(time.Second).Format(TimestampLayout)
+}
+
+// ParseTimestamp parses a stored timestamp, accepting RFC3339 with any offset or precision
+// and the legacy SQLite datetime format. The result is in UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		var legacyErr error
+		t, legacyErr = time.Parse(legacyTimestampLayout, s)
+		if legacyErr != nil {
+			return time.Time{}, err
+		}
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want string
+	}{
+		{"zero", time.Time{}, ""},
+		{"utc", time.Date(2025, 3, 30, 0, 30, 0, 0, time.UTC), "2025-03-30T00:30:00Z"},
+		{
+			"offset is converted to UTC",
+			time.Date(2025, 3, 30, 3, 30, 0, 0, berlin),
+			"2025-03-30T01:30:00Z",
+		},
+		{
+			"sub-second precision is dropped",
+			time.Date(2025, 1, 2, 3, 4, 5, 999_000_000, time.UTC),
+			"2025-01-02T03:04:05Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FormatTimestamp(tt.in))
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC)
+
+	for _, in := range []string{
+		"2025-10-26T01:30:00Z",
+		"2025-10-26T02:30:00+01:00",
+		"2025-10-25T21:30:00-04:00",
+		"2025-10-26T01:30:00.000Z",
+		"2025-10-26 01:30:00",
+	} {
+		t.Run(in, func(t *testing.T) {
+			got, err := ParseTimestamp(in)
+			require.NoError(t, err)
+			require.True(t, want.Equal(got))
+			require.Equal(t, time.UTC, got.Location())
+		})
+	}
+
+	_, err := ParseTimestamp("yesterday")
+	require.Error(t, err)
+}
+
+// Stored timestamps are compared as strings, so formatting must preserve time order,
+// including across DST transitions in the local zone the times were created in.
+func TestFormatTimestamp_PreservesOrder(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2025-11-02 01:00-02:00 happens twice in New York; step through it in 20 minute hops
+	start := time.Date(2025, 11, 2, 0, 0, 0, 0, newYork)
+	prev := FormatTimestamp(start)
+	for i := 1; i <= 12; i++ {
+		next := FormatTimestamp(start.Add(time.Duration(i) * 20 * time.Minute))
+		require.Less(t, prev, next)
+
+		parsed, err := ParseTimestamp(next)
+		require.NoError(t, err)
+		require.Equal(t, next, FormatTimestamp(parsed))
+		prev = next
+	}
+}
@@ -16,8 +16,11 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
+	"time"
 )
 
 // NullRawMessage represents a json.RawMessage that may be null.
@@ -90,3 +93,237 @@ type CleanupParams struct {
 	CutoffDate     string // ISO8601 format
 	BatchSize      int64
 }
+
+// EscalationParams contains parameters for escalating stale review requests
+type EscalationParams struct {
+	CutoffDate  string // ISO8601 format
+	EscalatedAt string // ISO8601 format
+	BatchSize   int64
+}
+
+// EscalatedNotification identifies a notification resurfaced by escalation
+type EscalatedNotification struct {
+	ID       int64
+	GithubID string
+}
+
+// AwaitingReplyParams contains parameters for classifying threads awaiting a reply
+type AwaitingReplyParams struct {
+	CutoffDate   string // ISO8601 format; own comments posted before this are unanswered
+	ClassifiedAt string // ISO8601 format
+	Resurface    bool   // Move newly classified, unmuted threads back to the inbox
+}
+
+// SnoozeReturnParams contains parameters for returning notifications whose snooze ran out
+type SnoozeReturnParams struct {
+	ReturnedAt string // ISO8601 format; snoozes ending at or before this have run out
+	MarkUnread bool   // Mark returned notifications unread
+}
+
+// CleanupCandidatesParams contains parameters for listing cleanup candidates
+type CleanupCandidatesParams struct {
+	CutoffDate string // ISO8601 format
+	Limit      int64
+	Offset     int64
+}
+
+// CleanupCandidate is an archived or muted notification older than the retention cutoff.
+// Starred and Tagged are reported so callers can decide whether the item is protected.
+type CleanupCandidate struct {
+	ID                int64
+	GithubID          string
+	SubjectTitle      string
+	SubjectType       string
+	RepoFullName      string
+	Archived          bool
+	Muted             bool
+	Starred           bool
+	Tagged            bool
+	EffectiveSortDate time.Time
+}
+
+// DisconnectMode selects which local data is retained when disconnecting GitHub
+type DisconnectMode string
+
+// Disconnect modes
+const (
+	// DisconnectModeFullWipe deletes all GitHub data
+	DisconnectModeFullWipe DisconnectMode = "full_wipe"
+	// DisconnectModeKeepTriage keeps notifications with their triage state (read, archived,
+	// starred, snoozed, tags) but purges raw GitHub payloads and pull request data
+	DisconnectModeKeepTriage DisconnectMode = "keep_triage"
+	// DisconnectModeKeepStarredTagged keeps only starred or tagged notifications
+	DisconnectModeKeepStarredTagged DisconnectMode = "keep_starred_tagged"
+)
+
+// Valid reports whether m is a known disconnect mode
+func (m DisconnectMode) Valid() bool {
+	switch m {
+	case DisconnectModeFullWipe, DisconnectModeKeepTriage, DisconnectModeKeepStarredTagged:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotificationGroupKey selects what notifications are grouped by in an aggregation
+type NotificationGroupKey string
+
+// Notification group keys
+const (
+	// NotificationGroupByRepo groups by repository full name
+	NotificationGroupByRepo NotificationGroupKey = "repo"
+	// NotificationGroupByOrg groups by the owner part of the repository name
+	NotificationGroupByOrg NotificationGroupKey = "org"
+	// NotificationGroupByReason groups by why GitHub sent the notification
+	NotificationGroupByReason NotificationGroupKey = "reason"
+	// NotificationGroupByAuthor groups by the author of the subject
+	NotificationGroupByAuthor NotificationGroupKey = "author"
+	// NotificationGroupByType groups by subject type
+	NotificationGroupByType NotificationGroupKey = "type"
+)
+
+// Valid reports whether k is a known notification group key
+func (k NotificationGroupKey) Valid() bool {
+	switch k {
+	case NotificationGroupByRepo, NotificationGroupByOrg, NotificationGroupByReason,
+		NotificationGroupByAuthor, NotificationGroupByType:
+		return true
+	default:
+		return false
+	}
+}
+
+// Content kinds classify a notification by what its subject mostly is
+const (
+	// ContentKindCode is a code change: pull requests and commits
+	ContentKindCode = "code"
+	// ContentKindDiscussion is a conversation thread: issues and discussions
+	ContentKindDiscussion = "discussion"
+	// ContentKindOther is everything else, such as releases, CI runs and security alerts
+	ContentKindOther = "other"
+)
+
+// ContentKindForSubjectType returns the content kind of a GitHub notification subject type
+func ContentKindForSubjectType(subjectType string) string {
+	switch strings.ToLower(subjectType) {
+	case "pullrequest", "commit":
+		return ContentKindCode
+	case "issue", "discussion":
+		return ContentKindDiscussion
+	default:
+		return ContentKindOther
+	}
+}
+
+// ValidContentKind reports whether kind is a known content kind
+func ValidContentKind(kind string) bool {
+	switch kind {
+	case ContentKindCode, ContentKindDiscussion, ContentKindOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// System notifications are created by Octobud itself for app events, such as sync
+// failing or a token expiring, so they can be triaged like GitHub notifications
+const (
+	// ReasonSystem is the reason of every system notification
+	ReasonSystem = "system"
+	// SubjectTypeSystem is the subject type of every system notification
+	SubjectTypeSystem = "System"
+	// SystemRepositoryFullName is the placeholder repository system notifications belong to
+	SystemRepositoryFullName = "octobud/system"
+	// SystemGithubIDPrefix prefixes system notification IDs so they never collide with
+	// GitHub's thread IDs
+	SystemGithubIDPrefix = "system:"
+)
+
+// DeleteGitHubDataProgress reports a completed step of DeleteGitHubData
+type DeleteGitHubDataProgress struct {
+	Step           string
+	StepsCompleted int
+	TotalSteps     int
+	RowsAffected   int64
+}
+
+// DeleteGitHubDataParams contains parameters for deleting GitHub data on disconnect.
+// OnProgress, if set, is called after each step.
+type DeleteGitHubDataParams struct {
+	Mode       DisconnectMode
+	OnProgress func(DeleteGitHubDataProgress)
+}
+
+// RepositoryDigest summarizes a repository's unread (not archived or muted) notifications
+type RepositoryDigest struct {
+	RepositoryID   int64
+	UnreadCount    int64
+	ReasonCounts   map[string]int64
+	OldestUnreadAt time.Time
+	RecentUnread   []RepositoryDigestItem
+}
+
+// RepositoryDigestItem is one of the most recently updated unread notifications in a digest
+type RepositoryDigestItem struct {
+	GithubID     string
+	SubjectTitle string
+	UpdatedAt    time.Time
+}
+
+// ReviewRequestReviewers is a review request notification with the reviewers currently
+// requested on its pull request
+type ReviewRequestReviewers struct {
+	GithubID  string
+	IsRead    bool
+	Open      bool
+	Reviewers []string
+}
+
+// DailyNotificationActivity counts the notifications synced, read and archived on one UTC
+// day. A notification synced twice in a day counts twice.
+type DailyNotificationActivity struct {
+	Day      time.Time
+	Received int64
+	Read     int64
+	Archived int64
+}
+
+// MeanTimeToRead is the average wait between a notification's latest update on GitHub and
+// the user first reading it
+type MeanTimeToRead struct {
+	Notifications int64
+	Mean          time.Duration
+}
+
+// NotificationVolume counts notifications in one repository with one reason
+type NotificationVolume struct {
+	Repository string
+	Reason     string
+	Total      int64
+	Unread     int64
+}
+
+// TriageState is a notification's triage state as exported to a backup. Tags are
+// identified by name so a backup can be restored into a database with different tag IDs.
+type TriageState struct {
+	GithubID     string
+	IsRead       bool
+	Archived     bool
+	Starred      bool
+	Muted        bool
+	SnoozedUntil sql.NullTime
+	TagNames     []string
+}
+
+// TriageRestore is triage state staged for merging into the notification with the same
+// GitHub ID once that notification has been synced
+type TriageRestore struct {
+	GithubID     string
+	IsRead       bool
+	Archived     bool
+	Starred      bool
+	Muted        bool
+	SnoozedUntil sql.NullTime
+	TagIDs       []string
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// MergePullRequest merges a pull request with one of the types.MergeMethod values.
+func (c *clientImpl) MergePullRequest(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	method string,
+) (*types.MergeResult, error) {
+	jsonBody, err := json.Marshal(map[string]string{"merge_method": method})
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal merge: %w", err)
+	}
+
+	respBody, err := c.doRepoRequest(
+		ctx,
+		http.MethodPut,
+		owner, repo,
+		fmt.Sprintf("pulls/%d/merge", number),
+		bytes.NewBuffer(jsonBody),
+		"merge",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.MergeResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("github: unmarshal merge: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateIssueState closes or reopens an issue or pull request. state is "closed" or
+// "open".
+func (c *clientImpl) UpdateIssueState(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	state string,
+) error {
+	jsonBody, err := json.Marshal(map[string]string{"state": state})
+	if err != nil {
+		return fmt.Errorf("github: marshal issue state: %w", err)
+	}
+
+	_, err = c.doRepoRequest(
+		ctx,
+		http.MethodPatch,
+		owner, repo,
+		fmt.Sprintf("issues/%d", number),
+		bytes.NewBuffer(jsonBody),
+		"issue state",
+	)
+	return err
+}
+
+// doRepoRequest sends a JSON request to a path under /repos/{owner}/{repo}/ and returns
+// the response body when GitHub answers with 200. what names the operation in errors.
+func (c *clientImpl) doRepoRequest(
+	ctx context.Context,
+	method, owner, repo, path string,
+	body io.Reader,
+	what string,
+) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/%s",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), path)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("github: create %s request: %w", what, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: %s: %w", what, err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read %s body: %w", what, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: %s status %d: %s", what, resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestMergePullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/repos/octo/cli/pulls/42/merge", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"merge_method": "squash"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sha": "abc123", "merged": true,
+			"message": "Pull Request successfully merged"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	result, err := c.MergePullRequest(context.Background(), "octo", "cli", 42, types.MergeMethodSquash)
+	require.NoError(t, err)
+	require.True(t, result.Merged)
+	require.Equal(t, "abc123", result.SHA)
+}
+
+func TestMergePullRequest_NotMergeable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte(`{"message": "Pull Request is not mergeable"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.MergePullRequest(context.Background(), "octo", "cli", 42, types.MergeMethodMerge)
+	require.ErrorContains(t, err, "merge status 405")
+}
+
+func TestUpdateIssueState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/repos/octo/cli/issues/7", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"state": "closed"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"number": 7, "state": "closed"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	require.NoError(t, c.UpdateIssueState(context.Background(), "octo", "cli", 7, "closed"))
+}
+
+func TestUpdateIssueState_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	err := c.UpdateIssueState(context.Background(), "octo", "cli", 7, "closed")
+	require.ErrorContains(t, err, "issue state status 403")
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// checkRunsPerPage is how many check runs FetchCheckRuns reads for a commit. Only the first
+// page is read, which covers all but the largest build matrices.
+const checkRunsPerPage = 100
+
+// FetchCheckRuns retrieves the check runs reported for a commit.
+func (c *clientImpl) FetchCheckRuns(
+	ctx context.Context,
+	owner, repo, ref string,
+) ([]types.CheckRun, error) {
+	respBody, err := c.doRepoRequest(
+		ctx,
+		http.MethodGet,
+		owner, repo,
+		fmt.Sprintf("commits/%s/check-runs?per_page=%d", url.PathEscape(ref), checkRunsPerPage),
+		http.NoBody,
+		"check runs",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var list types.CheckRunList
+	if err := json.Unmarshal(respBody, &list); err != nil {
+		return nil, fmt.Errorf("github: unmarshal check runs: %w", err)
+	}
+	return list.CheckRuns, nil
+}
+
+// FetchCombinedStatus retrieves the combined state of the commit statuses reported for a
+// commit.
+func (c *clientImpl) FetchCombinedStatus(
+	ctx context.Context,
+	owner, repo, ref string,
+) (types.CombinedStatus, error) {
+	respBody, err := c.doRepoRequest(
+		ctx,
+		http.MethodGet,
+		owner, repo,
+		fmt.Sprintf("commits/%s/status", url.PathEscape(ref)),
+		http.NoBody,
+		"combined status",
+	)
+	if err != nil {
+		return types.CombinedStatus{}, err
+	}
+
+	var status types.CombinedStatus
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return types.CombinedStatus{}, fmt.Errorf("github: unmarshal combined status: %w", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestFetchCheckRuns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/repos/octo/cli/commits/abc123/check-runs", r.URL.Path)
+		require.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+		_, _ = w.Write([]byte(`{"total_count": 2, "check_runs": [
+			{"id": 1, "name": "build", "status": "completed", "conclusion": "success"},
+			{"id": 2, "name": "test", "status": "in_progress", "conclusion": null}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	runs, err := c.FetchCheckRuns(context.Background(), "octo", "cli", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []types.CheckRun{
+		{ID: 1, Name: "build", Status: "completed", Conclusion: "success"},
+		{ID: 2, Name: "test", Status: "in_progress"},
+	}, runs)
+}
+
+func TestFetchCombinedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/octo/cli/commits/abc123/status", r.URL.Path)
+		_, _ = w.Write([]byte(`{"state": "failure", "total_count": 1,
+			"statuses": [{"context": "ci/jenkins", "state": "failure"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	status, err := c.FetchCombinedStatus(context.Background(), "octo", "cli", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, types.CombinedStatus{State: "failure", TotalCount: 1}, status)
+}
+
+func TestFetchCombinedStatus_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "No commit found for SHA: abc123"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.FetchCombinedStatus(context.Background(), "octo", "cli", "abc123")
+	require.ErrorContains(t, err, "combined status status 404")
+}
@@ -20,10 +20,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
@@ -37,6 +40,18 @@ const (
 	githubGraphQLBase = "https://api.github.com/graphql"
 )
 
+// clockSkewTolerance is how far GitHub's clock can be from the local clock before the
+// difference is reported. The Date header only has second precision.
+const clockSkewTolerance = 2 * time.Second
+
+// tokenExpirationHeader is set by GitHub on responses to requests made with a token that
+// expires, such as a fine-grained personal access token.
+const tokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+
+// tokenScopesHeader lists the scopes of a classic personal access token or OAuth token.
+// GitHub leaves it out for fine-grained tokens, whose permissions aren't scopes.
+const tokenScopesHeader = "X-OAuth-Scopes"
+
 // retryPageSizes defines the page sizes to try when encountering 502/504 errors.
 // These are progressively smaller to help with timeout issues.
 var retryPageSizes = []int{25, 15, minPerPage}
@@ -47,6 +62,10 @@ type clientImpl struct {
 	baseURL    string
 	perPage    int
 	token      string
+	clockSkew  atomic.Int64                    // nanoseconds GitHub's clock is ahead of ours
+	tokenExp   atomic.Int64                    // Unix seconds the token expires at, or 0 if it doesn't
+	scopes     atomic.Pointer[[]string]        // nil until a response reports the token's scopes
+	rateLimit  atomic.Pointer[types.RateLimit] // nil until a response reports the core rate limit
 }
 
 // NewClient constructs an HTTP-backed GitHub client.
@@ -60,6 +79,19 @@ func NewClient() githubinterfaces.Client {
 	}
 }
 
+// NewClientWithTransport constructs a GitHub client that sends requests through
+// transport, such as a replay.Recorder or replay.Replayer.
+func NewClientWithTransport(transport http.RoundTripper) githubinterfaces.Client {
+	return &clientImpl{
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: transport,
+		},
+		baseURL: githubAPIBase,
+		perPage: defaultPerPage,
+	}
+}
+
 // SetToken sets the GitHub token directly and validates it.
 func (c *clientImpl) SetToken(ctx context.Context, token string) error {
 	c.token = strings.TrimSpace(token)
@@ -90,6 +122,10 @@ func (c *clientImpl) validateToken(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	c.recordClockSkew(resp)
+	c.recordTokenExpiration(resp)
+	c.recordTokenScopes(resp)
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			// Error closing response body - log if we had a logger, but can't return it
@@ -134,19 +170,133 @@ func (c *clientImpl) GetAuthHeaders() map[string]string {
 	}
 }
 
+// ClockSkew returns how far GitHub's clock is ahead of the local clock, as of the latest
+// response. Differences within clockSkewTolerance are reported as zero.
+func (c *clientImpl) ClockSkew() time.Duration {
+	return time.Duration(c.clockSkew.Load())
+}
+
+// recordClockSkew compares the response's Date header with the local clock
+func (c *clientImpl) recordClockSkew(resp *http.Response) {
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	skew := serverTime.Sub(time.Now())
+	if skew.Abs() < clockSkewTolerance {
+		skew = 0
+	}
+	c.clockSkew.Store(int64(skew))
+}
+
+// TokenExpiration returns when the token expires, as reported by GitHub on the latest
+// response. It's zero for tokens that don't expire or before any response.
+func (c *clientImpl) TokenExpiration() time.Time {
+	seconds := c.tokenExp.Load()
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// recordTokenExpiration reads the token expiration header, e.g. "2025-04-25 20:11:04 UTC"
+func (c *clientImpl) recordTokenExpiration(resp *http.Response) {
+	value := resp.Header.Get(tokenExpirationHeader)
+	if value == "" {
+		c.tokenExp.Store(0)
+		return
+	}
+	expiresAt, err := time.Parse("2006-01-02 15:04:05 MST", value)
+	if err != nil {
+		return
+	}
+	c.tokenExp.Store(expiresAt.Unix())
+}
+
+// TokenScopes returns the token's OAuth scopes, as reported by GitHub on the latest
+// response. ok is false when they're unknown, such as for fine-grained tokens.
+func (c *clientImpl) TokenScopes() (scopes []string, ok bool) {
+	stored := c.scopes.Load()
+	if stored == nil {
+		return nil, false
+	}
+	return *stored, true
+}
+
+// recordTokenScopes reads the scopes header, e.g. "notifications, repo"
+func (c *clientImpl) recordTokenScopes(resp *http.Response) {
+	values := resp.Header.Values(tokenScopesHeader)
+	if len(values) == 0 {
+		c.scopes.Store(nil)
+		return
+	}
+	scopes := []string{}
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	c.scopes.Store(&scopes)
+}
+
+// RateLimit returns the core REST API rate limit, as reported by GitHub on the latest
+// response. ok is false until a response has reported it.
+func (c *clientImpl) RateLimit() (limit types.RateLimit, ok bool) {
+	stored := c.rateLimit.Load()
+	if stored == nil {
+		return types.RateLimit{}, false
+	}
+	return *stored, true
+}
+
+// recordRateLimit reads the rate limit headers. GraphQL and search have budgets of their
+// own, reported under another resource, which aren't what syncing spends.
+func (c *clientImpl) recordRateLimit(resp *http.Response) {
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	used, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Used"))
+	c.rateLimit.Store(&types.RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0).UTC(),
+	})
+}
+
 // isGatewayError checks if a status code is a gateway error (502 or 504).
 func isGatewayError(statusCode int) bool {
 	return statusCode == http.StatusBadGateway || statusCode == http.StatusGatewayTimeout
 }
 
-// fetchNotificationPage attempts to fetch a single page of notifications.
+// errNotModified is returned by fetchNotificationPage when GitHub answers 304 Not Modified
+// to a request sent with If-None-Match.
+var errNotModified = errors.New("github: notifications not modified")
+
+// fetchNotificationPage attempts to fetch a single page of notifications, along with the
+// page's ETag. A non-empty etag is sent as If-None-Match.
 // If a gateway error (502/504) occurs and retryPageSize > 0, it retries with the smaller page size.
 func (c *clientImpl) fetchNotificationPage(
 	ctx context.Context,
 	page, perPage int,
 	fetchAll bool,
 	since, before *time.Time,
-) ([]types.NotificationThread, error) {
+	etag string,
+) ([]types.NotificationThread, string, error) {
 	// Build URL with query parameters
 	url := fmt.Sprintf(
 		"%s/notifications?all=%t&per_page=%d&page=%d",
@@ -164,17 +314,24 @@ func (c *clientImpl) fetchNotificationPage(
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 	if err != nil {
-		return nil, fmt.Errorf("github: create request: %w", err)
+		return nil, "", fmt.Errorf("github: create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("github: fetch notifications page %d: %w", page, err)
+		return nil, "", fmt.Errorf("github: fetch notifications page %d: %w", page, err)
 	}
+	c.recordClockSkew(resp)
+	c.recordTokenExpiration(resp)
+	c.recordTokenScopes(resp)
+	c.recordRateLimit(resp)
 
 	payload, err := io.ReadAll(resp.Body)
 	if closeErr := resp.Body.Close(); closeErr != nil {
@@ -183,19 +340,25 @@ func (c *clientImpl) fetchNotificationPage(
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("github: read response body page %d: %w", page, err)
+		return nil, "", fmt.Errorf("github: read response body page %d: %w", page, err)
 	}
 
+	// Answering 304 doesn't count against the rate limit
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, errNotModified
+	}
+	pageETag := resp.Header.Get("ETag")
+
 	if resp.StatusCode != http.StatusOK {
 		// Check if this is a gateway error that we can retry with a smaller page size
 		if isGatewayError(resp.StatusCode) {
-			return nil, fmt.Errorf(
+			return nil, "", fmt.Errorf(
 				"github: API returned status %d (gateway error): %s",
 				resp.StatusCode,
 				string(payload),
 			)
 		}
-		return nil, fmt.Errorf(
+		return nil, "", fmt.Errorf(
 			"github: API returned status %d: %s",
 			resp.StatusCode,
 			string(payload),
@@ -203,24 +366,24 @@ func (c *clientImpl) fetchNotificationPage(
 	}
 
 	if len(bytes.TrimSpace(payload)) == 0 {
-		return []types.NotificationThread{}, nil
+		return []types.NotificationThread{}, pageETag, nil
 	}
 
 	var pageItems []types.NotificationThread
 	if err := json.Unmarshal(payload, &pageItems); err != nil {
-		return nil, fmt.Errorf("github: decode notifications page %d: %w", page, err)
+		return nil, "", fmt.Errorf("github: decode notifications page %d: %w", page, err)
 	}
 
 	// Add raw JSON to each notification
 	for i := range pageItems {
 		raw, err := json.Marshal(pageItems[i])
 		if err != nil {
-			return nil, fmt.Errorf("github: encode raw notification payload: %w", err)
+			return nil, "", fmt.Errorf("github: encode raw notification payload: %w", err)
 		}
 		pageItems[i].Raw = raw
 	}
 
-	return pageItems, nil
+	return pageItems, pageETag, nil
 }
 
 // FetchNotifications retrieves notification threads updated since the given instant.
@@ -235,14 +398,43 @@ func (c *clientImpl) FetchNotifications(
 	before *time.Time,
 	unreadOnly bool,
 ) ([]types.NotificationThread, error) {
+	result, err := c.fetchNotifications(ctx, since, before, unreadOnly, "")
+	if err != nil {
+		return nil, err
+	}
+	return result.Threads, nil
+}
+
+// FetchNotificationsIfChanged is FetchNotifications without an upper bound that sends etag,
+// from an earlier result, as If-None-Match on the first page. When GitHub answers 304 Not
+// Modified nothing changed since then, the result is marked NotModified, and no rate limit
+// is spent. An empty etag fetches unconditionally.
+func (c *clientImpl) FetchNotificationsIfChanged(
+	ctx context.Context,
+	since *time.Time,
+	unreadOnly bool,
+	etag string,
+) (types.NotificationsResult, error) {
+	return c.fetchNotifications(ctx, since, nil, unreadOnly, etag)
+}
+
+// fetchNotifications pages through notifications. etag, when set, is sent with the first
+// page only, since that's the page whose ETag results carry.
+func (c *clientImpl) fetchNotifications(
+	ctx context.Context,
+	since *time.Time,
+	before *time.Time,
+	unreadOnly bool,
+	etag string,
+) (types.NotificationsResult, error) {
 	perPage := c.perPage
 	if perPage <= 0 {
 		perPage = defaultPerPage
 	}
 
 	var (
-		allNotifications []types.NotificationThread
-		page             = 1
+		result types.NotificationsResult
+		page   = 1
 	)
 
 	// all=true fetches all notifications (read and unread)
@@ -256,12 +448,30 @@ func (c *clientImpl) FetchNotifications(
 
 		// Try fetching the page with current perPage size
 		currentPerPage := perPage
-		pageItems, err = c.fetchNotificationPage(ctx, page, currentPerPage, fetchAll, since, before)
+		pageETag := ""
+		if page == 1 {
+			pageETag = etag
+		}
+		pageItems, pageETag, err = c.fetchNotificationPage(
+			ctx,
+			page,
+			currentPerPage,
+			fetchAll,
+			since,
+			before,
+			pageETag,
+		)
+		if errors.Is(err, errNotModified) {
+			return types.NotificationsResult{ETag: etag, NotModified: true}, nil
+		}
+		if page == 1 && err == nil {
+			result.ETag = pageETag
+		}
 
 		// If we got a gateway error (502/504), try with progressively smaller page sizes
 		if err != nil && strings.Contains(err.Error(), "gateway error") {
 			// Calculate how many items we've already fetched
-			itemsFetched := len(allNotifications)
+			itemsFetched := len(result.Threads)
 
 			// Try all retry sizes in order until one works
 			for _, retrySize := range retryPageSizes {
@@ -289,13 +499,14 @@ func (c *clientImpl) FetchNotifications(
 				}
 
 				// Try with this retry size and recalculated page
-				pageItems, err = c.fetchNotificationPage(
+				pageItems, _, err = c.fetchNotificationPage(
 					ctx,
 					retryPage,
 					retrySize,
 					fetchAll,
 					since,
 					before,
+					"",
 				)
 				if err == nil {
 					// Success - use this size and update page for subsequent fetches
@@ -313,14 +524,14 @@ func (c *clientImpl) FetchNotifications(
 
 		// If we still have an error after all retries, return it
 		if err != nil {
-			return nil, err
+			return types.NotificationsResult{}, err
 		}
 
 		if len(pageItems) == 0 {
 			break
 		}
 
-		allNotifications = append(allNotifications, pageItems...)
+		result.Threads = append(result.Threads, pageItems...)
 
 		// If we got fewer items than requested, we're done
 		if len(pageItems) < perPage {
@@ -330,7 +541,39 @@ func (c *clientImpl) FetchNotifications(
 		page++
 	}
 
-	return allNotifications, nil
+	return result, nil
+}
+
+// FetchNotificationsPage retrieves the newest page of notifications updated after since
+// and before before, either of which may be nil. more reports whether older notifications
+// may follow, to fetch by moving before back to the oldest returned. A gateway error is
+// retried with smaller pages.
+func (c *clientImpl) FetchNotificationsPage(
+	ctx context.Context,
+	since, before *time.Time,
+	unreadOnly bool,
+) ([]types.NotificationThread, bool, error) {
+	perPage := c.perPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	fetchAll := !unreadOnly
+
+	threads, _, err := c.fetchNotificationPage(ctx, 1, perPage, fetchAll, since, before, "")
+	for _, retrySize := range retryPageSizes {
+		if err == nil || !strings.Contains(err.Error(), "gateway error") {
+			break
+		}
+		if retrySize >= perPage {
+			continue
+		}
+		perPage = retrySize
+		threads, _, err = c.fetchNotificationPage(ctx, 1, perPage, fetchAll, since, before, "")
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return threads, len(threads) >= perPage, nil
 }
 
 // FetchSubjectRaw retrieves the raw JSON payload for a notification subject.
@@ -355,6 +598,7 @@ func (c *clientImpl) FetchSubjectRaw(
 	if err != nil {
 		return nil, fmt.Errorf("github: fetch subject: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			// Error closing response body - log if we had a logger, but can't return it
@@ -401,6 +645,7 @@ func (c *clientImpl) FetchIssueComments(
 	if err != nil {
 		return nil, fmt.Errorf("github: fetch comments: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			// Error closing response body - log if we had a logger, but can't return it
@@ -447,6 +692,7 @@ func (c *clientImpl) FetchPullRequestReviews(
 	if err != nil {
 		return nil, fmt.Errorf("github: fetch reviews: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			// Error closing response body - log if we had a logger, but can't return it
@@ -493,6 +739,7 @@ func (c *clientImpl) FetchTimeline(
 	if err != nil {
 		return nil, fmt.Errorf("github: fetch timeline: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			// Error closing response body - log if we had a logger, but can't return it
@@ -645,6 +892,7 @@ func (c *clientImpl) FetchDiscussionComments( //nolint:gocritic // Prefer in-lin
 	if err != nil {
 		return nil, false, "", fmt.Errorf("github: execute graphql request: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
 			_ = closeErr
@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
 )
 
 const testToken = "test_token"
@@ -691,6 +694,59 @@ func TestFetchNotifications_Pagination(t *testing.T) {
 	require.Equal(t, 2, pageNum) // Should have made 2 requests
 }
 
+func TestFetchNotificationsPage(t *testing.T) {
+	before := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`[{"id": "1", "reason": "mention"}, {"id": "2", "reason": "assign"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	client.perPage = 2
+
+	threads, more, err := client.FetchNotificationsPage(context.Background(), nil, &before, true)
+
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	require.True(t, more, "a full page means older notifications may follow")
+	require.Len(t, queries, 1, "only the first page is fetched")
+	require.Equal(t, "2024-01-10T12:00:00Z", queries[0].Get("before"))
+	require.Equal(t, "false", queries[0].Get("all"))
+	require.Equal(t, "1", queries[0].Get("page"))
+}
+
+func TestFetchNotificationsPage_GatewayErrorRetry(t *testing.T) {
+	var perPageAttempts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage := r.URL.Query().Get("per_page")
+		perPageAttempts = append(perPageAttempts, perPage)
+		if perPage == "50" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`[{"id": "1", "reason": "mention"}]`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	client.perPage = 50
+
+	threads, more, err := client.FetchNotificationsPage(context.Background(), nil, nil, false)
+
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	require.False(t, more, "a short page is the last one")
+	require.Equal(t, []string{"50", "25"}, perPageAttempts)
+}
+
 func TestFetchNotifications_RawFieldPopulated(t *testing.T) {
 	serverResponse := `[{"id": "123", "reason": "mention", "updated_at": "2024-01-15T10:00:00Z"}]`
 
@@ -717,6 +773,217 @@ func TestFetchNotifications_RawFieldPopulated(t *testing.T) {
 	require.Equal(t, "123", rawData["id"])
 }
 
+func TestFetchNotifications_RecordsClockSkew(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   time.Duration
+		wantSkew time.Duration
+	}{
+		{"server ahead", time.Hour, time.Hour},
+		{"server behind", -10 * time.Minute, -10 * time.Minute},
+		{"within tolerance", time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					date := time.Now().Add(tt.offset).UTC().Format(http.TimeFormat)
+					w.Header().Set("Date", date)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`[]`))
+				}),
+			)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.token = testToken
+			require.Zero(t, client.ClockSkew())
+
+			_, err := client.FetchNotifications(context.Background(), nil, nil, false)
+			require.NoError(t, err)
+			if tt.wantSkew == 0 {
+				require.Zero(t, client.ClockSkew())
+				return
+			}
+			require.InDelta(t, tt.wantSkew, client.ClockSkew(), float64(clockSkewTolerance))
+		})
+	}
+}
+
+func TestFetchNotifications_RecordsTokenExpiration(t *testing.T) {
+	expiration := "2025-04-25 20:11:04 UTC"
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("GitHub-Authentication-Token-Expiration", expiration)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[]`))
+		}),
+	)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	require.True(t, client.TokenExpiration().IsZero())
+
+	_, err := client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 4, 25, 20, 11, 4, 0, time.UTC), client.TokenExpiration())
+
+	// Tokens that don't expire don't send the header
+	expiration = ""
+	_, err = client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	require.True(t, client.TokenExpiration().IsZero())
+}
+
+func TestFetchNotifications_RecordsTokenScopes(t *testing.T) {
+	scopes := "notifications, repo"
+	sendScopes := true
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if sendScopes {
+				w.Header().Set("X-OAuth-Scopes", scopes)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[]`))
+		}),
+	)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	_, ok := client.TokenScopes()
+	require.False(t, ok)
+
+	_, err := client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	got, ok := client.TokenScopes()
+	require.True(t, ok)
+	require.Equal(t, []string{"notifications", "repo"}, got)
+
+	// A classic token without scopes still sends the header, empty
+	scopes = ""
+	_, err = client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	got, ok = client.TokenScopes()
+	require.True(t, ok)
+	require.Empty(t, got)
+
+	// Fine-grained tokens don't send it at all
+	sendScopes = false
+	_, err = client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	_, ok = client.TokenScopes()
+	require.False(t, ok)
+}
+
+func TestClient_RecordsRateLimit(t *testing.T) {
+	resource := "core"
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "4321")
+			w.Header().Set("X-RateLimit-Used", "679")
+			w.Header().Set("X-RateLimit-Reset", "1735732800")
+			w.Header().Set("X-RateLimit-Resource", resource)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[]`))
+		}),
+	)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	_, ok := client.RateLimit()
+	require.False(t, ok)
+
+	_, err := client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	limit, ok := client.RateLimit()
+	require.True(t, ok)
+	require.Equal(t, types.RateLimit{
+		Limit:     5000,
+		Remaining: 4321,
+		Used:      679,
+		Reset:     time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, limit)
+
+	// Other budgets, such as GraphQL's, don't replace the core one
+	resource = "graphql"
+	_, err = client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	limit, ok = client.RateLimit()
+	require.True(t, ok)
+	require.Equal(t, 4321, limit.Remaining)
+}
+
+func TestFetchNotificationsIfChanged(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"id": "1", "reason": "mention"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+
+	// Without an ETag the page is fetched and its ETag returned
+	result, err := client.FetchNotificationsIfChanged(context.Background(), nil, false, "")
+	require.NoError(t, err)
+	require.False(t, result.NotModified)
+	require.Len(t, result.Threads, 1)
+	require.Equal(t, `W/"v1"`, result.ETag)
+
+	// With a matching ETag GitHub answers 304 and nothing is processed
+	result, err = client.FetchNotificationsIfChanged(context.Background(), nil, false, `W/"v1"`)
+	require.NoError(t, err)
+	require.True(t, result.NotModified)
+	require.Empty(t, result.Threads)
+	require.Equal(t, `W/"v1"`, result.ETag)
+
+	// A stale ETag gets the full response
+	result, err = client.FetchNotificationsIfChanged(context.Background(), nil, false, `W/"v0"`)
+	require.NoError(t, err)
+	require.False(t, result.NotModified)
+	require.Len(t, result.Threads, 1)
+
+	require.Equal(t, []string{"", `W/"v1"`, `W/"v0"`}, ifNoneMatch)
+}
+
+func TestFetchNotificationsIfChanged_OnlyFirstPageConditional(t *testing.T) {
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `W/"page-`+r.URL.Query().Get("page")+`"`)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("page") == "1" {
+			_, _ = w.Write([]byte(`[{"id": "1"}, {"id": "2"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id": "3"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	client.perPage = 2
+
+	result, err := client.FetchNotificationsIfChanged(context.Background(), nil, false, `W/"old"`)
+	require.NoError(t, err)
+	require.Len(t, result.Threads, 3)
+	// The first page decides whether anything changed, so its ETag is the one kept
+	require.Equal(t, `W/"page-1"`, result.ETag)
+	require.Equal(t, []string{`W/"old"`, ""}, ifNoneMatch)
+}
+
 func TestFetchNotifications_UnreadOnlyParameter(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -28,6 +28,19 @@ import (
 // Client defines the interface for GitHub API operations.
 type Client interface {
 	SetToken(ctx context.Context, token string) error
+	// ClockSkew returns how far GitHub's clock is ahead of the local clock (negative when
+	// behind), measured from the Date header of the latest response. Zero until known.
+	ClockSkew() time.Duration
+	// TokenExpiration returns when the token expires, as reported by GitHub on the latest
+	// response. Zero for tokens that don't expire or until known.
+	TokenExpiration() time.Time
+	// TokenScopes returns the token's OAuth scopes, as reported by GitHub on the latest
+	// response. ok is false for tokens without scopes, such as fine-grained ones, or
+	// until known.
+	TokenScopes() (scopes []string, ok bool)
+	// RateLimit returns the core REST API rate limit, as reported by GitHub on the latest
+	// response. ok is false until known.
+	RateLimit() (limit types.RateLimit, ok bool)
 	// FetchNotifications retrieves notification threads from GitHub.
 	// - since: only fetch notifications updated after this time (nil = use GitHub default window)
 	// - before: only fetch notifications updated before this time (nil = no upper bound)
@@ -39,6 +52,22 @@ type Client interface {
 		before *time.Time,
 		unreadOnly bool,
 	) ([]types.NotificationThread, error)
+	// FetchNotificationsIfChanged is FetchNotifications without an upper bound that sends
+	// etag from an earlier result as If-None-Match. When GitHub answers 304 Not Modified the
+	// result is marked NotModified, and no rate limit is spent.
+	FetchNotificationsIfChanged(
+		ctx context.Context,
+		since *time.Time,
+		unreadOnly bool,
+		etag string,
+	) (types.NotificationsResult, error)
+	// FetchNotificationsPage fetches the newest page of notifications between since and
+	// before, and whether older ones may follow.
+	FetchNotificationsPage(
+		ctx context.Context,
+		since, before *time.Time,
+		unreadOnly bool,
+	) ([]types.NotificationThread, bool, error)
 	FetchSubjectRaw(ctx context.Context, subjectURL string) (json.RawMessage, error)
 	FetchTimeline(
 		ctx context.Context,
@@ -55,6 +84,11 @@ type Client interface {
 		owner, repo string,
 		number, perPage, page int,
 	) ([]types.PullRequestReview, error)
+	// FetchCheckRuns retrieves the check runs reported for a commit.
+	FetchCheckRuns(ctx context.Context, owner, repo, ref string) ([]types.CheckRun, error)
+	// FetchCombinedStatus retrieves the combined state of the commit statuses reported for
+	// a commit.
+	FetchCombinedStatus(ctx context.Context, owner, repo, ref string) (types.CombinedStatus, error)
 	// FetchDiscussionComments retrieves comments for a discussion using GraphQL API.
 	// Returns comments as TimelineEvents, hasNextPage, endCursor, and error.
 	// This method converts discussion comments to TimelineEvent format for consistency.
@@ -64,4 +98,57 @@ type Client interface {
 		number, first int,
 		after string,
 	) ([]types.TimelineEvent, bool, string, error)
+	// FetchUserProfiles retrieves public profiles for the given logins in one batch.
+	// Logins that don't resolve to an account are left out of the result.
+	FetchUserProfiles(ctx context.Context, logins []string) ([]types.UserProfile, error)
+	// FetchRepositorySubscriptions retrieves the authenticated user's watch setting for
+	// each repository, by full name, in one batch. Repositories that no longer exist or
+	// can't be seen are left out of the result.
+	FetchRepositorySubscriptions(
+		ctx context.Context,
+		fullNames []string,
+	) ([]types.RepositorySubscription, error)
+	// FetchSubjectProjects retrieves the titles of the projects an issue or pull request,
+	// identified by its GraphQL node ID, belongs to.
+	FetchSubjectProjects(ctx context.Context, nodeID string) ([]string, error)
+	// FetchSavedReplies retrieves all of the authenticated user's saved replies.
+	FetchSavedReplies(ctx context.Context) ([]types.SavedReply, error)
+	// CreateIssueComment posts a comment on an issue or pull request.
+	CreateIssueComment(
+		ctx context.Context,
+		owner, repo string,
+		number int,
+		body string,
+	) (*types.IssueComment, error)
+	// SubmitPullRequestReview submits a review on a pull request with one of the
+	// types.ReviewEvent values.
+	SubmitPullRequestReview(
+		ctx context.Context,
+		owner, repo string,
+		number int,
+		event, body string,
+	) (*types.PullRequestReview, error)
+	// MergePullRequest merges a pull request with one of the types.MergeMethod values.
+	MergePullRequest(
+		ctx context.Context,
+		owner, repo string,
+		number int,
+		method string,
+	) (*types.MergeResult, error)
+	// UpdateIssueState closes or reopens an issue or pull request. state is "closed" or
+	// "open".
+	UpdateIssueState(ctx context.Context, owner, repo string, number int, state string) error
+	// MarkThreadRead marks a notification thread as read on GitHub.
+	MarkThreadRead(ctx context.Context, threadID string) error
+	// MarkThreadDone marks a notification thread as done on GitHub, which also marks it read.
+	MarkThreadDone(ctx context.Context, threadID string) error
+	// SetThreadSubscription subscribes to a notification thread on GitHub, or with ignored
+	// set, stops all of its notifications at the source.
+	SetThreadSubscription(
+		ctx context.Context,
+		threadID string,
+		ignored bool,
+	) (*types.ThreadSubscription, error)
+	// DeleteThreadSubscription removes the subscription to a notification thread on GitHub.
+	DeleteThreadSubscription(ctx context.Context, threadID string) error
 }
@@ -43,6 +43,79 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// ClockSkew mocks base method.
+func (m *MockClient) ClockSkew() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClockSkew")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ClockSkew indicates an expected call of ClockSkew.
+func (mr *MockClientMockRecorder) ClockSkew() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClockSkew", reflect.TypeOf((*MockClient)(nil).ClockSkew))
+}
+
+// CreateIssueComment mocks base method.
+func (m *MockClient) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*types.IssueComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssueComment", ctx, owner, repo, number, body)
+	ret0, _ := ret[0].(*types.IssueComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssueComment indicates an expected call of CreateIssueComment.
+func (mr *MockClientMockRecorder) CreateIssueComment(ctx, owner, repo, number, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueComment", reflect.TypeOf((*MockClient)(nil).CreateIssueComment), ctx, owner, repo, number, body)
+}
+
+// DeleteThreadSubscription mocks base method.
+func (m *MockClient) DeleteThreadSubscription(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteThreadSubscription", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteThreadSubscription indicates an expected call of DeleteThreadSubscription.
+func (mr *MockClientMockRecorder) DeleteThreadSubscription(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteThreadSubscription", reflect.TypeOf((*MockClient)(nil).DeleteThreadSubscription), ctx, threadID)
+}
+
+// FetchCheckRuns mocks base method.
+func (m *MockClient) FetchCheckRuns(ctx context.Context, owner, repo, ref string) ([]types.CheckRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCheckRuns", ctx, owner, repo, ref)
+	ret0, _ := ret[0].([]types.CheckRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCheckRuns indicates an expected call of FetchCheckRuns.
+func (mr *MockClientMockRecorder) FetchCheckRuns(ctx, owner, repo, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCheckRuns", reflect.TypeOf((*MockClient)(nil).FetchCheckRuns), ctx, owner, repo, ref)
+}
+
+// FetchCombinedStatus mocks base method.
+func (m *MockClient) FetchCombinedStatus(ctx context.Context, owner, repo, ref string) (types.CombinedStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCombinedStatus", ctx, owner, repo, ref)
+	ret0, _ := ret[0].(types.CombinedStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCombinedStatus indicates an expected call of FetchCombinedStatus.
+func (mr *MockClientMockRecorder) FetchCombinedStatus(ctx, owner, repo, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCombinedStatus", reflect.TypeOf((*MockClient)(nil).FetchCombinedStatus), ctx, owner, repo, ref)
+}
+
 // FetchDiscussionComments mocks base method.
 func (m *MockClient) FetchDiscussionComments(ctx context.Context, owner, repo string, number, first int, after string) ([]types.TimelineEvent, bool, string, error) {
 	m.ctrl.T.Helper()
@@ -90,6 +163,37 @@ func (mr *MockClientMockRecorder) FetchNotifications(ctx, since, before, unreadO
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchNotifications", reflect.TypeOf((*MockClient)(nil).FetchNotifications), ctx, since, before, unreadOnly)
 }
 
+// FetchNotificationsIfChanged mocks base method.
+func (m *MockClient) FetchNotificationsIfChanged(ctx context.Context, since *time.Time, unreadOnly bool, etag string) (types.NotificationsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchNotificationsIfChanged", ctx, since, unreadOnly, etag)
+	ret0, _ := ret[0].(types.NotificationsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchNotificationsIfChanged indicates an expected call of FetchNotificationsIfChanged.
+func (mr *MockClientMockRecorder) FetchNotificationsIfChanged(ctx, since, unreadOnly, etag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchNotificationsIfChanged", reflect.TypeOf((*MockClient)(nil).FetchNotificationsIfChanged), ctx, since, unreadOnly, etag)
+}
+
+// FetchNotificationsPage mocks base method.
+func (m *MockClient) FetchNotificationsPage(ctx context.Context, since, before *time.Time, unreadOnly bool) ([]types.NotificationThread, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchNotificationsPage", ctx, since, before, unreadOnly)
+	ret0, _ := ret[0].([]types.NotificationThread)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchNotificationsPage indicates an expected call of FetchNotificationsPage.
+func (mr *MockClientMockRecorder) FetchNotificationsPage(ctx, since, before, unreadOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchNotificationsPage", reflect.TypeOf((*MockClient)(nil).FetchNotificationsPage), ctx, since, before, unreadOnly)
+}
+
 // FetchPullRequestReviews mocks base method.
 func (m *MockClient) FetchPullRequestReviews(ctx context.Context, owner, repo string, number, perPage, page int) ([]types.PullRequestReview, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +209,51 @@ func (mr *MockClientMockRecorder) FetchPullRequestReviews(ctx, owner, repo, numb
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequestReviews", reflect.TypeOf((*MockClient)(nil).FetchPullRequestReviews), ctx, owner, repo, number, perPage, page)
 }
 
+// FetchRepositorySubscriptions mocks base method.
+func (m *MockClient) FetchRepositorySubscriptions(ctx context.Context, fullNames []string) ([]types.RepositorySubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRepositorySubscriptions", ctx, fullNames)
+	ret0, _ := ret[0].([]types.RepositorySubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRepositorySubscriptions indicates an expected call of FetchRepositorySubscriptions.
+func (mr *MockClientMockRecorder) FetchRepositorySubscriptions(ctx, fullNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRepositorySubscriptions", reflect.TypeOf((*MockClient)(nil).FetchRepositorySubscriptions), ctx, fullNames)
+}
+
+// FetchSavedReplies mocks base method.
+func (m *MockClient) FetchSavedReplies(ctx context.Context) ([]types.SavedReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSavedReplies", ctx)
+	ret0, _ := ret[0].([]types.SavedReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSavedReplies indicates an expected call of FetchSavedReplies.
+func (mr *MockClientMockRecorder) FetchSavedReplies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSavedReplies", reflect.TypeOf((*MockClient)(nil).FetchSavedReplies), ctx)
+}
+
+// FetchSubjectProjects mocks base method.
+func (m *MockClient) FetchSubjectProjects(ctx context.Context, nodeID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSubjectProjects", ctx, nodeID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSubjectProjects indicates an expected call of FetchSubjectProjects.
+func (mr *MockClientMockRecorder) FetchSubjectProjects(ctx, nodeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSubjectProjects", reflect.TypeOf((*MockClient)(nil).FetchSubjectProjects), ctx, nodeID)
+}
+
 // FetchSubjectRaw mocks base method.
 func (m *MockClient) FetchSubjectRaw(ctx context.Context, subjectURL string) (json.RawMessage, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +284,94 @@ func (mr *MockClientMockRecorder) FetchTimeline(ctx, owner, repo, number, perPag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTimeline", reflect.TypeOf((*MockClient)(nil).FetchTimeline), ctx, owner, repo, number, perPage, page)
 }
 
+// FetchUserProfiles mocks base method.
+func (m *MockClient) FetchUserProfiles(ctx context.Context, logins []string) ([]types.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserProfiles", ctx, logins)
+	ret0, _ := ret[0].([]types.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserProfiles indicates an expected call of FetchUserProfiles.
+func (mr *MockClientMockRecorder) FetchUserProfiles(ctx, logins any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserProfiles", reflect.TypeOf((*MockClient)(nil).FetchUserProfiles), ctx, logins)
+}
+
+// MarkThreadDone mocks base method.
+func (m *MockClient) MarkThreadDone(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkThreadDone", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkThreadDone indicates an expected call of MarkThreadDone.
+func (mr *MockClientMockRecorder) MarkThreadDone(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkThreadDone", reflect.TypeOf((*MockClient)(nil).MarkThreadDone), ctx, threadID)
+}
+
+// MarkThreadRead mocks base method.
+func (m *MockClient) MarkThreadRead(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkThreadRead", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkThreadRead indicates an expected call of MarkThreadRead.
+func (mr *MockClientMockRecorder) MarkThreadRead(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkThreadRead", reflect.TypeOf((*MockClient)(nil).MarkThreadRead), ctx, threadID)
+}
+
+// MergePullRequest mocks base method.
+func (m *MockClient) MergePullRequest(ctx context.Context, owner, repo string, number int, method string) (*types.MergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePullRequest", ctx, owner, repo, number, method)
+	ret0, _ := ret[0].(*types.MergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePullRequest indicates an expected call of MergePullRequest.
+func (mr *MockClientMockRecorder) MergePullRequest(ctx, owner, repo, number, method any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockClient)(nil).MergePullRequest), ctx, owner, repo, number, method)
+}
+
+// RateLimit mocks base method.
+func (m *MockClient) RateLimit() (types.RateLimit, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit")
+	ret0, _ := ret[0].(types.RateLimit)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockClientMockRecorder) RateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockClient)(nil).RateLimit))
+}
+
+// SetThreadSubscription mocks base method.
+func (m *MockClient) SetThreadSubscription(ctx context.Context, threadID string, ignored bool) (*types.ThreadSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetThreadSubscription", ctx, threadID, ignored)
+	ret0, _ := ret[0].(*types.ThreadSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetThreadSubscription indicates an expected call of SetThreadSubscription.
+func (mr *MockClientMockRecorder) SetThreadSubscription(ctx, threadID, ignored any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreadSubscription", reflect.TypeOf((*MockClient)(nil).SetThreadSubscription), ctx, threadID, ignored)
+}
+
 // SetToken mocks base method.
 func (m *MockClient) SetToken(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -148,3 +385,61 @@ func (mr *MockClientMockRecorder) SetToken(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetToken", reflect.TypeOf((*MockClient)(nil).SetToken), ctx, token)
 }
+
+// SubmitPullRequestReview mocks base method.
+func (m *MockClient) SubmitPullRequestReview(ctx context.Context, owner, repo string, number int, event, body string) (*types.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitPullRequestReview", ctx, owner, repo, number, event, body)
+	ret0, _ := ret[0].(*types.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitPullRequestReview indicates an expected call of SubmitPullRequestReview.
+func (mr *MockClientMockRecorder) SubmitPullRequestReview(ctx, owner, repo, number, event, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPullRequestReview", reflect.TypeOf((*MockClient)(nil).SubmitPullRequestReview), ctx, owner, repo, number, event, body)
+}
+
+// TokenExpiration mocks base method.
+func (m *MockClient) TokenExpiration() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenExpiration")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// TokenExpiration indicates an expected call of TokenExpiration.
+func (mr *MockClientMockRecorder) TokenExpiration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenExpiration", reflect.TypeOf((*MockClient)(nil).TokenExpiration))
+}
+
+// TokenScopes mocks base method.
+func (m *MockClient) TokenScopes() ([]string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenScopes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// TokenScopes indicates an expected call of TokenScopes.
+func (mr *MockClientMockRecorder) TokenScopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenScopes", reflect.TypeOf((*MockClient)(nil).TokenScopes))
+}
+
+// UpdateIssueState mocks base method.
+func (m *MockClient) UpdateIssueState(ctx context.Context, owner, repo string, number int, state string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIssueState", ctx, owner, repo, number, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIssueState indicates an expected call of UpdateIssueState.
+func (mr *MockClientMockRecorder) UpdateIssueState(ctx, owner, repo, number, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssueState", reflect.TypeOf((*MockClient)(nil).UpdateIssueState), ctx, owner, repo, number, state)
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// MaxProfileBatchSize is the most logins FetchUserProfiles looks up in one GraphQL request.
+const MaxProfileBatchSize = 50
+
+// profileOwner is the shape of each aliased repositoryOwner result.
+type profileOwner struct {
+	Typename  string `json:"__typename"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatarUrl"`
+}
+
+// FetchUserProfiles retrieves public profiles for up to MaxProfileBatchSize logins.
+// Users and organizations are resolved with a single aliased GraphQL query. GitHub App
+// bots ("name[bot]") aren't repository owners, so they're looked up one at a time over REST.
+func (c *clientImpl) FetchUserProfiles(
+	ctx context.Context,
+	logins []string,
+) ([]types.UserProfile, error) {
+	if len(logins) > MaxProfileBatchSize {
+		return nil, fmt.Errorf(
+			"github: too many logins in profile batch: %d (max %d)",
+			len(logins),
+			MaxProfileBatchSize,
+		)
+	}
+
+	var accounts, bots []string
+	for _, login := range logins {
+		if strings.HasSuffix(login, "[bot]") {
+			bots = append(bots, login)
+		} else {
+			accounts = append(accounts, login)
+		}
+	}
+
+	profiles, err := c.fetchAccountProfiles(ctx, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, login := range bots {
+		profile, err := c.fetchBotProfile(ctx, login)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil {
+			profiles = append(profiles, *profile)
+		}
+	}
+
+	return profiles, nil
+}
+
+// fetchAccountProfiles looks up users and organizations with one aliased GraphQL query.
+func (c *clientImpl) fetchAccountProfiles(
+	ctx context.Context,
+	logins []string,
+) ([]types.UserProfile, error) {
+	if len(logins) == 0 {
+		return nil, nil
+	}
+
+	var params, fields []string
+	variables := make(map[string]interface{}, len(logins))
+	for i, login := range logins {
+		params = append(params, fmt.Sprintf("$l%d: String!", i))
+		fields = append(fields, fmt.Sprintf(
+			"u%d: repositoryOwner(login: $l%d) { ...ProfileFields }", i, i,
+		))
+		variables[fmt.Sprintf("l%d", i)] = login
+	}
+
+	query := fmt.Sprintf(`
+		query GetProfiles(%s) {
+			%s
+		}
+		fragment ProfileFields on RepositoryOwner {
+			__typename
+			login
+			avatarUrl
+			... on User { name }
+			... on Organization { name }
+		}
+	`, strings.Join(params, ", "), strings.Join(fields, "\n\t\t\t"))
+
+	jsonBody, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal graphql request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		c.baseURL+"/graphql",
+		bytes.NewBuffer(jsonBody),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("github: create graphql request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: execute graphql request: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read graphql response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: graphql status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var graphqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &graphqlResp); err != nil {
+		return nil, fmt.Errorf("github: unmarshal graphql response: %w", err)
+	}
+
+	// Unknown logins come back as null fields, sometimes alongside NOT_FOUND errors,
+	// so errors only fail the batch when there's no data at all
+	var data map[string]*profileOwner
+	if len(graphqlResp.Data) > 0 && string(graphqlResp.Data) != "null" {
+		if err := json.Unmarshal(graphqlResp.Data, &data); err != nil {
+			return nil, fmt.Errorf("github: unmarshal graphql data: %w", err)
+		}
+	}
+	if data == nil && len(graphqlResp.Errors) > 0 {
+		var errorMsgs []string
+		for _, err := range graphqlResp.Errors {
+			errorMsgs = append(errorMsgs, err.Message)
+		}
+		return nil, fmt.Errorf("github: graphql errors: %v", errorMsgs)
+	}
+
+	profiles := make([]types.UserProfile, 0, len(logins))
+	for i := range logins {
+		owner := data[fmt.Sprintf("u%d", i)]
+		if owner == nil {
+			continue
+		}
+		profiles = append(profiles, types.UserProfile{
+			Login:     owner.Login,
+			Name:      owner.Name,
+			AvatarURL: owner.AvatarURL,
+			Type:      owner.Typename,
+		})
+	}
+
+	return profiles, nil
+}
+
+// fetchBotProfile looks up a GitHub App bot account over REST.
+// Returns nil if the account doesn't exist.
+func (c *clientImpl) fetchBotProfile(
+	ctx context.Context,
+	login string,
+) (*types.UserProfile, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		c.baseURL+"/users/"+url.PathEscape(login),
+		http.NoBody,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("github: create user request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: fetch user: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read user body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: user status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var profile types.UserProfile
+	if err := json.Unmarshal(body, &profile); err != nil {
+		return nil, fmt.Errorf("github: unmarshal user body: %w", err)
+	}
+
+	return &profile, nil
+}
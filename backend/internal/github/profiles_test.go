@@ -0,0 +1,120 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestFetchUserProfiles(t *testing.T) {
+	var graphqlRequest GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/graphql":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&graphqlRequest))
+			_, _ = w.Write([]byte(`{
+				"data": {
+					"u0": {"__typename": "User", "login": "Octocat", "name": "The Octocat",
+						"avatarUrl": "https://avatars.example.com/u/1"},
+					"u1": {"__typename": "Organization", "login": "acme", "name": "Acme",
+						"avatarUrl": "https://avatars.example.com/u/2"},
+					"u2": null
+				},
+				"errors": [{"message": "Could not resolve to a User with the login of 'ghost'."}]
+			}`))
+		case "/users/dependabot[bot]":
+			_, _ = w.Write([]byte(`{"login": "dependabot[bot]", "type": "Bot",
+				"avatar_url": "https://avatars.example.com/in/29110"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.token = testToken
+
+	profiles, err := c.FetchUserProfiles(
+		context.Background(),
+		[]string{"octocat", "acme", "ghost", "dependabot[bot]", "missing[bot]"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []types.UserProfile{
+		{
+			Login:     "Octocat",
+			Name:      "The Octocat",
+			AvatarURL: "https://avatars.example.com/u/1",
+			Type:      types.AccountTypeUser,
+		},
+		{
+			Login:     "acme",
+			Name:      "Acme",
+			AvatarURL: "https://avatars.example.com/u/2",
+			Type:      types.AccountTypeOrganization,
+		},
+		{
+			Login:     "dependabot[bot]",
+			AvatarURL: "https://avatars.example.com/in/29110",
+			Type:      types.AccountTypeBot,
+		},
+	}, profiles)
+
+	// Logins are passed as variables, never interpolated into the query
+	require.Equal(t, map[string]interface{}{"l0": "octocat", "l1": "acme", "l2": "ghost"},
+		graphqlRequest.Variables)
+	require.NotContains(t, graphqlRequest.Query, "octocat")
+}
+
+func TestFetchUserProfiles_Errors(t *testing.T) {
+	t.Run("batch too large", func(t *testing.T) {
+		c := newTestClient("http://unused")
+		_, err := c.FetchUserProfiles(context.Background(), make([]string, MaxProfileBatchSize+1))
+		require.ErrorContains(t, err, "too many logins")
+	})
+
+	t.Run("graphql errors without data", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(
+				[]byte(`{"data": null, "errors": [{"message": "API rate limit exceeded"}]}`),
+			)
+		}))
+		defer server.Close()
+
+		c := newTestClient(server.URL)
+		_, err := c.FetchUserProfiles(context.Background(), []string{"octocat"})
+		require.ErrorContains(t, err, "API rate limit exceeded")
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		c := newTestClient(server.URL)
+		_, err := c.FetchUserProfiles(context.Background(), []string{"octocat"})
+		require.ErrorContains(t, err, "graphql status 502")
+	})
+}
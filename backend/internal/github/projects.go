@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"slices"
+)
+
+// subjectProjectsLimit is the most projects FetchSubjectProjects reads for one subject.
+const subjectProjectsLimit = 20
+
+// subjectProjectsQuery looks up the projects an issue or pull request has been added to.
+// Only projects (not classic project boards) are exposed this way, and reading them needs
+// the read:project scope on classic tokens.
+const subjectProjectsQuery = `
+	query GetSubjectProjects($id: ID!, $first: Int!) {
+		node(id: $id) {
+			... on Issue { projectItems(first: $first) { nodes { project { title } } } }
+			... on PullRequest { projectItems(first: $first) { nodes { project { title } } } }
+		}
+	}
+`
+
+// subjectProjectsData is the shape of a subjectProjectsQuery result.
+type subjectProjectsData struct {
+	Node *struct {
+		ProjectItems struct {
+			Nodes []struct {
+				Project *struct {
+					Title string `json:"title"`
+				} `json:"project"`
+			} `json:"nodes"`
+		} `json:"projectItems"`
+	} `json:"node"`
+}
+
+// FetchSubjectProjects retrieves the titles of the projects an issue or pull request,
+// identified by its GraphQL node ID, belongs to. Subjects that can't be found have none.
+func (c *clientImpl) FetchSubjectProjects(ctx context.Context, nodeID string) ([]string, error) {
+	var data subjectProjectsData
+	variables := map[string]interface{}{"id": nodeID, "first": subjectProjectsLimit}
+	if err := c.graphQL(ctx, subjectProjectsQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	titles := []string{}
+	if data.Node == nil {
+		return titles, nil
+	}
+	for _, item := range data.Node.ProjectItems.Nodes {
+		if item.Project != nil && item.Project.Title != "" && !slices.Contains(titles, item.Project.Title) {
+			titles = append(titles, item.Project.Title)
+		}
+	}
+	return titles, nil
+}
+
+// SubjectProjectsScopeMissing reports whether a token's OAuth scopes are known and don't
+// allow reading projects, so looking them up would only fail.
+func SubjectProjectsScopeMissing(scopes []string, ok bool) bool {
+	if !ok {
+		return false
+	}
+	return !slices.Contains(scopes, "read:project") && !slices.Contains(scopes, "project")
+}
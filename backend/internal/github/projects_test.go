@@ -0,0 +1,92 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchSubjectProjects(t *testing.T) {
+	var graphqlRequest GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/graphql", r.URL.Path)
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&graphqlRequest))
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"node": {
+					"projectItems": {
+						"nodes": [
+							{"project": {"title": "Q3 Roadmap"}},
+							{"project": null},
+							{"project": {"title": "Bug Triage"}},
+							{"project": {"title": "Q3 Roadmap"}}
+						]
+					}
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.token = testToken
+
+	projects, err := c.FetchSubjectProjects(context.Background(), "I_kwDOA")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Q3 Roadmap", "Bug Triage"}, projects)
+	require.Equal(t, "I_kwDOA", graphqlRequest.Variables["id"])
+}
+
+func TestFetchSubjectProjects_Errors(t *testing.T) {
+	t.Run("unknown subject has no projects", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"data": {"node": null}}`))
+		}))
+		defer server.Close()
+
+		projects, err := newTestClient(server.URL).FetchSubjectProjects(context.Background(), "I_gone")
+		require.NoError(t, err)
+		require.Empty(t, projects)
+		require.NotNil(t, projects)
+	})
+
+	t.Run("missing scope fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{
+				"data": {"node": {"projectItems": null}},
+				"errors": [{"type": "INSUFFICIENT_SCOPES", "message": "Your token has not been granted the required scopes"}]
+			}`))
+		}))
+		defer server.Close()
+
+		_, err := newTestClient(server.URL).FetchSubjectProjects(context.Background(), "I_kwDOA")
+		require.ErrorContains(t, err, "required scopes")
+	})
+}
+
+func TestSubjectProjectsScopeMissing(t *testing.T) {
+	require.False(t, SubjectProjectsScopeMissing(nil, false))
+	require.False(t, SubjectProjectsScopeMissing([]string{"repo", "read:project"}, true))
+	require.False(t, SubjectProjectsScopeMissing([]string{"project"}, true))
+	require.True(t, SubjectProjectsScopeMissing([]string{"repo", "notifications"}, true))
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package replay records GitHub API traffic into fixture files and replays it, so sync
+// can be exercised end to end without reaching GitHub.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Fixture is a recorded sequence of GitHub API interactions.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request and the response GitHub returned for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Body holds the request body for GraphQL calls
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is a recorded response. JSON bodies are stored in JSON so fixtures stay
+// readable and editable; anything else (such as HTML gateway error pages) goes in Text.
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	JSON    json.RawMessage   `json:"json,omitempty"`
+	Text    string            `json:"text,omitempty"`
+}
+
+// body returns the response body bytes
+func (r Response) body() []byte {
+	if len(r.JSON) > 0 {
+		return r.JSON
+	}
+	return []byte(r.Text)
+}
+
+// Load reads a fixture file.
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Save writes the fixture to path, creating parent directories as needed.
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
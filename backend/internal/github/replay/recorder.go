@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Recorder is an http.RoundTripper that forwards requests and records sanitized
+// copies of each interaction. Request headers, including Authorization, are never
+// recorded.
+type Recorder struct {
+	base http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder returns a Recorder that sends requests with base.
+// A nil base uses http.DefaultTransport.
+func NewRecorder(base http.RoundTripper) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    Sanitize(req.URL.String()),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: sanitizeHeaders(resp.Header),
+		},
+	}
+	if len(reqBody) > 0 {
+		interaction.Request.Body = sanitizeBody(reqBody)
+	}
+	if body := sanitizeBody(respBody); body != nil {
+		interaction.Response.JSON = body
+	} else {
+		interaction.Response.Text = Sanitize(string(respBody))
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Fixture returns everything recorded so far.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.interactions))
+	copy(interactions, r.interactions)
+	return &Fixture{Interactions: interactions}
+}
+
+// Save writes everything recorded so far to path.
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().Save(path)
+}
+
+// sanitizeBody returns the sanitized body, compacted, if it is valid JSON; otherwise nil
+func sanitizeBody(body []byte) json.RawMessage {
+	sanitized := []byte(Sanitize(string(body)))
+	if !json.Valid(sanitized) {
+		return nil
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, sanitized); err != nil {
+		return nil
+	}
+	return compact.Bytes()
+}
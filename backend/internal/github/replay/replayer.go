@@ -0,0 +1,142 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// volatileParams are query parameters that depend on when a request is made.
+// They're ignored when matching, so a fixture recorded yesterday still replays today.
+var volatileParams = []string{"since", "before"}
+
+// Replayer is an http.RoundTripper that answers requests from a fixture instead of
+// the network. Requests match on method, URL (ignoring volatile parameters), and body.
+// Repeats of the same request get the recorded responses in order, and the last one
+// once they run out. Unmatched requests get a 404 and are reported by Unmatched.
+type Replayer struct {
+	mu        sync.Mutex
+	responses map[string][]Response
+	served    map[string]int
+	unmatched []string
+}
+
+// NewReplayer returns a Replayer serving the fixture's interactions.
+func NewReplayer(fixture *Fixture) *Replayer {
+	r := &Replayer{
+		responses: make(map[string][]Response),
+		served:    make(map[string]int),
+	}
+	for _, interaction := range fixture.Interactions {
+		req := interaction.Request
+		key := matchKey(req.Method, req.URL, req.Body)
+		r.responses[key] = append(r.responses[key], interaction.Response)
+	}
+	return r
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	key := matchKey(req.Method, req.URL.String(), body)
+
+	r.mu.Lock()
+	responses := r.responses[key]
+	if len(responses) == 0 {
+		r.unmatched = append(r.unmatched, req.Method+" "+req.URL.String())
+		r.mu.Unlock()
+		return newResponse(req, Response{
+			Status: http.StatusNotFound,
+			JSON:   json.RawMessage(`{"message":"Not Found (no recorded response)"}`),
+		}), nil
+	}
+	index := min(r.served[key], len(responses)-1)
+	r.served[key]++
+	r.mu.Unlock()
+
+	return newResponse(req, responses[index]), nil
+}
+
+// Unmatched returns the requests that had no recorded response.
+func (r *Replayer) Unmatched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unmatched := make([]string, len(r.unmatched))
+	copy(unmatched, r.unmatched)
+	return unmatched
+}
+
+// newResponse builds an http.Response for req from a recorded response
+func newResponse(req *http.Request, recorded Response) *http.Response {
+	body := recorded.body()
+	header := make(http.Header, len(recorded.Headers)+1)
+	for name, value := range recorded.Headers {
+		header.Set(name, value)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.Status) + " " + http.StatusText(recorded.Status),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// matchKey normalizes a request into the key used to look up recorded responses
+func matchKey(method, rawURL string, body []byte) string {
+	normalized := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		query := u.Query()
+		for _, param := range volatileParams {
+			query.Del(param)
+		}
+		// Encode sorts parameters, so their order doesn't matter either
+		u.RawQuery = query.Encode()
+		normalized = u.String()
+	}
+
+	key := method + " " + normalized
+	if len(body) > 0 {
+		var compact bytes.Buffer
+		if json.Compact(&compact, body) == nil {
+			body = compact.Bytes()
+		}
+		key += " " + string(body)
+	}
+	return key
+}
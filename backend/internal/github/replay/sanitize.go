@@ -0,0 +1,69 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// Redacted replaces sensitive values in recorded fixtures
+const Redacted = "REDACTED"
+
+var (
+	// tokenPattern matches GitHub tokens (classic, OAuth, app, refresh, and fine-grained)
+	tokenPattern = regexp.MustCompile(
+		`\b(gh[opusr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`,
+	)
+	// emailPattern matches email addresses, which appear in commit and user payloads
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// recordedHeaders lists the response headers kept in fixtures. Everything else,
+// including cookies and request IDs, is dropped.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"ETag",
+	"Last-Modified",
+	"X-Poll-Interval",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"Retry-After",
+}
+
+// Sanitize redacts tokens and email addresses from s.
+func Sanitize(s string) string {
+	s = tokenPattern.ReplaceAllString(s, Redacted)
+	return emailPattern.ReplaceAllString(s, Redacted)
+}
+
+// sanitizeHeaders keeps only the allowlisted headers, with values sanitized
+func sanitizeHeaders(header http.Header) map[string]string {
+	var kept map[string]string
+	for _, name := range recordedHeaders {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if kept == nil {
+			kept = make(map[string]string)
+		}
+		kept[name] = Sanitize(value)
+	}
+	return kept
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// savedRepliesPageSize is how many saved replies FetchSavedReplies requests per page.
+const savedRepliesPageSize = 100
+
+// savedRepliesQuery pages through the viewer's saved replies. GitHub only exposes saved
+// replies over GraphQL, and only for the authenticated user.
+const savedRepliesQuery = `
+	query GetSavedReplies($first: Int!, $after: String) {
+		viewer {
+			savedReplies(first: $first, after: $after) {
+				nodes { id title body }
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}
+`
+
+// savedRepliesData is the shape of a savedRepliesQuery result.
+type savedRepliesData struct {
+	Viewer struct {
+		SavedReplies struct {
+			Nodes    []types.SavedReply `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"savedReplies"`
+	} `json:"viewer"`
+}
+
+// FetchSavedReplies retrieves all of the authenticated user's saved replies.
+func (c *clientImpl) FetchSavedReplies(ctx context.Context) ([]types.SavedReply, error) {
+	var replies []types.SavedReply
+	var after string
+	for {
+		variables := map[string]interface{}{"first": savedRepliesPageSize}
+		if after != "" {
+			variables["after"] = after
+		}
+
+		var data savedRepliesData
+		if err := c.graphQL(ctx, savedRepliesQuery, variables, &data); err != nil {
+			return nil, err
+		}
+
+		page := data.Viewer.SavedReplies
+		replies = append(replies, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return replies, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+// graphQL runs a GraphQL query and decodes its data into out. Any GraphQL error fails
+// the request.
+func (c *clientImpl) graphQL(
+	ctx context.Context,
+	query string,
+	variables map[string]interface{},
+	out interface{},
+) error {
+	graphqlResp, err := c.doGraphQL(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+
+	if len(graphqlResp.Errors) > 0 {
+		return graphQLErrors(graphqlResp.Errors)
+	}
+
+	if err := json.Unmarshal(graphqlResp.Data, out); err != nil {
+		return fmt.Errorf("github: unmarshal graphql data: %w", err)
+	}
+	return nil
+}
+
+// doGraphQL sends a GraphQL query and returns the decoded response, leaving any GraphQL
+// errors in it for the caller to judge.
+func (c *clientImpl) doGraphQL(
+	ctx context.Context,
+	query string,
+	variables map[string]interface{},
+) (GraphQLResponse, error) {
+	jsonBody, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return GraphQLResponse{}, fmt.Errorf("github: marshal graphql request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		c.baseURL+"/graphql",
+		bytes.NewBuffer(jsonBody),
+	)
+	if err != nil {
+		return GraphQLResponse{}, fmt.Errorf("github: create graphql request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return GraphQLResponse{}, fmt.Errorf("github: execute graphql request: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return GraphQLResponse{}, fmt.Errorf("github: read graphql response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return GraphQLResponse{}, fmt.Errorf("github: graphql status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var graphqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &graphqlResp); err != nil {
+		return GraphQLResponse{}, fmt.Errorf("github: unmarshal graphql response: %w", err)
+	}
+	return graphqlResp, nil
+}
+
+// graphQLErrors combines GraphQL errors into one error
+func graphQLErrors(errs []GraphQLError) error {
+	errorMsgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		errorMsgs = append(errorMsgs, err.Message)
+	}
+	return fmt.Errorf("github: graphql errors: %v", errorMsgs)
+}
+
+// CreateIssueComment posts a comment on an issue or pull request.
+func (c *clientImpl) CreateIssueComment(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	body string,
+) (*types.IssueComment, error) {
+	jsonBody, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal comment: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), number)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("github: create comment request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: post comment: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read comment body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("github: comment status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var comment types.IssueComment
+	if err := json.Unmarshal(respBody, &comment); err != nil {
+		return nil, fmt.Errorf("github: unmarshal comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// SubmitPullRequestReview submits a review on a pull request. event is one of the
+// types.ReviewEvent values; body may be empty only when approving.
+func (c *clientImpl) SubmitPullRequestReview(
+	ctx context.Context,
+	owner, repo string,
+	number int,
+	event, body string,
+) (*types.PullRequestReview, error) {
+	payload := map[string]string{"event": event}
+	if body != "" {
+		payload["body"] = body
+	}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal review: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), number)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("github: create review request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: submit review: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read review body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: review status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var review types.PullRequestReview
+	if err := json.Unmarshal(respBody, &review); err != nil {
+		return nil, fmt.Errorf("github: unmarshal review: %w", err)
+	}
+
+	return &review, nil
+}
+
+// CanWriteRepository reports whether a token with the given OAuth scopes can write to a
+// repository, such as to comment on its issues. The repo scope covers every repository
+// and public_repo only public ones.
+func CanWriteRepository(scopes []string, private bool) bool {
+	for _, scope := range scopes {
+		if scope == "repo" || (scope == "public_repo" && !private) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestFetchSavedReplies(t *testing.T) {
+	var afters []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/graphql", r.URL.Path)
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+
+		var req GraphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		afters = append(afters, req.Variables["after"])
+
+		if req.Variables["after"] == nil {
+			_, _ = w.Write([]byte(`{"data": {"viewer": {"savedReplies": {
+				"nodes": [{"id": "SR_1", "title": "Thanks", "body": "Thanks @{{author}}!"}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}
+			}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"viewer": {"savedReplies": {
+			"nodes": [{"id": "SR_2", "title": "Dupe", "body": "Duplicate of #1"}],
+			"pageInfo": {"hasNextPage": false, "endCursor": "c2"}
+		}}}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.token = testToken
+
+	replies, err := c.FetchSavedReplies(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []types.SavedReply{
+		{ID: "SR_1", Title: "Thanks", Body: "Thanks @{{author}}!"},
+		{ID: "SR_2", Title: "Dupe", Body: "Duplicate of #1"},
+	}, replies)
+	require.Equal(t, []interface{}{nil, "c1"}, afters)
+}
+
+func TestFetchSavedReplies_GraphQLError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "Bad credentials"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.FetchSavedReplies(context.Background())
+	require.ErrorContains(t, err, "Bad credentials")
+}
+
+func TestCreateIssueComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/repos/octo/cli/issues/42/comments", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"body": "Looks good"}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7, "body": "Looks good",
+			"html_url": "https://github.com/octo/cli/issues/42#issuecomment-7"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	comment, err := c.CreateIssueComment(context.Background(), "octo", "cli", 42, "Looks good")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), comment.ID)
+	require.Equal(t, "https://github.com/octo/cli/issues/42#issuecomment-7", comment.HTMLURL)
+}
+
+func TestCreateIssueComment_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.CreateIssueComment(context.Background(), "octo", "cli", 42, "Looks good")
+	require.ErrorContains(t, err, "status 403")
+}
+
+func TestSubmitPullRequestReview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/repos/octo/cli/pulls/42/reviews", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"event": "APPROVE"}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 9, "state": "APPROVED",
+			"html_url": "https://github.com/octo/cli/pull/42#pullrequestreview-9"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	review, err := c.SubmitPullRequestReview(
+		context.Background(), "octo", "cli", 42, types.ReviewEventApprove, "",
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), review.ID)
+	require.Equal(t, "APPROVED", review.State)
+}
+
+func TestSubmitPullRequestReview_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Can not approve your own pull request"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	_, err := c.SubmitPullRequestReview(
+		context.Background(), "octo", "cli", 42, types.ReviewEventApprove, "",
+	)
+	require.ErrorContains(t, err, "status 422")
+}
+
+func TestCanWriteRepository(t *testing.T) {
+	require.True(t, CanWriteRepository([]string{"notifications", "repo"}, true))
+	require.True(t, CanWriteRepository([]string{"public_repo"}, false))
+	require.False(t, CanWriteRepository([]string{"public_repo"}, true))
+	require.False(t, CanWriteRepository([]string{"notifications"}, false))
+	require.False(t, CanWriteRepository(nil, false))
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// MaxSubscriptionBatchSize is the most repositories FetchRepositorySubscriptions looks
+// up in one GraphQL request.
+const MaxSubscriptionBatchSize = 50
+
+// FetchRepositorySubscriptions retrieves the viewer's watch setting for up to
+// MaxSubscriptionBatchSize repositories with a single aliased GraphQL query. GitHub only
+// exposes watching over the API; custom routing and per-event custom watching aren't
+// available.
+func (c *clientImpl) FetchRepositorySubscriptions(
+	ctx context.Context,
+	fullNames []string,
+) ([]types.RepositorySubscription, error) {
+	if len(fullNames) > MaxSubscriptionBatchSize {
+		return nil, fmt.Errorf(
+			"github: too many repositories in subscription batch: %d (max %d)",
+			len(fullNames),
+			MaxSubscriptionBatchSize,
+		)
+	}
+
+	var params, fields []string
+	variables := make(map[string]interface{}, 2*len(fullNames))
+	for i, fullName := range fullNames {
+		owner, name, ok := strings.Cut(fullName, "/")
+		if !ok {
+			return nil, fmt.Errorf("github: invalid repository name %q", fullName)
+		}
+		params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf(
+			"r%d: repository(owner: $o%d, name: $n%d) { nameWithOwner viewerSubscription }", i, i, i,
+		))
+		variables[fmt.Sprintf("o%d", i)] = owner
+		variables[fmt.Sprintf("n%d", i)] = name
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`
+		query GetSubscriptions(%s) {
+			%s
+		}
+	`, strings.Join(params, ", "), strings.Join(fields, "\n\t\t\t"))
+
+	graphqlResp, err := c.doGraphQL(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing repositories come back as null fields alongside NOT_FOUND errors, so errors
+	// only fail the batch when there's no data at all
+	var data map[string]*types.RepositorySubscription
+	if len(graphqlResp.Data) > 0 && string(graphqlResp.Data) != "null" {
+		if err := json.Unmarshal(graphqlResp.Data, &data); err != nil {
+			return nil, fmt.Errorf("github: unmarshal graphql data: %w", err)
+		}
+	}
+	if data == nil && len(graphqlResp.Errors) > 0 {
+		return nil, graphQLErrors(graphqlResp.Errors)
+	}
+
+	subscriptions := make([]types.RepositorySubscription, 0, len(fullNames))
+	for i := range fullNames {
+		if subscription := data[fmt.Sprintf("r%d", i)]; subscription != nil {
+			subscriptions = append(subscriptions, *subscription)
+		}
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestFetchRepositorySubscriptions(t *testing.T) {
+	var graphqlRequest GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/graphql", r.URL.Path)
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&graphqlRequest))
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"r0": {"nameWithOwner": "acme/api", "viewerSubscription": "SUBSCRIBED"},
+				"r1": null,
+				"r2": {"nameWithOwner": "acme/legacy", "viewerSubscription": "IGNORED"}
+			},
+			"errors": [{"message": "Could not resolve to a Repository with the name 'acme/gone'."}]
+		}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.token = testToken
+
+	subscriptions, err := c.FetchRepositorySubscriptions(
+		context.Background(),
+		[]string{"acme/api", "acme/gone", "acme/legacy"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []types.RepositorySubscription{
+		{FullName: "acme/api", Subscription: types.SubscriptionSubscribed},
+		{FullName: "acme/legacy", Subscription: types.SubscriptionIgnored},
+	}, subscriptions)
+
+	// Names are passed as variables, never interpolated into the query
+	require.Equal(t, "acme", graphqlRequest.Variables["o1"])
+	require.Equal(t, "gone", graphqlRequest.Variables["n1"])
+	require.NotContains(t, graphqlRequest.Query, "legacy")
+}
+
+func TestFetchRepositorySubscriptions_Errors(t *testing.T) {
+	t.Run("batch too large", func(t *testing.T) {
+		c := newTestClient("http://unused")
+		_, err := c.FetchRepositorySubscriptions(
+			context.Background(),
+			make([]string, MaxSubscriptionBatchSize+1),
+		)
+		require.ErrorContains(t, err, "too many repositories")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		c := newTestClient("http://unused")
+		_, err := c.FetchRepositorySubscriptions(context.Background(), []string{"acme"})
+		require.ErrorContains(t, err, "invalid repository name")
+	})
+
+	t.Run("graphql errors without data", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(
+				[]byte(`{"data": null, "errors": [{"message": "API rate limit exceeded"}]}`),
+			)
+		}))
+		defer server.Close()
+
+		c := newTestClient(server.URL)
+		_, err := c.FetchRepositorySubscriptions(context.Background(), []string{"acme/api"})
+		require.ErrorContains(t, err, "API rate limit exceeded")
+	})
+}
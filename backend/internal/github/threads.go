@@ -0,0 +1,137 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// MarkThreadRead marks a notification thread as read on GitHub.
+func (c *clientImpl) MarkThreadRead(ctx context.Context, threadID string) error {
+	return c.updateThread(ctx, http.MethodPatch, threadID, http.StatusResetContent)
+}
+
+// MarkThreadDone marks a notification thread as done on GitHub, which also marks it read.
+func (c *clientImpl) MarkThreadDone(ctx context.Context, threadID string) error {
+	return c.updateThread(ctx, http.MethodDelete, threadID, http.StatusNoContent)
+}
+
+// SetThreadSubscription subscribes to a notification thread on GitHub, or with ignored
+// set, stops all of its notifications at the source.
+func (c *clientImpl) SetThreadSubscription(
+	ctx context.Context,
+	threadID string,
+	ignored bool,
+) (*types.ThreadSubscription, error) {
+	jsonBody, err := json.Marshal(map[string]bool{"ignored": ignored})
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal thread subscription: %w", err)
+	}
+
+	respBody, err := c.doThreadRequest(
+		ctx,
+		http.MethodPut,
+		url.PathEscape(threadID)+"/subscription",
+		bytes.NewBuffer(jsonBody),
+		http.StatusOK,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription types.ThreadSubscription
+	if err := json.Unmarshal(respBody, &subscription); err != nil {
+		return nil, fmt.Errorf("github: unmarshal thread subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// DeleteThreadSubscription removes the subscription to a notification thread on GitHub.
+// Its notifications stop until the user comments or is mentioned, unless they watch the
+// repository.
+func (c *clientImpl) DeleteThreadSubscription(ctx context.Context, threadID string) error {
+	_, err := c.doThreadRequest(
+		ctx,
+		http.MethodDelete,
+		url.PathEscape(threadID)+"/subscription",
+		nil,
+		http.StatusNoContent,
+	)
+	return err
+}
+
+// updateThread sends a bodiless request to a notification thread and checks its status.
+// A thread already in the requested state still answers with the expected status.
+func (c *clientImpl) updateThread(
+	ctx context.Context,
+	method, threadID string,
+	expectedStatus int,
+) error {
+	_, err := c.doThreadRequest(ctx, method, url.PathEscape(threadID), nil, expectedStatus)
+	return err
+}
+
+// doThreadRequest sends a request to a path under /notifications/threads/ and returns the
+// response body when it has the expected status
+func (c *clientImpl) doThreadRequest(
+	ctx context.Context,
+	method, path string,
+	body io.Reader,
+	expectedStatus int,
+) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/notifications/threads/%s", c.baseURL, path)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("github: create thread request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: update thread: %w", err)
+	}
+	c.recordRateLimit(resp)
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			_ = closeErr
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read thread body: %w", err)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("github: thread status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
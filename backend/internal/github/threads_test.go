@@ -0,0 +1,98 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkThreadRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/notifications/threads/1234", r.URL.Path)
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusResetContent)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.token = testToken
+	require.NoError(t, c.MarkThreadRead(context.Background(), "1234"))
+}
+
+func TestMarkThreadDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/notifications/threads/1234", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	require.NoError(t, c.MarkThreadDone(context.Background(), "1234"))
+}
+
+func TestMarkThreadRead_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	err := c.MarkThreadRead(context.Background(), "1234")
+	require.ErrorContains(t, err, "status 403")
+	require.False(t, IsRetriableError(err))
+}
+
+func TestSetThreadSubscription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/notifications/threads/1234/subscription", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"ignored": true}`, string(body))
+
+		_, _ = w.Write([]byte(`{"subscribed": false, "ignored": true, "reason": null,
+			"created_at": "2024-05-01T10:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	subscription, err := c.SetThreadSubscription(context.Background(), "1234", true)
+	require.NoError(t, err)
+	require.True(t, subscription.Ignored)
+	require.False(t, subscription.Subscribed)
+	require.Nil(t, subscription.Reason)
+}
+
+func TestDeleteThreadSubscription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.Equal(t, "/notifications/threads/1234/subscription", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	require.NoError(t, c.DeleteThreadSubscription(context.Background(), "1234"))
+}
@@ -81,6 +81,51 @@ type SimpleUser struct {
 	HTMLURL   string `json:"html_url"`
 }
 
+// Account types GitHub reports for users, organizations, and GitHub Apps.
+const (
+	AccountTypeUser         = "User"
+	AccountTypeOrganization = "Organization"
+	AccountTypeBot          = "Bot"
+)
+
+// UserProfile is the public profile of a GitHub account.
+type UserProfile struct {
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+	Type      string `json:"type"`
+}
+
+// SavedReply is one of the authenticated user's GitHub saved replies.
+type SavedReply struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Repository subscription states, as GitHub reports the viewer's watch setting.
+// Custom watching (releases, discussions, ...) isn't exposed and reads as unsubscribed.
+const (
+	SubscriptionSubscribed   = "SUBSCRIBED"
+	SubscriptionUnsubscribed = "UNSUBSCRIBED"
+	SubscriptionIgnored      = "IGNORED"
+)
+
+// RepositorySubscription is the authenticated user's watch setting for a repository.
+type RepositorySubscription struct {
+	FullName     string `json:"nameWithOwner"`
+	Subscription string `json:"viewerSubscription"`
+}
+
+// ThreadSubscription is the authenticated user's subscription to a notification thread.
+// An ignored thread sends no notifications, even from a watched repository.
+type ThreadSubscription struct {
+	Subscribed bool       `json:"subscribed"`
+	Ignored    bool       `json:"ignored"`
+	Reason     *string    `json:"reason"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
 // SubjectInfo contains extracted location information about a notification's subject.
 // Used to make GitHub API calls for the subject (e.g., fetching timeline).
 // Note: Subject type should come from the notification's SubjectType field, not from URL parsing.
@@ -100,6 +145,65 @@ type IssueComment struct {
 	HTMLURL   string     `json:"html_url"`
 }
 
+// Review events accepted when submitting a pull request review.
+const (
+	ReviewEventApprove        = "APPROVE"
+	ReviewEventRequestChanges = "REQUEST_CHANGES"
+	ReviewEventComment        = "COMMENT"
+)
+
+// NotificationsResult is the outcome of a conditional notifications fetch.
+type NotificationsResult struct {
+	Threads []NotificationThread
+	// ETag identifies the first page, to send with the next fetch of the same notifications
+	ETag string
+	// NotModified is set when GitHub reported nothing changed since ETag; Threads is empty
+	NotModified bool
+}
+
+// RateLimit is the state of a GitHub API rate limit budget.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time // when Remaining is restored to Limit
+}
+
+// Merge methods accepted when merging a pull request.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergeResult is GitHub's answer to merging a pull request.
+type MergeResult struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+// CheckRun is a check run reported for a commit, such as a GitHub Actions job.
+type CheckRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress, completed, ...
+	Conclusion string `json:"conclusion"` // Set once completed: success, failure, neutral, ...
+}
+
+// CheckRunList is a page of check runs for a commit.
+type CheckRunList struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+// CombinedStatus is the combined state of the commit statuses reported for a commit by
+// integrations that don't use checks. State is pending when no statuses were reported.
+type CombinedStatus struct {
+	State      string `json:"state"` // success, failure, error or pending
+	TotalCount int    `json:"total_count"`
+}
+
 // PullRequestReview represents a review on a pull request.
 type PullRequestReview struct {
 	ID          int64      `json:"id"`
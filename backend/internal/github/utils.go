@@ -129,6 +129,318 @@ func ExtractSubjectStateReason(subjectJSON json.RawMessage) sql.NullString {
 	return sql.NullString{}
 }
 
+// ExtractSubjectDraft extracts the draft status from subject JSON.
+// Works for Pull Requests which have a "draft" field (boolean).
+func ExtractSubjectDraft(subjectJSON json.RawMessage) sql.NullBool {
+	var data struct {
+		Draft *bool `json:"draft"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Draft == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *data.Draft, Valid: true}
+}
+
+// ExtractSubjectMergeable extracts whether an open pull request can be merged without
+// conflicts. It is NULL for closed pull requests, and while GitHub is still computing it
+// (GitHub reports null then and works it out in the background).
+func ExtractSubjectMergeable(subjectJSON json.RawMessage) sql.NullBool {
+	var data struct {
+		State     string `json:"state"`
+		Mergeable *bool  `json:"mergeable"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Mergeable == nil ||
+		data.State != "open" {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *data.Mergeable, Valid: true}
+}
+
+// ExtractSubjectLabels extracts the label names from subject JSON as a JSON array.
+// Works for Issues and Pull Requests; nil is returned when the subject has no "labels" field.
+func ExtractSubjectLabels(subjectJSON json.RawMessage) json.RawMessage {
+	var data struct {
+		Labels *[]struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Labels == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(*data.Labels))
+	for _, label := range *data.Labels {
+		if label.Name != "" {
+			names = append(names, label.Name)
+		}
+	}
+	encoded, err := json.Marshal(names)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// ExtractSubjectMilestone extracts the title of the subject's milestone from subject JSON.
+// Works for Issues and Pull Requests, which have a "milestone" object or null.
+func ExtractSubjectMilestone(subjectJSON json.RawMessage) sql.NullString {
+	var data struct {
+		Milestone *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Milestone == nil ||
+		data.Milestone.Title == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: data.Milestone.Title, Valid: true}
+}
+
+// ExtractSubjectNodeID extracts the GraphQL node ID from subject JSON, or "" if there is none.
+func ExtractSubjectNodeID(subjectJSON json.RawMessage) string {
+	var data struct {
+		NodeID string `json:"node_id"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return ""
+	}
+	return data.NodeID
+}
+
+// Review states a pull request can be in, as stored on its notifications.
+const (
+	ReviewStateApproved         = "approved"
+	ReviewStateChangesRequested = "changes_requested"
+	ReviewStatePending          = "pending"
+)
+
+// CI states a pull request's head commit can be in, as stored on its notifications.
+const (
+	CIStateSuccess = "success"
+	CIStateFailure = "failure"
+	CIStatePending = "pending"
+)
+
+// CIStateFromChecks combines a commit's check runs and commit statuses the way GitHub's
+// status rollup does: any failing check or status fails the commit, then any that hasn't
+// finished leaves it pending. Neutral and skipped check runs count as passing. "" is returned
+// when nothing was reported for the commit.
+func CIStateFromChecks(runs []types.CheckRun, status types.CombinedStatus) string {
+	if len(runs) == 0 && status.TotalCount == 0 {
+		return ""
+	}
+
+	state := CIStateSuccess
+	if status.TotalCount > 0 {
+		switch strings.ToLower(status.State) {
+		case "failure", "error":
+			return CIStateFailure
+		case "pending":
+			state = CIStatePending
+		}
+	}
+	for _, run := range runs {
+		if !strings.EqualFold(run.Status, "completed") {
+			state = CIStatePending
+			continue
+		}
+		switch strings.ToLower(run.Conclusion) {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			return CIStateFailure
+		}
+	}
+	return state
+}
+
+// ReviewStateFromReviews summarizes a pull request's reviews, oldest first, the way GitHub's
+// review decision does: each reviewer's latest approval or change request counts, and a
+// dismissed review no longer does. Any outstanding change request wins over approvals, and a
+// pull request without either is pending.
+func ReviewStateFromReviews(reviews []types.PullRequestReview) string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		switch strings.ToUpper(review.State) {
+		case "APPROVED":
+			latest[review.User.Login] = ReviewStateApproved
+		case "CHANGES_REQUESTED":
+			latest[review.User.Login] = ReviewStateChangesRequested
+		case "DISMISSED":
+			delete(latest, review.User.Login)
+		}
+	}
+
+	state := ReviewStatePending
+	for _, s := range latest {
+		if s == ReviewStateChangesRequested {
+			return ReviewStateChangesRequested
+		}
+		state = ReviewStateApproved
+	}
+	return state
+}
+
+// Whose turn it is on an open issue or pull request, as stored on its notifications.
+const (
+	WaitingOnMe   = "me"
+	WaitingOnThem = "them"
+)
+
+// reasonsInConversation are the notification reasons that mean the user is part of the
+// conversation, so a reply from someone else is theirs to answer.
+var reasonsInConversation = map[string]bool{
+	"author":  true,
+	"assign":  true,
+	"comment": true,
+	"mention": true,
+}
+
+// WaitingOn works out whether an open issue or pull request is waiting on login or on
+// someone else. A pending review request, or changes requested on login's own pull request,
+// is always waiting on login. Otherwise whoever wrote the latest comment decides: login
+// having the last word leaves it waiting on them, and someone else's reply waits on login
+// when login is part of the conversation. latestMine is NULL when the latest comment's
+// author isn't known; login's own subjects then wait on them and assigned ones on login.
+// "" is returned when neither applies, for closed subjects, and when login is empty.
+func WaitingOn(
+	login, reason string,
+	subjectJSON json.RawMessage,
+	reviewState string,
+	latestMine sql.NullBool,
+) string {
+	state := ExtractSubjectState(subjectJSON)
+	if login == "" || !state.Valid || !strings.EqualFold(state.String, "open") {
+		return ""
+	}
+
+	reviewers, _ := ExtractRequestedReviews(subjectJSON)
+	if containsLogin(reviewers, login) {
+		return WaitingOnMe
+	}
+	author, _ := ExtractAuthorFromSubject(subjectJSON)
+	authored := author.Valid && strings.EqualFold(author.String, login)
+	if authored && reviewState == ReviewStateChangesRequested {
+		return WaitingOnMe
+	}
+	if latestMine.Valid && latestMine.Bool {
+		return WaitingOnThem
+	}
+
+	assigned := containsLogin(ExtractSubjectAssignees(subjectJSON), login)
+	switch {
+	case latestMine.Valid && (authored || assigned || reasonsInConversation[reason]):
+		return WaitingOnMe
+	case !latestMine.Valid && assigned:
+		return WaitingOnMe
+	case !latestMine.Valid && authored:
+		return WaitingOnThem
+	default:
+		return ""
+	}
+}
+
+// ExtractSubjectAssignees returns the logins an issue or pull request is assigned to. It is
+// empty when there are none and for invalid JSON.
+func ExtractSubjectAssignees(subjectJSON json.RawMessage) []string {
+	var data struct {
+		Assignees []struct {
+			Login string `json:"login"`
+		} `json:"assignees"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return nil
+	}
+	var logins []string
+	for _, assignee := range data.Assignees {
+		if assignee.Login != "" {
+			logins = append(logins, assignee.Login)
+		}
+	}
+	return logins
+}
+
+// containsLogin reports whether logins include login, compared case-insensitively like
+// GitHub does
+func containsLogin(logins []string, login string) bool {
+	for _, candidate := range logins {
+		if strings.EqualFold(candidate, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractCommentBody extracts the body from comment JSON, such as the payload behind a
+// notification's latest comment URL. A comment without a body gives an empty string.
+func ExtractCommentBody(commentJSON json.RawMessage) sql.NullString {
+	var data struct {
+		Body *string `json:"body"`
+	}
+	if err := json.Unmarshal(commentJSON, &data); err != nil {
+		return sql.NullString{}
+	}
+	if data.Body == nil {
+		return sql.NullString{String: "", Valid: true}
+	}
+	return sql.NullString{String: *data.Body, Valid: true}
+}
+
+// ExtractCommentCreatedAt extracts when a comment was posted from comment JSON. Reviews,
+// which a latest comment URL can also point at, carry the time in "submitted_at" instead.
+func ExtractCommentCreatedAt(commentJSON json.RawMessage) sql.NullTime {
+	var data struct {
+		CreatedAt   *time.Time `json:"created_at"`
+		SubmittedAt *time.Time `json:"submitted_at"`
+	}
+	if err := json.Unmarshal(commentJSON, &data); err != nil {
+		return sql.NullTime{}
+	}
+	if data.CreatedAt != nil {
+		return sql.NullTime{Time: data.CreatedAt.UTC(), Valid: true}
+	}
+	if data.SubmittedAt != nil {
+		return sql.NullTime{Time: data.SubmittedAt.UTC(), Valid: true}
+	}
+	return sql.NullTime{}
+}
+
+// ExtractSubjectBranches extracts the head and base branch names from subject JSON.
+// Works for Pull Requests, whose "head" and "base" objects carry the branch in "ref".
+func ExtractSubjectBranches(subjectJSON json.RawMessage) (head, base sql.NullString) {
+	var data struct {
+		Head *struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base *struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return sql.NullString{}, sql.NullString{}
+	}
+
+	if data.Head != nil && data.Head.Ref != "" {
+		head = sql.NullString{String: data.Head.Ref, Valid: true}
+	}
+	if data.Base != nil && data.Base.Ref != "" {
+		base = sql.NullString{String: data.Base.Ref, Valid: true}
+	}
+	return head, base
+}
+
+// ExtractSubjectHeadSHA extracts the SHA of a pull request's head commit from subject JSON,
+// or "" if there is none.
+func ExtractSubjectHeadSHA(subjectJSON json.RawMessage) string {
+	var data struct {
+		Head *struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Head == nil {
+		return ""
+	}
+	return data.Head.SHA
+}
+
 // PullRequestData represents extracted pull request data from GitHub API responses.
 // This is a pure data structure with no database dependencies.
 type PullRequestData struct {
@@ -145,6 +457,73 @@ type PullRequestData struct {
 	MergedAt    *time.Time
 	AuthorLogin *string
 	AuthorID    *int64
+	// RequestedReviewers are the logins of users whose review is still pending.
+	// GitHub removes a reviewer once they submit a review.
+	RequestedReviewers []string
+	// RequestedTeams are the "org/team" names of teams whose review is still pending
+	RequestedTeams []string
+}
+
+// requestedReviews is the part of a pull request's JSON naming whose review it's waiting on
+type requestedReviews struct {
+	RequestedReviewers []struct {
+		Login string `json:"login"`
+	} `json:"requested_reviewers"`
+	RequestedTeams []struct {
+		Slug    string `json:"slug"`
+		HTMLURL string `json:"html_url"`
+	} `json:"requested_teams"`
+	Base struct {
+		Repo struct {
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repo"`
+	} `json:"base"`
+}
+
+// reviewers returns the requested reviewer logins
+func (r requestedReviews) reviewers() []string {
+	var logins []string
+	for _, reviewer := range r.RequestedReviewers {
+		if reviewer.Login != "" {
+			logins = append(logins, reviewer.Login)
+		}
+	}
+	return logins
+}
+
+// teams returns the requested teams as "org/team". The org comes from the team's page URL,
+// or else from the owner of the pull request's repository, which a requested team belongs to.
+func (r requestedReviews) teams() []string {
+	var names []string
+	for _, team := range r.RequestedTeams {
+		if team.Slug == "" {
+			continue
+		}
+		org := r.Base.Repo.Owner.Login
+		if rest, ok := strings.CutPrefix(team.HTMLURL, "https://github.com/orgs/"); ok {
+			if name, _, found := strings.Cut(rest, "/"); found && name != "" {
+				org = name
+			}
+		}
+		if org == "" {
+			names = append(names, team.Slug)
+			continue
+		}
+		names = append(names, org+"/"+team.Slug)
+	}
+	return names
+}
+
+// ExtractRequestedReviews returns the logins and "org/team" names a pull request's review is
+// still requested from. Both are empty for other subjects and for invalid JSON.
+func ExtractRequestedReviews(subjectJSON json.RawMessage) (reviewers, teams []string) {
+	var requested requestedReviews
+	if len(subjectJSON) == 0 || json.Unmarshal(subjectJSON, &requested) != nil {
+		return nil, nil
+	}
+	return requested.reviewers(), requested.teams()
 }
 
 // ExtractPullRequestData parses PR data from subject JSON.
@@ -166,6 +545,7 @@ func ExtractPullRequestData(subjectJSON json.RawMessage) (*PullRequestData, erro
 			Login *string `json:"login"`
 			ID    *int64  `json:"id"`
 		} `json:"user"`
+		requestedReviews
 	}
 
 	if err := json.Unmarshal(subjectJSON, &prData); err != nil {
@@ -215,6 +595,9 @@ func ExtractPullRequestData(subjectJSON json.RawMessage) (*PullRequestData, erro
 		result.AuthorID = prData.User.ID
 	}
 
+	result.RequestedReviewers = prData.reviewers()
+	result.RequestedTeams = prData.teams()
+
 	return result, nil
 }
 
@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
 )
 
 func TestExtractAuthorFromSubject(t *testing.T) {
@@ -322,6 +324,279 @@ func TestExtractSubjectMerged(t *testing.T) {
 	}
 }
 
+func TestExtractSubjectBranches(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		wantHead    sql.NullString
+		wantBase    sql.NullString
+	}{
+		{
+			name: "PR with head and base",
+			subjectJSON: json.RawMessage(
+				`{"head": {"ref": "fix/login", "sha": "abc"}, "base": {"ref": "release/1.2"}}`,
+			),
+			wantHead: sql.NullString{String: "fix/login", Valid: true},
+			wantBase: sql.NullString{String: "release/1.2", Valid: true},
+		},
+		{
+			name:        "Issue without branches",
+			subjectJSON: json.RawMessage(`{"state": "open"}`),
+		},
+		{
+			name:        "Empty ref",
+			subjectJSON: json.RawMessage(`{"head": {"ref": ""}, "base": {"ref": "main"}}`),
+			wantBase:    sql.NullString{String: "main", Valid: true},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, base := ExtractSubjectBranches(tt.subjectJSON)
+			if head != tt.wantHead {
+				t.Errorf("ExtractSubjectBranches() head = %v, want %v", head, tt.wantHead)
+			}
+			if base != tt.wantBase {
+				t.Errorf("ExtractSubjectBranches() base = %v, want %v", base, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestExtractSubjectDraft(t *testing.T) {
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true},
+		ExtractSubjectDraft(json.RawMessage(`{"draft": true, "state": "open"}`)))
+	require.Equal(t, sql.NullBool{Valid: true},
+		ExtractSubjectDraft(json.RawMessage(`{"draft": false}`)))
+	require.Equal(t, sql.NullBool{}, ExtractSubjectDraft(json.RawMessage(`{"state": "open"}`)))
+	require.Equal(t, sql.NullBool{}, ExtractSubjectDraft(json.RawMessage(`{invalid json}`)))
+}
+
+func TestExtractSubjectMergeable(t *testing.T) {
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true},
+		ExtractSubjectMergeable(json.RawMessage(`{"state": "open", "mergeable": true}`)))
+	require.Equal(t, sql.NullBool{Valid: true},
+		ExtractSubjectMergeable(json.RawMessage(`{"state": "open", "mergeable": false}`)))
+	// GitHub hasn't worked it out yet
+	require.Equal(t, sql.NullBool{},
+		ExtractSubjectMergeable(json.RawMessage(`{"state": "open", "mergeable": null}`)))
+	require.Equal(t, sql.NullBool{},
+		ExtractSubjectMergeable(json.RawMessage(`{"state": "closed", "mergeable": false}`)))
+	require.Equal(t, sql.NullBool{}, ExtractSubjectMergeable(json.RawMessage(`{"state": "open"}`)))
+	require.Equal(t, sql.NullBool{}, ExtractSubjectMergeable(json.RawMessage(`{invalid json}`)))
+}
+
+func TestExtractSubjectLabels(t *testing.T) {
+	labels := ExtractSubjectLabels(json.RawMessage(
+		`{"labels": [{"name": "bug", "color": "d73a4a"}, {"name": ""}, {"name": "needs review"}]}`,
+	))
+	require.JSONEq(t, `["bug", "needs review"]`, string(labels))
+
+	require.JSONEq(t, `[]`, string(ExtractSubjectLabels(json.RawMessage(`{"labels": []}`))))
+	require.Nil(t, ExtractSubjectLabels(json.RawMessage(`{"state": "open"}`)))
+	require.Nil(t, ExtractSubjectLabels(json.RawMessage(`{invalid json}`)))
+}
+
+func TestExtractSubjectMilestone(t *testing.T) {
+	milestone := ExtractSubjectMilestone(json.RawMessage(
+		`{"milestone": {"number": 3, "title": "v2.0", "state": "open"}}`,
+	))
+	require.Equal(t, sql.NullString{String: "v2.0", Valid: true}, milestone)
+
+	require.False(t, ExtractSubjectMilestone(json.RawMessage(`{"milestone": null}`)).Valid)
+	require.False(t, ExtractSubjectMilestone(json.RawMessage(`{"state": "open"}`)).Valid)
+	require.False(t, ExtractSubjectMilestone(json.RawMessage(`{invalid json}`)).Valid)
+}
+
+func TestExtractSubjectNodeID(t *testing.T) {
+	require.Equal(t, "I_kwDOA", ExtractSubjectNodeID(json.RawMessage(`{"node_id": "I_kwDOA"}`)))
+	require.Empty(t, ExtractSubjectNodeID(json.RawMessage(`{"state": "open"}`)))
+	require.Empty(t, ExtractSubjectNodeID(json.RawMessage(`{invalid json}`)))
+}
+
+func TestExtractSubjectHeadSHA(t *testing.T) {
+	require.Equal(t, "abc123", ExtractSubjectHeadSHA(json.RawMessage(`{"head": {"ref": "fix", "sha": "abc123"}}`)))
+	require.Empty(t, ExtractSubjectHeadSHA(json.RawMessage(`{"state": "open"}`)))
+	require.Empty(t, ExtractSubjectHeadSHA(json.RawMessage(`{invalid json}`)))
+}
+
+func TestCIStateFromChecks(t *testing.T) {
+	run := func(status, conclusion string) types.CheckRun {
+		return types.CheckRun{Status: status, Conclusion: conclusion}
+	}
+	noStatuses := types.CombinedStatus{State: "pending"}
+
+	tests := []struct {
+		name   string
+		runs   []types.CheckRun
+		status types.CombinedStatus
+		want   string
+	}{
+		{name: "Nothing reported", status: noStatuses, want: ""},
+		{
+			name:   "All passing",
+			runs:   []types.CheckRun{run("completed", "success"), run("completed", "skipped")},
+			status: noStatuses,
+			want:   CIStateSuccess,
+		},
+		{
+			name:   "Failed check run",
+			runs:   []types.CheckRun{run("in_progress", ""), run("completed", "timed_out")},
+			status: noStatuses,
+			want:   CIStateFailure,
+		},
+		{
+			name:   "Unfinished check run",
+			runs:   []types.CheckRun{run("completed", "success"), run("queued", "")},
+			status: noStatuses,
+			want:   CIStatePending,
+		},
+		{
+			name:   "Errored commit status",
+			runs:   []types.CheckRun{run("completed", "success")},
+			status: types.CombinedStatus{State: "error", TotalCount: 1},
+			want:   CIStateFailure,
+		},
+		{
+			name:   "Pending commit status",
+			status: types.CombinedStatus{State: "pending", TotalCount: 2},
+			want:   CIStatePending,
+		},
+		{
+			name:   "Only commit statuses",
+			status: types.CombinedStatus{State: "success", TotalCount: 1},
+			want:   CIStateSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, CIStateFromChecks(tt.runs, tt.status))
+		})
+	}
+}
+
+func TestReviewStateFromReviews(t *testing.T) {
+	review := func(login, state string) types.PullRequestReview {
+		return types.PullRequestReview{State: state, User: types.SimpleUser{Login: login}}
+	}
+
+	tests := []struct {
+		name    string
+		reviews []types.PullRequestReview
+		want    string
+	}{
+		{name: "No reviews", want: ReviewStatePending},
+		{
+			name:    "Only comments",
+			reviews: []types.PullRequestReview{review("alice", "COMMENTED")},
+			want:    ReviewStatePending,
+		},
+		{
+			name:    "Approved",
+			reviews: []types.PullRequestReview{review("alice", "APPROVED"), review("alice", "COMMENTED")},
+			want:    ReviewStateApproved,
+		},
+		{
+			name:    "Change request outweighs approval",
+			reviews: []types.PullRequestReview{review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED")},
+			want:    ReviewStateChangesRequested,
+		},
+		{
+			name: "Later approval replaces change request",
+			reviews: []types.PullRequestReview{
+				review("bob", "CHANGES_REQUESTED"),
+				review("bob", "APPROVED"),
+			},
+			want: ReviewStateApproved,
+		},
+		{
+			name:    "Dismissed review no longer counts",
+			reviews: []types.PullRequestReview{review("bob", "CHANGES_REQUESTED"), review("bob", "DISMISSED")},
+			want:    ReviewStatePending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReviewStateFromReviews(tt.reviews))
+		})
+	}
+}
+
+func TestWaitingOn(t *testing.T) {
+	mine := sql.NullBool{Bool: true, Valid: true}
+	theirs := sql.NullBool{Valid: true}
+	unknown := sql.NullBool{}
+	myPR := `{"state": "open", "user": {"login": "Alice"}}`
+
+	tests := []struct {
+		name        string
+		reason      string
+		subject     string
+		reviewState string
+		latestMine  sql.NullBool
+		want        string
+	}{
+		{
+			name:       "Review requested from me",
+			subject:    `{"state": "open", "user": {"login": "bob"}, "requested_reviewers": [{"login": "alice"}]}`,
+			latestMine: mine,
+			want:       WaitingOnMe,
+		},
+		{
+			name:        "Changes requested on my pull request",
+			subject:     myPR,
+			reviewState: ReviewStateChangesRequested,
+			latestMine:  mine,
+			want:        WaitingOnMe,
+		},
+		{name: "I had the last word", subject: myPR, latestMine: mine, want: WaitingOnThem},
+		{name: "Reply on my pull request", subject: myPR, latestMine: theirs, want: WaitingOnMe},
+		{name: "My pull request without comments", subject: myPR, latestMine: unknown, want: WaitingOnThem},
+		{
+			name:       "Reply in a conversation I'm in",
+			reason:     "comment",
+			subject:    `{"state": "open", "user": {"login": "bob"}}`,
+			latestMine: theirs,
+			want:       WaitingOnMe,
+		},
+		{
+			name:       "Assigned to me",
+			reason:     "subscribed",
+			subject:    `{"state": "open", "user": {"login": "bob"}, "assignees": [{"login": "alice"}]}`,
+			latestMine: unknown,
+			want:       WaitingOnMe,
+		},
+		{
+			name:       "Just watching",
+			reason:     "subscribed",
+			subject:    `{"state": "open", "user": {"login": "bob"}}`,
+			latestMine: theirs,
+			want:       "",
+		},
+		{
+			name:       "Closed",
+			subject:    `{"state": "closed", "user": {"login": "alice"}}`,
+			latestMine: theirs,
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WaitingOn("alice", tt.reason, json.RawMessage(tt.subject), tt.reviewState, tt.latestMine)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Empty(t, WaitingOn("", "author", json.RawMessage(myPR), "", theirs))
+}
+
 func TestExtractSubjectStateReason(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -381,6 +656,72 @@ func TestExtractSubjectStateReason(t *testing.T) {
 	}
 }
 
+func TestExtractCommentBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		commentJSON json.RawMessage
+		want        sql.NullString
+	}{
+		{
+			name:        "Comment with body",
+			commentJSON: json.RawMessage(`{"id": 1, "body": "Looks good to me"}`),
+			want:        sql.NullString{String: "Looks good to me", Valid: true},
+		},
+		{
+			name:        "Comment with null body",
+			commentJSON: json.RawMessage(`{"id": 1, "body": null}`),
+			want:        sql.NullString{String: "", Valid: true},
+		},
+		{
+			name:        "Invalid JSON",
+			commentJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullString{Valid: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractCommentBody(tt.commentJSON))
+		})
+	}
+}
+
+func TestExtractCommentCreatedAt(t *testing.T) {
+	posted := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name        string
+		commentJSON json.RawMessage
+		want        sql.NullTime
+	}{
+		{
+			name:        "Issue comment",
+			commentJSON: json.RawMessage(`{"id": 1, "created_at": "2025-03-04T05:06:07Z"}`),
+			want:        sql.NullTime{Time: posted, Valid: true},
+		},
+		{
+			name:        "Pull request review",
+			commentJSON: json.RawMessage(`{"id": 1, "submitted_at": "2025-03-04T05:06:07Z"}`),
+			want:        sql.NullTime{Time: posted, Valid: true},
+		},
+		{
+			name:        "No timestamp",
+			commentJSON: json.RawMessage(`{"id": 1}`),
+			want:        sql.NullTime{Valid: false},
+		},
+		{
+			name:        "Invalid JSON",
+			commentJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullTime{Valid: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractCommentCreatedAt(tt.commentJSON))
+		})
+	}
+}
+
 func TestExtractSubjectState(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -594,6 +935,21 @@ func TestExtractPullRequestData(t *testing.T) {
 				require.NotNil(t, data.MergedAt)
 			},
 		},
+		{
+			name: "PR with requested reviewers",
+			subjectJSON: json.RawMessage(`{
+				"number": 9,
+				"requested_reviewers": [{"login": "alice"}, {"login": "bob"}],
+				"requested_teams": [{"slug": "core"}],
+				"base": {"repo": {"owner": {"login": "octo"}}}
+			}`),
+			expectErr: false,
+			validateData: func(t *testing.T, data *PullRequestData) {
+				require.NotNil(t, data)
+				require.Equal(t, []string{"alice", "bob"}, data.RequestedReviewers)
+				require.Equal(t, []string{"octo/core"}, data.RequestedTeams)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -665,6 +1021,47 @@ func TestExtractPullRequestData_InvalidTimeFormat(t *testing.T) {
 	require.Nil(t, data.CreatedAt)
 }
 
+func TestExtractRequestedReviews(t *testing.T) {
+	tests := []struct {
+		name          string
+		subjectJSON   json.RawMessage
+		wantReviewers []string
+		wantTeams     []string
+	}{
+		{
+			name: "org from team URL",
+			subjectJSON: json.RawMessage(`{
+				"requested_reviewers": [{"login": "alice"}, {"login": ""}],
+				"requested_teams": [{"slug": "core", "html_url": "https://github.com/orgs/acme/teams/core"}],
+				"base": {"repo": {"owner": {"login": "someone-else"}}}
+			}`),
+			wantReviewers: []string{"alice"},
+			wantTeams:     []string{"acme/core"},
+		},
+		{
+			name:        "slug only without an org",
+			subjectJSON: json.RawMessage(`{"requested_teams": [{"slug": "core"}, {"name": "no slug"}]}`),
+			wantTeams:   []string{"core"},
+		},
+		{
+			name:        "issue subject",
+			subjectJSON: json.RawMessage(`{"number": 3, "state": "open"}`),
+		},
+		{
+			name:        "invalid JSON",
+			subjectJSON: json.RawMessage(`{`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewers, teams := ExtractRequestedReviews(tt.subjectJSON)
+			require.Equal(t, tt.wantReviewers, reviewers)
+			require.Equal(t, tt.wantTeams, teams)
+		})
+	}
+}
+
 func TestExtractSubjectInfo(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+// GitHubMarker queues threads to be marked read, or done, on GitHub. The jobs are stored in
+// the database, so threads marked while the scheduler isn't running, such as from the
+// command line, are sent to GitHub once it is.
+type GitHubMarker struct {
+	queue  JobQueue
+	logger *zap.Logger
+}
+
+// NewGitHubMarker creates a GitHubMarker that adds jobs to queue
+func NewGitHubMarker(queue JobQueue, logger *zap.Logger) *GitHubMarker {
+	return &GitHubMarker{
+		queue:  queue,
+		logger: logger,
+	}
+}
+
+// EnqueueMarkOnGitHub enqueues a job per thread to mark it read, or done, on GitHub.
+// Jobs are kept through restarts and retried while GitHub can't be reached.
+func (m *GitHubMarker) EnqueueMarkOnGitHub(
+	ctx context.Context,
+	_ string,
+	githubIDs []string,
+	done bool,
+) error {
+	for _, githubID := range githubIDs {
+		payloadBytes, err := json.Marshal(handlers.MarkOnGitHubJobPayload{
+			GithubID: githubID,
+			Done:     done,
+		})
+		if err != nil {
+			m.logger.Warn("failed to marshal mark on GitHub job payload", zap.Error(err))
+			return err
+		}
+
+		jobID, err := m.queue.Enqueue(ctx, EnqueueParams{
+			Queue:       QueueMarkOnGitHub,
+			Payload:     payloadBytes,
+			MaxAttempts: markOnGitHubMaxAttempts,
+		})
+		if err != nil {
+			m.logger.Warn("failed to enqueue mark on GitHub job", zap.Error(err))
+			return err
+		}
+
+		m.logger.Debug("mark on GitHub job enqueued", zap.Int64("jobID", jobID))
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/osactions"
+)
+
+// inboxQuery decides whether the default alert applies to a notification
+const inboxQuery = "in:inbox"
+
+// nativeNotificationQuery limits native notifications to unread inbox notifications
+const nativeNotificationQuery = "in:inbox is:unread"
+
+// MatchAlert finds the alert configuration for a newly arrived notification. The first
+// enabled rule with an alert that matches wins, in the order rules run, unless a matching
+// rule stopped processing before it. Then the first view with an alert in display order.
+// Otherwise the default applies if the notification reached the inbox, and there's no
+// alert if rules filtered, archived or muted it.
+func (rm *RuleMatcher) MatchAlert(
+	ctx context.Context,
+	userID string,
+	notificationID int64,
+	settings *models.AlertSettings,
+) (alert.Match, error) {
+	notification, err := rm.store.GetNotificationByID(ctx, userID, notificationID)
+	if err != nil {
+		return alert.Match{}, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	rules, err := rm.store.ListEnabledRulesOrdered(ctx, userID)
+	if err != nil {
+		return alert.Match{}, fmt.Errorf("failed to list enabled rules: %w", err)
+	}
+	for _, rule := range rules {
+		var actions models.RuleActions
+		if len(rule.Actions) > 0 && json.Unmarshal(rule.Actions, &actions) != nil {
+			continue
+		}
+		if actions.Alert == nil && !rule.StopProcessing {
+			continue
+		}
+		matched, err := rm.checkRuleMatch(ctx, userID, notification, rule)
+		if err != nil || !matched {
+			// Skip rules that fail to match, like MatchAndApplyRules does
+			continue
+		}
+		if actions.Alert != nil {
+			return alert.Match{Config: *actions.Alert, Source: alert.SourceRule, SourceID: rule.ID}, nil
+		}
+		// A matching rule without an alert stopped processing, so later rules don't apply
+		break
+	}
+
+	if len(settings.Views) > 0 {
+		views, err := rm.store.ListViews(ctx, userID)
+		if err != nil {
+			return alert.Match{}, fmt.Errorf("failed to list views: %w", err)
+		}
+		for _, view := range views {
+			config, ok := settings.Views[view.ID]
+			if !ok || !view.Query.Valid || view.Query.String == "" {
+				continue
+			}
+			matched, err := rm.checkQueryMatch(ctx, userID, notification.ID, view.Query.String)
+			if err != nil || !matched {
+				continue
+			}
+			return alert.Match{Config: config, Source: alert.SourceView, SourceID: view.ID}, nil
+		}
+	}
+
+	inInbox, err := rm.checkQueryMatch(ctx, userID, notification.ID, inboxQuery)
+	if err != nil {
+		return alert.Match{}, err
+	}
+	if !inInbox {
+		return alert.Match{
+			Config: models.AlertConfig{Level: models.AlertLevelNone},
+			Source: alert.SourceDefault,
+		}, nil
+	}
+	return alert.Match{Config: settings.Default, Source: alert.SourceDefault}, nil
+}
+
+// RecordAlertWithDB matches and records the alert for a newly arrived notification
+func RecordAlertWithDB(
+	ctx context.Context,
+	store db.Store,
+	alerts alert.AlertService,
+	userID string,
+	notificationID int64,
+) (models.Alert, error) {
+	settings, err := alerts.GetSettings(ctx, userID)
+	if err != nil {
+		return models.Alert{}, err
+	}
+	match, err := NewRuleMatcher(store).MatchAlert(ctx, userID, notificationID, settings)
+	if err != nil {
+		return models.Alert{}, err
+	}
+	return alerts.Record(ctx, userID, notificationID, match)
+}
+
+// NotifyNativeWithDB shows a native OS notification for a newly arrived notification when
+// its recorded alert reached the desktop, native notifications are on and it's an unread
+// inbox notification matching their query. Reports whether a notification was shown.
+func NotifyNativeWithDB(
+	ctx context.Context,
+	store db.Store,
+	alerts alert.AlertService,
+	notifier osactions.Notifier,
+	userID string,
+	notificationID int64,
+	recorded models.Alert,
+) (bool, error) {
+	// Levels, quiet hours and do-not-disturb have already been applied to the recorded alert
+	if recorded.Level != models.AlertLevelDesktop {
+		return false, nil
+	}
+	settings, err := alerts.GetSettings(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.Native.Enabled {
+		return false, nil
+	}
+
+	queryStr := nativeNotificationQuery
+	if settings.Native.Query != "" {
+		queryStr = fmt.Sprintf("%s (%s)", nativeNotificationQuery, settings.Native.Query)
+	}
+	matched, err := NewRuleMatcher(store).checkQueryMatch(ctx, userID, notificationID, queryStr)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	notification, err := store.GetNotificationByID(ctx, userID, notificationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get notification: %w", err)
+	}
+	native := osactions.Notification{
+		Title: notification.SubjectTitle,
+		Sound: recorded.Sound,
+	}
+	// The repository only makes the notification easier to place, so it's best-effort
+	if repo, err := store.GetRepositoryByID(ctx, userID, notification.RepositoryID); err == nil {
+		native.Subtitle = repo.FullName
+	}
+	if notification.Reason.Valid {
+		native.Body = strings.ReplaceAll(notification.Reason.String, "_", " ")
+	}
+	if err := notifier.ShowNotification(ctx, native); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -23,6 +23,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query"
@@ -32,6 +33,7 @@ import (
 type ApplyRuleHandler struct {
 	store   db.Store
 	matcher *RuleMatcher
+	history history.HistoryService
 	logger  *zap.Logger
 }
 
@@ -44,6 +46,12 @@ func NewApplyRuleHandler(store db.Store, logger *zap.Logger) *ApplyRuleHandler {
 	}
 }
 
+// WithHistory records the rule in the history of each notification it's applied to.
+func (h *ApplyRuleHandler) WithHistory(history history.HistoryService) *ApplyRuleHandler {
+	h.history = history
+	return h
+}
+
 // Handle applies a rule to all matching notifications.
 func (h *ApplyRuleHandler) Handle(ctx context.Context, userID, ruleID string) error {
 	// Fetch the rule
@@ -106,6 +114,14 @@ func (h *ApplyRuleHandler) Handle(ctx context.Context, userID, ruleID string) er
 
 		// Apply rule actions to each notification
 		for _, notification := range result.Notifications {
+			if rule.Condition.Valid {
+				satisfied, err := h.matcher.checkCondition(ctx, userID, notification, rule.Condition.String)
+				if err != nil || !satisfied {
+					// Count it as processed so the loop still ends once every match is seen
+					totalProcessed++
+					continue
+				}
+			}
 			// Parse and apply actions
 			var actions models.RuleActions
 			if len(rule.Actions) > 0 {
@@ -118,6 +134,10 @@ func (h *ApplyRuleHandler) Handle(ctx context.Context, userID, ruleID string) er
 				// Continue processing other notifications even if one fails
 				continue
 			}
+			if h.history != nil {
+				h.history.Record(ctx, userID, []string{notification.GithubID},
+					models.HistoryRuleApplied, models.HistorySourceRule, rule.Name)
+			}
 			totalProcessed++
 		}
 
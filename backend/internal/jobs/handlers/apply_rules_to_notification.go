@@ -21,13 +21,15 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 )
 
 // ApplyRulesToNotificationHandler handles applying all enabled rules to a single notification.
 type ApplyRulesToNotificationHandler struct {
-	store  db.Store
-	logger *zap.Logger
+	store   db.Store
+	history history.HistoryService
+	logger  *zap.Logger
 }
 
 // NewApplyRulesToNotificationHandler creates a new ApplyRulesToNotificationHandler.
@@ -41,6 +43,14 @@ func NewApplyRulesToNotificationHandler(
 	}
 }
 
+// WithHistory records the rules applied in the notification's history.
+func (h *ApplyRulesToNotificationHandler) WithHistory(
+	history history.HistoryService,
+) *ApplyRulesToNotificationHandler {
+	h.history = history
+	return h
+}
+
 // ApplyRulesToNotificationJobPayload represents the payload for the job.
 type ApplyRulesToNotificationJobPayload struct {
 	UserID   string `json:"userID"`
@@ -79,7 +89,7 @@ func (h *ApplyRulesToNotificationHandler) Handle(
 	}
 
 	// Apply all enabled rules to this notification
-	matcher := NewRuleMatcher(h.store)
+	matcher := NewRuleMatcher(h.store).WithHistory(h.history)
 	_, matchErr := matcher.MatchAndApplyRules(ctx, actualUserID, notification.ID)
 	if matchErr != nil {
 		// Log the error but don't fail the job - rule application is best-effort
@@ -46,20 +46,20 @@ func NewCheckUpdatesHandler(
 }
 
 // Handle checks for updates based on user settings.
-// Returns true if an update is available, false otherwise.
-// If update checking is disabled or should be skipped, returns false without error.
-func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
+// Returns the available update, or nil if there isn't one.
+// If update checking is disabled or should be skipped, returns nil without error.
+func (h *CheckUpdatesHandler) Handle(ctx context.Context) (*update.Info, error) {
 	// Get user's update settings
 	settings, err := h.authSvc.GetUserUpdateSettings(ctx)
 	if err != nil {
 		h.logger.Warn("failed to get update settings", zap.Error(err))
-		return false, nil // Don't fail the job, just skip
+		return nil, nil // Don't fail the job, just skip
 	}
 
 	// If updates are disabled, skip
 	if !settings.Enabled {
 		h.logger.Debug("update checking is disabled")
-		return false, nil
+		return nil, nil
 	}
 
 	// Check if we should skip based on dismissed until
@@ -70,7 +70,7 @@ func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
 				"update check dismissed until",
 				zap.String("until", settings.DismissedUntil),
 			)
-			return false, nil
+			return nil, nil
 		}
 	}
 
@@ -86,9 +86,9 @@ func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
 				minInterval = 7 * 24 * time.Hour
 			case "on_startup":
 				// Only check on startup, not periodically
-				return false, nil
+				return nil, nil
 			case "never":
-				return false, nil
+				return nil, nil
 			default:
 				minInterval = 24 * time.Hour // Default to daily
 			}
@@ -98,7 +98,7 @@ func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
 					zap.String("frequency", settings.CheckFrequency),
 					zap.Time("lastChecked", lastChecked),
 				)
-				return false, nil
+				return nil, nil
 			}
 		}
 	}
@@ -107,7 +107,7 @@ func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
 	info, err := h.updateService.CheckForUpdates(ctx, settings.IncludePrereleases)
 	if err != nil {
 		h.logger.Warn("failed to check for updates", zap.Error(err))
-		return false, nil // Don't fail the job, just log and skip
+		return nil, nil // Don't fail the job, just log and skip
 	}
 
 	// Update last checked time
@@ -121,9 +121,9 @@ func (h *CheckUpdatesHandler) Handle(ctx context.Context) (bool, error) {
 			zap.String("current", info.CurrentVersion),
 			zap.String("latest", info.LatestVersion),
 		)
-		return true, nil
+		return info, nil
 	}
 
 	h.logger.Debug("no update available")
-	return false, nil
+	return nil, nil
 }
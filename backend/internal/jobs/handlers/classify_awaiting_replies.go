@@ -0,0 +1,98 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ClassifyAwaitingRepliesHandler periodically marks threads where the user's own comment
+// is the latest activity, and has gone unanswered for the configured number of days, as
+// awaiting a reply
+type ClassifyAwaitingRepliesHandler struct {
+	store  db.Store
+	logger *zap.Logger
+}
+
+// NewClassifyAwaitingRepliesHandler creates a new ClassifyAwaitingRepliesHandler
+func NewClassifyAwaitingRepliesHandler(
+	store db.Store,
+	logger *zap.Logger,
+) *ClassifyAwaitingRepliesHandler {
+	return &ClassifyAwaitingRepliesHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// AwaitingReplyResult contains the results of a classification run
+type AwaitingReplyResult struct {
+	Classified []string // GitHub IDs of notifications newly awaiting a reply
+	Skipped    bool     // True if classification was skipped (not configured)
+	SkipReason string   // Reason for skipping
+}
+
+// Handle classifies unanswered threads based on the user's sync settings. A reply from
+// anyone else clears the classification on the next sync of the thread.
+func (h *ClassifyAwaitingRepliesHandler) Handle(
+	ctx context.Context,
+	userID string,
+) (*AwaitingReplyResult, error) {
+	result := &AwaitingReplyResult{}
+
+	user, err := h.store.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	if err != nil {
+		h.logger.Warn("failed to load sync settings", zap.Error(err))
+		result.Skipped = true
+		result.SkipReason = "invalid sync settings"
+		return result, nil
+	}
+
+	if settings == nil || settings.AwaitingReplyDays <= 0 {
+		result.Skipped = true
+		result.SkipReason = "awaiting reply days not set"
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	classified, err := h.store.ClassifyAwaitingReplies(ctx, userID, db.AwaitingReplyParams{
+		CutoffDate:   db.FormatTimestamp(now.AddDate(0, 0, -settings.AwaitingReplyDays)),
+		ClassifiedAt: db.FormatTimestamp(now),
+		Resurface:    settings.ResurfaceAwaitingReplies,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Classified = classified
+
+	if len(classified) > 0 {
+		h.logger.Info("classified threads awaiting a reply",
+			zap.Int("count", len(classified)),
+			zap.Int("afterDays", settings.AwaitingReplyDays),
+			zap.Bool("resurfaced", settings.ResurfaceAwaitingReplies))
+	}
+
+	return result, nil
+}
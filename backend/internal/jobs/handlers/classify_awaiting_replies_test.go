@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func syncSettingsUser(t *testing.T, settings models.SyncSettings) db.User {
+	t.Helper()
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	return db.User{
+		ID:           1,
+		SyncSettings: db.NullRawMessage{RawMessage: data, Valid: true},
+	}
+}
+
+func TestClassifyAwaitingRepliesHandler_Handle_SkipsWhenNotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewClassifyAwaitingRepliesHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).
+		Return(syncSettingsUser(t, models.SyncSettings{SetupCompleted: true}), nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+	require.Equal(t, "awaiting reply days not set", result.SkipReason)
+}
+
+func TestClassifyAwaitingRepliesHandler_Handle_Classifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewClassifyAwaitingRepliesHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(syncSettingsUser(t, models.SyncSettings{
+		AwaitingReplyDays:        3,
+		ResurfaceAwaitingReplies: true,
+	}), nil)
+	mockStore.EXPECT().ClassifyAwaitingReplies(gomock.Any(), "test-user-id", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, params db.AwaitingReplyParams) ([]string, error) {
+			require.True(t, params.Resurface)
+			cutoff, err := time.Parse(time.RFC3339, params.CutoffDate)
+			require.NoError(t, err)
+			classifiedAt, err := time.Parse(time.RFC3339, params.ClassifiedAt)
+			require.NoError(t, err)
+			require.Equal(t, classifiedAt.AddDate(0, 0, -3), cutoff)
+			return []string{"thread-1", "thread-2"}, nil
+		})
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.False(t, result.Skipped)
+	require.Equal(t, []string{"thread-1", "thread-2"}, result.Classified)
+}
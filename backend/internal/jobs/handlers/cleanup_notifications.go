@@ -17,8 +17,10 @@ package handlers
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
@@ -34,6 +36,9 @@ const CleanupBatchSize = 100
 type CleanupNotificationsHandler struct {
 	store  db.Store
 	logger *zap.Logger
+	// approvalKey signs cleanup approval tokens. It only lives as long as the process, so
+	// restarting invalidates outstanding previews.
+	approvalKey []byte
 }
 
 // NewCleanupNotificationsHandler creates a new CleanupNotificationsHandler
@@ -41,9 +46,14 @@ func NewCleanupNotificationsHandler(
 	store db.Store,
 	logger *zap.Logger,
 ) *CleanupNotificationsHandler {
+	approvalKey := make([]byte, 32)
+	if _, err := rand.Read(approvalKey); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
 	return &CleanupNotificationsHandler{
-		store:  store,
-		logger: logger,
+		store:       store,
+		logger:      logger,
+		approvalKey: approvalKey,
 	}
 }
 
@@ -100,7 +110,7 @@ func (h *CleanupNotificationsHandler) Handle(
 
 	// Calculate cutoff date
 	cutoffDate := time.Now().UTC().AddDate(0, 0, -settings.RetentionDays)
-	cutoffStr := cutoffDate.Format(time.RFC3339)
+	cutoffStr := db.FormatTimestamp(cutoffDate)
 
 	h.logger.Info("starting notification cleanup",
 		zap.String("userID", userID),
@@ -180,7 +190,7 @@ func (h *CleanupNotificationsHandler) RunManualCleanup(
 
 	// Calculate cutoff date
 	cutoffDate := time.Now().UTC().AddDate(0, 0, -retentionDays)
-	cutoffStr := cutoffDate.Format(time.RFC3339)
+	cutoffStr := db.FormatTimestamp(cutoffDate)
 
 	h.logger.Info("starting manual notification cleanup",
 		zap.String("userID", userID),
@@ -278,7 +288,7 @@ func (h *CleanupNotificationsHandler) CountEligibleForCleanup(
 	}
 
 	cutoffDate := time.Now().UTC().AddDate(0, 0, -retentionDays)
-	cutoffStr := cutoffDate.Format(time.RFC3339)
+	cutoffStr := db.FormatTimestamp(cutoffDate)
 
 	return h.store.CountEligibleForCleanup(ctx, userID, db.CleanupParams{
 		ProtectStarred: protectStarred,
@@ -0,0 +1,322 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// CleanupApprovalTTL is how long an approval token from a cleanup preview stays valid
+const CleanupApprovalTTL = 15 * time.Minute
+
+// Protection reasons reported for cleanup candidates that will be skipped
+const (
+	ProtectionReasonStarred = "starred"
+	ProtectionReasonTagged  = "tagged"
+)
+
+// Error definitions
+var (
+	ErrInvalidApprovalToken = errors.New("invalid cleanup approval token")
+	ErrApprovalTokenExpired = errors.New("cleanup approval token expired")
+	ErrCleanupPreviewStale  = errors.New("cleanup preview no longer matches eligible notifications")
+)
+
+// CleanupPreviewOptions controls a dry-run cleanup preview
+type CleanupPreviewOptions struct {
+	RetentionDays  int
+	ProtectStarred bool
+	ProtectTagged  bool
+	Page           int
+	PageSize       int
+}
+
+// CleanupPreviewItem is a single notification considered by a cleanup preview.
+// ProtectionReasons is empty when the item would be deleted.
+type CleanupPreviewItem struct {
+	ID                int64
+	GithubID          string
+	SubjectTitle      string
+	SubjectType       string
+	RepoFullName      string
+	Archived          bool
+	Muted             bool
+	EffectiveSortDate time.Time
+	Eligible          bool
+	ProtectionReasons []string
+}
+
+// CleanupPreview is the result of a dry-run cleanup
+type CleanupPreview struct {
+	Items          []CleanupPreviewItem
+	CandidateCount int64 // archived/muted items older than the cutoff, including protected ones
+	EligibleCount  int64 // items that would actually be deleted
+	CutoffDate     time.Time
+	Page           int
+	PageSize       int
+	ApprovalToken  string
+	ExpiresAt      time.Time
+}
+
+// cleanupApproval is the payload encoded into an approval token. It pins the exact
+// cutoff and protection settings of the preview, plus a digest of the eligible IDs,
+// so confirming the token deletes precisely what the user was shown. Tokens are signed,
+// so none of this can be changed by the client.
+type cleanupApproval struct {
+	UserID         string `json:"userId"`
+	RetentionDays  int    `json:"retentionDays"`
+	ProtectStarred bool   `json:"protectStarred"`
+	ProtectTagged  bool   `json:"protectTagged"`
+	CutoffDate     string `json:"cutoffDate"`
+	EligibleCount  int64  `json:"eligibleCount"`
+	Digest         string `json:"digest"`
+	ExpiresAt      string `json:"expiresAt"`
+}
+
+// PreviewCleanup lists the notifications a cleanup would consider without deleting anything.
+// Protected items are included with the reasons they would be skipped. The returned
+// approval token must be passed to RunApprovedCleanup to perform the deletion.
+func (h *CleanupNotificationsHandler) PreviewCleanup(
+	ctx context.Context,
+	userID string,
+	opts CleanupPreviewOptions,
+) (*CleanupPreview, error) {
+	page, pageSize := opts.Page, opts.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	now := time.Now().UTC()
+	cutoffDate := now.AddDate(0, 0, -opts.RetentionDays)
+	cutoffStr := db.FormatTimestamp(cutoffDate)
+
+	candidates, err := h.store.ListCleanupCandidates(ctx, userID, db.CleanupCandidatesParams{
+		CutoffDate: cutoffStr,
+		Limit:      int64(pageSize),
+		Offset:     int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidateCount, err := h.store.CountCleanupCandidates(ctx, userID, cutoffStr)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.CleanupParams{
+		ProtectStarred: opts.ProtectStarred,
+		ProtectTagged:  opts.ProtectTagged,
+		CutoffDate:     cutoffStr,
+	}
+	eligibleIDs, err := h.store.ListEligibleForCleanupIDs(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]CleanupPreviewItem, 0, len(candidates))
+	for _, c := range candidates {
+		reasons := protectionReasons(c, opts.ProtectStarred, opts.ProtectTagged)
+		items = append(items, CleanupPreviewItem{
+			ID:                c.ID,
+			GithubID:          c.GithubID,
+			SubjectTitle:      c.SubjectTitle,
+			SubjectType:       c.SubjectType,
+			RepoFullName:      c.RepoFullName,
+			Archived:          c.Archived,
+			Muted:             c.Muted,
+			EffectiveSortDate: c.EffectiveSortDate,
+			Eligible:          len(reasons) == 0,
+			ProtectionReasons: reasons,
+		})
+	}
+
+	expiresAt := now.Add(CleanupApprovalTTL)
+	token, err := h.encodeCleanupApproval(cleanupApproval{
+		UserID:         userID,
+		RetentionDays:  opts.RetentionDays,
+		ProtectStarred: opts.ProtectStarred,
+		ProtectTagged:  opts.ProtectTagged,
+		CutoffDate:     cutoffStr,
+		EligibleCount:  int64(len(eligibleIDs)),
+		Digest:         digestIDs(eligibleIDs),
+		ExpiresAt:      expiresAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CleanupPreview{
+		Items:          items,
+		CandidateCount: candidateCount,
+		EligibleCount:  int64(len(eligibleIDs)),
+		CutoffDate:     cutoffDate,
+		Page:           page,
+		PageSize:       pageSize,
+		ApprovalToken:  token,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+// RunApprovedCleanup deletes the notifications shown by a previous PreviewCleanup call.
+// It fails with ErrCleanupPreviewStale if the eligible set changed since the preview
+// (for example a notification was unarchived or a new one aged past the cutoff).
+func (h *CleanupNotificationsHandler) RunApprovedCleanup(
+	ctx context.Context,
+	userID string,
+	token string,
+) (*CleanupResult, error) {
+	approval, err := h.decodeCleanupApproval(token)
+	if err != nil {
+		return nil, err
+	}
+	if approval.UserID != userID {
+		return nil, ErrInvalidApprovalToken
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, approval.ExpiresAt)
+	if err != nil {
+		return nil, ErrInvalidApprovalToken
+	}
+	if time.Now().After(expiresAt) {
+		return nil, ErrApprovalTokenExpired
+	}
+
+	params := db.CleanupParams{
+		ProtectStarred: approval.ProtectStarred,
+		ProtectTagged:  approval.ProtectTagged,
+		CutoffDate:     approval.CutoffDate,
+	}
+
+	h.logger.Info("starting approved notification cleanup",
+		zap.String("userID", userID),
+		zap.Int("retentionDays", approval.RetentionDays),
+		zap.String("cutoffDate", approval.CutoffDate),
+		zap.Int64("eligibleCount", approval.EligibleCount))
+
+	// The eligible set is checked and deleted in one transaction, so nothing can change
+	// between the check and the delete
+	deleted, err := h.store.DeleteApprovedCleanup(ctx, userID, params, func(ids []int64) error {
+		if digestIDs(ids) != approval.Digest {
+			return ErrCleanupPreviewStale
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &CleanupResult{NotificationsDeleted: deleted}
+
+	prDeleted, err := h.store.DeleteOrphanedPullRequests(ctx, userID)
+	if err != nil {
+		h.logger.Warn("failed to delete orphaned pull requests", zap.Error(err))
+	} else {
+		result.PullRequestsDeleted = prDeleted
+	}
+
+	h.logger.Info("approved cleanup completed",
+		zap.Int64("notificationsDeleted", result.NotificationsDeleted),
+		zap.Int64("pullRequestsDeleted", result.PullRequestsDeleted))
+
+	return result, nil
+}
+
+// protectionReasons returns why a candidate would be skipped, or nil if it would be deleted
+func protectionReasons(c db.CleanupCandidate, protectStarred, protectTagged bool) []string {
+	var reasons []string
+	if protectStarred && c.Starred {
+		reasons = append(reasons, ProtectionReasonStarred)
+	}
+	if protectTagged && c.Tagged {
+		reasons = append(reasons, ProtectionReasonTagged)
+	}
+	return reasons
+}
+
+// digestIDs returns a stable fingerprint of a sorted ID list
+func digestIDs(ids []int64) string {
+	hash := sha256.New()
+	buf := make([]byte, 8)
+	for _, id := range ids {
+		binary.BigEndian.PutUint64(buf, uint64(id))
+		hash.Write(buf)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// encodeCleanupApproval encodes an approval as a token signed with the handler's key
+func (h *CleanupNotificationsHandler) encodeCleanupApproval(
+	approval cleanupApproval,
+) (string, error) {
+	data, err := json.Marshal(approval)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(h.signApproval(payload)), nil
+}
+
+// decodeCleanupApproval decodes a token from encodeCleanupApproval, rejecting tokens whose
+// signature doesn't match
+func (h *CleanupNotificationsHandler) decodeCleanupApproval(
+	token string,
+) (cleanupApproval, error) {
+	var approval cleanupApproval
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return approval, ErrInvalidApprovalToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, h.signApproval(payload)) {
+		return approval, ErrInvalidApprovalToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return approval, ErrInvalidApprovalToken
+	}
+	if err := json.Unmarshal(data, &approval); err != nil {
+		return approval, ErrInvalidApprovalToken
+	}
+	if approval.CutoffDate == "" || approval.Digest == "" {
+		return approval, ErrInvalidApprovalToken
+	}
+	return approval, nil
+}
+
+func (h *CleanupNotificationsHandler) signApproval(payload string) []byte {
+	mac := hmac.New(sha256.New, h.approvalKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func TestCleanupNotificationsHandler_PreviewCleanup_ReportsProtectionReasons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewCleanupNotificationsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().
+		ListCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.CleanupCandidatesParams,
+		) ([]db.CleanupCandidate, error) {
+			require.Equal(t, int64(10), params.Limit)
+			require.Equal(t, int64(10), params.Offset)
+			return []db.CleanupCandidate{
+				{ID: 1, GithubID: "n1", Archived: true},
+				{ID: 2, GithubID: "n2", Archived: true, Starred: true},
+				{ID: 3, GithubID: "n3", Muted: true, Starred: true, Tagged: true},
+			}, nil
+		})
+	mockStore.EXPECT().
+		CountCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(13), nil)
+	mockStore.EXPECT().
+		ListEligibleForCleanupIDs(gomock.Any(), "test-user-id", gomock.Any()).
+		Return([]int64{1}, nil)
+
+	preview, err := handler.PreviewCleanup(
+		context.Background(),
+		"test-user-id",
+		handlers.CleanupPreviewOptions{
+			RetentionDays:  30,
+			ProtectStarred: true,
+			ProtectTagged:  true,
+			Page:           2,
+			PageSize:       10,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, preview.Items, 3)
+	require.Equal(t, int64(13), preview.CandidateCount)
+	require.Equal(t, int64(1), preview.EligibleCount)
+	require.NotEmpty(t, preview.ApprovalToken)
+
+	require.True(t, preview.Items[0].Eligible)
+	require.Empty(t, preview.Items[0].ProtectionReasons)
+	require.False(t, preview.Items[1].Eligible)
+	require.Equal(t, []string{handlers.ProtectionReasonStarred}, preview.Items[1].ProtectionReasons)
+	require.Equal(
+		t,
+		[]string{handlers.ProtectionReasonStarred, handlers.ProtectionReasonTagged},
+		preview.Items[2].ProtectionReasons,
+	)
+}
+
+func TestCleanupNotificationsHandler_RunApprovedCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewCleanupNotificationsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().
+		ListCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().
+		CountCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(2), nil)
+	mockStore.EXPECT().
+		ListEligibleForCleanupIDs(gomock.Any(), "test-user-id", gomock.Any()).
+		Return([]int64{4, 7}, nil)
+
+	preview, err := handler.PreviewCleanup(
+		context.Background(),
+		"test-user-id",
+		handlers.CleanupPreviewOptions{
+			RetentionDays: 30,
+		},
+	)
+	require.NoError(t, err)
+
+	// Confirmation re-checks the eligible set using the preview's pinned cutoff
+	mockStore.EXPECT().
+		DeleteApprovedCleanup(gomock.Any(), "test-user-id", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.CleanupParams,
+			approve func([]int64) error,
+		) (int64, error) {
+			require.Equal(t, preview.CutoffDate.Format(time.RFC3339), params.CutoffDate)
+			require.NoError(t, approve([]int64{4, 7}))
+			return 2, nil
+		})
+	mockStore.EXPECT().
+		DeleteOrphanedPullRequests(gomock.Any(), "test-user-id").
+		Return(int64(1), nil)
+
+	result, err := handler.RunApprovedCleanup(
+		context.Background(),
+		"test-user-id",
+		preview.ApprovalToken,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.NotificationsDeleted)
+	require.Equal(t, int64(1), result.PullRequestsDeleted)
+}
+
+func TestCleanupNotificationsHandler_RunApprovedCleanup_StalePreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewCleanupNotificationsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().
+		ListCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().
+		CountCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(2), nil)
+	mockStore.EXPECT().
+		ListEligibleForCleanupIDs(gomock.Any(), "test-user-id", gomock.Any()).
+		Return([]int64{4, 7}, nil)
+
+	preview, err := handler.PreviewCleanup(
+		context.Background(),
+		"test-user-id",
+		handlers.CleanupPreviewOptions{
+			RetentionDays: 30,
+		},
+	)
+	require.NoError(t, err)
+
+	// Notification 7 was unarchived after the preview, so nothing may be deleted
+	mockStore.EXPECT().
+		DeleteApprovedCleanup(gomock.Any(), "test-user-id", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			_ db.CleanupParams,
+			approve func([]int64) error,
+		) (int64, error) {
+			return 0, approve([]int64{4})
+		})
+
+	_, err = handler.RunApprovedCleanup(
+		context.Background(),
+		"test-user-id",
+		preview.ApprovalToken,
+	)
+	require.ErrorIs(t, err, handlers.ErrCleanupPreviewStale)
+}
+
+func TestCleanupNotificationsHandler_RunApprovedCleanup_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewCleanupNotificationsHandler(mockStore, zap.NewNop())
+
+	_, err := handler.RunApprovedCleanup(context.Background(), "test-user-id", "not-a-token")
+	require.ErrorIs(t, err, handlers.ErrInvalidApprovalToken)
+}
+
+func TestCleanupNotificationsHandler_RunApprovedCleanup_TamperedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewCleanupNotificationsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().
+		ListCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().
+		CountCleanupCandidates(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(0), nil)
+	mockStore.EXPECT().
+		ListEligibleForCleanupIDs(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, nil)
+
+	preview, err := handler.PreviewCleanup(
+		context.Background(),
+		"test-user-id",
+		handlers.CleanupPreviewOptions{
+			RetentionDays: 30,
+		},
+	)
+	require.NoError(t, err)
+
+	// Pushing the expiry out invalidates the signature
+	payload, signature, ok := strings.Cut(preview.ApprovalToken, ".")
+	require.True(t, ok)
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	var approval map[string]any
+	require.NoError(t, json.Unmarshal(data, &approval))
+	approval["expiresAt"] = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	data, err = json.Marshal(approval)
+	require.NoError(t, err)
+	tampered := base64.RawURLEncoding.EncodeToString(data) + "." + signature
+
+	_, err = handler.RunApprovedCleanup(context.Background(), "test-user-id", tampered)
+	require.ErrorIs(t, err, handlers.ErrInvalidApprovalToken)
+
+	// Tokens are also bound to the user who previewed
+	_, err = handler.RunApprovedCleanup(context.Background(), "other-user-id", preview.ApprovalToken)
+	require.ErrorIs(t, err, handlers.ErrInvalidApprovalToken)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+// EnforceWIPLimitsHandler checks views with a WIP limit after each sync. Views holding more
+// notifications than their limit are reported, and views that opt in have the overflow
+// snoozed until its default snooze time.
+type EnforceWIPLimitsHandler struct {
+	store    db.Store
+	snoozes  snooze.SnoozeService
+	notifier sysnotify.SystemNotifier
+	history  history.HistoryService
+	logger   *zap.Logger
+}
+
+// NewEnforceWIPLimitsHandler creates a new EnforceWIPLimitsHandler. notifier may be nil.
+func NewEnforceWIPLimitsHandler(
+	store db.Store,
+	snoozes snooze.SnoozeService,
+	notifier sysnotify.SystemNotifier,
+	logger *zap.Logger,
+) *EnforceWIPLimitsHandler {
+	return &EnforceWIPLimitsHandler{
+		store:    store,
+		snoozes:  snoozes,
+		notifier: notifier,
+		logger:   logger,
+	}
+}
+
+// WithHistory records overflow snoozes in each notification's history, naming the view
+func (h *EnforceWIPLimitsHandler) WithHistory(history history.HistoryService) *EnforceWIPLimitsHandler {
+	h.history = history
+	return h
+}
+
+// WIPLimitsResult contains the results of a WIP limit check
+type WIPLimitsResult struct {
+	OverLimit []string // IDs of views over their limit
+	Snoozed   []string // GitHub IDs of notifications snoozed as overflow
+}
+
+// Handle checks each view with a WIP limit. A view that fails to be checked is logged and
+// skipped so one bad query doesn't stop the others.
+func (h *EnforceWIPLimitsHandler) Handle(ctx context.Context, userID string) (*WIPLimitsResult, error) {
+	views, err := h.store.ListViews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WIPLimitsResult{}
+	for _, v := range views {
+		if !v.WIPLimit.Valid || !v.Query.Valid || v.Query.String == "" {
+			continue
+		}
+		ordered, err := h.byPriority(ctx, userID, v.Query.String)
+		if err != nil {
+			h.logger.Warn("failed to check view WIP limit",
+				zap.String("viewID", v.ID),
+				zap.Error(err))
+			continue
+		}
+		count := int64(len(ordered))
+		if count <= v.WIPLimit.Int64 {
+			continue
+		}
+		result.OverLimit = append(result.OverLimit, v.ID)
+
+		var snoozed []string
+		if v.WIPAutoSnooze {
+			snoozed = h.snoozeOverflow(ctx, userID, ordered[v.WIPLimit.Int64:])
+			result.Snoozed = append(result.Snoozed, snoozed...)
+			if h.history != nil {
+				h.history.Record(ctx, userID, snoozed, models.HistorySnoozed, models.HistorySourceSystem, v.Name)
+			}
+		}
+
+		if h.notifier != nil {
+			if _, err := h.notifier.ViewOverLimit(ctx, userID, v, count, len(snoozed)); err != nil {
+				h.logger.Warn("failed to report view over its WIP limit",
+					zap.String("viewID", v.ID),
+					zap.Error(err))
+			}
+		}
+	}
+
+	if len(result.Snoozed) > 0 {
+		h.logger.Info("snoozed notifications over view WIP limits",
+			zap.Int("count", len(result.Snoozed)))
+	}
+	return result, nil
+}
+
+// byPriority lists the GitHub IDs held against a view's WIP limit, highest priority first:
+// starred notifications, then the rest oldest first, so the newest arrivals overflow
+func (h *EnforceWIPLimitsHandler) byPriority(
+	ctx context.Context,
+	userID, viewQuery string,
+) ([]string, error) {
+	wipQuery := view.WIPQuery(viewQuery)
+	all, err := h.githubIDs(ctx, userID, wipQuery)
+	if err != nil {
+		return nil, err
+	}
+	starred, err := h.githubIDs(ctx, userID, wipQuery+" is:starred")
+	if err != nil {
+		return nil, err
+	}
+
+	// Both lists are newest first
+	ordered := make([]string, 0, len(all))
+	ordered = append(ordered, starred...)
+	for _, githubID := range slices.Backward(all) {
+		if !slices.Contains(starred, githubID) {
+			ordered = append(ordered, githubID)
+		}
+	}
+	return ordered, nil
+}
+
+func (h *EnforceWIPLimitsHandler) githubIDs(
+	ctx context.Context,
+	userID, queryStr string,
+) ([]string, error) {
+	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return h.store.ListNotificationGithubIDsFromQuery(ctx, userID, dbQuery)
+}
+
+// snoozeOverflow snoozes each notification until its default snooze time and returns the
+// ones that were snoozed
+func (h *EnforceWIPLimitsHandler) snoozeOverflow(
+	ctx context.Context,
+	userID string,
+	githubIDs []string,
+) []string {
+	snoozed := make([]string, 0, len(githubIDs))
+	for _, githubID := range githubIDs {
+		notification, err := h.store.GetNotificationByGithubID(ctx, userID, githubID)
+		if err != nil {
+			h.logger.Warn("failed to get overflow notification",
+				zap.String("githubID", githubID),
+				zap.Error(err))
+			continue
+		}
+		until, err := h.snoozes.DefaultUntil(ctx, userID, notification)
+		if err != nil {
+			h.logger.Warn("failed to resolve snooze for overflow notification",
+				zap.String("githubID", githubID),
+				zap.Error(err))
+			continue
+		}
+		_, err = h.store.SnoozeNotification(ctx, userID, db.SnoozeNotificationParams{
+			GithubID:     githubID,
+			SnoozedUntil: sql.NullTime{Time: until, Valid: true},
+		})
+		if err != nil {
+			h.logger.Warn("failed to snooze overflow notification",
+				zap.String("githubID", githubID),
+				zap.Error(err))
+			continue
+		}
+		snoozed = append(snoozed, githubID)
+	}
+	return snoozed
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	snoozemocks "github.com/octobud-hq/octobud/backend/internal/core/snooze/mocks"
+	sysnotifymocks "github.com/octobud-hq/octobud/backend/internal/core/sysnotify/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func TestEnforceWIPLimitsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockStore(ctrl)
+	snoozes := snoozemocks.NewMockSnoozeService(ctrl)
+	notifier := sysnotifymocks.NewMockSystemNotifier(ctrl)
+
+	reviews := db.View{
+		ID:            "reviews",
+		Name:          "Review queue",
+		Query:         sql.NullString{String: "reason:review_requested", Valid: true},
+		WIPLimit:      sql.NullInt64{Int64: 3, Valid: true},
+		WIPAutoSnooze: true,
+	}
+	mentions := db.View{
+		ID:       "mentions",
+		Name:     "Mentions",
+		Query:    sql.NullString{String: "reason:mention", Valid: true},
+		WIPLimit: sql.NullInt64{Int64: 1, Valid: true},
+	}
+	unlimited := db.View{
+		ID:    "everything-else",
+		Query: sql.NullString{String: "repo:cli/cli", Valid: true},
+	}
+	store.EXPECT().ListViews(gomock.Any(), "user-1").Return([]db.View{reviews, mentions, unlimited}, nil)
+
+	// Newest first, as the store lists them
+	held := map[string][]string{
+		"review_requested": {"n5", "n4", "n3", "n2", "n1"},
+		"mention":          {"m2", "m1"},
+	}
+	starred := map[string][]string{"review_requested": {"n4"}}
+	store.EXPECT().
+		ListNotificationGithubIDsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, q db.NotificationQuery) ([]string, error) {
+			reason := "mention"
+			if slices.Contains(q.Args, any("%review_requested%")) {
+				reason = "review_requested"
+			}
+			if strings.Contains(strings.Join(q.Where, " "), "n.starred = 1") {
+				return starred[reason], nil
+			}
+			return held[reason], nil
+		}).
+		Times(4)
+
+	// Starred n4 and the oldest n1 and n2 stay; the newest arrivals overflow
+	until := time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC)
+	for _, githubID := range []string{"n3", "n5"} {
+		notification := db.Notification{GithubID: githubID}
+		store.EXPECT().
+			GetNotificationByGithubID(gomock.Any(), "user-1", githubID).
+			Return(notification, nil)
+		snoozes.EXPECT().DefaultUntil(gomock.Any(), "user-1", notification).Return(until, nil)
+		store.EXPECT().
+			SnoozeNotification(gomock.Any(), "user-1", db.SnoozeNotificationParams{
+				GithubID:     githubID,
+				SnoozedUntil: sql.NullTime{Time: until, Valid: true},
+			}).
+			Return(notification, nil)
+	}
+
+	notifier.EXPECT().ViewOverLimit(gomock.Any(), "user-1", reviews, int64(5), 2).Return(true, nil)
+	notifier.EXPECT().ViewOverLimit(gomock.Any(), "user-1", mentions, int64(2), 0).Return(true, nil)
+
+	result, err := handlers.NewEnforceWIPLimitsHandler(store, snoozes, notifier, zap.NewNop()).
+		Handle(context.Background(), "user-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"reviews", "mentions"}, result.OverLimit)
+	require.Equal(t, []string{"n3", "n5"}, result.Snoozed)
+}
@@ -0,0 +1,255 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// EscalationBatchSize is the number of review requests to escalate per batch
+const EscalationBatchSize = 100
+
+// EscalationTagName is the tag applied to escalated notifications when tagging is enabled
+const EscalationTagName = "escalated"
+
+// EscalateReviewRequestsHandler periodically resurfaces review requests that have sat
+// unread for longer than the user's configured escalation window
+type EscalateReviewRequestsHandler struct {
+	store     db.Store
+	workHours workhours.WorkingHoursService
+	history   history.HistoryService
+	logger    *zap.Logger
+}
+
+// NewEscalateReviewRequestsHandler creates a new EscalateReviewRequestsHandler
+func NewEscalateReviewRequestsHandler(
+	store db.Store,
+	logger *zap.Logger,
+) *EscalateReviewRequestsHandler {
+	return &EscalateReviewRequestsHandler{
+		store:     store,
+		workHours: workhours.NewService(store),
+		logger:    logger,
+	}
+}
+
+// WithHistory records escalations in each notification's history
+func (h *EscalateReviewRequestsHandler) WithHistory(
+	history history.HistoryService,
+) *EscalateReviewRequestsHandler {
+	h.history = history
+	return h
+}
+
+// EscalationResult contains the results of an escalation run
+type EscalationResult struct {
+	Escalated  []string // GitHub IDs of escalated notifications
+	Skipped    bool     // True if escalation was skipped (disabled or not configured)
+	SkipReason string   // Reason for skipping
+}
+
+// Handle escalates stale review requests based on the user's escalation settings.
+// Escalated notifications are unarchived, unsnoozed and given a fresh sort date, which
+// moves them to the top of the inbox and fires a desktop alert on the next poll.
+func (h *EscalateReviewRequestsHandler) Handle(
+	ctx context.Context,
+	userID string,
+) (*EscalationResult, error) {
+	result := &EscalationResult{}
+
+	settings, err := h.GetEscalationSettings(ctx, userID)
+	if err != nil {
+		h.logger.Warn("failed to load escalation settings", zap.Error(err))
+		result.Skipped = true
+		result.SkipReason = "invalid escalation settings"
+		return result, nil
+	}
+
+	if !settings.Enabled {
+		result.Skipped = true
+		result.SkipReason = "escalation disabled"
+		return result, nil
+	}
+
+	if settings.AfterDays <= 0 {
+		result.Skipped = true
+		result.SkipReason = "escalation days not set"
+		return result, nil
+	}
+
+	now := time.Now().UTC()
+	cutoff := now.AddDate(0, 0, -settings.AfterDays)
+	// The window counts business days, so a Friday review request isn't escalated over
+	// the weekend. Without working hours every day is a business day.
+	calendar, err := h.workHours.Calendar(ctx, userID)
+	if err != nil {
+		h.logger.Warn("failed to load working hours, counting calendar days", zap.Error(err))
+	} else {
+		cutoff = calendar.AddBusinessDays(now, -settings.AfterDays).UTC()
+	}
+
+	params := db.EscalationParams{
+		CutoffDate:  db.FormatTimestamp(cutoff),
+		EscalatedAt: db.FormatTimestamp(now),
+		BatchSize:   EscalationBatchSize,
+	}
+
+	var tagID string
+	for {
+		// Escalated rows get a sort date past the cutoff, so each batch picks up new rows
+		batch, err := h.store.EscalateStaleReviewRequests(ctx, userID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if settings.TagEscalated && len(batch) > 0 {
+			if tagID == "" {
+				tagID, err = h.ensureEscalationTag(ctx, userID)
+				if err != nil {
+					// Escalation already happened; don't fail the run over the tag
+					h.logger.Warn("failed to create escalation tag", zap.Error(err))
+				}
+			}
+			if tagID != "" {
+				h.tagEscalated(ctx, userID, tagID, batch)
+			}
+		}
+
+		for _, n := range batch {
+			result.Escalated = append(result.Escalated, n.GithubID)
+		}
+
+		if len(batch) < EscalationBatchSize {
+			break
+		}
+	}
+
+	settings.LastRunAt = now.Format(time.RFC3339)
+	if err := h.UpdateEscalationSettings(ctx, userID, settings); err != nil {
+		h.logger.Warn("failed to update escalation lastRunAt", zap.Error(err))
+		// Don't fail the operation for this
+	}
+
+	if h.history != nil {
+		h.history.Record(ctx, userID, result.Escalated, models.HistoryEscalated, models.HistorySourceSystem, "")
+	}
+
+	if len(result.Escalated) > 0 {
+		h.logger.Info("escalated stale review requests",
+			zap.Int("count", len(result.Escalated)),
+			zap.Int("afterDays", settings.AfterDays))
+	}
+
+	return result, nil
+}
+
+// ensureEscalationTag returns the ID of the escalation tag, creating it if needed
+func (h *EscalateReviewRequestsHandler) ensureEscalationTag(
+	ctx context.Context,
+	userID string,
+) (string, error) {
+	tag, err := h.store.GetTagByName(ctx, userID, EscalationTagName)
+	if err == nil {
+		return tag.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	tag, err = h.store.UpsertTag(ctx, userID, db.UpsertTagParams{
+		Name: EscalationTagName,
+		Slug: models.Slugify(EscalationTagName),
+		Description: sql.NullString{
+			String: "Review requests resurfaced after sitting unread",
+			Valid:  true,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return tag.ID, nil
+}
+
+// tagEscalated assigns the escalation tag to each escalated notification
+func (h *EscalateReviewRequestsHandler) tagEscalated(
+	ctx context.Context,
+	userID, tagID string,
+	escalated []db.EscalatedNotification,
+) {
+	for _, n := range escalated {
+		_, err := h.store.AssignTagToEntity(ctx, userID, db.AssignTagToEntityParams{
+			TagID:      tagID,
+			EntityType: "notification",
+			EntityID:   n.ID,
+		})
+		if err != nil {
+			h.logger.Warn("failed to tag escalated notification",
+				zap.String("githubID", n.GithubID),
+				zap.Error(err))
+		}
+	}
+}
+
+// GetEscalationSettings returns current escalation settings for the user
+func (h *EscalateReviewRequestsHandler) GetEscalationSettings(
+	ctx context.Context,
+	userID string,
+) (*models.EscalationSettings, error) {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	user, err := h.store.GetUser(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultEscalationSettings(), nil
+		}
+		return nil, err
+	}
+
+	if !user.EscalationSettings.Valid {
+		return models.DefaultEscalationSettings(), nil
+	}
+	return models.EscalationSettingsFromJSON(user.EscalationSettings.RawMessage)
+}
+
+// UpdateEscalationSettings updates the user's escalation settings
+func (h *EscalateReviewRequestsHandler) UpdateEscalationSettings(
+	ctx context.Context,
+	userID string,
+	settings *models.EscalationSettings,
+) error {
+	// Note: userID is currently unused as we have single-user mode
+	_ = userID
+	data, err := settings.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = h.store.UpdateUserEscalationSettings(ctx, db.NullRawMessage{
+		RawMessage: data,
+		Valid:      true,
+	})
+	return err
+}
@@ -0,0 +1,265 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func escalationUser(t *testing.T, settings models.EscalationSettings) db.User {
+	t.Helper()
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	return db.User{
+		ID:                 1,
+		EscalationSettings: db.NullRawMessage{RawMessage: data, Valid: true},
+	}
+}
+
+func withWorkingHours(t *testing.T, user db.User, hours models.WorkingHours) db.User {
+	t.Helper()
+	data, err := json.Marshal(hours)
+	require.NoError(t, err)
+	user.WorkingHours = db.NullRawMessage{RawMessage: data, Valid: true}
+	return user
+}
+
+func TestEscalateReviewRequestsHandler_Handle_DisabledByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{ID: 1}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+	require.Equal(t, "escalation disabled", result.SkipReason)
+}
+
+func TestEscalateReviewRequestsHandler_Handle_EscalatesAndTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(escalationUser(t, models.EscalationSettings{
+		Enabled:      true,
+		AfterDays:    3,
+		TagEscalated: true,
+	}), nil).Times(2)
+
+	before := time.Now().UTC().AddDate(0, 0, -3).Add(-time.Second)
+	mockStore.EXPECT().
+		EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.EscalationParams,
+		) ([]db.EscalatedNotification, error) {
+			cutoff, err := time.Parse(time.RFC3339, params.CutoffDate)
+			require.NoError(t, err)
+			require.True(t, cutoff.After(before))
+			require.Equal(t, int64(handlers.EscalationBatchSize), params.BatchSize)
+			return []db.EscalatedNotification{
+				{ID: 10, GithubID: "gh-10"},
+				{ID: 11, GithubID: "gh-11"},
+			}, nil
+		})
+
+	mockStore.EXPECT().
+		GetTagByName(gomock.Any(), "test-user-id", handlers.EscalationTagName).
+		Return(db.Tag{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		UpsertTag(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertTagParams) (db.Tag, error) {
+			require.Equal(t, handlers.EscalationTagName, arg.Name)
+			return db.Tag{ID: "tag-escalated", Name: arg.Name}, nil
+		})
+	for _, id := range []int64{10, 11} {
+		mockStore.EXPECT().
+			AssignTagToEntity(gomock.Any(), "test-user-id", db.AssignTagToEntityParams{
+				TagID:      "tag-escalated",
+				EntityType: "notification",
+				EntityID:   id,
+			}).
+			Return(db.TagAssignment{}, nil)
+	}
+
+	mockStore.EXPECT().
+		UpdateUserEscalationSettings(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, raw db.NullRawMessage) (db.User, error) {
+			settings, err := models.EscalationSettingsFromJSON(raw.RawMessage)
+			require.NoError(t, err)
+			require.NotEmpty(t, settings.LastRunAt)
+			require.True(t, settings.Enabled)
+			return db.User{}, nil
+		})
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.False(t, result.Skipped)
+	require.Equal(t, []string{"gh-10", "gh-11"}, result.Escalated)
+}
+
+func TestEscalateReviewRequestsHandler_Handle_NoTagging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(escalationUser(t, models.EscalationSettings{
+		Enabled:   true,
+		AfterDays: 7,
+	}), nil).Times(2)
+	mockStore.EXPECT().
+		EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+		Return([]db.EscalatedNotification{{ID: 1, GithubID: "gh-1"}}, nil)
+	mockStore.EXPECT().
+		UpdateUserEscalationSettings(gomock.Any(), gomock.Any()).
+		Return(db.User{}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{"gh-1"}, result.Escalated)
+}
+
+func TestEscalateReviewRequestsHandler_Handle_ProcessesFullBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(escalationUser(t, models.EscalationSettings{
+		Enabled:   true,
+		AfterDays: 1,
+	}), nil).Times(2)
+
+	full := make([]db.EscalatedNotification, handlers.EscalationBatchSize)
+	for i := range full {
+		full[i] = db.EscalatedNotification{ID: int64(i), GithubID: "gh"}
+	}
+	gomock.InOrder(
+		mockStore.EXPECT().
+			EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(full, nil),
+		mockStore.EXPECT().
+			EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(nil, nil),
+	)
+	mockStore.EXPECT().
+		UpdateUserEscalationSettings(gomock.Any(), gomock.Any()).
+		Return(db.User{}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Len(t, result.Escalated, handlers.EscalationBatchSize)
+}
+
+func TestEscalateReviewRequestsHandler_Handle_CountsBusinessDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	// Only the weekday two days ago is a working day, so one business day back skips
+	// yesterday and lands two calendar days back
+	twoDaysAgo := time.Now().UTC().AddDate(0, 0, -2)
+	user := withWorkingHours(t, escalationUser(t, models.EscalationSettings{
+		Enabled:   true,
+		AfterDays: 1,
+	}), models.WorkingHours{
+		Enabled:  true,
+		Days:     []int{int(twoDaysAgo.Weekday())},
+		Start:    "09:00",
+		End:      "17:00",
+		Timezone: "UTC",
+	})
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(user, nil).Times(2)
+
+	mockStore.EXPECT().
+		EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.EscalationParams,
+		) ([]db.EscalatedNotification, error) {
+			cutoff, err := time.Parse(time.RFC3339, params.CutoffDate)
+			require.NoError(t, err)
+			require.WithinDuration(t, twoDaysAgo, cutoff, time.Minute)
+			return nil, nil
+		})
+	mockStore.EXPECT().
+		UpdateUserEscalationSettings(gomock.Any(), gomock.Any()).
+		Return(db.User{}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Empty(t, result.Escalated)
+}
+
+func TestEscalateReviewRequestsHandler_Handle_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(escalationUser(t, models.EscalationSettings{
+		Enabled:   true,
+		AfterDays: 3,
+	}), nil).Times(2)
+	mockStore.EXPECT().
+		EscalateStaleReviewRequests(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, errors.New("database error"))
+
+	_, err := handler.Handle(context.Background(), "test-user-id")
+	require.Error(t, err)
+}
+
+func TestEscalateReviewRequestsHandler_GetEscalationSettings_Default(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewEscalateReviewRequestsHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, sql.ErrNoRows)
+
+	settings, err := handler.GetEscalationSettings(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultEscalationSettings(), settings)
+}
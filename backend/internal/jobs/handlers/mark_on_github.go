@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"go.uber.org/zap"
+
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// MarkOnGitHubJobPayload represents the payload for marking a thread on GitHub.
+type MarkOnGitHubJobPayload struct {
+	GithubID string `json:"githubID"`
+	// Done marks the thread done instead of only read
+	Done bool `json:"done"`
+}
+
+// MarkOnGitHubHandler marks threads read or done on GitHub after they were marked read or
+// archived in Octobud. Failures GitHub won't recover from are dropped; the rest are
+// returned so the job is retried.
+type MarkOnGitHubHandler struct {
+	store    db.Store
+	client   githubinterfaces.Client
+	accounts AccountClientProvider
+	logger   *zap.Logger
+}
+
+// NewMarkOnGitHubHandler creates a new MarkOnGitHubHandler.
+func NewMarkOnGitHubHandler(
+	store db.Store,
+	client githubinterfaces.Client,
+	logger *zap.Logger,
+) *MarkOnGitHubHandler {
+	return &MarkOnGitHubHandler{
+		store:  store,
+		client: client,
+		logger: logger,
+	}
+}
+
+// WithAccountClients marks threads of linked accounts with that account's client.
+func (h *MarkOnGitHubHandler) WithAccountClients(
+	accounts AccountClientProvider,
+) *MarkOnGitHubHandler {
+	h.accounts = accounts
+	return h
+}
+
+// Handle marks one thread read or done on GitHub.
+func (h *MarkOnGitHubHandler) Handle(ctx context.Context, userID string, payload []byte) error {
+	var jobPayload MarkOnGitHubJobPayload
+	if err := json.Unmarshal(payload, &jobPayload); err != nil {
+		h.logger.Warn("failed to unmarshal mark on GitHub job payload", zap.Error(err))
+		return err
+	}
+
+	// Turned off after the job was queued
+	if enabled, err := h.enabled(ctx); err != nil {
+		return err
+	} else if !enabled {
+		return nil
+	}
+
+	notification, err := h.store.GetNotificationByGithubID(ctx, userID, jobPayload.GithubID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Deleted after the job was queued
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	client, err := h.clientFor(ctx, notification)
+	if errors.Is(err, coregithub.ErrAccountNotLinked) {
+		// Unlinked after the job was queued; its notifications are gone already
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if jobPayload.Done {
+		err = client.MarkThreadDone(ctx, jobPayload.GithubID)
+	} else {
+		err = client.MarkThreadRead(ctx, jobPayload.GithubID)
+	}
+	if err != nil && !github.IsRetriableError(err) {
+		h.logger.Warn("dropping thread GitHub refused to mark",
+			zap.String("githubID", jobPayload.GithubID),
+			zap.Bool("done", jobPayload.Done),
+			zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// enabled reports whether the user still has marking on GitHub turned on
+func (h *MarkOnGitHubHandler) enabled(ctx context.Context) (bool, error) {
+	user, err := h.store.GetUser(ctx)
+	if err != nil {
+		return false, err
+	}
+	settings, err := models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	if err != nil {
+		return false, err
+	}
+	return settings != nil && settings.MarkOnGitHub, nil
+}
+
+// clientFor returns the client of the account the notification was synced for
+func (h *MarkOnGitHubHandler) clientFor(
+	ctx context.Context,
+	notification db.Notification,
+) (githubinterfaces.Client, error) {
+	if !notification.Account.Valid || notification.Account.String == "" {
+		return h.client, nil
+	}
+	if h.accounts == nil {
+		return nil, ErrAccountClientsNotConfigured
+	}
+	return h.accounts.AccountClient(ctx, notification.Account.String)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+)
+
+func markOnGitHubPayload(t *testing.T, githubID string, done bool) []byte {
+	t.Helper()
+	payload, err := json.Marshal(MarkOnGitHubJobPayload{GithubID: githubID, Done: done})
+	require.NoError(t, err)
+	return payload
+}
+
+func markOnGitHubUser(enabled bool) db.User {
+	data := []byte(`{"markOnGitHub": false}`)
+	if enabled {
+		data = []byte(`{"markOnGitHub": true}`)
+	}
+	return db.User{ID: 1, SyncSettings: db.NullRawMessage{RawMessage: data, Valid: true}}
+}
+
+func TestMarkOnGitHubHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		done      bool
+		account   string
+		setup     func(primary, work *githubmocks.MockClient)
+		expectErr bool
+	}{
+		{
+			name: "read marks the thread read",
+			setup: func(primary, _ *githubmocks.MockClient) {
+				primary.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+		{
+			name: "done marks the thread done",
+			done: true,
+			setup: func(primary, _ *githubmocks.MockClient) {
+				primary.EXPECT().MarkThreadDone(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+		{
+			name:    "linked account threads use that account's client",
+			account: "work",
+			setup: func(_, work *githubmocks.MockClient) {
+				work.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+		{
+			name:    "threads of unlinked accounts are dropped",
+			account: "gone",
+			setup:   func(_, _ *githubmocks.MockClient) {},
+		},
+		{
+			name: "retriable failures are returned for a retry",
+			setup: func(primary, _ *githubmocks.MockClient) {
+				primary.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").
+					Return(errors.New("github: thread status 502: bad gateway"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "refused threads are dropped",
+			setup: func(primary, _ *githubmocks.MockClient) {
+				primary.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").
+					Return(errors.New("github: thread status 403: forbidden"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := dbmocks.NewMockStore(ctrl)
+			primary := githubmocks.NewMockClient(ctrl)
+			work := githubmocks.NewMockClient(ctrl)
+
+			mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(true), nil)
+			mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), "user-1", "thread-1").
+				Return(db.Notification{
+					GithubID: "thread-1",
+					Account:  sql.NullString{String: tt.account, Valid: tt.account != ""},
+				}, nil)
+			tt.setup(primary, work)
+
+			handler := NewMarkOnGitHubHandler(mockStore, primary, zap.NewNop()).
+				WithAccountClients(&fakeAccountClients{clients: map[string]*githubmocks.MockClient{
+					"work": work,
+				}})
+			err := handler.Handle(context.Background(), "user-1", markOnGitHubPayload(t, "thread-1", tt.done))
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMarkOnGitHubHandler_DropsWhenTurnedOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(false), nil)
+
+	handler := NewMarkOnGitHubHandler(mockStore, githubmocks.NewMockClient(ctrl), zap.NewNop())
+	err := handler.Handle(context.Background(), "user-1", markOnGitHubPayload(t, "thread-1", false))
+	require.NoError(t, err)
+}
+
+func TestMarkOnGitHubHandler_DropsDeletedNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(markOnGitHubUser(true), nil)
+	mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), "user-1", "thread-1").
+		Return(db.Notification{}, sql.ErrNoRows)
+
+	handler := NewMarkOnGitHubHandler(mockStore, githubmocks.NewMockClient(ctrl), zap.NewNop())
+	err := handler.Handle(context.Background(), "user-1", markOnGitHubPayload(t, "thread-1", true))
+	require.NoError(t, err)
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// MergeDuplicateRepositoriesHandler folds repository rows that describe the same GitHub
+// repository into a single row. Duplicates are left behind by older databases that keyed
+// repositories on their name alone, so a rename or a change in casing created a second row.
+type MergeDuplicateRepositoriesHandler struct {
+	store  db.Store
+	logger *zap.Logger
+}
+
+// NewMergeDuplicateRepositoriesHandler creates a new MergeDuplicateRepositoriesHandler
+func NewMergeDuplicateRepositoriesHandler(
+	store db.Store,
+	logger *zap.Logger,
+) *MergeDuplicateRepositoriesHandler {
+	return &MergeDuplicateRepositoriesHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// RepositoryMerge describes a duplicate repository row that was merged into the kept row
+type RepositoryMerge struct {
+	KeptID             int64
+	KeptFullName       string
+	MergedID           int64
+	MergedFullName     string
+	NotificationsMoved int64
+	PullRequestsMoved  int64
+}
+
+// MergeDuplicateRepositoriesResult contains the merges performed, in the order they ran
+type MergeDuplicateRepositoriesResult struct {
+	Merges []RepositoryMerge
+}
+
+// Handle detects duplicate repository rows and merges each group into one row. Every merge
+// is its own transaction; when one fails the merges already performed are still reported.
+func (h *MergeDuplicateRepositoriesHandler) Handle(
+	ctx context.Context,
+	userID string,
+) (*MergeDuplicateRepositoriesResult, error) {
+	result := &MergeDuplicateRepositoriesResult{Merges: []RepositoryMerge{}}
+
+	repos, err := h.store.ListRepositories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range findDuplicateRepositories(repos) {
+		kept := group[0]
+		for _, duplicate := range group[1:] {
+			moved, err := h.store.MergeRepositories(ctx, userID, db.MergeRepositoriesParams{
+				KeepID:      kept.ID,
+				DuplicateID: duplicate.ID,
+			})
+			if err != nil {
+				h.logger.Error("failed to merge duplicate repository",
+					zap.Int64("keptID", kept.ID),
+					zap.Int64("duplicateID", duplicate.ID),
+					zap.Error(err))
+				return result, err
+			}
+
+			h.logger.Info("merged duplicate repository",
+				zap.String("kept", kept.FullName),
+				zap.String("merged", duplicate.FullName),
+				zap.Int64("notificationsMoved", moved.NotificationsMoved),
+				zap.Int64("pullRequestsMoved", moved.PullRequestsMoved))
+
+			result.Merges = append(result.Merges, RepositoryMerge{
+				KeptID:             kept.ID,
+				KeptFullName:       kept.FullName,
+				MergedID:           duplicate.ID,
+				MergedFullName:     duplicate.FullName,
+				NotificationsMoved: moved.NotificationsMoved,
+				PullRequestsMoved:  moved.PullRequestsMoved,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// findDuplicateRepositories groups rows that describe the same GitHub repository: rows sharing
+// a GitHub ID, plus rows without one whose name matches a group's name ignoring case. Each
+// returned group has at least two rows and starts with the row to keep.
+func findDuplicateRepositories(repos []db.Repository) [][]db.Repository {
+	byGithubID := make(map[int64][]db.Repository)
+	githubIDByName := make(map[string]int64)
+	ambiguousNames := make(map[string]bool)
+	for _, repo := range repos {
+		if !repo.GithubID.Valid {
+			continue
+		}
+		byGithubID[repo.GithubID.Int64] = append(byGithubID[repo.GithubID.Int64], repo)
+
+		name := strings.ToLower(repo.FullName)
+		if id, ok := githubIDByName[name]; ok && id != repo.GithubID.Int64 {
+			ambiguousNames[name] = true
+		}
+		githubIDByName[name] = repo.GithubID.Int64
+	}
+
+	byName := make(map[string][]db.Repository)
+	for _, repo := range repos {
+		if repo.GithubID.Valid {
+			continue
+		}
+		name := strings.ToLower(repo.FullName)
+		if id, ok := githubIDByName[name]; ok && !ambiguousNames[name] {
+			byGithubID[id] = append(byGithubID[id], repo)
+			continue
+		}
+		byName[name] = append(byName[name], repo)
+	}
+
+	var groups [][]db.Repository
+	for _, group := range byGithubID {
+		if len(group) > 1 {
+			groups = append(groups, sortByKeepOrder(group))
+		}
+	}
+	for _, group := range byName {
+		if len(group) > 1 {
+			groups = append(groups, sortByKeepOrder(group))
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0].ID < groups[j][0].ID
+	})
+	return groups
+}
+
+// sortByKeepOrder puts the row to keep first: the one GitHub data was most recently written to,
+// falling back to rows with a GitHub ID and then the newest row, since rows created after a
+// rename carry the current name.
+func sortByKeepOrder(group []db.Repository) []db.Repository {
+	sort.Slice(group, func(i, j int) bool {
+		a, b := group[i], group[j]
+		if a.UpdatedAt.Valid != b.UpdatedAt.Valid {
+			return a.UpdatedAt.Valid
+		}
+		if a.UpdatedAt.Valid && !a.UpdatedAt.Time.Equal(b.UpdatedAt.Time) {
+			return a.UpdatedAt.Time.After(b.UpdatedAt.Time)
+		}
+		if a.GithubID.Valid != b.GithubID.Valid {
+			return a.GithubID.Valid
+		}
+		return a.ID > b.ID
+	})
+	return group
+}
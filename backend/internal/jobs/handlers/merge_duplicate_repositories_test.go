@@ -0,0 +1,162 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+)
+
+func testRepository(id int64, fullName string, githubID int64, updatedAt time.Time) db.Repository {
+	repo := db.Repository{ID: id, UserID: "test-user-id", FullName: fullName}
+	if githubID != 0 {
+		repo.GithubID = sql.NullInt64{Int64: githubID, Valid: true}
+	}
+	if !updatedAt.IsZero() {
+		repo.UpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+	}
+	return repo
+}
+
+func TestMergeDuplicateRepositoriesHandler_NoDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewMergeDuplicateRepositoriesHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return([]db.Repository{
+		testRepository(1, "octo/one", 100, time.Time{}),
+		testRepository(2, "octo/two", 200, time.Time{}),
+		testRepository(3, db.SystemRepositoryFullName, 0, time.Time{}),
+	}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Empty(t, result.Merges)
+}
+
+func TestMergeDuplicateRepositoriesHandler_KeepsMostRecentlyUpdatedRow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewMergeDuplicateRepositoriesHandler(mockStore, zap.NewNop())
+
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return([]db.Repository{
+		testRepository(1, "Octo/Repo", 100, newer),
+		testRepository(2, "octo/repo", 100, older),
+		testRepository(3, "octo/other", 200, older),
+	}, nil)
+	mockStore.EXPECT().
+		MergeRepositories(gomock.Any(), "test-user-id", db.MergeRepositoriesParams{
+			KeepID:      1,
+			DuplicateID: 2,
+		}).
+		Return(db.MergeRepositoriesResult{NotificationsMoved: 4, PullRequestsMoved: 2}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, []handlers.RepositoryMerge{{
+		KeptID:             1,
+		KeptFullName:       "Octo/Repo",
+		MergedID:           2,
+		MergedFullName:     "octo/repo",
+		NotificationsMoved: 4,
+		PullRequestsMoved:  2,
+	}}, result.Merges)
+}
+
+func TestMergeDuplicateRepositoriesHandler_FoldsRowsWithoutGithubIDByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewMergeDuplicateRepositoriesHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return([]db.Repository{
+		testRepository(1, "OCTO/REPO", 0, time.Time{}),
+		testRepository(2, "octo/repo", 100, time.Time{}),
+		testRepository(3, "octo/legacy", 0, time.Time{}),
+		testRepository(4, "Octo/Legacy", 0, time.Time{}),
+	}, nil)
+	gomock.InOrder(
+		mockStore.EXPECT().
+			MergeRepositories(gomock.Any(), "test-user-id", db.MergeRepositoriesParams{
+				KeepID:      2,
+				DuplicateID: 1,
+			}).
+			Return(db.MergeRepositoriesResult{NotificationsMoved: 1}, nil),
+		mockStore.EXPECT().
+			MergeRepositories(gomock.Any(), "test-user-id", db.MergeRepositoriesParams{
+				KeepID:      4,
+				DuplicateID: 3,
+			}).
+			Return(db.MergeRepositoriesResult{}, nil),
+	)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Len(t, result.Merges, 2)
+	require.Equal(t, "octo/repo", result.Merges[0].KeptFullName)
+	require.Equal(t, "OCTO/REPO", result.Merges[0].MergedFullName)
+	require.Equal(t, "Octo/Legacy", result.Merges[1].KeptFullName)
+}
+
+func TestMergeDuplicateRepositoriesHandler_ReportsMergesBeforeFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewMergeDuplicateRepositoriesHandler(mockStore, zap.NewNop())
+
+	mockStore.EXPECT().ListRepositories(gomock.Any(), "test-user-id").Return([]db.Repository{
+		testRepository(1, "octo/a", 100, time.Time{}),
+		testRepository(2, "octo/b", 100, time.Time{}),
+		testRepository(3, "octo/c", 100, time.Time{}),
+	}, nil)
+	gomock.InOrder(
+		mockStore.EXPECT().
+			MergeRepositories(gomock.Any(), "test-user-id", db.MergeRepositoriesParams{
+				KeepID:      3,
+				DuplicateID: 2,
+			}).
+			Return(db.MergeRepositoriesResult{}, nil),
+		mockStore.EXPECT().
+			MergeRepositories(gomock.Any(), "test-user-id", db.MergeRepositoriesParams{
+				KeepID:      3,
+				DuplicateID: 1,
+			}).
+			Return(db.MergeRepositoriesResult{}, errors.New("database is locked")),
+	)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.Error(t, err)
+	require.Len(t, result.Merges, 1)
+	require.Equal(t, int64(2), result.Merges[0].MergedID)
+}
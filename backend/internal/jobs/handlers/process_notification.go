@@ -18,18 +18,35 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/osactions"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
 )
 
+// ErrAccountClientsNotConfigured is returned for a linked account's notification when the
+// handler has no way to get that account's client
+var ErrAccountClientsNotConfigured = errors.New("linked account clients not configured")
+
 // ProcessNotificationHandler handles processing a single notification.
 type ProcessNotificationHandler struct {
 	store       db.Store
 	syncService sync.SyncOperations
+	alerts      alert.AlertService
+	notifier    osactions.Notifier
+	liveQueries livequery.LiveQueryService
+	accounts    AccountClientProvider
+	history     history.HistoryService
 	logger      *zap.Logger
 }
 
@@ -46,18 +63,93 @@ func NewProcessNotificationHandler(
 	}
 }
 
+// WithAlertService enables recording alerts for newly arrived notifications.
+func (h *ProcessNotificationHandler) WithAlertService(
+	alerts alert.AlertService,
+) *ProcessNotificationHandler {
+	h.alerts = alerts
+	return h
+}
+
+// WithNativeNotifier enables native OS notifications for new notifications that alert on the
+// desktop. It has no effect without an alert service.
+func (h *ProcessNotificationHandler) WithNativeNotifier(
+	notifier osactions.Notifier,
+) *ProcessNotificationHandler {
+	h.notifier = notifier
+	return h
+}
+
+// WithHistory records synced updates and the rules applied to new notifications in their
+// history.
+func (h *ProcessNotificationHandler) WithHistory(
+	history history.HistoryService,
+) *ProcessNotificationHandler {
+	h.history = history
+	return h
+}
+
+// WithLiveQueries enables telling live query subscribers about synced notifications.
+func (h *ProcessNotificationHandler) WithLiveQueries(
+	liveQueries livequery.LiveQueryService,
+) *ProcessNotificationHandler {
+	h.liveQueries = liveQueries
+	return h
+}
+
+// WithAccountClients enables processing notifications synced for linked accounts.
+func (h *ProcessNotificationHandler) WithAccountClients(
+	accounts AccountClientProvider,
+) *ProcessNotificationHandler {
+	h.accounts = accounts
+	return h
+}
+
 // Handle processes a single notification.
 func (h *ProcessNotificationHandler) Handle(
 	ctx context.Context,
 	userID string,
 	notificationData []byte,
 ) error {
+	syncService := h.syncService
+
+	// Notifications of linked accounts are fetched and recorded with that account's client
+	var wrapped AccountNotification
+	if err := json.Unmarshal(notificationData, &wrapped); err == nil &&
+		wrapped.Account != "" && len(wrapped.Thread) > 0 {
+		if h.accounts == nil {
+			return ErrAccountClientsNotConfigured
+		}
+		client, err := h.accounts.AccountClient(ctx, wrapped.Account)
+		if errors.Is(err, coregithub.ErrAccountNotLinked) {
+			// Unlinked after the job was queued; its notifications are gone already
+			h.logger.Debug("dropping notification of unlinked account",
+				zap.String("account", wrapped.Account))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		syncService = h.syncService.WithAccount(client, wrapped.Account)
+		notificationData = wrapped.Thread
+	}
+
 	var thread types.NotificationThread
 	if err := json.Unmarshal(notificationData, &thread); err != nil {
 		h.logger.Warn("failed to unmarshal notification data", zap.Error(err))
 		return err
 	}
 
+	// Orgs the user left are out of sync scope
+	if offboarded, err := h.isOffboarded(ctx, userID, thread); err != nil {
+		return err
+	} else if offboarded {
+		h.logger.Debug("dropping notification of offboarded org",
+			zap.String("githubID", thread.ID),
+			zap.String("repository", thread.Repository.FullName))
+		return nil
+	}
+
 	h.logger.Debug("processing notification",
 		zap.String("userID", userID),
 		zap.String("githubID", thread.ID),
@@ -79,7 +171,7 @@ func (h *ProcessNotificationHandler) Handle(
 		zap.String("githubID", thread.ID),
 		zap.Bool("isNew", isNewNotification))
 
-	if err := h.syncService.ProcessNotification(ctx, userID, thread); err != nil {
+	if err := syncService.ProcessNotification(ctx, userID, thread); err != nil {
 		h.logger.Warn("failed to process notification via sync service",
 			zap.String("githubID", thread.ID),
 			zap.Error(err))
@@ -88,24 +180,80 @@ func (h *ProcessNotificationHandler) Handle(
 
 	h.logger.Debug("notification synced successfully",
 		zap.String("githubID", thread.ID))
+	if h.history != nil {
+		h.history.Record(ctx, userID, []string{thread.ID},
+			models.HistorySynced, models.HistorySourceSync, thread.Reason)
+	}
+
+	// Merge triage state imported from a backup before this notification was synced.
+	// Existing notifications get theirs at import time, so only new ones are checked.
+	if isNewNotification && h.store != nil {
+		restored, err := h.store.ApplyTriageRestore(ctx, userID, thread.ID)
+		if err != nil {
+			// Best-effort like rules: the restore stays staged and can be retried by re-importing
+			h.logger.Warn("failed to restore imported triage state",
+				zap.String("githubID", thread.ID),
+				zap.Error(err))
+		} else if restored {
+			h.logger.Debug("restored imported triage state",
+				zap.String("githubID", thread.ID))
+		}
+	}
 
 	// Only apply rules to newly created notifications (INSERT), not updates
 	if isNewNotification && h.store != nil {
 		notification, err := h.store.GetNotificationByGithubID(ctx, userID, thread.ID)
 		if err == nil {
-			matcher := NewRuleMatcher(h.store)
+			matcher := NewRuleMatcher(h.store).WithHistory(h.history)
 			_, matchErr := matcher.MatchAndApplyRules(ctx, userID, notification.ID)
 			if matchErr != nil {
 				// Log the error but don't fail the job - rule application is best-effort
 				h.logger.Debug("failed to apply rules to notification",
 					zap.String("githubID", thread.ID),
 					zap.Error(matchErr))
-				return nil
+			} else {
+				h.logger.Debug("rules applied to new notification",
+					zap.String("githubID", thread.ID))
+			}
+
+			// Alerts are decided after rules so filtered or archived notifications stay quiet
+			if h.alerts != nil {
+				recorded, alertErr := RecordAlertWithDB(ctx, h.store, h.alerts, userID, notification.ID)
+				if alertErr != nil {
+					h.logger.Warn("failed to record alert for notification",
+						zap.String("githubID", thread.ID),
+						zap.Error(alertErr))
+				} else if h.notifier != nil {
+					_, notifyErr := NotifyNativeWithDB(
+						ctx, h.store, h.alerts, h.notifier, userID, notification.ID, recorded,
+					)
+					if notifyErr != nil {
+						h.logger.Warn("failed to show native notification",
+							zap.String("githubID", thread.ID),
+							zap.Error(notifyErr))
+					}
+				}
 			}
-			h.logger.Debug("rules applied to new notification",
-				zap.String("githubID", thread.ID))
 		}
 	}
 
+	// Checked last so subscribers see the notification after rules have run
+	if h.liveQueries != nil {
+		h.liveQueries.NotificationChanged(ctx, userID, thread.ID)
+	}
+
 	return nil
 }
+
+// isOffboarded reports whether the thread's repository belongs to an org the user left
+func (h *ProcessNotificationHandler) isOffboarded(
+	ctx context.Context,
+	userID string,
+	thread types.NotificationThread,
+) (bool, error) {
+	org, _, found := strings.Cut(thread.Repository.FullName, "/")
+	if h.store == nil || !found {
+		return false, nil
+	}
+	return h.store.IsOrgOffboarded(ctx, userID, org)
+}
@@ -17,6 +17,7 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -26,7 +27,14 @@ import (
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	alertmocks "github.com/octobud-hq/octobud/backend/internal/core/alert/mocks"
+	livequerymocks "github.com/octobud-hq/octobud/backend/internal/core/livequery/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
 )
 
@@ -69,6 +77,181 @@ func TestProcessNotificationHandler_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestProcessNotificationHandler_RestoresImportedTriageForNewNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	gomock.InOrder(
+		mockStore.EXPECT().
+			GetNotificationByGithubID(gomock.Any(), "test-user-id", "notif-123").
+			Return(db.Notification{}, sql.ErrNoRows),
+		mockSync.EXPECT().
+			ProcessNotification(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(nil),
+		mockStore.EXPECT().
+			ApplyTriageRestore(gomock.Any(), "test-user-id", "notif-123").
+			Return(true, nil),
+		mockStore.EXPECT().
+			GetNotificationByGithubID(gomock.Any(), "test-user-id", "notif-123").
+			Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil),
+	)
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "test-user-id", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "test-user-id").
+		Return(nil, nil)
+
+	handler := NewProcessNotificationHandler(mockStore, mockSync, zap.NewNop())
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", threadData))
+}
+
+func TestProcessNotificationHandler_RecordsAlertForNewNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockAlerts := alertmocks.NewMockAlertService(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "test-user-id", "notif-123").
+		Return(db.Notification{}, sql.ErrNoRows)
+	mockSync.EXPECT().
+		ProcessNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil)
+	mockStore.EXPECT().
+		ApplyTriageRestore(gomock.Any(), "test-user-id", "notif-123").
+		Return(false, nil)
+	mockStore.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "test-user-id", "notif-123").
+		Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil)
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "test-user-id", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil).
+		Times(2)
+
+	// Matched once to apply rules and once to pick the alert
+	actions, err := json.Marshal(models.RuleActions{
+		Alert: &models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "bell"},
+	})
+	require.NoError(t, err)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "test-user-id").
+		Return([]db.Rule{{
+			ID:      "rule-1",
+			Query:   sql.NullString{String: "repo:cli/cli", Valid: true},
+			Enabled: true,
+			Actions: actions,
+		}}, nil).
+		Times(2)
+	mockStore.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{Total: 1}, nil).
+		Times(2)
+	mockStore.EXPECT().
+		UpsertRuleEvaluation(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil)
+
+	mockAlerts.EXPECT().
+		GetSettings(gomock.Any(), "test-user-id").
+		Return(models.DefaultAlertSettings(), nil)
+	mockAlerts.EXPECT().
+		Record(gomock.Any(), "test-user-id", int64(1), alert.Match{
+			Config:   models.AlertConfig{Level: models.AlertLevelDesktop, Sound: "bell"},
+			Source:   alert.SourceRule,
+			SourceID: "rule-1",
+		}).
+		Return(models.Alert{ID: 1}, nil)
+
+	handler := NewProcessNotificationHandler(mockStore, mockSync, zap.NewNop()).
+		WithAlertService(mockAlerts)
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", threadData))
+}
+
+func TestProcessNotificationHandler_TellsLiveQueriesAboutUpdatedNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockLiveQueries := livequerymocks.NewMockLiveQueryService(ctrl)
+	gomock.InOrder(
+		mockStore.EXPECT().
+			GetNotificationByGithubID(gomock.Any(), "test-user-id", "notif-123").
+			Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil),
+		mockSync.EXPECT().
+			ProcessNotification(gomock.Any(), "test-user-id", gomock.Any()).
+			Return(nil),
+		mockLiveQueries.EXPECT().
+			NotificationChanged(gomock.Any(), "test-user-id", "notif-123"),
+	)
+
+	handler := NewProcessNotificationHandler(mockStore, mockSync, zap.NewNop()).
+		WithLiveQueries(mockLiveQueries)
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", threadData))
+}
+
+func TestProcessNotificationHandler_UsesLinkedAccountClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-work"})
+	require.NoError(t, err)
+	data, err := json.Marshal(AccountNotification{Account: "octocat-work", Thread: threadData})
+	require.NoError(t, err)
+
+	workClient := githubmocks.NewMockClient(ctrl)
+	accountSync := syncmocks.NewMockSyncOperations(ctrl)
+	accountSync.EXPECT().
+		ProcessNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg types.NotificationThread) error {
+			require.Equal(t, "notif-work", arg.ID)
+			return nil
+		})
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().WithAccount(workClient, "octocat-work").Return(accountSync)
+
+	handler := NewProcessNotificationHandler(nil, mockSync, zap.NewNop()).
+		WithAccountClients(&fakeAccountClients{clients: map[string]*githubmocks.MockClient{
+			"octocat-work": workClient,
+		}})
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", data))
+}
+
+func TestProcessNotificationHandler_DropsUnlinkedAccountNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data, err := json.Marshal(AccountNotification{
+		Account: "gone",
+		Thread:  json.RawMessage(`{"id":"notif-gone"}`),
+	})
+	require.NoError(t, err)
+
+	// No sync expectations: the notification must not be processed
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	handler := NewProcessNotificationHandler(nil, mockSync, zap.NewNop()).
+		WithAccountClients(&fakeAccountClients{})
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", data))
+}
+
 func TestProcessNotificationHandler_UnmarshalError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -180,3 +363,25 @@ func TestProcessNotificationHandler_NilData(t *testing.T) {
 	// Nil data should result in unmarshal error
 	require.Error(t, err)
 }
+
+func TestProcessNotificationHandler_DropsNotificationOfOffboardedOrg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thread := types.NotificationThread{
+		ID:         "notif-123",
+		Repository: types.RepositorySnapshot{FullName: "acme/widgets"},
+	}
+	threadData, err := json.Marshal(thread)
+	require.NoError(t, err)
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockStore.EXPECT().
+		IsOrgOffboarded(gomock.Any(), "test-user-id", "acme").
+		Return(true, nil)
+
+	handler := NewProcessNotificationHandler(mockStore, mockSync, zap.NewNop())
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id", threadData))
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+// ReturnSnoozesHandler periodically brings back notifications whose snooze has run out,
+// recording that they returned from a snooze and, if the user asked for it, marking them
+// unread
+type ReturnSnoozesHandler struct {
+	store   db.Store
+	events  events.EventBus
+	history history.HistoryService
+	now     func() time.Time
+	logger  *zap.Logger
+}
+
+// NewReturnSnoozesHandler creates a new ReturnSnoozesHandler
+func NewReturnSnoozesHandler(
+	store db.Store,
+	now func() time.Time,
+	logger *zap.Logger,
+) *ReturnSnoozesHandler {
+	return &ReturnSnoozesHandler{
+		store:  store,
+		now:    now,
+		logger: logger,
+	}
+}
+
+// WithEvents tells live update subscribers about each notification that returns
+func (h *ReturnSnoozesHandler) WithEvents(bus events.EventBus) *ReturnSnoozesHandler {
+	h.events = bus
+	return h
+}
+
+// WithHistory records each return in the notification's history
+func (h *ReturnSnoozesHandler) WithHistory(history history.HistoryService) *ReturnSnoozesHandler {
+	h.history = history
+	return h
+}
+
+// SnoozeReturnResult contains the results of a run
+type SnoozeReturnResult struct {
+	Returned   []string // GitHub IDs of notifications back from a snooze
+	MarkUnread bool     // Whether they were marked unread
+}
+
+// Handle returns expired snoozes to the inbox. Invalid snooze settings don't hold snoozes
+// back; the notifications just aren't marked unread.
+func (h *ReturnSnoozesHandler) Handle(
+	ctx context.Context,
+	userID string,
+) (*SnoozeReturnResult, error) {
+	result := &SnoozeReturnResult{}
+
+	user, err := h.store.GetUser(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		settings, err := models.SnoozeSettingsFromJSON(user.SnoozeSettings.RawMessage)
+		if err != nil {
+			h.logger.Warn("failed to load snooze settings", zap.Error(err))
+		} else {
+			result.MarkUnread = settings.MarkUnreadOnReturn
+		}
+	}
+
+	returned, err := h.store.ReturnExpiredSnoozes(ctx, userID, db.SnoozeReturnParams{
+		ReturnedAt: db.FormatTimestamp(h.now().UTC()),
+		MarkUnread: result.MarkUnread,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Returned = returned
+
+	if h.history != nil {
+		h.history.Record(ctx, userID, returned, models.HistorySnoozeReturned, models.HistorySourceSystem, "")
+	}
+	if h.events != nil {
+		for _, githubID := range returned {
+			h.events.Publish(userID, models.Event{Type: models.EventSnoozeReturned, GithubID: githubID})
+		}
+	}
+
+	if len(returned) > 0 {
+		h.logger.Info("returned notifications from snooze",
+			zap.Int("count", len(returned)),
+			zap.Bool("markedUnread", result.MarkUnread))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func snoozeSettingsUser(t *testing.T, settings models.SnoozeSettings) db.User {
+	t.Helper()
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	return db.User{
+		ID:             1,
+		SnoozeSettings: db.NullRawMessage{RawMessage: data, Valid: true},
+	}
+}
+
+func TestReturnSnoozesHandler_Handle_ReturnsAndPublishes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, time.March, 7, 9, 0, 0, 0, time.UTC)
+	mockStore := mocks.NewMockStore(ctrl)
+	bus := events.NewService(zap.NewNop(), time.Now)
+	sub := bus.Subscribe("test-user-id")
+	defer bus.Unsubscribe(sub)
+
+	handler := handlers.NewReturnSnoozesHandler(mockStore, func() time.Time { return now }, zap.NewNop()).
+		WithEvents(bus)
+
+	mockStore.EXPECT().GetUser(gomock.Any()).
+		Return(snoozeSettingsUser(t, models.SnoozeSettings{DefaultPreset: "tomorrow", MarkUnreadOnReturn: true}), nil)
+	mockStore.EXPECT().
+		ReturnExpiredSnoozes(gomock.Any(), "test-user-id", db.SnoozeReturnParams{
+			ReturnedAt: "2025-03-07T09:00:00Z",
+			MarkUnread: true,
+		}).
+		Return([]string{"thread-1"}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{"thread-1"}, result.Returned)
+	require.True(t, result.MarkUnread)
+
+	select {
+	case event := <-sub.Events():
+		require.Equal(t, models.EventSnoozeReturned, event.Type)
+		require.Equal(t, "thread-1", event.GithubID)
+	case <-time.After(time.Second):
+		t.Fatal("expected a snooze_returned event")
+	}
+}
+
+func TestReturnSnoozesHandler_Handle_LeavesReadStateByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewReturnSnoozesHandler(mockStore, time.Now, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{ID: 1}, nil)
+	mockStore.EXPECT().ReturnExpiredSnoozes(gomock.Any(), "test-user-id", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, params db.SnoozeReturnParams) ([]string, error) {
+			require.False(t, params.MarkUnread)
+			return nil, nil
+		})
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Empty(t, result.Returned)
+}
+
+func TestReturnSnoozesHandler_Handle_StoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	handler := handlers.NewReturnSnoozesHandler(mockStore, time.Now, zap.NewNop())
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{ID: 1}, nil)
+	mockStore.EXPECT().
+		ReturnExpiredSnoozes(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil, errors.New("database is locked"))
+
+	_, err := handler.Handle(context.Background(), "test-user-id")
+	require.Error(t, err)
+}
@@ -21,25 +21,42 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/tag"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query"
+	"github.com/octobud-hq/octobud/backend/internal/query/expr"
 )
 
 // RuleMatcher applies rules to notifications
 type RuleMatcher struct {
-	store db.Store
+	store   db.Store
+	tags    tag.TagService
+	history history.HistoryService
 }
 
 // NewRuleMatcher creates a new rule matcher
 func NewRuleMatcher(store db.Store) *RuleMatcher {
 	return &RuleMatcher{
 		store: store,
+		tags:  tag.NewService(store),
 	}
 }
 
-// MatchAndApplyRules checks notification against all enabled rules and applies matching rules
+// WithHistory records the rules applied in each notification's history. A nil service
+// leaves history alone.
+func (rm *RuleMatcher) WithHistory(h history.HistoryService) *RuleMatcher {
+	rm.history = h
+	return rm
+}
+
+// MatchAndApplyRules checks notification against all enabled rules and applies matching rules.
+// Rules run highest priority first, then in display order, and a matching rule with
+// StopProcessing set skips the rest. Which rules matched and which were skipped is recorded
+// as the notification's rule evaluation.
 // Returns true if any rule matched
 func (rm *RuleMatcher) MatchAndApplyRules(
 	ctx context.Context,
@@ -52,44 +69,95 @@ func (rm *RuleMatcher) MatchAndApplyRules(
 		return false, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	// Fetch all enabled rules ordered by display_order
+	// Fetch all enabled rules in the order they run
 	rules, err := rm.store.ListEnabledRulesOrdered(ctx, userID)
 	if err != nil {
 		return false, fmt.Errorf("failed to list enabled rules: %w", err)
 	}
+	if len(rules) == 0 {
+		return false, nil
+	}
 
 	anyMatched := false
+	stopped := false
+	trace := make([]models.RuleTraceEntry, 0, len(rules))
 
 	// Check each rule
 	for _, rule := range rules {
+		entry := models.RuleTraceEntry{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Priority: int(rule.Priority),
+		}
+		if stopped {
+			entry.Result = models.RuleResultSkipped
+			trace = append(trace, entry)
+			continue
+		}
+
 		matched, err := rm.checkRuleMatch(ctx, userID, notification, rule)
 		if err != nil {
 			// Skip rules that fail to match - don't fail the entire job
+			entry.Result = models.RuleResultError
+			entry.Error = err.Error()
+			trace = append(trace, entry)
+			continue
+		}
+		if !matched {
+			entry.Result = models.RuleResultNotMatched
+			trace = append(trace, entry)
 			continue
 		}
 
-		if matched {
-			anyMatched = true
-
-			// Parse and apply actions
-			var actions models.RuleActions
-			if len(rule.Actions) > 0 {
-				if err := json.Unmarshal(rule.Actions, &actions); err != nil {
-					continue
-				}
-			}
+		anyMatched = true
+		entry.Result = models.RuleResultMatched
+		if rule.StopProcessing {
+			entry.StoppedProcessing = true
+			stopped = true
+		}
 
-			if err := rm.ApplyRuleActions(ctx, userID, notification.GithubID, actions); err != nil {
-				// Continue processing other rules even if one fails
+		// Parse and apply actions. Continue processing other rules even if one fails.
+		var actions models.RuleActions
+		if len(rule.Actions) > 0 {
+			if err := json.Unmarshal(rule.Actions, &actions); err != nil {
+				entry.Error = err.Error()
+				trace = append(trace, entry)
 				continue
 			}
 		}
+		if err := rm.ApplyRuleActions(ctx, userID, notification.GithubID, actions); err != nil {
+			entry.Error = err.Error()
+		} else if rm.history != nil {
+			rm.history.Record(ctx, userID, []string{notification.GithubID},
+				models.HistoryRuleApplied, models.HistorySourceRule, rule.Name)
+		}
+		if err := rm.queueDeliveries(ctx, userID, notification.GithubID, rule, actions); err != nil {
+			if entry.Error != "" {
+				entry.Error += "; "
+			}
+			entry.Error += err.Error()
+		}
+		trace = append(trace, entry)
+	}
+
+	traceJSON, err := json.Marshal(trace)
+	if err != nil {
+		return anyMatched, fmt.Errorf("failed to encode rule evaluation: %w", err)
+	}
+	err = rm.store.UpsertRuleEvaluation(ctx, userID, db.UpsertRuleEvaluationParams{
+		NotificationID: notification.ID,
+		Trace:          traceJSON,
+		EvaluatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return anyMatched, fmt.Errorf("failed to record rule evaluation: %w", err)
 	}
 
 	return anyMatched, nil
 }
 
-// checkRuleMatch checks if a notification matches a rule's query
+// checkRuleMatch checks if a notification matches a rule's query and, when it has one,
+// its condition
 func (rm *RuleMatcher) checkRuleMatch(
 	ctx context.Context,
 	userID string,
@@ -119,6 +187,48 @@ func (rm *RuleMatcher) checkRuleMatch(
 		queryStr = rule.Query.String
 	}
 
+	matched, err := rm.checkQueryMatch(ctx, userID, notification.ID, queryStr)
+	if err != nil || !matched || !rule.Condition.Valid {
+		return matched, err
+	}
+	return rm.checkCondition(ctx, userID, notification, rule.Condition.String)
+}
+
+// checkCondition evaluates a rule's condition against a notification
+func (rm *RuleMatcher) checkCondition(
+	ctx context.Context,
+	userID string,
+	notification db.Notification,
+	condition string,
+) (bool, error) {
+	program, err := expr.Compile(condition)
+	if err != nil {
+		return false, fmt.Errorf("failed to compile condition: %w", err)
+	}
+
+	var repo *db.Repository
+	r, err := rm.store.GetRepositoryByID(ctx, userID, notification.RepositoryID)
+	switch {
+	case err == nil:
+		repo = &r
+	case !errors.Is(err, sql.ErrNoRows):
+		return false, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	matched, err := program.Eval(expr.NotificationEnv(&notification, repo, time.Now()))
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition: %w", err)
+	}
+	return matched, nil
+}
+
+// checkQueryMatch checks if a notification matches a query
+func (rm *RuleMatcher) checkQueryMatch(
+	ctx context.Context,
+	userID string,
+	notificationID int64,
+	queryStr string,
+) (bool, error) {
 	// Parse and build the query
 	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
 	if err != nil {
@@ -127,7 +237,7 @@ func (rm *RuleMatcher) checkRuleMatch(
 
 	// Add constraint that id must match the notification we're checking
 	dbQuery.Where = append(dbQuery.Where, "n.id = ?")
-	dbQuery.Args = append(dbQuery.Args, notification.ID)
+	dbQuery.Args = append(dbQuery.Args, notificationID)
 
 	// Execute the query
 	result, err := rm.store.ListNotificationsFromQuery(ctx, userID, dbQuery)
@@ -191,7 +301,7 @@ func (rm *RuleMatcher) ApplyRuleActions( //nolint:gocyclo // The complexity is h
 	}
 
 	// Get notification ID for tag operations
-	if len(actions.AssignTags) > 0 || len(actions.RemoveTags) > 0 {
+	if actions.HasTagActions() {
 		notification, err := rm.store.GetNotificationByGithubID(ctx, userID, githubID)
 		if err != nil {
 			errs = append(
@@ -243,6 +353,8 @@ func (rm *RuleMatcher) ApplyRuleActions( //nolint:gocyclo // The complexity is h
 					errs = append(errs, fmt.Errorf("failed to remove tag %s: %w", tagID, err))
 				}
 			}
+
+			errs = append(errs, rm.applyTagSlugs(ctx, userID, notification.ID, actions)...)
 		}
 	}
 
@@ -253,6 +365,84 @@ func (rm *RuleMatcher) ApplyRuleActions( //nolint:gocyclo // The complexity is h
 	return nil
 }
 
+// applyTagSlugs adds and removes the tags a rule names by slug, creating tags to add
+// that don't exist yet
+func (rm *RuleMatcher) applyTagSlugs(
+	ctx context.Context,
+	userID string,
+	notificationID int64,
+	actions models.RuleActions,
+) []error {
+	var errs []error
+
+	for _, slug := range actions.AddTagSlugs {
+		t, err := rm.tags.EnsureTagBySlug(ctx, userID, slug)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get or create tag %s: %w", slug, err))
+			continue
+		}
+		_, err = rm.store.AssignTagToEntity(ctx, userID, db.AssignTagToEntityParams{
+			TagID:      t.ID,
+			EntityType: "notification",
+			EntityID:   notificationID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to assign tag %s: %w", slug, err))
+		}
+	}
+
+	for _, slug := range actions.RemoveTagSlugs {
+		t, err := rm.store.GetTagBySlug(ctx, userID, slug)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				errs = append(errs, fmt.Errorf("failed to get tag %s for removal: %w", slug, err))
+			}
+			continue
+		}
+		err = rm.store.RemoveTagAssignment(ctx, userID, db.RemoveTagAssignmentParams{
+			TagID:      t.ID,
+			EntityType: "notification",
+			EntityID:   notificationID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove tag %s: %w", slug, err))
+		}
+	}
+
+	return errs
+}
+
+// queueDeliveries queues a delivery of the notification to each integration the rule
+// notifies. Integrations that have been deleted are skipped.
+func (rm *RuleMatcher) queueDeliveries(
+	ctx context.Context,
+	userID string,
+	githubID string,
+	rule db.Rule,
+	actions models.RuleActions,
+) error {
+	var errs []error
+	for _, integrationID := range actions.NotifyWebhook {
+		_, err := rm.store.GetIntegration(ctx, userID, integrationID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err == nil {
+			_, err = rm.store.CreateIntegrationDelivery(ctx, userID, db.CreateIntegrationDeliveryParams{
+				IntegrationID: integrationID,
+				RuleID:        sql.NullString{String: rule.ID, Valid: true},
+				RuleName:      rule.Name,
+				GithubID:      githubID,
+				CreatedAt:     time.Now(),
+			})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to queue delivery to integration %s: %w", integrationID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // MatchAndApplyRulesWithDB is a convenience wrapper that creates a RuleMatcher and applies rules
 func MatchAndApplyRulesWithDB(
 	ctx context.Context,
@@ -0,0 +1,393 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
+	alertmocks "github.com/octobud-hq/octobud/backend/internal/core/alert/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/osactions"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+func testRule(t *testing.T, id string, stop bool, actions models.RuleActions) db.Rule {
+	t.Helper()
+	data, err := json.Marshal(actions)
+	require.NoError(t, err)
+	return db.Rule{
+		ID:             id,
+		Name:           "Rule " + id,
+		Query:          sql.NullString{String: "repo:cli/cli", Valid: true},
+		Enabled:        true,
+		Actions:        data,
+		StopProcessing: stop,
+	}
+}
+
+func TestMatchAndApplyRules_StopProcessingSkipsLaterRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-1"}, nil)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "user-1").
+		Return([]db.Rule{
+			testRule(t, "no-match", false, models.RuleActions{MarkRead: true}),
+			testRule(t, "stop", true, models.RuleActions{Star: true}),
+			testRule(t, "later", false, models.RuleActions{Archive: true}),
+		}, nil)
+	gomock.InOrder(
+		mockStore.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: 0}, nil),
+		mockStore.EXPECT().
+			ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+			Return(db.ListNotificationsFromQueryResult{Total: 1}, nil),
+	)
+	// Only the stopping rule's actions run; the later rule would have archived
+	mockStore.EXPECT().
+		StarNotification(gomock.Any(), "user-1", "notif-1").
+		Return(db.Notification{}, nil)
+	mockStore.EXPECT().
+		UpsertRuleEvaluation(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertRuleEvaluationParams) error {
+			require.Equal(t, int64(1), arg.NotificationID)
+			var trace []models.RuleTraceEntry
+			require.NoError(t, json.Unmarshal(arg.Trace, &trace))
+			require.Equal(t, []models.RuleTraceEntry{
+				{RuleID: "no-match", RuleName: "Rule no-match", Result: models.RuleResultNotMatched},
+				{
+					RuleID:            "stop",
+					RuleName:          "Rule stop",
+					Result:            models.RuleResultMatched,
+					StoppedProcessing: true,
+				},
+				{RuleID: "later", RuleName: "Rule later", Result: models.RuleResultSkipped},
+			}, trace)
+			return nil
+		})
+
+	matched, err := NewRuleMatcher(mockStore).MatchAndApplyRules(context.Background(), "user-1", 1)
+	require.NoError(t, err)
+	require.True(t, matched)
+}
+
+func TestMatchAndApplyRules_Conditions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+		Return(db.Notification{
+			ID:            1,
+			GithubID:      "notif-1",
+			RepositoryID:  7,
+			SubjectNumber: sql.NullInt32{Int32: 12, Valid: true},
+		}, nil)
+	small := testRule(t, "small", false, models.RuleActions{Star: true})
+	small.Condition = sql.NullString{String: `number < 100 and org == "cli"`, Valid: true}
+	large := testRule(t, "large", false, models.RuleActions{Archive: true})
+	large.Condition = sql.NullString{String: `number >= 100`, Valid: true}
+	broken := testRule(t, "broken", false, models.RuleActions{Mute: true})
+	broken.Condition = sql.NullString{String: `number / 0 > 1`, Valid: true}
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "user-1").
+		Return([]db.Rule{small, large, broken}, nil)
+	mockStore.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{Total: 1}, nil).
+		Times(3)
+	mockStore.EXPECT().
+		GetRepositoryByID(gomock.Any(), "user-1", int64(7)).
+		Return(db.Repository{ID: 7, FullName: "cli/cli"}, nil).
+		Times(3)
+	// Only the rule whose condition holds applies its actions
+	mockStore.EXPECT().
+		StarNotification(gomock.Any(), "user-1", "notif-1").
+		Return(db.Notification{}, nil)
+	mockStore.EXPECT().
+		UpsertRuleEvaluation(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertRuleEvaluationParams) error {
+			var trace []models.RuleTraceEntry
+			require.NoError(t, json.Unmarshal(arg.Trace, &trace))
+			require.Len(t, trace, 3)
+			require.Equal(t, models.RuleResultMatched, trace[0].Result)
+			require.Equal(t, models.RuleResultNotMatched, trace[1].Result)
+			require.Equal(t, models.RuleResultError, trace[2].Result)
+			require.Contains(t, trace[2].Error, "division by zero")
+			return nil
+		})
+
+	matched, err := NewRuleMatcher(mockStore).MatchAndApplyRules(context.Background(), "user-1", 1)
+	require.NoError(t, err)
+	require.True(t, matched)
+}
+
+func TestMatchAndApplyRules_NoRulesRecordsNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-1"}, nil)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "user-1").
+		Return(nil, nil)
+
+	matched, err := NewRuleMatcher(mockStore).MatchAndApplyRules(context.Background(), "user-1", 1)
+	require.NoError(t, err)
+	require.False(t, matched)
+}
+
+func TestMatchAndApplyRules_QueuesWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-1"}, nil)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "user-1").
+		Return([]db.Rule{
+			testRule(t, "notify", false, models.RuleActions{NotifyWebhook: []string{"slack", "deleted"}}),
+		}, nil)
+	mockStore.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{Total: 1}, nil)
+	mockStore.EXPECT().
+		GetIntegration(gomock.Any(), "user-1", "slack").
+		Return(db.Integration{ID: "slack"}, nil)
+	mockStore.EXPECT().
+		CreateIntegrationDelivery(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			arg db.CreateIntegrationDeliveryParams,
+		) (db.IntegrationDelivery, error) {
+			require.Equal(t, "slack", arg.IntegrationID)
+			require.Equal(t, sql.NullString{String: "notify", Valid: true}, arg.RuleID)
+			require.Equal(t, "Rule notify", arg.RuleName)
+			require.Equal(t, "notif-1", arg.GithubID)
+			return db.IntegrationDelivery{ID: 1}, nil
+		})
+	// Integrations deleted since the rule was saved are skipped
+	mockStore.EXPECT().
+		GetIntegration(gomock.Any(), "user-1", "deleted").
+		Return(db.Integration{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		UpsertRuleEvaluation(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, arg db.UpsertRuleEvaluationParams) error {
+			var trace []models.RuleTraceEntry
+			require.NoError(t, json.Unmarshal(arg.Trace, &trace))
+			require.Len(t, trace, 1)
+			require.Empty(t, trace[0].Error)
+			return nil
+		})
+
+	matched, err := NewRuleMatcher(mockStore).MatchAndApplyRules(context.Background(), "user-1", 1)
+	require.NoError(t, err)
+	require.True(t, matched)
+}
+
+func TestApplyRuleActions_TagSlugs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByGithubID(gomock.Any(), "user-1", "notif-1").
+		Return(db.Notification{ID: 1, GithubID: "notif-1"}, nil)
+	// The tag to add doesn't exist yet, so it's created on demand
+	mockStore.EXPECT().
+		GetTagBySlug(gomock.Any(), "user-1", "security").
+		Return(db.Tag{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		UpsertTag(gomock.Any(), "user-1", db.UpsertTagParams{Name: "security", Slug: "security"}).
+		Return(db.Tag{ID: "tag-security", Slug: "security"}, nil)
+	mockStore.EXPECT().
+		AssignTagToEntity(gomock.Any(), "user-1", db.AssignTagToEntityParams{
+			TagID:      "tag-security",
+			EntityType: "notification",
+			EntityID:   1,
+		}).
+		Return(db.TagAssignment{}, nil)
+	// A missing tag to remove is nothing to do
+	mockStore.EXPECT().
+		GetTagBySlug(gomock.Any(), "user-1", "triage").
+		Return(db.Tag{}, sql.ErrNoRows)
+
+	err := NewRuleMatcher(mockStore).ApplyRuleActions(context.Background(), "user-1", "notif-1",
+		models.RuleActions{
+			AddTagSlugs:    []string{"security"},
+			RemoveTagSlugs: []string{"triage"},
+		})
+	require.NoError(t, err)
+}
+
+func TestMatchAlert_StopProcessingRuleWithoutAlertFallsThroughToViews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := dbmocks.NewMockStore(ctrl)
+
+	mockStore.EXPECT().
+		GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+		Return(db.Notification{ID: 1, GithubID: "notif-1"}, nil)
+	mockStore.EXPECT().
+		ListEnabledRulesOrdered(gomock.Any(), "user-1").
+		Return([]db.Rule{
+			testRule(t, "stop", true, models.RuleActions{Star: true}),
+			testRule(t, "later", false, models.RuleActions{
+				Alert: &models.AlertConfig{Level: models.AlertLevelDesktop},
+			}),
+		}, nil)
+	// The stopping rule and the inbox check are the only queries run
+	mockStore.EXPECT().
+		ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{Total: 1}, nil).
+		Times(2)
+
+	match, err := NewRuleMatcher(mockStore).
+		MatchAlert(context.Background(), "user-1", 1, models.DefaultAlertSettings())
+	require.NoError(t, err)
+	require.Equal(t, alert.SourceDefault, match.Source)
+}
+
+type recordingNotifier struct {
+	shown []osactions.Notification
+}
+
+func (n *recordingNotifier) ShowNotification(_ context.Context, notification osactions.Notification) error {
+	n.shown = append(n.shown, notification)
+	return nil
+}
+
+func TestNotifyNativeWithDB(t *testing.T) {
+	desktop := models.Alert{Level: models.AlertLevelDesktop, Sound: "pop"}
+	enabled := func(query string) *models.AlertSettings {
+		settings := models.DefaultAlertSettings()
+		settings.Native = models.NativeNotifications{Enabled: true, Query: query}
+		return settings
+	}
+
+	tests := []struct {
+		name      string
+		recorded  models.Alert
+		settings  *models.AlertSettings
+		matches   bool
+		wantQuery string
+		wantShown bool
+	}{
+		{
+			name:      "desktop alert matching the query is shown",
+			recorded:  desktop,
+			settings:  enabled("reason:review_requested"),
+			matches:   true,
+			wantQuery: "in:inbox is:unread (reason:review_requested)",
+			wantShown: true,
+		},
+		{
+			name:      "empty query shows every unread inbox notification",
+			recorded:  desktop,
+			settings:  enabled(""),
+			matches:   true,
+			wantQuery: "in:inbox is:unread",
+			wantShown: true,
+		},
+		{
+			name:      "notification outside the query is not shown",
+			recorded:  desktop,
+			settings:  enabled("reason:mention"),
+			wantQuery: "in:inbox is:unread (reason:mention)",
+		},
+		{
+			name:     "disabled native notifications are not shown",
+			recorded: desktop,
+			settings: models.DefaultAlertSettings(),
+		},
+		{
+			name:     "alert downgraded by quiet hours is not shown",
+			recorded: models.Alert{Level: models.AlertLevelBadge, Suppressed: models.AlertSuppressedQuietHours},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := dbmocks.NewMockStore(ctrl)
+			mockAlerts := alertmocks.NewMockAlertService(ctrl)
+			notifier := &recordingNotifier{}
+
+			if tt.settings != nil {
+				mockAlerts.EXPECT().GetSettings(gomock.Any(), "user-1").Return(tt.settings, nil)
+			}
+			if tt.wantQuery != "" {
+				expected, err := query.BuildQuery(tt.wantQuery, 1, 0)
+				require.NoError(t, err)
+				total := int64(0)
+				if tt.matches {
+					total = 1
+				}
+				mockStore.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), "user-1", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, q db.NotificationQuery) (
+						db.ListNotificationsFromQueryResult, error,
+					) {
+						require.Equal(t, append(expected.Where, "n.id = ?"), q.Where)
+						return db.ListNotificationsFromQueryResult{Total: total}, nil
+					})
+			}
+			if tt.wantShown {
+				mockStore.EXPECT().
+					GetNotificationByID(gomock.Any(), "user-1", int64(1)).
+					Return(db.Notification{
+						ID:           1,
+						RepositoryID: 7,
+						SubjectTitle: "Add dark mode",
+						Reason:       sql.NullString{String: "review_requested", Valid: true},
+					}, nil)
+				mockStore.EXPECT().
+					GetRepositoryByID(gomock.Any(), "user-1", int64(7)).
+					Return(db.Repository{ID: 7, FullName: "cli/cli"}, nil)
+			}
+
+			shown, err := NotifyNativeWithDB(
+				context.Background(), mockStore, mockAlerts, notifier, "user-1", 1, tt.recorded,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantShown, shown)
+			if tt.wantShown {
+				require.Equal(t, []osactions.Notification{{
+					Title:    "Add dark mode",
+					Subtitle: "cli/cli",
+					Body:     "review requested",
+					Sound:    "pop",
+				}}, notifier.shown)
+			} else {
+				require.Empty(t, notifier.shown)
+			}
+		})
+	}
+}
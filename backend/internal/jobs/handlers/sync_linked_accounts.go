@@ -0,0 +1,139 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+)
+
+// linkedAccountInitialSyncDays is how far back the first sync of a linked account goes
+const linkedAccountInitialSyncDays = 30
+
+// AccountClientProvider provides GitHub clients authenticated as linked accounts.
+type AccountClientProvider interface {
+	AccountClient(ctx context.Context, login string) (githubinterfaces.Client, error)
+}
+
+// AccountNotification is the process notification payload for a thread synced for a
+// linked account. Threads of the primary account are enqueued unwrapped.
+type AccountNotification struct {
+	Account string          `json:"account"`
+	Thread  json.RawMessage `json:"thread"`
+}
+
+// SyncLinkedAccountsHandler fetches notifications for each linked GitHub account and
+// enqueues them to be processed with that account's client.
+type SyncLinkedAccountsHandler struct {
+	store    db.Store
+	clients  AccountClientProvider
+	enqueuer NotificationEnqueuer
+	clock    func() time.Time
+	logger   *zap.Logger
+}
+
+// NewSyncLinkedAccountsHandler creates a new SyncLinkedAccountsHandler.
+func NewSyncLinkedAccountsHandler(
+	store db.Store,
+	clients AccountClientProvider,
+	enqueuer NotificationEnqueuer,
+	clock func() time.Time,
+	logger *zap.Logger,
+) *SyncLinkedAccountsHandler {
+	return &SyncLinkedAccountsHandler{
+		store:    store,
+		clients:  clients,
+		enqueuer: enqueuer,
+		clock:    clock,
+		logger:   logger,
+	}
+}
+
+// Handle syncs every linked account. A failing account doesn't stop the others.
+func (h *SyncLinkedAccountsHandler) Handle(ctx context.Context, userID string) error {
+	accounts, err := h.store.ListLinkedAccounts(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, account := range accounts {
+		if err := h.syncAccount(ctx, userID, account); err != nil {
+			h.logger.Warn("failed to sync linked account",
+				zap.String("account", account.Login),
+				zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncAccount enqueues the account's notifications updated since its last sync, and
+// records the latest update once all of them are enqueued
+func (h *SyncLinkedAccountsHandler) syncAccount(
+	ctx context.Context,
+	userID string,
+	account db.LinkedAccount,
+) error {
+	client, err := h.clients.AccountClient(ctx, account.Login)
+	if err != nil {
+		return err
+	}
+
+	since := h.clock().UTC().AddDate(0, 0, -linkedAccountInitialSyncDays)
+	if account.LastSyncedAt.Valid {
+		since = account.LastSyncedAt.Time
+	}
+
+	threads, err := client.FetchNotifications(ctx, &since, nil, false)
+	if err != nil {
+		return err
+	}
+
+	var latestUpdate time.Time
+	for _, thread := range threads {
+		threadData, err := json.Marshal(thread)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(AccountNotification{Account: account.Login, Thread: threadData})
+		if err != nil {
+			return err
+		}
+		// Processing is idempotent, so a partly enqueued batch is simply fetched again
+		if err := h.enqueuer.EnqueueProcessNotification(ctx, userID, data); err != nil {
+			return err
+		}
+		if thread.UpdatedAt.After(latestUpdate) {
+			latestUpdate = thread.UpdatedAt
+		}
+	}
+
+	if latestUpdate.IsZero() {
+		return nil
+	}
+	h.logger.Debug("enqueued linked account notifications",
+		zap.String("account", account.Login),
+		zap.Int("count", len(threads)))
+	return h.store.UpdateLinkedAccountSyncedAt(ctx, userID, account.Login, latestUpdate)
+}
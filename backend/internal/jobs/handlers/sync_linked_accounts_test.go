@@ -0,0 +1,151 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	coregithub "github.com/octobud-hq/octobud/backend/internal/core/github"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// fakeAccountClients implements AccountClientProvider for testing
+type fakeAccountClients struct {
+	clients map[string]*githubmocks.MockClient
+}
+
+func (f *fakeAccountClients) AccountClient(
+	_ context.Context,
+	login string,
+) (githubinterfaces.Client, error) {
+	client, ok := f.clients[login]
+	if !ok {
+		return nil, coregithub.ErrAccountNotLinked
+	}
+	return client, nil
+}
+
+func TestSyncLinkedAccountsHandler_EnqueuesWrappedThreads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+	lastSynced := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2024, 1, 25, 9, 0, 0, 0, time.UTC)
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().ListLinkedAccounts(gomock.Any(), "test-user-id").Return([]db.LinkedAccount{
+		{Login: "octocat-work"},
+		{Login: "octocat-oss", LastSyncedAt: sql.NullTime{Time: lastSynced, Valid: true}},
+	}, nil)
+
+	workClient := githubmocks.NewMockClient(ctrl)
+	workClient.EXPECT().
+		FetchNotifications(gomock.Any(), gomock.Any(), nil, false).
+		DoAndReturn(func(
+			_ context.Context,
+			since, _ *time.Time,
+			_ bool,
+		) ([]types.NotificationThread, error) {
+			// The first sync of an account goes back a fixed window
+			require.Equal(t, now.AddDate(0, 0, -linkedAccountInitialSyncDays), *since)
+			return []types.NotificationThread{
+				{ID: "notif-1", UpdatedAt: latest.Add(-time.Hour)},
+				{ID: "notif-2", UpdatedAt: latest},
+			}, nil
+		})
+	ossClient := githubmocks.NewMockClient(ctrl)
+	ossClient.EXPECT().
+		FetchNotifications(gomock.Any(), &lastSynced, nil, false).
+		Return(nil, nil)
+
+	mockStore.EXPECT().
+		UpdateLinkedAccountSyncedAt(gomock.Any(), "test-user-id", "octocat-work", latest).
+		Return(nil)
+
+	enqueuer := &mockEnqueuer{}
+	handler := NewSyncLinkedAccountsHandler(
+		mockStore,
+		&fakeAccountClients{clients: map[string]*githubmocks.MockClient{
+			"octocat-work": workClient,
+			"octocat-oss":  ossClient,
+		}},
+		enqueuer,
+		func() time.Time { return now },
+		zap.NewNop(),
+	)
+
+	require.NoError(t, handler.Handle(context.Background(), "test-user-id"))
+	require.Len(t, enqueuer.enqueuedData, 2)
+
+	var wrapped AccountNotification
+	require.NoError(t, json.Unmarshal(enqueuer.enqueuedData[0], &wrapped))
+	require.Equal(t, "octocat-work", wrapped.Account)
+	var thread types.NotificationThread
+	require.NoError(t, json.Unmarshal(wrapped.Thread, &thread))
+	require.Equal(t, "notif-1", thread.ID)
+}
+
+func TestSyncLinkedAccountsHandler_FailingAccountDoesNotStopOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().ListLinkedAccounts(gomock.Any(), "test-user-id").Return([]db.LinkedAccount{
+		{Login: "broken"},
+		{Login: "octocat-work"},
+	}, nil)
+
+	brokenClient := githubmocks.NewMockClient(ctrl)
+	brokenClient.EXPECT().
+		FetchNotifications(gomock.Any(), gomock.Any(), nil, false).
+		Return(nil, errors.New("401 bad credentials"))
+	workClient := githubmocks.NewMockClient(ctrl)
+	workClient.EXPECT().
+		FetchNotifications(gomock.Any(), gomock.Any(), nil, false).
+		Return([]types.NotificationThread{{ID: "notif-1", UpdatedAt: time.Now()}}, nil)
+	mockStore.EXPECT().
+		UpdateLinkedAccountSyncedAt(gomock.Any(), "test-user-id", "octocat-work", gomock.Any()).
+		Return(nil)
+
+	enqueuer := &mockEnqueuer{}
+	handler := NewSyncLinkedAccountsHandler(
+		mockStore,
+		&fakeAccountClients{clients: map[string]*githubmocks.MockClient{
+			"broken":       brokenClient,
+			"octocat-work": workClient,
+		}},
+		enqueuer,
+		time.Now,
+		zap.NewNop(),
+	)
+
+	require.Error(t, handler.Handle(context.Background(), "test-user-id"))
+	require.Len(t, enqueuer.enqueuedData, 1)
+}
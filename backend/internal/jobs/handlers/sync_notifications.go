@@ -23,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
 )
 
@@ -58,6 +59,8 @@ type SyncResult struct {
 	LatestUpdate       time.Time
 	OldestNotification time.Time
 	IsInitialSync      bool
+	// ETag is the new notifications ETag to store, empty when it didn't change
+	ETag string
 }
 
 // Handle syncs notifications from GitHub.
@@ -77,36 +80,28 @@ func (h *SyncNotificationsHandler) Handle(ctx context.Context, userID string) (*
 		return nil, nil
 	}
 
-	// Log sync status
 	if syncCtx.IsInitialSync {
-		if syncCtx.OldestNotificationSyncedAt.IsZero() {
-			h.logger.Info("initial sync starting")
-		} else {
-			h.logger.Info("initial sync in progress",
-				zap.Time("oldestSoFar", syncCtx.OldestNotificationSyncedAt))
-		}
+		return h.handleInitialSync(ctx, userID, syncCtx)
 	}
 
 	// Fetch notifications from GitHub
-	threads, err := h.syncService.FetchNotificationsToSync(ctx, syncCtx)
+	fetched, err := h.syncService.FetchNotificationsToSync(ctx, syncCtx)
 	if err != nil {
 		return nil, err
 	}
 
-	// If this was an initial sync and we found zero notifications, mark as complete
-	if syncCtx.IsInitialSync && len(threads) == 0 {
-		h.logger.Info("initial sync found 0 notifications, marking as complete")
-		now := time.Now().UTC()
-		var zeroTime time.Time
-		if err := h.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
-			ctx, userID, zeroTime, &now, nil,
-		); err != nil {
-			h.logger.Warn(
-				"failed to mark initial sync complete with 0 notifications",
-				zap.Error(err),
-			)
-		}
-		return nil, nil
+	// Nothing changed since the last poll, so there is nothing to process
+	if fetched.NotModified {
+		return &SyncResult{
+			UserID:  userID,
+			Threads: []types.NotificationThread{},
+		}, nil
+	}
+
+	threads := fetched.Threads
+	var etag string
+	if fetched.ETag != syncCtx.NotificationETag {
+		etag = fetched.ETag
 	}
 
 	if len(threads) == 0 {
@@ -114,21 +109,139 @@ func (h *SyncNotificationsHandler) Handle(ctx context.Context, userID string) (*
 		// Return a result with zero LatestUpdate so we can still update LastSuccessfulPoll
 		// even when there are no new notifications
 		return &SyncResult{
-			UserID:        userID,
-			Threads:       []types.NotificationThread{},
-			LatestUpdate:  time.Time{}, // Zero time indicates no new notifications
-			IsInitialSync: syncCtx.IsInitialSync,
+			UserID:       userID,
+			Threads:      []types.NotificationThread{},
+			LatestUpdate: time.Time{}, // Zero time indicates no new notifications
+			ETag:         etag,
 		}, nil
 	}
 
 	h.logger.Info("processing notifications", zap.Int("count", len(threads)))
 
 	result := &SyncResult{
-		UserID:        userID,
-		Threads:       threads,
-		IsInitialSync: syncCtx.IsInitialSync,
+		UserID:  userID,
+		Threads: threads,
+		ETag:    etag,
+	}
+	if err := h.enqueueThreads(ctx, userID, threads); err != nil {
+		return nil, err
+	}
+	for _, thread := range threads {
+		result.track(thread)
+	}
+
+	return result, nil
+}
+
+// handleInitialSync fetches the initial sync a page at a time, newest first, recording
+// progress after each page is queued. An interrupted initial sync resumes from the
+// oldest notification it queued instead of starting over.
+func (h *SyncNotificationsHandler) handleInitialSync(
+	ctx context.Context,
+	userID string,
+	syncCtx sync.SyncContext,
+) (*SyncResult, error) {
+	progress := syncCtx.InitialSyncProgress
+	var before *time.Time
+	if progress.Pages == 0 {
+		h.logger.Info("initial sync starting")
+		progress = models.InitialSyncProgress{StartedAt: time.Now().UTC()}
+	} else {
+		h.logger.Info("resuming initial sync",
+			zap.Int("pages", progress.Pages),
+			zap.Int("queued", progress.Queued),
+			zap.Time("oldestSoFar", progress.OldestNotification))
+		// Notifications updated in the same second as the oldest one are queued again,
+		// which is safe because processing is idempotent
+		if !progress.OldestNotification.IsZero() {
+			resumeAt := progress.OldestNotification.Add(time.Second)
+			before = &resumeAt
+		}
+	}
+
+	result := &SyncResult{
+		UserID:             userID,
+		Threads:            []types.NotificationThread{},
+		LatestUpdate:       progress.LatestNotification,
+		OldestNotification: progress.OldestNotification,
+		IsInitialSync:      true,
 	}
+	seen := make(map[string]bool)
 
+	for syncCtx.MaxCount == nil || progress.Queued < *syncCtx.MaxCount {
+		page, more, err := h.syncService.FetchInitialSyncPage(ctx, syncCtx, before)
+		if err != nil {
+			return nil, err
+		}
+
+		// Moving before back a second at a time returns the oldest of the last page again
+		threads := make([]types.NotificationThread, 0, len(page))
+		for _, thread := range page {
+			if !seen[thread.ID] {
+				seen[thread.ID] = true
+				threads = append(threads, thread)
+			}
+		}
+		if syncCtx.MaxCount != nil && progress.Queued+len(threads) > *syncCtx.MaxCount {
+			threads = threads[:*syncCtx.MaxCount-progress.Queued]
+			more = false
+		}
+
+		if err := h.enqueueThreads(ctx, userID, threads); err != nil {
+			return nil, err
+		}
+		for _, thread := range threads {
+			result.track(thread)
+		}
+		result.Threads = append(result.Threads, threads...)
+
+		progress.Pages++
+		progress.Queued += len(threads)
+		progress.LatestNotification = result.LatestUpdate
+		progress.OldestNotification = result.OldestNotification
+		if err := h.syncService.SaveInitialSyncProgress(ctx, userID, progress); err != nil {
+			return nil, err
+		}
+
+		if !more || len(page) == 0 || result.OldestNotification.IsZero() {
+			break
+		}
+		next := result.OldestNotification.Add(time.Second)
+		if before != nil && !next.Before(*before) {
+			// A whole page was updated within the same second, so step past it
+			next = result.OldestNotification
+		}
+		if before != nil && !next.Before(*before) {
+			break
+		}
+		before = &next
+	}
+
+	h.logger.Info("initial sync fetched",
+		zap.Int("pages", progress.Pages),
+		zap.Int("queued", progress.Queued))
+	return result, nil
+}
+
+// track folds a queued thread into the newest and oldest update of the sync
+func (r *SyncResult) track(thread types.NotificationThread) {
+	if thread.UpdatedAt.After(r.LatestUpdate) {
+		r.LatestUpdate = thread.UpdatedAt
+	}
+	if r.IsInitialSync &&
+		(r.OldestNotification.IsZero() || thread.UpdatedAt.Before(r.OldestNotification)) {
+		r.OldestNotification = thread.UpdatedAt
+	}
+}
+
+// enqueueThreads queues a processing job for each thread. Every thread is marshaled
+// before any is queued, and a failure aborts the batch so the sync state isn't updated
+// and the threads are fetched again on the next sync.
+func (h *SyncNotificationsHandler) enqueueThreads(
+	ctx context.Context,
+	userID string,
+	threads []types.NotificationThread,
+) error {
 	// First, marshal all notifications - if any fail, don't proceed
 	type preparedJob struct {
 		thread types.NotificationThread
@@ -142,7 +255,7 @@ func (h *SyncNotificationsHandler) Handle(ctx context.Context, userID string) (*
 			h.logger.Error("failed to marshal notification thread - aborting batch",
 				zap.String("threadID", thread.ID),
 				zap.Error(err))
-			return nil, err
+			return err
 		}
 		prepared = append(prepared, preparedJob{thread: thread, data: threadData})
 	}
@@ -165,29 +278,17 @@ func (h *SyncNotificationsHandler) Handle(ctx context.Context, userID string) (*
 				zap.Int("enqueuedSoFar", enqueuedCount),
 				zap.Int("remaining", len(prepared)-i),
 				zap.Error(err))
-			return nil, err
+			return err
 		}
 
 		enqueuedCount++
-
-		if job.thread.UpdatedAt.After(result.LatestUpdate) {
-			result.LatestUpdate = job.thread.UpdatedAt
-		}
-
-		// Track oldest notification for initial sync
-		if syncCtx.IsInitialSync {
-			if result.OldestNotification.IsZero() ||
-				job.thread.UpdatedAt.Before(result.OldestNotification) {
-				result.OldestNotification = job.thread.UpdatedAt
-			}
-		}
 	}
 
 	h.logger.Info("finished enqueueing notifications",
 		zap.Int("enqueued", enqueuedCount),
 		zap.Int("total", len(threads)))
 
-	return result, nil
+	return nil
 }
 
 // UpdateSyncState updates the sync state after processing.
@@ -201,18 +302,41 @@ func (h *SyncNotificationsHandler) UpdateSyncState(ctx context.Context, result *
 
 	if result.IsInitialSync {
 		now := time.Now().UTC()
-		return h.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
+		var oldest *time.Time // Unchanged when the initial sync found no notifications
+		if !result.OldestNotification.IsZero() {
+			oldest = &result.OldestNotification
+		}
+		if err := h.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
 			ctx,
 			result.UserID,
 			result.LatestUpdate,
 			&now,
-			&result.OldestNotification,
-		)
+			oldest,
+		); err != nil {
+			return err
+		}
+		return h.storeETag(ctx, result)
 	}
 
 	// Update sync state even if LatestUpdate is zero.
 	// UpdateSyncStateAfterProcessing always updates LastSuccessfulPoll to the current time,
 	// and only updates LatestNotificationAt if LatestUpdate is non-zero.
 	// This ensures LastSuccessfulPoll reflects when the poll occurred, not when the last notification was received.
-	return h.syncService.UpdateSyncStateAfterProcessing(ctx, result.UserID, result.LatestUpdate)
+	if err := h.syncService.UpdateSyncStateAfterProcessing(
+		ctx,
+		result.UserID,
+		result.LatestUpdate,
+	); err != nil {
+		return err
+	}
+	return h.storeETag(ctx, result)
+}
+
+// storeETag saves the ETag once the response's notifications are queued, so a 304 on the
+// next poll never hides notifications that weren't processed.
+func (h *SyncNotificationsHandler) storeETag(ctx context.Context, result *SyncResult) error {
+	if result.ETag == "" {
+		return nil
+	}
+	return h.syncService.SetNotificationETag(ctx, result.UserID, result.ETag)
 }
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
 	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
 )
@@ -76,7 +77,8 @@ func TestSyncNotificationsHandler_Success(t *testing.T) {
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
 	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
-	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).Return(notifications, nil)
+	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationsResult{Threads: notifications}, nil)
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
@@ -89,6 +91,31 @@ func TestSyncNotificationsHandler_Success(t *testing.T) {
 	require.Len(t, enqueuer.enqueuedData, 2)
 }
 
+func TestSyncNotificationsHandler_LatestUpdateOutOfOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Pages come back newest first, but retried pages can repeat or reorder threads,
+	// so the latest update is the newest of all rather than the first or last
+	notifications := []types.NotificationThread{
+		{ID: "notif-1", UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-2", UpdatedAt: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{ID: "notif-3", UpdatedAt: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)},
+	}
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true}
+	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
+	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationsResult{Threads: notifications}, nil)
+
+	handler := NewSyncNotificationsHandler(mockSync, &mockEnqueuer{}, zap.NewNop())
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), result.LatestUpdate)
+}
+
 func TestSyncNotificationsHandler_EmptyResults(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -98,7 +125,7 @@ func TestSyncNotificationsHandler_EmptyResults(t *testing.T) {
 	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return([]types.NotificationThread{}, nil)
+		Return(types.NotificationsResult{Threads: []types.NotificationThread{}}, nil)
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
@@ -140,7 +167,7 @@ func TestSyncNotificationsHandler_FetchError(t *testing.T) {
 	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(nil, errors.New("API error"))
+		Return(types.NotificationsResult{}, errors.New("API error"))
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
@@ -169,7 +196,8 @@ func TestSyncNotificationsHandler_QueueingFailure(t *testing.T) {
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
 	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
-	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).Return(notifications, nil)
+	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationsResult{Threads: notifications}, nil)
 
 	enqueuer := &mockEnqueuer{err: errors.New("queue full")}
 	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
@@ -199,7 +227,18 @@ func TestSyncNotificationsHandler_InitialSync(t *testing.T) {
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: true}
 	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
-	mockSync.EXPECT().FetchNotificationsToSync(gomock.Any(), syncCtx).Return(notifications, nil)
+	mockSync.EXPECT().FetchInitialSyncPage(gomock.Any(), syncCtx, (*time.Time)(nil)).
+		Return(notifications, false, nil)
+	mockSync.EXPECT().
+		SaveInitialSyncProgress(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, progress models.InitialSyncProgress) error {
+			require.False(t, progress.StartedAt.IsZero())
+			require.Equal(t, 1, progress.Pages)
+			require.Equal(t, 2, progress.Queued)
+			require.Equal(t, notifications[0].UpdatedAt, progress.LatestNotification)
+			require.Equal(t, notifications[1].UpdatedAt, progress.OldestNotification)
+			return nil
+		})
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
@@ -208,10 +247,98 @@ func TestSyncNotificationsHandler_InitialSync(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	require.True(t, result.IsInitialSync)
+	require.Len(t, enqueuer.enqueuedData, 2)
 	// Should track oldest notification
 	require.Equal(t, time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC), result.OldestNotification)
 }
 
+func TestSyncNotificationsHandler_InitialSyncResumes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	latest := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	oldestSoFar := time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)
+	syncCtx := sync.SyncContext{
+		IsSyncConfigured: true,
+		IsInitialSync:    true,
+		InitialSyncProgress: models.InitialSyncProgress{
+			StartedAt:          time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC),
+			Pages:              3,
+			Queued:             150,
+			LatestNotification: latest,
+			OldestNotification: oldestSoFar,
+		},
+	}
+	resumeAt := oldestSoFar.Add(time.Second)
+	older := time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
+	mockSync.EXPECT().FetchInitialSyncPage(gomock.Any(), syncCtx, &resumeAt).
+		Return([]types.NotificationThread{
+			{ID: "notif-150", UpdatedAt: oldestSoFar}, // Re-queued, same second as the cursor
+			{ID: "notif-151", UpdatedAt: older},
+		}, false, nil)
+	mockSync.EXPECT().
+		SaveInitialSyncProgress(gomock.Any(), "test-user-id", models.InitialSyncProgress{
+			StartedAt:          syncCtx.InitialSyncProgress.StartedAt,
+			Pages:              4,
+			Queued:             152,
+			LatestNotification: latest,
+			OldestNotification: older,
+		}).
+		Return(nil)
+
+	enqueuer := &mockEnqueuer{}
+	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.True(t, result.IsInitialSync)
+	require.Equal(t, latest, result.LatestUpdate)
+	require.Equal(t, older, result.OldestNotification)
+}
+
+func TestSyncNotificationsHandler_InitialSyncStopsAtMaxCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	maxCount := 3
+	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: true, MaxCount: &maxCount}
+	first := []types.NotificationThread{
+		{ID: "notif-1", UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-2", UpdatedAt: time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)},
+	}
+	second := []types.NotificationThread{
+		{ID: "notif-2", UpdatedAt: time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-3", UpdatedAt: time.Date(2024, 1, 13, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-4", UpdatedAt: time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC)},
+	}
+	nextPage := first[1].UpdatedAt.Add(time.Second)
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().GetSyncContext(gomock.Any(), "test-user-id").Return(syncCtx, nil)
+	gomock.InOrder(
+		mockSync.EXPECT().FetchInitialSyncPage(gomock.Any(), syncCtx, (*time.Time)(nil)).
+			Return(first, true, nil),
+		mockSync.EXPECT().FetchInitialSyncPage(gomock.Any(), syncCtx, &nextPage).
+			Return(second, true, nil),
+	)
+	mockSync.EXPECT().
+		SaveInitialSyncProgress(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	enqueuer := &mockEnqueuer{}
+	handler := NewSyncNotificationsHandler(mockSync, enqueuer, zap.NewNop())
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Len(t, result.Threads, 3)
+	require.Len(t, enqueuer.enqueuedData, 3)
+	require.Equal(t, "notif-3", result.Threads[2].ID)
+}
+
 func TestSyncNotificationsHandler_UpdateSyncState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
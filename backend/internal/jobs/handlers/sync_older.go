@@ -22,6 +22,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
 )
 
@@ -59,7 +60,10 @@ func NewSyncOlderHandler(
 	}
 }
 
-// Handle syncs older notifications from GitHub.
+// Handle syncs older notifications from GitHub a batch at a time, newest first, moving
+// the before cursor back to the oldest notification of each batch. The oldest synced
+// timestamp is updated after every batch, so a backfill that stops partway still
+// extends the archive and the next one carries on from where it got to.
 func (h *SyncOlderHandler) Handle(ctx context.Context, args SyncOlderArgs) error {
 	// Compute the time range
 	since := args.UntilTime.AddDate(0, 0, -args.Days)
@@ -72,30 +76,83 @@ func (h *SyncOlderHandler) Handle(ctx context.Context, args SyncOlderArgs) error
 		zap.Any("maxCount", args.MaxCount),
 		zap.Bool("unreadOnly", args.UnreadOnly))
 
-	// Fetch older notifications using the sync service
-	threads, err := h.syncService.FetchOlderNotificationsToSync(
-		ctx,
-		since,
-		args.UntilTime,
-		args.MaxCount,
-		args.UnreadOnly,
-	)
-	if err != nil {
-		h.logger.Error("failed to fetch older notifications", zap.Error(err))
-		return err
+	before := args.UntilTime
+	seen := make(map[string]bool)
+	var queued, batches int
+	// Track the oldest notification for updating sync state
+	var oldestNotification time.Time
+
+	for args.MaxCount == nil || queued < *args.MaxCount {
+		page, more, err := h.syncService.FetchOlderNotificationsPage(
+			ctx,
+			since,
+			before,
+			args.UnreadOnly,
+		)
+		if err != nil {
+			h.logger.Error("failed to fetch older notifications",
+				zap.Int("batches", batches),
+				zap.Error(err))
+			return err
+		}
+		batches++
+
+		// Moving before back a second at a time returns the oldest of the last batch again
+		threads := make([]types.NotificationThread, 0, len(page))
+		for _, thread := range page {
+			if !seen[thread.ID] {
+				seen[thread.ID] = true
+				threads = append(threads, thread)
+			}
+		}
+		if args.MaxCount != nil && queued+len(threads) > *args.MaxCount {
+			threads = threads[:*args.MaxCount-queued]
+			more = false
+		}
+
+		batchOldest := h.enqueue(ctx, args.UserID, threads)
+		queued += len(threads)
+		if !batchOldest.IsZero() &&
+			(oldestNotification.IsZero() || batchOldest.Before(oldestNotification)) {
+			oldestNotification = batchOldest
+			h.saveOldest(ctx, args.UserID, oldestNotification)
+		}
+
+		if !more || len(page) == 0 || oldestNotification.IsZero() {
+			break
+		}
+		next := oldestNotification.Add(time.Second)
+		if !next.Before(before) {
+			// A whole batch was updated within the same second, so step past it
+			next = oldestNotification
+		}
+		if !next.Before(before) {
+			break
+		}
+		before = next
 	}
 
-	if len(threads) == 0 {
+	if queued == 0 {
 		h.logger.Info("no older notifications found in time range")
 		return nil
 	}
 
-	h.logger.Info("processing older notifications", zap.Int("count", len(threads)))
+	h.logger.Info("completed sync older notifications",
+		zap.Int("processed", queued),
+		zap.Int("batches", batches),
+		zap.Time("oldestNotification", oldestNotification))
 
-	// Track the oldest notification for updating sync state
-	var oldestNotification time.Time
+	return nil
+}
 
-	// Enqueue individual processing jobs for each notification
+// enqueue queues a processing job for each thread in a batch and returns the oldest
+// one queued. Threads that fail to queue are skipped.
+func (h *SyncOlderHandler) enqueue(
+	ctx context.Context,
+	userID string,
+	threads []types.NotificationThread,
+) time.Time {
+	var oldest time.Time
 	for _, thread := range threads {
 		threadData, err := json.Marshal(thread)
 		if err != nil {
@@ -105,38 +162,32 @@ func (h *SyncOlderHandler) Handle(ctx context.Context, args SyncOlderArgs) error
 			continue
 		}
 
-		if err := h.enqueuer.EnqueueProcessNotification(ctx, args.UserID, threadData); err != nil {
+		if err := h.enqueuer.EnqueueProcessNotification(ctx, userID, threadData); err != nil {
 			h.logger.Warn("failed to enqueue notification processing",
 				zap.String("threadID", thread.ID),
 				zap.Error(err))
 			continue
 		}
 
-		// Track oldest notification
-		if oldestNotification.IsZero() || thread.UpdatedAt.Before(oldestNotification) {
-			oldestNotification = thread.UpdatedAt
+		if oldest.IsZero() || thread.UpdatedAt.Before(oldest) {
+			oldest = thread.UpdatedAt
 		}
 	}
+	return oldest
+}
 
-	// Update oldest_notification_synced_at if we found older notifications
-	if !oldestNotification.IsZero() {
-		h.logger.Info("updating oldest notification synced timestamp",
-			zap.Time("oldestNotification", oldestNotification))
+// saveOldest moves oldest_notification_synced_at back to the oldest notification queued
+func (h *SyncOlderHandler) saveOldest(ctx context.Context, userID string, oldest time.Time) {
+	h.logger.Info("updating oldest notification synced timestamp",
+		zap.Time("oldestNotification", oldest))
 
-		if err := h.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
-			ctx,
-			args.UserID,
-			time.Time{}, // Don't update latest_notification_at
-			nil,         // Don't change initial_sync_completed_at
-			&oldestNotification,
-		); err != nil {
-			h.logger.Warn("failed to update oldest notification timestamp", zap.Error(err))
-		}
+	if err := h.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
+		ctx,
+		userID,
+		time.Time{}, // Don't update latest_notification_at
+		nil,         // Don't change initial_sync_completed_at
+		&oldest,
+	); err != nil {
+		h.logger.Warn("failed to update oldest notification timestamp", zap.Error(err))
 	}
-
-	h.logger.Info("completed sync older notifications",
-		zap.Int("processed", len(threads)),
-		zap.Time("oldestNotification", oldestNotification))
-
-	return nil
 }
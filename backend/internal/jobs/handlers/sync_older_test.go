@@ -57,8 +57,8 @@ func TestSyncOlderHandler_Success(t *testing.T) {
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
-		Return(notifications, nil)
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+		Return(notifications, false, nil)
 	// The handler will pass:
 	// - completedAt: nil (4th param)
 	// - oldestNotification: pointer to Jan 5, 2024 (the oldest notification)
@@ -117,8 +117,8 @@ func TestSyncOlderHandler_EmptyResults(t *testing.T) {
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
-		Return([]types.NotificationThread{}, nil)
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+		Return([]types.NotificationThread{}, false, nil)
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncOlderHandler(mockSync, enqueuer, zap.NewNop())
@@ -141,23 +141,25 @@ func TestSyncOlderHandler_WithMaxCount(t *testing.T) {
 
 	untilTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 	sinceTime := untilTime.AddDate(0, 0, -30)
-	maxCount := 100
+	maxCount := 1
 
 	notifications := []types.NotificationThread{
 		{ID: "notif-old-1", UpdatedAt: time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-old-2", UpdatedAt: time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC)},
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	// More notifications follow, but the limit is reached in the first batch
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, &maxCount, false).
-		Return(notifications, nil)
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+		Return(notifications, true, nil)
 
 	// Use Do to capture arguments to avoid nil pointer panic
-	// We don't need to verify the values in this test, just avoid the panic
+	var capturedOldest *time.Time
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(gomock.Any(), "test-user-id", gomock.Any(), gomock.Any(), gomock.Any()).
-		Do(func(_ context.Context, _ string, _ time.Time, _ *time.Time, _ *time.Time) {
-			// Capture but don't verify - just avoiding gomock's nil pointer formatting
+		Do(func(_ context.Context, _ string, _ time.Time, _ *time.Time, oldest *time.Time) {
+			capturedOldest = oldest
 		}).
 		Return(nil)
 
@@ -174,6 +176,9 @@ func TestSyncOlderHandler_WithMaxCount(t *testing.T) {
 
 	err := handler.Handle(context.Background(), args)
 	require.NoError(t, err)
+	require.Len(t, enqueuer.enqueuedData, 1)
+	require.NotNil(t, capturedOldest)
+	require.Equal(t, notifications[0].UpdatedAt, *capturedOldest)
 }
 
 func TestSyncOlderHandler_WithUnreadOnly(t *testing.T) {
@@ -189,8 +194,8 @@ func TestSyncOlderHandler_WithUnreadOnly(t *testing.T) {
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), true).
-		Return(notifications, nil)
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, true).
+		Return(notifications, false, nil)
 
 	// Use Do to capture arguments to avoid nil pointer panic
 	mockSync.EXPECT().
@@ -224,8 +229,8 @@ func TestSyncOlderHandler_FetchError(t *testing.T) {
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
-		Return(nil, errors.New("API error"))
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+		Return(nil, false, errors.New("API error"))
 
 	enqueuer := &mockEnqueuer{}
 	handler := NewSyncOlderHandler(mockSync, enqueuer, zap.NewNop())
@@ -259,8 +264,8 @@ func TestSyncOlderHandler_TracksOldestNotification(t *testing.T) {
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
 	mockSync.EXPECT().
-		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
-		Return(notifications, nil)
+		FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+		Return(notifications, false, nil)
 
 	// Use Do to capture arguments to avoid nil pointer panic
 	var capturedCompletedAt *time.Time
@@ -298,3 +303,53 @@ func TestSyncOlderHandler_TracksOldestNotification(t *testing.T) {
 	require.NotNil(t, capturedOldest, "oldestNotification should not be nil")
 	require.Equal(t, oldestTime, *capturedOldest, "oldestNotification should point to Jan 5, 2024")
 }
+
+func TestSyncOlderHandler_FetchesInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	untilTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	sinceTime := untilTime.AddDate(0, 0, -30)
+
+	first := []types.NotificationThread{
+		{ID: "notif-old-1", UpdatedAt: time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-old-2", UpdatedAt: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)},
+	}
+	second := []types.NotificationThread{
+		{ID: "notif-old-2", UpdatedAt: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)}, // Same second as the cursor
+		{ID: "notif-old-3", UpdatedAt: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+	}
+	secondBefore := first[1].UpdatedAt.Add(time.Second)
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	gomock.InOrder(
+		mockSync.EXPECT().
+			FetchOlderNotificationsPage(gomock.Any(), sinceTime, untilTime, false).
+			Return(first, true, nil),
+		mockSync.EXPECT().
+			FetchOlderNotificationsPage(gomock.Any(), sinceTime, secondBefore, false).
+			Return(second, false, nil),
+	)
+
+	// The oldest synced timestamp moves back after each batch
+	var savedOldest []time.Time
+	mockSync.EXPECT().
+		UpdateSyncStateAfterProcessingWithInitialSync(gomock.Any(), "test-user-id", time.Time{}, gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ string, _ time.Time, _ *time.Time, oldest *time.Time) {
+			savedOldest = append(savedOldest, *oldest)
+		}).
+		Return(nil).
+		Times(2)
+
+	enqueuer := &mockEnqueuer{}
+	handler := NewSyncOlderHandler(mockSync, enqueuer, zap.NewNop())
+
+	err := handler.Handle(context.Background(), SyncOlderArgs{
+		UserID:    "test-user-id",
+		Days:      30,
+		UntilTime: untilTime,
+	})
+	require.NoError(t, err)
+	require.Len(t, enqueuer.enqueuedData, 3)
+	require.Equal(t, []time.Time{first[1].UpdatedAt, second[1].UpdatedAt}, savedOldest)
+}
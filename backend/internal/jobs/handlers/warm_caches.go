@@ -0,0 +1,275 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/timeline"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/query"
+)
+
+const (
+	// warmSubjectsPerQuery is how many notifications at the top of each recently opened
+	// list have their subjects refreshed
+	warmSubjectsPerQuery = 5
+	// maxWarmSubjects caps the subject requests one warm-up sends to GitHub
+	maxWarmSubjects = 20
+	// staleSubjectAge is how old a stored subject can be before warm-up refreshes it
+	staleSubjectAge = 10 * time.Minute
+)
+
+// SubjectRefresher fetches a notification's subject from GitHub and stores it
+type SubjectRefresher interface {
+	RefreshSubjectData(ctx context.Context, userID, githubID string) (bool, error)
+}
+
+// WarmCachesResult summarizes what a warm-up prepared
+type WarmCachesResult struct {
+	Counts    bool // Whether view counts were warmed
+	Subjects  int  // Subjects refreshed from GitHub
+	Timelines int  // Timeline pages warmed
+}
+
+// WarmCachesHandler prepares what the app shows first after launch: the sidebar counts,
+// the subjects at the top of recently opened lists and of recently opened notifications,
+// and those notifications' timelines. Requests go out one at a time so warm-up never
+// crowds out the first sync.
+type WarmCachesHandler struct {
+	store     db.Store
+	prewarm   prewarm.PrewarmService
+	views     view.ViewService
+	subjects  SubjectRefresher
+	timelines timeline.TimelineService
+	client    githubinterfaces.Client
+	now       func() time.Time
+	logger    *zap.Logger
+}
+
+// NewWarmCachesHandler creates a new WarmCachesHandler
+func NewWarmCachesHandler(
+	store db.Store,
+	prewarmSvc prewarm.PrewarmService,
+	views view.ViewService,
+	now func() time.Time,
+	logger *zap.Logger,
+) *WarmCachesHandler {
+	return &WarmCachesHandler{
+		store:   store,
+		prewarm: prewarmSvc,
+		views:   views,
+		now:     now,
+		logger:  logger,
+	}
+}
+
+// WithSubjects enables refreshing stale subjects from GitHub
+func (h *WarmCachesHandler) WithSubjects(subjects SubjectRefresher) *WarmCachesHandler {
+	h.subjects = subjects
+	return h
+}
+
+// WithTimelines enables warming the first timeline page of recently opened notifications
+func (h *WarmCachesHandler) WithTimelines(
+	timelines timeline.TimelineService,
+	client githubinterfaces.Client,
+) *WarmCachesHandler {
+	h.timelines = timelines
+	h.client = client
+	return h
+}
+
+// Handle warms the user's caches. Each step is best effort: a failure is logged and the
+// next step still runs. Only failing to read what was opened recently is returned.
+func (h *WarmCachesHandler) Handle(ctx context.Context, userID string) (WarmCachesResult, error) {
+	var result WarmCachesResult
+
+	views, err := h.views.ListViewsWithCounts(ctx, userID)
+	if err != nil {
+		h.logger.Warn("failed to warm view counts", zap.Error(err))
+	} else {
+		h.prewarm.PutViewCounts(userID, views)
+		result.Counts = true
+	}
+
+	opened, err := h.recentNotifications(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+	listed, err := h.recentListTops(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
+	// Storage settings can rule out fetching subjects or holding timelines, which carry
+	// comment bodies. Without them, warm-up stops short of both.
+	settings, err := h.syncSettings(ctx)
+	if err != nil {
+		h.logger.Warn("failed to load sync settings, skipping subjects and timelines", zap.Error(err))
+		return result, nil
+	}
+	if settings.FetchesSubjects() {
+		result.Subjects = h.refreshSubjects(ctx, userID, append(opened, listed...))
+	}
+	if settings.CachesComments() {
+		result.Timelines = h.warmTimelines(ctx, userID, opened)
+	}
+	return result, nil
+}
+
+// syncSettings loads the user's sync settings
+func (h *WarmCachesHandler) syncSettings(ctx context.Context) (*models.SyncSettings, error) {
+	user, err := h.store.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+}
+
+// recentNotifications loads the notifications opened most recently, skipping any that
+// have since been deleted
+func (h *WarmCachesHandler) recentNotifications(
+	ctx context.Context,
+	userID string,
+) ([]db.Notification, error) {
+	entries, err := h.prewarm.RecentAccess(ctx, userID, prewarm.KindNotification)
+	if err != nil {
+		return nil, err
+	}
+	notifications := make([]db.Notification, 0, len(entries))
+	for _, entry := range entries {
+		n, err := h.store.GetNotificationByGithubID(ctx, userID, entry.Target)
+		if err != nil {
+			continue
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+// recentListTops loads the first few notifications of each recently opened list.
+// Queries that no longer parse are skipped.
+func (h *WarmCachesHandler) recentListTops(
+	ctx context.Context,
+	userID string,
+) ([]db.Notification, error) {
+	entries, err := h.prewarm.RecentAccess(ctx, userID, prewarm.KindQuery)
+	if err != nil {
+		return nil, err
+	}
+	var notifications []db.Notification
+	for _, entry := range entries {
+		dbQuery, err := query.BuildQuery(entry.Target, warmSubjectsPerQuery, 0)
+		if err != nil {
+			continue
+		}
+		list, err := h.store.ListNotificationsFromQuery(ctx, userID, dbQuery)
+		if err != nil {
+			h.logger.Warn("failed to list recent notifications",
+				zap.String("query", entry.Target), zap.Error(err))
+			continue
+		}
+		notifications = append(notifications, list.Notifications...)
+	}
+	return notifications, nil
+}
+
+// refreshSubjects refreshes stale subjects, in order and once each, up to maxWarmSubjects
+func (h *WarmCachesHandler) refreshSubjects(
+	ctx context.Context,
+	userID string,
+	notifications []db.Notification,
+) int {
+	if h.subjects == nil {
+		return 0
+	}
+	cutoff := h.now().Add(-staleSubjectAge)
+	seen := make(map[string]bool)
+	refreshed := 0
+	for _, n := range notifications {
+		if refreshed >= maxWarmSubjects || ctx.Err() != nil {
+			break
+		}
+		if seen[n.GithubID] {
+			continue
+		}
+		seen[n.GithubID] = true
+		if !n.SubjectURL.Valid || (n.SubjectFetchedAt.Valid && n.SubjectFetchedAt.Time.After(cutoff)) {
+			continue
+		}
+		if _, err := h.subjects.RefreshSubjectData(ctx, userID, n.GithubID); err != nil {
+			h.logger.Debug("failed to warm subject",
+				zap.String("github_id", n.GithubID), zap.Error(err))
+			continue
+		}
+		refreshed++
+	}
+	return refreshed
+}
+
+// warmTimelines fetches the first timeline page of each notification
+func (h *WarmCachesHandler) warmTimelines(
+	ctx context.Context,
+	userID string,
+	notifications []db.Notification,
+) int {
+	if h.timelines == nil || h.client == nil {
+		return 0
+	}
+	warmed := 0
+	for _, n := range notifications {
+		if ctx.Err() != nil {
+			break
+		}
+		if !timeline.SupportsTimeline(n.SubjectType) {
+			continue
+		}
+		var subjectRaw json.RawMessage
+		if n.SubjectRaw.Valid {
+			subjectRaw = n.SubjectRaw.RawMessage
+		}
+		subjectInfo, err := github.ExtractSubjectInfo(n.SubjectURL.String, subjectRaw)
+		if err != nil {
+			continue
+		}
+		result, err := h.timelines.FetchFilteredTimeline(
+			ctx,
+			h.client,
+			subjectInfo,
+			n.SubjectType,
+			prewarm.TimelinePerPage,
+			1,
+		)
+		if err != nil {
+			h.logger.Debug("failed to warm timeline",
+				zap.String("github_id", n.GithubID), zap.Error(err))
+			continue
+		}
+		h.prewarm.PutTimeline(userID, prewarm.TimelineKey(n, prewarm.TimelinePerPage, 1), result)
+		warmed++
+	}
+	return warmed
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	timelinemocks "github.com/octobud-hq/octobud/backend/internal/core/timeline/mocks"
+	viewmocks "github.com/octobud-hq/octobud/backend/internal/core/view/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	githubmocks "github.com/octobud-hq/octobud/backend/internal/github/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
+)
+
+func TestWarmCachesHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockViews := viewmocks.NewMockViewService(ctrl)
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockTimelines := timelinemocks.NewMockTimelineService(ctrl)
+	prewarmSvc := prewarm.NewService(mockStore, clock)
+
+	handler := handlers.NewWarmCachesHandler(mockStore, prewarmSvc, mockViews, clock, zap.NewNop()).
+		WithSubjects(mockSync).
+		WithTimelines(mockTimelines, githubmocks.NewMockClient(ctrl))
+
+	subjectURL := sql.NullString{
+		String: "https://api.github.com/repos/octo/repo/issues/7",
+		Valid:  true,
+	}
+	// Opened recently, with a subject fetched an hour ago
+	opened := db.Notification{
+		GithubID:         "opened",
+		SubjectType:      "Issue",
+		SubjectURL:       subjectURL,
+		SubjectRaw:       db.NullRawMessage{RawMessage: json.RawMessage(`{"number": 7}`), Valid: true},
+		SubjectFetchedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		GithubUpdatedAt:  sql.NullTime{Time: now.Add(-2 * time.Hour), Valid: true},
+	}
+	// At the top of a recently opened list, with a fresh subject
+	fresh := db.Notification{
+		GithubID:         "fresh",
+		SubjectType:      "Issue",
+		SubjectURL:       subjectURL,
+		SubjectFetchedAt: sql.NullTime{Time: now.Add(-time.Minute), Valid: true},
+	}
+
+	views := []models.View{{ID: "inbox", UnreadCount: 4}}
+	mockViews.EXPECT().ListViewsWithCounts(gomock.Any(), "test-user-id").Return(views, nil)
+
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindNotification, int64(prewarm.KeepRecent)).
+		Return([]db.RecentAccess{{Kind: prewarm.KindNotification, Target: "opened"}, {
+			Kind:   prewarm.KindNotification,
+			Target: "deleted",
+		}}, nil)
+	mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "opened").Return(opened, nil)
+	mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "deleted").
+		Return(db.Notification{}, sql.ErrNoRows)
+
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindQuery, int64(prewarm.KeepRecent)).
+		Return([]db.RecentAccess{{Kind: prewarm.KindQuery, Target: "in:inbox"}}, nil)
+	mockStore.EXPECT().ListNotificationsFromQuery(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.ListNotificationsFromQueryResult{
+			Notifications: []db.Notification{opened, fresh},
+			Total:         2,
+		}, nil)
+
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+
+	// Only the stale subject is refreshed, once even though it's listed twice
+	mockSync.EXPECT().RefreshSubjectData(gomock.Any(), "test-user-id", "opened").Return(false, nil)
+
+	timelineResult := &models.TimelineResult{Total: 1, Page: 1, PerPage: prewarm.TimelinePerPage}
+	mockTimelines.EXPECT().
+		FetchFilteredTimeline(gomock.Any(), gomock.Any(), gomock.Any(), "Issue", prewarm.TimelinePerPage, 1).
+		Return(timelineResult, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, handlers.WarmCachesResult{Counts: true, Subjects: 1, Timelines: 1}, result)
+
+	gotViews, ok := prewarmSvc.TakeViewCounts("test-user-id")
+	require.True(t, ok)
+	require.Equal(t, views, gotViews)
+
+	gotTimeline, ok := prewarmSvc.TakeTimeline(
+		"test-user-id",
+		prewarm.TimelineKey(opened, prewarm.TimelinePerPage, 1),
+	)
+	require.True(t, ok)
+	require.Equal(t, timelineResult, gotTimeline)
+}
+
+func TestWarmCachesHandler_Handle_KeepsGoingAfterFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockViews := viewmocks.NewMockViewService(ctrl)
+	prewarmSvc := prewarm.NewService(mockStore, time.Now)
+	handler := handlers.NewWarmCachesHandler(mockStore, prewarmSvc, mockViews, time.Now, zap.NewNop())
+
+	mockViews.EXPECT().ListViewsWithCounts(gomock.Any(), "test-user-id").
+		Return(nil, errors.New("database is locked"))
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindNotification, gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindQuery, gomock.Any()).
+		Return([]db.RecentAccess{{Kind: prewarm.KindQuery, Target: "is:"}}, nil)
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, handlers.WarmCachesResult{}, result)
+
+	_, ok := prewarmSvc.TakeViewCounts("test-user-id")
+	require.False(t, ok)
+}
+
+func TestWarmCachesHandler_Handle_RespectsStorageSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockViews := viewmocks.NewMockViewService(ctrl)
+	prewarmSvc := prewarm.NewService(mockStore, time.Now)
+	// Neither subjects nor timelines are fetched when only titles are kept
+	handler := handlers.NewWarmCachesHandler(mockStore, prewarmSvc, mockViews, time.Now, zap.NewNop()).
+		WithSubjects(syncmocks.NewMockSyncOperations(ctrl)).
+		WithTimelines(timelinemocks.NewMockTimelineService(ctrl), githubmocks.NewMockClient(ctrl))
+
+	opened := db.Notification{
+		GithubID:    "opened",
+		SubjectType: "Issue",
+		SubjectURL:  sql.NullString{String: "https://api.github.com/repos/octo/repo/issues/7", Valid: true},
+	}
+
+	mockViews.EXPECT().ListViewsWithCounts(gomock.Any(), "test-user-id").Return(nil, nil)
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindNotification, gomock.Any()).
+		Return([]db.RecentAccess{{Kind: prewarm.KindNotification, Target: "opened"}}, nil)
+	mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), "test-user-id", "opened").Return(opened, nil)
+	mockStore.EXPECT().
+		ListRecentAccess(gomock.Any(), "test-user-id", prewarm.KindQuery, gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		SyncSettings: db.NullRawMessage{RawMessage: json.RawMessage(`{"titlesOnly": true}`), Valid: true},
+	}, nil)
+
+	result, err := handler.Handle(context.Background(), "test-user-id")
+	require.NoError(t, err)
+	require.Equal(t, handlers.WarmCachesResult{Counts: true}, result)
+}
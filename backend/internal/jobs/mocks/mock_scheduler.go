@@ -69,6 +69,20 @@ func (mr *MockSchedulerMockRecorder) EnqueueApplyRulesToNotification(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueApplyRulesToNotification", reflect.TypeOf((*MockScheduler)(nil).EnqueueApplyRulesToNotification), ctx, userID, githubID)
 }
 
+// EnqueueMarkOnGitHub mocks base method.
+func (m *MockScheduler) EnqueueMarkOnGitHub(ctx context.Context, userID string, githubIDs []string, done bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueMarkOnGitHub", ctx, userID, githubIDs, done)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueMarkOnGitHub indicates an expected call of EnqueueMarkOnGitHub.
+func (mr *MockSchedulerMockRecorder) EnqueueMarkOnGitHub(ctx, userID, githubIDs, done any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueMarkOnGitHub", reflect.TypeOf((*MockScheduler)(nil).EnqueueMarkOnGitHub), ctx, userID, githubIDs, done)
+}
+
 // EnqueueProcessNotification mocks base method.
 func (m *MockScheduler) EnqueueProcessNotification(ctx context.Context, userID string, notificationData []byte) error {
 	m.ctrl.T.Helper()
@@ -111,6 +125,21 @@ func (mr *MockSchedulerMockRecorder) EnqueueSyncOlder(ctx, args any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueSyncOlder", reflect.TypeOf((*MockScheduler)(nil).EnqueueSyncOlder), ctx, args)
 }
 
+// NotificationBacklog mocks base method.
+func (m *MockScheduler) NotificationBacklog(ctx context.Context) (jobs.QueueStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationBacklog", ctx)
+	ret0, _ := ret[0].(jobs.QueueStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotificationBacklog indicates an expected call of NotificationBacklog.
+func (mr *MockSchedulerMockRecorder) NotificationBacklog(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationBacklog", reflect.TypeOf((*MockScheduler)(nil).NotificationBacklog), ctx)
+}
+
 // Start mocks base method.
 func (m *MockScheduler) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -33,6 +33,7 @@ const (
 	QueueApplyRule                = "apply_rule"
 	QueueSyncOlder                = "sync_older"
 	QueueApplyRulesToNotification = "apply_rules_to_notification"
+	QueueMarkOnGitHub             = "mark_on_github"
 )
 
 // Default configuration
@@ -125,7 +126,7 @@ func (q *SQLiteJobQueue) Enqueue(ctx context.Context, params EnqueueParams) (int
 			Queue:       params.Queue,
 			Payload:     string(params.Payload),
 			MaxAttempts: int64(maxAttempts),
-			ScheduledAt: scheduledAt.Format(time.RFC3339),
+			ScheduledAt: db.FormatTimestamp(scheduledAt),
 		})
 	})
 	if err != nil {
@@ -148,11 +149,11 @@ func (q *SQLiteJobQueue) Dequeue(ctx context.Context, queue string) (*Job, error
 		return nil, err
 	}
 
-	createdAt, err := time.Parse(time.RFC3339, sqliteJob.CreatedAt)
+	createdAt, err := db.ParseTimestamp(sqliteJob.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse createdAt: %w", err)
 	}
-	scheduledAt, err := time.Parse(time.RFC3339, sqliteJob.ScheduledAt)
+	scheduledAt, err := db.ParseTimestamp(sqliteJob.ScheduledAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse scheduledAt: %w", err)
 	}
@@ -218,7 +219,7 @@ func (q *SQLiteJobQueue) Nack(ctx context.Context, jobID int64, jobErr error) er
 	return db.RetryVoidOnBusy(ctx, func() error {
 		return q.queries.NackJobRetry(ctx, sqlite.NackJobRetryParams{
 			ID:          jobID,
-			ScheduledAt: nextSchedule.Format(time.RFC3339),
+			ScheduledAt: db.FormatTimestamp(nextSchedule),
 			LastError:   errStr,
 		})
 	})
@@ -230,7 +231,7 @@ func (q *SQLiteJobQueue) ResetStale(ctx context.Context, timeout time.Duration)
 	// Retry on SQLITE_BUSY
 	return db.RetryOnBusy(ctx, func() (int64, error) {
 		return q.queries.ResetStaleJobs(ctx, sql.NullString{
-			String: cutoff.Format(time.RFC3339),
+			String: db.FormatTimestamp(cutoff),
 			Valid:  true,
 		})
 	})
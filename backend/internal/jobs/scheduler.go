@@ -24,6 +24,12 @@ import (
 // CleanupNotificationsHandler is re-exported from handlers for API access
 type CleanupNotificationsHandler = handlers.CleanupNotificationsHandler
 
+// MergeDuplicateRepositoriesHandler is re-exported from handlers for API access
+type MergeDuplicateRepositoriesHandler = handlers.MergeDuplicateRepositoriesHandler
+
+// EscalateReviewRequestsHandler is re-exported from handlers for API access
+type EscalateReviewRequestsHandler = handlers.EscalateReviewRequestsHandler
+
 // Scheduler defines the interface for a background job scheduler
 type Scheduler interface {
 	// Start begins the scheduler's background processing
@@ -46,6 +52,12 @@ type Scheduler interface {
 
 	// EnqueueApplyRulesToNotification enqueues a job to apply all enabled rules to a single notification
 	EnqueueApplyRulesToNotification(ctx context.Context, userID string, githubID string) error
+
+	// EnqueueMarkOnGitHub enqueues jobs to mark threads read, or done, on GitHub
+	EnqueueMarkOnGitHub(ctx context.Context, userID string, githubIDs []string, done bool) error
+
+	// NotificationBacklog returns the state of the notification processing queue
+	NotificationBacklog(ctx context.Context) (QueueStats, error)
 }
 
 // JobHandler defines the interface for handling different job types
@@ -25,10 +25,28 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/octobud-hq/octobud/backend/internal/core/alert"
 	"github.com/octobud-hq/octobud/backend/internal/core/auth"
+	"github.com/octobud-hq/octobud/backend/internal/core/backup"
+	"github.com/octobud-hq/octobud/backend/internal/core/digest"
+	"github.com/octobud-hq/octobud/backend/internal/core/events"
+	"github.com/octobud-hq/octobud/backend/internal/core/history"
+	"github.com/octobud-hq/octobud/backend/internal/core/integration"
+	"github.com/octobud-hq/octobud/backend/internal/core/livequery"
+	"github.com/octobud-hq/octobud/backend/internal/core/prewarm"
+	"github.com/octobud-hq/octobud/backend/internal/core/priority"
+	"github.com/octobud-hq/octobud/backend/internal/core/snooze"
+	"github.com/octobud-hq/octobud/backend/internal/core/sysnotify"
+	"github.com/octobud-hq/octobud/backend/internal/core/timeline"
 	"github.com/octobud-hq/octobud/backend/internal/core/update"
+	"github.com/octobud-hq/octobud/backend/internal/core/view"
+	"github.com/octobud-hq/octobud/backend/internal/core/workhours"
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
 	"github.com/octobud-hq/octobud/backend/internal/jobs/handlers"
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/osactions"
 	coresync "github.com/octobud-hq/octobud/backend/internal/sync"
 )
 
@@ -47,10 +65,50 @@ type SQLiteScheduler struct {
 	applyRuleHandler                *handlers.ApplyRuleHandler
 	processNotificationHandler      *handlers.ProcessNotificationHandler
 	syncNotificationsHandler        *handlers.SyncNotificationsHandler
+	syncLinkedAccountsHandler       *handlers.SyncLinkedAccountsHandler
 	syncOlderHandler                *handlers.SyncOlderHandler
 	cleanupNotificationsHandler     *handlers.CleanupNotificationsHandler
+	mergeRepositoriesHandler        *handlers.MergeDuplicateRepositoriesHandler
+	escalateReviewRequestsHandler   *handlers.EscalateReviewRequestsHandler
+	classifyAwaitingRepliesHandler  *handlers.ClassifyAwaitingRepliesHandler
+	returnSnoozesHandler            *handlers.ReturnSnoozesHandler
+	enforceWIPLimitsHandler         *handlers.EnforceWIPLimitsHandler
 	checkUpdatesHandler             *handlers.CheckUpdatesHandler
 	applyRulesToNotificationHandler *handlers.ApplyRulesToNotificationHandler
+	markOnGitHubHandler             *handlers.MarkOnGitHubHandler
+	warmCachesHandler               *handlers.WarmCachesHandler
+
+	// Closed once the startup warm-up has finished, or right away when there's none
+	warmed chan struct{}
+
+	// System notifications for app events; nil disables them
+	systemNotifier  sysnotify.SystemNotifier
+	tokenExpiration func() time.Time
+
+	// Live query subscriptions to refresh after syncs and rules; nil disables them
+	liveQueries livequery.LiveQueryService
+
+	// Live update subscribers told when syncs start and finish; nil disables them
+	events events.EventBus
+
+	// Scheduled database backups; nil disables them
+	backups backup.BackupService
+
+	// Rescores notifications as they go stale; nil disables it
+	priorities priority.PriorityService
+
+	// Scheduled notification digests; nil disables them
+	digests digest.DigestService
+
+	// Sends the notifications rules queue for webhook integrations; nil disables it
+	integrations integration.IntegrationService
+
+	// The GitHub client's latest core rate limit; nil turns off throttling
+	rateLimit func() (types.RateLimit, bool)
+
+	// Consecutive sync failures, only touched by the run loop
+	syncFailures     int
+	syncFailingSince time.Time
 
 	// Channels for non-persistent jobs (sync triggers)
 	syncNotificationsQueue chan struct{}
@@ -83,6 +141,35 @@ type SQLiteSchedulerConfig struct {
 	SyncInterval  time.Duration
 	AuthService   auth.AuthService
 	UpdateService *update.Service
+	AlertService  alert.AlertService // Optional; records alerts for new notifications
+	// Optional; shows native OS notifications for new notifications that alert on the desktop
+	NativeNotifier osactions.Notifier
+	// Optional; reports sync failures, cleanups, updates and expiring tokens as notifications
+	SystemNotifier sysnotify.SystemNotifier
+	// Optional; when the GitHub token expires (zero if it doesn't)
+	TokenExpiration func() time.Time
+	// Optional; tells live query subscribers about synced and changed notifications
+	LiveQueries livequery.LiveQueryService
+	// Optional; tells live update subscribers when syncs start, complete and fail
+	Events events.EventBus
+	// Optional; syncs linked GitHub accounts after each sync of the primary account
+	AccountClients handlers.AccountClientProvider
+	// Optional; warms counts, subjects and timelines of recently opened items on startup
+	Prewarm prewarm.PrewarmService
+	// Optional; fetches timelines during the startup warm-up, marks threads read or done
+	// on GitHub when the user has two-way sync on, and reports the rate limit that
+	// enrichment and backfills are throttled by
+	GitHubClient githubinterfaces.Client
+	// Optional; backs up the database on the schedule in the user's backup settings
+	Backups backup.BackupService
+	// Optional; rescores notifications so the points for going without an update keep up
+	Priorities priority.PriorityService
+	// Optional; generates notification digests on the schedule in the user's digest settings
+	Digests digest.DigestService
+	// Optional; sends the notifications rules queue for webhook integrations
+	Integrations integration.IntegrationService
+	// Optional; how many notifications are processed at once (defaults to 4)
+	NotificationWorkers int
 }
 
 // Default number of workers for processing notifications concurrently.
@@ -94,11 +181,39 @@ const staleJobCheckInterval = 1 * time.Minute
 // Interval for cleanup job (daily)
 const cleanupInterval = 24 * time.Hour
 
+// Interval for evaluating review request escalation
+const escalationInterval = 1 * time.Hour
+
+// Interval for returning notifications whose snooze has run out
+const snoozeReturnInterval = 1 * time.Minute
+
+// Interval for checking whether a scheduled backup is due
+const backupCheckInterval = 10 * time.Minute
+
+// Interval for rescoring notification priorities as they go stale
+const priorityInterval = 1 * time.Hour
+
+// Interval for checking whether a scheduled digest is due
+const digestCheckInterval = 5 * time.Minute
+
+// Interval for sending queued webhook deliveries
+const deliveryInterval = 15 * time.Second
+
+// Consecutive sync failures before they're reported as a system notification
+const syncFailureThreshold = 5
+
+// Attempts at marking a thread on GitHub. With backoff capped at 5 minutes, retries go on
+// for over an hour, enough to ride out going offline for a while.
+const markOnGitHubMaxAttempts = 24
+
 // NewSQLiteScheduler creates a new SQLite scheduler with persistent job queue.
 func NewSQLiteScheduler(cfg SQLiteSchedulerConfig) *SQLiteScheduler {
 	if cfg.SyncInterval == 0 {
 		cfg.SyncInterval = 30 * time.Second
 	}
+	if cfg.NotificationWorkers <= 0 {
+		cfg.NotificationWorkers = defaultNotificationWorkers
+	}
 
 	s := &SQLiteScheduler{
 		logger:                 cfg.Logger,
@@ -111,27 +226,103 @@ func NewSQLiteScheduler(cfg SQLiteSchedulerConfig) *SQLiteScheduler {
 		syncOlderQueue:         make(chan SyncOlderNotificationsArgs, 10),
 		stopCh:                 make(chan struct{}),
 		doneCh:                 make(chan struct{}),
-		notificationWorkers:    defaultNotificationWorkers,
+		notificationWorkers:    cfg.NotificationWorkers,
+		systemNotifier:         cfg.SystemNotifier,
+		tokenExpiration:        cfg.TokenExpiration,
+		liveQueries:            cfg.LiveQueries,
+		events:                 cfg.Events,
+		backups:                cfg.Backups,
+		priorities:             cfg.Priorities,
+		digests:                cfg.Digests,
+		integrations:           cfg.Integrations,
+		warmed:                 make(chan struct{}),
 	}
 
+	// Changes made by sync, rules and scheduled jobs go in each notification's history
+	historySvc := history.NewService(cfg.Store, time.Now, cfg.Logger)
+
 	// Initialize handlers
-	s.applyRuleHandler = handlers.NewApplyRuleHandler(cfg.Store, cfg.Logger)
+	s.applyRuleHandler = handlers.NewApplyRuleHandler(cfg.Store, cfg.Logger).WithHistory(historySvc)
 	s.processNotificationHandler = handlers.NewProcessNotificationHandler(
 		cfg.Store,
 		cfg.SyncService,
 		cfg.Logger,
-	)
+	).WithHistory(historySvc)
+	if cfg.AlertService != nil {
+		s.processNotificationHandler.WithAlertService(cfg.AlertService)
+	}
+	if cfg.NativeNotifier != nil {
+		s.processNotificationHandler.WithNativeNotifier(cfg.NativeNotifier)
+	}
+	if cfg.LiveQueries != nil {
+		s.processNotificationHandler.WithLiveQueries(cfg.LiveQueries)
+	}
 	s.syncNotificationsHandler = handlers.NewSyncNotificationsHandler(
 		cfg.SyncService,
 		s,
 		cfg.Logger,
 	)
+	if cfg.AccountClients != nil {
+		s.processNotificationHandler.WithAccountClients(cfg.AccountClients)
+		s.syncLinkedAccountsHandler = handlers.NewSyncLinkedAccountsHandler(
+			cfg.Store,
+			cfg.AccountClients,
+			s,
+			time.Now,
+			cfg.Logger,
+		)
+	}
 	s.syncOlderHandler = handlers.NewSyncOlderHandler(cfg.SyncService, s, cfg.Logger)
 	s.cleanupNotificationsHandler = handlers.NewCleanupNotificationsHandler(cfg.Store, cfg.Logger)
-	s.applyRulesToNotificationHandler = handlers.NewApplyRulesToNotificationHandler(
+	s.mergeRepositoriesHandler = handlers.NewMergeDuplicateRepositoriesHandler(cfg.Store, cfg.Logger)
+	s.escalateReviewRequestsHandler = handlers.NewEscalateReviewRequestsHandler(
+		cfg.Store,
+		cfg.Logger,
+	).WithHistory(historySvc)
+	s.classifyAwaitingRepliesHandler = handlers.NewClassifyAwaitingRepliesHandler(
 		cfg.Store,
 		cfg.Logger,
 	)
+	s.returnSnoozesHandler = handlers.NewReturnSnoozesHandler(
+		cfg.Store,
+		time.Now,
+		cfg.Logger,
+	).WithEvents(cfg.Events).WithHistory(historySvc)
+	s.enforceWIPLimitsHandler = handlers.NewEnforceWIPLimitsHandler(
+		cfg.Store,
+		snooze.NewService(cfg.Store, workhours.NewService(cfg.Store), time.Now),
+		cfg.SystemNotifier,
+		cfg.Logger,
+	).WithHistory(historySvc)
+	s.applyRulesToNotificationHandler = handlers.NewApplyRulesToNotificationHandler(
+		cfg.Store,
+		cfg.Logger,
+	).WithHistory(historySvc)
+
+	if cfg.GitHubClient != nil {
+		s.rateLimit = cfg.GitHubClient.RateLimit
+		s.markOnGitHubHandler = handlers.NewMarkOnGitHubHandler(
+			cfg.Store,
+			cfg.GitHubClient,
+			cfg.Logger,
+		)
+		if cfg.AccountClients != nil {
+			s.markOnGitHubHandler.WithAccountClients(cfg.AccountClients)
+		}
+	}
+
+	if cfg.Prewarm != nil {
+		s.warmCachesHandler = handlers.NewWarmCachesHandler(
+			cfg.Store,
+			cfg.Prewarm,
+			view.NewService(cfg.Store),
+			time.Now,
+			cfg.Logger,
+		).WithSubjects(cfg.SyncService)
+		if cfg.GitHubClient != nil {
+			s.warmCachesHandler.WithTimelines(timeline.NewService(cfg.Logger), cfg.GitHubClient)
+		}
+	}
 
 	// Initialize update check handler if services are provided
 	if cfg.AuthService != nil && cfg.UpdateService != nil {
@@ -179,6 +370,16 @@ func (s *SQLiteScheduler) Start(ctx context.Context) error {
 	s.workerWg.Add(1)
 	go s.applyRulesToNotificationWorker(ctx)
 
+	// Start mark on GitHub worker (if a GitHub client is configured)
+	if s.markOnGitHubHandler != nil {
+		s.workerWg.Add(1)
+		go s.markOnGitHubWorker(ctx)
+	}
+
+	// Warm recently opened items before the first page loads
+	s.workerWg.Add(1)
+	go s.warmCaches(ctx)
+
 	// Start stale job cleanup goroutine
 	s.workerWg.Add(1)
 	go s.staleJobCleanupLoop(ctx)
@@ -187,6 +388,38 @@ func (s *SQLiteScheduler) Start(ctx context.Context) error {
 	s.workerWg.Add(1)
 	go s.cleanupLoop(ctx)
 
+	// Start review request escalation loop
+	s.workerWg.Add(1)
+	go s.escalationLoop(ctx)
+
+	// Start snooze return loop
+	s.workerWg.Add(1)
+	go s.snoozeReturnLoop(ctx)
+
+	// Start scheduled backup loop (if backups are configured)
+	if s.backups != nil {
+		s.workerWg.Add(1)
+		go s.backupLoop(ctx)
+	}
+
+	// Start priority rescoring loop (if priorities are configured)
+	if s.priorities != nil {
+		s.workerWg.Add(1)
+		go s.priorityLoop(ctx)
+	}
+
+	// Start scheduled digest loop (if digests are configured)
+	if s.digests != nil {
+		s.workerWg.Add(1)
+		go s.digestLoop(ctx)
+	}
+
+	// Start webhook delivery loop (if integrations are configured)
+	if s.integrations != nil {
+		s.workerWg.Add(1)
+		go s.deliveryLoop(ctx)
+	}
+
 	// Start update check loop (if handler is configured)
 	if s.checkUpdatesHandler != nil {
 		s.workerWg.Add(1)
@@ -340,6 +573,22 @@ func (s *SQLiteScheduler) EnqueueApplyRulesToNotification(
 	return nil
 }
 
+// EnqueueMarkOnGitHub enqueues a job per thread to mark it read, or done, on GitHub.
+// Jobs are kept through restarts and retried while GitHub can't be reached.
+func (s *SQLiteScheduler) EnqueueMarkOnGitHub(
+	ctx context.Context,
+	userID string,
+	githubIDs []string,
+	done bool,
+) error {
+	return NewGitHubMarker(s.jobQueue, s.logger).EnqueueMarkOnGitHub(ctx, userID, githubIDs, done)
+}
+
+// NotificationBacklog returns how many notifications are waiting to be, or being, processed
+func (s *SQLiteScheduler) NotificationBacklog(ctx context.Context) (QueueStats, error) {
+	return s.jobQueue.Stats(ctx, QueueProcessNotification)
+}
+
 func (s *SQLiteScheduler) run(ctx context.Context) {
 	defer close(s.doneCh)
 
@@ -399,6 +648,21 @@ func (s *SQLiteScheduler) notificationWorker(ctx context.Context, workerID int)
 		default:
 		}
 
+		// Spread subject enrichment out when the rate limit budget runs low. The workers
+		// share the budget, so each waits its share.
+		if delay := s.throttleDelay() * time.Duration(s.notificationWorkers); delay > 0 {
+			s.logger.Debug("throttling notification worker",
+				zap.Int("workerID", workerID),
+				zap.Duration("delay", delay))
+			select {
+			case <-s.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+
 		// Try to dequeue a job
 		job, err := s.jobQueue.Dequeue(ctx, QueueProcessNotification)
 		if err != nil {
@@ -535,7 +799,79 @@ func (s *SQLiteScheduler) applyRulesToNotificationWorker(ctx context.Context) {
 			s.logger.Debug("apply rules to notification job completed",
 				zap.Int64("jobID", job.ID),
 				zap.Int("attempt", job.Attempts))
+			s.refreshLiveQueries(ctx, userID)
+
+			// Ack removes the job from the queue
+			if ackErr := s.jobQueue.Ack(ctx, job.ID); ackErr != nil {
+				s.logger.Error("failed to ack job", zap.Int64("jobID", job.ID), zap.Error(ackErr))
+			}
+		}
+	}
+}
+
+// markOnGitHubWorker processes mark on GitHub jobs from the persistent job queue.
+func (s *SQLiteScheduler) markOnGitHubWorker(ctx context.Context) {
+	defer s.workerWg.Done()
+	s.logger.Debug("mark on GitHub worker started")
+
+	pollInterval := 100 * time.Millisecond
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.logger.Debug("mark on GitHub worker stopping")
+			return
+		case <-ctx.Done():
+			s.logger.Debug("mark on GitHub worker context canceled")
+			return
+		default:
+		}
+
+		// Try to dequeue a job
+		job, err := s.jobQueue.Dequeue(ctx, QueueMarkOnGitHub)
+		if err != nil {
+			if errors.Is(err, ErrNoJobAvailable) {
+				// No jobs available, wait before polling again
+				select {
+				case <-s.stopCh:
+					return
+				case <-ctx.Done():
+					return
+				case <-time.After(pollInterval):
+					continue
+				}
+			}
+			// Actual error
+			s.logger.Warn("failed to dequeue mark on GitHub job", zap.Error(err))
+			time.Sleep(time.Second) // Back off on errors
+			continue
+		}
 
+		// Get current user ID for processing
+		userID, err := s.getCurrentUserID(ctx)
+		if err != nil {
+			s.logger.Warn("cannot process mark on GitHub job - no user ID",
+				zap.Int64("jobID", job.ID),
+				zap.Error(err))
+			// Nack the job to retry later when user is configured
+			if nackErr := s.jobQueue.Nack(ctx, job.ID, err); nackErr != nil {
+				s.logger.Error("failed to nack job", zap.Int64("jobID", job.ID), zap.Error(nackErr))
+			}
+			continue
+		}
+
+		err = s.markOnGitHubHandler.Handle(ctx, userID, job.Payload)
+		if err != nil {
+			s.logger.Warn("mark on GitHub job failed",
+				zap.Int64("jobID", job.ID),
+				zap.Int("attempt", job.Attempts),
+				zap.Error(err))
+
+			// Nack will either retry or dead-letter the job
+			if nackErr := s.jobQueue.Nack(ctx, job.ID, err); nackErr != nil {
+				s.logger.Error("failed to nack job", zap.Int64("jobID", job.ID), zap.Error(nackErr))
+			}
+		} else {
 			// Ack removes the job from the queue
 			if ackErr := s.jobQueue.Ack(ctx, job.ID); ackErr != nil {
 				s.logger.Error("failed to ack job", zap.Int64("jobID", job.ID), zap.Error(ackErr))
@@ -578,11 +914,19 @@ func (s *SQLiteScheduler) doSync(ctx context.Context) {
 		return
 	}
 
+	s.publishSyncStatus(userID, models.SyncStatus{State: models.SyncStatusStarted})
 	result, err := s.syncNotificationsHandler.Handle(ctx, userID)
 	if err != nil {
 		s.logger.Warn("failed to sync notifications", zap.Error(err))
+		s.recordSyncFailure(ctx, userID)
+		s.publishSyncStatus(userID, models.SyncStatus{State: models.SyncStatusFailed, Error: err.Error()})
 		return
 	}
+	s.syncFailures = 0
+	s.notifyTokenExpiring(ctx, userID)
+	s.enforceWIPLimits(ctx, userID)
+	// Catches snoozes that ended since the last sync
+	s.refreshLiveQueries(ctx, userID)
 
 	// Update sync state after successful processing
 	if result != nil {
@@ -590,6 +934,63 @@ func (s *SQLiteScheduler) doSync(ctx context.Context) {
 			s.logger.Warn("failed to update sync state", zap.Error(err))
 		}
 	}
+
+	s.publishSyncStatus(userID, models.SyncStatus{State: models.SyncStatusCompleted})
+
+	s.syncLinkedAccounts(ctx, userID)
+}
+
+// publishSyncStatus tells the user's live update subscribers where the sync is at
+func (s *SQLiteScheduler) publishSyncStatus(userID string, status models.SyncStatus) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(userID, models.Event{Type: models.EventSyncStatus, Sync: &status})
+}
+
+// syncLinkedAccounts syncs linked GitHub accounts. Their failures are logged by the
+// handler and don't count as sync failures of the primary account.
+func (s *SQLiteScheduler) syncLinkedAccounts(ctx context.Context, userID string) {
+	if s.syncLinkedAccountsHandler == nil {
+		return
+	}
+	if err := s.syncLinkedAccountsHandler.Handle(ctx, userID); err != nil {
+		s.logger.Debug("linked account sync incomplete", zap.Error(err))
+	}
+}
+
+// recordSyncFailure counts a failed sync and reports the run of failures once it reaches
+// syncFailureThreshold
+func (s *SQLiteScheduler) recordSyncFailure(ctx context.Context, userID string) {
+	if s.syncFailures == 0 {
+		s.syncFailingSince = time.Now()
+	}
+	s.syncFailures++
+	if s.systemNotifier == nil || s.syncFailures < syncFailureThreshold {
+		return
+	}
+	if _, err := s.systemNotifier.SyncFailing(
+		ctx, userID, s.syncFailingSince, s.syncFailures,
+	); err != nil {
+		s.logger.Warn("failed to report sync failures", zap.Error(err))
+	}
+}
+
+// notifyTokenExpiring reports a GitHub token that expires soon
+func (s *SQLiteScheduler) notifyTokenExpiring(ctx context.Context, userID string) {
+	if s.systemNotifier == nil || s.tokenExpiration == nil {
+		return
+	}
+	if _, err := s.systemNotifier.TokenExpiring(ctx, userID, s.tokenExpiration()); err != nil {
+		s.logger.Warn("failed to report expiring token", zap.Error(err))
+	}
+}
+
+// enforceWIPLimits checks views with a WIP limit against what the sync brought in
+func (s *SQLiteScheduler) enforceWIPLimits(ctx context.Context, userID string) {
+	if _, err := s.enforceWIPLimitsHandler.Handle(ctx, userID); err != nil {
+		s.logger.Warn("failed to enforce view WIP limits", zap.Error(err))
+	}
 }
 
 func (s *SQLiteScheduler) doApplyRule(ctx context.Context, job applyRuleJob) {
@@ -597,6 +998,16 @@ func (s *SQLiteScheduler) doApplyRule(ctx context.Context, job applyRuleJob) {
 	if err != nil {
 		s.logger.Warn("failed to apply rule", zap.String("ruleID", job.RuleID), zap.Error(err))
 	}
+	s.refreshLiveQueries(ctx, job.UserID)
+}
+
+// refreshLiveQueries checks the user's live queries again after changes that can touch
+// many notifications at once
+func (s *SQLiteScheduler) refreshLiveQueries(ctx context.Context, userID string) {
+	if s.liveQueries == nil {
+		return
+	}
+	s.liveQueries.Refresh(ctx, userID)
 }
 
 func (s *SQLiteScheduler) doSyncOlder(ctx context.Context, args SyncOlderNotificationsArgs) {
@@ -607,6 +1018,18 @@ func (s *SQLiteScheduler) doSyncOlder(ctx context.Context, args SyncOlderNotific
 		return
 	}
 
+	if wait := s.backfillWait(); wait > 0 {
+		s.logger.Info("rate limit budget low, postponing sync older until it resets",
+			zap.Duration("wait", wait))
+		time.AfterFunc(wait, func() {
+			select {
+			case s.syncOlderQueue <- args:
+			case <-s.stopCh:
+			}
+		})
+		return
+	}
+
 	err = s.syncOlderHandler.Handle(ctx, handlers.SyncOlderArgs{
 		UserID:     userID,
 		Days:       args.Days,
@@ -619,7 +1042,7 @@ func (s *SQLiteScheduler) doSyncOlder(ctx context.Context, args SyncOlderNotific
 	}
 }
 
-// cleanupLoop runs the notification cleanup job daily
+// cleanupLoop runs the notification cleanup and duplicate repository merge jobs daily
 func (s *SQLiteScheduler) cleanupLoop(ctx context.Context) {
 	defer s.workerWg.Done()
 
@@ -631,6 +1054,7 @@ func (s *SQLiteScheduler) cleanupLoop(ctx context.Context) {
 		return
 	case <-time.After(30 * time.Second):
 		s.doCleanup(ctx)
+		s.doMergeDuplicateRepositories(ctx)
 	}
 
 	ticker := time.NewTicker(cleanupInterval)
@@ -644,6 +1068,7 @@ func (s *SQLiteScheduler) cleanupLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			s.doCleanup(ctx)
+			s.doMergeDuplicateRepositories(ctx)
 		}
 	}
 }
@@ -670,6 +1095,35 @@ func (s *SQLiteScheduler) doCleanup(ctx context.Context) {
 		s.logger.Info("daily cleanup completed",
 			zap.Int64("notificationsDeleted", result.NotificationsDeleted),
 			zap.Int64("pullRequestsDeleted", result.PullRequestsDeleted))
+		if s.systemNotifier != nil {
+			if _, err := s.systemNotifier.CleanupCompleted(
+				ctx, userID, result.NotificationsDeleted,
+			); err != nil {
+				s.logger.Warn("failed to report cleanup", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doMergeDuplicateRepositories(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping repository merge - no user ID configured", zap.Error(err))
+		return
+	}
+
+	result, err := s.mergeRepositoriesHandler.Handle(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to merge duplicate repositories", zap.Error(err))
+		if result == nil {
+			return
+		}
+	}
+
+	if len(result.Merges) > 0 && s.systemNotifier != nil {
+		if _, err := s.systemNotifier.RepositoriesMerged(ctx, userID, len(result.Merges)); err != nil {
+			s.logger.Warn("failed to report repository merge", zap.Error(err))
+		}
 	}
 }
 
@@ -678,6 +1132,322 @@ func (s *SQLiteScheduler) GetCleanupHandler() *handlers.CleanupNotificationsHand
 	return s.cleanupNotificationsHandler
 }
 
+// GetMergeRepositoriesHandler returns the duplicate repository merge handler for API access
+func (s *SQLiteScheduler) GetMergeRepositoriesHandler() *handlers.MergeDuplicateRepositoriesHandler {
+	return s.mergeRepositoriesHandler
+}
+
+// escalationLoop periodically resurfaces review requests that have sat unread too long and
+// classifies threads awaiting a reply to the user's own comment
+func (s *SQLiteScheduler) escalationLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	// Run on startup (after a short delay to let the initial sync settle)
+	select {
+	case <-s.stopCh:
+		return
+	case <-ctx.Done():
+		return
+	case <-time.After(45 * time.Second):
+		s.doEscalation(ctx)
+		s.doClassifyAwaitingReplies(ctx)
+	}
+
+	ticker := time.NewTicker(escalationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doEscalation(ctx)
+			s.doClassifyAwaitingReplies(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doEscalation(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping escalation - no user ID configured", zap.Error(err))
+		return
+	}
+
+	result, err := s.escalateReviewRequestsHandler.Handle(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to escalate review requests", zap.Error(err))
+		return
+	}
+
+	if result.Skipped {
+		s.logger.Debug("escalation skipped", zap.String("reason", result.SkipReason))
+	}
+}
+
+func (s *SQLiteScheduler) doClassifyAwaitingReplies(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping awaiting reply classification - no user ID configured", zap.Error(err))
+		return
+	}
+
+	result, err := s.classifyAwaitingRepliesHandler.Handle(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to classify threads awaiting a reply", zap.Error(err))
+		return
+	}
+
+	if result.Skipped {
+		s.logger.Debug("awaiting reply classification skipped", zap.String("reason", result.SkipReason))
+	}
+}
+
+// snoozeReturnLoop brings back notifications whose snooze has run out, starting with any
+// that expired while the app wasn't running
+func (s *SQLiteScheduler) snoozeReturnLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	s.doReturnSnoozes(ctx)
+
+	ticker := time.NewTicker(snoozeReturnInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doReturnSnoozes(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doReturnSnoozes(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping snooze return - no user ID configured", zap.Error(err))
+		return
+	}
+
+	result, err := s.returnSnoozesHandler.Handle(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to return expired snoozes", zap.Error(err))
+		return
+	}
+
+	if len(result.Returned) > 0 {
+		s.refreshLiveQueries(ctx, userID)
+	}
+}
+
+// backupLoop backs up the database whenever a scheduled backup is due. The first check
+// waits a minute so startup isn't slowed down.
+func (s *SQLiteScheduler) backupLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	select {
+	case <-s.stopCh:
+		return
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Minute):
+		s.doBackup(ctx)
+	}
+
+	ticker := time.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doBackup(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doBackup(ctx context.Context) {
+	info, err := s.backups.RunScheduled(ctx)
+	if err != nil {
+		s.logger.Warn("failed to run scheduled backup", zap.Error(err))
+		return
+	}
+	if info != nil {
+		s.logger.Info("backed up database",
+			zap.String("path", info.Path),
+			zap.Int64("sizeBytes", info.SizeBytes))
+	}
+}
+
+// priorityLoop rescores notifications every hour, since the points for going without an
+// update only change at sync when GitHub reports something new
+func (s *SQLiteScheduler) priorityLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	ticker := time.NewTicker(priorityInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doRecalculatePriorities(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doRecalculatePriorities(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping priority rescoring - no user ID configured", zap.Error(err))
+		return
+	}
+
+	updated, err := s.priorities.Recalculate(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to rescore notification priorities", zap.Error(err))
+		return
+	}
+
+	if updated > 0 {
+		s.logger.Debug("rescored notification priorities", zap.Int("updated", updated))
+		s.refreshLiveQueries(ctx, userID)
+	}
+}
+
+// digestLoop generates the notification digest whenever a scheduled one is due. The first
+// check waits a minute so startup isn't slowed down and the first sync can land.
+func (s *SQLiteScheduler) digestLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	select {
+	case <-s.stopCh:
+		return
+	case <-ctx.Done():
+		return
+	case <-time.After(time.Minute):
+		s.doDigest(ctx)
+	}
+
+	ticker := time.NewTicker(digestCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doDigest(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doDigest(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping digest - no user ID configured", zap.Error(err))
+		return
+	}
+
+	generated, err := s.digests.RunScheduled(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to generate scheduled digest", zap.Error(err))
+		return
+	}
+	if generated != nil {
+		s.logger.Info("generated notification digest",
+			zap.String("name", generated.Name),
+			zap.Bool("emailed", generated.EmailedTo != ""))
+	}
+}
+
+// deliveryLoop sends the webhook deliveries that are due, including retries of ones that
+// failed earlier
+func (s *SQLiteScheduler) deliveryLoop(ctx context.Context) {
+	defer s.workerWg.Done()
+
+	ticker := time.NewTicker(deliveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.doDeliveries(ctx)
+		}
+	}
+}
+
+func (s *SQLiteScheduler) doDeliveries(ctx context.Context) {
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping webhook deliveries - no user ID configured", zap.Error(err))
+		return
+	}
+
+	attempted, err := s.integrations.DeliverDue(ctx, userID)
+	if err != nil {
+		s.logger.Warn("failed to send webhook deliveries", zap.Error(err))
+		return
+	}
+	if attempted > 0 {
+		s.logger.Debug("sent webhook deliveries", zap.Int("attempted", attempted))
+	}
+}
+
+// Warmed returns a channel that's closed once the startup warm-up has finished
+func (s *SQLiteScheduler) Warmed() <-chan struct{} {
+	return s.warmed
+}
+
+// warmCaches runs the startup warm-up once for the current user
+func (s *SQLiteScheduler) warmCaches(ctx context.Context) {
+	defer s.workerWg.Done()
+	defer close(s.warmed)
+
+	if s.warmCachesHandler == nil {
+		return
+	}
+	userID, err := s.getCurrentUserID(ctx)
+	if err != nil {
+		s.logger.Debug("skipping cache warm-up, no user configured", zap.Error(err))
+		return
+	}
+
+	start := time.Now()
+	result, err := s.warmCachesHandler.Handle(ctx, userID)
+	if err != nil {
+		s.logger.Warn("cache warm-up failed", zap.Error(err))
+		return
+	}
+	s.logger.Info("warmed caches for recently opened items",
+		zap.Bool("counts", result.Counts),
+		zap.Int("subjects", result.Subjects),
+		zap.Int("timelines", result.Timelines),
+		zap.Duration("took", time.Since(start)))
+}
+
+// GetEscalationHandler returns the escalation handler for API access
+func (s *SQLiteScheduler) GetEscalationHandler() *handlers.EscalateReviewRequestsHandler {
+	return s.escalateReviewRequestsHandler
+}
+
 // updateCheckInterval is how often to check for updates (if enabled and frequency allows)
 const updateCheckInterval = 1 * time.Hour
 
@@ -716,15 +1486,24 @@ func (s *SQLiteScheduler) doUpdateCheck(ctx context.Context) {
 
 	s.logger.Debug("checking for updates")
 
-	available, err := s.checkUpdatesHandler.Handle(ctx)
+	info, err := s.checkUpdatesHandler.Handle(ctx)
 	if err != nil {
 		s.logger.Warn("failed to check for updates", zap.Error(err))
 		return
 	}
 
-	if available {
+	if info != nil {
 		s.logger.Info("update check found new version available")
 		// Note: The frontend will poll the API endpoint to get update details
-		// We just log here that a check was performed
+		if s.systemNotifier == nil {
+			return
+		}
+		userID, err := s.getCurrentUserID(ctx)
+		if err != nil {
+			return
+		}
+		if _, err := s.systemNotifier.UpdateAvailable(ctx, userID, info.LatestVersion); err != nil {
+			s.logger.Warn("failed to report available update", zap.Error(err))
+		}
 	}
 }
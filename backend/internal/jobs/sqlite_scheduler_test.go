@@ -29,9 +29,12 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
+	eventmocks "github.com/octobud-hq/octobud/backend/internal/core/events/mocks"
+	sysnotifymocks "github.com/octobud-hq/octobud/backend/internal/core/sysnotify/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	dbmocks "github.com/octobud-hq/octobud/backend/internal/db/mocks"
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/sync"
 	syncmocks "github.com/octobud-hq/octobud/backend/internal/sync/mocks"
 
@@ -46,9 +49,23 @@ func setupMockStore(ctrl *gomock.Controller) *dbmocks.MockStore {
 	mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
 		GithubUserID: sql.NullString{String: "test-user-id", Valid: true},
 	}, nil).AnyTimes()
-	// ProcessNotification handler may call GetNotificationByGithubID
+	// ProcessNotification handler skips orgs the user left...
+	mockStore.EXPECT().IsOrgOffboarded(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(false, nil).AnyTimes()
+	// ...and may call GetNotificationByGithubID
 	mockStore.EXPECT().GetNotificationByGithubID(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(db.Notification{}, sql.ErrNoRows).AnyTimes()
+	// ...and restore imported triage state for new notifications
+	mockStore.EXPECT().ApplyTriageRestore(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(false, nil).AnyTimes()
+	// Views are checked against their WIP limits after each successful sync
+	mockStore.EXPECT().ListViews(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	// Changes made by sync and rules are recorded in notification history
+	mockStore.EXPECT().RecordNotificationEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).AnyTimes()
+	// Expired snoozes are returned when the scheduler starts
+	mockStore.EXPECT().ReturnExpiredSnoozes(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	return mockStore
 }
 
@@ -114,6 +131,20 @@ func TestSQLiteScheduler_NewScheduler(t *testing.T) {
 	require.NotNil(t, scheduler.jobQueue)
 }
 
+func TestSQLiteScheduler_NotificationWorkers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler := NewSQLiteScheduler(SQLiteSchedulerConfig{
+		Logger:              zap.NewNop(),
+		DBConn:              setupTestDB(t),
+		SyncService:         syncmocks.NewMockSyncOperations(ctrl),
+		NotificationWorkers: 12,
+	})
+
+	require.Equal(t, 12, scheduler.notificationWorkers)
+}
+
 func TestSQLiteScheduler_DefaultSyncInterval(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -224,6 +255,40 @@ func TestSQLiteScheduler_EnqueueProcessNotification_Persisted(t *testing.T) {
 	require.Equal(t, string(testData), payload)
 }
 
+func TestSQLiteScheduler_EnqueueMarkOnGitHub_Persisted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testDB := setupTestDB(t)
+	scheduler := NewSQLiteScheduler(SQLiteSchedulerConfig{
+		Logger:      zaptest.NewLogger(t),
+		DBConn:      testDB,
+		SyncService: syncmocks.NewMockSyncOperations(ctrl),
+	})
+
+	ctx := context.Background()
+	require.NoError(t, scheduler.EnqueueMarkOnGitHub(ctx, "1", []string{"thread-1", "thread-2"}, true))
+
+	rows, err := testDB.QueryContext(ctx,
+		"SELECT payload, max_attempts FROM jobs WHERE queue = ? ORDER BY id", QueueMarkOnGitHub)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var payloads []string
+	for rows.Next() {
+		var payload string
+		var maxAttempts int
+		require.NoError(t, rows.Scan(&payload, &maxAttempts))
+		require.Equal(t, markOnGitHubMaxAttempts, maxAttempts)
+		payloads = append(payloads, payload)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{
+		`{"githubID":"thread-1","done":true}`,
+		`{"githubID":"thread-2","done":true}`,
+	}, payloads)
+}
+
 func TestSQLiteScheduler_EnqueueSyncNotifications(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -540,6 +605,117 @@ func TestSQLiteScheduler_PeriodicSyncTriggered(t *testing.T) {
 	require.GreaterOrEqual(t, syncCalls.Load(), int32(2))
 }
 
+func TestSQLiteScheduler_ReportsRepeatedSyncFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := true
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any(), gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil).
+		AnyTimes()
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ sync.SyncContext) (types.NotificationsResult, error) {
+			if failing {
+				return types.NotificationsResult{}, fmt.Errorf("github: 502 bad gateway")
+			}
+			return types.NotificationsResult{}, nil
+		}).
+		AnyTimes()
+	mockSync.EXPECT().
+		UpdateSyncStateAfterProcessing(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	expiresAt := time.Now().Add(48 * time.Hour)
+	notifier := sysnotifymocks.NewMockSystemNotifier(ctrl)
+	scheduler := NewSQLiteScheduler(SQLiteSchedulerConfig{
+		Logger:          zap.NewNop(),
+		DBConn:          setupTestDB(t),
+		Store:           setupMockStore(ctrl),
+		SyncService:     mockSync,
+		SystemNotifier:  notifier,
+		TokenExpiration: func() time.Time { return expiresAt },
+	})
+	ctx := context.Background()
+
+	// Failures below the threshold aren't reported
+	for i := 1; i < syncFailureThreshold; i++ {
+		scheduler.doSync(ctx)
+	}
+
+	notifier.EXPECT().
+		SyncFailing(gomock.Any(), "test-user-id", scheduler.syncFailingSince, syncFailureThreshold).
+		Return(true, nil)
+	scheduler.doSync(ctx)
+
+	// A successful sync resets the count and checks the token
+	failing = false
+	notifier.EXPECT().
+		TokenExpiring(gomock.Any(), "test-user-id", expiresAt).
+		Return(false, nil)
+	scheduler.doSync(ctx)
+	require.Equal(t, 0, scheduler.syncFailures)
+}
+
+func TestSQLiteScheduler_PublishesSyncStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := true
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any(), gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil).
+		AnyTimes()
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ sync.SyncContext) (types.NotificationsResult, error) {
+			if failing {
+				return types.NotificationsResult{}, fmt.Errorf("github: 502 bad gateway")
+			}
+			return types.NotificationsResult{}, nil
+		}).
+		AnyTimes()
+	mockSync.EXPECT().
+		UpdateSyncStateAfterProcessing(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	bus := eventmocks.NewMockEventBus(ctrl)
+	scheduler := NewSQLiteScheduler(SQLiteSchedulerConfig{
+		Logger:      zap.NewNop(),
+		DBConn:      setupTestDB(t),
+		Store:       setupMockStore(ctrl),
+		SyncService: mockSync,
+		Events:      bus,
+	})
+	ctx := context.Background()
+
+	syncStatus := func(status models.SyncStatus) models.Event {
+		return models.Event{Type: models.EventSyncStatus, Sync: &status}
+	}
+
+	gomock.InOrder(
+		bus.EXPECT().Publish("test-user-id", syncStatus(models.SyncStatus{State: models.SyncStatusStarted})),
+		bus.EXPECT().Publish("test-user-id", gomock.Cond(func(x any) bool {
+			event, ok := x.(models.Event)
+			return ok && event.Sync != nil && event.Sync.State == models.SyncStatusFailed &&
+				event.Sync.Error != ""
+		})),
+	)
+	scheduler.doSync(ctx)
+
+	failing = false
+	gomock.InOrder(
+		bus.EXPECT().Publish("test-user-id", syncStatus(models.SyncStatus{State: models.SyncStatusStarted})),
+		bus.EXPECT().Publish("test-user-id", syncStatus(models.SyncStatus{State: models.SyncStatusCompleted})),
+	)
+	scheduler.doSync(ctx)
+}
+
 func TestSQLiteScheduler_EnqueueSyncOlder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,69 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+// RateLimitReserve is how much of the core rate limit budget background work leaves for
+// syncs and the user's own actions. Below it, subject enrichment is spread out and
+// backfills wait for the budget to reset.
+const RateLimitReserve = 500
+
+// ThrottleDelay returns how long to wait between background requests so the remaining
+// budget lasts until it resets. It's zero while the budget is above RateLimitReserve.
+func ThrottleDelay(limit types.RateLimit, now time.Time) time.Duration {
+	if limit.Remaining >= RateLimitReserve {
+		return 0
+	}
+	untilReset := limit.Reset.Sub(now)
+	if untilReset <= 0 {
+		return 0
+	}
+	if limit.Remaining <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(limit.Remaining)
+}
+
+// throttleDelay returns how long the next background request should wait, given the
+// client's latest rate limit
+func (s *SQLiteScheduler) throttleDelay() time.Duration {
+	if s.rateLimit == nil {
+		return 0
+	}
+	limit, ok := s.rateLimit()
+	if !ok {
+		return 0
+	}
+	return ThrottleDelay(limit, time.Now())
+}
+
+// backfillWait returns how long a backfill should wait for the budget to reset. Each
+// backfill fetches many pages at once, so it isn't started while the budget is low.
+func (s *SQLiteScheduler) backfillWait() time.Duration {
+	if s.rateLimit == nil {
+		return 0
+	}
+	limit, ok := s.rateLimit()
+	if !ok || limit.Remaining >= RateLimitReserve {
+		return 0
+	}
+	return max(time.Until(limit.Reset), 0)
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/github/types"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name     string
+		limit    types.RateLimit
+		expected time.Duration
+	}{
+		{
+			name:     "budget above reserve",
+			limit:    types.RateLimit{Limit: 5000, Remaining: 4000, Reset: reset},
+			expected: 0,
+		},
+		{
+			name:     "budget below reserve spreads requests until reset",
+			limit:    types.RateLimit{Limit: 5000, Remaining: 300, Reset: reset},
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "exhausted budget waits for reset",
+			limit:    types.RateLimit{Limit: 5000, Remaining: 0, Reset: reset},
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "reset already passed",
+			limit:    types.RateLimit{Limit: 5000, Remaining: 0, Reset: now.Add(-time.Second)},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ThrottleDelay(tt.limit, now))
+		})
+	}
+}
+
+func TestSQLiteScheduler_backfillWait(t *testing.T) {
+	s := &SQLiteScheduler{}
+	require.Zero(t, s.backfillWait(), "no rate limit source")
+
+	limit := types.RateLimit{Limit: 5000, Remaining: 4000, Reset: time.Now().Add(time.Hour)}
+	known := false
+	s.rateLimit = func() (types.RateLimit, bool) { return limit, known }
+	require.Zero(t, s.backfillWait(), "rate limit not seen yet")
+
+	known = true
+	require.Zero(t, s.backfillWait(), "budget above reserve")
+
+	limit.Remaining = 10
+	wait := s.backfillWait()
+	require.Greater(t, wait, 59*time.Minute)
+	require.LessOrEqual(t, wait, time.Hour)
+}
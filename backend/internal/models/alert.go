@@ -0,0 +1,142 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Alert levels, from quietest to loudest
+const (
+	AlertLevelNone    = "none"    // No badge, desktop notification or sound
+	AlertLevelBadge   = "badge"   // Counted in unread badges only
+	AlertLevelDesktop = "desktop" // Desktop notification, with a sound if one is set
+)
+
+// AlertSoundSilent shows a desktop notification without any sound. An empty sound uses
+// the system's default notification sound.
+const AlertSoundSilent = "silent"
+
+// AlertSounds lists the named sounds clients can play for an alert
+var AlertSounds = []string{"chime", "ping", "pop", "bell"}
+
+// Reasons an alert was downgraded when it was recorded
+const (
+	AlertSuppressedDND        = "dnd"         // Notifications were globally muted
+	AlertSuppressedQuietHours = "quiet_hours" // Quiet hours, or outside working hours
+)
+
+// AlertConfig is how loudly notifications matching a rule or view should alert
+type AlertConfig struct {
+	Level string `json:"level"`
+	Sound string `json:"sound,omitempty"`
+}
+
+// Validate checks the level and sound are known
+func (c AlertConfig) Validate() error {
+	switch c.Level {
+	case AlertLevelNone, AlertLevelBadge, AlertLevelDesktop:
+	default:
+		return fmt.Errorf("alert level %q must be none, badge or desktop", c.Level)
+	}
+	if c.Sound != "" && c.Sound != AlertSoundSilent && !slices.Contains(AlertSounds, c.Sound) {
+		return fmt.Errorf("unknown alert sound %q", c.Sound)
+	}
+	return nil
+}
+
+// QuietHours is a daily window in which desktop alerts are downgraded to badges.
+// The window may cross midnight, e.g. 22:00 to 07:00.
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`              // "HH:MM"
+	End      string `json:"end"`                // "HH:MM"
+	Timezone string `json:"timezone,omitempty"` // IANA time zone name (empty = system time zone)
+}
+
+// NativeNotifications shows notifications through the operating system rather than the
+// browser, so desktop alerts still appear when no Octobud tab is open
+type NativeNotifications struct {
+	Enabled bool `json:"enabled"`
+	// Query narrows which unread inbox notifications are shown (empty = all of them)
+	Query string `json:"query,omitempty"`
+}
+
+// AlertSettings is the user's alert policy. Rule alerts are part of each rule's actions;
+// view alerts live here, keyed by view ID.
+type AlertSettings struct {
+	// Default applies to new inbox notifications that no rule or view alert matches
+	Default AlertConfig            `json:"default"`
+	Views   map[string]AlertConfig `json:"views"`
+
+	QuietHours QuietHours `json:"quietHours"`
+	// QuietOutsideWorkingHours treats time outside the user's working hours as quiet hours
+	QuietOutsideWorkingHours bool `json:"quietOutsideWorkingHours"`
+
+	// Native notifications follow the same levels and quiet hours: only desktop alerts show one
+	Native NativeNotifications `json:"native"`
+}
+
+// DefaultAlertSettings returns the default alert settings: desktop notifications for
+// everything in the inbox, no view alerts and no quiet hours
+func DefaultAlertSettings() *AlertSettings {
+	return &AlertSettings{
+		Default: AlertConfig{Level: AlertLevelDesktop},
+		Views:   map[string]AlertConfig{},
+		QuietHours: QuietHours{
+			Start: "22:00",
+			End:   "07:00",
+		},
+	}
+}
+
+// ToJSON converts AlertSettings to JSON bytes
+func (s *AlertSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// AlertSettingsFromJSON creates AlertSettings from JSON bytes
+func AlertSettingsFromJSON(data json.RawMessage) (*AlertSettings, error) {
+	if len(data) == 0 {
+		return DefaultAlertSettings(), nil
+	}
+	var settings AlertSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	if settings.Views == nil {
+		settings.Views = map[string]AlertConfig{}
+	}
+	return &settings, nil
+}
+
+// Alert is the alert decided for a notification when it arrived
+type Alert struct {
+	ID         int64     `json:"id"`
+	GithubID   string    `json:"githubId"`
+	Level      string    `json:"level"`
+	Sound      string    `json:"sound,omitempty"`
+	Source     string    `json:"source"` // "rule", "view" or "default"
+	SourceID   string    `json:"sourceId,omitempty"`
+	Suppressed string    `json:"suppressed,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
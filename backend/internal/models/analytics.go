@@ -0,0 +1,78 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// BreakdownDimension is what notification volume is broken down by
+type BreakdownDimension string
+
+// BreakdownDimension constants
+const (
+	BreakdownByRepository BreakdownDimension = "repo"
+	BreakdownByOrg        BreakdownDimension = "org"
+	BreakdownByReason     BreakdownDimension = "reason"
+)
+
+// IsValid reports whether d is a known breakdown dimension
+func (d BreakdownDimension) IsValid() bool {
+	switch d {
+	case BreakdownByRepository, BreakdownByOrg, BreakdownByReason:
+		return true
+	}
+	return false
+}
+
+// DailyActivity counts what happened to notifications on one UTC day
+type DailyActivity struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	// Received counts notifications synced, so one updated twice in a day counts twice
+	Received int64 `json:"received"`
+	Read     int64 `json:"read"`
+	Archived int64 `json:"archived"`
+}
+
+// ActivityReport is a day-by-day series of notification activity over a window, with how
+// quickly the user read notifications in it.
+type ActivityReport struct {
+	Since time.Time `json:"since"`
+	Days  int       `json:"days"`
+	// Daily holds one entry per day of the window, oldest first, including days without
+	// any activity
+	Daily []DailyActivity `json:"daily"`
+	// MeanTimeToReadSeconds is the average wait between a notification's latest update on
+	// GitHub and the user first reading it, or 0 when nothing was read
+	MeanTimeToReadSeconds int64 `json:"meanTimeToReadSeconds"`
+	// ReadMeasured counts the notifications the mean time to read is taken over
+	ReadMeasured int64 `json:"readMeasured"`
+}
+
+// VolumeEntry counts the notifications in one group of a breakdown
+type VolumeEntry struct {
+	Key    string `json:"key"`
+	Total  int64  `json:"total"`
+	Unread int64  `json:"unread"`
+}
+
+// VolumeBreakdown counts notifications updated in a window by repository, org or reason.
+type VolumeBreakdown struct {
+	Since time.Time          `json:"since"`
+	Days  int                `json:"days"`
+	By    BreakdownDimension `json:"by"`
+	// Entries holds one entry per group, largest first
+	Entries []VolumeEntry `json:"entries"`
+	Total   int64         `json:"total"`
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// API token scopes
+const (
+	APITokenScopeRead  = "read"  // GET and HEAD requests only
+	APITokenScopeWrite = "write" // Everything a signed-in user can do
+)
+
+// APIToken is a personal API token for scripts and CLI tools. The token itself is only
+// shown once, when it's created; Prefix is enough to tell tokens apart.
+type APIToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scope      string     `json:"scope"`
+	Account    string     `json:"account"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// APITokenFromDB converts a db.APIToken to an APIToken
+func APITokenFromDB(token db.APIToken) APIToken {
+	return APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scope:      token.Scope,
+		Account:    token.Account,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  NullTimePtr(token.ExpiresAt),
+		LastUsedAt: NullTimePtr(token.LastUsedAt),
+	}
+}
+
+// ReadOnly reports whether the token may only make GET and HEAD requests
+func (t APIToken) ReadOnly() bool {
+	return t.Scope != APITokenScopeWrite
+}
+
+// APITokenParams contains the parameters for creating an API token. Account is the
+// GitHub login the creator may access, which the token inherits. A nil ExpiresAt
+// creates a token that doesn't expire.
+type APITokenParams struct {
+	Name      string
+	Scope     string
+	Account   string
+	ExpiresAt *time.Time
+}
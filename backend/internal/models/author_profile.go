@@ -0,0 +1,61 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"strings"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// Author types
+const (
+	AuthorTypeUser = "user"
+	AuthorTypeBot  = "bot"
+	AuthorTypeOrg  = "org"
+)
+
+// AuthorProfile represents a notification author's GitHub profile
+type AuthorProfile struct {
+	Login     string  `json:"login"`
+	Name      *string `json:"name,omitempty"`
+	AvatarURL *string `json:"avatarUrl,omitempty"`
+	Type      string  `json:"type"`
+}
+
+// IsBot reports whether the author is a bot account
+func (p AuthorProfile) IsBot() bool {
+	return p.Type == AuthorTypeBot
+}
+
+// AuthorProfileFromDB converts a db.AuthorProfile to an AuthorProfile
+func AuthorProfileFromDB(profile db.AuthorProfile) AuthorProfile {
+	return AuthorProfile{
+		Login:     profile.Login,
+		Name:      NullStringPtr(profile.Name),
+		AvatarURL: NullStringPtr(profile.AvatarURL),
+		Type:      profile.Type,
+	}
+}
+
+// AuthorTypeFromLogin guesses an author's type from their login alone.
+// GitHub App bots always have logins ending in "[bot]".
+func AuthorTypeFromLogin(login string) string {
+	if strings.HasSuffix(login, "[bot]") {
+		return AuthorTypeBot
+	}
+	return AuthorTypeUser
+}
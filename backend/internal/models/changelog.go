@@ -0,0 +1,44 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// ChangelogEntry is a user-facing note about a change introduced by an update
+type ChangelogEntry struct {
+	ID            int64     `json:"id"`
+	SchemaVersion int64     `json:"schemaVersion"`
+	Kind          string    `json:"kind"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ChangelogEntryFromDB converts a db.ChangelogEntry to a models.ChangelogEntry
+func ChangelogEntryFromDB(entry db.ChangelogEntry) ChangelogEntry {
+	return ChangelogEntry{
+		ID:            entry.ID,
+		SchemaVersion: entry.SchemaVersion,
+		Kind:          entry.Kind,
+		Title:         entry.Title,
+		Description:   entry.Description,
+		CreatedAt:     entry.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// EventType says what an inbox event is about
+type EventType string
+
+// EventType constants
+const (
+	// EventNotificationUpserted means a notification was created or updated
+	EventNotificationUpserted EventType = "notification_upserted"
+	// EventBulkCompleted means a bulk operation finished
+	EventBulkCompleted EventType = "bulk_completed"
+	// EventSnoozeReturned means a notification's snooze ran out and it is back in the inbox
+	EventSnoozeReturned EventType = "snooze_returned"
+	// EventSyncStatus means a sync with GitHub started, completed or failed
+	EventSyncStatus EventType = "sync_status"
+	// EventReset means events were dropped because the client fell behind, so it
+	// should reload what it shows
+	EventReset EventType = "reset"
+)
+
+// SyncStatus state constants
+const (
+	SyncStatusStarted   = "started"
+	SyncStatusCompleted = "completed"
+	SyncStatusFailed    = "failed"
+)
+
+// Event is a change pushed to clients listening for live inbox updates
+type Event struct {
+	Type      EventType   `json:"type"`
+	GithubID  string      `json:"githubId,omitempty"`
+	Bulk      *BulkResult `json:"bulk,omitempty"`
+	Sync      *SyncStatus `json:"sync,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// BulkResult describes a finished bulk operation
+type BulkResult struct {
+	Operation string `json:"operation"`
+	Count     int64  `json:"count"`
+}
+
+// SyncStatus describes where a sync with GitHub is at
+type SyncStatus struct {
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// HiddenMechanism is a way notifications are kept out of the inbox for now
+type HiddenMechanism string
+
+// HiddenMechanism constants
+const (
+	HiddenSnoozed  HiddenMechanism = "snoozed"
+	HiddenMuted    HiddenMechanism = "muted"
+	HiddenFiltered HiddenMechanism = "filtered"
+	// HiddenAccount is the account-wide mute of desktop notifications
+	HiddenAccount HiddenMechanism = "account"
+)
+
+// HiddenGroup lists the notifications hidden by one mechanism
+type HiddenGroup struct {
+	Mechanism HiddenMechanism `json:"mechanism"`
+	// Query lists every notification in the group
+	Query string `json:"query"`
+	Total int64  `json:"total"`
+	// Notifications are the first notifications of the group. Snoozed notifications
+	// carry their wake time in snoozedUntil; muted and filtered ones never wake on their own.
+	Notifications []Notification `json:"notifications"`
+}
+
+// HiddenSummary is everything currently hidden, grouped by mechanism
+type HiddenSummary struct {
+	Groups []HiddenGroup `json:"groups"`
+	// AccountMutedUntil is when the account-wide mute ends, if it's active
+	AccountMutedUntil *time.Time `json:"accountMutedUntil,omitempty"`
+}
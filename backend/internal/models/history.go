@@ -0,0 +1,83 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package models
+
+import "time"
+
+// HistoryAction is a change recorded in a notification's history
+type HistoryAction string
+
+// HistoryAction constants
+const (
+	HistoryRead           HistoryAction = "read"
+	HistoryUnread         HistoryAction = "unread"
+	HistoryArchived       HistoryAction = "archived"
+	HistoryUnarchived     HistoryAction = "unarchived"
+	HistoryMuted          HistoryAction = "muted"
+	HistoryUnmuted        HistoryAction = "unmuted"
+	HistoryStarred        HistoryAction = "starred"
+	HistoryUnstarred      HistoryAction = "unstarred"
+	HistorySnoozed        HistoryAction = "snoozed"
+	HistoryUnsnoozed      HistoryAction = "unsnoozed"
+	HistorySnoozeReturned HistoryAction = "snooze_returned"
+	HistoryUnfiltered     HistoryAction = "unfiltered"
+	HistoryEscalated      HistoryAction = "escalated"
+	HistoryRuleApplied    HistoryAction = "rule_applied"
+	HistorySynced         HistoryAction = "synced"
+	// HistoryUndone is a bulk operation being undone; the detail is the operation
+	HistoryUndone HistoryAction = "undone"
+)
+
+// HistorySource says what made a change in a notification's history
+type HistorySource string
+
+// HistorySource constants
+const (
+	HistorySourceUser HistorySource = "user"
+	HistorySourceRule HistorySource = "rule"
+	HistorySourceSync HistorySource = "sync"
+	// HistorySourceSystem is a scheduled job, such as returning snoozes or enforcing WIP limits
+	HistorySourceSystem HistorySource = "system"
+)
+
+// HistoryActionForBulkOp returns the history action a bulk operation records
+func HistoryActionForBulkOp(op BulkOperationType) (HistoryAction, bool) {
+	action, ok := bulkOpHistoryActions[op]
+	return action, ok
+}
+
+var bulkOpHistoryActions = map[BulkOperationType]HistoryAction{
+	BulkOpMarkRead:   HistoryRead,
+	BulkOpMarkUnread: HistoryUnread,
+	BulkOpArchive:    HistoryArchived,
+	BulkOpUnarchive:  HistoryUnarchived,
+	BulkOpMute:       HistoryMuted,
+	BulkOpUnmute:     HistoryUnmuted,
+	BulkOpStar:       HistoryStarred,
+	BulkOpUnstar:     HistoryUnstarred,
+	BulkOpSnooze:     HistorySnoozed,
+	BulkOpUnsnooze:   HistoryUnsnoozed,
+	BulkOpUnfilter:   HistoryUnfiltered,
+}
+
+// NotificationEvent is one change in a notification's history
+type NotificationEvent struct {
+	Action HistoryAction `json:"action"`
+	Source HistorySource `json:"source"`
+	// Detail says more about the change, such as which rule applied or which view's WIP
+	// limit snoozed the notification
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// Formats an integration's deliveries are sent in
+const (
+	IntegrationFormatJSON  = "json"  // Octobud's own payload, for generic webhooks
+	IntegrationFormatSlack = "slack" // A Slack incoming webhook message
+)
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"   // Not sent yet, or waiting to be retried
+	DeliveryDelivered = "delivered" // The endpoint accepted it
+	DeliveryFailed    = "failed"    // Gave up after running out of attempts or being refused
+)
+
+// Integration is an outbound webhook that rules can notify. The secret is never returned;
+// HasSecret says whether deliveries are signed.
+type Integration struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Format    string    `json:"format"`
+	URL       string    `json:"url"`
+	HasSecret bool      `json:"hasSecret"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// IntegrationFromDB converts a db.Integration to an Integration
+func IntegrationFromDB(integration db.Integration) Integration {
+	return Integration{
+		ID:        integration.ID,
+		Name:      integration.Name,
+		Format:    integration.Format,
+		URL:       integration.URL,
+		HasSecret: integration.SecretEncrypted.Valid && integration.SecretEncrypted.String != "",
+		CreatedAt: integration.CreatedAt,
+		UpdatedAt: integration.UpdatedAt,
+	}
+}
+
+// IntegrationParams contains the parameters for creating or updating an integration. A nil
+// Secret keeps the current secret on update; an empty one removes it.
+type IntegrationParams struct {
+	Name   string
+	Format string
+	URL    string
+	Secret *string
+}
+
+// IntegrationDelivery is one notification a rule sent, or is still sending, to an
+// integration
+type IntegrationDelivery struct {
+	ID             int64      `json:"id"`
+	IntegrationID  string     `json:"integrationId"`
+	RuleID         *string    `json:"ruleId,omitempty"`
+	RuleName       string     `json:"ruleName"`
+	GithubID       string     `json:"githubId"`
+	Status         string     `json:"status"`
+	Attempts       int64      `json:"attempts"`
+	ResponseStatus *int64     `json:"responseStatus,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"` // Only while pending
+	CreatedAt      time.Time  `json:"createdAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+}
+
+// IntegrationDeliveryFromDB converts a db.IntegrationDelivery to an IntegrationDelivery
+func IntegrationDeliveryFromDB(delivery db.IntegrationDelivery) IntegrationDelivery {
+	result := IntegrationDelivery{
+		ID:             delivery.ID,
+		IntegrationID:  delivery.IntegrationID,
+		RuleID:         NullStringPtr(delivery.RuleID),
+		RuleName:       delivery.RuleName,
+		GithubID:       delivery.GithubID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: NullInt64Ptr(delivery.ResponseStatus),
+		Error:          NullStringPtr(delivery.LastError),
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    NullTimePtr(delivery.DeliveredAt),
+	}
+	if delivery.Status == DeliveryPending {
+		next := delivery.NextAttemptAt
+		result.NextAttemptAt = &next
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// LiveQueryEventType says how a live query's results changed
+type LiveQueryEventType string
+
+// LiveQueryEventType constants
+const (
+	// LiveQueryMatch means a notification started matching the query
+	LiveQueryMatch LiveQueryEventType = "match"
+	// LiveQueryUnmatch means a notification stopped matching the query
+	LiveQueryUnmatch LiveQueryEventType = "unmatch"
+	// LiveQueryReset means events were dropped because the client fell behind, so it
+	// should reload the query's results
+	LiveQueryReset LiveQueryEventType = "reset"
+)
+
+// LiveQueryEvent is a change to the notifications matching a live query
+type LiveQueryEvent struct {
+	Type      LiveQueryEventType `json:"type"`
+	GithubID  string             `json:"githubId,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
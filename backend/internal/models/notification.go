@@ -38,6 +38,8 @@ type Notification struct {
 	Muted                   bool            `json:"muted"`
 	SnoozedUntil            *time.Time      `json:"snoozedUntil,omitempty"`
 	SnoozedAt               *time.Time      `json:"snoozedAt,omitempty"`
+	SnoozeUntilUpdated      bool            `json:"snoozeUntilUpdated,omitempty"`
+	ReturnedFromSnoozeAt    *time.Time      `json:"returnedFromSnoozeAt,omitempty"`
 	EffectiveSortDate       time.Time       `json:"effectiveSortDate"`
 	Starred                 bool            `json:"starred"`
 	Filtered                bool            `json:"filtered"`
@@ -54,10 +56,34 @@ type Notification struct {
 	SubjectState            *string         `json:"subjectState,omitempty"`
 	SubjectMerged           *bool           `json:"subjectMerged,omitempty"`
 	SubjectStateReason      *string         `json:"subjectStateReason,omitempty"`
+	ContentKind             string          `json:"contentKind"`
+	HeadBranch              *string         `json:"headBranch,omitempty"`
+	BaseBranch              *string         `json:"baseBranch,omitempty"`
+	SubjectDraft            *bool           `json:"subjectDraft,omitempty"`
+	Labels                  []string        `json:"labels,omitempty"`
+	ReviewState             *string         `json:"reviewState,omitempty"`
+	Milestone               *string         `json:"milestone,omitempty"`
+	Projects                []string        `json:"projects,omitempty"`
+	CIState                 *string         `json:"ciState,omitempty"`
+	Mergeable               *bool           `json:"mergeable,omitempty"`
+	WaitingOn               *string         `json:"waitingOn,omitempty"`
+	Priority                *int64          `json:"priority,omitempty"`
+	Account                 *string         `json:"account,omitempty"`
+	AwaitingReply           bool            `json:"awaitingReply"`
+	AwaitingReplySince      *time.Time      `json:"awaitingReplySince,omitempty"`
 	AuthorLogin             *string         `json:"authorLogin,omitempty"`
+	Author                  *AuthorProfile  `json:"author,omitempty"`
 	Repository              *Repository     `json:"repository,omitempty"`
 	ActionHints             *ActionHints    `json:"actionHints,omitempty"`
 	Tags                    []Tag           `json:"tags,omitempty"`
+	Conversation            *Conversation   `json:"conversation,omitempty"`
+}
+
+// Conversation summarizes the notifications sharing a subject when a list collapses them
+// into the newest one
+type Conversation struct {
+	Count        int64   `json:"count"`                  // Notifications for the subject, this one included
+	NewestReason *string `json:"newestReason,omitempty"` // Why the newest of them was sent
 }
 
 // NotificationFromDB converts a db.Notification to a models.Notification (without enrichment)
@@ -72,6 +98,16 @@ func NotificationFromDB(notification db.Notification) Notification {
 		subjectRaw = notification.SubjectRaw.RawMessage
 	}
 
+	// Labels are stored as a JSON array of names; a malformed value is treated as none
+	var labels []string
+	if notification.SubjectLabels.Valid {
+		_ = json.Unmarshal(notification.SubjectLabels.RawMessage, &labels)
+	}
+	var projects []string
+	if notification.SubjectProjects.Valid {
+		_ = json.Unmarshal(notification.SubjectProjects.RawMessage, &projects)
+	}
+
 	return Notification{
 		ID:                      notification.ID,
 		GithubID:                notification.GithubID,
@@ -87,6 +123,8 @@ func NotificationFromDB(notification db.Notification) Notification {
 		Muted:                   notification.Muted,
 		SnoozedUntil:            NullTimePtr(notification.SnoozedUntil),
 		SnoozedAt:               NullTimePtr(notification.SnoozedAt),
+		SnoozeUntilUpdated:      notification.SnoozeUntilUpdated && notification.SnoozedUntil.Valid,
+		ReturnedFromSnoozeAt:    NullTimePtr(notification.ReturnedFromSnoozeAt),
 		EffectiveSortDate:       notification.EffectiveSortDate,
 		Starred:                 notification.Starred,
 		Filtered:                notification.Filtered,
@@ -104,6 +142,21 @@ func NotificationFromDB(notification db.Notification) Notification {
 		SubjectState:            NullStringPtr(notification.SubjectState),
 		SubjectMerged:           NullBoolPtr(notification.SubjectMerged),
 		SubjectStateReason:      NullStringPtr(notification.SubjectStateReason),
+		ContentKind:             notification.ContentKind,
+		HeadBranch:              NullStringPtr(notification.HeadBranch),
+		BaseBranch:              NullStringPtr(notification.BaseBranch),
+		SubjectDraft:            NullBoolPtr(notification.SubjectDraft),
+		Labels:                  labels,
+		ReviewState:             NullStringPtr(notification.ReviewState),
+		Milestone:               NullStringPtr(notification.SubjectMilestone),
+		Projects:                projects,
+		CIState:                 NullStringPtr(notification.CIState),
+		Mergeable:               NullBoolPtr(notification.SubjectMergeable),
+		WaitingOn:               NullStringPtr(notification.WaitingOn),
+		Priority:                NullInt64Ptr(notification.Priority),
+		Account:                 NullStringPtr(notification.Account),
+		AwaitingReply:           notification.AwaitingReply,
+		AwaitingReplySince:      NullTimePtr(notification.AwaitingReplySince),
 	}
 }
 
@@ -41,6 +41,13 @@ type BulkOperationTarget struct {
 	Query string
 }
 
+// BulkUndoResult describes a bulk operation that was undone
+type BulkUndoResult struct {
+	Operation BulkOperationType
+	// GithubIDs are the notifications that were restored
+	GithubIDs []string
+}
+
 // BulkUpdateParams holds optional parameters for bulk operations
 type BulkUpdateParams struct {
 	// SnoozedUntil is required for snooze operations (RFC3339 format)
@@ -22,10 +22,29 @@ import (
 // ListOptions captures the available filtering and pagination controls for listing notifications.
 type ListOptions struct {
 	//nolint:lll // Long comment with example
-	Query          string // Combined query string with key-value pairs and free text (e.g., "repo:cli/cli urgent PR -author:bot")
-	Page           int
-	PageSize       int
-	IncludeSubject bool // Whether to include subjectRaw in the response (default: false to reduce payload size)
+	Query           string // Combined query string with key-value pairs and free text (e.g., "repo:cli/cli urgent PR -author:bot")
+	Page            int
+	PageSize        int
+	IncludeSubject  bool // Whether to include subjectRaw in the response (default: false to reduce payload size)
+	ExplainDefaults bool // Whether to report the implicit filters applied to the query
+	Conversations   bool // Whether to list each subject once, as its newest notification
+	// Cursor is a previous page's NextCursor. When set, the page starts right after it and
+	// Page is ignored, so notifications moving while triaging don't shift later pages.
+	Cursor string
+	// Sort orders the list: SortPriority lists the highest priority score first, and empty
+	// lists the most recently updated first
+	Sort string
+}
+
+// SortPriority is the ListOptions.Sort value that lists the highest priority score first
+const SortPriority = "priority"
+
+// NextOptions selects the notification next to a cursor in a query's list order. At most
+// one of After and Before is set; with neither, the first notification is selected.
+type NextOptions struct {
+	Query  string // Same syntax and defaults as ListOptions.Query
+	After  string // GitHub ID of the notification to step forward from
+	Before string // GitHub ID of the notification to step back from
 }
 
 // ListResult is the normalized output of a filtered list request.
@@ -42,6 +61,16 @@ type ListDetailsResult struct {
 	Total         int64
 	Page          int
 	PageSize      int
+	Defaults      *QueryDefaults // Set when ListOptions.ExplainDefaults is true
+	// NextCursor continues the list after this page, or is empty on the last page
+	NextCursor string
+}
+
+// QueryRunResult is the output of running an ad-hoc query: a page of matching
+// notifications, with Total counting every match and UnreadCount the unread ones
+type QueryRunResult struct {
+	ListDetailsResult
+	UnreadCount int64
 }
 
 // ListPollResult is the output of a filtered list request with only essential fields for polling.
@@ -51,3 +80,25 @@ type ListPollResult struct {
 	Page          int
 	PageSize      int
 }
+
+// GroupOptions selects how the notifications matching a query are grouped.
+type GroupOptions struct {
+	Query    string // Same syntax and defaults as ListOptions.Query
+	GroupBy  string // repo, org, reason, author or type
+	PerGroup int    // Newest notifications returned per group (0 = counts only)
+}
+
+// NotificationGroup is a group of notifications sharing a value for the group key. Key is
+// empty for notifications without a value, such as those with no known author.
+type NotificationGroup struct {
+	Key           string         `json:"key"`
+	Count         int64          `json:"count"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// GroupResult is the output of a grouped list request, most recently active group first.
+type GroupResult struct {
+	GroupBy string              `json:"groupBy"`
+	Total   int64               `json:"total"`
+	Groups  []NotificationGroup `json:"groups"`
+}
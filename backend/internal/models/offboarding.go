@@ -0,0 +1,39 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// OffboardAction is what happens to an org's notifications when the user leaves it
+type OffboardAction string
+
+// OffboardAction constants
+const (
+	OffboardArchive OffboardAction = "archive"
+	OffboardDelete  OffboardAction = "delete"
+)
+
+// OrgOffboarding summarizes leaving an org: its notifications were archived or deleted
+// and its repositories are no longer synced
+type OrgOffboarding struct {
+	Org    string         `json:"org"`
+	Action OffboardAction `json:"action"`
+	// NotificationsAffected is how many notifications were archived or deleted
+	NotificationsAffected int64 `json:"notificationsAffected"`
+	// Repositories is how many of the org's repositories were taken out of sync
+	Repositories int64     `json:"repositories"`
+	OffboardedAt time.Time `json:"offboardedAt"`
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// PrioritySettings holds the weights a notification's priority score is worked out from.
+// The score adds up the points for its reason, repository, author and CI state, and the
+// points for each day it has gone without an update.
+type PrioritySettings struct {
+	Reasons          map[string]int `json:"reasons"`          // Points by reason, e.g. "review_requested"
+	Repositories     map[string]int `json:"repositories"`     // Points by repository full name, e.g. "cli/cli"
+	Authors          map[string]int `json:"authors"`          // Points by author login
+	CIStates         map[string]int `json:"ciStates"`         // Points by CI state: failure, pending or success
+	StalenessPerDay  int            `json:"stalenessPerDay"`  // Points for each day without an update
+	MaxStalenessDays int            `json:"maxStalenessDays"` // Days after which staleness stops adding points
+}
+
+// PriorityInputs is what a notification's priority score is worked out from. Empty fields
+// score nothing.
+type PriorityInputs struct {
+	Reason     string
+	Repository string // Full name, e.g. "cli/cli"
+	Author     string
+	CIState    string
+	UpdatedAt  time.Time // When GitHub last updated the thread
+}
+
+// DefaultPrioritySettings returns the default priority weights, which rank security alerts
+// and requests for the user's attention above threads they're only watching
+func DefaultPrioritySettings() *PrioritySettings {
+	return &PrioritySettings{
+		Reasons: map[string]int{
+			"security_alert":   40,
+			"review_requested": 30,
+			"mention":          25,
+			"assign":           20,
+			"team_mention":     15,
+			"author":           10,
+			"comment":          5,
+			"manual":           5,
+		},
+		Repositories:     map[string]int{},
+		Authors:          map[string]int{},
+		CIStates:         map[string]int{"failure": 15},
+		StalenessPerDay:  1,
+		MaxStalenessDays: 14,
+	}
+}
+
+// Score returns the priority score of a notification as of now
+func (s *PrioritySettings) Score(in PriorityInputs, now time.Time) int64 {
+	score := int64(weight(s.Reasons, in.Reason))
+	score += int64(weight(s.Repositories, in.Repository))
+	score += int64(weight(s.Authors, in.Author))
+	score += int64(weight(s.CIStates, in.CIState))
+
+	if !in.UpdatedAt.IsZero() && now.After(in.UpdatedAt) {
+		days := int64(now.Sub(in.UpdatedAt) / (24 * time.Hour))
+		days = min(days, int64(s.MaxStalenessDays))
+		score += days * int64(s.StalenessPerDay)
+	}
+	return score
+}
+
+// weight returns the points for key, matched case-insensitively since logins and
+// repository names are
+func weight(weights map[string]int, key string) int {
+	if key == "" {
+		return 0
+	}
+	if points, ok := weights[key]; ok {
+		return points
+	}
+	for name, points := range weights {
+		if strings.EqualFold(name, key) {
+			return points
+		}
+	}
+	return 0
+}
+
+// ToJSON converts PrioritySettings to JSON bytes
+func (s *PrioritySettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// PrioritySettingsFromJSON creates PrioritySettings from JSON bytes
+func PrioritySettingsFromJSON(data json.RawMessage) (*PrioritySettings, error) {
+	if len(data) == 0 {
+		return DefaultPrioritySettings(), nil
+	}
+	var settings PrioritySettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	if settings.Reasons == nil {
+		settings.Reasons = map[string]int{}
+	}
+	if settings.Repositories == nil {
+		settings.Repositories = map[string]int{}
+	}
+	if settings.Authors == nil {
+		settings.Authors = map[string]int{}
+	}
+	if settings.CIStates == nil {
+		settings.CIStates = map[string]int{}
+	}
+	return &settings, nil
+}
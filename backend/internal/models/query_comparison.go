@@ -0,0 +1,39 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// QueryIDSet is a set of notifications identified by GitHub ID. Count is always the full size of
+// the set; IDs may be truncated, in which case Truncated is true.
+type QueryIDSet struct {
+	Count     int      `json:"count"`
+	IDs       []string `json:"ids"`
+	Truncated bool     `json:"truncated,omitempty"`
+}
+
+// QueryComparisonSide describes one of the two queries being compared.
+type QueryComparisonSide struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// QueryComparison is the set relationship between the notifications matched by two queries.
+type QueryComparison struct {
+	Left      QueryComparisonSide `json:"left"`
+	Right     QueryComparisonSide `json:"right"`
+	OnlyLeft  QueryIDSet          `json:"onlyLeft"`
+	OnlyRight QueryIDSet          `json:"onlyRight"`
+	Both      QueryIDSet          `json:"both"`
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// Scopes of implicit filters a notification query can get
+const (
+	QueryDefaultScopeInbox     = "inbox"
+	QueryDefaultScopeMutedOnly = "mutedOnly"
+	QueryDefaultScopeNone      = "none"
+)
+
+// QueryDefaults explains which implicit filters were added to a notification query and why
+type QueryDefaults struct {
+	Scope    string   `json:"scope"`
+	Excluded []string `json:"excluded"` // States hidden by the defaults, e.g. "muted"
+	Reason   string   `json:"reason"`
+}
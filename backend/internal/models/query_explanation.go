@@ -0,0 +1,40 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// QueryPlanStep is a step in SQLite's plan for a query. Parent is the ID of the step it's
+// nested under, or 0 at the top level.
+type QueryPlanStep struct {
+	ID     int64  `json:"id"`
+	Parent int64  `json:"parent"`
+	Detail string `json:"detail"`
+}
+
+// QueryExplanation shows how a notification query is run: the joins and conditions it
+// compiles to, the implicit defaults added to it, the full statement that lists its first
+// page with the arguments bound to the statement's placeholders in order, and SQLite's plan
+// for that statement. Joins, Where and Args cover only what the query itself adds.
+type QueryExplanation struct {
+	Query    string          `json:"query"`
+	Sort     string          `json:"sort,omitempty"`
+	Joins    []string        `json:"joins"`
+	Where    []string        `json:"where"`
+	Args     []any           `json:"args"`
+	Defaults QueryDefaults   `json:"defaults"`
+	SQL      string          `json:"sql"`
+	SQLArgs  []any           `json:"sqlArgs"`
+	Plan     []QueryPlanStep `json:"plan"`
+}
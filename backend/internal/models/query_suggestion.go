@@ -0,0 +1,37 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package models
+
+// Kinds of query suggestions
+const (
+	QuerySuggestionField = "field"
+	QuerySuggestionValue = "value"
+)
+
+// QuerySuggestion is a completion candidate for a partly typed query
+type QuerySuggestion struct {
+	Kind  string `json:"kind"`  // "field" for a field name like "repo:", "value" for a value
+	Value string `json:"value"` // The text to insert
+}
+
+// QuerySuggestions are the completions for the text at a cursor in a query. Choosing a
+// suggestion replaces the query between Start and End with its value.
+type QuerySuggestions struct {
+	Field       string            `json:"field,omitempty"` // Field whose value is being typed
+	Prefix      string            `json:"prefix"`
+	Start       int               `json:"start"`
+	End         int               `json:"end"`
+	Suggestions []QuerySuggestion `json:"suggestions"`
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// QuickLookItem is a compact notification summary for OS-level quick look integrations.
+// Its JSON shape is part of the stable quick look API; only add fields, never rename them.
+type QuickLookItem struct {
+	GithubID    string    `json:"githubId"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	Repository  string    `json:"repository"`
+	AuthorLogin *string   `json:"authorLogin,omitempty"`
+	Reason      *string   `json:"reason,omitempty"`
+	Number      *int64    `json:"number,omitempty"`
+	IsRead      bool      `json:"isRead"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	URL         *string   `json:"url,omitempty"`
+}
+
+// QuickLookSummary is the inbox unread count plus the newest inbox items.
+type QuickLookSummary struct {
+	UnreadCount int64           `json:"unreadCount"`
+	Items       []QuickLookItem `json:"items"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+}
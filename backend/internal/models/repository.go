@@ -47,6 +47,24 @@ type Repository struct {
 	OwnerAvatarURL *string         `json:"ownerAvatarUrl,omitempty"`
 
 	OwnerHTMLURL *string `json:"ownerHtmlUrl,omitempty"`
+
+	Digest *RepositoryDigest `json:"digest,omitempty"`
+}
+
+// RepositoryDigest summarizes the unread notifications of a repository
+type RepositoryDigest struct {
+	UnreadCount            int64                  `json:"unreadCount"`
+	ReasonCounts           map[string]int64       `json:"reasonCounts"`
+	OldestUnreadAt         *time.Time             `json:"oldestUnreadAt,omitempty"`
+	OldestUnreadAgeSeconds int64                  `json:"oldestUnreadAgeSeconds"`
+	RecentUnread           []RepositoryDigestItem `json:"recentUnread"`
+}
+
+// RepositoryDigestItem is one of the most recently updated unread notifications of a repository
+type RepositoryDigestItem struct {
+	GithubID  string    `json:"githubId"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 // RepositoryFromDB converts a db.Repository to a Repository
@@ -80,3 +98,59 @@ func RepositoryFromDB(repository db.Repository) Repository {
 		OwnerHTMLURL:   NullStringPtr(repository.OwnerHTMLURL),
 	}
 }
+
+// RepositoryDigestFromDB converts a db.RepositoryDigest to a RepositoryDigest, measuring
+// the age of the oldest unread notification relative to now
+func RepositoryDigestFromDB(digest db.RepositoryDigest, now time.Time) RepositoryDigest {
+	result := RepositoryDigest{
+		UnreadCount:  digest.UnreadCount,
+		ReasonCounts: make(map[string]int64, len(digest.ReasonCounts)),
+		RecentUnread: make([]RepositoryDigestItem, 0, len(digest.RecentUnread)),
+	}
+	for reason, count := range digest.ReasonCounts {
+		result.ReasonCounts[reason] = count
+	}
+	if !digest.OldestUnreadAt.IsZero() {
+		oldest := digest.OldestUnreadAt
+		result.OldestUnreadAt = &oldest
+		if age := now.Sub(oldest); age > 0 {
+			result.OldestUnreadAgeSeconds = int64(age / time.Second)
+		}
+	}
+	for _, item := range digest.RecentUnread {
+		result.RecentUnread = append(result.RecentUnread, RepositoryDigestItem{
+			GithubID:  item.GithubID,
+			Title:     item.SubjectTitle,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return result
+}
+
+// How a repository's notifications are synced
+const (
+	RepositorySyncFull = "full"
+	// The notification's title, reason and links are stored, but its subject isn't fetched
+	RepositorySyncHeadersOnly = "headers_only"
+	// Notifications from the repository are skipped
+	RepositorySyncExcluded = "excluded"
+)
+
+// RepositorySettings holds the user's settings for a repository
+type RepositorySettings struct {
+	RepositoryID  int64   `json:"repositoryId"`
+	DefaultSnooze *string `json:"defaultSnooze"` // Overrides the user's default snooze (null = use it)
+	// Overrides the user's resurface suppression window (null = use it, 0 = off)
+	ResurfaceSuppressionMinutes *int64 `json:"resurfaceSuppressionMinutes"`
+	SyncMode                    string `json:"syncMode"`
+}
+
+// RepositorySettingsFromDB converts a db.RepositorySetting to RepositorySettings
+func RepositorySettingsFromDB(settings db.RepositorySetting) RepositorySettings {
+	return RepositorySettings{
+		RepositoryID:                settings.RepositoryID,
+		DefaultSnooze:               NullStringPtr(settings.DefaultSnooze),
+		ResurfaceSuppressionMinutes: NullInt64Ptr(settings.ResurfaceSuppressionMinutes),
+		SyncMode:                    settings.SyncMode,
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// ReviewerLoad is the number of review requests pending on one reviewer in the window.
+type ReviewerLoad struct {
+	Login string `json:"login"`
+	// Requests counts review requests on which the reviewer is still requested
+	Requests int `json:"requests"`
+	// Open counts the requests whose pull request is still open
+	Open int `json:"open"`
+	// Unread counts the requests whose notification is still unread
+	Unread int `json:"unread"`
+	// Overloaded is set when Requests is well above the team average
+	Overloaded bool `json:"overloaded"`
+}
+
+// ReviewLoadReport aggregates review requests by requested reviewer over a window.
+type ReviewLoadReport struct {
+	Since time.Time `json:"since"`
+	Days  int       `json:"days"`
+	// Reviewers holds one entry per team member, busiest first. Without configured team
+	// members, every requested reviewer seen in the window is listed.
+	Reviewers []ReviewerLoad `json:"reviewers"`
+	// Average is the mean number of requests per listed reviewer
+	Average float64 `json:"average"`
+	// TotalRequests counts review requests in the window
+	TotalRequests int `json:"totalRequests"`
+	// Unassigned counts requests with none of the listed reviewers requested, including
+	// pull requests synced before reviewers were recorded
+	Unassigned int `json:"unassigned"`
+}
@@ -32,8 +32,14 @@ type Rule struct {
 	Actions      RuleActions `json:"actions"`
 	Enabled      bool        `json:"enabled"`
 	DisplayOrder int         `json:"displayOrder"`
-	CreatedAt    string      `json:"createdAt"`
-	UpdatedAt    string      `json:"updatedAt"`
+	// Rules run highest priority first, then in display order
+	Priority int `json:"priority"`
+	// When the rule matches, rules after it don't run
+	StopProcessing bool `json:"stopProcessing"`
+	// Expression a notification must also satisfy, checked after the query
+	Condition string `json:"condition,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
 }
 
 // CreateRuleParams contains parameters for creating a rule
@@ -44,17 +50,73 @@ type CreateRuleParams struct {
 	ViewID          *string
 	Actions         RuleActions
 	Enabled         *bool
+	Priority        int
+	StopProcessing  bool
+	Condition       *string
 	ApplyToExisting bool
 }
 
 // UpdateRuleParams contains parameters for updating a rule
 type UpdateRuleParams struct {
-	Name        *string
-	Description *string
-	Query       *string
-	ViewID      *string
-	Actions     *RuleActions
-	Enabled     *bool
+	Name           *string
+	Description    *string
+	Query          *string
+	ViewID         *string
+	Actions        *RuleActions
+	Enabled        *bool
+	Priority       *int
+	StopProcessing *bool
+	Condition      *string // An empty string clears it
+}
+
+// RuleRunResult reports the outcome of running a rule against existing notifications
+type RuleRunResult struct {
+	// Matched is the number of notifications the rule's query and condition matched
+	Matched int64 `json:"matched"`
+	// DryRun is set when the actions were not applied
+	DryRun bool `json:"dryRun"`
+}
+
+// Rule evaluation results
+const (
+	RuleResultMatched    = "matched"
+	RuleResultNotMatched = "not_matched"
+	RuleResultSkipped    = "skipped" // An earlier rule matched and stopped processing
+	RuleResultError      = "error"
+)
+
+// RuleTraceEntry records what happened to one rule when a notification was evaluated
+type RuleTraceEntry struct {
+	RuleID   string `json:"ruleId"`
+	RuleName string `json:"ruleName"`
+	Priority int    `json:"priority"`
+	Result   string `json:"result"`
+	// Set on the rule that matched and stopped processing
+	StoppedProcessing bool   `json:"stoppedProcessing,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+// RuleEvaluation is the latest evaluation of the rules against a notification
+type RuleEvaluation struct {
+	GithubID     string           `json:"githubId"`
+	SubjectTitle string           `json:"subjectTitle"`
+	EvaluatedAt  string           `json:"evaluatedAt"`
+	Rules        []RuleTraceEntry `json:"rules"`
+}
+
+// RuleEvaluationFromDB converts a db.RuleEvaluation to a models.RuleEvaluation
+func RuleEvaluationFromDB(evaluation db.RuleEvaluation) RuleEvaluation {
+	rules := []RuleTraceEntry{}
+	if len(evaluation.Trace) > 0 {
+		// An unreadable trace is shown as empty rather than failing the whole list
+		_ = json.Unmarshal(evaluation.Trace, &rules)
+	}
+	return RuleEvaluation{
+		GithubID:     evaluation.GithubID,
+		SubjectTitle: evaluation.SubjectTitle,
+		EvaluatedAt:  evaluation.EvaluatedAt.Format(time.RFC3339),
+		Rules:        rules,
+	}
 }
 
 // RuleFromDB converts a db.Rule to a models.Rule
@@ -80,15 +142,18 @@ func RuleFromDB(rule db.Rule) Rule {
 	}
 
 	return Rule{
-		ID:           rule.ID, // Now a UUID string
-		Name:         rule.Name,
-		Description:  NullStringPtr(rule.Description),
-		Query:        queryStr,
-		ViewID:       viewID,
-		Actions:      actions,
-		Enabled:      rule.Enabled,
-		DisplayOrder: int(rule.DisplayOrder),
-		CreatedAt:    rule.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    rule.UpdatedAt.Format(time.RFC3339),
+		ID:             rule.ID, // Now a UUID string
+		Name:           rule.Name,
+		Description:    NullStringPtr(rule.Description),
+		Query:          queryStr,
+		ViewID:         viewID,
+		Actions:        actions,
+		Enabled:        rule.Enabled,
+		DisplayOrder:   int(rule.DisplayOrder),
+		Priority:       int(rule.Priority),
+		StopProcessing: rule.StopProcessing,
+		Condition:      rule.Condition.String,
+		CreatedAt:      rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      rule.UpdatedAt.Format(time.RFC3339),
 	}
 }
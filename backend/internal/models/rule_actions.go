@@ -15,6 +15,8 @@
 
 package models
 
+import "fmt"
+
 // RuleActions represents actions that a rule can perform
 type RuleActions struct {
 	SkipInbox  bool     `json:"skipInbox"`
@@ -24,4 +26,39 @@ type RuleActions struct {
 	Mute       bool     `json:"mute,omitempty"`
 	AssignTags []string `json:"assignTags,omitempty"`
 	RemoveTags []string `json:"removeTags,omitempty"`
+
+	// AddTagSlugs and RemoveTagSlugs name tags by slug instead of ID. A tag in
+	// AddTagSlugs is created the first time the rule matches if it doesn't exist yet.
+	AddTagSlugs    []string `json:"addTagSlugs,omitempty"`
+	RemoveTagSlugs []string `json:"removeTagSlugs,omitempty"`
+
+	// Alert sets how loudly matching notifications alert when they arrive.
+	// The first matching rule with an alert wins.
+	Alert *AlertConfig `json:"alert,omitempty"`
+
+	// NotifyWebhook lists the IDs of integrations sent each notification the rule matches
+	// as it syncs. Running the rule against existing notifications doesn't send them.
+	NotifyWebhook []string `json:"notifyWebhook,omitempty"`
+}
+
+// HasTagActions reports whether the actions add or remove any tags
+func (a RuleActions) HasTagActions() bool {
+	return len(a.AssignTags) > 0 || len(a.RemoveTags) > 0 ||
+		len(a.AddTagSlugs) > 0 || len(a.RemoveTagSlugs) > 0
+}
+
+// ValidateTagSlugs checks that AddTagSlugs and RemoveTagSlugs hold only slugs
+func (a RuleActions) ValidateTagSlugs() error {
+	for _, slugs := range [][]string{a.AddTagSlugs, a.RemoveTagSlugs} {
+		for _, slug := range slugs {
+			suggestion := Slugify(slug)
+			if suggestion == "" {
+				return fmt.Errorf("%q is not a tag slug", slug)
+			}
+			if suggestion != slug {
+				return fmt.Errorf("%q is not a tag slug (try %q)", slug, suggestion)
+			}
+		}
+	}
+	return nil
 }
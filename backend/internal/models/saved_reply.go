@@ -0,0 +1,35 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "github.com/octobud-hq/octobud/backend/internal/db"
+
+// SavedReply represents one of the user's GitHub saved replies. ID is GitHub's ID, so it
+// stays the same across imports.
+type SavedReply struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// SavedReplyFromDB converts a db.SavedReply to a SavedReply
+func SavedReplyFromDB(reply db.SavedReply) SavedReply {
+	return SavedReply{
+		ID:    reply.GithubID,
+		Title: reply.Title,
+		Body:  reply.Body,
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// Server user roles
+const (
+	ServerUserRoleAdmin  = "admin"
+	ServerUserRoleMember = "member"
+)
+
+// ServerUser is a person who signs in to a multi-user server. Each has a workspace of
+// their own, so their GitHub account, notifications, views and rules aren't shared.
+type ServerUser struct {
+	Username    string    `json:"username"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	Workspace   string    `json:"workspace"`   // Slug of the workspace holding their data
+	HasPassword bool      `json:"hasPassword"` // False for users who sign in through a proxy
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// IsAdmin reports whether the user can manage other users
+func (u ServerUser) IsAdmin() bool {
+	return u.Role == ServerUserRoleAdmin
+}
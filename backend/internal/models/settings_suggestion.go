@@ -0,0 +1,43 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// Kinds of settings suggestions
+const (
+	SettingsSuggestionView = "view"
+	SettingsSuggestionRule = "rule"
+)
+
+// SettingsSuggestion is a view or rule suggested to mirror the user's GitHub notification
+// settings
+type SettingsSuggestion struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"` // Which GitHub setting the suggestion mirrors
+	Query  string `json:"query"`
+	// Actions are set for rule suggestions
+	Actions      *RuleActions `json:"actions,omitempty"`
+	Repositories []string     `json:"repositories"`
+	// Adopted is set once a view or rule with the suggestion's name exists
+	Adopted bool `json:"adopted"`
+}
+
+// AdoptedSuggestion is the view or rule created from a settings suggestion
+type AdoptedSuggestion struct {
+	View *View `json:"view,omitempty"`
+	Rule *Rule `json:"rule,omitempty"`
+}
@@ -40,3 +40,8 @@ func Slugify(name string) string {
 
 	return slug
 }
+
+// IsSlug reports whether s is already a slug, i.e. Slugify leaves it unchanged.
+func IsSlug(s string) bool {
+	return s != "" && Slugify(s) == s
+}
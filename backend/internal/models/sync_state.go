@@ -27,4 +27,19 @@ type SyncState struct {
 	UpdatedAt                  time.Time
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	LastNotificationETag       sql.NullString
+	// Progress of the initial sync, kept once it completes
+	InitialSyncStartedAt sql.NullTime
+	InitialSyncPages     int64
+	InitialSyncQueued    int64
+}
+
+// InitialSyncProgress is how far the initial sync got, saved after each page of
+// notifications is queued
+type InitialSyncProgress struct {
+	StartedAt          time.Time
+	Pages              int
+	Queued             int
+	LatestNotification time.Time // Newest notification queued, zero if none
+	OldestNotification time.Time // Oldest notification queued, where fetching resumes
 }
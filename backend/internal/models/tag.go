@@ -16,6 +16,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/octobud-hq/octobud/backend/internal/db"
 )
 
@@ -27,6 +29,8 @@ type Tag struct {
 	Color       *string `json:"color,omitempty"`
 	Description *string `json:"description,omitempty"`
 	UnreadCount *int64  `json:"unreadCount,omitempty"`
+	// AssignedAt is when the tag was assigned, on tags listed for a notification
+	AssignedAt *time.Time `json:"assignedAt,omitempty"`
 }
 
 // TagFromDB converts a db.Tag to a models.Tag
@@ -42,5 +46,8 @@ func TagFromDB(tag db.Tag) Tag {
 	if tag.Description.Valid {
 		resp.Description = &tag.Description.String
 	}
+	if tag.AssignedAt.Valid {
+		resp.AssignedAt = &tag.AssignedAt.Time
+	}
 	return resp
 }
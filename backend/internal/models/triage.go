@@ -0,0 +1,130 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import "time"
+
+// TriageBackupVersion is the format version of triage backups written by this release.
+// Version 2 added tags, views and rules; version 1 backups can still be imported.
+const TriageBackupVersion = 2
+
+// TriageBackup is an export of the user's triage state. It can be imported after GitHub
+// data has been deleted or the token changed to restore state as notifications re-sync.
+type TriageBackup struct {
+	Version       int           `json:"version"`
+	ExportedAt    time.Time     `json:"exportedAt"`
+	Notifications []TriageEntry `json:"notifications"`
+	// Tags, Views and Rules carry the user's setup so it can be moved to another machine.
+	// An import creates the ones missing locally and leaves existing ones untouched.
+	Tags  []TagBackup  `json:"tags,omitempty"`
+	Views []ViewBackup `json:"views,omitempty"`
+	Rules []RuleBackup `json:"rules,omitempty"`
+}
+
+// TriageEntry is the triage state of one notification, matched by GitHub ID on import.
+// Tags are listed by name.
+type TriageEntry struct {
+	GithubID     string     `json:"githubId"`
+	IsRead       bool       `json:"isRead"`
+	Archived     bool       `json:"archived"`
+	Starred      bool       `json:"starred"`
+	Muted        bool       `json:"muted"`
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+}
+
+// TagBackup is a tag in a backup, matched by name or slug on import
+type TagBackup struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ViewBackup is a custom view in a backup, matched by name or slug on import
+type ViewBackup struct {
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	Description   string `json:"description,omitempty"`
+	Icon          string `json:"icon,omitempty"`
+	Query         string `json:"query"`
+	WIPLimit      *int64 `json:"wipLimit,omitempty"`
+	WIPAutoSnooze bool   `json:"wipAutoSnooze,omitempty"`
+}
+
+// RuleBackup is a rule in a backup, matched by name on import. A rule that follows a view
+// names it by slug, and tag actions name tags by slug, so neither depends on local IDs.
+type RuleBackup struct {
+	Name           string      `json:"name"`
+	Description    string      `json:"description,omitempty"`
+	Query          string      `json:"query,omitempty"`
+	ViewSlug       string      `json:"viewSlug,omitempty"`
+	Enabled        bool        `json:"enabled"`
+	Actions        RuleActions `json:"actions"`
+	Priority       int         `json:"priority,omitempty"`
+	StopProcessing bool        `json:"stopProcessing,omitempty"`
+	Condition      string      `json:"condition,omitempty"`
+}
+
+// TriageImportCounts reports how many items of one kind an import created, and how many
+// it skipped because they already exist or can't be used here
+type TriageImportCounts struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+// TriageImportResult reports what an import did: how many entries were staged, how many of
+// those were merged into existing notifications, how many are still waiting to sync, and
+// which tags, views and rules were created
+type TriageImportResult struct {
+	Staged  int                `json:"staged"`
+	Applied int64              `json:"applied"`
+	Pending int64              `json:"pending"`
+	Tags    TriageImportCounts `json:"tags"`
+	Views   TriageImportCounts `json:"views"`
+	Rules   TriageImportCounts `json:"rules"`
+}
+
+// TriageExportSummary describes a triage backup once it has been written. Exports with the
+// same ExportedAt have the same Digest unless triage state changed in between.
+type TriageExportSummary struct {
+	ExportedAt time.Time
+	Entries    int
+	Size       int64
+	// Digest is the hex-encoded SHA-256 of the written backup
+	Digest string
+}
+
+// ViewShareVersion is the format version of shared views written by this release
+const ViewShareVersion = 1
+
+// ViewShare is a custom view exported to share with someone else. It may carry the rules
+// that follow the view and the tags those rules name, in the same shape as a backup.
+type ViewShare struct {
+	Version    int          `json:"version"`
+	ExportedAt time.Time    `json:"exportedAt"`
+	View       ViewBackup   `json:"view"`
+	Rules      []RuleBackup `json:"rules,omitempty"`
+	Tags       []TagBackup  `json:"tags,omitempty"`
+}
+
+// ViewShareImportResult reports what importing a shared view created. Renamed is set when
+// the view's name or slug was already taken and it was imported under a new name.
+type ViewShareImportResult struct {
+	View    View               `json:"view"`
+	Renamed bool               `json:"renamed"`
+	Rules   TriageImportCounts `json:"rules"`
+	Tags    TriageImportCounts `json:"tags"`
+}
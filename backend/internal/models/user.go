@@ -18,6 +18,8 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
+	"time"
 )
 
 // User represents a user in the system
@@ -32,14 +34,111 @@ type User struct {
 	MutedUntil        sql.NullTime // When notifications are muted until (null if not muted)
 }
 
+// LinkedAccount is an additional GitHub account whose notifications are synced alongside
+// the primary account's
+type LinkedAccount struct {
+	GithubUserID string     `json:"githubUserId"`
+	Login        string     `json:"login"`
+	LastSyncedAt *time.Time `json:"lastSyncedAt,omitempty"`
+}
+
 // SyncSettings represents the user's sync configuration
 type SyncSettings struct {
 	InitialSyncDays       *int `json:"initialSyncDays,omitempty"`     // Number of days to sync (null = unlimited)
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"` // Maximum notifications (null = no limit)
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`         // Only sync unread notifications initially
 	SetupCompleted        bool `json:"setupCompleted"`                // Whether setup was completed
+	WebhooksEnabled       bool `json:"webhooksEnabled"`               // Act on GitHub webhook deliveries
+	// Minutes after an archive during which new activity doesn't bring a thread back to
+	// the inbox, unless the user is mentioned directly (0 = off)
+	ResurfaceSuppressionMinutes int `json:"resurfaceSuppressionMinutes"`
+	// Days without a reply after which a thread whose latest comment is the user's own
+	// counts as awaiting a reply (0 = off)
+	AwaitingReplyDays int `json:"awaitingReplyDays"`
+	// Bring threads back to the inbox when they start awaiting a reply
+	ResurfaceAwaitingReplies bool `json:"resurfaceAwaitingReplies"`
+	// Don't keep raw GitHub payloads; the metadata extracted from them is still stored
+	SkipPayloadStorage bool `json:"skipPayloadStorage"`
+	// Don't keep the body of the latest comment on each thread
+	SkipCommentCaching bool `json:"skipCommentCaching"`
+	// Keep only what the notification itself carries: no subject or comment is fetched
+	TitlesOnly bool `json:"titlesOnly"`
+	// Mark threads read on GitHub when they're marked read here, and done when archived
+	MarkOnGitHub bool `json:"markOnGitHub"`
+	// Orgs whose notifications are synced; empty syncs every org not in OrgBlocklist
+	OrgAllowlist []string `json:"orgAllowlist,omitempty"`
+	// Orgs whose notifications are never synced
+	OrgBlocklist []string `json:"orgBlocklist,omitempty"`
+}
+
+// StorageCapabilities reports which features the storage settings leave available
+type StorageCapabilities struct {
+	SubjectDetails    bool `json:"subjectDetails"`    // Author, state, branches and pull request metadata
+	DescriptionSearch bool `json:"descriptionSearch"` // body: matches issue and pull request descriptions
+	CommentSearch     bool `json:"commentSearch"`     // body: matches the latest comment
+	AwaitingReply     bool `json:"awaitingReply"`     // awaiting:reply tracking
+	TimelineWarming   bool `json:"timelineWarming"`   // Timelines are fetched ahead of time at startup
+}
+
+// FetchesSubjects reports whether sync fetches each notification's subject and latest comment
+func (s *SyncSettings) FetchesSubjects() bool {
+	return s == nil || !s.TitlesOnly
+}
+
+// StoresPayloads reports whether raw GitHub payloads are kept
+func (s *SyncSettings) StoresPayloads() bool {
+	return s.FetchesSubjects() && (s == nil || !s.SkipPayloadStorage)
+}
+
+// CachesComments reports whether the body of the latest comment is kept
+func (s *SyncSettings) CachesComments() bool {
+	return s.FetchesSubjects() && (s == nil || !s.SkipCommentCaching)
+}
+
+// Capabilities reports which features are available under the storage settings
+func (s *SyncSettings) Capabilities() StorageCapabilities {
+	return StorageCapabilities{
+		SubjectDetails:    s.FetchesSubjects(),
+		DescriptionSearch: s.StoresPayloads(),
+		CommentSearch:     s.CachesComments(),
+		AwaitingReply:     s.FetchesSubjects(),
+		TimelineWarming:   s.CachesComments(),
+	}
+}
+
+// SyncsOrg reports whether notifications from repositories owned by the org are synced.
+// Org names are compared case-insensitively, and the blocklist wins over the allowlist.
+func (s *SyncSettings) SyncsOrg(org string) bool {
+	if s == nil {
+		return true
+	}
+	if containsOrg(s.OrgBlocklist, org) {
+		return false
+	}
+	return len(s.OrgAllowlist) == 0 || containsOrg(s.OrgAllowlist, org)
 }
 
+// SyncsRepository reports whether notifications from the repository are synced
+func (s *SyncSettings) SyncsRepository(fullName string) bool {
+	org, _, _ := strings.Cut(fullName, "/")
+	return s.SyncsOrg(org)
+}
+
+func containsOrg(orgs []string, org string) bool {
+	for _, candidate := range orgs {
+		if strings.EqualFold(candidate, org) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxResurfaceSuppressionMinutes caps the resurface suppression window at one week
+const MaxResurfaceSuppressionMinutes = 7 * 24 * 60
+
+// MaxAwaitingReplyDays caps how long a thread can wait before it counts as awaiting a reply
+const MaxAwaitingReplyDays = 90
+
 // ToJSON converts SyncSettings to JSON bytes
 func (s *SyncSettings) ToJSON() (json.RawMessage, error) {
 	if s == nil {
@@ -136,3 +235,225 @@ func UpdateSettingsFromJSON(data json.RawMessage) (*UpdateSettings, error) {
 	}
 	return &settings, nil
 }
+
+// EscalationSettings represents the user's review request escalation configuration
+type EscalationSettings struct {
+	Enabled      bool   `json:"enabled"`             // false = never escalate (default)
+	AfterDays    int    `json:"afterDays"`           // Unread business days before escalating
+	TagEscalated bool   `json:"tagEscalated"`        // Tag escalated notifications "escalated"
+	LastRunAt    string `json:"lastRunAt,omitempty"` // ISO8601 timestamp of last evaluation
+}
+
+// DefaultEscalationSettings returns the default escalation settings (escalation disabled)
+func DefaultEscalationSettings() *EscalationSettings {
+	return &EscalationSettings{
+		Enabled:      false,
+		AfterDays:    3,
+		TagEscalated: true,
+	}
+}
+
+// ToJSON converts EscalationSettings to JSON bytes
+func (s *EscalationSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// EscalationSettingsFromJSON creates EscalationSettings from JSON bytes
+func EscalationSettingsFromJSON(data json.RawMessage) (*EscalationSettings, error) {
+	if len(data) == 0 {
+		return DefaultEscalationSettings(), nil
+	}
+	var settings EscalationSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// WorkingHours represents the user's working hours, used for business-day calculations
+type WorkingHours struct {
+	Enabled  bool     `json:"enabled"`            // false = every day counts as a business day
+	Days     []int    `json:"days"`               // Working weekdays, 0 = Sunday ... 6 = Saturday
+	Start    string   `json:"start"`              // Start of the working day, "HH:MM"
+	End      string   `json:"end"`                // End of the working day, "HH:MM"
+	Timezone string   `json:"timezone,omitempty"` // IANA time zone name (empty = system time zone)
+	Holidays []string `json:"holidays"`           // Non-working dates, "YYYY-MM-DD"
+}
+
+// DefaultWorkingHours returns the default working hours (Monday to Friday, 9 to 5, disabled)
+func DefaultWorkingHours() *WorkingHours {
+	return &WorkingHours{
+		Enabled:  false,
+		Days:     []int{1, 2, 3, 4, 5},
+		Start:    "09:00",
+		End:      "17:00",
+		Holidays: []string{},
+	}
+}
+
+// ToJSON converts WorkingHours to JSON bytes
+func (s *WorkingHours) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// WorkingHoursFromJSON creates WorkingHours from JSON bytes
+func WorkingHoursFromJSON(data json.RawMessage) (*WorkingHours, error) {
+	if len(data) == 0 {
+		return DefaultWorkingHours(), nil
+	}
+	var settings WorkingHours
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// TeamSettings lists the team members whose review load is reported
+type TeamSettings struct {
+	Members []string `json:"members"` // GitHub logins of team members
+}
+
+// DefaultTeamSettings returns the default team settings (no members)
+func DefaultTeamSettings() *TeamSettings {
+	return &TeamSettings{Members: []string{}}
+}
+
+// ToJSON converts TeamSettings to JSON bytes
+func (s *TeamSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// TeamSettingsFromJSON creates TeamSettings from JSON bytes
+func TeamSettingsFromJSON(data json.RawMessage) (*TeamSettings, error) {
+	if len(data) == 0 {
+		return DefaultTeamSettings(), nil
+	}
+	var settings TeamSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	if settings.Members == nil {
+		settings.Members = []string{}
+	}
+	return &settings, nil
+}
+
+// SnoozeSettings holds the default snooze used when a notification is snoozed without a
+// time and its repository has no default of its own, and what happens when a snooze runs out
+type SnoozeSettings struct {
+	DefaultPreset      string `json:"defaultPreset"`      // Snooze preset or duration, e.g. "tomorrow" or "3d"
+	MarkUnreadOnReturn bool   `json:"markUnreadOnReturn"` // Mark notifications unread when their snooze runs out
+}
+
+// DefaultSnoozeSettings returns the default snooze settings (until tomorrow morning)
+func DefaultSnoozeSettings() *SnoozeSettings {
+	return &SnoozeSettings{DefaultPreset: "tomorrow"}
+}
+
+// ToJSON converts SnoozeSettings to JSON bytes
+func (s *SnoozeSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// SnoozeSettingsFromJSON creates SnoozeSettings from JSON bytes
+func SnoozeSettingsFromJSON(data json.RawMessage) (*SnoozeSettings, error) {
+	if len(data) == 0 {
+		return DefaultSnoozeSettings(), nil
+	}
+	var settings SnoozeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// BackupSettings holds how often the database is backed up and how many backups are kept
+type BackupSettings struct {
+	Enabled       bool `json:"enabled"`       // Back up on a schedule; manual backups work either way
+	IntervalHours int  `json:"intervalHours"` // Hours between scheduled backups
+	Keep          int  `json:"keep"`          // Backups kept; older ones are deleted after each backup
+}
+
+// DefaultBackupSettings returns the default backup settings (daily, keeping a week)
+func DefaultBackupSettings() *BackupSettings {
+	return &BackupSettings{Enabled: true, IntervalHours: 24, Keep: 7}
+}
+
+// ToJSON converts BackupSettings to JSON bytes
+func (s *BackupSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// BackupSettingsFromJSON creates BackupSettings from JSON bytes
+func BackupSettingsFromJSON(data json.RawMessage) (*BackupSettings, error) {
+	if len(data) == 0 {
+		return DefaultBackupSettings(), nil
+	}
+	var settings BackupSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Digest frequencies
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestSettings holds when the notification digest is put together, which notifications
+// it lists and where it's sent
+type DigestSettings struct {
+	Enabled   bool   `json:"enabled"`   // Put a digest together on the schedule; on-demand digests work either way
+	Frequency string `json:"frequency"` // DigestDaily or DigestWeekly
+	Hour      int    `json:"hour"`      // Hour of the day, 0-23, in the working hours time zone
+	Weekday   int    `json:"weekday"`   // Day of the week for weekly digests, 0 for Sunday
+	Query     string `json:"query"`     // Notifications the digest lists
+	EmailTo   string `json:"emailTo"`   // Address to email the digest to; empty only keeps the file
+}
+
+// DefaultDigestSettings returns the default digest settings (off, daily at 8am, unread inbox)
+func DefaultDigestSettings() *DigestSettings {
+	return &DigestSettings{
+		Frequency: DigestDaily,
+		Hour:      8,
+		Weekday:   int(time.Monday),
+		Query:     "in:inbox is:unread",
+	}
+}
+
+// ToJSON converts DigestSettings to JSON bytes
+func (s *DigestSettings) ToJSON() (json.RawMessage, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// DigestSettingsFromJSON creates DigestSettings from JSON bytes
+func DigestSettingsFromJSON(data json.RawMessage) (*DigestSettings, error) {
+	if len(data) == 0 {
+		return DefaultDigestSettings(), nil
+	}
+	var settings DigestSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
@@ -31,6 +31,35 @@ type View struct {
 	Query        string  `json:"query"`
 	UnreadCount  int64   `json:"unreadCount"`
 	DisplayOrder int     `json:"displayOrder"`
+	// GroupID is the sidebar group the view is in, if any
+	GroupID *string `json:"groupId,omitempty"`
+	// WIP limit, set for views that should hold at most WIPLimit notifications
+	WIPLimit      *int64 `json:"wipLimit,omitempty"`
+	WIPAutoSnooze bool   `json:"wipAutoSnooze,omitempty"`
+	// Count and OverLimit are only filled in when listing views with a WIP limit
+	Count     *int64 `json:"count,omitempty"`
+	OverLimit bool   `json:"overLimit,omitempty"`
+}
+
+// ViewCount is a view's counts without the rest of the view
+type ViewCount struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	UnreadCount int64  `json:"unreadCount"`
+	// Count and OverLimit are only filled in for views with a WIP limit
+	Count     *int64 `json:"count,omitempty"`
+	OverLimit bool   `json:"overLimit,omitempty"`
+}
+
+// ViewCountFromView takes the counts from a view listed with its counts
+func ViewCountFromView(view View) ViewCount {
+	return ViewCount{
+		ID:          view.ID,
+		Slug:        view.Slug,
+		UnreadCount: view.UnreadCount,
+		Count:       view.Count,
+		OverLimit:   view.OverLimit,
+	}
 }
 
 // SystemView represents a system view (inbox, everything, etc.)
@@ -51,13 +80,34 @@ func ViewFromDB(view db.View) View {
 	}
 
 	return View{
-		ID:           view.ID, // Now a UUID string
-		Name:         view.Name,
-		Slug:         view.Slug,
-		Description:  NullStringPtr(view.Description),
-		Icon:         NullStringPtr(view.Icon),
-		IsDefault:    view.IsDefault,
-		Query:        query,
-		DisplayOrder: int(view.DisplayOrder),
+		ID:            view.ID, // Now a UUID string
+		Name:          view.Name,
+		Slug:          view.Slug,
+		Description:   NullStringPtr(view.Description),
+		Icon:          NullStringPtr(view.Icon),
+		IsDefault:     view.IsDefault,
+		Query:         query,
+		DisplayOrder:  int(view.DisplayOrder),
+		WIPLimit:      NullInt64Ptr(view.WIPLimit),
+		WIPAutoSnooze: view.WIPAutoSnooze,
+		GroupID:       NullStringPtr(view.GroupID),
+	}
+}
+
+// ViewGroup is a named group of custom views in the sidebar
+type ViewGroup struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DisplayOrder int    `json:"displayOrder"`
+	Collapsed    bool   `json:"collapsed"`
+}
+
+// ViewGroupFromDB converts a db.ViewGroup to a ViewGroup
+func ViewGroupFromDB(group db.ViewGroup) ViewGroup {
+	return ViewGroup{
+		ID:           group.ID,
+		Name:         group.Name,
+		DisplayOrder: int(group.DisplayOrder),
+		Collapsed:    group.Collapsed,
 	}
 }
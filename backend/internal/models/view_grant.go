@@ -0,0 +1,59 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// Permissions a view can be shared with
+const (
+	ViewPermissionRead  = "read"  // See the view and its notifications
+	ViewPermissionWrite = "write" // Also change the view's name, description, icon and query
+)
+
+// ViewGrant gives another user of a multi-user server access to one of your views
+type ViewGrant struct {
+	ViewID     string    `json:"viewId"`
+	Grantee    string    `json:"username"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ViewGrantFromDB converts a db.ViewShare to a ViewGrant
+func ViewGrantFromDB(share db.ViewShare) ViewGrant {
+	return ViewGrant{
+		ViewID:     share.ViewID,
+		Grantee:    share.Grantee,
+		Permission: share.Permission,
+		CreatedAt:  share.CreatedAt,
+	}
+}
+
+// SharedView is a view another user shared with you. Its notifications are the owner's.
+type SharedView struct {
+	Owner      string    `json:"owner"`
+	Permission string    `json:"permission"`
+	SharedAt   time.Time `json:"sharedAt"`
+	View       View      `json:"view"`
+}
+
+// CanWrite reports whether the view may be changed by the user it's shared with
+func (v SharedView) CanWrite() bool {
+	return v.Permission == ViewPermissionWrite
+}
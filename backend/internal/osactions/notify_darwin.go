@@ -0,0 +1,67 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build darwin
+
+package osactions
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// macSounds maps alert sounds to macOS system sounds. AppleScript can't ask for the
+// user's default notification sound, so an empty sound plays Glass.
+var macSounds = map[string]string{
+	"":      "Glass",
+	"chime": "Glass",
+	"ping":  "Ping",
+	"pop":   "Pop",
+	"bell":  "Tink",
+}
+
+// notifyScript shows a notification from its arguments, which avoids escaping them
+// into the script: title, subtitle, body and then an optional sound name.
+const notifyScript = `on run argv
+	if (count of argv) > 3 then
+		display notification (item 3 of argv) with title (item 1 of argv) ¬
+			subtitle (item 2 of argv) sound name (item 4 of argv)
+	else
+		display notification (item 3 of argv) with title (item 1 of argv) subtitle (item 2 of argv)
+	end if
+end run`
+
+// ShowNotification displays a notification in the macOS Notification Center.
+func (s *service) ShowNotification(ctx context.Context, notification Notification) error {
+	args := []string{
+		"-e", notifyScript,
+		notification.Title, notification.Subtitle, notification.Body,
+	}
+	if notification.Sound != soundSilent {
+		sound, ok := macSounds[notification.Sound]
+		if !ok {
+			sound = macSounds[""]
+		}
+		args = append(args, sound)
+	}
+
+	//nolint:gosec // G204: osascript is a standard macOS utility and the script is a constant
+	cmd := exec.CommandContext(ctx, "osascript", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notification AppleScript error: %w: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build linux
+
+package osactions
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// linuxSounds maps alert sounds to freedesktop sound theme names.
+var linuxSounds = map[string]string{
+	"":      "message-new-instant",
+	"chime": "complete",
+	"ping":  "message-new-instant",
+	"pop":   "dialog-information",
+	"bell":  "bell",
+}
+
+// ShowNotification displays a notification with notify-send, which talks to the
+// desktop's notification daemon. The subtitle becomes the first line of the body.
+func (s *service) ShowNotification(ctx context.Context, notification Notification) error {
+	body := notification.Body
+	if notification.Subtitle != "" {
+		body = notification.Subtitle + "\n" + body
+	}
+
+	sound := "--hint=boolean:suppress-sound:true"
+	if notification.Sound != soundSilent {
+		name, ok := linuxSounds[notification.Sound]
+		if !ok {
+			name = linuxSounds[""]
+		}
+		sound = "--hint=string:sound-name:" + name
+	}
+
+	//nolint:gosec // G204: notify-send is invoked directly with arguments, not through a shell
+	cmd := exec.CommandContext(ctx, "notify-send", "--app-name=Octobud", sound,
+		"--", notification.Title, body)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify-send error: %w: %s", err, output)
+	}
+	return nil
+}
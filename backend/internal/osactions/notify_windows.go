@@ -0,0 +1,80 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build windows
+
+package osactions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// windowsSounds maps alert sounds to Windows toast audio.
+var windowsSounds = map[string]string{
+	"":      "ms-winsoundevent:Notification.Default",
+	"chime": "ms-winsoundevent:Notification.Reminder",
+	"ping":  "ms-winsoundevent:Notification.IM",
+	"pop":   "ms-winsoundevent:Notification.SMS",
+	"bell":  "ms-winsoundevent:Notification.Mail",
+}
+
+// toastScript shows a toast notification. The text is read from environment variables so
+// nothing needs escaping into the script.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastTemplateType]::ToastText04
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent($template)
+$text = $xml.GetElementsByTagName("text")
+$text.Item(0).AppendChild($xml.CreateTextNode($env:OCTOBUD_TOAST_TITLE)) | Out-Null
+$text.Item(1).AppendChild($xml.CreateTextNode($env:OCTOBUD_TOAST_SUBTITLE)) | Out-Null
+$text.Item(2).AppendChild($xml.CreateTextNode($env:OCTOBUD_TOAST_BODY)) | Out-Null
+$audio = $xml.CreateElement("audio")
+if ($env:OCTOBUD_TOAST_SOUND) {
+	$audio.SetAttribute("src", $env:OCTOBUD_TOAST_SOUND)
+} else {
+	$audio.SetAttribute("silent", "true")
+}
+$xml.DocumentElement.AppendChild($audio) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($xml)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("Octobud").Show($toast)
+`
+
+// ShowNotification displays a toast notification through PowerShell.
+func (s *service) ShowNotification(ctx context.Context, notification Notification) error {
+	sound := ""
+	if notification.Sound != soundSilent {
+		var ok bool
+		if sound, ok = windowsSounds[notification.Sound]; !ok {
+			sound = windowsSounds[""]
+		}
+	}
+
+	//nolint:gosec // G204: powershell is a standard Windows utility and the script is a constant
+	cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+	cmd.Env = append(os.Environ(),
+		"OCTOBUD_TOAST_TITLE="+notification.Title,
+		"OCTOBUD_TOAST_SUBTITLE="+notification.Subtitle,
+		"OCTOBUD_TOAST_BODY="+notification.Body,
+		"OCTOBUD_TOAST_SOUND="+sound,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("toast notification error: %w: %s", err, output)
+	}
+	return nil
+}
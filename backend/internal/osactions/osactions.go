@@ -13,7 +13,8 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package osactions provides platform-specific OS actions (app restart, browser tab activation, etc.).
+// Package osactions provides platform-specific OS actions (app restart, browser tab activation,
+// native notifications, etc.).
 // On macOS, this uses AppleScript. On other platforms, it provides no-op implementations.
 package osactions
 
@@ -22,6 +23,8 @@ import "context"
 // Service provides platform-specific OS actions.
 // On macOS, this uses AppleScript. On other platforms, it provides no-op implementations.
 type Service interface {
+	Notifier
+
 	// RestartApp quits the current application and launches the installed app.
 	// The app's startup code will handle checking for existing browser tabs.
 	// Returns an error if the restart fails.
@@ -45,3 +48,23 @@ type Service interface {
 	// return an error if the version cannot be determined from the installed bundle.
 	GetInstalledAppVersion(ctx context.Context) (string, error)
 }
+
+// soundSilent shows a notification without any sound.
+const soundSilent = "silent"
+
+// Notification is a native OS notification.
+type Notification struct {
+	Title    string
+	Subtitle string
+	Body     string
+	// Sound is one of the named alert sounds, "silent", or empty for the platform's default.
+	Sound string
+}
+
+// Notifier shows native OS notifications.
+type Notifier interface {
+	// ShowNotification displays a notification through the platform's notification center.
+	// On macOS this uses AppleScript, on Linux notify-send and on Windows a PowerShell toast.
+	// Returns an error if the notification can't be shown or the platform isn't supported.
+	ShowNotification(ctx context.Context, notification Notification) error
+}
@@ -17,12 +17,15 @@
 package eval
 
 import (
+	"database/sql"
 	"encoding/json"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/github"
 	"github.com/octobud-hq/octobud/backend/internal/query/parse"
 )
 
@@ -39,7 +42,7 @@ func NewEvaluator(ast parse.Node) *Evaluator {
 
 // Matches returns true if the notification matches the query with explicit query context:
 // - Empty query "" → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
-// - Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
+// - Query with in: operator (any value, not negated) → No defaults (in: explicitly handles lifecycle)
 // - Query without in: operator (non-empty) → Apply muted-only default (exclude muted unless explicitly requested)
 func (e *Evaluator) Matches(notif *db.Notification, repo *db.Repository) bool {
 	// 1. Empty query → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
@@ -48,8 +51,9 @@ func (e *Evaluator) Matches(notif *db.Notification, repo *db.Repository) bool {
 	}
 
 	// 2. Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
-	// This includes in:inbox, in:archive, in:snoozed, in:filtered, in:anywhere, etc.
-	if parse.HasInOperator(e.ast) {
+	// This includes in:inbox, in:archive, in:snoozed, in:starred, in:filtered, in:anywhere, etc.
+	// A negated in: (e.g. -in:archive) only removes a scope, so the muted default still applies
+	if parse.HasScopeOverride(e.ast) {
 		return e.evaluateNode(notif, repo, e.ast)
 	}
 
@@ -161,14 +165,154 @@ func (e *Evaluator) evaluateFieldValue(
 		return false
 	case "title":
 		return strings.Contains(strings.ToLower(notif.SubjectTitle), strings.ToLower(value))
+	case "body":
+		// Only the subject description is at hand here; the SQL builder also searches the
+		// latest comment through the full-text index
+		return strings.Contains(strings.ToLower(subjectBody(notif)), strings.ToLower(value))
 	case "type":
 		return strings.EqualFold(notif.SubjectType, value)
+	case "kind":
+		kind := notif.ContentKind
+		if kind == "" {
+			kind = db.ContentKindForSubjectType(notif.SubjectType)
+		}
+		return strings.EqualFold(kind, value)
+	case "branch":
+		return matchesBranch(notif.HeadBranch, value) || matchesBranch(notif.BaseBranch, value)
+	case "account":
+		return notif.Account.Valid && strings.EqualFold(notif.Account.String, strings.TrimSpace(value))
+	case "awaiting":
+		return notif.AwaitingReply && strings.EqualFold(strings.TrimSpace(value), "reply")
+	case "starred":
+		return matchesBool(notif.Starred, value)
+	case "filtered":
+		return matchesBool(notif.Filtered, value)
+	case "draft":
+		return matchesNullBool(notif.SubjectDraft, value)
+	case "mergeable":
+		return matchesNullBool(notif.SubjectMergeable, value)
+	case "label":
+		return hasName(notif.SubjectLabels, value)
+	case "milestone":
+		return notif.SubjectMilestone.Valid &&
+			strings.EqualFold(notif.SubjectMilestone.String, strings.TrimSpace(value))
+	case "project":
+		return hasName(notif.SubjectProjects, value)
+	case "review":
+		return notif.ReviewState.Valid && strings.EqualFold(notif.ReviewState.String, strings.TrimSpace(value))
+	case "ci":
+		state, ok := parse.CIStateFor(strings.ToLower(strings.TrimSpace(value)))
+		return ok && notif.CIState.Valid && notif.CIState.String == state
+	case "waiting-on":
+		return notif.WaitingOn.Valid && strings.EqualFold(notif.WaitingOn.String, strings.TrimSpace(value))
+	case "priority":
+		comparison, ok := parse.ParsePriority(value)
+		if !ok || !notif.Priority.Valid {
+			return false
+		}
+		switch comparison.Operator {
+		case ">":
+			return notif.Priority.Int64 > comparison.Score
+		case "<":
+			return notif.Priority.Int64 < comparison.Score
+		default:
+			return notif.Priority.Int64 == comparison.Score
+		}
+	case "tagged":
+		age, ok := parse.ParseAge(value)
+		if !ok {
+			return false
+		}
+		// Like the SQL filter, any tag assigned on the right side of the cutoff matches
+		cutoff := time.Now().Add(-age.Duration)
+		for _, assignedAt := range notif.TagsAssignedAt {
+			if assignedAt.Before(cutoff) == age.Older {
+				return true
+			}
+		}
+		return false
+	case "reviewer":
+		reviewers, _ := github.ExtractRequestedReviews(subjectRaw(notif))
+		return containsFold(reviewers, value)
+	case "team-review-requested":
+		_, teams := github.ExtractRequestedReviews(subjectRaw(notif))
+		return containsFold(teams, value)
 	// Add other fields as needed (participant, label, etc.)
 	default:
 		return true // Unknown fields don't filter
 	}
 }
 
+// matchesBranch reports whether a branch matches a pattern where * matches any run of
+// characters, including slashes. Matching is case-insensitive like the SQL LIKE filter.
+func matchesBranch(branch sql.NullString, pattern string) bool {
+	if !branch.Valid {
+		return false
+	}
+	parts := strings.Split(strings.TrimSpace(pattern), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("(?i)^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(branch.String)
+}
+
+// matchesBool reports whether a flag matches a boolean value like the SQL builder's
+// true/false, yes/no and 1/0 forms
+func matchesBool(flag bool, value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "1":
+		return flag
+	case "false", "no", "0":
+		return !flag
+	default:
+		return false
+	}
+}
+
+// matchesNullBool reports whether a pull request flag, like its draft status, matches a
+// boolean value. Notifications without the flag, such as issues, match neither value.
+func matchesNullBool(flag sql.NullBool, value string) bool {
+	if !flag.Valid {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "1":
+		return flag.Bool
+	case "false", "no", "0":
+		return !flag.Bool
+	default:
+		return false
+	}
+}
+
+// containsFold reports whether names include a name, compared case-insensitively
+func containsFold(names []string, name string) bool {
+	name = strings.TrimSpace(name)
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasName reports whether a stored JSON array of names, such as labels or projects,
+// includes a name, compared case-insensitively like on GitHub.
+func hasName(names db.NullRawMessage, name string) bool {
+	if !names.Valid {
+		return false
+	}
+	var list []string
+	if err := json.Unmarshal(names.RawMessage, &list); err != nil {
+		return false
+	}
+	return containsFold(list, name)
+}
+
 func (e *Evaluator) evaluateIsCondition(notif *db.Notification, value string) bool {
 	switch value {
 	case "read":
@@ -193,6 +337,8 @@ func (e *Evaluator) evaluateIsCondition(notif *db.Notification, value string) bo
 		return !notif.SnoozedUntil.Valid || notif.SnoozedUntil.Time.Before(time.Now())
 	case "filtered":
 		return notif.Filtered
+	case "system":
+		return notif.Reason.Valid && notif.Reason.String == db.ReasonSystem
 	default:
 		return true
 	}
@@ -215,6 +361,9 @@ func (e *Evaluator) evaluateInCondition(notif *db.Notification, value string) bo
 			notif.SnoozedUntil.Time.After(time.Now()) &&
 			!notif.Archived &&
 			!notif.Muted
+	case "starred":
+		// in:starred - show starred wherever they are (exclude muted)
+		return notif.Starred && !notif.Muted
 	case "filtered":
 		// in:filtered - exclude snoozed, archived, muted
 		return notif.Filtered &&
@@ -237,6 +386,7 @@ func (e *Evaluator) evaluateInCondition(notif *db.Notification, value string) bo
 // - Author login
 // - subject_state (extracted column, preferred over subject_raw JSON parsing)
 // - subject_number (cast to text for search)
+// - subject description (from subject_raw)
 //
 
 func (e *Evaluator) evaluateFreeText( //nolint:gocyclo // The complexity is just due to the number of fields we check.
@@ -314,5 +464,35 @@ func (e *Evaluator) evaluateFreeText( //nolint:gocyclo // The complexity is just
 		}
 	}
 
+	// Check the subject description, which the SQL builder searches through the full-text index
+	if strings.Contains(strings.ToLower(subjectBody(notif)), lowerText) {
+		return true
+	}
+
 	return false
 }
+
+// subjectRaw returns the subject data stored with a notification, or nil without any. The
+// SQL builder reads requested reviews from the pull request table instead, which is filled
+// from the same data at sync.
+func subjectRaw(notif *db.Notification) json.RawMessage {
+	if !notif.SubjectRaw.Valid {
+		return nil
+	}
+	return notif.SubjectRaw.RawMessage
+}
+
+// subjectBody returns the description of the issue, pull request, or discussion behind a
+// notification, or "" when its subject data has none
+func subjectBody(notif *db.Notification) string {
+	if !notif.SubjectRaw.Valid {
+		return ""
+	}
+	var subject struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(notif.SubjectRaw.RawMessage, &subject); err != nil {
+		return ""
+	}
+	return subject.Body
+}
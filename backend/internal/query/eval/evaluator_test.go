@@ -72,6 +72,22 @@ func TestEvaluator_Matches_Term(t *testing.T) {
 			term:     &parse.Term{Field: "is", Values: []string{"unread"}},
 			expected: false,
 		},
+		{
+			name: "matches is:system for system notification",
+			notif: &db.Notification{
+				Reason: sql.NullString{String: db.ReasonSystem, Valid: true},
+			},
+			repo:     &db.Repository{FullName: db.SystemRepositoryFullName},
+			term:     &parse.Term{Field: "is", Values: []string{"system"}},
+			expected: true,
+		},
+		{
+			name:     "does not match is:system for GitHub notification",
+			notif:    &db.Notification{Reason: sql.NullString{String: "mention", Valid: true}},
+			repo:     &db.Repository{FullName: "owner/repo"},
+			term:     &parse.Term{Field: "is", Values: []string{"system"}},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -419,6 +435,24 @@ func TestEvaluator_evaluateInCondition(t *testing.T) {
 			"filtered",
 			false,
 		},
+		{
+			"starred - matches archived",
+			&db.Notification{Starred: true, Archived: true},
+			"starred",
+			true,
+		},
+		{
+			"starred - not starred",
+			&db.Notification{Starred: false},
+			"starred",
+			false,
+		},
+		{
+			"starred - muted",
+			&db.Notification{Starred: true, Muted: true},
+			"starred",
+			false,
+		},
 		{
 			"anywhere - always matches",
 			&db.Notification{Archived: true, Muted: true, Filtered: true},
@@ -529,6 +563,26 @@ func TestEvaluator_evaluateFieldValue(t *testing.T) {
 			term:     &parse.Term{Field: "title", Values: []string{"security"}},
 			expected: false,
 		},
+		// Body field tests
+		{
+			name: "body matches subject description",
+			notif: &db.Notification{
+				SubjectRaw: db.NullRawMessage{
+					RawMessage: json.RawMessage(`{"body": "Steps to reproduce the Flaky test"}`),
+					Valid:      true,
+				},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "body", Values: []string{"flaky test"}},
+			expected: true,
+		},
+		{
+			name:     "body does not match without subject data",
+			notif:    &db.Notification{SubjectTitle: "Flaky test"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "body", Values: []string{"flaky"}},
+			expected: false,
+		},
 		// Reason field tests
 		{
 			name: "reason matches",
@@ -577,6 +631,350 @@ func TestEvaluator_evaluateFieldValue(t *testing.T) {
 			term:     &parse.Term{Field: "type", Values: []string{"Issue"}},
 			expected: false,
 		},
+		// Kind field tests
+		{
+			name:     "kind matches stored content kind",
+			notif:    &db.Notification{SubjectType: "PullRequest", ContentKind: db.ContentKindCode},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "kind", Values: []string{"code"}},
+			expected: true,
+		},
+		{
+			name:     "kind does not match different content kind",
+			notif:    &db.Notification{SubjectType: "Issue", ContentKind: db.ContentKindDiscussion},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "kind", Values: []string{"code"}},
+			expected: false,
+		},
+		{
+			name:     "kind falls back to subject type when not stored",
+			notif:    &db.Notification{SubjectType: "Discussion"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "kind", Values: []string{"discussion"}},
+			expected: true,
+		},
+		// Awaiting field tests
+		{
+			name:     "awaiting reply matches classified thread",
+			notif:    &db.Notification{AwaitingReply: true},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "awaiting", Values: []string{"reply"}},
+			expected: true,
+		},
+		{
+			name: "awaiting reply does not match unclassified thread",
+			notif: &db.Notification{
+				AwaitingReplySince: sql.NullTime{Time: time.Now(), Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "awaiting", Values: []string{"reply"}},
+			expected: false,
+		},
+		// Branch field tests
+		{
+			name: "branch glob matches base branch",
+			notif: &db.Notification{
+				HeadBranch: sql.NullString{String: "fix/login", Valid: true},
+				BaseBranch: sql.NullString{String: "release/1.2", Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "branch", Values: []string{"release/*"}},
+			expected: true,
+		},
+		{
+			name: "branch without wildcard matches exactly",
+			notif: &db.Notification{
+				HeadBranch: sql.NullString{String: "fix/login", Valid: true},
+				BaseBranch: sql.NullString{String: "main", Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "branch", Values: []string{"fix"}},
+			expected: false,
+		},
+		{
+			name: "branch matches head branch",
+			notif: &db.Notification{
+				HeadBranch: sql.NullString{String: "Fix/Login", Valid: true},
+				BaseBranch: sql.NullString{String: "main", Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "branch", Values: []string{"fix/login"}},
+			expected: true,
+		},
+		{
+			name:     "branch does not match notifications without branches",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "branch", Values: []string{"*"}},
+			expected: false,
+		},
+		// Pull request metadata field tests
+		{
+			name:     "draft matches draft pull request",
+			notif:    &db.Notification{SubjectDraft: sql.NullBool{Bool: true, Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "draft", Values: []string{"true"}},
+			expected: true,
+		},
+		{
+			name:     "draft false does not match issues",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "draft", Values: []string{"false"}},
+			expected: false,
+		},
+		{
+			name:     "mergeable false matches conflicting pull request",
+			notif:    &db.Notification{SubjectMergeable: sql.NullBool{Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "mergeable", Values: []string{"false"}},
+			expected: true,
+		},
+		{
+			name:     "mergeable false does not match pull requests GitHub hasn't checked",
+			notif:    &db.Notification{SubjectType: "PullRequest"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "mergeable", Values: []string{"false"}},
+			expected: false,
+		},
+		{
+			name: "label matches case-insensitively",
+			notif: &db.Notification{
+				SubjectLabels: db.NullRawMessage{RawMessage: []byte(`["bug","Needs Review"]`), Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "label", Values: []string{"needs review"}},
+			expected: true,
+		},
+		{
+			name: "label does not match other labels",
+			notif: &db.Notification{
+				SubjectLabels: db.NullRawMessage{RawMessage: []byte(`["bug"]`), Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "label", Values: []string{"bu"}},
+			expected: false,
+		},
+		{
+			name:     "milestone matches title case-insensitively",
+			notif:    &db.Notification{SubjectMilestone: sql.NullString{String: "v2.0-RC", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "milestone", Values: []string{"v2.0-rc"}},
+			expected: true,
+		},
+		{
+			name:     "milestone does not match notifications without one",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "milestone", Values: []string{"v2.0"}},
+			expected: false,
+		},
+		{
+			name: "project matches any stored project",
+			notif: &db.Notification{
+				SubjectProjects: db.NullRawMessage{RawMessage: []byte(`["Bug Triage","Q3 Roadmap"]`), Valid: true},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "project", Values: []string{"q3 roadmap"}},
+			expected: true,
+		},
+		{
+			name:     "project does not match unknown projects",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "project", Values: []string{"Q3 Roadmap"}},
+			expected: false,
+		},
+		{
+			name:     "ci failing matches failed checks",
+			notif:    &db.Notification{CIState: sql.NullString{String: "failure", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "ci", Values: []string{"failing"}},
+			expected: true,
+		},
+		{
+			name:     "ci passing does not match unknown state",
+			notif:    &db.Notification{SubjectType: "PullRequest"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "ci", Values: []string{"passing"}},
+			expected: false,
+		},
+		{
+			name:     "waiting-on matches stored turn",
+			notif:    &db.Notification{WaitingOn: sql.NullString{String: "me", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "waiting-on", Values: []string{"ME"}},
+			expected: true,
+		},
+		{
+			name:     "waiting-on does not match subjects waiting on no one",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "waiting-on", Values: []string{"them"}},
+			expected: false,
+		},
+		{
+			name:     "priority above a score",
+			notif:    &db.Notification{Priority: sql.NullInt64{Int64: 35, Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "priority", Values: []string{">30"}},
+			expected: true,
+		},
+		{
+			name:     "priority below a score",
+			notif:    &db.Notification{Priority: sql.NullInt64{Int64: 35, Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "priority", Values: []string{"<30", "10"}},
+			expected: false,
+		},
+		{
+			name:     "priority does not match unscored notifications",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "priority", Values: []string{"<30"}},
+			expected: false,
+		},
+		{
+			name: "tagged within an age matches a recently assigned tag",
+			notif: &db.Notification{
+				TagIDs:         []string{"old", "new"},
+				TagsAssignedAt: []time.Time{time.Now().Add(-30 * 24 * time.Hour), time.Now().Add(-time.Hour)},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "tagged", Values: []string{"<7d"}},
+			expected: true,
+		},
+		{
+			name: "tagged within an age does not match tags assigned before it",
+			notif: &db.Notification{
+				TagIDs:         []string{"old"},
+				TagsAssignedAt: []time.Time{time.Now().Add(-30 * 24 * time.Hour)},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "tagged", Values: []string{"<7d"}},
+			expected: false,
+		},
+		{
+			name: "tagged older than an age matches tags assigned before it",
+			notif: &db.Notification{
+				TagIDs:         []string{"old"},
+				TagsAssignedAt: []time.Time{time.Now().Add(-30 * 24 * time.Hour)},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "tagged", Values: []string{">2w"}},
+			expected: true,
+		},
+		{
+			name:     "tagged does not match untagged notifications",
+			notif:    &db.Notification{SubjectType: "Issue"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "tagged", Values: []string{"<7d", ">7d"}},
+			expected: false,
+		},
+		{
+			name:     "review matches stored state",
+			notif:    &db.Notification{ReviewState: sql.NullString{String: "approved", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "review", Values: []string{"pending", "approved"}},
+			expected: true,
+		},
+		{
+			name:     "review does not match unknown state",
+			notif:    &db.Notification{SubjectType: "PullRequest"},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "review", Values: []string{"pending"}},
+			expected: false,
+		},
+		// Boolean field tests
+		{
+			name:     "starred true matches starred",
+			notif:    &db.Notification{Starred: true},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "starred", Values: []string{"true"}},
+			expected: true,
+		},
+		{
+			name:     "starred false does not match starred",
+			notif:    &db.Notification{Starred: true},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "starred", Values: []string{"no"}},
+			expected: false,
+		},
+		{
+			name:     "filtered true matches filtered",
+			notif:    &db.Notification{Filtered: true},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "filtered", Values: []string{"1"}},
+			expected: true,
+		},
+		{
+			name:     "filtered true does not match unfiltered",
+			notif:    &db.Notification{},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "filtered", Values: []string{"true"}},
+			expected: false,
+		},
+		// Requested review field tests
+		{
+			name: "reviewer matches requested reviewer case-insensitively",
+			notif: &db.Notification{
+				SubjectRaw: db.NullRawMessage{
+					RawMessage: []byte(`{"requested_reviewers": [{"login": "Octocat"}]}`),
+					Valid:      true,
+				},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "reviewer", Values: []string{"octocat"}},
+			expected: true,
+		},
+		{
+			name:     "reviewer does not match without subject data",
+			notif:    &db.Notification{},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "reviewer", Values: []string{"octocat"}},
+			expected: false,
+		},
+		{
+			name: "team-review-requested matches org/team",
+			notif: &db.Notification{
+				SubjectRaw: db.NullRawMessage{
+					RawMessage: []byte(`{"requested_teams": [{"slug": "core"}],
+						"base": {"repo": {"owner": {"login": "acme"}}}}`),
+					Valid: true,
+				},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "team-review-requested", Values: []string{"acme/core"}},
+			expected: true,
+		},
+		{
+			name: "team-review-requested does not match other orgs",
+			notif: &db.Notification{
+				SubjectRaw: db.NullRawMessage{
+					RawMessage: []byte(`{"requested_teams": [{"slug": "core"}],
+						"base": {"repo": {"owner": {"login": "acme"}}}}`),
+					Valid: true,
+				},
+			},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "team-review-requested", Values: []string{"other/core"}},
+			expected: false,
+		},
+		// Account field tests
+		{
+			name:     "account matches case-insensitively",
+			notif:    &db.Notification{Account: sql.NullString{String: "Octocat-Work", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "account", Values: []string{"octocat-work"}},
+			expected: true,
+		},
+		{
+			name:     "account does not match other account",
+			notif:    &db.Notification{Account: sql.NullString{String: "octocat", Valid: true}},
+			repo:     &db.Repository{},
+			term:     &parse.Term{Field: "account", Values: []string{"octocat-work"}},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -779,6 +1177,15 @@ func TestEvaluator_Matches_Unified(t *testing.T) {
 			ast:      &parse.Term{Field: "repo", Values: []string{"test"}},
 			expected: true,
 		},
+		{
+			name:  "negated in: keeps muted-only default",
+			notif: &db.Notification{Muted: true},
+			repo:  &db.Repository{},
+			ast: &parse.NotExpr{
+				Expr: &parse.Term{Field: "in", Values: []string{"archive"}},
+			},
+			expected: false,
+		},
 		{
 			name: "in:inbox provides its own filters - no additional defaults",
 			notif: &db.Notification{
@@ -803,6 +1210,20 @@ func TestEvaluator_Matches_Unified(t *testing.T) {
 			ast:      &parse.Term{Field: "in", Values: []string{"inbox"}},
 			expected: false,
 		},
+		{
+			name:     "in:starred provides its own filters - allows archived",
+			notif:    &db.Notification{Starred: true, Archived: true},
+			repo:     &db.Repository{},
+			ast:      &parse.Term{Field: "in", Values: []string{"starred"}},
+			expected: true,
+		},
+		{
+			name:     "starred:true keeps muted-only default",
+			notif:    &db.Notification{Starred: true, Muted: true},
+			repo:     &db.Repository{},
+			ast:      &parse.Term{Field: "starred", Values: []string{"true"}},
+			expected: false,
+		},
 		{
 			name: "in:inbox rejects filtered - no additional defaults",
 			notif: &db.Notification{
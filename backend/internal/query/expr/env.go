@@ -0,0 +1,131 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package expr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// fields are the notification fields a condition can use, by name
+var fields = map[string]struct{ typ valueType }{
+	"title":              {typeString},
+	"type":               {typeString}, // Subject type, e.g. "PullRequest"
+	"reason":             {typeString},
+	"repo":               {typeString}, // Full name, e.g. "cli/cli"
+	"org":                {typeString}, // Repository owner
+	"author":             {typeString},
+	"state":              {typeString}, // e.g. "open" or "closed"
+	"state_reason":       {typeString},
+	"merged":             {typeBool},
+	"draft":              {typeBool},
+	"number":             {typeNumber},
+	"labels":             {typeList},
+	"milestone":          {typeString},
+	"projects":           {typeList},
+	"ci":                 {typeString}, // failure, pending or success
+	"mergeable":          {typeBool},
+	"waiting_on":         {typeString}, // me or them
+	"review_state":       {typeString},
+	"priority":           {typeNumber},
+	"account":            {typeString},
+	"head_branch":        {typeString},
+	"base_branch":        {typeString},
+	"read":               {typeBool},
+	"starred":            {typeBool},
+	"archived":           {typeBool},
+	"muted":              {typeBool},
+	"snoozed":            {typeBool},
+	"hours_since_update": {typeNumber}, // Since GitHub last updated the thread
+}
+
+// NotificationEnv returns the fields of a notification for evaluating conditions. repo
+// may be nil if the repository isn't known.
+func NotificationEnv(n *db.Notification, repo *db.Repository, now time.Time) Env {
+	env := Env{
+		"title":        n.SubjectTitle,
+		"type":         n.SubjectType,
+		"reason":       nullString(n.Reason),
+		"author":       nullString(n.AuthorLogin),
+		"state":        nullString(n.SubjectState),
+		"state_reason": nullString(n.SubjectStateReason),
+		"merged":       nullBool(n.SubjectMerged),
+		"draft":        nullBool(n.SubjectDraft),
+		"labels":       stringList(n.SubjectLabels),
+		"milestone":    nullString(n.SubjectMilestone),
+		"projects":     stringList(n.SubjectProjects),
+		"ci":           nullString(n.CIState),
+		"mergeable":    nullBool(n.SubjectMergeable),
+		"waiting_on":   nullString(n.WaitingOn),
+		"review_state": nullString(n.ReviewState),
+		"account":      nullString(n.Account),
+		"head_branch":  nullString(n.HeadBranch),
+		"base_branch":  nullString(n.BaseBranch),
+		"read":         n.IsRead,
+		"starred":      n.Starred,
+		"archived":     n.Archived,
+		"muted":        n.Muted,
+		"snoozed":      n.SnoozedUntil.Valid && n.SnoozedUntil.Time.After(now),
+	}
+	if n.SubjectNumber.Valid {
+		env["number"] = float64(n.SubjectNumber.Int32)
+	}
+	if n.Priority.Valid {
+		env["priority"] = float64(n.Priority.Int64)
+	}
+	if n.GithubUpdatedAt.Valid {
+		env["hours_since_update"] = now.Sub(n.GithubUpdatedAt.Time).Hours()
+	}
+	if repo != nil {
+		env["repo"] = repo.FullName
+		if owner, _, found := strings.Cut(repo.FullName, "/"); found {
+			env["org"] = owner
+		}
+	}
+	return env
+}
+
+func nullString(s sql.NullString) any {
+	if !s.Valid {
+		return nil
+	}
+	return s.String
+}
+
+func nullBool(b sql.NullBool) any {
+	if !b.Valid {
+		return nil
+	}
+	return b.Bool
+}
+
+// stringList decodes a JSON array of strings, such as label names, as a list
+func stringList(raw db.NullRawMessage) []any {
+	var values []string
+	if raw.Valid && len(raw.RawMessage) > 0 {
+		// An unreadable list is treated as empty
+		_ = json.Unmarshal(raw.RawMessage, &values)
+	}
+	list := make([]any, len(values))
+	for i, value := range values {
+		list[i] = value
+	}
+	return list
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Limits on evaluating a condition. Conditions have no loops, so these are only reached
+// by long lists or slow pattern matches on long values.
+const (
+	MaxSteps = 10000
+	Timeout  = 10 * time.Millisecond
+)
+
+// How often, in steps, the deadline is checked
+const deadlineCheckInterval = 64
+
+// Env holds the field values a condition is evaluated against. Values are strings,
+// float64s, bools, []any lists, or nil when missing.
+type Env map[string]any
+
+// Eval evaluates the condition. Missing values are null: comparing null with anything
+// other than == and != is false, arithmetic with null is null, and null counts as false
+// where true or false is expected.
+func (p *Program) Eval(env Env) (bool, error) {
+	e := &evaluator{env: env, deadline: time.Now().Add(Timeout)}
+	value, err := e.eval(p.root)
+	if err != nil {
+		return false, err
+	}
+	return truthy(value), nil
+}
+
+type evaluator struct {
+	env      Env
+	steps    int
+	deadline time.Time
+}
+
+// step counts one unit of work against MaxSteps and Timeout
+func (e *evaluator) step() error {
+	e.steps++
+	if e.steps > MaxSteps {
+		return fmt.Errorf("%w: more than %d steps", ErrLimitExceeded, MaxSteps)
+	}
+	if e.steps%deadlineCheckInterval == 0 && time.Now().After(e.deadline) {
+		return fmt.Errorf("%w: took longer than %s", ErrLimitExceeded, Timeout)
+	}
+	return nil
+}
+
+func (e *evaluator) eval(n *node) (any, error) {
+	if err := e.step(); err != nil {
+		return nil, err
+	}
+	switch n.kind {
+	case nodeLiteral:
+		return n.value, nil
+	case nodeField:
+		return e.env[n.name], nil
+	case nodeList:
+		items := make([]any, len(n.args))
+		for i, arg := range n.args {
+			item, err := e.eval(arg)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case nodeUnary:
+		return e.evalUnary(n)
+	case nodeCall:
+		return e.evalCall(n)
+	default:
+		return e.evalBinary(n)
+	}
+}
+
+func (e *evaluator) evalUnary(n *node) (any, error) {
+	operand, err := e.eval(n.args[0])
+	if err != nil {
+		return nil, err
+	}
+	if n.name == "!" {
+		return !truthy(operand), nil
+	}
+	if operand == nil {
+		return nil, nil
+	}
+	number, ok := operand.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: - needs a number", ErrEval)
+	}
+	return -number, nil
+}
+
+func (e *evaluator) evalBinary(n *node) (any, error) {
+	left, err := e.eval(n.args[0])
+	if err != nil {
+		return nil, err
+	}
+	// and and or don't evaluate their right side when the left decides the result
+	switch n.name {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := e.eval(n.args[1])
+		return truthy(right), err
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := e.eval(n.args[1])
+		return truthy(right), err
+	}
+
+	right, err := e.eval(n.args[1])
+	if err != nil {
+		return nil, err
+	}
+	switch n.name {
+	case "+", "-", "*", "/", "%":
+		return arithmetic(n.name, left, right)
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		return compare(n.name, left, right), nil
+	case "in":
+		return e.contains(right, left)
+	case "contains":
+		return e.contains(left, right)
+	case "startsWith", "endsWith":
+		s, sOK := left.(string)
+		prefix, pOK := right.(string)
+		if !sOK || !pOK {
+			return false, nil
+		}
+		if n.name == "startsWith" {
+			return strings.HasPrefix(s, prefix), nil
+		}
+		return strings.HasSuffix(s, prefix), nil
+	case "matches":
+		s, ok := left.(string)
+		return ok && n.re.MatchString(s), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %s", ErrEval, n.name)
+	}
+}
+
+// contains reports whether a list holds a value or a string holds a substring
+func (e *evaluator) contains(container, value any) (bool, error) {
+	switch c := container.(type) {
+	case string:
+		s, ok := value.(string)
+		return ok && strings.Contains(c, s), nil
+	case []any:
+		for _, item := range c {
+			if err := e.step(); err != nil {
+				return false, err
+			}
+			if equal(item, value) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (e *evaluator) evalCall(n *node) (any, error) {
+	arg, err := e.eval(n.args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch n.name {
+	case "len":
+		switch v := arg.(type) {
+		case string:
+			return float64(len([]rune(v))), nil
+		case []any:
+			return float64(len(v)), nil
+		}
+		return float64(0), nil
+	case "lower", "upper":
+		s, ok := arg.(string)
+		if !ok {
+			return nil, nil
+		}
+		if n.name == "lower" {
+			return strings.ToLower(s), nil
+		}
+		return strings.ToUpper(s), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown function %s", ErrEval, n.name)
+	}
+}
+
+func arithmetic(op string, left, right any) (any, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	a, aOK := left.(float64)
+	b, bOK := right.(float64)
+	if !aOK || !bOK {
+		return nil, fmt.Errorf("%w: %s needs numbers", ErrEval, op)
+	}
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	}
+	if b == 0 {
+		return nil, fmt.Errorf("%w: division by zero", ErrEval)
+	}
+	if op == "/" {
+		return a / b, nil
+	}
+	return math.Mod(a, b), nil
+}
+
+func equal(left, right any) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	a, aIsList := left.([]any)
+	b, bIsList := right.([]any)
+	if aIsList || bIsList {
+		if !aIsList || !bIsList || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return left == right
+}
+
+func compare(op string, left, right any) bool {
+	var cmp int
+	switch a := left.(type) {
+	case float64:
+		b, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	case string:
+		b, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(a, b)
+	default:
+		return false
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// truthy treats anything but true, including null, as false
+func truthy(value any) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
@@ -0,0 +1,503 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package expr compiles and evaluates rule conditions: small expressions over a
+// notification's fields for checks the query language can't express, such as comparing
+// numbers or matching a regular expression. Conditions have no loops or assignments, and
+// their size and evaluation are capped, so a condition can't stall the rules engine.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Error definitions
+var (
+	ErrSyntax        = errors.New("syntax error")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrType          = errors.New("type error")
+	ErrTooComplex    = errors.New("condition is too complex")
+	ErrLimitExceeded = errors.New("condition exceeded its evaluation limit")
+	ErrEval          = errors.New("condition could not be evaluated")
+)
+
+// Limits on what a condition can hold
+const (
+	MaxLength        = 2000 // Characters in a condition
+	MaxNodes         = 250  // Literals, fields, operators and calls
+	MaxDepth         = 32   // Nesting of parentheses, lists, calls and unary operators
+	MaxListItems     = 100  // Items in a list literal
+	MaxPatternLength = 256  // Characters in a matches pattern
+)
+
+// valueType is the static type of an expression
+type valueType int
+
+const (
+	typeNull valueType = iota // The null literal, which compares with anything
+	typeString
+	typeNumber
+	typeBool
+	typeList
+)
+
+func (t valueType) String() string {
+	switch t {
+	case typeString:
+		return "string"
+	case typeNumber:
+		return "number"
+	case typeBool:
+		return "bool"
+	case typeList:
+		return "list"
+	default:
+		return "null"
+	}
+}
+
+// nodeKind is the kind of an AST node
+type nodeKind int
+
+const (
+	nodeLiteral nodeKind = iota
+	nodeField
+	nodeList
+	nodeUnary
+	nodeBinary
+	nodeCall
+)
+
+// node is a checked expression. Operators, fields and calls keep their name in name.
+type node struct {
+	kind  nodeKind
+	name  string
+	value any // Literal value
+	args  []*node
+	typ   valueType
+	re    *regexp.Regexp // Compiled pattern for matches
+	pos   int
+}
+
+// Program is a compiled condition, safe for concurrent use
+type Program struct {
+	source string
+	root   *node
+}
+
+// String returns the condition's source
+func (p *Program) String() string {
+	return p.source
+}
+
+// comparisons are the operators that compare two values
+var comparisons = []string{
+	"==", "!=", "<", "<=", ">", ">=", "in", "contains", "startsWith", "endsWith", "matches",
+}
+
+// functions are the built-in functions and the argument types each accepts
+var functions = map[string]struct {
+	accepts []valueType
+	returns valueType
+}{
+	"len":   {accepts: []valueType{typeString, typeList}, returns: typeNumber},
+	"lower": {accepts: []valueType{typeString}, returns: typeString},
+	"upper": {accepts: []valueType{typeString}, returns: typeString},
+}
+
+// Compile parses a condition and checks its fields and types. The condition must
+// evaluate to true or false.
+func Compile(source string) (*Program, error) {
+	if len(source) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrTooComplex, MaxLength)
+	}
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.typ != tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.value, tok.pos)
+	}
+	if root.typ != typeBool && root.typ != typeNull {
+		return nil, fmt.Errorf("%w: condition must be true or false, not a %s", ErrType, root.typ)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// parser builds a checked AST from tokens, by precedence from loosest to tightest:
+// or, and, not, comparisons, + and -, * / and %, unary minus, then literals, fields,
+// calls, lists and parentheses
+type parser struct {
+	tokens []token
+	pos    int
+	nodes  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.typ != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	tok := p.peek()
+	if tok.typ != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if tok.value == op {
+			return true
+		}
+	}
+	return false
+}
+
+// newNode counts nodes against MaxNodes
+func (p *parser) newNode(n *node) (*node, error) {
+	p.nodes++
+	if p.nodes > MaxNodes {
+		return nil, fmt.Errorf("%w: more than %d terms", ErrTooComplex, MaxNodes)
+	}
+	return n, nil
+}
+
+func checkDepth(depth int) error {
+	if depth > MaxDepth {
+		return fmt.Errorf("%w: nested more than %d levels deep", ErrTooComplex, MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) parseOr(depth int) (*node, error) {
+	return p.parseLogical(depth, "||", p.parseAnd)
+}
+
+func (p *parser) parseAnd(depth int) (*node, error) {
+	return p.parseLogical(depth, "&&", p.parseNot)
+}
+
+func (p *parser) parseLogical(
+	depth int,
+	op string,
+	operand func(int) (*node, error),
+) (*node, error) {
+	left, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(op) {
+		tok := p.next()
+		right, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		for _, side := range []*node{left, right} {
+			if side.typ != typeBool && side.typ != typeNull {
+				return nil, fmt.Errorf("%w: %s needs true or false on both sides, not a %s",
+					ErrType, operatorName(op), side.typ)
+			}
+		}
+		left, err = p.newNode(binary(op, left, right, typeBool, tok.pos))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot(depth int) (*node, error) {
+	if !p.isOperator("!") {
+		return p.parseComparison(depth)
+	}
+	tok := p.next()
+	if err := checkDepth(depth + 1); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseNot(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ != typeBool && operand.typ != typeNull {
+		return nil, fmt.Errorf("%w: not needs true or false, not a %s", ErrType, operand.typ)
+	}
+	return p.newNode(unary("!", operand, typeBool, tok.pos))
+}
+
+func (p *parser) parseComparison(depth int) (*node, error) {
+	left, err := p.parseAdditive(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	negate := false
+	// "x not in list" reads better than "not (x in list)"
+	if p.isOperator("!") && p.pos+1 < len(p.tokens) &&
+		p.tokens[p.pos+1].typ == tokenOperator && p.tokens[p.pos+1].value == "in" {
+		p.next()
+		negate = true
+	}
+	if !p.isOperator(comparisons...) {
+		return left, nil
+	}
+	tok := p.next()
+	right, err := p.parseAdditive(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	n := binary(tok.value, left, right, typeBool, tok.pos)
+	if err := checkComparison(n); err != nil {
+		return nil, err
+	}
+	if tok.value == "matches" {
+		if right.kind != nodeLiteral || right.typ != typeString {
+			return nil, fmt.Errorf("%w: matches needs a quoted pattern", ErrType)
+		}
+		pattern := right.value.(string)
+		if len(pattern) > MaxPatternLength {
+			return nil, fmt.Errorf("%w: pattern longer than %d characters", ErrTooComplex, MaxPatternLength)
+		}
+		if n.re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern: %w", ErrSyntax, err)
+		}
+	}
+	if n, err = p.newNode(n); err != nil {
+		return nil, err
+	}
+	if negate {
+		return p.newNode(unary("!", n, typeBool, tok.pos))
+	}
+	return n, nil
+}
+
+// checkComparison checks the operand types of a comparison
+func checkComparison(n *node) error {
+	left, right := n.args[0].typ, n.args[1].typ
+	ok := false
+	switch n.name {
+	case "==", "!=":
+		ok = left == right || left == typeNull || right == typeNull
+	case "<", "<=", ">", ">=":
+		ok = (left == typeNumber || left == typeString || left == typeNull) &&
+			(right == left || right == typeNull || left == typeNull)
+	case "in":
+		ok = (right == typeList && left != typeList) ||
+			(right == typeString && (left == typeString || left == typeNull))
+	case "contains":
+		ok = (left == typeString && right == typeString) ||
+			(left == typeList && right != typeList)
+	case "startsWith", "endsWith", "matches":
+		ok = (left == typeString || left == typeNull) && right == typeString
+	}
+	if !ok {
+		return fmt.Errorf("%w: can't use %s with a %s and a %s", ErrType, n.name, left, right)
+	}
+	return nil
+}
+
+func (p *parser) parseAdditive(depth int) (*node, error) {
+	return p.parseArithmetic(depth, []string{"+", "-"}, p.parseMultiplicative)
+}
+
+func (p *parser) parseMultiplicative(depth int) (*node, error) {
+	return p.parseArithmetic(depth, []string{"*", "/", "%"}, p.parseUnary)
+}
+
+func (p *parser) parseArithmetic(
+	depth int,
+	ops []string,
+	operand func(int) (*node, error),
+) (*node, error) {
+	left, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(ops...) {
+		tok := p.next()
+		right, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		for _, side := range []*node{left, right} {
+			if side.typ != typeNumber && side.typ != typeNull {
+				return nil, fmt.Errorf("%w: %s needs numbers, not a %s", ErrType, tok.value, side.typ)
+			}
+		}
+		left, err = p.newNode(binary(tok.value, left, right, typeNumber, tok.pos))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary(depth int) (*node, error) {
+	if !p.isOperator("-") {
+		return p.parsePrimary(depth)
+	}
+	tok := p.next()
+	if err := checkDepth(depth + 1); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ != typeNumber && operand.typ != typeNull {
+		return nil, fmt.Errorf("%w: - needs a number, not a %s", ErrType, operand.typ)
+	}
+	return p.newNode(unary("-", operand, typeNumber, tok.pos))
+}
+
+func (p *parser) parsePrimary(depth int) (*node, error) {
+	tok := p.next()
+	if tok.typ == tokenLParen || tok.typ == tokenLBracket || p.peek().typ == tokenLParen {
+		if err := checkDepth(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	switch tok.typ {
+	case tokenNumber:
+		value, _ := strconv.ParseFloat(tok.value, 64)
+		return p.newNode(&node{kind: nodeLiteral, value: value, typ: typeNumber, pos: tok.pos})
+	case tokenString:
+		return p.newNode(&node{kind: nodeLiteral, value: tok.value, typ: typeString, pos: tok.pos})
+	case tokenLParen:
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.typ != tokenRParen {
+			return nil, fmt.Errorf("%w: expected ) at position %d", ErrSyntax, closing.pos)
+		}
+		return inner, nil
+	case tokenLBracket:
+		return p.parseList(depth+1, tok)
+	case tokenIdent:
+		switch tok.value {
+		case "true", "false":
+			literal := &node{kind: nodeLiteral, value: tok.value == "true", typ: typeBool, pos: tok.pos}
+			return p.newNode(literal)
+		case "null":
+			return p.newNode(&node{kind: nodeLiteral, typ: typeNull, pos: tok.pos})
+		}
+		if p.peek().typ == tokenLParen {
+			return p.parseCall(depth+1, tok)
+		}
+		field, ok := fields[tok.value]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, tok.value)
+		}
+		return p.newNode(&node{kind: nodeField, name: tok.value, typ: field.typ, pos: tok.pos})
+	case tokenEOF:
+		return nil, fmt.Errorf("%w: unexpected end of condition", ErrSyntax)
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.value, tok.pos)
+	}
+}
+
+// parseList parses a list literal of strings and numbers
+func (p *parser) parseList(depth int, open token) (*node, error) {
+	list := &node{kind: nodeList, typ: typeList, pos: open.pos}
+	for p.peek().typ != tokenRBracket {
+		if len(list.args) > 0 {
+			if comma := p.next(); comma.typ != tokenComma {
+				return nil, fmt.Errorf("%w: expected , or ] at position %d", ErrSyntax, comma.pos)
+			}
+		}
+		item, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if item.typ != typeString && item.typ != typeNumber {
+			return nil, fmt.Errorf("%w: lists can only hold strings and numbers", ErrType)
+		}
+		list.args = append(list.args, item)
+		if len(list.args) > MaxListItems {
+			return nil, fmt.Errorf("%w: list longer than %d items", ErrTooComplex, MaxListItems)
+		}
+	}
+	p.next()
+	return p.newNode(list)
+}
+
+// parseCall parses a call to a built-in function
+func (p *parser) parseCall(depth int, name token) (*node, error) {
+	fn, ok := functions[name.value]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %s", ErrSyntax, name.value)
+	}
+	p.next() // (
+	call := &node{kind: nodeCall, name: name.value, typ: fn.returns, pos: name.pos}
+	for p.peek().typ != tokenRParen {
+		if len(call.args) > 0 {
+			if comma := p.next(); comma.typ != tokenComma {
+				return nil, fmt.Errorf("%w: expected , or ) at position %d", ErrSyntax, comma.pos)
+			}
+		}
+		arg, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next()
+
+	if len(call.args) != 1 {
+		return nil, fmt.Errorf("%w: %s takes one argument", ErrType, name.value)
+	}
+	argType := call.args[0].typ
+	accepted := argType == typeNull
+	for _, t := range fn.accepts {
+		accepted = accepted || argType == t
+	}
+	if !accepted {
+		return nil, fmt.Errorf("%w: %s can't take a %s", ErrType, name.value, argType)
+	}
+	return p.newNode(call)
+}
+
+func binary(op string, left, right *node, typ valueType, pos int) *node {
+	return &node{kind: nodeBinary, name: op, args: []*node{left, right}, typ: typ, pos: pos}
+}
+
+func unary(op string, operand *node, typ valueType, pos int) *node {
+	return &node{kind: nodeUnary, name: op, args: []*node{operand}, typ: typ, pos: pos}
+}
+
+func operatorName(op string) string {
+	switch op {
+	case "&&":
+		return "and"
+	case "||":
+		return "or"
+	default:
+		return op
+	}
+}
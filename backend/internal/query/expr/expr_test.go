@@ -0,0 +1,195 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package expr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+func testEnv() Env {
+	return Env{
+		"title":    "Fix the parser [WIP]",
+		"type":     "PullRequest",
+		"reason":   "review_requested",
+		"repo":     "octo/repo",
+		"org":      "octo",
+		"author":   "octocat",
+		"number":   float64(42),
+		"labels":   []any{"bug", "needs-review"},
+		"projects": []any{},
+		"draft":    false,
+		"read":     false,
+		"priority": float64(35),
+	}
+}
+
+func TestProgram_Eval(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		want      bool
+	}{
+		{"equality", `reason == "review_requested"`, true},
+		{"inequality", `author != "octocat"`, false},
+		{"number comparison", `number >= 40 && number < 50`, true},
+		{"arithmetic", `priority * 2 - 10 > 59`, true},
+		{"modulo", `number % 2 == 0`, true},
+		{"keyword operators", `not read and (draft or priority > 30)`, true},
+		{"in list literal", `org in ["octo", "cli"]`, true},
+		{"not in list literal", `org not in ["octo", "cli"]`, false},
+		{"list contains", `labels contains "bug"`, true},
+		{"value in list field", `"security" in labels`, false},
+		{"substring", `"parser" in title`, true},
+		{"string contains", `title contains "[WIP]"`, true},
+		{"starts with", `repo startsWith "octo/"`, true},
+		{"ends with", `title endsWith "WIP]"`, true},
+		{"matches", `title matches "(?i)\\[wip\\]$"`, true},
+		{"len of list", `len(labels) >= 2`, true},
+		{"len of empty list", `len(projects) == 0`, true},
+		{"lower", `lower(title) contains "fix"`, true},
+		{"string ordering", `author < "zed"`, true},
+		{"missing value equals null", `milestone == null`, true},
+		{"missing value compares false", `hours_since_update > 1`, false},
+		{"missing value in arithmetic", `hours_since_update + 1 > 0`, false},
+		{"missing bool counts as false", `mergeable`, false},
+		{"negated missing bool", `!mergeable`, true},
+		{"short circuit skips division by zero", `false && number / 0 > 1`, false},
+		{"literal true", `true`, true},
+		{"nested parentheses", `((((((((read == false))))))))`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := Compile(tt.condition)
+			require.NoError(t, err)
+			got, err := program.Eval(testEnv())
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   error
+	}{
+		{"empty", ``, ErrSyntax},
+		{"unterminated string", `title == "fix`, ErrSyntax},
+		{"unexpected character", `title == #`, ErrSyntax},
+		{"missing paren", `(read or starred`, ErrSyntax},
+		{"trailing tokens", `read starred`, ErrSyntax},
+		{"unknown field", `comments > 3`, ErrUnknownField},
+		{"unknown function", `trim(title) == ""`, ErrSyntax},
+		{"not a bool", `number + 1`, ErrType},
+		{"compare string and number", `title > 3`, ErrType},
+		{"arithmetic on string", `title * 2 > 1`, ErrType},
+		{"and needs bools", `title and read`, ErrType},
+		{"contains on number", `number contains 4`, ErrType},
+		{"matches needs a literal", `title matches author`, ErrType},
+		{"bad pattern", `title matches "("`, ErrSyntax},
+		{"len of number", `len(number) > 1`, ErrType},
+		{"too long", strings.Repeat(" ", MaxLength+1), ErrTooComplex},
+		{
+			"too deep",
+			strings.Repeat("(", MaxDepth+1) + "read" + strings.Repeat(")", MaxDepth+1),
+			ErrTooComplex,
+		},
+		{
+			"too many terms",
+			strings.TrimSuffix(strings.Repeat("read or ", MaxNodes), " or "),
+			ErrTooComplex,
+		},
+		{
+			"list too long",
+			`number in [` + strings.TrimSuffix(strings.Repeat("1,", MaxListItems+1), ",") + `]`,
+			ErrTooComplex,
+		},
+		{
+			"pattern too long",
+			`title matches "` + strings.Repeat("a", MaxPatternLength+1) + `"`,
+			ErrTooComplex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.condition)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestProgram_Eval_Errors(t *testing.T) {
+	program, err := Compile(`number / (priority - 35) > 1`)
+	require.NoError(t, err)
+	_, err = program.Eval(testEnv())
+	require.ErrorIs(t, err, ErrEval)
+}
+
+func TestProgram_Eval_StepLimit(t *testing.T) {
+	// Each membership test walks the whole list, so a few of them over a long list run
+	// out of steps
+	program, err := Compile(strings.TrimSuffix(strings.Repeat(`"x" in labels or `, 60), " or "))
+	require.NoError(t, err)
+
+	labels := make([]any, 200)
+	for i := range labels {
+		labels[i] = "label"
+	}
+	_, err = program.Eval(Env{"labels": labels})
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestNotificationEnv(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	labels, err := json.Marshal([]string{"bug"})
+	require.NoError(t, err)
+
+	env := NotificationEnv(&db.Notification{
+		SubjectTitle:    "Crash on start",
+		SubjectType:     "Issue",
+		Reason:          sql.NullString{String: "mention", Valid: true},
+		SubjectNumber:   sql.NullInt32{Int32: 7, Valid: true},
+		SubjectLabels:   db.NullRawMessage{RawMessage: labels, Valid: true},
+		GithubUpdatedAt: sql.NullTime{Time: now.Add(-36 * time.Hour), Valid: true},
+		SnoozedUntil:    sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+	}, &db.Repository{FullName: "octo/repo"}, now)
+
+	require.Equal(t, "Crash on start", env["title"])
+	require.Equal(t, "mention", env["reason"])
+	require.Nil(t, env["author"])
+	require.Equal(t, float64(7), env["number"])
+	require.Equal(t, []any{"bug"}, env["labels"])
+	require.Equal(t, float64(36), env["hours_since_update"])
+	require.Equal(t, true, env["snoozed"])
+	require.Equal(t, "octo/repo", env["repo"])
+	require.Equal(t, "octo", env["org"])
+
+	for name := range env {
+		_, ok := fields[name]
+		require.True(t, ok, "env has %s, which isn't a field", name)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenType is the kind of a lexical token
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator // Punctuation operators and keyword operators such as "and" and "in"
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+)
+
+// token is a lexical token. Value holds the decoded string for string literals.
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+// keywords are the identifiers with a meaning of their own
+var keywords = map[string]string{
+	"and":        "&&",
+	"or":         "||",
+	"not":        "!",
+	"in":         "in",
+	"contains":   "contains",
+	"startsWith": "startsWith",
+	"endsWith":   "endsWith",
+	"matches":    "matches",
+}
+
+// operators are the punctuation operators, longest first so "<=" isn't read as "<"
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%"}
+
+// tokenize splits a condition into tokens. Keyword operators are normalized, so "and"
+// and "&&" both become "&&".
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for {
+		for pos < len(input) && isSpace(input[pos]) {
+			pos++
+		}
+		if pos >= len(input) {
+			return append(tokens, token{typ: tokenEOF, pos: pos}), nil
+		}
+
+		start := pos
+		ch := input[pos]
+		switch {
+		case ch == '(':
+			tokens = append(tokens, token{typ: tokenLParen, value: "(", pos: start})
+			pos++
+		case ch == ')':
+			tokens = append(tokens, token{typ: tokenRParen, value: ")", pos: start})
+			pos++
+		case ch == '[':
+			tokens = append(tokens, token{typ: tokenLBracket, value: "[", pos: start})
+			pos++
+		case ch == ']':
+			tokens = append(tokens, token{typ: tokenRBracket, value: "]", pos: start})
+			pos++
+		case ch == ',':
+			tokens = append(tokens, token{typ: tokenComma, value: ",", pos: start})
+			pos++
+		case ch == '"' || ch == '\'':
+			value, end, err := readString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{typ: tokenString, value: value, pos: start})
+			pos = end
+		case isDigit(ch):
+			for pos < len(input) && (isDigit(input[pos]) || input[pos] == '.') {
+				pos++
+			}
+			if _, err := strconv.ParseFloat(input[start:pos], 64); err != nil {
+				return nil, fmt.Errorf("%w: invalid number %q at position %d",
+					ErrSyntax, input[start:pos], start)
+			}
+			tokens = append(tokens, token{typ: tokenNumber, value: input[start:pos], pos: start})
+		case isIdentStart(ch):
+			for pos < len(input) && isIdentPart(input[pos]) {
+				pos++
+			}
+			word := input[start:pos]
+			if op, ok := keywords[word]; ok {
+				tokens = append(tokens, token{typ: tokenOperator, value: op, pos: start})
+			} else {
+				tokens = append(tokens, token{typ: tokenIdent, value: word, pos: start})
+			}
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(input[pos:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrSyntax, ch, start)
+			}
+			tokens = append(tokens, token{typ: tokenOperator, value: op, pos: start})
+			pos += len(op)
+		}
+	}
+}
+
+// readString reads the quoted string starting at pos and returns its value and the
+// position just past the closing quote
+func readString(input string, pos int) (string, int, error) {
+	quote := input[pos]
+	var b strings.Builder
+	for i := pos + 1; i < len(input); i++ {
+		ch := input[i]
+		switch {
+		case ch == quote:
+			return b.String(), i + 1, nil
+		case ch == '\\' && i+1 < len(input):
+			i++
+			switch input[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(input[i])
+			}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", 0, fmt.Errorf("%w: unterminated string at position %d", ErrSyntax, pos)
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentPart(ch byte) bool {
+	return isIdentStart(ch) || isDigit(ch)
+}
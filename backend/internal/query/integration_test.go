@@ -19,9 +19,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query/parse"
 )
 
+// repoMatch is the SQL generated for a single repo: or org: value, which also matches
+// previous names recorded in repository_aliases
+const repoMatch = "(r.full_name LIKE ? OR EXISTS (SELECT 1 FROM repository_aliases ra " +
+	"WHERE ra.repository_id = r.id AND ra.full_name LIKE ?))"
+
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
@@ -315,6 +321,26 @@ func TestIntegration_ValidationErrors(t *testing.T) {
 			input:      "read:maybe",
 			wantErrMsg: "invalid boolean value",
 		},
+		{
+			name:       "invalid starred boolean",
+			input:      "starred:maybe",
+			wantErrMsg: "invalid boolean value",
+		},
+		{
+			name:       "team without org",
+			input:      "team-review-requested:core",
+			wantErrMsg: "invalid value for team-review-requested",
+		},
+		{
+			name:       "invalid tagged age",
+			input:      "tagged:<7y",
+			wantErrMsg: "invalid value for tagged",
+		},
+		{
+			name:       "invalid priority",
+			input:      "priority:high",
+			wantErrMsg: "invalid value for priority",
+		},
 	}
 
 	for _, tt := range tests {
@@ -543,8 +569,8 @@ func TestIntegration_FullSQL(t *testing.T) { //nolint:gocyclo // This is a test
 		{
 			name:      "simple repo filter",
 			input:     "repo:cli",
-			wantWhere: []string{"r.full_name LIKE ?"},
-			wantArgs:  []string{"%cli%"},
+			wantWhere: []string{repoMatch},
+			wantArgs:  []string{"%cli%", "%cli%"},
 			wantJoins: []string{"LEFT JOIN repositories r ON r.id = n.repository_id"},
 		},
 		{
@@ -638,15 +664,15 @@ func TestIntegration_FullSQL(t *testing.T) { //nolint:gocyclo // This is a test
 		{
 			name:      "explicit AND",
 			input:     "repo:cli AND is:unread",
-			wantWhere: []string{"(r.full_name LIKE ? AND n.is_read = 0)"},
-			wantArgs:  []string{"%cli%"},
+			wantWhere: []string{"(" + repoMatch + " AND n.is_read = 0)"},
+			wantArgs:  []string{"%cli%", "%cli%"},
 			wantJoins: []string{"LEFT JOIN repositories r ON r.id = n.repository_id"},
 		},
 		{
 			name:      "explicit OR",
 			input:     "repo:cli OR repo:other",
-			wantWhere: []string{"(r.full_name LIKE ? OR r.full_name LIKE ?)"},
-			wantArgs:  []string{"%cli%", "%other%"},
+			wantWhere: []string{"(" + repoMatch + " OR " + repoMatch + ")"},
+			wantArgs:  []string{"%cli%", "%cli%", "%other%", "%other%"},
 			wantJoins: []string{"LEFT JOIN repositories r ON r.id = n.repository_id"},
 		},
 		{
@@ -700,8 +726,8 @@ func TestIntegration_FullSQL(t *testing.T) { //nolint:gocyclo // This is a test
 		{
 			name:      "org: prefix matching",
 			input:     "org:github",
-			wantWhere: []string{"r.full_name LIKE ?"},
-			wantArgs:  []string{"github/%"},
+			wantWhere: []string{repoMatch},
+			wantArgs:  []string{"github/%", "github/%"},
 			wantJoins: []string{"LEFT JOIN repositories r ON r.id = n.repository_id"},
 		},
 		{
@@ -729,9 +755,9 @@ func TestIntegration_FullSQL(t *testing.T) { //nolint:gocyclo // This is a test
 			name:  "grouped OR with AND",
 			input: "(repo:cli OR repo:other) AND is:unread",
 			wantWhere: []string{
-				"((r.full_name LIKE ? OR r.full_name LIKE ?) AND n.is_read = 0)",
+				"((" + repoMatch + " OR " + repoMatch + ") AND n.is_read = 0)",
 			},
-			wantArgs:  []string{"%cli%", "%other%"},
+			wantArgs:  []string{"%cli%", "%cli%", "%other%", "%other%"},
 			wantJoins: []string{"LEFT JOIN repositories r ON r.id = n.repository_id"},
 		},
 		{
@@ -852,20 +878,23 @@ func TestIntegration_ComplexSQL(t *testing.T) {
 		{
 			name:      "review requests from cli",
 			input:     "repo:cli reason:review_requested is:unread",
-			wantWhere: "((r.full_name LIKE ? AND n.reason LIKE ?) AND n.is_read = 0)",
-			wantArgs:  []string{"%cli%", "%review_requested%"},
+			wantWhere: "((" + repoMatch + " AND n.reason LIKE ?) AND n.is_read = 0)",
+			wantArgs:  []string{"%cli%", "%cli%", "%review_requested%"},
 		},
 		{
 			name:      "multiple repos with bot exclusion",
 			input:     "(repo:cli OR repo:other) AND NOT author:bot",
-			wantWhere: "((r.full_name LIKE ? OR r.full_name LIKE ?) AND NOT (n.author_login LIKE ?))",
-			wantArgs:  []string{"%cli%", "%other%", "%bot%"},
+			wantWhere: "((" + repoMatch + " OR " + repoMatch + ") AND NOT (n.author_login LIKE ?))",
+			wantArgs:  []string{"%cli%", "%cli%", "%other%", "%other%", "%bot%"},
 		},
 		{
-			name:      "comma OR with multiple fields",
-			input:     "repo:cli,other reason:review_requested,mention",
-			wantWhere: "((r.full_name LIKE ? OR r.full_name LIKE ?) AND (n.reason LIKE ? OR n.reason LIKE ?))",
-			wantArgs:  []string{"%cli%", "%other%", "%review_requested%", "%mention%"},
+			name:  "comma OR with multiple fields",
+			input: "repo:cli,other reason:review_requested,mention",
+			wantWhere: "((" + repoMatch + " OR " + repoMatch + ") AND " +
+				"(n.reason LIKE ? OR n.reason LIKE ?))",
+			wantArgs: []string{
+				"%cli%", "%cli%", "%other%", "%other%", "%review_requested%", "%mention%",
+			},
 		},
 	}
 
@@ -994,7 +1023,7 @@ func TestIntegration_InboxQueryExact(t *testing.T) {
 			name:  "simple query gets muted-only defaults",
 			input: "repo:cli",
 			wantWhere: []string{
-				"r.full_name LIKE ?",
+				repoMatch,
 				"n.muted = 0", // Non-empty query without in: only excludes muted
 			},
 		},
@@ -1002,7 +1031,7 @@ func TestIntegration_InboxQueryExact(t *testing.T) {
 			name:  "explicit in:anywhere - no defaults",
 			input: "in:anywhere repo:cli",
 			wantWhere: []string{
-				"(1 AND r.full_name LIKE ?)",
+				"(1 AND " + repoMatch + ")",
 			},
 		},
 		{
@@ -1010,7 +1039,7 @@ func TestIntegration_InboxQueryExact(t *testing.T) {
 			input: "in:inbox repo:cli",
 			wantWhere: []string{
 
-				"((n.archived = 0 AND (n.snoozed_until IS NULL OR n.snoozed_until <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now')) AND n.muted = 0 AND n.filtered = 0) AND r.full_name LIKE ?)",
+				"((n.archived = 0 AND (n.snoozed_until IS NULL OR n.snoozed_until <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now')) AND n.muted = 0 AND n.filtered = 0) AND " + repoMatch + ")",
 			},
 		},
 		{
@@ -1181,13 +1210,14 @@ func TestIntegration_FreeText(t *testing.T) {
 
 	where := query.Where[0]
 
-	// Should search across title, type, repo, author, state
+	// Should search across title, type, repo, author, state, and the full-text index
 	expectedParts := []string{
 		"n.subject_title LIKE",
 		"n.subject_type LIKE",
 		"r.full_name LIKE",
 		"n.author_login LIKE",
 		"n.subject_state LIKE",
+		"notification_search MATCH",
 		"OR",
 	}
 
@@ -1197,9 +1227,10 @@ func TestIntegration_FreeText(t *testing.T) {
 		}
 	}
 
-	// Should have 12 args (6 fields × 2 words: title, type, repo, author, state, subject_number)
-	if len(query.Args) != 12 {
-		t.Errorf("expected 12 args for 2 words across 6 fields, got %d", len(query.Args))
+	// Should have 14 args (7 per word: title, type, repo, author, state, subject_number,
+	// and the full-text match)
+	if len(query.Args) != 14 {
+		t.Errorf("expected 14 args for 2 words across 7 matches, got %d", len(query.Args))
 	}
 
 	// Should require repo join
@@ -1338,6 +1369,8 @@ func TestCoverage_MultipleValuesForBooleans(t *testing.T) {
 		"archived:true,false",
 		"muted:yes,no",
 		"snoozed:1,0",
+		"starred:true,false",
+		"filtered:yes,no",
 	}
 
 	for _, queryStr := range tests {
@@ -1538,9 +1571,9 @@ func TestCoverage_OrgMultipleValues(t *testing.T) {
 		t.Error("multiple org values should use OR")
 	}
 
-	// Should use prefix matching (/)
-	if len(query.Args) != 2 {
-		t.Errorf("expected 2 args, got %d", len(query.Args))
+	// Should use prefix matching (/), once for the full name and once for aliases
+	if len(query.Args) != 4 {
+		t.Errorf("expected 4 args, got %d", len(query.Args))
 	}
 
 	arg1, ok := query.Args[0].(string)
@@ -1762,7 +1795,7 @@ func TestCoverage_MultipleNegations(t *testing.T) {
 
 // TestCoverage_InOperatorAllValues tests all in: operator values
 func TestCoverage_InOperatorAllValues(t *testing.T) {
-	values := []string{"inbox", "archive", "snoozed", "anywhere"}
+	values := []string{"inbox", "archive", "snoozed", "starred", "filtered", "anywhere"}
 
 	for _, val := range values {
 		t.Run("in:"+val, func(t *testing.T) {
@@ -1994,3 +2027,90 @@ func TestIntegration_TagsQuery(t *testing.T) {
 		})
 	}
 }
+
+// TestNegation_EveryField checks that the - prefix negates each field the same way as NOT
+func TestNegation_EveryField(t *testing.T) {
+	terms := []string{
+		"in:archive", "in:inbox,snoozed", "is:read", "is:unread,starred", "repo:cli/cli",
+		"org:github", "reason:mention", "type:PullRequest", "author:bot[bot]", `title:"a b"`,
+		"state:open", "read:true", "archived:true", "muted:true", "snoozed:true",
+		"filtered:true", "tags:urgent", `"wip fix"`,
+	}
+
+	for _, term := range terms {
+		t.Run(term, func(t *testing.T) {
+			dash, err := BuildQuery("-"+term, 50, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery(-%s) failed: %v", term, err)
+			}
+			not, err := BuildQuery("NOT "+term, 50, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery(NOT %s) failed: %v", term, err)
+			}
+			if strings.Join(dash.Where, " AND ") != strings.Join(not.Where, " AND ") {
+				t.Errorf("-%s and NOT %s differ:\n%v\n%v", term, term, dash.Where, not.Where)
+			}
+			if !strings.HasPrefix(dash.Where[0], "NOT (") {
+				t.Errorf("-%s should be negated, got %v", term, dash.Where)
+			}
+		})
+	}
+}
+
+// TestExplainDefaults checks the explanation matches the filters BuildQuery applies
+func TestExplainDefaults(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantScope string
+		wantMuted bool // whether BuildQuery adds the muted-only filter
+	}{
+		{"", models.QueryDefaultScopeInbox, true},
+		{"is:unread", models.QueryDefaultScopeMutedOnly, true},
+		{"in:archive", models.QueryDefaultScopeNone, false},
+		{"is:unread OR in:anywhere", models.QueryDefaultScopeNone, false},
+		{"is:muted", models.QueryDefaultScopeNone, false},
+		{"-is:muted", models.QueryDefaultScopeMutedOnly, true},
+		// A negated in: only removes a scope, so muted notifications stay hidden
+		{"-in:archive", models.QueryDefaultScopeMutedOnly, true},
+		{"is:unread NOT in:snoozed", models.QueryDefaultScopeMutedOnly, true},
+		{"in:starred", models.QueryDefaultScopeNone, false},
+		{"in:filtered", models.QueryDefaultScopeNone, false},
+		{"is:starred", models.QueryDefaultScopeMutedOnly, true},
+		{"starred:true", models.QueryDefaultScopeMutedOnly, true},
+		{"filtered:true", models.QueryDefaultScopeMutedOnly, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ExplainDefaults(tt.query)
+			if err != nil {
+				t.Fatalf("ExplainDefaults() error = %v", err)
+			}
+			if got.Scope != tt.wantScope {
+				t.Errorf("Scope = %q, want %q", got.Scope, tt.wantScope)
+			}
+			if got.Reason == "" {
+				t.Error("expected a reason")
+			}
+
+			query, err := BuildQuery(tt.query, 50, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery() error = %v", err)
+			}
+			hasMuted := false
+			for _, where := range query.Where {
+				hasMuted = hasMuted || where == "n.muted = 0"
+			}
+			if hasMuted != tt.wantMuted {
+				t.Errorf("muted default applied = %v, want %v", hasMuted, tt.wantMuted)
+			}
+			if hasMuted != contains(strings.Join(got.Excluded, ","), "muted") {
+				t.Errorf("Excluded = %v, applied filters %v", got.Excluded, query.Where)
+			}
+		})
+	}
+
+	if _, err := ExplainDefaults("in:nowhere"); err == nil {
+		t.Error("expected an error for an invalid query")
+	}
+}
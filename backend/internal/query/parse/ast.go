@@ -26,11 +26,19 @@ type Node interface {
 	String() string // For debugging
 }
 
+// Span locates a node in the query string it was parsed from, as byte offsets with an
+// exclusive end, so query[Start:End] is the node's source text
+type Span struct {
+	Start int
+	End   int
+}
+
 // BinaryExpr represents AND/OR operations
 type BinaryExpr struct {
 	Op    string // "AND" or "OR"
 	Left  Node
 	Right Node
+	Span  Span
 }
 
 func (b *BinaryExpr) String() string {
@@ -40,6 +48,7 @@ func (b *BinaryExpr) String() string {
 // NotExpr represents negation
 type NotExpr struct {
 	Expr Node
+	Span Span
 }
 
 func (n *NotExpr) String() string {
@@ -51,6 +60,7 @@ type Term struct {
 	Field   string
 	Values  []string
 	Negated bool // For -field:value syntax (deprecated in favor of NOT)
+	Span    Span
 }
 
 func (t *Term) String() string {
@@ -67,6 +77,7 @@ func (t *Term) String() string {
 // FreeText represents unstructured search text
 type FreeText struct {
 	Text string
+	Span Span
 }
 
 func (f *FreeText) String() string {
@@ -76,8 +87,27 @@ func (f *FreeText) String() string {
 // ParenExpr represents grouped expression
 type ParenExpr struct {
 	Expr Node
+	Span Span
 }
 
 func (p *ParenExpr) String() string {
 	return fmt.Sprintf("(%s)", p.Expr.String())
 }
+
+// SpanOf returns the span of a node, or the zero Span for nodes not built by the parser
+func SpanOf(node Node) Span {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return n.Span
+	case *NotExpr:
+		return n.Span
+	case *Term:
+		return n.Span
+	case *FreeText:
+		return n.Span
+	case *ParenExpr:
+		return n.Span
+	default:
+		return Span{}
+	}
+}
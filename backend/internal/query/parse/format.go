@@ -0,0 +1,126 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"strings"
+)
+
+// Operator precedence for Format, lowest first
+const (
+	precOr = iota + 1
+	precAnd
+	precUnary
+)
+
+// Format renders a node as a canonical query string. Parsing the result gives back an
+// equivalent tree: AND and OR are written out, parentheses are added only where the
+// tree's grouping differs from the parser's precedence, and values are quoted when they
+// wouldn't otherwise read back as a single value. A nil node formats as "".
+func Format(node Node) string {
+	if node == nil {
+		return ""
+	}
+	var b strings.Builder
+	format(&b, node, precOr)
+	return b.String()
+}
+
+// format writes node, wrapping it in parentheses if it binds looser than minPrec
+func format(b *strings.Builder, node Node, minPrec int) {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		prec := precAnd
+		if n.Op == "OR" {
+			prec = precOr
+		}
+		if prec < minPrec {
+			b.WriteString("(")
+			defer b.WriteString(")")
+		}
+		// Both operators are left-associative, so a right operand of the same
+		// precedence needs parentheses to keep its grouping
+		format(b, n.Left, prec)
+		b.WriteString(" " + n.Op + " ")
+		format(b, n.Right, prec+1)
+	case *NotExpr:
+		if _, ok := n.Expr.(*Term); ok {
+			b.WriteString("-")
+		} else {
+			b.WriteString("NOT ")
+		}
+		format(b, n.Expr, precUnary)
+	case *ParenExpr:
+		b.WriteString("(")
+		format(b, n.Expr, precOr)
+		b.WriteString(")")
+	case *Term:
+		if n.Negated {
+			b.WriteString("-")
+		}
+		b.WriteString(n.Field)
+		b.WriteString(":")
+		for i, value := range n.Values {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(QuoteIfNeeded(value))
+		}
+	case *FreeText:
+		b.WriteString(QuoteIfNeeded(n.Text))
+	}
+}
+
+// QuoteIfNeeded returns s unchanged if the lexer reads it back as one word, and as a
+// quoted string otherwise
+func QuoteIfNeeded(s string) string {
+	if isBareWord(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteString(`"`)
+	for _, ch := range s {
+		switch ch {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(ch)
+		}
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+// isBareWord reports whether s can be written without quotes
+func isBareWord(s string) bool {
+	if s == "" || s == "AND" || s == "OR" || s == "NOT" || s[0] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		// The lexer reads bytes, so check bytes rather than runes
+		if !isWordChar(rune(s[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseQuery(t *testing.T, input string) Node {
+	t.Helper()
+	tokens, err := NewLexer(input).Tokenize()
+	require.NoError(t, err)
+	node, err := NewParser(tokens).Parse()
+	require.NoError(t, err)
+	return node
+}
+
+func TestFormat_Canonical(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"repo:cli", "repo:cli"},
+		{"repo:cli,other  is:unread", "repo:cli,other AND is:unread"},
+		{"repo:cli OR repo:go is:unread", "repo:cli OR repo:go AND is:unread"},
+		{"(repo:cli OR repo:go) is:unread", "(repo:cli OR repo:go) AND is:unread"},
+		{"-reason:subscribed", "-reason:subscribed"},
+		{"NOT is:read", "-is:read"},
+		{"NOT (is:read OR is:archived)", "NOT (is:read OR is:archived)"},
+		{`NOT "wip"`, "NOT wip"},
+		{`is:unread AND "two words"`, `is:unread AND "two words"`},
+		{`subject:"urgent fix"`, `subject:"urgent fix"`},
+		{`"AND"`, `"AND"`},
+		{`title:"-draft"`, `title:"-draft"`},
+		{`"say \"hi\"\\now"`, `"say \"hi\"\\now"`},
+		{`"café"`, `café`},
+		{`label:"🐛 bug"`, `label:"🐛 bug"`},
+		{`label:🐛`, `label:🐛`},
+		{"author:dependabot[bot]", "author:dependabot[bot]"},
+		{"fix bug", "fix AND bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			node := parseQuery(t, tt.input)
+			canonical := Format(node)
+			require.Equal(t, tt.expected, canonical)
+
+			// The canonical form parses back to the same tree and is stable
+			reparsed := parseQuery(t, canonical)
+			if node == nil {
+				require.Nil(t, reparsed)
+				return
+			}
+			require.Equal(t, node.String(), reparsed.String())
+			require.Equal(t, canonical, Format(reparsed))
+		})
+	}
+}
+
+func TestFormat_AddsParenthesesForGrouping(t *testing.T) {
+	a := &Term{Field: "repo", Values: []string{"a"}}
+	b := &Term{Field: "repo", Values: []string{"b"}}
+	c := &Term{Field: "is", Values: []string{"unread"}}
+
+	tests := []struct {
+		name     string
+		node     Node
+		expected string
+	}{
+		{
+			name: "OR inside AND",
+			node: &BinaryExpr{
+				Op: "AND", Left: &BinaryExpr{Op: "OR", Left: a, Right: b}, Right: c,
+			},
+			expected: "(repo:a OR repo:b) AND is:unread",
+		},
+		{
+			name: "right-nested OR",
+			node: &BinaryExpr{
+				Op: "OR", Left: a, Right: &BinaryExpr{Op: "OR", Left: b, Right: c},
+			},
+			expected: "repo:a OR (repo:b OR is:unread)",
+		},
+		{
+			name:     "NOT of AND",
+			node:     &NotExpr{Expr: &BinaryExpr{Op: "AND", Left: a, Right: c}},
+			expected: "NOT (repo:a AND is:unread)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical := Format(tt.node)
+			require.Equal(t, tt.expected, canonical)
+
+			reparsed := parseQuery(t, canonical)
+			require.Equal(t, Format(reparsed), canonical)
+		})
+	}
+}
+
+func TestParser_Spans(t *testing.T) {
+	input := `repo:cli,go  NOT "wip fix" (is:unread OR is:starred)`
+	node := parseQuery(t, input)
+
+	root, ok := node.(*BinaryExpr)
+	require.True(t, ok)
+	require.Equal(t, Span{Start: 0, End: len(input)}, root.Span)
+
+	left, ok := root.Left.(*BinaryExpr)
+	require.True(t, ok)
+	require.Equal(t, "repo:cli,go", input[SpanOf(left.Left).Start:SpanOf(left.Left).End])
+	require.Equal(t, `NOT "wip fix"`, input[SpanOf(left.Right).Start:SpanOf(left.Right).End])
+
+	group, ok := root.Right.(*ParenExpr)
+	require.True(t, ok)
+	require.Equal(t, "(is:unread OR is:starred)", input[group.Span.Start:group.Span.End])
+	or := group.Expr.(*BinaryExpr)
+	require.Equal(t, "is:unread OR is:starred", input[or.Span.Start:or.Span.End])
+}
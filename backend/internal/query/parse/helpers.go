@@ -39,6 +39,27 @@ func HasInOperator(node Node) bool {
 	}
 }
 
+// HasScopeOverride checks if the AST contains an "in:" operator outside of a negation.
+// A negated in: (e.g. -in:archive) only excludes a scope, so default filters still apply.
+func HasScopeOverride(node Node) bool {
+	if node == nil {
+		return false
+	}
+
+	switch n := node.(type) {
+	case *Term:
+		return !n.Negated && strings.EqualFold(n.Field, "in")
+	case *BinaryExpr:
+		return HasScopeOverride(n.Left) || HasScopeOverride(n.Right)
+	case *NotExpr:
+		return false
+	case *ParenExpr:
+		return HasScopeOverride(n.Expr)
+	default:
+		return false
+	}
+}
+
 // HasInAnywhere checks if the AST contains an "in:anywhere" operator
 func HasInAnywhere(node Node) bool {
 	if node == nil {
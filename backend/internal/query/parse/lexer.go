@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Error definitions
@@ -33,6 +34,8 @@ type Lexer struct {
 	input string
 	pos   int
 	ch    rune
+	// partial tolerates input that is still being typed; see NewPartialLexer
+	partial bool
 }
 
 // NewLexer creates a new lexer for the given input
@@ -45,6 +48,15 @@ func NewLexer(input string) *Lexer {
 	return l
 }
 
+// NewPartialLexer creates a lexer for a query that is still being typed. An unterminated
+// quoted string runs to the end of the input and unexpected characters are skipped, so
+// tokenizing never fails.
+func NewPartialLexer(input string) *Lexer {
+	l := NewLexer(input)
+	l.partial = true
+	return l
+}
+
 // Tokenize returns all tokens from the input
 func (l *Lexer) Tokenize() ([]Token, error) {
 	var tokens []Token
@@ -54,6 +66,10 @@ func (l *Lexer) Tokenize() ([]Token, error) {
 		if err != nil {
 			return nil, err
 		}
+		tok.End = tok.Pos
+		if tok.Type != TokenEOF {
+			tok.End = l.pos
+		}
 		tokens = append(tokens, tok)
 		if tok.Type == TokenEOF {
 			break
@@ -94,10 +110,10 @@ func (l *Lexer) nextToken() (Token, error) {
 	case '-':
 		// Could be NOT operator or part of a word/value
 		// If at start of token and followed by a letter (like -repo:), it's NOT
-		// If followed by whitespace or special char, it's also NOT
+		// If followed by whitespace, '(' or a quoted string (like -"wip"), it's also NOT
 		// Otherwise it's part of a hyphenated word
 		nextChar := l.peekChar()
-		if nextChar == 0 || unicode.IsSpace(nextChar) || nextChar == '(' ||
+		if nextChar == 0 || unicode.IsSpace(nextChar) || nextChar == '(' || nextChar == '"' ||
 			unicode.IsLetter(nextChar) {
 			l.readChar()
 			return Token{Type: TokenNot, Value: "-", Pos: pos}, nil
@@ -110,6 +126,10 @@ func (l *Lexer) nextToken() (Token, error) {
 			word := l.readWord()
 			return l.classifyWord(word, pos), nil
 		}
+		if l.partial {
+			l.readChar()
+			return l.nextToken()
+		}
 		return Token{}, errors.Join(
 			ErrUnexpectedCharacter,
 			fmt.Errorf("character %q at position %d", l.ch, pos),
@@ -161,6 +181,9 @@ func (l *Lexer) readQuotedString() (string, error) {
 		if l.ch == '\\' {
 			l.readChar()
 			if l.ch == 0 {
+				if l.partial {
+					break
+				}
 				return "", errors.Join(
 					ErrUnterminatedQuotedString,
 					fmt.Errorf("at position %d", start),
@@ -177,15 +200,19 @@ func (l *Lexer) readQuotedString() (string, error) {
 			case '\\':
 				result.WriteRune('\\')
 			default:
-				result.WriteRune(l.ch)
+				result.WriteByte(byte(l.ch))
 			}
 		} else {
-			result.WriteRune(l.ch)
+			// Input is read a byte at a time, so copy bytes to keep UTF-8 intact
+			result.WriteByte(byte(l.ch))
 		}
 		l.readChar()
 	}
 
 	if l.ch == 0 {
+		if l.partial {
+			return result.String(), nil
+		}
 		return "", errors.Join(ErrUnterminatedQuotedString, fmt.Errorf("at position %d", start))
 	}
 
@@ -208,10 +235,15 @@ func (l *Lexer) classifyWord(word string, pos int) Token {
 	}
 }
 
-// isWordChar returns true if the character is part of a word
+// isWordChar returns true if the character is part of a word. Input is read a byte at a
+// time, so every byte of a multi-byte UTF-8 character counts, letting values like labels
+// with accents or emoji go unquoted.
 func isWordChar(ch rune) bool {
-	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '-' || ch == '_' || ch == '/' ||
+	return ch >= utf8.RuneSelf ||
+		unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '-' || ch == '_' || ch == '/' ||
 		ch == '.' ||
 		ch == '@' ||
-		ch == '[' || ch == ']'
+		ch == '[' || ch == ']' ||
+		ch == '*' ||
+		ch == '<' || ch == '>'
 }
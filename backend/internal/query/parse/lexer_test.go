@@ -128,6 +128,11 @@ func TestLexer_Operators(t *testing.T) {
 			input:    "-repo:cli",
 			expected: []TokenType{TokenNot, TokenFreeText, TokenColon, TokenFreeText, TokenEOF},
 		},
+		{
+			name:     "dash NOT before quoted string",
+			input:    `-"wip fix"`,
+			expected: []TokenType{TokenNot, TokenValue, TokenEOF},
+		},
 		{
 			name:     "lowercase operators are free text",
 			input:    "and or not",
@@ -254,6 +259,21 @@ func TestLexer_SpecialChars(t *testing.T) {
 			input: "author:[bot]",
 			valid: true,
 		},
+		{
+			name:  "wildcard in branch value",
+			input: "branch:release/*",
+			valid: true,
+		},
+		{
+			name:  "emoji in value",
+			input: "label:🐛",
+			valid: true,
+		},
+		{
+			name:  "accents in value",
+			input: "label:priorité",
+			valid: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +97,7 @@ func (p *Parser) parseOrExpr() (Node, error) {
 			Op:    "OR",
 			Left:  left,
 			Right: right,
+			Span:  joinSpans(left, right),
 		}
 	}
 
@@ -123,6 +124,7 @@ func (p *Parser) parseAndExpr() (Node, error) {
 				Op:    "AND",
 				Left:  left,
 				Right: right,
+				Span:  joinSpans(left, right),
 			}
 			continue
 		}
@@ -137,6 +139,7 @@ func (p *Parser) parseAndExpr() (Node, error) {
 				Op:    "AND",
 				Left:  left,
 				Right: right,
+				Span:  joinSpans(left, right),
 			}
 			continue
 		}
@@ -150,12 +153,13 @@ func (p *Parser) parseAndExpr() (Node, error) {
 // parseNotExpr handles NOT operations
 func (p *Parser) parseNotExpr() (Node, error) {
 	if p.current.Type == TokenNot {
+		start := p.current
 		p.advance()                   // consume NOT
 		expr, err := p.parseNotExpr() // Allow chaining: NOT NOT expr
 		if err != nil {
 			return nil, err
 		}
-		return &NotExpr{Expr: expr}, nil
+		return &NotExpr{Expr: expr, Span: Span{Start: start.Pos - 1, End: SpanOf(expr).End}}, nil
 	}
 
 	return p.parsePrimary()
@@ -183,9 +187,9 @@ func (p *Parser) parsePrimary() (Node, error) {
 
 	// Free text (including quoted strings)
 	if p.current.Type == TokenFreeText || p.current.Type == TokenValue {
-		text := p.current.Value
+		tok := p.current
 		p.advance()
-		return &FreeText{Text: text}, nil
+		return &FreeText{Text: tok.Value, Span: tokenSpan(tok, tok)}, nil
 	}
 
 	return nil, errors.Join(
@@ -202,6 +206,7 @@ func (p *Parser) parseParenExpr() (Node, error) {
 			fmt.Errorf("at position %d", p.current.Pos),
 		)
 	}
+	start := p.current
 	p.advance() // consume '('
 
 	expr, err := p.parseExpression()
@@ -215,9 +220,10 @@ func (p *Parser) parseParenExpr() (Node, error) {
 			fmt.Errorf("at position %d, got %s", p.current.Pos, p.current.Type),
 		)
 	}
+	end := p.current
 	p.advance() // consume ')'
 
-	return &ParenExpr{Expr: expr}, nil
+	return &ParenExpr{Expr: expr, Span: tokenSpan(start, end)}, nil
 }
 
 // parseTerm parses a field:value1,value2 term
@@ -226,7 +232,8 @@ func (p *Parser) parseTerm() (*Term, error) {
 		return nil, errors.Join(ErrExpectedFieldName, fmt.Errorf("at position %d", p.current.Pos))
 	}
 
-	field := p.current.Value
+	start := p.current
+	field := start.Value
 	p.advance()
 
 	if p.current.Type != TokenColon {
@@ -239,6 +246,7 @@ func (p *Parser) parseTerm() (*Term, error) {
 
 	// Parse values (comma-separated)
 	var values []string
+	var last Token
 
 	for {
 		// Values can be TokenFreeText or TokenValue (quoted strings)
@@ -250,6 +258,7 @@ func (p *Parser) parseTerm() (*Term, error) {
 		}
 
 		values = append(values, p.current.Value)
+		last = p.current
 		p.advance()
 
 		// Check for comma (OR within field)
@@ -268,6 +277,7 @@ func (p *Parser) parseTerm() (*Term, error) {
 	return &Term{
 		Field:  field,
 		Values: values,
+		Span:   tokenSpan(start, last),
 	}, nil
 }
 
@@ -292,6 +302,17 @@ func (p *Parser) isStartOfTerm() bool {
 	return false
 }
 
+// tokenSpan returns the span from the start of first to the end of last. Token positions
+// are 1-based, matching error messages; spans are 0-based offsets.
+func tokenSpan(first, last Token) Span {
+	return Span{Start: first.Pos - 1, End: last.End - 1}
+}
+
+// joinSpans returns the span covering both nodes
+func joinSpans(left, right Node) Span {
+	return Span{Start: SpanOf(left).Start, End: SpanOf(right).End}
+}
+
 // advance moves to the next token
 func (p *Parser) advance() {
 	p.pos++
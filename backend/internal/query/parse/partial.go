@@ -0,0 +1,107 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package parse
+
+import "strings"
+
+// Partial describes the term being typed at a cursor in a query that may not parse yet,
+// such as `repo:cli/` or `author:"oct`.
+type Partial struct {
+	// Field is the lowercased field whose value is being typed. It's empty while a field
+	// name or free text is being typed.
+	Field string
+	// Prefix is the text typed so far, without quotes
+	Prefix string
+	// Quoted is true when the text being typed starts with a quote
+	Quoted bool
+	// Start and End are the 0-based byte offsets of the text a completion replaces
+	Start int
+	End   int
+}
+
+// ParsePartial finds what is being typed at cursor, a 0-based byte offset into input.
+// Only the input before the cursor decides the context, so unterminated quotes, dangling
+// colons, and unbalanced parentheses are fine and ParsePartial never fails.
+func ParsePartial(input string, cursor int) Partial {
+	cursor = max(0, min(cursor, len(input)))
+	partial := Partial{Start: cursor, End: cursor}
+
+	// The partial lexer doesn't fail, so the error can be ignored
+	tokens, _ := NewPartialLexer(input[:cursor]).Tokenize()
+	tokens = tokens[:len(tokens)-1] // drop EOF
+	if len(tokens) == 0 {
+		return partial
+	}
+
+	last := len(tokens) - 1
+	tok := tokens[last]
+	if tok.End-1 < cursor {
+		// Whitespace before the cursor: a new term starts here
+		return partial
+	}
+
+	switch tok.Type {
+	case TokenColon, TokenComma:
+		partial.Field = fieldBefore(tokens, last)
+	case TokenFreeText, TokenValue, TokenAnd, TokenOr:
+		partial.Prefix = tok.Value
+		partial.Start = tok.Pos - 1
+		partial.Quoted = input[partial.Start] == '"'
+		if !partial.Quoted {
+			partial.End = wordEnd(input, cursor)
+		} else if _, err := NewLexer(input[partial.Start:cursor]).Tokenize(); err != nil {
+			// Still inside the quotes, so replace through the closing quote if there is one
+			partial.End = quoteEnd(input, cursor)
+		}
+		if last > 0 && (tokens[last-1].Type == TokenColon || tokens[last-1].Type == TokenComma) {
+			partial.Field = fieldBefore(tokens, last-1)
+		}
+	}
+	return partial
+}
+
+// fieldBefore returns the lowercased field that owns the colon or comma at tokens[i], or
+// an empty string when the values don't follow a field.
+func fieldBefore(tokens []Token, i int) string {
+	// Walk back over value, comma pairs to the colon
+	for i >= 0 && tokens[i].Type == TokenComma {
+		if i == 0 || (tokens[i-1].Type != TokenFreeText && tokens[i-1].Type != TokenValue) {
+			return ""
+		}
+		i -= 2
+	}
+	if i < 1 || tokens[i].Type != TokenColon || tokens[i-1].Type != TokenFreeText {
+		return ""
+	}
+	return strings.ToLower(tokens[i-1].Value)
+}
+
+// wordEnd returns where the unquoted word under the cursor ends
+func wordEnd(input string, cursor int) int {
+	end := cursor
+	for end < len(input) && isWordChar(rune(input[end])) {
+		end++
+	}
+	return end
+}
+
+// quoteEnd returns the offset just past the closing quote after the cursor, or the end of
+// the input when the quote isn't closed.
+func quoteEnd(input string, cursor int) int {
+	if i := strings.IndexByte(input[cursor:], '"'); i >= 0 {
+		return cursor + i + 1
+	}
+	return len(input)
+}
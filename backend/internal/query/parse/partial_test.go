@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+package parse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePartial(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string // | marks the cursor; it's at the end when missing
+		expected Partial
+	}{
+		{
+			name:     "empty query",
+			input:    "",
+			expected: Partial{},
+		},
+		{
+			name:     "field name being typed",
+			input:    "is:unread rep",
+			expected: Partial{Prefix: "rep", Start: 10, End: 13},
+		},
+		{
+			name:     "new term after whitespace",
+			input:    "is:unread ",
+			expected: Partial{Start: 10, End: 10},
+		},
+		{
+			name:     "dangling colon",
+			input:    "repo:",
+			expected: Partial{Field: "repo", Start: 5, End: 5},
+		},
+		{
+			name:     "value being typed",
+			input:    "-Repo:cli/",
+			expected: Partial{Field: "repo", Prefix: "cli/", Start: 6, End: 10},
+		},
+		{
+			name:     "value after commas",
+			input:    "author:octocat,hubot,mo",
+			expected: Partial{Field: "author", Prefix: "mo", Start: 21, End: 23},
+		},
+		{
+			name:     "dangling comma",
+			input:    "(tags:bug,",
+			expected: Partial{Field: "tags", Start: 10, End: 10},
+		},
+		{
+			name:     "unterminated quote",
+			input:    `author:"oct`,
+			expected: Partial{Field: "author", Prefix: "oct", Quoted: true, Start: 7, End: 11},
+		},
+		{
+			name:     "cursor inside a quoted value replaces through the closing quote",
+			input:    `author:"oc|to" is:unread`,
+			expected: Partial{Field: "author", Prefix: "oc", Quoted: true, Start: 7, End: 13},
+		},
+		{
+			name:     "cursor after a closed quote",
+			input:    `author:"octo"`,
+			expected: Partial{Field: "author", Prefix: "octo", Quoted: true, Start: 7, End: 13},
+		},
+		{
+			name:     "cursor mid-word replaces the whole word",
+			input:    "org:ac|me is:unread",
+			expected: Partial{Field: "org", Prefix: "ac", Start: 4, End: 8},
+		},
+		{
+			name:     "stray characters are skipped",
+			input:    "repo:cli # org:ac",
+			expected: Partial{Field: "org", Prefix: "ac", Start: 15, End: 17},
+		},
+		{
+			name:     "only text before the cursor counts",
+			input:    "rep|o:cli",
+			expected: Partial{Prefix: "rep", Start: 0, End: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, cursor := tt.input, len(tt.input)
+			if i := strings.Index(input, "|"); i >= 0 {
+				input, cursor = input[:i]+input[i+1:], i
+			}
+			require.Equal(t, tt.expected, ParsePartial(input, cursor))
+		})
+	}
+}
+
+func TestParsePartial_ClampsCursor(t *testing.T) {
+	require.Equal(t, Partial{Field: "repo", Prefix: "cli", Start: 5, End: 8}, ParsePartial("repo:cli", 99))
+	require.Equal(t, Partial{}, ParsePartial("repo:cli", -1))
+}
+
+func TestPartialLexer_ToleratesIncompleteInput(t *testing.T) {
+	tokens, err := NewPartialLexer(`title:"half # open`).Tokenize()
+	require.NoError(t, err)
+	require.Len(t, tokens, 4)
+	require.Equal(t, TokenValue, tokens[2].Type)
+	require.Equal(t, "half # open", tokens[2].Value)
+
+	_, err = NewLexer(`title:"half`).Tokenize()
+	require.ErrorIs(t, err, ErrUnterminatedQuotedString)
+}
@@ -40,6 +40,7 @@ type Token struct {
 	Type  TokenType
 	Value string
 	Pos   int // Position in original string
+	End   int // Position just past the token, in the same units as Pos
 }
 
 // String returns a string representation of the token type
@@ -18,7 +18,11 @@ package parse
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Error definitions
@@ -100,8 +104,24 @@ func (v *Validator) validateTerm(node *Term) {
 		v.validateInValues(node.Values)
 	case "is":
 		v.validateIsValues(node.Values)
-	case "read", "archived", "muted", "snoozed", "filtered":
+	case "read", "archived", "muted", "snoozed", "starred", "filtered", "draft", "mergeable":
 		v.validateBooleanValues(field, node.Values)
+	case "kind":
+		v.validateKindValues(node.Values)
+	case "awaiting":
+		v.validateAwaitingValues(node.Values)
+	case "review":
+		v.validateReviewValues(node.Values)
+	case "ci":
+		v.validateCIValues(node.Values)
+	case "waiting-on":
+		v.validateWaitingOnValues(node.Values)
+	case "team-review-requested":
+		v.validateTeamValues(node.Values)
+	case "tagged":
+		v.validateTaggedValues(node.Values)
+	case "priority":
+		v.validatePriorityValues(node.Values)
 	}
 }
 
@@ -111,6 +131,7 @@ func (v *Validator) validateInValues(values []string) {
 		"inbox":    true,
 		"archive":  true,
 		"snoozed":  true,
+		"starred":  true,
 		"filtered": true,
 		"anywhere": true,
 	}
@@ -121,7 +142,7 @@ func (v *Validator) validateInValues(values []string) {
 			v.errors = append(
 				v.errors,
 				fmt.Sprintf(
-					"invalid value for in: operator: %s (valid: inbox, archive, snoozed, filtered, anywhere)",
+					"invalid value for in: operator: %s (valid: inbox, archive, snoozed, starred, filtered, anywhere)",
 					value,
 				),
 			)
@@ -139,22 +160,231 @@ func (v *Validator) validateIsValues(values []string) {
 		"snoozed":  true,
 		"starred":  true,
 		"filtered": true,
+		"system":   true,
+	}
+
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !validValues[value] {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf(
+					"invalid value for is: operator: %s "+
+						"(valid: unread, read, archived, muted, snoozed, starred, filtered, system)",
+					value,
+				),
+			)
+		}
+	}
+}
+
+// validateKindValues validates values for the kind: field
+func (v *Validator) validateKindValues(values []string) {
+	validValues := map[string]bool{
+		"code":       true,
+		"discussion": true,
+		"other":      true,
 	}
 
 	for _, value := range values {
 		value = strings.ToLower(strings.TrimSpace(value))
 		if !validValues[value] {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for kind: %s (valid: code, discussion, other)", value),
+			)
+		}
+	}
+}
+
+// validateAwaitingValues validates values for the awaiting: field
+func (v *Validator) validateAwaitingValues(values []string) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "reply" {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for awaiting: %s (valid: reply)", value),
+			)
+		}
+	}
+}
+
+// reviewStates lists the values of the review: field
+var reviewStates = []string{"approved", "changes_requested", "pending"}
+
+// ValidReviewState reports whether a lowercased value is a review state the review: field
+// accepts
+func ValidReviewState(value string) bool {
+	for _, state := range reviewStates {
+		if value == state {
+			return true
+		}
+	}
+	return false
+}
+
+// validateReviewValues validates values for the review: field
+func (v *Validator) validateReviewValues(values []string) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !ValidReviewState(value) {
 			v.errors = append(
 				v.errors,
 				fmt.Sprintf(
-					"invalid value for is: operator: %s (valid: unread, read, archived, muted, snoozed, starred, filtered)",
+					"invalid value for review: %s (valid: %s)",
 					value,
+					strings.Join(reviewStates, ", "),
 				),
 			)
 		}
 	}
 }
 
+// ciValues lists the values of the ci: field as shown in errors
+var ciValues = []string{"failing", "passing", "pending"}
+
+// ciStates maps each value the ci: field accepts to the CI state it matches. GitHub's own
+// state names are accepted alongside the friendlier ones.
+var ciStates = map[string]string{
+	"failing": "failure",
+	"failure": "failure",
+	"passing": "success",
+	"success": "success",
+	"pending": "pending",
+}
+
+// CIStateFor returns the stored CI state a lowercased ci: value matches, and whether the
+// value is one the field accepts
+func CIStateFor(value string) (string, bool) {
+	state, ok := ciStates[value]
+	return state, ok
+}
+
+// validateCIValues validates values for the ci: field
+func (v *Validator) validateCIValues(values []string) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if _, ok := CIStateFor(value); !ok {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for ci: %s (valid: %s)", value, strings.Join(ciValues, ", ")),
+			)
+		}
+	}
+}
+
+// validateWaitingOnValues validates values for the waiting-on: field
+func (v *Validator) validateWaitingOnValues(values []string) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "me" && value != "them" {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for waiting-on: %s (valid: me, them)", value),
+			)
+		}
+	}
+}
+
+// validateTeamValues validates values for the team-review-requested: field
+func (v *Validator) validateTeamValues(values []string) {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		org, team, ok := strings.Cut(value, "/")
+		if !ok || org == "" || team == "" || strings.Contains(team, "/") {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for team-review-requested: %s (expected org/team)", value),
+			)
+		}
+	}
+}
+
+// agePattern matches a tagged: value such as "<7d", ">2w" or "12h"
+var agePattern = regexp.MustCompile(`^([<>]?)([1-9][0-9]{0,5})([mhdw])$`)
+
+var ageUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// Age is a parsed tagged: value. "<7d" and "7d" mean within the last 7 days; ">7d" means
+// more than 7 days ago.
+type Age struct {
+	Older    bool
+	Duration time.Duration
+}
+
+// ParseAge parses a tagged: value, reporting false when it isn't an age
+func ParseAge(value string) (Age, bool) {
+	match := agePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return Age{}, false
+	}
+	count, err := strconv.Atoi(match[2])
+	if err != nil {
+		return Age{}, false
+	}
+	return Age{
+		Older:    match[1] == ">",
+		Duration: time.Duration(count) * ageUnits[match[3]],
+	}, true
+}
+
+// validateTaggedValues validates values for the tagged: field
+func (v *Validator) validateTaggedValues(values []string) {
+	for _, value := range values {
+		if _, ok := ParseAge(value); !ok {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for tagged: %s (expected an age such as <7d, >2w or <12h)", value),
+			)
+		}
+	}
+}
+
+// priorityPattern matches a priority: value such as ">20", "<0" or "15"
+var priorityPattern = regexp.MustCompile(`^([<>]?)(-?[0-9]{1,6})$`)
+
+// PriorityComparison is a parsed priority: value. ">20" matches scores above 20, "<20"
+// scores below it and "20" a score of exactly 20.
+type PriorityComparison struct {
+	Operator string // "=", ">" or "<"
+	Score    int64
+}
+
+// ParsePriority parses a priority: value, reporting false when it isn't a comparison
+func ParsePriority(value string) (PriorityComparison, bool) {
+	match := priorityPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return PriorityComparison{}, false
+	}
+	score, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return PriorityComparison{}, false
+	}
+	operator := match[1]
+	if operator == "" {
+		operator = "="
+	}
+	return PriorityComparison{Operator: operator, Score: score}, true
+}
+
+// validatePriorityValues validates values for the priority: field
+func (v *Validator) validatePriorityValues(values []string) {
+	for _, value := range values {
+		if _, ok := ParsePriority(value); !ok {
+			v.errors = append(
+				v.errors,
+				fmt.Sprintf("invalid value for priority: %s (expected a score such as >20, <5 or 10)", value),
+			)
+		}
+	}
+}
+
 // validateBooleanValues validates boolean values
 func (v *Validator) validateBooleanValues(field string, values []string) {
 	validValues := map[string]bool{
@@ -181,27 +411,56 @@ func (v *Validator) validateBooleanValues(field string, values []string) {
 	}
 }
 
+// knownFields lists the supported field names
+var knownFields = map[string]bool{
+	"in":                    true,
+	"is":                    true,
+	"repo":                  true,
+	"repository":            true,
+	"org":                   true,
+	"reason":                true,
+	"type":                  true,
+	"subject_type":          true,
+	"author":                true,
+	"title":                 true,
+	"body":                  true,
+	"state":                 true,
+	"read":                  true,
+	"archived":              true,
+	"muted":                 true,
+	"snoozed":               true,
+	"starred":               true,
+	"filtered":              true,
+	"tags":                  true,
+	"kind":                  true,
+	"branch":                true,
+	"draft":                 true,
+	"mergeable":             true,
+	"label":                 true,
+	"milestone":             true,
+	"project":               true,
+	"review":                true,
+	"ci":                    true,
+	"reviewer":              true,
+	"team-review-requested": true,
+	"account":               true,
+	"awaiting":              true,
+	"waiting-on":            true,
+	"tagged":                true,
+	"priority":              true,
+}
+
 // isKnownField checks if a field name is supported
 func isKnownField(field string) bool {
-	knownFields := map[string]bool{
-		"in":           true,
-		"is":           true,
-		"repo":         true,
-		"repository":   true,
-		"org":          true,
-		"reason":       true,
-		"type":         true,
-		"subject_type": true,
-		"author":       true,
-		"title":        true,
-		"state":        true,
-		"read":         true,
-		"archived":     true,
-		"muted":        true,
-		"snoozed":      true,
-		"filtered":     true,
-		"tags":         true,
-	}
-
 	return knownFields[field]
 }
+
+// KnownFields returns the supported field names in alphabetical order
+func KnownFields() []string {
+	fields := make([]string, 0, len(knownFields))
+	for field := range knownFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
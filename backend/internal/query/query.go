@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/query/parse"
 	"github.com/octobud-hq/octobud/backend/internal/query/sql"
 )
@@ -62,7 +63,7 @@ func ParseAndValidate(queryStr string) (Node, error) {
 
 // BuildQuery parses a query string and generates SQL with explicit query context:
 // - Empty query "" → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
-// - Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
+// - Query with in: operator (any value, not negated) → No defaults (in: explicitly handles lifecycle)
 // - Query without in: operator (non-empty) → Apply muted-only default (exclude muted unless explicitly requested)
 func BuildQuery(queryStr string, limit, offset int32) (db.NotificationQuery, error) {
 	return BuildQueryWithOptions(queryStr, limit, offset, true)
@@ -97,29 +98,67 @@ func BuildQueryWithOptions(
 
 // applyUnifiedDefaults applies default filters based on explicit query context:
 // - Empty query → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
-// - Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
+// - Query with in: operator (any value, not negated) → No defaults (in: explicitly handles lifecycle)
 // - Query without in: operator (non-empty) → Apply muted-only default (exclude muted unless explicitly requested)
 func applyUnifiedDefaults(
 	query db.NotificationQuery,
 	ast Node,
 	queryStr string,
 ) db.NotificationQuery {
+	switch explainDefaults(ast, queryStr).Scope {
+	case models.QueryDefaultScopeInbox:
+		return ApplyInboxDefaults(query)
+	case models.QueryDefaultScopeMutedOnly:
+		return ApplyMutedOnlyDefaults(query)
+	default:
+		return query
+	}
+}
+
+// ExplainDefaults reports which implicit filters BuildQuery adds to a query, and why
+func ExplainDefaults(queryStr string) (models.QueryDefaults, error) {
+	ast, err := ParseAndValidate(queryStr)
+	if err != nil {
+		return models.QueryDefaults{}, err
+	}
+	return explainDefaults(ast, queryStr), nil
+}
+
+// explainDefaults picks the default scope for a parsed query; applyUnifiedDefaults applies it
+func explainDefaults(ast Node, queryStr string) models.QueryDefaults {
 	// 1. Empty query → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
 	if queryStr == "" || ast == nil {
-		return ApplyInboxDefaults(query)
+		return models.QueryDefaults{
+			Scope:    models.QueryDefaultScopeInbox,
+			Excluded: []string{"archived", "snoozed", "muted", "filtered"},
+			Reason:   "an empty query shows the inbox",
+		}
 	}
 
 	// 2. Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
-	// This includes in:inbox, in:archive, in:snoozed, in:filtered, in:anywhere, etc.
-	if parse.HasInOperator(ast) {
-		return query
+	// This includes in:inbox, in:archive, in:snoozed, in:starred, in:filtered, in:anywhere, etc.
+	// A negated in: (e.g. -in:archive) only removes a scope, so it falls through to the muted default
+	if parse.HasScopeOverride(ast) {
+		return models.QueryDefaults{
+			Scope:    models.QueryDefaultScopeNone,
+			Excluded: []string{},
+			Reason:   "the query sets its scope with in:",
+		}
 	}
 
 	// 3. Query without in: operator (non-empty) → Apply muted-only default
 	// This allows archived, snoozed, and filtered to show unless explicitly excluded
 	// BUT: if query explicitly asks for muted (is:muted or muted:true), don't apply the default
 	if parse.HasExplicitMuted(ast) {
-		return query
+		return models.QueryDefaults{
+			Scope:    models.QueryDefaultScopeNone,
+			Excluded: []string{},
+			Reason:   "the query asks for muted notifications",
+		}
+	}
+	return models.QueryDefaults{
+		Scope:    models.QueryDefaultScopeMutedOnly,
+		Excluded: []string{"muted"},
+		Reason:   "muted notifications are hidden unless the query uses in: or is:muted",
 	}
-	return ApplyMutedOnlyDefaults(query)
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	"github.com/octobud-hq/octobud/backend/internal/query/parse"
@@ -34,6 +35,9 @@ const (
 	queryValueYes      = "yes"
 	// sqliteNowFunc is the SQLite function to get current time in ISO 8601 format
 	sqliteNowFunc = "strftime('%Y-%m-%dT%H:%M:%SZ', 'now')"
+	// searchMatchFormat matches notifications through the notification_search full-text
+	// index, whose rowid is the notification's id
+	searchMatchFormat = "n.id IN (SELECT rowid FROM notification_search WHERE notification_search MATCH %s)"
 )
 
 // Error definitions
@@ -45,7 +49,14 @@ var (
 	ErrInvalidBooleanValue    = errors.New("invalid boolean value")
 	ErrInvalidSnoozedValue    = errors.New("invalid boolean value for snoozed")
 	ErrInvalidMergedValue     = errors.New("invalid value for merged field")
+	ErrInvalidKindValue       = errors.New("invalid value for kind field")
+	ErrInvalidAwaitingValue   = errors.New("invalid value for awaiting field")
+	ErrInvalidReviewValue     = errors.New("invalid value for review field")
+	ErrInvalidCIValue         = errors.New("invalid value for ci field")
+	ErrInvalidWaitingOnValue  = errors.New("invalid value for waiting-on field")
 	ErrTagsFieldRequiresValue = errors.New("tags field requires at least one value")
+	ErrInvalidTaggedValue     = errors.New("invalid value for tagged field")
+	ErrInvalidPriorityValue   = errors.New("invalid value for priority field")
 )
 
 // Builder builds SQL queries from AST nodes
@@ -170,12 +181,42 @@ func (b *Builder) visitTerm(node *parse.Term) (string, error) {
 		return b.handleAuthorField(node.Values)
 	case "title":
 		return b.handleTitleField(node.Values)
+	case "body":
+		return b.handleBodyField(node.Values)
 	case "state":
 		return b.handleStateField(node.Values)
 	case "merged":
 		return b.handleMergedField(node.Values)
 	case "state_reason":
 		return b.handleStateReasonField(node.Values)
+	case "kind":
+		return b.handleKindField(node.Values)
+	case "branch":
+		return b.handleBranchField(node.Values)
+	case "draft":
+		return b.handleDraftField(node.Values)
+	case "mergeable":
+		return b.handleMergeableField(node.Values)
+	case "label":
+		return b.handleLabelField(node.Values)
+	case "milestone":
+		return b.handleMilestoneField(node.Values)
+	case "project":
+		return b.handleProjectField(node.Values)
+	case "review":
+		return b.handleReviewField(node.Values)
+	case "ci":
+		return b.handleCIField(node.Values)
+	case "waiting-on":
+		return b.handleWaitingOnField(node.Values)
+	case "reviewer":
+		return b.handleReviewerField(node.Values)
+	case "team-review-requested":
+		return b.handleTeamReviewRequestedField(node.Values)
+	case "awaiting":
+		return b.handleAwaitingField(node.Values)
+	case "account":
+		return b.handleAccountField(node.Values)
 	case "read":
 		return b.handleReadField(node.Values)
 	case "archived":
@@ -184,16 +225,23 @@ func (b *Builder) visitTerm(node *parse.Term) (string, error) {
 		return b.handleMutedField(node.Values)
 	case queryValueSnoozed:
 		return b.handleSnoozedField(node.Values)
+	case "starred":
+		return b.handleStarredField(node.Values)
 	case queryValueFiltered:
 		return b.handleFilteredField(node.Values)
 	case "tags":
 		return b.handleTagsField(node.Values)
+	case "tagged":
+		return b.handleTaggedField(node.Values)
+	case "priority":
+		return b.handlePriorityField(node.Values)
 	default:
 		return "", errors.Join(ErrUnsupportedField, fmt.Errorf("field: %s", field))
 	}
 }
 
-// visitFreeText handles free text search
+// visitFreeText handles free text search. Besides the LIKE matches on short columns, it
+// searches subject descriptions and latest comments through the full-text index.
 func (b *Builder) visitFreeText(node *parse.FreeText) (string, error) {
 	b.requireRepoJoin()
 	pattern := "%" + node.Text + "%"
@@ -205,16 +253,21 @@ func (b *Builder) visitFreeText(node *parse.FreeText) (string, error) {
 	placeholder5 := b.addArg(pattern)
 	placeholder6 := b.addArg(pattern)
 
-	return fmt.Sprintf(
-		"(n.subject_title LIKE %s OR n.subject_type LIKE %s OR r.full_name LIKE %s OR "+
-			"n.author_login LIKE %s OR n.subject_state LIKE %s OR CAST(n.subject_number AS TEXT) LIKE %s)",
+	condition := fmt.Sprintf(
+		"n.subject_title LIKE %s OR n.subject_type LIKE %s OR r.full_name LIKE %s OR "+
+			"n.author_login LIKE %s OR n.subject_state LIKE %s OR CAST(n.subject_number AS TEXT) LIKE %s",
 		placeholder1,
 		placeholder2,
 		placeholder3,
 		placeholder4,
 		placeholder5,
 		placeholder6,
-	), nil
+	)
+	if match := bodySearchMatch(node.Text); match != "" {
+		condition += " OR " + fmt.Sprintf(searchMatchFormat, b.addArg(match))
+	}
+
+	return "(" + condition + ")", nil
 }
 
 // Field handler methods
@@ -224,6 +277,7 @@ func (b *Builder) handleInOperator(values []string) (string, error) {
 	// in:inbox - exclude archived, snoozed, muted, filtered
 	// in:archive - show only archived (exclude muted)
 	// in:snoozed - show only snoozed (exclude archived, muted)
+	// in:starred - show starred wherever they are (exclude muted)
 	// in:filtered - exclude snoozed, archived, muted
 	// in:anywhere - show all (no lifecycle filters)
 
@@ -253,6 +307,8 @@ func (b *Builder) handleInOperator(values []string) (string, error) {
 					nowFunc,
 				),
 			)
+		case "starred":
+			conditions = append(conditions, "(n.starred = 1 AND n.muted = 0)")
 		case queryValueFiltered:
 			conditions = append(
 				conditions,
@@ -302,6 +358,9 @@ func (b *Builder) handleIsOperator(values []string) (string, error) {
 			conditions = append(conditions, "n.starred = 1")
 		case queryValueFiltered:
 			conditions = append(conditions, "n.filtered = 1")
+		case "system":
+			placeholder := b.addArg(db.ReasonSystem)
+			conditions = append(conditions, fmt.Sprintf("n.reason = %s", placeholder))
 		default:
 			return "", errors.Join(ErrInvalidIsOperatorValue, fmt.Errorf("value: %s", value))
 		}
@@ -315,7 +374,15 @@ func (b *Builder) handleIsOperator(values []string) (string, error) {
 
 func (b *Builder) handleRepoField(values []string) (string, error) {
 	b.requireRepoJoin()
-	return b.buildStringFilter("r.full_name", values), nil
+	var conditions []string
+	for _, value := range values {
+		conditions = append(conditions, b.buildRepoNameMatch("%"+value+"%"))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
 func (b *Builder) handleOrgField(values []string) (string, error) {
@@ -323,9 +390,7 @@ func (b *Builder) handleOrgField(values []string) (string, error) {
 	// Org is prefix matching: org:cli matches cli/*
 	var conditions []string
 	for _, value := range values {
-		pattern := value + "/%"
-		placeholder := b.addArg(pattern)
-		conditions = append(conditions, fmt.Sprintf("r.full_name LIKE %s", placeholder))
+		conditions = append(conditions, b.buildRepoNameMatch(value+"/%"))
 	}
 
 	if len(conditions) == 1 {
@@ -391,6 +456,234 @@ func (b *Builder) handleStateReasonField(values []string) (string, error) {
 	return b.buildStringFilter("n.subject_state_reason", values), nil
 }
 
+func (b *Builder) handleKindField(values []string) (string, error) {
+	// Kind is stored in content_kind column (derived from subject_type at sync)
+	var conditions []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !db.ValidContentKind(value) {
+			return "", errors.Join(ErrInvalidKindValue, fmt.Errorf("value: %s", value))
+		}
+		placeholder := b.addArg(value)
+		conditions = append(conditions, fmt.Sprintf("n.content_kind = %s", placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleAwaitingField(values []string) (string, error) {
+	// Threads are classified as awaiting a reply by a periodic job, using the user's
+	// sync settings, so the filter only reads the stored flag
+	for _, value := range values {
+		if strings.ToLower(strings.TrimSpace(value)) != "reply" {
+			return "", errors.Join(ErrInvalidAwaitingValue, fmt.Errorf("value: %s", value))
+		}
+	}
+	return "n.awaiting_reply = 1", nil
+}
+
+func (b *Builder) handleBranchField(values []string) (string, error) {
+	// Branches are stored in head_branch/base_branch (extracted from subject_raw)
+	// Only applies to Pull Requests; a value matches either branch. Missing branches
+	// count as no match so negated filters keep notifications without branches, like
+	// the rule evaluator does.
+	var conditions []string
+	for _, value := range values {
+		pattern := globToLike(strings.TrimSpace(value))
+		headPlaceholder := b.addArg(pattern)
+		basePlaceholder := b.addArg(pattern)
+		conditions = append(conditions, fmt.Sprintf(
+			"(COALESCE(n.head_branch LIKE %s ESCAPE '\\', 0) OR "+
+				"COALESCE(n.base_branch LIKE %s ESCAPE '\\', 0))",
+			headPlaceholder,
+			basePlaceholder,
+		))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleDraftField(values []string) (string, error) {
+	// Draft is stored in subject_draft (extracted from subject_raw)
+	// Only applies to Pull Requests, so other notifications match neither value
+	return b.buildNullableBoolFilter("n.subject_draft", values)
+}
+
+func (b *Builder) handleMergeableField(values []string) (string, error) {
+	// Mergeability is stored in subject_mergeable (extracted from subject_raw)
+	// Only known for open Pull Requests, so other notifications match neither value
+	return b.buildNullableBoolFilter("n.subject_mergeable", values)
+}
+
+// buildNullableBoolFilter matches a boolean column that is NULL when it doesn't apply;
+// NULL rows match neither true nor false
+func (b *Builder) buildNullableBoolFilter(column string, values []string) (string, error) {
+	var conditions []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch value {
+		case queryValueTrue, queryValueYes, "1":
+			conditions = append(conditions, fmt.Sprintf("COALESCE(%s = 1, 0)", column))
+		case queryValueFalse, "no", "0":
+			conditions = append(conditions, fmt.Sprintf("COALESCE(%s = 0, 0)", column))
+		default:
+			return "", errors.Join(ErrInvalidBooleanValue, fmt.Errorf("value: %s", value))
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleLabelField(values []string) (string, error) {
+	// Labels are stored in subject_labels as a JSON array of names (extracted from
+	// subject_raw); names are matched exactly but case-insensitively like on GitHub
+	return b.buildNameListFilter("n.subject_labels", values), nil
+}
+
+func (b *Builder) handleMilestoneField(values []string) (string, error) {
+	// The milestone title is stored in subject_milestone (extracted from subject_raw);
+	// titles are matched exactly but case-insensitively, and notifications without a
+	// milestone match no value
+	var conditions []string
+	for _, value := range values {
+		placeholder := b.addArg(strings.TrimSpace(value))
+		conditions = append(conditions, fmt.Sprintf(
+			"COALESCE(n.subject_milestone = %s COLLATE NOCASE, 0)",
+			placeholder,
+		))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleProjectField(values []string) (string, error) {
+	// Projects are looked up at sync and stored in subject_projects as a JSON array of
+	// titles; titles are matched exactly but case-insensitively
+	return b.buildNameListFilter("n.subject_projects", values), nil
+}
+
+func (b *Builder) handleReviewField(values []string) (string, error) {
+	// Review state is worked out from the reviews of open Pull Requests at sync and
+	// stored in review_state; notifications without one match no value
+	var conditions []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !parse.ValidReviewState(value) {
+			return "", errors.Join(ErrInvalidReviewValue, fmt.Errorf("value: %s", value))
+		}
+		placeholder := b.addArg(value)
+		conditions = append(conditions, fmt.Sprintf("COALESCE(n.review_state = %s, 0)", placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleCIField(values []string) (string, error) {
+	// CI state is worked out from the checks on open Pull Requests' head commits at sync
+	// and stored in ci_state; notifications without one match no value
+	var conditions []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		state, ok := parse.CIStateFor(value)
+		if !ok {
+			return "", errors.Join(ErrInvalidCIValue, fmt.Errorf("value: %s", value))
+		}
+		placeholder := b.addArg(state)
+		conditions = append(conditions, fmt.Sprintf("COALESCE(n.ci_state = %s, 0)", placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleWaitingOnField(values []string) (string, error) {
+	// Whose turn it is on an open subject is worked out at sync and stored in waiting_on;
+	// notifications that aren't waiting on anyone in particular match no value
+	var conditions []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "me" && value != "them" {
+			return "", errors.Join(ErrInvalidWaitingOnValue, fmt.Errorf("value: %s", value))
+		}
+		placeholder := b.addArg(value)
+		conditions = append(conditions, fmt.Sprintf("COALESCE(n.waiting_on = %s, 0)", placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handleReviewerField(values []string) (string, error) {
+	// Requested reviewers are stored on the pull request as a JSON array of logins whose
+	// review is still pending; logins are matched exactly but case-insensitively
+	return b.buildRequestedReviewFilter("pr.requested_reviewers", values), nil
+}
+
+func (b *Builder) handleTeamReviewRequestedField(values []string) (string, error) {
+	// Requested teams are stored on the pull request as a JSON array of "org/team" names
+	return b.buildRequestedReviewFilter("pr.requested_teams", values), nil
+}
+
+// buildRequestedReviewFilter matches pull requests whose JSON array column holds any of the
+// values. Notifications without a pull request match nothing.
+func (b *Builder) buildRequestedReviewFilter(column string, values []string) string {
+	b.requirePRJoin()
+	return b.buildNameListFilter(column, values)
+}
+
+// buildNameListFilter matches rows whose JSON array column holds any of the values,
+// compared case-insensitively. A NULL column matches nothing.
+func (b *Builder) buildNameListFilter(column string, values []string) string {
+	var conditions []string
+	for _, value := range values {
+		placeholder := b.addArg(strings.TrimSpace(value))
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value = %s COLLATE NOCASE)",
+			column,
+			placeholder,
+		))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+func (b *Builder) handleAccountField(values []string) (string, error) {
+	// Account is the login of the GitHub account the notification was synced for;
+	// logins are matched exactly but case-insensitively like on GitHub
+	var conditions []string
+	for _, value := range values {
+		placeholder := b.addArg(strings.TrimSpace(value))
+		conditions = append(conditions, fmt.Sprintf("n.account = %s COLLATE NOCASE", placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
 func (b *Builder) handleReadField(values []string) (string, error) {
 	return b.buildBooleanFilter("n.is_read", values)
 }
@@ -432,6 +725,10 @@ func (b *Builder) handleSnoozedField(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
+func (b *Builder) handleStarredField(values []string) (string, error) {
+	return b.buildBooleanFilter("n.starred", values)
+}
+
 func (b *Builder) handleFilteredField(values []string) (string, error) {
 	return b.buildBooleanFilter("n.filtered", values)
 }
@@ -458,12 +755,101 @@ func (b *Builder) handleTagsField(values []string) (string, error) {
 	), nil
 }
 
+// handleTaggedField matches notifications by when a tag was assigned to them: tagged:<7d
+// for any tag assigned within the last 7 days, tagged:>7d for any assigned before that
+func (b *Builder) handleTaggedField(values []string) (string, error) {
+	if len(values) == 0 {
+		return "", ErrInvalidTaggedValue
+	}
+
+	var conditions []string
+	for _, value := range values {
+		age, ok := parse.ParseAge(value)
+		if !ok {
+			return "", errors.Join(ErrInvalidTaggedValue, fmt.Errorf("value: %s", value))
+		}
+		operator := ">="
+		if age.Older {
+			operator = "<"
+		}
+		modifier := b.addArg(fmt.Sprintf("-%d seconds", int64(age.Duration.Seconds())))
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM tag_assignments ta WHERE ta.entity_type = 'notification' "+
+				"AND ta.entity_id = n.id AND ta.created_at %s strftime('%%Y-%%m-%%dT%%H:%%M:%%SZ', 'now', %s))",
+			operator,
+			modifier,
+		))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (b *Builder) handlePriorityField(values []string) (string, error) {
+	// Scores are worked out at sync and stored in priority; notifications that haven't been
+	// scored yet match no value
+	var conditions []string
+	for _, value := range values {
+		comparison, ok := parse.ParsePriority(value)
+		if !ok {
+			return "", errors.Join(ErrInvalidPriorityValue, fmt.Errorf("value: %s", value))
+		}
+		placeholder := b.addArg(comparison.Score)
+		conditions = append(conditions, fmt.Sprintf(
+			"COALESCE(n.priority %s %s, 0)", comparison.Operator, placeholder,
+		))
+	}
+
+	if len(conditions) == 0 {
+		return "", ErrInvalidPriorityValue
+	}
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
 func (b *Builder) handleTitleField(values []string) (string, error) {
 	return b.buildStringFilter("n.subject_title", values), nil
 }
 
+// handleBodyField matches words in subject descriptions and latest comments, in order,
+// with the last word matched as a prefix
+func (b *Builder) handleBodyField(values []string) (string, error) {
+	var conditions []string
+	for _, value := range values {
+		match := bodySearchMatch(value)
+		if match == "" {
+			// Nothing to search for, such as punctuation only
+			conditions = append(conditions, "0")
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf(searchMatchFormat, b.addArg(match)))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
 // Helper methods
 
+// bodySearchMatch builds an FTS5 query for text in the body and comment columns of
+// notification_search. The text is quoted as a single phrase so FTS5 operators in it are
+// taken literally. It returns "" when the text has no words to match.
+func bodySearchMatch(text string) string {
+	hasWord := strings.ContainsFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	})
+	if !hasWord {
+		return ""
+	}
+	return `{body comment} : "` + strings.ReplaceAll(text, `"`, `""`) + `"*`
+}
+
 func (b *Builder) buildStringFilter(column string, values []string) string {
 	var conditions []string
 	for _, value := range values {
@@ -478,6 +864,19 @@ func (b *Builder) buildStringFilter(column string, values []string) string {
 	return "(" + strings.Join(conditions, " OR ") + ")"
 }
 
+// buildRepoNameMatch matches a repository's current full name or any previous name
+// recorded in repository_aliases, so renamed/transferred repositories match both.
+func (b *Builder) buildRepoNameMatch(pattern string) string {
+	placeholder := b.addArg(pattern)
+	aliasPlaceholder := b.addArg(pattern)
+	return fmt.Sprintf(
+		"(r.full_name LIKE %s OR EXISTS (SELECT 1 FROM repository_aliases ra "+
+			"WHERE ra.repository_id = r.id AND ra.full_name LIKE %s))",
+		placeholder,
+		aliasPlaceholder,
+	)
+}
+
 func (b *Builder) buildBooleanFilter(column string, values []string) (string, error) {
 	var conditions []string
 	for _, value := range values {
@@ -498,6 +897,24 @@ func (b *Builder) buildBooleanFilter(column string, values []string) (string, er
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
+// globToLike converts a branch pattern where * matches any run of characters,
+// including slashes, to a LIKE pattern. LIKE wildcards in the value are escaped.
+func globToLike(pattern string) string {
+	var like strings.Builder
+	for _, ch := range pattern {
+		switch ch {
+		case '*':
+			like.WriteRune('%')
+		case '%', '_', '\\':
+			like.WriteRune('\\')
+			like.WriteRune(ch)
+		default:
+			like.WriteRune(ch)
+		}
+	}
+	return like.String()
+}
+
 func (b *Builder) addArg(arg interface{}) string {
 	b.args = append(b.args, arg)
 	b.argCounter++
@@ -508,9 +925,6 @@ func (b *Builder) requireRepoJoin() {
 	b.joins["LEFT JOIN repositories r ON r.id = n.repository_id"] = true
 }
 
-// requirePRJoin is kept for potential future use
-//
-//nolint:unused // Reserved for future PR-related queries
 func (b *Builder) requirePRJoin() {
 	b.joins["LEFT JOIN pull_requests pr ON pr.id = n.pull_request_id"] = true
 }
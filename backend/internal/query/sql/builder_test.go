@@ -16,6 +16,7 @@
 package sql
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/octobud-hq/octobud/backend/internal/db"
@@ -31,10 +32,11 @@ func TestBuilder_BasicTerms(t *testing.T) {
 		wantJoins int
 	}{
 		{
-			name:      "simple repo term",
-			input:     "repo:cli",
-			wantWhere: "r.full_name LIKE ?",
-			wantArgs:  []interface{}{"%cli%"},
+			name:  "simple repo term",
+			input: "repo:cli",
+			wantWhere: "(r.full_name LIKE ? OR EXISTS (SELECT 1 FROM repository_aliases ra " +
+				"WHERE ra.repository_id = r.id AND ra.full_name LIKE ?))",
+			wantArgs:  []interface{}{"%cli%", "%cli%"},
 			wantJoins: 1,
 		},
 		{
@@ -58,6 +60,190 @@ func TestBuilder_BasicTerms(t *testing.T) {
 			wantArgs:  []interface{}{"%security%"},
 			wantJoins: 0,
 		},
+		{
+			name:      "kind term",
+			input:     "kind:Code",
+			wantWhere: "n.content_kind = ?",
+			wantArgs:  []interface{}{"code"},
+			wantJoins: 0,
+		},
+		{
+			name:      "awaiting reply term",
+			input:     "awaiting:reply",
+			wantWhere: "n.awaiting_reply = 1",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:  "branch glob",
+			input: "branch:release/*",
+			wantWhere: "(COALESCE(n.head_branch LIKE ? ESCAPE '\\', 0) OR " +
+				"COALESCE(n.base_branch LIKE ? ESCAPE '\\', 0))",
+			wantArgs:  []interface{}{"release/%", "release/%"},
+			wantJoins: 0,
+		},
+		{
+			name:  "branch escapes like wildcards",
+			input: `branch:"fix_100%"`,
+			wantWhere: "(COALESCE(n.head_branch LIKE ? ESCAPE '\\', 0) OR " +
+				"COALESCE(n.base_branch LIKE ? ESCAPE '\\', 0))",
+			wantArgs:  []interface{}{`fix\_100\%`, `fix\_100\%`},
+			wantJoins: 0,
+		},
+		{
+			name:      "draft term",
+			input:     "draft:true",
+			wantWhere: "COALESCE(n.subject_draft = 1, 0)",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:      "mergeable term",
+			input:     "mergeable:false",
+			wantWhere: "COALESCE(n.subject_mergeable = 0, 0)",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:      "starred term",
+			input:     "starred:true",
+			wantWhere: "n.starred = 1",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:      "filtered term",
+			input:     "filtered:no",
+			wantWhere: "n.filtered = 0",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:  "label term",
+			input: `label:"needs review"`,
+			wantWhere: "EXISTS (SELECT 1 FROM json_each(n.subject_labels) " +
+				"WHERE json_each.value = ? COLLATE NOCASE)",
+			wantArgs:  []interface{}{"needs review"},
+			wantJoins: 0,
+		},
+		{
+			name:      "milestone term",
+			input:     `milestone:"v2.0"`,
+			wantWhere: "COALESCE(n.subject_milestone = ? COLLATE NOCASE, 0)",
+			wantArgs:  []interface{}{"v2.0"},
+			wantJoins: 0,
+		},
+		{
+			name:  "project term",
+			input: `project:"Q3 Roadmap"`,
+			wantWhere: "EXISTS (SELECT 1 FROM json_each(n.subject_projects) " +
+				"WHERE json_each.value = ? COLLATE NOCASE)",
+			wantArgs:  []interface{}{"Q3 Roadmap"},
+			wantJoins: 0,
+		},
+		{
+			name:  "reviewer term",
+			input: "reviewer:octocat",
+			wantWhere: "EXISTS (SELECT 1 FROM json_each(pr.requested_reviewers) " +
+				"WHERE json_each.value = ? COLLATE NOCASE)",
+			wantArgs:  []interface{}{"octocat"},
+			wantJoins: 1,
+		},
+		{
+			name:  "team review requested term",
+			input: "team-review-requested:acme/core",
+			wantWhere: "EXISTS (SELECT 1 FROM json_each(pr.requested_teams) " +
+				"WHERE json_each.value = ? COLLATE NOCASE)",
+			wantArgs:  []interface{}{"acme/core"},
+			wantJoins: 1,
+		},
+		{
+			name:      "ci term",
+			input:     "ci:Failing,pending",
+			wantWhere: "(COALESCE(n.ci_state = ?, 0) OR COALESCE(n.ci_state = ?, 0))",
+			wantArgs:  []interface{}{"failure", "pending"},
+			wantJoins: 0,
+		},
+		{
+			name:      "waiting-on term",
+			input:     "waiting-on:Me",
+			wantWhere: "COALESCE(n.waiting_on = ?, 0)",
+			wantArgs:  []interface{}{"me"},
+			wantJoins: 0,
+		},
+		{
+			name:      "priority term",
+			input:     "priority:>20",
+			wantWhere: "COALESCE(n.priority > ?, 0)",
+			wantArgs:  []interface{}{int64(20)},
+			wantJoins: 0,
+		},
+		{
+			name:      "priority values",
+			input:     "priority:<-5,0",
+			wantWhere: "(COALESCE(n.priority < ?, 0) OR COALESCE(n.priority = ?, 0))",
+			wantArgs:  []interface{}{int64(-5), int64(0)},
+			wantJoins: 0,
+		},
+		{
+			name:      "review term",
+			input:     "review:Changes_Requested",
+			wantWhere: "COALESCE(n.review_state = ?, 0)",
+			wantArgs:  []interface{}{"changes_requested"},
+			wantJoins: 0,
+		},
+		{
+			name:  "body term",
+			input: `body:"flaky test"`,
+			wantWhere: "n.id IN (SELECT rowid FROM notification_search " +
+				"WHERE notification_search MATCH ?)",
+			wantArgs:  []interface{}{`{body comment} : "flaky test"*`},
+			wantJoins: 0,
+		},
+		{
+			name:  "body term quotes fts operators",
+			input: `body:"say \"NEAR\" OR not"`,
+			wantWhere: "n.id IN (SELECT rowid FROM notification_search " +
+				"WHERE notification_search MATCH ?)",
+			wantArgs:  []interface{}{`{body comment} : "say ""NEAR"" OR not"*`},
+			wantJoins: 0,
+		},
+		{
+			name:      "body term without words",
+			input:     `body:"#"`,
+			wantWhere: "0",
+			wantArgs:  []interface{}{},
+			wantJoins: 0,
+		},
+		{
+			name:      "account",
+			input:     "account:Octocat-Work",
+			wantWhere: "n.account = ? COLLATE NOCASE",
+			wantArgs:  []interface{}{"Octocat-Work"},
+			wantJoins: 0,
+		},
+		{
+			name:  "tagged within",
+			input: "tagged:<7d",
+			wantWhere: "EXISTS (SELECT 1 FROM tag_assignments ta WHERE ta.entity_type = 'notification' " +
+				"AND ta.entity_id = n.id AND ta.created_at >= strftime('%Y-%m-%dT%H:%M:%SZ', 'now', ?))",
+			wantArgs:  []interface{}{"-604800 seconds"},
+			wantJoins: 0,
+		},
+		{
+			name:      "tagged before",
+			input:     "tagged:>12h",
+			wantWhere: "ta.created_at < strftime('%Y-%m-%dT%H:%M:%SZ', 'now', ?)",
+			wantArgs:  []interface{}{"-43200 seconds"},
+			wantJoins: 0,
+		},
+		{
+			name:      "is system",
+			input:     "is:system",
+			wantWhere: "n.reason = ?",
+			wantArgs:  []interface{}{"system"},
+			wantJoins: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -172,6 +358,11 @@ func TestBuilder_InOperator(t *testing.T) {
 				"n.archived = 0",
 			},
 		},
+		{
+			name:         "in:starred",
+			input:        "in:starred",
+			wantContains: []string{"n.starred = 1", "n.muted = 0"},
+		},
 		{
 			name:  "in:filtered",
 			input: "in:filtered",
@@ -363,6 +554,66 @@ func TestBuilder_ComplexQueries(t *testing.T) {
 	}
 }
 
+func TestBuilder_InvalidReviewValue(t *testing.T) {
+	ast, err := parseQuery("review:merged")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, err = NewBuilder().Build(ast)
+	if !errors.Is(err, ErrInvalidReviewValue) {
+		t.Errorf("expected ErrInvalidReviewValue, got %v", err)
+	}
+}
+
+func TestBuilder_InvalidCIValue(t *testing.T) {
+	ast, err := parseQuery("ci:red")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, err = NewBuilder().Build(ast)
+	if !errors.Is(err, ErrInvalidCIValue) {
+		t.Errorf("expected ErrInvalidCIValue, got %v", err)
+	}
+}
+
+func TestBuilder_InvalidWaitingOnValue(t *testing.T) {
+	ast, err := parseQuery("waiting-on:you")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, err = NewBuilder().Build(ast)
+	if !errors.Is(err, ErrInvalidWaitingOnValue) {
+		t.Errorf("expected ErrInvalidWaitingOnValue, got %v", err)
+	}
+}
+
+func TestBuilder_InvalidTaggedValue(t *testing.T) {
+	ast, err := parseQuery("tagged:lastweek")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, err = NewBuilder().Build(ast)
+	if !errors.Is(err, ErrInvalidTaggedValue) {
+		t.Errorf("expected ErrInvalidTaggedValue, got %v", err)
+	}
+}
+
+func TestBuilder_InvalidPriorityValue(t *testing.T) {
+	ast, err := parseQuery("priority:high")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, err = NewBuilder().Build(ast)
+	if !errors.Is(err, ErrInvalidPriorityValue) {
+		t.Errorf("expected ErrInvalidPriorityValue, got %v", err)
+	}
+}
+
 // Note: Tests for default filters (BuildQuery) are in the main query package
 // to avoid circular dependencies. This package only tests the pure SQL builder.
 
@@ -382,6 +633,11 @@ func TestBuilder_Joins(t *testing.T) {
 			input:     "org:github",
 			wantJoins: []string{"repositories"},
 		},
+		{
+			name:      "reviewer requires pull request join",
+			input:     "reviewer:octocat",
+			wantJoins: []string{"pull_requests"},
+		},
 		{
 			name:      "reason doesn't require join",
 			input:     "reason:mention",
@@ -0,0 +1,261 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package recovery detects unclean shutdowns on startup, runs consistency checks
+// on jobs and sync state, repairs obvious issues, and records a startup report.
+package recovery
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+)
+
+// MarkerFileName is the file written to the data directory while the app is running.
+// It is removed on clean shutdown, so finding it on startup means the previous run
+// did not exit cleanly.
+const MarkerFileName = "octobud.running"
+
+// clockSkewTolerance is how far in the future a sync timestamp may be before it is repaired
+const clockSkewTolerance = 5 * time.Minute
+
+// Check statuses
+const (
+	CheckStatusOK       = "ok"
+	CheckStatusRepaired = "repaired"
+	CheckStatusWarning  = "warning"
+)
+
+// Check is the result of a single startup consistency check
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report describes the most recent startup
+type Report struct {
+	StartedAt         time.Time  `json:"startedAt"`
+	UncleanShutdown   bool       `json:"uncleanShutdown"`
+	PreviousStartedAt *time.Time `json:"previousStartedAt,omitempty"`
+	PreviousPID       int        `json:"previousPid,omitempty"`
+	WALRecovered      bool       `json:"walRecovered"`
+	Checks            []Check    `json:"checks"`
+}
+
+// DataLossPossible reports whether the previous run ended uncleanly and any check
+// needed a repair or raised a warning
+func (r *Report) DataLossPossible() bool {
+	if !r.UncleanShutdown {
+		return false
+	}
+	for _, c := range r.Checks {
+		if c.Status != CheckStatusOK {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) addCheck(name, status, detail string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: detail})
+}
+
+// marker is the content of the running marker file
+type marker struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Begin must be called before the database is opened. It detects an unclean shutdown
+// from a leftover marker file or a non-empty SQLite WAL file, then writes a new marker
+// for this run.
+func Begin(dataDir, dbPath string) (*Report, error) {
+	report := &Report{
+		StartedAt: time.Now().UTC(),
+		Checks:    []Check{},
+	}
+
+	markerPath := filepath.Join(dataDir, MarkerFileName)
+	if data, err := os.ReadFile(markerPath); err == nil {
+		report.UncleanShutdown = true
+		var previous marker
+		if json.Unmarshal(data, &previous) == nil {
+			report.PreviousPID = previous.PID
+			if !previous.StartedAt.IsZero() {
+				report.PreviousStartedAt = &previous.StartedAt
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read startup marker: %w", err)
+	}
+
+	// SQLite checkpoints and removes the WAL when the last connection closes cleanly
+	if info, err := os.Stat(dbPath + "-wal"); err == nil && info.Size() > 0 {
+		report.WALRecovered = true
+		report.UncleanShutdown = true
+	}
+
+	data, err := json.Marshal(marker{PID: os.Getpid(), StartedAt: report.StartedAt})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(markerPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write startup marker: %w", err)
+	}
+
+	return report, nil
+}
+
+// End removes the running marker. It should be called on clean shutdown.
+func End(dataDir string) error {
+	err := os.Remove(filepath.Join(dataDir, MarkerFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// RunChecks runs consistency checks after migrations and before the scheduler starts.
+// userID may be empty if no GitHub account is connected, in which case sync state
+// checks are skipped.
+func RunChecks(
+	ctx context.Context,
+	report *Report,
+	dbConn *sql.DB,
+	jobQueue jobs.JobQueue,
+	store db.Store,
+	userID string,
+) {
+	checkIntegrity(ctx, report, dbConn)
+	checkJobs(ctx, report, jobQueue)
+	if userID != "" {
+		checkSyncState(ctx, report, store, userID)
+	}
+}
+
+// checkIntegrity runs SQLite's quick_check
+func checkIntegrity(ctx context.Context, report *Report, dbConn *sql.DB) {
+	var result string
+	if err := dbConn.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		report.addCheck("database_integrity", CheckStatusWarning, err.Error())
+		return
+	}
+	if result != "ok" {
+		report.addCheck("database_integrity", CheckStatusWarning, result)
+		return
+	}
+	report.addCheck("database_integrity", CheckStatusOK, "")
+}
+
+// checkJobs requeues jobs left in "processing" by the previous run. No workers are
+// running yet, so every processing job is stuck regardless of when it started.
+func checkJobs(ctx context.Context, report *Report, jobQueue jobs.JobQueue) {
+	stats, err := jobQueue.AllStats(ctx)
+	if err != nil {
+		report.addCheck("stuck_jobs", CheckStatusWarning, err.Error())
+		return
+	}
+
+	if stats.Processing == 0 {
+		report.addCheck("stuck_jobs", CheckStatusOK, "")
+	} else {
+		// A negative timeout puts the cutoff in the future so every processing job qualifies
+		reset, err := jobQueue.ResetStale(ctx, -time.Minute)
+		if err != nil {
+			report.addCheck("stuck_jobs", CheckStatusWarning, err.Error())
+		} else {
+			report.addCheck(
+				"stuck_jobs",
+				CheckStatusRepaired,
+				fmt.Sprintf("requeued %d interrupted job(s)", reset),
+			)
+		}
+	}
+
+	if stats.Failed > 0 {
+		report.addCheck(
+			"failed_jobs",
+			CheckStatusWarning,
+			fmt.Sprintf("%d job(s) failed permanently", stats.Failed),
+		)
+	} else {
+		report.addCheck("failed_jobs", CheckStatusOK, "")
+	}
+}
+
+// checkSyncState clamps sync timestamps that are in the future. A future
+// latest-notification time would make incremental sync skip new notifications.
+func checkSyncState(ctx context.Context, report *Report, store db.Store, userID string) {
+	state, err := store.GetSyncState(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			report.addCheck("sync_state", CheckStatusOK, "no sync state yet")
+			return
+		}
+		report.addCheck("sync_state", CheckStatusWarning, err.Error())
+		return
+	}
+
+	limit := report.StartedAt.Add(clockSkewTolerance)
+	repaired := false
+	if state.LastSuccessfulPoll.Valid && state.LastSuccessfulPoll.Time.After(limit) {
+		state.LastSuccessfulPoll.Time = report.StartedAt
+		repaired = true
+	}
+	if state.LatestNotificationAt.Valid && state.LatestNotificationAt.Time.After(limit) {
+		state.LatestNotificationAt.Time = report.StartedAt
+		repaired = true
+	}
+
+	if repaired {
+		_, err := store.UpsertSyncState(ctx, userID, db.UpsertSyncStateParams{
+			LastSuccessfulPoll:         state.LastSuccessfulPoll,
+			LastNotificationEtag:       state.LastNotificationEtag,
+			LatestNotificationAt:       state.LatestNotificationAt,
+			InitialSyncCompletedAt:     state.InitialSyncCompletedAt,
+			OldestNotificationSyncedAt: state.OldestNotificationSyncedAt,
+		})
+		if err != nil {
+			report.addCheck("sync_state", CheckStatusWarning, err.Error())
+			return
+		}
+		report.addCheck(
+			"sync_state",
+			CheckStatusRepaired,
+			"reset sync timestamps that were in the future",
+		)
+		return
+	}
+
+	if report.UncleanShutdown && !state.InitialSyncCompletedAt.Valid {
+		report.addCheck(
+			"sync_state",
+			CheckStatusWarning,
+			"initial sync was interrupted and will continue on the next sync",
+		)
+		return
+	}
+
+	report.addCheck("sync_state", CheckStatusOK, "")
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package recovery_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+	"github.com/octobud-hq/octobud/backend/internal/db/mocks"
+	"github.com/octobud-hq/octobud/backend/internal/jobs"
+	"github.com/octobud-hq/octobud/backend/internal/recovery"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestBegin_CleanStartup(t *testing.T) {
+	dataDir := t.TempDir()
+
+	report, err := recovery.Begin(dataDir, filepath.Join(dataDir, "octobud.db"))
+	require.NoError(t, err)
+	require.False(t, report.UncleanShutdown)
+	require.False(t, report.WALRecovered)
+	require.FileExists(t, filepath.Join(dataDir, recovery.MarkerFileName))
+
+	require.NoError(t, recovery.End(dataDir))
+	require.NoFileExists(t, filepath.Join(dataDir, recovery.MarkerFileName))
+}
+
+func TestBegin_LeftoverMarker(t *testing.T) {
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "octobud.db")
+
+	// Previous run never called End
+	_, err := recovery.Begin(dataDir, dbPath)
+	require.NoError(t, err)
+
+	report, err := recovery.Begin(dataDir, dbPath)
+	require.NoError(t, err)
+	require.True(t, report.UncleanShutdown)
+	require.Equal(t, os.Getpid(), report.PreviousPID)
+	require.NotNil(t, report.PreviousStartedAt)
+}
+
+func TestBegin_NonEmptyWAL(t *testing.T) {
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "octobud.db")
+	require.NoError(t, os.WriteFile(dbPath+"-wal", []byte("frames"), 0o600))
+
+	report, err := recovery.Begin(dataDir, dbPath)
+	require.NoError(t, err)
+	require.True(t, report.UncleanShutdown)
+	require.True(t, report.WALRecovered)
+}
+
+func setupJobsDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "jobs.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = dbConn.Close() })
+
+	_, err = dbConn.Exec(`
+		CREATE TABLE jobs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			queue TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			max_attempts INTEGER NOT NULL DEFAULT 5,
+			created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			scheduled_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			started_at TEXT,
+			completed_at TEXT,
+			last_error TEXT
+		)`)
+	require.NoError(t, err)
+	return dbConn
+}
+
+func findCheck(t *testing.T, report *recovery.Report, name string) recovery.Check {
+	t.Helper()
+	for _, c := range report.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return recovery.Check{}
+}
+
+func TestRunChecks_RepairsStuckJobsAndFutureSyncState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn := setupJobsDB(t)
+	_, err := dbConn.Exec(`
+		INSERT INTO jobs (queue, payload, status, started_at)
+		VALUES ('process_notification', '{}', 'processing', strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))`)
+	require.NoError(t, err)
+
+	future := time.Now().UTC().Add(48 * time.Hour)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		GetSyncState(gomock.Any(), "test-user-id").
+		Return(db.GetSyncStateRow{
+			LatestNotificationAt:   sql.NullTime{Time: future, Valid: true},
+			InitialSyncCompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}, nil)
+	mockStore.EXPECT().
+		UpsertSyncState(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			params db.UpsertSyncStateParams,
+		) (db.UpsertSyncStateRow, error) {
+			require.True(t, params.LatestNotificationAt.Time.Before(future))
+			require.True(t, params.InitialSyncCompletedAt.Valid)
+			return db.UpsertSyncStateRow{}, nil
+		})
+
+	report := &recovery.Report{StartedAt: time.Now().UTC(), UncleanShutdown: true}
+	recovery.RunChecks(
+		context.Background(),
+		report,
+		dbConn,
+		jobs.NewSQLiteJobQueue(dbConn),
+		mockStore,
+		"test-user-id",
+	)
+
+	require.Equal(t, recovery.CheckStatusOK, findCheck(t, report, "database_integrity").Status)
+	require.Equal(t, recovery.CheckStatusRepaired, findCheck(t, report, "stuck_jobs").Status)
+	require.Equal(t, recovery.CheckStatusOK, findCheck(t, report, "failed_jobs").Status)
+	require.Equal(t, recovery.CheckStatusRepaired, findCheck(t, report, "sync_state").Status)
+	require.True(t, report.DataLossPossible())
+
+	var status string
+	require.NoError(t, dbConn.QueryRow("SELECT status FROM jobs").Scan(&status))
+	require.Equal(t, "pending", status)
+}
+
+func TestRunChecks_CleanState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn := setupJobsDB(t)
+	mockStore := mocks.NewMockStore(ctrl)
+
+	// No user connected yet, so sync state is not checked
+	report := &recovery.Report{StartedAt: time.Now().UTC()}
+	recovery.RunChecks(
+		context.Background(),
+		report,
+		dbConn,
+		jobs.NewSQLiteJobQueue(dbConn),
+		mockStore,
+		"",
+	)
+
+	for _, c := range report.Checks {
+		require.Equal(t, recovery.CheckStatusOK, c.Status, c.Name)
+	}
+	require.False(t, report.DataLossPossible())
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sanitize
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ImageProxyPath is where Octobud serves images from GitHub content
+const ImageProxyPath = "/api/integrations/images"
+
+// imageKind says how an image in a body is shown
+type imageKind int
+
+const (
+	// imageLinked images are from other hosts and are shown as links instead
+	imageLinked imageKind = iota
+	// imageProxied images are fetched through the image proxy
+	imageProxied
+	// imageAttachment images need a GitHub session, so they're left for the frontend to
+	// replace with a link to GitHub
+	imageAttachment
+	// imageDropped images have no usable URL
+	imageDropped
+)
+
+// ProxyableImage reports whether the image proxy may fetch a URL. Only public GitHub image
+// hosts are allowed, so the proxy can't be used to reach other servers.
+func ProxyableImage(u *url.URL) bool {
+	if u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "github.com":
+		return !isAttachment(u)
+	case host == "private-user-images.githubusercontent.com":
+		return false
+	case strings.HasSuffix(host, ".githubusercontent.com"),
+		host == "dependabot-badges.githubapp.com":
+		return true
+	}
+	return false
+}
+
+// ProxyURL returns the image proxy URL that serves an image
+func ProxyURL(src string) string {
+	return ImageProxyPath + "?url=" + url.QueryEscape(src)
+}
+
+// classifyImage decides how an image URL from a body is shown
+func classifyImage(src string) imageKind {
+	if !safeURL(src) {
+		return imageDropped
+	}
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil || !u.IsAbs() {
+		return imageDropped
+	}
+	switch {
+	case ProxyableImage(u):
+		return imageProxied
+	case isAttachment(u):
+		return imageAttachment
+	}
+	return imageLinked
+}
+
+// isAttachment reports whether a URL is a file attached to an issue or comment
+func isAttachment(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return (host == "github.com" && strings.HasPrefix(u.Path, "/user-attachments/")) ||
+		host == "private-user-images.githubusercontent.com"
+}
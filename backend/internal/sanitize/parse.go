@@ -0,0 +1,234 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sanitize
+
+import "strings"
+
+// linkDest is a markdown link destination as written
+type linkDest struct {
+	url       string
+	bracketed bool // written as <url>
+}
+
+// inlineLink is the (destination "title") part of a markdown link or image
+type inlineLink struct {
+	dest  linkDest
+	title string // with its quotes
+}
+
+// encode writes the destination without angle brackets, spaces, or unbalanced parentheses,
+// so it can't be read as HTML if a renderer disagrees about where the link ends
+func (d linkDest) encode() string {
+	r := strings.NewReplacer("<", "%3C", ">", "%3E")
+	if d.bracketed {
+		r = strings.NewReplacer("<", "%3C", ">", "%3E", " ", "%20", "(", "%28", ")", "%29")
+	}
+	return r.Replace(d.url)
+}
+
+// parseTag parses the HTML tag s starts with, returning how many bytes it takes up. Tags
+// that aren't well formed are rejected rather than guessed at.
+func parseTag(s string) (tag, int, bool) {
+	var t tag
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		t.closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && (isLetter(s[i]) || (i > start && (isDigit(s[i]) || s[i] == '-'))) {
+		i++
+	}
+	if i == start {
+		return tag{}, 0, false
+	}
+	t.name = strings.ToLower(s[start:i])
+
+	for {
+		afterName := i
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		switch {
+		case i >= len(s):
+			return tag{}, 0, false
+		case s[i] == '>':
+			return t, i + 1, true
+		case s[i] == '/' && !t.closing && strings.HasPrefix(s[i:], "/>"):
+			return t, i + 2, true
+		case t.closing || i == afterName:
+			// Closing tags have no attributes, and attributes are separated by whitespace
+			return tag{}, 0, false
+		}
+		a, n, ok := parseAttr(s[i:])
+		if !ok {
+			return tag{}, 0, false
+		}
+		t.attrs = append(t.attrs, a)
+		i += n
+	}
+}
+
+// parseAttr parses the attribute s starts with
+func parseAttr(s string) (attr, int, bool) {
+	if !isLetter(s[0]) && s[0] != '_' && s[0] != ':' {
+		return attr{}, 0, false
+	}
+	i := 1
+	for i < len(s) && (isLetter(s[i]) || isDigit(s[i]) || strings.IndexByte("_.:-", s[i]) >= 0) {
+		i++
+	}
+	a := attr{name: strings.ToLower(s[:i])}
+
+	j := i
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+	if j >= len(s) || s[j] != '=' {
+		return a, i, true
+	}
+	j++
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+	if j >= len(s) {
+		return attr{}, 0, false
+	}
+	if quote := s[j]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(s[j+1:], quote)
+		if end < 0 {
+			return attr{}, 0, false
+		}
+		a.value = s[j+1 : j+1+end]
+		return a, j + end + 2, true
+	}
+	k := j
+	for k < len(s) && !isSpace(s[k]) && strings.IndexByte("\"'=<>`", s[k]) < 0 {
+		k++
+	}
+	if k == j {
+		return attr{}, 0, false
+	}
+	a.value = s[j:k]
+	return a, k, true
+}
+
+// parseInlineLink parses the (destination "title") s starts with
+func parseInlineLink(s string) (inlineLink, int, bool) {
+	var link inlineLink
+	i := skipLinkSpace(s, 1)
+	if i < len(s) && s[i] == ')' {
+		return link, i + 1, true
+	}
+	dest, n, ok := parseDest(s[i:])
+	if !ok {
+		return inlineLink{}, 0, false
+	}
+	link.dest = dest
+	i += n
+
+	if j := skipLinkSpace(s, i); j > i && j < len(s) && strings.IndexByte(`"'(`, s[j]) >= 0 {
+		closing := s[j]
+		if closing == '(' {
+			closing = ')'
+		}
+		end := j + 1
+		for end < len(s) && s[end] != closing {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return inlineLink{}, 0, false
+		}
+		link.title = s[j : end+1]
+		i = end + 1
+	}
+
+	i = skipLinkSpace(s, i)
+	if i >= len(s) || s[i] != ')' {
+		return inlineLink{}, 0, false
+	}
+	return link, i + 1, true
+}
+
+// parseDefinition parses the destination of a link reference definition, after its colon.
+// It returns the whitespace before the destination too.
+func parseDefinition(s string) (string, linkDest, int, bool) {
+	i := skipLinkSpace(s, 0)
+	dest, n, ok := parseDest(s[i:])
+	if !ok {
+		return "", linkDest{}, 0, false
+	}
+	return s[:i], dest, i + n, true
+}
+
+// parseDest parses a link destination, either <bracketed> or a run of non-space
+// characters with balanced parentheses
+func parseDest(s string) (linkDest, int, bool) {
+	if strings.HasPrefix(s, "<") {
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '\n', '<':
+				return linkDest{}, 0, false
+			case '>':
+				return linkDest{url: s[1:i], bracketed: true}, i + 1, true
+			}
+		}
+		return linkDest{}, 0, false
+	}
+
+	depth := 0
+	i := 0
+	for ; i < len(s) && s[i] > ' ' && s[i] != 0x7f; i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) && isPunct(s[i+1]) {
+				i++
+			}
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return linkDest{url: s[:i]}, i, i > 0
+			}
+			depth--
+		}
+	}
+	if i == 0 || depth != 0 {
+		return linkDest{}, 0, false
+	}
+	return linkDest{url: s[:i]}, i, true
+}
+
+// skipLinkSpace skips spaces, tabs, and at most one line break from i
+func skipLinkSpace(s string, i int) int {
+	newline := false
+	for i < len(s) {
+		switch {
+		case s[i] == ' ' || s[i] == '\t':
+		case s[i] == '\n' && !newline:
+			newline = true
+		default:
+			return i
+		}
+		i++
+	}
+	return i
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package sanitize cleans GitHub markdown before it's sent to the browser. Bodies stay
+// markdown, but the HTML inside them is cut down to an allowlist, links are limited to safe
+// schemes, and images from GitHub are served through Octobud's image proxy.
+package sanitize
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// allowedTags are the HTML elements kept in a body, roughly what GitHub itself allows
+var allowedTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdo": true, "blockquote": true, "br": true,
+	"caption": true, "cite": true, "code": true, "dd": true, "del": true, "details": true,
+	"dfn": true, "div": true, "dl": true, "dt": true, "em": true, "figcaption": true,
+	"figure": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"hr": true, "i": true, "img": true, "ins": true, "kbd": true, "li": true, "mark": true,
+	"ol": true, "p": true, "pre": true, "q": true, "rp": true, "rt": true, "ruby": true,
+	"s": true, "samp": true, "small": true, "span": true, "strike": true, "strong": true,
+	"sub": true, "summary": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "time": true, "tr": true, "tt": true, "ul": true,
+	"var": true, "wbr": true,
+}
+
+// allowedAttrs are the attributes kept on allowed elements. href is only kept on links and
+// src only on images, and both only with safe URLs.
+var allowedAttrs = map[string]bool{
+	"align": true, "alt": true, "colspan": true, "height": true, "href": true, "open": true,
+	"rowspan": true, "src": true, "start": true, "title": true, "width": true,
+}
+
+// droppedTags are removed along with everything inside them
+var droppedTags = map[string]bool{
+	"frameset": true, "iframe": true, "math": true, "noembed": true, "noframes": true,
+	"noscript": true, "object": true, "plaintext": true, "script": true, "select": true,
+	"style": true, "svg": true, "template": true, "textarea": true, "title": true, "xmp": true,
+}
+
+// safeSchemes are the URL schemes links may use. URLs without a scheme are relative.
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// containerPrefix matches the list markers and quote markers a block can be nested in
+const containerPrefix = `^[ \t]*(?:(?:[-+*]|\d{1,9}[.)])[ \t]+|>[ \t]*)*`
+
+var (
+	// htmlBlockStart matches a line that may start an HTML block, inside a list or quote too.
+	// Fences and code spans aren't code there, so they're sanitized like everything else.
+	htmlBlockStart = regexp.MustCompile(containerPrefix + `<[A-Za-z/!?]`)
+	// rawTextBlock matches HTML blocks that run to a closing tag rather than a blank line
+	rawTextBlock = regexp.MustCompile(
+		`(?i)` + containerPrefix + `<(script|pre|style|textarea)(?:[\s>]|$)`,
+	)
+	// autolinkPattern matches markdown autolinks such as <https://github.com>
+	autolinkPattern = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}):[^\s<>]*>`)
+)
+
+// Markdown sanitizes a GitHub markdown body. Code blocks and code spans are left alone,
+// since they're shown as text.
+func Markdown(body string) string {
+	if !strings.ContainsAny(body, "<[") {
+		return body
+	}
+
+	var out strings.Builder
+	var segment strings.Builder
+	var htmlLines []bool
+	flush := func() {
+		out.WriteString(sanitizeText(segment.String(), htmlLines))
+		segment.Reset()
+		htmlLines = nil
+	}
+
+	fence := ""
+	htmlEnd := ""
+	inHTML := false
+	for _, line := range strings.SplitAfter(body, "\n") {
+		if fence != "" {
+			out.WriteString(line)
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		// Track HTML blocks, whose lines are raw HTML even when they look like code
+		isHTML := inHTML
+		switch {
+		case inHTML && htmlEnd != "":
+			if strings.Contains(strings.ToLower(line), htmlEnd) {
+				inHTML, htmlEnd = false, ""
+			}
+		case inHTML:
+			if strings.TrimSpace(line) == "" {
+				inHTML, isHTML = false, false
+			}
+		default:
+			if f := openingFence(line); f != "" {
+				flush()
+				out.WriteString(line)
+				fence = f
+				continue
+			}
+			if m := rawTextBlock.FindStringSubmatch(line); m != nil {
+				end := "</" + strings.ToLower(m[1])
+				isHTML = true
+				if !strings.Contains(strings.ToLower(line[len(m[0]):]), end) {
+					inHTML, htmlEnd = true, end
+				}
+			} else if htmlBlockStart.MatchString(line) {
+				inHTML, isHTML = true, true
+			}
+		}
+		segment.WriteString(line)
+		htmlLines = append(htmlLines, isHTML)
+	}
+	flush()
+	return out.String()
+}
+
+// SubjectBody sanitizes the body of a GitHub subject (an issue, pull request, or
+// discussion) stored as raw JSON. Subjects without a body are returned unchanged.
+func SubjectBody(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return raw
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+	var body string
+	if err := json.Unmarshal(fields["body"], &body); err != nil {
+		return raw
+	}
+	clean := Markdown(body)
+	if clean == body {
+		return raw
+	}
+	encoded, err := json.Marshal(clean)
+	if err != nil {
+		return raw
+	}
+	fields["body"] = encoded
+	sanitized, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+	return sanitized
+}
+
+// openingFence returns the fence a line opens a code block with, if any. Only unindented
+// fences count, since indented ones may belong to a list item that ends before the fence
+// closes.
+func openingFence(line string) string {
+	marker := fenceMarker(line)
+	if marker == "" {
+		return ""
+	}
+	if marker[0] == '`' && strings.Contains(line[len(marker):], "`") {
+		return ""
+	}
+	return marker
+}
+
+// closesFence reports whether a line closes a code block opened with fence
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return false
+	}
+	marker := fenceMarker(trimmed)
+	return marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+		strings.TrimSpace(trimmed[len(marker):]) == ""
+}
+
+// fenceMarker returns the run of three or more backticks or tildes a line starts with
+func fenceMarker(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	n := 0
+	for n < len(line) && line[n] == line[0] {
+		n++
+	}
+	if n < 3 {
+		return ""
+	}
+	return line[:n]
+}
+
+// safeURL reports whether a link or image URL uses a safe scheme, decoding entities and
+// dropping the whitespace and control characters browsers ignore first
+func safeURL(raw string) bool {
+	u := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(raw))
+	i := strings.IndexAny(u, ":/?#")
+	if i < 0 || u[i] != ':' {
+		return true
+	}
+	return safeSchemes[strings.ToLower(u[:i])]
+}
+
+// unescapeMarkdown removes the backslash escapes markdown allows before punctuation
+func unescapeMarkdown(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sanitize
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// dangerous matches what must never reach the browser as HTML: script-capable elements,
+// event handler attributes, and script URLs
+var dangerous = regexp.MustCompile(
+	`(?i)<(script|iframe|object|embed|style|svg|math|form|input|base|meta|link)\b` +
+		`|<[^>]*\son[a-z]+\s*=|<[^>]*(javascript|vbscript|data):`,
+)
+
+func TestMarkdown_KeepsOrdinaryMarkdown(t *testing.T) {
+	tests := []string{
+		"Plain text with **bold** and _emphasis_.",
+		"Compare `a < b` and `<script>` in code.",
+		"[docs](https://example.com/docs \"Docs\") and <https://github.com>",
+		"```html\n<script>alert(1)</script>\n```\n",
+		"~~~\n<iframe src=x></iframe>\n~~~\n",
+		"<details open=\"\">\n<summary>Logs</summary>\n\nok\n</details>",
+		"<table><tr><td colspan=\"2\">cell</td></tr></table>",
+		"- [x] done\n- [ ] todo",
+	}
+	for _, body := range tests {
+		require.Equal(t, body, Markdown(body))
+	}
+}
+
+func TestMarkdown_XSSVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"script tag", "<script>alert(1)</script>"},
+		{"unclosed script", "hi <script>alert(1)"},
+		{"img onerror", `<img src="x" onerror="alert(1)">`},
+		{"unquoted handler", "<img src=x onerror=alert(1)>"},
+		{"svg onload", `<svg onload="alert(1)"><circle r="1"></circle></svg>`},
+		{"details ontoggle", `<details open ontoggle="alert(1)"><summary>x</summary></details>`},
+		{"body onload", `<body onload="alert(1)">`},
+		{"iframe", `<iframe src="https://example.com"></iframe>`},
+		{"object", `<object data="https://example.com/x.swf"></object>`},
+		{"embed", `<embed src="https://example.com/x.swf">`},
+		{"form", `<form action="https://example.com"><input name="pw"><button>Go</button></form>`},
+		{"style", "<style>body { display: none }</style>"},
+		{"base", `<base href="https://evil.example.com/">`},
+		{"meta refresh", `<meta http-equiv="refresh" content="0;url=https://example.com">`},
+		{"html link", `<a href="javascript:alert(1)">click</a>`},
+		{"mixed case", `<a href="JaVaScRiPt:alert(1)">click</a>`},
+		{"entity encoded", `<a href="&#106;avascript:alert(1)">click</a>`},
+		{"tab in scheme", "<a href=\"java\tscript:alert(1)\">click</a>"},
+		{"vbscript", `<a href="vbscript:msgbox(1)">click</a>`},
+		{"data html", `<a href="data:text/html,<script>alert(1)</script>">click</a>`},
+		{"markdown link", "[click](javascript:alert(1))"},
+		{"escaped markdown link", `[click](javascript\:alert(1))`},
+		{"bracketed markdown link", "[click](<javascript:alert(1)>)"},
+		{"markdown image", "![x](javascript:alert(1))"},
+		{"reference link", "[click][1]\n\n[1]: javascript:alert(1)"},
+		{"autolink", "<javascript:alert(1)>"},
+		{"missing whitespace", `<a href="x"onclick="alert(1)">click</a>`},
+		{"noscript mutation", `<noscript><p title="</noscript><img src=x onerror=alert(1)>"></noscript>`},
+		{"math mutation", "<math><mtext><table><mglyph><style><img src=x onerror=alert(1)>"},
+		{"fence in html block", "<div>\n```\n<img src=x onerror=alert(1)>\n```\n</div>"},
+		{"code span in html block", "<div>\n`<img src=x onerror=alert(1)>`\n</div>"},
+		{"fence in pre block", "<pre>\n\n```\n<img src=x onerror=alert(1)>\n```\n</pre>"},
+		{"escape in html block", "<div>\n\\<img src=x onerror=alert(1)>\n</div>"},
+		{"indented fence in list", "1. x\n\n   ```\n<img src=x onerror=alert(1)>\n```"},
+		{"code span across paragraphs", "`a\n\n<img src=x onerror=alert(1)>`"},
+		{"comment", "<!-- --><img src=x onerror=alert(1)> -->"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clean := Markdown(tt.body)
+			require.NotRegexp(t, dangerous, clean)
+			require.NotRegexp(t, `(?i)\]\((javascript|vbscript)`, clean)
+			require.NotRegexp(t, `(?i)\]:\s*javascript`, clean)
+		})
+	}
+}
+
+func TestMarkdown_KeepsAllowedAttributes(t *testing.T) {
+	require.Equal(
+		t,
+		`<a href="https://example.com" title="Docs">docs</a>`,
+		Markdown(`<a href="https://example.com" title=Docs class="btn" style="color:red">docs</a>`),
+	)
+	require.Equal(t, "<div>Session expired</div>", Markdown(`<div style="position:fixed">Session expired</div>`))
+	require.Equal(t, "Hi there", Markdown("Hi <blink>there</blink>"))
+	require.Equal(t, "a &lt; b", Markdown("a < b"))
+}
+
+func TestMarkdown_ProxiesImages(t *testing.T) {
+	raw := "https://raw.githubusercontent.com/octo/repo/main/logo.png"
+	proxied := ProxyURL(raw)
+
+	require.Equal(t, "![logo]("+proxied+")", Markdown("![logo]("+raw+")"))
+	require.Equal(t, `<img src="`+strings.ReplaceAll(proxied, "&", "&amp;")+`" alt="logo" width="40">`,
+		Markdown(`<img src="`+raw+`" alt="logo" width="40" onerror="alert(1)">`))
+
+	// Images from other hosts become links, so they aren't loaded behind the user's back
+	require.Equal(t, "[pixel](https://tracker.example.com/p.gif)",
+		Markdown("![pixel](https://tracker.example.com/p.gif)"))
+	require.Equal(t, `<a href="https://tracker.example.com/p.gif">pixel</a>`,
+		Markdown(`<img src="https://tracker.example.com/p.gif" alt="pixel">`))
+
+	// Attachments need a GitHub session, so they're left for the frontend to replace
+	attachment := "![shot](https://github.com/user-attachments/assets/1234)"
+	require.Equal(t, attachment, Markdown(attachment))
+
+	// Nested image inside a link, as used for badges
+	badge := "https://dependabot-badges.githubapp.com/badges/compatibility_score?x=1"
+	require.Equal(
+		t,
+		"[!["+"score]("+ProxyURL(badge)+")](https://github.com)",
+		Markdown("[![score]("+badge+")](https://github.com)"),
+	)
+}
+
+func TestProxyableImage(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://raw.githubusercontent.com/o/r/main/a.png", true},
+		{"https://user-images.githubusercontent.com/1/a.png", true},
+		{"https://camo.githubusercontent.com/abc", true},
+		{"https://github.com/o/r/raw/main/a.png", true},
+		{"https://dependabot-badges.githubapp.com/badges/x", true},
+		{"https://github.com/user-attachments/assets/1234", false},
+		{"https://private-user-images.githubusercontent.com/1/a.png?jwt=x", false},
+		{"http://raw.githubusercontent.com/o/r/main/a.png", false},
+		{"https://raw.githubusercontent.com:8443/a.png", false},
+		{"https://user@raw.githubusercontent.com/a.png", false},
+		{"https://githubusercontent.com.evil.example.com/a.png", false},
+		{"https://example.com/a.png", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, ProxyableImage(u), tt.url)
+	}
+}
+
+func TestSubjectBody(t *testing.T) {
+	raw := json.RawMessage(`{"title":"Bug","body":"<script>alert(1)</script>Steps","number":1}`)
+
+	var subject map[string]any
+	require.NoError(t, json.Unmarshal(SubjectBody(raw), &subject))
+	require.Equal(t, "Steps", subject["body"])
+	require.Equal(t, "Bug", subject["title"])
+	require.EqualValues(t, 1, subject["number"])
+
+	clean := json.RawMessage(`{"title":"Bug","body":"Steps"}`)
+	require.Equal(t, clean, SubjectBody(clean))
+	require.Equal(t, json.RawMessage(`{"title":"Bug"}`), SubjectBody(json.RawMessage(`{"title":"Bug"}`)))
+	require.Nil(t, SubjectBody(nil))
+}
@@ -0,0 +1,368 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sanitize
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+// bracket is an open [ or ![ that may turn out to be a link or an image
+type bracket struct {
+	at         int  // where the bracket starts in the output
+	image      bool // opened with ![
+	definition bool // starts a line, so it may be a link reference definition
+}
+
+// tag is a parsed HTML tag
+type tag struct {
+	name    string
+	closing bool
+	attrs   []attr
+}
+
+// attr is an HTML attribute with its value as written, entities and all
+type attr struct {
+	name  string
+	value string
+}
+
+// textSanitizer sanitizes the markdown between code blocks. Anything that isn't a kept tag,
+// an autolink, or code is written so the browser can't read it as HTML.
+type textSanitizer struct {
+	src        string
+	pos        int
+	out        []byte
+	lineStarts []int
+	htmlLines  []bool
+	brackets   []bracket
+}
+
+// sanitizeText sanitizes markdown text. htmlLines marks the lines inside HTML blocks, where
+// backticks and backslashes have no special meaning.
+func sanitizeText(src string, htmlLines []bool) string {
+	if !strings.ContainsAny(src, "<[") {
+		return src
+	}
+	s := &textSanitizer{
+		src:        src,
+		out:        make([]byte, 0, len(src)),
+		lineStarts: []int{0},
+		htmlLines:  htmlLines,
+	}
+	for i := 0; i < len(src)-1; i++ {
+		if src[i] == '\n' {
+			s.lineStarts = append(s.lineStarts, i+1)
+		}
+	}
+
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		switch {
+		case c == '\\' && s.pos+1 < len(s.src) && isPunct(s.src[s.pos+1]) && !s.isHTMLLine(s.pos):
+			s.out = append(s.out, s.src[s.pos:s.pos+2]...)
+			s.pos += 2
+		case c == '`':
+			s.codeSpan()
+		case c == '<':
+			s.tag()
+		case c == '!' && strings.HasPrefix(s.src[s.pos:], "!["):
+			s.brackets = append(s.brackets, bracket{at: len(s.out), image: true})
+			s.out = append(s.out, "!["...)
+			s.pos += 2
+		case c == '[':
+			s.brackets = append(s.brackets, bracket{at: len(s.out), definition: s.atLineStart()})
+			s.out = append(s.out, '[')
+			s.pos++
+		case c == ']':
+			s.closeBracket()
+		default:
+			s.out = append(s.out, c)
+			s.pos++
+		}
+	}
+	return string(s.out)
+}
+
+// codeSpan copies a code span as is. Backticks in HTML blocks, or without a closing run
+// before the paragraph ends, are plain text.
+func (s *textSanitizer) codeSpan() {
+	n := runLength(s.src[s.pos:], '`')
+	if !s.isHTMLLine(s.pos) {
+		for j := s.pos + n; j < len(s.src); {
+			switch s.src[j] {
+			case '`':
+				m := runLength(s.src[j:], '`')
+				if m == n {
+					s.out = append(s.out, s.src[s.pos:j+m]...)
+					s.pos = j + m
+					return
+				}
+				j += m
+				continue
+			case '\n':
+				if s.paragraphEnds(j + 1) {
+					j = len(s.src)
+					continue
+				}
+			}
+			j++
+		}
+	}
+	s.out = append(s.out, s.src[s.pos:s.pos+n]...)
+	s.pos += n
+}
+
+// paragraphEnds reports whether the line starting at pos ends the paragraph before it, by
+// being blank or HTML
+func (s *textSanitizer) paragraphEnds(pos int) bool {
+	end := strings.IndexByte(s.src[pos:], '\n')
+	if end < 0 {
+		end = len(s.src) - pos
+	}
+	return strings.TrimSpace(s.src[pos:pos+end]) == "" || s.isHTMLLine(pos)
+}
+
+// tag handles a <. Allowed tags are rebuilt with their allowed attributes, other tags are
+// removed, and a < that doesn't start a tag is escaped.
+func (s *textSanitizer) tag() {
+	rest := s.src[s.pos:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		s.skipPast(4, "-->")
+		return
+	case strings.HasPrefix(rest, "<?"),
+		len(rest) > 2 && rest[1] == '!' && (isLetter(rest[2]) || rest[2] == '['):
+		s.skipPast(2, ">")
+		return
+	}
+
+	if m := autolinkPattern.FindStringSubmatch(rest); m != nil {
+		if safeSchemes[strings.ToLower(m[1])] {
+			s.out = append(s.out, m[0]...)
+			s.pos += len(m[0])
+			return
+		}
+	}
+
+	t, n, ok := parseTag(rest)
+	if !ok {
+		s.out = append(s.out, "&lt;"...)
+		s.pos++
+		return
+	}
+	s.pos += n
+	switch {
+	case droppedTags[t.name]:
+		if !t.closing {
+			s.skipPast(0, "</"+t.name)
+			s.skipPast(0, ">")
+		}
+	case allowedTags[t.name]:
+		s.writeTag(t)
+	}
+}
+
+// skipPast drops everything up to and including the next match of end, searching from
+// offset bytes ahead. Without a match it drops the rest of the text.
+func (s *textSanitizer) skipPast(offset int, end string) {
+	i := strings.Index(strings.ToLower(s.src[s.pos+offset:]), end)
+	if i < 0 {
+		s.pos = len(s.src)
+		return
+	}
+	s.pos += offset + i + len(end)
+}
+
+// writeTag writes an allowed tag with only its allowed attributes
+func (s *textSanitizer) writeTag(t tag) {
+	if t.closing {
+		s.out = append(s.out, "</"+t.name+">"...)
+		return
+	}
+	if t.name == "img" {
+		s.writeImage(t)
+		return
+	}
+	s.out = append(s.out, '<')
+	s.out = append(s.out, t.name...)
+	for _, a := range t.attrs {
+		if !allowedAttrs[a.name] || a.name == "src" {
+			continue
+		}
+		if a.name == "href" && (t.name != "a" || !safeURL(a.value)) {
+			continue
+		}
+		s.writeAttr(a.name, html.UnescapeString(a.value))
+	}
+	s.out = append(s.out, '>')
+}
+
+// writeImage writes an img tag with its source proxied. Images from other hosts become
+// links, so they aren't loaded without the user asking, and images without a usable
+// source are replaced by their alt text.
+func (s *textSanitizer) writeImage(t tag) {
+	var src, alt string
+	for _, a := range t.attrs {
+		switch a.name {
+		case "src":
+			src = html.UnescapeString(a.value)
+		case "alt":
+			alt = html.UnescapeString(a.value)
+		}
+	}
+
+	switch classifyImage(src) {
+	case imageProxied:
+		src = ProxyURL(src)
+	case imageAttachment:
+	case imageLinked:
+		if alt == "" {
+			alt = src
+		}
+		link := `<a href="` + html.EscapeString(src) + `">` + html.EscapeString(alt) + "</a>"
+		s.out = append(s.out, link...)
+		return
+	case imageDropped:
+		s.out = append(s.out, html.EscapeString(alt)...)
+		return
+	}
+
+	s.out = append(s.out, "<img"...)
+	s.writeAttr("src", src)
+	for _, a := range t.attrs {
+		if allowedAttrs[a.name] && a.name != "src" && a.name != "href" {
+			s.writeAttr(a.name, html.UnescapeString(a.value))
+		}
+	}
+	s.out = append(s.out, '>')
+}
+
+func (s *textSanitizer) writeAttr(name, value string) {
+	s.out = append(s.out, ' ')
+	s.out = append(s.out, name...)
+	s.out = append(s.out, `="`...)
+	s.out = append(s.out, html.EscapeString(value)...)
+	s.out = append(s.out, '"')
+}
+
+// closeBracket handles a ], checking the link, image, or link reference definition it
+// may close
+func (s *textSanitizer) closeBracket() {
+	if len(s.brackets) == 0 {
+		s.out = append(s.out, ']')
+		s.pos++
+		return
+	}
+	b := s.brackets[len(s.brackets)-1]
+	s.brackets = s.brackets[:len(s.brackets)-1]
+
+	next := s.src[s.pos+1:]
+	switch {
+	case strings.HasPrefix(next, "("):
+		if link, n, ok := parseInlineLink(next); ok {
+			s.out = append(s.out, ']')
+			s.writeInlineLink(b, link)
+			s.pos += 1 + n
+			return
+		}
+	case strings.HasPrefix(next, ":") && b.definition:
+		if space, dest, n, ok := parseDefinition(next[1:]); ok {
+			if !safeURL(unescapeMarkdown(dest.url)) {
+				dest = linkDest{url: "#"}
+			}
+			s.out = append(s.out, "]:"+space+dest.encode()...)
+			s.pos += 2 + n
+			return
+		}
+	}
+	s.out = append(s.out, ']')
+	s.pos++
+}
+
+// writeInlineLink writes the (destination "title") of a link or image, proxying images and
+// replacing unsafe link destinations
+func (s *textSanitizer) writeInlineLink(b bracket, link inlineLink) {
+	target := unescapeMarkdown(link.dest.url)
+	if b.image {
+		switch classifyImage(target) {
+		case imageProxied:
+			link.dest = linkDest{url: ProxyURL(target)}
+		case imageAttachment:
+		case imageLinked:
+			s.removeBang(b.at)
+		case imageDropped:
+			s.removeBang(b.at)
+			link.dest = linkDest{url: "#"}
+		}
+	} else if !safeURL(target) {
+		link.dest = linkDest{url: "#"}
+	}
+
+	s.out = append(s.out, '(')
+	s.out = append(s.out, link.dest.encode()...)
+	if link.title != "" {
+		s.out = append(s.out, ' ')
+		s.out = append(s.out, strings.ReplaceAll(link.title, "<", "&lt;")...)
+	}
+	s.out = append(s.out, ')')
+}
+
+// removeBang turns an image into a link by dropping the ! written at the given offset
+func (s *textSanitizer) removeBang(at int) {
+	if at < len(s.out) && s.out[at] == '!' {
+		s.out = append(s.out[:at], s.out[at+1:]...)
+	}
+}
+
+// atLineStart reports whether the current position follows at most three spaces at the
+// start of a line outside HTML blocks
+func (s *textSanitizer) atLineStart() bool {
+	start := s.lineStarts[s.lineIndex(s.pos)]
+	return s.pos-start <= 3 && strings.TrimLeft(s.src[start:s.pos], " ") == "" &&
+		!s.isHTMLLine(s.pos)
+}
+
+// isHTMLLine reports whether the line holding pos is inside an HTML block
+func (s *textSanitizer) isHTMLLine(pos int) bool {
+	i := s.lineIndex(pos)
+	return i < len(s.htmlLines) && s.htmlLines[i]
+}
+
+func (s *textSanitizer) lineIndex(pos int) int {
+	return sort.SearchInts(s.lineStarts, pos+1) - 1
+}
+
+func runLength(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -36,6 +36,11 @@ type Config struct {
 
 	// CORSOrigins is a list of allowed CORS origins. If empty, defaults to localhost origins.
 	CORSOrigins []string
+
+	// RealIP sets each request's remote address from the X-Forwarded-For and X-Real-IP
+	// headers. Turn it off when the remote address decides whether to trust a proxy,
+	// since any client can set those headers.
+	RealIP bool
 }
 
 // DefaultConfig returns a Config with sensible defaults for local development.
@@ -43,6 +48,7 @@ func DefaultConfig() Config {
 	return Config{
 		RequestLogging: false,
 		CORSOrigins:    []string{"http://localhost:*", "http://127.0.0.1:*"},
+		RealIP:         true,
 	}
 }
 
@@ -52,7 +58,9 @@ func NewRouter(cfg Config) chi.Router {
 
 	// Core middleware
 	router.Use(middleware.RequestID)
-	router.Use(middleware.RealIP)
+	if cfg.RealIP {
+		router.Use(middleware.RealIP)
+	}
 	router.Use(middleware.Recoverer)
 
 	// Optional request logging
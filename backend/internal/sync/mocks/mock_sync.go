@@ -11,11 +11,13 @@ package syncmocks
 
 import (
 	context "context"
+	json "encoding/json"
 	reflect "reflect"
 	time "time"
 
 	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
 	types "github.com/octobud-hq/octobud/backend/internal/github/types"
+	models "github.com/octobud-hq/octobud/backend/internal/models"
 	sync "github.com/octobud-hq/octobud/backend/internal/sync"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -44,11 +46,41 @@ func (m *MockSyncOperations) EXPECT() *MockSyncOperationsMockRecorder {
 	return m.recorder
 }
 
+// ApplySubjectData mocks base method.
+func (m *MockSyncOperations) ApplySubjectData(ctx context.Context, userID, githubID string, subjectRaw json.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySubjectData", ctx, userID, githubID, subjectRaw)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySubjectData indicates an expected call of ApplySubjectData.
+func (mr *MockSyncOperationsMockRecorder) ApplySubjectData(ctx, userID, githubID, subjectRaw any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySubjectData", reflect.TypeOf((*MockSyncOperations)(nil).ApplySubjectData), ctx, userID, githubID, subjectRaw)
+}
+
+// FetchInitialSyncPage mocks base method.
+func (m *MockSyncOperations) FetchInitialSyncPage(ctx context.Context, syncCtx sync.SyncContext, before *time.Time) ([]types.NotificationThread, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchInitialSyncPage", ctx, syncCtx, before)
+	ret0, _ := ret[0].([]types.NotificationThread)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchInitialSyncPage indicates an expected call of FetchInitialSyncPage.
+func (mr *MockSyncOperationsMockRecorder) FetchInitialSyncPage(ctx, syncCtx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInitialSyncPage", reflect.TypeOf((*MockSyncOperations)(nil).FetchInitialSyncPage), ctx, syncCtx, before)
+}
+
 // FetchNotificationsToSync mocks base method.
-func (m *MockSyncOperations) FetchNotificationsToSync(ctx context.Context, syncCtx sync.SyncContext) ([]types.NotificationThread, error) {
+func (m *MockSyncOperations) FetchNotificationsToSync(ctx context.Context, syncCtx sync.SyncContext) (types.NotificationsResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchNotificationsToSync", ctx, syncCtx)
-	ret0, _ := ret[0].([]types.NotificationThread)
+	ret0, _ := ret[0].(types.NotificationsResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -59,19 +91,20 @@ func (mr *MockSyncOperationsMockRecorder) FetchNotificationsToSync(ctx, syncCtx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchNotificationsToSync", reflect.TypeOf((*MockSyncOperations)(nil).FetchNotificationsToSync), ctx, syncCtx)
 }
 
-// FetchOlderNotificationsToSync mocks base method.
-func (m *MockSyncOperations) FetchOlderNotificationsToSync(ctx context.Context, since, until time.Time, maxCount *int, unreadOnly bool) ([]types.NotificationThread, error) {
+// FetchOlderNotificationsPage mocks base method.
+func (m *MockSyncOperations) FetchOlderNotificationsPage(ctx context.Context, since, before time.Time, unreadOnly bool) ([]types.NotificationThread, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchOlderNotificationsToSync", ctx, since, until, maxCount, unreadOnly)
+	ret := m.ctrl.Call(m, "FetchOlderNotificationsPage", ctx, since, before, unreadOnly)
 	ret0, _ := ret[0].([]types.NotificationThread)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// FetchOlderNotificationsToSync indicates an expected call of FetchOlderNotificationsToSync.
-func (mr *MockSyncOperationsMockRecorder) FetchOlderNotificationsToSync(ctx, since, until, maxCount, unreadOnly any) *gomock.Call {
+// FetchOlderNotificationsPage indicates an expected call of FetchOlderNotificationsPage.
+func (mr *MockSyncOperationsMockRecorder) FetchOlderNotificationsPage(ctx, since, before, unreadOnly any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOlderNotificationsToSync", reflect.TypeOf((*MockSyncOperations)(nil).FetchOlderNotificationsToSync), ctx, since, until, maxCount, unreadOnly)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOlderNotificationsPage", reflect.TypeOf((*MockSyncOperations)(nil).FetchOlderNotificationsPage), ctx, since, before, unreadOnly)
 }
 
 // GetSyncContext mocks base method.
@@ -147,6 +180,34 @@ func (mr *MockSyncOperationsMockRecorder) RefreshSubjectData(ctx, userID, github
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSubjectData", reflect.TypeOf((*MockSyncOperations)(nil).RefreshSubjectData), ctx, userID, githubID)
 }
 
+// SaveInitialSyncProgress mocks base method.
+func (m *MockSyncOperations) SaveInitialSyncProgress(ctx context.Context, userID string, progress models.InitialSyncProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInitialSyncProgress", ctx, userID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInitialSyncProgress indicates an expected call of SaveInitialSyncProgress.
+func (mr *MockSyncOperationsMockRecorder) SaveInitialSyncProgress(ctx, userID, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInitialSyncProgress", reflect.TypeOf((*MockSyncOperations)(nil).SaveInitialSyncProgress), ctx, userID, progress)
+}
+
+// SetNotificationETag mocks base method.
+func (m *MockSyncOperations) SetNotificationETag(ctx context.Context, userID, etag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationETag", ctx, userID, etag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationETag indicates an expected call of SetNotificationETag.
+func (mr *MockSyncOperationsMockRecorder) SetNotificationETag(ctx, userID, etag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationETag", reflect.TypeOf((*MockSyncOperations)(nil).SetNotificationETag), ctx, userID, etag)
+}
+
 // UpdateSyncStateAfterProcessing mocks base method.
 func (m *MockSyncOperations) UpdateSyncStateAfterProcessing(ctx context.Context, userID string, latestUpdate time.Time) error {
 	m.ctrl.T.Helper()
@@ -175,6 +236,20 @@ func (mr *MockSyncOperationsMockRecorder) UpdateSyncStateAfterProcessingWithInit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncStateAfterProcessingWithInitialSync", reflect.TypeOf((*MockSyncOperations)(nil).UpdateSyncStateAfterProcessingWithInitialSync), ctx, userID, latestUpdate, initialSyncCompletedAt, oldestNotificationSyncedAt)
 }
 
+// WithAccount mocks base method.
+func (m *MockSyncOperations) WithAccount(client githubinterfaces.Client, account string) sync.SyncOperations {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithAccount", client, account)
+	ret0, _ := ret[0].(sync.SyncOperations)
+	return ret0
+}
+
+// WithAccount indicates an expected call of WithAccount.
+func (mr *MockSyncOperationsMockRecorder) WithAccount(client, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithAccount", reflect.TypeOf((*MockSyncOperations)(nil).WithAccount), client, account)
+}
+
 // WithClient mocks base method.
 func (m *MockSyncOperations) WithClient(client githubinterfaces.Client) sync.SyncOperations {
 	m.ctrl.T.Helper()
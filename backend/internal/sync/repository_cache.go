@@ -0,0 +1,118 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+	gosync "sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/db"
+)
+
+// How long an upserted repository is reused before it's upserted again. Long enough to
+// cover a sync's worth of notifications, short enough that merged or removed repositories
+// drop out quickly.
+const repositoryCacheTTL = time.Minute
+
+// Most repositories remembered at once; the cache starts over when it fills up
+const repositoryCacheSize = 1000
+
+// repositoryCache remembers recently upserted repositories, so that when many notifications
+// of the same repository are processed at once the repository is written once rather than
+// for every notification. It's shared by the notification workers.
+type repositoryCache struct {
+	mu      gosync.Mutex
+	entries map[string]repositoryCacheEntry
+}
+
+type repositoryCacheEntry struct {
+	fingerprint [sha256.Size]byte
+	repo        db.Repository
+	storedAt    time.Time
+}
+
+func newRepositoryCache() *repositoryCache {
+	return &repositoryCache{entries: make(map[string]repositoryCacheEntry)}
+}
+
+// repositoryCacheKey identifies a repository of a user, or returns false when the
+// notification didn't include the repository's ID.
+func repositoryCacheKey(userID string, params db.UpsertRepositoryParams) (string, bool) {
+	if !params.GithubID.Valid {
+		return "", false
+	}
+	return userID + "|" + strconv.FormatInt(params.GithubID.Int64, 10), true
+}
+
+// repositoryFingerprint hashes the upsert parameters, so a repository whose details changed,
+// such as one that was renamed or archived, is written again.
+func repositoryFingerprint(params db.UpsertRepositoryParams) ([sha256.Size]byte, bool) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(data), true
+}
+
+// get returns the repository stored under key if it was upserted with the same
+// parameters within the TTL.
+func (c *repositoryCache) get(
+	key string,
+	fingerprint [sha256.Size]byte,
+	now time.Time,
+) (db.Repository, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.fingerprint != fingerprint || now.Sub(entry.storedAt) >= repositoryCacheTTL {
+		return db.Repository{}, false
+	}
+	return entry.repo, true
+}
+
+// put remembers an upserted repository.
+func (c *repositoryCache) put(
+	key string,
+	fingerprint [sha256.Size]byte,
+	repo db.Repository,
+	now time.Time,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= repositoryCacheSize {
+		for k, entry := range c.entries {
+			if now.Sub(entry.storedAt) >= repositoryCacheTTL {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= repositoryCacheSize {
+			c.entries = make(map[string]repositoryCacheEntry)
+		}
+	}
+	c.entries[key] = repositoryCacheEntry{fingerprint: fingerprint, repo: repo, storedAt: now}
+}
+
+// forget drops the repository stored under key, for when it turned out to be stale.
+func (c *repositoryCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -18,6 +18,7 @@ package sync
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"go.uber.org/zap"
@@ -29,6 +30,7 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/db"
 	githubinterfaces "github.com/octobud-hq/octobud/backend/internal/github/interfaces"
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 // SyncContext contains ALL state needed for a sync operation.
@@ -58,6 +60,17 @@ type SyncContext struct {
 	// OldestNotificationSyncedAt is for tracking purposes (used by job)
 	// Existing oldest notification timestamp from previous partial sync (zero if none)
 	OldestNotificationSyncedAt time.Time
+
+	// InitialSyncProgress is how far an interrupted initial sync got (zero when none was)
+	InitialSyncProgress models.InitialSyncProgress
+
+	// NotificationETag is the ETag of the last notifications response (empty during initial sync).
+	// GitHub answers 304 Not Modified when nothing changed, which costs no rate limit.
+	NotificationETag string
+
+	// OrgAllowlist and OrgBlocklist limit which orgs' notifications are synced
+	OrgAllowlist []string
+	OrgBlocklist []string
 }
 
 // SyncOperations interface defines operations for syncing notifications
@@ -70,28 +83,40 @@ type SyncOperations interface {
 	// FetchNotificationsToSync fetches notifications from GitHub using the provided context.
 	// This method ONLY fetches and filters - it does NOT fetch or update any state.
 	// The syncCtx parameter MUST come from GetSyncContext().
+	// NotModified is set when GitHub reports nothing changed since the last poll.
 	FetchNotificationsToSync(
 		ctx context.Context,
 		syncCtx SyncContext,
-	) ([]types.NotificationThread, error)
-
-	// FetchOlderNotificationsToSync fetches notifications older than the specified until time.
-	// This is used for backfilling older notifications that weren't included in initial sync.
-	// - since: fetch notifications updated after this time
-	// - until: only include notifications updated before this time (filters out newer ones)
-	// - maxCount: optional limit on number of notifications to return
-	// - unreadOnly: when true, only fetch unread notifications from GitHub API
-	FetchOlderNotificationsToSync(
+	) (types.NotificationsResult, error)
+
+	// FetchInitialSyncPage fetches the newest page of notifications for the initial sync that
+	// were updated before the given time (nil for the newest of all), and whether older ones
+	// may follow. The syncCtx parameter MUST come from GetSyncContext().
+	FetchInitialSyncPage(
+		ctx context.Context,
+		syncCtx SyncContext,
+		before *time.Time,
+	) ([]types.NotificationThread, bool, error)
+
+	// SaveInitialSyncProgress records how far the initial sync got after queueing a page.
+	SaveInitialSyncProgress(ctx context.Context, userID string, progress models.InitialSyncProgress) error
+
+	// FetchOlderNotificationsPage fetches a batch of notifications updated between since and
+	// before, newest first, for backfilling history the initial sync didn't cover. more
+	// reports whether older notifications may follow.
+	FetchOlderNotificationsPage(
 		ctx context.Context,
 		since time.Time,
-		until time.Time,
-		maxCount *int,
+		before time.Time,
 		unreadOnly bool,
-	) ([]types.NotificationThread, error)
+	) ([]types.NotificationThread, bool, error)
 
 	// UpdateSyncStateAfterProcessing updates sync state after notifications are processed.
 	UpdateSyncStateAfterProcessing(ctx context.Context, userID string, latestUpdate time.Time) error
 
+	// SetNotificationETag remembers the ETag of the latest notifications response for the next poll.
+	SetNotificationETag(ctx context.Context, userID string, etag string) error
+
 	// UpdateSyncStateAfterProcessingWithInitialSync updates sync state including initial sync markers.
 	UpdateSyncStateAfterProcessingWithInitialSync(
 		ctx context.Context,
@@ -111,11 +136,24 @@ type SyncOperations interface {
 	// Returns (wasMissing, error) where wasMissing indicates if subject data was previously missing.
 	RefreshSubjectData(ctx context.Context, userID string, githubID string) (bool, error)
 
+	// ApplySubjectData updates a notification from subject data that was delivered rather
+	// than fetched, such as the issue or pull request in a webhook payload.
+	ApplySubjectData(
+		ctx context.Context,
+		userID string,
+		githubID string,
+		subjectRaw json.RawMessage,
+	) error
+
 	// IsInitialSyncComplete checks if the initial sync has been completed
 	IsInitialSyncComplete(ctx context.Context, userID string) (bool, error)
 
 	// WithClient returns a copy with a different GitHub client (for multi-tenant)
 	WithClient(client githubinterfaces.Client) SyncOperations
+
+	// WithAccount returns a copy that processes notifications for a linked GitHub account,
+	// using that account's client and recording its login on each notification
+	WithAccount(client githubinterfaces.Client, account string) SyncOperations
 }
 
 // Service coordinates fetching notifications from GitHub and persisting them.
@@ -128,6 +166,12 @@ type Service struct {
 	pullRequestService  pullrequest.PullRequestService
 	notificationService notification.NotificationService
 	userStore           db.Store // Used only for GetUser (sync settings)
+
+	// account is the login of the linked account being synced; empty for the primary account
+	account string
+
+	// repositories is shared with copies, so all notification workers skip repeat upserts
+	repositories *repositoryCache
 }
 
 // NewService assembles a Service with the provided dependencies.
@@ -150,6 +194,7 @@ func NewService(
 		pullRequestService:  pullRequestService,
 		notificationService: notificationService,
 		userStore:           userStore,
+		repositories:        newRepositoryCache(),
 	}
 }
 
@@ -165,6 +210,24 @@ func (s *Service) WithClient(client githubinterfaces.Client) SyncOperations {
 		pullRequestService:  s.pullRequestService,
 		notificationService: s.notificationService,
 		userStore:           s.userStore,
+		account:             s.account,
+		repositories:        s.repositories,
+	}
+}
+
+// WithAccount returns a copy of the service that syncs a linked account with its own client.
+func (s *Service) WithAccount(client githubinterfaces.Client, account string) SyncOperations {
+	return &Service{
+		logger:              s.logger,
+		clock:               s.clock,
+		client:              client,
+		syncStateService:    s.syncStateService,
+		repositoryService:   s.repositoryService,
+		pullRequestService:  s.pullRequestService,
+		notificationService: s.notificationService,
+		userStore:           s.userStore,
+		account:             account,
+		repositories:        s.repositories,
 	}
 }
 
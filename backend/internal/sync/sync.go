@@ -31,6 +31,10 @@ import (
 	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
+// reviewsPerPage is how many pull request reviews are fetched per request when working out
+// the review state.
+const reviewsPerPage = 100
+
 // Error definitions
 var (
 	ErrFailedToGetSyncState           = errors.New("failed to get sync state")
@@ -41,6 +45,8 @@ var (
 	ErrFailedToFetchSubject           = errors.New("failed to fetch subject")
 	ErrFailedToGetRepository          = errors.New("failed to get repository")
 	ErrFailedToExtractPullRequestData = errors.New("failed to extract pull request data")
+	ErrFailedToLoadSyncSettings       = errors.New("failed to load sync settings")
+	ErrSubjectDetailsDisabled         = errors.New("subject details are turned off in sync settings")
 )
 
 // GetSyncContext gathers ALL necessary state for a sync operation.
@@ -71,11 +77,12 @@ func (s *Service) GetSyncContext(ctx context.Context, userID string) (SyncContex
 	isInitialSync := !state.InitialSyncCompletedAt.Valid
 
 	// Pre-compute the 'since' timestamp for GitHub API
+	now := s.now()
 	var sinceTimestamp *time.Time
 	if isInitialSync {
 		// Initial sync: use the user's configured time period
 		if syncSettings.InitialSyncDays != nil && *syncSettings.InitialSyncDays > 0 {
-			cutoff := calculateSyncSinceDate(*syncSettings.InitialSyncDays)
+			cutoff := calculateSyncSinceDate(now, *syncSettings.InitialSyncDays)
 			sinceTimestamp = &cutoff
 		}
 		// If InitialSyncDays is nil (all time), sinceTimestamp stays nil
@@ -83,6 +90,14 @@ func (s *Service) GetSyncContext(ctx context.Context, userID string) (SyncContex
 		// Regular sync: use the latest notification timestamp
 		if state.LatestNotificationAt.Valid {
 			t := state.LatestNotificationAt.Time
+			// A timestamp ahead of GitHub's clock (e.g. written while the local clock was
+			// skewed) would hide every new notification, so never ask for a future 'since'
+			if t.After(now) {
+				s.logger.Warn("latest notification timestamp is in the future, clamping",
+					zap.Time("latestNotificationAt", t),
+					zap.Time("now", now))
+				t = now
+			}
 			sinceTimestamp = &t
 		}
 		// If no LatestNotificationAt, sinceTimestamp stays nil
@@ -96,11 +111,25 @@ func (s *Service) GetSyncContext(ctx context.Context, userID string) (SyncContex
 		SinceTimestamp:   sinceTimestamp,
 		MaxCount:         syncSettings.InitialSyncMaxCount,
 		UnreadOnly:       syncSettings.InitialSyncUnreadOnly,
+		OrgAllowlist:     syncSettings.OrgAllowlist,
+		OrgBlocklist:     syncSettings.OrgBlocklist,
 	}
 
 	if state.OldestNotificationSyncedAt.Valid {
 		syncCtx.OldestNotificationSyncedAt = state.OldestNotificationSyncedAt.Time
 	}
+	if isInitialSync && state.InitialSyncPages > 0 {
+		syncCtx.InitialSyncProgress = models.InitialSyncProgress{
+			StartedAt:          state.InitialSyncStartedAt.Time,
+			Pages:              int(state.InitialSyncPages),
+			Queued:             int(state.InitialSyncQueued),
+			LatestNotification: state.LatestNotificationAt.Time,
+			OldestNotification: state.OldestNotificationSyncedAt.Time,
+		}
+	}
+	if !isInitialSync && state.LastNotificationETag.Valid {
+		syncCtx.NotificationETag = state.LastNotificationETag.String
+	}
 
 	s.logger.Debug("sync context prepared",
 		zap.String("userID", userID),
@@ -118,11 +147,11 @@ func (s *Service) GetSyncContext(ctx context.Context, userID string) (SyncContex
 func (s *Service) FetchNotificationsToSync(
 	ctx context.Context,
 	syncCtx SyncContext,
-) ([]types.NotificationThread, error) {
+) (types.NotificationsResult, error) {
 	// Defensive check - caller should have checked IsSyncConfigured
 	if !syncCtx.IsSyncConfigured {
 		s.logger.Warn("FetchNotificationsToSync called but sync not configured")
-		return []types.NotificationThread{}, nil
+		return types.NotificationsResult{Threads: []types.NotificationThread{}}, nil
 	}
 
 	s.logger.Info("fetching notifications from GitHub",
@@ -131,66 +160,115 @@ func (s *Service) FetchNotificationsToSync(
 
 	// Fetch from GitHub
 	// Pass UnreadOnly to control whether to fetch all or only unread notifications
-	// Regular sync has no upper bound (before=nil)
-	threads, err := s.client.FetchNotifications(
+	// Regular sync has no upper bound (before=nil) and sends the ETag of the last poll
+	result, err := s.client.FetchNotificationsIfChanged(
 		ctx,
 		syncCtx.SinceTimestamp,
-		nil,
 		syncCtx.UnreadOnly,
+		syncCtx.NotificationETag,
 	)
 	if err != nil {
 		s.logger.Error("failed to fetch notifications from GitHub", zap.Error(err))
-		return nil, errors.Join(ErrFailedToFetchNotifications, err)
+		return types.NotificationsResult{}, errors.Join(ErrFailedToFetchNotifications, err)
+	}
+
+	if result.NotModified {
+		s.logger.Debug("notifications unchanged since last poll")
+		return result, nil
 	}
 
 	s.logger.Info("fetched notifications from GitHub",
-		zap.Int("count", len(threads)),
+		zap.Int("count", len(result.Threads)),
 		zap.Bool("isInitialSync", syncCtx.IsInitialSync))
 
+	// Drop notifications from orgs the user doesn't sync before they're processed
+	if len(syncCtx.OrgAllowlist) > 0 || len(syncCtx.OrgBlocklist) > 0 {
+		fetched := len(result.Threads)
+		result.Threads = filterSyncedOrgs(result.Threads, syncCtx)
+		s.logger.Info("applied org filter",
+			zap.Int("dropped", fetched-len(result.Threads)))
+	}
+
 	// Apply initial sync limits if this is the initial sync
 	if syncCtx.IsInitialSync && (syncCtx.MaxCount != nil || syncCtx.UnreadOnly) {
-		threads = applyInitialSyncLimitsFromContext(threads, syncCtx)
+		result.Threads = applyInitialSyncLimitsFromContext(result.Threads, syncCtx)
 		s.logger.Info("applied initial sync limits",
-			zap.Int("countAfterLimits", len(threads)))
+			zap.Int("countAfterLimits", len(result.Threads)))
+	}
+
+	return result, nil
+}
+
+// FetchInitialSyncPage fetches the newest page of notifications for the initial sync that
+// were updated before the given time (nil for the newest of all), and whether older ones
+// may follow. The initial sync's max count is left to the caller, which tracks the total.
+func (s *Service) FetchInitialSyncPage(
+	ctx context.Context,
+	syncCtx SyncContext,
+	before *time.Time,
+) ([]types.NotificationThread, bool, error) {
+	s.logger.Debug("fetching initial sync page from GitHub",
+		zap.Any("since", syncCtx.SinceTimestamp),
+		zap.Any("before", before))
+
+	threads, more, err := s.client.FetchNotificationsPage(
+		ctx,
+		syncCtx.SinceTimestamp,
+		before,
+		syncCtx.UnreadOnly,
+	)
+	if err != nil {
+		s.logger.Error("failed to fetch notifications from GitHub", zap.Error(err))
+		return nil, false, errors.Join(ErrFailedToFetchNotifications, err)
 	}
 
-	return threads, nil
+	if syncCtx.UnreadOnly {
+		unread := threads[:0]
+		for _, thread := range threads {
+			if thread.Unread {
+				unread = append(unread, thread)
+			}
+		}
+		threads = unread
+	}
+
+	return threads, more, nil
+}
+
+// SaveInitialSyncProgress records how far the initial sync got after queueing a page.
+func (s *Service) SaveInitialSyncProgress(
+	ctx context.Context,
+	userID string,
+	progress models.InitialSyncProgress,
+) error {
+	if err := s.syncStateService.SaveInitialSyncProgress(ctx, userID, progress); err != nil {
+		s.logger.Error("failed to save initial sync progress", zap.Error(err))
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+	return nil
 }
 
-// FetchOlderNotificationsToSync fetches notifications older than the specified until time.
-// This is used for backfilling older notifications that weren't included in initial sync.
-func (s *Service) FetchOlderNotificationsToSync(
+// FetchOlderNotificationsPage fetches a batch of notifications updated between since and
+// before, newest first. more reports whether older notifications may follow.
+func (s *Service) FetchOlderNotificationsPage(
 	ctx context.Context,
 	since time.Time,
-	until time.Time,
-	maxCount *int,
+	before time.Time,
 	unreadOnly bool,
-) ([]types.NotificationThread, error) {
-	s.logger.Info("fetching older notifications from GitHub",
+) ([]types.NotificationThread, bool, error) {
+	s.logger.Debug("fetching older notifications from GitHub",
 		zap.Time("since", since),
-		zap.Time("until", until),
-		zap.Any("maxCount", maxCount),
+		zap.Time("before", before),
 		zap.Bool("unreadOnly", unreadOnly))
 
-	// Fetch from GitHub with since and before parameters
 	// The GitHub API handles the time range filtering for us
-	threads, err := s.client.FetchNotifications(ctx, &since, &until, unreadOnly)
+	threads, more, err := s.client.FetchNotificationsPage(ctx, &since, &before, unreadOnly)
 	if err != nil {
 		s.logger.Error("failed to fetch older notifications from GitHub", zap.Error(err))
-		return nil, errors.Join(ErrFailedToFetchNotifications, err)
+		return nil, false, errors.Join(ErrFailedToFetchNotifications, err)
 	}
 
-	s.logger.Info("fetched notifications from GitHub",
-		zap.Int("count", len(threads)))
-
-	// Apply max count limit if specified
-	if maxCount != nil && len(threads) > *maxCount {
-		threads = threads[:*maxCount]
-		s.logger.Info("applied max count limit",
-			zap.Int("afterMaxCount", len(threads)))
-	}
-
-	return threads, nil
+	return threads, more, nil
 }
 
 // UpdateSyncStateAfterProcessing updates the sync state after notifications have been processed.
@@ -219,7 +297,7 @@ func (s *Service) UpdateSyncStateAfterProcessingWithInitialSync(
 	initialSyncCompletedAt *time.Time,
 	oldestNotificationSyncedAt *time.Time,
 ) error {
-	now := s.clock().UTC()
+	now := s.now()
 	var latestNotification *time.Time
 	if !latestUpdate.IsZero() {
 		latest := latestUpdate.UTC()
@@ -241,6 +319,15 @@ func (s *Service) UpdateSyncStateAfterProcessingWithInitialSync(
 	return nil
 }
 
+// SetNotificationETag remembers the ETag of the latest notifications response for the next poll.
+func (s *Service) SetNotificationETag(ctx context.Context, userID string, etag string) error {
+	if err := s.syncStateService.SetNotificationETag(ctx, userID, etag); err != nil {
+		s.logger.Error("failed to store notification ETag", zap.Error(err))
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+	return nil
+}
+
 // IsInitialSyncComplete checks if the initial sync has been completed
 func (s *Service) IsInitialSyncComplete(ctx context.Context, userID string) (bool, error) {
 	state, err := s.syncStateService.GetSyncState(ctx, userID)
@@ -257,8 +344,28 @@ func (s *Service) ProcessNotification(
 	userID string,
 	thread types.NotificationThread,
 ) error {
+	// Storage settings decide what is kept, so don't store anything without them
+	user, err := s.getSyncUser(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadSyncSettings, err)
+	}
+	settings, login := user.settings, user.login
+	storePayloads := settings.StoresPayloads()
+
+	// Initial and older syncs fetch notifications a page at a time, so the org filter is
+	// applied here too
+	if !settings.SyncsRepository(thread.Repository.FullName) {
+		s.logger.Debug("org not synced, skipping notification",
+			zap.String("githubID", thread.ID),
+			zap.String("fullName", thread.Repository.FullName))
+		return nil
+	}
+
 	// Upsert repository
-	rawRepo := thread.Repository.Raw()
+	var rawRepo json.RawMessage
+	if storePayloads {
+		rawRepo = thread.Repository.Raw()
+	}
 	repoParams := db.UpsertRepositoryParams{
 		GithubID:       models.SQLNullInt64(thread.Repository.ID),
 		NodeID:         models.SQLNullString(thread.Repository.NodeID),
@@ -285,7 +392,7 @@ func (s *Service) ProcessNotification(
 		},
 	}
 
-	repo, err := s.repositoryService.UpsertRepository(ctx, userID, repoParams)
+	repo, err := s.upsertRepository(ctx, userID, repoParams)
 	if err != nil {
 		s.logger.Error(
 			"failed to upsert repository",
@@ -295,13 +402,30 @@ func (s *Service) ProcessNotification(
 		return errors.Join(ErrFailedToUpsertRepository, err)
 	}
 
+	// The repository is kept either way, so it can still be listed and included again
+	repoSettings, err := s.repositoryService.GetRepositorySettings(ctx, userID, repo.ID)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadSyncSettings, err)
+	}
+	if repoSettings.SyncMode == models.RepositorySyncExcluded {
+		s.logger.Debug("repository excluded from sync, skipping notification",
+			zap.String("githubID", thread.ID),
+			zap.String("fullName", repo.FullName))
+		return nil
+	}
+	fetchSubjects := settings.FetchesSubjects() &&
+		repoSettings.SyncMode != models.RepositorySyncHeadersOnly
+
 	// Fetch subject details
 	var (
 		subjectPayload   db.NullRawMessage
 		subjectFetchedAt sql.NullTime
 	)
 
-	if rawSubject, err := s.client.FetchSubjectRaw(ctx, thread.Subject.URL); err == nil &&
+	if !fetchSubjects {
+		s.logger.Debug("subject details turned off, storing the title only",
+			zap.String("githubID", thread.ID))
+	} else if rawSubject, err := s.client.FetchSubjectRaw(ctx, thread.Subject.URL); err == nil &&
 		len(rawSubject) > 0 {
 		subjectPayload = db.NullRawMessage{
 			RawMessage: rawSubject,
@@ -319,20 +443,29 @@ func (s *Service) ProcessNotification(
 			return errors.Join(ErrFailedToFetchSubject, err)
 		}
 		// Non-retriable error - log but don't fail, continue without subject data
-		//nolint:lll // Long warning message with multiple zap fields
-		s.logger.Warn("failed to fetch subject data (non-retriable, continuing without it)", zap.String("githubID", thread.ID), zap.String("subjectURL", thread.Subject.URL), zap.Error(err))
+		s.logger.Warn("failed to fetch subject data (non-retriable, continuing without it)",
+			zap.String("githubID", thread.ID),
+			zap.String("subjectURL", thread.Subject.URL),
+			zap.Error(err))
 	}
 
 	// Process pull request metadata if subject is a PullRequest
 	var pullRequestID sql.NullInt64
+	var reviewState, ciState sql.NullString
 	if strings.EqualFold(thread.Subject.Type, "PullRequest") && subjectPayload.Valid {
-		if pr, err := s.upsertPullRequestFromSubject(ctx, userID, repo.ID, subjectPayload.RawMessage); err == nil &&
+		reviewState = s.fetchReviewState(ctx, repo.FullName, subjectPayload.RawMessage)
+		ciState = s.fetchCIState(ctx, repo.FullName, subjectPayload.RawMessage)
+		if pr, err := s.upsertPullRequestFromSubject(
+			ctx, userID, repo.ID, subjectPayload.RawMessage, storePayloads,
+		); err == nil &&
 			pr != nil {
 			pullRequestID = sql.NullInt64{Int64: pr.ID, Valid: true}
 		} else if err != nil {
 			// Log but don't fail - PR metadata is optional
-			//nolint:lll // Long warning message with multiple zap fields
-			s.logger.Warn("failed to upsert pull request metadata (continuing without it)", zap.String("githubID", thread.ID), zap.Int64("repoID", repo.ID), zap.Error(err))
+			s.logger.Warn("failed to upsert pull request metadata (continuing without it)",
+				zap.String("githubID", thread.ID),
+				zap.Int64("repoID", repo.ID),
+				zap.Error(err))
 		}
 	}
 
@@ -343,12 +476,32 @@ func (s *Service) ProcessNotification(
 	var subjectState sql.NullString
 	var subjectMerged sql.NullBool
 	var subjectStateReason sql.NullString
+	var headBranch, baseBranch sql.NullString
+	var subjectDraft, subjectMergeable sql.NullBool
+	var subjectLabels db.NullRawMessage
+	var subjectMilestone sql.NullString
+	var subjectProjects db.NullRawMessage
 	if subjectPayload.Valid {
 		authorLogin, authorID = github.ExtractAuthorFromSubject(subjectPayload.RawMessage)
 		subjectNumber = github.ExtractSubjectNumber(subjectPayload.RawMessage)
 		subjectState = github.ExtractSubjectState(subjectPayload.RawMessage)
 		subjectMerged = github.ExtractSubjectMerged(subjectPayload.RawMessage)
 		subjectStateReason = github.ExtractSubjectStateReason(subjectPayload.RawMessage)
+		headBranch, baseBranch = github.ExtractSubjectBranches(subjectPayload.RawMessage)
+		subjectDraft = github.ExtractSubjectDraft(subjectPayload.RawMessage)
+		subjectMergeable = github.ExtractSubjectMergeable(subjectPayload.RawMessage)
+		labels := github.ExtractSubjectLabels(subjectPayload.RawMessage)
+		subjectLabels = db.NullRawMessage{RawMessage: labels, Valid: labels != nil}
+		subjectMilestone = github.ExtractSubjectMilestone(subjectPayload.RawMessage)
+		subjectProjects = s.fetchSubjectProjects(ctx, thread.Subject.Type, subjectPayload.RawMessage)
+	}
+
+	// Metadata has been extracted, so the payloads themselves can be dropped
+	subjectJSON := subjectPayload.RawMessage
+	payload := db.NullRawMessage{RawMessage: thread.Raw, Valid: len(thread.Raw) > 0}
+	if !storePayloads {
+		payload = db.NullRawMessage{}
+		subjectPayload = db.NullRawMessage{}
 	}
 
 	// Upsert notification
@@ -366,18 +519,72 @@ func (s *Service) ProcessNotification(
 		GithubLastReadAt:        models.SQLNullTime(thread.LastReadAt),
 		GithubURL:               models.SQLNullString(thread.URL),
 		GithubSubscriptionURL:   models.SQLNullString(thread.SubscriptionURL),
-		Payload: db.NullRawMessage{
-			RawMessage: thread.Raw,
-			Valid:      len(thread.Raw) > 0,
-		},
-		SubjectRaw:         subjectPayload,
-		SubjectFetchedAt:   subjectFetchedAt,
-		AuthorLogin:        authorLogin,
-		AuthorID:           authorID,
-		SubjectNumber:      subjectNumber,
-		SubjectState:       subjectState,
-		SubjectMerged:      subjectMerged,
-		SubjectStateReason: subjectStateReason,
+		Payload:                 payload,
+		SubjectRaw:              subjectPayload,
+		SubjectFetchedAt:        subjectFetchedAt,
+		AuthorLogin:             authorLogin,
+		AuthorID:                authorID,
+		SubjectNumber:           subjectNumber,
+		SubjectState:            subjectState,
+		SubjectMerged:           subjectMerged,
+		SubjectStateReason:      subjectStateReason,
+		HeadBranch:              headBranch,
+		BaseBranch:              baseBranch,
+		SubjectDraft:            subjectDraft,
+		SubjectLabels:           subjectLabels,
+		ReviewState:             reviewState,
+		SubjectMilestone:        subjectMilestone,
+		SubjectProjects:         subjectProjects,
+		CIState:                 ciState,
+		SubjectMergeable:        subjectMergeable,
+		Priority: s.score(user.priority, models.PriorityInputs{
+			Reason:     thread.Reason,
+			Repository: repo.FullName,
+			Author:     authorLogin.String,
+			CIState:    ciState.String,
+			UpdatedAt:  thread.UpdatedAt,
+		}),
+		Account: models.SQLNullString(s.account), // Empty for the primary account
+	}
+
+	// Index the latest comment for full-text search. When there are no comments GitHub
+	// points the latest comment URL at the subject itself, which is already indexed.
+	// An empty body clears whatever was indexed before comment caching was turned off.
+	// Whoever wrote the latest comment, or opened the subject when there are none, also
+	// decides whose turn it is.
+	latestMine := writtenBy(login, subjectJSON)
+	if !fetchSubjects {
+		notificationParams.LatestCommentBody = sql.NullString{Valid: true}
+	} else if commentURL := thread.Subject.LatestCommentURL; commentURL != "" &&
+		commentURL != thread.Subject.URL {
+		if rawComment, err := s.client.FetchSubjectRaw(ctx, commentURL); err == nil {
+			notificationParams.LatestCommentBody = sql.NullString{Valid: true}
+			if settings.CachesComments() {
+				notificationParams.LatestCommentBody = github.ExtractCommentBody(rawComment)
+			}
+			latestMine = writtenBy(login, rawComment)
+			if latestMine.Valid && latestMine.Bool {
+				// Waiting on someone else's reply
+				notificationParams.AwaitingReplySince = github.ExtractCommentCreatedAt(rawComment)
+			}
+		} else {
+			latestMine = sql.NullBool{}
+			s.logger.Warn("failed to fetch latest comment (continuing without it)",
+				zap.String("githubID", thread.ID), zap.String("commentURL", commentURL), zap.Error(err))
+		}
+	}
+	notificationParams.WaitingOn = waitingOn(
+		login, thread.Reason, subjectJSON, reviewState.String, latestMine,
+	)
+
+	// Keep recently archived threads out of the inbox unless the user is mentioned directly
+	if thread.Reason != "mention" {
+		if window := resurfaceSuppressionWindow(repoSettings, settings); window > 0 {
+			notificationParams.KeepArchivedSince = sql.NullTime{
+				Time:  s.clock().UTC().Add(-window),
+				Valid: true,
+			}
+		}
 	}
 
 	if _, err := s.notificationService.UpsertNotification(ctx, userID, notificationParams); err != nil {
@@ -386,18 +593,93 @@ func (s *Service) ProcessNotification(
 			zap.String("githubID", thread.ID),
 			zap.Error(err),
 		)
+		// The remembered repository may be gone, say merged into a duplicate, so the
+		// retry upserts it again
+		if key, ok := repositoryCacheKey(userID, repoParams); ok {
+			s.repositories.forget(key)
+		}
 		return err
 	}
 
 	return nil
 }
 
+// upsertRepository upserts a notification's repository, unless the same repository was
+// upserted with the same details moments ago by another notification of the sync.
+func (s *Service) upsertRepository(
+	ctx context.Context,
+	userID string,
+	params db.UpsertRepositoryParams,
+) (db.Repository, error) {
+	key, cacheable := repositoryCacheKey(userID, params)
+	fingerprint, ok := repositoryFingerprint(params)
+	cacheable = cacheable && ok
+	now := s.clock()
+	if cacheable {
+		if repo, ok := s.repositories.get(key, fingerprint, now); ok {
+			return repo, nil
+		}
+	}
+
+	repo, err := s.repositoryService.UpsertRepository(ctx, userID, params)
+	if err != nil {
+		return db.Repository{}, err
+	}
+	if cacheable {
+		s.repositories.put(key, fingerprint, repo, now)
+	}
+	return repo, nil
+}
+
+// writtenBy reports whether login wrote a comment, or opened an issue or pull request. It is
+// NULL when either the author or login isn't known.
+func writtenBy(login string, raw json.RawMessage) sql.NullBool {
+	author, _ := github.ExtractAuthorFromSubject(raw)
+	if login == "" || !author.Valid {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: strings.EqualFold(author.String, login), Valid: true}
+}
+
+// latestCommentMine reports whether login wrote a notification's latest comment, going by what
+// the last sync stored: the comment isn't fetched again when only the subject is refreshed.
+// Without comments it is whoever opened the subject.
+func latestCommentMine(login string, notification db.Notification, subjectJSON json.RawMessage) sql.NullBool {
+	commentURL := notification.SubjectLatestCommentURL
+	if !commentURL.Valid || commentURL.String == "" || commentURL.String == notification.SubjectURL.String {
+		return writtenBy(login, subjectJSON)
+	}
+	if login == "" {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: notification.AwaitingReplySince.Valid, Valid: true}
+}
+
+// waitingOn works out whose turn it is on an open subject, which is NULL when it isn't
+// waiting on anyone in particular.
+func waitingOn(
+	login, reason string,
+	subjectJSON json.RawMessage,
+	reviewState string,
+	latestMine sql.NullBool,
+) sql.NullString {
+	turn := github.WaitingOn(login, reason, subjectJSON, reviewState, latestMine)
+	return sql.NullString{String: turn, Valid: turn != ""}
+}
+
+// score works out a notification's priority score from the user's weights
+func (s *Service) score(settings *models.PrioritySettings, in models.PriorityInputs) sql.NullInt64 {
+	return sql.NullInt64{Int64: settings.Score(in, s.clock()), Valid: true}
+}
+
 // upsertPullRequestFromSubject extracts PR data from subject JSON and upserts it to the database.
+// The subject JSON itself is only kept when storeRaw is set.
 func (s *Service) upsertPullRequestFromSubject(
 	ctx context.Context,
 	userID string,
 	repoID int64,
 	subjectJSON json.RawMessage,
+	storeRaw bool,
 ) (*db.PullRequest, error) {
 	prData, err := github.ExtractPullRequestData(subjectJSON)
 	if err != nil {
@@ -424,10 +706,27 @@ func (s *Service) upsertPullRequestFromSubject(
 		MergedAt:    models.SQLNullTime(prData.MergedAt),
 		Raw: db.NullRawMessage{
 			RawMessage: subjectJSON,
-			Valid:      true,
+			Valid:      storeRaw,
 		},
 	}
 
+	// Record requested reviewers and teams even when there are none, so a finished review
+	// clears them
+	requestedReviewers := prData.RequestedReviewers
+	if requestedReviewers == nil {
+		requestedReviewers = []string{}
+	}
+	if reviewersJSON, err := json.Marshal(requestedReviewers); err == nil {
+		params.RequestedReviewers = db.NullRawMessage{RawMessage: reviewersJSON, Valid: true}
+	}
+	requestedTeams := prData.RequestedTeams
+	if requestedTeams == nil {
+		requestedTeams = []string{}
+	}
+	if teamsJSON, err := json.Marshal(requestedTeams); err == nil {
+		params.RequestedTeams = db.NullRawMessage{RawMessage: teamsJSON, Valid: true}
+	}
+
 	pr, err := s.pullRequestService.UpsertPullRequest(ctx, userID, params)
 	if err != nil {
 		s.logger.Error(
@@ -442,6 +741,108 @@ func (s *Service) upsertPullRequestFromSubject(
 	return &pr, nil
 }
 
+// fetchReviewState works out the review state of an open pull request from its reviews. It is
+// left unknown for closed pull requests and when the reviews can't be fetched, which doesn't
+// fail the sync.
+func (s *Service) fetchReviewState(
+	ctx context.Context,
+	repoFullName string,
+	subjectJSON json.RawMessage,
+) sql.NullString {
+	state := github.ExtractSubjectState(subjectJSON)
+	number := github.ExtractSubjectNumber(subjectJSON)
+	owner, name, ok := strings.Cut(repoFullName, "/")
+	if !ok || !number.Valid || !state.Valid || !strings.EqualFold(state.String, "open") {
+		return sql.NullString{}
+	}
+
+	var reviews []types.PullRequestReview
+	for page := 1; ; page++ {
+		batch, err := s.client.FetchPullRequestReviews(
+			ctx, owner, name, int(number.Int32), reviewsPerPage, page,
+		)
+		if err != nil {
+			s.logger.Warn("failed to fetch pull request reviews (continuing without review state)",
+				zap.String("repository", repoFullName),
+				zap.Int32("number", number.Int32),
+				zap.Error(err))
+			return sql.NullString{}
+		}
+		reviews = append(reviews, batch...)
+		if len(batch) < reviewsPerPage {
+			break
+		}
+	}
+	return sql.NullString{String: github.ReviewStateFromReviews(reviews), Valid: true}
+}
+
+// fetchCIState works out the combined CI state of an open pull request's head commit from its
+// check runs and commit statuses. It is left unknown for closed pull requests, when nothing was
+// reported for the commit, and when the checks can't be fetched, which doesn't fail the sync.
+func (s *Service) fetchCIState(
+	ctx context.Context,
+	repoFullName string,
+	subjectJSON json.RawMessage,
+) sql.NullString {
+	state := github.ExtractSubjectState(subjectJSON)
+	sha := github.ExtractSubjectHeadSHA(subjectJSON)
+	owner, name, ok := strings.Cut(repoFullName, "/")
+	if !ok || sha == "" || !state.Valid || !strings.EqualFold(state.String, "open") {
+		return sql.NullString{}
+	}
+
+	runs, err := s.client.FetchCheckRuns(ctx, owner, name, sha)
+	if err != nil {
+		s.logger.Warn("failed to fetch check runs (continuing without CI state)",
+			zap.String("repository", repoFullName),
+			zap.String("sha", sha),
+			zap.Error(err))
+		return sql.NullString{}
+	}
+	status, err := s.client.FetchCombinedStatus(ctx, owner, name, sha)
+	if err != nil {
+		s.logger.Warn("failed to fetch commit statuses (continuing without CI state)",
+			zap.String("repository", repoFullName),
+			zap.String("sha", sha),
+			zap.Error(err))
+		return sql.NullString{}
+	}
+
+	ciState := github.CIStateFromChecks(runs, status)
+	return sql.NullString{String: ciState, Valid: ciState != ""}
+}
+
+// fetchSubjectProjects looks up the titles of the projects an issue or pull request belongs
+// to, as a JSON array. It is skipped when the token can't read projects, and a failed lookup
+// doesn't fail the sync: NULL is returned so the projects already stored are kept.
+func (s *Service) fetchSubjectProjects(
+	ctx context.Context,
+	subjectType string,
+	subjectJSON json.RawMessage,
+) db.NullRawMessage {
+	if !strings.EqualFold(subjectType, "Issue") && !strings.EqualFold(subjectType, "PullRequest") {
+		return db.NullRawMessage{}
+	}
+	nodeID := github.ExtractSubjectNodeID(subjectJSON)
+	if nodeID == "" || github.SubjectProjectsScopeMissing(s.client.TokenScopes()) {
+		return db.NullRawMessage{}
+	}
+
+	projects, err := s.client.FetchSubjectProjects(ctx, nodeID)
+	if err != nil {
+		// Tokens without access to projects fail every lookup, so this isn't a warning
+		s.logger.Debug("failed to fetch subject projects (keeping the stored ones)",
+			zap.String("nodeID", nodeID),
+			zap.Error(err))
+		return db.NullRawMessage{}
+	}
+	encoded, err := json.Marshal(projects)
+	if err != nil {
+		return db.NullRawMessage{}
+	}
+	return db.NullRawMessage{RawMessage: encoded, Valid: true}
+}
+
 // RefreshSubjectData fetches fresh subject data from GitHub and updates the notification.
 // Returns (wasMissing, error) where wasMissing indicates if subject data was previously missing.
 func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID string) (bool, error) {
@@ -476,6 +877,14 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 		return wasMissing, ErrNotificationMissingSubjectURL
 	}
 
+	user, err := s.getSyncUser(ctx)
+	if err != nil {
+		return wasMissing, errors.Join(ErrFailedToLoadSyncSettings, err)
+	}
+	if err := s.checkFetchesSubjects(ctx, userID, notification.RepositoryID, user.settings); err != nil {
+		return wasMissing, err
+	}
+
 	// Fetch fresh subject data
 	subjectRaw, err := s.client.FetchSubjectRaw(ctx, notification.SubjectURL.String)
 	if err != nil {
@@ -488,6 +897,50 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 		return wasMissing, errors.Join(ErrFailedToFetchSubject, err)
 	}
 
+	return wasMissing, s.applySubjectData(ctx, userID, user, notification, subjectRaw)
+}
+
+// ApplySubjectData updates a notification from subject data that was delivered rather than
+// fetched, such as the issue or pull request in a webhook payload.
+func (s *Service) ApplySubjectData(
+	ctx context.Context,
+	userID, githubID string,
+	subjectRaw json.RawMessage,
+) error {
+	notification, err := s.notificationService.GetByGithubID(ctx, userID, githubID)
+	if err != nil {
+		s.logger.Error(
+			"failed to get notification",
+			zap.String("githubID", githubID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	user, err := s.getSyncUser(ctx)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadSyncSettings, err)
+	}
+	if err := s.checkFetchesSubjects(ctx, userID, notification.RepositoryID, user.settings); err != nil {
+		return err
+	}
+	return s.applySubjectData(ctx, userID, user, notification, subjectRaw)
+}
+
+// applySubjectData stores the metadata extracted from subject data on a notification, along
+// with the pull request it describes, and scores it again. The subject data itself is only
+// kept when the user's sync settings store payloads.
+func (s *Service) applySubjectData(
+	ctx context.Context,
+	userID string,
+	user syncUser,
+	notification db.Notification,
+	subjectRaw json.RawMessage,
+) error {
+	githubID := notification.GithubID
+	login := user.login
+	storeRaw := user.settings.StoresPayloads()
+
 	// Update the notification with fresh subject data
 	subjectPayload := db.NullRawMessage{
 		RawMessage: subjectRaw,
@@ -496,24 +949,26 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 	fetched := s.clock().UTC()
 	subjectFetchedAt := models.SQLNullTime(&fetched)
 
+	repo, err := s.repositoryService.GetRepositoryByID(ctx, userID, notification.RepositoryID)
+	if err != nil {
+		s.logger.Error(
+			"failed to get repository",
+			zap.Int64("repositoryID", notification.RepositoryID),
+			zap.Error(err),
+		)
+		return errors.Join(ErrFailedToGetRepository, err)
+	}
+
 	// If it's a PR, update the pull_request table too
 	var pullRequestID sql.NullInt64
+	var reviewState, ciState sql.NullString
 	if strings.EqualFold(notification.SubjectType, "PullRequest") && subjectPayload.Valid {
-		repo, repoErr := s.repositoryService.GetRepositoryByID(
-			ctx,
-			userID,
-			notification.RepositoryID,
-		)
-		if repoErr != nil {
-			s.logger.Error(
-				"failed to get repository",
-				zap.Int64("repositoryID", notification.RepositoryID),
-				zap.Error(repoErr),
-			)
-			return wasMissing, errors.Join(ErrFailedToGetRepository, repoErr)
-		}
+		reviewState = s.fetchReviewState(ctx, repo.FullName, subjectPayload.RawMessage)
+		ciState = s.fetchCIState(ctx, repo.FullName, subjectPayload.RawMessage)
 
-		if pr, prErr := s.upsertPullRequestFromSubject(ctx, userID, repo.ID, subjectPayload.RawMessage); prErr == nil &&
+		if pr, prErr := s.upsertPullRequestFromSubject(
+			ctx, userID, repo.ID, subjectPayload.RawMessage, storeRaw,
+		); prErr == nil &&
 			pr != nil {
 			pullRequestID = sql.NullInt64{Int64: pr.ID, Valid: true}
 		} else if prErr != nil {
@@ -534,12 +989,34 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 	var subjectState sql.NullString
 	var subjectMerged sql.NullBool
 	var subjectStateReason sql.NullString
+	var headBranch, baseBranch sql.NullString
+	var subjectDraft, subjectMergeable sql.NullBool
+	var subjectLabels db.NullRawMessage
+	var subjectMilestone sql.NullString
+	var subjectProjects db.NullRawMessage
 	if subjectPayload.Valid {
 		authorLogin, authorID = github.ExtractAuthorFromSubject(subjectPayload.RawMessage)
 		subjectNumber = github.ExtractSubjectNumber(subjectPayload.RawMessage)
 		subjectState = github.ExtractSubjectState(subjectPayload.RawMessage)
 		subjectMerged = github.ExtractSubjectMerged(subjectPayload.RawMessage)
 		subjectStateReason = github.ExtractSubjectStateReason(subjectPayload.RawMessage)
+		headBranch, baseBranch = github.ExtractSubjectBranches(subjectPayload.RawMessage)
+		subjectDraft = github.ExtractSubjectDraft(subjectPayload.RawMessage)
+		subjectMergeable = github.ExtractSubjectMergeable(subjectPayload.RawMessage)
+		labels := github.ExtractSubjectLabels(subjectPayload.RawMessage)
+		subjectLabels = db.NullRawMessage{RawMessage: labels, Valid: labels != nil}
+		subjectMilestone = github.ExtractSubjectMilestone(subjectPayload.RawMessage)
+		subjectProjects = s.fetchSubjectProjects(ctx, notification.SubjectType, subjectPayload.RawMessage)
+	}
+	turn := waitingOn(
+		login,
+		notification.Reason.String,
+		subjectPayload.RawMessage,
+		reviewState.String,
+		latestCommentMine(login, notification, subjectPayload.RawMessage),
+	)
+	if !storeRaw {
+		subjectPayload = db.NullRawMessage{}
 	}
 
 	// Update the notification with the fresh subject data
@@ -555,8 +1032,25 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 			SubjectState:       subjectState,
 			SubjectMerged:      subjectMerged,
 			SubjectStateReason: subjectStateReason,
-			AuthorLogin:        authorLogin,
-			AuthorID:           authorID,
+			HeadBranch:         headBranch,
+			BaseBranch:         baseBranch,
+			SubjectDraft:       subjectDraft,
+			SubjectLabels:      subjectLabels,
+			ReviewState:        reviewState,
+			SubjectMilestone:   subjectMilestone,
+			SubjectProjects:    subjectProjects,
+			CIState:            ciState,
+			SubjectMergeable:   subjectMergeable,
+			WaitingOn:          turn,
+			Priority: s.score(user.priority, models.PriorityInputs{
+				Reason:     notification.Reason.String,
+				Repository: repo.FullName,
+				Author:     authorLogin.String,
+				CIState:    ciState.String,
+				UpdatedAt:  notification.GithubUpdatedAt.Time,
+			}),
+			AuthorLogin: authorLogin,
+			AuthorID:    authorID,
 		},
 	)
 	if err != nil {
@@ -565,10 +1059,10 @@ func (s *Service) RefreshSubjectData(ctx context.Context, userID, githubID strin
 			zap.String("githubID", githubID),
 			zap.Error(err),
 		)
-		return wasMissing, err
+		return err
 	}
 
-	return wasMissing, nil
+	return nil
 }
 
 // ProcessNotificationData processes a notification from JSON data.
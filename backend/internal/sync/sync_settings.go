@@ -17,6 +17,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/octobud-hq/octobud/backend/internal/github/types"
@@ -25,16 +26,107 @@ import (
 
 // getUserSyncSettings retrieves sync settings from the user
 func (s *Service) getUserSyncSettings(ctx context.Context) (*models.SyncSettings, error) {
+	user, err := s.getSyncUser(ctx)
+	return user.settings, err
+}
+
+// syncUser is what sync needs to know about the user
+type syncUser struct {
+	settings *models.SyncSettings
+	priority *models.PrioritySettings
+	// The GitHub login notifications are synced for: the linked account being synced, or
+	// the user's own username for the primary account. Empty when it isn't known.
+	login string
+}
+
+// getSyncUser retrieves sync settings and priority weights from the user along with the
+// GitHub login notifications are synced for
+func (s *Service) getSyncUser(ctx context.Context) (syncUser, error) {
 	user, err := s.userStore.GetUser(ctx)
 	if err != nil {
-		return nil, err
+		return syncUser{}, err
+	}
+	settings, err := models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	if err != nil {
+		return syncUser{}, err
+	}
+	priority, err := models.PrioritySettingsFromJSON(user.PrioritySettings.RawMessage)
+	if err != nil {
+		return syncUser{}, err
+	}
+	login := s.account // Empty for the primary account
+	if login == "" {
+		login = user.GithubUsername.String
+	}
+	return syncUser{settings: settings, priority: priority, login: login}, nil
+}
+
+// resurfaceSuppressionWindow returns how long after an archive new activity on a thread in
+// the repository is kept out of the inbox. A repository override wins over the user's
+// setting; zero means archived threads resurface as soon as there is new activity.
+func resurfaceSuppressionWindow(
+	repoSettings models.RepositorySettings,
+	syncSettings *models.SyncSettings,
+) time.Duration {
+	if repoSettings.ResurfaceSuppressionMinutes != nil {
+		return time.Duration(*repoSettings.ResurfaceSuppressionMinutes) * time.Minute
+	}
+	if syncSettings == nil {
+		return 0
+	}
+	return time.Duration(syncSettings.ResurfaceSuppressionMinutes) * time.Minute
+}
+
+// checkFetchesSubjects returns ErrSubjectDetailsDisabled when subjects aren't fetched for
+// the repository's notifications, because titles only is on or the repository only
+// syncs notification headers.
+func (s *Service) checkFetchesSubjects(
+	ctx context.Context,
+	userID string,
+	repositoryID int64,
+	syncSettings *models.SyncSettings,
+) error {
+	if !syncSettings.FetchesSubjects() {
+		return ErrSubjectDetailsDisabled
 	}
-	return models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	repoSettings, err := s.repositoryService.GetRepositorySettings(ctx, userID, repositoryID)
+	if err != nil {
+		return errors.Join(ErrFailedToLoadSyncSettings, err)
+	}
+	if repoSettings.SyncMode == models.RepositorySyncHeadersOnly {
+		return ErrSubjectDetailsDisabled
+	}
+	return nil
+}
+
+// filterSyncedOrgs drops threads from repositories whose org the sync context leaves out
+func filterSyncedOrgs(
+	threads []types.NotificationThread,
+	syncCtx SyncContext,
+) []types.NotificationThread {
+	orgs := &models.SyncSettings{
+		OrgAllowlist: syncCtx.OrgAllowlist,
+		OrgBlocklist: syncCtx.OrgBlocklist,
+	}
+	filtered := make([]types.NotificationThread, 0, len(threads))
+	for _, thread := range threads {
+		if orgs.SyncsRepository(thread.Repository.FullName) {
+			filtered = append(filtered, thread)
+		}
+	}
+	return filtered
+}
+
+// calculateSyncSinceDate calculates the timestamp for N days before now
+func calculateSyncSinceDate(now time.Time, days int) time.Time {
+	return now.UTC().AddDate(0, 0, -days)
 }
 
-// calculateSyncSinceDate calculates the timestamp for N days ago
-func calculateSyncSinceDate(days int) time.Time {
-	return time.Now().UTC().AddDate(0, 0, -days)
+// now returns the current time by GitHub's clock. The local clock is corrected by the skew
+// the client last saw, so 'since' windows and poll times line up with the timestamps
+// GitHub reports even when the local clock is off.
+func (s *Service) now() time.Time {
+	return s.clock().Add(s.client.ClockSkew()).UTC()
 }
 
 // applyInitialSyncLimitsFromContext applies sync limits from a SyncContext.
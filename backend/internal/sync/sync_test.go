@@ -20,6 +20,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -70,6 +71,16 @@ func setupSyncService(
 	)
 }
 
+// expectRepositorySettings lets the sync look up repository settings, which have nothing saved
+func expectRepositorySettings(mockRepository *repositorymocks.MockRepositoryService) {
+	mockRepository.EXPECT().
+		GetRepositorySettings(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, repositoryID int64) (models.RepositorySettings, error) {
+			return models.RepositorySettings{RepositoryID: repositoryID, SyncMode: models.RepositorySyncFull}, nil
+		}).
+		AnyTimes()
+}
+
 // TestFetchNotificationsToSync_InitialSync tests fetching notifications when no sync state exists
 func TestFetchNotificationsToSync_InitialSync(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -92,8 +103,8 @@ func TestFetchNotificationsToSync_InitialSync(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(notifications, nil)
+		FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), "").
+		Return(types.NotificationsResult{Threads: notifications}, nil)
 
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
@@ -116,11 +127,93 @@ func TestFetchNotificationsToSync_InitialSync(t *testing.T) {
 		SinceTimestamp:   nil,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	result, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 1)
-	require.Equal(t, "notif-1", threads[0].ID)
+	require.Len(t, result.Threads, 1)
+	require.Equal(t, "notif-1", result.Threads[0].ID)
+}
+
+// TestFetchNotificationsToSync_OrgFilter tests that notifications from orgs left out of
+// sync are dropped before they're processed
+func TestFetchNotificationsToSync_OrgFilter(t *testing.T) {
+	thread := func(id, fullName string) types.NotificationThread {
+		return types.NotificationThread{
+			ID:         id,
+			Repository: types.RepositorySnapshot{FullName: fullName},
+			UpdatedAt:  time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		}
+	}
+	notifications := []types.NotificationThread{
+		thread("notif-1", "acme/api"),
+		thread("notif-2", "Globex/web"),
+		thread("notif-3", "octocat/dotfiles"),
+	}
+
+	tests := []struct {
+		name        string
+		allowlist   []string
+		blocklist   []string
+		expectedIDs []string
+	}{
+		{
+			name:        "no filter keeps every org",
+			expectedIDs: []string{"notif-1", "notif-2", "notif-3"},
+		},
+		{
+			name:        "allowlist keeps only listed orgs",
+			allowlist:   []string{"acme", "globex"},
+			expectedIDs: []string{"notif-1", "notif-2"},
+		},
+		{
+			name:        "blocklist drops listed orgs",
+			blocklist:   []string{"ACME"},
+			expectedIDs: []string{"notif-2", "notif-3"},
+		},
+		{
+			name:        "blocklist wins over allowlist",
+			allowlist:   []string{"acme", "globex"},
+			blocklist:   []string{"globex"},
+			expectedIDs: []string{"notif-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockClient.EXPECT().
+				FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), "").
+				Return(types.NotificationsResult{
+					Threads: append([]types.NotificationThread(nil), notifications...),
+				}, nil)
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				syncstatemocks.NewMockSyncStateService(ctrl),
+				repositorymocks.NewMockRepositoryService(ctrl),
+				pullrequestmocks.NewMockPullRequestService(ctrl),
+				notificationmocks.NewMockNotificationService(ctrl),
+				dbmocks.NewMockStore(ctrl),
+			)
+
+			result, err := service.FetchNotificationsToSync(context.Background(), SyncContext{
+				IsSyncConfigured: true,
+				OrgAllowlist:     tt.allowlist,
+				OrgBlocklist:     tt.blocklist,
+			})
+
+			require.NoError(t, err)
+			ids := make([]string, 0, len(result.Threads))
+			for _, thread := range result.Threads {
+				ids = append(ids, thread.ID)
+			}
+			require.Equal(t, tt.expectedIDs, ids)
+		})
+	}
 }
 
 // TestFetchNotificationsToSync_WithExistingState tests fetching with existing sync state
@@ -143,10 +236,10 @@ func TestFetchNotificationsToSync_WithExistingState(t *testing.T) {
 	var capturedSince *time.Time
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, since *time.Time, _ *time.Time, _ bool) ([]types.NotificationThread, error) {
+		FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), `W/"old"`).
+		DoAndReturn(func(_ context.Context, since *time.Time, _ bool, _ string) (types.NotificationsResult, error) {
 			capturedSince = since
-			return notifications, nil
+			return types.NotificationsResult{Threads: notifications, ETag: `W/"new"`}, nil
 		})
 
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
@@ -168,13 +261,15 @@ func TestFetchNotificationsToSync_WithExistingState(t *testing.T) {
 		IsSyncConfigured: true,
 		IsInitialSync:    false,
 		SinceTimestamp:   &latestNotification,
+		NotificationETag: `W/"old"`,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	result, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 1)
-	require.Equal(t, "notif-2", threads[0].ID)
+	require.Len(t, result.Threads, 1)
+	require.Equal(t, "notif-2", result.Threads[0].ID)
+	require.Equal(t, `W/"new"`, result.ETag)
 	require.NotNil(t, capturedSince)
 	require.Equal(t, latestNotification, *capturedSince)
 }
@@ -186,8 +281,44 @@ func TestFetchNotificationsToSync_EmptyResults(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return([]types.NotificationThread{}, nil)
+		FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NotificationsResult{Threads: []types.NotificationThread{}}, nil)
+
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	syncCtx := SyncContext{
+		IsSyncConfigured: true,
+		IsInitialSync:    false,
+	}
+
+	result, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+
+	require.NoError(t, err)
+	require.Len(t, result.Threads, 0)
+}
+
+// TestFetchNotificationsToSync_NotModified tests that a 304 from GitHub skips processing
+func TestFetchNotificationsToSync_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), `W/"same"`).
+		Return(types.NotificationsResult{ETag: `W/"same"`, NotModified: true}, nil)
 
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
@@ -207,12 +338,14 @@ func TestFetchNotificationsToSync_EmptyResults(t *testing.T) {
 	syncCtx := SyncContext{
 		IsSyncConfigured: true,
 		IsInitialSync:    false,
+		NotificationETag: `W/"same"`,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	result, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 0)
+	require.True(t, result.NotModified)
+	require.Empty(t, result.Threads)
 }
 
 // TestFetchNotificationsToSync_ClientError tests handling of GitHub client errors
@@ -222,8 +355,8 @@ func TestFetchNotificationsToSync_ClientError(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, errors.New("API error"))
+		FetchNotificationsIfChanged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NotificationsResult{}, errors.New("API error"))
 
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
@@ -245,11 +378,90 @@ func TestFetchNotificationsToSync_ClientError(t *testing.T) {
 		IsInitialSync:    true,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	result, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "fetch notifications")
-	require.Nil(t, threads)
+	require.Nil(t, result.Threads)
+}
+
+// TestFetchInitialSyncPage_UnreadOnly tests a page of the initial sync keeps only unread threads
+func TestFetchInitialSyncPage_UnreadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	before := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotificationsPage(gomock.Any(), (*time.Time)(nil), &before, true).
+		Return([]types.NotificationThread{
+			{ID: "notif-1", Unread: true},
+			{ID: "notif-2", Unread: false},
+			{ID: "notif-3", Unread: true},
+		}, true, nil)
+
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	syncCtx := SyncContext{
+		IsSyncConfigured: true,
+		IsInitialSync:    true,
+		UnreadOnly:       true,
+	}
+
+	threads, more, err := service.FetchInitialSyncPage(context.Background(), syncCtx, &before)
+
+	require.NoError(t, err)
+	require.True(t, more)
+	require.Len(t, threads, 2)
+	require.Equal(t, "notif-1", threads[0].ID)
+	require.Equal(t, "notif-3", threads[1].ID)
+}
+
+// TestFetchOlderNotificationsPage tests a batch of older notifications is fetched between since and before
+func TestFetchOlderNotificationsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	since := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotificationsPage(gomock.Any(), &since, &before, false).
+		Return([]types.NotificationThread{{ID: "notif-1"}}, true, nil)
+
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	threads, more, err := service.FetchOlderNotificationsPage(context.Background(), since, before, false)
+
+	require.NoError(t, err)
+	require.True(t, more)
+	require.Len(t, threads, 1)
 }
 
 // TestUpdateSyncStateAfterProcessing_Success tests successful sync state update
@@ -260,6 +472,7 @@ func TestUpdateSyncStateAfterProcessing_Success(t *testing.T) {
 	latestUpdate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
 
 	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ClockSkew().Return(time.Duration(0))
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
 	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
@@ -295,6 +508,7 @@ func TestUpdateSyncStateAfterProcessing_ZeroTime(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ClockSkew().Return(time.Duration(0))
 	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
 	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
@@ -346,6 +560,7 @@ func TestProcessNotification_RepositoryError(t *testing.T) {
 	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
 	mockUserStore := dbmocks.NewMockStore(ctrl)
 
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
 	mockRepository.EXPECT().
 		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
 		Return(db.Repository{}, errors.New("database error"))
@@ -371,196 +586,1198 @@ func TestRefreshSubjectData_NotificationNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockClient := githubmocks.NewMockClient(ctrl)
-	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
-	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
-	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
-	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
-	mockUserStore := dbmocks.NewMockStore(ctrl)
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockNotification.EXPECT().
+		GetByGithubID(gomock.Any(), "test-user-id", "nonexistent").
+		Return(db.Notification{}, sql.ErrNoRows)
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	_, err := service.RefreshSubjectData(context.Background(), "test-user-id", "nonexistent")
+
+	require.Error(t, err)
+	require.Equal(t, sql.ErrNoRows, err)
+}
+
+// TestProcessNotification_RetriableSubjectFetchError tests that retriable errors cause ProcessNotification to fail
+func TestProcessNotification_RetriableSubjectFetchError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thread := types.NotificationThread{
+		ID: "notif-123",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject: types.NotificationSubject{
+			Title: "Test Issue",
+			Type:  "Issue",
+			URL:   "https://api.github.com/repos/owner/test-repo/issues/1",
+		},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	expectRepositorySettings(mockRepository)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Repository{ID: 1}, nil)
+
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
+		Return(nil, errors.New("github: subject status 500: internal server error"))
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "fetch subject")
+}
+
+// TestProcessNotification_NonRetriableSubjectFetchError tests that non-retriable errors allow processing to continue
+func TestProcessNotification_NonRetriableSubjectFetchError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thread := types.NotificationThread{
+		ID: "notif-123",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject: types.NotificationSubject{
+			Title: "Test Issue",
+			Type:  "Issue",
+			URL:   "https://api.github.com/repos/owner/test-repo/issues/1",
+		},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Repository{ID: 1}, nil)
+
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
+		Return(nil, errors.New("github: subject status 403: forbidden"))
+
+	mockRepository.EXPECT().
+		GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+		Return(models.RepositorySettings{RepositoryID: 1}, nil)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil)
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.NoError(t, err)
+}
+
+func TestProcessNotification_UpsertsRepositoryOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadIn := func(id string, repoID int64, repoName string) types.NotificationThread {
+		return types.NotificationThread{
+			ID:     id,
+			Reason: "mention",
+			Repository: types.RepositorySnapshot{
+				ID:       repoID,
+				FullName: "owner/" + repoName,
+				Name:     repoName,
+			},
+			Subject: types.NotificationSubject{
+				Title: "Test Issue",
+				Type:  "Issue",
+				URL:   "https://api.github.com/repos/owner/" + repoName + "/issues/1",
+			},
+			UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		}
+	}
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	expectRepositorySettings(mockRepository)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	upserts := map[string]int{}
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpsertRepositoryParams) (db.Repository, error) {
+			upserts[params.Name]++
+			return db.Repository{ID: params.GithubID.Int64, Name: params.Name}, nil
+		}).
+		AnyTimes()
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), gomock.Any()).
+		Return(json.RawMessage(`{"number": 1}`), nil).
+		AnyTimes()
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil).AnyTimes()
+
+	var repositoryIDs []int64
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpsertNotificationParams) (db.Notification, error) {
+			repositoryIDs = append(repositoryIDs, params.RepositoryID)
+			return db.Notification{GithubID: params.GithubID}, nil
+		}).
+		Times(3)
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+	ctx := context.Background()
+
+	require.NoError(t, service.ProcessNotification(ctx, "test-user-id", threadIn("n1", 789, "test-repo")))
+	require.NoError(t, service.ProcessNotification(ctx, "test-user-id", threadIn("n2", 789, "test-repo")))
+	require.NoError(t, service.ProcessNotification(ctx, "test-user-id", threadIn("n3", 790, "other-repo")))
+	require.Equal(t, map[string]int{"test-repo": 1, "other-repo": 1}, upserts)
+	require.Equal(t, []int64{789, 789, 790}, repositoryIDs)
+
+	// Changed details, such as a rename, are written even when the repository was just upserted
+	renamed := threadIn("n4", 789, "renamed-repo")
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Notification{}, errors.New("FOREIGN KEY constraint failed"))
+	require.Error(t, service.ProcessNotification(ctx, "test-user-id", renamed))
+	require.Equal(t, 1, upserts["renamed-repo"])
+
+	// A failed notification upsert drops the repository, so the retry writes it again
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Notification{GithubID: "n4"}, nil)
+	require.NoError(t, service.ProcessNotification(ctx, "test-user-id", renamed))
+	require.Equal(t, 2, upserts["renamed-repo"])
+}
+
+// TestProcessNotification_Priority tests that the notification is scored with the user's
+// priority weights
+func TestProcessNotification_Priority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thread := types.NotificationThread{
+		ID:     "notif-123",
+		Reason: "review_requested",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject: types.NotificationSubject{
+			Title: "Test Issue",
+			Type:  "Issue",
+		},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
+	expectRepositorySettings(mockRepository)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		SyncSettings: db.NullRawMessage{RawMessage: json.RawMessage(`{"titlesOnly":true}`), Valid: true},
+		PrioritySettings: db.NullRawMessage{
+			RawMessage: json.RawMessage(`{"reasons":{"review_requested":30},"repositories":{"owner/test-repo":20}}`),
+			Valid:      true,
+		},
+	}, nil)
+
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpsertNotificationParams) (db.Notification, error) {
+			require.Equal(t, sql.NullInt64{Int64: 50, Valid: true}, params.Priority)
+			return db.Notification{ID: 1, GithubID: "notif-123"}, nil
+		})
+
+	service := setupSyncService(
+		ctrl,
+		githubmocks.NewMockClient(ctrl),
+		syncstatemocks.NewMockSyncStateService(ctrl),
+		mockRepository,
+		pullrequestmocks.NewMockPullRequestService(ctrl),
+		mockNotification,
+		mockUserStore,
+	)
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.NoError(t, err)
+}
+
+func TestProcessNotification_IndexesLatestComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thread := types.NotificationThread{
+		ID:     "notif-123",
+		Reason: "mention",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject: types.NotificationSubject{
+			Title:            "Test Issue",
+			Type:             "Issue",
+			URL:              "https://api.github.com/repos/owner/test-repo/issues/1",
+			LatestCommentURL: "https://api.github.com/repos/owner/test-repo/issues/comments/42",
+		},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	expectRepositorySettings(mockRepository)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Repository{ID: 1}, nil)
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
+		Return(json.RawMessage(`{"number": 1, "body": "Issue description"}`), nil)
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/comments/42").
+		Return(json.RawMessage(`{"id": 42, "body": "Ran into this again on main"}`), nil)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+
+	var upserted db.UpsertNotificationParams
+	mockNotification.EXPECT().
+		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpsertNotificationParams) (db.Notification, error) {
+			upserted = params
+			return db.Notification{ID: 1, GithubID: "notif-123"}, nil
+		})
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		sql.NullString{String: "Ran into this again on main", Valid: true},
+		upserted.LatestCommentBody,
+	)
+}
+
+// TestProcessNotification_AwaitingReply tests that only the user's own latest comment starts
+// the awaiting reply clock, and that the latest comment decides whose turn it is
+func TestProcessNotification_AwaitingReply(t *testing.T) {
+	postedAt := time.Date(2024, 1, 14, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		commentAuthor   string
+		expectedSince   sql.NullTime
+		expectedWaiting sql.NullString
+	}{
+		{
+			name:            "own comment records when it was posted",
+			commentAuthor:   "Octocat",
+			expectedSince:   sql.NullTime{Time: postedAt, Valid: true},
+			expectedWaiting: sql.NullString{String: "them", Valid: true},
+		},
+		{
+			name:            "someone else's comment clears it",
+			commentAuthor:   "hubot",
+			expectedWaiting: sql.NullString{String: "me", Valid: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			thread := types.NotificationThread{
+				ID:     "notif-123",
+				Reason: "mention",
+				Repository: types.RepositorySnapshot{
+					ID:       789,
+					FullName: "owner/test-repo",
+					Name:     "test-repo",
+				},
+				Subject: types.NotificationSubject{
+					Title:            "Test Issue",
+					Type:             "Issue",
+					URL:              "https://api.github.com/repos/owner/test-repo/issues/1",
+					LatestCommentURL: "https://api.github.com/repos/owner/test-repo/issues/comments/42",
+				},
+				UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+			}
+			comment := fmt.Sprintf(
+				`{"id": 42, "body": "Any update?", "user": {"login": %q}, "created_at": %q}`,
+				tt.commentAuthor,
+				postedAt.Format(time.RFC3339),
+			)
+
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			expectRepositorySettings(mockRepository)
+			mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+			mockUserStore := dbmocks.NewMockStore(ctrl)
+
+			mockRepository.EXPECT().
+				UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+				Return(db.Repository{ID: 1}, nil)
+			mockClient.EXPECT().
+				FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
+				Return(json.RawMessage(`{"number": 1, "state": "open", "body": "Issue description"}`), nil)
+			mockClient.EXPECT().
+				FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/comments/42").
+				Return(json.RawMessage(comment), nil)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+				ID:             1,
+				GithubUsername: sql.NullString{String: "octocat", Valid: true},
+			}, nil).AnyTimes()
+
+			var upserted db.UpsertNotificationParams
+			mockNotification.EXPECT().
+				UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params db.UpsertNotificationParams) (db.Notification, error) {
+					upserted = params
+					return db.Notification{ID: 1, GithubID: "notif-123"}, nil
+				})
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				mockSyncState,
+				mockRepository,
+				mockPullRequest,
+				mockNotification,
+				mockUserStore,
+			)
+
+			err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSince, upserted.AwaitingReplySince)
+			require.Equal(t, tt.expectedWaiting, upserted.WaitingOn)
+		})
+	}
+}
+
+// TestProcessNotification_StorageSettings tests that storage settings decide what is fetched
+// and kept, while metadata extracted from the subject survives
+func TestProcessNotification_StorageSettings(t *testing.T) {
+	subjectURL := "https://api.github.com/repos/owner/test-repo/issues/1"
+	commentURL := "https://api.github.com/repos/owner/test-repo/issues/comments/42"
+
+	tests := []struct {
+		name            string
+		syncSettings    string
+		syncMode        string
+		expectFetch     bool
+		expectPayloads  bool
+		expectedAuthor  sql.NullString
+		expectedComment sql.NullString
+	}{
+		{
+			name:            "everything is kept by default",
+			expectFetch:     true,
+			expectPayloads:  true,
+			expectedAuthor:  sql.NullString{String: "octocat", Valid: true},
+			expectedComment: sql.NullString{String: "Ran into this again on main", Valid: true},
+		},
+		{
+			name:            "payloads are dropped after extraction",
+			syncSettings:    `{"skipPayloadStorage": true}`,
+			expectFetch:     true,
+			expectedAuthor:  sql.NullString{String: "octocat", Valid: true},
+			expectedComment: sql.NullString{String: "Ran into this again on main", Valid: true},
+		},
+		{
+			name:            "comment bodies are cleared",
+			syncSettings:    `{"skipCommentCaching": true}`,
+			expectFetch:     true,
+			expectPayloads:  true,
+			expectedAuthor:  sql.NullString{String: "octocat", Valid: true},
+			expectedComment: sql.NullString{Valid: true},
+		},
+		{
+			name:            "titles only fetches nothing",
+			syncSettings:    `{"titlesOnly": true}`,
+			expectedComment: sql.NullString{Valid: true},
+		},
+		{
+			name:            "headers only repository fetches nothing",
+			syncMode:        models.RepositorySyncHeadersOnly,
+			expectPayloads:  true,
+			expectedComment: sql.NullString{Valid: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			thread := types.NotificationThread{
+				ID:     "notif-123",
+				Reason: "mention",
+				Repository: types.RepositorySnapshot{
+					ID:       789,
+					FullName: "owner/test-repo",
+					Name:     "test-repo",
+				},
+				Subject: types.NotificationSubject{
+					Title:            "Test Issue",
+					Type:             "Issue",
+					URL:              subjectURL,
+					LatestCommentURL: commentURL,
+				},
+				UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+				Raw:       json.RawMessage(`{"id": "notif-123"}`),
+			}
+
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			syncMode := tt.syncMode
+			if syncMode == "" {
+				syncMode = models.RepositorySyncFull
+			}
+			mockRepository.EXPECT().
+				GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+				Return(models.RepositorySettings{RepositoryID: 1, SyncMode: syncMode}, nil)
+			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+			mockUserStore := dbmocks.NewMockStore(ctrl)
+
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+				SyncSettings: db.NullRawMessage{
+					RawMessage: json.RawMessage(tt.syncSettings),
+					Valid:      tt.syncSettings != "",
+				},
+			}, nil)
+			mockRepository.EXPECT().
+				UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+				Return(db.Repository{ID: 1}, nil)
+			if tt.expectFetch {
+				mockClient.EXPECT().
+					FetchSubjectRaw(gomock.Any(), subjectURL).
+					Return(json.RawMessage(`{"number": 1, "body": "Issue description", "user": {"login": "octocat"}}`), nil)
+				mockClient.EXPECT().
+					FetchSubjectRaw(gomock.Any(), commentURL).
+					Return(json.RawMessage(`{"id": 42, "body": "Ran into this again on main"}`), nil)
+			}
+
+			var upserted db.UpsertNotificationParams
+			mockNotification.EXPECT().
+				UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params db.UpsertNotificationParams) (db.Notification, error) {
+					upserted = params
+					return db.Notification{ID: 1, GithubID: "notif-123"}, nil
+				})
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				syncstatemocks.NewMockSyncStateService(ctrl),
+				mockRepository,
+				pullrequestmocks.NewMockPullRequestService(ctrl),
+				mockNotification,
+				mockUserStore,
+			)
+
+			err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+			require.NoError(t, err)
+			require.Equal(t, "Test Issue", upserted.SubjectTitle)
+			require.Equal(t, tt.expectPayloads, upserted.Payload.Valid)
+			require.Equal(t, tt.expectPayloads && tt.expectFetch, upserted.SubjectRaw.Valid)
+			require.Equal(t, tt.expectFetch, upserted.SubjectFetchedAt.Valid)
+			require.Equal(t, tt.expectedAuthor, upserted.AuthorLogin)
+			require.Equal(t, tt.expectedComment, upserted.LatestCommentBody)
+		})
+	}
+}
+
+// TestProcessNotification_ExcludedRepository tests that notifications from an excluded
+// repository are skipped while the repository itself is still recorded
+func TestProcessNotification_ExcludedRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+	mockRepository.EXPECT().
+		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(db.Repository{ID: 1}, nil)
+	mockRepository.EXPECT().
+		GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+		Return(models.RepositorySettings{RepositoryID: 1, SyncMode: models.RepositorySyncExcluded}, nil)
+
+	// Neither GitHub nor the notification service is touched
+	service := setupSyncService(
+		ctrl,
+		githubmocks.NewMockClient(ctrl),
+		syncstatemocks.NewMockSyncStateService(ctrl),
+		mockRepository,
+		pullrequestmocks.NewMockPullRequestService(ctrl),
+		notificationmocks.NewMockNotificationService(ctrl),
+		mockUserStore,
+	)
+
+	thread := types.NotificationThread{
+		ID:     "notif-123",
+		Reason: "mention",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject: types.NotificationSubject{
+			Title: "Test Issue",
+			Type:  "Issue",
+			URL:   "https://api.github.com/repos/owner/test-repo/issues/1",
+		},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Raw:       json.RawMessage(`{"id": "notif-123"}`),
+	}
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.NoError(t, err)
+}
+
+// TestProcessNotification_BlockedOrg tests that notifications from a blocked org are
+// skipped before anything is stored, since initial syncs don't filter pages by org
+func TestProcessNotification_BlockedOrg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		SyncSettings: db.NullRawMessage{
+			RawMessage: json.RawMessage(`{"orgBlocklist": ["owner"]}`),
+			Valid:      true,
+		},
+	}, nil)
+
+	// Neither the repository nor the notification is stored
+	service := setupSyncService(
+		ctrl,
+		githubmocks.NewMockClient(ctrl),
+		syncstatemocks.NewMockSyncStateService(ctrl),
+		repositorymocks.NewMockRepositoryService(ctrl),
+		pullrequestmocks.NewMockPullRequestService(ctrl),
+		notificationmocks.NewMockNotificationService(ctrl),
+		mockUserStore,
+	)
+
+	thread := types.NotificationThread{
+		ID: "notif-123",
+		Repository: types.RepositorySnapshot{
+			ID:       789,
+			FullName: "owner/test-repo",
+			Name:     "test-repo",
+		},
+		Subject:   types.NotificationSubject{Title: "Test Issue", Type: "Issue"},
+		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+
+	require.NoError(t, err)
+}
+
+// TestProcessNotification_ResurfaceSuppression tests how the suppression window is resolved
+func TestProcessNotification_ResurfaceSuppression(t *testing.T) {
+	twoHours := int64(120)
+	off := int64(0)
+
+	tests := []struct {
+		name              string
+		reason            string
+		repoMinutes       *int64
+		syncSettings      string
+		expectedKeepSince sql.NullTime
+	}{
+		{
+			name:              "user window applies",
+			reason:            "subscribed",
+			syncSettings:      `{"resurfaceSuppressionMinutes": 60}`,
+			expectedKeepSince: sql.NullTime{Time: mockClock().UTC().Add(-time.Hour), Valid: true},
+		},
+		{
+			name:              "repository window overrides user window",
+			reason:            "subscribed",
+			repoMinutes:       &twoHours,
+			expectedKeepSince: sql.NullTime{Time: mockClock().UTC().Add(-2 * time.Hour), Valid: true},
+		},
+		{
+			name:        "repository can turn suppression off",
+			reason:      "subscribed",
+			repoMinutes: &off,
+		},
+		{
+			name:   "no window configured",
+			reason: "subscribed",
+		},
+		{
+			name:   "direct mentions always resurface",
+			reason: "mention",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			thread := types.NotificationThread{
+				ID:         "notif-123",
+				Repository: types.RepositorySnapshot{ID: 789, FullName: "owner/test-repo"},
+				Subject:    types.NotificationSubject{Title: "Test Issue", Type: "Issue"},
+				Reason:     tt.reason,
+				UpdatedAt:  time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+			}
+
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockClient.EXPECT().FetchSubjectRaw(gomock.Any(), "").Return(nil, nil)
+			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			mockRepository.EXPECT().
+				UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
+				Return(db.Repository{ID: 1}, nil)
+			mockRepository.EXPECT().
+				GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+				Return(models.RepositorySettings{
+					RepositoryID:                1,
+					ResurfaceSuppressionMinutes: tt.repoMinutes,
+				}, nil)
+			mockUserStore := dbmocks.NewMockStore(ctrl)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+				SyncSettings: db.NullRawMessage{
+					RawMessage: json.RawMessage(tt.syncSettings),
+					Valid:      tt.syncSettings != "",
+				},
+			}, nil)
+			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+			mockNotification.EXPECT().
+				UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(
+					_ context.Context,
+					_ string,
+					params db.UpsertNotificationParams,
+				) (db.Notification, error) {
+					require.Equal(t, tt.expectedKeepSince, params.KeepArchivedSince)
+					return db.Notification{ID: 1, GithubID: "notif-123"}, nil
+				})
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				syncstatemocks.NewMockSyncStateService(ctrl),
+				mockRepository,
+				pullrequestmocks.NewMockPullRequestService(ctrl),
+				mockNotification,
+				mockUserStore,
+			)
+
+			err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestRefreshSubjectData_ExtractsAuthor tests that RefreshSubjectData extracts and saves author information
+func TestRefreshSubjectData_ExtractsAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	expectRepositorySettings(mockRepository)
+	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+	mockUserStore := dbmocks.NewMockStore(ctrl)
+
+	notif := db.Notification{
+		ID:           1,
+		UserID:       "test-user-id",
+		GithubID:     "notif-123",
+		RepositoryID: 1,
+		SubjectType:  "Issue",
+		SubjectURL: sql.NullString{
+			String: "https://api.github.com/repos/owner/test-repo/issues/1",
+			Valid:  true,
+		},
+		SubjectFetchedAt: sql.NullTime{Valid: false},
+		AuthorLogin:      sql.NullString{Valid: false},
+		Reason:           sql.NullString{String: "mention", Valid: true},
+	}
+	mockNotification.EXPECT().
+		GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+		Return(notif, nil)
+
+	// Priority weights for the reason, repository and author are all added up
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		PrioritySettings: db.NullRawMessage{
+			RawMessage: json.RawMessage(
+				`{"reasons":{"mention":25},"repositories":{"owner/test-repo":10},"authors":{"testuser":5}}`,
+			),
+			Valid: true,
+		},
+	}, nil)
+	mockRepository.EXPECT().
+		GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+		Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
+
+	subjectJSON := `{"id": 1, "number": 42, "title": "Test Issue", "user": {"login": "testuser", "id": 12345}}`
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
+		Return([]byte(subjectJSON), nil)
+
+	mockNotification.EXPECT().
+		UpdateNotificationSubject(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, params db.UpdateNotificationSubjectParams) error {
+			require.True(t, params.AuthorLogin.Valid)
+			require.Equal(t, "testuser", params.AuthorLogin.String)
+			require.True(t, params.AuthorID.Valid)
+			require.Equal(t, int64(12345), params.AuthorID.Int64)
+			require.Equal(t, sql.NullInt64{Int64: 40, Valid: true}, params.Priority)
+			return nil
+		})
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		mockRepository,
+		mockPullRequest,
+		mockNotification,
+		mockUserStore,
+	)
+
+	wasMissing, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
+
+	require.NoError(t, err)
+	require.True(t, wasMissing)
+}
+
+// TestRefreshSubjectData_PullRequestMetadata tests that draft, mergeability, labels, requested
+// reviews, review state, CI state and whose turn it is are stored for pull requests, and that
+// failing to fetch reviews or checks doesn't fail the refresh
+func TestRefreshSubjectData_PullRequestMetadata(t *testing.T) {
+	subjectJSON := `{"id": 7, "number": 42, "state": "open", "draft": true, "mergeable": false,
+		"labels": [{"name": "bug"}, {"name": "needs review"}], "head": {"ref": "fix", "sha": "abc123"},
+		"base": {"repo": {"owner": {"login": "owner"}}},
+		"requested_reviewers": [{"login": "alice"}], "requested_teams": [{"slug": "core"}]}`
+
+	tests := []struct {
+		name      string
+		reviews   []types.PullRequestReview
+		reviewErr error
+		wantState sql.NullString
+		checkRuns []types.CheckRun
+		checksErr error
+		wantCI    sql.NullString
+	}{
+		{
+			name: "reviews and checks fetched",
+			reviews: []types.PullRequestReview{
+				{State: "APPROVED", User: types.SimpleUser{Login: "alice"}},
+			},
+			wantState: sql.NullString{String: "approved", Valid: true},
+			checkRuns: []types.CheckRun{{Name: "test", Status: "completed", Conclusion: "failure"}},
+			wantCI:    sql.NullString{String: "failure", Valid: true},
+		},
+		{
+			name:      "reviews and checks unavailable",
+			reviewErr: errors.New("boom"),
+			checksErr: errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			expectRepositorySettings(mockRepository)
+			mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
+			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+			mockUserStore := dbmocks.NewMockStore(ctrl)
+
+			mockNotification.EXPECT().
+				GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+				Return(db.Notification{
+					GithubID:     "notif-123",
+					RepositoryID: 1,
+					SubjectType:  "PullRequest",
+					SubjectURL: sql.NullString{
+						String: "https://api.github.com/repos/owner/test-repo/pulls/42",
+						Valid:  true,
+					},
+				}, nil)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+				GithubUsername: sql.NullString{String: "alice", Valid: true},
+			}, nil)
+			mockClient.EXPECT().
+				FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/pulls/42").
+				Return([]byte(subjectJSON), nil)
+			mockRepository.EXPECT().
+				GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+				Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
+			mockClient.EXPECT().
+				FetchPullRequestReviews(gomock.Any(), "owner", "test-repo", 42, 100, 1).
+				Return(tt.reviews, tt.reviewErr)
+			mockClient.EXPECT().
+				FetchCheckRuns(gomock.Any(), "owner", "test-repo", "abc123").
+				Return(tt.checkRuns, tt.checksErr)
+			if tt.checksErr == nil {
+				mockClient.EXPECT().
+					FetchCombinedStatus(gomock.Any(), "owner", "test-repo", "abc123").
+					Return(types.CombinedStatus{State: "pending"}, nil)
+			}
+			mockPullRequest.EXPECT().
+				UpsertPullRequest(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params db.UpsertPullRequestParams) (db.PullRequest, error) {
+					require.JSONEq(t, `["alice"]`, string(params.RequestedReviewers.RawMessage))
+					require.JSONEq(t, `["owner/core"]`, string(params.RequestedTeams.RawMessage))
+					return db.PullRequest{ID: 9}, nil
+				})
+			mockNotification.EXPECT().
+				UpdateNotificationSubject(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params db.UpdateNotificationSubjectParams) error {
+					require.Equal(t, sql.NullBool{Bool: true, Valid: true}, params.SubjectDraft)
+					require.Equal(t, sql.NullBool{Valid: true}, params.SubjectMergeable)
+					require.True(t, params.SubjectLabels.Valid)
+					require.JSONEq(t, `["bug", "needs review"]`, string(params.SubjectLabels.RawMessage))
+					require.Equal(t, tt.wantState, params.ReviewState)
+					require.Equal(t, tt.wantCI, params.CIState)
+					// Review is requested from the synced user
+					require.Equal(t, sql.NullString{String: "me", Valid: true}, params.WaitingOn)
+					require.Equal(t, sql.NullInt64{Int64: 9, Valid: true}, params.PullRequestID)
+					return nil
+				})
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				syncstatemocks.NewMockSyncStateService(ctrl),
+				mockRepository,
+				mockPullRequest,
+				mockNotification,
+				mockUserStore,
+			)
+
+			_, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestRefreshSubjectData_MilestoneAndProjects tests that the milestone and projects are stored
+// for issues, and that projects are kept as they were when they can't be looked up
+func TestRefreshSubjectData_MilestoneAndProjects(t *testing.T) {
+	subjectJSON := `{"id": 1, "node_id": "I_kwDOA", "number": 7, "state": "open",
+		"milestone": {"number": 3, "title": "v2.0"}}`
+
+	tests := []struct {
+		name         string
+		scopes       []string
+		scopesKnown  bool
+		lookup       bool
+		projects     []string
+		projectsErr  error
+		wantProjects db.NullRawMessage
+	}{
+		{
+			name:         "projects fetched",
+			lookup:       true,
+			projects:     []string{"Q3 Roadmap"},
+			wantProjects: db.NullRawMessage{RawMessage: []byte(`["Q3 Roadmap"]`), Valid: true},
+		},
+		{
+			name:        "projects unavailable",
+			lookup:      true,
+			projectsErr: errors.New("github: graphql errors: [insufficient scopes]"),
+		},
+		{
+			name:        "token can't read projects",
+			scopes:      []string{"repo", "notifications"},
+			scopesKnown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-	mockNotification.EXPECT().
-		GetByGithubID(gomock.Any(), "test-user-id", "nonexistent").
-		Return(db.Notification{}, sql.ErrNoRows)
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			expectRepositorySettings(mockRepository)
+			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
+			mockUserStore := dbmocks.NewMockStore(ctrl)
 
-	service := setupSyncService(
-		ctrl,
-		mockClient,
-		mockSyncState,
-		mockRepository,
-		mockPullRequest,
-		mockNotification,
-		mockUserStore,
-	)
+			mockNotification.EXPECT().
+				GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+				Return(db.Notification{
+					GithubID:     "notif-123",
+					RepositoryID: 1,
+					SubjectType:  "Issue",
+					SubjectURL: sql.NullString{
+						String: "https://api.github.com/repos/owner/test-repo/issues/7",
+						Valid:  true,
+					},
+				}, nil)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+			mockClient.EXPECT().
+				FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/7").
+				Return([]byte(subjectJSON), nil)
+			mockRepository.EXPECT().
+				GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+				Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
+			mockClient.EXPECT().TokenScopes().Return(tt.scopes, tt.scopesKnown)
+			if tt.lookup {
+				mockClient.EXPECT().
+					FetchSubjectProjects(gomock.Any(), "I_kwDOA").
+					Return(tt.projects, tt.projectsErr)
+			}
+			mockNotification.EXPECT().
+				UpdateNotificationSubject(gomock.Any(), "test-user-id", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params db.UpdateNotificationSubjectParams) error {
+					require.Equal(t, sql.NullString{String: "v2.0", Valid: true}, params.SubjectMilestone)
+					require.Equal(t, tt.wantProjects.Valid, params.SubjectProjects.Valid)
+					if tt.wantProjects.Valid {
+						require.JSONEq(t, string(tt.wantProjects.RawMessage), string(params.SubjectProjects.RawMessage))
+					}
+					return nil
+				})
 
-	_, err := service.RefreshSubjectData(context.Background(), "test-user-id", "nonexistent")
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				syncstatemocks.NewMockSyncStateService(ctrl),
+				mockRepository,
+				pullrequestmocks.NewMockPullRequestService(ctrl),
+				mockNotification,
+				mockUserStore,
+			)
 
-	require.Error(t, err)
-	require.Equal(t, sql.ErrNoRows, err)
+			_, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
+			require.NoError(t, err)
+		})
+	}
 }
 
-// TestProcessNotification_RetriableSubjectFetchError tests that retriable errors cause ProcessNotification to fail
-func TestProcessNotification_RetriableSubjectFetchError(t *testing.T) {
+// TestRefreshSubjectData_TitlesOnly tests that subjects aren't fetched when only titles are kept
+func TestRefreshSubjectData_TitlesOnly(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	thread := types.NotificationThread{
-		ID: "notif-123",
-		Repository: types.RepositorySnapshot{
-			ID:       789,
-			FullName: "owner/test-repo",
-			Name:     "test-repo",
-		},
-		Subject: types.NotificationSubject{
-			Title: "Test Issue",
-			Type:  "Issue",
-			URL:   "https://api.github.com/repos/owner/test-repo/issues/1",
-		},
-		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
-	}
-
-	mockClient := githubmocks.NewMockClient(ctrl)
-	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
-	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
-	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
 	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
 	mockUserStore := dbmocks.NewMockStore(ctrl)
 
-	mockRepository.EXPECT().
-		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
-		Return(db.Repository{ID: 1}, nil)
-
-	mockClient.EXPECT().
-		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
-		Return(nil, errors.New("github: subject status 500: internal server error"))
+	mockNotification.EXPECT().
+		GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+		Return(db.Notification{
+			GithubID:    "notif-123",
+			SubjectType: "Issue",
+			SubjectURL: sql.NullString{
+				String: "https://api.github.com/repos/owner/test-repo/issues/1",
+				Valid:  true,
+			},
+		}, nil)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+		SyncSettings: db.NullRawMessage{RawMessage: json.RawMessage(`{"titlesOnly": true}`), Valid: true},
+	}, nil)
 
 	service := setupSyncService(
 		ctrl,
-		mockClient,
-		mockSyncState,
-		mockRepository,
-		mockPullRequest,
+		githubmocks.NewMockClient(ctrl),
+		syncstatemocks.NewMockSyncStateService(ctrl),
+		repositorymocks.NewMockRepositoryService(ctrl),
+		pullrequestmocks.NewMockPullRequestService(ctrl),
 		mockNotification,
 		mockUserStore,
 	)
 
-	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+	wasMissing, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
 
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "fetch subject")
+	require.ErrorIs(t, err, ErrSubjectDetailsDisabled)
+	require.True(t, wasMissing)
 }
 
-// TestProcessNotification_NonRetriableSubjectFetchError tests that non-retriable errors allow processing to continue
-func TestProcessNotification_NonRetriableSubjectFetchError(t *testing.T) {
+// TestRefreshSubjectData_HeadersOnlyRepository tests that refreshing a notification from a
+// repository synced headers only is refused
+func TestRefreshSubjectData_HeadersOnlyRepository(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	thread := types.NotificationThread{
-		ID: "notif-123",
-		Repository: types.RepositorySnapshot{
-			ID:       789,
-			FullName: "owner/test-repo",
-			Name:     "test-repo",
-		},
-		Subject: types.NotificationSubject{
-			Title: "Test Issue",
-			Type:  "Issue",
-			URL:   "https://api.github.com/repos/owner/test-repo/issues/1",
-		},
-		UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
-	}
-
-	mockClient := githubmocks.NewMockClient(ctrl)
-	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
-	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
-	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
 	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
 	mockUserStore := dbmocks.NewMockStore(ctrl)
-
-	mockRepository.EXPECT().
-		UpsertRepository(gomock.Any(), "test-user-id", gomock.Any()).
-		Return(db.Repository{ID: 1}, nil)
-
-	mockClient.EXPECT().
-		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
-		Return(nil, errors.New("github: subject status 403: forbidden"))
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
 
 	mockNotification.EXPECT().
-		UpsertNotification(gomock.Any(), "test-user-id", gomock.Any()).
-		Return(db.Notification{ID: 1, GithubID: "notif-123"}, nil)
+		GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
+		Return(db.Notification{
+			GithubID:     "notif-123",
+			RepositoryID: 1,
+			SubjectType:  "Issue",
+			SubjectURL: sql.NullString{
+				String: "https://api.github.com/repos/owner/test-repo/issues/1",
+				Valid:  true,
+			},
+		}, nil)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+	mockRepository.EXPECT().
+		GetRepositorySettings(gomock.Any(), "test-user-id", int64(1)).
+		Return(models.RepositorySettings{RepositoryID: 1, SyncMode: models.RepositorySyncHeadersOnly}, nil)
 
 	service := setupSyncService(
 		ctrl,
-		mockClient,
-		mockSyncState,
+		githubmocks.NewMockClient(ctrl),
+		syncstatemocks.NewMockSyncStateService(ctrl),
 		mockRepository,
-		mockPullRequest,
+		pullrequestmocks.NewMockPullRequestService(ctrl),
 		mockNotification,
 		mockUserStore,
 	)
 
-	err := service.ProcessNotification(context.Background(), "test-user-id", thread)
+	wasMissing, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
 
-	require.NoError(t, err)
+	require.ErrorIs(t, err, ErrSubjectDetailsDisabled)
+	require.True(t, wasMissing)
 }
 
-// TestRefreshSubjectData_ExtractsAuthor tests that RefreshSubjectData extracts and saves author information
-func TestRefreshSubjectData_ExtractsAuthor(t *testing.T) {
+// TestApplySubjectData_UsesDeliveredData tests that ApplySubjectData stores subject data
+// without fetching it from GitHub
+func TestApplySubjectData_UsesDeliveredData(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockClient := githubmocks.NewMockClient(ctrl)
-	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
-	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
-	mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
 	mockNotification := notificationmocks.NewMockNotificationService(ctrl)
 	mockUserStore := dbmocks.NewMockStore(ctrl)
+	mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+	expectRepositorySettings(mockRepository)
 
-	notif := db.Notification{
-		ID:           1,
-		UserID:       "test-user-id",
-		GithubID:     "notif-123",
-		RepositoryID: 1,
-		SubjectType:  "Issue", // Not a PullRequest, so GetRepositoryByID won't be called
-		SubjectURL: sql.NullString{
-			String: "https://api.github.com/repos/owner/test-repo/issues/1",
-			Valid:  true,
-		},
-		SubjectFetchedAt: sql.NullTime{Valid: false},
-		AuthorLogin:      sql.NullString{Valid: false},
-	}
 	mockNotification.EXPECT().
 		GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
-		Return(notif, nil)
-
-	subjectJSON := `{"id": 1, "number": 42, "title": "Test Issue", "user": {"login": "testuser", "id": 12345}}`
-	mockClient.EXPECT().
-		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
-		Return([]byte(subjectJSON), nil)
-
+		Return(db.Notification{
+			GithubID:     "notif-123",
+			RepositoryID: 1,
+			SubjectType:  "Issue",
+		}, nil)
+	mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
+	mockRepository.EXPECT().
+		GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+		Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
 	mockNotification.EXPECT().
 		UpdateNotificationSubject(gomock.Any(), "test-user-id", gomock.Any()).
 		DoAndReturn(func(_ context.Context, _ string, params db.UpdateNotificationSubjectParams) error {
-			require.True(t, params.AuthorLogin.Valid)
+			require.Equal(t, "notif-123", params.GithubID)
+			require.Equal(t, "closed", params.SubjectState.String)
 			require.Equal(t, "testuser", params.AuthorLogin.String)
-			require.True(t, params.AuthorID.Valid)
-			require.Equal(t, int64(12345), params.AuthorID.Int64)
+			require.True(t, params.SubjectFetchedAt.Valid)
 			return nil
 		})
 
 	service := setupSyncService(
 		ctrl,
 		mockClient,
-		mockSyncState,
+		syncstatemocks.NewMockSyncStateService(ctrl),
 		mockRepository,
-		mockPullRequest,
+		pullrequestmocks.NewMockPullRequestService(ctrl),
 		mockNotification,
 		mockUserStore,
 	)
 
-	wasMissing, err := service.RefreshSubjectData(context.Background(), "test-user-id", "notif-123")
-
+	err := service.ApplySubjectData(
+		context.Background(),
+		"test-user-id",
+		"notif-123",
+		[]byte(`{"number": 42, "state": "closed", "user": {"login": "testuser", "id": 12345}}`),
+	)
 	require.NoError(t, err)
-	require.True(t, wasMissing)
 }
 
 // TestRefreshSubjectData_WasMissing tests the wasMissing return value
@@ -607,6 +1824,7 @@ func TestRefreshSubjectData_WasMissing(t *testing.T) {
 			mockClient := githubmocks.NewMockClient(ctrl)
 			mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
 			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
+			expectRepositorySettings(mockRepository)
 			mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
 			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
 			mockUserStore := dbmocks.NewMockStore(ctrl)
@@ -616,7 +1834,7 @@ func TestRefreshSubjectData_WasMissing(t *testing.T) {
 				UserID:       "test-user-id",
 				GithubID:     "notif-123",
 				RepositoryID: 1,
-				SubjectType:  "Issue", // Not a PullRequest, so GetRepositoryByID won't be called
+				SubjectType:  "Issue",
 				SubjectURL: sql.NullString{
 					String: "https://api.github.com/repos/owner/test-repo/issues/1",
 					Valid:  true,
@@ -627,12 +1845,15 @@ func TestRefreshSubjectData_WasMissing(t *testing.T) {
 			mockNotification.EXPECT().
 				GetByGithubID(gomock.Any(), "test-user-id", "notif-123").
 				Return(notif, nil)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{}, nil)
 
 			mockClient.EXPECT().
 				FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/test-repo/issues/1").
 				Return([]byte(subjectJSON), nil)
 
-			// GetRepositoryByID not called for Issue type
+			mockRepository.EXPECT().
+				GetRepositoryByID(gomock.Any(), "test-user-id", int64(1)).
+				Return(db.Repository{ID: 1, FullName: "owner/test-repo"}, nil)
 			mockNotification.EXPECT().
 				UpdateNotificationSubject(gomock.Any(), "test-user-id", gomock.Any()).
 				Return(nil)
@@ -670,7 +1891,7 @@ func intPtr(i int) *int {
 
 // TestCalculateSyncSinceDate tests the calculateSyncSinceDate helper
 func TestCalculateSyncSinceDate(t *testing.T) {
-	now := time.Now().UTC()
+	now := time.Now()
 
 	tests := []struct {
 		name         string
@@ -701,7 +1922,7 @@ func TestCalculateSyncSinceDate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateSyncSinceDate(tt.days)
+			result := calculateSyncSinceDate(now, tt.days)
 
 			diff := now.Sub(result)
 			require.InDelta(t, tt.expectedDiff.Seconds(), diff.Seconds(), 1.0)
@@ -935,6 +2156,59 @@ func TestGetSyncContext(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "resuming an interrupted initial sync",
+			setupMocks: func(ctrl *gomock.Controller) (db.Store, syncstate.SyncStateService) {
+				mockUserStore := dbmocks.NewMockStore(ctrl)
+				syncSettingsJSON := `{"setupCompleted": true}`
+				user := db.User{
+					ID: 1,
+					SyncSettings: db.NullRawMessage{
+						RawMessage: json.RawMessage(syncSettingsJSON),
+						Valid:      true,
+					},
+				}
+				mockUserStore.EXPECT().
+					GetUser(gomock.Any()).
+					Return(user, nil)
+
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				mockSyncState.EXPECT().
+					GetSyncState(gomock.Any(), "test-user-id").
+					Return(models.SyncState{
+						LatestNotificationAt: sql.NullTime{
+							Time:  time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+							Valid: true,
+						},
+						OldestNotificationSyncedAt: sql.NullTime{
+							Time:  time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+							Valid: true,
+						},
+						InitialSyncStartedAt: sql.NullTime{
+							Time:  time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+							Valid: true,
+						},
+						InitialSyncPages:  4,
+						InitialSyncQueued: 200,
+					}, nil)
+
+				return mockUserStore, mockSyncState
+			},
+			expectedContext: SyncContext{
+				UserID:                     "test-user-id",
+				IsSyncConfigured:           true,
+				IsInitialSync:              true,
+				OldestNotificationSyncedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				InitialSyncProgress: models.InitialSyncProgress{
+					StartedAt:          time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+					Pages:              4,
+					Queued:             200,
+					LatestNotification: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+					OldestNotification: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				},
+			},
+			expectError: false,
+		},
 		{
 			name: "regular sync (initial sync already completed)",
 			setupMocks: func(ctrl *gomock.Controller) (db.Store, syncstate.SyncStateService) {
@@ -959,6 +2233,7 @@ func TestGetSyncContext(t *testing.T) {
 					Return(models.SyncState{
 						LatestNotificationAt:   sql.NullTime{Valid: true, Time: latestNotification},
 						InitialSyncCompletedAt: sql.NullTime{Valid: true, Time: completedAt},
+						LastNotificationETag:   sql.NullString{Valid: true, String: `W/"abc"`},
 					}, nil)
 
 				return mockUserStore, mockSyncState
@@ -969,6 +2244,7 @@ func TestGetSyncContext(t *testing.T) {
 				IsInitialSync:    false,
 				SinceTimestamp:   timePtr(time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)),
 				UnreadOnly:       false,
+				NotificationETag: `W/"abc"`,
 			},
 			expectError: false,
 		},
@@ -1067,6 +2343,7 @@ func TestGetSyncContext(t *testing.T) {
 
 			mockUserStore, mockSyncState := tt.setupMocks(ctrl)
 			mockClient := githubmocks.NewMockClient(ctrl)
+			mockClient.EXPECT().ClockSkew().Return(time.Duration(0)).AnyTimes()
 			mockRepository := repositorymocks.NewMockRepositoryService(ctrl)
 			mockPullRequest := pullrequestmocks.NewMockPullRequestService(ctrl)
 			mockNotification := notificationmocks.NewMockNotificationService(ctrl)
@@ -1091,6 +2368,8 @@ func TestGetSyncContext(t *testing.T) {
 				require.Equal(t, tt.expectedContext.IsSyncConfigured, result.IsSyncConfigured)
 				require.Equal(t, tt.expectedContext.IsInitialSync, result.IsInitialSync)
 				require.Equal(t, tt.expectedContext.UnreadOnly, result.UnreadOnly)
+				require.Equal(t, tt.expectedContext.NotificationETag, result.NotificationETag)
+				require.Equal(t, tt.expectedContext.InitialSyncProgress, result.InitialSyncProgress)
 
 				if tt.expectedContext.MaxCount != nil {
 					require.NotNil(t, result.MaxCount)
@@ -1108,6 +2387,114 @@ func TestGetSyncContext(t *testing.T) {
 	}
 }
 
+// TestGetSyncContext_ClockSkew tests that 'since' is computed from GitHub's clock
+func TestGetSyncContext_ClockSkew(t *testing.T) {
+	skew := -3 * time.Hour // local clock is three hours fast
+	githubNow := mockClock().Add(skew)
+
+	tests := []struct {
+		name          string
+		syncSettings  string
+		state         models.SyncState
+		expectedSince time.Time
+	}{
+		{
+			name:          "initial sync window starts from GitHub's now",
+			syncSettings:  `{"initialSyncDays": 7, "setupCompleted": true}`,
+			expectedSince: githubNow.AddDate(0, 0, -7),
+		},
+		{
+			name:         "latest notification ahead of GitHub's clock is clamped",
+			syncSettings: `{"setupCompleted": true}`,
+			state: models.SyncState{
+				LatestNotificationAt:   sql.NullTime{Valid: true, Time: mockClock()},
+				InitialSyncCompletedAt: sql.NullTime{Valid: true, Time: mockClock()},
+			},
+			expectedSince: githubNow,
+		},
+		{
+			name:         "latest notification in the past is used as is",
+			syncSettings: `{"setupCompleted": true}`,
+			state: models.SyncState{
+				LatestNotificationAt:   sql.NullTime{Valid: true, Time: githubNow.Add(-time.Hour)},
+				InitialSyncCompletedAt: sql.NullTime{Valid: true, Time: mockClock()},
+			},
+			expectedSince: githubNow.Add(-time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserStore := dbmocks.NewMockStore(ctrl)
+			mockUserStore.EXPECT().GetUser(gomock.Any()).Return(db.User{
+				SyncSettings: db.NullRawMessage{
+					RawMessage: json.RawMessage(tt.syncSettings),
+					Valid:      true,
+				},
+			}, nil)
+			mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+			mockSyncState.EXPECT().GetSyncState(gomock.Any(), "test-user-id").Return(tt.state, nil)
+			mockClient := githubmocks.NewMockClient(ctrl)
+			mockClient.EXPECT().ClockSkew().Return(skew)
+
+			service := setupSyncService(
+				ctrl,
+				mockClient,
+				mockSyncState,
+				repositorymocks.NewMockRepositoryService(ctrl),
+				pullrequestmocks.NewMockPullRequestService(ctrl),
+				notificationmocks.NewMockNotificationService(ctrl),
+				mockUserStore,
+			)
+
+			result, err := service.GetSyncContext(context.Background(), "test-user-id")
+			require.NoError(t, err)
+			require.NotNil(t, result.SinceTimestamp)
+			require.True(t, tt.expectedSince.Equal(*result.SinceTimestamp),
+				"expected %s, got %s", tt.expectedSince, *result.SinceTimestamp)
+		})
+	}
+}
+
+// TestUpdateSyncStateAfterProcessing_ClockSkew tests that the poll time uses GitHub's clock
+func TestUpdateSyncStateAfterProcessing_ClockSkew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	skew := 90 * time.Minute
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ClockSkew().Return(skew)
+	mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+	mockSyncState.EXPECT().
+		UpsertSyncStateWithInitialSync(
+			gomock.Any(), "test-user-id", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		).
+		DoAndReturn(func(
+			_ context.Context,
+			_ string,
+			lastSuccessfulPoll, _, _, _ *time.Time,
+		) (models.SyncState, error) {
+			require.True(t, mockClock().Add(skew).Equal(*lastSuccessfulPoll))
+			return models.SyncState{}, nil
+		})
+
+	service := setupSyncService(
+		ctrl,
+		mockClient,
+		mockSyncState,
+		repositorymocks.NewMockRepositoryService(ctrl),
+		pullrequestmocks.NewMockPullRequestService(ctrl),
+		notificationmocks.NewMockNotificationService(ctrl),
+		dbmocks.NewMockStore(ctrl),
+	)
+
+	err := service.UpdateSyncStateAfterProcessing(context.Background(), "test-user-id", time.Time{})
+	require.NoError(t, err)
+}
+
 // TestIsInitialSyncComplete tests the IsInitialSyncComplete method
 func TestIsInitialSyncComplete(t *testing.T) {
 	tests := []struct {
@@ -36,6 +36,7 @@ import (
 
 	"github.com/octobud-hq/octobud/backend/assets"
 	"github.com/octobud-hq/octobud/backend/internal/api/navigation"
+	"github.com/octobud-hq/octobud/backend/internal/models"
 	"github.com/octobud-hq/octobud/backend/internal/osactions"
 )
 
@@ -63,6 +64,10 @@ type Tray struct {
 	// OS actions service for browser tab activation
 	osActionsSvc osactions.Service
 
+	// Workspace submenu items by slug, and the callback for switching workspaces
+	workspaceItems    map[string]*systray.MenuItem
+	onSelectWorkspace func(slug string)
+
 	// Menu items (for updating)
 	mStatus      *systray.MenuItem
 	mInbox       *systray.MenuItem
@@ -73,6 +78,7 @@ type Tray struct {
 	mMute1h      *systray.MenuItem
 	mMuteRestDay *systray.MenuItem
 	mUnmute      *systray.MenuItem
+	mWorkspace   *systray.MenuItem
 	mSettings    *systray.MenuItem
 	mQuit        *systray.MenuItem
 }
@@ -88,9 +94,10 @@ func RunWithApp(baseURL string, logger *zap.Logger, onAppReady func(t *Tray), on
 	}
 	osActionsSvc := osactions.NewService()
 	t := &Tray{
-		baseURL:      baseURL,
-		logger:       logger,
-		osActionsSvc: osActionsSvc,
+		baseURL:        baseURL,
+		logger:         logger,
+		osActionsSvc:   osActionsSvc,
+		workspaceItems: make(map[string]*systray.MenuItem),
 	}
 
 	systray.Run(func() {
@@ -140,6 +147,10 @@ func (t *Tray) onReady() {
 	t.mUnmute = t.mMute.AddSubMenuItem("Unmute", "Unmute notifications")
 	t.mUnmute.Disable() // Disabled by default, enabled when muted
 
+	// Workspace submenu, filled in by SetWorkspaces and hidden while there's only one
+	t.mWorkspace = systray.AddMenuItem("Workspace", "Switch workspace")
+	t.mWorkspace.Hide()
+
 	// Settings
 	t.mSettings = systray.AddMenuItem("Settings", "Open Octobud settings")
 
@@ -207,6 +218,63 @@ func (t *Tray) SetNavigationBroadcaster(broadcaster *navigation.Broadcaster) {
 	t.navBroadcaster = broadcaster
 }
 
+// SetWorkspaces updates the Workspace submenu. onSelect is called with the slug of the
+// workspace the user picks.
+func (t *Tray) SetWorkspaces(workspaces []models.Workspace, onSelect func(slug string)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.mWorkspace == nil {
+		return
+	}
+	t.onSelectWorkspace = onSelect
+
+	listed := make(map[string]bool, len(workspaces))
+	for _, ws := range workspaces {
+		listed[ws.Slug] = true
+		item, ok := t.workspaceItems[ws.Slug]
+		if !ok {
+			item = t.mWorkspace.AddSubMenuItemCheckbox(ws.Name, "Switch to "+ws.Name, false)
+			t.workspaceItems[ws.Slug] = item
+			go t.handleWorkspaceClicks(ws.Slug, item)
+		}
+		item.SetTitle(ws.Name)
+		item.Show()
+		if ws.Active {
+			item.Check()
+			t.mWorkspace.SetTitle("Workspace: " + ws.Name)
+		} else {
+			item.Uncheck()
+		}
+	}
+
+	// Menu items can't be removed, so deleted workspaces are hidden
+	for slug, item := range t.workspaceItems {
+		if !listed[slug] {
+			item.Hide()
+		}
+	}
+
+	if len(workspaces) > 1 {
+		t.mWorkspace.Show()
+	} else {
+		t.mWorkspace.Hide()
+	}
+}
+
+func (t *Tray) handleWorkspaceClicks(slug string, item *systray.MenuItem) {
+	for range item.ClickedCh {
+		t.mu.RLock()
+		onSelect := t.onSelectWorkspace
+		t.mu.RUnlock()
+
+		t.logger.Info("Menu action: Switch workspace", zap.String("workspace", slug))
+		if onSelect != nil {
+			onSelect(slug)
+		}
+	}
+}
+
 // SetUnreadCount updates the unread notification count displayed in the menu.
 func (t *Tray) SetUnreadCount(count int) {
 	t.mu.Lock()
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
 )
 
 // Tray is a no-op on non-darwin platforms.
@@ -57,3 +59,6 @@ func (t *Tray) SetSyncing(syncing bool) {}
 
 // SetNavigationBroadcaster is a no-op on non-darwin platforms.
 func (t *Tray) SetNavigationBroadcaster(broadcaster interface{}) {}
+
+// SetWorkspaces is a no-op on non-darwin platforms.
+func (t *Tray) SetWorkspaces(workspaces []models.Workspace, onSelect func(slug string)) {}
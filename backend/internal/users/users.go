@@ -0,0 +1,545 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package users manages the people who sign in to a multi-user server. Users are kept in
+// a file in the base data directory next to the workspaces file, and each has a
+// workspace of their own holding their data.
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+const (
+	// FileName is the file in the base data directory that lists users
+	FileName = "users.json"
+	// MinPasswordLength is the shortest password accepted
+	MinPasswordLength = 8
+
+	dirName           = "users"
+	maxNameLength     = 50
+	maxPasswordLength = 256
+	bootstrapUsername = "admin"
+)
+
+// Error definitions
+var (
+	ErrNotFound           = errors.New("user not found")
+	ErrAlreadyExists      = errors.New("user already exists")
+	ErrInvalidUsername    = errors.New("invalid username")
+	ErrInvalidName        = errors.New("invalid name")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrSessionRevoked     = errors.New("session revoked")
+	ErrLastAdmin          = errors.New("cannot remove the last admin")
+	ErrDeleteDefault      = errors.New("cannot delete the user with the default workspace")
+	ErrFailedToPersist    = errors.New("failed to save users")
+	ErrFailedToOpen       = errors.New("failed to open the user's workspace")
+)
+
+// usernamePattern allows lowercase logins and email addresses, as passed by most proxies
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._@+-]{0,63}$`)
+
+// Hooks start and stop users' workspaces as users are added and removed
+type Hooks struct {
+	// Added brings up a new user's workspace. If it returns an error, the user isn't added.
+	Added func(user models.ServerUser, dataDir string) error
+	// Removed stops a deleted user's workspace before its data is removed
+	Removed func(user models.ServerUser)
+}
+
+// CreateParams describes a new user. Password may be empty for users who sign in
+// through a proxy.
+type CreateParams struct {
+	Username string
+	Name     string
+	Role     string
+	Password string
+}
+
+// UpdateParams changes the fields that are set
+type UpdateParams struct {
+	Name     *string
+	Role     *string
+	Password *string
+}
+
+// entry is a user as stored in FileName
+type entry struct {
+	Username     string    `json:"username"`
+	Name         string    `json:"name,omitempty"`
+	Role         string    `json:"role"`
+	Workspace    string    `json:"workspace"`
+	PasswordHash string    `json:"passwordHash,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	// SessionVersion is bumped to sign the user out everywhere, e.g. on a password change
+	SessionVersion int `json:"sessionVersion"`
+}
+
+// fileState is the contents of FileName
+type fileState struct {
+	Users []entry `json:"users"`
+}
+
+// Manager tracks the users of a server and checks their credentials
+type Manager struct {
+	baseDir string
+	now     func() time.Time
+
+	// writeMu serializes changes, which can be slow while a workspace comes up or shuts
+	// down; mu guards state
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	state   fileState
+	hooks   Hooks
+}
+
+// NewManager loads the users stored in baseDir. Without a users file there are none.
+func NewManager(baseDir string, now func() time.Time) (*Manager, error) {
+	m := &Manager{baseDir: baseDir, now: now}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, FileName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	default:
+		if err := json.Unmarshal(data, &m.state); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+		}
+	}
+	return m, nil
+}
+
+// SetHooks sets the functions run as users are added and removed
+func (m *Manager) SetHooks(hooks Hooks) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = hooks
+}
+
+// List returns all users in the order they were added
+func (m *Manager) List() []models.ServerUser {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]models.ServerUser, 0, len(m.state.Users))
+	for _, e := range m.state.Users {
+		users = append(users, e.toModel())
+	}
+	return users
+}
+
+// Get returns a user by username, which is matched case-insensitively
+func (m *Manager) Get(username string) (models.ServerUser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i, ok := m.find(username)
+	if !ok {
+		return models.ServerUser{}, ErrNotFound
+	}
+	return m.state.Users[i].toModel(), nil
+}
+
+// DataDir returns the directory holding a user's workspace
+func (m *Manager) DataDir(user models.ServerUser) string {
+	if user.Workspace == workspace.DefaultSlug {
+		return m.baseDir
+	}
+	return filepath.Join(m.baseDir, dirName, user.Workspace)
+}
+
+// Create adds a user and brings up their workspace. The first user gets the default
+// workspace, so data from before multi-user mode was turned on belongs to them.
+func (m *Manager) Create(params CreateParams) (models.ServerUser, error) {
+	username, err := normalizeUsername(params.Username)
+	if err != nil {
+		return models.ServerUser{}, err
+	}
+	name, err := normalizeName(params.Name)
+	if err != nil {
+		return models.ServerUser{}, err
+	}
+	if params.Role != models.ServerUserRoleAdmin && params.Role != models.ServerUserRoleMember {
+		return models.ServerUser{}, errors.Join(
+			ErrInvalidRole, fmt.Errorf("role: %q", params.Role),
+		)
+	}
+	var passwordHash string
+	if params.Password != "" {
+		if passwordHash, err = newPasswordHash(params.Password); err != nil {
+			return models.ServerUser{}, err
+		}
+	}
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	m.mu.Lock()
+	if _, ok := m.find(username); ok {
+		m.mu.Unlock()
+		return models.ServerUser{}, errors.Join(
+			ErrAlreadyExists, fmt.Errorf("username: %s", username),
+		)
+	}
+	e := entry{
+		Username:     username,
+		Name:         name,
+		Role:         params.Role,
+		Workspace:    m.workspaceSlug(username),
+		PasswordHash: passwordHash,
+		CreatedAt:    m.now().UTC().Truncate(time.Second),
+	}
+	user := e.toModel()
+	previous := m.state
+	next := fileState{Users: append(append([]entry{}, previous.Users...), e)}
+	added := m.hooks.Added
+	m.mu.Unlock()
+
+	dataDir := m.DataDir(user)
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return models.ServerUser{}, errors.Join(ErrFailedToOpen, err)
+	}
+
+	// Record the user first, so a workspace never runs for a user that won't come back
+	// after a restart. They can't sign in until it's up.
+	if err := m.save(next); err != nil {
+		return models.ServerUser{}, err
+	}
+	if added != nil {
+		if err := added(user, dataDir); err != nil {
+			if saveErr := m.save(previous); saveErr != nil {
+				err = errors.Join(err, saveErr)
+			}
+			return models.ServerUser{}, errors.Join(ErrFailedToOpen, err)
+		}
+	}
+
+	m.mu.Lock()
+	m.state = next
+	m.mu.Unlock()
+	return user, nil
+}
+
+// Bootstrap creates an admin with a random password when there are no users yet, so a
+// new server can be signed in to. It returns the password, or "" if users already exist.
+func (m *Manager) Bootstrap() (models.ServerUser, string, error) {
+	m.mu.Lock()
+	empty := len(m.state.Users) == 0
+	m.mu.Unlock()
+	if !empty {
+		return models.ServerUser{}, "", nil
+	}
+
+	password := randomPassword()
+	user, err := m.Create(CreateParams{
+		Username: bootstrapUsername,
+		Role:     models.ServerUserRoleAdmin,
+		Password: password,
+	})
+	if err != nil {
+		return models.ServerUser{}, "", err
+	}
+	return user, password, nil
+}
+
+// Update changes a user's name, role or password. Setting a password signs the user out
+// of their other sessions.
+func (m *Manager) Update(username string, params UpdateParams) (models.ServerUser, error) {
+	e, err := m.update(username, params)
+	if err != nil {
+		return models.ServerUser{}, err
+	}
+	return e.toModel(), nil
+}
+
+// update applies UpdateParams and returns the stored entry
+func (m *Manager) update(username string, params UpdateParams) (entry, error) {
+	var passwordHash string
+	if params.Password != nil {
+		var err error
+		if passwordHash, err = newPasswordHash(*params.Password); err != nil {
+			return entry{}, err
+		}
+	}
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i, ok := m.find(username)
+	if !ok {
+		return entry{}, ErrNotFound
+	}
+	next := fileState{Users: append([]entry{}, m.state.Users...)}
+	e := &next.Users[i]
+	if params.Name != nil {
+		name, err := normalizeName(*params.Name)
+		if err != nil {
+			return entry{}, err
+		}
+		e.Name = name
+	}
+	if params.Role != nil {
+		role := *params.Role
+		if role != models.ServerUserRoleAdmin && role != models.ServerUserRoleMember {
+			return entry{}, errors.Join(ErrInvalidRole, fmt.Errorf("role: %q", role))
+		}
+		if role != models.ServerUserRoleAdmin && e.Role == models.ServerUserRoleAdmin &&
+			m.admins() == 1 {
+			return entry{}, ErrLastAdmin
+		}
+		e.Role = role
+	}
+	if params.Password != nil {
+		e.PasswordHash = passwordHash
+		e.SessionVersion++
+	}
+
+	if err := m.save(next); err != nil {
+		return entry{}, err
+	}
+	m.state = next
+	return next.Users[i], nil
+}
+
+// ChangePassword sets a user's own password after checking their current one. It returns
+// the new session version so the caller can sign them back in.
+func (m *Manager) ChangePassword(username, current, password string) (int, error) {
+	if _, _, err := m.Authenticate(username, current); err != nil {
+		return 0, err
+	}
+	e, err := m.update(username, UpdateParams{Password: &password})
+	if err != nil {
+		return 0, err
+	}
+	return e.SessionVersion, nil
+}
+
+// Delete removes a user and their workspace's data. The user with the default workspace
+// can't be deleted, since its data directory holds everyone else's too.
+func (m *Manager) Delete(username string) error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	m.mu.Lock()
+	i, ok := m.find(username)
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	e := m.state.Users[i]
+	if e.Workspace == workspace.DefaultSlug {
+		m.mu.Unlock()
+		return ErrDeleteDefault
+	}
+	if e.Role == models.ServerUserRoleAdmin && m.admins() == 1 {
+		m.mu.Unlock()
+		return ErrLastAdmin
+	}
+
+	next := fileState{Users: make([]entry, 0, len(m.state.Users)-1)}
+	for _, other := range m.state.Users {
+		if other.Username != e.Username {
+			next.Users = append(next.Users, other)
+		}
+	}
+	if err := m.save(next); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	m.state = next
+	removed := m.hooks.Removed
+	m.mu.Unlock()
+
+	user := e.toModel()
+	if removed != nil {
+		removed(user)
+	}
+	if err := os.RemoveAll(m.DataDir(user)); err != nil {
+		return fmt.Errorf("failed to remove user data: %w", err)
+	}
+	return nil
+}
+
+// Authenticate checks a username and password, returning the user and their current
+// session version. Unknown users take as long to reject as wrong passwords.
+func (m *Manager) Authenticate(username, password string) (models.ServerUser, int, error) {
+	m.mu.Lock()
+	i, ok := m.find(username)
+	var e entry
+	if ok {
+		e = m.state.Users[i]
+	}
+	m.mu.Unlock()
+
+	if !ok || e.PasswordHash == "" {
+		checkPassword(dummyHash(), password)
+		return models.ServerUser{}, 0, ErrInvalidCredentials
+	}
+	if !checkPassword(e.PasswordHash, password) {
+		return models.ServerUser{}, 0, ErrInvalidCredentials
+	}
+	return e.toModel(), e.SessionVersion, nil
+}
+
+// CheckSession returns the user a session belongs to, as long as they still exist and
+// haven't been signed out everywhere since it was issued
+func (m *Manager) CheckSession(username string, version int) (models.ServerUser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i, ok := m.find(username)
+	if !ok {
+		return models.ServerUser{}, ErrNotFound
+	}
+	if m.state.Users[i].SessionVersion != version {
+		return models.ServerUser{}, ErrSessionRevoked
+	}
+	return m.state.Users[i].toModel(), nil
+}
+
+// dummyHash is checked against when a user doesn't exist, so a login takes as long
+// either way
+var dummyHash = sync.OnceValue(func() string {
+	hash, err := hashPassword(randomPassword())
+	if err != nil {
+		panic(fmt.Sprintf("failed to hash password: %v", err))
+	}
+	return hash
+})
+
+// find returns the index of a user; callers must hold mu
+func (m *Manager) find(username string) (int, bool) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	for i, e := range m.state.Users {
+		if e.Username == username {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// admins counts the admins; callers must hold mu
+func (m *Manager) admins() int {
+	count := 0
+	for _, e := range m.state.Users {
+		if e.Role == models.ServerUserRoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
+// workspaceSlug picks the workspace for a new user: the default one if nobody has it,
+// otherwise a slug derived from the username that isn't taken. Callers must hold mu.
+func (m *Manager) workspaceSlug(username string) string {
+	taken := make(map[string]bool, len(m.state.Users))
+	for _, e := range m.state.Users {
+		taken[e.Workspace] = true
+	}
+	if !taken[workspace.DefaultSlug] {
+		return workspace.DefaultSlug
+	}
+
+	base := models.Slugify(username)
+	if base == "" || base == workspace.DefaultSlug {
+		base = "user"
+	}
+	slug := base
+	for n := 2; taken[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug
+}
+
+// save writes state to FileName, replacing the old file atomically; callers must hold mu
+func (m *Manager) save(state fileState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+
+	path := filepath.Join(m.baseDir, FileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+	return nil
+}
+
+func (e entry) toModel() models.ServerUser {
+	return models.ServerUser{
+		Username:    e.Username,
+		Name:        e.Name,
+		Role:        e.Role,
+		Workspace:   e.Workspace,
+		HasPassword: e.PasswordHash != "",
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+// normalizeUsername lowercases a username and checks its characters
+func normalizeUsername(username string) (string, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if !usernamePattern.MatchString(username) {
+		return "", errors.Join(ErrInvalidUsername, errors.New(
+			"username must be 1-64 letters, digits or . _ @ + - and start with a letter or digit",
+		))
+	}
+	return username, nil
+}
+
+// normalizeName trims a display name
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) > maxNameLength {
+		return "", errors.Join(
+			ErrInvalidName, fmt.Errorf("name must be at most %d characters", maxNameLength),
+		)
+	}
+	return name, nil
+}
+
+// newPasswordHash checks a password's length and hashes it
+func newPasswordHash(password string) (string, error) {
+	if len(password) < MinPasswordLength || len(password) > maxPasswordLength {
+		return "", errors.Join(ErrInvalidPassword, fmt.Errorf(
+			"password must be %d-%d characters", MinPasswordLength, maxPasswordLength,
+		))
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return "", errors.Join(ErrFailedToPersist, err)
+	}
+	return hash, nil
+}
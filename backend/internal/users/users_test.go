@@ -0,0 +1,218 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package users
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+	"github.com/octobud-hq/octobud/backend/internal/workspace"
+)
+
+func newTestManager(t *testing.T) (*Manager, string) {
+	t.Helper()
+	base := t.TempDir()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m, err := NewManager(base, func() time.Time { return now })
+	require.NoError(t, err)
+	return m, base
+}
+
+func TestManager_Create(t *testing.T) {
+	m, base := newTestManager(t)
+
+	var opened []string
+	m.SetHooks(Hooks{Added: func(user models.ServerUser, dataDir string) error {
+		opened = append(opened, user.Username+"="+dataDir)
+		return nil
+	}})
+
+	first, err := m.Create(CreateParams{
+		Username: " Alice ",
+		Name:     "Alice",
+		Role:     models.ServerUserRoleAdmin,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.ServerUser{
+		Username:    "alice",
+		Name:        "Alice",
+		Role:        models.ServerUserRoleAdmin,
+		Workspace:   workspace.DefaultSlug,
+		HasPassword: true,
+		CreatedAt:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}, first)
+
+	second, err := m.Create(CreateParams{Username: "bob@example.com", Role: models.ServerUserRoleMember})
+	require.NoError(t, err)
+	require.Equal(t, "bob-example-com", second.Workspace)
+	require.False(t, second.HasPassword)
+	require.DirExists(t, filepath.Join(base, "users", "bob-example-com"))
+
+	require.Equal(t, []string{
+		"alice=" + base,
+		"bob@example.com=" + filepath.Join(base, "users", "bob-example-com"),
+	}, opened)
+
+	_, err = m.Create(CreateParams{Username: "ALICE", Role: models.ServerUserRoleMember})
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = m.Create(CreateParams{Username: "-bad", Role: models.ServerUserRoleMember})
+	require.ErrorIs(t, err, ErrInvalidUsername)
+	_, err = m.Create(CreateParams{Username: "carol", Role: "owner"})
+	require.ErrorIs(t, err, ErrInvalidRole)
+	_, err = m.Create(CreateParams{Username: "carol", Role: models.ServerUserRoleMember, Password: "short"})
+	require.ErrorIs(t, err, ErrInvalidPassword)
+
+	// Users are kept across restarts
+	reloaded, err := NewManager(base, time.Now)
+	require.NoError(t, err)
+	require.Equal(t, m.List(), reloaded.List())
+}
+
+func TestManager_Create_HookFailure(t *testing.T) {
+	m, base := newTestManager(t)
+	m.SetHooks(Hooks{Added: func(models.ServerUser, string) error {
+		return errors.New("migrations failed")
+	}})
+
+	_, err := m.Create(CreateParams{Username: "alice", Role: models.ServerUserRoleAdmin})
+	require.ErrorIs(t, err, ErrFailedToOpen)
+	require.Empty(t, m.List())
+
+	reloaded, err := NewManager(base, time.Now)
+	require.NoError(t, err)
+	require.Empty(t, reloaded.List())
+}
+
+func TestManager_Authenticate(t *testing.T) {
+	m, _ := newTestManager(t)
+	_, err := m.Create(CreateParams{
+		Username: "alice",
+		Role:     models.ServerUserRoleAdmin,
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+
+	user, version, err := m.Authenticate("Alice", "correct horse")
+	require.NoError(t, err)
+	require.Equal(t, "alice", user.Username)
+
+	_, _, err = m.Authenticate("alice", "wrong horse")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, _, err = m.Authenticate("nobody", "correct horse")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = m.CheckSession("alice", version)
+	require.NoError(t, err)
+
+	// A new password signs out existing sessions
+	newVersion, err := m.ChangePassword("alice", "correct horse", "battery staple")
+	require.NoError(t, err)
+	require.NotEqual(t, version, newVersion)
+	_, err = m.CheckSession("alice", version)
+	require.ErrorIs(t, err, ErrSessionRevoked)
+	_, err = m.CheckSession("alice", newVersion)
+	require.NoError(t, err)
+
+	_, err = m.ChangePassword("alice", "correct horse", "something else")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = m.CheckSession("nobody", 0)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManager_Update(t *testing.T) {
+	m, _ := newTestManager(t)
+	_, err := m.Create(CreateParams{Username: "alice", Role: models.ServerUserRoleAdmin})
+	require.NoError(t, err)
+	_, err = m.Create(CreateParams{Username: "bob", Role: models.ServerUserRoleMember})
+	require.NoError(t, err)
+
+	member := models.ServerUserRoleMember
+	_, err = m.Update("alice", UpdateParams{Role: &member})
+	require.ErrorIs(t, err, ErrLastAdmin)
+
+	admin := models.ServerUserRoleAdmin
+	name := "  Bob  "
+	bob, err := m.Update("bob", UpdateParams{Role: &admin, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "Bob", bob.Name)
+	require.True(t, bob.IsAdmin())
+
+	alice, err := m.Update("alice", UpdateParams{Role: &member})
+	require.NoError(t, err)
+	require.False(t, alice.IsAdmin())
+
+	_, err = m.Update("nobody", UpdateParams{Name: &name})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManager_Delete(t *testing.T) {
+	m, base := newTestManager(t)
+
+	var removed []string
+	m.SetHooks(Hooks{Removed: func(user models.ServerUser) {
+		removed = append(removed, user.Username)
+	}})
+
+	_, err := m.Create(CreateParams{Username: "alice", Role: models.ServerUserRoleAdmin})
+	require.NoError(t, err)
+	bob, err := m.Create(CreateParams{Username: "bob", Role: models.ServerUserRoleAdmin})
+	require.NoError(t, err)
+	carol, err := m.Create(CreateParams{Username: "carol", Role: models.ServerUserRoleMember})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, m.Delete("alice"), ErrDeleteDefault)
+	require.NoError(t, m.Delete("bob"))
+	require.NoDirExists(t, m.DataDir(bob))
+	require.DirExists(t, base)
+	require.ErrorIs(t, m.Delete("bob"), ErrNotFound)
+
+	require.NoError(t, m.Delete("carol"))
+	require.NoDirExists(t, m.DataDir(carol))
+	require.Equal(t, []string{"bob", "carol"}, removed)
+	require.Len(t, m.List(), 1)
+}
+
+func TestManager_Bootstrap(t *testing.T) {
+	m, _ := newTestManager(t)
+
+	user, password, err := m.Bootstrap()
+	require.NoError(t, err)
+	require.Equal(t, "admin", user.Username)
+	require.True(t, user.IsAdmin())
+	require.Equal(t, workspace.DefaultSlug, user.Workspace)
+	_, _, err = m.Authenticate("admin", password)
+	require.NoError(t, err)
+
+	_, password, err = m.Bootstrap()
+	require.NoError(t, err)
+	require.Empty(t, password)
+	require.Len(t, m.List(), 1)
+}
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	require.NoError(t, err)
+	require.True(t, checkPassword(hash, "correct horse"))
+	require.False(t, checkPassword(hash, "correct horsE"))
+	require.False(t, checkPassword("", "correct horse"))
+	require.False(t, checkPassword("bcrypt$10$abc$def", "correct horse"))
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workspace
+
+import "github.com/octobud-hq/octobud/backend/internal/xcrypto"
+
+// keychain stores tokens under a workspace-specific keychain service
+type keychain struct {
+	inner xcrypto.Keychain
+	slug  string
+}
+
+// Keychain namespaces stored tokens by workspace, so each workspace keeps its own GitHub
+// token even when two workspaces connect the same account. The default workspace uses the
+// keychain entries it used before workspaces existed.
+func Keychain(inner xcrypto.Keychain, slug string) xcrypto.Keychain {
+	if slug == DefaultSlug {
+		return inner
+	}
+	return &keychain{inner: inner, slug: slug}
+}
+
+func (k *keychain) service(service string) string {
+	return service + "." + k.slug
+}
+
+// StoreToken stores a token for this workspace
+func (k *keychain) StoreToken(service, account, token string) error {
+	return k.inner.StoreToken(k.service(service), account, token)
+}
+
+// GetToken retrieves this workspace's token
+func (k *keychain) GetToken(service, account string) (string, error) {
+	return k.inner.GetToken(k.service(service), account)
+}
+
+// DeleteToken removes this workspace's token
+func (k *keychain) DeleteToken(service, account string) error {
+	return k.inner.DeleteToken(k.service(service), account)
+}
+
+// TokenExists checks if this workspace has a token
+func (k *keychain) TokenExists(service, account string) (bool, error) {
+	return k.inner.TokenExists(k.service(service), account)
+}
@@ -0,0 +1,342 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package workspace manages named workspaces. Each workspace keeps its data in its own
+// directory, so one running server can switch between, e.g., "Work" and "OSS" profiles.
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+const (
+	// DefaultSlug is the workspace whose data lives directly in the base data directory,
+	// where it was before workspaces existed
+	DefaultSlug = "default"
+	// FileName is the file in the base data directory that lists workspaces
+	FileName = "workspaces.json"
+
+	defaultName   = "Default"
+	dirName       = "workspaces"
+	maxNameLength = 50
+)
+
+// Error definitions
+var (
+	ErrNotFound        = errors.New("workspace not found")
+	ErrAlreadyExists   = errors.New("workspace already exists")
+	ErrInvalidName     = errors.New("invalid workspace name")
+	ErrDeleteActive    = errors.New("cannot delete the active workspace")
+	ErrDeleteDefault   = errors.New("cannot delete the default workspace")
+	ErrNoSwitcher      = errors.New("workspace switching is not available")
+	ErrFailedToSwitch  = errors.New("failed to switch workspace")
+	ErrFailedToPersist = errors.New("failed to save workspaces")
+)
+
+// Switcher brings up the given workspace and retires the current one. If it returns an
+// error, the current workspace must still be running.
+type Switcher func(ws models.Workspace, dataDir string) error
+
+// entry is a workspace as stored in FileName
+type entry struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// fileState is the contents of FileName
+type fileState struct {
+	Active     string  `json:"active"`
+	Workspaces []entry `json:"workspaces"`
+}
+
+// Manager tracks the workspaces in a base data directory and which one is active
+type Manager struct {
+	baseDir string
+
+	// switchMu serializes switches, which can be slow; mu guards state
+	switchMu sync.Mutex
+	mu       sync.Mutex
+	state    fileState
+	switcher Switcher
+	onChange func()
+}
+
+// NewManager loads the workspaces stored in baseDir. Without a workspaces file, only the
+// default workspace exists.
+func NewManager(baseDir string) (*Manager, error) {
+	m := &Manager{baseDir: baseDir}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, FileName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	default:
+		if err := json.Unmarshal(data, &m.state); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+		}
+	}
+
+	// The default workspace is always listed first, and a missing active workspace falls
+	// back to it so a bad file can't stop the app from starting
+	if len(m.state.Workspaces) == 0 || m.state.Workspaces[0].Slug != DefaultSlug {
+		m.state.Workspaces = append(
+			[]entry{{Slug: DefaultSlug, Name: defaultName}},
+			m.state.Workspaces...,
+		)
+	}
+	if _, ok := m.find(m.state.Active); !ok {
+		m.state.Active = DefaultSlug
+	}
+
+	return m, nil
+}
+
+// SetSwitcher sets the function Activate uses to switch the running server over
+func (m *Manager) SetSwitcher(switcher Switcher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.switcher = switcher
+}
+
+// SetOnChange sets a callback run after workspaces are created, deleted, or switched
+func (m *Manager) SetOnChange(onChange func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = onChange
+}
+
+// List returns all workspaces, the default first
+func (m *Manager) List() []models.Workspace {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	workspaces := make([]models.Workspace, 0, len(m.state.Workspaces))
+	for _, e := range m.state.Workspaces {
+		workspaces = append(workspaces, m.toModel(e))
+	}
+	return workspaces
+}
+
+// Active returns the active workspace
+func (m *Manager) Active() models.Workspace {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, _ := m.find(m.state.Active)
+	return m.toModel(e)
+}
+
+// DataDir returns the directory holding a workspace's data
+func (m *Manager) DataDir(slug string) string {
+	if slug == DefaultSlug {
+		return m.baseDir
+	}
+	return filepath.Join(m.baseDir, dirName, slug)
+}
+
+// Create adds a workspace. Its data directory is created when it's first activated.
+func (m *Manager) Create(name string) (models.Workspace, error) {
+	name, slug, err := normalizeName(name)
+	if err != nil {
+		return models.Workspace{}, err
+	}
+
+	m.mu.Lock()
+	if _, ok := m.find(slug); ok {
+		m.mu.Unlock()
+		return models.Workspace{}, errors.Join(ErrAlreadyExists, fmt.Errorf("slug: %s", slug))
+	}
+	next := m.state
+	next.Workspaces = append([]entry{}, m.state.Workspaces...)
+	next.Workspaces = append(next.Workspaces, entry{Slug: slug, Name: name})
+	if err := m.save(next); err != nil {
+		m.mu.Unlock()
+		return models.Workspace{}, err
+	}
+	m.state = next
+	ws := m.toModel(entry{Slug: slug, Name: name})
+	m.mu.Unlock()
+
+	m.changed()
+	return ws, nil
+}
+
+// Activate switches the running server to a workspace. If the switcher fails, the
+// previous workspace stays active.
+func (m *Manager) Activate(slug string) (models.Workspace, error) {
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+
+	m.mu.Lock()
+	e, ok := m.find(slug)
+	switcher := m.switcher
+	previous := m.state
+	m.mu.Unlock()
+
+	if !ok {
+		return models.Workspace{}, ErrNotFound
+	}
+	if slug == previous.Active {
+		return m.Active(), nil
+	}
+	if switcher == nil {
+		return models.Workspace{}, ErrNoSwitcher
+	}
+
+	dataDir := m.DataDir(slug)
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return models.Workspace{}, errors.Join(ErrFailedToSwitch, err)
+	}
+
+	// Record the switch first, so the server never runs a workspace that won't come back
+	// after a restart
+	m.mu.Lock()
+	next := m.state
+	next.Active = slug
+	err := m.save(next)
+	m.mu.Unlock()
+	if err != nil {
+		return models.Workspace{}, err
+	}
+
+	ws := models.Workspace{Slug: e.Slug, Name: e.Name, Active: true}
+	if err := switcher(ws, dataDir); err != nil {
+		m.mu.Lock()
+		if saveErr := m.save(m.state); saveErr != nil {
+			err = errors.Join(err, saveErr)
+		}
+		m.mu.Unlock()
+		return models.Workspace{}, errors.Join(ErrFailedToSwitch, err)
+	}
+
+	m.mu.Lock()
+	m.state.Active = slug
+	m.mu.Unlock()
+
+	m.changed()
+	return ws, nil
+}
+
+// Delete removes a workspace and its data directory
+func (m *Manager) Delete(slug string) error {
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+
+	m.mu.Lock()
+	switch {
+	case slug == DefaultSlug:
+		m.mu.Unlock()
+		return ErrDeleteDefault
+	case slug == m.state.Active:
+		m.mu.Unlock()
+		return ErrDeleteActive
+	}
+	if _, ok := m.find(slug); !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+	next := m.state
+	next.Workspaces = make([]entry, 0, len(m.state.Workspaces)-1)
+	for _, e := range m.state.Workspaces {
+		if e.Slug != slug {
+			next.Workspaces = append(next.Workspaces, e)
+		}
+	}
+	if err := m.save(next); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	m.state = next
+	m.mu.Unlock()
+
+	m.changed()
+	if err := os.RemoveAll(m.DataDir(slug)); err != nil {
+		return fmt.Errorf("failed to remove workspace data: %w", err)
+	}
+	return nil
+}
+
+// find returns the stored entry for slug; callers must hold mu
+func (m *Manager) find(slug string) (entry, bool) {
+	for _, e := range m.state.Workspaces {
+		if e.Slug == slug {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+// toModel converts a stored entry; callers must hold mu
+func (m *Manager) toModel(e entry) models.Workspace {
+	return models.Workspace{Slug: e.Slug, Name: e.Name, Active: e.Slug == m.state.Active}
+}
+
+// save writes state to FileName, replacing the old file atomically; callers must hold mu
+func (m *Manager) save(state fileState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+
+	path := filepath.Join(m.baseDir, FileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(ErrFailedToPersist, err)
+	}
+	return nil
+}
+
+func (m *Manager) changed() {
+	m.mu.Lock()
+	onChange := m.onChange
+	m.mu.Unlock()
+
+	if onChange != nil {
+		onChange()
+	}
+}
+
+// normalizeName trims a workspace name and derives its slug
+func normalizeName(name string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength {
+		return "", "", errors.Join(
+			ErrInvalidName,
+			fmt.Errorf("name must be 1-%d characters", maxNameLength),
+		)
+	}
+	slug := models.Slugify(name)
+	if slug == "" {
+		return "", "", errors.Join(
+			ErrInvalidName, errors.New("name must contain a letter or digit"),
+		)
+	}
+	if slug == DefaultSlug {
+		return "", "", errors.Join(ErrAlreadyExists, fmt.Errorf("slug: %s", slug))
+	}
+	return name, slug, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/octobud-hq/octobud/backend/internal/models"
+)
+
+func TestNewManager_DefaultsWithoutFile(t *testing.T) {
+	base := t.TempDir()
+	m, err := NewManager(base)
+	require.NoError(t, err)
+
+	expected := []models.Workspace{{Slug: DefaultSlug, Name: "Default", Active: true}}
+	require.Equal(t, expected, m.List())
+	require.Equal(t, DefaultSlug, m.Active().Slug)
+	require.Equal(t, base, m.DataDir(DefaultSlug))
+	require.Equal(t, filepath.Join(base, "workspaces", "oss"), m.DataDir("oss"))
+}
+
+func TestNewManager_UnknownActiveFallsBackToDefault(t *testing.T) {
+	base := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(base, FileName),
+		[]byte(`{"active": "gone", "workspaces": [{"slug": "work", "name": "Work"}]}`),
+		0o600,
+	))
+
+	m, err := NewManager(base)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSlug, m.Active().Slug)
+	require.Equal(t, []models.Workspace{
+		{Slug: DefaultSlug, Name: "Default", Active: true},
+		{Slug: "work", Name: "Work"},
+	}, m.List())
+}
+
+func TestManager_Create(t *testing.T) {
+	m, err := NewManager(t.TempDir())
+	require.NoError(t, err)
+
+	changes := 0
+	m.SetOnChange(func() { changes++ })
+
+	ws, err := m.Create("  Open Source  ")
+	require.NoError(t, err)
+	require.Equal(t, models.Workspace{Slug: "open-source", Name: "Open Source"}, ws)
+	require.Equal(t, 1, changes)
+
+	_, err = m.Create("open source")
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = m.Create("Default")
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = m.Create("  ")
+	require.ErrorIs(t, err, ErrInvalidName)
+	_, err = m.Create("!!!")
+	require.ErrorIs(t, err, ErrInvalidName)
+	require.Len(t, m.List(), 2)
+	require.Equal(t, 1, changes)
+}
+
+func TestManager_Activate(t *testing.T) {
+	base := t.TempDir()
+	m, err := NewManager(base)
+	require.NoError(t, err)
+	_, err = m.Create("Work")
+	require.NoError(t, err)
+
+	_, err = m.Activate("work")
+	require.ErrorIs(t, err, ErrNoSwitcher)
+
+	var switchedTo models.Workspace
+	var switchedDir string
+	m.SetSwitcher(func(ws models.Workspace, dataDir string) error {
+		switchedTo, switchedDir = ws, dataDir
+		return nil
+	})
+
+	ws, err := m.Activate("work")
+	require.NoError(t, err)
+	require.Equal(t, models.Workspace{Slug: "work", Name: "Work", Active: true}, ws)
+	require.Equal(t, ws, switchedTo)
+	require.Equal(t, filepath.Join(base, "workspaces", "work"), switchedDir)
+	require.DirExists(t, switchedDir)
+	require.Equal(t, "work", m.Active().Slug)
+
+	_, err = m.Activate("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// The active workspace survives a restart
+	reloaded, err := NewManager(base)
+	require.NoError(t, err)
+	require.Equal(t, "work", reloaded.Active().Slug)
+}
+
+func TestManager_ActivateFailureKeepsPreviousWorkspace(t *testing.T) {
+	base := t.TempDir()
+	m, err := NewManager(base)
+	require.NoError(t, err)
+	_, err = m.Create("Work")
+	require.NoError(t, err)
+
+	m.SetSwitcher(func(models.Workspace, string) error {
+		return errors.New("database is locked")
+	})
+
+	_, err = m.Activate("work")
+	require.ErrorIs(t, err, ErrFailedToSwitch)
+	require.Equal(t, DefaultSlug, m.Active().Slug)
+
+	reloaded, err := NewManager(base)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSlug, reloaded.Active().Slug)
+}
+
+func TestManager_Delete(t *testing.T) {
+	m, err := NewManager(t.TempDir())
+	require.NoError(t, err)
+	m.SetSwitcher(func(models.Workspace, string) error { return nil })
+	_, err = m.Create("Work")
+	require.NoError(t, err)
+	_, err = m.Create("OSS")
+	require.NoError(t, err)
+	_, err = m.Activate("oss")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, m.Delete(DefaultSlug), ErrDeleteDefault)
+	require.ErrorIs(t, m.Delete("oss"), ErrDeleteActive)
+	require.ErrorIs(t, m.Delete("missing"), ErrNotFound)
+
+	workDir := m.DataDir("work")
+	require.NoError(t, os.MkdirAll(workDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "octobud.db"), nil, 0o600))
+
+	require.NoError(t, m.Delete("work"))
+	require.NoDirExists(t, workDir)
+	require.Equal(t, []string{DefaultSlug, "oss"}, slugs(m.List()))
+}
+
+func TestKeychain_NamespacesByWorkspace(t *testing.T) {
+	inner := fakeKeychain{}
+	require.NoError(t, Keychain(inner, DefaultSlug).StoreToken("io.octobud", "github_a", "a"))
+	require.Equal(t, "a", inner["io.octobud/github_a"])
+
+	work := Keychain(inner, "work")
+	require.NoError(t, work.StoreToken("io.octobud", "github_octocat", "work-token"))
+	require.NoError(t, inner.StoreToken("io.octobud", "github_octocat", "default-token"))
+
+	token, err := work.GetToken("io.octobud", "github_octocat")
+	require.NoError(t, err)
+	require.Equal(t, "work-token", token)
+	require.Equal(t, "default-token", inner["io.octobud/github_octocat"])
+
+	require.NoError(t, work.DeleteToken("io.octobud", "github_octocat"))
+	exists, err := work.TokenExists("io.octobud", "github_octocat")
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.Equal(t, "default-token", inner["io.octobud/github_octocat"])
+}
+
+func slugs(workspaces []models.Workspace) []string {
+	result := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		result = append(result, ws.Slug)
+	}
+	return result
+}
+
+// fakeKeychain stores tokens in memory, keyed by service/account
+type fakeKeychain map[string]string
+
+func (k fakeKeychain) StoreToken(service, account, token string) error {
+	k[service+"/"+account] = token
+	return nil
+}
+
+func (k fakeKeychain) GetToken(service, account string) (string, error) {
+	return k[service+"/"+account], nil
+}
+
+func (k fakeKeychain) DeleteToken(service, account string) error {
+	delete(k, service+"/"+account)
+	return nil
+}
+
+func (k fakeKeychain) TokenExists(service, account string) (bool, error) {
+	_, ok := k[service+"/"+account]
+	return ok, nil
+}
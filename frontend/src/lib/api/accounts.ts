@@ -0,0 +1,67 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// An additional GitHub account whose notifications are synced alongside the primary one
+export interface LinkedAccount {
+	githubUserId: string;
+	login: string;
+	lastSyncedAt?: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function listLinkedAccounts(fetchImpl?: typeof fetch): Promise<LinkedAccount[]> {
+	const response = await fetchAPI("/api/user/accounts", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load linked accounts"));
+	}
+	const data: { accounts: LinkedAccount[] } = await response.json();
+	return data.accounts;
+}
+
+// linkAccount validates the token with GitHub and starts syncing the account it belongs to
+export async function linkAccount(token: string, fetchImpl?: typeof fetch): Promise<LinkedAccount> {
+	const response = await fetchAPI(
+		"/api/user/accounts",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ token }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to link account"));
+	}
+	const data: { account: LinkedAccount } = await response.json();
+	return data.account;
+}
+
+// unlinkAccount removes the account along with the notifications synced for it
+export async function unlinkAccount(login: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/user/accounts/${encodeURIComponent(login)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to unlink account"));
+	}
+}
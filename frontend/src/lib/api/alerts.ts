@@ -0,0 +1,112 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// How loudly a notification alerts: not at all, only the unread badge, or a desktop
+// notification
+export type AlertLevel = "none" | "badge" | "desktop";
+
+// Named alert sounds. "silent" shows desktop notifications without a sound and an
+// empty sound uses the system default.
+export const alertSounds = ["chime", "ping", "pop", "bell"] as const;
+export type AlertSound = (typeof alertSounds)[number] | "silent" | "";
+
+export interface AlertConfig {
+	level: AlertLevel;
+	sound?: AlertSound;
+}
+
+// A daily window, in HH:MM, when desktop alerts become badges
+export interface QuietHours {
+	enabled: boolean;
+	start: string;
+	end: string;
+	timezone?: string;
+}
+
+// Notifications shown by the operating system for unread inbox items that alert on the
+// desktop. An empty query shows all of them.
+export interface NativeNotifications {
+	enabled: boolean;
+	query?: string;
+}
+
+// The alert policy applied on the server as notifications sync. Rules carry their own
+// alert in their actions; views are keyed by view ID.
+export interface AlertSettings {
+	default: AlertConfig;
+	views: Record<string, AlertConfig>;
+	quietHours: QuietHours;
+	quietOutsideWorkingHours: boolean;
+	native: NativeNotifications;
+}
+
+// An alert recorded when a notification arrived. suppressed is "dnd" or "quiet_hours"
+// when a desktop alert was downgraded to a badge.
+export interface Alert {
+	id: number;
+	githubId: string;
+	level: AlertLevel;
+	sound?: AlertSound;
+	source: "rule" | "view" | "default";
+	sourceId?: string;
+	suppressed?: "dnd" | "quiet_hours";
+	createdAt: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function getAlertSettings(fetchImpl?: typeof fetch): Promise<AlertSettings> {
+	const response = await fetchAPI("/api/user/alert-settings", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load alert settings"));
+	}
+	return response.json();
+}
+
+export async function updateAlertSettings(
+	settings: AlertSettings,
+	fetchImpl?: typeof fetch
+): Promise<AlertSettings> {
+	const response = await fetchAPI(
+		"/api/user/alert-settings",
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to save alert settings"));
+	}
+	return response.json();
+}
+
+// listAlerts returns alerts recorded after the given alert ID, oldest first. Without a
+// cursor it returns the most recent alerts.
+export async function listAlerts(after?: number, fetchImpl?: typeof fetch): Promise<Alert[]> {
+	const path = after === undefined ? "/api/alerts" : `/api/alerts?after=${after}`;
+	const response = await fetchAPI(path, { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load alerts"));
+	}
+	const data: { alerts: Alert[] } = await response.json();
+	return data.alerts;
+}
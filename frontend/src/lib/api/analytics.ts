@@ -0,0 +1,83 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// What notification volume is broken down by
+export type BreakdownDimension = "repo" | "org" | "reason";
+
+// What happened to notifications on one UTC day. Received counts syncs, so a notification
+// updated twice in a day counts twice.
+export interface DailyActivity {
+	date: string;
+	received: number;
+	read: number;
+	archived: number;
+}
+
+export interface ActivityReport {
+	since: string;
+	days: number;
+	daily: DailyActivity[];
+	meanTimeToReadSeconds: number;
+	readMeasured: number;
+}
+
+export interface VolumeEntry {
+	key: string;
+	total: number;
+	unread: number;
+}
+
+export interface VolumeBreakdown {
+	since: string;
+	days: number;
+	by: BreakdownDimension;
+	entries: VolumeEntry[];
+	total: number;
+}
+
+/**
+ * Get notifications received, read and archived on each of the last `days` days.
+ */
+export async function getActivity(days = 30, fetchImpl?: typeof fetch): Promise<ActivityReport> {
+	const response = await fetchAPI(`/api/analytics/activity?days=${days}`, { method: "GET" }, fetchImpl);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get activity" }));
+		throw new Error(error.error || "Failed to get activity");
+	}
+
+	return response.json();
+}
+
+/**
+ * Get notifications updated in the last `days` days counted by repository, org or reason.
+ */
+export async function getBreakdown(
+	by: BreakdownDimension = "repo",
+	days = 30,
+	fetchImpl?: typeof fetch
+): Promise<VolumeBreakdown> {
+	const params = new URLSearchParams({ by, days: String(days) });
+	const response = await fetchAPI(`/api/analytics/breakdown?${params}`, { method: "GET" }, fetchImpl);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get breakdown" }));
+		throw new Error(error.error || "Failed to get breakdown");
+	}
+
+	return response.json();
+}
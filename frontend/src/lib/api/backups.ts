@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// A database backup in the backups folder of the data directory
+export interface Backup {
+	name: string;
+	path: string;
+	sizeBytes: number;
+	createdAt: string;
+}
+
+export interface BackupList {
+	backups: Backup[];
+	pendingRestore: boolean;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+// fetchBackups lists backups newest first and whether a restore waits for the next start
+export async function fetchBackups(fetchImpl?: typeof fetch): Promise<BackupList> {
+	const response = await fetchAPI("/api/system/backups", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load backups"));
+	}
+	return response.json();
+}
+
+// createBackup backs up the database now
+export async function createBackup(fetchImpl?: typeof fetch): Promise<Backup> {
+	const response = await fetchAPI("/api/system/backups", { method: "POST" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to back up the database"));
+	}
+	return response.json();
+}
+
+// restoreBackup stages a backup to replace the database the next time Octobud starts
+export async function restoreBackup(name: string, fetchImpl?: typeof fetch): Promise<Backup> {
+	const response = await fetchAPI(
+		`/api/system/backups/${encodeURIComponent(name)}/restore`,
+		{ method: "POST" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to restore the backup"));
+	}
+	const data: { backup: Backup; restartRequired: boolean } = await response.json();
+	return data.backup;
+}
+
+// cancelRestore drops a staged restore before it is applied
+export async function cancelRestore(fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI("/api/system/backups/restore", { method: "DELETE" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to cancel the restore"));
+	}
+}
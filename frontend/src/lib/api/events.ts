@@ -0,0 +1,63 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { buildApiUrl } from "./fetch";
+
+// What an inbox event is about. "reset" means events were dropped because the client
+// fell behind, so whatever is shown should be loaded again.
+export type InboxEventType =
+	| "notification_upserted"
+	| "bulk_completed"
+	| "snooze_returned"
+	| "sync_status"
+	| "reset";
+
+const inboxEventTypes: InboxEventType[] = [
+	"notification_upserted",
+	"bulk_completed",
+	"snooze_returned",
+	"sync_status",
+	"reset",
+];
+
+export interface InboxEvent {
+	type: InboxEventType;
+	githubId?: string;
+	bulk?: { operation: string; count: number };
+	sync?: { state: "started" | "completed" | "failed"; error?: string };
+	timestamp: string;
+}
+
+export interface InboxEventHandlers {
+	onEvent: (event: InboxEvent) => void;
+	onError?: (error: Event) => void;
+}
+
+// subscribeInboxEvents reports synced notifications, finished bulk actions, returned
+// snoozes and sync status as they happen. Call the returned function to unsubscribe.
+export function subscribeInboxEvents(handlers: InboxEventHandlers): () => void {
+	const eventSource = new EventSource(buildApiUrl("/api/events"));
+
+	for (const type of inboxEventTypes) {
+		eventSource.addEventListener(type, (event: MessageEvent) => {
+			handlers.onEvent(JSON.parse(event.data));
+		});
+	}
+	eventSource.onerror = (error) => {
+		handlers.onError?.(error);
+	};
+
+	return () => eventSource.close();
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+import { fromBackendNotification } from "./notifications";
+import type { BackendNotificationResponse, Notification } from "./types";
+
+// How notifications are kept out of the inbox. "account" is the account-wide mute of
+// desktop notifications, which has no notifications of its own.
+export type HiddenMechanism = "snoozed" | "muted" | "filtered" | "account";
+
+// The first notifications hidden by one mechanism. Snoozed notifications carry their
+// wake time in snoozedUntil.
+export interface HiddenGroup {
+	mechanism: HiddenMechanism;
+	query: string;
+	total: number;
+	notifications: Notification[];
+}
+
+export interface HiddenSummary {
+	groups: HiddenGroup[];
+	accountMutedUntil?: string;
+}
+
+interface BackendHiddenGroup extends Omit<HiddenGroup, "notifications"> {
+	notifications: BackendNotificationResponse[];
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+// fetchHidden returns everything currently hidden from the inbox, grouped by mechanism
+export async function fetchHidden(fetchImpl?: typeof fetch): Promise<HiddenSummary> {
+	const response = await fetchAPI("/api/hidden", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load hidden notifications"));
+	}
+	const data: { groups: BackendHiddenGroup[]; accountMutedUntil?: string } =
+		await response.json();
+	return {
+		groups: data.groups.map((group) => ({
+			...group,
+			notifications: group.notifications.map(fromBackendNotification),
+		})),
+		accountMutedUntil: data.accountMutedUntil,
+	};
+}
+
+// wakeHidden brings back everything hidden by a mechanism and returns how much was woken
+export async function wakeHidden(
+	mechanism: HiddenMechanism,
+	fetchImpl?: typeof fetch
+): Promise<number> {
+	const response = await fetchAPI(
+		`/api/hidden/${encodeURIComponent(mechanism)}/wake`,
+		{ method: "POST" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to wake hidden notifications"));
+	}
+	const data: { woken: number } = await response.json();
+	return data.woken;
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+export type WebhookFormat = "json" | "slack";
+
+// A webhook rules can send matched notifications to. The secret is never returned.
+export interface Webhook {
+	id: string;
+	name: string;
+	format: WebhookFormat;
+	url: string;
+	hasSecret: boolean;
+	createdAt: string;
+	updatedAt: string;
+}
+
+// Leaving secret out keeps the current one; an empty string removes it
+export interface WebhookParams {
+	name: string;
+	format?: WebhookFormat;
+	url: string;
+	secret?: string;
+}
+
+export interface WebhookDelivery {
+	id: number;
+	integrationId: string;
+	ruleId?: string;
+	ruleName: string;
+	githubId: string;
+	status: "pending" | "delivered" | "failed";
+	attempts: number;
+	responseStatus?: number;
+	error?: string;
+	nextAttemptAt?: string;
+	createdAt: string;
+	deliveredAt?: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function fetchWebhooks(fetchImpl?: typeof fetch): Promise<Webhook[]> {
+	const response = await fetchAPI("/api/integrations/webhooks", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load webhooks"));
+	}
+	const data = await response.json();
+	return data.webhooks ?? [];
+}
+
+export async function createWebhook(
+	params: WebhookParams,
+	fetchImpl?: typeof fetch
+): Promise<Webhook> {
+	const response = await fetchAPI(
+		"/api/integrations/webhooks",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to create webhook"));
+	}
+	return response.json();
+}
+
+export async function updateWebhook(
+	id: string,
+	params: WebhookParams,
+	fetchImpl?: typeof fetch
+): Promise<Webhook> {
+	const response = await fetchAPI(
+		`/api/integrations/webhooks/${encodeURIComponent(id)}`,
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to update webhook"));
+	}
+	return response.json();
+}
+
+export async function deleteWebhook(id: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/integrations/webhooks/${encodeURIComponent(id)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to delete webhook"));
+	}
+}
+
+// fetchWebhookDeliveries lists a webhook's recent deliveries, newest first
+export async function fetchWebhookDeliveries(
+	id: string,
+	limit?: number,
+	fetchImpl?: typeof fetch
+): Promise<WebhookDelivery[]> {
+	const query = limit ? `?limit=${limit}` : "";
+	const response = await fetchAPI(
+		`/api/integrations/webhooks/${encodeURIComponent(id)}/deliveries${query}`,
+		{ method: "GET" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load deliveries"));
+	}
+	const data = await response.json();
+	return data.deliveries ?? [];
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { buildApiUrl } from "./fetch";
+
+// How a live query's results changed. "reset" means events were dropped because the
+// client fell behind, so the results should be loaded again.
+export type LiveQueryEventType = "match" | "unmatch" | "reset";
+
+export interface LiveQueryEvent {
+	type: LiveQueryEventType;
+	githubId?: string;
+	timestamp: string;
+}
+
+export interface LiveQueryHandlers {
+	// Called once the query is registered, with how many notifications match it now.
+	// It's called again after a reconnect, when results may have changed in between.
+	onSubscribed?: (matching: number) => void;
+	onEvent: (event: LiveQueryEvent) => void;
+	onError?: (error: Event) => void;
+}
+
+// subscribeLiveQuery registers a query and reports notifications that start or stop
+// matching it as they're synced or changed. Call the returned function to unsubscribe.
+export function subscribeLiveQuery(query: string, handlers: LiveQueryHandlers): () => void {
+	const eventSource = new EventSource(
+		buildApiUrl(`/api/live-queries?query=${encodeURIComponent(query)}`)
+	);
+
+	eventSource.addEventListener("subscribed", (event: MessageEvent) => {
+		const data: { matching: number } = JSON.parse(event.data);
+		handlers.onSubscribed?.(data.matching);
+	});
+	for (const type of ["match", "unmatch", "reset"] as const) {
+		eventSource.addEventListener(type, (event: MessageEvent) => {
+			handlers.onEvent(JSON.parse(event.data));
+		});
+	}
+	eventSource.onerror = (error) => {
+		handlers.onError?.(error);
+	};
+
+	return () => eventSource.close();
+}
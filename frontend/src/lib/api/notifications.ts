@@ -23,8 +23,10 @@ import type {
 	NotificationSubjectSummary,
 	NotificationViewFilter,
 	NotificationTimelineResponse,
+	QueryDefaults,
 } from "./types";
 import { constructGitHubHtmlUrl } from "$lib/utils/githubUrls";
+import type { ReplyComment } from "./savedReplies";
 import { fetchWithAuth, buildApiUrl, ApiUnreachableError, isProxyConnectionError } from "./fetch";
 
 const PAGE_SIZE = 30;
@@ -33,6 +35,14 @@ export interface FetchNotificationsParams {
 	page?: number;
 	pageSize?: number;
 	filters?: Partial<NotificationFilters>;
+	// Ask the backend to report which implicit filters it applied to the query
+	explainDefaults?: boolean;
+	// List each subject once, as its newest notification
+	conversations?: boolean;
+	// A previous page's nextCursor; the page starts right after it and page is ignored
+	cursor?: string;
+	// "priority" lists the highest priority score first; newest first otherwise
+	sort?: "priority";
 }
 
 const normalizeSubjectType = (subjectType: string): string => {
@@ -46,7 +56,9 @@ const normalizeSubjectType = (subjectType: string): string => {
 	return normalized;
 };
 
-const fromBackendNotification = (notification: BackendNotificationResponse): Notification => {
+export const fromBackendNotification = (
+	notification: BackendNotificationResponse
+): Notification => {
 	const repoFullName =
 		notification.repository?.fullName ?? notification.repository?.name ?? "Unknown repository";
 
@@ -81,12 +93,15 @@ const fromBackendNotification = (notification: BackendNotificationResponse): Not
 		filtered: notification.filtered ?? false,
 		snoozedUntil: notification.snoozedUntil ?? undefined,
 		snoozedAt: notification.snoozedAt ?? undefined,
+		snoozeUntilUpdated: notification.snoozeUntilUpdated ?? false,
+		returnedFromSnoozeAt: notification.returnedFromSnoozeAt ?? undefined,
 		updatedAt: notification.githubUpdatedAt ?? notification.importedAt,
 		labels: [],
 		viewIds: ["inbox"],
 		repositoryId: notification.repositoryId,
 		state: subject?.state as any, // Use actual state from parsed subject
 		authorLogin: notification.authorLogin ?? undefined,
+		author: notification.author ?? undefined,
 		githubUrl: notification.githubUrl ?? undefined,
 		htmlUrl,
 		subjectUrl: notification.subjectUrl ?? undefined,
@@ -99,9 +114,23 @@ const fromBackendNotification = (notification: BackendNotificationResponse): Not
 		subjectState: notification.subjectState ?? undefined,
 		subjectMerged: notification.subjectMerged ?? undefined,
 		subjectStateReason: notification.subjectStateReason ?? undefined,
+		contentKind: notification.contentKind,
+		headBranch: notification.headBranch ?? undefined,
+		baseBranch: notification.baseBranch ?? undefined,
+		subjectDraft: notification.subjectDraft ?? undefined,
+		labels: notification.labels ?? undefined,
+		reviewState: notification.reviewState ?? undefined,
+		ciState: notification.ciState ?? undefined,
+		mergeable: notification.mergeable ?? undefined,
+		waitingOn: notification.waitingOn ?? undefined,
+		priority: notification.priority ?? undefined,
+		milestone: notification.milestone ?? undefined,
+		projects: notification.projects ?? undefined,
+		account: notification.account ?? undefined,
 		actionHints: notification.actionHints,
 		tags: notification.tags ?? [],
 		effectiveSortDate: notification.effectiveSortDate,
+		conversation: notification.conversation,
 	};
 };
 
@@ -219,11 +248,31 @@ export async function fetchNotifications(
 	params: FetchNotificationsParams = {},
 	fetchImpl?: typeof fetch
 ): Promise<NotificationPage> {
-	const { page = 1, pageSize = PAGE_SIZE, filters = {} } = params;
+	const {
+		page = 1,
+		pageSize = PAGE_SIZE,
+		filters = {},
+		explainDefaults = false,
+		conversations = false,
+		cursor,
+		sort,
+	} = params;
 
 	const searchParams = new URLSearchParams();
 	searchParams.set("page", String(page));
 	searchParams.set("pageSize", String(pageSize));
+	if (explainDefaults) {
+		searchParams.set("explainDefaults", "true");
+	}
+	if (conversations) {
+		searchParams.set("conversations", "true");
+	}
+	if (cursor) {
+		searchParams.set("cursor", cursor);
+	}
+	if (sort) {
+		searchParams.set("sort", sort);
+	}
 
 	// Use combined query string if provided (includes key-value pairs, free text, and status filtering)
 	// Always send query parameter (even if empty) to ensure new query engine is used with inbox defaults
@@ -281,6 +330,8 @@ export async function fetchNotifications(
 		total?: number;
 		page?: number;
 		pageSize?: number;
+		defaults?: QueryDefaults;
+		nextCursor?: string;
 	} = await response.json();
 	const notifications = (payload.notifications ?? []).map(fromBackendNotification);
 
@@ -289,6 +340,148 @@ export async function fetchNotifications(
 		total: payload.total ?? notifications.length,
 		pageSize: payload.pageSize ?? pageSize,
 		page: payload.page ?? page,
+		defaults: payload.defaults,
+		nextCursor: payload.nextCursor,
+	};
+}
+
+export interface RunQueryParams {
+	query: string;
+	sort?: string;
+	pageSize?: number;
+	cursor?: string;
+	conversations?: boolean;
+}
+
+// A page of results for an ad-hoc query, with how many of all the matches are unread
+export interface QueryRunPage extends NotificationPage {
+	unreadCount: number;
+}
+
+export interface RecentQuery {
+	query: string;
+	ranAt: string;
+}
+
+async function queryErrorMessage(response: Response, fallback: string): Promise<string> {
+	const errorData: { error?: string } = await response.json().catch(() => ({}));
+	return errorData.error || `${fallback} (${response.status})`;
+}
+
+// runQuery runs a query without saving it as a view. The first page of each query is
+// added to the recent query history.
+export async function runQuery(
+	params: RunQueryParams,
+	fetchImpl?: typeof fetch
+): Promise<QueryRunPage> {
+	const response = await fetchWithAuth(
+		"/api/query/run",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		const error = new Error(await queryErrorMessage(response, "Failed to run query")) as Error & {
+			statusCode?: number;
+		};
+		error.statusCode = response.status;
+		throw error;
+	}
+
+	const payload: {
+		notifications: BackendNotificationResponse[];
+		total: number;
+		page: number;
+		pageSize: number;
+		nextCursor?: string;
+		unreadCount: number;
+	} = await response.json();
+	return {
+		items: (payload.notifications ?? []).map(fromBackendNotification),
+		total: payload.total,
+		page: payload.page,
+		pageSize: payload.pageSize,
+		nextCursor: payload.nextCursor,
+		unreadCount: payload.unreadCount,
+	};
+}
+
+export async function fetchRecentQueries(fetchImpl?: typeof fetch): Promise<RecentQuery[]> {
+	const response = await fetchWithAuth("/api/query/recent", {}, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await queryErrorMessage(response, "Failed to load recent queries"));
+	}
+	const payload: { queries: RecentQuery[] } = await response.json();
+	return payload.queries ?? [];
+}
+
+export async function clearRecentQueries(fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchWithAuth("/api/query/recent", { method: "DELETE" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await queryErrorMessage(response, "Failed to clear recent queries"));
+	}
+}
+
+// What a grouped inbox groups notifications by
+export type NotificationGroupKey = "repo" | "org" | "reason" | "author" | "type";
+
+export interface NotificationGroup {
+	key: string; // Empty for notifications without a value, such as no known author
+	count: number;
+	notifications: Notification[]; // Newest first
+}
+
+export interface NotificationGroups {
+	groupBy: NotificationGroupKey;
+	total: number;
+	groups: NotificationGroup[]; // Most recently active group first
+}
+
+export async function fetchNotificationGroups(
+	query: string,
+	groupBy: NotificationGroupKey,
+	perGroup = 5,
+	fetchImpl?: typeof fetch
+): Promise<NotificationGroups> {
+	const searchParams = new URLSearchParams({ query, groupBy, perGroup: String(perGroup) });
+	const response = await fetchWithAuth(
+		`/api/notifications/groups?${searchParams.toString()}`,
+		{},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		let errorMessage = `Failed to group notifications (${response.status})`;
+		try {
+			const errorData: { error?: string } = await response.json();
+			if (errorData.error) {
+				errorMessage = errorData.error;
+			}
+		} catch (e) {
+			console.error("Failed to parse error response body:", e);
+		}
+
+		const error = new Error(errorMessage) as Error & { status?: number };
+		error.status = response.status;
+		throw error;
+	}
+
+	const payload: {
+		groupBy: NotificationGroupKey;
+		total: number;
+		groups: { key: string; count: number; notifications: BackendNotificationResponse[] }[];
+	} = await response.json();
+	return {
+		groupBy: payload.groupBy,
+		total: payload.total,
+		groups: (payload.groups ?? []).map((group) => ({
+			key: group.key,
+			count: group.count,
+			notifications: (group.notifications ?? []).map(fromBackendNotification),
+		})),
 	};
 }
 
@@ -326,12 +519,99 @@ export async function fetchNotificationDetail(
 	};
 }
 
+export interface NotificationHistoryEvent {
+	action: string;
+	// Who made the change: "user", "rule", "sync" or "system"
+	source: string;
+	detail?: string;
+	createdAt: string;
+}
+
+// Fetch a notification's history, newest first
+export async function fetchNotificationHistory(
+	githubId: string,
+	fetchImpl?: typeof fetch
+): Promise<NotificationHistoryEvent[]> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/history`,
+		{},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to load notification history (${response.status})`);
+	}
+
+	const payload: { events: NotificationHistoryEvent[] } = await response.json();
+	return payload.events ?? [];
+}
+
+// Fetch every notification sharing a notification's subject, newest first
+export async function fetchConversation(
+	githubId: string,
+	fetchImpl?: typeof fetch
+): Promise<Notification[]> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/conversation`,
+		{},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to load conversation (${response.status})`);
+	}
+
+	const payload: { notifications: BackendNotificationResponse[] } = await response.json();
+	return (payload.notifications ?? []).map(fromBackendNotification);
+}
+
+// Fetch the notification after (or before) a cursor in a query's list order, or the first
+// one without a cursor. Returns null past either end of the list.
+export async function fetchNextNotification(
+	params: { query: string; after?: string; before?: string },
+	fetchImpl?: typeof fetch
+): Promise<Notification | null> {
+	const searchParams = new URLSearchParams({ query: params.query });
+	if (params.after) {
+		searchParams.set("after", params.after);
+	}
+	if (params.before) {
+		searchParams.set("before", params.before);
+	}
+
+	const response = await fetchWithAuth(
+		`/api/notifications/next?${searchParams.toString()}`,
+		{},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to load next notification (${response.status})`);
+	}
+
+	const payload: { notification: BackendNotificationResponse | null } = await response.json();
+	return payload.notification ? fromBackendNotification(payload.notification) : null;
+}
+
 export interface UpdateNotificationResponse {
 	notification: BackendNotificationResponse;
 }
 
 export interface BulkUpdateNotificationResponse {
 	count: number;
+	// Undoes the operation through undoBulkOperation for a while afterwards
+	undoId?: number;
+}
+
+export interface UndoBulkOperationResponse {
+	operation: string;
+	count: number;
+	githubIDs: string[];
+}
+
+export interface MarkGroupReadResponse {
+	count: number;
+	githubIDs: string[];
 }
 
 // Mark notification as read
@@ -418,13 +698,16 @@ export async function unarchiveNotification(
 	return fromBackendNotification(payload.notification);
 }
 
-// Mute notification
+// Mute notification. With unsubscribe, the thread is ignored on GitHub first so no more
+// notifications are sent for it at the source.
 export async function muteNotification(
 	githubId: string,
-	fetchImpl?: typeof fetch
+	fetchImpl?: typeof fetch,
+	options: { unsubscribe?: boolean } = {}
 ): Promise<Notification> {
+	const query = options.unsubscribe ? "?unsubscribe=true" : "";
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/mute`,
+		`/api/notifications/${encodeURIComponent(githubId)}/mute${query}`,
 		{
 			method: "POST",
 		},
@@ -439,6 +722,59 @@ export async function muteNotification(
 	return fromBackendNotification(payload.notification);
 }
 
+// The user's subscription to a notification's thread on GitHub
+export interface ThreadSubscription {
+	subscribed: boolean;
+	ignored: boolean;
+	reason?: string;
+	createdAt?: string;
+}
+
+// Subscribe to a notification's thread on GitHub, or with ignored, stop its notifications
+export async function setThreadSubscription(
+	githubId: string,
+	ignored: boolean,
+	fetchImpl?: typeof fetch
+): Promise<ThreadSubscription> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/subscription`,
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ ignored }),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to update subscription (${response.status})`);
+	}
+
+	const payload: { subscription: ThreadSubscription } = await response.json();
+	return payload.subscription;
+}
+
+// Remove the subscription to a notification's thread on GitHub. Notifications stop until
+// you comment or are mentioned, unless you watch the repository.
+export async function deleteThreadSubscription(
+	githubId: string,
+	fetchImpl?: typeof fetch
+): Promise<void> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/subscription`,
+		{
+			method: "DELETE",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to remove subscription (${response.status})`);
+	}
+}
+
 // Unmute notification
 export async function unmuteNotification(
 	githubId: string,
@@ -486,6 +822,34 @@ export async function snoozeNotification(
 	return fromBackendNotification(payload.notification);
 }
 
+// Snooze a notification until a preset such as "tomorrow" or a duration such as "3d",
+// resolved on the server in the user's time zone. With untilUpdated, the snooze also ends
+// once the thread is updated; without a preset it then lasts until that update.
+export async function snoozeNotificationWithPreset(
+	githubId: string,
+	options: { preset?: string; untilUpdated?: boolean },
+	fetchImpl?: typeof fetch
+): Promise<Notification> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/snooze`,
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(options),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to snooze notification (${response.status})`);
+	}
+
+	const payload: UpdateNotificationResponse = await response.json();
+	return fromBackendNotification(payload.notification);
+}
+
 // Unsnooze notification (clear snoozed_until)
 export async function unsnoozeNotification(
 	githubId: string,
@@ -691,6 +1055,52 @@ export async function bulkMarkNotificationsRead(
 	return payload.count;
 }
 
+// Mark every unread notification in a thread group (shared subject URL) as read.
+// Returns the GitHub IDs that changed so grouped rows can be updated in place.
+export async function markGroupRead(
+	subjectUrl: string,
+	fetchImpl?: typeof fetch
+): Promise<string[]> {
+	const response = await fetchWithAuth(
+		"/api/notifications/bulk/mark-group-read",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ subjectUrl }),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to mark group as read (${response.status})`);
+	}
+
+	const payload: MarkGroupReadResponse = await response.json();
+	return payload.githubIDs;
+}
+
+// Undo a recent bulk operation, putting back what it changed
+export async function undoBulkOperation(
+	undoId: number,
+	fetchImpl?: typeof fetch
+): Promise<UndoBulkOperationResponse> {
+	const response = await fetchWithAuth(
+		`/api/notifications/bulk/undo/${undoId}`,
+		{
+			method: "POST",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		throw new Error(`Failed to undo bulk operation (${response.status})`);
+	}
+
+	return response.json();
+}
+
 // Bulk archive
 export async function bulkArchiveNotifications(
 	githubIds: string[],
@@ -1026,4 +1436,152 @@ export async function fetchNotificationTimeline(
 	return payload;
 }
 
+// A comment posted from the notification view, with the first timeline page fetched
+// afterwards so it can be shown straight away. The timeline is missing if that fetch failed.
+export interface PostedComment {
+	comment: ReplyComment;
+	timeline?: NotificationTimelineResponse;
+}
+
+export async function postNotificationComment(
+	githubId: string,
+	body: string,
+	fetchImpl?: typeof fetch
+): Promise<PostedComment> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/comments`,
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ body }),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const errorData: { error?: string } = await response.json().catch(() => ({}));
+		const error = new Error(
+			errorData.error || `Failed to post comment (${response.status})`
+		) as Error & { status?: number };
+		error.status = response.status;
+		throw error;
+	}
+
+	return (await response.json()) as PostedComment;
+}
+
+export type ReviewEvent = "approve" | "request_changes" | "comment";
+
+// A pull request review submitted from the notification view
+export interface SubmittedReview {
+	review: {
+		id: number;
+		state: string;
+		body: string;
+		htmlUrl: string;
+	};
+	timeline?: NotificationTimelineResponse;
+}
+
+// submitPullRequestReview reviews the notification's pull request. body is required
+// unless approving.
+export async function submitPullRequestReview(
+	githubId: string,
+	event: ReviewEvent,
+	body = "",
+	fetchImpl?: typeof fetch
+): Promise<SubmittedReview> {
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/reviews`,
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ event, body }),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const errorData: { error?: string } = await response.json().catch(() => ({}));
+		const error = new Error(
+			errorData.error || `Failed to submit review (${response.status})`
+		) as Error & { status?: number };
+		error.status = response.status;
+		throw error;
+	}
+
+	return (await response.json()) as SubmittedReview;
+}
+
+export type MergeMethod = "merge" | "squash" | "rebase";
+
+export interface MergeResult {
+	merged: boolean;
+	sha: string;
+	message: string;
+	timeline?: NotificationTimelineResponse;
+}
+
+export interface SubjectStateResult {
+	state: "open" | "closed";
+	timeline?: NotificationTimelineResponse;
+}
+
+async function postSubjectAction<T>(
+	githubId: string,
+	action: "merge" | "close" | "reopen",
+	body: unknown,
+	fetchImpl?: typeof fetch
+): Promise<T> {
+	// The backend refuses these without confirm=true, so callers must have asked the user
+	const response = await fetchWithAuth(
+		`/api/notifications/${encodeURIComponent(githubId)}/${action}?confirm=true`,
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: body === undefined ? undefined : JSON.stringify(body),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const errorData: { error?: string } = await response.json().catch(() => ({}));
+		const error = new Error(
+			errorData.error || `Failed to ${action} (${response.status})`
+		) as Error & { status?: number };
+		error.status = response.status;
+		throw error;
+	}
+
+	return (await response.json()) as T;
+}
+
+// mergePullRequest merges the notification's pull request. Only call it after the user
+// confirmed.
+export async function mergePullRequest(
+	githubId: string,
+	method: MergeMethod = "merge",
+	fetchImpl?: typeof fetch
+): Promise<MergeResult> {
+	return postSubjectAction<MergeResult>(githubId, "merge", { method }, fetchImpl);
+}
+
+// closeSubject closes the notification's issue or pull request. Only call it after the
+// user confirmed.
+export async function closeSubject(
+	githubId: string,
+	fetchImpl?: typeof fetch
+): Promise<SubjectStateResult> {
+	return postSubjectAction<SubjectStateResult>(githubId, "close", undefined, fetchImpl);
+}
+
+// reopenSubject reopens the notification's issue or pull request. Only call it after the
+// user confirmed.
+export async function reopenSubject(
+	githubId: string,
+	fetchImpl?: typeof fetch
+): Promise<SubjectStateResult> {
+	return postSubjectAction<SubjectStateResult>(githubId, "reopen", undefined, fetchImpl);
+}
+
 export { fromBackendNotification };
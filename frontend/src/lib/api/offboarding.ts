@@ -0,0 +1,77 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+import { fetchAPI } from "./fetch";
+
+// What offboarding does with an org's notifications
+export type OffboardAction = "archive" | "delete";
+
+// An org the user left and what leaving it changed
+export interface OrgOffboarding {
+	org: string;
+	action: OffboardAction;
+	notificationsAffected: number;
+	repositories: number;
+	offboardedAt: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+// fetchOffboardings lists the orgs the user offboarded, most recent first
+export async function fetchOffboardings(fetchImpl?: typeof fetch): Promise<OrgOffboarding[]> {
+	const response = await fetchAPI("/api/offboarding/orgs", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load offboarded orgs"));
+	}
+	const data: { offboardings: OrgOffboarding[] } = await response.json();
+	return data.offboardings;
+}
+
+// offboardOrg archives or deletes an org's notifications and stops syncing it
+export async function offboardOrg(
+	org: string,
+	action: OffboardAction = "archive",
+	fetchImpl?: typeof fetch
+): Promise<OrgOffboarding> {
+	const response = await fetchAPI(
+		"/api/offboarding/orgs",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ org, action }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to offboard org"));
+	}
+	return response.json();
+}
+
+// rejoinOrg syncs an offboarded org again
+export async function rejoinOrg(org: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/offboarding/orgs/${encodeURIComponent(org)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to rejoin org"));
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchWithAuth } from "./fetch";
+import type { QueryDefaults } from "./types";
+
+export type QueryNodeType = "binary" | "not" | "group" | "term" | "text";
+
+// A node of a parsed query. start and end are byte offsets into the UTF-8 query (end
+// exclusive); for ASCII queries query.slice(node.start, node.end) is the node's source text.
+export interface QueryNode {
+	type: QueryNodeType;
+	op?: "AND" | "OR"; // binary
+	left?: QueryNode; // binary
+	right?: QueryNode; // binary
+	expr?: QueryNode; // not, group
+	field?: string; // term
+	values?: string[]; // term
+	text?: string; // text
+	start: number;
+	end: number;
+}
+
+export interface ParsedQuery {
+	query: string;
+	// The query as the backend writes it; parsing it gives the same AST
+	canonical: string;
+	ast: QueryNode | null;
+	// The implicit filters applied when the query lists notifications
+	defaults: QueryDefaults;
+}
+
+export async function parseQuery(
+	query: string,
+	fetchImpl: typeof fetch = fetch
+): Promise<ParsedQuery> {
+	const response = await fetchWithAuth(
+		"/api/query/parse",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ query }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		const errorData = await response.json().catch(() => ({}));
+		throw new Error(errorData.error || `Failed to parse query: ${response.statusText}`);
+	}
+	return (await response.json()) as ParsedQuery;
+}
+
+export interface QueryPlanStep {
+	id: number;
+	parent: number;
+	detail: string;
+}
+
+export interface QueryExplanation {
+	query: string;
+	sort?: string;
+	joins: string[];
+	where: string[];
+	args: unknown[];
+	defaults: QueryDefaults;
+	sql: string;
+	sqlArgs: unknown[];
+	plan: QueryPlanStep[];
+}
+
+export async function explainQuery(
+	query: string,
+	sort?: string,
+	fetchImpl: typeof fetch = fetch
+): Promise<QueryExplanation> {
+	const response = await fetchWithAuth(
+		"/api/query/explain",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ query, sort }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		const errorData = await response.json().catch(() => ({}));
+		throw new Error(errorData.error || `Failed to explain query: ${response.statusText}`);
+	}
+	return (await response.json()) as QueryExplanation;
+}
+
+export interface QuerySuggestion {
+	kind: "field" | "value";
+	value: string;
+}
+
+// Completions for the text at a cursor. Choosing one replaces query.slice(start, end),
+// byte offsets like QueryNode's, with its value.
+export interface QuerySuggestions {
+	field?: string; // The field whose value is being typed
+	prefix: string;
+	start: number;
+	end: number;
+	suggestions: QuerySuggestion[];
+}
+
+// suggestQuery completes a partly typed query from known repositories, orgs, authors, labels,
+// and tags. The cursor defaults to the end of the query.
+export async function suggestQuery(
+	query: string,
+	cursor?: number,
+	fetchImpl: typeof fetch = fetch
+): Promise<QuerySuggestions> {
+	const response = await fetchWithAuth(
+		"/api/query/suggest",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ query, cursor }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		const errorData = await response.json().catch(() => ({}));
+		throw new Error(errorData.error || `Failed to suggest completions: ${response.statusText}`);
+	}
+	return (await response.json()) as QuerySuggestions;
+}
+
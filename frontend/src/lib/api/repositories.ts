@@ -0,0 +1,72 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// How much sync stores for a repository's notifications
+export type RepositorySyncMode = "full" | "headers_only" | "excluded";
+
+// Per-repository settings. A null value means the user's own setting applies.
+export interface RepositorySettings {
+	repositoryId: number;
+	defaultSnooze: string | null;
+	// Minutes after an archive during which new activity doesn't resurface a thread
+	resurfaceSuppressionMinutes: number | null;
+	syncMode: RepositorySyncMode;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function getRepositorySettings(
+	repositoryId: number,
+	fetchImpl?: typeof fetch
+): Promise<RepositorySettings> {
+	const response = await fetchAPI(
+		`/api/repositories/${repositoryId}/settings`,
+		{ method: "GET" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load repository settings"));
+	}
+	const data: { settings: RepositorySettings } = await response.json();
+	return data.settings;
+}
+
+// updateRepositorySettings replaces the repository's settings, e.g. a "1w" default snooze
+// for a noisy repository. Null fields go back to the user's own setting.
+export async function updateRepositorySettings(
+	repositoryId: number,
+	settings: Omit<RepositorySettings, "repositoryId">,
+	fetchImpl?: typeof fetch
+): Promise<RepositorySettings> {
+	const response = await fetchAPI(
+		`/api/repositories/${repositoryId}/settings`,
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to update repository settings"));
+	}
+	const data: { settings: RepositorySettings } = await response.json();
+	return data.settings;
+}
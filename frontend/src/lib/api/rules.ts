@@ -13,6 +13,8 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
+import type { AlertConfig } from "./alerts";
+
 export interface RuleActions {
 	skipInbox: boolean;
 	markRead?: boolean;
@@ -21,6 +23,10 @@ export interface RuleActions {
 	mute?: boolean;
 	assignTags?: string[]; // Tag IDs as strings
 	removeTags?: string[]; // Tag IDs as strings
+	addTagSlugs?: string[]; // Tags by slug, created if missing
+	removeTagSlugs?: string[]; // Tags by slug
+	alert?: AlertConfig; // How loudly matching notifications alert
+	notifyWebhook?: string[]; // Webhook IDs sent each match as it syncs
 }
 
 export interface Rule {
@@ -32,6 +38,9 @@ export interface Rule {
 	actions: RuleActions;
 	enabled: boolean;
 	displayOrder: number;
+	priority: number; // Higher runs first; ties run in display order
+	stopProcessing: boolean; // When the rule matches, later rules are skipped
+	condition?: string; // Expression a notification must also satisfy, checked after the query
 	createdAt: string;
 	updatedAt: string;
 }
@@ -51,6 +60,9 @@ interface CreateRuleRequest {
 	viewId?: string;
 	actions: RuleActions;
 	enabled?: boolean;
+	priority?: number;
+	stopProcessing?: boolean;
+	condition?: string;
 	applyToExisting?: boolean;
 }
 
@@ -61,6 +73,29 @@ interface UpdateRuleRequest {
 	viewId?: string;
 	actions?: RuleActions;
 	enabled?: boolean;
+	priority?: number;
+	stopProcessing?: boolean;
+	condition?: string; // An empty string removes it
+}
+
+export type RuleResult = "matched" | "not_matched" | "skipped" | "error";
+
+// What happened to one rule when a notification was evaluated
+export interface RuleTraceEntry {
+	ruleId: string;
+	ruleName: string;
+	priority: number;
+	result: RuleResult;
+	stoppedProcessing?: boolean;
+	error?: string;
+}
+
+// The latest evaluation of the rules against a notification
+export interface RuleEvaluation {
+	githubId: string;
+	subjectTitle: string;
+	evaluatedAt: string;
+	rules: RuleTraceEntry[];
 }
 
 import { fetchWithAuth, buildApiUrl } from "./fetch";
@@ -155,3 +190,20 @@ export async function reorderRules(
 	const data: RulesResponse = await response.json();
 	return data.rules;
 }
+
+// fetchRuleActivity lists recent rule evaluations, newest first. Pass a GitHub ID to see
+// only the evaluation of that notification.
+export async function fetchRuleActivity(
+	githubId?: string,
+	fetchImpl: typeof fetch = fetch
+): Promise<RuleEvaluation[]> {
+	const path = githubId
+		? `/api/rules/activity?notification=${encodeURIComponent(githubId)}`
+		: "/api/rules/activity";
+	const response = await fetchWithAuth(path, {}, fetchImpl);
+	if (!response.ok) {
+		throw new Error(`Failed to fetch rule activity: ${response.statusText}`);
+	}
+	const data: { evaluations: RuleEvaluation[] } = await response.json();
+	return data.evaluations;
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// A saved reply imported from the user's GitHub account. The body may contain
+// {{author}} and {{pr_title}}, which are filled in when the reply is posted.
+export interface SavedReply {
+	id: string;
+	title: string;
+	body: string;
+}
+
+// The comment created on GitHub by a reply
+export interface ReplyComment {
+	id: number;
+	body: string;
+	htmlUrl: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+// listSavedReplies returns the cached saved replies. Pass refresh to import them from
+// GitHub again first.
+export async function listSavedReplies(
+	refresh = false,
+	fetchImpl?: typeof fetch
+): Promise<SavedReply[]> {
+	const path = refresh ? "/api/saved-replies?refresh=true" : "/api/saved-replies";
+	const response = await fetchAPI(path, { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load saved replies"));
+	}
+	const data: { savedReplies: SavedReply[] } = await response.json();
+	return data.savedReplies;
+}
+
+// replyToNotification posts a comment on the notification's issue or pull request,
+// using either a body or a saved reply.
+export async function replyToNotification(
+	githubId: string,
+	reply: { body: string } | { savedReplyId: string },
+	fetchImpl?: typeof fetch
+): Promise<ReplyComment> {
+	const response = await fetchAPI(
+		`/api/notifications/${encodeURIComponent(githubId)}/reply`,
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(reply),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to post reply"));
+	}
+	const data: { comment: ReplyComment } = await response.json();
+	return data.comment;
+}
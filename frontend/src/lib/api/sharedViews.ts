@@ -0,0 +1,207 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+import { fromBackendNotification } from "./notifications";
+import type { BackendNotificationResponse, NotificationPage, NotificationView } from "./types";
+
+export type ViewPermission = "read" | "write";
+
+// Access another user of a multi-user server has to one of your views
+export interface ViewGrant {
+	viewId: string;
+	username: string;
+	permission: ViewPermission;
+	createdAt: string;
+}
+
+// A view another user shared with you. Its notifications are the owner's.
+export interface SharedView {
+	owner: string;
+	permission: ViewPermission;
+	sharedAt: string;
+	view: NotificationView;
+}
+
+// The fields of a shared view its grantees may change. Only the owner can change its query.
+export interface SharedViewUpdate {
+	name?: string;
+	description?: string;
+	icon?: string;
+}
+
+export interface FetchSharedViewNotificationsParams {
+	page?: number;
+	pageSize?: number;
+	cursor?: string;
+	sort?: string;
+	includeSubject?: boolean;
+	conversations?: boolean;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+const sharedViewPath = (owner: string, id: string): string =>
+	`/api/shared-views/${encodeURIComponent(owner)}/${encodeURIComponent(id)}`;
+
+const viewSharesPath = (viewId: string): string =>
+	`/api/views/${encodeURIComponent(viewId)}/shares`;
+
+export async function listSharedViews(fetchImpl?: typeof fetch): Promise<SharedView[]> {
+	const response = await fetchAPI("/api/shared-views", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load shared views"));
+	}
+	const data: { views: SharedView[] } = await response.json();
+	return data.views ?? [];
+}
+
+export async function getSharedView(
+	owner: string,
+	id: string,
+	fetchImpl?: typeof fetch
+): Promise<SharedView> {
+	const response = await fetchAPI(sharedViewPath(owner, id), { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load shared view"));
+	}
+	const data: { view: SharedView } = await response.json();
+	return data.view;
+}
+
+// Changes a view shared with write permission. The owner sees the change too.
+export async function updateSharedView(
+	owner: string,
+	id: string,
+	update: SharedViewUpdate,
+	fetchImpl?: typeof fetch
+): Promise<NotificationView> {
+	const response = await fetchAPI(
+		sharedViewPath(owner, id),
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(update),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to update shared view"));
+	}
+	const data: { view: NotificationView } = await response.json();
+	return data.view;
+}
+
+// Stops seeing a view someone shared with you
+export async function leaveSharedView(
+	owner: string,
+	id: string,
+	fetchImpl?: typeof fetch
+): Promise<void> {
+	const response = await fetchAPI(sharedViewPath(owner, id), { method: "DELETE" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to leave shared view"));
+	}
+}
+
+export async function fetchSharedViewNotifications(
+	owner: string,
+	id: string,
+	params: FetchSharedViewNotificationsParams = {},
+	fetchImpl?: typeof fetch
+): Promise<NotificationPage> {
+	const search = new URLSearchParams();
+	for (const [key, value] of Object.entries(params)) {
+		if (value !== undefined && value !== "") {
+			search.set(key, String(value));
+		}
+	}
+	const query = search.toString();
+	const response = await fetchAPI(
+		`${sharedViewPath(owner, id)}/notifications${query ? `?${query}` : ""}`,
+		{ method: "GET" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load shared view notifications"));
+	}
+	const payload: {
+		notifications: BackendNotificationResponse[];
+		total?: number;
+		page?: number;
+		pageSize?: number;
+		nextCursor?: string;
+	} = await response.json();
+	const items = (payload.notifications ?? []).map(fromBackendNotification);
+	return {
+		items,
+		total: payload.total ?? items.length,
+		page: payload.page ?? params.page ?? 1,
+		pageSize: payload.pageSize ?? params.pageSize ?? items.length,
+		nextCursor: payload.nextCursor,
+	};
+}
+
+export async function listViewShares(
+	viewId: string,
+	fetchImpl?: typeof fetch
+): Promise<ViewGrant[]> {
+	const response = await fetchAPI(viewSharesPath(viewId), { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load view shares"));
+	}
+	const data: { shares: ViewGrant[] } = await response.json();
+	return data.shares ?? [];
+}
+
+export async function shareView(
+	viewId: string,
+	username: string,
+	permission: ViewPermission,
+	fetchImpl?: typeof fetch
+): Promise<ViewGrant> {
+	const response = await fetchAPI(
+		`${viewSharesPath(viewId)}/${encodeURIComponent(username)}`,
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ permission }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to share view"));
+	}
+	const data: { share: ViewGrant } = await response.json();
+	return data.share;
+}
+
+export async function unshareView(
+	viewId: string,
+	username: string,
+	fetchImpl?: typeof fetch
+): Promise<void> {
+	const response = await fetchAPI(
+		`${viewSharesPath(viewId)}/${encodeURIComponent(username)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to unshare view"));
+	}
+}
@@ -106,15 +106,28 @@ export async function updateTag(
 	return data.tag;
 }
 
-export async function deleteTag(id: string, fetchImpl: typeof fetch = fetch): Promise<void> {
+export async function deleteTag(
+	id: string,
+	force: boolean = false,
+	fetchImpl: typeof fetch = fetch
+): Promise<void> {
 	const response = await fetchWithAuth(
-		`/api/tags/${id}`,
+		`/api/tags/${id}${force ? "?force=true" : ""}`,
 		{
 			method: "DELETE",
 		},
 		fetchImpl
 	);
 	if (!response.ok) {
+		if (response.status === 409) {
+			// Conflict - tag is used by rules
+			const errorData = await response.json();
+			const error: any = new Error("This tag is used by rules that will be disabled");
+			error.linkedRuleCount = errorData.linkedRuleCount;
+			error.dependentRules = errorData.dependentRules ?? [];
+			error.statusCode = 409;
+			throw error;
+		}
 		throw new Error(`Failed to delete tag: ${response.statusText}`);
 	}
 }
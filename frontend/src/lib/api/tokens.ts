@@ -0,0 +1,88 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+
+import { fetchAPI } from "./fetch";
+
+export type APITokenScope = "read" | "write";
+
+// A personal API token for scripts and CLI tools. Only the start of the token is kept,
+// so its full value can't be shown again after it's created.
+export interface APIToken {
+	id: string;
+	name: string;
+	prefix: string;
+	scope: APITokenScope;
+	account: string;
+	createdAt: string;
+	expiresAt?: string;
+	lastUsedAt?: string;
+}
+
+export interface CreateTokenParams {
+	name: string;
+	scope?: APITokenScope;
+	expiresInDays?: number;
+}
+
+// A newly created token along with its value, which is shown only this once
+export interface CreatedToken {
+	token: APIToken;
+	value: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function listTokens(fetchImpl?: typeof fetch): Promise<APIToken[]> {
+	const response = await fetchAPI("/api/tokens", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load API tokens"));
+	}
+	const data: { tokens: APIToken[] } = await response.json();
+	return data.tokens;
+}
+
+export async function createToken(
+	params: CreateTokenParams,
+	fetchImpl?: typeof fetch
+): Promise<CreatedToken> {
+	const response = await fetchAPI(
+		"/api/tokens",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to create API token"));
+	}
+	return response.json();
+}
+
+export async function revokeToken(id: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/tokens/${encodeURIComponent(id)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to revoke API token"));
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+import type { NotificationView } from "./types";
+
+// The triage state of one notification in a backup, matched by GitHub ID on import
+export interface TriageEntry {
+	githubId: string;
+	isRead: boolean;
+	archived: boolean;
+	starred: boolean;
+	muted: boolean;
+	snoozedUntil?: string;
+	tags?: string[];
+}
+
+export interface TriageTagBackup {
+	name: string;
+	color?: string;
+	description?: string;
+}
+
+export interface TriageViewBackup {
+	name: string;
+	slug: string;
+	description?: string;
+	icon?: string;
+	query: string;
+	wipLimit?: number;
+	wipAutoSnooze?: boolean;
+}
+
+// A rule in a backup names its view and tags by slug so it can be restored elsewhere
+export interface TriageRuleBackup {
+	name: string;
+	description?: string;
+	query?: string;
+	viewSlug?: string;
+	enabled: boolean;
+	actions: Record<string, unknown>;
+	priority?: number;
+	stopProcessing?: boolean;
+}
+
+export interface TriageBackup {
+	version: number;
+	exportedAt: string;
+	notifications: TriageEntry[];
+	tags?: TriageTagBackup[];
+	views?: TriageViewBackup[];
+	rules?: TriageRuleBackup[];
+}
+
+export interface TriageImportCounts {
+	created: number;
+	skipped: number;
+}
+
+export interface TriageImportResult {
+	staged: number;
+	applied: number;
+	pending: number;
+	tags: TriageImportCounts;
+	views: TriageImportCounts;
+	rules: TriageImportCounts;
+	resyncQueued: boolean;
+}
+
+// Entries sent per import request, keeping each request under the server's body limit
+const IMPORT_CHUNK_SIZE = 5000;
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function exportTriage(fetchImpl?: typeof fetch): Promise<TriageBackup> {
+	const response = await fetchAPI("/api/user/triage-export", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to export triage state"));
+	}
+	return response.json();
+}
+
+// importTriage restores a backup. Large backups are sent in several requests; state is
+// merged into notifications that already exist and the rest is applied as they sync.
+// Tags, views and rules go with the first request. Pass resync to queue a sync once the
+// last chunk is staged.
+export async function importTriage(
+	backup: TriageBackup,
+	resync = false,
+	fetchImpl?: typeof fetch
+): Promise<TriageImportResult> {
+	const total: TriageImportResult = {
+		staged: 0,
+		applied: 0,
+		pending: 0,
+		tags: { created: 0, skipped: 0 },
+		views: { created: 0, skipped: 0 },
+		rules: { created: 0, skipped: 0 },
+		resyncQueued: false,
+	};
+	const { tags, views, rules, ...rest } = backup;
+	const entries = backup.notifications ?? [];
+	for (let start = 0; start === 0 || start < entries.length; start += IMPORT_CHUNK_SIZE) {
+		const last = start + IMPORT_CHUNK_SIZE >= entries.length;
+		const setup = start === 0 ? { tags, views, rules } : {};
+		const response = await fetchAPI(
+			"/api/user/triage-import",
+			{
+				method: "POST",
+				headers: { "Content-Type": "application/json" },
+				body: JSON.stringify({
+					backup: {
+						...rest,
+						...setup,
+						notifications: entries.slice(start, start + IMPORT_CHUNK_SIZE),
+					},
+					resync: resync && last,
+				}),
+			},
+			fetchImpl
+		);
+		if (!response.ok) {
+			throw new Error(await errorMessage(response, "Failed to import triage state"));
+		}
+		const data: TriageImportResult = await response.json();
+		total.staged += data.staged;
+		total.applied += data.applied;
+		total.pending = data.pending;
+		if (start === 0) {
+			total.tags = data.tags;
+			total.views = data.views;
+			total.rules = data.rules;
+		}
+		total.resyncQueued = data.resyncQueued;
+	}
+	return total;
+}
+
+// discardTriageImport drops imported state that hasn't matched a notification yet
+export async function discardTriageImport(fetchImpl?: typeof fetch): Promise<number> {
+	const response = await fetchAPI("/api/user/triage-import", { method: "DELETE" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to discard triage import"));
+	}
+	const data: { discarded: number } = await response.json();
+	return data.discarded;
+}
+
+// A custom view shared as a document, optionally with the rules that follow it and the
+// tags those rules name
+export interface ViewShare {
+	version: number;
+	exportedAt: string;
+	view: TriageViewBackup;
+	rules?: TriageRuleBackup[];
+	tags?: TriageTagBackup[];
+}
+
+// renamed is set when the shared view's name was taken and it was imported under a new one
+export interface ViewShareImportResult {
+	view: NotificationView;
+	renamed: boolean;
+	rules: TriageImportCounts;
+	tags: TriageImportCounts;
+}
+
+export async function exportView(
+	id: string,
+	includeRules = false,
+	fetchImpl?: typeof fetch
+): Promise<ViewShare> {
+	const params = includeRules ? "?rules=true" : "";
+	const response = await fetchAPI(
+		`/api/views/${encodeURIComponent(id)}/export${params}`,
+		{ method: "GET" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to export view"));
+	}
+	return response.json();
+}
+
+export async function importView(
+	share: ViewShare,
+	fetchImpl?: typeof fetch
+): Promise<ViewShareImportResult> {
+	const response = await fetchAPI(
+		"/api/views/import",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(share),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to import view"));
+	}
+	return response.json();
+}
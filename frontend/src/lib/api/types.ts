@@ -24,6 +24,7 @@ export interface Tag {
 	description?: string;
 	slug: string;
 	unreadCount?: number;
+	assignedAt?: string; // When the tag was assigned, on a notification's tags
 }
 
 export interface BackendRepositoryResponse {
@@ -48,6 +49,31 @@ export interface BackendRepositoryResponse {
 	raw?: unknown;
 	ownerAvatarUrl?: string | null;
 	ownerHtmlUrl?: string | null;
+	digest?: RepositoryDigest | null;
+}
+
+export interface RepositoryDigestItem {
+	githubId: string;
+	title: string;
+	updatedAt: string;
+}
+
+// Unread summary returned by GET /api/repositories and GET /api/repositories/:id
+export interface RepositoryDigest {
+	unreadCount: number;
+	reasonCounts: Record<string, number>;
+	oldestUnreadAt?: string | null;
+	oldestUnreadAgeSeconds: number;
+	recentUnread: RepositoryDigestItem[];
+}
+
+export type AuthorType = "user" | "bot" | "org";
+
+export interface AuthorProfile {
+	login: string;
+	name?: string | null;
+	avatarUrl?: string | null;
+	type: AuthorType;
 }
 
 export interface BackendNotificationResponse {
@@ -67,6 +93,8 @@ export interface BackendNotificationResponse {
 	filtered: boolean;
 	snoozedUntil?: string | null;
 	snoozedAt?: string | null;
+	snoozeUntilUpdated?: boolean;
+	returnedFromSnoozeAt?: string | null;
 	effectiveSortDate: string;
 	githubUnread?: boolean | null;
 	githubUpdatedAt?: string | null;
@@ -82,11 +110,46 @@ export interface BackendNotificationResponse {
 	subjectState?: string | null;
 	subjectMerged?: boolean | null;
 	subjectStateReason?: string | null;
+	contentKind?: ContentKind;
+	headBranch?: string | null;
+	baseBranch?: string | null;
+	subjectDraft?: boolean | null;
+	labels?: string[] | null;
+	reviewState?: ReviewState | null;
+	ciState?: CIState | null;
+	mergeable?: boolean | null;
+	waitingOn?: WaitingOn | null;
+	priority?: number | null;
+	milestone?: string | null;
+	projects?: string[] | null;
+	account?: string | null;
+	awaitingReply?: boolean;
+	awaitingReplySince?: string | null;
 	actionHints?: ActionHints;
 	tags?: Tag[];
 	authorLogin?: string | null;
+	author?: AuthorProfile | null;
+	conversation?: Conversation;
 }
 
+// The notifications sharing a subject, when a list collapses them into the newest one
+export interface Conversation {
+	count: number;
+	newestReason?: string;
+}
+
+// What a notification's subject mostly is: a code change, a conversation, or neither
+export type ContentKind = "code" | "discussion" | "other";
+
+// Worked out from an open pull request's reviews at sync
+export type ReviewState = "approved" | "changes_requested" | "pending";
+
+// Rolled up from the checks on an open pull request's head commit at sync
+export type CIState = "success" | "failure" | "pending";
+
+// Whose turn it is on an open issue or pull request, worked out at sync
+export type WaitingOn = "me" | "them";
+
 export interface ActionHints {
 	dismissedOn: string[];
 }
@@ -105,12 +168,17 @@ export interface Notification {
 	filtered?: boolean;
 	snoozedUntil?: string;
 	snoozedAt?: string;
+	// The snooze also ends once the thread is updated on GitHub
+	snoozeUntilUpdated?: boolean;
+	// When the notification last came back from a snooze that ran out
+	returnedFromSnoozeAt?: string;
 	updatedAt: string;
 	labels: string[];
 	viewIds: string[];
 	repositoryId?: number;
 	state?: NotificationState;
 	authorLogin?: string;
+	author?: AuthorProfile;
 	githubUrl?: string;
 	htmlUrl?: string;
 	subjectUrl?: string;
@@ -123,9 +191,23 @@ export interface Notification {
 	subjectState?: string;
 	subjectMerged?: boolean;
 	subjectStateReason?: string;
+	contentKind?: ContentKind;
+	headBranch?: string; // Pull requests only
+	baseBranch?: string; // Pull requests only
+	subjectDraft?: boolean; // Pull requests only
+	labels?: string[];
+	reviewState?: ReviewState; // Open pull requests only
+	ciState?: CIState; // Open pull requests only
+	mergeable?: boolean; // Open pull requests only; false when it has conflicts
+	waitingOn?: WaitingOn; // Open issues and pull requests only
+	priority?: number; // Score from the user's priority weights
+	milestone?: string; // Milestone title
+	projects?: string[]; // Titles of the projects the subject is in
+	account?: string; // Login of the GitHub account it was synced for
 	actionHints?: ActionHints;
 	tags?: Tag[];
 	effectiveSortDate?: string;
+	conversation?: Conversation; // Only set when listed with conversations
 }
 
 export type ViewFilterOperator = "equals" | "contains" | "does not equal" | "does not contain";
@@ -147,6 +229,26 @@ export interface NotificationView {
 	query: string; // New: query string instead of filters array
 	unreadCount: number;
 	displayOrder?: number;
+	wipLimit?: number;
+	wipAutoSnooze?: boolean;
+	count?: number;
+	overLimit?: boolean;
+	groupId?: string; // Sidebar group the view is in, if any
+}
+
+export interface NotificationViewGroup {
+	id: string;
+	name: string;
+	displayOrder: number;
+	collapsed: boolean;
+}
+
+export interface NotificationViewCount {
+	id: string;
+	slug: string;
+	unreadCount: number;
+	count?: number;
+	overLimit?: boolean;
 }
 
 export interface NotificationViewInput {
@@ -171,11 +273,20 @@ export interface NotificationFilters {
 	reason?: string;
 }
 
+// The implicit filters the backend added to a query, and why
+export interface QueryDefaults {
+	scope: "inbox" | "mutedOnly" | "none";
+	excluded: string[]; // e.g. "muted"
+	reason: string;
+}
+
 export interface NotificationPage {
 	items: Notification[];
 	total: number;
 	pageSize: number;
 	page: number;
+	defaults?: QueryDefaults; // Only set when fetched with explainDefaults
+	nextCursor?: string; // Fetches the page after this one; unset on the last page
 }
 
 export interface NotificationTarget {
@@ -33,6 +33,45 @@ export interface SyncSettings {
 	initialSyncMaxCount?: number | null;
 	initialSyncUnreadOnly: boolean;
 	setupCompleted: boolean;
+	// Whether GitHub webhook deliveries are applied as they arrive. Polling stays on
+	// either way.
+	webhooksEnabled: boolean;
+	// Minutes after an archive during which new activity doesn't bring a thread back to the
+	// inbox, unless the user is mentioned directly. 0 turns it off.
+	resurfaceSuppressionMinutes: number;
+	// Days without a reply after which a thread whose latest comment is your own counts as
+	// awaiting a reply. 0 turns it off.
+	awaitingReplyDays: number;
+	// Whether threads come back to the inbox once they are awaiting a reply
+	resurfaceAwaitingReplies: boolean;
+	// Drop raw GitHub payloads once their metadata is extracted
+	skipPayloadStorage: boolean;
+	// Keep the body of the latest comment out of storage
+	skipCommentCaching: boolean;
+	// Keep only what the notification itself carries; no subject or comment is fetched
+	titlesOnly: boolean;
+	// Mark threads read on GitHub when they're read here, and done when archived. Changes
+	// that fail while offline are retried for over an hour.
+	markOnGitHub: boolean;
+	// Orgs whose notifications are synced. Empty syncs every org that isn't blocked.
+	orgAllowlist: string[];
+	// Orgs whose notifications are never synced
+	orgBlocklist: string[];
+	// Which features the storage settings leave available
+	capabilities: StorageCapabilities;
+}
+
+export interface StorageCapabilities {
+	// Author, state, branches and pull request metadata
+	subjectDetails: boolean;
+	// body: matches issue and pull request descriptions
+	descriptionSearch: boolean;
+	// body: matches the latest comment
+	commentSearch: boolean;
+	// awaiting:reply tracking
+	awaitingReply: boolean;
+	// Timelines are fetched ahead of time at startup
+	timelineWarming: boolean;
 }
 
 export interface UserResponse {
@@ -80,6 +119,26 @@ export interface UpdateSyncSettingsRequest {
 	initialSyncMaxCount?: number | null;
 	initialSyncUnreadOnly: boolean;
 	setupCompleted: boolean;
+	// Left unchanged when omitted
+	webhooksEnabled?: boolean;
+	// Left unchanged when omitted
+	resurfaceSuppressionMinutes?: number;
+	// Left unchanged when omitted
+	awaitingReplyDays?: number;
+	// Left unchanged when omitted
+	resurfaceAwaitingReplies?: boolean;
+	// Left unchanged when omitted
+	skipPayloadStorage?: boolean;
+	// Left unchanged when omitted
+	skipCommentCaching?: boolean;
+	// Left unchanged when omitted
+	titlesOnly?: boolean;
+	// Left unchanged when omitted
+	markOnGitHub?: boolean;
+	// Left unchanged when omitted
+	orgAllowlist?: string[];
+	// Left unchanged when omitted
+	orgBlocklist?: string[];
 }
 
 export async function updateSyncSettings(
@@ -128,6 +187,77 @@ export async function getSyncState(fetchImpl?: typeof fetch): Promise<SyncState>
 	return response.json();
 }
 
+// The GitHub API budget background syncing spends, as of GitHub's latest response.
+// Below the reserve, subject enrichment slows down and backfills wait for the reset.
+export interface RateLimit {
+	known: boolean;
+	limit: number;
+	remaining: number;
+	used: number;
+	resetAt?: string;
+	reserve: number;
+	throttled: boolean;
+}
+
+export async function getRateLimit(fetchImpl?: typeof fetch): Promise<RateLimit> {
+	const response = await fetchAPI("/api/sync/rate-limit", { method: "GET" }, fetchImpl);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get rate limit" }));
+		throw new Error(error.error || "Failed to get rate limit");
+	}
+
+	return response.json();
+}
+
+// How far the initial sync has got. Pages are fetched newest first and queued for
+// processing; an interrupted sync picks up from the oldest notification synced.
+export type SyncPhase = "waiting" | "fetching" | "processing" | "complete";
+
+export interface SyncProgress {
+	phase: SyncPhase;
+	startedAt?: string;
+	completedAt?: string;
+	pagesFetched: number;
+	queued: number;
+	processed: number;
+	pending: number;
+	oldestSyncedAt?: string;
+	etaSeconds?: number; // Covers only the notifications queued so far
+}
+
+export async function getSyncProgress(fetchImpl?: typeof fetch): Promise<SyncProgress> {
+	const response = await fetchAPI("/api/sync/progress", { method: "GET" }, fetchImpl);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get sync progress" }));
+		throw new Error(error.error || "Failed to get sync progress");
+	}
+
+	return response.json();
+}
+
+// An org seen in synced repositories or named by the org filter
+export interface SyncOrg {
+	login: string;
+	// Repositories of the org seen so far
+	repositories: number;
+	// Whether the org filter lets its notifications sync
+	synced: boolean;
+}
+
+export async function getSyncOrgs(fetchImpl?: typeof fetch): Promise<SyncOrg[]> {
+	const response = await fetchAPI("/api/user/sync-settings/orgs", { method: "GET" }, fetchImpl);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to list orgs" }));
+		throw new Error(error.error || "Failed to list orgs");
+	}
+
+	const data: { orgs: SyncOrg[] } = await response.json();
+	return data.orgs;
+}
+
 export interface SyncOlderRequest {
 	days: number;
 	maxCount?: number | null;
@@ -586,3 +716,525 @@ export async function restartApp(fetchImpl?: typeof fetch): Promise<void> {
 	// Response is not needed, but we can return it if needed
 	await response.json();
 }
+
+export interface EscalationSettings {
+	enabled: boolean;
+	afterDays: number;
+	tagEscalated: boolean;
+	lastRunAt?: string;
+}
+
+export async function getEscalationSettings(fetchImpl?: typeof fetch): Promise<EscalationSettings> {
+	const response = await fetchAPI(
+		"/api/user/escalation-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to get escalation settings" }));
+		throw new Error(error.error || "Failed to get escalation settings");
+	}
+
+	return response.json();
+}
+
+export interface UpdateEscalationSettingsRequest {
+	enabled: boolean;
+	afterDays: number;
+	tagEscalated: boolean;
+}
+
+export async function updateEscalationSettings(
+	settings: UpdateEscalationSettingsRequest,
+	fetchImpl?: typeof fetch
+): Promise<EscalationSettings> {
+	const response = await fetchAPI(
+		"/api/user/escalation-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update escalation settings" }));
+		throw new Error(error.error || "Failed to update escalation settings");
+	}
+
+	return response.json();
+}
+
+export interface WorkingHours {
+	enabled: boolean;
+	days: number[]; // 0 = Sunday ... 6 = Saturday
+	start: string; // "HH:MM"
+	end: string; // "HH:MM"
+	timezone?: string;
+	holidays: string[]; // "YYYY-MM-DD"
+}
+
+export async function getWorkingHours(fetchImpl?: typeof fetch): Promise<WorkingHours> {
+	const response = await fetchAPI(
+		"/api/user/working-hours",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get working hours" }));
+		throw new Error(error.error || "Failed to get working hours");
+	}
+
+	return response.json();
+}
+
+export async function updateWorkingHours(
+	settings: WorkingHours,
+	fetchImpl?: typeof fetch
+): Promise<WorkingHours> {
+	const response = await fetchAPI(
+		"/api/user/working-hours",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update working hours" }));
+		throw new Error(error.error || "Failed to update working hours");
+	}
+
+	return response.json();
+}
+
+export interface BusinessTimeResponse {
+	at: string;
+}
+
+/**
+ * Get the start of working hours on the next business day after `from` (default now).
+ * Backs the "Next business day" snooze preset.
+ */
+export async function getNextBusinessDay(
+	from?: string,
+	fetchImpl?: typeof fetch
+): Promise<BusinessTimeResponse> {
+	const params = from ? `?from=${encodeURIComponent(from)}` : "";
+	const response = await fetchAPI(
+		`/api/user/working-hours/next-business-day${params}`,
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to get next business day" }));
+		throw new Error(error.error || "Failed to get next business day");
+	}
+
+	return response.json();
+}
+
+/**
+ * Move `from` (default now) by a number of business days, e.g. to compute an SLA deadline.
+ */
+export async function addBusinessDays(
+	days: number,
+	from?: string,
+	fetchImpl?: typeof fetch
+): Promise<BusinessTimeResponse> {
+	const params = new URLSearchParams({ days: String(days) });
+	if (from) {
+		params.set("from", from);
+	}
+	const response = await fetchAPI(
+		`/api/user/working-hours/add-business-days?${params.toString()}`,
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to add business days" }));
+		throw new Error(error.error || "Failed to add business days");
+	}
+
+	return response.json();
+}
+
+export interface TeamSettings {
+	members: string[]; // GitHub logins
+}
+
+export async function getTeamSettings(fetchImpl?: typeof fetch): Promise<TeamSettings> {
+	const response = await fetchAPI(
+		"/api/user/team-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get team settings" }));
+		throw new Error(error.error || "Failed to get team settings");
+	}
+
+	return response.json();
+}
+
+export async function updateTeamSettings(
+	settings: TeamSettings,
+	fetchImpl?: typeof fetch
+): Promise<TeamSettings> {
+	const response = await fetchAPI(
+		"/api/user/team-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update team settings" }));
+		throw new Error(error.error || "Failed to update team settings");
+	}
+
+	return response.json();
+}
+
+// Default snooze used when a notification is snoozed without a time and its repository has
+// no default of its own. A preset such as "tomorrow", or a duration such as "3d".
+// markUnreadOnReturn marks notifications unread when their snooze runs out.
+export interface SnoozeSettings {
+	defaultPreset: string;
+	markUnreadOnReturn?: boolean;
+}
+
+export async function getSnoozeSettings(fetchImpl?: typeof fetch): Promise<SnoozeSettings> {
+	const response = await fetchAPI(
+		"/api/user/snooze-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get snooze settings" }));
+		throw new Error(error.error || "Failed to get snooze settings");
+	}
+
+	return response.json();
+}
+
+export async function updateSnoozeSettings(
+	settings: SnoozeSettings,
+	fetchImpl?: typeof fetch
+): Promise<SnoozeSettings> {
+	const response = await fetchAPI(
+		"/api/user/snooze-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update snooze settings" }));
+		throw new Error(error.error || "Failed to update snooze settings");
+	}
+
+	return response.json();
+}
+
+// Priority weights. A notification's score adds the points for its reason, repository,
+// author and CI state, plus stalenessPerDay for each day without an update, for up to
+// maxStalenessDays days.
+export interface PrioritySettings {
+	reasons: Record<string, number>;
+	repositories: Record<string, number>;
+	authors: Record<string, number>;
+	ciStates: Record<string, number>;
+	stalenessPerDay: number;
+	maxStalenessDays: number;
+}
+
+export async function getPrioritySettings(fetchImpl?: typeof fetch): Promise<PrioritySettings> {
+	const response = await fetchAPI(
+		"/api/user/priority-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to get priority settings" }));
+		throw new Error(error.error || "Failed to get priority settings");
+	}
+
+	return response.json();
+}
+
+export async function updatePrioritySettings(
+	settings: PrioritySettings,
+	fetchImpl?: typeof fetch
+): Promise<PrioritySettings> {
+	const response = await fetchAPI(
+		"/api/user/priority-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update priority settings" }));
+		throw new Error(error.error || "Failed to update priority settings");
+	}
+
+	return response.json();
+}
+
+// Periodic notification digests. A digest of the notifications matching query is generated
+// at hour each day, or each week on weekday (0 is Sunday), in the working hours time zone,
+// and emailed to emailTo when set. canEmail is whether a mail server is configured.
+export interface DigestSettings {
+	enabled: boolean;
+	frequency: "daily" | "weekly";
+	hour: number;
+	weekday: number;
+	query: string;
+	emailTo: string;
+	canEmail?: boolean;
+}
+
+export interface Digest {
+	name: string;
+	createdAt: string;
+	markdown: string;
+	html: string;
+	emailedTo?: string;
+}
+
+export async function getDigestSettings(fetchImpl?: typeof fetch): Promise<DigestSettings> {
+	const response = await fetchAPI(
+		"/api/user/digest-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to get digest settings" }));
+		throw new Error(error.error || "Failed to get digest settings");
+	}
+
+	return response.json();
+}
+
+export async function updateDigestSettings(
+	settings: DigestSettings,
+	fetchImpl?: typeof fetch
+): Promise<DigestSettings> {
+	const response = await fetchAPI(
+		"/api/user/digest-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update digest settings" }));
+		throw new Error(error.error || "Failed to update digest settings");
+	}
+
+	return response.json();
+}
+
+// generateDigest generates a digest now, emailing it when the settings have an address
+export async function generateDigest(fetchImpl?: typeof fetch): Promise<Digest> {
+	const response = await fetchAPI(
+		"/api/user/digests",
+		{
+			method: "POST",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to generate digest" }));
+		throw new Error(error.error || "Failed to generate digest");
+	}
+
+	return response.json();
+}
+
+// getLatestDigest returns the most recent digest, or null if none has been generated
+export async function getLatestDigest(fetchImpl?: typeof fetch): Promise<Digest | null> {
+	const response = await fetchAPI(
+		"/api/user/digests/latest",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (response.status === 404) {
+		return null;
+	}
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get latest digest" }));
+		throw new Error(error.error || "Failed to get latest digest");
+	}
+
+	return response.json();
+}
+
+// Scheduled database backups. A backup is taken every intervalHours while enabled, and only
+// the newest keep backups are kept.
+export interface BackupSettings {
+	enabled: boolean;
+	intervalHours: number;
+	keep: number;
+}
+
+export async function getBackupSettings(fetchImpl?: typeof fetch): Promise<BackupSettings> {
+	const response = await fetchAPI(
+		"/api/user/backup-settings",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get backup settings" }));
+		throw new Error(error.error || "Failed to get backup settings");
+	}
+
+	return response.json();
+}
+
+export async function updateBackupSettings(
+	settings: BackupSettings,
+	fetchImpl?: typeof fetch
+): Promise<BackupSettings> {
+	const response = await fetchAPI(
+		"/api/user/backup-settings",
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(settings),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response
+			.json()
+			.catch(() => ({ error: "Failed to update backup settings" }));
+		throw new Error(error.error || "Failed to update backup settings");
+	}
+
+	return response.json();
+}
+
+export interface ReviewerLoad {
+	login: string;
+	requests: number;
+	open: number;
+	unread: number;
+	overloaded: boolean;
+}
+
+export interface ReviewLoadReport {
+	since: string;
+	days: number;
+	reviewers: ReviewerLoad[];
+	average: number;
+	totalRequests: number;
+	unassigned: number;
+}
+
+/**
+ * Get review requests from the last `days` days grouped by requested reviewer.
+ */
+export async function getReviewLoad(
+	days = 14,
+	fetchImpl?: typeof fetch
+): Promise<ReviewLoadReport> {
+	const response = await fetchAPI(
+		`/api/team/review-load?days=${days}`,
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get review load" }));
+		throw new Error(error.error || "Failed to get review load");
+	}
+
+	return response.json();
+}
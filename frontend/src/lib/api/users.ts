@@ -0,0 +1,160 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+
+import { fetchAPI } from "./fetch";
+
+export type ServerUserRole = "admin" | "member";
+
+// A user of a multi-user server. Each user has a workspace of their own, with their
+// own GitHub account, notifications, views, tags, and rules.
+export interface ServerUser {
+	username: string;
+	name: string;
+	role: ServerUserRole;
+	workspace: string;
+	hasPassword: boolean;
+	createdAt: string;
+}
+
+// The signed-in user's session. admin is set for users who may manage other users.
+export interface AuthSession {
+	sub: string;
+	name?: string;
+	email?: string;
+	groups?: string[];
+	account: string;
+	admin?: boolean;
+	exp: number;
+}
+
+export interface CreateUserParams {
+	username: string;
+	name?: string;
+	role?: ServerUserRole;
+	password?: string;
+}
+
+export interface UpdateUserParams {
+	name?: string;
+	role?: ServerUserRole;
+	password?: string;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+// fetchSession returns the signed-in user's session, or null when the server doesn't
+// require signing in
+export async function fetchSession(fetchImpl?: typeof fetch): Promise<AuthSession | null> {
+	const response = await fetchAPI("/api/auth/session", { method: "GET" }, fetchImpl);
+	if (response.status === 404) {
+		return null;
+	}
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load session"));
+	}
+	return response.json();
+}
+
+export async function logout(fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI("/api/auth/logout", { method: "POST" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to sign out"));
+	}
+}
+
+export async function changePassword(
+	currentPassword: string,
+	newPassword: string,
+	fetchImpl?: typeof fetch
+): Promise<void> {
+	const response = await fetchAPI(
+		"/api/auth/password",
+		{
+			method: "PUT",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ currentPassword, newPassword }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to change password"));
+	}
+}
+
+export async function listUsers(fetchImpl?: typeof fetch): Promise<ServerUser[]> {
+	const response = await fetchAPI("/api/users", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load users"));
+	}
+	const data: { users: ServerUser[] } = await response.json();
+	return data.users;
+}
+
+export async function createUser(
+	params: CreateUserParams,
+	fetchImpl?: typeof fetch
+): Promise<ServerUser> {
+	const response = await fetchAPI(
+		"/api/users",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to create user"));
+	}
+	const data: { user: ServerUser } = await response.json();
+	return data.user;
+}
+
+export async function updateUser(
+	username: string,
+	params: UpdateUserParams,
+	fetchImpl?: typeof fetch
+): Promise<ServerUser> {
+	const response = await fetchAPI(
+		`/api/users/${encodeURIComponent(username)}`,
+		{
+			method: "PATCH",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(params),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to update user"));
+	}
+	const data: { user: ServerUser } = await response.json();
+	return data.user;
+}
+
+// deleteUser removes a user along with their workspace and all of its data
+export async function deleteUser(username: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/users/${encodeURIComponent(username)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to delete user"));
+	}
+}
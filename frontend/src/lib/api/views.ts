@@ -21,7 +21,12 @@ import {
 	isProxyConnectionError,
 } from "./fetch";
 import { DEFAULT_VIEW_ICON } from "$lib/utils/viewIcons";
-import type { NotificationView, NotificationViewInput } from "./types";
+import type {
+	NotificationView,
+	NotificationViewCount,
+	NotificationViewGroup,
+	NotificationViewInput,
+} from "./types";
 
 const cloneView = (view: NotificationView): NotificationView => ({
 	...view,
@@ -74,6 +79,15 @@ export async function fetchViews(fetchImpl?: typeof fetch): Promise<Notification
 	}
 }
 
+export async function fetchViewCounts(fetchImpl?: typeof fetch): Promise<NotificationViewCount[]> {
+	const response = await fetchWithAuth("/api/views/counts", {}, fetchImpl);
+	if (!response.ok) {
+		throw new Error(`Failed to load view counts (${response.status})`);
+	}
+	const payload: { counts: NotificationViewCount[] } = await response.json();
+	return payload?.counts ?? [];
+}
+
 export async function createView(
 	input: NotificationViewInput,
 	fetchImpl?: typeof fetch
@@ -153,6 +167,43 @@ export async function updateView(
 	return cloneView(updated);
 }
 
+export async function setViewWIPLimit(
+	id: string,
+	limit: number | null,
+	autoSnooze: boolean,
+	fetchImpl?: typeof fetch
+): Promise<NotificationView> {
+	const response = await fetchWithAuth(
+		`/api/views/${encodeURIComponent(id)}/wip-limit`,
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ limit, autoSnooze }),
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		let errorMessage = `Failed to update WIP limit (${response.status})`;
+		try {
+			const errorData: { error?: string } = await response.json();
+			if (errorData.error) {
+				errorMessage = errorData.error;
+			}
+		} catch {
+			// If parsing fails, use the default error message
+		}
+		const error: any = new Error(errorMessage);
+		error.statusCode = response.status;
+		throw error;
+	}
+
+	const payload: { view: NotificationView } = await response.json();
+	return cloneView(payload.view);
+}
+
 export async function deleteView(
 	id: string,
 	force: boolean = false,
@@ -170,8 +221,9 @@ export async function deleteView(
 		if (response.status === 409) {
 			// Conflict - view has linked rules
 			const errorData = await response.json();
-			const error: any = new Error("This view has linked rules that will also be deleted");
+			const error: any = new Error("This view has linked rules that will be disabled");
 			error.linkedRuleCount = errorData.linkedRuleCount;
+			error.dependentRules = errorData.dependentRules ?? [];
 			error.statusCode = 409;
 			throw error;
 		}
@@ -203,3 +255,139 @@ export async function reorderViews(
 	const data = (payload?.views ?? []).map(cloneView);
 	return data.map(cloneView);
 }
+
+// throwGroupError raises the API's error message for a failed view group request
+async function throwGroupError(response: Response, fallback: string): Promise<never> {
+	let errorMessage = `${fallback} (${response.status})`;
+	try {
+		const errorData: { error?: string } = await response.json();
+		if (errorData.error) {
+			errorMessage = errorData.error;
+		}
+	} catch {
+		// If parsing fails, use the default error message
+	}
+	const error: any = new Error(errorMessage);
+	error.statusCode = response.status;
+	throw error;
+}
+
+export async function fetchViewGroups(fetchImpl?: typeof fetch): Promise<NotificationViewGroup[]> {
+	const response = await fetchWithAuth("/api/views/groups", {}, fetchImpl);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to load view groups");
+	}
+	const payload: { groups: NotificationViewGroup[] } = await response.json();
+	return payload?.groups ?? [];
+}
+
+export async function createViewGroup(
+	name: string,
+	fetchImpl?: typeof fetch
+): Promise<NotificationViewGroup> {
+	const response = await fetchWithAuth(
+		"/api/views/groups",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ name }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to create view group");
+	}
+	const payload: { group: NotificationViewGroup } = await response.json();
+	return payload.group;
+}
+
+// updateViewGroup renames a group or sets whether it's collapsed
+export async function updateViewGroup(
+	id: string,
+	input: { name?: string; collapsed?: boolean },
+	fetchImpl?: typeof fetch
+): Promise<NotificationViewGroup> {
+	const response = await fetchWithAuth(
+		`/api/views/groups/${encodeURIComponent(id)}`,
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify(input),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to update view group");
+	}
+	const payload: { group: NotificationViewGroup } = await response.json();
+	return payload.group;
+}
+
+// deleteViewGroup deletes a group; its views are kept and become ungrouped
+export async function deleteViewGroup(id: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchWithAuth(
+		`/api/views/groups/${encodeURIComponent(id)}`,
+		{
+			method: "DELETE",
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to delete view group");
+	}
+}
+
+export async function reorderViewGroups(
+	groupIds: string[],
+	fetchImpl?: typeof fetch
+): Promise<NotificationViewGroup[]> {
+	const response = await fetchWithAuth(
+		"/api/views/groups/reorder",
+		{
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ groupIds }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to reorder view groups");
+	}
+	const payload: { groups: NotificationViewGroup[] } = await response.json();
+	return payload?.groups ?? [];
+}
+
+// setViewGroup moves a view into a group, or out of its group with a null groupId
+export async function setViewGroup(
+	id: string,
+	groupId: string | null,
+	fetchImpl?: typeof fetch
+): Promise<NotificationView> {
+	const response = await fetchWithAuth(
+		`/api/views/${encodeURIComponent(id)}/group`,
+		{
+			method: "PUT",
+			headers: {
+				"Content-Type": "application/json",
+			},
+			body: JSON.stringify({ groupId }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		await throwGroupError(response, "Failed to move view");
+	}
+	const payload: { view: NotificationView } = await response.json();
+	return cloneView(payload.view);
+}
+
+// viewRenderUrl is the address of a printable page listing the view's notifications
+export function viewRenderUrl(id: string): string {
+	return buildApiUrl(`/api/views/${encodeURIComponent(id)}/render?format=html`);
+}
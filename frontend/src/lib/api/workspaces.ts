@@ -0,0 +1,84 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { fetchAPI } from "./fetch";
+
+// A workspace is an independent profile with its own GitHub account, notifications,
+// views, tags, and rules. Only one is active at a time.
+export interface Workspace {
+	slug: string;
+	name: string;
+	active: boolean;
+}
+
+async function errorMessage(response: Response, fallback: string): Promise<string> {
+	const error = await response.json().catch(() => ({ error: fallback }));
+	return error.error || fallback;
+}
+
+export async function listWorkspaces(fetchImpl?: typeof fetch): Promise<Workspace[]> {
+	const response = await fetchAPI("/api/workspaces", { method: "GET" }, fetchImpl);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to load workspaces"));
+	}
+	const data: { workspaces: Workspace[] } = await response.json();
+	return data.workspaces;
+}
+
+export async function createWorkspace(name: string, fetchImpl?: typeof fetch): Promise<Workspace> {
+	const response = await fetchAPI(
+		"/api/workspaces",
+		{
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify({ name }),
+		},
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to create workspace"));
+	}
+	const data: { workspace: Workspace } = await response.json();
+	return data.workspace;
+}
+
+// activateWorkspace switches the server to another workspace. Everything the page has
+// loaded belongs to the previous workspace, so callers should reload afterwards.
+export async function activateWorkspace(
+	slug: string,
+	fetchImpl?: typeof fetch
+): Promise<Workspace> {
+	const response = await fetchAPI(
+		`/api/workspaces/${encodeURIComponent(slug)}/activate`,
+		{ method: "POST" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to switch workspace"));
+	}
+	const data: { workspace: Workspace } = await response.json();
+	return data.workspace;
+}
+
+export async function deleteWorkspace(slug: string, fetchImpl?: typeof fetch): Promise<void> {
+	const response = await fetchAPI(
+		`/api/workspaces/${encodeURIComponent(slug)}`,
+		{ method: "DELETE" },
+		fetchImpl
+	);
+	if (!response.ok) {
+		throw new Error(await errorMessage(response, "Failed to delete workspace"));
+	}
+}
@@ -29,13 +29,23 @@ export interface FilterFieldConfig {
 export const FILTER_FIELDS: FilterFieldConfig[] = [
 	{
 		value: "in",
-		description: "View context (inbox, archive, snoozed, filtered, anywhere)",
-		valueSuggestions: ["inbox", "archive", "snoozed", "filtered", "anywhere"],
+		description: "View context (inbox, archive, snoozed, starred, filtered, anywhere)",
+		valueSuggestions: ["inbox", "archive", "snoozed", "starred", "filtered", "anywhere"],
 	},
 	{
 		value: "is",
 		description: "Special status flag",
-		valueSuggestions: ["read", "unread", "muted"],
+		valueSuggestions: ["read", "unread", "muted", "starred", "filtered", "system"],
+	},
+	{
+		value: "starred",
+		description: "Starred notifications",
+		valueSuggestions: ["true", "false"],
+	},
+	{
+		value: "filtered",
+		description: "Notifications that skipped the inbox",
+		valueSuggestions: ["true", "false"],
 	},
 	{
 		value: "reason",
@@ -59,10 +69,79 @@ export const FILTER_FIELDS: FilterFieldConfig[] = [
 		description: "Subject type (issue, pullrequest, etc.)",
 		valueSuggestions: ["issue", "pullrequest", "release", "discussion", "commit"],
 	},
+	{
+		value: "kind",
+		description: "Content kind (code changes, discussions, or other)",
+		valueSuggestions: ["code", "discussion", "other"],
+	},
+	{
+		value: "awaiting",
+		description: "Threads where your latest comment hasn't had a reply",
+		valueSuggestions: ["reply"],
+	},
+	{
+		value: "waiting-on",
+		description: "Whose turn it is on open issues and PRs",
+		valueSuggestions: ["me", "them"],
+	},
+	{
+		value: "priority",
+		description: "Priority score from your weights (>N, <N or N)",
+		valueSuggestions: [">30", ">10", "<5"],
+	},
 	{
 		value: "repo",
 		description: "Repository full name",
 	},
+	{
+		value: "branch",
+		description: "PR head or base branch (* matches anything)",
+		valueSuggestions: ["main", "release/*"],
+	},
+	{
+		value: "draft",
+		description: "Draft pull requests",
+		valueSuggestions: ["true", "false"],
+	},
+	{
+		value: "mergeable",
+		description: "Open pull requests without conflicts (false: blocked on conflicts)",
+		valueSuggestions: ["false", "true"],
+	},
+	{
+		value: "label",
+		description: "Issue or PR label name",
+	},
+	{
+		value: "milestone",
+		description: "Issue or PR milestone title",
+	},
+	{
+		value: "project",
+		description: "Project the issue or PR was added to",
+	},
+	{
+		value: "review",
+		description: "Review state of open pull requests",
+		valueSuggestions: ["pending", "approved", "changes_requested"],
+	},
+	{
+		value: "ci",
+		description: "Combined check state of open pull requests",
+		valueSuggestions: ["failing", "pending", "passing"],
+	},
+	{
+		value: "reviewer",
+		description: "Pull requests waiting on a review from this login",
+	},
+	{
+		value: "team-review-requested",
+		description: "Pull requests waiting on a review from this team (org/team)",
+	},
+	{
+		value: "account",
+		description: "GitHub account the notification was synced for",
+	},
 	{
 		value: "state",
 		description: "Issue or PR state (open, closed)",
@@ -76,6 +155,10 @@ export const FILTER_FIELDS: FilterFieldConfig[] = [
 		value: "title",
 		description: "Notification title",
 	},
+	{
+		value: "body",
+		description: "Words in the description or latest comment",
+	},
 	{
 		value: "org",
 		description: "Organization name",
@@ -84,6 +167,11 @@ export const FILTER_FIELDS: FilterFieldConfig[] = [
 		value: "tags",
 		description: "Tag slug (supports partial matching)",
 	},
+	{
+		value: "tagged",
+		description: "When a tag was assigned (<7d within a week, >7d before that)",
+		valueSuggestions: ["<1d", "<7d", ">30d"],
+	},
 ];
 
 /**
@@ -82,6 +82,13 @@ export const BUILT_IN_VIEWS = {
 		icon: "snooze",
 		query: "snoozed:true -muted:true",
 	},
+	recentlyTagged: {
+		id: "__recently-tagged__",
+		slug: "recently-tagged",
+		name: "Recently tagged",
+		icon: "tag",
+		query: "tagged:<7d in:anywhere -is:muted",
+	},
 	everything: {
 		id: "__everything__",
 		slug: "everything",
@@ -375,7 +375,7 @@ export function createViewDialogController(
 		const deletedViewId = currentEditing.id;
 		try {
 			// User confirmed - try again with force=true
-			// The backend will cascade delete due to FK constraint
+			// The backend disables the linked rules and keeps the view's query on them
 			await deleteView(deletedViewId, true);
 			try {
 				const updatedViews = await fetchViews();
@@ -396,7 +396,7 @@ export function createViewDialogController(
 			}
 
 			setTimeout(() => {
-				toastStore.success("View and linked rules deleted");
+				toastStore.success("View deleted and linked rules disabled");
 			}, 0);
 		} catch (retryError) {
 			setTimeout(() => {
@@ -53,12 +53,13 @@ describe("ViewStore", () => {
 			const store = createViewStore([], BUILT_IN_VIEWS.inbox.id);
 			const builtInViews = get(store.builtInViewList);
 
-			expect(builtInViews).toHaveLength(5);
+			expect(builtInViews).toHaveLength(6);
 			expect(builtInViews.map((v) => v.id)).toEqual([
 				BUILT_IN_VIEWS.inbox.id,
 				BUILT_IN_VIEWS.starred.id,
 				BUILT_IN_VIEWS.snoozed.id,
 				BUILT_IN_VIEWS.archive.id,
+				BUILT_IN_VIEWS.recentlyTagged.id,
 				BUILT_IN_VIEWS.everything.id,
 			]);
 		});
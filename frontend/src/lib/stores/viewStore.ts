@@ -60,6 +60,17 @@ export function createViewStore(initialViews: NotificationView[], initialViewId:
 		unreadCount: 0,
 	};
 
+	const builtInRecentlyTaggedView: NotificationView = {
+		id: BUILT_IN_VIEWS.recentlyTagged.id,
+		slug: BUILT_IN_VIEWS.recentlyTagged.slug,
+		name: BUILT_IN_VIEWS.recentlyTagged.name,
+		description: "",
+		icon: BUILT_IN_VIEWS.recentlyTagged.icon,
+		isDefault: false,
+		query: BUILT_IN_VIEWS.recentlyTagged.query,
+		unreadCount: 0,
+	};
+
 	const builtInEverythingView: NotificationView = {
 		id: BUILT_IN_VIEWS.everything.id,
 		slug: BUILT_IN_VIEWS.everything.slug,
@@ -90,6 +101,7 @@ export function createViewStore(initialViews: NotificationView[], initialViewId:
 		builtInStarredView,
 		builtInSnoozedView,
 		builtInArchiveView,
+		builtInRecentlyTaggedView,
 		builtInEverythingView,
 	]);
 
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { debugLog } from "$lib/utils/debug";
+
+// Short tones for the named alert sounds: [frequency Hz, start s, duration s]
+const tones: Record<string, [number, number, number][]> = {
+	chime: [
+		[880, 0, 0.25],
+		[1320, 0.12, 0.35],
+	],
+	ping: [[1760, 0, 0.2]],
+	pop: [[440, 0, 0.08]],
+	bell: [
+		[660, 0, 0.6],
+		[1320, 0, 0.4],
+	],
+};
+
+let audioContext: AudioContext | null = null;
+
+// playAlertSound plays one of the named alert sounds. Unknown names, "silent" and the
+// system default are ignored; browsers may also refuse until the page has been used.
+export function playAlertSound(sound: string): void {
+	const notes = tones[sound];
+	if (!notes || typeof AudioContext === "undefined") {
+		return;
+	}
+
+	try {
+		audioContext ??= new AudioContext();
+		const start = audioContext.currentTime;
+		for (const [frequency, offset, duration] of notes) {
+			const oscillator = audioContext.createOscillator();
+			const gain = audioContext.createGain();
+			oscillator.type = "sine";
+			oscillator.frequency.value = frequency;
+			gain.gain.setValueAtTime(0.2, start + offset);
+			gain.gain.exponentialRampToValueAtTime(0.001, start + offset + duration);
+			oscillator.connect(gain).connect(audioContext.destination);
+			oscillator.start(start + offset);
+			oscillator.stop(start + offset + duration);
+		}
+	} catch (error) {
+		debugLog("[App] Failed to play alert sound:", sound, error);
+	}
+}
@@ -13,7 +13,10 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Utility to replace GitHub user-attachment images with a helpful message
+import { buildApiUrl } from "$lib/api/fetch";
+
+// Utility to replace GitHub user-attachment images with a helpful message, and to point
+// images the API proxies at the API server
 export function replaceGitHubAttachmentImages(html: string): string {
 	// Only run in browser context
 	if (typeof window === "undefined") {
@@ -28,6 +31,10 @@ export function replaceGitHubAttachmentImages(html: string): string {
 
 	images.forEach((img) => {
 		const src = img.getAttribute("src");
+		if (src?.startsWith("/api/")) {
+			img.setAttribute("src", buildApiUrl(src));
+			return;
+		}
 		if (src && isGitHubUserAttachmentUrl(src)) {
 			// Replace with a badge/message
 			const badge = doc.createElement("span");
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { describe, it, expect } from "vitest";
+import { renderMarkdown, sanitizeHtml } from "./markdown";
+
+// Render a body and parse the result, so assertions look at the DOM the browser would build
+function render(body: string): HTMLElement {
+	const container = document.createElement("div");
+	container.innerHTML = renderMarkdown(body);
+	return container;
+}
+
+function hasEventHandlers(root: HTMLElement): boolean {
+	return Array.from(root.querySelectorAll("*")).some((el) =>
+		Array.from(el.attributes).some((attr) => attr.name.toLowerCase().startsWith("on"))
+	);
+}
+
+describe("renderMarkdown", () => {
+	it("keeps ordinary GitHub markdown", () => {
+		const root = render("**bold** [docs](https://example.com)\n\n```go\nfmt.Println(1)\n```");
+		expect(root.querySelector("strong")?.textContent).toBe("bold");
+		expect(root.querySelector("a")?.getAttribute("href")).toBe("https://example.com");
+		expect(root.querySelector("pre code.hljs")).not.toBeNull();
+	});
+
+	it.each([
+		["script tag", "<script>alert(1)</script>"],
+		["img onerror", '<img src="x" onerror="alert(1)">'],
+		["svg onload", '<svg onload="alert(1)"><circle r="1"></circle></svg>'],
+		["details ontoggle", '<details open ontoggle="alert(1)"><summary>x</summary></details>'],
+		["body onload", '<body onload="alert(1)">'],
+		[
+			"noscript mutation",
+			'<noscript><p title="</noscript><img src=x onerror=alert(1)>"></noscript>',
+		],
+		["math mutation", "<math><mtext><table><mglyph><style><img src=x onerror=alert(1)>"],
+	])("strips scripts and event handlers: %s", (_, body) => {
+		const root = render(body);
+		expect(root.querySelector("script")).toBeNull();
+		expect(hasEventHandlers(root)).toBe(false);
+	});
+
+	it.each([
+		["markdown link", "[click](javascript:alert(1))"],
+		["html link", '<a href="javascript:alert(1)">click</a>'],
+		["mixed case", '<a href="JaVaScRiPt:alert(1)">click</a>'],
+		["entity encoded", '<a href="&#106;avascript:alert(1)">click</a>'],
+		["vbscript", '<a href="vbscript:msgbox(1)">click</a>'],
+		["data html", '<a href="data:text/html,<script>alert(1)</script>">click</a>'],
+	])("drops script URLs: %s", (_, body) => {
+		const root = render(body);
+		for (const link of Array.from(root.querySelectorAll("a"))) {
+			const href = (link.getAttribute("href") ?? "").toLowerCase();
+			expect(href).not.toMatch(/^(javascript|vbscript|data):/);
+		}
+	});
+
+	it.each([
+		["iframe", '<iframe src="https://example.com"></iframe>', "iframe"],
+		["object", '<object data="https://example.com/x.swf"></object>', "object"],
+		["embed", '<embed src="https://example.com/x.swf">', "embed"],
+		["form", '<form action="https://example.com"><button>Log in</button></form>', "form"],
+		["input", '<input type="password" name="pw">', "input"],
+		["style", "<style>body { display: none }</style>", "style"],
+		["base", '<base href="https://evil.example.com/">', "base"],
+		["meta refresh", '<meta http-equiv="refresh" content="0;url=https://example.com">', "meta"],
+	])("removes embedding and form elements: %s", (_, body, selector) => {
+		expect(render(body).querySelector(selector)).toBeNull();
+	});
+
+	it("removes inline styles used to overlay the page", () => {
+		const root = render('<div style="position:fixed;inset:0">Session expired</div>');
+		expect(root.textContent).toContain("Session expired");
+		expect(root.querySelector("[style]")).toBeNull();
+	});
+
+	it("drops elements outside the allowlist but keeps their text", () => {
+		const root = render("<custom-widget>Approve</custom-widget> <video>clip</video>");
+		expect(root.querySelector("custom-widget")).toBeNull();
+		expect(root.querySelector("video")).toBeNull();
+		expect(root.textContent).toContain("Approve");
+	});
+
+	it("keeps images served by the API's image proxy", () => {
+		const src = "/api/integrations/images?url=https%3A%2F%2Fraw.githubusercontent.com%2Fa.png";
+		const root = render(`![logo](${src})`);
+		expect(root.querySelector("img")?.getAttribute("src")).toBe(src);
+	});
+
+	it("escapes HTML inside code spans", () => {
+		const root = render("`<img src=x onerror=alert(1)>`");
+		expect(root.querySelector("img")).toBeNull();
+		expect(root.querySelector("code")?.textContent).toBe("<img src=x onerror=alert(1)>");
+	});
+});
+
+describe("sanitizeHtml", () => {
+	it("keeps highlight.js markup", () => {
+		const html = '<pre><code class="hljs"><span class="hljs-keyword">func</span></code></pre>';
+		expect(sanitizeHtml(html)).toBe(html);
+	});
+});
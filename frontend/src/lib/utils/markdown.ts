@@ -18,7 +18,7 @@
  */
 
 import { marked } from "marked";
-import DOMPurify from "dompurify";
+import DOMPurify, { type Config } from "dompurify";
 import hljs from "highlight.js";
 import "./markdown-styles.css"; // Custom styles with light/dark theme support
 import { replaceGitHubAttachmentImages } from "./imageProxy";
@@ -29,6 +29,97 @@ marked.setOptions({
 	breaks: true,
 });
 
+// Comment and description bodies are written by anyone who can comment on a repository. The
+// API has already cut their HTML down to an allowlist and proxied their images; this is the
+// same allowlist again, plus the markup our renderer adds (highlight.js spans and classes).
+const SANITIZE_CONFIG: Config = {
+	ALLOWED_TAGS: [
+		"a",
+		"abbr",
+		"b",
+		"bdo",
+		"blockquote",
+		"br",
+		"caption",
+		"cite",
+		"code",
+		"dd",
+		"del",
+		"details",
+		"dfn",
+		"div",
+		"dl",
+		"dt",
+		"em",
+		"figcaption",
+		"figure",
+		"h1",
+		"h2",
+		"h3",
+		"h4",
+		"h5",
+		"h6",
+		"hr",
+		"i",
+		"img",
+		"ins",
+		"kbd",
+		"li",
+		"mark",
+		"ol",
+		"p",
+		"pre",
+		"q",
+		"rp",
+		"rt",
+		"ruby",
+		"s",
+		"samp",
+		"small",
+		"span",
+		"strike",
+		"strong",
+		"sub",
+		"summary",
+		"sup",
+		"table",
+		"tbody",
+		"td",
+		"tfoot",
+		"th",
+		"thead",
+		"time",
+		"tr",
+		"tt",
+		"ul",
+		"var",
+		"wbr",
+	],
+	ALLOWED_ATTR: [
+		"align",
+		"alt",
+		"class",
+		"colspan",
+		"height",
+		"href",
+		"open",
+		"rowspan",
+		"src",
+		"start",
+		"title",
+		"width",
+	],
+	ALLOW_DATA_ATTR: false,
+	ALLOW_UNKNOWN_PROTOCOLS: false,
+};
+
+/**
+ * Sanitize untrusted HTML (rendered GitHub content) with the markdown allowlist
+ */
+export function sanitizeHtml(html: string): string {
+	return DOMPurify.sanitize(html, SANITIZE_CONFIG);
+}
+
 /**
  * Render markdown content to HTML with syntax highlighting for code blocks.
  * Also handles image proxy replacement and sanitization.
@@ -91,15 +182,12 @@ export function renderMarkdown(content: string): string {
 		// Render markdown to HTML
 		const rawHtml = marked(content, { renderer }) as string;
 
-		// Replace GitHub attachment images
-		const withImageProxies = replaceGitHubAttachmentImages(rawHtml);
-
-		// Sanitize HTML - use DOMPurify defaults (works fine for markdown + highlight.js)
-		const sanitized = DOMPurify.sanitize(withImageProxies);
-
-		return sanitized;
+		// Sanitize HTML - highlight.js output only uses spans with classes, so the allowlist keeps it.
+		// Attachment images are swapped for our own badge markup afterwards, since its icon is an
+		// SVG the allowlist would strip.
+		return replaceGitHubAttachmentImages(sanitizeHtml(rawHtml));
 	} catch (error) {
 		// Fallback to sanitized plain HTML if markdown parsing fails
-		return DOMPurify.sanitize(content);
+		return sanitizeHtml(content);
 	}
 }
@@ -213,6 +213,8 @@ export function formatSubjectTypeLabel(type: string): string {
 		case "repositoryvulnerabilityalert":
 		case "securityalert":
 			return "Security Alert";
+		case "system":
+			return "Octobud";
 		case "checkrun":
 		case "checksuite":
 		case "workflowrun":
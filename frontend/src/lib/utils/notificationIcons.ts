@@ -222,6 +222,15 @@ export function getNotificationIcon(
 		};
 	}
 
+	// System notification from Octobud itself
+	if (normalizedType === "system") {
+		return {
+			path: getIconPath("info"),
+			colorClass: "text-gray-500 dark:text-gray-400",
+			label: "Octobud",
+		};
+	}
+
 	// Default fallback
 	return {
 		path: getIconPath("issue-opened"),
@@ -92,6 +92,14 @@ export function getNotificationTypeConfig(subjectType: string): NotificationType
 				timestampVerb: "reported",
 			};
 
+		case "system":
+			return {
+				showCommentThread: false,
+				contentLabel: "Octobud",
+				emptyContentMessage: "No details available.",
+				timestampVerb: "reported",
+			};
+
 		default:
 			// Fallback for unknown types
 			return {
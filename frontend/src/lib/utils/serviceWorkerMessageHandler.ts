@@ -17,6 +17,7 @@ import { browser } from "$app/environment";
 import { goto } from "$app/navigation";
 import { resolve } from "$app/paths";
 import { debugLog } from "$lib/utils/debug";
+import { playAlertSound } from "$lib/utils/alertSounds";
 import { getSWHealthStore } from "$lib/stores/swHealthStore";
 import type { NotificationPageController } from "$lib/state/types";
 
@@ -30,7 +31,7 @@ interface ServiceWorkerHandlerParams {
  * to ensure messages aren't missed.
  *
  * Handles:
- * - Service worker messages (NEW_NOTIFICATIONS, OPEN_NOTIFICATION, TOKEN_EXPIRED)
+ * - Service worker messages (NEW_NOTIFICATIONS, OPEN_NOTIFICATION, PLAY_ALERT_SOUND, TOKEN_EXPIRED)
  * - Page visibility changes (sync notifications when page becomes visible)
  *
  * @param params - Function to get pageController when available
@@ -61,6 +62,9 @@ export function setupServiceWorkerHandlers(params: ServiceWorkerHandlerParams):
 			if (pageController) {
 				void pageController.actions.handleSyncNewNotifications();
 			}
+		} else if (event.data?.type === "PLAY_ALERT_SOUND") {
+			// The service worker can't play audio, so named alert sounds are played here
+			playAlertSound(String(event.data.sound || ""));
 		} else if (event.data?.type === "OPEN_NOTIFICATION") {
 			// Service worker wants to open a specific notification
 			// Navigate to the URL - the page's reactive statement will handle opening the detail
@@ -17,10 +17,11 @@
  * Handles background sync and desktop notifications
  */
 
-const SW_VERSION = '1.0.4';
+const SW_VERSION = '1.0.5';
 const CACHE_NAME = `octobud-sw-${SW_VERSION}`;
 const NOTIFICATIONS_URL = '/api/notifications';
 const POLL_NOTIFICATIONS_URL = '/api/notifications/poll'; // Poll endpoint for service worker
+const ALERTS_URL = '/api/alerts'; // Alerts recorded by the server as notifications sync
 const POLL_INTERVAL = 10000; // 10 seconds
 const DB_NAME = 'OctobudDB';
 const DB_VERSION = 5; // Stores: pollState, queryConfig
@@ -46,6 +47,7 @@ let isPolling = false;
 let isStartingPolling = false; // Prevent race condition during async startup
 let notificationPermission = 'default'; // Track permission status
 let notificationsEnabled = true; // Default to enabled
+let lastAlertId = null; // Cursor into the alert feed (null until the first fetch)
 
 // IndexedDB helpers
 function openDB() {
@@ -296,6 +298,67 @@ async function fetchNotifications() {
     }
 }
 
+// Fetch alerts recorded since the last poll, keyed by githubId. The server decides each
+// notification's alert level and sound from rules, views, quiet hours and DND.
+async function fetchAlerts() {
+    const alerts = new Map();
+    try {
+        const url = lastAlertId === null ? ALERTS_URL : `${ALERTS_URL}?after=${lastAlertId}`;
+        const response = await fetch(url, {
+            credentials: 'include',
+            cache: 'no-cache',
+            headers: {
+                'Cache-Control': 'no-cache'
+            }
+        });
+        if (!response.ok) {
+            throw new Error(`Alerts fetch failed: ${response.status}`);
+        }
+
+        const data = await response.json();
+        for (const alert of data.alerts || []) {
+            alerts.set(alert.githubId, alert);
+            if (lastAlertId === null || alert.id > lastAlertId) {
+                lastAlertId = alert.id;
+            }
+        }
+        if (lastAlertId === null) {
+            lastAlertId = 0;
+        }
+        debugLog('[SW] Received', alerts.size, 'alerts, cursor now', lastAlertId);
+    } catch (error) {
+        // Without alerts every new notification falls back to a desktop notification
+        console.error('[SW] Failed to fetch alerts:', error);
+    }
+    return alerts;
+}
+
+// Whether a notification should get a desktop notification. Notifications without a
+// recorded alert (e.g. synced before alerts existed) keep the old behavior.
+function alertsDesktop(alert) {
+    return !alert || alert.level === 'desktop';
+}
+
+// Ask one open window to play a named alert sound, since service workers can't play audio.
+// Returns true if a window will play it.
+async function playAlertSound(sound) {
+    try {
+        const clients = await self.clients.matchAll({
+            includeUncontrolled: true,
+            type: 'window'
+        });
+        if (clients.length === 0) {
+            return false;
+        }
+        const client = clients.find(c => c.focused) || clients[0];
+        client.postMessage({ type: 'PLAY_ALERT_SOUND', sound });
+        return true;
+    } catch (error) {
+        console.error('[SW] Failed to request alert sound:', error);
+        return false;
+    }
+}
+
 // Check if all windows are hidden
 async function areAllWindowsHidden() {
     try {
@@ -499,7 +562,7 @@ async function showSummaryNotification(count) {
 
 // Show desktop notification
 // Returns true if notification was successfully shown, false otherwise
-async function showDesktopNotification(notification) {
+async function showDesktopNotification(notification, alert) {
     // Check if we can show notifications
     if (!(await hasNotificationPermission())) {
         console.warn('[SW] Cannot show notification:', {
@@ -553,6 +616,15 @@ async function showDesktopNotification(notification) {
     // Format: "subject title" + "subjectType · reason" (using middle dot separator)
     const body = `${truncatedTitle}\n${subjectType} · ${reason}`;
 
+    // "silent" alerts show without a sound; named sounds are played by an open window
+    // instead of the system sound when one is available
+    let silent = false;
+    if (alert?.sound === 'silent') {
+        silent = true;
+    } else if (alert?.sound) {
+        silent = await playAlertSound(alert.sound);
+    }
+
     // Build options - keep it simple to avoid browser compatibility issues
     const options = {
         body: body,
@@ -560,7 +632,7 @@ async function showDesktopNotification(notification) {
         tag: id, // Prevent duplicate notifications
         renotify: true, // Re-alert even for notifications with same tag
         // requireInteraction: true, // Set to true to encourage macOS to show as alert instead of just notification center
-        silent: false, // Ensure sound plays
+        silent: silent,
         data: {
             notificationId: id,
             githubId: (notification.githubId && notification.githubId.trim() !== '')
@@ -690,22 +762,28 @@ async function pollForUpdates() {
                 debugLog('[SW] Should show desktop notifications:', shouldShow);
                 debugLog('[SW] Notifications enabled:', notificationsEnabled);
 
+                // Alerts decide which notifications get a desktop notification; the rest
+                // only update the badge
+                const alerts = await fetchAlerts();
+                const desktopNotifications = newNotifications.filter(n => alertsDesktop(alerts.get(n.githubId)));
+                debugLog('[SW]', desktopNotifications.length, 'of', newNotifications.length, 'new notifications alert on the desktop');
+
                 // Show desktop notifications if enabled in settings AND should show
-                if (notificationsEnabled && shouldShow) {
-                    if (newNotifications.length > 3) {
+                if (notificationsEnabled && shouldShow && desktopNotifications.length > 0) {
+                    if (desktopNotifications.length > 3) {
                         // Show summary notification if more than 3
-                        debugLog('[SW] Showing summary notification for', newNotifications.length, 'notifications');
-                        await showSummaryNotification(newNotifications.length);
+                        debugLog('[SW] Showing summary notification for', desktopNotifications.length, 'notifications');
+                        await showSummaryNotification(desktopNotifications.length);
                     } else {
                         // Show individual notifications (up to 3)
-                        debugLog('[SW] Showing', newNotifications.length, 'individual notifications');
-                        for (const notification of newNotifications) {
+                        debugLog('[SW] Showing', desktopNotifications.length, 'individual notifications');
+                        for (const notification of desktopNotifications) {
                             try {
                                 const id = (notification.githubId && notification.githubId.trim() !== '')
                                     ? String(notification.githubId)
                                     : String(notification.id);
                                 debugLog('[SW] Showing desktop notification for', id);
-                                await showDesktopNotification(notification);
+                                await showDesktopNotification(notification, alerts.get(notification.githubId));
                                 debugLog('[SW] Successfully showed notification for', id);
                                 // Small delay between notifications to avoid overwhelming the user
                                 await new Promise(resolve => setTimeout(resolve, 100));
@@ -721,8 +799,8 @@ async function pollForUpdates() {
                         }
                     }
                 } else {
-                    // Notifications disabled or shouldn't show (user is on inbox) - don't show but still update state
-                    debugLog('[SW] Not showing notifications - disabled or user viewing inbox.');
+                    // Notifications disabled, shouldn't show (user is on inbox) or only badge alerts - don't show but still update state
+                    debugLog('[SW] Not showing notifications - disabled, user viewing inbox, or badge-only alerts.');
                 }
 
                 // Notify clients regardless of notification setting or window visibility